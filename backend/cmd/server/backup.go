@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/repository"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+// backupPassphraseEnv 口令环境变量，避免口令出现在进程列表/shell 历史中
+const backupPassphraseEnv = "SUB2API_BACKUP_PASSPHRASE"
+
+// runBackupCommand 处理 `sub2api backup` / `sub2api restore` 子命令，返回 false 表示不是子命令
+func runBackupCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	var err error
+	switch args[0] {
+	case "backup":
+		err = runBackup(args[1:])
+	case "restore":
+		err = runRestore(args[1:])
+	default:
+		return false
+	}
+	if err != nil {
+		log.Fatalf("%s failed: %v", args[0], err)
+	}
+	return true
+}
+
+func runBackup(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	output := fs.String("o", "", "Output archive path (default: stdout)")
+	includeUsageLogs := fs.Bool("include-usage-logs", false, "Include usage logs in the archive")
+	passphrase := fs.String("passphrase", "", "Passphrase used to encrypt secrets (or set "+backupPassphraseEnv+")")
+	_ = fs.Parse(args)
+
+	svc, cleanup, err := newCLIBackupService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.OpenFile(*output, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+
+	summary, err := svc.Export(context.Background(), w, service.BackupExportOptions{
+		Passphrase:       backupPassphrase(*passphrase),
+		IncludeUsageLogs: *includeUsageLogs,
+	})
+	if err != nil {
+		if *output != "" {
+			_ = os.Remove(*output)
+		}
+		return err
+	}
+	printBackupSummary("Backup", summary)
+	return nil
+}
+
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	input := fs.String("i", "", "Archive path to restore")
+	passphrase := fs.String("passphrase", "", "Passphrase used when the archive was created (or set "+backupPassphraseEnv+")")
+	_ = fs.Parse(args)
+	if *input == "" {
+		return errors.New("-i is required")
+	}
+
+	f, err := os.Open(*input)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	svc, cleanup, err := newCLIBackupService()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	summary, err := svc.Restore(context.Background(), f, backupPassphrase(*passphrase))
+	if err != nil {
+		return err
+	}
+	printBackupSummary("Restore", summary)
+	return nil
+}
+
+func backupPassphrase(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv(backupPassphraseEnv)
+}
+
+// newCLIBackupService 直接连接数据库构建备份服务，不启动 Redis 及后台任务
+func newCLIBackupService() (*service.BackupService, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load config: %w", err)
+	}
+	client, sqlDB, err := repository.InitEnt(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("init database: %w", err)
+	}
+	cleanup := func() { _ = client.Close() }
+
+	secretCipher, err := repository.NewEnvelopeCipher(cfg)
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("init credential encryption: %w", err)
+	}
+	totpEncryptor, err := repository.NewAESEncryptor(cfg)
+	if err != nil {
+		log.Printf("TOTP encryption unavailable (%v); two-factor secrets will be skipped", err)
+		totpEncryptor = nil
+	}
+
+	svc := service.NewBackupService(repository.NewBackupRepository(sqlDB), secretCipher, totpEncryptor, cfg, service.BuildInfo{
+		Version:   Version,
+		BuildType: BuildType,
+	})
+	return svc, cleanup, nil
+}
+
+func printBackupSummary(action string, summary *service.BackupSummary) {
+	tables := make([]string, 0, len(summary.Counts))
+	for table := range summary.Counts {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	log.Printf("%s completed (schema %s)", action, summary.SchemaVersion)
+	for _, table := range tables {
+		log.Printf("  %-22s %d", table, summary.Counts[table])
+	}
+	for _, w := range summary.Warnings {
+		log.Printf("warning: %s", w)
+	}
+}
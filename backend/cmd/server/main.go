@@ -64,6 +64,11 @@ func main() {
 	// Initialize slog logger based on gin mode
 	initLogger()
 
+	// 子命令：sub2api backup / sub2api restore
+	if runBackupCommand(os.Args[1:]) {
+		return
+	}
+
 	// Parse command line flags
 	setupMode := flag.Bool("setup", false, "Run setup wizard in CLI mode")
 	showVersion := flag.Bool("version", false, "Show version information")
@@ -156,10 +161,17 @@ func runMainServer() {
 
 	log.Printf("Server started on %s", app.Server.Addr)
 
-	// 等待中断信号
+	// 等待中断信号；SIGHUP 触发配置热重载
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range quit {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		if _, err := app.ConfigReloader.Reload(config.ReloadSourceSignal); err != nil {
+			log.Printf("Config reload failed: %v", err)
+		}
+	}
 
 	log.Println("Shutting down server...")
 
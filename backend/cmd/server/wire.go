@@ -22,8 +22,9 @@ import (
 )
 
 type Application struct {
-	Server  *http.Server
-	Cleanup func()
+	Server         *http.Server
+	ConfigReloader *config.Reloader
+	Cleanup        func()
 }
 
 func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
@@ -47,7 +48,7 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 		provideCleanup,
 
 		// Application struct
-		wire.Struct(new(Application), "Server", "Cleanup"),
+		wire.Struct(new(Application), "Server", "ConfigReloader", "Cleanup"),
 	)
 	return nil, nil
 }
@@ -61,7 +62,7 @@ func provideServiceBuildInfo(buildInfo handler.BuildInfo) service.BuildInfo {
 
 func provideCleanup(
 	entClient *ent.Client,
-	rdb *redis.Client,
+	rdb redis.UniversalClient,
 	opsMetricsCollector *service.OpsMetricsCollector,
 	opsAggregation *service.OpsAggregationService,
 	opsAlertEvaluator *service.OpsAlertEvaluatorService,
@@ -71,6 +72,10 @@ func provideCleanup(
 	tokenRefresh *service.TokenRefreshService,
 	accountExpiry *service.AccountExpiryService,
 	subscriptionExpiry *service.SubscriptionExpiryService,
+	inviteCommission *service.InviteCommissionService,
+	apiKeyHashMigration *service.APIKeyHashMigrationService,
+	secretReencrypt *service.SecretReencryptService,
+	ipProtection *service.IPProtectionService,
 	usageCleanup *service.UsageCleanupService,
 	pricing *service.PricingService,
 	emailQueue *service.EmailQueueService,
@@ -143,6 +148,22 @@ func provideCleanup(
 				subscriptionExpiry.Stop()
 				return nil
 			}},
+			{"InviteCommissionService", func() error {
+				inviteCommission.Stop()
+				return nil
+			}},
+			{"APIKeyHashMigrationService", func() error {
+				apiKeyHashMigration.Stop()
+				return nil
+			}},
+			{"SecretReencryptService", func() error {
+				secretReencrypt.Stop()
+				return nil
+			}},
+			{"IPProtectionService", func() error {
+				ipProtection.Stop()
+				return nil
+			}},
 			{"PricingService", func() error {
 				pricing.Stop()
 				return nil
@@ -43,54 +43,71 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 		return nil, err
 	}
 	userRepository := repository.NewUserRepository(client, db)
-	settingRepository := repository.NewSettingRepository(client)
+	secretCipher, err := repository.NewEnvelopeCipher(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	settingRepository := repository.NewSettingRepository(client, secretCipher)
 	settingService := service.NewSettingService(settingRepository, configConfig)
-	redisClient := repository.ProvideRedis(configConfig)
-	emailCache := repository.NewEmailCache(redisClient)
+	universalClient := repository.ProvideRedis(configConfig)
+	emailCache := repository.NewEmailCache(universalClient)
 	emailService := service.NewEmailService(settingRepository, emailCache)
 	turnstileVerifier := repository.NewTurnstileVerifier()
 	turnstileService := service.NewTurnstileService(settingService, turnstileVerifier)
 	emailQueueService := service.ProvideEmailQueueService(emailService)
 	promoCodeRepository := repository.NewPromoCodeRepository(client)
-	billingCache := repository.NewBillingCache(redisClient)
+	billingCache := repository.NewBillingCache(universalClient)
 	userSubscriptionRepository := repository.NewUserSubscriptionRepository(client)
-	billingCacheService := service.NewBillingCacheService(billingCache, userRepository, userSubscriptionRepository, configConfig)
+	organizationRepository := repository.NewOrganizationRepository(client, db)
+	billingCacheService := service.NewBillingCacheService(billingCache, userRepository, userSubscriptionRepository, organizationRepository, configConfig)
 	apiKeyRepository := repository.NewAPIKeyRepository(client)
 	groupRepository := repository.NewGroupRepository(client, db)
-	apiKeyCache := repository.NewAPIKeyCache(redisClient)
-	apiKeyService := service.NewAPIKeyService(apiKeyRepository, userRepository, groupRepository, userSubscriptionRepository, apiKeyCache, configConfig)
+	apiKeyCache := repository.NewAPIKeyCache(universalClient)
+	apiKeyService := service.ProvideAPIKeyService(apiKeyRepository, userRepository, groupRepository, userSubscriptionRepository, organizationRepository, apiKeyCache, configConfig)
 	apiKeyAuthCacheInvalidator := service.ProvideAPIKeyAuthCacheInvalidator(apiKeyService)
 	promoService := service.NewPromoService(promoCodeRepository, userRepository, billingCacheService, client, apiKeyAuthCacheInvalidator)
 	inviteRepository := repository.NewInviteRepository(client)
 	inviteLogRepository := repository.NewInviteLogRepository(client)
+	inviteCommissionRepository := repository.NewInviteCommissionRepository(client)
 	redeemCodeRepository := repository.NewRedeemCodeRepository(client)
-	inviteService := service.NewInviteService(client, inviteRepository, inviteLogRepository, userRepository, redeemCodeRepository, settingService, billingCacheService, apiKeyAuthCacheInvalidator)
+	inviteService := service.NewInviteService(client, inviteRepository, inviteLogRepository, inviteCommissionRepository, userRepository, redeemCodeRepository, settingService, billingCacheService, apiKeyAuthCacheInvalidator)
 	authService := service.NewAuthService(userRepository, configConfig, settingService, emailService, turnstileService, emailQueueService, promoService, inviteService)
 	userService := service.NewUserService(userRepository, apiKeyAuthCacheInvalidator)
 	secretEncryptor, err := repository.NewAESEncryptor(configConfig)
 	if err != nil {
 		return nil, err
 	}
-	totpCache := repository.NewTotpCache(redisClient)
+	totpCache := repository.NewTotpCache(universalClient)
 	totpService := service.NewTotpService(userRepository, secretEncryptor, totpCache, settingService, emailService, emailQueueService)
-	authHandler := handler.NewAuthHandler(configConfig, authService, userService, settingService, promoService, totpService)
-	userHandler := handler.NewUserHandler(userService)
+	authSessionCache := repository.NewAuthSessionCache(universalClient)
+	authSessionService := service.ProvideAuthSessionService(authSessionCache, userRepository, authService, userService, configConfig)
+	webAuthnCredentialRepository := repository.NewWebAuthnCredentialRepository(client)
+	webAuthnChallengeCache := repository.NewWebAuthnChallengeCache(universalClient)
+	webAuthnService := service.NewWebAuthnService(webAuthnCredentialRepository, webAuthnChallengeCache, userRepository, settingService, totpService, configConfig)
+	recoveryCodeRepository := repository.NewRecoveryCodeRepository(client)
+	twoFactorService := service.NewTwoFactorService(recoveryCodeRepository, webAuthnCredentialRepository, userRepository, settingService, totpService)
+	authHandler := handler.NewAuthHandler(configConfig, authService, userService, settingService, promoService, totpService, authSessionService, webAuthnService, twoFactorService)
+	userHandler := handler.NewUserHandler(userService, authSessionService)
 	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
 	usageLogRepository := repository.NewUsageLogRepository(client, db)
 	usageService := service.NewUsageService(usageLogRepository, userRepository, client, apiKeyAuthCacheInvalidator)
 	usageHandler := handler.NewUsageHandler(usageService, apiKeyService)
 	subscriptionService := service.NewSubscriptionService(groupRepository, userSubscriptionRepository, billingCacheService)
-	redeemCache := repository.NewRedeemCache(redisClient)
+	redeemCache := repository.NewRedeemCache(universalClient)
 	redeemService := service.NewRedeemService(redeemCodeRepository, userRepository, subscriptionService, redeemCache, billingCacheService, client, apiKeyAuthCacheInvalidator)
 	redeemHandler := handler.NewRedeemHandler(redeemService)
-	subscriptionReminderService := service.NewSubscriptionReminderService(userRepository, userSubscriptionRepository, redisClient, emailQueueService)
-	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionService, subscriptionReminderService)
-	inviteHandler := handler.NewInviteHandler(inviteService)
+	subscriptionReminderService := service.NewSubscriptionReminderService(userRepository, userSubscriptionRepository, universalClient, emailQueueService)
+	subscriptionLifecycleService := service.NewSubscriptionLifecycleService(client, userRepository, groupRepository, userSubscriptionRepository, subscriptionService, billingCacheService, emailQueueService, apiKeyAuthCacheInvalidator)
+	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionService, subscriptionReminderService, subscriptionLifecycleService)
+	inviteCommissionService := service.ProvideInviteCommissionService(client, inviteCommissionRepository, userRepository, redeemCodeRepository, settingService, billingCacheService, apiKeyAuthCacheInvalidator)
+	inviteHandler := handler.NewInviteHandler(inviteService, inviteCommissionService)
 	planRepository := repository.NewPlanRepository(client)
 	planService := service.NewPlanService(planRepository)
 	planHandler := handler.NewPlanHandler(planService)
+	organizationService := service.NewOrganizationService(organizationRepository, userRepository, client, billingCacheService, apiKeyAuthCacheInvalidator, emailQueueService, settingService, usageService)
+	organizationHandler := handler.NewOrganizationHandler(organizationService)
 	dashboardAggregationRepository := repository.NewDashboardAggregationRepository(db)
-	dashboardStatsCache := repository.NewDashboardCache(redisClient, configConfig)
+	dashboardStatsCache := repository.NewDashboardCache(universalClient, configConfig)
 	dashboardService := service.NewDashboardService(usageLogRepository, dashboardAggregationRepository, dashboardStatsCache, configConfig)
 	timingWheelService, err := service.ProvideTimingWheelService()
 	if err != nil {
@@ -98,44 +115,60 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	}
 	dashboardAggregationService := service.ProvideDashboardAggregationService(dashboardAggregationRepository, timingWheelService, configConfig)
 	dashboardHandler := admin.NewDashboardHandler(dashboardService, dashboardAggregationService)
-	schedulerCache := repository.NewSchedulerCache(redisClient)
-	accountRepository := repository.NewAccountRepository(client, db, schedulerCache)
-	proxyRepository := repository.NewProxyRepository(client, db)
+	schedulerCache := repository.NewSchedulerCache(universalClient, secretCipher)
+	accountRepository := repository.NewAccountRepository(client, db, schedulerCache, secretCipher)
+	proxyRepository := repository.NewProxyRepository(client, db, secretCipher)
 	proxyExitInfoProber := repository.NewProxyExitInfoProber(configConfig)
-	proxyLatencyCache := repository.NewProxyLatencyCache(redisClient)
+	proxyLatencyCache := repository.NewProxyLatencyCache(universalClient)
 	adminService := service.NewAdminService(userRepository, groupRepository, accountRepository, proxyRepository, apiKeyRepository, redeemCodeRepository, inviteService, billingCacheService, proxyExitInfoProber, proxyLatencyCache, apiKeyAuthCacheInvalidator)
-	adminUserHandler := admin.NewUserHandler(adminService)
-	groupHandler := admin.NewGroupHandler(adminService)
+	adminUserHandler := admin.NewUserHandler(adminService, authSessionService)
+	gatewayCache := repository.NewGatewayCache(universalClient)
+	schedulerOutboxRepository := repository.NewSchedulerOutboxRepository(db)
+	schedulerSnapshotService := service.ProvideSchedulerSnapshotService(schedulerCache, schedulerOutboxRepository, accountRepository, groupRepository, configConfig)
+	concurrencyCache := repository.ProvideConcurrencyCache(universalClient, configConfig)
+	concurrencyService := service.ProvideConcurrencyService(concurrencyCache, accountRepository, configConfig)
+	pricingRemoteClient := repository.ProvidePricingRemoteClient(configConfig)
+	pricingService, err := service.ProvidePricingService(configConfig, pricingRemoteClient)
+	if err != nil {
+		return nil, err
+	}
+	billingService := service.NewBillingService(configConfig, pricingService)
+	geminiQuotaService := service.NewGeminiQuotaService(configConfig, settingRepository)
+	tempUnschedCache := repository.NewTempUnschedCache(universalClient)
+	timeoutCounterCache := repository.NewTimeoutCounterCache(universalClient)
+	geminiTokenCache := repository.NewGeminiTokenCache(universalClient)
+	compositeTokenCacheInvalidator := service.NewCompositeTokenCacheInvalidator(geminiTokenCache)
+	rateLimitService := service.ProvideRateLimitService(accountRepository, usageLogRepository, configConfig, geminiQuotaService, tempUnschedCache, timeoutCounterCache, settingService, compositeTokenCacheInvalidator)
+	identityCache := repository.NewIdentityCache(universalClient)
+	identityService := service.NewIdentityService(identityCache)
+	reloader := config.NewReloader(configConfig)
+	httpUpstream := repository.ProvideHTTPUpstream(configConfig, reloader)
+	deferredService := service.ProvideDeferredService(accountRepository, timingWheelService)
 	claudeOAuthClient := repository.NewClaudeOAuthClient()
 	oAuthService := service.NewOAuthService(proxyRepository, claudeOAuthClient)
+	claudeTokenProvider := service.NewClaudeTokenProvider(accountRepository, geminiTokenCache, oAuthService)
+	sessionLimitCache := repository.ProvideSessionLimitCache(universalClient, configConfig)
+	accountDedicationRepository := repository.NewAccountDedicationRepository(client)
+	accountDedicationService := service.NewAccountDedicationService(accountDedicationRepository, accountRepository, apiKeyRepository, usageLogRepository, concurrencyService, apiKeyAuthCacheInvalidator)
+	gatewayService := service.NewGatewayService(accountRepository, groupRepository, usageLogRepository, userRepository, userSubscriptionRepository, gatewayCache, configConfig, schedulerSnapshotService, concurrencyService, billingService, rateLimitService, billingCacheService, identityService, httpUpstream, deferredService, claudeTokenProvider, sessionLimitCache, accountDedicationService)
+	groupHandler := admin.NewGroupHandler(adminService, gatewayService)
 	openAIOAuthClient := repository.NewOpenAIOAuthClient()
 	openAIOAuthService := service.NewOpenAIOAuthService(proxyRepository, openAIOAuthClient)
 	geminiOAuthClient := repository.NewGeminiOAuthClient(configConfig)
 	geminiCliCodeAssistClient := repository.NewGeminiCliCodeAssistClient()
 	geminiOAuthService := service.NewGeminiOAuthService(proxyRepository, geminiOAuthClient, geminiCliCodeAssistClient, configConfig)
 	antigravityOAuthService := service.NewAntigravityOAuthService(proxyRepository)
-	geminiQuotaService := service.NewGeminiQuotaService(configConfig, settingRepository)
-	tempUnschedCache := repository.NewTempUnschedCache(redisClient)
-	timeoutCounterCache := repository.NewTimeoutCounterCache(redisClient)
-	geminiTokenCache := repository.NewGeminiTokenCache(redisClient)
-	compositeTokenCacheInvalidator := service.NewCompositeTokenCacheInvalidator(geminiTokenCache)
-	rateLimitService := service.ProvideRateLimitService(accountRepository, usageLogRepository, configConfig, geminiQuotaService, tempUnschedCache, timeoutCounterCache, settingService, compositeTokenCacheInvalidator)
-	httpUpstream := repository.NewHTTPUpstream(configConfig)
 	claudeUsageFetcher := repository.NewClaudeUsageFetcher(httpUpstream)
 	antigravityQuotaFetcher := service.NewAntigravityQuotaFetcher(proxyRepository)
 	usageCache := service.NewUsageCache()
-	identityCache := repository.NewIdentityCache(redisClient)
 	accountUsageService := service.NewAccountUsageService(accountRepository, usageLogRepository, claudeUsageFetcher, geminiQuotaService, antigravityQuotaFetcher, usageCache, identityCache)
 	geminiTokenProvider := service.NewGeminiTokenProvider(accountRepository, geminiTokenCache, geminiOAuthService)
-	gatewayCache := repository.NewGatewayCache(redisClient)
 	antigravityTokenProvider := service.NewAntigravityTokenProvider(accountRepository, geminiTokenCache, antigravityOAuthService)
 	antigravityGatewayService := service.NewAntigravityGatewayService(accountRepository, gatewayCache, antigravityTokenProvider, rateLimitService, httpUpstream, settingService)
 	accountTestService := service.NewAccountTestService(accountRepository, geminiTokenProvider, antigravityGatewayService, httpUpstream, configConfig)
-	concurrencyCache := repository.ProvideConcurrencyCache(redisClient, configConfig)
-	concurrencyService := service.ProvideConcurrencyService(concurrencyCache, accountRepository, configConfig)
 	crsSyncService := service.NewCRSSyncService(accountRepository, proxyRepository, oAuthService, openAIOAuthService, geminiOAuthService, configConfig)
-	sessionLimitCache := repository.ProvideSessionLimitCache(redisClient, configConfig)
-	accountHandler := admin.NewAccountHandler(adminService, oAuthService, openAIOAuthService, geminiOAuthService, antigravityOAuthService, rateLimitService, accountUsageService, accountTestService, concurrencyService, crsSyncService, sessionLimitCache, compositeTokenCacheInvalidator)
+	accountTransferService := service.NewAccountTransferService(adminService, accountRepository, proxyRepository)
+	accountHandler := admin.NewAccountHandler(adminService, oAuthService, openAIOAuthService, geminiOAuthService, antigravityOAuthService, rateLimitService, accountUsageService, accountTestService, concurrencyService, crsSyncService, accountTransferService, sessionLimitCache, compositeTokenCacheInvalidator)
 	oAuthHandler := admin.NewOAuthHandler(oAuthService)
 	openAIOAuthHandler := admin.NewOpenAIOAuthHandler(openAIOAuthService, adminService)
 	geminiOAuthHandler := admin.NewGeminiOAuthHandler(geminiOAuthService)
@@ -149,29 +182,23 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	uploadService := service.NewUploadService()
 	uploadHandler := admin.NewUploadHandler(uploadService, adminActionLogService)
 	opsRepository := repository.NewOpsRepository(db)
-	schedulerOutboxRepository := repository.NewSchedulerOutboxRepository(db)
-	schedulerSnapshotService := service.ProvideSchedulerSnapshotService(schedulerCache, schedulerOutboxRepository, accountRepository, groupRepository, configConfig)
-	pricingRemoteClient := repository.ProvidePricingRemoteClient(configConfig)
-	pricingService, err := service.ProvidePricingService(configConfig, pricingRemoteClient)
-	if err != nil {
-		return nil, err
-	}
-	billingService := service.NewBillingService(configConfig, pricingService)
-	identityService := service.NewIdentityService(identityCache)
-	deferredService := service.ProvideDeferredService(accountRepository, timingWheelService)
-	claudeTokenProvider := service.NewClaudeTokenProvider(accountRepository, geminiTokenCache, oAuthService)
-	gatewayService := service.NewGatewayService(accountRepository, groupRepository, usageLogRepository, userRepository, userSubscriptionRepository, gatewayCache, configConfig, schedulerSnapshotService, concurrencyService, billingService, rateLimitService, billingCacheService, identityService, httpUpstream, deferredService, claudeTokenProvider, sessionLimitCache)
 	openAITokenProvider := service.NewOpenAITokenProvider(accountRepository, geminiTokenCache, openAIOAuthService)
-	openAIGatewayService := service.NewOpenAIGatewayService(accountRepository, usageLogRepository, userRepository, userSubscriptionRepository, gatewayCache, configConfig, schedulerSnapshotService, concurrencyService, billingService, rateLimitService, billingCacheService, httpUpstream, deferredService, openAITokenProvider)
+	openAIGatewayService := service.NewOpenAIGatewayService(accountRepository, usageLogRepository, userRepository, userSubscriptionRepository, gatewayCache, configConfig, schedulerSnapshotService, concurrencyService, billingService, rateLimitService, billingCacheService, httpUpstream, deferredService, openAITokenProvider, accountDedicationService)
 	geminiMessagesCompatService := service.NewGeminiMessagesCompatService(accountRepository, groupRepository, gatewayCache, schedulerSnapshotService, geminiTokenProvider, rateLimitService, httpUpstream, antigravityGatewayService, configConfig)
 	opsService := service.NewOpsService(opsRepository, settingRepository, configConfig, accountRepository, concurrencyService, gatewayService, openAIGatewayService, geminiMessagesCompatService, antigravityGatewayService)
 	settingHandler := admin.NewSettingHandler(settingService, emailService, turnstileService, opsService, adminActionLogService)
 	opsHandler := admin.NewOpsHandler(opsService)
-	updateCache := repository.NewUpdateCache(redisClient)
+	updateCache := repository.NewUpdateCache(universalClient)
 	gitHubReleaseClient := repository.ProvideGitHubReleaseClient(configConfig)
 	serviceBuildInfo := provideServiceBuildInfo(buildInfo)
 	updateService := service.ProvideUpdateService(updateCache, gitHubReleaseClient, serviceBuildInfo)
-	systemHandler := handler.ProvideSystemHandler(updateService)
+	systemHandler := handler.ProvideSystemHandler(updateService, reloader)
+	backupRepository := repository.NewBackupRepository(db)
+	backupService := service.NewBackupService(backupRepository, secretCipher, secretEncryptor, configConfig, serviceBuildInfo)
+	backupHandler := admin.NewBackupHandler(backupService, adminActionLogService)
+	ipProtectionCache := repository.NewIPProtectionCache(universalClient)
+	ipProtectionService := service.ProvideIPProtectionService(ipProtectionCache, settingRepository, configConfig)
+	ipProtectionHandler := admin.NewIPProtectionHandler(ipProtectionService, adminActionLogService)
 	adminSubscriptionHandler := admin.NewSubscriptionHandler(subscriptionService)
 	usageCleanupRepository := repository.NewUsageCleanupRepository(client, db)
 	usageCleanupService := service.ProvideUsageCleanupService(usageCleanupRepository, timingWheelService, dashboardAggregationService, configConfig)
@@ -180,30 +207,41 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	userAttributeValueRepository := repository.NewUserAttributeValueRepository(client)
 	userAttributeService := service.NewUserAttributeService(userAttributeDefinitionRepository, userAttributeValueRepository)
 	userAttributeHandler := admin.NewUserAttributeHandler(userAttributeService)
-	adminInviteHandler := admin.NewInviteHandler(inviteService, adminActionLogService)
-	adminHandlers := handler.ProvideAdminHandlers(dashboardHandler, adminUserHandler, groupHandler, accountHandler, oAuthHandler, openAIOAuthHandler, geminiOAuthHandler, antigravityOAuthHandler, proxyHandler, adminRedeemHandler, promoHandler, adminPlanHandler, uploadHandler, settingHandler, opsHandler, systemHandler, adminSubscriptionHandler, adminUsageHandler, userAttributeHandler, adminInviteHandler)
-	gatewayHandler := handler.NewGatewayHandler(gatewayService, geminiMessagesCompatService, antigravityGatewayService, userService, concurrencyService, billingCacheService, configConfig)
-	openAIGatewayHandler := handler.NewOpenAIGatewayHandler(openAIGatewayService, concurrencyService, billingCacheService, configConfig)
+	adminInviteHandler := admin.NewInviteHandler(inviteService, inviteCommissionService, adminActionLogService)
+	dedicatedAccountHandler := admin.NewDedicatedAccountHandler(accountDedicationService, adminActionLogService)
+	adminOrganizationHandler := admin.NewOrganizationHandler(organizationService, adminActionLogService)
+	adminHandlers := handler.ProvideAdminHandlers(dashboardHandler, adminUserHandler, groupHandler, accountHandler, oAuthHandler, openAIOAuthHandler, geminiOAuthHandler, antigravityOAuthHandler, proxyHandler, adminRedeemHandler, promoHandler, adminPlanHandler, uploadHandler, settingHandler, opsHandler, systemHandler, backupHandler, ipProtectionHandler, adminSubscriptionHandler, adminUsageHandler, userAttributeHandler, adminInviteHandler, dedicatedAccountHandler, adminOrganizationHandler)
+	contentPolicyService := service.NewContentPolicyService(configConfig)
+	gatewayHandler := handler.NewGatewayHandler(gatewayService, geminiMessagesCompatService, antigravityGatewayService, userService, concurrencyService, billingCacheService, contentPolicyService, configConfig)
+	openAIGatewayHandler := handler.NewOpenAIGatewayHandler(openAIGatewayService, concurrencyService, billingCacheService, contentPolicyService, configConfig)
 	handlerSettingHandler := handler.ProvideSettingHandler(settingService, buildInfo)
 	totpHandler := handler.NewTotpHandler(totpService)
-	handlers := handler.ProvideHandlers(authHandler, userHandler, apiKeyHandler, usageHandler, redeemHandler, subscriptionHandler, inviteHandler, planHandler, adminHandlers, gatewayHandler, openAIGatewayHandler, handlerSettingHandler, totpHandler)
-	jwtAuthMiddleware := middleware.NewJWTAuthMiddleware(authService, userService)
-	adminAuthMiddleware := middleware.NewAdminAuthMiddleware(authService, userService, settingService)
+	twoFactorHandler := handler.NewTwoFactorHandler(webAuthnService, twoFactorService)
+	handlers := handler.ProvideHandlers(authHandler, userHandler, apiKeyHandler, usageHandler, redeemHandler, subscriptionHandler, inviteHandler, planHandler, organizationHandler, adminHandlers, gatewayHandler, openAIGatewayHandler, handlerSettingHandler, totpHandler, twoFactorHandler)
+	jwtAuthMiddleware := middleware.NewJWTAuthMiddleware(authService, userService, authSessionService)
+	adminAuthMiddleware := middleware.NewAdminAuthMiddleware(authService, userService, settingService, authSessionService, twoFactorService)
 	apiKeyAuthMiddleware := middleware.NewAPIKeyAuthMiddleware(apiKeyService, subscriptionService, configConfig)
-	engine := server.ProvideRouter(configConfig, handlers, jwtAuthMiddleware, adminAuthMiddleware, apiKeyAuthMiddleware, apiKeyService, subscriptionService, opsService, settingService, redisClient)
+	ipProtectionMiddleware := middleware.NewIPProtectionMiddleware(ipProtectionService)
+	engine := server.ProvideRouter(configConfig, handlers, jwtAuthMiddleware, adminAuthMiddleware, apiKeyAuthMiddleware, ipProtectionMiddleware, apiKeyService, subscriptionService, opsService, settingService, universalClient)
 	httpServer := server.ProvideHTTPServer(configConfig, engine)
-	opsMetricsCollector := service.ProvideOpsMetricsCollector(opsRepository, settingRepository, accountRepository, concurrencyService, db, redisClient, configConfig)
-	opsAggregationService := service.ProvideOpsAggregationService(opsRepository, settingRepository, db, redisClient, configConfig)
-	opsAlertEvaluatorService := service.ProvideOpsAlertEvaluatorService(opsService, opsRepository, emailService, redisClient, configConfig)
-	opsCleanupService := service.ProvideOpsCleanupService(opsRepository, db, redisClient, configConfig)
-	opsScheduledReportService := service.ProvideOpsScheduledReportService(opsService, userService, emailService, redisClient, configConfig)
+	opsMetricsCollector := service.ProvideOpsMetricsCollector(opsRepository, settingRepository, accountRepository, concurrencyService, db, universalClient, configConfig)
+	opsAggregationService := service.ProvideOpsAggregationService(opsRepository, settingRepository, db, universalClient, configConfig)
+	usageAnomalyRepository := repository.NewUsageAnomalyRepository(db)
+	usageAnomalyService := service.NewUsageAnomalyService(usageAnomalyRepository, apiKeyService, userRepository, emailQueueService, universalClient)
+	opsAlertEvaluatorService := service.ProvideOpsAlertEvaluatorService(opsService, opsRepository, emailService, usageAnomalyService, universalClient, configConfig)
+	opsCleanupService := service.ProvideOpsCleanupService(opsRepository, db, universalClient, configConfig)
+	opsScheduledReportService := service.ProvideOpsScheduledReportService(opsService, userService, emailService, universalClient, configConfig)
 	tokenRefreshService := service.ProvideTokenRefreshService(accountRepository, oAuthService, openAIOAuthService, geminiOAuthService, antigravityOAuthService, compositeTokenCacheInvalidator, configConfig)
 	accountExpiryService := service.ProvideAccountExpiryService(accountRepository)
-	subscriptionExpiryService := service.ProvideSubscriptionExpiryService(userSubscriptionRepository)
-	v := provideCleanup(client, redisClient, opsMetricsCollector, opsAggregationService, opsAlertEvaluatorService, opsCleanupService, opsScheduledReportService, schedulerSnapshotService, tokenRefreshService, accountExpiryService, subscriptionExpiryService, usageCleanupService, pricingService, emailQueueService, billingCacheService, oAuthService, openAIOAuthService, geminiOAuthService, antigravityOAuthService)
+	subscriptionExpiryService := service.ProvideSubscriptionExpiryService(userSubscriptionRepository, subscriptionLifecycleService)
+	apiKeyHashMigrationService := service.ProvideAPIKeyHashMigrationService(apiKeyRepository, apiKeyService)
+	secretReencryptRepository := repository.NewSecretReencryptRepository(db, secretCipher)
+	secretReencryptService := service.ProvideSecretReencryptService(secretReencryptRepository, secretCipher, configConfig)
+	v := provideCleanup(client, universalClient, opsMetricsCollector, opsAggregationService, opsAlertEvaluatorService, opsCleanupService, opsScheduledReportService, schedulerSnapshotService, tokenRefreshService, accountExpiryService, subscriptionExpiryService, inviteCommissionService, apiKeyHashMigrationService, secretReencryptService, ipProtectionService, usageCleanupService, pricingService, emailQueueService, billingCacheService, oAuthService, openAIOAuthService, geminiOAuthService, antigravityOAuthService)
 	application := &Application{
-		Server:  httpServer,
-		Cleanup: v,
+		Server:         httpServer,
+		ConfigReloader: reloader,
+		Cleanup:        v,
 	}
 	return application, nil
 }
@@ -211,8 +249,9 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 // wire.go:
 
 type Application struct {
-	Server  *http.Server
-	Cleanup func()
+	Server         *http.Server
+	ConfigReloader *config.Reloader
+	Cleanup        func()
 }
 
 func provideServiceBuildInfo(buildInfo handler.BuildInfo) service.BuildInfo {
@@ -224,7 +263,7 @@ func provideServiceBuildInfo(buildInfo handler.BuildInfo) service.BuildInfo {
 
 func provideCleanup(
 	entClient *ent.Client,
-	rdb *redis.Client,
+	rdb redis.UniversalClient,
 	opsMetricsCollector *service.OpsMetricsCollector,
 	opsAggregation *service.OpsAggregationService,
 	opsAlertEvaluator *service.OpsAlertEvaluatorService,
@@ -234,6 +273,10 @@ func provideCleanup(
 	tokenRefresh *service.TokenRefreshService,
 	accountExpiry *service.AccountExpiryService,
 	subscriptionExpiry *service.SubscriptionExpiryService,
+	inviteCommission *service.InviteCommissionService,
+	apiKeyHashMigration *service.APIKeyHashMigrationService,
+	secretReencrypt *service.SecretReencryptService,
+	ipProtection *service.IPProtectionService,
 	usageCleanup *service.UsageCleanupService,
 	pricing *service.PricingService,
 	emailQueue *service.EmailQueueService,
@@ -305,6 +348,22 @@ func provideCleanup(
 				subscriptionExpiry.Stop()
 				return nil
 			}},
+			{"InviteCommissionService", func() error {
+				inviteCommission.Stop()
+				return nil
+			}},
+			{"APIKeyHashMigrationService", func() error {
+				apiKeyHashMigration.Stop()
+				return nil
+			}},
+			{"SecretReencryptService", func() error {
+				secretReencrypt.Stop()
+				return nil
+			}},
+			{"IPProtectionService", func() error {
+				ipProtection.Stop()
+				return nil
+			}},
 			{"PricingService", func() error {
 				pricing.Stop()
 				return nil
@@ -13,6 +13,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
 	"github.com/Wei-Shaw/sub2api/ent/group"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
 	"github.com/Wei-Shaw/sub2api/ent/usagelog"
 	"github.com/Wei-Shaw/sub2api/ent/user"
 )
@@ -79,6 +80,42 @@ func (_c *APIKeyCreate) SetKey(v string) *APIKeyCreate {
 	return _c
 }
 
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableKey(v *string) *APIKeyCreate {
+	if v != nil {
+		_c.SetKey(*v)
+	}
+	return _c
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (_c *APIKeyCreate) SetKeyHash(v string) *APIKeyCreate {
+	_c.mutation.SetKeyHash(v)
+	return _c
+}
+
+// SetNillableKeyHash sets the "key_hash" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableKeyHash(v *string) *APIKeyCreate {
+	if v != nil {
+		_c.SetKeyHash(*v)
+	}
+	return _c
+}
+
+// SetKeyPrefix sets the "key_prefix" field.
+func (_c *APIKeyCreate) SetKeyPrefix(v string) *APIKeyCreate {
+	_c.mutation.SetKeyPrefix(v)
+	return _c
+}
+
+// SetNillableKeyPrefix sets the "key_prefix" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableKeyPrefix(v *string) *APIKeyCreate {
+	if v != nil {
+		_c.SetKeyPrefix(*v)
+	}
+	return _c
+}
+
 // SetName sets the "name" field.
 func (_c *APIKeyCreate) SetName(v string) *APIKeyCreate {
 	_c.mutation.SetName(v)
@@ -125,6 +162,40 @@ func (_c *APIKeyCreate) SetIPBlacklist(v []string) *APIKeyCreate {
 	return _c
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (_c *APIKeyCreate) SetDedicatedAccountIds(v []int64) *APIKeyCreate {
+	_c.mutation.SetDedicatedAccountIds(v)
+	return _c
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (_c *APIKeyCreate) SetDedicatedAccountsOnly(v bool) *APIKeyCreate {
+	_c.mutation.SetDedicatedAccountsOnly(v)
+	return _c
+}
+
+// SetNillableDedicatedAccountsOnly sets the "dedicated_accounts_only" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableDedicatedAccountsOnly(v *bool) *APIKeyCreate {
+	if v != nil {
+		_c.SetDedicatedAccountsOnly(*v)
+	}
+	return _c
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_c *APIKeyCreate) SetOrganizationID(v int64) *APIKeyCreate {
+	_c.mutation.SetOrganizationID(v)
+	return _c
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableOrganizationID(v *int64) *APIKeyCreate {
+	if v != nil {
+		_c.SetOrganizationID(*v)
+	}
+	return _c
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_c *APIKeyCreate) SetUser(v *User) *APIKeyCreate {
 	return _c.SetUserID(v.ID)
@@ -135,6 +206,11 @@ func (_c *APIKeyCreate) SetGroup(v *Group) *APIKeyCreate {
 	return _c.SetGroupID(v.ID)
 }
 
+// SetOrganization sets the "organization" edge to the Organization entity.
+func (_c *APIKeyCreate) SetOrganization(v *Organization) *APIKeyCreate {
+	return _c.SetOrganizationID(v.ID)
+}
+
 // AddUsageLogIDs adds the "usage_logs" edge to the UsageLog entity by IDs.
 func (_c *APIKeyCreate) AddUsageLogIDs(ids ...int64) *APIKeyCreate {
 	_c.mutation.AddUsageLogIDs(ids...)
@@ -201,10 +277,18 @@ func (_c *APIKeyCreate) defaults() error {
 		v := apikey.DefaultUpdatedAt()
 		_c.mutation.SetUpdatedAt(v)
 	}
+	if _, ok := _c.mutation.KeyPrefix(); !ok {
+		v := apikey.DefaultKeyPrefix
+		_c.mutation.SetKeyPrefix(v)
+	}
 	if _, ok := _c.mutation.Status(); !ok {
 		v := apikey.DefaultStatus
 		_c.mutation.SetStatus(v)
 	}
+	if _, ok := _c.mutation.DedicatedAccountsOnly(); !ok {
+		v := apikey.DefaultDedicatedAccountsOnly
+		_c.mutation.SetDedicatedAccountsOnly(v)
+	}
 	return nil
 }
 
@@ -219,14 +303,24 @@ func (_c *APIKeyCreate) check() error {
 	if _, ok := _c.mutation.UserID(); !ok {
 		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "APIKey.user_id"`)}
 	}
-	if _, ok := _c.mutation.Key(); !ok {
-		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "APIKey.key"`)}
-	}
 	if v, ok := _c.mutation.Key(); ok {
 		if err := apikey.KeyValidator(v); err != nil {
 			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "APIKey.key": %w`, err)}
 		}
 	}
+	if v, ok := _c.mutation.KeyHash(); ok {
+		if err := apikey.KeyHashValidator(v); err != nil {
+			return &ValidationError{Name: "key_hash", err: fmt.Errorf(`ent: validator failed for field "APIKey.key_hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.KeyPrefix(); !ok {
+		return &ValidationError{Name: "key_prefix", err: errors.New(`ent: missing required field "APIKey.key_prefix"`)}
+	}
+	if v, ok := _c.mutation.KeyPrefix(); ok {
+		if err := apikey.KeyPrefixValidator(v); err != nil {
+			return &ValidationError{Name: "key_prefix", err: fmt.Errorf(`ent: validator failed for field "APIKey.key_prefix": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.Name(); !ok {
 		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "APIKey.name"`)}
 	}
@@ -243,6 +337,9 @@ func (_c *APIKeyCreate) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "APIKey.status": %w`, err)}
 		}
 	}
+	if _, ok := _c.mutation.DedicatedAccountsOnly(); !ok {
+		return &ValidationError{Name: "dedicated_accounts_only", err: errors.New(`ent: missing required field "APIKey.dedicated_accounts_only"`)}
+	}
 	if len(_c.mutation.UserIDs()) == 0 {
 		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "APIKey.user"`)}
 	}
@@ -287,7 +384,15 @@ func (_c *APIKeyCreate) createSpec() (*APIKey, *sqlgraph.CreateSpec) {
 	}
 	if value, ok := _c.mutation.Key(); ok {
 		_spec.SetField(apikey.FieldKey, field.TypeString, value)
-		_node.Key = value
+		_node.Key = &value
+	}
+	if value, ok := _c.mutation.KeyHash(); ok {
+		_spec.SetField(apikey.FieldKeyHash, field.TypeString, value)
+		_node.KeyHash = &value
+	}
+	if value, ok := _c.mutation.KeyPrefix(); ok {
+		_spec.SetField(apikey.FieldKeyPrefix, field.TypeString, value)
+		_node.KeyPrefix = value
 	}
 	if value, ok := _c.mutation.Name(); ok {
 		_spec.SetField(apikey.FieldName, field.TypeString, value)
@@ -305,6 +410,14 @@ func (_c *APIKeyCreate) createSpec() (*APIKey, *sqlgraph.CreateSpec) {
 		_spec.SetField(apikey.FieldIPBlacklist, field.TypeJSON, value)
 		_node.IPBlacklist = value
 	}
+	if value, ok := _c.mutation.DedicatedAccountIds(); ok {
+		_spec.SetField(apikey.FieldDedicatedAccountIds, field.TypeJSON, value)
+		_node.DedicatedAccountIds = value
+	}
+	if value, ok := _c.mutation.DedicatedAccountsOnly(); ok {
+		_spec.SetField(apikey.FieldDedicatedAccountsOnly, field.TypeBool, value)
+		_node.DedicatedAccountsOnly = value
+	}
 	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -339,6 +452,23 @@ func (_c *APIKeyCreate) createSpec() (*APIKey, *sqlgraph.CreateSpec) {
 		_node.GroupID = &nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.OrganizationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   apikey.OrganizationTable,
+			Columns: []string{apikey.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.OrganizationID = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	if nodes := _c.mutation.UsageLogsIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -461,6 +591,42 @@ func (u *APIKeyUpsert) UpdateKey() *APIKeyUpsert {
 	return u
 }
 
+// ClearKey clears the value of the "key" field.
+func (u *APIKeyUpsert) ClearKey() *APIKeyUpsert {
+	u.SetNull(apikey.FieldKey)
+	return u
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (u *APIKeyUpsert) SetKeyHash(v string) *APIKeyUpsert {
+	u.Set(apikey.FieldKeyHash, v)
+	return u
+}
+
+// UpdateKeyHash sets the "key_hash" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateKeyHash() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldKeyHash)
+	return u
+}
+
+// ClearKeyHash clears the value of the "key_hash" field.
+func (u *APIKeyUpsert) ClearKeyHash() *APIKeyUpsert {
+	u.SetNull(apikey.FieldKeyHash)
+	return u
+}
+
+// SetKeyPrefix sets the "key_prefix" field.
+func (u *APIKeyUpsert) SetKeyPrefix(v string) *APIKeyUpsert {
+	u.Set(apikey.FieldKeyPrefix, v)
+	return u
+}
+
+// UpdateKeyPrefix sets the "key_prefix" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateKeyPrefix() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldKeyPrefix)
+	return u
+}
+
 // SetName sets the "name" field.
 func (u *APIKeyUpsert) SetName(v string) *APIKeyUpsert {
 	u.Set(apikey.FieldName, v)
@@ -539,6 +705,54 @@ func (u *APIKeyUpsert) ClearIPBlacklist() *APIKeyUpsert {
 	return u
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (u *APIKeyUpsert) SetDedicatedAccountIds(v []int64) *APIKeyUpsert {
+	u.Set(apikey.FieldDedicatedAccountIds, v)
+	return u
+}
+
+// UpdateDedicatedAccountIds sets the "dedicated_account_ids" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateDedicatedAccountIds() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldDedicatedAccountIds)
+	return u
+}
+
+// ClearDedicatedAccountIds clears the value of the "dedicated_account_ids" field.
+func (u *APIKeyUpsert) ClearDedicatedAccountIds() *APIKeyUpsert {
+	u.SetNull(apikey.FieldDedicatedAccountIds)
+	return u
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (u *APIKeyUpsert) SetDedicatedAccountsOnly(v bool) *APIKeyUpsert {
+	u.Set(apikey.FieldDedicatedAccountsOnly, v)
+	return u
+}
+
+// UpdateDedicatedAccountsOnly sets the "dedicated_accounts_only" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateDedicatedAccountsOnly() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldDedicatedAccountsOnly)
+	return u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *APIKeyUpsert) SetOrganizationID(v int64) *APIKeyUpsert {
+	u.Set(apikey.FieldOrganizationID, v)
+	return u
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateOrganizationID() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldOrganizationID)
+	return u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (u *APIKeyUpsert) ClearOrganizationID() *APIKeyUpsert {
+	u.SetNull(apikey.FieldOrganizationID)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -647,6 +861,48 @@ func (u *APIKeyUpsertOne) UpdateKey() *APIKeyUpsertOne {
 	})
 }
 
+// ClearKey clears the value of the "key" field.
+func (u *APIKeyUpsertOne) ClearKey() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearKey()
+	})
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (u *APIKeyUpsertOne) SetKeyHash(v string) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetKeyHash(v)
+	})
+}
+
+// UpdateKeyHash sets the "key_hash" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateKeyHash() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateKeyHash()
+	})
+}
+
+// ClearKeyHash clears the value of the "key_hash" field.
+func (u *APIKeyUpsertOne) ClearKeyHash() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearKeyHash()
+	})
+}
+
+// SetKeyPrefix sets the "key_prefix" field.
+func (u *APIKeyUpsertOne) SetKeyPrefix(v string) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetKeyPrefix(v)
+	})
+}
+
+// UpdateKeyPrefix sets the "key_prefix" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateKeyPrefix() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateKeyPrefix()
+	})
+}
+
 // SetName sets the "name" field.
 func (u *APIKeyUpsertOne) SetName(v string) *APIKeyUpsertOne {
 	return u.Update(func(s *APIKeyUpsert) {
@@ -738,6 +994,62 @@ func (u *APIKeyUpsertOne) ClearIPBlacklist() *APIKeyUpsertOne {
 	})
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (u *APIKeyUpsertOne) SetDedicatedAccountIds(v []int64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetDedicatedAccountIds(v)
+	})
+}
+
+// UpdateDedicatedAccountIds sets the "dedicated_account_ids" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateDedicatedAccountIds() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateDedicatedAccountIds()
+	})
+}
+
+// ClearDedicatedAccountIds clears the value of the "dedicated_account_ids" field.
+func (u *APIKeyUpsertOne) ClearDedicatedAccountIds() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearDedicatedAccountIds()
+	})
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (u *APIKeyUpsertOne) SetDedicatedAccountsOnly(v bool) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetDedicatedAccountsOnly(v)
+	})
+}
+
+// UpdateDedicatedAccountsOnly sets the "dedicated_accounts_only" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateDedicatedAccountsOnly() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateDedicatedAccountsOnly()
+	})
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *APIKeyUpsertOne) SetOrganizationID(v int64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetOrganizationID(v)
+	})
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateOrganizationID() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateOrganizationID()
+	})
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (u *APIKeyUpsertOne) ClearOrganizationID() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearOrganizationID()
+	})
+}
+
 // Exec executes the query.
 func (u *APIKeyUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -1012,6 +1324,48 @@ func (u *APIKeyUpsertBulk) UpdateKey() *APIKeyUpsertBulk {
 	})
 }
 
+// ClearKey clears the value of the "key" field.
+func (u *APIKeyUpsertBulk) ClearKey() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearKey()
+	})
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (u *APIKeyUpsertBulk) SetKeyHash(v string) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetKeyHash(v)
+	})
+}
+
+// UpdateKeyHash sets the "key_hash" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateKeyHash() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateKeyHash()
+	})
+}
+
+// ClearKeyHash clears the value of the "key_hash" field.
+func (u *APIKeyUpsertBulk) ClearKeyHash() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearKeyHash()
+	})
+}
+
+// SetKeyPrefix sets the "key_prefix" field.
+func (u *APIKeyUpsertBulk) SetKeyPrefix(v string) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetKeyPrefix(v)
+	})
+}
+
+// UpdateKeyPrefix sets the "key_prefix" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateKeyPrefix() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateKeyPrefix()
+	})
+}
+
 // SetName sets the "name" field.
 func (u *APIKeyUpsertBulk) SetName(v string) *APIKeyUpsertBulk {
 	return u.Update(func(s *APIKeyUpsert) {
@@ -1103,6 +1457,62 @@ func (u *APIKeyUpsertBulk) ClearIPBlacklist() *APIKeyUpsertBulk {
 	})
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (u *APIKeyUpsertBulk) SetDedicatedAccountIds(v []int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetDedicatedAccountIds(v)
+	})
+}
+
+// UpdateDedicatedAccountIds sets the "dedicated_account_ids" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateDedicatedAccountIds() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateDedicatedAccountIds()
+	})
+}
+
+// ClearDedicatedAccountIds clears the value of the "dedicated_account_ids" field.
+func (u *APIKeyUpsertBulk) ClearDedicatedAccountIds() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearDedicatedAccountIds()
+	})
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (u *APIKeyUpsertBulk) SetDedicatedAccountsOnly(v bool) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetDedicatedAccountsOnly(v)
+	})
+}
+
+// UpdateDedicatedAccountsOnly sets the "dedicated_accounts_only" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateDedicatedAccountsOnly() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateDedicatedAccountsOnly()
+	})
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *APIKeyUpsertBulk) SetOrganizationID(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetOrganizationID(v)
+	})
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateOrganizationID() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateOrganizationID()
+	})
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (u *APIKeyUpsertBulk) ClearOrganizationID() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearOrganizationID()
+	})
+}
+
 // Exec executes the query.
 func (u *APIKeyUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
@@ -14,6 +14,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
 	"github.com/Wei-Shaw/sub2api/ent/group"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
 	"github.com/Wei-Shaw/sub2api/ent/predicate"
 	"github.com/Wei-Shaw/sub2api/ent/usagelog"
 	"github.com/Wei-Shaw/sub2api/ent/user"
@@ -86,6 +87,46 @@ func (_u *APIKeyUpdate) SetNillableKey(v *string) *APIKeyUpdate {
 	return _u
 }
 
+// ClearKey clears the value of the "key" field.
+func (_u *APIKeyUpdate) ClearKey() *APIKeyUpdate {
+	_u.mutation.ClearKey()
+	return _u
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (_u *APIKeyUpdate) SetKeyHash(v string) *APIKeyUpdate {
+	_u.mutation.SetKeyHash(v)
+	return _u
+}
+
+// SetNillableKeyHash sets the "key_hash" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableKeyHash(v *string) *APIKeyUpdate {
+	if v != nil {
+		_u.SetKeyHash(*v)
+	}
+	return _u
+}
+
+// ClearKeyHash clears the value of the "key_hash" field.
+func (_u *APIKeyUpdate) ClearKeyHash() *APIKeyUpdate {
+	_u.mutation.ClearKeyHash()
+	return _u
+}
+
+// SetKeyPrefix sets the "key_prefix" field.
+func (_u *APIKeyUpdate) SetKeyPrefix(v string) *APIKeyUpdate {
+	_u.mutation.SetKeyPrefix(v)
+	return _u
+}
+
+// SetNillableKeyPrefix sets the "key_prefix" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableKeyPrefix(v *string) *APIKeyUpdate {
+	if v != nil {
+		_u.SetKeyPrefix(*v)
+	}
+	return _u
+}
+
 // SetName sets the "name" field.
 func (_u *APIKeyUpdate) SetName(v string) *APIKeyUpdate {
 	_u.mutation.SetName(v)
@@ -170,6 +211,58 @@ func (_u *APIKeyUpdate) ClearIPBlacklist() *APIKeyUpdate {
 	return _u
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (_u *APIKeyUpdate) SetDedicatedAccountIds(v []int64) *APIKeyUpdate {
+	_u.mutation.SetDedicatedAccountIds(v)
+	return _u
+}
+
+// AppendDedicatedAccountIds appends value to the "dedicated_account_ids" field.
+func (_u *APIKeyUpdate) AppendDedicatedAccountIds(v []int64) *APIKeyUpdate {
+	_u.mutation.AppendDedicatedAccountIds(v)
+	return _u
+}
+
+// ClearDedicatedAccountIds clears the value of the "dedicated_account_ids" field.
+func (_u *APIKeyUpdate) ClearDedicatedAccountIds() *APIKeyUpdate {
+	_u.mutation.ClearDedicatedAccountIds()
+	return _u
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (_u *APIKeyUpdate) SetDedicatedAccountsOnly(v bool) *APIKeyUpdate {
+	_u.mutation.SetDedicatedAccountsOnly(v)
+	return _u
+}
+
+// SetNillableDedicatedAccountsOnly sets the "dedicated_accounts_only" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableDedicatedAccountsOnly(v *bool) *APIKeyUpdate {
+	if v != nil {
+		_u.SetDedicatedAccountsOnly(*v)
+	}
+	return _u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_u *APIKeyUpdate) SetOrganizationID(v int64) *APIKeyUpdate {
+	_u.mutation.SetOrganizationID(v)
+	return _u
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableOrganizationID(v *int64) *APIKeyUpdate {
+	if v != nil {
+		_u.SetOrganizationID(*v)
+	}
+	return _u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (_u *APIKeyUpdate) ClearOrganizationID() *APIKeyUpdate {
+	_u.mutation.ClearOrganizationID()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *APIKeyUpdate) SetUser(v *User) *APIKeyUpdate {
 	return _u.SetUserID(v.ID)
@@ -180,6 +273,11 @@ func (_u *APIKeyUpdate) SetGroup(v *Group) *APIKeyUpdate {
 	return _u.SetGroupID(v.ID)
 }
 
+// SetOrganization sets the "organization" edge to the Organization entity.
+func (_u *APIKeyUpdate) SetOrganization(v *Organization) *APIKeyUpdate {
+	return _u.SetOrganizationID(v.ID)
+}
+
 // AddUsageLogIDs adds the "usage_logs" edge to the UsageLog entity by IDs.
 func (_u *APIKeyUpdate) AddUsageLogIDs(ids ...int64) *APIKeyUpdate {
 	_u.mutation.AddUsageLogIDs(ids...)
@@ -212,6 +310,12 @@ func (_u *APIKeyUpdate) ClearGroup() *APIKeyUpdate {
 	return _u
 }
 
+// ClearOrganization clears the "organization" edge to the Organization entity.
+func (_u *APIKeyUpdate) ClearOrganization() *APIKeyUpdate {
+	_u.mutation.ClearOrganization()
+	return _u
+}
+
 // ClearUsageLogs clears all "usage_logs" edges to the UsageLog entity.
 func (_u *APIKeyUpdate) ClearUsageLogs() *APIKeyUpdate {
 	_u.mutation.ClearUsageLogs()
@@ -282,6 +386,16 @@ func (_u *APIKeyUpdate) check() error {
 			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "APIKey.key": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.KeyHash(); ok {
+		if err := apikey.KeyHashValidator(v); err != nil {
+			return &ValidationError{Name: "key_hash", err: fmt.Errorf(`ent: validator failed for field "APIKey.key_hash": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.KeyPrefix(); ok {
+		if err := apikey.KeyPrefixValidator(v); err != nil {
+			return &ValidationError{Name: "key_prefix", err: fmt.Errorf(`ent: validator failed for field "APIKey.key_prefix": %w`, err)}
+		}
+	}
 	if v, ok := _u.mutation.Name(); ok {
 		if err := apikey.NameValidator(v); err != nil {
 			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "APIKey.name": %w`, err)}
@@ -322,6 +436,18 @@ func (_u *APIKeyUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.Key(); ok {
 		_spec.SetField(apikey.FieldKey, field.TypeString, value)
 	}
+	if _u.mutation.KeyCleared() {
+		_spec.ClearField(apikey.FieldKey, field.TypeString)
+	}
+	if value, ok := _u.mutation.KeyHash(); ok {
+		_spec.SetField(apikey.FieldKeyHash, field.TypeString, value)
+	}
+	if _u.mutation.KeyHashCleared() {
+		_spec.ClearField(apikey.FieldKeyHash, field.TypeString)
+	}
+	if value, ok := _u.mutation.KeyPrefix(); ok {
+		_spec.SetField(apikey.FieldKeyPrefix, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.Name(); ok {
 		_spec.SetField(apikey.FieldName, field.TypeString, value)
 	}
@@ -350,6 +476,20 @@ func (_u *APIKeyUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.IPBlacklistCleared() {
 		_spec.ClearField(apikey.FieldIPBlacklist, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.DedicatedAccountIds(); ok {
+		_spec.SetField(apikey.FieldDedicatedAccountIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedDedicatedAccountIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apikey.FieldDedicatedAccountIds, value)
+		})
+	}
+	if _u.mutation.DedicatedAccountIdsCleared() {
+		_spec.ClearField(apikey.FieldDedicatedAccountIds, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.DedicatedAccountsOnly(); ok {
+		_spec.SetField(apikey.FieldDedicatedAccountsOnly, field.TypeBool, value)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -408,6 +548,35 @@ func (_u *APIKeyUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OrganizationCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   apikey.OrganizationTable,
+			Columns: []string{apikey.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OrganizationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   apikey.OrganizationTable,
+			Columns: []string{apikey.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _u.mutation.UsageLogsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -527,6 +696,46 @@ func (_u *APIKeyUpdateOne) SetNillableKey(v *string) *APIKeyUpdateOne {
 	return _u
 }
 
+// ClearKey clears the value of the "key" field.
+func (_u *APIKeyUpdateOne) ClearKey() *APIKeyUpdateOne {
+	_u.mutation.ClearKey()
+	return _u
+}
+
+// SetKeyHash sets the "key_hash" field.
+func (_u *APIKeyUpdateOne) SetKeyHash(v string) *APIKeyUpdateOne {
+	_u.mutation.SetKeyHash(v)
+	return _u
+}
+
+// SetNillableKeyHash sets the "key_hash" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableKeyHash(v *string) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetKeyHash(*v)
+	}
+	return _u
+}
+
+// ClearKeyHash clears the value of the "key_hash" field.
+func (_u *APIKeyUpdateOne) ClearKeyHash() *APIKeyUpdateOne {
+	_u.mutation.ClearKeyHash()
+	return _u
+}
+
+// SetKeyPrefix sets the "key_prefix" field.
+func (_u *APIKeyUpdateOne) SetKeyPrefix(v string) *APIKeyUpdateOne {
+	_u.mutation.SetKeyPrefix(v)
+	return _u
+}
+
+// SetNillableKeyPrefix sets the "key_prefix" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableKeyPrefix(v *string) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetKeyPrefix(*v)
+	}
+	return _u
+}
+
 // SetName sets the "name" field.
 func (_u *APIKeyUpdateOne) SetName(v string) *APIKeyUpdateOne {
 	_u.mutation.SetName(v)
@@ -611,6 +820,58 @@ func (_u *APIKeyUpdateOne) ClearIPBlacklist() *APIKeyUpdateOne {
 	return _u
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (_u *APIKeyUpdateOne) SetDedicatedAccountIds(v []int64) *APIKeyUpdateOne {
+	_u.mutation.SetDedicatedAccountIds(v)
+	return _u
+}
+
+// AppendDedicatedAccountIds appends value to the "dedicated_account_ids" field.
+func (_u *APIKeyUpdateOne) AppendDedicatedAccountIds(v []int64) *APIKeyUpdateOne {
+	_u.mutation.AppendDedicatedAccountIds(v)
+	return _u
+}
+
+// ClearDedicatedAccountIds clears the value of the "dedicated_account_ids" field.
+func (_u *APIKeyUpdateOne) ClearDedicatedAccountIds() *APIKeyUpdateOne {
+	_u.mutation.ClearDedicatedAccountIds()
+	return _u
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (_u *APIKeyUpdateOne) SetDedicatedAccountsOnly(v bool) *APIKeyUpdateOne {
+	_u.mutation.SetDedicatedAccountsOnly(v)
+	return _u
+}
+
+// SetNillableDedicatedAccountsOnly sets the "dedicated_accounts_only" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableDedicatedAccountsOnly(v *bool) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetDedicatedAccountsOnly(*v)
+	}
+	return _u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_u *APIKeyUpdateOne) SetOrganizationID(v int64) *APIKeyUpdateOne {
+	_u.mutation.SetOrganizationID(v)
+	return _u
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableOrganizationID(v *int64) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetOrganizationID(*v)
+	}
+	return _u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (_u *APIKeyUpdateOne) ClearOrganizationID() *APIKeyUpdateOne {
+	_u.mutation.ClearOrganizationID()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *APIKeyUpdateOne) SetUser(v *User) *APIKeyUpdateOne {
 	return _u.SetUserID(v.ID)
@@ -621,6 +882,11 @@ func (_u *APIKeyUpdateOne) SetGroup(v *Group) *APIKeyUpdateOne {
 	return _u.SetGroupID(v.ID)
 }
 
+// SetOrganization sets the "organization" edge to the Organization entity.
+func (_u *APIKeyUpdateOne) SetOrganization(v *Organization) *APIKeyUpdateOne {
+	return _u.SetOrganizationID(v.ID)
+}
+
 // AddUsageLogIDs adds the "usage_logs" edge to the UsageLog entity by IDs.
 func (_u *APIKeyUpdateOne) AddUsageLogIDs(ids ...int64) *APIKeyUpdateOne {
 	_u.mutation.AddUsageLogIDs(ids...)
@@ -653,6 +919,12 @@ func (_u *APIKeyUpdateOne) ClearGroup() *APIKeyUpdateOne {
 	return _u
 }
 
+// ClearOrganization clears the "organization" edge to the Organization entity.
+func (_u *APIKeyUpdateOne) ClearOrganization() *APIKeyUpdateOne {
+	_u.mutation.ClearOrganization()
+	return _u
+}
+
 // ClearUsageLogs clears all "usage_logs" edges to the UsageLog entity.
 func (_u *APIKeyUpdateOne) ClearUsageLogs() *APIKeyUpdateOne {
 	_u.mutation.ClearUsageLogs()
@@ -736,6 +1008,16 @@ func (_u *APIKeyUpdateOne) check() error {
 			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "APIKey.key": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.KeyHash(); ok {
+		if err := apikey.KeyHashValidator(v); err != nil {
+			return &ValidationError{Name: "key_hash", err: fmt.Errorf(`ent: validator failed for field "APIKey.key_hash": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.KeyPrefix(); ok {
+		if err := apikey.KeyPrefixValidator(v); err != nil {
+			return &ValidationError{Name: "key_prefix", err: fmt.Errorf(`ent: validator failed for field "APIKey.key_prefix": %w`, err)}
+		}
+	}
 	if v, ok := _u.mutation.Name(); ok {
 		if err := apikey.NameValidator(v); err != nil {
 			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "APIKey.name": %w`, err)}
@@ -793,6 +1075,18 @@ func (_u *APIKeyUpdateOne) sqlSave(ctx context.Context) (_node *APIKey, err erro
 	if value, ok := _u.mutation.Key(); ok {
 		_spec.SetField(apikey.FieldKey, field.TypeString, value)
 	}
+	if _u.mutation.KeyCleared() {
+		_spec.ClearField(apikey.FieldKey, field.TypeString)
+	}
+	if value, ok := _u.mutation.KeyHash(); ok {
+		_spec.SetField(apikey.FieldKeyHash, field.TypeString, value)
+	}
+	if _u.mutation.KeyHashCleared() {
+		_spec.ClearField(apikey.FieldKeyHash, field.TypeString)
+	}
+	if value, ok := _u.mutation.KeyPrefix(); ok {
+		_spec.SetField(apikey.FieldKeyPrefix, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.Name(); ok {
 		_spec.SetField(apikey.FieldName, field.TypeString, value)
 	}
@@ -821,6 +1115,20 @@ func (_u *APIKeyUpdateOne) sqlSave(ctx context.Context) (_node *APIKey, err erro
 	if _u.mutation.IPBlacklistCleared() {
 		_spec.ClearField(apikey.FieldIPBlacklist, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.DedicatedAccountIds(); ok {
+		_spec.SetField(apikey.FieldDedicatedAccountIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedDedicatedAccountIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apikey.FieldDedicatedAccountIds, value)
+		})
+	}
+	if _u.mutation.DedicatedAccountIdsCleared() {
+		_spec.ClearField(apikey.FieldDedicatedAccountIds, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.DedicatedAccountsOnly(); ok {
+		_spec.SetField(apikey.FieldDedicatedAccountsOnly, field.TypeBool, value)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -879,6 +1187,35 @@ func (_u *APIKeyUpdateOne) sqlSave(ctx context.Context) (_node *APIKey, err erro
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OrganizationCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   apikey.OrganizationTable,
+			Columns: []string{apikey.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OrganizationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   apikey.OrganizationTable,
+			Columns: []string{apikey.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _u.mutation.UsageLogsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -19,9 +19,14 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/accountgroup"
 	"github.com/Wei-Shaw/sub2api/ent/adminactionlog"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
+	"github.com/Wei-Shaw/sub2api/ent/commissionwithdrawal"
 	"github.com/Wei-Shaw/sub2api/ent/group"
 	"github.com/Wei-Shaw/sub2api/ent/invitation"
+	"github.com/Wei-Shaw/sub2api/ent/invitecommission"
 	"github.com/Wei-Shaw/sub2api/ent/invitelog"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
+	"github.com/Wei-Shaw/sub2api/ent/organizationinvitation"
+	"github.com/Wei-Shaw/sub2api/ent/organizationmember"
 	"github.com/Wei-Shaw/sub2api/ent/plan"
 	"github.com/Wei-Shaw/sub2api/ent/promocode"
 	"github.com/Wei-Shaw/sub2api/ent/promocodeusage"
@@ -34,7 +39,9 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/userallowedgroup"
 	"github.com/Wei-Shaw/sub2api/ent/userattributedefinition"
 	"github.com/Wei-Shaw/sub2api/ent/userattributevalue"
+	"github.com/Wei-Shaw/sub2api/ent/userrecoverycode"
 	"github.com/Wei-Shaw/sub2api/ent/usersubscription"
+	"github.com/Wei-Shaw/sub2api/ent/webauthncredential"
 
 	stdsql "database/sql"
 )
@@ -52,12 +59,22 @@ type Client struct {
 	AccountGroup *AccountGroupClient
 	// AdminActionLog is the client for interacting with the AdminActionLog builders.
 	AdminActionLog *AdminActionLogClient
+	// CommissionWithdrawal is the client for interacting with the CommissionWithdrawal builders.
+	CommissionWithdrawal *CommissionWithdrawalClient
 	// Group is the client for interacting with the Group builders.
 	Group *GroupClient
 	// Invitation is the client for interacting with the Invitation builders.
 	Invitation *InvitationClient
+	// InviteCommission is the client for interacting with the InviteCommission builders.
+	InviteCommission *InviteCommissionClient
 	// InviteLog is the client for interacting with the InviteLog builders.
 	InviteLog *InviteLogClient
+	// Organization is the client for interacting with the Organization builders.
+	Organization *OrganizationClient
+	// OrganizationInvitation is the client for interacting with the OrganizationInvitation builders.
+	OrganizationInvitation *OrganizationInvitationClient
+	// OrganizationMember is the client for interacting with the OrganizationMember builders.
+	OrganizationMember *OrganizationMemberClient
 	// Plan is the client for interacting with the Plan builders.
 	Plan *PlanClient
 	// PromoCode is the client for interacting with the PromoCode builders.
@@ -82,8 +99,12 @@ type Client struct {
 	UserAttributeDefinition *UserAttributeDefinitionClient
 	// UserAttributeValue is the client for interacting with the UserAttributeValue builders.
 	UserAttributeValue *UserAttributeValueClient
+	// UserRecoveryCode is the client for interacting with the UserRecoveryCode builders.
+	UserRecoveryCode *UserRecoveryCodeClient
 	// UserSubscription is the client for interacting with the UserSubscription builders.
 	UserSubscription *UserSubscriptionClient
+	// WebAuthnCredential is the client for interacting with the WebAuthnCredential builders.
+	WebAuthnCredential *WebAuthnCredentialClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -99,9 +120,14 @@ func (c *Client) init() {
 	c.Account = NewAccountClient(c.config)
 	c.AccountGroup = NewAccountGroupClient(c.config)
 	c.AdminActionLog = NewAdminActionLogClient(c.config)
+	c.CommissionWithdrawal = NewCommissionWithdrawalClient(c.config)
 	c.Group = NewGroupClient(c.config)
 	c.Invitation = NewInvitationClient(c.config)
+	c.InviteCommission = NewInviteCommissionClient(c.config)
 	c.InviteLog = NewInviteLogClient(c.config)
+	c.Organization = NewOrganizationClient(c.config)
+	c.OrganizationInvitation = NewOrganizationInvitationClient(c.config)
+	c.OrganizationMember = NewOrganizationMemberClient(c.config)
 	c.Plan = NewPlanClient(c.config)
 	c.PromoCode = NewPromoCodeClient(c.config)
 	c.PromoCodeUsage = NewPromoCodeUsageClient(c.config)
@@ -114,7 +140,9 @@ func (c *Client) init() {
 	c.UserAllowedGroup = NewUserAllowedGroupClient(c.config)
 	c.UserAttributeDefinition = NewUserAttributeDefinitionClient(c.config)
 	c.UserAttributeValue = NewUserAttributeValueClient(c.config)
+	c.UserRecoveryCode = NewUserRecoveryCodeClient(c.config)
 	c.UserSubscription = NewUserSubscriptionClient(c.config)
+	c.WebAuthnCredential = NewWebAuthnCredentialClient(c.config)
 }
 
 type (
@@ -211,9 +239,14 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 		Account:                 NewAccountClient(cfg),
 		AccountGroup:            NewAccountGroupClient(cfg),
 		AdminActionLog:          NewAdminActionLogClient(cfg),
+		CommissionWithdrawal:    NewCommissionWithdrawalClient(cfg),
 		Group:                   NewGroupClient(cfg),
 		Invitation:              NewInvitationClient(cfg),
+		InviteCommission:        NewInviteCommissionClient(cfg),
 		InviteLog:               NewInviteLogClient(cfg),
+		Organization:            NewOrganizationClient(cfg),
+		OrganizationInvitation:  NewOrganizationInvitationClient(cfg),
+		OrganizationMember:      NewOrganizationMemberClient(cfg),
 		Plan:                    NewPlanClient(cfg),
 		PromoCode:               NewPromoCodeClient(cfg),
 		PromoCodeUsage:          NewPromoCodeUsageClient(cfg),
@@ -226,7 +259,9 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 		UserAllowedGroup:        NewUserAllowedGroupClient(cfg),
 		UserAttributeDefinition: NewUserAttributeDefinitionClient(cfg),
 		UserAttributeValue:      NewUserAttributeValueClient(cfg),
+		UserRecoveryCode:        NewUserRecoveryCodeClient(cfg),
 		UserSubscription:        NewUserSubscriptionClient(cfg),
+		WebAuthnCredential:      NewWebAuthnCredentialClient(cfg),
 	}, nil
 }
 
@@ -250,9 +285,14 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 		Account:                 NewAccountClient(cfg),
 		AccountGroup:            NewAccountGroupClient(cfg),
 		AdminActionLog:          NewAdminActionLogClient(cfg),
+		CommissionWithdrawal:    NewCommissionWithdrawalClient(cfg),
 		Group:                   NewGroupClient(cfg),
 		Invitation:              NewInvitationClient(cfg),
+		InviteCommission:        NewInviteCommissionClient(cfg),
 		InviteLog:               NewInviteLogClient(cfg),
+		Organization:            NewOrganizationClient(cfg),
+		OrganizationInvitation:  NewOrganizationInvitationClient(cfg),
+		OrganizationMember:      NewOrganizationMemberClient(cfg),
 		Plan:                    NewPlanClient(cfg),
 		PromoCode:               NewPromoCodeClient(cfg),
 		PromoCodeUsage:          NewPromoCodeUsageClient(cfg),
@@ -265,7 +305,9 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 		UserAllowedGroup:        NewUserAllowedGroupClient(cfg),
 		UserAttributeDefinition: NewUserAttributeDefinitionClient(cfg),
 		UserAttributeValue:      NewUserAttributeValueClient(cfg),
+		UserRecoveryCode:        NewUserRecoveryCodeClient(cfg),
 		UserSubscription:        NewUserSubscriptionClient(cfg),
+		WebAuthnCredential:      NewWebAuthnCredentialClient(cfg),
 	}, nil
 }
 
@@ -295,10 +337,13 @@ func (c *Client) Close() error {
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
 	for _, n := range []interface{ Use(...Hook) }{
-		c.APIKey, c.Account, c.AccountGroup, c.AdminActionLog, c.Group, c.Invitation,
-		c.InviteLog, c.Plan, c.PromoCode, c.PromoCodeUsage, c.Proxy, c.RedeemCode,
-		c.Setting, c.UsageCleanupTask, c.UsageLog, c.User, c.UserAllowedGroup,
-		c.UserAttributeDefinition, c.UserAttributeValue, c.UserSubscription,
+		c.APIKey, c.Account, c.AccountGroup, c.AdminActionLog, c.CommissionWithdrawal,
+		c.Group, c.Invitation, c.InviteCommission, c.InviteLog, c.Organization,
+		c.OrganizationInvitation, c.OrganizationMember, c.Plan, c.PromoCode,
+		c.PromoCodeUsage, c.Proxy, c.RedeemCode, c.Setting, c.UsageCleanupTask,
+		c.UsageLog, c.User, c.UserAllowedGroup, c.UserAttributeDefinition,
+		c.UserAttributeValue, c.UserRecoveryCode, c.UserSubscription,
+		c.WebAuthnCredential,
 	} {
 		n.Use(hooks...)
 	}
@@ -308,10 +353,13 @@ func (c *Client) Use(hooks ...Hook) {
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	for _, n := range []interface{ Intercept(...Interceptor) }{
-		c.APIKey, c.Account, c.AccountGroup, c.AdminActionLog, c.Group, c.Invitation,
-		c.InviteLog, c.Plan, c.PromoCode, c.PromoCodeUsage, c.Proxy, c.RedeemCode,
-		c.Setting, c.UsageCleanupTask, c.UsageLog, c.User, c.UserAllowedGroup,
-		c.UserAttributeDefinition, c.UserAttributeValue, c.UserSubscription,
+		c.APIKey, c.Account, c.AccountGroup, c.AdminActionLog, c.CommissionWithdrawal,
+		c.Group, c.Invitation, c.InviteCommission, c.InviteLog, c.Organization,
+		c.OrganizationInvitation, c.OrganizationMember, c.Plan, c.PromoCode,
+		c.PromoCodeUsage, c.Proxy, c.RedeemCode, c.Setting, c.UsageCleanupTask,
+		c.UsageLog, c.User, c.UserAllowedGroup, c.UserAttributeDefinition,
+		c.UserAttributeValue, c.UserRecoveryCode, c.UserSubscription,
+		c.WebAuthnCredential,
 	} {
 		n.Intercept(interceptors...)
 	}
@@ -328,12 +376,22 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.AccountGroup.mutate(ctx, m)
 	case *AdminActionLogMutation:
 		return c.AdminActionLog.mutate(ctx, m)
+	case *CommissionWithdrawalMutation:
+		return c.CommissionWithdrawal.mutate(ctx, m)
 	case *GroupMutation:
 		return c.Group.mutate(ctx, m)
 	case *InvitationMutation:
 		return c.Invitation.mutate(ctx, m)
+	case *InviteCommissionMutation:
+		return c.InviteCommission.mutate(ctx, m)
 	case *InviteLogMutation:
 		return c.InviteLog.mutate(ctx, m)
+	case *OrganizationMutation:
+		return c.Organization.mutate(ctx, m)
+	case *OrganizationInvitationMutation:
+		return c.OrganizationInvitation.mutate(ctx, m)
+	case *OrganizationMemberMutation:
+		return c.OrganizationMember.mutate(ctx, m)
 	case *PlanMutation:
 		return c.Plan.mutate(ctx, m)
 	case *PromoCodeMutation:
@@ -358,8 +416,12 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.UserAttributeDefinition.mutate(ctx, m)
 	case *UserAttributeValueMutation:
 		return c.UserAttributeValue.mutate(ctx, m)
+	case *UserRecoveryCodeMutation:
+		return c.UserRecoveryCode.mutate(ctx, m)
 	case *UserSubscriptionMutation:
 		return c.UserSubscription.mutate(ctx, m)
+	case *WebAuthnCredentialMutation:
+		return c.WebAuthnCredential.mutate(ctx, m)
 	default:
 		return nil, fmt.Errorf("ent: unknown mutation type %T", m)
 	}
@@ -505,6 +567,22 @@ func (c *APIKeyClient) QueryGroup(_m *APIKey) *GroupQuery {
 	return query
 }
 
+// QueryOrganization queries the organization edge of a APIKey.
+func (c *APIKeyClient) QueryOrganization(_m *APIKey) *OrganizationQuery {
+	query := (&OrganizationClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(apikey.Table, apikey.FieldID, id),
+			sqlgraph.To(organization.Table, organization.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, apikey.OrganizationTable, apikey.OrganizationColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
 // QueryUsageLogs queries the usage_logs edge of a APIKey.
 func (c *APIKeyClient) QueryUsageLogs(_m *APIKey) *UsageLogQuery {
 	query := (&UsageLogClient{config: c.config}).Query()
@@ -1012,6 +1090,139 @@ func (c *AdminActionLogClient) mutate(ctx context.Context, m *AdminActionLogMuta
 	}
 }
 
+// CommissionWithdrawalClient is a client for the CommissionWithdrawal schema.
+type CommissionWithdrawalClient struct {
+	config
+}
+
+// NewCommissionWithdrawalClient returns a client for the CommissionWithdrawal from the given config.
+func NewCommissionWithdrawalClient(c config) *CommissionWithdrawalClient {
+	return &CommissionWithdrawalClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `commissionwithdrawal.Hooks(f(g(h())))`.
+func (c *CommissionWithdrawalClient) Use(hooks ...Hook) {
+	c.hooks.CommissionWithdrawal = append(c.hooks.CommissionWithdrawal, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `commissionwithdrawal.Intercept(f(g(h())))`.
+func (c *CommissionWithdrawalClient) Intercept(interceptors ...Interceptor) {
+	c.inters.CommissionWithdrawal = append(c.inters.CommissionWithdrawal, interceptors...)
+}
+
+// Create returns a builder for creating a CommissionWithdrawal entity.
+func (c *CommissionWithdrawalClient) Create() *CommissionWithdrawalCreate {
+	mutation := newCommissionWithdrawalMutation(c.config, OpCreate)
+	return &CommissionWithdrawalCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of CommissionWithdrawal entities.
+func (c *CommissionWithdrawalClient) CreateBulk(builders ...*CommissionWithdrawalCreate) *CommissionWithdrawalCreateBulk {
+	return &CommissionWithdrawalCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *CommissionWithdrawalClient) MapCreateBulk(slice any, setFunc func(*CommissionWithdrawalCreate, int)) *CommissionWithdrawalCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &CommissionWithdrawalCreateBulk{err: fmt.Errorf("calling to CommissionWithdrawalClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*CommissionWithdrawalCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &CommissionWithdrawalCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for CommissionWithdrawal.
+func (c *CommissionWithdrawalClient) Update() *CommissionWithdrawalUpdate {
+	mutation := newCommissionWithdrawalMutation(c.config, OpUpdate)
+	return &CommissionWithdrawalUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *CommissionWithdrawalClient) UpdateOne(_m *CommissionWithdrawal) *CommissionWithdrawalUpdateOne {
+	mutation := newCommissionWithdrawalMutation(c.config, OpUpdateOne, withCommissionWithdrawal(_m))
+	return &CommissionWithdrawalUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *CommissionWithdrawalClient) UpdateOneID(id int64) *CommissionWithdrawalUpdateOne {
+	mutation := newCommissionWithdrawalMutation(c.config, OpUpdateOne, withCommissionWithdrawalID(id))
+	return &CommissionWithdrawalUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for CommissionWithdrawal.
+func (c *CommissionWithdrawalClient) Delete() *CommissionWithdrawalDelete {
+	mutation := newCommissionWithdrawalMutation(c.config, OpDelete)
+	return &CommissionWithdrawalDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *CommissionWithdrawalClient) DeleteOne(_m *CommissionWithdrawal) *CommissionWithdrawalDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *CommissionWithdrawalClient) DeleteOneID(id int64) *CommissionWithdrawalDeleteOne {
+	builder := c.Delete().Where(commissionwithdrawal.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &CommissionWithdrawalDeleteOne{builder}
+}
+
+// Query returns a query builder for CommissionWithdrawal.
+func (c *CommissionWithdrawalClient) Query() *CommissionWithdrawalQuery {
+	return &CommissionWithdrawalQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeCommissionWithdrawal},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a CommissionWithdrawal entity by its id.
+func (c *CommissionWithdrawalClient) Get(ctx context.Context, id int64) (*CommissionWithdrawal, error) {
+	return c.Query().Where(commissionwithdrawal.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *CommissionWithdrawalClient) GetX(ctx context.Context, id int64) *CommissionWithdrawal {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *CommissionWithdrawalClient) Hooks() []Hook {
+	return c.hooks.CommissionWithdrawal
+}
+
+// Interceptors returns the client interceptors.
+func (c *CommissionWithdrawalClient) Interceptors() []Interceptor {
+	return c.inters.CommissionWithdrawal
+}
+
+func (c *CommissionWithdrawalClient) mutate(ctx context.Context, m *CommissionWithdrawalMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&CommissionWithdrawalCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&CommissionWithdrawalUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&CommissionWithdrawalUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&CommissionWithdrawalDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown CommissionWithdrawal mutation op: %q", m.Op())
+	}
+}
+
 // GroupClient is a client for the Group schema.
 type GroupClient struct {
 	config
@@ -1472,6 +1683,139 @@ func (c *InvitationClient) mutate(ctx context.Context, m *InvitationMutation) (V
 	}
 }
 
+// InviteCommissionClient is a client for the InviteCommission schema.
+type InviteCommissionClient struct {
+	config
+}
+
+// NewInviteCommissionClient returns a client for the InviteCommission from the given config.
+func NewInviteCommissionClient(c config) *InviteCommissionClient {
+	return &InviteCommissionClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `invitecommission.Hooks(f(g(h())))`.
+func (c *InviteCommissionClient) Use(hooks ...Hook) {
+	c.hooks.InviteCommission = append(c.hooks.InviteCommission, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `invitecommission.Intercept(f(g(h())))`.
+func (c *InviteCommissionClient) Intercept(interceptors ...Interceptor) {
+	c.inters.InviteCommission = append(c.inters.InviteCommission, interceptors...)
+}
+
+// Create returns a builder for creating a InviteCommission entity.
+func (c *InviteCommissionClient) Create() *InviteCommissionCreate {
+	mutation := newInviteCommissionMutation(c.config, OpCreate)
+	return &InviteCommissionCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of InviteCommission entities.
+func (c *InviteCommissionClient) CreateBulk(builders ...*InviteCommissionCreate) *InviteCommissionCreateBulk {
+	return &InviteCommissionCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *InviteCommissionClient) MapCreateBulk(slice any, setFunc func(*InviteCommissionCreate, int)) *InviteCommissionCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &InviteCommissionCreateBulk{err: fmt.Errorf("calling to InviteCommissionClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*InviteCommissionCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &InviteCommissionCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for InviteCommission.
+func (c *InviteCommissionClient) Update() *InviteCommissionUpdate {
+	mutation := newInviteCommissionMutation(c.config, OpUpdate)
+	return &InviteCommissionUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *InviteCommissionClient) UpdateOne(_m *InviteCommission) *InviteCommissionUpdateOne {
+	mutation := newInviteCommissionMutation(c.config, OpUpdateOne, withInviteCommission(_m))
+	return &InviteCommissionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *InviteCommissionClient) UpdateOneID(id int64) *InviteCommissionUpdateOne {
+	mutation := newInviteCommissionMutation(c.config, OpUpdateOne, withInviteCommissionID(id))
+	return &InviteCommissionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for InviteCommission.
+func (c *InviteCommissionClient) Delete() *InviteCommissionDelete {
+	mutation := newInviteCommissionMutation(c.config, OpDelete)
+	return &InviteCommissionDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *InviteCommissionClient) DeleteOne(_m *InviteCommission) *InviteCommissionDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *InviteCommissionClient) DeleteOneID(id int64) *InviteCommissionDeleteOne {
+	builder := c.Delete().Where(invitecommission.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &InviteCommissionDeleteOne{builder}
+}
+
+// Query returns a query builder for InviteCommission.
+func (c *InviteCommissionClient) Query() *InviteCommissionQuery {
+	return &InviteCommissionQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeInviteCommission},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a InviteCommission entity by its id.
+func (c *InviteCommissionClient) Get(ctx context.Context, id int64) (*InviteCommission, error) {
+	return c.Query().Where(invitecommission.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *InviteCommissionClient) GetX(ctx context.Context, id int64) *InviteCommission {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *InviteCommissionClient) Hooks() []Hook {
+	return c.hooks.InviteCommission
+}
+
+// Interceptors returns the client interceptors.
+func (c *InviteCommissionClient) Interceptors() []Interceptor {
+	return c.inters.InviteCommission
+}
+
+func (c *InviteCommissionClient) mutate(ctx context.Context, m *InviteCommissionMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&InviteCommissionCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&InviteCommissionUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&InviteCommissionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&InviteCommissionDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown InviteCommission mutation op: %q", m.Op())
+	}
+}
+
 // InviteLogClient is a client for the InviteLog schema.
 type InviteLogClient struct {
 	config
@@ -1593,34 +1937,531 @@ func (c *InviteLogClient) QueryInvite(_m *InviteLog) *InvitationQuery {
 		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
 		return fromV, nil
 	}
-	return query
+	return query
+}
+
+// QueryInviter queries the inviter edge of a InviteLog.
+func (c *InviteLogClient) QueryInviter(_m *InviteLog) *UserQuery {
+	query := (&UserClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(invitelog.Table, invitelog.FieldID, id),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, invitelog.InviterTable, invitelog.InviterColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QueryInvitee queries the invitee edge of a InviteLog.
+func (c *InviteLogClient) QueryInvitee(_m *InviteLog) *UserQuery {
+	query := (&UserClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(invitelog.Table, invitelog.FieldID, id),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, invitelog.InviteeTable, invitelog.InviteeColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QueryAdmin queries the admin edge of a InviteLog.
+func (c *InviteLogClient) QueryAdmin(_m *InviteLog) *UserQuery {
+	query := (&UserClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(invitelog.Table, invitelog.FieldID, id),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, invitelog.AdminTable, invitelog.AdminColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *InviteLogClient) Hooks() []Hook {
+	return c.hooks.InviteLog
+}
+
+// Interceptors returns the client interceptors.
+func (c *InviteLogClient) Interceptors() []Interceptor {
+	return c.inters.InviteLog
+}
+
+func (c *InviteLogClient) mutate(ctx context.Context, m *InviteLogMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&InviteLogCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&InviteLogUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&InviteLogUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&InviteLogDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown InviteLog mutation op: %q", m.Op())
+	}
+}
+
+// OrganizationClient is a client for the Organization schema.
+type OrganizationClient struct {
+	config
+}
+
+// NewOrganizationClient returns a client for the Organization from the given config.
+func NewOrganizationClient(c config) *OrganizationClient {
+	return &OrganizationClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `organization.Hooks(f(g(h())))`.
+func (c *OrganizationClient) Use(hooks ...Hook) {
+	c.hooks.Organization = append(c.hooks.Organization, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `organization.Intercept(f(g(h())))`.
+func (c *OrganizationClient) Intercept(interceptors ...Interceptor) {
+	c.inters.Organization = append(c.inters.Organization, interceptors...)
+}
+
+// Create returns a builder for creating a Organization entity.
+func (c *OrganizationClient) Create() *OrganizationCreate {
+	mutation := newOrganizationMutation(c.config, OpCreate)
+	return &OrganizationCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of Organization entities.
+func (c *OrganizationClient) CreateBulk(builders ...*OrganizationCreate) *OrganizationCreateBulk {
+	return &OrganizationCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *OrganizationClient) MapCreateBulk(slice any, setFunc func(*OrganizationCreate, int)) *OrganizationCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &OrganizationCreateBulk{err: fmt.Errorf("calling to OrganizationClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*OrganizationCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &OrganizationCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for Organization.
+func (c *OrganizationClient) Update() *OrganizationUpdate {
+	mutation := newOrganizationMutation(c.config, OpUpdate)
+	return &OrganizationUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *OrganizationClient) UpdateOne(_m *Organization) *OrganizationUpdateOne {
+	mutation := newOrganizationMutation(c.config, OpUpdateOne, withOrganization(_m))
+	return &OrganizationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *OrganizationClient) UpdateOneID(id int64) *OrganizationUpdateOne {
+	mutation := newOrganizationMutation(c.config, OpUpdateOne, withOrganizationID(id))
+	return &OrganizationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for Organization.
+func (c *OrganizationClient) Delete() *OrganizationDelete {
+	mutation := newOrganizationMutation(c.config, OpDelete)
+	return &OrganizationDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *OrganizationClient) DeleteOne(_m *Organization) *OrganizationDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *OrganizationClient) DeleteOneID(id int64) *OrganizationDeleteOne {
+	builder := c.Delete().Where(organization.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &OrganizationDeleteOne{builder}
+}
+
+// Query returns a query builder for Organization.
+func (c *OrganizationClient) Query() *OrganizationQuery {
+	return &OrganizationQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeOrganization},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a Organization entity by its id.
+func (c *OrganizationClient) Get(ctx context.Context, id int64) (*Organization, error) {
+	return c.Query().Where(organization.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *OrganizationClient) GetX(ctx context.Context, id int64) *Organization {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryMembers queries the members edge of a Organization.
+func (c *OrganizationClient) QueryMembers(_m *Organization) *OrganizationMemberQuery {
+	query := (&OrganizationMemberClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(organization.Table, organization.FieldID, id),
+			sqlgraph.To(organizationmember.Table, organizationmember.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, organization.MembersTable, organization.MembersColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QueryInvitations queries the invitations edge of a Organization.
+func (c *OrganizationClient) QueryInvitations(_m *Organization) *OrganizationInvitationQuery {
+	query := (&OrganizationInvitationClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(organization.Table, organization.FieldID, id),
+			sqlgraph.To(organizationinvitation.Table, organizationinvitation.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, organization.InvitationsTable, organization.InvitationsColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QueryAPIKeys queries the api_keys edge of a Organization.
+func (c *OrganizationClient) QueryAPIKeys(_m *Organization) *APIKeyQuery {
+	query := (&APIKeyClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(organization.Table, organization.FieldID, id),
+			sqlgraph.To(apikey.Table, apikey.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, organization.APIKeysTable, organization.APIKeysColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *OrganizationClient) Hooks() []Hook {
+	hooks := c.hooks.Organization
+	return append(hooks[:len(hooks):len(hooks)], organization.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *OrganizationClient) Interceptors() []Interceptor {
+	inters := c.inters.Organization
+	return append(inters[:len(inters):len(inters)], organization.Interceptors[:]...)
+}
+
+func (c *OrganizationClient) mutate(ctx context.Context, m *OrganizationMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&OrganizationCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&OrganizationUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&OrganizationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&OrganizationDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown Organization mutation op: %q", m.Op())
+	}
+}
+
+// OrganizationInvitationClient is a client for the OrganizationInvitation schema.
+type OrganizationInvitationClient struct {
+	config
+}
+
+// NewOrganizationInvitationClient returns a client for the OrganizationInvitation from the given config.
+func NewOrganizationInvitationClient(c config) *OrganizationInvitationClient {
+	return &OrganizationInvitationClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `organizationinvitation.Hooks(f(g(h())))`.
+func (c *OrganizationInvitationClient) Use(hooks ...Hook) {
+	c.hooks.OrganizationInvitation = append(c.hooks.OrganizationInvitation, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `organizationinvitation.Intercept(f(g(h())))`.
+func (c *OrganizationInvitationClient) Intercept(interceptors ...Interceptor) {
+	c.inters.OrganizationInvitation = append(c.inters.OrganizationInvitation, interceptors...)
+}
+
+// Create returns a builder for creating a OrganizationInvitation entity.
+func (c *OrganizationInvitationClient) Create() *OrganizationInvitationCreate {
+	mutation := newOrganizationInvitationMutation(c.config, OpCreate)
+	return &OrganizationInvitationCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of OrganizationInvitation entities.
+func (c *OrganizationInvitationClient) CreateBulk(builders ...*OrganizationInvitationCreate) *OrganizationInvitationCreateBulk {
+	return &OrganizationInvitationCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *OrganizationInvitationClient) MapCreateBulk(slice any, setFunc func(*OrganizationInvitationCreate, int)) *OrganizationInvitationCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &OrganizationInvitationCreateBulk{err: fmt.Errorf("calling to OrganizationInvitationClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*OrganizationInvitationCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &OrganizationInvitationCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for OrganizationInvitation.
+func (c *OrganizationInvitationClient) Update() *OrganizationInvitationUpdate {
+	mutation := newOrganizationInvitationMutation(c.config, OpUpdate)
+	return &OrganizationInvitationUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *OrganizationInvitationClient) UpdateOne(_m *OrganizationInvitation) *OrganizationInvitationUpdateOne {
+	mutation := newOrganizationInvitationMutation(c.config, OpUpdateOne, withOrganizationInvitation(_m))
+	return &OrganizationInvitationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *OrganizationInvitationClient) UpdateOneID(id int64) *OrganizationInvitationUpdateOne {
+	mutation := newOrganizationInvitationMutation(c.config, OpUpdateOne, withOrganizationInvitationID(id))
+	return &OrganizationInvitationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for OrganizationInvitation.
+func (c *OrganizationInvitationClient) Delete() *OrganizationInvitationDelete {
+	mutation := newOrganizationInvitationMutation(c.config, OpDelete)
+	return &OrganizationInvitationDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *OrganizationInvitationClient) DeleteOne(_m *OrganizationInvitation) *OrganizationInvitationDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *OrganizationInvitationClient) DeleteOneID(id int64) *OrganizationInvitationDeleteOne {
+	builder := c.Delete().Where(organizationinvitation.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &OrganizationInvitationDeleteOne{builder}
+}
+
+// Query returns a query builder for OrganizationInvitation.
+func (c *OrganizationInvitationClient) Query() *OrganizationInvitationQuery {
+	return &OrganizationInvitationQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeOrganizationInvitation},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a OrganizationInvitation entity by its id.
+func (c *OrganizationInvitationClient) Get(ctx context.Context, id int64) (*OrganizationInvitation, error) {
+	return c.Query().Where(organizationinvitation.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *OrganizationInvitationClient) GetX(ctx context.Context, id int64) *OrganizationInvitation {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryOrganization queries the organization edge of a OrganizationInvitation.
+func (c *OrganizationInvitationClient) QueryOrganization(_m *OrganizationInvitation) *OrganizationQuery {
+	query := (&OrganizationClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(organizationinvitation.Table, organizationinvitation.FieldID, id),
+			sqlgraph.To(organization.Table, organization.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, organizationinvitation.OrganizationTable, organizationinvitation.OrganizationColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *OrganizationInvitationClient) Hooks() []Hook {
+	return c.hooks.OrganizationInvitation
+}
+
+// Interceptors returns the client interceptors.
+func (c *OrganizationInvitationClient) Interceptors() []Interceptor {
+	return c.inters.OrganizationInvitation
+}
+
+func (c *OrganizationInvitationClient) mutate(ctx context.Context, m *OrganizationInvitationMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&OrganizationInvitationCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&OrganizationInvitationUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&OrganizationInvitationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&OrganizationInvitationDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown OrganizationInvitation mutation op: %q", m.Op())
+	}
+}
+
+// OrganizationMemberClient is a client for the OrganizationMember schema.
+type OrganizationMemberClient struct {
+	config
+}
+
+// NewOrganizationMemberClient returns a client for the OrganizationMember from the given config.
+func NewOrganizationMemberClient(c config) *OrganizationMemberClient {
+	return &OrganizationMemberClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `organizationmember.Hooks(f(g(h())))`.
+func (c *OrganizationMemberClient) Use(hooks ...Hook) {
+	c.hooks.OrganizationMember = append(c.hooks.OrganizationMember, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `organizationmember.Intercept(f(g(h())))`.
+func (c *OrganizationMemberClient) Intercept(interceptors ...Interceptor) {
+	c.inters.OrganizationMember = append(c.inters.OrganizationMember, interceptors...)
+}
+
+// Create returns a builder for creating a OrganizationMember entity.
+func (c *OrganizationMemberClient) Create() *OrganizationMemberCreate {
+	mutation := newOrganizationMemberMutation(c.config, OpCreate)
+	return &OrganizationMemberCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of OrganizationMember entities.
+func (c *OrganizationMemberClient) CreateBulk(builders ...*OrganizationMemberCreate) *OrganizationMemberCreateBulk {
+	return &OrganizationMemberCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *OrganizationMemberClient) MapCreateBulk(slice any, setFunc func(*OrganizationMemberCreate, int)) *OrganizationMemberCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &OrganizationMemberCreateBulk{err: fmt.Errorf("calling to OrganizationMemberClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*OrganizationMemberCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &OrganizationMemberCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for OrganizationMember.
+func (c *OrganizationMemberClient) Update() *OrganizationMemberUpdate {
+	mutation := newOrganizationMemberMutation(c.config, OpUpdate)
+	return &OrganizationMemberUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *OrganizationMemberClient) UpdateOne(_m *OrganizationMember) *OrganizationMemberUpdateOne {
+	mutation := newOrganizationMemberMutation(c.config, OpUpdateOne, withOrganizationMember(_m))
+	return &OrganizationMemberUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *OrganizationMemberClient) UpdateOneID(id int64) *OrganizationMemberUpdateOne {
+	mutation := newOrganizationMemberMutation(c.config, OpUpdateOne, withOrganizationMemberID(id))
+	return &OrganizationMemberUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for OrganizationMember.
+func (c *OrganizationMemberClient) Delete() *OrganizationMemberDelete {
+	mutation := newOrganizationMemberMutation(c.config, OpDelete)
+	return &OrganizationMemberDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *OrganizationMemberClient) DeleteOne(_m *OrganizationMember) *OrganizationMemberDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *OrganizationMemberClient) DeleteOneID(id int64) *OrganizationMemberDeleteOne {
+	builder := c.Delete().Where(organizationmember.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &OrganizationMemberDeleteOne{builder}
+}
+
+// Query returns a query builder for OrganizationMember.
+func (c *OrganizationMemberClient) Query() *OrganizationMemberQuery {
+	return &OrganizationMemberQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeOrganizationMember},
+		inters: c.Interceptors(),
+	}
 }
 
-// QueryInviter queries the inviter edge of a InviteLog.
-func (c *InviteLogClient) QueryInviter(_m *InviteLog) *UserQuery {
-	query := (&UserClient{config: c.config}).Query()
-	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
-		id := _m.ID
-		step := sqlgraph.NewStep(
-			sqlgraph.From(invitelog.Table, invitelog.FieldID, id),
-			sqlgraph.To(user.Table, user.FieldID),
-			sqlgraph.Edge(sqlgraph.M2O, true, invitelog.InviterTable, invitelog.InviterColumn),
-		)
-		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
-		return fromV, nil
+// Get returns a OrganizationMember entity by its id.
+func (c *OrganizationMemberClient) Get(ctx context.Context, id int64) (*OrganizationMember, error) {
+	return c.Query().Where(organizationmember.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *OrganizationMemberClient) GetX(ctx context.Context, id int64) *OrganizationMember {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
 	}
-	return query
+	return obj
 }
 
-// QueryInvitee queries the invitee edge of a InviteLog.
-func (c *InviteLogClient) QueryInvitee(_m *InviteLog) *UserQuery {
-	query := (&UserClient{config: c.config}).Query()
+// QueryOrganization queries the organization edge of a OrganizationMember.
+func (c *OrganizationMemberClient) QueryOrganization(_m *OrganizationMember) *OrganizationQuery {
+	query := (&OrganizationClient{config: c.config}).Query()
 	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
 		id := _m.ID
 		step := sqlgraph.NewStep(
-			sqlgraph.From(invitelog.Table, invitelog.FieldID, id),
-			sqlgraph.To(user.Table, user.FieldID),
-			sqlgraph.Edge(sqlgraph.M2O, true, invitelog.InviteeTable, invitelog.InviteeColumn),
+			sqlgraph.From(organizationmember.Table, organizationmember.FieldID, id),
+			sqlgraph.To(organization.Table, organization.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, organizationmember.OrganizationTable, organizationmember.OrganizationColumn),
 		)
 		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
 		return fromV, nil
@@ -1628,15 +2469,15 @@ func (c *InviteLogClient) QueryInvitee(_m *InviteLog) *UserQuery {
 	return query
 }
 
-// QueryAdmin queries the admin edge of a InviteLog.
-func (c *InviteLogClient) QueryAdmin(_m *InviteLog) *UserQuery {
+// QueryUser queries the user edge of a OrganizationMember.
+func (c *OrganizationMemberClient) QueryUser(_m *OrganizationMember) *UserQuery {
 	query := (&UserClient{config: c.config}).Query()
 	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
 		id := _m.ID
 		step := sqlgraph.NewStep(
-			sqlgraph.From(invitelog.Table, invitelog.FieldID, id),
+			sqlgraph.From(organizationmember.Table, organizationmember.FieldID, id),
 			sqlgraph.To(user.Table, user.FieldID),
-			sqlgraph.Edge(sqlgraph.M2O, true, invitelog.AdminTable, invitelog.AdminColumn),
+			sqlgraph.Edge(sqlgraph.M2O, true, organizationmember.UserTable, organizationmember.UserColumn),
 		)
 		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
 		return fromV, nil
@@ -1645,27 +2486,27 @@ func (c *InviteLogClient) QueryAdmin(_m *InviteLog) *UserQuery {
 }
 
 // Hooks returns the client hooks.
-func (c *InviteLogClient) Hooks() []Hook {
-	return c.hooks.InviteLog
+func (c *OrganizationMemberClient) Hooks() []Hook {
+	return c.hooks.OrganizationMember
 }
 
 // Interceptors returns the client interceptors.
-func (c *InviteLogClient) Interceptors() []Interceptor {
-	return c.inters.InviteLog
+func (c *OrganizationMemberClient) Interceptors() []Interceptor {
+	return c.inters.OrganizationMember
 }
 
-func (c *InviteLogClient) mutate(ctx context.Context, m *InviteLogMutation) (Value, error) {
+func (c *OrganizationMemberClient) mutate(ctx context.Context, m *OrganizationMemberMutation) (Value, error) {
 	switch m.Op() {
 	case OpCreate:
-		return (&InviteLogCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+		return (&OrganizationMemberCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
 	case OpUpdate:
-		return (&InviteLogUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+		return (&OrganizationMemberUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
 	case OpUpdateOne:
-		return (&InviteLogUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+		return (&OrganizationMemberUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
 	case OpDelete, OpDeleteOne:
-		return (&InviteLogDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+		return (&OrganizationMemberDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
 	default:
-		return nil, fmt.Errorf("ent: unknown InviteLog mutation op: %q", m.Op())
+		return nil, fmt.Errorf("ent: unknown OrganizationMember mutation op: %q", m.Op())
 	}
 }
 
@@ -3259,6 +4100,54 @@ func (c *UserClient) QueryAdminActionLogs(_m *User) *AdminActionLogQuery {
 	return query
 }
 
+// QueryOrganizationMemberships queries the organization_memberships edge of a User.
+func (c *UserClient) QueryOrganizationMemberships(_m *User) *OrganizationMemberQuery {
+	query := (&OrganizationMemberClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(user.Table, user.FieldID, id),
+			sqlgraph.To(organizationmember.Table, organizationmember.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, user.OrganizationMembershipsTable, user.OrganizationMembershipsColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QueryWebauthnCredentials queries the webauthn_credentials edge of a User.
+func (c *UserClient) QueryWebauthnCredentials(_m *User) *WebAuthnCredentialQuery {
+	query := (&WebAuthnCredentialClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(user.Table, user.FieldID, id),
+			sqlgraph.To(webauthncredential.Table, webauthncredential.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, user.WebauthnCredentialsTable, user.WebauthnCredentialsColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QueryRecoveryCodes queries the recovery_codes edge of a User.
+func (c *UserClient) QueryRecoveryCodes(_m *User) *UserRecoveryCodeQuery {
+	query := (&UserRecoveryCodeClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(user.Table, user.FieldID, id),
+			sqlgraph.To(userrecoverycode.Table, userrecoverycode.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, user.RecoveryCodesTable, user.RecoveryCodesColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
 // QueryUserAllowedGroups queries the user_allowed_groups edge of a User.
 func (c *UserClient) QueryUserAllowedGroups(_m *User) *UserAllowedGroupQuery {
 	query := (&UserAllowedGroupClient{config: c.config}).Query()
@@ -3734,6 +4623,155 @@ func (c *UserAttributeValueClient) mutate(ctx context.Context, m *UserAttributeV
 	}
 }
 
+// UserRecoveryCodeClient is a client for the UserRecoveryCode schema.
+type UserRecoveryCodeClient struct {
+	config
+}
+
+// NewUserRecoveryCodeClient returns a client for the UserRecoveryCode from the given config.
+func NewUserRecoveryCodeClient(c config) *UserRecoveryCodeClient {
+	return &UserRecoveryCodeClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `userrecoverycode.Hooks(f(g(h())))`.
+func (c *UserRecoveryCodeClient) Use(hooks ...Hook) {
+	c.hooks.UserRecoveryCode = append(c.hooks.UserRecoveryCode, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `userrecoverycode.Intercept(f(g(h())))`.
+func (c *UserRecoveryCodeClient) Intercept(interceptors ...Interceptor) {
+	c.inters.UserRecoveryCode = append(c.inters.UserRecoveryCode, interceptors...)
+}
+
+// Create returns a builder for creating a UserRecoveryCode entity.
+func (c *UserRecoveryCodeClient) Create() *UserRecoveryCodeCreate {
+	mutation := newUserRecoveryCodeMutation(c.config, OpCreate)
+	return &UserRecoveryCodeCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of UserRecoveryCode entities.
+func (c *UserRecoveryCodeClient) CreateBulk(builders ...*UserRecoveryCodeCreate) *UserRecoveryCodeCreateBulk {
+	return &UserRecoveryCodeCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *UserRecoveryCodeClient) MapCreateBulk(slice any, setFunc func(*UserRecoveryCodeCreate, int)) *UserRecoveryCodeCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &UserRecoveryCodeCreateBulk{err: fmt.Errorf("calling to UserRecoveryCodeClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*UserRecoveryCodeCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &UserRecoveryCodeCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for UserRecoveryCode.
+func (c *UserRecoveryCodeClient) Update() *UserRecoveryCodeUpdate {
+	mutation := newUserRecoveryCodeMutation(c.config, OpUpdate)
+	return &UserRecoveryCodeUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *UserRecoveryCodeClient) UpdateOne(_m *UserRecoveryCode) *UserRecoveryCodeUpdateOne {
+	mutation := newUserRecoveryCodeMutation(c.config, OpUpdateOne, withUserRecoveryCode(_m))
+	return &UserRecoveryCodeUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *UserRecoveryCodeClient) UpdateOneID(id int64) *UserRecoveryCodeUpdateOne {
+	mutation := newUserRecoveryCodeMutation(c.config, OpUpdateOne, withUserRecoveryCodeID(id))
+	return &UserRecoveryCodeUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for UserRecoveryCode.
+func (c *UserRecoveryCodeClient) Delete() *UserRecoveryCodeDelete {
+	mutation := newUserRecoveryCodeMutation(c.config, OpDelete)
+	return &UserRecoveryCodeDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *UserRecoveryCodeClient) DeleteOne(_m *UserRecoveryCode) *UserRecoveryCodeDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *UserRecoveryCodeClient) DeleteOneID(id int64) *UserRecoveryCodeDeleteOne {
+	builder := c.Delete().Where(userrecoverycode.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &UserRecoveryCodeDeleteOne{builder}
+}
+
+// Query returns a query builder for UserRecoveryCode.
+func (c *UserRecoveryCodeClient) Query() *UserRecoveryCodeQuery {
+	return &UserRecoveryCodeQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeUserRecoveryCode},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a UserRecoveryCode entity by its id.
+func (c *UserRecoveryCodeClient) Get(ctx context.Context, id int64) (*UserRecoveryCode, error) {
+	return c.Query().Where(userrecoverycode.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *UserRecoveryCodeClient) GetX(ctx context.Context, id int64) *UserRecoveryCode {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryUser queries the user edge of a UserRecoveryCode.
+func (c *UserRecoveryCodeClient) QueryUser(_m *UserRecoveryCode) *UserQuery {
+	query := (&UserClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(userrecoverycode.Table, userrecoverycode.FieldID, id),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, userrecoverycode.UserTable, userrecoverycode.UserColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *UserRecoveryCodeClient) Hooks() []Hook {
+	return c.hooks.UserRecoveryCode
+}
+
+// Interceptors returns the client interceptors.
+func (c *UserRecoveryCodeClient) Interceptors() []Interceptor {
+	return c.inters.UserRecoveryCode
+}
+
+func (c *UserRecoveryCodeClient) mutate(ctx context.Context, m *UserRecoveryCodeMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&UserRecoveryCodeCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&UserRecoveryCodeUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&UserRecoveryCodeUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&UserRecoveryCodeDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown UserRecoveryCode mutation op: %q", m.Op())
+	}
+}
+
 // UserSubscriptionClient is a client for the UserSubscription schema.
 type UserSubscriptionClient struct {
 	config
@@ -3933,19 +4971,172 @@ func (c *UserSubscriptionClient) mutate(ctx context.Context, m *UserSubscription
 	}
 }
 
+// WebAuthnCredentialClient is a client for the WebAuthnCredential schema.
+type WebAuthnCredentialClient struct {
+	config
+}
+
+// NewWebAuthnCredentialClient returns a client for the WebAuthnCredential from the given config.
+func NewWebAuthnCredentialClient(c config) *WebAuthnCredentialClient {
+	return &WebAuthnCredentialClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `webauthncredential.Hooks(f(g(h())))`.
+func (c *WebAuthnCredentialClient) Use(hooks ...Hook) {
+	c.hooks.WebAuthnCredential = append(c.hooks.WebAuthnCredential, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `webauthncredential.Intercept(f(g(h())))`.
+func (c *WebAuthnCredentialClient) Intercept(interceptors ...Interceptor) {
+	c.inters.WebAuthnCredential = append(c.inters.WebAuthnCredential, interceptors...)
+}
+
+// Create returns a builder for creating a WebAuthnCredential entity.
+func (c *WebAuthnCredentialClient) Create() *WebAuthnCredentialCreate {
+	mutation := newWebAuthnCredentialMutation(c.config, OpCreate)
+	return &WebAuthnCredentialCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of WebAuthnCredential entities.
+func (c *WebAuthnCredentialClient) CreateBulk(builders ...*WebAuthnCredentialCreate) *WebAuthnCredentialCreateBulk {
+	return &WebAuthnCredentialCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *WebAuthnCredentialClient) MapCreateBulk(slice any, setFunc func(*WebAuthnCredentialCreate, int)) *WebAuthnCredentialCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &WebAuthnCredentialCreateBulk{err: fmt.Errorf("calling to WebAuthnCredentialClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*WebAuthnCredentialCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &WebAuthnCredentialCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for WebAuthnCredential.
+func (c *WebAuthnCredentialClient) Update() *WebAuthnCredentialUpdate {
+	mutation := newWebAuthnCredentialMutation(c.config, OpUpdate)
+	return &WebAuthnCredentialUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *WebAuthnCredentialClient) UpdateOne(_m *WebAuthnCredential) *WebAuthnCredentialUpdateOne {
+	mutation := newWebAuthnCredentialMutation(c.config, OpUpdateOne, withWebAuthnCredential(_m))
+	return &WebAuthnCredentialUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *WebAuthnCredentialClient) UpdateOneID(id int64) *WebAuthnCredentialUpdateOne {
+	mutation := newWebAuthnCredentialMutation(c.config, OpUpdateOne, withWebAuthnCredentialID(id))
+	return &WebAuthnCredentialUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for WebAuthnCredential.
+func (c *WebAuthnCredentialClient) Delete() *WebAuthnCredentialDelete {
+	mutation := newWebAuthnCredentialMutation(c.config, OpDelete)
+	return &WebAuthnCredentialDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *WebAuthnCredentialClient) DeleteOne(_m *WebAuthnCredential) *WebAuthnCredentialDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *WebAuthnCredentialClient) DeleteOneID(id int64) *WebAuthnCredentialDeleteOne {
+	builder := c.Delete().Where(webauthncredential.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &WebAuthnCredentialDeleteOne{builder}
+}
+
+// Query returns a query builder for WebAuthnCredential.
+func (c *WebAuthnCredentialClient) Query() *WebAuthnCredentialQuery {
+	return &WebAuthnCredentialQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeWebAuthnCredential},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a WebAuthnCredential entity by its id.
+func (c *WebAuthnCredentialClient) Get(ctx context.Context, id int64) (*WebAuthnCredential, error) {
+	return c.Query().Where(webauthncredential.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *WebAuthnCredentialClient) GetX(ctx context.Context, id int64) *WebAuthnCredential {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryUser queries the user edge of a WebAuthnCredential.
+func (c *WebAuthnCredentialClient) QueryUser(_m *WebAuthnCredential) *UserQuery {
+	query := (&UserClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(webauthncredential.Table, webauthncredential.FieldID, id),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, webauthncredential.UserTable, webauthncredential.UserColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *WebAuthnCredentialClient) Hooks() []Hook {
+	return c.hooks.WebAuthnCredential
+}
+
+// Interceptors returns the client interceptors.
+func (c *WebAuthnCredentialClient) Interceptors() []Interceptor {
+	return c.inters.WebAuthnCredential
+}
+
+func (c *WebAuthnCredentialClient) mutate(ctx context.Context, m *WebAuthnCredentialMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&WebAuthnCredentialCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&WebAuthnCredentialUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&WebAuthnCredentialUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&WebAuthnCredentialDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown WebAuthnCredential mutation op: %q", m.Op())
+	}
+}
+
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		APIKey, Account, AccountGroup, AdminActionLog, Group, Invitation, InviteLog,
-		Plan, PromoCode, PromoCodeUsage, Proxy, RedeemCode, Setting, UsageCleanupTask,
-		UsageLog, User, UserAllowedGroup, UserAttributeDefinition, UserAttributeValue,
-		UserSubscription []ent.Hook
+		APIKey, Account, AccountGroup, AdminActionLog, CommissionWithdrawal, Group,
+		Invitation, InviteCommission, InviteLog, Organization, OrganizationInvitation,
+		OrganizationMember, Plan, PromoCode, PromoCodeUsage, Proxy, RedeemCode,
+		Setting, UsageCleanupTask, UsageLog, User, UserAllowedGroup,
+		UserAttributeDefinition, UserAttributeValue, UserRecoveryCode,
+		UserSubscription, WebAuthnCredential []ent.Hook
 	}
 	inters struct {
-		APIKey, Account, AccountGroup, AdminActionLog, Group, Invitation, InviteLog,
-		Plan, PromoCode, PromoCodeUsage, Proxy, RedeemCode, Setting, UsageCleanupTask,
-		UsageLog, User, UserAllowedGroup, UserAttributeDefinition, UserAttributeValue,
-		UserSubscription []ent.Interceptor
+		APIKey, Account, AccountGroup, AdminActionLog, CommissionWithdrawal, Group,
+		Invitation, InviteCommission, InviteLog, Organization, OrganizationInvitation,
+		OrganizationMember, Plan, PromoCode, PromoCodeUsage, Proxy, RedeemCode,
+		Setting, UsageCleanupTask, UsageLog, User, UserAllowedGroup,
+		UserAttributeDefinition, UserAttributeValue, UserRecoveryCode,
+		UserSubscription, WebAuthnCredential []ent.Interceptor
 	}
 )
 
@@ -0,0 +1,202 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Wei-Shaw/sub2api/ent/commissionwithdrawal"
+)
+
+// CommissionWithdrawal is the model entity for the CommissionWithdrawal schema.
+type CommissionWithdrawal struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID int64 `json:"user_id,omitempty"`
+	// Amount holds the value of the "amount" field.
+	Amount float64 `json:"amount,omitempty"`
+	// Method holds the value of the "method" field.
+	Method string `json:"method,omitempty"`
+	// Status holds the value of the "status" field.
+	Status string `json:"status,omitempty"`
+	// AccountInfo holds the value of the "account_info" field.
+	AccountInfo string `json:"account_info,omitempty"`
+	// ProcessedBy holds the value of the "processed_by" field.
+	ProcessedBy *int64 `json:"processed_by,omitempty"`
+	// AdminNotes holds the value of the "admin_notes" field.
+	AdminNotes string `json:"admin_notes,omitempty"`
+	// ProcessedAt holds the value of the "processed_at" field.
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*CommissionWithdrawal) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case commissionwithdrawal.FieldAmount:
+			values[i] = new(sql.NullFloat64)
+		case commissionwithdrawal.FieldID, commissionwithdrawal.FieldUserID, commissionwithdrawal.FieldProcessedBy:
+			values[i] = new(sql.NullInt64)
+		case commissionwithdrawal.FieldMethod, commissionwithdrawal.FieldStatus, commissionwithdrawal.FieldAccountInfo, commissionwithdrawal.FieldAdminNotes:
+			values[i] = new(sql.NullString)
+		case commissionwithdrawal.FieldProcessedAt, commissionwithdrawal.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the CommissionWithdrawal fields.
+func (_m *CommissionWithdrawal) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case commissionwithdrawal.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int64(value.Int64)
+		case commissionwithdrawal.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				_m.UserID = value.Int64
+			}
+		case commissionwithdrawal.FieldAmount:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field amount", values[i])
+			} else if value.Valid {
+				_m.Amount = value.Float64
+			}
+		case commissionwithdrawal.FieldMethod:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field method", values[i])
+			} else if value.Valid {
+				_m.Method = value.String
+			}
+		case commissionwithdrawal.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				_m.Status = value.String
+			}
+		case commissionwithdrawal.FieldAccountInfo:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field account_info", values[i])
+			} else if value.Valid {
+				_m.AccountInfo = value.String
+			}
+		case commissionwithdrawal.FieldProcessedBy:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field processed_by", values[i])
+			} else if value.Valid {
+				_m.ProcessedBy = new(int64)
+				*_m.ProcessedBy = value.Int64
+			}
+		case commissionwithdrawal.FieldAdminNotes:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field admin_notes", values[i])
+			} else if value.Valid {
+				_m.AdminNotes = value.String
+			}
+		case commissionwithdrawal.FieldProcessedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field processed_at", values[i])
+			} else if value.Valid {
+				_m.ProcessedAt = new(time.Time)
+				*_m.ProcessedAt = value.Time
+			}
+		case commissionwithdrawal.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the CommissionWithdrawal.
+// This includes values selected through modifiers, order, etc.
+func (_m *CommissionWithdrawal) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this CommissionWithdrawal.
+// Note that you need to call CommissionWithdrawal.Unwrap() before calling this method if this CommissionWithdrawal
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *CommissionWithdrawal) Update() *CommissionWithdrawalUpdateOne {
+	return NewCommissionWithdrawalClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the CommissionWithdrawal entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *CommissionWithdrawal) Unwrap() *CommissionWithdrawal {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: CommissionWithdrawal is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *CommissionWithdrawal) String() string {
+	var builder strings.Builder
+	builder.WriteString("CommissionWithdrawal(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("amount=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Amount))
+	builder.WriteString(", ")
+	builder.WriteString("method=")
+	builder.WriteString(_m.Method)
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(_m.Status)
+	builder.WriteString(", ")
+	builder.WriteString("account_info=")
+	builder.WriteString(_m.AccountInfo)
+	builder.WriteString(", ")
+	if v := _m.ProcessedBy; v != nil {
+		builder.WriteString("processed_by=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("admin_notes=")
+	builder.WriteString(_m.AdminNotes)
+	builder.WriteString(", ")
+	if v := _m.ProcessedAt; v != nil {
+		builder.WriteString("processed_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// CommissionWithdrawals is a parsable slice of CommissionWithdrawal.
+type CommissionWithdrawals []*CommissionWithdrawal
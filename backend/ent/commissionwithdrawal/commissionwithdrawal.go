@@ -0,0 +1,128 @@
+// Code generated by ent, DO NOT EDIT.
+
+package commissionwithdrawal
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the commissionwithdrawal type in the database.
+	Label = "commission_withdrawal"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldAmount holds the string denoting the amount field in the database.
+	FieldAmount = "amount"
+	// FieldMethod holds the string denoting the method field in the database.
+	FieldMethod = "method"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
+	// FieldAccountInfo holds the string denoting the account_info field in the database.
+	FieldAccountInfo = "account_info"
+	// FieldProcessedBy holds the string denoting the processed_by field in the database.
+	FieldProcessedBy = "processed_by"
+	// FieldAdminNotes holds the string denoting the admin_notes field in the database.
+	FieldAdminNotes = "admin_notes"
+	// FieldProcessedAt holds the string denoting the processed_at field in the database.
+	FieldProcessedAt = "processed_at"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// Table holds the table name of the commissionwithdrawal in the database.
+	Table = "commission_withdrawals"
+)
+
+// Columns holds all SQL columns for commissionwithdrawal fields.
+var Columns = []string{
+	FieldID,
+	FieldUserID,
+	FieldAmount,
+	FieldMethod,
+	FieldStatus,
+	FieldAccountInfo,
+	FieldProcessedBy,
+	FieldAdminNotes,
+	FieldProcessedAt,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// MethodValidator is a validator for the "method" field. It is called by the builders before save.
+	MethodValidator func(string) error
+	// DefaultStatus holds the default value on creation for the "status" field.
+	DefaultStatus string
+	// StatusValidator is a validator for the "status" field. It is called by the builders before save.
+	StatusValidator func(string) error
+	// DefaultAccountInfo holds the default value on creation for the "account_info" field.
+	DefaultAccountInfo string
+	// DefaultAdminNotes holds the default value on creation for the "admin_notes" field.
+	DefaultAdminNotes string
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the CommissionWithdrawal queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByUserID orders the results by the user_id field.
+func ByUserID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUserID, opts...).ToFunc()
+}
+
+// ByAmount orders the results by the amount field.
+func ByAmount(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAmount, opts...).ToFunc()
+}
+
+// ByMethod orders the results by the method field.
+func ByMethod(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMethod, opts...).ToFunc()
+}
+
+// ByStatus orders the results by the status field.
+func ByStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStatus, opts...).ToFunc()
+}
+
+// ByAccountInfo orders the results by the account_info field.
+func ByAccountInfo(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAccountInfo, opts...).ToFunc()
+}
+
+// ByProcessedBy orders the results by the processed_by field.
+func ByProcessedBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldProcessedBy, opts...).ToFunc()
+}
+
+// ByAdminNotes orders the results by the admin_notes field.
+func ByAdminNotes(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAdminNotes, opts...).ToFunc()
+}
+
+// ByProcessedAt orders the results by the processed_at field.
+func ByProcessedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldProcessedAt, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
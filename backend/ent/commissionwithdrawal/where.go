@@ -0,0 +1,595 @@
+// Code generated by ent, DO NOT EDIT.
+
+package commissionwithdrawal
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLTE(FieldID, id))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldUserID, v))
+}
+
+// Amount applies equality check predicate on the "amount" field. It's identical to AmountEQ.
+func Amount(v float64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldAmount, v))
+}
+
+// Method applies equality check predicate on the "method" field. It's identical to MethodEQ.
+func Method(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldMethod, v))
+}
+
+// Status applies equality check predicate on the "status" field. It's identical to StatusEQ.
+func Status(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldStatus, v))
+}
+
+// AccountInfo applies equality check predicate on the "account_info" field. It's identical to AccountInfoEQ.
+func AccountInfo(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldAccountInfo, v))
+}
+
+// ProcessedBy applies equality check predicate on the "processed_by" field. It's identical to ProcessedByEQ.
+func ProcessedBy(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldProcessedBy, v))
+}
+
+// AdminNotes applies equality check predicate on the "admin_notes" field. It's identical to AdminNotesEQ.
+func AdminNotes(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldAdminNotes, v))
+}
+
+// ProcessedAt applies equality check predicate on the "processed_at" field. It's identical to ProcessedAtEQ.
+func ProcessedAt(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldProcessedAt, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGT(FieldUserID, v))
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGTE(FieldUserID, v))
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLT(FieldUserID, v))
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLTE(FieldUserID, v))
+}
+
+// AmountEQ applies the EQ predicate on the "amount" field.
+func AmountEQ(v float64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldAmount, v))
+}
+
+// AmountNEQ applies the NEQ predicate on the "amount" field.
+func AmountNEQ(v float64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNEQ(FieldAmount, v))
+}
+
+// AmountIn applies the In predicate on the "amount" field.
+func AmountIn(vs ...float64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIn(FieldAmount, vs...))
+}
+
+// AmountNotIn applies the NotIn predicate on the "amount" field.
+func AmountNotIn(vs ...float64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotIn(FieldAmount, vs...))
+}
+
+// AmountGT applies the GT predicate on the "amount" field.
+func AmountGT(v float64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGT(FieldAmount, v))
+}
+
+// AmountGTE applies the GTE predicate on the "amount" field.
+func AmountGTE(v float64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGTE(FieldAmount, v))
+}
+
+// AmountLT applies the LT predicate on the "amount" field.
+func AmountLT(v float64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLT(FieldAmount, v))
+}
+
+// AmountLTE applies the LTE predicate on the "amount" field.
+func AmountLTE(v float64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLTE(FieldAmount, v))
+}
+
+// MethodEQ applies the EQ predicate on the "method" field.
+func MethodEQ(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldMethod, v))
+}
+
+// MethodNEQ applies the NEQ predicate on the "method" field.
+func MethodNEQ(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNEQ(FieldMethod, v))
+}
+
+// MethodIn applies the In predicate on the "method" field.
+func MethodIn(vs ...string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIn(FieldMethod, vs...))
+}
+
+// MethodNotIn applies the NotIn predicate on the "method" field.
+func MethodNotIn(vs ...string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotIn(FieldMethod, vs...))
+}
+
+// MethodGT applies the GT predicate on the "method" field.
+func MethodGT(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGT(FieldMethod, v))
+}
+
+// MethodGTE applies the GTE predicate on the "method" field.
+func MethodGTE(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGTE(FieldMethod, v))
+}
+
+// MethodLT applies the LT predicate on the "method" field.
+func MethodLT(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLT(FieldMethod, v))
+}
+
+// MethodLTE applies the LTE predicate on the "method" field.
+func MethodLTE(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLTE(FieldMethod, v))
+}
+
+// MethodContains applies the Contains predicate on the "method" field.
+func MethodContains(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldContains(FieldMethod, v))
+}
+
+// MethodHasPrefix applies the HasPrefix predicate on the "method" field.
+func MethodHasPrefix(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldHasPrefix(FieldMethod, v))
+}
+
+// MethodHasSuffix applies the HasSuffix predicate on the "method" field.
+func MethodHasSuffix(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldHasSuffix(FieldMethod, v))
+}
+
+// MethodEqualFold applies the EqualFold predicate on the "method" field.
+func MethodEqualFold(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEqualFold(FieldMethod, v))
+}
+
+// MethodContainsFold applies the ContainsFold predicate on the "method" field.
+func MethodContainsFold(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldContainsFold(FieldMethod, v))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// StatusGT applies the GT predicate on the "status" field.
+func StatusGT(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGT(FieldStatus, v))
+}
+
+// StatusGTE applies the GTE predicate on the "status" field.
+func StatusGTE(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGTE(FieldStatus, v))
+}
+
+// StatusLT applies the LT predicate on the "status" field.
+func StatusLT(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLT(FieldStatus, v))
+}
+
+// StatusLTE applies the LTE predicate on the "status" field.
+func StatusLTE(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLTE(FieldStatus, v))
+}
+
+// StatusContains applies the Contains predicate on the "status" field.
+func StatusContains(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldContains(FieldStatus, v))
+}
+
+// StatusHasPrefix applies the HasPrefix predicate on the "status" field.
+func StatusHasPrefix(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldHasPrefix(FieldStatus, v))
+}
+
+// StatusHasSuffix applies the HasSuffix predicate on the "status" field.
+func StatusHasSuffix(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldHasSuffix(FieldStatus, v))
+}
+
+// StatusEqualFold applies the EqualFold predicate on the "status" field.
+func StatusEqualFold(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEqualFold(FieldStatus, v))
+}
+
+// StatusContainsFold applies the ContainsFold predicate on the "status" field.
+func StatusContainsFold(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldContainsFold(FieldStatus, v))
+}
+
+// AccountInfoEQ applies the EQ predicate on the "account_info" field.
+func AccountInfoEQ(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldAccountInfo, v))
+}
+
+// AccountInfoNEQ applies the NEQ predicate on the "account_info" field.
+func AccountInfoNEQ(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNEQ(FieldAccountInfo, v))
+}
+
+// AccountInfoIn applies the In predicate on the "account_info" field.
+func AccountInfoIn(vs ...string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIn(FieldAccountInfo, vs...))
+}
+
+// AccountInfoNotIn applies the NotIn predicate on the "account_info" field.
+func AccountInfoNotIn(vs ...string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotIn(FieldAccountInfo, vs...))
+}
+
+// AccountInfoGT applies the GT predicate on the "account_info" field.
+func AccountInfoGT(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGT(FieldAccountInfo, v))
+}
+
+// AccountInfoGTE applies the GTE predicate on the "account_info" field.
+func AccountInfoGTE(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGTE(FieldAccountInfo, v))
+}
+
+// AccountInfoLT applies the LT predicate on the "account_info" field.
+func AccountInfoLT(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLT(FieldAccountInfo, v))
+}
+
+// AccountInfoLTE applies the LTE predicate on the "account_info" field.
+func AccountInfoLTE(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLTE(FieldAccountInfo, v))
+}
+
+// AccountInfoContains applies the Contains predicate on the "account_info" field.
+func AccountInfoContains(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldContains(FieldAccountInfo, v))
+}
+
+// AccountInfoHasPrefix applies the HasPrefix predicate on the "account_info" field.
+func AccountInfoHasPrefix(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldHasPrefix(FieldAccountInfo, v))
+}
+
+// AccountInfoHasSuffix applies the HasSuffix predicate on the "account_info" field.
+func AccountInfoHasSuffix(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldHasSuffix(FieldAccountInfo, v))
+}
+
+// AccountInfoEqualFold applies the EqualFold predicate on the "account_info" field.
+func AccountInfoEqualFold(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEqualFold(FieldAccountInfo, v))
+}
+
+// AccountInfoContainsFold applies the ContainsFold predicate on the "account_info" field.
+func AccountInfoContainsFold(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldContainsFold(FieldAccountInfo, v))
+}
+
+// ProcessedByEQ applies the EQ predicate on the "processed_by" field.
+func ProcessedByEQ(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldProcessedBy, v))
+}
+
+// ProcessedByNEQ applies the NEQ predicate on the "processed_by" field.
+func ProcessedByNEQ(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNEQ(FieldProcessedBy, v))
+}
+
+// ProcessedByIn applies the In predicate on the "processed_by" field.
+func ProcessedByIn(vs ...int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIn(FieldProcessedBy, vs...))
+}
+
+// ProcessedByNotIn applies the NotIn predicate on the "processed_by" field.
+func ProcessedByNotIn(vs ...int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotIn(FieldProcessedBy, vs...))
+}
+
+// ProcessedByGT applies the GT predicate on the "processed_by" field.
+func ProcessedByGT(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGT(FieldProcessedBy, v))
+}
+
+// ProcessedByGTE applies the GTE predicate on the "processed_by" field.
+func ProcessedByGTE(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGTE(FieldProcessedBy, v))
+}
+
+// ProcessedByLT applies the LT predicate on the "processed_by" field.
+func ProcessedByLT(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLT(FieldProcessedBy, v))
+}
+
+// ProcessedByLTE applies the LTE predicate on the "processed_by" field.
+func ProcessedByLTE(v int64) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLTE(FieldProcessedBy, v))
+}
+
+// ProcessedByIsNil applies the IsNil predicate on the "processed_by" field.
+func ProcessedByIsNil() predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIsNull(FieldProcessedBy))
+}
+
+// ProcessedByNotNil applies the NotNil predicate on the "processed_by" field.
+func ProcessedByNotNil() predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotNull(FieldProcessedBy))
+}
+
+// AdminNotesEQ applies the EQ predicate on the "admin_notes" field.
+func AdminNotesEQ(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldAdminNotes, v))
+}
+
+// AdminNotesNEQ applies the NEQ predicate on the "admin_notes" field.
+func AdminNotesNEQ(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNEQ(FieldAdminNotes, v))
+}
+
+// AdminNotesIn applies the In predicate on the "admin_notes" field.
+func AdminNotesIn(vs ...string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIn(FieldAdminNotes, vs...))
+}
+
+// AdminNotesNotIn applies the NotIn predicate on the "admin_notes" field.
+func AdminNotesNotIn(vs ...string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotIn(FieldAdminNotes, vs...))
+}
+
+// AdminNotesGT applies the GT predicate on the "admin_notes" field.
+func AdminNotesGT(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGT(FieldAdminNotes, v))
+}
+
+// AdminNotesGTE applies the GTE predicate on the "admin_notes" field.
+func AdminNotesGTE(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGTE(FieldAdminNotes, v))
+}
+
+// AdminNotesLT applies the LT predicate on the "admin_notes" field.
+func AdminNotesLT(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLT(FieldAdminNotes, v))
+}
+
+// AdminNotesLTE applies the LTE predicate on the "admin_notes" field.
+func AdminNotesLTE(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLTE(FieldAdminNotes, v))
+}
+
+// AdminNotesContains applies the Contains predicate on the "admin_notes" field.
+func AdminNotesContains(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldContains(FieldAdminNotes, v))
+}
+
+// AdminNotesHasPrefix applies the HasPrefix predicate on the "admin_notes" field.
+func AdminNotesHasPrefix(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldHasPrefix(FieldAdminNotes, v))
+}
+
+// AdminNotesHasSuffix applies the HasSuffix predicate on the "admin_notes" field.
+func AdminNotesHasSuffix(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldHasSuffix(FieldAdminNotes, v))
+}
+
+// AdminNotesEqualFold applies the EqualFold predicate on the "admin_notes" field.
+func AdminNotesEqualFold(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEqualFold(FieldAdminNotes, v))
+}
+
+// AdminNotesContainsFold applies the ContainsFold predicate on the "admin_notes" field.
+func AdminNotesContainsFold(v string) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldContainsFold(FieldAdminNotes, v))
+}
+
+// ProcessedAtEQ applies the EQ predicate on the "processed_at" field.
+func ProcessedAtEQ(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldProcessedAt, v))
+}
+
+// ProcessedAtNEQ applies the NEQ predicate on the "processed_at" field.
+func ProcessedAtNEQ(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNEQ(FieldProcessedAt, v))
+}
+
+// ProcessedAtIn applies the In predicate on the "processed_at" field.
+func ProcessedAtIn(vs ...time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIn(FieldProcessedAt, vs...))
+}
+
+// ProcessedAtNotIn applies the NotIn predicate on the "processed_at" field.
+func ProcessedAtNotIn(vs ...time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotIn(FieldProcessedAt, vs...))
+}
+
+// ProcessedAtGT applies the GT predicate on the "processed_at" field.
+func ProcessedAtGT(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGT(FieldProcessedAt, v))
+}
+
+// ProcessedAtGTE applies the GTE predicate on the "processed_at" field.
+func ProcessedAtGTE(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGTE(FieldProcessedAt, v))
+}
+
+// ProcessedAtLT applies the LT predicate on the "processed_at" field.
+func ProcessedAtLT(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLT(FieldProcessedAt, v))
+}
+
+// ProcessedAtLTE applies the LTE predicate on the "processed_at" field.
+func ProcessedAtLTE(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLTE(FieldProcessedAt, v))
+}
+
+// ProcessedAtIsNil applies the IsNil predicate on the "processed_at" field.
+func ProcessedAtIsNil() predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIsNull(FieldProcessedAt))
+}
+
+// ProcessedAtNotNil applies the NotNil predicate on the "processed_at" field.
+func ProcessedAtNotNil() predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotNull(FieldProcessedAt))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.CommissionWithdrawal) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.CommissionWithdrawal) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.CommissionWithdrawal) predicate.CommissionWithdrawal {
+	return predicate.CommissionWithdrawal(sql.NotPredicates(p))
+}
@@ -0,0 +1,1053 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/commissionwithdrawal"
+)
+
+// CommissionWithdrawalCreate is the builder for creating a CommissionWithdrawal entity.
+type CommissionWithdrawalCreate struct {
+	config
+	mutation *CommissionWithdrawalMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *CommissionWithdrawalCreate) SetUserID(v int64) *CommissionWithdrawalCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetAmount sets the "amount" field.
+func (_c *CommissionWithdrawalCreate) SetAmount(v float64) *CommissionWithdrawalCreate {
+	_c.mutation.SetAmount(v)
+	return _c
+}
+
+// SetMethod sets the "method" field.
+func (_c *CommissionWithdrawalCreate) SetMethod(v string) *CommissionWithdrawalCreate {
+	_c.mutation.SetMethod(v)
+	return _c
+}
+
+// SetStatus sets the "status" field.
+func (_c *CommissionWithdrawalCreate) SetStatus(v string) *CommissionWithdrawalCreate {
+	_c.mutation.SetStatus(v)
+	return _c
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_c *CommissionWithdrawalCreate) SetNillableStatus(v *string) *CommissionWithdrawalCreate {
+	if v != nil {
+		_c.SetStatus(*v)
+	}
+	return _c
+}
+
+// SetAccountInfo sets the "account_info" field.
+func (_c *CommissionWithdrawalCreate) SetAccountInfo(v string) *CommissionWithdrawalCreate {
+	_c.mutation.SetAccountInfo(v)
+	return _c
+}
+
+// SetNillableAccountInfo sets the "account_info" field if the given value is not nil.
+func (_c *CommissionWithdrawalCreate) SetNillableAccountInfo(v *string) *CommissionWithdrawalCreate {
+	if v != nil {
+		_c.SetAccountInfo(*v)
+	}
+	return _c
+}
+
+// SetProcessedBy sets the "processed_by" field.
+func (_c *CommissionWithdrawalCreate) SetProcessedBy(v int64) *CommissionWithdrawalCreate {
+	_c.mutation.SetProcessedBy(v)
+	return _c
+}
+
+// SetNillableProcessedBy sets the "processed_by" field if the given value is not nil.
+func (_c *CommissionWithdrawalCreate) SetNillableProcessedBy(v *int64) *CommissionWithdrawalCreate {
+	if v != nil {
+		_c.SetProcessedBy(*v)
+	}
+	return _c
+}
+
+// SetAdminNotes sets the "admin_notes" field.
+func (_c *CommissionWithdrawalCreate) SetAdminNotes(v string) *CommissionWithdrawalCreate {
+	_c.mutation.SetAdminNotes(v)
+	return _c
+}
+
+// SetNillableAdminNotes sets the "admin_notes" field if the given value is not nil.
+func (_c *CommissionWithdrawalCreate) SetNillableAdminNotes(v *string) *CommissionWithdrawalCreate {
+	if v != nil {
+		_c.SetAdminNotes(*v)
+	}
+	return _c
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (_c *CommissionWithdrawalCreate) SetProcessedAt(v time.Time) *CommissionWithdrawalCreate {
+	_c.mutation.SetProcessedAt(v)
+	return _c
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (_c *CommissionWithdrawalCreate) SetNillableProcessedAt(v *time.Time) *CommissionWithdrawalCreate {
+	if v != nil {
+		_c.SetProcessedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *CommissionWithdrawalCreate) SetCreatedAt(v time.Time) *CommissionWithdrawalCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *CommissionWithdrawalCreate) SetNillableCreatedAt(v *time.Time) *CommissionWithdrawalCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the CommissionWithdrawalMutation object of the builder.
+func (_c *CommissionWithdrawalCreate) Mutation() *CommissionWithdrawalMutation {
+	return _c.mutation
+}
+
+// Save creates the CommissionWithdrawal in the database.
+func (_c *CommissionWithdrawalCreate) Save(ctx context.Context) (*CommissionWithdrawal, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *CommissionWithdrawalCreate) SaveX(ctx context.Context) *CommissionWithdrawal {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *CommissionWithdrawalCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *CommissionWithdrawalCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *CommissionWithdrawalCreate) defaults() {
+	if _, ok := _c.mutation.Status(); !ok {
+		v := commissionwithdrawal.DefaultStatus
+		_c.mutation.SetStatus(v)
+	}
+	if _, ok := _c.mutation.AccountInfo(); !ok {
+		v := commissionwithdrawal.DefaultAccountInfo
+		_c.mutation.SetAccountInfo(v)
+	}
+	if _, ok := _c.mutation.AdminNotes(); !ok {
+		v := commissionwithdrawal.DefaultAdminNotes
+		_c.mutation.SetAdminNotes(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := commissionwithdrawal.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *CommissionWithdrawalCreate) check() error {
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "CommissionWithdrawal.user_id"`)}
+	}
+	if _, ok := _c.mutation.Amount(); !ok {
+		return &ValidationError{Name: "amount", err: errors.New(`ent: missing required field "CommissionWithdrawal.amount"`)}
+	}
+	if _, ok := _c.mutation.Method(); !ok {
+		return &ValidationError{Name: "method", err: errors.New(`ent: missing required field "CommissionWithdrawal.method"`)}
+	}
+	if v, ok := _c.mutation.Method(); ok {
+		if err := commissionwithdrawal.MethodValidator(v); err != nil {
+			return &ValidationError{Name: "method", err: fmt.Errorf(`ent: validator failed for field "CommissionWithdrawal.method": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "CommissionWithdrawal.status"`)}
+	}
+	if v, ok := _c.mutation.Status(); ok {
+		if err := commissionwithdrawal.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "CommissionWithdrawal.status": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.AccountInfo(); !ok {
+		return &ValidationError{Name: "account_info", err: errors.New(`ent: missing required field "CommissionWithdrawal.account_info"`)}
+	}
+	if _, ok := _c.mutation.AdminNotes(); !ok {
+		return &ValidationError{Name: "admin_notes", err: errors.New(`ent: missing required field "CommissionWithdrawal.admin_notes"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "CommissionWithdrawal.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *CommissionWithdrawalCreate) sqlSave(ctx context.Context) (*CommissionWithdrawal, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int64(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *CommissionWithdrawalCreate) createSpec() (*CommissionWithdrawal, *sqlgraph.CreateSpec) {
+	var (
+		_node = &CommissionWithdrawal{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(commissionwithdrawal.Table, sqlgraph.NewFieldSpec(commissionwithdrawal.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.UserID(); ok {
+		_spec.SetField(commissionwithdrawal.FieldUserID, field.TypeInt64, value)
+		_node.UserID = value
+	}
+	if value, ok := _c.mutation.Amount(); ok {
+		_spec.SetField(commissionwithdrawal.FieldAmount, field.TypeFloat64, value)
+		_node.Amount = value
+	}
+	if value, ok := _c.mutation.Method(); ok {
+		_spec.SetField(commissionwithdrawal.FieldMethod, field.TypeString, value)
+		_node.Method = value
+	}
+	if value, ok := _c.mutation.Status(); ok {
+		_spec.SetField(commissionwithdrawal.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
+	if value, ok := _c.mutation.AccountInfo(); ok {
+		_spec.SetField(commissionwithdrawal.FieldAccountInfo, field.TypeString, value)
+		_node.AccountInfo = value
+	}
+	if value, ok := _c.mutation.ProcessedBy(); ok {
+		_spec.SetField(commissionwithdrawal.FieldProcessedBy, field.TypeInt64, value)
+		_node.ProcessedBy = &value
+	}
+	if value, ok := _c.mutation.AdminNotes(); ok {
+		_spec.SetField(commissionwithdrawal.FieldAdminNotes, field.TypeString, value)
+		_node.AdminNotes = value
+	}
+	if value, ok := _c.mutation.ProcessedAt(); ok {
+		_spec.SetField(commissionwithdrawal.FieldProcessedAt, field.TypeTime, value)
+		_node.ProcessedAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(commissionwithdrawal.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.CommissionWithdrawal.Create().
+//		SetUserID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.CommissionWithdrawalUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *CommissionWithdrawalCreate) OnConflict(opts ...sql.ConflictOption) *CommissionWithdrawalUpsertOne {
+	_c.conflict = opts
+	return &CommissionWithdrawalUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.CommissionWithdrawal.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *CommissionWithdrawalCreate) OnConflictColumns(columns ...string) *CommissionWithdrawalUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &CommissionWithdrawalUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// CommissionWithdrawalUpsertOne is the builder for "upsert"-ing
+	//  one CommissionWithdrawal node.
+	CommissionWithdrawalUpsertOne struct {
+		create *CommissionWithdrawalCreate
+	}
+
+	// CommissionWithdrawalUpsert is the "OnConflict" setter.
+	CommissionWithdrawalUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUserID sets the "user_id" field.
+func (u *CommissionWithdrawalUpsert) SetUserID(v int64) *CommissionWithdrawalUpsert {
+	u.Set(commissionwithdrawal.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsert) UpdateUserID() *CommissionWithdrawalUpsert {
+	u.SetExcluded(commissionwithdrawal.FieldUserID)
+	return u
+}
+
+// AddUserID adds v to the "user_id" field.
+func (u *CommissionWithdrawalUpsert) AddUserID(v int64) *CommissionWithdrawalUpsert {
+	u.Add(commissionwithdrawal.FieldUserID, v)
+	return u
+}
+
+// SetAmount sets the "amount" field.
+func (u *CommissionWithdrawalUpsert) SetAmount(v float64) *CommissionWithdrawalUpsert {
+	u.Set(commissionwithdrawal.FieldAmount, v)
+	return u
+}
+
+// UpdateAmount sets the "amount" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsert) UpdateAmount() *CommissionWithdrawalUpsert {
+	u.SetExcluded(commissionwithdrawal.FieldAmount)
+	return u
+}
+
+// AddAmount adds v to the "amount" field.
+func (u *CommissionWithdrawalUpsert) AddAmount(v float64) *CommissionWithdrawalUpsert {
+	u.Add(commissionwithdrawal.FieldAmount, v)
+	return u
+}
+
+// SetMethod sets the "method" field.
+func (u *CommissionWithdrawalUpsert) SetMethod(v string) *CommissionWithdrawalUpsert {
+	u.Set(commissionwithdrawal.FieldMethod, v)
+	return u
+}
+
+// UpdateMethod sets the "method" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsert) UpdateMethod() *CommissionWithdrawalUpsert {
+	u.SetExcluded(commissionwithdrawal.FieldMethod)
+	return u
+}
+
+// SetStatus sets the "status" field.
+func (u *CommissionWithdrawalUpsert) SetStatus(v string) *CommissionWithdrawalUpsert {
+	u.Set(commissionwithdrawal.FieldStatus, v)
+	return u
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsert) UpdateStatus() *CommissionWithdrawalUpsert {
+	u.SetExcluded(commissionwithdrawal.FieldStatus)
+	return u
+}
+
+// SetAccountInfo sets the "account_info" field.
+func (u *CommissionWithdrawalUpsert) SetAccountInfo(v string) *CommissionWithdrawalUpsert {
+	u.Set(commissionwithdrawal.FieldAccountInfo, v)
+	return u
+}
+
+// UpdateAccountInfo sets the "account_info" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsert) UpdateAccountInfo() *CommissionWithdrawalUpsert {
+	u.SetExcluded(commissionwithdrawal.FieldAccountInfo)
+	return u
+}
+
+// SetProcessedBy sets the "processed_by" field.
+func (u *CommissionWithdrawalUpsert) SetProcessedBy(v int64) *CommissionWithdrawalUpsert {
+	u.Set(commissionwithdrawal.FieldProcessedBy, v)
+	return u
+}
+
+// UpdateProcessedBy sets the "processed_by" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsert) UpdateProcessedBy() *CommissionWithdrawalUpsert {
+	u.SetExcluded(commissionwithdrawal.FieldProcessedBy)
+	return u
+}
+
+// AddProcessedBy adds v to the "processed_by" field.
+func (u *CommissionWithdrawalUpsert) AddProcessedBy(v int64) *CommissionWithdrawalUpsert {
+	u.Add(commissionwithdrawal.FieldProcessedBy, v)
+	return u
+}
+
+// ClearProcessedBy clears the value of the "processed_by" field.
+func (u *CommissionWithdrawalUpsert) ClearProcessedBy() *CommissionWithdrawalUpsert {
+	u.SetNull(commissionwithdrawal.FieldProcessedBy)
+	return u
+}
+
+// SetAdminNotes sets the "admin_notes" field.
+func (u *CommissionWithdrawalUpsert) SetAdminNotes(v string) *CommissionWithdrawalUpsert {
+	u.Set(commissionwithdrawal.FieldAdminNotes, v)
+	return u
+}
+
+// UpdateAdminNotes sets the "admin_notes" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsert) UpdateAdminNotes() *CommissionWithdrawalUpsert {
+	u.SetExcluded(commissionwithdrawal.FieldAdminNotes)
+	return u
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (u *CommissionWithdrawalUpsert) SetProcessedAt(v time.Time) *CommissionWithdrawalUpsert {
+	u.Set(commissionwithdrawal.FieldProcessedAt, v)
+	return u
+}
+
+// UpdateProcessedAt sets the "processed_at" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsert) UpdateProcessedAt() *CommissionWithdrawalUpsert {
+	u.SetExcluded(commissionwithdrawal.FieldProcessedAt)
+	return u
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (u *CommissionWithdrawalUpsert) ClearProcessedAt() *CommissionWithdrawalUpsert {
+	u.SetNull(commissionwithdrawal.FieldProcessedAt)
+	return u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *CommissionWithdrawalUpsert) SetCreatedAt(v time.Time) *CommissionWithdrawalUpsert {
+	u.Set(commissionwithdrawal.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsert) UpdateCreatedAt() *CommissionWithdrawalUpsert {
+	u.SetExcluded(commissionwithdrawal.FieldCreatedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.CommissionWithdrawal.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *CommissionWithdrawalUpsertOne) UpdateNewValues() *CommissionWithdrawalUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.CommissionWithdrawal.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *CommissionWithdrawalUpsertOne) Ignore() *CommissionWithdrawalUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *CommissionWithdrawalUpsertOne) DoNothing() *CommissionWithdrawalUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the CommissionWithdrawalCreate.OnConflict
+// documentation for more info.
+func (u *CommissionWithdrawalUpsertOne) Update(set func(*CommissionWithdrawalUpsert)) *CommissionWithdrawalUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&CommissionWithdrawalUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *CommissionWithdrawalUpsertOne) SetUserID(v int64) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// AddUserID adds v to the "user_id" field.
+func (u *CommissionWithdrawalUpsertOne) AddUserID(v int64) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.AddUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertOne) UpdateUserID() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetAmount sets the "amount" field.
+func (u *CommissionWithdrawalUpsertOne) SetAmount(v float64) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetAmount(v)
+	})
+}
+
+// AddAmount adds v to the "amount" field.
+func (u *CommissionWithdrawalUpsertOne) AddAmount(v float64) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.AddAmount(v)
+	})
+}
+
+// UpdateAmount sets the "amount" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertOne) UpdateAmount() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateAmount()
+	})
+}
+
+// SetMethod sets the "method" field.
+func (u *CommissionWithdrawalUpsertOne) SetMethod(v string) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetMethod(v)
+	})
+}
+
+// UpdateMethod sets the "method" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertOne) UpdateMethod() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateMethod()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *CommissionWithdrawalUpsertOne) SetStatus(v string) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertOne) UpdateStatus() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetAccountInfo sets the "account_info" field.
+func (u *CommissionWithdrawalUpsertOne) SetAccountInfo(v string) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetAccountInfo(v)
+	})
+}
+
+// UpdateAccountInfo sets the "account_info" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertOne) UpdateAccountInfo() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateAccountInfo()
+	})
+}
+
+// SetProcessedBy sets the "processed_by" field.
+func (u *CommissionWithdrawalUpsertOne) SetProcessedBy(v int64) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetProcessedBy(v)
+	})
+}
+
+// AddProcessedBy adds v to the "processed_by" field.
+func (u *CommissionWithdrawalUpsertOne) AddProcessedBy(v int64) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.AddProcessedBy(v)
+	})
+}
+
+// UpdateProcessedBy sets the "processed_by" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertOne) UpdateProcessedBy() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateProcessedBy()
+	})
+}
+
+// ClearProcessedBy clears the value of the "processed_by" field.
+func (u *CommissionWithdrawalUpsertOne) ClearProcessedBy() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.ClearProcessedBy()
+	})
+}
+
+// SetAdminNotes sets the "admin_notes" field.
+func (u *CommissionWithdrawalUpsertOne) SetAdminNotes(v string) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetAdminNotes(v)
+	})
+}
+
+// UpdateAdminNotes sets the "admin_notes" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertOne) UpdateAdminNotes() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateAdminNotes()
+	})
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (u *CommissionWithdrawalUpsertOne) SetProcessedAt(v time.Time) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetProcessedAt(v)
+	})
+}
+
+// UpdateProcessedAt sets the "processed_at" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertOne) UpdateProcessedAt() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateProcessedAt()
+	})
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (u *CommissionWithdrawalUpsertOne) ClearProcessedAt() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.ClearProcessedAt()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *CommissionWithdrawalUpsertOne) SetCreatedAt(v time.Time) *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertOne) UpdateCreatedAt() *CommissionWithdrawalUpsertOne {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *CommissionWithdrawalUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for CommissionWithdrawalCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *CommissionWithdrawalUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *CommissionWithdrawalUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *CommissionWithdrawalUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// CommissionWithdrawalCreateBulk is the builder for creating many CommissionWithdrawal entities in bulk.
+type CommissionWithdrawalCreateBulk struct {
+	config
+	err      error
+	builders []*CommissionWithdrawalCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the CommissionWithdrawal entities in the database.
+func (_c *CommissionWithdrawalCreateBulk) Save(ctx context.Context) ([]*CommissionWithdrawal, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*CommissionWithdrawal, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CommissionWithdrawalMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *CommissionWithdrawalCreateBulk) SaveX(ctx context.Context) []*CommissionWithdrawal {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *CommissionWithdrawalCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *CommissionWithdrawalCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.CommissionWithdrawal.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.CommissionWithdrawalUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *CommissionWithdrawalCreateBulk) OnConflict(opts ...sql.ConflictOption) *CommissionWithdrawalUpsertBulk {
+	_c.conflict = opts
+	return &CommissionWithdrawalUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.CommissionWithdrawal.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *CommissionWithdrawalCreateBulk) OnConflictColumns(columns ...string) *CommissionWithdrawalUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &CommissionWithdrawalUpsertBulk{
+		create: _c,
+	}
+}
+
+// CommissionWithdrawalUpsertBulk is the builder for "upsert"-ing
+// a bulk of CommissionWithdrawal nodes.
+type CommissionWithdrawalUpsertBulk struct {
+	create *CommissionWithdrawalCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.CommissionWithdrawal.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *CommissionWithdrawalUpsertBulk) UpdateNewValues() *CommissionWithdrawalUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.CommissionWithdrawal.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *CommissionWithdrawalUpsertBulk) Ignore() *CommissionWithdrawalUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *CommissionWithdrawalUpsertBulk) DoNothing() *CommissionWithdrawalUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the CommissionWithdrawalCreateBulk.OnConflict
+// documentation for more info.
+func (u *CommissionWithdrawalUpsertBulk) Update(set func(*CommissionWithdrawalUpsert)) *CommissionWithdrawalUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&CommissionWithdrawalUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *CommissionWithdrawalUpsertBulk) SetUserID(v int64) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// AddUserID adds v to the "user_id" field.
+func (u *CommissionWithdrawalUpsertBulk) AddUserID(v int64) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.AddUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertBulk) UpdateUserID() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetAmount sets the "amount" field.
+func (u *CommissionWithdrawalUpsertBulk) SetAmount(v float64) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetAmount(v)
+	})
+}
+
+// AddAmount adds v to the "amount" field.
+func (u *CommissionWithdrawalUpsertBulk) AddAmount(v float64) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.AddAmount(v)
+	})
+}
+
+// UpdateAmount sets the "amount" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertBulk) UpdateAmount() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateAmount()
+	})
+}
+
+// SetMethod sets the "method" field.
+func (u *CommissionWithdrawalUpsertBulk) SetMethod(v string) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetMethod(v)
+	})
+}
+
+// UpdateMethod sets the "method" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertBulk) UpdateMethod() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateMethod()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *CommissionWithdrawalUpsertBulk) SetStatus(v string) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertBulk) UpdateStatus() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetAccountInfo sets the "account_info" field.
+func (u *CommissionWithdrawalUpsertBulk) SetAccountInfo(v string) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetAccountInfo(v)
+	})
+}
+
+// UpdateAccountInfo sets the "account_info" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertBulk) UpdateAccountInfo() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateAccountInfo()
+	})
+}
+
+// SetProcessedBy sets the "processed_by" field.
+func (u *CommissionWithdrawalUpsertBulk) SetProcessedBy(v int64) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetProcessedBy(v)
+	})
+}
+
+// AddProcessedBy adds v to the "processed_by" field.
+func (u *CommissionWithdrawalUpsertBulk) AddProcessedBy(v int64) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.AddProcessedBy(v)
+	})
+}
+
+// UpdateProcessedBy sets the "processed_by" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertBulk) UpdateProcessedBy() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateProcessedBy()
+	})
+}
+
+// ClearProcessedBy clears the value of the "processed_by" field.
+func (u *CommissionWithdrawalUpsertBulk) ClearProcessedBy() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.ClearProcessedBy()
+	})
+}
+
+// SetAdminNotes sets the "admin_notes" field.
+func (u *CommissionWithdrawalUpsertBulk) SetAdminNotes(v string) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetAdminNotes(v)
+	})
+}
+
+// UpdateAdminNotes sets the "admin_notes" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertBulk) UpdateAdminNotes() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateAdminNotes()
+	})
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (u *CommissionWithdrawalUpsertBulk) SetProcessedAt(v time.Time) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetProcessedAt(v)
+	})
+}
+
+// UpdateProcessedAt sets the "processed_at" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertBulk) UpdateProcessedAt() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateProcessedAt()
+	})
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (u *CommissionWithdrawalUpsertBulk) ClearProcessedAt() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.ClearProcessedAt()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *CommissionWithdrawalUpsertBulk) SetCreatedAt(v time.Time) *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *CommissionWithdrawalUpsertBulk) UpdateCreatedAt() *CommissionWithdrawalUpsertBulk {
+	return u.Update(func(s *CommissionWithdrawalUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *CommissionWithdrawalUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the CommissionWithdrawalCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for CommissionWithdrawalCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *CommissionWithdrawalUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,614 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/commissionwithdrawal"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// CommissionWithdrawalUpdate is the builder for updating CommissionWithdrawal entities.
+type CommissionWithdrawalUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CommissionWithdrawalMutation
+}
+
+// Where appends a list predicates to the CommissionWithdrawalUpdate builder.
+func (_u *CommissionWithdrawalUpdate) Where(ps ...predicate.CommissionWithdrawal) *CommissionWithdrawalUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *CommissionWithdrawalUpdate) SetUserID(v int64) *CommissionWithdrawalUpdate {
+	_u.mutation.ResetUserID()
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdate) SetNillableUserID(v *int64) *CommissionWithdrawalUpdate {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// AddUserID adds value to the "user_id" field.
+func (_u *CommissionWithdrawalUpdate) AddUserID(v int64) *CommissionWithdrawalUpdate {
+	_u.mutation.AddUserID(v)
+	return _u
+}
+
+// SetAmount sets the "amount" field.
+func (_u *CommissionWithdrawalUpdate) SetAmount(v float64) *CommissionWithdrawalUpdate {
+	_u.mutation.ResetAmount()
+	_u.mutation.SetAmount(v)
+	return _u
+}
+
+// SetNillableAmount sets the "amount" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdate) SetNillableAmount(v *float64) *CommissionWithdrawalUpdate {
+	if v != nil {
+		_u.SetAmount(*v)
+	}
+	return _u
+}
+
+// AddAmount adds value to the "amount" field.
+func (_u *CommissionWithdrawalUpdate) AddAmount(v float64) *CommissionWithdrawalUpdate {
+	_u.mutation.AddAmount(v)
+	return _u
+}
+
+// SetMethod sets the "method" field.
+func (_u *CommissionWithdrawalUpdate) SetMethod(v string) *CommissionWithdrawalUpdate {
+	_u.mutation.SetMethod(v)
+	return _u
+}
+
+// SetNillableMethod sets the "method" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdate) SetNillableMethod(v *string) *CommissionWithdrawalUpdate {
+	if v != nil {
+		_u.SetMethod(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *CommissionWithdrawalUpdate) SetStatus(v string) *CommissionWithdrawalUpdate {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdate) SetNillableStatus(v *string) *CommissionWithdrawalUpdate {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetAccountInfo sets the "account_info" field.
+func (_u *CommissionWithdrawalUpdate) SetAccountInfo(v string) *CommissionWithdrawalUpdate {
+	_u.mutation.SetAccountInfo(v)
+	return _u
+}
+
+// SetNillableAccountInfo sets the "account_info" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdate) SetNillableAccountInfo(v *string) *CommissionWithdrawalUpdate {
+	if v != nil {
+		_u.SetAccountInfo(*v)
+	}
+	return _u
+}
+
+// SetProcessedBy sets the "processed_by" field.
+func (_u *CommissionWithdrawalUpdate) SetProcessedBy(v int64) *CommissionWithdrawalUpdate {
+	_u.mutation.ResetProcessedBy()
+	_u.mutation.SetProcessedBy(v)
+	return _u
+}
+
+// SetNillableProcessedBy sets the "processed_by" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdate) SetNillableProcessedBy(v *int64) *CommissionWithdrawalUpdate {
+	if v != nil {
+		_u.SetProcessedBy(*v)
+	}
+	return _u
+}
+
+// AddProcessedBy adds value to the "processed_by" field.
+func (_u *CommissionWithdrawalUpdate) AddProcessedBy(v int64) *CommissionWithdrawalUpdate {
+	_u.mutation.AddProcessedBy(v)
+	return _u
+}
+
+// ClearProcessedBy clears the value of the "processed_by" field.
+func (_u *CommissionWithdrawalUpdate) ClearProcessedBy() *CommissionWithdrawalUpdate {
+	_u.mutation.ClearProcessedBy()
+	return _u
+}
+
+// SetAdminNotes sets the "admin_notes" field.
+func (_u *CommissionWithdrawalUpdate) SetAdminNotes(v string) *CommissionWithdrawalUpdate {
+	_u.mutation.SetAdminNotes(v)
+	return _u
+}
+
+// SetNillableAdminNotes sets the "admin_notes" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdate) SetNillableAdminNotes(v *string) *CommissionWithdrawalUpdate {
+	if v != nil {
+		_u.SetAdminNotes(*v)
+	}
+	return _u
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (_u *CommissionWithdrawalUpdate) SetProcessedAt(v time.Time) *CommissionWithdrawalUpdate {
+	_u.mutation.SetProcessedAt(v)
+	return _u
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdate) SetNillableProcessedAt(v *time.Time) *CommissionWithdrawalUpdate {
+	if v != nil {
+		_u.SetProcessedAt(*v)
+	}
+	return _u
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (_u *CommissionWithdrawalUpdate) ClearProcessedAt() *CommissionWithdrawalUpdate {
+	_u.mutation.ClearProcessedAt()
+	return _u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_u *CommissionWithdrawalUpdate) SetCreatedAt(v time.Time) *CommissionWithdrawalUpdate {
+	_u.mutation.SetCreatedAt(v)
+	return _u
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdate) SetNillableCreatedAt(v *time.Time) *CommissionWithdrawalUpdate {
+	if v != nil {
+		_u.SetCreatedAt(*v)
+	}
+	return _u
+}
+
+// Mutation returns the CommissionWithdrawalMutation object of the builder.
+func (_u *CommissionWithdrawalUpdate) Mutation() *CommissionWithdrawalMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *CommissionWithdrawalUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *CommissionWithdrawalUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *CommissionWithdrawalUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *CommissionWithdrawalUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *CommissionWithdrawalUpdate) check() error {
+	if v, ok := _u.mutation.Method(); ok {
+		if err := commissionwithdrawal.MethodValidator(v); err != nil {
+			return &ValidationError{Name: "method", err: fmt.Errorf(`ent: validator failed for field "CommissionWithdrawal.method": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Status(); ok {
+		if err := commissionwithdrawal.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "CommissionWithdrawal.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *CommissionWithdrawalUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(commissionwithdrawal.Table, commissionwithdrawal.Columns, sqlgraph.NewFieldSpec(commissionwithdrawal.FieldID, field.TypeInt64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(commissionwithdrawal.FieldUserID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUserID(); ok {
+		_spec.AddField(commissionwithdrawal.FieldUserID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.Amount(); ok {
+		_spec.SetField(commissionwithdrawal.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedAmount(); ok {
+		_spec.AddField(commissionwithdrawal.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Method(); ok {
+		_spec.SetField(commissionwithdrawal.FieldMethod, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(commissionwithdrawal.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.AccountInfo(); ok {
+		_spec.SetField(commissionwithdrawal.FieldAccountInfo, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ProcessedBy(); ok {
+		_spec.SetField(commissionwithdrawal.FieldProcessedBy, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedProcessedBy(); ok {
+		_spec.AddField(commissionwithdrawal.FieldProcessedBy, field.TypeInt64, value)
+	}
+	if _u.mutation.ProcessedByCleared() {
+		_spec.ClearField(commissionwithdrawal.FieldProcessedBy, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.AdminNotes(); ok {
+		_spec.SetField(commissionwithdrawal.FieldAdminNotes, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ProcessedAt(); ok {
+		_spec.SetField(commissionwithdrawal.FieldProcessedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ProcessedAtCleared() {
+		_spec.ClearField(commissionwithdrawal.FieldProcessedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.CreatedAt(); ok {
+		_spec.SetField(commissionwithdrawal.FieldCreatedAt, field.TypeTime, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{commissionwithdrawal.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// CommissionWithdrawalUpdateOne is the builder for updating a single CommissionWithdrawal entity.
+type CommissionWithdrawalUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CommissionWithdrawalMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *CommissionWithdrawalUpdateOne) SetUserID(v int64) *CommissionWithdrawalUpdateOne {
+	_u.mutation.ResetUserID()
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdateOne) SetNillableUserID(v *int64) *CommissionWithdrawalUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// AddUserID adds value to the "user_id" field.
+func (_u *CommissionWithdrawalUpdateOne) AddUserID(v int64) *CommissionWithdrawalUpdateOne {
+	_u.mutation.AddUserID(v)
+	return _u
+}
+
+// SetAmount sets the "amount" field.
+func (_u *CommissionWithdrawalUpdateOne) SetAmount(v float64) *CommissionWithdrawalUpdateOne {
+	_u.mutation.ResetAmount()
+	_u.mutation.SetAmount(v)
+	return _u
+}
+
+// SetNillableAmount sets the "amount" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdateOne) SetNillableAmount(v *float64) *CommissionWithdrawalUpdateOne {
+	if v != nil {
+		_u.SetAmount(*v)
+	}
+	return _u
+}
+
+// AddAmount adds value to the "amount" field.
+func (_u *CommissionWithdrawalUpdateOne) AddAmount(v float64) *CommissionWithdrawalUpdateOne {
+	_u.mutation.AddAmount(v)
+	return _u
+}
+
+// SetMethod sets the "method" field.
+func (_u *CommissionWithdrawalUpdateOne) SetMethod(v string) *CommissionWithdrawalUpdateOne {
+	_u.mutation.SetMethod(v)
+	return _u
+}
+
+// SetNillableMethod sets the "method" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdateOne) SetNillableMethod(v *string) *CommissionWithdrawalUpdateOne {
+	if v != nil {
+		_u.SetMethod(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *CommissionWithdrawalUpdateOne) SetStatus(v string) *CommissionWithdrawalUpdateOne {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdateOne) SetNillableStatus(v *string) *CommissionWithdrawalUpdateOne {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetAccountInfo sets the "account_info" field.
+func (_u *CommissionWithdrawalUpdateOne) SetAccountInfo(v string) *CommissionWithdrawalUpdateOne {
+	_u.mutation.SetAccountInfo(v)
+	return _u
+}
+
+// SetNillableAccountInfo sets the "account_info" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdateOne) SetNillableAccountInfo(v *string) *CommissionWithdrawalUpdateOne {
+	if v != nil {
+		_u.SetAccountInfo(*v)
+	}
+	return _u
+}
+
+// SetProcessedBy sets the "processed_by" field.
+func (_u *CommissionWithdrawalUpdateOne) SetProcessedBy(v int64) *CommissionWithdrawalUpdateOne {
+	_u.mutation.ResetProcessedBy()
+	_u.mutation.SetProcessedBy(v)
+	return _u
+}
+
+// SetNillableProcessedBy sets the "processed_by" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdateOne) SetNillableProcessedBy(v *int64) *CommissionWithdrawalUpdateOne {
+	if v != nil {
+		_u.SetProcessedBy(*v)
+	}
+	return _u
+}
+
+// AddProcessedBy adds value to the "processed_by" field.
+func (_u *CommissionWithdrawalUpdateOne) AddProcessedBy(v int64) *CommissionWithdrawalUpdateOne {
+	_u.mutation.AddProcessedBy(v)
+	return _u
+}
+
+// ClearProcessedBy clears the value of the "processed_by" field.
+func (_u *CommissionWithdrawalUpdateOne) ClearProcessedBy() *CommissionWithdrawalUpdateOne {
+	_u.mutation.ClearProcessedBy()
+	return _u
+}
+
+// SetAdminNotes sets the "admin_notes" field.
+func (_u *CommissionWithdrawalUpdateOne) SetAdminNotes(v string) *CommissionWithdrawalUpdateOne {
+	_u.mutation.SetAdminNotes(v)
+	return _u
+}
+
+// SetNillableAdminNotes sets the "admin_notes" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdateOne) SetNillableAdminNotes(v *string) *CommissionWithdrawalUpdateOne {
+	if v != nil {
+		_u.SetAdminNotes(*v)
+	}
+	return _u
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (_u *CommissionWithdrawalUpdateOne) SetProcessedAt(v time.Time) *CommissionWithdrawalUpdateOne {
+	_u.mutation.SetProcessedAt(v)
+	return _u
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdateOne) SetNillableProcessedAt(v *time.Time) *CommissionWithdrawalUpdateOne {
+	if v != nil {
+		_u.SetProcessedAt(*v)
+	}
+	return _u
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (_u *CommissionWithdrawalUpdateOne) ClearProcessedAt() *CommissionWithdrawalUpdateOne {
+	_u.mutation.ClearProcessedAt()
+	return _u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_u *CommissionWithdrawalUpdateOne) SetCreatedAt(v time.Time) *CommissionWithdrawalUpdateOne {
+	_u.mutation.SetCreatedAt(v)
+	return _u
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_u *CommissionWithdrawalUpdateOne) SetNillableCreatedAt(v *time.Time) *CommissionWithdrawalUpdateOne {
+	if v != nil {
+		_u.SetCreatedAt(*v)
+	}
+	return _u
+}
+
+// Mutation returns the CommissionWithdrawalMutation object of the builder.
+func (_u *CommissionWithdrawalUpdateOne) Mutation() *CommissionWithdrawalMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the CommissionWithdrawalUpdate builder.
+func (_u *CommissionWithdrawalUpdateOne) Where(ps ...predicate.CommissionWithdrawal) *CommissionWithdrawalUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *CommissionWithdrawalUpdateOne) Select(field string, fields ...string) *CommissionWithdrawalUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated CommissionWithdrawal entity.
+func (_u *CommissionWithdrawalUpdateOne) Save(ctx context.Context) (*CommissionWithdrawal, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *CommissionWithdrawalUpdateOne) SaveX(ctx context.Context) *CommissionWithdrawal {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *CommissionWithdrawalUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *CommissionWithdrawalUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *CommissionWithdrawalUpdateOne) check() error {
+	if v, ok := _u.mutation.Method(); ok {
+		if err := commissionwithdrawal.MethodValidator(v); err != nil {
+			return &ValidationError{Name: "method", err: fmt.Errorf(`ent: validator failed for field "CommissionWithdrawal.method": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Status(); ok {
+		if err := commissionwithdrawal.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "CommissionWithdrawal.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *CommissionWithdrawalUpdateOne) sqlSave(ctx context.Context) (_node *CommissionWithdrawal, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(commissionwithdrawal.Table, commissionwithdrawal.Columns, sqlgraph.NewFieldSpec(commissionwithdrawal.FieldID, field.TypeInt64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "CommissionWithdrawal.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, commissionwithdrawal.FieldID)
+		for _, f := range fields {
+			if !commissionwithdrawal.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != commissionwithdrawal.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(commissionwithdrawal.FieldUserID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUserID(); ok {
+		_spec.AddField(commissionwithdrawal.FieldUserID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.Amount(); ok {
+		_spec.SetField(commissionwithdrawal.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedAmount(); ok {
+		_spec.AddField(commissionwithdrawal.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Method(); ok {
+		_spec.SetField(commissionwithdrawal.FieldMethod, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(commissionwithdrawal.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.AccountInfo(); ok {
+		_spec.SetField(commissionwithdrawal.FieldAccountInfo, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ProcessedBy(); ok {
+		_spec.SetField(commissionwithdrawal.FieldProcessedBy, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedProcessedBy(); ok {
+		_spec.AddField(commissionwithdrawal.FieldProcessedBy, field.TypeInt64, value)
+	}
+	if _u.mutation.ProcessedByCleared() {
+		_spec.ClearField(commissionwithdrawal.FieldProcessedBy, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.AdminNotes(); ok {
+		_spec.SetField(commissionwithdrawal.FieldAdminNotes, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ProcessedAt(); ok {
+		_spec.SetField(commissionwithdrawal.FieldProcessedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ProcessedAtCleared() {
+		_spec.ClearField(commissionwithdrawal.FieldProcessedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.CreatedAt(); ok {
+		_spec.SetField(commissionwithdrawal.FieldCreatedAt, field.TypeTime, value)
+	}
+	_node = &CommissionWithdrawal{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{commissionwithdrawal.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -16,9 +16,14 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/accountgroup"
 	"github.com/Wei-Shaw/sub2api/ent/adminactionlog"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
+	"github.com/Wei-Shaw/sub2api/ent/commissionwithdrawal"
 	"github.com/Wei-Shaw/sub2api/ent/group"
 	"github.com/Wei-Shaw/sub2api/ent/invitation"
+	"github.com/Wei-Shaw/sub2api/ent/invitecommission"
 	"github.com/Wei-Shaw/sub2api/ent/invitelog"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
+	"github.com/Wei-Shaw/sub2api/ent/organizationinvitation"
+	"github.com/Wei-Shaw/sub2api/ent/organizationmember"
 	"github.com/Wei-Shaw/sub2api/ent/plan"
 	"github.com/Wei-Shaw/sub2api/ent/promocode"
 	"github.com/Wei-Shaw/sub2api/ent/promocodeusage"
@@ -31,7 +36,9 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/userallowedgroup"
 	"github.com/Wei-Shaw/sub2api/ent/userattributedefinition"
 	"github.com/Wei-Shaw/sub2api/ent/userattributevalue"
+	"github.com/Wei-Shaw/sub2api/ent/userrecoverycode"
 	"github.com/Wei-Shaw/sub2api/ent/usersubscription"
+	"github.com/Wei-Shaw/sub2api/ent/webauthncredential"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -96,9 +103,14 @@ func checkColumn(t, c string) error {
 			account.Table:                 account.ValidColumn,
 			accountgroup.Table:            accountgroup.ValidColumn,
 			adminactionlog.Table:          adminactionlog.ValidColumn,
+			commissionwithdrawal.Table:    commissionwithdrawal.ValidColumn,
 			group.Table:                   group.ValidColumn,
 			invitation.Table:              invitation.ValidColumn,
+			invitecommission.Table:        invitecommission.ValidColumn,
 			invitelog.Table:               invitelog.ValidColumn,
+			organization.Table:            organization.ValidColumn,
+			organizationinvitation.Table:  organizationinvitation.ValidColumn,
+			organizationmember.Table:      organizationmember.ValidColumn,
 			plan.Table:                    plan.ValidColumn,
 			promocode.Table:               promocode.ValidColumn,
 			promocodeusage.Table:          promocodeusage.ValidColumn,
@@ -111,7 +123,9 @@ func checkColumn(t, c string) error {
 			userallowedgroup.Table:        userallowedgroup.ValidColumn,
 			userattributedefinition.Table: userattributedefinition.ValidColumn,
 			userattributevalue.Table:      userattributevalue.ValidColumn,
+			userrecoverycode.Table:        userrecoverycode.ValidColumn,
 			usersubscription.Table:        usersubscription.ValidColumn,
+			webauthncredential.Table:      webauthncredential.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -60,6 +60,26 @@ type Group struct {
 	ModelRouting map[string][]int64 `json:"model_routing,omitempty"`
 	// 是否启用模型路由配置
 	ModelRoutingEnabled bool `json:"model_routing_enabled,omitempty"`
+	// 账号调度策略：空=默认(优先级>负载>LRU), least_loaded, weighted, quota_aware, cost_aware
+	SchedulingStrategy string `json:"scheduling_strategy,omitempty"`
+	// 内容策略：黑名单、PII 检测、图片限制、外部审核 Webhook
+	ContentPolicy json.RawMessage `json:"content_policy,omitempty"`
+	// 请求改写规则：按平台/模型匹配，修改请求体字段、系统提示词与上游请求头
+	RewriteRules json.RawMessage `json:"rewrite_rules,omitempty"`
+	// 面向用户的虚拟模型名：按顺序解析为 (平台, 模型) 目标列表并逐个故障转移
+	ModelAliases json.RawMessage `json:"model_aliases,omitempty"`
+	// 订阅额度用尽后的处理：reject=拒绝, balance=按超额倍率扣余额, fallback_group=降级到指定分组
+	OveragePolicy string `json:"overage_policy,omitempty"`
+	// balance 策略的计费倍率（替代订阅分组倍率）
+	OverageRateMultiplier float64 `json:"overage_rate_multiplier,omitempty"`
+	// fallback_group 策略使用的按量计费分组 ID
+	OverageGroupID *int64 `json:"overage_group_id,omitempty"`
+	// 每个 default_validity_days 周期的余额价格（USD），为空表示不支持用户自助续费/切换
+	SubscriptionPrice *float64 `json:"subscription_price,omitempty"`
+	// 订阅窗口内的 token / 请求次数额度，可按模型限定，与 USD 限额同时生效
+	UsageQuotas json.RawMessage `json:"usage_quotas,omitempty"`
+	// 按会话稳定分配的加权实验组：指定账号子集或改写目标模型
+	TrafficSplit json.RawMessage `json:"traffic_split,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the GroupQuery when eager-loading is set.
 	Edges        GroupEdges `json:"edges"`
@@ -166,15 +186,15 @@ func (*Group) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case group.FieldModelRouting:
+		case group.FieldModelRouting, group.FieldContentPolicy, group.FieldRewriteRules, group.FieldModelAliases, group.FieldUsageQuotas, group.FieldTrafficSplit:
 			values[i] = new([]byte)
 		case group.FieldIsExclusive, group.FieldClaudeCodeOnly, group.FieldModelRoutingEnabled:
 			values[i] = new(sql.NullBool)
-		case group.FieldRateMultiplier, group.FieldDailyLimitUsd, group.FieldWeeklyLimitUsd, group.FieldMonthlyLimitUsd, group.FieldImagePrice1k, group.FieldImagePrice2k, group.FieldImagePrice4k:
+		case group.FieldRateMultiplier, group.FieldDailyLimitUsd, group.FieldWeeklyLimitUsd, group.FieldMonthlyLimitUsd, group.FieldImagePrice1k, group.FieldImagePrice2k, group.FieldImagePrice4k, group.FieldOverageRateMultiplier, group.FieldSubscriptionPrice:
 			values[i] = new(sql.NullFloat64)
-		case group.FieldID, group.FieldDefaultValidityDays, group.FieldFallbackGroupID:
+		case group.FieldID, group.FieldDefaultValidityDays, group.FieldFallbackGroupID, group.FieldOverageGroupID:
 			values[i] = new(sql.NullInt64)
-		case group.FieldName, group.FieldDescription, group.FieldStatus, group.FieldPlatform, group.FieldSubscriptionType:
+		case group.FieldName, group.FieldDescription, group.FieldStatus, group.FieldPlatform, group.FieldSubscriptionType, group.FieldSchedulingStrategy, group.FieldOveragePolicy:
 			values[i] = new(sql.NullString)
 		case group.FieldCreatedAt, group.FieldUpdatedAt, group.FieldDeletedAt:
 			values[i] = new(sql.NullTime)
@@ -336,6 +356,78 @@ func (_m *Group) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.ModelRoutingEnabled = value.Bool
 			}
+		case group.FieldSchedulingStrategy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field scheduling_strategy", values[i])
+			} else if value.Valid {
+				_m.SchedulingStrategy = value.String
+			}
+		case group.FieldContentPolicy:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field content_policy", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.ContentPolicy); err != nil {
+					return fmt.Errorf("unmarshal field content_policy: %w", err)
+				}
+			}
+		case group.FieldRewriteRules:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field rewrite_rules", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.RewriteRules); err != nil {
+					return fmt.Errorf("unmarshal field rewrite_rules: %w", err)
+				}
+			}
+		case group.FieldModelAliases:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field model_aliases", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.ModelAliases); err != nil {
+					return fmt.Errorf("unmarshal field model_aliases: %w", err)
+				}
+			}
+		case group.FieldOveragePolicy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field overage_policy", values[i])
+			} else if value.Valid {
+				_m.OveragePolicy = value.String
+			}
+		case group.FieldOverageRateMultiplier:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field overage_rate_multiplier", values[i])
+			} else if value.Valid {
+				_m.OverageRateMultiplier = value.Float64
+			}
+		case group.FieldOverageGroupID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field overage_group_id", values[i])
+			} else if value.Valid {
+				_m.OverageGroupID = new(int64)
+				*_m.OverageGroupID = value.Int64
+			}
+		case group.FieldSubscriptionPrice:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field subscription_price", values[i])
+			} else if value.Valid {
+				_m.SubscriptionPrice = new(float64)
+				*_m.SubscriptionPrice = value.Float64
+			}
+		case group.FieldUsageQuotas:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field usage_quotas", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.UsageQuotas); err != nil {
+					return fmt.Errorf("unmarshal field usage_quotas: %w", err)
+				}
+			}
+		case group.FieldTrafficSplit:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field traffic_split", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.TrafficSplit); err != nil {
+					return fmt.Errorf("unmarshal field traffic_split: %w", err)
+				}
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -492,6 +584,40 @@ func (_m *Group) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("model_routing_enabled=")
 	builder.WriteString(fmt.Sprintf("%v", _m.ModelRoutingEnabled))
+	builder.WriteString(", ")
+	builder.WriteString("scheduling_strategy=")
+	builder.WriteString(_m.SchedulingStrategy)
+	builder.WriteString(", ")
+	builder.WriteString("content_policy=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ContentPolicy))
+	builder.WriteString(", ")
+	builder.WriteString("rewrite_rules=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RewriteRules))
+	builder.WriteString(", ")
+	builder.WriteString("model_aliases=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ModelAliases))
+	builder.WriteString(", ")
+	builder.WriteString("overage_policy=")
+	builder.WriteString(_m.OveragePolicy)
+	builder.WriteString(", ")
+	builder.WriteString("overage_rate_multiplier=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OverageRateMultiplier))
+	builder.WriteString(", ")
+	if v := _m.OverageGroupID; v != nil {
+		builder.WriteString("overage_group_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.SubscriptionPrice; v != nil {
+		builder.WriteString("subscription_price=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("usage_quotas=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UsageQuotas))
+	builder.WriteString(", ")
+	builder.WriteString("traffic_split=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TrafficSplit))
 	builder.WriteByte(')')
 	return builder.String()
 }
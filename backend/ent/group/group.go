@@ -57,6 +57,26 @@ const (
 	FieldModelRouting = "model_routing"
 	// FieldModelRoutingEnabled holds the string denoting the model_routing_enabled field in the database.
 	FieldModelRoutingEnabled = "model_routing_enabled"
+	// FieldSchedulingStrategy holds the string denoting the scheduling_strategy field in the database.
+	FieldSchedulingStrategy = "scheduling_strategy"
+	// FieldContentPolicy holds the string denoting the content_policy field in the database.
+	FieldContentPolicy = "content_policy"
+	// FieldRewriteRules holds the string denoting the rewrite_rules field in the database.
+	FieldRewriteRules = "rewrite_rules"
+	// FieldModelAliases holds the string denoting the model_aliases field in the database.
+	FieldModelAliases = "model_aliases"
+	// FieldOveragePolicy holds the string denoting the overage_policy field in the database.
+	FieldOveragePolicy = "overage_policy"
+	// FieldOverageRateMultiplier holds the string denoting the overage_rate_multiplier field in the database.
+	FieldOverageRateMultiplier = "overage_rate_multiplier"
+	// FieldOverageGroupID holds the string denoting the overage_group_id field in the database.
+	FieldOverageGroupID = "overage_group_id"
+	// FieldSubscriptionPrice holds the string denoting the subscription_price field in the database.
+	FieldSubscriptionPrice = "subscription_price"
+	// FieldUsageQuotas holds the string denoting the usage_quotas field in the database.
+	FieldUsageQuotas = "usage_quotas"
+	// FieldTrafficSplit holds the string denoting the traffic_split field in the database.
+	FieldTrafficSplit = "traffic_split"
 	// EdgeAPIKeys holds the string denoting the api_keys edge name in mutations.
 	EdgeAPIKeys = "api_keys"
 	// EdgeRedeemCodes holds the string denoting the redeem_codes edge name in mutations.
@@ -153,6 +173,16 @@ var Columns = []string{
 	FieldFallbackGroupID,
 	FieldModelRouting,
 	FieldModelRoutingEnabled,
+	FieldSchedulingStrategy,
+	FieldContentPolicy,
+	FieldRewriteRules,
+	FieldModelAliases,
+	FieldOveragePolicy,
+	FieldOverageRateMultiplier,
+	FieldOverageGroupID,
+	FieldSubscriptionPrice,
+	FieldUsageQuotas,
+	FieldTrafficSplit,
 }
 
 var (
@@ -212,6 +242,16 @@ var (
 	DefaultClaudeCodeOnly bool
 	// DefaultModelRoutingEnabled holds the default value on creation for the "model_routing_enabled" field.
 	DefaultModelRoutingEnabled bool
+	// DefaultSchedulingStrategy holds the default value on creation for the "scheduling_strategy" field.
+	DefaultSchedulingStrategy string
+	// SchedulingStrategyValidator is a validator for the "scheduling_strategy" field. It is called by the builders before save.
+	SchedulingStrategyValidator func(string) error
+	// DefaultOveragePolicy holds the default value on creation for the "overage_policy" field.
+	DefaultOveragePolicy string
+	// OveragePolicyValidator is a validator for the "overage_policy" field. It is called by the builders before save.
+	OveragePolicyValidator func(string) error
+	// DefaultOverageRateMultiplier holds the default value on creation for the "overage_rate_multiplier" field.
+	DefaultOverageRateMultiplier float64
 )
 
 // OrderOption defines the ordering options for the Group queries.
@@ -322,6 +362,31 @@ func ByModelRoutingEnabled(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldModelRoutingEnabled, opts...).ToFunc()
 }
 
+// BySchedulingStrategy orders the results by the scheduling_strategy field.
+func BySchedulingStrategy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSchedulingStrategy, opts...).ToFunc()
+}
+
+// ByOveragePolicy orders the results by the overage_policy field.
+func ByOveragePolicy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOveragePolicy, opts...).ToFunc()
+}
+
+// ByOverageRateMultiplier orders the results by the overage_rate_multiplier field.
+func ByOverageRateMultiplier(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOverageRateMultiplier, opts...).ToFunc()
+}
+
+// ByOverageGroupID orders the results by the overage_group_id field.
+func ByOverageGroupID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOverageGroupID, opts...).ToFunc()
+}
+
+// BySubscriptionPrice orders the results by the subscription_price field.
+func BySubscriptionPrice(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSubscriptionPrice, opts...).ToFunc()
+}
+
 // ByAPIKeysCount orders the results by api_keys count.
 func ByAPIKeysCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
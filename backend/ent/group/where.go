@@ -155,6 +155,31 @@ func ModelRoutingEnabled(v bool) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldModelRoutingEnabled, v))
 }
 
+// SchedulingStrategy applies equality check predicate on the "scheduling_strategy" field. It's identical to SchedulingStrategyEQ.
+func SchedulingStrategy(v string) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldSchedulingStrategy, v))
+}
+
+// OveragePolicy applies equality check predicate on the "overage_policy" field. It's identical to OveragePolicyEQ.
+func OveragePolicy(v string) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldOveragePolicy, v))
+}
+
+// OverageRateMultiplier applies equality check predicate on the "overage_rate_multiplier" field. It's identical to OverageRateMultiplierEQ.
+func OverageRateMultiplier(v float64) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldOverageRateMultiplier, v))
+}
+
+// OverageGroupID applies equality check predicate on the "overage_group_id" field. It's identical to OverageGroupIDEQ.
+func OverageGroupID(v int64) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldOverageGroupID, v))
+}
+
+// SubscriptionPrice applies equality check predicate on the "subscription_price" field. It's identical to SubscriptionPriceEQ.
+func SubscriptionPrice(v float64) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldSubscriptionPrice, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldCreatedAt, v))
@@ -1090,6 +1115,326 @@ func ModelRoutingEnabledNEQ(v bool) predicate.Group {
 	return predicate.Group(sql.FieldNEQ(FieldModelRoutingEnabled, v))
 }
 
+// SchedulingStrategyEQ applies the EQ predicate on the "scheduling_strategy" field.
+func SchedulingStrategyEQ(v string) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyNEQ applies the NEQ predicate on the "scheduling_strategy" field.
+func SchedulingStrategyNEQ(v string) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyIn applies the In predicate on the "scheduling_strategy" field.
+func SchedulingStrategyIn(vs ...string) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldSchedulingStrategy, vs...))
+}
+
+// SchedulingStrategyNotIn applies the NotIn predicate on the "scheduling_strategy" field.
+func SchedulingStrategyNotIn(vs ...string) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldSchedulingStrategy, vs...))
+}
+
+// SchedulingStrategyGT applies the GT predicate on the "scheduling_strategy" field.
+func SchedulingStrategyGT(v string) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyGTE applies the GTE predicate on the "scheduling_strategy" field.
+func SchedulingStrategyGTE(v string) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyLT applies the LT predicate on the "scheduling_strategy" field.
+func SchedulingStrategyLT(v string) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyLTE applies the LTE predicate on the "scheduling_strategy" field.
+func SchedulingStrategyLTE(v string) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyContains applies the Contains predicate on the "scheduling_strategy" field.
+func SchedulingStrategyContains(v string) predicate.Group {
+	return predicate.Group(sql.FieldContains(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyHasPrefix applies the HasPrefix predicate on the "scheduling_strategy" field.
+func SchedulingStrategyHasPrefix(v string) predicate.Group {
+	return predicate.Group(sql.FieldHasPrefix(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyHasSuffix applies the HasSuffix predicate on the "scheduling_strategy" field.
+func SchedulingStrategyHasSuffix(v string) predicate.Group {
+	return predicate.Group(sql.FieldHasSuffix(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyEqualFold applies the EqualFold predicate on the "scheduling_strategy" field.
+func SchedulingStrategyEqualFold(v string) predicate.Group {
+	return predicate.Group(sql.FieldEqualFold(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyContainsFold applies the ContainsFold predicate on the "scheduling_strategy" field.
+func SchedulingStrategyContainsFold(v string) predicate.Group {
+	return predicate.Group(sql.FieldContainsFold(FieldSchedulingStrategy, v))
+}
+
+// ContentPolicyIsNil applies the IsNil predicate on the "content_policy" field.
+func ContentPolicyIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldContentPolicy))
+}
+
+// ContentPolicyNotNil applies the NotNil predicate on the "content_policy" field.
+func ContentPolicyNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldContentPolicy))
+}
+
+// RewriteRulesIsNil applies the IsNil predicate on the "rewrite_rules" field.
+func RewriteRulesIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldRewriteRules))
+}
+
+// RewriteRulesNotNil applies the NotNil predicate on the "rewrite_rules" field.
+func RewriteRulesNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldRewriteRules))
+}
+
+// ModelAliasesIsNil applies the IsNil predicate on the "model_aliases" field.
+func ModelAliasesIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldModelAliases))
+}
+
+// ModelAliasesNotNil applies the NotNil predicate on the "model_aliases" field.
+func ModelAliasesNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldModelAliases))
+}
+
+// OveragePolicyEQ applies the EQ predicate on the "overage_policy" field.
+func OveragePolicyEQ(v string) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldOveragePolicy, v))
+}
+
+// OveragePolicyNEQ applies the NEQ predicate on the "overage_policy" field.
+func OveragePolicyNEQ(v string) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldOveragePolicy, v))
+}
+
+// OveragePolicyIn applies the In predicate on the "overage_policy" field.
+func OveragePolicyIn(vs ...string) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldOveragePolicy, vs...))
+}
+
+// OveragePolicyNotIn applies the NotIn predicate on the "overage_policy" field.
+func OveragePolicyNotIn(vs ...string) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldOveragePolicy, vs...))
+}
+
+// OveragePolicyGT applies the GT predicate on the "overage_policy" field.
+func OveragePolicyGT(v string) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldOveragePolicy, v))
+}
+
+// OveragePolicyGTE applies the GTE predicate on the "overage_policy" field.
+func OveragePolicyGTE(v string) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldOveragePolicy, v))
+}
+
+// OveragePolicyLT applies the LT predicate on the "overage_policy" field.
+func OveragePolicyLT(v string) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldOveragePolicy, v))
+}
+
+// OveragePolicyLTE applies the LTE predicate on the "overage_policy" field.
+func OveragePolicyLTE(v string) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldOveragePolicy, v))
+}
+
+// OveragePolicyContains applies the Contains predicate on the "overage_policy" field.
+func OveragePolicyContains(v string) predicate.Group {
+	return predicate.Group(sql.FieldContains(FieldOveragePolicy, v))
+}
+
+// OveragePolicyHasPrefix applies the HasPrefix predicate on the "overage_policy" field.
+func OveragePolicyHasPrefix(v string) predicate.Group {
+	return predicate.Group(sql.FieldHasPrefix(FieldOveragePolicy, v))
+}
+
+// OveragePolicyHasSuffix applies the HasSuffix predicate on the "overage_policy" field.
+func OveragePolicyHasSuffix(v string) predicate.Group {
+	return predicate.Group(sql.FieldHasSuffix(FieldOveragePolicy, v))
+}
+
+// OveragePolicyEqualFold applies the EqualFold predicate on the "overage_policy" field.
+func OveragePolicyEqualFold(v string) predicate.Group {
+	return predicate.Group(sql.FieldEqualFold(FieldOveragePolicy, v))
+}
+
+// OveragePolicyContainsFold applies the ContainsFold predicate on the "overage_policy" field.
+func OveragePolicyContainsFold(v string) predicate.Group {
+	return predicate.Group(sql.FieldContainsFold(FieldOveragePolicy, v))
+}
+
+// OverageRateMultiplierEQ applies the EQ predicate on the "overage_rate_multiplier" field.
+func OverageRateMultiplierEQ(v float64) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldOverageRateMultiplier, v))
+}
+
+// OverageRateMultiplierNEQ applies the NEQ predicate on the "overage_rate_multiplier" field.
+func OverageRateMultiplierNEQ(v float64) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldOverageRateMultiplier, v))
+}
+
+// OverageRateMultiplierIn applies the In predicate on the "overage_rate_multiplier" field.
+func OverageRateMultiplierIn(vs ...float64) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldOverageRateMultiplier, vs...))
+}
+
+// OverageRateMultiplierNotIn applies the NotIn predicate on the "overage_rate_multiplier" field.
+func OverageRateMultiplierNotIn(vs ...float64) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldOverageRateMultiplier, vs...))
+}
+
+// OverageRateMultiplierGT applies the GT predicate on the "overage_rate_multiplier" field.
+func OverageRateMultiplierGT(v float64) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldOverageRateMultiplier, v))
+}
+
+// OverageRateMultiplierGTE applies the GTE predicate on the "overage_rate_multiplier" field.
+func OverageRateMultiplierGTE(v float64) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldOverageRateMultiplier, v))
+}
+
+// OverageRateMultiplierLT applies the LT predicate on the "overage_rate_multiplier" field.
+func OverageRateMultiplierLT(v float64) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldOverageRateMultiplier, v))
+}
+
+// OverageRateMultiplierLTE applies the LTE predicate on the "overage_rate_multiplier" field.
+func OverageRateMultiplierLTE(v float64) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldOverageRateMultiplier, v))
+}
+
+// OverageGroupIDEQ applies the EQ predicate on the "overage_group_id" field.
+func OverageGroupIDEQ(v int64) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldOverageGroupID, v))
+}
+
+// OverageGroupIDNEQ applies the NEQ predicate on the "overage_group_id" field.
+func OverageGroupIDNEQ(v int64) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldOverageGroupID, v))
+}
+
+// OverageGroupIDIn applies the In predicate on the "overage_group_id" field.
+func OverageGroupIDIn(vs ...int64) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldOverageGroupID, vs...))
+}
+
+// OverageGroupIDNotIn applies the NotIn predicate on the "overage_group_id" field.
+func OverageGroupIDNotIn(vs ...int64) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldOverageGroupID, vs...))
+}
+
+// OverageGroupIDGT applies the GT predicate on the "overage_group_id" field.
+func OverageGroupIDGT(v int64) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldOverageGroupID, v))
+}
+
+// OverageGroupIDGTE applies the GTE predicate on the "overage_group_id" field.
+func OverageGroupIDGTE(v int64) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldOverageGroupID, v))
+}
+
+// OverageGroupIDLT applies the LT predicate on the "overage_group_id" field.
+func OverageGroupIDLT(v int64) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldOverageGroupID, v))
+}
+
+// OverageGroupIDLTE applies the LTE predicate on the "overage_group_id" field.
+func OverageGroupIDLTE(v int64) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldOverageGroupID, v))
+}
+
+// OverageGroupIDIsNil applies the IsNil predicate on the "overage_group_id" field.
+func OverageGroupIDIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldOverageGroupID))
+}
+
+// OverageGroupIDNotNil applies the NotNil predicate on the "overage_group_id" field.
+func OverageGroupIDNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldOverageGroupID))
+}
+
+// SubscriptionPriceEQ applies the EQ predicate on the "subscription_price" field.
+func SubscriptionPriceEQ(v float64) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldSubscriptionPrice, v))
+}
+
+// SubscriptionPriceNEQ applies the NEQ predicate on the "subscription_price" field.
+func SubscriptionPriceNEQ(v float64) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldSubscriptionPrice, v))
+}
+
+// SubscriptionPriceIn applies the In predicate on the "subscription_price" field.
+func SubscriptionPriceIn(vs ...float64) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldSubscriptionPrice, vs...))
+}
+
+// SubscriptionPriceNotIn applies the NotIn predicate on the "subscription_price" field.
+func SubscriptionPriceNotIn(vs ...float64) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldSubscriptionPrice, vs...))
+}
+
+// SubscriptionPriceGT applies the GT predicate on the "subscription_price" field.
+func SubscriptionPriceGT(v float64) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldSubscriptionPrice, v))
+}
+
+// SubscriptionPriceGTE applies the GTE predicate on the "subscription_price" field.
+func SubscriptionPriceGTE(v float64) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldSubscriptionPrice, v))
+}
+
+// SubscriptionPriceLT applies the LT predicate on the "subscription_price" field.
+func SubscriptionPriceLT(v float64) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldSubscriptionPrice, v))
+}
+
+// SubscriptionPriceLTE applies the LTE predicate on the "subscription_price" field.
+func SubscriptionPriceLTE(v float64) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldSubscriptionPrice, v))
+}
+
+// SubscriptionPriceIsNil applies the IsNil predicate on the "subscription_price" field.
+func SubscriptionPriceIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldSubscriptionPrice))
+}
+
+// SubscriptionPriceNotNil applies the NotNil predicate on the "subscription_price" field.
+func SubscriptionPriceNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldSubscriptionPrice))
+}
+
+// UsageQuotasIsNil applies the IsNil predicate on the "usage_quotas" field.
+func UsageQuotasIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldUsageQuotas))
+}
+
+// UsageQuotasNotNil applies the NotNil predicate on the "usage_quotas" field.
+func UsageQuotasNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldUsageQuotas))
+}
+
+// TrafficSplitIsNil applies the IsNil predicate on the "traffic_split" field.
+func TrafficSplitIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldTrafficSplit))
+}
+
+// TrafficSplitNotNil applies the NotNil predicate on the "traffic_split" field.
+func TrafficSplitNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldTrafficSplit))
+}
+
 // HasAPIKeys applies the HasEdge predicate on the "api_keys" edge.
 func HasAPIKeys() predicate.Group {
 	return predicate.Group(func(s *sql.Selector) {
@@ -4,6 +4,7 @@ package ent
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -306,6 +307,106 @@ func (_c *GroupCreate) SetNillableModelRoutingEnabled(v *bool) *GroupCreate {
 	return _c
 }
 
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (_c *GroupCreate) SetSchedulingStrategy(v string) *GroupCreate {
+	_c.mutation.SetSchedulingStrategy(v)
+	return _c
+}
+
+// SetNillableSchedulingStrategy sets the "scheduling_strategy" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableSchedulingStrategy(v *string) *GroupCreate {
+	if v != nil {
+		_c.SetSchedulingStrategy(*v)
+	}
+	return _c
+}
+
+// SetContentPolicy sets the "content_policy" field.
+func (_c *GroupCreate) SetContentPolicy(v json.RawMessage) *GroupCreate {
+	_c.mutation.SetContentPolicy(v)
+	return _c
+}
+
+// SetRewriteRules sets the "rewrite_rules" field.
+func (_c *GroupCreate) SetRewriteRules(v json.RawMessage) *GroupCreate {
+	_c.mutation.SetRewriteRules(v)
+	return _c
+}
+
+// SetModelAliases sets the "model_aliases" field.
+func (_c *GroupCreate) SetModelAliases(v json.RawMessage) *GroupCreate {
+	_c.mutation.SetModelAliases(v)
+	return _c
+}
+
+// SetOveragePolicy sets the "overage_policy" field.
+func (_c *GroupCreate) SetOveragePolicy(v string) *GroupCreate {
+	_c.mutation.SetOveragePolicy(v)
+	return _c
+}
+
+// SetNillableOveragePolicy sets the "overage_policy" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableOveragePolicy(v *string) *GroupCreate {
+	if v != nil {
+		_c.SetOveragePolicy(*v)
+	}
+	return _c
+}
+
+// SetOverageRateMultiplier sets the "overage_rate_multiplier" field.
+func (_c *GroupCreate) SetOverageRateMultiplier(v float64) *GroupCreate {
+	_c.mutation.SetOverageRateMultiplier(v)
+	return _c
+}
+
+// SetNillableOverageRateMultiplier sets the "overage_rate_multiplier" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableOverageRateMultiplier(v *float64) *GroupCreate {
+	if v != nil {
+		_c.SetOverageRateMultiplier(*v)
+	}
+	return _c
+}
+
+// SetOverageGroupID sets the "overage_group_id" field.
+func (_c *GroupCreate) SetOverageGroupID(v int64) *GroupCreate {
+	_c.mutation.SetOverageGroupID(v)
+	return _c
+}
+
+// SetNillableOverageGroupID sets the "overage_group_id" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableOverageGroupID(v *int64) *GroupCreate {
+	if v != nil {
+		_c.SetOverageGroupID(*v)
+	}
+	return _c
+}
+
+// SetSubscriptionPrice sets the "subscription_price" field.
+func (_c *GroupCreate) SetSubscriptionPrice(v float64) *GroupCreate {
+	_c.mutation.SetSubscriptionPrice(v)
+	return _c
+}
+
+// SetNillableSubscriptionPrice sets the "subscription_price" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableSubscriptionPrice(v *float64) *GroupCreate {
+	if v != nil {
+		_c.SetSubscriptionPrice(*v)
+	}
+	return _c
+}
+
+// SetUsageQuotas sets the "usage_quotas" field.
+func (_c *GroupCreate) SetUsageQuotas(v json.RawMessage) *GroupCreate {
+	_c.mutation.SetUsageQuotas(v)
+	return _c
+}
+
+// SetTrafficSplit sets the "traffic_split" field.
+func (_c *GroupCreate) SetTrafficSplit(v json.RawMessage) *GroupCreate {
+	_c.mutation.SetTrafficSplit(v)
+	return _c
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_c *GroupCreate) AddAPIKeyIDs(ids ...int64) *GroupCreate {
 	_c.mutation.AddAPIKeyIDs(ids...)
@@ -479,6 +580,18 @@ func (_c *GroupCreate) defaults() error {
 		v := group.DefaultModelRoutingEnabled
 		_c.mutation.SetModelRoutingEnabled(v)
 	}
+	if _, ok := _c.mutation.SchedulingStrategy(); !ok {
+		v := group.DefaultSchedulingStrategy
+		_c.mutation.SetSchedulingStrategy(v)
+	}
+	if _, ok := _c.mutation.OveragePolicy(); !ok {
+		v := group.DefaultOveragePolicy
+		_c.mutation.SetOveragePolicy(v)
+	}
+	if _, ok := _c.mutation.OverageRateMultiplier(); !ok {
+		v := group.DefaultOverageRateMultiplier
+		_c.mutation.SetOverageRateMultiplier(v)
+	}
 	return nil
 }
 
@@ -537,6 +650,25 @@ func (_c *GroupCreate) check() error {
 	if _, ok := _c.mutation.ModelRoutingEnabled(); !ok {
 		return &ValidationError{Name: "model_routing_enabled", err: errors.New(`ent: missing required field "Group.model_routing_enabled"`)}
 	}
+	if _, ok := _c.mutation.SchedulingStrategy(); !ok {
+		return &ValidationError{Name: "scheduling_strategy", err: errors.New(`ent: missing required field "Group.scheduling_strategy"`)}
+	}
+	if v, ok := _c.mutation.SchedulingStrategy(); ok {
+		if err := group.SchedulingStrategyValidator(v); err != nil {
+			return &ValidationError{Name: "scheduling_strategy", err: fmt.Errorf(`ent: validator failed for field "Group.scheduling_strategy": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.OveragePolicy(); !ok {
+		return &ValidationError{Name: "overage_policy", err: errors.New(`ent: missing required field "Group.overage_policy"`)}
+	}
+	if v, ok := _c.mutation.OveragePolicy(); ok {
+		if err := group.OveragePolicyValidator(v); err != nil {
+			return &ValidationError{Name: "overage_policy", err: fmt.Errorf(`ent: validator failed for field "Group.overage_policy": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.OverageRateMultiplier(); !ok {
+		return &ValidationError{Name: "overage_rate_multiplier", err: errors.New(`ent: missing required field "Group.overage_rate_multiplier"`)}
+	}
 	return nil
 }
 
@@ -648,6 +780,46 @@ func (_c *GroupCreate) createSpec() (*Group, *sqlgraph.CreateSpec) {
 		_spec.SetField(group.FieldModelRoutingEnabled, field.TypeBool, value)
 		_node.ModelRoutingEnabled = value
 	}
+	if value, ok := _c.mutation.SchedulingStrategy(); ok {
+		_spec.SetField(group.FieldSchedulingStrategy, field.TypeString, value)
+		_node.SchedulingStrategy = value
+	}
+	if value, ok := _c.mutation.ContentPolicy(); ok {
+		_spec.SetField(group.FieldContentPolicy, field.TypeJSON, value)
+		_node.ContentPolicy = value
+	}
+	if value, ok := _c.mutation.RewriteRules(); ok {
+		_spec.SetField(group.FieldRewriteRules, field.TypeJSON, value)
+		_node.RewriteRules = value
+	}
+	if value, ok := _c.mutation.ModelAliases(); ok {
+		_spec.SetField(group.FieldModelAliases, field.TypeJSON, value)
+		_node.ModelAliases = value
+	}
+	if value, ok := _c.mutation.OveragePolicy(); ok {
+		_spec.SetField(group.FieldOveragePolicy, field.TypeString, value)
+		_node.OveragePolicy = value
+	}
+	if value, ok := _c.mutation.OverageRateMultiplier(); ok {
+		_spec.SetField(group.FieldOverageRateMultiplier, field.TypeFloat64, value)
+		_node.OverageRateMultiplier = value
+	}
+	if value, ok := _c.mutation.OverageGroupID(); ok {
+		_spec.SetField(group.FieldOverageGroupID, field.TypeInt64, value)
+		_node.OverageGroupID = &value
+	}
+	if value, ok := _c.mutation.SubscriptionPrice(); ok {
+		_spec.SetField(group.FieldSubscriptionPrice, field.TypeFloat64, value)
+		_node.SubscriptionPrice = &value
+	}
+	if value, ok := _c.mutation.UsageQuotas(); ok {
+		_spec.SetField(group.FieldUsageQuotas, field.TypeJSON, value)
+		_node.UsageQuotas = value
+	}
+	if value, ok := _c.mutation.TrafficSplit(); ok {
+		_spec.SetField(group.FieldTrafficSplit, field.TypeJSON, value)
+		_node.TrafficSplit = value
+	}
 	if nodes := _c.mutation.APIKeysIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1158,6 +1330,186 @@ func (u *GroupUpsert) UpdateModelRoutingEnabled() *GroupUpsert {
 	return u
 }
 
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (u *GroupUpsert) SetSchedulingStrategy(v string) *GroupUpsert {
+	u.Set(group.FieldSchedulingStrategy, v)
+	return u
+}
+
+// UpdateSchedulingStrategy sets the "scheduling_strategy" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateSchedulingStrategy() *GroupUpsert {
+	u.SetExcluded(group.FieldSchedulingStrategy)
+	return u
+}
+
+// SetContentPolicy sets the "content_policy" field.
+func (u *GroupUpsert) SetContentPolicy(v json.RawMessage) *GroupUpsert {
+	u.Set(group.FieldContentPolicy, v)
+	return u
+}
+
+// UpdateContentPolicy sets the "content_policy" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateContentPolicy() *GroupUpsert {
+	u.SetExcluded(group.FieldContentPolicy)
+	return u
+}
+
+// ClearContentPolicy clears the value of the "content_policy" field.
+func (u *GroupUpsert) ClearContentPolicy() *GroupUpsert {
+	u.SetNull(group.FieldContentPolicy)
+	return u
+}
+
+// SetRewriteRules sets the "rewrite_rules" field.
+func (u *GroupUpsert) SetRewriteRules(v json.RawMessage) *GroupUpsert {
+	u.Set(group.FieldRewriteRules, v)
+	return u
+}
+
+// UpdateRewriteRules sets the "rewrite_rules" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateRewriteRules() *GroupUpsert {
+	u.SetExcluded(group.FieldRewriteRules)
+	return u
+}
+
+// ClearRewriteRules clears the value of the "rewrite_rules" field.
+func (u *GroupUpsert) ClearRewriteRules() *GroupUpsert {
+	u.SetNull(group.FieldRewriteRules)
+	return u
+}
+
+// SetModelAliases sets the "model_aliases" field.
+func (u *GroupUpsert) SetModelAliases(v json.RawMessage) *GroupUpsert {
+	u.Set(group.FieldModelAliases, v)
+	return u
+}
+
+// UpdateModelAliases sets the "model_aliases" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateModelAliases() *GroupUpsert {
+	u.SetExcluded(group.FieldModelAliases)
+	return u
+}
+
+// ClearModelAliases clears the value of the "model_aliases" field.
+func (u *GroupUpsert) ClearModelAliases() *GroupUpsert {
+	u.SetNull(group.FieldModelAliases)
+	return u
+}
+
+// SetOveragePolicy sets the "overage_policy" field.
+func (u *GroupUpsert) SetOveragePolicy(v string) *GroupUpsert {
+	u.Set(group.FieldOveragePolicy, v)
+	return u
+}
+
+// UpdateOveragePolicy sets the "overage_policy" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateOveragePolicy() *GroupUpsert {
+	u.SetExcluded(group.FieldOveragePolicy)
+	return u
+}
+
+// SetOverageRateMultiplier sets the "overage_rate_multiplier" field.
+func (u *GroupUpsert) SetOverageRateMultiplier(v float64) *GroupUpsert {
+	u.Set(group.FieldOverageRateMultiplier, v)
+	return u
+}
+
+// UpdateOverageRateMultiplier sets the "overage_rate_multiplier" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateOverageRateMultiplier() *GroupUpsert {
+	u.SetExcluded(group.FieldOverageRateMultiplier)
+	return u
+}
+
+// AddOverageRateMultiplier adds v to the "overage_rate_multiplier" field.
+func (u *GroupUpsert) AddOverageRateMultiplier(v float64) *GroupUpsert {
+	u.Add(group.FieldOverageRateMultiplier, v)
+	return u
+}
+
+// SetOverageGroupID sets the "overage_group_id" field.
+func (u *GroupUpsert) SetOverageGroupID(v int64) *GroupUpsert {
+	u.Set(group.FieldOverageGroupID, v)
+	return u
+}
+
+// UpdateOverageGroupID sets the "overage_group_id" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateOverageGroupID() *GroupUpsert {
+	u.SetExcluded(group.FieldOverageGroupID)
+	return u
+}
+
+// AddOverageGroupID adds v to the "overage_group_id" field.
+func (u *GroupUpsert) AddOverageGroupID(v int64) *GroupUpsert {
+	u.Add(group.FieldOverageGroupID, v)
+	return u
+}
+
+// ClearOverageGroupID clears the value of the "overage_group_id" field.
+func (u *GroupUpsert) ClearOverageGroupID() *GroupUpsert {
+	u.SetNull(group.FieldOverageGroupID)
+	return u
+}
+
+// SetSubscriptionPrice sets the "subscription_price" field.
+func (u *GroupUpsert) SetSubscriptionPrice(v float64) *GroupUpsert {
+	u.Set(group.FieldSubscriptionPrice, v)
+	return u
+}
+
+// UpdateSubscriptionPrice sets the "subscription_price" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateSubscriptionPrice() *GroupUpsert {
+	u.SetExcluded(group.FieldSubscriptionPrice)
+	return u
+}
+
+// AddSubscriptionPrice adds v to the "subscription_price" field.
+func (u *GroupUpsert) AddSubscriptionPrice(v float64) *GroupUpsert {
+	u.Add(group.FieldSubscriptionPrice, v)
+	return u
+}
+
+// ClearSubscriptionPrice clears the value of the "subscription_price" field.
+func (u *GroupUpsert) ClearSubscriptionPrice() *GroupUpsert {
+	u.SetNull(group.FieldSubscriptionPrice)
+	return u
+}
+
+// SetUsageQuotas sets the "usage_quotas" field.
+func (u *GroupUpsert) SetUsageQuotas(v json.RawMessage) *GroupUpsert {
+	u.Set(group.FieldUsageQuotas, v)
+	return u
+}
+
+// UpdateUsageQuotas sets the "usage_quotas" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateUsageQuotas() *GroupUpsert {
+	u.SetExcluded(group.FieldUsageQuotas)
+	return u
+}
+
+// ClearUsageQuotas clears the value of the "usage_quotas" field.
+func (u *GroupUpsert) ClearUsageQuotas() *GroupUpsert {
+	u.SetNull(group.FieldUsageQuotas)
+	return u
+}
+
+// SetTrafficSplit sets the "traffic_split" field.
+func (u *GroupUpsert) SetTrafficSplit(v json.RawMessage) *GroupUpsert {
+	u.Set(group.FieldTrafficSplit, v)
+	return u
+}
+
+// UpdateTrafficSplit sets the "traffic_split" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateTrafficSplit() *GroupUpsert {
+	u.SetExcluded(group.FieldTrafficSplit)
+	return u
+}
+
+// ClearTrafficSplit clears the value of the "traffic_split" field.
+func (u *GroupUpsert) ClearTrafficSplit() *GroupUpsert {
+	u.SetNull(group.FieldTrafficSplit)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -1616,6 +1968,216 @@ func (u *GroupUpsertOne) UpdateModelRoutingEnabled() *GroupUpsertOne {
 	})
 }
 
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (u *GroupUpsertOne) SetSchedulingStrategy(v string) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetSchedulingStrategy(v)
+	})
+}
+
+// UpdateSchedulingStrategy sets the "scheduling_strategy" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateSchedulingStrategy() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateSchedulingStrategy()
+	})
+}
+
+// SetContentPolicy sets the "content_policy" field.
+func (u *GroupUpsertOne) SetContentPolicy(v json.RawMessage) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetContentPolicy(v)
+	})
+}
+
+// UpdateContentPolicy sets the "content_policy" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateContentPolicy() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateContentPolicy()
+	})
+}
+
+// ClearContentPolicy clears the value of the "content_policy" field.
+func (u *GroupUpsertOne) ClearContentPolicy() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearContentPolicy()
+	})
+}
+
+// SetRewriteRules sets the "rewrite_rules" field.
+func (u *GroupUpsertOne) SetRewriteRules(v json.RawMessage) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetRewriteRules(v)
+	})
+}
+
+// UpdateRewriteRules sets the "rewrite_rules" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateRewriteRules() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateRewriteRules()
+	})
+}
+
+// ClearRewriteRules clears the value of the "rewrite_rules" field.
+func (u *GroupUpsertOne) ClearRewriteRules() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearRewriteRules()
+	})
+}
+
+// SetModelAliases sets the "model_aliases" field.
+func (u *GroupUpsertOne) SetModelAliases(v json.RawMessage) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetModelAliases(v)
+	})
+}
+
+// UpdateModelAliases sets the "model_aliases" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateModelAliases() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateModelAliases()
+	})
+}
+
+// ClearModelAliases clears the value of the "model_aliases" field.
+func (u *GroupUpsertOne) ClearModelAliases() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearModelAliases()
+	})
+}
+
+// SetOveragePolicy sets the "overage_policy" field.
+func (u *GroupUpsertOne) SetOveragePolicy(v string) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetOveragePolicy(v)
+	})
+}
+
+// UpdateOveragePolicy sets the "overage_policy" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateOveragePolicy() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateOveragePolicy()
+	})
+}
+
+// SetOverageRateMultiplier sets the "overage_rate_multiplier" field.
+func (u *GroupUpsertOne) SetOverageRateMultiplier(v float64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetOverageRateMultiplier(v)
+	})
+}
+
+// AddOverageRateMultiplier adds v to the "overage_rate_multiplier" field.
+func (u *GroupUpsertOne) AddOverageRateMultiplier(v float64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddOverageRateMultiplier(v)
+	})
+}
+
+// UpdateOverageRateMultiplier sets the "overage_rate_multiplier" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateOverageRateMultiplier() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateOverageRateMultiplier()
+	})
+}
+
+// SetOverageGroupID sets the "overage_group_id" field.
+func (u *GroupUpsertOne) SetOverageGroupID(v int64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetOverageGroupID(v)
+	})
+}
+
+// AddOverageGroupID adds v to the "overage_group_id" field.
+func (u *GroupUpsertOne) AddOverageGroupID(v int64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddOverageGroupID(v)
+	})
+}
+
+// UpdateOverageGroupID sets the "overage_group_id" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateOverageGroupID() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateOverageGroupID()
+	})
+}
+
+// ClearOverageGroupID clears the value of the "overage_group_id" field.
+func (u *GroupUpsertOne) ClearOverageGroupID() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearOverageGroupID()
+	})
+}
+
+// SetSubscriptionPrice sets the "subscription_price" field.
+func (u *GroupUpsertOne) SetSubscriptionPrice(v float64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetSubscriptionPrice(v)
+	})
+}
+
+// AddSubscriptionPrice adds v to the "subscription_price" field.
+func (u *GroupUpsertOne) AddSubscriptionPrice(v float64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddSubscriptionPrice(v)
+	})
+}
+
+// UpdateSubscriptionPrice sets the "subscription_price" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateSubscriptionPrice() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateSubscriptionPrice()
+	})
+}
+
+// ClearSubscriptionPrice clears the value of the "subscription_price" field.
+func (u *GroupUpsertOne) ClearSubscriptionPrice() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearSubscriptionPrice()
+	})
+}
+
+// SetUsageQuotas sets the "usage_quotas" field.
+func (u *GroupUpsertOne) SetUsageQuotas(v json.RawMessage) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetUsageQuotas(v)
+	})
+}
+
+// UpdateUsageQuotas sets the "usage_quotas" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateUsageQuotas() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateUsageQuotas()
+	})
+}
+
+// ClearUsageQuotas clears the value of the "usage_quotas" field.
+func (u *GroupUpsertOne) ClearUsageQuotas() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearUsageQuotas()
+	})
+}
+
+// SetTrafficSplit sets the "traffic_split" field.
+func (u *GroupUpsertOne) SetTrafficSplit(v json.RawMessage) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetTrafficSplit(v)
+	})
+}
+
+// UpdateTrafficSplit sets the "traffic_split" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateTrafficSplit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateTrafficSplit()
+	})
+}
+
+// ClearTrafficSplit clears the value of the "traffic_split" field.
+func (u *GroupUpsertOne) ClearTrafficSplit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearTrafficSplit()
+	})
+}
+
 // Exec executes the query.
 func (u *GroupUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -2240,6 +2802,216 @@ func (u *GroupUpsertBulk) UpdateModelRoutingEnabled() *GroupUpsertBulk {
 	})
 }
 
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (u *GroupUpsertBulk) SetSchedulingStrategy(v string) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetSchedulingStrategy(v)
+	})
+}
+
+// UpdateSchedulingStrategy sets the "scheduling_strategy" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateSchedulingStrategy() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateSchedulingStrategy()
+	})
+}
+
+// SetContentPolicy sets the "content_policy" field.
+func (u *GroupUpsertBulk) SetContentPolicy(v json.RawMessage) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetContentPolicy(v)
+	})
+}
+
+// UpdateContentPolicy sets the "content_policy" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateContentPolicy() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateContentPolicy()
+	})
+}
+
+// ClearContentPolicy clears the value of the "content_policy" field.
+func (u *GroupUpsertBulk) ClearContentPolicy() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearContentPolicy()
+	})
+}
+
+// SetRewriteRules sets the "rewrite_rules" field.
+func (u *GroupUpsertBulk) SetRewriteRules(v json.RawMessage) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetRewriteRules(v)
+	})
+}
+
+// UpdateRewriteRules sets the "rewrite_rules" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateRewriteRules() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateRewriteRules()
+	})
+}
+
+// ClearRewriteRules clears the value of the "rewrite_rules" field.
+func (u *GroupUpsertBulk) ClearRewriteRules() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearRewriteRules()
+	})
+}
+
+// SetModelAliases sets the "model_aliases" field.
+func (u *GroupUpsertBulk) SetModelAliases(v json.RawMessage) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetModelAliases(v)
+	})
+}
+
+// UpdateModelAliases sets the "model_aliases" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateModelAliases() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateModelAliases()
+	})
+}
+
+// ClearModelAliases clears the value of the "model_aliases" field.
+func (u *GroupUpsertBulk) ClearModelAliases() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearModelAliases()
+	})
+}
+
+// SetOveragePolicy sets the "overage_policy" field.
+func (u *GroupUpsertBulk) SetOveragePolicy(v string) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetOveragePolicy(v)
+	})
+}
+
+// UpdateOveragePolicy sets the "overage_policy" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateOveragePolicy() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateOveragePolicy()
+	})
+}
+
+// SetOverageRateMultiplier sets the "overage_rate_multiplier" field.
+func (u *GroupUpsertBulk) SetOverageRateMultiplier(v float64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetOverageRateMultiplier(v)
+	})
+}
+
+// AddOverageRateMultiplier adds v to the "overage_rate_multiplier" field.
+func (u *GroupUpsertBulk) AddOverageRateMultiplier(v float64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddOverageRateMultiplier(v)
+	})
+}
+
+// UpdateOverageRateMultiplier sets the "overage_rate_multiplier" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateOverageRateMultiplier() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateOverageRateMultiplier()
+	})
+}
+
+// SetOverageGroupID sets the "overage_group_id" field.
+func (u *GroupUpsertBulk) SetOverageGroupID(v int64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetOverageGroupID(v)
+	})
+}
+
+// AddOverageGroupID adds v to the "overage_group_id" field.
+func (u *GroupUpsertBulk) AddOverageGroupID(v int64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddOverageGroupID(v)
+	})
+}
+
+// UpdateOverageGroupID sets the "overage_group_id" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateOverageGroupID() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateOverageGroupID()
+	})
+}
+
+// ClearOverageGroupID clears the value of the "overage_group_id" field.
+func (u *GroupUpsertBulk) ClearOverageGroupID() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearOverageGroupID()
+	})
+}
+
+// SetSubscriptionPrice sets the "subscription_price" field.
+func (u *GroupUpsertBulk) SetSubscriptionPrice(v float64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetSubscriptionPrice(v)
+	})
+}
+
+// AddSubscriptionPrice adds v to the "subscription_price" field.
+func (u *GroupUpsertBulk) AddSubscriptionPrice(v float64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddSubscriptionPrice(v)
+	})
+}
+
+// UpdateSubscriptionPrice sets the "subscription_price" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateSubscriptionPrice() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateSubscriptionPrice()
+	})
+}
+
+// ClearSubscriptionPrice clears the value of the "subscription_price" field.
+func (u *GroupUpsertBulk) ClearSubscriptionPrice() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearSubscriptionPrice()
+	})
+}
+
+// SetUsageQuotas sets the "usage_quotas" field.
+func (u *GroupUpsertBulk) SetUsageQuotas(v json.RawMessage) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetUsageQuotas(v)
+	})
+}
+
+// UpdateUsageQuotas sets the "usage_quotas" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateUsageQuotas() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateUsageQuotas()
+	})
+}
+
+// ClearUsageQuotas clears the value of the "usage_quotas" field.
+func (u *GroupUpsertBulk) ClearUsageQuotas() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearUsageQuotas()
+	})
+}
+
+// SetTrafficSplit sets the "traffic_split" field.
+func (u *GroupUpsertBulk) SetTrafficSplit(v json.RawMessage) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetTrafficSplit(v)
+	})
+}
+
+// UpdateTrafficSplit sets the "traffic_split" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateTrafficSplit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateTrafficSplit()
+	})
+}
+
+// ClearTrafficSplit clears the value of the "traffic_split" field.
+func (u *GroupUpsertBulk) ClearTrafficSplit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearTrafficSplit()
+	})
+}
+
 // Exec executes the query.
 func (u *GroupUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
@@ -4,12 +4,14 @@ package ent
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 	"github.com/Wei-Shaw/sub2api/ent/account"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
@@ -421,6 +423,199 @@ func (_u *GroupUpdate) SetNillableModelRoutingEnabled(v *bool) *GroupUpdate {
 	return _u
 }
 
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (_u *GroupUpdate) SetSchedulingStrategy(v string) *GroupUpdate {
+	_u.mutation.SetSchedulingStrategy(v)
+	return _u
+}
+
+// SetNillableSchedulingStrategy sets the "scheduling_strategy" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableSchedulingStrategy(v *string) *GroupUpdate {
+	if v != nil {
+		_u.SetSchedulingStrategy(*v)
+	}
+	return _u
+}
+
+// SetContentPolicy sets the "content_policy" field.
+func (_u *GroupUpdate) SetContentPolicy(v json.RawMessage) *GroupUpdate {
+	_u.mutation.SetContentPolicy(v)
+	return _u
+}
+
+// AppendContentPolicy appends value to the "content_policy" field.
+func (_u *GroupUpdate) AppendContentPolicy(v json.RawMessage) *GroupUpdate {
+	_u.mutation.AppendContentPolicy(v)
+	return _u
+}
+
+// ClearContentPolicy clears the value of the "content_policy" field.
+func (_u *GroupUpdate) ClearContentPolicy() *GroupUpdate {
+	_u.mutation.ClearContentPolicy()
+	return _u
+}
+
+// SetRewriteRules sets the "rewrite_rules" field.
+func (_u *GroupUpdate) SetRewriteRules(v json.RawMessage) *GroupUpdate {
+	_u.mutation.SetRewriteRules(v)
+	return _u
+}
+
+// AppendRewriteRules appends value to the "rewrite_rules" field.
+func (_u *GroupUpdate) AppendRewriteRules(v json.RawMessage) *GroupUpdate {
+	_u.mutation.AppendRewriteRules(v)
+	return _u
+}
+
+// ClearRewriteRules clears the value of the "rewrite_rules" field.
+func (_u *GroupUpdate) ClearRewriteRules() *GroupUpdate {
+	_u.mutation.ClearRewriteRules()
+	return _u
+}
+
+// SetModelAliases sets the "model_aliases" field.
+func (_u *GroupUpdate) SetModelAliases(v json.RawMessage) *GroupUpdate {
+	_u.mutation.SetModelAliases(v)
+	return _u
+}
+
+// AppendModelAliases appends value to the "model_aliases" field.
+func (_u *GroupUpdate) AppendModelAliases(v json.RawMessage) *GroupUpdate {
+	_u.mutation.AppendModelAliases(v)
+	return _u
+}
+
+// ClearModelAliases clears the value of the "model_aliases" field.
+func (_u *GroupUpdate) ClearModelAliases() *GroupUpdate {
+	_u.mutation.ClearModelAliases()
+	return _u
+}
+
+// SetOveragePolicy sets the "overage_policy" field.
+func (_u *GroupUpdate) SetOveragePolicy(v string) *GroupUpdate {
+	_u.mutation.SetOveragePolicy(v)
+	return _u
+}
+
+// SetNillableOveragePolicy sets the "overage_policy" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableOveragePolicy(v *string) *GroupUpdate {
+	if v != nil {
+		_u.SetOveragePolicy(*v)
+	}
+	return _u
+}
+
+// SetOverageRateMultiplier sets the "overage_rate_multiplier" field.
+func (_u *GroupUpdate) SetOverageRateMultiplier(v float64) *GroupUpdate {
+	_u.mutation.ResetOverageRateMultiplier()
+	_u.mutation.SetOverageRateMultiplier(v)
+	return _u
+}
+
+// SetNillableOverageRateMultiplier sets the "overage_rate_multiplier" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableOverageRateMultiplier(v *float64) *GroupUpdate {
+	if v != nil {
+		_u.SetOverageRateMultiplier(*v)
+	}
+	return _u
+}
+
+// AddOverageRateMultiplier adds value to the "overage_rate_multiplier" field.
+func (_u *GroupUpdate) AddOverageRateMultiplier(v float64) *GroupUpdate {
+	_u.mutation.AddOverageRateMultiplier(v)
+	return _u
+}
+
+// SetOverageGroupID sets the "overage_group_id" field.
+func (_u *GroupUpdate) SetOverageGroupID(v int64) *GroupUpdate {
+	_u.mutation.ResetOverageGroupID()
+	_u.mutation.SetOverageGroupID(v)
+	return _u
+}
+
+// SetNillableOverageGroupID sets the "overage_group_id" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableOverageGroupID(v *int64) *GroupUpdate {
+	if v != nil {
+		_u.SetOverageGroupID(*v)
+	}
+	return _u
+}
+
+// AddOverageGroupID adds value to the "overage_group_id" field.
+func (_u *GroupUpdate) AddOverageGroupID(v int64) *GroupUpdate {
+	_u.mutation.AddOverageGroupID(v)
+	return _u
+}
+
+// ClearOverageGroupID clears the value of the "overage_group_id" field.
+func (_u *GroupUpdate) ClearOverageGroupID() *GroupUpdate {
+	_u.mutation.ClearOverageGroupID()
+	return _u
+}
+
+// SetSubscriptionPrice sets the "subscription_price" field.
+func (_u *GroupUpdate) SetSubscriptionPrice(v float64) *GroupUpdate {
+	_u.mutation.ResetSubscriptionPrice()
+	_u.mutation.SetSubscriptionPrice(v)
+	return _u
+}
+
+// SetNillableSubscriptionPrice sets the "subscription_price" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableSubscriptionPrice(v *float64) *GroupUpdate {
+	if v != nil {
+		_u.SetSubscriptionPrice(*v)
+	}
+	return _u
+}
+
+// AddSubscriptionPrice adds value to the "subscription_price" field.
+func (_u *GroupUpdate) AddSubscriptionPrice(v float64) *GroupUpdate {
+	_u.mutation.AddSubscriptionPrice(v)
+	return _u
+}
+
+// ClearSubscriptionPrice clears the value of the "subscription_price" field.
+func (_u *GroupUpdate) ClearSubscriptionPrice() *GroupUpdate {
+	_u.mutation.ClearSubscriptionPrice()
+	return _u
+}
+
+// SetUsageQuotas sets the "usage_quotas" field.
+func (_u *GroupUpdate) SetUsageQuotas(v json.RawMessage) *GroupUpdate {
+	_u.mutation.SetUsageQuotas(v)
+	return _u
+}
+
+// AppendUsageQuotas appends value to the "usage_quotas" field.
+func (_u *GroupUpdate) AppendUsageQuotas(v json.RawMessage) *GroupUpdate {
+	_u.mutation.AppendUsageQuotas(v)
+	return _u
+}
+
+// ClearUsageQuotas clears the value of the "usage_quotas" field.
+func (_u *GroupUpdate) ClearUsageQuotas() *GroupUpdate {
+	_u.mutation.ClearUsageQuotas()
+	return _u
+}
+
+// SetTrafficSplit sets the "traffic_split" field.
+func (_u *GroupUpdate) SetTrafficSplit(v json.RawMessage) *GroupUpdate {
+	_u.mutation.SetTrafficSplit(v)
+	return _u
+}
+
+// AppendTrafficSplit appends value to the "traffic_split" field.
+func (_u *GroupUpdate) AppendTrafficSplit(v json.RawMessage) *GroupUpdate {
+	_u.mutation.AppendTrafficSplit(v)
+	return _u
+}
+
+// ClearTrafficSplit clears the value of the "traffic_split" field.
+func (_u *GroupUpdate) ClearTrafficSplit() *GroupUpdate {
+	_u.mutation.ClearTrafficSplit()
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *GroupUpdate) AddAPIKeyIDs(ids ...int64) *GroupUpdate {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -706,6 +901,16 @@ func (_u *GroupUpdate) check() error {
 			return &ValidationError{Name: "subscription_type", err: fmt.Errorf(`ent: validator failed for field "Group.subscription_type": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.SchedulingStrategy(); ok {
+		if err := group.SchedulingStrategyValidator(v); err != nil {
+			return &ValidationError{Name: "scheduling_strategy", err: fmt.Errorf(`ent: validator failed for field "Group.scheduling_strategy": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.OveragePolicy(); ok {
+		if err := group.OveragePolicyValidator(v); err != nil {
+			return &ValidationError{Name: "overage_policy", err: fmt.Errorf(`ent: validator failed for field "Group.overage_policy": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -838,6 +1043,91 @@ func (_u *GroupUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.ModelRoutingEnabled(); ok {
 		_spec.SetField(group.FieldModelRoutingEnabled, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.SchedulingStrategy(); ok {
+		_spec.SetField(group.FieldSchedulingStrategy, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ContentPolicy(); ok {
+		_spec.SetField(group.FieldContentPolicy, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedContentPolicy(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldContentPolicy, value)
+		})
+	}
+	if _u.mutation.ContentPolicyCleared() {
+		_spec.ClearField(group.FieldContentPolicy, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.RewriteRules(); ok {
+		_spec.SetField(group.FieldRewriteRules, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRewriteRules(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldRewriteRules, value)
+		})
+	}
+	if _u.mutation.RewriteRulesCleared() {
+		_spec.ClearField(group.FieldRewriteRules, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.ModelAliases(); ok {
+		_spec.SetField(group.FieldModelAliases, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedModelAliases(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldModelAliases, value)
+		})
+	}
+	if _u.mutation.ModelAliasesCleared() {
+		_spec.ClearField(group.FieldModelAliases, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.OveragePolicy(); ok {
+		_spec.SetField(group.FieldOveragePolicy, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.OverageRateMultiplier(); ok {
+		_spec.SetField(group.FieldOverageRateMultiplier, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedOverageRateMultiplier(); ok {
+		_spec.AddField(group.FieldOverageRateMultiplier, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.OverageGroupID(); ok {
+		_spec.SetField(group.FieldOverageGroupID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedOverageGroupID(); ok {
+		_spec.AddField(group.FieldOverageGroupID, field.TypeInt64, value)
+	}
+	if _u.mutation.OverageGroupIDCleared() {
+		_spec.ClearField(group.FieldOverageGroupID, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.SubscriptionPrice(); ok {
+		_spec.SetField(group.FieldSubscriptionPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedSubscriptionPrice(); ok {
+		_spec.AddField(group.FieldSubscriptionPrice, field.TypeFloat64, value)
+	}
+	if _u.mutation.SubscriptionPriceCleared() {
+		_spec.ClearField(group.FieldSubscriptionPrice, field.TypeFloat64)
+	}
+	if value, ok := _u.mutation.UsageQuotas(); ok {
+		_spec.SetField(group.FieldUsageQuotas, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedUsageQuotas(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldUsageQuotas, value)
+		})
+	}
+	if _u.mutation.UsageQuotasCleared() {
+		_spec.ClearField(group.FieldUsageQuotas, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.TrafficSplit(); ok {
+		_spec.SetField(group.FieldTrafficSplit, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedTrafficSplit(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldTrafficSplit, value)
+		})
+	}
+	if _u.mutation.TrafficSplitCleared() {
+		_spec.ClearField(group.FieldTrafficSplit, field.TypeJSON)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1539,6 +1829,199 @@ func (_u *GroupUpdateOne) SetNillableModelRoutingEnabled(v *bool) *GroupUpdateOn
 	return _u
 }
 
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (_u *GroupUpdateOne) SetSchedulingStrategy(v string) *GroupUpdateOne {
+	_u.mutation.SetSchedulingStrategy(v)
+	return _u
+}
+
+// SetNillableSchedulingStrategy sets the "scheduling_strategy" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableSchedulingStrategy(v *string) *GroupUpdateOne {
+	if v != nil {
+		_u.SetSchedulingStrategy(*v)
+	}
+	return _u
+}
+
+// SetContentPolicy sets the "content_policy" field.
+func (_u *GroupUpdateOne) SetContentPolicy(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.SetContentPolicy(v)
+	return _u
+}
+
+// AppendContentPolicy appends value to the "content_policy" field.
+func (_u *GroupUpdateOne) AppendContentPolicy(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.AppendContentPolicy(v)
+	return _u
+}
+
+// ClearContentPolicy clears the value of the "content_policy" field.
+func (_u *GroupUpdateOne) ClearContentPolicy() *GroupUpdateOne {
+	_u.mutation.ClearContentPolicy()
+	return _u
+}
+
+// SetRewriteRules sets the "rewrite_rules" field.
+func (_u *GroupUpdateOne) SetRewriteRules(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.SetRewriteRules(v)
+	return _u
+}
+
+// AppendRewriteRules appends value to the "rewrite_rules" field.
+func (_u *GroupUpdateOne) AppendRewriteRules(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.AppendRewriteRules(v)
+	return _u
+}
+
+// ClearRewriteRules clears the value of the "rewrite_rules" field.
+func (_u *GroupUpdateOne) ClearRewriteRules() *GroupUpdateOne {
+	_u.mutation.ClearRewriteRules()
+	return _u
+}
+
+// SetModelAliases sets the "model_aliases" field.
+func (_u *GroupUpdateOne) SetModelAliases(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.SetModelAliases(v)
+	return _u
+}
+
+// AppendModelAliases appends value to the "model_aliases" field.
+func (_u *GroupUpdateOne) AppendModelAliases(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.AppendModelAliases(v)
+	return _u
+}
+
+// ClearModelAliases clears the value of the "model_aliases" field.
+func (_u *GroupUpdateOne) ClearModelAliases() *GroupUpdateOne {
+	_u.mutation.ClearModelAliases()
+	return _u
+}
+
+// SetOveragePolicy sets the "overage_policy" field.
+func (_u *GroupUpdateOne) SetOveragePolicy(v string) *GroupUpdateOne {
+	_u.mutation.SetOveragePolicy(v)
+	return _u
+}
+
+// SetNillableOveragePolicy sets the "overage_policy" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableOveragePolicy(v *string) *GroupUpdateOne {
+	if v != nil {
+		_u.SetOveragePolicy(*v)
+	}
+	return _u
+}
+
+// SetOverageRateMultiplier sets the "overage_rate_multiplier" field.
+func (_u *GroupUpdateOne) SetOverageRateMultiplier(v float64) *GroupUpdateOne {
+	_u.mutation.ResetOverageRateMultiplier()
+	_u.mutation.SetOverageRateMultiplier(v)
+	return _u
+}
+
+// SetNillableOverageRateMultiplier sets the "overage_rate_multiplier" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableOverageRateMultiplier(v *float64) *GroupUpdateOne {
+	if v != nil {
+		_u.SetOverageRateMultiplier(*v)
+	}
+	return _u
+}
+
+// AddOverageRateMultiplier adds value to the "overage_rate_multiplier" field.
+func (_u *GroupUpdateOne) AddOverageRateMultiplier(v float64) *GroupUpdateOne {
+	_u.mutation.AddOverageRateMultiplier(v)
+	return _u
+}
+
+// SetOverageGroupID sets the "overage_group_id" field.
+func (_u *GroupUpdateOne) SetOverageGroupID(v int64) *GroupUpdateOne {
+	_u.mutation.ResetOverageGroupID()
+	_u.mutation.SetOverageGroupID(v)
+	return _u
+}
+
+// SetNillableOverageGroupID sets the "overage_group_id" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableOverageGroupID(v *int64) *GroupUpdateOne {
+	if v != nil {
+		_u.SetOverageGroupID(*v)
+	}
+	return _u
+}
+
+// AddOverageGroupID adds value to the "overage_group_id" field.
+func (_u *GroupUpdateOne) AddOverageGroupID(v int64) *GroupUpdateOne {
+	_u.mutation.AddOverageGroupID(v)
+	return _u
+}
+
+// ClearOverageGroupID clears the value of the "overage_group_id" field.
+func (_u *GroupUpdateOne) ClearOverageGroupID() *GroupUpdateOne {
+	_u.mutation.ClearOverageGroupID()
+	return _u
+}
+
+// SetSubscriptionPrice sets the "subscription_price" field.
+func (_u *GroupUpdateOne) SetSubscriptionPrice(v float64) *GroupUpdateOne {
+	_u.mutation.ResetSubscriptionPrice()
+	_u.mutation.SetSubscriptionPrice(v)
+	return _u
+}
+
+// SetNillableSubscriptionPrice sets the "subscription_price" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableSubscriptionPrice(v *float64) *GroupUpdateOne {
+	if v != nil {
+		_u.SetSubscriptionPrice(*v)
+	}
+	return _u
+}
+
+// AddSubscriptionPrice adds value to the "subscription_price" field.
+func (_u *GroupUpdateOne) AddSubscriptionPrice(v float64) *GroupUpdateOne {
+	_u.mutation.AddSubscriptionPrice(v)
+	return _u
+}
+
+// ClearSubscriptionPrice clears the value of the "subscription_price" field.
+func (_u *GroupUpdateOne) ClearSubscriptionPrice() *GroupUpdateOne {
+	_u.mutation.ClearSubscriptionPrice()
+	return _u
+}
+
+// SetUsageQuotas sets the "usage_quotas" field.
+func (_u *GroupUpdateOne) SetUsageQuotas(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.SetUsageQuotas(v)
+	return _u
+}
+
+// AppendUsageQuotas appends value to the "usage_quotas" field.
+func (_u *GroupUpdateOne) AppendUsageQuotas(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.AppendUsageQuotas(v)
+	return _u
+}
+
+// ClearUsageQuotas clears the value of the "usage_quotas" field.
+func (_u *GroupUpdateOne) ClearUsageQuotas() *GroupUpdateOne {
+	_u.mutation.ClearUsageQuotas()
+	return _u
+}
+
+// SetTrafficSplit sets the "traffic_split" field.
+func (_u *GroupUpdateOne) SetTrafficSplit(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.SetTrafficSplit(v)
+	return _u
+}
+
+// AppendTrafficSplit appends value to the "traffic_split" field.
+func (_u *GroupUpdateOne) AppendTrafficSplit(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.AppendTrafficSplit(v)
+	return _u
+}
+
+// ClearTrafficSplit clears the value of the "traffic_split" field.
+func (_u *GroupUpdateOne) ClearTrafficSplit() *GroupUpdateOne {
+	_u.mutation.ClearTrafficSplit()
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *GroupUpdateOne) AddAPIKeyIDs(ids ...int64) *GroupUpdateOne {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -1837,6 +2320,16 @@ func (_u *GroupUpdateOne) check() error {
 			return &ValidationError{Name: "subscription_type", err: fmt.Errorf(`ent: validator failed for field "Group.subscription_type": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.SchedulingStrategy(); ok {
+		if err := group.SchedulingStrategyValidator(v); err != nil {
+			return &ValidationError{Name: "scheduling_strategy", err: fmt.Errorf(`ent: validator failed for field "Group.scheduling_strategy": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.OveragePolicy(); ok {
+		if err := group.OveragePolicyValidator(v); err != nil {
+			return &ValidationError{Name: "overage_policy", err: fmt.Errorf(`ent: validator failed for field "Group.overage_policy": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -1986,6 +2479,91 @@ func (_u *GroupUpdateOne) sqlSave(ctx context.Context) (_node *Group, err error)
 	if value, ok := _u.mutation.ModelRoutingEnabled(); ok {
 		_spec.SetField(group.FieldModelRoutingEnabled, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.SchedulingStrategy(); ok {
+		_spec.SetField(group.FieldSchedulingStrategy, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ContentPolicy(); ok {
+		_spec.SetField(group.FieldContentPolicy, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedContentPolicy(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldContentPolicy, value)
+		})
+	}
+	if _u.mutation.ContentPolicyCleared() {
+		_spec.ClearField(group.FieldContentPolicy, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.RewriteRules(); ok {
+		_spec.SetField(group.FieldRewriteRules, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRewriteRules(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldRewriteRules, value)
+		})
+	}
+	if _u.mutation.RewriteRulesCleared() {
+		_spec.ClearField(group.FieldRewriteRules, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.ModelAliases(); ok {
+		_spec.SetField(group.FieldModelAliases, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedModelAliases(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldModelAliases, value)
+		})
+	}
+	if _u.mutation.ModelAliasesCleared() {
+		_spec.ClearField(group.FieldModelAliases, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.OveragePolicy(); ok {
+		_spec.SetField(group.FieldOveragePolicy, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.OverageRateMultiplier(); ok {
+		_spec.SetField(group.FieldOverageRateMultiplier, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedOverageRateMultiplier(); ok {
+		_spec.AddField(group.FieldOverageRateMultiplier, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.OverageGroupID(); ok {
+		_spec.SetField(group.FieldOverageGroupID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedOverageGroupID(); ok {
+		_spec.AddField(group.FieldOverageGroupID, field.TypeInt64, value)
+	}
+	if _u.mutation.OverageGroupIDCleared() {
+		_spec.ClearField(group.FieldOverageGroupID, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.SubscriptionPrice(); ok {
+		_spec.SetField(group.FieldSubscriptionPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedSubscriptionPrice(); ok {
+		_spec.AddField(group.FieldSubscriptionPrice, field.TypeFloat64, value)
+	}
+	if _u.mutation.SubscriptionPriceCleared() {
+		_spec.ClearField(group.FieldSubscriptionPrice, field.TypeFloat64)
+	}
+	if value, ok := _u.mutation.UsageQuotas(); ok {
+		_spec.SetField(group.FieldUsageQuotas, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedUsageQuotas(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldUsageQuotas, value)
+		})
+	}
+	if _u.mutation.UsageQuotasCleared() {
+		_spec.ClearField(group.FieldUsageQuotas, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.TrafficSplit(); ok {
+		_spec.SetField(group.FieldTrafficSplit, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedTrafficSplit(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldTrafficSplit, value)
+		})
+	}
+	if _u.mutation.TrafficSplitCleared() {
+		_spec.ClearField(group.FieldTrafficSplit, field.TypeJSON)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -12,9 +12,14 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/accountgroup"
 	"github.com/Wei-Shaw/sub2api/ent/adminactionlog"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
+	"github.com/Wei-Shaw/sub2api/ent/commissionwithdrawal"
 	"github.com/Wei-Shaw/sub2api/ent/group"
 	"github.com/Wei-Shaw/sub2api/ent/invitation"
+	"github.com/Wei-Shaw/sub2api/ent/invitecommission"
 	"github.com/Wei-Shaw/sub2api/ent/invitelog"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
+	"github.com/Wei-Shaw/sub2api/ent/organizationinvitation"
+	"github.com/Wei-Shaw/sub2api/ent/organizationmember"
 	"github.com/Wei-Shaw/sub2api/ent/plan"
 	"github.com/Wei-Shaw/sub2api/ent/predicate"
 	"github.com/Wei-Shaw/sub2api/ent/promocode"
@@ -28,7 +33,9 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/userallowedgroup"
 	"github.com/Wei-Shaw/sub2api/ent/userattributedefinition"
 	"github.com/Wei-Shaw/sub2api/ent/userattributevalue"
+	"github.com/Wei-Shaw/sub2api/ent/userrecoverycode"
 	"github.com/Wei-Shaw/sub2api/ent/usersubscription"
+	"github.com/Wei-Shaw/sub2api/ent/webauthncredential"
 )
 
 // The Query interface represents an operation that queries a graph.
@@ -195,6 +202,33 @@ func (f TraverseAdminActionLog) Traverse(ctx context.Context, q ent.Query) error
 	return fmt.Errorf("unexpected query type %T. expect *ent.AdminActionLogQuery", q)
 }
 
+// The CommissionWithdrawalFunc type is an adapter to allow the use of ordinary function as a Querier.
+type CommissionWithdrawalFunc func(context.Context, *ent.CommissionWithdrawalQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f CommissionWithdrawalFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.CommissionWithdrawalQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.CommissionWithdrawalQuery", q)
+}
+
+// The TraverseCommissionWithdrawal type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseCommissionWithdrawal func(context.Context, *ent.CommissionWithdrawalQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseCommissionWithdrawal) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseCommissionWithdrawal) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.CommissionWithdrawalQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.CommissionWithdrawalQuery", q)
+}
+
 // The GroupFunc type is an adapter to allow the use of ordinary function as a Querier.
 type GroupFunc func(context.Context, *ent.GroupQuery) (ent.Value, error)
 
@@ -249,6 +283,33 @@ func (f TraverseInvitation) Traverse(ctx context.Context, q ent.Query) error {
 	return fmt.Errorf("unexpected query type %T. expect *ent.InvitationQuery", q)
 }
 
+// The InviteCommissionFunc type is an adapter to allow the use of ordinary function as a Querier.
+type InviteCommissionFunc func(context.Context, *ent.InviteCommissionQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f InviteCommissionFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.InviteCommissionQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.InviteCommissionQuery", q)
+}
+
+// The TraverseInviteCommission type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseInviteCommission func(context.Context, *ent.InviteCommissionQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseInviteCommission) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseInviteCommission) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.InviteCommissionQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.InviteCommissionQuery", q)
+}
+
 // The InviteLogFunc type is an adapter to allow the use of ordinary function as a Querier.
 type InviteLogFunc func(context.Context, *ent.InviteLogQuery) (ent.Value, error)
 
@@ -276,6 +337,87 @@ func (f TraverseInviteLog) Traverse(ctx context.Context, q ent.Query) error {
 	return fmt.Errorf("unexpected query type %T. expect *ent.InviteLogQuery", q)
 }
 
+// The OrganizationFunc type is an adapter to allow the use of ordinary function as a Querier.
+type OrganizationFunc func(context.Context, *ent.OrganizationQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f OrganizationFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.OrganizationQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.OrganizationQuery", q)
+}
+
+// The TraverseOrganization type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseOrganization func(context.Context, *ent.OrganizationQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseOrganization) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseOrganization) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.OrganizationQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.OrganizationQuery", q)
+}
+
+// The OrganizationInvitationFunc type is an adapter to allow the use of ordinary function as a Querier.
+type OrganizationInvitationFunc func(context.Context, *ent.OrganizationInvitationQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f OrganizationInvitationFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.OrganizationInvitationQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.OrganizationInvitationQuery", q)
+}
+
+// The TraverseOrganizationInvitation type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseOrganizationInvitation func(context.Context, *ent.OrganizationInvitationQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseOrganizationInvitation) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseOrganizationInvitation) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.OrganizationInvitationQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.OrganizationInvitationQuery", q)
+}
+
+// The OrganizationMemberFunc type is an adapter to allow the use of ordinary function as a Querier.
+type OrganizationMemberFunc func(context.Context, *ent.OrganizationMemberQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f OrganizationMemberFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.OrganizationMemberQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.OrganizationMemberQuery", q)
+}
+
+// The TraverseOrganizationMember type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseOrganizationMember func(context.Context, *ent.OrganizationMemberQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseOrganizationMember) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseOrganizationMember) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.OrganizationMemberQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.OrganizationMemberQuery", q)
+}
+
 // The PlanFunc type is an adapter to allow the use of ordinary function as a Querier.
 type PlanFunc func(context.Context, *ent.PlanQuery) (ent.Value, error)
 
@@ -600,6 +742,33 @@ func (f TraverseUserAttributeValue) Traverse(ctx context.Context, q ent.Query) e
 	return fmt.Errorf("unexpected query type %T. expect *ent.UserAttributeValueQuery", q)
 }
 
+// The UserRecoveryCodeFunc type is an adapter to allow the use of ordinary function as a Querier.
+type UserRecoveryCodeFunc func(context.Context, *ent.UserRecoveryCodeQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f UserRecoveryCodeFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.UserRecoveryCodeQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.UserRecoveryCodeQuery", q)
+}
+
+// The TraverseUserRecoveryCode type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseUserRecoveryCode func(context.Context, *ent.UserRecoveryCodeQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseUserRecoveryCode) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseUserRecoveryCode) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.UserRecoveryCodeQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.UserRecoveryCodeQuery", q)
+}
+
 // The UserSubscriptionFunc type is an adapter to allow the use of ordinary function as a Querier.
 type UserSubscriptionFunc func(context.Context, *ent.UserSubscriptionQuery) (ent.Value, error)
 
@@ -627,6 +796,33 @@ func (f TraverseUserSubscription) Traverse(ctx context.Context, q ent.Query) err
 	return fmt.Errorf("unexpected query type %T. expect *ent.UserSubscriptionQuery", q)
 }
 
+// The WebAuthnCredentialFunc type is an adapter to allow the use of ordinary function as a Querier.
+type WebAuthnCredentialFunc func(context.Context, *ent.WebAuthnCredentialQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f WebAuthnCredentialFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.WebAuthnCredentialQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.WebAuthnCredentialQuery", q)
+}
+
+// The TraverseWebAuthnCredential type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseWebAuthnCredential func(context.Context, *ent.WebAuthnCredentialQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseWebAuthnCredential) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseWebAuthnCredential) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.WebAuthnCredentialQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.WebAuthnCredentialQuery", q)
+}
+
 // NewQuery returns the generic Query interface for the given typed query.
 func NewQuery(q ent.Query) (Query, error) {
 	switch q := q.(type) {
@@ -638,12 +834,22 @@ func NewQuery(q ent.Query) (Query, error) {
 		return &query[*ent.AccountGroupQuery, predicate.AccountGroup, accountgroup.OrderOption]{typ: ent.TypeAccountGroup, tq: q}, nil
 	case *ent.AdminActionLogQuery:
 		return &query[*ent.AdminActionLogQuery, predicate.AdminActionLog, adminactionlog.OrderOption]{typ: ent.TypeAdminActionLog, tq: q}, nil
+	case *ent.CommissionWithdrawalQuery:
+		return &query[*ent.CommissionWithdrawalQuery, predicate.CommissionWithdrawal, commissionwithdrawal.OrderOption]{typ: ent.TypeCommissionWithdrawal, tq: q}, nil
 	case *ent.GroupQuery:
 		return &query[*ent.GroupQuery, predicate.Group, group.OrderOption]{typ: ent.TypeGroup, tq: q}, nil
 	case *ent.InvitationQuery:
 		return &query[*ent.InvitationQuery, predicate.Invitation, invitation.OrderOption]{typ: ent.TypeInvitation, tq: q}, nil
+	case *ent.InviteCommissionQuery:
+		return &query[*ent.InviteCommissionQuery, predicate.InviteCommission, invitecommission.OrderOption]{typ: ent.TypeInviteCommission, tq: q}, nil
 	case *ent.InviteLogQuery:
 		return &query[*ent.InviteLogQuery, predicate.InviteLog, invitelog.OrderOption]{typ: ent.TypeInviteLog, tq: q}, nil
+	case *ent.OrganizationQuery:
+		return &query[*ent.OrganizationQuery, predicate.Organization, organization.OrderOption]{typ: ent.TypeOrganization, tq: q}, nil
+	case *ent.OrganizationInvitationQuery:
+		return &query[*ent.OrganizationInvitationQuery, predicate.OrganizationInvitation, organizationinvitation.OrderOption]{typ: ent.TypeOrganizationInvitation, tq: q}, nil
+	case *ent.OrganizationMemberQuery:
+		return &query[*ent.OrganizationMemberQuery, predicate.OrganizationMember, organizationmember.OrderOption]{typ: ent.TypeOrganizationMember, tq: q}, nil
 	case *ent.PlanQuery:
 		return &query[*ent.PlanQuery, predicate.Plan, plan.OrderOption]{typ: ent.TypePlan, tq: q}, nil
 	case *ent.PromoCodeQuery:
@@ -668,8 +874,12 @@ func NewQuery(q ent.Query) (Query, error) {
 		return &query[*ent.UserAttributeDefinitionQuery, predicate.UserAttributeDefinition, userattributedefinition.OrderOption]{typ: ent.TypeUserAttributeDefinition, tq: q}, nil
 	case *ent.UserAttributeValueQuery:
 		return &query[*ent.UserAttributeValueQuery, predicate.UserAttributeValue, userattributevalue.OrderOption]{typ: ent.TypeUserAttributeValue, tq: q}, nil
+	case *ent.UserRecoveryCodeQuery:
+		return &query[*ent.UserRecoveryCodeQuery, predicate.UserRecoveryCode, userrecoverycode.OrderOption]{typ: ent.TypeUserRecoveryCode, tq: q}, nil
 	case *ent.UserSubscriptionQuery:
 		return &query[*ent.UserSubscriptionQuery, predicate.UserSubscription, usersubscription.OrderOption]{typ: ent.TypeUserSubscription, tq: q}, nil
+	case *ent.WebAuthnCredentialQuery:
+		return &query[*ent.WebAuthnCredentialQuery, predicate.WebAuthnCredential, webauthncredential.OrderOption]{typ: ent.TypeWebAuthnCredential, tq: q}, nil
 	default:
 		return nil, fmt.Errorf("unknown query type %T", q)
 	}
@@ -32,6 +32,14 @@ type Invitation struct {
 	ConfirmedBy *int64 `json:"confirmed_by,omitempty"`
 	// ConfirmedAt holds the value of the "confirmed_at" field.
 	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	// CommissionStatus holds the value of the "commission_status" field.
+	CommissionStatus string `json:"commission_status,omitempty"`
+	// CommissionBlockReason holds the value of the "commission_block_reason" field.
+	CommissionBlockReason string `json:"commission_block_reason,omitempty"`
+	// CommissionCursor holds the value of the "commission_cursor" field.
+	CommissionCursor int64 `json:"commission_cursor,omitempty"`
+	// CommissionTotal holds the value of the "commission_total" field.
+	CommissionTotal float64 `json:"commission_total,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -102,11 +110,11 @@ func (*Invitation) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case invitation.FieldRewardAmount:
+		case invitation.FieldRewardAmount, invitation.FieldCommissionTotal:
 			values[i] = new(sql.NullFloat64)
-		case invitation.FieldID, invitation.FieldInviterID, invitation.FieldInviteeID, invitation.FieldConfirmedBy:
+		case invitation.FieldID, invitation.FieldInviterID, invitation.FieldInviteeID, invitation.FieldConfirmedBy, invitation.FieldCommissionCursor:
 			values[i] = new(sql.NullInt64)
-		case invitation.FieldInviteCode, invitation.FieldStatus:
+		case invitation.FieldInviteCode, invitation.FieldStatus, invitation.FieldCommissionStatus, invitation.FieldCommissionBlockReason:
 			values[i] = new(sql.NullString)
 		case invitation.FieldConfirmedAt, invitation.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
@@ -175,6 +183,30 @@ func (_m *Invitation) assignValues(columns []string, values []any) error {
 				_m.ConfirmedAt = new(time.Time)
 				*_m.ConfirmedAt = value.Time
 			}
+		case invitation.FieldCommissionStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field commission_status", values[i])
+			} else if value.Valid {
+				_m.CommissionStatus = value.String
+			}
+		case invitation.FieldCommissionBlockReason:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field commission_block_reason", values[i])
+			} else if value.Valid {
+				_m.CommissionBlockReason = value.String
+			}
+		case invitation.FieldCommissionCursor:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field commission_cursor", values[i])
+			} else if value.Valid {
+				_m.CommissionCursor = value.Int64
+			}
+		case invitation.FieldCommissionTotal:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field commission_total", values[i])
+			} else if value.Valid {
+				_m.CommissionTotal = value.Float64
+			}
 		case invitation.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -262,6 +294,18 @@ func (_m *Invitation) String() string {
 		builder.WriteString(v.Format(time.ANSIC))
 	}
 	builder.WriteString(", ")
+	builder.WriteString("commission_status=")
+	builder.WriteString(_m.CommissionStatus)
+	builder.WriteString(", ")
+	builder.WriteString("commission_block_reason=")
+	builder.WriteString(_m.CommissionBlockReason)
+	builder.WriteString(", ")
+	builder.WriteString("commission_cursor=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CommissionCursor))
+	builder.WriteString(", ")
+	builder.WriteString("commission_total=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CommissionTotal))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteByte(')')
@@ -28,6 +28,14 @@ const (
 	FieldConfirmedBy = "confirmed_by"
 	// FieldConfirmedAt holds the string denoting the confirmed_at field in the database.
 	FieldConfirmedAt = "confirmed_at"
+	// FieldCommissionStatus holds the string denoting the commission_status field in the database.
+	FieldCommissionStatus = "commission_status"
+	// FieldCommissionBlockReason holds the string denoting the commission_block_reason field in the database.
+	FieldCommissionBlockReason = "commission_block_reason"
+	// FieldCommissionCursor holds the string denoting the commission_cursor field in the database.
+	FieldCommissionCursor = "commission_cursor"
+	// FieldCommissionTotal holds the string denoting the commission_total field in the database.
+	FieldCommissionTotal = "commission_total"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// EdgeInviter holds the string denoting the inviter edge name in mutations.
@@ -80,6 +88,10 @@ var Columns = []string{
 	FieldStatus,
 	FieldConfirmedBy,
 	FieldConfirmedAt,
+	FieldCommissionStatus,
+	FieldCommissionBlockReason,
+	FieldCommissionCursor,
+	FieldCommissionTotal,
 	FieldCreatedAt,
 }
 
@@ -102,6 +114,18 @@ var (
 	DefaultStatus string
 	// StatusValidator is a validator for the "status" field. It is called by the builders before save.
 	StatusValidator func(string) error
+	// DefaultCommissionStatus holds the default value on creation for the "commission_status" field.
+	DefaultCommissionStatus string
+	// CommissionStatusValidator is a validator for the "commission_status" field. It is called by the builders before save.
+	CommissionStatusValidator func(string) error
+	// DefaultCommissionBlockReason holds the default value on creation for the "commission_block_reason" field.
+	DefaultCommissionBlockReason string
+	// CommissionBlockReasonValidator is a validator for the "commission_block_reason" field. It is called by the builders before save.
+	CommissionBlockReasonValidator func(string) error
+	// DefaultCommissionCursor holds the default value on creation for the "commission_cursor" field.
+	DefaultCommissionCursor int64
+	// DefaultCommissionTotal holds the default value on creation for the "commission_total" field.
+	DefaultCommissionTotal float64
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 )
@@ -149,6 +173,26 @@ func ByConfirmedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldConfirmedAt, opts...).ToFunc()
 }
 
+// ByCommissionStatus orders the results by the commission_status field.
+func ByCommissionStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCommissionStatus, opts...).ToFunc()
+}
+
+// ByCommissionBlockReason orders the results by the commission_block_reason field.
+func ByCommissionBlockReason(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCommissionBlockReason, opts...).ToFunc()
+}
+
+// ByCommissionCursor orders the results by the commission_cursor field.
+func ByCommissionCursor(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCommissionCursor, opts...).ToFunc()
+}
+
+// ByCommissionTotal orders the results by the commission_total field.
+func ByCommissionTotal(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCommissionTotal, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
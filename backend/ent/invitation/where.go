@@ -90,6 +90,26 @@ func ConfirmedAt(v time.Time) predicate.Invitation {
 	return predicate.Invitation(sql.FieldEQ(FieldConfirmedAt, v))
 }
 
+// CommissionStatus applies equality check predicate on the "commission_status" field. It's identical to CommissionStatusEQ.
+func CommissionStatus(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldEQ(FieldCommissionStatus, v))
+}
+
+// CommissionBlockReason applies equality check predicate on the "commission_block_reason" field. It's identical to CommissionBlockReasonEQ.
+func CommissionBlockReason(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldEQ(FieldCommissionBlockReason, v))
+}
+
+// CommissionCursor applies equality check predicate on the "commission_cursor" field. It's identical to CommissionCursorEQ.
+func CommissionCursor(v int64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldEQ(FieldCommissionCursor, v))
+}
+
+// CommissionTotal applies equality check predicate on the "commission_total" field. It's identical to CommissionTotalEQ.
+func CommissionTotal(v float64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldEQ(FieldCommissionTotal, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Invitation {
 	return predicate.Invitation(sql.FieldEQ(FieldCreatedAt, v))
@@ -385,6 +405,216 @@ func ConfirmedAtNotNil() predicate.Invitation {
 	return predicate.Invitation(sql.FieldNotNull(FieldConfirmedAt))
 }
 
+// CommissionStatusEQ applies the EQ predicate on the "commission_status" field.
+func CommissionStatusEQ(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldEQ(FieldCommissionStatus, v))
+}
+
+// CommissionStatusNEQ applies the NEQ predicate on the "commission_status" field.
+func CommissionStatusNEQ(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldNEQ(FieldCommissionStatus, v))
+}
+
+// CommissionStatusIn applies the In predicate on the "commission_status" field.
+func CommissionStatusIn(vs ...string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldIn(FieldCommissionStatus, vs...))
+}
+
+// CommissionStatusNotIn applies the NotIn predicate on the "commission_status" field.
+func CommissionStatusNotIn(vs ...string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldNotIn(FieldCommissionStatus, vs...))
+}
+
+// CommissionStatusGT applies the GT predicate on the "commission_status" field.
+func CommissionStatusGT(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldGT(FieldCommissionStatus, v))
+}
+
+// CommissionStatusGTE applies the GTE predicate on the "commission_status" field.
+func CommissionStatusGTE(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldGTE(FieldCommissionStatus, v))
+}
+
+// CommissionStatusLT applies the LT predicate on the "commission_status" field.
+func CommissionStatusLT(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldLT(FieldCommissionStatus, v))
+}
+
+// CommissionStatusLTE applies the LTE predicate on the "commission_status" field.
+func CommissionStatusLTE(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldLTE(FieldCommissionStatus, v))
+}
+
+// CommissionStatusContains applies the Contains predicate on the "commission_status" field.
+func CommissionStatusContains(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldContains(FieldCommissionStatus, v))
+}
+
+// CommissionStatusHasPrefix applies the HasPrefix predicate on the "commission_status" field.
+func CommissionStatusHasPrefix(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldHasPrefix(FieldCommissionStatus, v))
+}
+
+// CommissionStatusHasSuffix applies the HasSuffix predicate on the "commission_status" field.
+func CommissionStatusHasSuffix(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldHasSuffix(FieldCommissionStatus, v))
+}
+
+// CommissionStatusEqualFold applies the EqualFold predicate on the "commission_status" field.
+func CommissionStatusEqualFold(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldEqualFold(FieldCommissionStatus, v))
+}
+
+// CommissionStatusContainsFold applies the ContainsFold predicate on the "commission_status" field.
+func CommissionStatusContainsFold(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldContainsFold(FieldCommissionStatus, v))
+}
+
+// CommissionBlockReasonEQ applies the EQ predicate on the "commission_block_reason" field.
+func CommissionBlockReasonEQ(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldEQ(FieldCommissionBlockReason, v))
+}
+
+// CommissionBlockReasonNEQ applies the NEQ predicate on the "commission_block_reason" field.
+func CommissionBlockReasonNEQ(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldNEQ(FieldCommissionBlockReason, v))
+}
+
+// CommissionBlockReasonIn applies the In predicate on the "commission_block_reason" field.
+func CommissionBlockReasonIn(vs ...string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldIn(FieldCommissionBlockReason, vs...))
+}
+
+// CommissionBlockReasonNotIn applies the NotIn predicate on the "commission_block_reason" field.
+func CommissionBlockReasonNotIn(vs ...string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldNotIn(FieldCommissionBlockReason, vs...))
+}
+
+// CommissionBlockReasonGT applies the GT predicate on the "commission_block_reason" field.
+func CommissionBlockReasonGT(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldGT(FieldCommissionBlockReason, v))
+}
+
+// CommissionBlockReasonGTE applies the GTE predicate on the "commission_block_reason" field.
+func CommissionBlockReasonGTE(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldGTE(FieldCommissionBlockReason, v))
+}
+
+// CommissionBlockReasonLT applies the LT predicate on the "commission_block_reason" field.
+func CommissionBlockReasonLT(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldLT(FieldCommissionBlockReason, v))
+}
+
+// CommissionBlockReasonLTE applies the LTE predicate on the "commission_block_reason" field.
+func CommissionBlockReasonLTE(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldLTE(FieldCommissionBlockReason, v))
+}
+
+// CommissionBlockReasonContains applies the Contains predicate on the "commission_block_reason" field.
+func CommissionBlockReasonContains(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldContains(FieldCommissionBlockReason, v))
+}
+
+// CommissionBlockReasonHasPrefix applies the HasPrefix predicate on the "commission_block_reason" field.
+func CommissionBlockReasonHasPrefix(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldHasPrefix(FieldCommissionBlockReason, v))
+}
+
+// CommissionBlockReasonHasSuffix applies the HasSuffix predicate on the "commission_block_reason" field.
+func CommissionBlockReasonHasSuffix(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldHasSuffix(FieldCommissionBlockReason, v))
+}
+
+// CommissionBlockReasonEqualFold applies the EqualFold predicate on the "commission_block_reason" field.
+func CommissionBlockReasonEqualFold(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldEqualFold(FieldCommissionBlockReason, v))
+}
+
+// CommissionBlockReasonContainsFold applies the ContainsFold predicate on the "commission_block_reason" field.
+func CommissionBlockReasonContainsFold(v string) predicate.Invitation {
+	return predicate.Invitation(sql.FieldContainsFold(FieldCommissionBlockReason, v))
+}
+
+// CommissionCursorEQ applies the EQ predicate on the "commission_cursor" field.
+func CommissionCursorEQ(v int64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldEQ(FieldCommissionCursor, v))
+}
+
+// CommissionCursorNEQ applies the NEQ predicate on the "commission_cursor" field.
+func CommissionCursorNEQ(v int64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldNEQ(FieldCommissionCursor, v))
+}
+
+// CommissionCursorIn applies the In predicate on the "commission_cursor" field.
+func CommissionCursorIn(vs ...int64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldIn(FieldCommissionCursor, vs...))
+}
+
+// CommissionCursorNotIn applies the NotIn predicate on the "commission_cursor" field.
+func CommissionCursorNotIn(vs ...int64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldNotIn(FieldCommissionCursor, vs...))
+}
+
+// CommissionCursorGT applies the GT predicate on the "commission_cursor" field.
+func CommissionCursorGT(v int64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldGT(FieldCommissionCursor, v))
+}
+
+// CommissionCursorGTE applies the GTE predicate on the "commission_cursor" field.
+func CommissionCursorGTE(v int64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldGTE(FieldCommissionCursor, v))
+}
+
+// CommissionCursorLT applies the LT predicate on the "commission_cursor" field.
+func CommissionCursorLT(v int64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldLT(FieldCommissionCursor, v))
+}
+
+// CommissionCursorLTE applies the LTE predicate on the "commission_cursor" field.
+func CommissionCursorLTE(v int64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldLTE(FieldCommissionCursor, v))
+}
+
+// CommissionTotalEQ applies the EQ predicate on the "commission_total" field.
+func CommissionTotalEQ(v float64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldEQ(FieldCommissionTotal, v))
+}
+
+// CommissionTotalNEQ applies the NEQ predicate on the "commission_total" field.
+func CommissionTotalNEQ(v float64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldNEQ(FieldCommissionTotal, v))
+}
+
+// CommissionTotalIn applies the In predicate on the "commission_total" field.
+func CommissionTotalIn(vs ...float64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldIn(FieldCommissionTotal, vs...))
+}
+
+// CommissionTotalNotIn applies the NotIn predicate on the "commission_total" field.
+func CommissionTotalNotIn(vs ...float64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldNotIn(FieldCommissionTotal, vs...))
+}
+
+// CommissionTotalGT applies the GT predicate on the "commission_total" field.
+func CommissionTotalGT(v float64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldGT(FieldCommissionTotal, v))
+}
+
+// CommissionTotalGTE applies the GTE predicate on the "commission_total" field.
+func CommissionTotalGTE(v float64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldGTE(FieldCommissionTotal, v))
+}
+
+// CommissionTotalLT applies the LT predicate on the "commission_total" field.
+func CommissionTotalLT(v float64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldLT(FieldCommissionTotal, v))
+}
+
+// CommissionTotalLTE applies the LTE predicate on the "commission_total" field.
+func CommissionTotalLTE(v float64) predicate.Invitation {
+	return predicate.Invitation(sql.FieldLTE(FieldCommissionTotal, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Invitation {
 	return predicate.Invitation(sql.FieldEQ(FieldCreatedAt, v))
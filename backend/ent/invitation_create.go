@@ -98,6 +98,62 @@ func (_c *InvitationCreate) SetNillableConfirmedAt(v *time.Time) *InvitationCrea
 	return _c
 }
 
+// SetCommissionStatus sets the "commission_status" field.
+func (_c *InvitationCreate) SetCommissionStatus(v string) *InvitationCreate {
+	_c.mutation.SetCommissionStatus(v)
+	return _c
+}
+
+// SetNillableCommissionStatus sets the "commission_status" field if the given value is not nil.
+func (_c *InvitationCreate) SetNillableCommissionStatus(v *string) *InvitationCreate {
+	if v != nil {
+		_c.SetCommissionStatus(*v)
+	}
+	return _c
+}
+
+// SetCommissionBlockReason sets the "commission_block_reason" field.
+func (_c *InvitationCreate) SetCommissionBlockReason(v string) *InvitationCreate {
+	_c.mutation.SetCommissionBlockReason(v)
+	return _c
+}
+
+// SetNillableCommissionBlockReason sets the "commission_block_reason" field if the given value is not nil.
+func (_c *InvitationCreate) SetNillableCommissionBlockReason(v *string) *InvitationCreate {
+	if v != nil {
+		_c.SetCommissionBlockReason(*v)
+	}
+	return _c
+}
+
+// SetCommissionCursor sets the "commission_cursor" field.
+func (_c *InvitationCreate) SetCommissionCursor(v int64) *InvitationCreate {
+	_c.mutation.SetCommissionCursor(v)
+	return _c
+}
+
+// SetNillableCommissionCursor sets the "commission_cursor" field if the given value is not nil.
+func (_c *InvitationCreate) SetNillableCommissionCursor(v *int64) *InvitationCreate {
+	if v != nil {
+		_c.SetCommissionCursor(*v)
+	}
+	return _c
+}
+
+// SetCommissionTotal sets the "commission_total" field.
+func (_c *InvitationCreate) SetCommissionTotal(v float64) *InvitationCreate {
+	_c.mutation.SetCommissionTotal(v)
+	return _c
+}
+
+// SetNillableCommissionTotal sets the "commission_total" field if the given value is not nil.
+func (_c *InvitationCreate) SetNillableCommissionTotal(v *float64) *InvitationCreate {
+	if v != nil {
+		_c.SetCommissionTotal(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *InvitationCreate) SetCreatedAt(v time.Time) *InvitationCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -199,6 +255,22 @@ func (_c *InvitationCreate) defaults() {
 		v := invitation.DefaultStatus
 		_c.mutation.SetStatus(v)
 	}
+	if _, ok := _c.mutation.CommissionStatus(); !ok {
+		v := invitation.DefaultCommissionStatus
+		_c.mutation.SetCommissionStatus(v)
+	}
+	if _, ok := _c.mutation.CommissionBlockReason(); !ok {
+		v := invitation.DefaultCommissionBlockReason
+		_c.mutation.SetCommissionBlockReason(v)
+	}
+	if _, ok := _c.mutation.CommissionCursor(); !ok {
+		v := invitation.DefaultCommissionCursor
+		_c.mutation.SetCommissionCursor(v)
+	}
+	if _, ok := _c.mutation.CommissionTotal(); !ok {
+		v := invitation.DefaultCommissionTotal
+		_c.mutation.SetCommissionTotal(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := invitation.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
@@ -232,6 +304,28 @@ func (_c *InvitationCreate) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Invitation.status": %w`, err)}
 		}
 	}
+	if _, ok := _c.mutation.CommissionStatus(); !ok {
+		return &ValidationError{Name: "commission_status", err: errors.New(`ent: missing required field "Invitation.commission_status"`)}
+	}
+	if v, ok := _c.mutation.CommissionStatus(); ok {
+		if err := invitation.CommissionStatusValidator(v); err != nil {
+			return &ValidationError{Name: "commission_status", err: fmt.Errorf(`ent: validator failed for field "Invitation.commission_status": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CommissionBlockReason(); !ok {
+		return &ValidationError{Name: "commission_block_reason", err: errors.New(`ent: missing required field "Invitation.commission_block_reason"`)}
+	}
+	if v, ok := _c.mutation.CommissionBlockReason(); ok {
+		if err := invitation.CommissionBlockReasonValidator(v); err != nil {
+			return &ValidationError{Name: "commission_block_reason", err: fmt.Errorf(`ent: validator failed for field "Invitation.commission_block_reason": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CommissionCursor(); !ok {
+		return &ValidationError{Name: "commission_cursor", err: errors.New(`ent: missing required field "Invitation.commission_cursor"`)}
+	}
+	if _, ok := _c.mutation.CommissionTotal(); !ok {
+		return &ValidationError{Name: "commission_total", err: errors.New(`ent: missing required field "Invitation.commission_total"`)}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Invitation.created_at"`)}
 	}
@@ -284,6 +378,22 @@ func (_c *InvitationCreate) createSpec() (*Invitation, *sqlgraph.CreateSpec) {
 		_spec.SetField(invitation.FieldConfirmedAt, field.TypeTime, value)
 		_node.ConfirmedAt = &value
 	}
+	if value, ok := _c.mutation.CommissionStatus(); ok {
+		_spec.SetField(invitation.FieldCommissionStatus, field.TypeString, value)
+		_node.CommissionStatus = value
+	}
+	if value, ok := _c.mutation.CommissionBlockReason(); ok {
+		_spec.SetField(invitation.FieldCommissionBlockReason, field.TypeString, value)
+		_node.CommissionBlockReason = value
+	}
+	if value, ok := _c.mutation.CommissionCursor(); ok {
+		_spec.SetField(invitation.FieldCommissionCursor, field.TypeInt64, value)
+		_node.CommissionCursor = value
+	}
+	if value, ok := _c.mutation.CommissionTotal(); ok {
+		_spec.SetField(invitation.FieldCommissionTotal, field.TypeFloat64, value)
+		_node.CommissionTotal = value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(invitation.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -509,6 +619,66 @@ func (u *InvitationUpsert) ClearConfirmedAt() *InvitationUpsert {
 	return u
 }
 
+// SetCommissionStatus sets the "commission_status" field.
+func (u *InvitationUpsert) SetCommissionStatus(v string) *InvitationUpsert {
+	u.Set(invitation.FieldCommissionStatus, v)
+	return u
+}
+
+// UpdateCommissionStatus sets the "commission_status" field to the value that was provided on create.
+func (u *InvitationUpsert) UpdateCommissionStatus() *InvitationUpsert {
+	u.SetExcluded(invitation.FieldCommissionStatus)
+	return u
+}
+
+// SetCommissionBlockReason sets the "commission_block_reason" field.
+func (u *InvitationUpsert) SetCommissionBlockReason(v string) *InvitationUpsert {
+	u.Set(invitation.FieldCommissionBlockReason, v)
+	return u
+}
+
+// UpdateCommissionBlockReason sets the "commission_block_reason" field to the value that was provided on create.
+func (u *InvitationUpsert) UpdateCommissionBlockReason() *InvitationUpsert {
+	u.SetExcluded(invitation.FieldCommissionBlockReason)
+	return u
+}
+
+// SetCommissionCursor sets the "commission_cursor" field.
+func (u *InvitationUpsert) SetCommissionCursor(v int64) *InvitationUpsert {
+	u.Set(invitation.FieldCommissionCursor, v)
+	return u
+}
+
+// UpdateCommissionCursor sets the "commission_cursor" field to the value that was provided on create.
+func (u *InvitationUpsert) UpdateCommissionCursor() *InvitationUpsert {
+	u.SetExcluded(invitation.FieldCommissionCursor)
+	return u
+}
+
+// AddCommissionCursor adds v to the "commission_cursor" field.
+func (u *InvitationUpsert) AddCommissionCursor(v int64) *InvitationUpsert {
+	u.Add(invitation.FieldCommissionCursor, v)
+	return u
+}
+
+// SetCommissionTotal sets the "commission_total" field.
+func (u *InvitationUpsert) SetCommissionTotal(v float64) *InvitationUpsert {
+	u.Set(invitation.FieldCommissionTotal, v)
+	return u
+}
+
+// UpdateCommissionTotal sets the "commission_total" field to the value that was provided on create.
+func (u *InvitationUpsert) UpdateCommissionTotal() *InvitationUpsert {
+	u.SetExcluded(invitation.FieldCommissionTotal)
+	return u
+}
+
+// AddCommissionTotal adds v to the "commission_total" field.
+func (u *InvitationUpsert) AddCommissionTotal(v float64) *InvitationUpsert {
+	u.Add(invitation.FieldCommissionTotal, v)
+	return u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (u *InvitationUpsert) SetCreatedAt(v time.Time) *InvitationUpsert {
 	u.Set(invitation.FieldCreatedAt, v)
@@ -680,6 +850,76 @@ func (u *InvitationUpsertOne) ClearConfirmedAt() *InvitationUpsertOne {
 	})
 }
 
+// SetCommissionStatus sets the "commission_status" field.
+func (u *InvitationUpsertOne) SetCommissionStatus(v string) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetCommissionStatus(v)
+	})
+}
+
+// UpdateCommissionStatus sets the "commission_status" field to the value that was provided on create.
+func (u *InvitationUpsertOne) UpdateCommissionStatus() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateCommissionStatus()
+	})
+}
+
+// SetCommissionBlockReason sets the "commission_block_reason" field.
+func (u *InvitationUpsertOne) SetCommissionBlockReason(v string) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetCommissionBlockReason(v)
+	})
+}
+
+// UpdateCommissionBlockReason sets the "commission_block_reason" field to the value that was provided on create.
+func (u *InvitationUpsertOne) UpdateCommissionBlockReason() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateCommissionBlockReason()
+	})
+}
+
+// SetCommissionCursor sets the "commission_cursor" field.
+func (u *InvitationUpsertOne) SetCommissionCursor(v int64) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetCommissionCursor(v)
+	})
+}
+
+// AddCommissionCursor adds v to the "commission_cursor" field.
+func (u *InvitationUpsertOne) AddCommissionCursor(v int64) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.AddCommissionCursor(v)
+	})
+}
+
+// UpdateCommissionCursor sets the "commission_cursor" field to the value that was provided on create.
+func (u *InvitationUpsertOne) UpdateCommissionCursor() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateCommissionCursor()
+	})
+}
+
+// SetCommissionTotal sets the "commission_total" field.
+func (u *InvitationUpsertOne) SetCommissionTotal(v float64) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetCommissionTotal(v)
+	})
+}
+
+// AddCommissionTotal adds v to the "commission_total" field.
+func (u *InvitationUpsertOne) AddCommissionTotal(v float64) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.AddCommissionTotal(v)
+	})
+}
+
+// UpdateCommissionTotal sets the "commission_total" field to the value that was provided on create.
+func (u *InvitationUpsertOne) UpdateCommissionTotal() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateCommissionTotal()
+	})
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (u *InvitationUpsertOne) SetCreatedAt(v time.Time) *InvitationUpsertOne {
 	return u.Update(func(s *InvitationUpsert) {
@@ -1017,6 +1257,76 @@ func (u *InvitationUpsertBulk) ClearConfirmedAt() *InvitationUpsertBulk {
 	})
 }
 
+// SetCommissionStatus sets the "commission_status" field.
+func (u *InvitationUpsertBulk) SetCommissionStatus(v string) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetCommissionStatus(v)
+	})
+}
+
+// UpdateCommissionStatus sets the "commission_status" field to the value that was provided on create.
+func (u *InvitationUpsertBulk) UpdateCommissionStatus() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateCommissionStatus()
+	})
+}
+
+// SetCommissionBlockReason sets the "commission_block_reason" field.
+func (u *InvitationUpsertBulk) SetCommissionBlockReason(v string) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetCommissionBlockReason(v)
+	})
+}
+
+// UpdateCommissionBlockReason sets the "commission_block_reason" field to the value that was provided on create.
+func (u *InvitationUpsertBulk) UpdateCommissionBlockReason() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateCommissionBlockReason()
+	})
+}
+
+// SetCommissionCursor sets the "commission_cursor" field.
+func (u *InvitationUpsertBulk) SetCommissionCursor(v int64) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetCommissionCursor(v)
+	})
+}
+
+// AddCommissionCursor adds v to the "commission_cursor" field.
+func (u *InvitationUpsertBulk) AddCommissionCursor(v int64) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.AddCommissionCursor(v)
+	})
+}
+
+// UpdateCommissionCursor sets the "commission_cursor" field to the value that was provided on create.
+func (u *InvitationUpsertBulk) UpdateCommissionCursor() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateCommissionCursor()
+	})
+}
+
+// SetCommissionTotal sets the "commission_total" field.
+func (u *InvitationUpsertBulk) SetCommissionTotal(v float64) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetCommissionTotal(v)
+	})
+}
+
+// AddCommissionTotal adds v to the "commission_total" field.
+func (u *InvitationUpsertBulk) AddCommissionTotal(v float64) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.AddCommissionTotal(v)
+	})
+}
+
+// UpdateCommissionTotal sets the "commission_total" field to the value that was provided on create.
+func (u *InvitationUpsertBulk) UpdateCommissionTotal() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateCommissionTotal()
+	})
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (u *InvitationUpsertBulk) SetCreatedAt(v time.Time) *InvitationUpsertBulk {
 	return u.Update(func(s *InvitationUpsert) {
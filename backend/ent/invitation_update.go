@@ -147,6 +147,76 @@ func (_u *InvitationUpdate) ClearConfirmedAt() *InvitationUpdate {
 	return _u
 }
 
+// SetCommissionStatus sets the "commission_status" field.
+func (_u *InvitationUpdate) SetCommissionStatus(v string) *InvitationUpdate {
+	_u.mutation.SetCommissionStatus(v)
+	return _u
+}
+
+// SetNillableCommissionStatus sets the "commission_status" field if the given value is not nil.
+func (_u *InvitationUpdate) SetNillableCommissionStatus(v *string) *InvitationUpdate {
+	if v != nil {
+		_u.SetCommissionStatus(*v)
+	}
+	return _u
+}
+
+// SetCommissionBlockReason sets the "commission_block_reason" field.
+func (_u *InvitationUpdate) SetCommissionBlockReason(v string) *InvitationUpdate {
+	_u.mutation.SetCommissionBlockReason(v)
+	return _u
+}
+
+// SetNillableCommissionBlockReason sets the "commission_block_reason" field if the given value is not nil.
+func (_u *InvitationUpdate) SetNillableCommissionBlockReason(v *string) *InvitationUpdate {
+	if v != nil {
+		_u.SetCommissionBlockReason(*v)
+	}
+	return _u
+}
+
+// SetCommissionCursor sets the "commission_cursor" field.
+func (_u *InvitationUpdate) SetCommissionCursor(v int64) *InvitationUpdate {
+	_u.mutation.ResetCommissionCursor()
+	_u.mutation.SetCommissionCursor(v)
+	return _u
+}
+
+// SetNillableCommissionCursor sets the "commission_cursor" field if the given value is not nil.
+func (_u *InvitationUpdate) SetNillableCommissionCursor(v *int64) *InvitationUpdate {
+	if v != nil {
+		_u.SetCommissionCursor(*v)
+	}
+	return _u
+}
+
+// AddCommissionCursor adds value to the "commission_cursor" field.
+func (_u *InvitationUpdate) AddCommissionCursor(v int64) *InvitationUpdate {
+	_u.mutation.AddCommissionCursor(v)
+	return _u
+}
+
+// SetCommissionTotal sets the "commission_total" field.
+func (_u *InvitationUpdate) SetCommissionTotal(v float64) *InvitationUpdate {
+	_u.mutation.ResetCommissionTotal()
+	_u.mutation.SetCommissionTotal(v)
+	return _u
+}
+
+// SetNillableCommissionTotal sets the "commission_total" field if the given value is not nil.
+func (_u *InvitationUpdate) SetNillableCommissionTotal(v *float64) *InvitationUpdate {
+	if v != nil {
+		_u.SetCommissionTotal(*v)
+	}
+	return _u
+}
+
+// AddCommissionTotal adds value to the "commission_total" field.
+func (_u *InvitationUpdate) AddCommissionTotal(v float64) *InvitationUpdate {
+	_u.mutation.AddCommissionTotal(v)
+	return _u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_u *InvitationUpdate) SetCreatedAt(v time.Time) *InvitationUpdate {
 	_u.mutation.SetCreatedAt(v)
@@ -288,6 +358,16 @@ func (_u *InvitationUpdate) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Invitation.status": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.CommissionStatus(); ok {
+		if err := invitation.CommissionStatusValidator(v); err != nil {
+			return &ValidationError{Name: "commission_status", err: fmt.Errorf(`ent: validator failed for field "Invitation.commission_status": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.CommissionBlockReason(); ok {
+		if err := invitation.CommissionBlockReasonValidator(v); err != nil {
+			return &ValidationError{Name: "commission_block_reason", err: fmt.Errorf(`ent: validator failed for field "Invitation.commission_block_reason": %w`, err)}
+		}
+	}
 	if _u.mutation.InviterCleared() && len(_u.mutation.InviterIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Invitation.inviter"`)
 	}
@@ -327,6 +407,24 @@ func (_u *InvitationUpdate) sqlSave(ctx context.Context) (_node int, err error)
 	if _u.mutation.ConfirmedAtCleared() {
 		_spec.ClearField(invitation.FieldConfirmedAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.CommissionStatus(); ok {
+		_spec.SetField(invitation.FieldCommissionStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CommissionBlockReason(); ok {
+		_spec.SetField(invitation.FieldCommissionBlockReason, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CommissionCursor(); ok {
+		_spec.SetField(invitation.FieldCommissionCursor, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedCommissionCursor(); ok {
+		_spec.AddField(invitation.FieldCommissionCursor, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.CommissionTotal(); ok {
+		_spec.SetField(invitation.FieldCommissionTotal, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedCommissionTotal(); ok {
+		_spec.AddField(invitation.FieldCommissionTotal, field.TypeFloat64, value)
+	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(invitation.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -599,6 +697,76 @@ func (_u *InvitationUpdateOne) ClearConfirmedAt() *InvitationUpdateOne {
 	return _u
 }
 
+// SetCommissionStatus sets the "commission_status" field.
+func (_u *InvitationUpdateOne) SetCommissionStatus(v string) *InvitationUpdateOne {
+	_u.mutation.SetCommissionStatus(v)
+	return _u
+}
+
+// SetNillableCommissionStatus sets the "commission_status" field if the given value is not nil.
+func (_u *InvitationUpdateOne) SetNillableCommissionStatus(v *string) *InvitationUpdateOne {
+	if v != nil {
+		_u.SetCommissionStatus(*v)
+	}
+	return _u
+}
+
+// SetCommissionBlockReason sets the "commission_block_reason" field.
+func (_u *InvitationUpdateOne) SetCommissionBlockReason(v string) *InvitationUpdateOne {
+	_u.mutation.SetCommissionBlockReason(v)
+	return _u
+}
+
+// SetNillableCommissionBlockReason sets the "commission_block_reason" field if the given value is not nil.
+func (_u *InvitationUpdateOne) SetNillableCommissionBlockReason(v *string) *InvitationUpdateOne {
+	if v != nil {
+		_u.SetCommissionBlockReason(*v)
+	}
+	return _u
+}
+
+// SetCommissionCursor sets the "commission_cursor" field.
+func (_u *InvitationUpdateOne) SetCommissionCursor(v int64) *InvitationUpdateOne {
+	_u.mutation.ResetCommissionCursor()
+	_u.mutation.SetCommissionCursor(v)
+	return _u
+}
+
+// SetNillableCommissionCursor sets the "commission_cursor" field if the given value is not nil.
+func (_u *InvitationUpdateOne) SetNillableCommissionCursor(v *int64) *InvitationUpdateOne {
+	if v != nil {
+		_u.SetCommissionCursor(*v)
+	}
+	return _u
+}
+
+// AddCommissionCursor adds value to the "commission_cursor" field.
+func (_u *InvitationUpdateOne) AddCommissionCursor(v int64) *InvitationUpdateOne {
+	_u.mutation.AddCommissionCursor(v)
+	return _u
+}
+
+// SetCommissionTotal sets the "commission_total" field.
+func (_u *InvitationUpdateOne) SetCommissionTotal(v float64) *InvitationUpdateOne {
+	_u.mutation.ResetCommissionTotal()
+	_u.mutation.SetCommissionTotal(v)
+	return _u
+}
+
+// SetNillableCommissionTotal sets the "commission_total" field if the given value is not nil.
+func (_u *InvitationUpdateOne) SetNillableCommissionTotal(v *float64) *InvitationUpdateOne {
+	if v != nil {
+		_u.SetCommissionTotal(*v)
+	}
+	return _u
+}
+
+// AddCommissionTotal adds value to the "commission_total" field.
+func (_u *InvitationUpdateOne) AddCommissionTotal(v float64) *InvitationUpdateOne {
+	_u.mutation.AddCommissionTotal(v)
+	return _u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_u *InvitationUpdateOne) SetCreatedAt(v time.Time) *InvitationUpdateOne {
 	_u.mutation.SetCreatedAt(v)
@@ -753,6 +921,16 @@ func (_u *InvitationUpdateOne) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Invitation.status": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.CommissionStatus(); ok {
+		if err := invitation.CommissionStatusValidator(v); err != nil {
+			return &ValidationError{Name: "commission_status", err: fmt.Errorf(`ent: validator failed for field "Invitation.commission_status": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.CommissionBlockReason(); ok {
+		if err := invitation.CommissionBlockReasonValidator(v); err != nil {
+			return &ValidationError{Name: "commission_block_reason", err: fmt.Errorf(`ent: validator failed for field "Invitation.commission_block_reason": %w`, err)}
+		}
+	}
 	if _u.mutation.InviterCleared() && len(_u.mutation.InviterIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Invitation.inviter"`)
 	}
@@ -809,6 +987,24 @@ func (_u *InvitationUpdateOne) sqlSave(ctx context.Context) (_node *Invitation,
 	if _u.mutation.ConfirmedAtCleared() {
 		_spec.ClearField(invitation.FieldConfirmedAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.CommissionStatus(); ok {
+		_spec.SetField(invitation.FieldCommissionStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CommissionBlockReason(); ok {
+		_spec.SetField(invitation.FieldCommissionBlockReason, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CommissionCursor(); ok {
+		_spec.SetField(invitation.FieldCommissionCursor, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedCommissionCursor(); ok {
+		_spec.AddField(invitation.FieldCommissionCursor, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.CommissionTotal(); ok {
+		_spec.SetField(invitation.FieldCommissionTotal, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedCommissionTotal(); ok {
+		_spec.AddField(invitation.FieldCommissionTotal, field.TypeFloat64, value)
+	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(invitation.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -0,0 +1,205 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Wei-Shaw/sub2api/ent/invitecommission"
+)
+
+// InviteCommission is the model entity for the InviteCommission schema.
+type InviteCommission struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// InviteID holds the value of the "invite_id" field.
+	InviteID int64 `json:"invite_id,omitempty"`
+	// InviterID holds the value of the "inviter_id" field.
+	InviterID int64 `json:"inviter_id,omitempty"`
+	// InviteeID holds the value of the "invitee_id" field.
+	InviteeID int64 `json:"invitee_id,omitempty"`
+	// BaseAmount holds the value of the "base_amount" field.
+	BaseAmount float64 `json:"base_amount,omitempty"`
+	// Rate holds the value of the "rate" field.
+	Rate float64 `json:"rate,omitempty"`
+	// Amount holds the value of the "amount" field.
+	Amount float64 `json:"amount,omitempty"`
+	// FromLogID holds the value of the "from_log_id" field.
+	FromLogID int64 `json:"from_log_id,omitempty"`
+	// ToLogID holds the value of the "to_log_id" field.
+	ToLogID int64 `json:"to_log_id,omitempty"`
+	// LogCount holds the value of the "log_count" field.
+	LogCount int `json:"log_count,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*InviteCommission) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case invitecommission.FieldBaseAmount, invitecommission.FieldRate, invitecommission.FieldAmount:
+			values[i] = new(sql.NullFloat64)
+		case invitecommission.FieldID, invitecommission.FieldInviteID, invitecommission.FieldInviterID, invitecommission.FieldInviteeID, invitecommission.FieldFromLogID, invitecommission.FieldToLogID, invitecommission.FieldLogCount:
+			values[i] = new(sql.NullInt64)
+		case invitecommission.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the InviteCommission fields.
+func (_m *InviteCommission) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case invitecommission.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int64(value.Int64)
+		case invitecommission.FieldInviteID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field invite_id", values[i])
+			} else if value.Valid {
+				_m.InviteID = value.Int64
+			}
+		case invitecommission.FieldInviterID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field inviter_id", values[i])
+			} else if value.Valid {
+				_m.InviterID = value.Int64
+			}
+		case invitecommission.FieldInviteeID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field invitee_id", values[i])
+			} else if value.Valid {
+				_m.InviteeID = value.Int64
+			}
+		case invitecommission.FieldBaseAmount:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field base_amount", values[i])
+			} else if value.Valid {
+				_m.BaseAmount = value.Float64
+			}
+		case invitecommission.FieldRate:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field rate", values[i])
+			} else if value.Valid {
+				_m.Rate = value.Float64
+			}
+		case invitecommission.FieldAmount:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field amount", values[i])
+			} else if value.Valid {
+				_m.Amount = value.Float64
+			}
+		case invitecommission.FieldFromLogID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field from_log_id", values[i])
+			} else if value.Valid {
+				_m.FromLogID = value.Int64
+			}
+		case invitecommission.FieldToLogID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field to_log_id", values[i])
+			} else if value.Valid {
+				_m.ToLogID = value.Int64
+			}
+		case invitecommission.FieldLogCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field log_count", values[i])
+			} else if value.Valid {
+				_m.LogCount = int(value.Int64)
+			}
+		case invitecommission.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the InviteCommission.
+// This includes values selected through modifiers, order, etc.
+func (_m *InviteCommission) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this InviteCommission.
+// Note that you need to call InviteCommission.Unwrap() before calling this method if this InviteCommission
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *InviteCommission) Update() *InviteCommissionUpdateOne {
+	return NewInviteCommissionClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the InviteCommission entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *InviteCommission) Unwrap() *InviteCommission {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: InviteCommission is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *InviteCommission) String() string {
+	var builder strings.Builder
+	builder.WriteString("InviteCommission(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("invite_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.InviteID))
+	builder.WriteString(", ")
+	builder.WriteString("inviter_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.InviterID))
+	builder.WriteString(", ")
+	builder.WriteString("invitee_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.InviteeID))
+	builder.WriteString(", ")
+	builder.WriteString("base_amount=")
+	builder.WriteString(fmt.Sprintf("%v", _m.BaseAmount))
+	builder.WriteString(", ")
+	builder.WriteString("rate=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Rate))
+	builder.WriteString(", ")
+	builder.WriteString("amount=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Amount))
+	builder.WriteString(", ")
+	builder.WriteString("from_log_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.FromLogID))
+	builder.WriteString(", ")
+	builder.WriteString("to_log_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ToLogID))
+	builder.WriteString(", ")
+	builder.WriteString("log_count=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LogCount))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// InviteCommissions is a parsable slice of InviteCommission.
+type InviteCommissions []*InviteCommission
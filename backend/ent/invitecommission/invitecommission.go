@@ -0,0 +1,128 @@
+// Code generated by ent, DO NOT EDIT.
+
+package invitecommission
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the invitecommission type in the database.
+	Label = "invite_commission"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldInviteID holds the string denoting the invite_id field in the database.
+	FieldInviteID = "invite_id"
+	// FieldInviterID holds the string denoting the inviter_id field in the database.
+	FieldInviterID = "inviter_id"
+	// FieldInviteeID holds the string denoting the invitee_id field in the database.
+	FieldInviteeID = "invitee_id"
+	// FieldBaseAmount holds the string denoting the base_amount field in the database.
+	FieldBaseAmount = "base_amount"
+	// FieldRate holds the string denoting the rate field in the database.
+	FieldRate = "rate"
+	// FieldAmount holds the string denoting the amount field in the database.
+	FieldAmount = "amount"
+	// FieldFromLogID holds the string denoting the from_log_id field in the database.
+	FieldFromLogID = "from_log_id"
+	// FieldToLogID holds the string denoting the to_log_id field in the database.
+	FieldToLogID = "to_log_id"
+	// FieldLogCount holds the string denoting the log_count field in the database.
+	FieldLogCount = "log_count"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// Table holds the table name of the invitecommission in the database.
+	Table = "invite_commissions"
+)
+
+// Columns holds all SQL columns for invitecommission fields.
+var Columns = []string{
+	FieldID,
+	FieldInviteID,
+	FieldInviterID,
+	FieldInviteeID,
+	FieldBaseAmount,
+	FieldRate,
+	FieldAmount,
+	FieldFromLogID,
+	FieldToLogID,
+	FieldLogCount,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultLogCount holds the default value on creation for the "log_count" field.
+	DefaultLogCount int
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the InviteCommission queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByInviteID orders the results by the invite_id field.
+func ByInviteID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldInviteID, opts...).ToFunc()
+}
+
+// ByInviterID orders the results by the inviter_id field.
+func ByInviterID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldInviterID, opts...).ToFunc()
+}
+
+// ByInviteeID orders the results by the invitee_id field.
+func ByInviteeID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldInviteeID, opts...).ToFunc()
+}
+
+// ByBaseAmount orders the results by the base_amount field.
+func ByBaseAmount(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldBaseAmount, opts...).ToFunc()
+}
+
+// ByRate orders the results by the rate field.
+func ByRate(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRate, opts...).ToFunc()
+}
+
+// ByAmount orders the results by the amount field.
+func ByAmount(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAmount, opts...).ToFunc()
+}
+
+// ByFromLogID orders the results by the from_log_id field.
+func ByFromLogID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldFromLogID, opts...).ToFunc()
+}
+
+// ByToLogID orders the results by the to_log_id field.
+func ByToLogID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldToLogID, opts...).ToFunc()
+}
+
+// ByLogCount orders the results by the log_count field.
+func ByLogCount(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLogCount, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
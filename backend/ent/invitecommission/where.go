@@ -0,0 +1,520 @@
+// Code generated by ent, DO NOT EDIT.
+
+package invitecommission
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldID, id))
+}
+
+// InviteID applies equality check predicate on the "invite_id" field. It's identical to InviteIDEQ.
+func InviteID(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldInviteID, v))
+}
+
+// InviterID applies equality check predicate on the "inviter_id" field. It's identical to InviterIDEQ.
+func InviterID(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldInviterID, v))
+}
+
+// InviteeID applies equality check predicate on the "invitee_id" field. It's identical to InviteeIDEQ.
+func InviteeID(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldInviteeID, v))
+}
+
+// BaseAmount applies equality check predicate on the "base_amount" field. It's identical to BaseAmountEQ.
+func BaseAmount(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldBaseAmount, v))
+}
+
+// Rate applies equality check predicate on the "rate" field. It's identical to RateEQ.
+func Rate(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldRate, v))
+}
+
+// Amount applies equality check predicate on the "amount" field. It's identical to AmountEQ.
+func Amount(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldAmount, v))
+}
+
+// FromLogID applies equality check predicate on the "from_log_id" field. It's identical to FromLogIDEQ.
+func FromLogID(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldFromLogID, v))
+}
+
+// ToLogID applies equality check predicate on the "to_log_id" field. It's identical to ToLogIDEQ.
+func ToLogID(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldToLogID, v))
+}
+
+// LogCount applies equality check predicate on the "log_count" field. It's identical to LogCountEQ.
+func LogCount(v int) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldLogCount, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// InviteIDEQ applies the EQ predicate on the "invite_id" field.
+func InviteIDEQ(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldInviteID, v))
+}
+
+// InviteIDNEQ applies the NEQ predicate on the "invite_id" field.
+func InviteIDNEQ(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldInviteID, v))
+}
+
+// InviteIDIn applies the In predicate on the "invite_id" field.
+func InviteIDIn(vs ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldInviteID, vs...))
+}
+
+// InviteIDNotIn applies the NotIn predicate on the "invite_id" field.
+func InviteIDNotIn(vs ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldInviteID, vs...))
+}
+
+// InviteIDGT applies the GT predicate on the "invite_id" field.
+func InviteIDGT(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldInviteID, v))
+}
+
+// InviteIDGTE applies the GTE predicate on the "invite_id" field.
+func InviteIDGTE(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldInviteID, v))
+}
+
+// InviteIDLT applies the LT predicate on the "invite_id" field.
+func InviteIDLT(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldInviteID, v))
+}
+
+// InviteIDLTE applies the LTE predicate on the "invite_id" field.
+func InviteIDLTE(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldInviteID, v))
+}
+
+// InviterIDEQ applies the EQ predicate on the "inviter_id" field.
+func InviterIDEQ(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldInviterID, v))
+}
+
+// InviterIDNEQ applies the NEQ predicate on the "inviter_id" field.
+func InviterIDNEQ(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldInviterID, v))
+}
+
+// InviterIDIn applies the In predicate on the "inviter_id" field.
+func InviterIDIn(vs ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldInviterID, vs...))
+}
+
+// InviterIDNotIn applies the NotIn predicate on the "inviter_id" field.
+func InviterIDNotIn(vs ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldInviterID, vs...))
+}
+
+// InviterIDGT applies the GT predicate on the "inviter_id" field.
+func InviterIDGT(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldInviterID, v))
+}
+
+// InviterIDGTE applies the GTE predicate on the "inviter_id" field.
+func InviterIDGTE(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldInviterID, v))
+}
+
+// InviterIDLT applies the LT predicate on the "inviter_id" field.
+func InviterIDLT(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldInviterID, v))
+}
+
+// InviterIDLTE applies the LTE predicate on the "inviter_id" field.
+func InviterIDLTE(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldInviterID, v))
+}
+
+// InviteeIDEQ applies the EQ predicate on the "invitee_id" field.
+func InviteeIDEQ(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldInviteeID, v))
+}
+
+// InviteeIDNEQ applies the NEQ predicate on the "invitee_id" field.
+func InviteeIDNEQ(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldInviteeID, v))
+}
+
+// InviteeIDIn applies the In predicate on the "invitee_id" field.
+func InviteeIDIn(vs ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldInviteeID, vs...))
+}
+
+// InviteeIDNotIn applies the NotIn predicate on the "invitee_id" field.
+func InviteeIDNotIn(vs ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldInviteeID, vs...))
+}
+
+// InviteeIDGT applies the GT predicate on the "invitee_id" field.
+func InviteeIDGT(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldInviteeID, v))
+}
+
+// InviteeIDGTE applies the GTE predicate on the "invitee_id" field.
+func InviteeIDGTE(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldInviteeID, v))
+}
+
+// InviteeIDLT applies the LT predicate on the "invitee_id" field.
+func InviteeIDLT(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldInviteeID, v))
+}
+
+// InviteeIDLTE applies the LTE predicate on the "invitee_id" field.
+func InviteeIDLTE(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldInviteeID, v))
+}
+
+// BaseAmountEQ applies the EQ predicate on the "base_amount" field.
+func BaseAmountEQ(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldBaseAmount, v))
+}
+
+// BaseAmountNEQ applies the NEQ predicate on the "base_amount" field.
+func BaseAmountNEQ(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldBaseAmount, v))
+}
+
+// BaseAmountIn applies the In predicate on the "base_amount" field.
+func BaseAmountIn(vs ...float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldBaseAmount, vs...))
+}
+
+// BaseAmountNotIn applies the NotIn predicate on the "base_amount" field.
+func BaseAmountNotIn(vs ...float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldBaseAmount, vs...))
+}
+
+// BaseAmountGT applies the GT predicate on the "base_amount" field.
+func BaseAmountGT(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldBaseAmount, v))
+}
+
+// BaseAmountGTE applies the GTE predicate on the "base_amount" field.
+func BaseAmountGTE(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldBaseAmount, v))
+}
+
+// BaseAmountLT applies the LT predicate on the "base_amount" field.
+func BaseAmountLT(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldBaseAmount, v))
+}
+
+// BaseAmountLTE applies the LTE predicate on the "base_amount" field.
+func BaseAmountLTE(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldBaseAmount, v))
+}
+
+// RateEQ applies the EQ predicate on the "rate" field.
+func RateEQ(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldRate, v))
+}
+
+// RateNEQ applies the NEQ predicate on the "rate" field.
+func RateNEQ(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldRate, v))
+}
+
+// RateIn applies the In predicate on the "rate" field.
+func RateIn(vs ...float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldRate, vs...))
+}
+
+// RateNotIn applies the NotIn predicate on the "rate" field.
+func RateNotIn(vs ...float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldRate, vs...))
+}
+
+// RateGT applies the GT predicate on the "rate" field.
+func RateGT(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldRate, v))
+}
+
+// RateGTE applies the GTE predicate on the "rate" field.
+func RateGTE(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldRate, v))
+}
+
+// RateLT applies the LT predicate on the "rate" field.
+func RateLT(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldRate, v))
+}
+
+// RateLTE applies the LTE predicate on the "rate" field.
+func RateLTE(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldRate, v))
+}
+
+// AmountEQ applies the EQ predicate on the "amount" field.
+func AmountEQ(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldAmount, v))
+}
+
+// AmountNEQ applies the NEQ predicate on the "amount" field.
+func AmountNEQ(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldAmount, v))
+}
+
+// AmountIn applies the In predicate on the "amount" field.
+func AmountIn(vs ...float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldAmount, vs...))
+}
+
+// AmountNotIn applies the NotIn predicate on the "amount" field.
+func AmountNotIn(vs ...float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldAmount, vs...))
+}
+
+// AmountGT applies the GT predicate on the "amount" field.
+func AmountGT(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldAmount, v))
+}
+
+// AmountGTE applies the GTE predicate on the "amount" field.
+func AmountGTE(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldAmount, v))
+}
+
+// AmountLT applies the LT predicate on the "amount" field.
+func AmountLT(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldAmount, v))
+}
+
+// AmountLTE applies the LTE predicate on the "amount" field.
+func AmountLTE(v float64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldAmount, v))
+}
+
+// FromLogIDEQ applies the EQ predicate on the "from_log_id" field.
+func FromLogIDEQ(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldFromLogID, v))
+}
+
+// FromLogIDNEQ applies the NEQ predicate on the "from_log_id" field.
+func FromLogIDNEQ(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldFromLogID, v))
+}
+
+// FromLogIDIn applies the In predicate on the "from_log_id" field.
+func FromLogIDIn(vs ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldFromLogID, vs...))
+}
+
+// FromLogIDNotIn applies the NotIn predicate on the "from_log_id" field.
+func FromLogIDNotIn(vs ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldFromLogID, vs...))
+}
+
+// FromLogIDGT applies the GT predicate on the "from_log_id" field.
+func FromLogIDGT(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldFromLogID, v))
+}
+
+// FromLogIDGTE applies the GTE predicate on the "from_log_id" field.
+func FromLogIDGTE(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldFromLogID, v))
+}
+
+// FromLogIDLT applies the LT predicate on the "from_log_id" field.
+func FromLogIDLT(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldFromLogID, v))
+}
+
+// FromLogIDLTE applies the LTE predicate on the "from_log_id" field.
+func FromLogIDLTE(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldFromLogID, v))
+}
+
+// ToLogIDEQ applies the EQ predicate on the "to_log_id" field.
+func ToLogIDEQ(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldToLogID, v))
+}
+
+// ToLogIDNEQ applies the NEQ predicate on the "to_log_id" field.
+func ToLogIDNEQ(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldToLogID, v))
+}
+
+// ToLogIDIn applies the In predicate on the "to_log_id" field.
+func ToLogIDIn(vs ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldToLogID, vs...))
+}
+
+// ToLogIDNotIn applies the NotIn predicate on the "to_log_id" field.
+func ToLogIDNotIn(vs ...int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldToLogID, vs...))
+}
+
+// ToLogIDGT applies the GT predicate on the "to_log_id" field.
+func ToLogIDGT(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldToLogID, v))
+}
+
+// ToLogIDGTE applies the GTE predicate on the "to_log_id" field.
+func ToLogIDGTE(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldToLogID, v))
+}
+
+// ToLogIDLT applies the LT predicate on the "to_log_id" field.
+func ToLogIDLT(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldToLogID, v))
+}
+
+// ToLogIDLTE applies the LTE predicate on the "to_log_id" field.
+func ToLogIDLTE(v int64) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldToLogID, v))
+}
+
+// LogCountEQ applies the EQ predicate on the "log_count" field.
+func LogCountEQ(v int) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldLogCount, v))
+}
+
+// LogCountNEQ applies the NEQ predicate on the "log_count" field.
+func LogCountNEQ(v int) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldLogCount, v))
+}
+
+// LogCountIn applies the In predicate on the "log_count" field.
+func LogCountIn(vs ...int) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldLogCount, vs...))
+}
+
+// LogCountNotIn applies the NotIn predicate on the "log_count" field.
+func LogCountNotIn(vs ...int) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldLogCount, vs...))
+}
+
+// LogCountGT applies the GT predicate on the "log_count" field.
+func LogCountGT(v int) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldLogCount, v))
+}
+
+// LogCountGTE applies the GTE predicate on the "log_count" field.
+func LogCountGTE(v int) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldLogCount, v))
+}
+
+// LogCountLT applies the LT predicate on the "log_count" field.
+func LogCountLT(v int) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldLogCount, v))
+}
+
+// LogCountLTE applies the LTE predicate on the "log_count" field.
+func LogCountLTE(v int) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldLogCount, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.InviteCommission) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.InviteCommission) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.InviteCommission) predicate.InviteCommission {
+	return predicate.InviteCommission(sql.NotPredicates(p))
+}
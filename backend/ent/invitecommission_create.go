@@ -0,0 +1,1142 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/invitecommission"
+)
+
+// InviteCommissionCreate is the builder for creating a InviteCommission entity.
+type InviteCommissionCreate struct {
+	config
+	mutation *InviteCommissionMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetInviteID sets the "invite_id" field.
+func (_c *InviteCommissionCreate) SetInviteID(v int64) *InviteCommissionCreate {
+	_c.mutation.SetInviteID(v)
+	return _c
+}
+
+// SetInviterID sets the "inviter_id" field.
+func (_c *InviteCommissionCreate) SetInviterID(v int64) *InviteCommissionCreate {
+	_c.mutation.SetInviterID(v)
+	return _c
+}
+
+// SetInviteeID sets the "invitee_id" field.
+func (_c *InviteCommissionCreate) SetInviteeID(v int64) *InviteCommissionCreate {
+	_c.mutation.SetInviteeID(v)
+	return _c
+}
+
+// SetBaseAmount sets the "base_amount" field.
+func (_c *InviteCommissionCreate) SetBaseAmount(v float64) *InviteCommissionCreate {
+	_c.mutation.SetBaseAmount(v)
+	return _c
+}
+
+// SetRate sets the "rate" field.
+func (_c *InviteCommissionCreate) SetRate(v float64) *InviteCommissionCreate {
+	_c.mutation.SetRate(v)
+	return _c
+}
+
+// SetAmount sets the "amount" field.
+func (_c *InviteCommissionCreate) SetAmount(v float64) *InviteCommissionCreate {
+	_c.mutation.SetAmount(v)
+	return _c
+}
+
+// SetFromLogID sets the "from_log_id" field.
+func (_c *InviteCommissionCreate) SetFromLogID(v int64) *InviteCommissionCreate {
+	_c.mutation.SetFromLogID(v)
+	return _c
+}
+
+// SetToLogID sets the "to_log_id" field.
+func (_c *InviteCommissionCreate) SetToLogID(v int64) *InviteCommissionCreate {
+	_c.mutation.SetToLogID(v)
+	return _c
+}
+
+// SetLogCount sets the "log_count" field.
+func (_c *InviteCommissionCreate) SetLogCount(v int) *InviteCommissionCreate {
+	_c.mutation.SetLogCount(v)
+	return _c
+}
+
+// SetNillableLogCount sets the "log_count" field if the given value is not nil.
+func (_c *InviteCommissionCreate) SetNillableLogCount(v *int) *InviteCommissionCreate {
+	if v != nil {
+		_c.SetLogCount(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *InviteCommissionCreate) SetCreatedAt(v time.Time) *InviteCommissionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *InviteCommissionCreate) SetNillableCreatedAt(v *time.Time) *InviteCommissionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the InviteCommissionMutation object of the builder.
+func (_c *InviteCommissionCreate) Mutation() *InviteCommissionMutation {
+	return _c.mutation
+}
+
+// Save creates the InviteCommission in the database.
+func (_c *InviteCommissionCreate) Save(ctx context.Context) (*InviteCommission, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *InviteCommissionCreate) SaveX(ctx context.Context) *InviteCommission {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *InviteCommissionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *InviteCommissionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *InviteCommissionCreate) defaults() {
+	if _, ok := _c.mutation.LogCount(); !ok {
+		v := invitecommission.DefaultLogCount
+		_c.mutation.SetLogCount(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := invitecommission.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *InviteCommissionCreate) check() error {
+	if _, ok := _c.mutation.InviteID(); !ok {
+		return &ValidationError{Name: "invite_id", err: errors.New(`ent: missing required field "InviteCommission.invite_id"`)}
+	}
+	if _, ok := _c.mutation.InviterID(); !ok {
+		return &ValidationError{Name: "inviter_id", err: errors.New(`ent: missing required field "InviteCommission.inviter_id"`)}
+	}
+	if _, ok := _c.mutation.InviteeID(); !ok {
+		return &ValidationError{Name: "invitee_id", err: errors.New(`ent: missing required field "InviteCommission.invitee_id"`)}
+	}
+	if _, ok := _c.mutation.BaseAmount(); !ok {
+		return &ValidationError{Name: "base_amount", err: errors.New(`ent: missing required field "InviteCommission.base_amount"`)}
+	}
+	if _, ok := _c.mutation.Rate(); !ok {
+		return &ValidationError{Name: "rate", err: errors.New(`ent: missing required field "InviteCommission.rate"`)}
+	}
+	if _, ok := _c.mutation.Amount(); !ok {
+		return &ValidationError{Name: "amount", err: errors.New(`ent: missing required field "InviteCommission.amount"`)}
+	}
+	if _, ok := _c.mutation.FromLogID(); !ok {
+		return &ValidationError{Name: "from_log_id", err: errors.New(`ent: missing required field "InviteCommission.from_log_id"`)}
+	}
+	if _, ok := _c.mutation.ToLogID(); !ok {
+		return &ValidationError{Name: "to_log_id", err: errors.New(`ent: missing required field "InviteCommission.to_log_id"`)}
+	}
+	if _, ok := _c.mutation.LogCount(); !ok {
+		return &ValidationError{Name: "log_count", err: errors.New(`ent: missing required field "InviteCommission.log_count"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "InviteCommission.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *InviteCommissionCreate) sqlSave(ctx context.Context) (*InviteCommission, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int64(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *InviteCommissionCreate) createSpec() (*InviteCommission, *sqlgraph.CreateSpec) {
+	var (
+		_node = &InviteCommission{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(invitecommission.Table, sqlgraph.NewFieldSpec(invitecommission.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.InviteID(); ok {
+		_spec.SetField(invitecommission.FieldInviteID, field.TypeInt64, value)
+		_node.InviteID = value
+	}
+	if value, ok := _c.mutation.InviterID(); ok {
+		_spec.SetField(invitecommission.FieldInviterID, field.TypeInt64, value)
+		_node.InviterID = value
+	}
+	if value, ok := _c.mutation.InviteeID(); ok {
+		_spec.SetField(invitecommission.FieldInviteeID, field.TypeInt64, value)
+		_node.InviteeID = value
+	}
+	if value, ok := _c.mutation.BaseAmount(); ok {
+		_spec.SetField(invitecommission.FieldBaseAmount, field.TypeFloat64, value)
+		_node.BaseAmount = value
+	}
+	if value, ok := _c.mutation.Rate(); ok {
+		_spec.SetField(invitecommission.FieldRate, field.TypeFloat64, value)
+		_node.Rate = value
+	}
+	if value, ok := _c.mutation.Amount(); ok {
+		_spec.SetField(invitecommission.FieldAmount, field.TypeFloat64, value)
+		_node.Amount = value
+	}
+	if value, ok := _c.mutation.FromLogID(); ok {
+		_spec.SetField(invitecommission.FieldFromLogID, field.TypeInt64, value)
+		_node.FromLogID = value
+	}
+	if value, ok := _c.mutation.ToLogID(); ok {
+		_spec.SetField(invitecommission.FieldToLogID, field.TypeInt64, value)
+		_node.ToLogID = value
+	}
+	if value, ok := _c.mutation.LogCount(); ok {
+		_spec.SetField(invitecommission.FieldLogCount, field.TypeInt, value)
+		_node.LogCount = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(invitecommission.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.InviteCommission.Create().
+//		SetInviteID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.InviteCommissionUpsert) {
+//			SetInviteID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *InviteCommissionCreate) OnConflict(opts ...sql.ConflictOption) *InviteCommissionUpsertOne {
+	_c.conflict = opts
+	return &InviteCommissionUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.InviteCommission.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *InviteCommissionCreate) OnConflictColumns(columns ...string) *InviteCommissionUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &InviteCommissionUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// InviteCommissionUpsertOne is the builder for "upsert"-ing
+	//  one InviteCommission node.
+	InviteCommissionUpsertOne struct {
+		create *InviteCommissionCreate
+	}
+
+	// InviteCommissionUpsert is the "OnConflict" setter.
+	InviteCommissionUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetInviteID sets the "invite_id" field.
+func (u *InviteCommissionUpsert) SetInviteID(v int64) *InviteCommissionUpsert {
+	u.Set(invitecommission.FieldInviteID, v)
+	return u
+}
+
+// UpdateInviteID sets the "invite_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsert) UpdateInviteID() *InviteCommissionUpsert {
+	u.SetExcluded(invitecommission.FieldInviteID)
+	return u
+}
+
+// AddInviteID adds v to the "invite_id" field.
+func (u *InviteCommissionUpsert) AddInviteID(v int64) *InviteCommissionUpsert {
+	u.Add(invitecommission.FieldInviteID, v)
+	return u
+}
+
+// SetInviterID sets the "inviter_id" field.
+func (u *InviteCommissionUpsert) SetInviterID(v int64) *InviteCommissionUpsert {
+	u.Set(invitecommission.FieldInviterID, v)
+	return u
+}
+
+// UpdateInviterID sets the "inviter_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsert) UpdateInviterID() *InviteCommissionUpsert {
+	u.SetExcluded(invitecommission.FieldInviterID)
+	return u
+}
+
+// AddInviterID adds v to the "inviter_id" field.
+func (u *InviteCommissionUpsert) AddInviterID(v int64) *InviteCommissionUpsert {
+	u.Add(invitecommission.FieldInviterID, v)
+	return u
+}
+
+// SetInviteeID sets the "invitee_id" field.
+func (u *InviteCommissionUpsert) SetInviteeID(v int64) *InviteCommissionUpsert {
+	u.Set(invitecommission.FieldInviteeID, v)
+	return u
+}
+
+// UpdateInviteeID sets the "invitee_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsert) UpdateInviteeID() *InviteCommissionUpsert {
+	u.SetExcluded(invitecommission.FieldInviteeID)
+	return u
+}
+
+// AddInviteeID adds v to the "invitee_id" field.
+func (u *InviteCommissionUpsert) AddInviteeID(v int64) *InviteCommissionUpsert {
+	u.Add(invitecommission.FieldInviteeID, v)
+	return u
+}
+
+// SetBaseAmount sets the "base_amount" field.
+func (u *InviteCommissionUpsert) SetBaseAmount(v float64) *InviteCommissionUpsert {
+	u.Set(invitecommission.FieldBaseAmount, v)
+	return u
+}
+
+// UpdateBaseAmount sets the "base_amount" field to the value that was provided on create.
+func (u *InviteCommissionUpsert) UpdateBaseAmount() *InviteCommissionUpsert {
+	u.SetExcluded(invitecommission.FieldBaseAmount)
+	return u
+}
+
+// AddBaseAmount adds v to the "base_amount" field.
+func (u *InviteCommissionUpsert) AddBaseAmount(v float64) *InviteCommissionUpsert {
+	u.Add(invitecommission.FieldBaseAmount, v)
+	return u
+}
+
+// SetRate sets the "rate" field.
+func (u *InviteCommissionUpsert) SetRate(v float64) *InviteCommissionUpsert {
+	u.Set(invitecommission.FieldRate, v)
+	return u
+}
+
+// UpdateRate sets the "rate" field to the value that was provided on create.
+func (u *InviteCommissionUpsert) UpdateRate() *InviteCommissionUpsert {
+	u.SetExcluded(invitecommission.FieldRate)
+	return u
+}
+
+// AddRate adds v to the "rate" field.
+func (u *InviteCommissionUpsert) AddRate(v float64) *InviteCommissionUpsert {
+	u.Add(invitecommission.FieldRate, v)
+	return u
+}
+
+// SetAmount sets the "amount" field.
+func (u *InviteCommissionUpsert) SetAmount(v float64) *InviteCommissionUpsert {
+	u.Set(invitecommission.FieldAmount, v)
+	return u
+}
+
+// UpdateAmount sets the "amount" field to the value that was provided on create.
+func (u *InviteCommissionUpsert) UpdateAmount() *InviteCommissionUpsert {
+	u.SetExcluded(invitecommission.FieldAmount)
+	return u
+}
+
+// AddAmount adds v to the "amount" field.
+func (u *InviteCommissionUpsert) AddAmount(v float64) *InviteCommissionUpsert {
+	u.Add(invitecommission.FieldAmount, v)
+	return u
+}
+
+// SetFromLogID sets the "from_log_id" field.
+func (u *InviteCommissionUpsert) SetFromLogID(v int64) *InviteCommissionUpsert {
+	u.Set(invitecommission.FieldFromLogID, v)
+	return u
+}
+
+// UpdateFromLogID sets the "from_log_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsert) UpdateFromLogID() *InviteCommissionUpsert {
+	u.SetExcluded(invitecommission.FieldFromLogID)
+	return u
+}
+
+// AddFromLogID adds v to the "from_log_id" field.
+func (u *InviteCommissionUpsert) AddFromLogID(v int64) *InviteCommissionUpsert {
+	u.Add(invitecommission.FieldFromLogID, v)
+	return u
+}
+
+// SetToLogID sets the "to_log_id" field.
+func (u *InviteCommissionUpsert) SetToLogID(v int64) *InviteCommissionUpsert {
+	u.Set(invitecommission.FieldToLogID, v)
+	return u
+}
+
+// UpdateToLogID sets the "to_log_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsert) UpdateToLogID() *InviteCommissionUpsert {
+	u.SetExcluded(invitecommission.FieldToLogID)
+	return u
+}
+
+// AddToLogID adds v to the "to_log_id" field.
+func (u *InviteCommissionUpsert) AddToLogID(v int64) *InviteCommissionUpsert {
+	u.Add(invitecommission.FieldToLogID, v)
+	return u
+}
+
+// SetLogCount sets the "log_count" field.
+func (u *InviteCommissionUpsert) SetLogCount(v int) *InviteCommissionUpsert {
+	u.Set(invitecommission.FieldLogCount, v)
+	return u
+}
+
+// UpdateLogCount sets the "log_count" field to the value that was provided on create.
+func (u *InviteCommissionUpsert) UpdateLogCount() *InviteCommissionUpsert {
+	u.SetExcluded(invitecommission.FieldLogCount)
+	return u
+}
+
+// AddLogCount adds v to the "log_count" field.
+func (u *InviteCommissionUpsert) AddLogCount(v int) *InviteCommissionUpsert {
+	u.Add(invitecommission.FieldLogCount, v)
+	return u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *InviteCommissionUpsert) SetCreatedAt(v time.Time) *InviteCommissionUpsert {
+	u.Set(invitecommission.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *InviteCommissionUpsert) UpdateCreatedAt() *InviteCommissionUpsert {
+	u.SetExcluded(invitecommission.FieldCreatedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.InviteCommission.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *InviteCommissionUpsertOne) UpdateNewValues() *InviteCommissionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.InviteCommission.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *InviteCommissionUpsertOne) Ignore() *InviteCommissionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *InviteCommissionUpsertOne) DoNothing() *InviteCommissionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the InviteCommissionCreate.OnConflict
+// documentation for more info.
+func (u *InviteCommissionUpsertOne) Update(set func(*InviteCommissionUpsert)) *InviteCommissionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&InviteCommissionUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetInviteID sets the "invite_id" field.
+func (u *InviteCommissionUpsertOne) SetInviteID(v int64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetInviteID(v)
+	})
+}
+
+// AddInviteID adds v to the "invite_id" field.
+func (u *InviteCommissionUpsertOne) AddInviteID(v int64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddInviteID(v)
+	})
+}
+
+// UpdateInviteID sets the "invite_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsertOne) UpdateInviteID() *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateInviteID()
+	})
+}
+
+// SetInviterID sets the "inviter_id" field.
+func (u *InviteCommissionUpsertOne) SetInviterID(v int64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetInviterID(v)
+	})
+}
+
+// AddInviterID adds v to the "inviter_id" field.
+func (u *InviteCommissionUpsertOne) AddInviterID(v int64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddInviterID(v)
+	})
+}
+
+// UpdateInviterID sets the "inviter_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsertOne) UpdateInviterID() *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateInviterID()
+	})
+}
+
+// SetInviteeID sets the "invitee_id" field.
+func (u *InviteCommissionUpsertOne) SetInviteeID(v int64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetInviteeID(v)
+	})
+}
+
+// AddInviteeID adds v to the "invitee_id" field.
+func (u *InviteCommissionUpsertOne) AddInviteeID(v int64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddInviteeID(v)
+	})
+}
+
+// UpdateInviteeID sets the "invitee_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsertOne) UpdateInviteeID() *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateInviteeID()
+	})
+}
+
+// SetBaseAmount sets the "base_amount" field.
+func (u *InviteCommissionUpsertOne) SetBaseAmount(v float64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetBaseAmount(v)
+	})
+}
+
+// AddBaseAmount adds v to the "base_amount" field.
+func (u *InviteCommissionUpsertOne) AddBaseAmount(v float64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddBaseAmount(v)
+	})
+}
+
+// UpdateBaseAmount sets the "base_amount" field to the value that was provided on create.
+func (u *InviteCommissionUpsertOne) UpdateBaseAmount() *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateBaseAmount()
+	})
+}
+
+// SetRate sets the "rate" field.
+func (u *InviteCommissionUpsertOne) SetRate(v float64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetRate(v)
+	})
+}
+
+// AddRate adds v to the "rate" field.
+func (u *InviteCommissionUpsertOne) AddRate(v float64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddRate(v)
+	})
+}
+
+// UpdateRate sets the "rate" field to the value that was provided on create.
+func (u *InviteCommissionUpsertOne) UpdateRate() *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateRate()
+	})
+}
+
+// SetAmount sets the "amount" field.
+func (u *InviteCommissionUpsertOne) SetAmount(v float64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetAmount(v)
+	})
+}
+
+// AddAmount adds v to the "amount" field.
+func (u *InviteCommissionUpsertOne) AddAmount(v float64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddAmount(v)
+	})
+}
+
+// UpdateAmount sets the "amount" field to the value that was provided on create.
+func (u *InviteCommissionUpsertOne) UpdateAmount() *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateAmount()
+	})
+}
+
+// SetFromLogID sets the "from_log_id" field.
+func (u *InviteCommissionUpsertOne) SetFromLogID(v int64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetFromLogID(v)
+	})
+}
+
+// AddFromLogID adds v to the "from_log_id" field.
+func (u *InviteCommissionUpsertOne) AddFromLogID(v int64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddFromLogID(v)
+	})
+}
+
+// UpdateFromLogID sets the "from_log_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsertOne) UpdateFromLogID() *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateFromLogID()
+	})
+}
+
+// SetToLogID sets the "to_log_id" field.
+func (u *InviteCommissionUpsertOne) SetToLogID(v int64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetToLogID(v)
+	})
+}
+
+// AddToLogID adds v to the "to_log_id" field.
+func (u *InviteCommissionUpsertOne) AddToLogID(v int64) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddToLogID(v)
+	})
+}
+
+// UpdateToLogID sets the "to_log_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsertOne) UpdateToLogID() *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateToLogID()
+	})
+}
+
+// SetLogCount sets the "log_count" field.
+func (u *InviteCommissionUpsertOne) SetLogCount(v int) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetLogCount(v)
+	})
+}
+
+// AddLogCount adds v to the "log_count" field.
+func (u *InviteCommissionUpsertOne) AddLogCount(v int) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddLogCount(v)
+	})
+}
+
+// UpdateLogCount sets the "log_count" field to the value that was provided on create.
+func (u *InviteCommissionUpsertOne) UpdateLogCount() *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateLogCount()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *InviteCommissionUpsertOne) SetCreatedAt(v time.Time) *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *InviteCommissionUpsertOne) UpdateCreatedAt() *InviteCommissionUpsertOne {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *InviteCommissionUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for InviteCommissionCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *InviteCommissionUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *InviteCommissionUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *InviteCommissionUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// InviteCommissionCreateBulk is the builder for creating many InviteCommission entities in bulk.
+type InviteCommissionCreateBulk struct {
+	config
+	err      error
+	builders []*InviteCommissionCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the InviteCommission entities in the database.
+func (_c *InviteCommissionCreateBulk) Save(ctx context.Context) ([]*InviteCommission, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*InviteCommission, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*InviteCommissionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *InviteCommissionCreateBulk) SaveX(ctx context.Context) []*InviteCommission {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *InviteCommissionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *InviteCommissionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.InviteCommission.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.InviteCommissionUpsert) {
+//			SetInviteID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *InviteCommissionCreateBulk) OnConflict(opts ...sql.ConflictOption) *InviteCommissionUpsertBulk {
+	_c.conflict = opts
+	return &InviteCommissionUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.InviteCommission.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *InviteCommissionCreateBulk) OnConflictColumns(columns ...string) *InviteCommissionUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &InviteCommissionUpsertBulk{
+		create: _c,
+	}
+}
+
+// InviteCommissionUpsertBulk is the builder for "upsert"-ing
+// a bulk of InviteCommission nodes.
+type InviteCommissionUpsertBulk struct {
+	create *InviteCommissionCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.InviteCommission.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *InviteCommissionUpsertBulk) UpdateNewValues() *InviteCommissionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.InviteCommission.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *InviteCommissionUpsertBulk) Ignore() *InviteCommissionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *InviteCommissionUpsertBulk) DoNothing() *InviteCommissionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the InviteCommissionCreateBulk.OnConflict
+// documentation for more info.
+func (u *InviteCommissionUpsertBulk) Update(set func(*InviteCommissionUpsert)) *InviteCommissionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&InviteCommissionUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetInviteID sets the "invite_id" field.
+func (u *InviteCommissionUpsertBulk) SetInviteID(v int64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetInviteID(v)
+	})
+}
+
+// AddInviteID adds v to the "invite_id" field.
+func (u *InviteCommissionUpsertBulk) AddInviteID(v int64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddInviteID(v)
+	})
+}
+
+// UpdateInviteID sets the "invite_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsertBulk) UpdateInviteID() *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateInviteID()
+	})
+}
+
+// SetInviterID sets the "inviter_id" field.
+func (u *InviteCommissionUpsertBulk) SetInviterID(v int64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetInviterID(v)
+	})
+}
+
+// AddInviterID adds v to the "inviter_id" field.
+func (u *InviteCommissionUpsertBulk) AddInviterID(v int64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddInviterID(v)
+	})
+}
+
+// UpdateInviterID sets the "inviter_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsertBulk) UpdateInviterID() *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateInviterID()
+	})
+}
+
+// SetInviteeID sets the "invitee_id" field.
+func (u *InviteCommissionUpsertBulk) SetInviteeID(v int64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetInviteeID(v)
+	})
+}
+
+// AddInviteeID adds v to the "invitee_id" field.
+func (u *InviteCommissionUpsertBulk) AddInviteeID(v int64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddInviteeID(v)
+	})
+}
+
+// UpdateInviteeID sets the "invitee_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsertBulk) UpdateInviteeID() *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateInviteeID()
+	})
+}
+
+// SetBaseAmount sets the "base_amount" field.
+func (u *InviteCommissionUpsertBulk) SetBaseAmount(v float64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetBaseAmount(v)
+	})
+}
+
+// AddBaseAmount adds v to the "base_amount" field.
+func (u *InviteCommissionUpsertBulk) AddBaseAmount(v float64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddBaseAmount(v)
+	})
+}
+
+// UpdateBaseAmount sets the "base_amount" field to the value that was provided on create.
+func (u *InviteCommissionUpsertBulk) UpdateBaseAmount() *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateBaseAmount()
+	})
+}
+
+// SetRate sets the "rate" field.
+func (u *InviteCommissionUpsertBulk) SetRate(v float64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetRate(v)
+	})
+}
+
+// AddRate adds v to the "rate" field.
+func (u *InviteCommissionUpsertBulk) AddRate(v float64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddRate(v)
+	})
+}
+
+// UpdateRate sets the "rate" field to the value that was provided on create.
+func (u *InviteCommissionUpsertBulk) UpdateRate() *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateRate()
+	})
+}
+
+// SetAmount sets the "amount" field.
+func (u *InviteCommissionUpsertBulk) SetAmount(v float64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetAmount(v)
+	})
+}
+
+// AddAmount adds v to the "amount" field.
+func (u *InviteCommissionUpsertBulk) AddAmount(v float64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddAmount(v)
+	})
+}
+
+// UpdateAmount sets the "amount" field to the value that was provided on create.
+func (u *InviteCommissionUpsertBulk) UpdateAmount() *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateAmount()
+	})
+}
+
+// SetFromLogID sets the "from_log_id" field.
+func (u *InviteCommissionUpsertBulk) SetFromLogID(v int64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetFromLogID(v)
+	})
+}
+
+// AddFromLogID adds v to the "from_log_id" field.
+func (u *InviteCommissionUpsertBulk) AddFromLogID(v int64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddFromLogID(v)
+	})
+}
+
+// UpdateFromLogID sets the "from_log_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsertBulk) UpdateFromLogID() *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateFromLogID()
+	})
+}
+
+// SetToLogID sets the "to_log_id" field.
+func (u *InviteCommissionUpsertBulk) SetToLogID(v int64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetToLogID(v)
+	})
+}
+
+// AddToLogID adds v to the "to_log_id" field.
+func (u *InviteCommissionUpsertBulk) AddToLogID(v int64) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddToLogID(v)
+	})
+}
+
+// UpdateToLogID sets the "to_log_id" field to the value that was provided on create.
+func (u *InviteCommissionUpsertBulk) UpdateToLogID() *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateToLogID()
+	})
+}
+
+// SetLogCount sets the "log_count" field.
+func (u *InviteCommissionUpsertBulk) SetLogCount(v int) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetLogCount(v)
+	})
+}
+
+// AddLogCount adds v to the "log_count" field.
+func (u *InviteCommissionUpsertBulk) AddLogCount(v int) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.AddLogCount(v)
+	})
+}
+
+// UpdateLogCount sets the "log_count" field to the value that was provided on create.
+func (u *InviteCommissionUpsertBulk) UpdateLogCount() *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateLogCount()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *InviteCommissionUpsertBulk) SetCreatedAt(v time.Time) *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *InviteCommissionUpsertBulk) UpdateCreatedAt() *InviteCommissionUpsertBulk {
+	return u.Update(func(s *InviteCommissionUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *InviteCommissionUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the InviteCommissionCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for InviteCommissionCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *InviteCommissionUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,696 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/invitecommission"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// InviteCommissionUpdate is the builder for updating InviteCommission entities.
+type InviteCommissionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *InviteCommissionMutation
+}
+
+// Where appends a list predicates to the InviteCommissionUpdate builder.
+func (_u *InviteCommissionUpdate) Where(ps ...predicate.InviteCommission) *InviteCommissionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetInviteID sets the "invite_id" field.
+func (_u *InviteCommissionUpdate) SetInviteID(v int64) *InviteCommissionUpdate {
+	_u.mutation.ResetInviteID()
+	_u.mutation.SetInviteID(v)
+	return _u
+}
+
+// SetNillableInviteID sets the "invite_id" field if the given value is not nil.
+func (_u *InviteCommissionUpdate) SetNillableInviteID(v *int64) *InviteCommissionUpdate {
+	if v != nil {
+		_u.SetInviteID(*v)
+	}
+	return _u
+}
+
+// AddInviteID adds value to the "invite_id" field.
+func (_u *InviteCommissionUpdate) AddInviteID(v int64) *InviteCommissionUpdate {
+	_u.mutation.AddInviteID(v)
+	return _u
+}
+
+// SetInviterID sets the "inviter_id" field.
+func (_u *InviteCommissionUpdate) SetInviterID(v int64) *InviteCommissionUpdate {
+	_u.mutation.ResetInviterID()
+	_u.mutation.SetInviterID(v)
+	return _u
+}
+
+// SetNillableInviterID sets the "inviter_id" field if the given value is not nil.
+func (_u *InviteCommissionUpdate) SetNillableInviterID(v *int64) *InviteCommissionUpdate {
+	if v != nil {
+		_u.SetInviterID(*v)
+	}
+	return _u
+}
+
+// AddInviterID adds value to the "inviter_id" field.
+func (_u *InviteCommissionUpdate) AddInviterID(v int64) *InviteCommissionUpdate {
+	_u.mutation.AddInviterID(v)
+	return _u
+}
+
+// SetInviteeID sets the "invitee_id" field.
+func (_u *InviteCommissionUpdate) SetInviteeID(v int64) *InviteCommissionUpdate {
+	_u.mutation.ResetInviteeID()
+	_u.mutation.SetInviteeID(v)
+	return _u
+}
+
+// SetNillableInviteeID sets the "invitee_id" field if the given value is not nil.
+func (_u *InviteCommissionUpdate) SetNillableInviteeID(v *int64) *InviteCommissionUpdate {
+	if v != nil {
+		_u.SetInviteeID(*v)
+	}
+	return _u
+}
+
+// AddInviteeID adds value to the "invitee_id" field.
+func (_u *InviteCommissionUpdate) AddInviteeID(v int64) *InviteCommissionUpdate {
+	_u.mutation.AddInviteeID(v)
+	return _u
+}
+
+// SetBaseAmount sets the "base_amount" field.
+func (_u *InviteCommissionUpdate) SetBaseAmount(v float64) *InviteCommissionUpdate {
+	_u.mutation.ResetBaseAmount()
+	_u.mutation.SetBaseAmount(v)
+	return _u
+}
+
+// SetNillableBaseAmount sets the "base_amount" field if the given value is not nil.
+func (_u *InviteCommissionUpdate) SetNillableBaseAmount(v *float64) *InviteCommissionUpdate {
+	if v != nil {
+		_u.SetBaseAmount(*v)
+	}
+	return _u
+}
+
+// AddBaseAmount adds value to the "base_amount" field.
+func (_u *InviteCommissionUpdate) AddBaseAmount(v float64) *InviteCommissionUpdate {
+	_u.mutation.AddBaseAmount(v)
+	return _u
+}
+
+// SetRate sets the "rate" field.
+func (_u *InviteCommissionUpdate) SetRate(v float64) *InviteCommissionUpdate {
+	_u.mutation.ResetRate()
+	_u.mutation.SetRate(v)
+	return _u
+}
+
+// SetNillableRate sets the "rate" field if the given value is not nil.
+func (_u *InviteCommissionUpdate) SetNillableRate(v *float64) *InviteCommissionUpdate {
+	if v != nil {
+		_u.SetRate(*v)
+	}
+	return _u
+}
+
+// AddRate adds value to the "rate" field.
+func (_u *InviteCommissionUpdate) AddRate(v float64) *InviteCommissionUpdate {
+	_u.mutation.AddRate(v)
+	return _u
+}
+
+// SetAmount sets the "amount" field.
+func (_u *InviteCommissionUpdate) SetAmount(v float64) *InviteCommissionUpdate {
+	_u.mutation.ResetAmount()
+	_u.mutation.SetAmount(v)
+	return _u
+}
+
+// SetNillableAmount sets the "amount" field if the given value is not nil.
+func (_u *InviteCommissionUpdate) SetNillableAmount(v *float64) *InviteCommissionUpdate {
+	if v != nil {
+		_u.SetAmount(*v)
+	}
+	return _u
+}
+
+// AddAmount adds value to the "amount" field.
+func (_u *InviteCommissionUpdate) AddAmount(v float64) *InviteCommissionUpdate {
+	_u.mutation.AddAmount(v)
+	return _u
+}
+
+// SetFromLogID sets the "from_log_id" field.
+func (_u *InviteCommissionUpdate) SetFromLogID(v int64) *InviteCommissionUpdate {
+	_u.mutation.ResetFromLogID()
+	_u.mutation.SetFromLogID(v)
+	return _u
+}
+
+// SetNillableFromLogID sets the "from_log_id" field if the given value is not nil.
+func (_u *InviteCommissionUpdate) SetNillableFromLogID(v *int64) *InviteCommissionUpdate {
+	if v != nil {
+		_u.SetFromLogID(*v)
+	}
+	return _u
+}
+
+// AddFromLogID adds value to the "from_log_id" field.
+func (_u *InviteCommissionUpdate) AddFromLogID(v int64) *InviteCommissionUpdate {
+	_u.mutation.AddFromLogID(v)
+	return _u
+}
+
+// SetToLogID sets the "to_log_id" field.
+func (_u *InviteCommissionUpdate) SetToLogID(v int64) *InviteCommissionUpdate {
+	_u.mutation.ResetToLogID()
+	_u.mutation.SetToLogID(v)
+	return _u
+}
+
+// SetNillableToLogID sets the "to_log_id" field if the given value is not nil.
+func (_u *InviteCommissionUpdate) SetNillableToLogID(v *int64) *InviteCommissionUpdate {
+	if v != nil {
+		_u.SetToLogID(*v)
+	}
+	return _u
+}
+
+// AddToLogID adds value to the "to_log_id" field.
+func (_u *InviteCommissionUpdate) AddToLogID(v int64) *InviteCommissionUpdate {
+	_u.mutation.AddToLogID(v)
+	return _u
+}
+
+// SetLogCount sets the "log_count" field.
+func (_u *InviteCommissionUpdate) SetLogCount(v int) *InviteCommissionUpdate {
+	_u.mutation.ResetLogCount()
+	_u.mutation.SetLogCount(v)
+	return _u
+}
+
+// SetNillableLogCount sets the "log_count" field if the given value is not nil.
+func (_u *InviteCommissionUpdate) SetNillableLogCount(v *int) *InviteCommissionUpdate {
+	if v != nil {
+		_u.SetLogCount(*v)
+	}
+	return _u
+}
+
+// AddLogCount adds value to the "log_count" field.
+func (_u *InviteCommissionUpdate) AddLogCount(v int) *InviteCommissionUpdate {
+	_u.mutation.AddLogCount(v)
+	return _u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_u *InviteCommissionUpdate) SetCreatedAt(v time.Time) *InviteCommissionUpdate {
+	_u.mutation.SetCreatedAt(v)
+	return _u
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_u *InviteCommissionUpdate) SetNillableCreatedAt(v *time.Time) *InviteCommissionUpdate {
+	if v != nil {
+		_u.SetCreatedAt(*v)
+	}
+	return _u
+}
+
+// Mutation returns the InviteCommissionMutation object of the builder.
+func (_u *InviteCommissionUpdate) Mutation() *InviteCommissionMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *InviteCommissionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *InviteCommissionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *InviteCommissionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *InviteCommissionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *InviteCommissionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(invitecommission.Table, invitecommission.Columns, sqlgraph.NewFieldSpec(invitecommission.FieldID, field.TypeInt64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.InviteID(); ok {
+		_spec.SetField(invitecommission.FieldInviteID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedInviteID(); ok {
+		_spec.AddField(invitecommission.FieldInviteID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.InviterID(); ok {
+		_spec.SetField(invitecommission.FieldInviterID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedInviterID(); ok {
+		_spec.AddField(invitecommission.FieldInviterID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.InviteeID(); ok {
+		_spec.SetField(invitecommission.FieldInviteeID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedInviteeID(); ok {
+		_spec.AddField(invitecommission.FieldInviteeID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.BaseAmount(); ok {
+		_spec.SetField(invitecommission.FieldBaseAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedBaseAmount(); ok {
+		_spec.AddField(invitecommission.FieldBaseAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Rate(); ok {
+		_spec.SetField(invitecommission.FieldRate, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedRate(); ok {
+		_spec.AddField(invitecommission.FieldRate, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Amount(); ok {
+		_spec.SetField(invitecommission.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedAmount(); ok {
+		_spec.AddField(invitecommission.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.FromLogID(); ok {
+		_spec.SetField(invitecommission.FieldFromLogID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedFromLogID(); ok {
+		_spec.AddField(invitecommission.FieldFromLogID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.ToLogID(); ok {
+		_spec.SetField(invitecommission.FieldToLogID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedToLogID(); ok {
+		_spec.AddField(invitecommission.FieldToLogID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.LogCount(); ok {
+		_spec.SetField(invitecommission.FieldLogCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedLogCount(); ok {
+		_spec.AddField(invitecommission.FieldLogCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.CreatedAt(); ok {
+		_spec.SetField(invitecommission.FieldCreatedAt, field.TypeTime, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{invitecommission.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// InviteCommissionUpdateOne is the builder for updating a single InviteCommission entity.
+type InviteCommissionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *InviteCommissionMutation
+}
+
+// SetInviteID sets the "invite_id" field.
+func (_u *InviteCommissionUpdateOne) SetInviteID(v int64) *InviteCommissionUpdateOne {
+	_u.mutation.ResetInviteID()
+	_u.mutation.SetInviteID(v)
+	return _u
+}
+
+// SetNillableInviteID sets the "invite_id" field if the given value is not nil.
+func (_u *InviteCommissionUpdateOne) SetNillableInviteID(v *int64) *InviteCommissionUpdateOne {
+	if v != nil {
+		_u.SetInviteID(*v)
+	}
+	return _u
+}
+
+// AddInviteID adds value to the "invite_id" field.
+func (_u *InviteCommissionUpdateOne) AddInviteID(v int64) *InviteCommissionUpdateOne {
+	_u.mutation.AddInviteID(v)
+	return _u
+}
+
+// SetInviterID sets the "inviter_id" field.
+func (_u *InviteCommissionUpdateOne) SetInviterID(v int64) *InviteCommissionUpdateOne {
+	_u.mutation.ResetInviterID()
+	_u.mutation.SetInviterID(v)
+	return _u
+}
+
+// SetNillableInviterID sets the "inviter_id" field if the given value is not nil.
+func (_u *InviteCommissionUpdateOne) SetNillableInviterID(v *int64) *InviteCommissionUpdateOne {
+	if v != nil {
+		_u.SetInviterID(*v)
+	}
+	return _u
+}
+
+// AddInviterID adds value to the "inviter_id" field.
+func (_u *InviteCommissionUpdateOne) AddInviterID(v int64) *InviteCommissionUpdateOne {
+	_u.mutation.AddInviterID(v)
+	return _u
+}
+
+// SetInviteeID sets the "invitee_id" field.
+func (_u *InviteCommissionUpdateOne) SetInviteeID(v int64) *InviteCommissionUpdateOne {
+	_u.mutation.ResetInviteeID()
+	_u.mutation.SetInviteeID(v)
+	return _u
+}
+
+// SetNillableInviteeID sets the "invitee_id" field if the given value is not nil.
+func (_u *InviteCommissionUpdateOne) SetNillableInviteeID(v *int64) *InviteCommissionUpdateOne {
+	if v != nil {
+		_u.SetInviteeID(*v)
+	}
+	return _u
+}
+
+// AddInviteeID adds value to the "invitee_id" field.
+func (_u *InviteCommissionUpdateOne) AddInviteeID(v int64) *InviteCommissionUpdateOne {
+	_u.mutation.AddInviteeID(v)
+	return _u
+}
+
+// SetBaseAmount sets the "base_amount" field.
+func (_u *InviteCommissionUpdateOne) SetBaseAmount(v float64) *InviteCommissionUpdateOne {
+	_u.mutation.ResetBaseAmount()
+	_u.mutation.SetBaseAmount(v)
+	return _u
+}
+
+// SetNillableBaseAmount sets the "base_amount" field if the given value is not nil.
+func (_u *InviteCommissionUpdateOne) SetNillableBaseAmount(v *float64) *InviteCommissionUpdateOne {
+	if v != nil {
+		_u.SetBaseAmount(*v)
+	}
+	return _u
+}
+
+// AddBaseAmount adds value to the "base_amount" field.
+func (_u *InviteCommissionUpdateOne) AddBaseAmount(v float64) *InviteCommissionUpdateOne {
+	_u.mutation.AddBaseAmount(v)
+	return _u
+}
+
+// SetRate sets the "rate" field.
+func (_u *InviteCommissionUpdateOne) SetRate(v float64) *InviteCommissionUpdateOne {
+	_u.mutation.ResetRate()
+	_u.mutation.SetRate(v)
+	return _u
+}
+
+// SetNillableRate sets the "rate" field if the given value is not nil.
+func (_u *InviteCommissionUpdateOne) SetNillableRate(v *float64) *InviteCommissionUpdateOne {
+	if v != nil {
+		_u.SetRate(*v)
+	}
+	return _u
+}
+
+// AddRate adds value to the "rate" field.
+func (_u *InviteCommissionUpdateOne) AddRate(v float64) *InviteCommissionUpdateOne {
+	_u.mutation.AddRate(v)
+	return _u
+}
+
+// SetAmount sets the "amount" field.
+func (_u *InviteCommissionUpdateOne) SetAmount(v float64) *InviteCommissionUpdateOne {
+	_u.mutation.ResetAmount()
+	_u.mutation.SetAmount(v)
+	return _u
+}
+
+// SetNillableAmount sets the "amount" field if the given value is not nil.
+func (_u *InviteCommissionUpdateOne) SetNillableAmount(v *float64) *InviteCommissionUpdateOne {
+	if v != nil {
+		_u.SetAmount(*v)
+	}
+	return _u
+}
+
+// AddAmount adds value to the "amount" field.
+func (_u *InviteCommissionUpdateOne) AddAmount(v float64) *InviteCommissionUpdateOne {
+	_u.mutation.AddAmount(v)
+	return _u
+}
+
+// SetFromLogID sets the "from_log_id" field.
+func (_u *InviteCommissionUpdateOne) SetFromLogID(v int64) *InviteCommissionUpdateOne {
+	_u.mutation.ResetFromLogID()
+	_u.mutation.SetFromLogID(v)
+	return _u
+}
+
+// SetNillableFromLogID sets the "from_log_id" field if the given value is not nil.
+func (_u *InviteCommissionUpdateOne) SetNillableFromLogID(v *int64) *InviteCommissionUpdateOne {
+	if v != nil {
+		_u.SetFromLogID(*v)
+	}
+	return _u
+}
+
+// AddFromLogID adds value to the "from_log_id" field.
+func (_u *InviteCommissionUpdateOne) AddFromLogID(v int64) *InviteCommissionUpdateOne {
+	_u.mutation.AddFromLogID(v)
+	return _u
+}
+
+// SetToLogID sets the "to_log_id" field.
+func (_u *InviteCommissionUpdateOne) SetToLogID(v int64) *InviteCommissionUpdateOne {
+	_u.mutation.ResetToLogID()
+	_u.mutation.SetToLogID(v)
+	return _u
+}
+
+// SetNillableToLogID sets the "to_log_id" field if the given value is not nil.
+func (_u *InviteCommissionUpdateOne) SetNillableToLogID(v *int64) *InviteCommissionUpdateOne {
+	if v != nil {
+		_u.SetToLogID(*v)
+	}
+	return _u
+}
+
+// AddToLogID adds value to the "to_log_id" field.
+func (_u *InviteCommissionUpdateOne) AddToLogID(v int64) *InviteCommissionUpdateOne {
+	_u.mutation.AddToLogID(v)
+	return _u
+}
+
+// SetLogCount sets the "log_count" field.
+func (_u *InviteCommissionUpdateOne) SetLogCount(v int) *InviteCommissionUpdateOne {
+	_u.mutation.ResetLogCount()
+	_u.mutation.SetLogCount(v)
+	return _u
+}
+
+// SetNillableLogCount sets the "log_count" field if the given value is not nil.
+func (_u *InviteCommissionUpdateOne) SetNillableLogCount(v *int) *InviteCommissionUpdateOne {
+	if v != nil {
+		_u.SetLogCount(*v)
+	}
+	return _u
+}
+
+// AddLogCount adds value to the "log_count" field.
+func (_u *InviteCommissionUpdateOne) AddLogCount(v int) *InviteCommissionUpdateOne {
+	_u.mutation.AddLogCount(v)
+	return _u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_u *InviteCommissionUpdateOne) SetCreatedAt(v time.Time) *InviteCommissionUpdateOne {
+	_u.mutation.SetCreatedAt(v)
+	return _u
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_u *InviteCommissionUpdateOne) SetNillableCreatedAt(v *time.Time) *InviteCommissionUpdateOne {
+	if v != nil {
+		_u.SetCreatedAt(*v)
+	}
+	return _u
+}
+
+// Mutation returns the InviteCommissionMutation object of the builder.
+func (_u *InviteCommissionUpdateOne) Mutation() *InviteCommissionMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the InviteCommissionUpdate builder.
+func (_u *InviteCommissionUpdateOne) Where(ps ...predicate.InviteCommission) *InviteCommissionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *InviteCommissionUpdateOne) Select(field string, fields ...string) *InviteCommissionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated InviteCommission entity.
+func (_u *InviteCommissionUpdateOne) Save(ctx context.Context) (*InviteCommission, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *InviteCommissionUpdateOne) SaveX(ctx context.Context) *InviteCommission {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *InviteCommissionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *InviteCommissionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *InviteCommissionUpdateOne) sqlSave(ctx context.Context) (_node *InviteCommission, err error) {
+	_spec := sqlgraph.NewUpdateSpec(invitecommission.Table, invitecommission.Columns, sqlgraph.NewFieldSpec(invitecommission.FieldID, field.TypeInt64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "InviteCommission.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, invitecommission.FieldID)
+		for _, f := range fields {
+			if !invitecommission.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != invitecommission.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.InviteID(); ok {
+		_spec.SetField(invitecommission.FieldInviteID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedInviteID(); ok {
+		_spec.AddField(invitecommission.FieldInviteID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.InviterID(); ok {
+		_spec.SetField(invitecommission.FieldInviterID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedInviterID(); ok {
+		_spec.AddField(invitecommission.FieldInviterID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.InviteeID(); ok {
+		_spec.SetField(invitecommission.FieldInviteeID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedInviteeID(); ok {
+		_spec.AddField(invitecommission.FieldInviteeID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.BaseAmount(); ok {
+		_spec.SetField(invitecommission.FieldBaseAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedBaseAmount(); ok {
+		_spec.AddField(invitecommission.FieldBaseAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Rate(); ok {
+		_spec.SetField(invitecommission.FieldRate, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedRate(); ok {
+		_spec.AddField(invitecommission.FieldRate, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Amount(); ok {
+		_spec.SetField(invitecommission.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedAmount(); ok {
+		_spec.AddField(invitecommission.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.FromLogID(); ok {
+		_spec.SetField(invitecommission.FieldFromLogID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedFromLogID(); ok {
+		_spec.AddField(invitecommission.FieldFromLogID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.ToLogID(); ok {
+		_spec.SetField(invitecommission.FieldToLogID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedToLogID(); ok {
+		_spec.AddField(invitecommission.FieldToLogID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.LogCount(); ok {
+		_spec.SetField(invitecommission.FieldLogCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedLogCount(); ok {
+		_spec.AddField(invitecommission.FieldLogCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.CreatedAt(); ok {
+		_spec.SetField(invitecommission.FieldCreatedAt, field.TypeTime, value)
+	}
+	_node = &InviteCommission{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{invitecommission.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -15,12 +15,17 @@ var (
 		{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
 		{Name: "updated_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
 		{Name: "deleted_at", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
-		{Name: "key", Type: field.TypeString, Unique: true, Size: 128},
+		{Name: "key", Type: field.TypeString, Unique: true, Nullable: true, Size: 128},
+		{Name: "key_hash", Type: field.TypeString, Unique: true, Nullable: true, Size: 64},
+		{Name: "key_prefix", Type: field.TypeString, Size: 16, Default: ""},
 		{Name: "name", Type: field.TypeString, Size: 100},
 		{Name: "status", Type: field.TypeString, Size: 20, Default: "active"},
 		{Name: "ip_whitelist", Type: field.TypeJSON, Nullable: true},
 		{Name: "ip_blacklist", Type: field.TypeJSON, Nullable: true},
+		{Name: "dedicated_account_ids", Type: field.TypeJSON, Nullable: true},
+		{Name: "dedicated_accounts_only", Type: field.TypeBool, Default: false},
 		{Name: "group_id", Type: field.TypeInt64, Nullable: true},
+		{Name: "organization_id", Type: field.TypeInt64, Nullable: true},
 		{Name: "user_id", Type: field.TypeInt64},
 	}
 	// APIKeysTable holds the schema information for the "api_keys" table.
@@ -31,13 +36,19 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "api_keys_groups_api_keys",
-				Columns:    []*schema.Column{APIKeysColumns[9]},
+				Columns:    []*schema.Column{APIKeysColumns[13]},
 				RefColumns: []*schema.Column{GroupsColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
+			{
+				Symbol:     "api_keys_organizations_api_keys",
+				Columns:    []*schema.Column{APIKeysColumns[14]},
+				RefColumns: []*schema.Column{OrganizationsColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
 			{
 				Symbol:     "api_keys_users_api_keys",
-				Columns:    []*schema.Column{APIKeysColumns[10]},
+				Columns:    []*schema.Column{APIKeysColumns[15]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
@@ -46,17 +57,22 @@ var (
 			{
 				Name:    "apikey_user_id",
 				Unique:  false,
-				Columns: []*schema.Column{APIKeysColumns[10]},
+				Columns: []*schema.Column{APIKeysColumns[15]},
 			},
 			{
 				Name:    "apikey_group_id",
 				Unique:  false,
-				Columns: []*schema.Column{APIKeysColumns[9]},
+				Columns: []*schema.Column{APIKeysColumns[13]},
+			},
+			{
+				Name:    "apikey_organization_id",
+				Unique:  false,
+				Columns: []*schema.Column{APIKeysColumns[14]},
 			},
 			{
 				Name:    "apikey_status",
 				Unique:  false,
-				Columns: []*schema.Column{APIKeysColumns[6]},
+				Columns: []*schema.Column{APIKeysColumns[8]},
 			},
 			{
 				Name:    "apikey_deleted_at",
@@ -247,6 +263,42 @@ var (
 			},
 		},
 	}
+	// CommissionWithdrawalsColumns holds the columns for the "commission_withdrawals" table.
+	CommissionWithdrawalsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "user_id", Type: field.TypeInt64},
+		{Name: "amount", Type: field.TypeFloat64, SchemaType: map[string]string{"postgres": "decimal(20,8)"}},
+		{Name: "method", Type: field.TypeString, Size: 20},
+		{Name: "status", Type: field.TypeString, Size: 20, Default: "pending"},
+		{Name: "account_info", Type: field.TypeString, Default: "", SchemaType: map[string]string{"postgres": "text"}},
+		{Name: "processed_by", Type: field.TypeInt64, Nullable: true},
+		{Name: "admin_notes", Type: field.TypeString, Default: "", SchemaType: map[string]string{"postgres": "text"}},
+		{Name: "processed_at", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+	}
+	// CommissionWithdrawalsTable holds the schema information for the "commission_withdrawals" table.
+	CommissionWithdrawalsTable = &schema.Table{
+		Name:       "commission_withdrawals",
+		Columns:    CommissionWithdrawalsColumns,
+		PrimaryKey: []*schema.Column{CommissionWithdrawalsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "commissionwithdrawal_user_id",
+				Unique:  false,
+				Columns: []*schema.Column{CommissionWithdrawalsColumns[1]},
+			},
+			{
+				Name:    "commissionwithdrawal_status",
+				Unique:  false,
+				Columns: []*schema.Column{CommissionWithdrawalsColumns[4]},
+			},
+			{
+				Name:    "commissionwithdrawal_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{CommissionWithdrawalsColumns[9]},
+			},
+		},
+	}
 	// GroupsColumns holds the columns for the "groups" table.
 	GroupsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
@@ -271,6 +323,16 @@ var (
 		{Name: "fallback_group_id", Type: field.TypeInt64, Nullable: true},
 		{Name: "model_routing", Type: field.TypeJSON, Nullable: true, SchemaType: map[string]string{"postgres": "jsonb"}},
 		{Name: "model_routing_enabled", Type: field.TypeBool, Default: false},
+		{Name: "scheduling_strategy", Type: field.TypeString, Size: 32, Default: ""},
+		{Name: "content_policy", Type: field.TypeJSON, Nullable: true, SchemaType: map[string]string{"postgres": "jsonb"}},
+		{Name: "rewrite_rules", Type: field.TypeJSON, Nullable: true, SchemaType: map[string]string{"postgres": "jsonb"}},
+		{Name: "model_aliases", Type: field.TypeJSON, Nullable: true, SchemaType: map[string]string{"postgres": "jsonb"}},
+		{Name: "overage_policy", Type: field.TypeString, Size: 32, Default: "reject"},
+		{Name: "overage_rate_multiplier", Type: field.TypeFloat64, Default: 1, SchemaType: map[string]string{"postgres": "decimal(10,4)"}},
+		{Name: "overage_group_id", Type: field.TypeInt64, Nullable: true},
+		{Name: "subscription_price", Type: field.TypeFloat64, Nullable: true, SchemaType: map[string]string{"postgres": "decimal(20,8)"}},
+		{Name: "usage_quotas", Type: field.TypeJSON, Nullable: true, SchemaType: map[string]string{"postgres": "jsonb"}},
+		{Name: "traffic_split", Type: field.TypeJSON, Nullable: true, SchemaType: map[string]string{"postgres": "jsonb"}},
 	}
 	// GroupsTable holds the schema information for the "groups" table.
 	GroupsTable = &schema.Table{
@@ -312,6 +374,10 @@ var (
 		{Name: "reward_amount", Type: field.TypeFloat64, Default: 0, SchemaType: map[string]string{"postgres": "decimal(20,8)"}},
 		{Name: "status", Type: field.TypeString, Size: 20, Default: "pending"},
 		{Name: "confirmed_at", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "commission_status", Type: field.TypeString, Size: 20, Default: "active"},
+		{Name: "commission_block_reason", Type: field.TypeString, Size: 50, Default: ""},
+		{Name: "commission_cursor", Type: field.TypeInt64, Default: 0},
+		{Name: "commission_total", Type: field.TypeFloat64, Default: 0, SchemaType: map[string]string{"postgres": "decimal(20,8)"}},
 		{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
 		{Name: "inviter_id", Type: field.TypeInt64},
 		{Name: "invitee_id", Type: field.TypeInt64, Unique: true},
@@ -325,19 +391,19 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "user_invites_users_sent_invitations",
-				Columns:    []*schema.Column{UserInvitesColumns[6]},
+				Columns:    []*schema.Column{UserInvitesColumns[10]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "user_invites_users_received_invitation",
-				Columns:    []*schema.Column{UserInvitesColumns[7]},
+				Columns:    []*schema.Column{UserInvitesColumns[11]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "user_invites_users_confirmed_invites",
-				Columns:    []*schema.Column{UserInvitesColumns[8]},
+				Columns:    []*schema.Column{UserInvitesColumns[12]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
@@ -346,12 +412,12 @@ var (
 			{
 				Name:    "invitation_invitee_id",
 				Unique:  true,
-				Columns: []*schema.Column{UserInvitesColumns[7]},
+				Columns: []*schema.Column{UserInvitesColumns[11]},
 			},
 			{
 				Name:    "invitation_inviter_id",
 				Unique:  false,
-				Columns: []*schema.Column{UserInvitesColumns[6]},
+				Columns: []*schema.Column{UserInvitesColumns[10]},
 			},
 			{
 				Name:    "invitation_status",
@@ -361,10 +427,52 @@ var (
 			{
 				Name:    "invitation_created_at",
 				Unique:  false,
+				Columns: []*schema.Column{UserInvitesColumns[9]},
+			},
+			{
+				Name:    "invitation_commission_status",
+				Unique:  false,
 				Columns: []*schema.Column{UserInvitesColumns[5]},
 			},
 		},
 	}
+	// InviteCommissionsColumns holds the columns for the "invite_commissions" table.
+	InviteCommissionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "invite_id", Type: field.TypeInt64},
+		{Name: "inviter_id", Type: field.TypeInt64},
+		{Name: "invitee_id", Type: field.TypeInt64},
+		{Name: "base_amount", Type: field.TypeFloat64, SchemaType: map[string]string{"postgres": "decimal(20,10)"}},
+		{Name: "rate", Type: field.TypeFloat64, SchemaType: map[string]string{"postgres": "decimal(10,4)"}},
+		{Name: "amount", Type: field.TypeFloat64, SchemaType: map[string]string{"postgres": "decimal(20,8)"}},
+		{Name: "from_log_id", Type: field.TypeInt64},
+		{Name: "to_log_id", Type: field.TypeInt64},
+		{Name: "log_count", Type: field.TypeInt, Default: 0},
+		{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+	}
+	// InviteCommissionsTable holds the schema information for the "invite_commissions" table.
+	InviteCommissionsTable = &schema.Table{
+		Name:       "invite_commissions",
+		Columns:    InviteCommissionsColumns,
+		PrimaryKey: []*schema.Column{InviteCommissionsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "invitecommission_invite_id",
+				Unique:  false,
+				Columns: []*schema.Column{InviteCommissionsColumns[1]},
+			},
+			{
+				Name:    "invitecommission_inviter_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{InviteCommissionsColumns[2], InviteCommissionsColumns[10]},
+			},
+			{
+				Name:    "invitecommission_invitee_id",
+				Unique:  false,
+				Columns: []*schema.Column{InviteCommissionsColumns[3]},
+			},
+		},
+	}
 	// InviteLogsColumns holds the columns for the "invite_logs" table.
 	InviteLogsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
@@ -435,6 +543,130 @@ var (
 			},
 		},
 	}
+	// OrganizationsColumns holds the columns for the "organizations" table.
+	OrganizationsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "updated_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "name", Type: field.TypeString, Size: 100},
+		{Name: "owner_user_id", Type: field.TypeInt64},
+		{Name: "balance", Type: field.TypeFloat64, Default: 0, SchemaType: map[string]string{"postgres": "decimal(20,8)"}},
+		{Name: "status", Type: field.TypeString, Size: 20, Default: "active"},
+		{Name: "notes", Type: field.TypeString, Default: "", SchemaType: map[string]string{"postgres": "text"}},
+	}
+	// OrganizationsTable holds the schema information for the "organizations" table.
+	OrganizationsTable = &schema.Table{
+		Name:       "organizations",
+		Columns:    OrganizationsColumns,
+		PrimaryKey: []*schema.Column{OrganizationsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "organization_owner_user_id",
+				Unique:  false,
+				Columns: []*schema.Column{OrganizationsColumns[5]},
+			},
+			{
+				Name:    "organization_status",
+				Unique:  false,
+				Columns: []*schema.Column{OrganizationsColumns[7]},
+			},
+			{
+				Name:    "organization_deleted_at",
+				Unique:  false,
+				Columns: []*schema.Column{OrganizationsColumns[3]},
+			},
+		},
+	}
+	// OrganizationInvitationsColumns holds the columns for the "organization_invitations" table.
+	OrganizationInvitationsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "email", Type: field.TypeString, Size: 255},
+		{Name: "role", Type: field.TypeString, Size: 20, Default: "member"},
+		{Name: "token_hash", Type: field.TypeString, Unique: true, Size: 64},
+		{Name: "invited_by", Type: field.TypeInt64},
+		{Name: "status", Type: field.TypeString, Size: 20, Default: "pending"},
+		{Name: "expires_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "accepted_user_id", Type: field.TypeInt64, Nullable: true},
+		{Name: "accepted_at", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "organization_id", Type: field.TypeInt64},
+	}
+	// OrganizationInvitationsTable holds the schema information for the "organization_invitations" table.
+	OrganizationInvitationsTable = &schema.Table{
+		Name:       "organization_invitations",
+		Columns:    OrganizationInvitationsColumns,
+		PrimaryKey: []*schema.Column{OrganizationInvitationsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "organization_invitations_organizations_invitations",
+				Columns:    []*schema.Column{OrganizationInvitationsColumns[10]},
+				RefColumns: []*schema.Column{OrganizationsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "organizationinvitation_organization_id",
+				Unique:  false,
+				Columns: []*schema.Column{OrganizationInvitationsColumns[10]},
+			},
+			{
+				Name:    "organizationinvitation_email",
+				Unique:  false,
+				Columns: []*schema.Column{OrganizationInvitationsColumns[1]},
+			},
+			{
+				Name:    "organizationinvitation_status",
+				Unique:  false,
+				Columns: []*schema.Column{OrganizationInvitationsColumns[5]},
+			},
+		},
+	}
+	// OrganizationMembersColumns holds the columns for the "organization_members" table.
+	OrganizationMembersColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "updated_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "role", Type: field.TypeString, Size: 20, Default: "member"},
+		{Name: "monthly_limit_usd", Type: field.TypeFloat64, Nullable: true, SchemaType: map[string]string{"postgres": "decimal(20,8)"}},
+		{Name: "monthly_usage_usd", Type: field.TypeFloat64, Default: 0, SchemaType: map[string]string{"postgres": "decimal(20,10)"}},
+		{Name: "monthly_window_start", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "organization_id", Type: field.TypeInt64},
+		{Name: "user_id", Type: field.TypeInt64},
+	}
+	// OrganizationMembersTable holds the schema information for the "organization_members" table.
+	OrganizationMembersTable = &schema.Table{
+		Name:       "organization_members",
+		Columns:    OrganizationMembersColumns,
+		PrimaryKey: []*schema.Column{OrganizationMembersColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "organization_members_organizations_members",
+				Columns:    []*schema.Column{OrganizationMembersColumns[7]},
+				RefColumns: []*schema.Column{OrganizationsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+			{
+				Symbol:     "organization_members_users_organization_memberships",
+				Columns:    []*schema.Column{OrganizationMembersColumns[8]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "organizationmember_organization_id_user_id",
+				Unique:  true,
+				Columns: []*schema.Column{OrganizationMembersColumns[7], OrganizationMembersColumns[8]},
+			},
+			{
+				Name:    "organizationmember_user_id",
+				Unique:  false,
+				Columns: []*schema.Column{OrganizationMembersColumns[8]},
+			},
+		},
+	}
 	// PlansColumns holds the columns for the "plans" table.
 	PlansColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
@@ -714,6 +946,7 @@ var (
 		{Name: "first_token_ms", Type: field.TypeInt, Nullable: true},
 		{Name: "user_agent", Type: field.TypeString, Nullable: true, Size: 512},
 		{Name: "ip_address", Type: field.TypeString, Nullable: true, Size: 45},
+		{Name: "traffic_arm", Type: field.TypeString, Nullable: true, Size: 64},
 		{Name: "image_count", Type: field.TypeInt, Default: 0},
 		{Name: "image_size", Type: field.TypeString, Nullable: true, Size: 10},
 		{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
@@ -731,31 +964,31 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "usage_logs_api_keys_usage_logs",
-				Columns:    []*schema.Column{UsageLogsColumns[26]},
+				Columns:    []*schema.Column{UsageLogsColumns[27]},
 				RefColumns: []*schema.Column{APIKeysColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "usage_logs_accounts_usage_logs",
-				Columns:    []*schema.Column{UsageLogsColumns[27]},
+				Columns:    []*schema.Column{UsageLogsColumns[28]},
 				RefColumns: []*schema.Column{AccountsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "usage_logs_groups_usage_logs",
-				Columns:    []*schema.Column{UsageLogsColumns[28]},
+				Columns:    []*schema.Column{UsageLogsColumns[29]},
 				RefColumns: []*schema.Column{GroupsColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
 			{
 				Symbol:     "usage_logs_users_usage_logs",
-				Columns:    []*schema.Column{UsageLogsColumns[29]},
+				Columns:    []*schema.Column{UsageLogsColumns[30]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "usage_logs_user_subscriptions_usage_logs",
-				Columns:    []*schema.Column{UsageLogsColumns[30]},
+				Columns:    []*schema.Column{UsageLogsColumns[31]},
 				RefColumns: []*schema.Column{UserSubscriptionsColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
@@ -764,32 +997,32 @@ var (
 			{
 				Name:    "usagelog_user_id",
 				Unique:  false,
-				Columns: []*schema.Column{UsageLogsColumns[29]},
+				Columns: []*schema.Column{UsageLogsColumns[30]},
 			},
 			{
 				Name:    "usagelog_api_key_id",
 				Unique:  false,
-				Columns: []*schema.Column{UsageLogsColumns[26]},
+				Columns: []*schema.Column{UsageLogsColumns[27]},
 			},
 			{
 				Name:    "usagelog_account_id",
 				Unique:  false,
-				Columns: []*schema.Column{UsageLogsColumns[27]},
+				Columns: []*schema.Column{UsageLogsColumns[28]},
 			},
 			{
 				Name:    "usagelog_group_id",
 				Unique:  false,
-				Columns: []*schema.Column{UsageLogsColumns[28]},
+				Columns: []*schema.Column{UsageLogsColumns[29]},
 			},
 			{
 				Name:    "usagelog_subscription_id",
 				Unique:  false,
-				Columns: []*schema.Column{UsageLogsColumns[30]},
+				Columns: []*schema.Column{UsageLogsColumns[31]},
 			},
 			{
 				Name:    "usagelog_created_at",
 				Unique:  false,
-				Columns: []*schema.Column{UsageLogsColumns[25]},
+				Columns: []*schema.Column{UsageLogsColumns[26]},
 			},
 			{
 				Name:    "usagelog_model",
@@ -804,12 +1037,12 @@ var (
 			{
 				Name:    "usagelog_user_id_created_at",
 				Unique:  false,
-				Columns: []*schema.Column{UsageLogsColumns[29], UsageLogsColumns[25]},
+				Columns: []*schema.Column{UsageLogsColumns[30], UsageLogsColumns[26]},
 			},
 			{
 				Name:    "usagelog_api_key_id_created_at",
 				Unique:  false,
-				Columns: []*schema.Column{UsageLogsColumns[26], UsageLogsColumns[25]},
+				Columns: []*schema.Column{UsageLogsColumns[27], UsageLogsColumns[26]},
 			},
 		},
 	}
@@ -831,6 +1064,11 @@ var (
 		{Name: "totp_secret_encrypted", Type: field.TypeString, Nullable: true, SchemaType: map[string]string{"postgres": "text"}},
 		{Name: "totp_enabled", Type: field.TypeBool, Default: false},
 		{Name: "totp_enabled_at", Type: field.TypeTime, Nullable: true},
+		{Name: "dedicated_account_ids", Type: field.TypeJSON, Nullable: true},
+		{Name: "dedicated_accounts_only", Type: field.TypeBool, Default: false},
+		{Name: "commission_balance", Type: field.TypeFloat64, Default: 0, SchemaType: map[string]string{"postgres": "decimal(20,8)"}},
+		{Name: "register_ip", Type: field.TypeString, Nullable: true, Size: 45},
+		{Name: "register_device", Type: field.TypeString, Nullable: true, Size: 128},
 	}
 	// UsersTable holds the schema information for the "users" table.
 	UsersTable = &schema.Table{
@@ -969,6 +1207,35 @@ var (
 			},
 		},
 	}
+	// UserRecoveryCodesColumns holds the columns for the "user_recovery_codes" table.
+	UserRecoveryCodesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "code_hash", Type: field.TypeString, Size: 64},
+		{Name: "used_at", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "user_id", Type: field.TypeInt64},
+	}
+	// UserRecoveryCodesTable holds the schema information for the "user_recovery_codes" table.
+	UserRecoveryCodesTable = &schema.Table{
+		Name:       "user_recovery_codes",
+		Columns:    UserRecoveryCodesColumns,
+		PrimaryKey: []*schema.Column{UserRecoveryCodesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_recovery_codes_users_recovery_codes",
+				Columns:    []*schema.Column{UserRecoveryCodesColumns[4]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "userrecoverycode_user_id_code_hash",
+				Unique:  true,
+				Columns: []*schema.Column{UserRecoveryCodesColumns[4], UserRecoveryCodesColumns[1]},
+			},
+		},
+	}
 	// UserSubscriptionsColumns holds the columns for the "user_subscriptions" table.
 	UserSubscriptionsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
@@ -986,6 +1253,9 @@ var (
 		{Name: "monthly_usage_usd", Type: field.TypeFloat64, Default: 0, SchemaType: map[string]string{"postgres": "decimal(20,10)"}},
 		{Name: "assigned_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
 		{Name: "notes", Type: field.TypeString, Nullable: true, SchemaType: map[string]string{"postgres": "text"}},
+		{Name: "auto_renew", Type: field.TypeBool, Default: false},
+		{Name: "paused_at", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "renewal_failed_at", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
 		{Name: "group_id", Type: field.TypeInt64},
 		{Name: "user_id", Type: field.TypeInt64},
 		{Name: "assigned_by", Type: field.TypeInt64, Nullable: true},
@@ -998,19 +1268,19 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "user_subscriptions_groups_subscriptions",
-				Columns:    []*schema.Column{UserSubscriptionsColumns[15]},
+				Columns:    []*schema.Column{UserSubscriptionsColumns[18]},
 				RefColumns: []*schema.Column{GroupsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "user_subscriptions_users_subscriptions",
-				Columns:    []*schema.Column{UserSubscriptionsColumns[16]},
+				Columns:    []*schema.Column{UserSubscriptionsColumns[19]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "user_subscriptions_users_assigned_subscriptions",
-				Columns:    []*schema.Column{UserSubscriptionsColumns[17]},
+				Columns:    []*schema.Column{UserSubscriptionsColumns[20]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
@@ -1019,12 +1289,12 @@ var (
 			{
 				Name:    "usersubscription_user_id",
 				Unique:  false,
-				Columns: []*schema.Column{UserSubscriptionsColumns[16]},
+				Columns: []*schema.Column{UserSubscriptionsColumns[19]},
 			},
 			{
 				Name:    "usersubscription_group_id",
 				Unique:  false,
-				Columns: []*schema.Column{UserSubscriptionsColumns[15]},
+				Columns: []*schema.Column{UserSubscriptionsColumns[18]},
 			},
 			{
 				Name:    "usersubscription_status",
@@ -1039,12 +1309,12 @@ var (
 			{
 				Name:    "usersubscription_assigned_by",
 				Unique:  false,
-				Columns: []*schema.Column{UserSubscriptionsColumns[17]},
+				Columns: []*schema.Column{UserSubscriptionsColumns[20]},
 			},
 			{
 				Name:    "usersubscription_user_id_group_id",
 				Unique:  false,
-				Columns: []*schema.Column{UserSubscriptionsColumns[16], UserSubscriptionsColumns[15]},
+				Columns: []*schema.Column{UserSubscriptionsColumns[19], UserSubscriptionsColumns[18]},
 			},
 			{
 				Name:    "usersubscription_deleted_at",
@@ -1053,15 +1323,56 @@ var (
 			},
 		},
 	}
+	// WebauthnCredentialsColumns holds the columns for the "webauthn_credentials" table.
+	WebauthnCredentialsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "updated_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "name", Type: field.TypeString, Size: 64},
+		{Name: "credential_id", Type: field.TypeString, Unique: true, Size: 1400},
+		{Name: "public_key", Type: field.TypeBytes},
+		{Name: "sign_count", Type: field.TypeInt64, Default: 0},
+		{Name: "aaguid", Type: field.TypeString, Size: 36, Default: ""},
+		{Name: "transports", Type: field.TypeJSON, Nullable: true},
+		{Name: "user_verified", Type: field.TypeBool, Default: false},
+		{Name: "last_used_at", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "user_id", Type: field.TypeInt64},
+	}
+	// WebauthnCredentialsTable holds the schema information for the "webauthn_credentials" table.
+	WebauthnCredentialsTable = &schema.Table{
+		Name:       "webauthn_credentials",
+		Columns:    WebauthnCredentialsColumns,
+		PrimaryKey: []*schema.Column{WebauthnCredentialsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "webauthn_credentials_users_webauthn_credentials",
+				Columns:    []*schema.Column{WebauthnCredentialsColumns[11]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "webauthncredential_user_id",
+				Unique:  false,
+				Columns: []*schema.Column{WebauthnCredentialsColumns[11]},
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		APIKeysTable,
 		AccountsTable,
 		AccountGroupsTable,
 		AdminActionLogsTable,
+		CommissionWithdrawalsTable,
 		GroupsTable,
 		UserInvitesTable,
+		InviteCommissionsTable,
 		InviteLogsTable,
+		OrganizationsTable,
+		OrganizationInvitationsTable,
+		OrganizationMembersTable,
 		PlansTable,
 		PromoCodesTable,
 		PromoCodeUsagesTable,
@@ -1074,13 +1385,16 @@ var (
 		UserAllowedGroupsTable,
 		UserAttributeDefinitionsTable,
 		UserAttributeValuesTable,
+		UserRecoveryCodesTable,
 		UserSubscriptionsTable,
+		WebauthnCredentialsTable,
 	}
 )
 
 func init() {
 	APIKeysTable.ForeignKeys[0].RefTable = GroupsTable
-	APIKeysTable.ForeignKeys[1].RefTable = UsersTable
+	APIKeysTable.ForeignKeys[1].RefTable = OrganizationsTable
+	APIKeysTable.ForeignKeys[2].RefTable = UsersTable
 	APIKeysTable.Annotation = &entsql.Annotation{
 		Table: "api_keys",
 	}
@@ -1097,6 +1411,9 @@ func init() {
 	AdminActionLogsTable.Annotation = &entsql.Annotation{
 		Table: "admin_action_logs",
 	}
+	CommissionWithdrawalsTable.Annotation = &entsql.Annotation{
+		Table: "commission_withdrawals",
+	}
 	GroupsTable.Annotation = &entsql.Annotation{
 		Table: "groups",
 	}
@@ -1106,6 +1423,9 @@ func init() {
 	UserInvitesTable.Annotation = &entsql.Annotation{
 		Table: "user_invites",
 	}
+	InviteCommissionsTable.Annotation = &entsql.Annotation{
+		Table: "invite_commissions",
+	}
 	InviteLogsTable.ForeignKeys[0].RefTable = UserInvitesTable
 	InviteLogsTable.ForeignKeys[1].RefTable = UsersTable
 	InviteLogsTable.ForeignKeys[2].RefTable = UsersTable
@@ -1113,6 +1433,18 @@ func init() {
 	InviteLogsTable.Annotation = &entsql.Annotation{
 		Table: "invite_logs",
 	}
+	OrganizationsTable.Annotation = &entsql.Annotation{
+		Table: "organizations",
+	}
+	OrganizationInvitationsTable.ForeignKeys[0].RefTable = OrganizationsTable
+	OrganizationInvitationsTable.Annotation = &entsql.Annotation{
+		Table: "organization_invitations",
+	}
+	OrganizationMembersTable.ForeignKeys[0].RefTable = OrganizationsTable
+	OrganizationMembersTable.ForeignKeys[1].RefTable = UsersTable
+	OrganizationMembersTable.Annotation = &entsql.Annotation{
+		Table: "organization_members",
+	}
 	PlansTable.Annotation = &entsql.Annotation{
 		Table: "plans",
 	}
@@ -1162,10 +1494,18 @@ func init() {
 	UserAttributeValuesTable.Annotation = &entsql.Annotation{
 		Table: "user_attribute_values",
 	}
+	UserRecoveryCodesTable.ForeignKeys[0].RefTable = UsersTable
+	UserRecoveryCodesTable.Annotation = &entsql.Annotation{
+		Table: "user_recovery_codes",
+	}
 	UserSubscriptionsTable.ForeignKeys[0].RefTable = GroupsTable
 	UserSubscriptionsTable.ForeignKeys[1].RefTable = UsersTable
 	UserSubscriptionsTable.ForeignKeys[2].RefTable = UsersTable
 	UserSubscriptionsTable.Annotation = &entsql.Annotation{
 		Table: "user_subscriptions",
 	}
+	WebauthnCredentialsTable.ForeignKeys[0].RefTable = UsersTable
+	WebauthnCredentialsTable.Annotation = &entsql.Annotation{
+		Table: "webauthn_credentials",
+	}
 }
@@ -0,0 +1,240 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
+	"github.com/Wei-Shaw/sub2api/ent/organizationinvitation"
+)
+
+// OrganizationInvitation is the model entity for the OrganizationInvitation schema.
+type OrganizationInvitation struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID int64 `json:"organization_id,omitempty"`
+	// Email holds the value of the "email" field.
+	Email string `json:"email,omitempty"`
+	// Role holds the value of the "role" field.
+	Role string `json:"role,omitempty"`
+	// TokenHash holds the value of the "token_hash" field.
+	TokenHash string `json:"token_hash,omitempty"`
+	// InvitedBy holds the value of the "invited_by" field.
+	InvitedBy int64 `json:"invited_by,omitempty"`
+	// Status holds the value of the "status" field.
+	Status string `json:"status,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// AcceptedUserID holds the value of the "accepted_user_id" field.
+	AcceptedUserID *int64 `json:"accepted_user_id,omitempty"`
+	// AcceptedAt holds the value of the "accepted_at" field.
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the OrganizationInvitationQuery when eager-loading is set.
+	Edges        OrganizationInvitationEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// OrganizationInvitationEdges holds the relations/edges for other nodes in the graph.
+type OrganizationInvitationEdges struct {
+	// Organization holds the value of the organization edge.
+	Organization *Organization `json:"organization,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// OrganizationOrErr returns the Organization value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e OrganizationInvitationEdges) OrganizationOrErr() (*Organization, error) {
+	if e.Organization != nil {
+		return e.Organization, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: organization.Label}
+	}
+	return nil, &NotLoadedError{edge: "organization"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*OrganizationInvitation) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case organizationinvitation.FieldID, organizationinvitation.FieldOrganizationID, organizationinvitation.FieldInvitedBy, organizationinvitation.FieldAcceptedUserID:
+			values[i] = new(sql.NullInt64)
+		case organizationinvitation.FieldEmail, organizationinvitation.FieldRole, organizationinvitation.FieldTokenHash, organizationinvitation.FieldStatus:
+			values[i] = new(sql.NullString)
+		case organizationinvitation.FieldExpiresAt, organizationinvitation.FieldAcceptedAt, organizationinvitation.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the OrganizationInvitation fields.
+func (_m *OrganizationInvitation) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case organizationinvitation.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int64(value.Int64)
+		case organizationinvitation.FieldOrganizationID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[i])
+			} else if value.Valid {
+				_m.OrganizationID = value.Int64
+			}
+		case organizationinvitation.FieldEmail:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email", values[i])
+			} else if value.Valid {
+				_m.Email = value.String
+			}
+		case organizationinvitation.FieldRole:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field role", values[i])
+			} else if value.Valid {
+				_m.Role = value.String
+			}
+		case organizationinvitation.FieldTokenHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field token_hash", values[i])
+			} else if value.Valid {
+				_m.TokenHash = value.String
+			}
+		case organizationinvitation.FieldInvitedBy:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field invited_by", values[i])
+			} else if value.Valid {
+				_m.InvitedBy = value.Int64
+			}
+		case organizationinvitation.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				_m.Status = value.String
+			}
+		case organizationinvitation.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				_m.ExpiresAt = value.Time
+			}
+		case organizationinvitation.FieldAcceptedUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field accepted_user_id", values[i])
+			} else if value.Valid {
+				_m.AcceptedUserID = new(int64)
+				*_m.AcceptedUserID = value.Int64
+			}
+		case organizationinvitation.FieldAcceptedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field accepted_at", values[i])
+			} else if value.Valid {
+				_m.AcceptedAt = new(time.Time)
+				*_m.AcceptedAt = value.Time
+			}
+		case organizationinvitation.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the OrganizationInvitation.
+// This includes values selected through modifiers, order, etc.
+func (_m *OrganizationInvitation) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryOrganization queries the "organization" edge of the OrganizationInvitation entity.
+func (_m *OrganizationInvitation) QueryOrganization() *OrganizationQuery {
+	return NewOrganizationInvitationClient(_m.config).QueryOrganization(_m)
+}
+
+// Update returns a builder for updating this OrganizationInvitation.
+// Note that you need to call OrganizationInvitation.Unwrap() before calling this method if this OrganizationInvitation
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *OrganizationInvitation) Update() *OrganizationInvitationUpdateOne {
+	return NewOrganizationInvitationClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the OrganizationInvitation entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *OrganizationInvitation) Unwrap() *OrganizationInvitation {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: OrganizationInvitation is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *OrganizationInvitation) String() string {
+	var builder strings.Builder
+	builder.WriteString("OrganizationInvitation(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("organization_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OrganizationID))
+	builder.WriteString(", ")
+	builder.WriteString("email=")
+	builder.WriteString(_m.Email)
+	builder.WriteString(", ")
+	builder.WriteString("role=")
+	builder.WriteString(_m.Role)
+	builder.WriteString(", ")
+	builder.WriteString("token_hash=")
+	builder.WriteString(_m.TokenHash)
+	builder.WriteString(", ")
+	builder.WriteString("invited_by=")
+	builder.WriteString(fmt.Sprintf("%v", _m.InvitedBy))
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(_m.Status)
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(_m.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := _m.AcceptedUserID; v != nil {
+		builder.WriteString("accepted_user_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.AcceptedAt; v != nil {
+		builder.WriteString("accepted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// OrganizationInvitations is a parsable slice of OrganizationInvitation.
+type OrganizationInvitations []*OrganizationInvitation
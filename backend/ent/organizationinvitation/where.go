@@ -0,0 +1,644 @@
+// Code generated by ent, DO NOT EDIT.
+
+package organizationinvitation
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLTE(FieldID, id))
+}
+
+// OrganizationID applies equality check predicate on the "organization_id" field. It's identical to OrganizationIDEQ.
+func OrganizationID(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldOrganizationID, v))
+}
+
+// Email applies equality check predicate on the "email" field. It's identical to EmailEQ.
+func Email(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldEmail, v))
+}
+
+// Role applies equality check predicate on the "role" field. It's identical to RoleEQ.
+func Role(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldRole, v))
+}
+
+// TokenHash applies equality check predicate on the "token_hash" field. It's identical to TokenHashEQ.
+func TokenHash(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldTokenHash, v))
+}
+
+// InvitedBy applies equality check predicate on the "invited_by" field. It's identical to InvitedByEQ.
+func InvitedBy(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldInvitedBy, v))
+}
+
+// Status applies equality check predicate on the "status" field. It's identical to StatusEQ.
+func Status(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldStatus, v))
+}
+
+// ExpiresAt applies equality check predicate on the "expires_at" field. It's identical to ExpiresAtEQ.
+func ExpiresAt(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// AcceptedUserID applies equality check predicate on the "accepted_user_id" field. It's identical to AcceptedUserIDEQ.
+func AcceptedUserID(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldAcceptedUserID, v))
+}
+
+// AcceptedAt applies equality check predicate on the "accepted_at" field. It's identical to AcceptedAtEQ.
+func AcceptedAt(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldAcceptedAt, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// OrganizationIDEQ applies the EQ predicate on the "organization_id" field.
+func OrganizationIDEQ(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDNEQ applies the NEQ predicate on the "organization_id" field.
+func OrganizationIDNEQ(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDIn applies the In predicate on the "organization_id" field.
+func OrganizationIDIn(vs ...int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldOrganizationID, vs...))
+}
+
+// OrganizationIDNotIn applies the NotIn predicate on the "organization_id" field.
+func OrganizationIDNotIn(vs ...int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldOrganizationID, vs...))
+}
+
+// EmailEQ applies the EQ predicate on the "email" field.
+func EmailEQ(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldEmail, v))
+}
+
+// EmailNEQ applies the NEQ predicate on the "email" field.
+func EmailNEQ(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldEmail, v))
+}
+
+// EmailIn applies the In predicate on the "email" field.
+func EmailIn(vs ...string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldEmail, vs...))
+}
+
+// EmailNotIn applies the NotIn predicate on the "email" field.
+func EmailNotIn(vs ...string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldEmail, vs...))
+}
+
+// EmailGT applies the GT predicate on the "email" field.
+func EmailGT(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGT(FieldEmail, v))
+}
+
+// EmailGTE applies the GTE predicate on the "email" field.
+func EmailGTE(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGTE(FieldEmail, v))
+}
+
+// EmailLT applies the LT predicate on the "email" field.
+func EmailLT(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLT(FieldEmail, v))
+}
+
+// EmailLTE applies the LTE predicate on the "email" field.
+func EmailLTE(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLTE(FieldEmail, v))
+}
+
+// EmailContains applies the Contains predicate on the "email" field.
+func EmailContains(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldContains(FieldEmail, v))
+}
+
+// EmailHasPrefix applies the HasPrefix predicate on the "email" field.
+func EmailHasPrefix(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldHasPrefix(FieldEmail, v))
+}
+
+// EmailHasSuffix applies the HasSuffix predicate on the "email" field.
+func EmailHasSuffix(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldHasSuffix(FieldEmail, v))
+}
+
+// EmailEqualFold applies the EqualFold predicate on the "email" field.
+func EmailEqualFold(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEqualFold(FieldEmail, v))
+}
+
+// EmailContainsFold applies the ContainsFold predicate on the "email" field.
+func EmailContainsFold(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldContainsFold(FieldEmail, v))
+}
+
+// RoleEQ applies the EQ predicate on the "role" field.
+func RoleEQ(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldRole, v))
+}
+
+// RoleNEQ applies the NEQ predicate on the "role" field.
+func RoleNEQ(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldRole, v))
+}
+
+// RoleIn applies the In predicate on the "role" field.
+func RoleIn(vs ...string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldRole, vs...))
+}
+
+// RoleNotIn applies the NotIn predicate on the "role" field.
+func RoleNotIn(vs ...string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldRole, vs...))
+}
+
+// RoleGT applies the GT predicate on the "role" field.
+func RoleGT(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGT(FieldRole, v))
+}
+
+// RoleGTE applies the GTE predicate on the "role" field.
+func RoleGTE(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGTE(FieldRole, v))
+}
+
+// RoleLT applies the LT predicate on the "role" field.
+func RoleLT(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLT(FieldRole, v))
+}
+
+// RoleLTE applies the LTE predicate on the "role" field.
+func RoleLTE(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLTE(FieldRole, v))
+}
+
+// RoleContains applies the Contains predicate on the "role" field.
+func RoleContains(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldContains(FieldRole, v))
+}
+
+// RoleHasPrefix applies the HasPrefix predicate on the "role" field.
+func RoleHasPrefix(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldHasPrefix(FieldRole, v))
+}
+
+// RoleHasSuffix applies the HasSuffix predicate on the "role" field.
+func RoleHasSuffix(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldHasSuffix(FieldRole, v))
+}
+
+// RoleEqualFold applies the EqualFold predicate on the "role" field.
+func RoleEqualFold(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEqualFold(FieldRole, v))
+}
+
+// RoleContainsFold applies the ContainsFold predicate on the "role" field.
+func RoleContainsFold(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldContainsFold(FieldRole, v))
+}
+
+// TokenHashEQ applies the EQ predicate on the "token_hash" field.
+func TokenHashEQ(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldTokenHash, v))
+}
+
+// TokenHashNEQ applies the NEQ predicate on the "token_hash" field.
+func TokenHashNEQ(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldTokenHash, v))
+}
+
+// TokenHashIn applies the In predicate on the "token_hash" field.
+func TokenHashIn(vs ...string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldTokenHash, vs...))
+}
+
+// TokenHashNotIn applies the NotIn predicate on the "token_hash" field.
+func TokenHashNotIn(vs ...string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldTokenHash, vs...))
+}
+
+// TokenHashGT applies the GT predicate on the "token_hash" field.
+func TokenHashGT(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGT(FieldTokenHash, v))
+}
+
+// TokenHashGTE applies the GTE predicate on the "token_hash" field.
+func TokenHashGTE(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGTE(FieldTokenHash, v))
+}
+
+// TokenHashLT applies the LT predicate on the "token_hash" field.
+func TokenHashLT(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLT(FieldTokenHash, v))
+}
+
+// TokenHashLTE applies the LTE predicate on the "token_hash" field.
+func TokenHashLTE(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLTE(FieldTokenHash, v))
+}
+
+// TokenHashContains applies the Contains predicate on the "token_hash" field.
+func TokenHashContains(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldContains(FieldTokenHash, v))
+}
+
+// TokenHashHasPrefix applies the HasPrefix predicate on the "token_hash" field.
+func TokenHashHasPrefix(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldHasPrefix(FieldTokenHash, v))
+}
+
+// TokenHashHasSuffix applies the HasSuffix predicate on the "token_hash" field.
+func TokenHashHasSuffix(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldHasSuffix(FieldTokenHash, v))
+}
+
+// TokenHashEqualFold applies the EqualFold predicate on the "token_hash" field.
+func TokenHashEqualFold(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEqualFold(FieldTokenHash, v))
+}
+
+// TokenHashContainsFold applies the ContainsFold predicate on the "token_hash" field.
+func TokenHashContainsFold(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldContainsFold(FieldTokenHash, v))
+}
+
+// InvitedByEQ applies the EQ predicate on the "invited_by" field.
+func InvitedByEQ(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldInvitedBy, v))
+}
+
+// InvitedByNEQ applies the NEQ predicate on the "invited_by" field.
+func InvitedByNEQ(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldInvitedBy, v))
+}
+
+// InvitedByIn applies the In predicate on the "invited_by" field.
+func InvitedByIn(vs ...int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldInvitedBy, vs...))
+}
+
+// InvitedByNotIn applies the NotIn predicate on the "invited_by" field.
+func InvitedByNotIn(vs ...int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldInvitedBy, vs...))
+}
+
+// InvitedByGT applies the GT predicate on the "invited_by" field.
+func InvitedByGT(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGT(FieldInvitedBy, v))
+}
+
+// InvitedByGTE applies the GTE predicate on the "invited_by" field.
+func InvitedByGTE(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGTE(FieldInvitedBy, v))
+}
+
+// InvitedByLT applies the LT predicate on the "invited_by" field.
+func InvitedByLT(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLT(FieldInvitedBy, v))
+}
+
+// InvitedByLTE applies the LTE predicate on the "invited_by" field.
+func InvitedByLTE(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLTE(FieldInvitedBy, v))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// StatusGT applies the GT predicate on the "status" field.
+func StatusGT(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGT(FieldStatus, v))
+}
+
+// StatusGTE applies the GTE predicate on the "status" field.
+func StatusGTE(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGTE(FieldStatus, v))
+}
+
+// StatusLT applies the LT predicate on the "status" field.
+func StatusLT(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLT(FieldStatus, v))
+}
+
+// StatusLTE applies the LTE predicate on the "status" field.
+func StatusLTE(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLTE(FieldStatus, v))
+}
+
+// StatusContains applies the Contains predicate on the "status" field.
+func StatusContains(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldContains(FieldStatus, v))
+}
+
+// StatusHasPrefix applies the HasPrefix predicate on the "status" field.
+func StatusHasPrefix(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldHasPrefix(FieldStatus, v))
+}
+
+// StatusHasSuffix applies the HasSuffix predicate on the "status" field.
+func StatusHasSuffix(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldHasSuffix(FieldStatus, v))
+}
+
+// StatusEqualFold applies the EqualFold predicate on the "status" field.
+func StatusEqualFold(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEqualFold(FieldStatus, v))
+}
+
+// StatusContainsFold applies the ContainsFold predicate on the "status" field.
+func StatusContainsFold(v string) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldContainsFold(FieldStatus, v))
+}
+
+// ExpiresAtEQ applies the EQ predicate on the "expires_at" field.
+func ExpiresAtEQ(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtNEQ applies the NEQ predicate on the "expires_at" field.
+func ExpiresAtNEQ(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtIn applies the In predicate on the "expires_at" field.
+func ExpiresAtIn(vs ...time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtNotIn applies the NotIn predicate on the "expires_at" field.
+func ExpiresAtNotIn(vs ...time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtGT applies the GT predicate on the "expires_at" field.
+func ExpiresAtGT(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGT(FieldExpiresAt, v))
+}
+
+// ExpiresAtGTE applies the GTE predicate on the "expires_at" field.
+func ExpiresAtGTE(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGTE(FieldExpiresAt, v))
+}
+
+// ExpiresAtLT applies the LT predicate on the "expires_at" field.
+func ExpiresAtLT(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLT(FieldExpiresAt, v))
+}
+
+// ExpiresAtLTE applies the LTE predicate on the "expires_at" field.
+func ExpiresAtLTE(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLTE(FieldExpiresAt, v))
+}
+
+// AcceptedUserIDEQ applies the EQ predicate on the "accepted_user_id" field.
+func AcceptedUserIDEQ(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldAcceptedUserID, v))
+}
+
+// AcceptedUserIDNEQ applies the NEQ predicate on the "accepted_user_id" field.
+func AcceptedUserIDNEQ(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldAcceptedUserID, v))
+}
+
+// AcceptedUserIDIn applies the In predicate on the "accepted_user_id" field.
+func AcceptedUserIDIn(vs ...int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldAcceptedUserID, vs...))
+}
+
+// AcceptedUserIDNotIn applies the NotIn predicate on the "accepted_user_id" field.
+func AcceptedUserIDNotIn(vs ...int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldAcceptedUserID, vs...))
+}
+
+// AcceptedUserIDGT applies the GT predicate on the "accepted_user_id" field.
+func AcceptedUserIDGT(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGT(FieldAcceptedUserID, v))
+}
+
+// AcceptedUserIDGTE applies the GTE predicate on the "accepted_user_id" field.
+func AcceptedUserIDGTE(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGTE(FieldAcceptedUserID, v))
+}
+
+// AcceptedUserIDLT applies the LT predicate on the "accepted_user_id" field.
+func AcceptedUserIDLT(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLT(FieldAcceptedUserID, v))
+}
+
+// AcceptedUserIDLTE applies the LTE predicate on the "accepted_user_id" field.
+func AcceptedUserIDLTE(v int64) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLTE(FieldAcceptedUserID, v))
+}
+
+// AcceptedUserIDIsNil applies the IsNil predicate on the "accepted_user_id" field.
+func AcceptedUserIDIsNil() predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIsNull(FieldAcceptedUserID))
+}
+
+// AcceptedUserIDNotNil applies the NotNil predicate on the "accepted_user_id" field.
+func AcceptedUserIDNotNil() predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotNull(FieldAcceptedUserID))
+}
+
+// AcceptedAtEQ applies the EQ predicate on the "accepted_at" field.
+func AcceptedAtEQ(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldAcceptedAt, v))
+}
+
+// AcceptedAtNEQ applies the NEQ predicate on the "accepted_at" field.
+func AcceptedAtNEQ(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldAcceptedAt, v))
+}
+
+// AcceptedAtIn applies the In predicate on the "accepted_at" field.
+func AcceptedAtIn(vs ...time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldAcceptedAt, vs...))
+}
+
+// AcceptedAtNotIn applies the NotIn predicate on the "accepted_at" field.
+func AcceptedAtNotIn(vs ...time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldAcceptedAt, vs...))
+}
+
+// AcceptedAtGT applies the GT predicate on the "accepted_at" field.
+func AcceptedAtGT(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGT(FieldAcceptedAt, v))
+}
+
+// AcceptedAtGTE applies the GTE predicate on the "accepted_at" field.
+func AcceptedAtGTE(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGTE(FieldAcceptedAt, v))
+}
+
+// AcceptedAtLT applies the LT predicate on the "accepted_at" field.
+func AcceptedAtLT(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLT(FieldAcceptedAt, v))
+}
+
+// AcceptedAtLTE applies the LTE predicate on the "accepted_at" field.
+func AcceptedAtLTE(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLTE(FieldAcceptedAt, v))
+}
+
+// AcceptedAtIsNil applies the IsNil predicate on the "accepted_at" field.
+func AcceptedAtIsNil() predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIsNull(FieldAcceptedAt))
+}
+
+// AcceptedAtNotNil applies the NotNil predicate on the "accepted_at" field.
+func AcceptedAtNotNil() predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotNull(FieldAcceptedAt))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// HasOrganization applies the HasEdge predicate on the "organization" edge.
+func HasOrganization() predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, OrganizationTable, OrganizationColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOrganizationWith applies the HasEdge predicate on the "organization" edge with a given conditions (other predicates).
+func HasOrganizationWith(preds ...predicate.Organization) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(func(s *sql.Selector) {
+		step := newOrganizationStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.OrganizationInvitation) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.OrganizationInvitation) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.OrganizationInvitation) predicate.OrganizationInvitation {
+	return predicate.OrganizationInvitation(sql.NotPredicates(p))
+}
@@ -0,0 +1,1106 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
+	"github.com/Wei-Shaw/sub2api/ent/organizationinvitation"
+)
+
+// OrganizationInvitationCreate is the builder for creating a OrganizationInvitation entity.
+type OrganizationInvitationCreate struct {
+	config
+	mutation *OrganizationInvitationMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_c *OrganizationInvitationCreate) SetOrganizationID(v int64) *OrganizationInvitationCreate {
+	_c.mutation.SetOrganizationID(v)
+	return _c
+}
+
+// SetEmail sets the "email" field.
+func (_c *OrganizationInvitationCreate) SetEmail(v string) *OrganizationInvitationCreate {
+	_c.mutation.SetEmail(v)
+	return _c
+}
+
+// SetRole sets the "role" field.
+func (_c *OrganizationInvitationCreate) SetRole(v string) *OrganizationInvitationCreate {
+	_c.mutation.SetRole(v)
+	return _c
+}
+
+// SetNillableRole sets the "role" field if the given value is not nil.
+func (_c *OrganizationInvitationCreate) SetNillableRole(v *string) *OrganizationInvitationCreate {
+	if v != nil {
+		_c.SetRole(*v)
+	}
+	return _c
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (_c *OrganizationInvitationCreate) SetTokenHash(v string) *OrganizationInvitationCreate {
+	_c.mutation.SetTokenHash(v)
+	return _c
+}
+
+// SetInvitedBy sets the "invited_by" field.
+func (_c *OrganizationInvitationCreate) SetInvitedBy(v int64) *OrganizationInvitationCreate {
+	_c.mutation.SetInvitedBy(v)
+	return _c
+}
+
+// SetStatus sets the "status" field.
+func (_c *OrganizationInvitationCreate) SetStatus(v string) *OrganizationInvitationCreate {
+	_c.mutation.SetStatus(v)
+	return _c
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_c *OrganizationInvitationCreate) SetNillableStatus(v *string) *OrganizationInvitationCreate {
+	if v != nil {
+		_c.SetStatus(*v)
+	}
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *OrganizationInvitationCreate) SetExpiresAt(v time.Time) *OrganizationInvitationCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetAcceptedUserID sets the "accepted_user_id" field.
+func (_c *OrganizationInvitationCreate) SetAcceptedUserID(v int64) *OrganizationInvitationCreate {
+	_c.mutation.SetAcceptedUserID(v)
+	return _c
+}
+
+// SetNillableAcceptedUserID sets the "accepted_user_id" field if the given value is not nil.
+func (_c *OrganizationInvitationCreate) SetNillableAcceptedUserID(v *int64) *OrganizationInvitationCreate {
+	if v != nil {
+		_c.SetAcceptedUserID(*v)
+	}
+	return _c
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (_c *OrganizationInvitationCreate) SetAcceptedAt(v time.Time) *OrganizationInvitationCreate {
+	_c.mutation.SetAcceptedAt(v)
+	return _c
+}
+
+// SetNillableAcceptedAt sets the "accepted_at" field if the given value is not nil.
+func (_c *OrganizationInvitationCreate) SetNillableAcceptedAt(v *time.Time) *OrganizationInvitationCreate {
+	if v != nil {
+		_c.SetAcceptedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *OrganizationInvitationCreate) SetCreatedAt(v time.Time) *OrganizationInvitationCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *OrganizationInvitationCreate) SetNillableCreatedAt(v *time.Time) *OrganizationInvitationCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetOrganization sets the "organization" edge to the Organization entity.
+func (_c *OrganizationInvitationCreate) SetOrganization(v *Organization) *OrganizationInvitationCreate {
+	return _c.SetOrganizationID(v.ID)
+}
+
+// Mutation returns the OrganizationInvitationMutation object of the builder.
+func (_c *OrganizationInvitationCreate) Mutation() *OrganizationInvitationMutation {
+	return _c.mutation
+}
+
+// Save creates the OrganizationInvitation in the database.
+func (_c *OrganizationInvitationCreate) Save(ctx context.Context) (*OrganizationInvitation, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *OrganizationInvitationCreate) SaveX(ctx context.Context) *OrganizationInvitation {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *OrganizationInvitationCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *OrganizationInvitationCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *OrganizationInvitationCreate) defaults() {
+	if _, ok := _c.mutation.Role(); !ok {
+		v := organizationinvitation.DefaultRole
+		_c.mutation.SetRole(v)
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		v := organizationinvitation.DefaultStatus
+		_c.mutation.SetStatus(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := organizationinvitation.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *OrganizationInvitationCreate) check() error {
+	if _, ok := _c.mutation.OrganizationID(); !ok {
+		return &ValidationError{Name: "organization_id", err: errors.New(`ent: missing required field "OrganizationInvitation.organization_id"`)}
+	}
+	if _, ok := _c.mutation.Email(); !ok {
+		return &ValidationError{Name: "email", err: errors.New(`ent: missing required field "OrganizationInvitation.email"`)}
+	}
+	if v, ok := _c.mutation.Email(); ok {
+		if err := organizationinvitation.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "OrganizationInvitation.email": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Role(); !ok {
+		return &ValidationError{Name: "role", err: errors.New(`ent: missing required field "OrganizationInvitation.role"`)}
+	}
+	if v, ok := _c.mutation.Role(); ok {
+		if err := organizationinvitation.RoleValidator(v); err != nil {
+			return &ValidationError{Name: "role", err: fmt.Errorf(`ent: validator failed for field "OrganizationInvitation.role": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.TokenHash(); !ok {
+		return &ValidationError{Name: "token_hash", err: errors.New(`ent: missing required field "OrganizationInvitation.token_hash"`)}
+	}
+	if v, ok := _c.mutation.TokenHash(); ok {
+		if err := organizationinvitation.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "OrganizationInvitation.token_hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.InvitedBy(); !ok {
+		return &ValidationError{Name: "invited_by", err: errors.New(`ent: missing required field "OrganizationInvitation.invited_by"`)}
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "OrganizationInvitation.status"`)}
+	}
+	if v, ok := _c.mutation.Status(); ok {
+		if err := organizationinvitation.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "OrganizationInvitation.status": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "OrganizationInvitation.expires_at"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "OrganizationInvitation.created_at"`)}
+	}
+	if len(_c.mutation.OrganizationIDs()) == 0 {
+		return &ValidationError{Name: "organization", err: errors.New(`ent: missing required edge "OrganizationInvitation.organization"`)}
+	}
+	return nil
+}
+
+func (_c *OrganizationInvitationCreate) sqlSave(ctx context.Context) (*OrganizationInvitation, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int64(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *OrganizationInvitationCreate) createSpec() (*OrganizationInvitation, *sqlgraph.CreateSpec) {
+	var (
+		_node = &OrganizationInvitation{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(organizationinvitation.Table, sqlgraph.NewFieldSpec(organizationinvitation.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.Email(); ok {
+		_spec.SetField(organizationinvitation.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := _c.mutation.Role(); ok {
+		_spec.SetField(organizationinvitation.FieldRole, field.TypeString, value)
+		_node.Role = value
+	}
+	if value, ok := _c.mutation.TokenHash(); ok {
+		_spec.SetField(organizationinvitation.FieldTokenHash, field.TypeString, value)
+		_node.TokenHash = value
+	}
+	if value, ok := _c.mutation.InvitedBy(); ok {
+		_spec.SetField(organizationinvitation.FieldInvitedBy, field.TypeInt64, value)
+		_node.InvitedBy = value
+	}
+	if value, ok := _c.mutation.Status(); ok {
+		_spec.SetField(organizationinvitation.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(organizationinvitation.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := _c.mutation.AcceptedUserID(); ok {
+		_spec.SetField(organizationinvitation.FieldAcceptedUserID, field.TypeInt64, value)
+		_node.AcceptedUserID = &value
+	}
+	if value, ok := _c.mutation.AcceptedAt(); ok {
+		_spec.SetField(organizationinvitation.FieldAcceptedAt, field.TypeTime, value)
+		_node.AcceptedAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(organizationinvitation.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.OrganizationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   organizationinvitation.OrganizationTable,
+			Columns: []string{organizationinvitation.OrganizationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.OrganizationID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.OrganizationInvitation.Create().
+//		SetOrganizationID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.OrganizationInvitationUpsert) {
+//			SetOrganizationID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *OrganizationInvitationCreate) OnConflict(opts ...sql.ConflictOption) *OrganizationInvitationUpsertOne {
+	_c.conflict = opts
+	return &OrganizationInvitationUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.OrganizationInvitation.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *OrganizationInvitationCreate) OnConflictColumns(columns ...string) *OrganizationInvitationUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &OrganizationInvitationUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// OrganizationInvitationUpsertOne is the builder for "upsert"-ing
+	//  one OrganizationInvitation node.
+	OrganizationInvitationUpsertOne struct {
+		create *OrganizationInvitationCreate
+	}
+
+	// OrganizationInvitationUpsert is the "OnConflict" setter.
+	OrganizationInvitationUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *OrganizationInvitationUpsert) SetOrganizationID(v int64) *OrganizationInvitationUpsert {
+	u.Set(organizationinvitation.FieldOrganizationID, v)
+	return u
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsert) UpdateOrganizationID() *OrganizationInvitationUpsert {
+	u.SetExcluded(organizationinvitation.FieldOrganizationID)
+	return u
+}
+
+// SetEmail sets the "email" field.
+func (u *OrganizationInvitationUpsert) SetEmail(v string) *OrganizationInvitationUpsert {
+	u.Set(organizationinvitation.FieldEmail, v)
+	return u
+}
+
+// UpdateEmail sets the "email" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsert) UpdateEmail() *OrganizationInvitationUpsert {
+	u.SetExcluded(organizationinvitation.FieldEmail)
+	return u
+}
+
+// SetRole sets the "role" field.
+func (u *OrganizationInvitationUpsert) SetRole(v string) *OrganizationInvitationUpsert {
+	u.Set(organizationinvitation.FieldRole, v)
+	return u
+}
+
+// UpdateRole sets the "role" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsert) UpdateRole() *OrganizationInvitationUpsert {
+	u.SetExcluded(organizationinvitation.FieldRole)
+	return u
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (u *OrganizationInvitationUpsert) SetTokenHash(v string) *OrganizationInvitationUpsert {
+	u.Set(organizationinvitation.FieldTokenHash, v)
+	return u
+}
+
+// UpdateTokenHash sets the "token_hash" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsert) UpdateTokenHash() *OrganizationInvitationUpsert {
+	u.SetExcluded(organizationinvitation.FieldTokenHash)
+	return u
+}
+
+// SetInvitedBy sets the "invited_by" field.
+func (u *OrganizationInvitationUpsert) SetInvitedBy(v int64) *OrganizationInvitationUpsert {
+	u.Set(organizationinvitation.FieldInvitedBy, v)
+	return u
+}
+
+// UpdateInvitedBy sets the "invited_by" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsert) UpdateInvitedBy() *OrganizationInvitationUpsert {
+	u.SetExcluded(organizationinvitation.FieldInvitedBy)
+	return u
+}
+
+// AddInvitedBy adds v to the "invited_by" field.
+func (u *OrganizationInvitationUpsert) AddInvitedBy(v int64) *OrganizationInvitationUpsert {
+	u.Add(organizationinvitation.FieldInvitedBy, v)
+	return u
+}
+
+// SetStatus sets the "status" field.
+func (u *OrganizationInvitationUpsert) SetStatus(v string) *OrganizationInvitationUpsert {
+	u.Set(organizationinvitation.FieldStatus, v)
+	return u
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsert) UpdateStatus() *OrganizationInvitationUpsert {
+	u.SetExcluded(organizationinvitation.FieldStatus)
+	return u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *OrganizationInvitationUpsert) SetExpiresAt(v time.Time) *OrganizationInvitationUpsert {
+	u.Set(organizationinvitation.FieldExpiresAt, v)
+	return u
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsert) UpdateExpiresAt() *OrganizationInvitationUpsert {
+	u.SetExcluded(organizationinvitation.FieldExpiresAt)
+	return u
+}
+
+// SetAcceptedUserID sets the "accepted_user_id" field.
+func (u *OrganizationInvitationUpsert) SetAcceptedUserID(v int64) *OrganizationInvitationUpsert {
+	u.Set(organizationinvitation.FieldAcceptedUserID, v)
+	return u
+}
+
+// UpdateAcceptedUserID sets the "accepted_user_id" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsert) UpdateAcceptedUserID() *OrganizationInvitationUpsert {
+	u.SetExcluded(organizationinvitation.FieldAcceptedUserID)
+	return u
+}
+
+// AddAcceptedUserID adds v to the "accepted_user_id" field.
+func (u *OrganizationInvitationUpsert) AddAcceptedUserID(v int64) *OrganizationInvitationUpsert {
+	u.Add(organizationinvitation.FieldAcceptedUserID, v)
+	return u
+}
+
+// ClearAcceptedUserID clears the value of the "accepted_user_id" field.
+func (u *OrganizationInvitationUpsert) ClearAcceptedUserID() *OrganizationInvitationUpsert {
+	u.SetNull(organizationinvitation.FieldAcceptedUserID)
+	return u
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (u *OrganizationInvitationUpsert) SetAcceptedAt(v time.Time) *OrganizationInvitationUpsert {
+	u.Set(organizationinvitation.FieldAcceptedAt, v)
+	return u
+}
+
+// UpdateAcceptedAt sets the "accepted_at" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsert) UpdateAcceptedAt() *OrganizationInvitationUpsert {
+	u.SetExcluded(organizationinvitation.FieldAcceptedAt)
+	return u
+}
+
+// ClearAcceptedAt clears the value of the "accepted_at" field.
+func (u *OrganizationInvitationUpsert) ClearAcceptedAt() *OrganizationInvitationUpsert {
+	u.SetNull(organizationinvitation.FieldAcceptedAt)
+	return u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *OrganizationInvitationUpsert) SetCreatedAt(v time.Time) *OrganizationInvitationUpsert {
+	u.Set(organizationinvitation.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsert) UpdateCreatedAt() *OrganizationInvitationUpsert {
+	u.SetExcluded(organizationinvitation.FieldCreatedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.OrganizationInvitation.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *OrganizationInvitationUpsertOne) UpdateNewValues() *OrganizationInvitationUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.OrganizationInvitation.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *OrganizationInvitationUpsertOne) Ignore() *OrganizationInvitationUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *OrganizationInvitationUpsertOne) DoNothing() *OrganizationInvitationUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the OrganizationInvitationCreate.OnConflict
+// documentation for more info.
+func (u *OrganizationInvitationUpsertOne) Update(set func(*OrganizationInvitationUpsert)) *OrganizationInvitationUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&OrganizationInvitationUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *OrganizationInvitationUpsertOne) SetOrganizationID(v int64) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetOrganizationID(v)
+	})
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertOne) UpdateOrganizationID() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateOrganizationID()
+	})
+}
+
+// SetEmail sets the "email" field.
+func (u *OrganizationInvitationUpsertOne) SetEmail(v string) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetEmail(v)
+	})
+}
+
+// UpdateEmail sets the "email" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertOne) UpdateEmail() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateEmail()
+	})
+}
+
+// SetRole sets the "role" field.
+func (u *OrganizationInvitationUpsertOne) SetRole(v string) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetRole(v)
+	})
+}
+
+// UpdateRole sets the "role" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertOne) UpdateRole() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateRole()
+	})
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (u *OrganizationInvitationUpsertOne) SetTokenHash(v string) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetTokenHash(v)
+	})
+}
+
+// UpdateTokenHash sets the "token_hash" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertOne) UpdateTokenHash() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateTokenHash()
+	})
+}
+
+// SetInvitedBy sets the "invited_by" field.
+func (u *OrganizationInvitationUpsertOne) SetInvitedBy(v int64) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetInvitedBy(v)
+	})
+}
+
+// AddInvitedBy adds v to the "invited_by" field.
+func (u *OrganizationInvitationUpsertOne) AddInvitedBy(v int64) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.AddInvitedBy(v)
+	})
+}
+
+// UpdateInvitedBy sets the "invited_by" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertOne) UpdateInvitedBy() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateInvitedBy()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *OrganizationInvitationUpsertOne) SetStatus(v string) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertOne) UpdateStatus() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *OrganizationInvitationUpsertOne) SetExpiresAt(v time.Time) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertOne) UpdateExpiresAt() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// SetAcceptedUserID sets the "accepted_user_id" field.
+func (u *OrganizationInvitationUpsertOne) SetAcceptedUserID(v int64) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetAcceptedUserID(v)
+	})
+}
+
+// AddAcceptedUserID adds v to the "accepted_user_id" field.
+func (u *OrganizationInvitationUpsertOne) AddAcceptedUserID(v int64) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.AddAcceptedUserID(v)
+	})
+}
+
+// UpdateAcceptedUserID sets the "accepted_user_id" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertOne) UpdateAcceptedUserID() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateAcceptedUserID()
+	})
+}
+
+// ClearAcceptedUserID clears the value of the "accepted_user_id" field.
+func (u *OrganizationInvitationUpsertOne) ClearAcceptedUserID() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.ClearAcceptedUserID()
+	})
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (u *OrganizationInvitationUpsertOne) SetAcceptedAt(v time.Time) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetAcceptedAt(v)
+	})
+}
+
+// UpdateAcceptedAt sets the "accepted_at" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertOne) UpdateAcceptedAt() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateAcceptedAt()
+	})
+}
+
+// ClearAcceptedAt clears the value of the "accepted_at" field.
+func (u *OrganizationInvitationUpsertOne) ClearAcceptedAt() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.ClearAcceptedAt()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *OrganizationInvitationUpsertOne) SetCreatedAt(v time.Time) *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertOne) UpdateCreatedAt() *OrganizationInvitationUpsertOne {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *OrganizationInvitationUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for OrganizationInvitationCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *OrganizationInvitationUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *OrganizationInvitationUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *OrganizationInvitationUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// OrganizationInvitationCreateBulk is the builder for creating many OrganizationInvitation entities in bulk.
+type OrganizationInvitationCreateBulk struct {
+	config
+	err      error
+	builders []*OrganizationInvitationCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the OrganizationInvitation entities in the database.
+func (_c *OrganizationInvitationCreateBulk) Save(ctx context.Context) ([]*OrganizationInvitation, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*OrganizationInvitation, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*OrganizationInvitationMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *OrganizationInvitationCreateBulk) SaveX(ctx context.Context) []*OrganizationInvitation {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *OrganizationInvitationCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *OrganizationInvitationCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.OrganizationInvitation.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.OrganizationInvitationUpsert) {
+//			SetOrganizationID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *OrganizationInvitationCreateBulk) OnConflict(opts ...sql.ConflictOption) *OrganizationInvitationUpsertBulk {
+	_c.conflict = opts
+	return &OrganizationInvitationUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.OrganizationInvitation.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *OrganizationInvitationCreateBulk) OnConflictColumns(columns ...string) *OrganizationInvitationUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &OrganizationInvitationUpsertBulk{
+		create: _c,
+	}
+}
+
+// OrganizationInvitationUpsertBulk is the builder for "upsert"-ing
+// a bulk of OrganizationInvitation nodes.
+type OrganizationInvitationUpsertBulk struct {
+	create *OrganizationInvitationCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.OrganizationInvitation.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *OrganizationInvitationUpsertBulk) UpdateNewValues() *OrganizationInvitationUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.OrganizationInvitation.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *OrganizationInvitationUpsertBulk) Ignore() *OrganizationInvitationUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *OrganizationInvitationUpsertBulk) DoNothing() *OrganizationInvitationUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the OrganizationInvitationCreateBulk.OnConflict
+// documentation for more info.
+func (u *OrganizationInvitationUpsertBulk) Update(set func(*OrganizationInvitationUpsert)) *OrganizationInvitationUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&OrganizationInvitationUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *OrganizationInvitationUpsertBulk) SetOrganizationID(v int64) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetOrganizationID(v)
+	})
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertBulk) UpdateOrganizationID() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateOrganizationID()
+	})
+}
+
+// SetEmail sets the "email" field.
+func (u *OrganizationInvitationUpsertBulk) SetEmail(v string) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetEmail(v)
+	})
+}
+
+// UpdateEmail sets the "email" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertBulk) UpdateEmail() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateEmail()
+	})
+}
+
+// SetRole sets the "role" field.
+func (u *OrganizationInvitationUpsertBulk) SetRole(v string) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetRole(v)
+	})
+}
+
+// UpdateRole sets the "role" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertBulk) UpdateRole() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateRole()
+	})
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (u *OrganizationInvitationUpsertBulk) SetTokenHash(v string) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetTokenHash(v)
+	})
+}
+
+// UpdateTokenHash sets the "token_hash" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertBulk) UpdateTokenHash() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateTokenHash()
+	})
+}
+
+// SetInvitedBy sets the "invited_by" field.
+func (u *OrganizationInvitationUpsertBulk) SetInvitedBy(v int64) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetInvitedBy(v)
+	})
+}
+
+// AddInvitedBy adds v to the "invited_by" field.
+func (u *OrganizationInvitationUpsertBulk) AddInvitedBy(v int64) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.AddInvitedBy(v)
+	})
+}
+
+// UpdateInvitedBy sets the "invited_by" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertBulk) UpdateInvitedBy() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateInvitedBy()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *OrganizationInvitationUpsertBulk) SetStatus(v string) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertBulk) UpdateStatus() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *OrganizationInvitationUpsertBulk) SetExpiresAt(v time.Time) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertBulk) UpdateExpiresAt() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// SetAcceptedUserID sets the "accepted_user_id" field.
+func (u *OrganizationInvitationUpsertBulk) SetAcceptedUserID(v int64) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetAcceptedUserID(v)
+	})
+}
+
+// AddAcceptedUserID adds v to the "accepted_user_id" field.
+func (u *OrganizationInvitationUpsertBulk) AddAcceptedUserID(v int64) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.AddAcceptedUserID(v)
+	})
+}
+
+// UpdateAcceptedUserID sets the "accepted_user_id" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertBulk) UpdateAcceptedUserID() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateAcceptedUserID()
+	})
+}
+
+// ClearAcceptedUserID clears the value of the "accepted_user_id" field.
+func (u *OrganizationInvitationUpsertBulk) ClearAcceptedUserID() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.ClearAcceptedUserID()
+	})
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (u *OrganizationInvitationUpsertBulk) SetAcceptedAt(v time.Time) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetAcceptedAt(v)
+	})
+}
+
+// UpdateAcceptedAt sets the "accepted_at" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertBulk) UpdateAcceptedAt() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateAcceptedAt()
+	})
+}
+
+// ClearAcceptedAt clears the value of the "accepted_at" field.
+func (u *OrganizationInvitationUpsertBulk) ClearAcceptedAt() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.ClearAcceptedAt()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *OrganizationInvitationUpsertBulk) SetCreatedAt(v time.Time) *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *OrganizationInvitationUpsertBulk) UpdateCreatedAt() *OrganizationInvitationUpsertBulk {
+	return u.Update(func(s *OrganizationInvitationUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *OrganizationInvitationUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the OrganizationInvitationCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for OrganizationInvitationCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *OrganizationInvitationUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
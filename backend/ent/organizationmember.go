@@ -0,0 +1,239 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
+	"github.com/Wei-Shaw/sub2api/ent/organizationmember"
+	"github.com/Wei-Shaw/sub2api/ent/user"
+)
+
+// OrganizationMember is the model entity for the OrganizationMember schema.
+type OrganizationMember struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID int64 `json:"organization_id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID int64 `json:"user_id,omitempty"`
+	// Role holds the value of the "role" field.
+	Role string `json:"role,omitempty"`
+	// MonthlyLimitUsd holds the value of the "monthly_limit_usd" field.
+	MonthlyLimitUsd *float64 `json:"monthly_limit_usd,omitempty"`
+	// MonthlyUsageUsd holds the value of the "monthly_usage_usd" field.
+	MonthlyUsageUsd float64 `json:"monthly_usage_usd,omitempty"`
+	// MonthlyWindowStart holds the value of the "monthly_window_start" field.
+	MonthlyWindowStart *time.Time `json:"monthly_window_start,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the OrganizationMemberQuery when eager-loading is set.
+	Edges        OrganizationMemberEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// OrganizationMemberEdges holds the relations/edges for other nodes in the graph.
+type OrganizationMemberEdges struct {
+	// Organization holds the value of the organization edge.
+	Organization *Organization `json:"organization,omitempty"`
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// OrganizationOrErr returns the Organization value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e OrganizationMemberEdges) OrganizationOrErr() (*Organization, error) {
+	if e.Organization != nil {
+		return e.Organization, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: organization.Label}
+	}
+	return nil, &NotLoadedError{edge: "organization"}
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e OrganizationMemberEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*OrganizationMember) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case organizationmember.FieldMonthlyLimitUsd, organizationmember.FieldMonthlyUsageUsd:
+			values[i] = new(sql.NullFloat64)
+		case organizationmember.FieldID, organizationmember.FieldOrganizationID, organizationmember.FieldUserID:
+			values[i] = new(sql.NullInt64)
+		case organizationmember.FieldRole:
+			values[i] = new(sql.NullString)
+		case organizationmember.FieldCreatedAt, organizationmember.FieldUpdatedAt, organizationmember.FieldMonthlyWindowStart:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the OrganizationMember fields.
+func (_m *OrganizationMember) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case organizationmember.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int64(value.Int64)
+		case organizationmember.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case organizationmember.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		case organizationmember.FieldOrganizationID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[i])
+			} else if value.Valid {
+				_m.OrganizationID = value.Int64
+			}
+		case organizationmember.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				_m.UserID = value.Int64
+			}
+		case organizationmember.FieldRole:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field role", values[i])
+			} else if value.Valid {
+				_m.Role = value.String
+			}
+		case organizationmember.FieldMonthlyLimitUsd:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field monthly_limit_usd", values[i])
+			} else if value.Valid {
+				_m.MonthlyLimitUsd = new(float64)
+				*_m.MonthlyLimitUsd = value.Float64
+			}
+		case organizationmember.FieldMonthlyUsageUsd:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field monthly_usage_usd", values[i])
+			} else if value.Valid {
+				_m.MonthlyUsageUsd = value.Float64
+			}
+		case organizationmember.FieldMonthlyWindowStart:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field monthly_window_start", values[i])
+			} else if value.Valid {
+				_m.MonthlyWindowStart = new(time.Time)
+				*_m.MonthlyWindowStart = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the OrganizationMember.
+// This includes values selected through modifiers, order, etc.
+func (_m *OrganizationMember) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryOrganization queries the "organization" edge of the OrganizationMember entity.
+func (_m *OrganizationMember) QueryOrganization() *OrganizationQuery {
+	return NewOrganizationMemberClient(_m.config).QueryOrganization(_m)
+}
+
+// QueryUser queries the "user" edge of the OrganizationMember entity.
+func (_m *OrganizationMember) QueryUser() *UserQuery {
+	return NewOrganizationMemberClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this OrganizationMember.
+// Note that you need to call OrganizationMember.Unwrap() before calling this method if this OrganizationMember
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *OrganizationMember) Update() *OrganizationMemberUpdateOne {
+	return NewOrganizationMemberClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the OrganizationMember entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *OrganizationMember) Unwrap() *OrganizationMember {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: OrganizationMember is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *OrganizationMember) String() string {
+	var builder strings.Builder
+	builder.WriteString("OrganizationMember(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("organization_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OrganizationID))
+	builder.WriteString(", ")
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("role=")
+	builder.WriteString(_m.Role)
+	builder.WriteString(", ")
+	if v := _m.MonthlyLimitUsd; v != nil {
+		builder.WriteString("monthly_limit_usd=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("monthly_usage_usd=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MonthlyUsageUsd))
+	builder.WriteString(", ")
+	if v := _m.MonthlyWindowStart; v != nil {
+		builder.WriteString("monthly_window_start=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// OrganizationMembers is a parsable slice of OrganizationMember.
+type OrganizationMembers []*OrganizationMember
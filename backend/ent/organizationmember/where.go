@@ -0,0 +1,482 @@
+// Code generated by ent, DO NOT EDIT.
+
+package organizationmember
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLTE(FieldID, id))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// OrganizationID applies equality check predicate on the "organization_id" field. It's identical to OrganizationIDEQ.
+func OrganizationID(v int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldOrganizationID, v))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldUserID, v))
+}
+
+// Role applies equality check predicate on the "role" field. It's identical to RoleEQ.
+func Role(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldRole, v))
+}
+
+// MonthlyLimitUsd applies equality check predicate on the "monthly_limit_usd" field. It's identical to MonthlyLimitUsdEQ.
+func MonthlyLimitUsd(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldMonthlyLimitUsd, v))
+}
+
+// MonthlyUsageUsd applies equality check predicate on the "monthly_usage_usd" field. It's identical to MonthlyUsageUsdEQ.
+func MonthlyUsageUsd(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldMonthlyUsageUsd, v))
+}
+
+// MonthlyWindowStart applies equality check predicate on the "monthly_window_start" field. It's identical to MonthlyWindowStartEQ.
+func MonthlyWindowStart(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldMonthlyWindowStart, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// OrganizationIDEQ applies the EQ predicate on the "organization_id" field.
+func OrganizationIDEQ(v int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDNEQ applies the NEQ predicate on the "organization_id" field.
+func OrganizationIDNEQ(v int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDIn applies the In predicate on the "organization_id" field.
+func OrganizationIDIn(vs ...int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIn(FieldOrganizationID, vs...))
+}
+
+// OrganizationIDNotIn applies the NotIn predicate on the "organization_id" field.
+func OrganizationIDNotIn(vs ...int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotIn(FieldOrganizationID, vs...))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...int64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// RoleEQ applies the EQ predicate on the "role" field.
+func RoleEQ(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldRole, v))
+}
+
+// RoleNEQ applies the NEQ predicate on the "role" field.
+func RoleNEQ(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNEQ(FieldRole, v))
+}
+
+// RoleIn applies the In predicate on the "role" field.
+func RoleIn(vs ...string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIn(FieldRole, vs...))
+}
+
+// RoleNotIn applies the NotIn predicate on the "role" field.
+func RoleNotIn(vs ...string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotIn(FieldRole, vs...))
+}
+
+// RoleGT applies the GT predicate on the "role" field.
+func RoleGT(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGT(FieldRole, v))
+}
+
+// RoleGTE applies the GTE predicate on the "role" field.
+func RoleGTE(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGTE(FieldRole, v))
+}
+
+// RoleLT applies the LT predicate on the "role" field.
+func RoleLT(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLT(FieldRole, v))
+}
+
+// RoleLTE applies the LTE predicate on the "role" field.
+func RoleLTE(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLTE(FieldRole, v))
+}
+
+// RoleContains applies the Contains predicate on the "role" field.
+func RoleContains(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldContains(FieldRole, v))
+}
+
+// RoleHasPrefix applies the HasPrefix predicate on the "role" field.
+func RoleHasPrefix(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldHasPrefix(FieldRole, v))
+}
+
+// RoleHasSuffix applies the HasSuffix predicate on the "role" field.
+func RoleHasSuffix(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldHasSuffix(FieldRole, v))
+}
+
+// RoleEqualFold applies the EqualFold predicate on the "role" field.
+func RoleEqualFold(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEqualFold(FieldRole, v))
+}
+
+// RoleContainsFold applies the ContainsFold predicate on the "role" field.
+func RoleContainsFold(v string) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldContainsFold(FieldRole, v))
+}
+
+// MonthlyLimitUsdEQ applies the EQ predicate on the "monthly_limit_usd" field.
+func MonthlyLimitUsdEQ(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldMonthlyLimitUsd, v))
+}
+
+// MonthlyLimitUsdNEQ applies the NEQ predicate on the "monthly_limit_usd" field.
+func MonthlyLimitUsdNEQ(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNEQ(FieldMonthlyLimitUsd, v))
+}
+
+// MonthlyLimitUsdIn applies the In predicate on the "monthly_limit_usd" field.
+func MonthlyLimitUsdIn(vs ...float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIn(FieldMonthlyLimitUsd, vs...))
+}
+
+// MonthlyLimitUsdNotIn applies the NotIn predicate on the "monthly_limit_usd" field.
+func MonthlyLimitUsdNotIn(vs ...float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotIn(FieldMonthlyLimitUsd, vs...))
+}
+
+// MonthlyLimitUsdGT applies the GT predicate on the "monthly_limit_usd" field.
+func MonthlyLimitUsdGT(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGT(FieldMonthlyLimitUsd, v))
+}
+
+// MonthlyLimitUsdGTE applies the GTE predicate on the "monthly_limit_usd" field.
+func MonthlyLimitUsdGTE(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGTE(FieldMonthlyLimitUsd, v))
+}
+
+// MonthlyLimitUsdLT applies the LT predicate on the "monthly_limit_usd" field.
+func MonthlyLimitUsdLT(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLT(FieldMonthlyLimitUsd, v))
+}
+
+// MonthlyLimitUsdLTE applies the LTE predicate on the "monthly_limit_usd" field.
+func MonthlyLimitUsdLTE(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLTE(FieldMonthlyLimitUsd, v))
+}
+
+// MonthlyLimitUsdIsNil applies the IsNil predicate on the "monthly_limit_usd" field.
+func MonthlyLimitUsdIsNil() predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIsNull(FieldMonthlyLimitUsd))
+}
+
+// MonthlyLimitUsdNotNil applies the NotNil predicate on the "monthly_limit_usd" field.
+func MonthlyLimitUsdNotNil() predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotNull(FieldMonthlyLimitUsd))
+}
+
+// MonthlyUsageUsdEQ applies the EQ predicate on the "monthly_usage_usd" field.
+func MonthlyUsageUsdEQ(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldMonthlyUsageUsd, v))
+}
+
+// MonthlyUsageUsdNEQ applies the NEQ predicate on the "monthly_usage_usd" field.
+func MonthlyUsageUsdNEQ(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNEQ(FieldMonthlyUsageUsd, v))
+}
+
+// MonthlyUsageUsdIn applies the In predicate on the "monthly_usage_usd" field.
+func MonthlyUsageUsdIn(vs ...float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIn(FieldMonthlyUsageUsd, vs...))
+}
+
+// MonthlyUsageUsdNotIn applies the NotIn predicate on the "monthly_usage_usd" field.
+func MonthlyUsageUsdNotIn(vs ...float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotIn(FieldMonthlyUsageUsd, vs...))
+}
+
+// MonthlyUsageUsdGT applies the GT predicate on the "monthly_usage_usd" field.
+func MonthlyUsageUsdGT(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGT(FieldMonthlyUsageUsd, v))
+}
+
+// MonthlyUsageUsdGTE applies the GTE predicate on the "monthly_usage_usd" field.
+func MonthlyUsageUsdGTE(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGTE(FieldMonthlyUsageUsd, v))
+}
+
+// MonthlyUsageUsdLT applies the LT predicate on the "monthly_usage_usd" field.
+func MonthlyUsageUsdLT(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLT(FieldMonthlyUsageUsd, v))
+}
+
+// MonthlyUsageUsdLTE applies the LTE predicate on the "monthly_usage_usd" field.
+func MonthlyUsageUsdLTE(v float64) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLTE(FieldMonthlyUsageUsd, v))
+}
+
+// MonthlyWindowStartEQ applies the EQ predicate on the "monthly_window_start" field.
+func MonthlyWindowStartEQ(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldEQ(FieldMonthlyWindowStart, v))
+}
+
+// MonthlyWindowStartNEQ applies the NEQ predicate on the "monthly_window_start" field.
+func MonthlyWindowStartNEQ(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNEQ(FieldMonthlyWindowStart, v))
+}
+
+// MonthlyWindowStartIn applies the In predicate on the "monthly_window_start" field.
+func MonthlyWindowStartIn(vs ...time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIn(FieldMonthlyWindowStart, vs...))
+}
+
+// MonthlyWindowStartNotIn applies the NotIn predicate on the "monthly_window_start" field.
+func MonthlyWindowStartNotIn(vs ...time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotIn(FieldMonthlyWindowStart, vs...))
+}
+
+// MonthlyWindowStartGT applies the GT predicate on the "monthly_window_start" field.
+func MonthlyWindowStartGT(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGT(FieldMonthlyWindowStart, v))
+}
+
+// MonthlyWindowStartGTE applies the GTE predicate on the "monthly_window_start" field.
+func MonthlyWindowStartGTE(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldGTE(FieldMonthlyWindowStart, v))
+}
+
+// MonthlyWindowStartLT applies the LT predicate on the "monthly_window_start" field.
+func MonthlyWindowStartLT(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLT(FieldMonthlyWindowStart, v))
+}
+
+// MonthlyWindowStartLTE applies the LTE predicate on the "monthly_window_start" field.
+func MonthlyWindowStartLTE(v time.Time) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldLTE(FieldMonthlyWindowStart, v))
+}
+
+// MonthlyWindowStartIsNil applies the IsNil predicate on the "monthly_window_start" field.
+func MonthlyWindowStartIsNil() predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldIsNull(FieldMonthlyWindowStart))
+}
+
+// MonthlyWindowStartNotNil applies the NotNil predicate on the "monthly_window_start" field.
+func MonthlyWindowStartNotNil() predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.FieldNotNull(FieldMonthlyWindowStart))
+}
+
+// HasOrganization applies the HasEdge predicate on the "organization" edge.
+func HasOrganization() predicate.OrganizationMember {
+	return predicate.OrganizationMember(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, OrganizationTable, OrganizationColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOrganizationWith applies the HasEdge predicate on the "organization" edge with a given conditions (other predicates).
+func HasOrganizationWith(preds ...predicate.Organization) predicate.OrganizationMember {
+	return predicate.OrganizationMember(func(s *sql.Selector) {
+		step := newOrganizationStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasUser applies the HasEdge predicate on the "user" edge.
+func HasUser() predicate.OrganizationMember {
+	return predicate.OrganizationMember(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, UserTable, UserColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasUserWith applies the HasEdge predicate on the "user" edge with a given conditions (other predicates).
+func HasUserWith(preds ...predicate.User) predicate.OrganizationMember {
+	return predicate.OrganizationMember(func(s *sql.Selector) {
+		step := newUserStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.OrganizationMember) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.OrganizationMember) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.OrganizationMember) predicate.OrganizationMember {
+	return predicate.OrganizationMember(sql.NotPredicates(p))
+}
@@ -18,15 +18,30 @@ type AccountGroup func(*sql.Selector)
 // AdminActionLog is the predicate function for adminactionlog builders.
 type AdminActionLog func(*sql.Selector)
 
+// CommissionWithdrawal is the predicate function for commissionwithdrawal builders.
+type CommissionWithdrawal func(*sql.Selector)
+
 // Group is the predicate function for group builders.
 type Group func(*sql.Selector)
 
 // Invitation is the predicate function for invitation builders.
 type Invitation func(*sql.Selector)
 
+// InviteCommission is the predicate function for invitecommission builders.
+type InviteCommission func(*sql.Selector)
+
 // InviteLog is the predicate function for invitelog builders.
 type InviteLog func(*sql.Selector)
 
+// Organization is the predicate function for organization builders.
+type Organization func(*sql.Selector)
+
+// OrganizationInvitation is the predicate function for organizationinvitation builders.
+type OrganizationInvitation func(*sql.Selector)
+
+// OrganizationMember is the predicate function for organizationmember builders.
+type OrganizationMember func(*sql.Selector)
+
 // Plan is the predicate function for plan builders.
 type Plan func(*sql.Selector)
 
@@ -63,5 +78,11 @@ type UserAttributeDefinition func(*sql.Selector)
 // UserAttributeValue is the predicate function for userattributevalue builders.
 type UserAttributeValue func(*sql.Selector)
 
+// UserRecoveryCode is the predicate function for userrecoverycode builders.
+type UserRecoveryCode func(*sql.Selector)
+
 // UserSubscription is the predicate function for usersubscription builders.
 type UserSubscription func(*sql.Selector)
+
+// WebAuthnCredential is the predicate function for webauthncredential builders.
+type WebAuthnCredential func(*sql.Selector)
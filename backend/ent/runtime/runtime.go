@@ -9,9 +9,14 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/accountgroup"
 	"github.com/Wei-Shaw/sub2api/ent/adminactionlog"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
+	"github.com/Wei-Shaw/sub2api/ent/commissionwithdrawal"
 	"github.com/Wei-Shaw/sub2api/ent/group"
 	"github.com/Wei-Shaw/sub2api/ent/invitation"
+	"github.com/Wei-Shaw/sub2api/ent/invitecommission"
 	"github.com/Wei-Shaw/sub2api/ent/invitelog"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
+	"github.com/Wei-Shaw/sub2api/ent/organizationinvitation"
+	"github.com/Wei-Shaw/sub2api/ent/organizationmember"
 	"github.com/Wei-Shaw/sub2api/ent/plan"
 	"github.com/Wei-Shaw/sub2api/ent/promocode"
 	"github.com/Wei-Shaw/sub2api/ent/promocodeusage"
@@ -25,7 +30,9 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/userallowedgroup"
 	"github.com/Wei-Shaw/sub2api/ent/userattributedefinition"
 	"github.com/Wei-Shaw/sub2api/ent/userattributevalue"
+	"github.com/Wei-Shaw/sub2api/ent/userrecoverycode"
 	"github.com/Wei-Shaw/sub2api/ent/usersubscription"
+	"github.com/Wei-Shaw/sub2api/ent/webauthncredential"
 )
 
 // The init function reads all schema descriptors with runtime code
@@ -54,23 +61,19 @@ func init() {
 	// apikeyDescKey is the schema descriptor for key field.
 	apikeyDescKey := apikeyFields[1].Descriptor()
 	// apikey.KeyValidator is a validator for the "key" field. It is called by the builders before save.
-	apikey.KeyValidator = func() func(string) error {
-		validators := apikeyDescKey.Validators
-		fns := [...]func(string) error{
-			validators[0].(func(string) error),
-			validators[1].(func(string) error),
-		}
-		return func(key string) error {
-			for _, fn := range fns {
-				if err := fn(key); err != nil {
-					return err
-				}
-			}
-			return nil
-		}
-	}()
+	apikey.KeyValidator = apikeyDescKey.Validators[0].(func(string) error)
+	// apikeyDescKeyHash is the schema descriptor for key_hash field.
+	apikeyDescKeyHash := apikeyFields[2].Descriptor()
+	// apikey.KeyHashValidator is a validator for the "key_hash" field. It is called by the builders before save.
+	apikey.KeyHashValidator = apikeyDescKeyHash.Validators[0].(func(string) error)
+	// apikeyDescKeyPrefix is the schema descriptor for key_prefix field.
+	apikeyDescKeyPrefix := apikeyFields[3].Descriptor()
+	// apikey.DefaultKeyPrefix holds the default value on creation for the key_prefix field.
+	apikey.DefaultKeyPrefix = apikeyDescKeyPrefix.Default.(string)
+	// apikey.KeyPrefixValidator is a validator for the "key_prefix" field. It is called by the builders before save.
+	apikey.KeyPrefixValidator = apikeyDescKeyPrefix.Validators[0].(func(string) error)
 	// apikeyDescName is the schema descriptor for name field.
-	apikeyDescName := apikeyFields[2].Descriptor()
+	apikeyDescName := apikeyFields[4].Descriptor()
 	// apikey.NameValidator is a validator for the "name" field. It is called by the builders before save.
 	apikey.NameValidator = func() func(string) error {
 		validators := apikeyDescName.Validators
@@ -88,11 +91,15 @@ func init() {
 		}
 	}()
 	// apikeyDescStatus is the schema descriptor for status field.
-	apikeyDescStatus := apikeyFields[4].Descriptor()
+	apikeyDescStatus := apikeyFields[6].Descriptor()
 	// apikey.DefaultStatus holds the default value on creation for the status field.
 	apikey.DefaultStatus = apikeyDescStatus.Default.(string)
 	// apikey.StatusValidator is a validator for the "status" field. It is called by the builders before save.
 	apikey.StatusValidator = apikeyDescStatus.Validators[0].(func(string) error)
+	// apikeyDescDedicatedAccountsOnly is the schema descriptor for dedicated_accounts_only field.
+	apikeyDescDedicatedAccountsOnly := apikeyFields[10].Descriptor()
+	// apikey.DefaultDedicatedAccountsOnly holds the default value on creation for the dedicated_accounts_only field.
+	apikey.DefaultDedicatedAccountsOnly = apikeyDescDedicatedAccountsOnly.Default.(bool)
 	accountMixin := schema.Account{}.Mixin()
 	accountMixinHooks1 := accountMixin[1].Hooks()
 	account.Hooks[0] = accountMixinHooks1[0]
@@ -232,6 +239,30 @@ func init() {
 	adminactionlogDescCreatedAt := adminactionlogFields[7].Descriptor()
 	// adminactionlog.DefaultCreatedAt holds the default value on creation for the created_at field.
 	adminactionlog.DefaultCreatedAt = adminactionlogDescCreatedAt.Default.(func() time.Time)
+	commissionwithdrawalFields := schema.CommissionWithdrawal{}.Fields()
+	_ = commissionwithdrawalFields
+	// commissionwithdrawalDescMethod is the schema descriptor for method field.
+	commissionwithdrawalDescMethod := commissionwithdrawalFields[2].Descriptor()
+	// commissionwithdrawal.MethodValidator is a validator for the "method" field. It is called by the builders before save.
+	commissionwithdrawal.MethodValidator = commissionwithdrawalDescMethod.Validators[0].(func(string) error)
+	// commissionwithdrawalDescStatus is the schema descriptor for status field.
+	commissionwithdrawalDescStatus := commissionwithdrawalFields[3].Descriptor()
+	// commissionwithdrawal.DefaultStatus holds the default value on creation for the status field.
+	commissionwithdrawal.DefaultStatus = commissionwithdrawalDescStatus.Default.(string)
+	// commissionwithdrawal.StatusValidator is a validator for the "status" field. It is called by the builders before save.
+	commissionwithdrawal.StatusValidator = commissionwithdrawalDescStatus.Validators[0].(func(string) error)
+	// commissionwithdrawalDescAccountInfo is the schema descriptor for account_info field.
+	commissionwithdrawalDescAccountInfo := commissionwithdrawalFields[4].Descriptor()
+	// commissionwithdrawal.DefaultAccountInfo holds the default value on creation for the account_info field.
+	commissionwithdrawal.DefaultAccountInfo = commissionwithdrawalDescAccountInfo.Default.(string)
+	// commissionwithdrawalDescAdminNotes is the schema descriptor for admin_notes field.
+	commissionwithdrawalDescAdminNotes := commissionwithdrawalFields[6].Descriptor()
+	// commissionwithdrawal.DefaultAdminNotes holds the default value on creation for the admin_notes field.
+	commissionwithdrawal.DefaultAdminNotes = commissionwithdrawalDescAdminNotes.Default.(string)
+	// commissionwithdrawalDescCreatedAt is the schema descriptor for created_at field.
+	commissionwithdrawalDescCreatedAt := commissionwithdrawalFields[8].Descriptor()
+	// commissionwithdrawal.DefaultCreatedAt holds the default value on creation for the created_at field.
+	commissionwithdrawal.DefaultCreatedAt = commissionwithdrawalDescCreatedAt.Default.(func() time.Time)
 	groupMixin := schema.Group{}.Mixin()
 	groupMixinHooks1 := groupMixin[1].Hooks()
 	group.Hooks[0] = groupMixinHooks1[0]
@@ -307,6 +338,22 @@ func init() {
 	groupDescModelRoutingEnabled := groupFields[17].Descriptor()
 	// group.DefaultModelRoutingEnabled holds the default value on creation for the model_routing_enabled field.
 	group.DefaultModelRoutingEnabled = groupDescModelRoutingEnabled.Default.(bool)
+	// groupDescSchedulingStrategy is the schema descriptor for scheduling_strategy field.
+	groupDescSchedulingStrategy := groupFields[18].Descriptor()
+	// group.DefaultSchedulingStrategy holds the default value on creation for the scheduling_strategy field.
+	group.DefaultSchedulingStrategy = groupDescSchedulingStrategy.Default.(string)
+	// group.SchedulingStrategyValidator is a validator for the "scheduling_strategy" field. It is called by the builders before save.
+	group.SchedulingStrategyValidator = groupDescSchedulingStrategy.Validators[0].(func(string) error)
+	// groupDescOveragePolicy is the schema descriptor for overage_policy field.
+	groupDescOveragePolicy := groupFields[22].Descriptor()
+	// group.DefaultOveragePolicy holds the default value on creation for the overage_policy field.
+	group.DefaultOveragePolicy = groupDescOveragePolicy.Default.(string)
+	// group.OveragePolicyValidator is a validator for the "overage_policy" field. It is called by the builders before save.
+	group.OveragePolicyValidator = groupDescOveragePolicy.Validators[0].(func(string) error)
+	// groupDescOverageRateMultiplier is the schema descriptor for overage_rate_multiplier field.
+	groupDescOverageRateMultiplier := groupFields[23].Descriptor()
+	// group.DefaultOverageRateMultiplier holds the default value on creation for the overage_rate_multiplier field.
+	group.DefaultOverageRateMultiplier = groupDescOverageRateMultiplier.Default.(float64)
 	invitationFields := schema.Invitation{}.Fields()
 	_ = invitationFields
 	// invitationDescInviteCode is the schema descriptor for invite_code field.
@@ -323,10 +370,40 @@ func init() {
 	invitation.DefaultStatus = invitationDescStatus.Default.(string)
 	// invitation.StatusValidator is a validator for the "status" field. It is called by the builders before save.
 	invitation.StatusValidator = invitationDescStatus.Validators[0].(func(string) error)
+	// invitationDescCommissionStatus is the schema descriptor for commission_status field.
+	invitationDescCommissionStatus := invitationFields[7].Descriptor()
+	// invitation.DefaultCommissionStatus holds the default value on creation for the commission_status field.
+	invitation.DefaultCommissionStatus = invitationDescCommissionStatus.Default.(string)
+	// invitation.CommissionStatusValidator is a validator for the "commission_status" field. It is called by the builders before save.
+	invitation.CommissionStatusValidator = invitationDescCommissionStatus.Validators[0].(func(string) error)
+	// invitationDescCommissionBlockReason is the schema descriptor for commission_block_reason field.
+	invitationDescCommissionBlockReason := invitationFields[8].Descriptor()
+	// invitation.DefaultCommissionBlockReason holds the default value on creation for the commission_block_reason field.
+	invitation.DefaultCommissionBlockReason = invitationDescCommissionBlockReason.Default.(string)
+	// invitation.CommissionBlockReasonValidator is a validator for the "commission_block_reason" field. It is called by the builders before save.
+	invitation.CommissionBlockReasonValidator = invitationDescCommissionBlockReason.Validators[0].(func(string) error)
+	// invitationDescCommissionCursor is the schema descriptor for commission_cursor field.
+	invitationDescCommissionCursor := invitationFields[9].Descriptor()
+	// invitation.DefaultCommissionCursor holds the default value on creation for the commission_cursor field.
+	invitation.DefaultCommissionCursor = invitationDescCommissionCursor.Default.(int64)
+	// invitationDescCommissionTotal is the schema descriptor for commission_total field.
+	invitationDescCommissionTotal := invitationFields[10].Descriptor()
+	// invitation.DefaultCommissionTotal holds the default value on creation for the commission_total field.
+	invitation.DefaultCommissionTotal = invitationDescCommissionTotal.Default.(float64)
 	// invitationDescCreatedAt is the schema descriptor for created_at field.
-	invitationDescCreatedAt := invitationFields[7].Descriptor()
+	invitationDescCreatedAt := invitationFields[11].Descriptor()
 	// invitation.DefaultCreatedAt holds the default value on creation for the created_at field.
 	invitation.DefaultCreatedAt = invitationDescCreatedAt.Default.(func() time.Time)
+	invitecommissionFields := schema.InviteCommission{}.Fields()
+	_ = invitecommissionFields
+	// invitecommissionDescLogCount is the schema descriptor for log_count field.
+	invitecommissionDescLogCount := invitecommissionFields[8].Descriptor()
+	// invitecommission.DefaultLogCount holds the default value on creation for the log_count field.
+	invitecommission.DefaultLogCount = invitecommissionDescLogCount.Default.(int)
+	// invitecommissionDescCreatedAt is the schema descriptor for created_at field.
+	invitecommissionDescCreatedAt := invitecommissionFields[9].Descriptor()
+	// invitecommission.DefaultCreatedAt holds the default value on creation for the created_at field.
+	invitecommission.DefaultCreatedAt = invitecommissionDescCreatedAt.Default.(func() time.Time)
 	invitelogFields := schema.InviteLog{}.Fields()
 	_ = invitelogFields
 	// invitelogDescAction is the schema descriptor for action field.
@@ -341,6 +418,136 @@ func init() {
 	invitelogDescCreatedAt := invitelogFields[6].Descriptor()
 	// invitelog.DefaultCreatedAt holds the default value on creation for the created_at field.
 	invitelog.DefaultCreatedAt = invitelogDescCreatedAt.Default.(func() time.Time)
+	organizationMixin := schema.Organization{}.Mixin()
+	organizationMixinHooks1 := organizationMixin[1].Hooks()
+	organization.Hooks[0] = organizationMixinHooks1[0]
+	organizationMixinInters1 := organizationMixin[1].Interceptors()
+	organization.Interceptors[0] = organizationMixinInters1[0]
+	organizationMixinFields0 := organizationMixin[0].Fields()
+	_ = organizationMixinFields0
+	organizationFields := schema.Organization{}.Fields()
+	_ = organizationFields
+	// organizationDescCreatedAt is the schema descriptor for created_at field.
+	organizationDescCreatedAt := organizationMixinFields0[0].Descriptor()
+	// organization.DefaultCreatedAt holds the default value on creation for the created_at field.
+	organization.DefaultCreatedAt = organizationDescCreatedAt.Default.(func() time.Time)
+	// organizationDescUpdatedAt is the schema descriptor for updated_at field.
+	organizationDescUpdatedAt := organizationMixinFields0[1].Descriptor()
+	// organization.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	organization.DefaultUpdatedAt = organizationDescUpdatedAt.Default.(func() time.Time)
+	// organization.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	organization.UpdateDefaultUpdatedAt = organizationDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// organizationDescName is the schema descriptor for name field.
+	organizationDescName := organizationFields[0].Descriptor()
+	// organization.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	organization.NameValidator = func() func(string) error {
+		validators := organizationDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// organizationDescBalance is the schema descriptor for balance field.
+	organizationDescBalance := organizationFields[2].Descriptor()
+	// organization.DefaultBalance holds the default value on creation for the balance field.
+	organization.DefaultBalance = organizationDescBalance.Default.(float64)
+	// organizationDescStatus is the schema descriptor for status field.
+	organizationDescStatus := organizationFields[3].Descriptor()
+	// organization.DefaultStatus holds the default value on creation for the status field.
+	organization.DefaultStatus = organizationDescStatus.Default.(string)
+	// organization.StatusValidator is a validator for the "status" field. It is called by the builders before save.
+	organization.StatusValidator = organizationDescStatus.Validators[0].(func(string) error)
+	// organizationDescNotes is the schema descriptor for notes field.
+	organizationDescNotes := organizationFields[4].Descriptor()
+	// organization.DefaultNotes holds the default value on creation for the notes field.
+	organization.DefaultNotes = organizationDescNotes.Default.(string)
+	organizationinvitationFields := schema.OrganizationInvitation{}.Fields()
+	_ = organizationinvitationFields
+	// organizationinvitationDescEmail is the schema descriptor for email field.
+	organizationinvitationDescEmail := organizationinvitationFields[1].Descriptor()
+	// organizationinvitation.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	organizationinvitation.EmailValidator = func() func(string) error {
+		validators := organizationinvitationDescEmail.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(email string) error {
+			for _, fn := range fns {
+				if err := fn(email); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// organizationinvitationDescRole is the schema descriptor for role field.
+	organizationinvitationDescRole := organizationinvitationFields[2].Descriptor()
+	// organizationinvitation.DefaultRole holds the default value on creation for the role field.
+	organizationinvitation.DefaultRole = organizationinvitationDescRole.Default.(string)
+	// organizationinvitation.RoleValidator is a validator for the "role" field. It is called by the builders before save.
+	organizationinvitation.RoleValidator = organizationinvitationDescRole.Validators[0].(func(string) error)
+	// organizationinvitationDescTokenHash is the schema descriptor for token_hash field.
+	organizationinvitationDescTokenHash := organizationinvitationFields[3].Descriptor()
+	// organizationinvitation.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	organizationinvitation.TokenHashValidator = func() func(string) error {
+		validators := organizationinvitationDescTokenHash.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(token_hash string) error {
+			for _, fn := range fns {
+				if err := fn(token_hash); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// organizationinvitationDescStatus is the schema descriptor for status field.
+	organizationinvitationDescStatus := organizationinvitationFields[5].Descriptor()
+	// organizationinvitation.DefaultStatus holds the default value on creation for the status field.
+	organizationinvitation.DefaultStatus = organizationinvitationDescStatus.Default.(string)
+	// organizationinvitation.StatusValidator is a validator for the "status" field. It is called by the builders before save.
+	organizationinvitation.StatusValidator = organizationinvitationDescStatus.Validators[0].(func(string) error)
+	// organizationinvitationDescCreatedAt is the schema descriptor for created_at field.
+	organizationinvitationDescCreatedAt := organizationinvitationFields[9].Descriptor()
+	// organizationinvitation.DefaultCreatedAt holds the default value on creation for the created_at field.
+	organizationinvitation.DefaultCreatedAt = organizationinvitationDescCreatedAt.Default.(func() time.Time)
+	organizationmemberMixin := schema.OrganizationMember{}.Mixin()
+	organizationmemberMixinFields0 := organizationmemberMixin[0].Fields()
+	_ = organizationmemberMixinFields0
+	organizationmemberFields := schema.OrganizationMember{}.Fields()
+	_ = organizationmemberFields
+	// organizationmemberDescCreatedAt is the schema descriptor for created_at field.
+	organizationmemberDescCreatedAt := organizationmemberMixinFields0[0].Descriptor()
+	// organizationmember.DefaultCreatedAt holds the default value on creation for the created_at field.
+	organizationmember.DefaultCreatedAt = organizationmemberDescCreatedAt.Default.(func() time.Time)
+	// organizationmemberDescUpdatedAt is the schema descriptor for updated_at field.
+	organizationmemberDescUpdatedAt := organizationmemberMixinFields0[1].Descriptor()
+	// organizationmember.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	organizationmember.DefaultUpdatedAt = organizationmemberDescUpdatedAt.Default.(func() time.Time)
+	// organizationmember.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	organizationmember.UpdateDefaultUpdatedAt = organizationmemberDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// organizationmemberDescRole is the schema descriptor for role field.
+	organizationmemberDescRole := organizationmemberFields[2].Descriptor()
+	// organizationmember.DefaultRole holds the default value on creation for the role field.
+	organizationmember.DefaultRole = organizationmemberDescRole.Default.(string)
+	// organizationmember.RoleValidator is a validator for the "role" field. It is called by the builders before save.
+	organizationmember.RoleValidator = organizationmemberDescRole.Validators[0].(func(string) error)
+	// organizationmemberDescMonthlyUsageUsd is the schema descriptor for monthly_usage_usd field.
+	organizationmemberDescMonthlyUsageUsd := organizationmemberFields[4].Descriptor()
+	// organizationmember.DefaultMonthlyUsageUsd holds the default value on creation for the monthly_usage_usd field.
+	organizationmember.DefaultMonthlyUsageUsd = organizationmemberDescMonthlyUsageUsd.Default.(float64)
 	planFields := schema.Plan{}.Fields()
 	_ = planFields
 	// planDescTitle is the schema descriptor for title field.
@@ -755,16 +962,20 @@ func init() {
 	usagelogDescIPAddress := usagelogFields[26].Descriptor()
 	// usagelog.IPAddressValidator is a validator for the "ip_address" field. It is called by the builders before save.
 	usagelog.IPAddressValidator = usagelogDescIPAddress.Validators[0].(func(string) error)
+	// usagelogDescTrafficArm is the schema descriptor for traffic_arm field.
+	usagelogDescTrafficArm := usagelogFields[27].Descriptor()
+	// usagelog.TrafficArmValidator is a validator for the "traffic_arm" field. It is called by the builders before save.
+	usagelog.TrafficArmValidator = usagelogDescTrafficArm.Validators[0].(func(string) error)
 	// usagelogDescImageCount is the schema descriptor for image_count field.
-	usagelogDescImageCount := usagelogFields[27].Descriptor()
+	usagelogDescImageCount := usagelogFields[28].Descriptor()
 	// usagelog.DefaultImageCount holds the default value on creation for the image_count field.
 	usagelog.DefaultImageCount = usagelogDescImageCount.Default.(int)
 	// usagelogDescImageSize is the schema descriptor for image_size field.
-	usagelogDescImageSize := usagelogFields[28].Descriptor()
+	usagelogDescImageSize := usagelogFields[29].Descriptor()
 	// usagelog.ImageSizeValidator is a validator for the "image_size" field. It is called by the builders before save.
 	usagelog.ImageSizeValidator = usagelogDescImageSize.Validators[0].(func(string) error)
 	// usagelogDescCreatedAt is the schema descriptor for created_at field.
-	usagelogDescCreatedAt := usagelogFields[29].Descriptor()
+	usagelogDescCreatedAt := usagelogFields[30].Descriptor()
 	// usagelog.DefaultCreatedAt holds the default value on creation for the created_at field.
 	usagelog.DefaultCreatedAt = usagelogDescCreatedAt.Default.(func() time.Time)
 	userMixin := schema.User{}.Mixin()
@@ -860,6 +1071,22 @@ func init() {
 	userDescTotpEnabled := userFields[10].Descriptor()
 	// user.DefaultTotpEnabled holds the default value on creation for the totp_enabled field.
 	user.DefaultTotpEnabled = userDescTotpEnabled.Default.(bool)
+	// userDescDedicatedAccountsOnly is the schema descriptor for dedicated_accounts_only field.
+	userDescDedicatedAccountsOnly := userFields[13].Descriptor()
+	// user.DefaultDedicatedAccountsOnly holds the default value on creation for the dedicated_accounts_only field.
+	user.DefaultDedicatedAccountsOnly = userDescDedicatedAccountsOnly.Default.(bool)
+	// userDescCommissionBalance is the schema descriptor for commission_balance field.
+	userDescCommissionBalance := userFields[14].Descriptor()
+	// user.DefaultCommissionBalance holds the default value on creation for the commission_balance field.
+	user.DefaultCommissionBalance = userDescCommissionBalance.Default.(float64)
+	// userDescRegisterIP is the schema descriptor for register_ip field.
+	userDescRegisterIP := userFields[15].Descriptor()
+	// user.RegisterIPValidator is a validator for the "register_ip" field. It is called by the builders before save.
+	user.RegisterIPValidator = userDescRegisterIP.Validators[0].(func(string) error)
+	// userDescRegisterDevice is the schema descriptor for register_device field.
+	userDescRegisterDevice := userFields[16].Descriptor()
+	// user.RegisterDeviceValidator is a validator for the "register_device" field. It is called by the builders before save.
+	user.RegisterDeviceValidator = userDescRegisterDevice.Validators[0].(func(string) error)
 	userallowedgroupFields := schema.UserAllowedGroup{}.Fields()
 	_ = userallowedgroupFields
 	// userallowedgroupDescCreatedAt is the schema descriptor for created_at field.
@@ -988,6 +1215,30 @@ func init() {
 	userattributevalueDescValue := userattributevalueFields[2].Descriptor()
 	// userattributevalue.DefaultValue holds the default value on creation for the value field.
 	userattributevalue.DefaultValue = userattributevalueDescValue.Default.(string)
+	userrecoverycodeFields := schema.UserRecoveryCode{}.Fields()
+	_ = userrecoverycodeFields
+	// userrecoverycodeDescCodeHash is the schema descriptor for code_hash field.
+	userrecoverycodeDescCodeHash := userrecoverycodeFields[1].Descriptor()
+	// userrecoverycode.CodeHashValidator is a validator for the "code_hash" field. It is called by the builders before save.
+	userrecoverycode.CodeHashValidator = func() func(string) error {
+		validators := userrecoverycodeDescCodeHash.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(code_hash string) error {
+			for _, fn := range fns {
+				if err := fn(code_hash); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// userrecoverycodeDescCreatedAt is the schema descriptor for created_at field.
+	userrecoverycodeDescCreatedAt := userrecoverycodeFields[3].Descriptor()
+	// userrecoverycode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	userrecoverycode.DefaultCreatedAt = userrecoverycodeDescCreatedAt.Default.(func() time.Time)
 	usersubscriptionMixin := schema.UserSubscription{}.Mixin()
 	usersubscriptionMixinHooks1 := usersubscriptionMixin[1].Hooks()
 	usersubscription.Hooks[0] = usersubscriptionMixinHooks1[0]
@@ -1029,6 +1280,79 @@ func init() {
 	usersubscriptionDescAssignedAt := usersubscriptionFields[12].Descriptor()
 	// usersubscription.DefaultAssignedAt holds the default value on creation for the assigned_at field.
 	usersubscription.DefaultAssignedAt = usersubscriptionDescAssignedAt.Default.(func() time.Time)
+	// usersubscriptionDescAutoRenew is the schema descriptor for auto_renew field.
+	usersubscriptionDescAutoRenew := usersubscriptionFields[14].Descriptor()
+	// usersubscription.DefaultAutoRenew holds the default value on creation for the auto_renew field.
+	usersubscription.DefaultAutoRenew = usersubscriptionDescAutoRenew.Default.(bool)
+	webauthncredentialMixin := schema.WebAuthnCredential{}.Mixin()
+	webauthncredentialMixinFields0 := webauthncredentialMixin[0].Fields()
+	_ = webauthncredentialMixinFields0
+	webauthncredentialFields := schema.WebAuthnCredential{}.Fields()
+	_ = webauthncredentialFields
+	// webauthncredentialDescCreatedAt is the schema descriptor for created_at field.
+	webauthncredentialDescCreatedAt := webauthncredentialMixinFields0[0].Descriptor()
+	// webauthncredential.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webauthncredential.DefaultCreatedAt = webauthncredentialDescCreatedAt.Default.(func() time.Time)
+	// webauthncredentialDescUpdatedAt is the schema descriptor for updated_at field.
+	webauthncredentialDescUpdatedAt := webauthncredentialMixinFields0[1].Descriptor()
+	// webauthncredential.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webauthncredential.DefaultUpdatedAt = webauthncredentialDescUpdatedAt.Default.(func() time.Time)
+	// webauthncredential.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webauthncredential.UpdateDefaultUpdatedAt = webauthncredentialDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// webauthncredentialDescName is the schema descriptor for name field.
+	webauthncredentialDescName := webauthncredentialFields[1].Descriptor()
+	// webauthncredential.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	webauthncredential.NameValidator = func() func(string) error {
+		validators := webauthncredentialDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// webauthncredentialDescCredentialID is the schema descriptor for credential_id field.
+	webauthncredentialDescCredentialID := webauthncredentialFields[2].Descriptor()
+	// webauthncredential.CredentialIDValidator is a validator for the "credential_id" field. It is called by the builders before save.
+	webauthncredential.CredentialIDValidator = func() func(string) error {
+		validators := webauthncredentialDescCredentialID.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(credential_id string) error {
+			for _, fn := range fns {
+				if err := fn(credential_id); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// webauthncredentialDescPublicKey is the schema descriptor for public_key field.
+	webauthncredentialDescPublicKey := webauthncredentialFields[3].Descriptor()
+	// webauthncredential.PublicKeyValidator is a validator for the "public_key" field. It is called by the builders before save.
+	webauthncredential.PublicKeyValidator = webauthncredentialDescPublicKey.Validators[0].(func([]byte) error)
+	// webauthncredentialDescSignCount is the schema descriptor for sign_count field.
+	webauthncredentialDescSignCount := webauthncredentialFields[4].Descriptor()
+	// webauthncredential.DefaultSignCount holds the default value on creation for the sign_count field.
+	webauthncredential.DefaultSignCount = webauthncredentialDescSignCount.Default.(int64)
+	// webauthncredentialDescAaguid is the schema descriptor for aaguid field.
+	webauthncredentialDescAaguid := webauthncredentialFields[5].Descriptor()
+	// webauthncredential.DefaultAaguid holds the default value on creation for the aaguid field.
+	webauthncredential.DefaultAaguid = webauthncredentialDescAaguid.Default.(string)
+	// webauthncredential.AaguidValidator is a validator for the "aaguid" field. It is called by the builders before save.
+	webauthncredential.AaguidValidator = webauthncredentialDescAaguid.Validators[0].(func(string) error)
+	// webauthncredentialDescUserVerified is the schema descriptor for user_verified field.
+	webauthncredentialDescUserVerified := webauthncredentialFields[7].Descriptor()
+	// webauthncredential.DefaultUserVerified holds the default value on creation for the user_verified field.
+	webauthncredential.DefaultUserVerified = webauthncredentialDescUserVerified.Default.(bool)
 }
 
 const (
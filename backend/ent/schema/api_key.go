@@ -33,10 +33,21 @@ func (APIKey) Mixin() []ent.Mixin {
 func (APIKey) Fields() []ent.Field {
 	return []ent.Field{
 		field.Int64("user_id"),
+		// key 为旧版明文存储，在线迁移完成后置空；新 Key 只保存哈希与展示前缀
 		field.String("key").
 			MaxLen(128).
-			NotEmpty().
+			Optional().
+			Nillable().
 			Unique(),
+		field.String("key_hash").
+			MaxLen(64).
+			Optional().
+			Nillable().
+			Unique().
+			Comment("HMAC-SHA256 of the key, hex encoded"),
+		field.String("key_prefix").
+			MaxLen(16).
+			Default(""),
 		field.String("name").
 			MaxLen(100).
 			NotEmpty(),
@@ -52,6 +63,15 @@ func (APIKey) Fields() []ent.Field {
 		field.JSON("ip_blacklist", []string{}).
 			Optional().
 			Comment("Blocked IPs/CIDRs"),
+		field.JSON("dedicated_account_ids", []int64{}).
+			Optional().
+			Comment("Dedicated account IDs; overrides the owner's dedicated accounts when non-empty"),
+		field.Bool("dedicated_accounts_only").
+			Default(false),
+		// 所属组织：非空时该 Key 的消费从组织余额/订阅扣除
+		field.Int64("organization_id").
+			Optional().
+			Nillable(),
 	}
 }
 
@@ -66,15 +86,20 @@ func (APIKey) Edges() []ent.Edge {
 			Ref("api_keys").
 			Field("group_id").
 			Unique(),
+		edge.From("organization", Organization.Type).
+			Ref("api_keys").
+			Field("organization_id").
+			Unique(),
 		edge.To("usage_logs", UsageLog.Type),
 	}
 }
 
 func (APIKey) Indexes() []ent.Index {
 	return []ent.Index{
-		// key 字段已在 Fields() 中声明 Unique()，无需重复索引
+		// key / key_hash 字段已在 Fields() 中声明 Unique()，无需重复索引
 		index.Fields("user_id"),
 		index.Fields("group_id"),
+		index.Fields("organization_id"),
 		index.Fields("status"),
 		index.Fields("deleted_at"),
 	}
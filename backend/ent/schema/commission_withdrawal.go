@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// CommissionWithdrawal holds the schema definition for the CommissionWithdrawal entity.
+// 佣金转出记录：转入账户余额（即时完成）或申请提现（需管理员审核）。
+type CommissionWithdrawal struct {
+	ent.Schema
+}
+
+func (CommissionWithdrawal) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "commission_withdrawals"},
+	}
+}
+
+func (CommissionWithdrawal) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("user_id"),
+		field.Float("amount").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}),
+		// balance: 转入账户余额；withdraw: 提现
+		field.String("method").
+			MaxLen(20),
+		field.String("status").
+			MaxLen(20).
+			Default(service.CommissionWithdrawalStatusPending),
+		// 收款信息（提现时由用户填写）
+		field.String("account_info").
+			SchemaType(map[string]string{dialect.Postgres: "text"}).
+			Default(""),
+		field.Int64("processed_by").
+			Optional().
+			Nillable(),
+		field.String("admin_notes").
+			SchemaType(map[string]string{dialect.Postgres: "text"}).
+			Default(""),
+		field.Time("processed_at").
+			Optional().
+			Nillable().
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+		field.Time("created_at").
+			Default(time.Now).
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+	}
+}
+
+func (CommissionWithdrawal) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+		index.Fields("status"),
+		index.Fields("created_at"),
+	}
+}
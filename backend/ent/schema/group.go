@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"encoding/json"
+
 	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
@@ -106,6 +108,63 @@ func (Group) Fields() []ent.Field {
 		field.Bool("model_routing_enabled").
 			Default(false).
 			Comment("是否启用模型路由配置"),
+
+		// 调度策略 (added by migration 048)
+		field.String("scheduling_strategy").
+			MaxLen(32).
+			Default("").
+			Comment("账号调度策略：空=默认(优先级>负载>LRU), least_loaded, weighted, quota_aware, cost_aware"),
+
+		// 内容策略 (added by migration 050)
+		field.JSON("content_policy", json.RawMessage{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("内容策略：黑名单、PII 检测、图片限制、外部审核 Webhook"),
+
+		// 请求改写规则 (added by migration 055)
+		field.JSON("rewrite_rules", json.RawMessage{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("请求改写规则：按平台/模型匹配，修改请求体字段、系统提示词与上游请求头"),
+
+		// 模型别名 / 虚拟模型 (added by migration 056)
+		field.JSON("model_aliases", json.RawMessage{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("面向用户的虚拟模型名：按顺序解析为 (平台, 模型) 目标列表并逐个故障转移"),
+
+		// 订阅超额策略 (added by migration 057)
+		field.String("overage_policy").
+			MaxLen(32).
+			Default("reject").
+			Comment("订阅额度用尽后的处理：reject=拒绝, balance=按超额倍率扣余额, fallback_group=降级到指定分组"),
+		field.Float("overage_rate_multiplier").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(10,4)"}).
+			Default(1.0).
+			Comment("balance 策略的计费倍率（替代订阅分组倍率）"),
+		field.Int64("overage_group_id").
+			Optional().
+			Nillable().
+			Comment("fallback_group 策略使用的按量计费分组 ID"),
+
+		// 订阅自助续费价格 (added by migration 058)
+		field.Float("subscription_price").
+			Optional().
+			Nillable().
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}).
+			Comment("每个 default_validity_days 周期的余额价格（USD），为空表示不支持用户自助续费/切换"),
+
+		// 订阅 token / 请求次数额度 (added by migration 059)
+		field.JSON("usage_quotas", json.RawMessage{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("订阅窗口内的 token / 请求次数额度，可按模型限定，与 USD 限额同时生效"),
+
+		// 灰度 / A-B 分流 (added by migration 060)
+		field.JSON("traffic_split", json.RawMessage{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("按会话稳定分配的加权实验组：指定账号子集或改写目标模型"),
 	}
 }
 
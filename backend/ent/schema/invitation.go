@@ -44,6 +44,19 @@ func (Invitation) Fields() []ent.Field {
 			Optional().
 			Nillable().
 			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+		// 返佣状态：active 正常计佣；blocked 因反作弊或管理员操作停止计佣
+		field.String("commission_status").
+			MaxLen(20).
+			Default(service.InviteCommissionStatusActive),
+		field.String("commission_block_reason").
+			MaxLen(50).
+			Default(""),
+		// 已计佣的最后一条 usage_logs.id（增量累计游标）
+		field.Int64("commission_cursor").
+			Default(0),
+		field.Float("commission_total").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}).
+			Default(0),
 		field.Time("created_at").
 			Default(time.Now).
 			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
@@ -77,5 +90,6 @@ func (Invitation) Indexes() []ent.Index {
 		index.Fields("inviter_id"),
 		index.Fields("status"),
 		index.Fields("created_at"),
+		index.Fields("commission_status"),
 	}
 }
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// InviteCommission holds the schema definition for the InviteCommission entity.
+// 每条记录对应后台任务对某个邀请关系的一次增量计佣。
+type InviteCommission struct {
+	ent.Schema
+}
+
+func (InviteCommission) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "invite_commissions"},
+	}
+}
+
+func (InviteCommission) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("invite_id"),
+		field.Int64("inviter_id"),
+		field.Int64("invitee_id"),
+		// 被邀请人在本批次内的实际消费（actual_cost 之和）
+		field.Float("base_amount").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,10)"}),
+		// 计佣比例（百分比）
+		field.Float("rate").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(10,4)"}),
+		field.Float("amount").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}),
+		field.Int64("from_log_id"),
+		field.Int64("to_log_id"),
+		field.Int("log_count").
+			Default(0),
+		field.Time("created_at").
+			Default(time.Now).
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+	}
+}
+
+func (InviteCommission) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("invite_id"),
+		index.Fields("inviter_id", "created_at"),
+		index.Fields("invitee_id"),
+	}
+}
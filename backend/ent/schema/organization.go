@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Organization holds the schema definition for the Organization entity.
+//
+// 组织拥有共享余额，成员通过归属于组织的 API Key 使用时从组织余额扣费。
+type Organization struct {
+	ent.Schema
+}
+
+func (Organization) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "organizations"},
+	}
+}
+
+func (Organization) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.TimeMixin{},
+		mixins.SoftDeleteMixin{},
+	}
+}
+
+func (Organization) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			MaxLen(100).
+			NotEmpty(),
+		field.Int64("owner_user_id"),
+		field.Float("balance").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}).
+			Default(0),
+		field.String("status").
+			MaxLen(20).
+			Default(service.StatusActive),
+		field.String("notes").
+			SchemaType(map[string]string{dialect.Postgres: "text"}).
+			Default(""),
+	}
+}
+
+func (Organization) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("members", OrganizationMember.Type),
+		edge.To("invitations", OrganizationInvitation.Type),
+		edge.To("api_keys", APIKey.Type),
+	}
+}
+
+func (Organization) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("owner_user_id"),
+		index.Fields("status"),
+		index.Fields("deleted_at"),
+	}
+}
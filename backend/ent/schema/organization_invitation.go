@@ -0,0 +1,77 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// OrganizationInvitation holds the schema definition for the OrganizationInvitation entity.
+type OrganizationInvitation struct {
+	ent.Schema
+}
+
+func (OrganizationInvitation) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "organization_invitations"},
+	}
+}
+
+func (OrganizationInvitation) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("organization_id"),
+		field.String("email").
+			MaxLen(255).
+			NotEmpty(),
+		field.String("role").
+			MaxLen(20).
+			Default(service.OrganizationRoleMember),
+		// 邀请令牌仅保存 SHA-256 摘要
+		field.String("token_hash").
+			MaxLen(64).
+			NotEmpty().
+			Unique(),
+		field.Int64("invited_by"),
+		field.String("status").
+			MaxLen(20).
+			Default(service.OrganizationInvitationStatusPending),
+		field.Time("expires_at").
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+		field.Int64("accepted_user_id").
+			Optional().
+			Nillable(),
+		field.Time("accepted_at").
+			Optional().
+			Nillable().
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+		field.Time("created_at").
+			Default(time.Now).
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+	}
+}
+
+func (OrganizationInvitation) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("organization", Organization.Type).
+			Ref("invitations").
+			Field("organization_id").
+			Unique().
+			Required(),
+	}
+}
+
+func (OrganizationInvitation) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("organization_id"),
+		index.Fields("email"),
+		index.Fields("status"),
+	}
+}
@@ -111,6 +111,12 @@ func (UsageLog) Fields() []ent.Field {
 			Optional().
 			Nillable(),
 
+		// 灰度 / A-B 分流实验组（分组未启用分流时为空）
+		field.String("traffic_arm").
+			MaxLen(64).
+			Optional().
+			Nillable(),
+
 		// 图片生成字段（仅 gemini-3-pro-image 等图片模型使用）
 		field.Int("image_count").
 			Default(0),
@@ -76,6 +76,26 @@ func (User) Fields() []ent.Field {
 		field.Time("totp_enabled_at").
 			Optional().
 			Nillable(),
+
+		// 专属账号：优先（或仅）使用这些账号调度，其他用户不会调度到这些账号
+		field.JSON("dedicated_account_ids", []int64{}).
+			Optional(),
+		field.Bool("dedicated_accounts_only").
+			Default(false),
+
+		// 返佣：可提现/可转余额的佣金余额
+		field.Float("commission_balance").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}).
+			Default(0),
+		// 注册来源（用于返佣反作弊：同 IP / 同设备注册检测）
+		field.String("register_ip").
+			MaxLen(45).
+			Optional().
+			Nillable(),
+		field.String("register_device").
+			MaxLen(128).
+			Optional().
+			Nillable(),
 	}
 }
 
@@ -98,6 +118,9 @@ func (User) Edges() []ent.Edge {
 		edge.To("invite_logs_as_invitee", InviteLog.Type),
 		edge.To("invite_logs_as_admin", InviteLog.Type),
 		edge.To("admin_action_logs", AdminActionLog.Type),
+		edge.To("organization_memberships", OrganizationMember.Type),
+		edge.To("webauthn_credentials", WebAuthnCredential.Type),
+		edge.To("recovery_codes", UserRecoveryCode.Type),
 	}
 }
 
@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// UserRecoveryCode holds the schema definition for the UserRecoveryCode entity.
+// 两步验证恢复码，仅保存 SHA-256 摘要，每个恢复码只能使用一次。
+type UserRecoveryCode struct {
+	ent.Schema
+}
+
+func (UserRecoveryCode) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "user_recovery_codes"},
+	}
+}
+
+func (UserRecoveryCode) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("user_id"),
+		field.String("code_hash").
+			MaxLen(64).
+			NotEmpty(),
+		field.Time("used_at").
+			Optional().
+			Nillable().
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now).
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+	}
+}
+
+func (UserRecoveryCode) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("recovery_codes").
+			Field("user_id").
+			Unique().
+			Required(),
+	}
+}
+
+func (UserRecoveryCode) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "code_hash").
+			Unique(),
+	}
+}
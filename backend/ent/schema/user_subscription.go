@@ -79,6 +79,21 @@ func (UserSubscription) Fields() []ent.Field {
 			Optional().
 			Nillable().
 			SchemaType(map[string]string{dialect.Postgres: "text"}),
+
+		// 自动续费与暂停 (added by migration 058)
+		field.Bool("auto_renew").
+			Default(false).
+			Comment("到期前自动从余额续费"),
+		field.Time("paused_at").
+			Optional().
+			Nillable().
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}).
+			Comment("暂停时间；恢复时按暂停时长顺延到期时间"),
+		field.Time("renewal_failed_at").
+			Optional().
+			Nillable().
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}).
+			Comment("最近一次自动续费失败时间，用于重试节流与避免重复通知"),
 	}
 }
 
@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// WebAuthnCredential holds the schema definition for the WebAuthnCredential entity.
+// 用户注册的 passkey / 安全密钥，可作为第二因素或免密码登录凭证。
+type WebAuthnCredential struct {
+	ent.Schema
+}
+
+func (WebAuthnCredential) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "webauthn_credentials"},
+	}
+}
+
+func (WebAuthnCredential) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.TimeMixin{},
+	}
+}
+
+func (WebAuthnCredential) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("user_id"),
+		field.String("name").
+			MaxLen(64).
+			NotEmpty(),
+		// 凭证 ID（base64url 编码），全局唯一
+		field.String("credential_id").
+			MaxLen(1400).
+			NotEmpty().
+			Unique(),
+		// COSE 编码的公钥
+		field.Bytes("public_key").
+			NotEmpty(),
+		field.Int64("sign_count").
+			Default(0),
+		field.String("aaguid").
+			MaxLen(36).
+			Default(""),
+		field.JSON("transports", []string{}).
+			Optional(),
+		// 注册时认证器是否完成了用户验证（生物识别 / PIN），决定能否用于免密码登录
+		field.Bool("user_verified").
+			Default(false),
+		field.Time("last_used_at").
+			Optional().
+			Nillable().
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+	}
+}
+
+func (WebAuthnCredential) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("webauthn_credentials").
+			Field("user_id").
+			Unique().
+			Required(),
+	}
+}
+
+func (WebAuthnCredential) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+	}
+}
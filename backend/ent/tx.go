@@ -22,12 +22,22 @@ type Tx struct {
 	AccountGroup *AccountGroupClient
 	// AdminActionLog is the client for interacting with the AdminActionLog builders.
 	AdminActionLog *AdminActionLogClient
+	// CommissionWithdrawal is the client for interacting with the CommissionWithdrawal builders.
+	CommissionWithdrawal *CommissionWithdrawalClient
 	// Group is the client for interacting with the Group builders.
 	Group *GroupClient
 	// Invitation is the client for interacting with the Invitation builders.
 	Invitation *InvitationClient
+	// InviteCommission is the client for interacting with the InviteCommission builders.
+	InviteCommission *InviteCommissionClient
 	// InviteLog is the client for interacting with the InviteLog builders.
 	InviteLog *InviteLogClient
+	// Organization is the client for interacting with the Organization builders.
+	Organization *OrganizationClient
+	// OrganizationInvitation is the client for interacting with the OrganizationInvitation builders.
+	OrganizationInvitation *OrganizationInvitationClient
+	// OrganizationMember is the client for interacting with the OrganizationMember builders.
+	OrganizationMember *OrganizationMemberClient
 	// Plan is the client for interacting with the Plan builders.
 	Plan *PlanClient
 	// PromoCode is the client for interacting with the PromoCode builders.
@@ -52,8 +62,12 @@ type Tx struct {
 	UserAttributeDefinition *UserAttributeDefinitionClient
 	// UserAttributeValue is the client for interacting with the UserAttributeValue builders.
 	UserAttributeValue *UserAttributeValueClient
+	// UserRecoveryCode is the client for interacting with the UserRecoveryCode builders.
+	UserRecoveryCode *UserRecoveryCodeClient
 	// UserSubscription is the client for interacting with the UserSubscription builders.
 	UserSubscription *UserSubscriptionClient
+	// WebAuthnCredential is the client for interacting with the WebAuthnCredential builders.
+	WebAuthnCredential *WebAuthnCredentialClient
 
 	// lazily loaded.
 	client     *Client
@@ -189,9 +203,14 @@ func (tx *Tx) init() {
 	tx.Account = NewAccountClient(tx.config)
 	tx.AccountGroup = NewAccountGroupClient(tx.config)
 	tx.AdminActionLog = NewAdminActionLogClient(tx.config)
+	tx.CommissionWithdrawal = NewCommissionWithdrawalClient(tx.config)
 	tx.Group = NewGroupClient(tx.config)
 	tx.Invitation = NewInvitationClient(tx.config)
+	tx.InviteCommission = NewInviteCommissionClient(tx.config)
 	tx.InviteLog = NewInviteLogClient(tx.config)
+	tx.Organization = NewOrganizationClient(tx.config)
+	tx.OrganizationInvitation = NewOrganizationInvitationClient(tx.config)
+	tx.OrganizationMember = NewOrganizationMemberClient(tx.config)
 	tx.Plan = NewPlanClient(tx.config)
 	tx.PromoCode = NewPromoCodeClient(tx.config)
 	tx.PromoCodeUsage = NewPromoCodeUsageClient(tx.config)
@@ -204,7 +223,9 @@ func (tx *Tx) init() {
 	tx.UserAllowedGroup = NewUserAllowedGroupClient(tx.config)
 	tx.UserAttributeDefinition = NewUserAttributeDefinitionClient(tx.config)
 	tx.UserAttributeValue = NewUserAttributeValueClient(tx.config)
+	tx.UserRecoveryCode = NewUserRecoveryCodeClient(tx.config)
 	tx.UserSubscription = NewUserSubscriptionClient(tx.config)
+	tx.WebAuthnCredential = NewWebAuthnCredentialClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
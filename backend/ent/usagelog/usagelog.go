@@ -68,6 +68,8 @@ const (
 	FieldUserAgent = "user_agent"
 	// FieldIPAddress holds the string denoting the ip_address field in the database.
 	FieldIPAddress = "ip_address"
+	// FieldTrafficArm holds the string denoting the traffic_arm field in the database.
+	FieldTrafficArm = "traffic_arm"
 	// FieldImageCount holds the string denoting the image_count field in the database.
 	FieldImageCount = "image_count"
 	// FieldImageSize holds the string denoting the image_size field in the database.
@@ -153,6 +155,7 @@ var Columns = []string{
 	FieldFirstTokenMs,
 	FieldUserAgent,
 	FieldIPAddress,
+	FieldTrafficArm,
 	FieldImageCount,
 	FieldImageSize,
 	FieldCreatedAt,
@@ -207,6 +210,8 @@ var (
 	UserAgentValidator func(string) error
 	// IPAddressValidator is a validator for the "ip_address" field. It is called by the builders before save.
 	IPAddressValidator func(string) error
+	// TrafficArmValidator is a validator for the "traffic_arm" field. It is called by the builders before save.
+	TrafficArmValidator func(string) error
 	// DefaultImageCount holds the default value on creation for the "image_count" field.
 	DefaultImageCount int
 	// ImageSizeValidator is a validator for the "image_size" field. It is called by the builders before save.
@@ -358,6 +363,11 @@ func ByIPAddress(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldIPAddress, opts...).ToFunc()
 }
 
+// ByTrafficArm orders the results by the traffic_arm field.
+func ByTrafficArm(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTrafficArm, opts...).ToFunc()
+}
+
 // ByImageCount orders the results by the image_count field.
 func ByImageCount(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldImageCount, opts...).ToFunc()
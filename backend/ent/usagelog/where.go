@@ -190,6 +190,11 @@ func IPAddress(v string) predicate.UsageLog {
 	return predicate.UsageLog(sql.FieldEQ(FieldIPAddress, v))
 }
 
+// TrafficArm applies equality check predicate on the "traffic_arm" field. It's identical to TrafficArmEQ.
+func TrafficArm(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldTrafficArm, v))
+}
+
 // ImageCount applies equality check predicate on the "image_count" field. It's identical to ImageCountEQ.
 func ImageCount(v int) predicate.UsageLog {
 	return predicate.UsageLog(sql.FieldEQ(FieldImageCount, v))
@@ -1325,6 +1330,81 @@ func IPAddressContainsFold(v string) predicate.UsageLog {
 	return predicate.UsageLog(sql.FieldContainsFold(FieldIPAddress, v))
 }
 
+// TrafficArmEQ applies the EQ predicate on the "traffic_arm" field.
+func TrafficArmEQ(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldTrafficArm, v))
+}
+
+// TrafficArmNEQ applies the NEQ predicate on the "traffic_arm" field.
+func TrafficArmNEQ(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNEQ(FieldTrafficArm, v))
+}
+
+// TrafficArmIn applies the In predicate on the "traffic_arm" field.
+func TrafficArmIn(vs ...string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldIn(FieldTrafficArm, vs...))
+}
+
+// TrafficArmNotIn applies the NotIn predicate on the "traffic_arm" field.
+func TrafficArmNotIn(vs ...string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNotIn(FieldTrafficArm, vs...))
+}
+
+// TrafficArmGT applies the GT predicate on the "traffic_arm" field.
+func TrafficArmGT(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldGT(FieldTrafficArm, v))
+}
+
+// TrafficArmGTE applies the GTE predicate on the "traffic_arm" field.
+func TrafficArmGTE(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldGTE(FieldTrafficArm, v))
+}
+
+// TrafficArmLT applies the LT predicate on the "traffic_arm" field.
+func TrafficArmLT(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldLT(FieldTrafficArm, v))
+}
+
+// TrafficArmLTE applies the LTE predicate on the "traffic_arm" field.
+func TrafficArmLTE(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldLTE(FieldTrafficArm, v))
+}
+
+// TrafficArmContains applies the Contains predicate on the "traffic_arm" field.
+func TrafficArmContains(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldContains(FieldTrafficArm, v))
+}
+
+// TrafficArmHasPrefix applies the HasPrefix predicate on the "traffic_arm" field.
+func TrafficArmHasPrefix(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldHasPrefix(FieldTrafficArm, v))
+}
+
+// TrafficArmHasSuffix applies the HasSuffix predicate on the "traffic_arm" field.
+func TrafficArmHasSuffix(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldHasSuffix(FieldTrafficArm, v))
+}
+
+// TrafficArmIsNil applies the IsNil predicate on the "traffic_arm" field.
+func TrafficArmIsNil() predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldIsNull(FieldTrafficArm))
+}
+
+// TrafficArmNotNil applies the NotNil predicate on the "traffic_arm" field.
+func TrafficArmNotNil() predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNotNull(FieldTrafficArm))
+}
+
+// TrafficArmEqualFold applies the EqualFold predicate on the "traffic_arm" field.
+func TrafficArmEqualFold(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEqualFold(FieldTrafficArm, v))
+}
+
+// TrafficArmContainsFold applies the ContainsFold predicate on the "traffic_arm" field.
+func TrafficArmContainsFold(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldContainsFold(FieldTrafficArm, v))
+}
+
 // ImageCountEQ applies the EQ predicate on the "image_count" field.
 func ImageCountEQ(v int) predicate.UsageLog {
 	return predicate.UsageLog(sql.FieldEQ(FieldImageCount, v))
@@ -365,6 +365,20 @@ func (_c *UsageLogCreate) SetNillableIPAddress(v *string) *UsageLogCreate {
 	return _c
 }
 
+// SetTrafficArm sets the "traffic_arm" field.
+func (_c *UsageLogCreate) SetTrafficArm(v string) *UsageLogCreate {
+	_c.mutation.SetTrafficArm(v)
+	return _c
+}
+
+// SetNillableTrafficArm sets the "traffic_arm" field if the given value is not nil.
+func (_c *UsageLogCreate) SetNillableTrafficArm(v *string) *UsageLogCreate {
+	if v != nil {
+		_c.SetTrafficArm(*v)
+	}
+	return _c
+}
+
 // SetImageCount sets the "image_count" field.
 func (_c *UsageLogCreate) SetImageCount(v int) *UsageLogCreate {
 	_c.mutation.SetImageCount(v)
@@ -619,6 +633,11 @@ func (_c *UsageLogCreate) check() error {
 			return &ValidationError{Name: "ip_address", err: fmt.Errorf(`ent: validator failed for field "UsageLog.ip_address": %w`, err)}
 		}
 	}
+	if v, ok := _c.mutation.TrafficArm(); ok {
+		if err := usagelog.TrafficArmValidator(v); err != nil {
+			return &ValidationError{Name: "traffic_arm", err: fmt.Errorf(`ent: validator failed for field "UsageLog.traffic_arm": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.ImageCount(); !ok {
 		return &ValidationError{Name: "image_count", err: errors.New(`ent: missing required field "UsageLog.image_count"`)}
 	}
@@ -754,6 +773,10 @@ func (_c *UsageLogCreate) createSpec() (*UsageLog, *sqlgraph.CreateSpec) {
 		_spec.SetField(usagelog.FieldIPAddress, field.TypeString, value)
 		_node.IPAddress = &value
 	}
+	if value, ok := _c.mutation.TrafficArm(); ok {
+		_spec.SetField(usagelog.FieldTrafficArm, field.TypeString, value)
+		_node.TrafficArm = &value
+	}
 	if value, ok := _c.mutation.ImageCount(); ok {
 		_spec.SetField(usagelog.FieldImageCount, field.TypeInt, value)
 		_node.ImageCount = value
@@ -1371,6 +1394,24 @@ func (u *UsageLogUpsert) ClearIPAddress() *UsageLogUpsert {
 	return u
 }
 
+// SetTrafficArm sets the "traffic_arm" field.
+func (u *UsageLogUpsert) SetTrafficArm(v string) *UsageLogUpsert {
+	u.Set(usagelog.FieldTrafficArm, v)
+	return u
+}
+
+// UpdateTrafficArm sets the "traffic_arm" field to the value that was provided on create.
+func (u *UsageLogUpsert) UpdateTrafficArm() *UsageLogUpsert {
+	u.SetExcluded(usagelog.FieldTrafficArm)
+	return u
+}
+
+// ClearTrafficArm clears the value of the "traffic_arm" field.
+func (u *UsageLogUpsert) ClearTrafficArm() *UsageLogUpsert {
+	u.SetNull(usagelog.FieldTrafficArm)
+	return u
+}
+
 // SetImageCount sets the "image_count" field.
 func (u *UsageLogUpsert) SetImageCount(v int) *UsageLogUpsert {
 	u.Set(usagelog.FieldImageCount, v)
@@ -1998,6 +2039,27 @@ func (u *UsageLogUpsertOne) ClearIPAddress() *UsageLogUpsertOne {
 	})
 }
 
+// SetTrafficArm sets the "traffic_arm" field.
+func (u *UsageLogUpsertOne) SetTrafficArm(v string) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetTrafficArm(v)
+	})
+}
+
+// UpdateTrafficArm sets the "traffic_arm" field to the value that was provided on create.
+func (u *UsageLogUpsertOne) UpdateTrafficArm() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateTrafficArm()
+	})
+}
+
+// ClearTrafficArm clears the value of the "traffic_arm" field.
+func (u *UsageLogUpsertOne) ClearTrafficArm() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.ClearTrafficArm()
+	})
+}
+
 // SetImageCount sets the "image_count" field.
 func (u *UsageLogUpsertOne) SetImageCount(v int) *UsageLogUpsertOne {
 	return u.Update(func(s *UsageLogUpsert) {
@@ -2797,6 +2859,27 @@ func (u *UsageLogUpsertBulk) ClearIPAddress() *UsageLogUpsertBulk {
 	})
 }
 
+// SetTrafficArm sets the "traffic_arm" field.
+func (u *UsageLogUpsertBulk) SetTrafficArm(v string) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetTrafficArm(v)
+	})
+}
+
+// UpdateTrafficArm sets the "traffic_arm" field to the value that was provided on create.
+func (u *UsageLogUpsertBulk) UpdateTrafficArm() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateTrafficArm()
+	})
+}
+
+// ClearTrafficArm clears the value of the "traffic_arm" field.
+func (u *UsageLogUpsertBulk) ClearTrafficArm() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.ClearTrafficArm()
+	})
+}
+
 // SetImageCount sets the "image_count" field.
 func (u *UsageLogUpsertBulk) SetImageCount(v int) *UsageLogUpsertBulk {
 	return u.Update(func(s *UsageLogUpsert) {
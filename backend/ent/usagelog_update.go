@@ -571,6 +571,26 @@ func (_u *UsageLogUpdate) ClearIPAddress() *UsageLogUpdate {
 	return _u
 }
 
+// SetTrafficArm sets the "traffic_arm" field.
+func (_u *UsageLogUpdate) SetTrafficArm(v string) *UsageLogUpdate {
+	_u.mutation.SetTrafficArm(v)
+	return _u
+}
+
+// SetNillableTrafficArm sets the "traffic_arm" field if the given value is not nil.
+func (_u *UsageLogUpdate) SetNillableTrafficArm(v *string) *UsageLogUpdate {
+	if v != nil {
+		_u.SetTrafficArm(*v)
+	}
+	return _u
+}
+
+// ClearTrafficArm clears the value of the "traffic_arm" field.
+func (_u *UsageLogUpdate) ClearTrafficArm() *UsageLogUpdate {
+	_u.mutation.ClearTrafficArm()
+	return _u
+}
+
 // SetImageCount sets the "image_count" field.
 func (_u *UsageLogUpdate) SetImageCount(v int) *UsageLogUpdate {
 	_u.mutation.ResetImageCount()
@@ -721,6 +741,11 @@ func (_u *UsageLogUpdate) check() error {
 			return &ValidationError{Name: "ip_address", err: fmt.Errorf(`ent: validator failed for field "UsageLog.ip_address": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.TrafficArm(); ok {
+		if err := usagelog.TrafficArmValidator(v); err != nil {
+			return &ValidationError{Name: "traffic_arm", err: fmt.Errorf(`ent: validator failed for field "UsageLog.traffic_arm": %w`, err)}
+		}
+	}
 	if v, ok := _u.mutation.ImageSize(); ok {
 		if err := usagelog.ImageSizeValidator(v); err != nil {
 			return &ValidationError{Name: "image_size", err: fmt.Errorf(`ent: validator failed for field "UsageLog.image_size": %w`, err)}
@@ -882,6 +907,12 @@ func (_u *UsageLogUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.IPAddressCleared() {
 		_spec.ClearField(usagelog.FieldIPAddress, field.TypeString)
 	}
+	if value, ok := _u.mutation.TrafficArm(); ok {
+		_spec.SetField(usagelog.FieldTrafficArm, field.TypeString, value)
+	}
+	if _u.mutation.TrafficArmCleared() {
+		_spec.ClearField(usagelog.FieldTrafficArm, field.TypeString)
+	}
 	if value, ok := _u.mutation.ImageCount(); ok {
 		_spec.SetField(usagelog.FieldImageCount, field.TypeInt, value)
 	}
@@ -1598,6 +1629,26 @@ func (_u *UsageLogUpdateOne) ClearIPAddress() *UsageLogUpdateOne {
 	return _u
 }
 
+// SetTrafficArm sets the "traffic_arm" field.
+func (_u *UsageLogUpdateOne) SetTrafficArm(v string) *UsageLogUpdateOne {
+	_u.mutation.SetTrafficArm(v)
+	return _u
+}
+
+// SetNillableTrafficArm sets the "traffic_arm" field if the given value is not nil.
+func (_u *UsageLogUpdateOne) SetNillableTrafficArm(v *string) *UsageLogUpdateOne {
+	if v != nil {
+		_u.SetTrafficArm(*v)
+	}
+	return _u
+}
+
+// ClearTrafficArm clears the value of the "traffic_arm" field.
+func (_u *UsageLogUpdateOne) ClearTrafficArm() *UsageLogUpdateOne {
+	_u.mutation.ClearTrafficArm()
+	return _u
+}
+
 // SetImageCount sets the "image_count" field.
 func (_u *UsageLogUpdateOne) SetImageCount(v int) *UsageLogUpdateOne {
 	_u.mutation.ResetImageCount()
@@ -1761,6 +1812,11 @@ func (_u *UsageLogUpdateOne) check() error {
 			return &ValidationError{Name: "ip_address", err: fmt.Errorf(`ent: validator failed for field "UsageLog.ip_address": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.TrafficArm(); ok {
+		if err := usagelog.TrafficArmValidator(v); err != nil {
+			return &ValidationError{Name: "traffic_arm", err: fmt.Errorf(`ent: validator failed for field "UsageLog.traffic_arm": %w`, err)}
+		}
+	}
 	if v, ok := _u.mutation.ImageSize(); ok {
 		if err := usagelog.ImageSizeValidator(v); err != nil {
 			return &ValidationError{Name: "image_size", err: fmt.Errorf(`ent: validator failed for field "UsageLog.image_size": %w`, err)}
@@ -1939,6 +1995,12 @@ func (_u *UsageLogUpdateOne) sqlSave(ctx context.Context) (_node *UsageLog, err
 	if _u.mutation.IPAddressCleared() {
 		_spec.ClearField(usagelog.FieldIPAddress, field.TypeString)
 	}
+	if value, ok := _u.mutation.TrafficArm(); ok {
+		_spec.SetField(usagelog.FieldTrafficArm, field.TypeString, value)
+	}
+	if _u.mutation.TrafficArmCleared() {
+		_spec.ClearField(usagelog.FieldTrafficArm, field.TypeString)
+	}
 	if value, ok := _u.mutation.ImageCount(); ok {
 		_spec.SetField(usagelog.FieldImageCount, field.TypeInt, value)
 	}
@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -48,6 +49,16 @@ type User struct {
 	TotpEnabled bool `json:"totp_enabled,omitempty"`
 	// TotpEnabledAt holds the value of the "totp_enabled_at" field.
 	TotpEnabledAt *time.Time `json:"totp_enabled_at,omitempty"`
+	// DedicatedAccountIds holds the value of the "dedicated_account_ids" field.
+	DedicatedAccountIds []int64 `json:"dedicated_account_ids,omitempty"`
+	// DedicatedAccountsOnly holds the value of the "dedicated_accounts_only" field.
+	DedicatedAccountsOnly bool `json:"dedicated_accounts_only,omitempty"`
+	// CommissionBalance holds the value of the "commission_balance" field.
+	CommissionBalance float64 `json:"commission_balance,omitempty"`
+	// RegisterIP holds the value of the "register_ip" field.
+	RegisterIP *string `json:"register_ip,omitempty"`
+	// RegisterDevice holds the value of the "register_device" field.
+	RegisterDevice *string `json:"register_device,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the UserQuery when eager-loading is set.
 	Edges        UserEdges `json:"edges"`
@@ -86,11 +97,17 @@ type UserEdges struct {
 	InviteLogsAsAdmin []*InviteLog `json:"invite_logs_as_admin,omitempty"`
 	// AdminActionLogs holds the value of the admin_action_logs edge.
 	AdminActionLogs []*AdminActionLog `json:"admin_action_logs,omitempty"`
+	// OrganizationMemberships holds the value of the organization_memberships edge.
+	OrganizationMemberships []*OrganizationMember `json:"organization_memberships,omitempty"`
+	// WebauthnCredentials holds the value of the webauthn_credentials edge.
+	WebauthnCredentials []*WebAuthnCredential `json:"webauthn_credentials,omitempty"`
+	// RecoveryCodes holds the value of the recovery_codes edge.
+	RecoveryCodes []*UserRecoveryCode `json:"recovery_codes,omitempty"`
 	// UserAllowedGroups holds the value of the user_allowed_groups edge.
 	UserAllowedGroups []*UserAllowedGroup `json:"user_allowed_groups,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [16]bool
+	loadedTypes [19]bool
 }
 
 // APIKeysOrErr returns the APIKeys value or an error if the edge
@@ -230,10 +247,37 @@ func (e UserEdges) AdminActionLogsOrErr() ([]*AdminActionLog, error) {
 	return nil, &NotLoadedError{edge: "admin_action_logs"}
 }
 
+// OrganizationMembershipsOrErr returns the OrganizationMemberships value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) OrganizationMembershipsOrErr() ([]*OrganizationMember, error) {
+	if e.loadedTypes[15] {
+		return e.OrganizationMemberships, nil
+	}
+	return nil, &NotLoadedError{edge: "organization_memberships"}
+}
+
+// WebauthnCredentialsOrErr returns the WebauthnCredentials value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) WebauthnCredentialsOrErr() ([]*WebAuthnCredential, error) {
+	if e.loadedTypes[16] {
+		return e.WebauthnCredentials, nil
+	}
+	return nil, &NotLoadedError{edge: "webauthn_credentials"}
+}
+
+// RecoveryCodesOrErr returns the RecoveryCodes value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) RecoveryCodesOrErr() ([]*UserRecoveryCode, error) {
+	if e.loadedTypes[17] {
+		return e.RecoveryCodes, nil
+	}
+	return nil, &NotLoadedError{edge: "recovery_codes"}
+}
+
 // UserAllowedGroupsOrErr returns the UserAllowedGroups value or an error if the edge
 // was not loaded in eager-loading.
 func (e UserEdges) UserAllowedGroupsOrErr() ([]*UserAllowedGroup, error) {
-	if e.loadedTypes[15] {
+	if e.loadedTypes[18] {
 		return e.UserAllowedGroups, nil
 	}
 	return nil, &NotLoadedError{edge: "user_allowed_groups"}
@@ -244,13 +288,15 @@ func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case user.FieldTotpEnabled:
+		case user.FieldDedicatedAccountIds:
+			values[i] = new([]byte)
+		case user.FieldTotpEnabled, user.FieldDedicatedAccountsOnly:
 			values[i] = new(sql.NullBool)
-		case user.FieldBalance:
+		case user.FieldBalance, user.FieldCommissionBalance:
 			values[i] = new(sql.NullFloat64)
 		case user.FieldID, user.FieldConcurrency:
 			values[i] = new(sql.NullInt64)
-		case user.FieldEmail, user.FieldPasswordHash, user.FieldRole, user.FieldInviteCode, user.FieldStatus, user.FieldUsername, user.FieldNotes, user.FieldTotpSecretEncrypted:
+		case user.FieldEmail, user.FieldPasswordHash, user.FieldRole, user.FieldInviteCode, user.FieldStatus, user.FieldUsername, user.FieldNotes, user.FieldTotpSecretEncrypted, user.FieldRegisterIP, user.FieldRegisterDevice:
 			values[i] = new(sql.NullString)
 		case user.FieldCreatedAt, user.FieldUpdatedAt, user.FieldDeletedAt, user.FieldTotpEnabledAt:
 			values[i] = new(sql.NullTime)
@@ -369,6 +415,40 @@ func (_m *User) assignValues(columns []string, values []any) error {
 				_m.TotpEnabledAt = new(time.Time)
 				*_m.TotpEnabledAt = value.Time
 			}
+		case user.FieldDedicatedAccountIds:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field dedicated_account_ids", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.DedicatedAccountIds); err != nil {
+					return fmt.Errorf("unmarshal field dedicated_account_ids: %w", err)
+				}
+			}
+		case user.FieldDedicatedAccountsOnly:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field dedicated_accounts_only", values[i])
+			} else if value.Valid {
+				_m.DedicatedAccountsOnly = value.Bool
+			}
+		case user.FieldCommissionBalance:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field commission_balance", values[i])
+			} else if value.Valid {
+				_m.CommissionBalance = value.Float64
+			}
+		case user.FieldRegisterIP:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field register_ip", values[i])
+			} else if value.Valid {
+				_m.RegisterIP = new(string)
+				*_m.RegisterIP = value.String
+			}
+		case user.FieldRegisterDevice:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field register_device", values[i])
+			} else if value.Valid {
+				_m.RegisterDevice = new(string)
+				*_m.RegisterDevice = value.String
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -457,6 +537,21 @@ func (_m *User) QueryAdminActionLogs() *AdminActionLogQuery {
 	return NewUserClient(_m.config).QueryAdminActionLogs(_m)
 }
 
+// QueryOrganizationMemberships queries the "organization_memberships" edge of the User entity.
+func (_m *User) QueryOrganizationMemberships() *OrganizationMemberQuery {
+	return NewUserClient(_m.config).QueryOrganizationMemberships(_m)
+}
+
+// QueryWebauthnCredentials queries the "webauthn_credentials" edge of the User entity.
+func (_m *User) QueryWebauthnCredentials() *WebAuthnCredentialQuery {
+	return NewUserClient(_m.config).QueryWebauthnCredentials(_m)
+}
+
+// QueryRecoveryCodes queries the "recovery_codes" edge of the User entity.
+func (_m *User) QueryRecoveryCodes() *UserRecoveryCodeQuery {
+	return NewUserClient(_m.config).QueryRecoveryCodes(_m)
+}
+
 // QueryUserAllowedGroups queries the "user_allowed_groups" edge of the User entity.
 func (_m *User) QueryUserAllowedGroups() *UserAllowedGroupQuery {
 	return NewUserClient(_m.config).QueryUserAllowedGroups(_m)
@@ -537,6 +632,25 @@ func (_m *User) String() string {
 		builder.WriteString("totp_enabled_at=")
 		builder.WriteString(v.Format(time.ANSIC))
 	}
+	builder.WriteString(", ")
+	builder.WriteString("dedicated_account_ids=")
+	builder.WriteString(fmt.Sprintf("%v", _m.DedicatedAccountIds))
+	builder.WriteString(", ")
+	builder.WriteString("dedicated_accounts_only=")
+	builder.WriteString(fmt.Sprintf("%v", _m.DedicatedAccountsOnly))
+	builder.WriteString(", ")
+	builder.WriteString("commission_balance=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CommissionBalance))
+	builder.WriteString(", ")
+	if v := _m.RegisterIP; v != nil {
+		builder.WriteString("register_ip=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.RegisterDevice; v != nil {
+		builder.WriteString("register_device=")
+		builder.WriteString(*v)
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
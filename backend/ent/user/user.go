@@ -45,6 +45,16 @@ const (
 	FieldTotpEnabled = "totp_enabled"
 	// FieldTotpEnabledAt holds the string denoting the totp_enabled_at field in the database.
 	FieldTotpEnabledAt = "totp_enabled_at"
+	// FieldDedicatedAccountIds holds the string denoting the dedicated_account_ids field in the database.
+	FieldDedicatedAccountIds = "dedicated_account_ids"
+	// FieldDedicatedAccountsOnly holds the string denoting the dedicated_accounts_only field in the database.
+	FieldDedicatedAccountsOnly = "dedicated_accounts_only"
+	// FieldCommissionBalance holds the string denoting the commission_balance field in the database.
+	FieldCommissionBalance = "commission_balance"
+	// FieldRegisterIP holds the string denoting the register_ip field in the database.
+	FieldRegisterIP = "register_ip"
+	// FieldRegisterDevice holds the string denoting the register_device field in the database.
+	FieldRegisterDevice = "register_device"
 	// EdgeAPIKeys holds the string denoting the api_keys edge name in mutations.
 	EdgeAPIKeys = "api_keys"
 	// EdgeRedeemCodes holds the string denoting the redeem_codes edge name in mutations.
@@ -75,6 +85,12 @@ const (
 	EdgeInviteLogsAsAdmin = "invite_logs_as_admin"
 	// EdgeAdminActionLogs holds the string denoting the admin_action_logs edge name in mutations.
 	EdgeAdminActionLogs = "admin_action_logs"
+	// EdgeOrganizationMemberships holds the string denoting the organization_memberships edge name in mutations.
+	EdgeOrganizationMemberships = "organization_memberships"
+	// EdgeWebauthnCredentials holds the string denoting the webauthn_credentials edge name in mutations.
+	EdgeWebauthnCredentials = "webauthn_credentials"
+	// EdgeRecoveryCodes holds the string denoting the recovery_codes edge name in mutations.
+	EdgeRecoveryCodes = "recovery_codes"
 	// EdgeUserAllowedGroups holds the string denoting the user_allowed_groups edge name in mutations.
 	EdgeUserAllowedGroups = "user_allowed_groups"
 	// Table holds the table name of the user in the database.
@@ -182,6 +198,27 @@ const (
 	AdminActionLogsInverseTable = "admin_action_logs"
 	// AdminActionLogsColumn is the table column denoting the admin_action_logs relation/edge.
 	AdminActionLogsColumn = "admin_id"
+	// OrganizationMembershipsTable is the table that holds the organization_memberships relation/edge.
+	OrganizationMembershipsTable = "organization_members"
+	// OrganizationMembershipsInverseTable is the table name for the OrganizationMember entity.
+	// It exists in this package in order to avoid circular dependency with the "organizationmember" package.
+	OrganizationMembershipsInverseTable = "organization_members"
+	// OrganizationMembershipsColumn is the table column denoting the organization_memberships relation/edge.
+	OrganizationMembershipsColumn = "user_id"
+	// WebauthnCredentialsTable is the table that holds the webauthn_credentials relation/edge.
+	WebauthnCredentialsTable = "webauthn_credentials"
+	// WebauthnCredentialsInverseTable is the table name for the WebAuthnCredential entity.
+	// It exists in this package in order to avoid circular dependency with the "webauthncredential" package.
+	WebauthnCredentialsInverseTable = "webauthn_credentials"
+	// WebauthnCredentialsColumn is the table column denoting the webauthn_credentials relation/edge.
+	WebauthnCredentialsColumn = "user_id"
+	// RecoveryCodesTable is the table that holds the recovery_codes relation/edge.
+	RecoveryCodesTable = "user_recovery_codes"
+	// RecoveryCodesInverseTable is the table name for the UserRecoveryCode entity.
+	// It exists in this package in order to avoid circular dependency with the "userrecoverycode" package.
+	RecoveryCodesInverseTable = "user_recovery_codes"
+	// RecoveryCodesColumn is the table column denoting the recovery_codes relation/edge.
+	RecoveryCodesColumn = "user_id"
 	// UserAllowedGroupsTable is the table that holds the user_allowed_groups relation/edge.
 	UserAllowedGroupsTable = "user_allowed_groups"
 	// UserAllowedGroupsInverseTable is the table name for the UserAllowedGroup entity.
@@ -209,6 +246,11 @@ var Columns = []string{
 	FieldTotpSecretEncrypted,
 	FieldTotpEnabled,
 	FieldTotpEnabledAt,
+	FieldDedicatedAccountIds,
+	FieldDedicatedAccountsOnly,
+	FieldCommissionBalance,
+	FieldRegisterIP,
+	FieldRegisterDevice,
 }
 
 var (
@@ -267,6 +309,14 @@ var (
 	DefaultNotes string
 	// DefaultTotpEnabled holds the default value on creation for the "totp_enabled" field.
 	DefaultTotpEnabled bool
+	// DefaultDedicatedAccountsOnly holds the default value on creation for the "dedicated_accounts_only" field.
+	DefaultDedicatedAccountsOnly bool
+	// DefaultCommissionBalance holds the default value on creation for the "commission_balance" field.
+	DefaultCommissionBalance float64
+	// RegisterIPValidator is a validator for the "register_ip" field. It is called by the builders before save.
+	RegisterIPValidator func(string) error
+	// RegisterDeviceValidator is a validator for the "register_device" field. It is called by the builders before save.
+	RegisterDeviceValidator func(string) error
 )
 
 // OrderOption defines the ordering options for the User queries.
@@ -352,6 +402,26 @@ func ByTotpEnabledAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldTotpEnabledAt, opts...).ToFunc()
 }
 
+// ByDedicatedAccountsOnly orders the results by the dedicated_accounts_only field.
+func ByDedicatedAccountsOnly(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDedicatedAccountsOnly, opts...).ToFunc()
+}
+
+// ByCommissionBalance orders the results by the commission_balance field.
+func ByCommissionBalance(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCommissionBalance, opts...).ToFunc()
+}
+
+// ByRegisterIP orders the results by the register_ip field.
+func ByRegisterIP(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRegisterIP, opts...).ToFunc()
+}
+
+// ByRegisterDevice orders the results by the register_device field.
+func ByRegisterDevice(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRegisterDevice, opts...).ToFunc()
+}
+
 // ByAPIKeysCount orders the results by api_keys count.
 func ByAPIKeysCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -555,6 +625,48 @@ func ByAdminActionLogs(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 	}
 }
 
+// ByOrganizationMembershipsCount orders the results by organization_memberships count.
+func ByOrganizationMembershipsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newOrganizationMembershipsStep(), opts...)
+	}
+}
+
+// ByOrganizationMemberships orders the results by organization_memberships terms.
+func ByOrganizationMemberships(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOrganizationMembershipsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByWebauthnCredentialsCount orders the results by webauthn_credentials count.
+func ByWebauthnCredentialsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newWebauthnCredentialsStep(), opts...)
+	}
+}
+
+// ByWebauthnCredentials orders the results by webauthn_credentials terms.
+func ByWebauthnCredentials(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newWebauthnCredentialsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByRecoveryCodesCount orders the results by recovery_codes count.
+func ByRecoveryCodesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newRecoveryCodesStep(), opts...)
+	}
+}
+
+// ByRecoveryCodes orders the results by recovery_codes terms.
+func ByRecoveryCodes(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newRecoveryCodesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
 // ByUserAllowedGroupsCount orders the results by user_allowed_groups count.
 func ByUserAllowedGroupsCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -673,6 +785,27 @@ func newAdminActionLogsStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, AdminActionLogsTable, AdminActionLogsColumn),
 	)
 }
+func newOrganizationMembershipsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(OrganizationMembershipsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, OrganizationMembershipsTable, OrganizationMembershipsColumn),
+	)
+}
+func newWebauthnCredentialsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(WebauthnCredentialsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, WebauthnCredentialsTable, WebauthnCredentialsColumn),
+	)
+}
+func newRecoveryCodesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(RecoveryCodesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, RecoveryCodesTable, RecoveryCodesColumn),
+	)
+}
 func newUserAllowedGroupsStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -130,6 +130,26 @@ func TotpEnabledAt(v time.Time) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldTotpEnabledAt, v))
 }
 
+// DedicatedAccountsOnly applies equality check predicate on the "dedicated_accounts_only" field. It's identical to DedicatedAccountsOnlyEQ.
+func DedicatedAccountsOnly(v bool) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDedicatedAccountsOnly, v))
+}
+
+// CommissionBalance applies equality check predicate on the "commission_balance" field. It's identical to CommissionBalanceEQ.
+func CommissionBalance(v float64) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldCommissionBalance, v))
+}
+
+// RegisterIP applies equality check predicate on the "register_ip" field. It's identical to RegisterIPEQ.
+func RegisterIP(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldRegisterIP, v))
+}
+
+// RegisterDevice applies equality check predicate on the "register_device" field. It's identical to RegisterDeviceEQ.
+func RegisterDevice(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldRegisterDevice, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldCreatedAt, v))
@@ -940,6 +960,216 @@ func TotpEnabledAtNotNil() predicate.User {
 	return predicate.User(sql.FieldNotNull(FieldTotpEnabledAt))
 }
 
+// DedicatedAccountIdsIsNil applies the IsNil predicate on the "dedicated_account_ids" field.
+func DedicatedAccountIdsIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldDedicatedAccountIds))
+}
+
+// DedicatedAccountIdsNotNil applies the NotNil predicate on the "dedicated_account_ids" field.
+func DedicatedAccountIdsNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldDedicatedAccountIds))
+}
+
+// DedicatedAccountsOnlyEQ applies the EQ predicate on the "dedicated_accounts_only" field.
+func DedicatedAccountsOnlyEQ(v bool) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDedicatedAccountsOnly, v))
+}
+
+// DedicatedAccountsOnlyNEQ applies the NEQ predicate on the "dedicated_accounts_only" field.
+func DedicatedAccountsOnlyNEQ(v bool) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldDedicatedAccountsOnly, v))
+}
+
+// CommissionBalanceEQ applies the EQ predicate on the "commission_balance" field.
+func CommissionBalanceEQ(v float64) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldCommissionBalance, v))
+}
+
+// CommissionBalanceNEQ applies the NEQ predicate on the "commission_balance" field.
+func CommissionBalanceNEQ(v float64) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldCommissionBalance, v))
+}
+
+// CommissionBalanceIn applies the In predicate on the "commission_balance" field.
+func CommissionBalanceIn(vs ...float64) predicate.User {
+	return predicate.User(sql.FieldIn(FieldCommissionBalance, vs...))
+}
+
+// CommissionBalanceNotIn applies the NotIn predicate on the "commission_balance" field.
+func CommissionBalanceNotIn(vs ...float64) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldCommissionBalance, vs...))
+}
+
+// CommissionBalanceGT applies the GT predicate on the "commission_balance" field.
+func CommissionBalanceGT(v float64) predicate.User {
+	return predicate.User(sql.FieldGT(FieldCommissionBalance, v))
+}
+
+// CommissionBalanceGTE applies the GTE predicate on the "commission_balance" field.
+func CommissionBalanceGTE(v float64) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldCommissionBalance, v))
+}
+
+// CommissionBalanceLT applies the LT predicate on the "commission_balance" field.
+func CommissionBalanceLT(v float64) predicate.User {
+	return predicate.User(sql.FieldLT(FieldCommissionBalance, v))
+}
+
+// CommissionBalanceLTE applies the LTE predicate on the "commission_balance" field.
+func CommissionBalanceLTE(v float64) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldCommissionBalance, v))
+}
+
+// RegisterIPEQ applies the EQ predicate on the "register_ip" field.
+func RegisterIPEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldRegisterIP, v))
+}
+
+// RegisterIPNEQ applies the NEQ predicate on the "register_ip" field.
+func RegisterIPNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldRegisterIP, v))
+}
+
+// RegisterIPIn applies the In predicate on the "register_ip" field.
+func RegisterIPIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldRegisterIP, vs...))
+}
+
+// RegisterIPNotIn applies the NotIn predicate on the "register_ip" field.
+func RegisterIPNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldRegisterIP, vs...))
+}
+
+// RegisterIPGT applies the GT predicate on the "register_ip" field.
+func RegisterIPGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldRegisterIP, v))
+}
+
+// RegisterIPGTE applies the GTE predicate on the "register_ip" field.
+func RegisterIPGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldRegisterIP, v))
+}
+
+// RegisterIPLT applies the LT predicate on the "register_ip" field.
+func RegisterIPLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldRegisterIP, v))
+}
+
+// RegisterIPLTE applies the LTE predicate on the "register_ip" field.
+func RegisterIPLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldRegisterIP, v))
+}
+
+// RegisterIPContains applies the Contains predicate on the "register_ip" field.
+func RegisterIPContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldRegisterIP, v))
+}
+
+// RegisterIPHasPrefix applies the HasPrefix predicate on the "register_ip" field.
+func RegisterIPHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldRegisterIP, v))
+}
+
+// RegisterIPHasSuffix applies the HasSuffix predicate on the "register_ip" field.
+func RegisterIPHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldRegisterIP, v))
+}
+
+// RegisterIPIsNil applies the IsNil predicate on the "register_ip" field.
+func RegisterIPIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldRegisterIP))
+}
+
+// RegisterIPNotNil applies the NotNil predicate on the "register_ip" field.
+func RegisterIPNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldRegisterIP))
+}
+
+// RegisterIPEqualFold applies the EqualFold predicate on the "register_ip" field.
+func RegisterIPEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldRegisterIP, v))
+}
+
+// RegisterIPContainsFold applies the ContainsFold predicate on the "register_ip" field.
+func RegisterIPContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldRegisterIP, v))
+}
+
+// RegisterDeviceEQ applies the EQ predicate on the "register_device" field.
+func RegisterDeviceEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldRegisterDevice, v))
+}
+
+// RegisterDeviceNEQ applies the NEQ predicate on the "register_device" field.
+func RegisterDeviceNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldRegisterDevice, v))
+}
+
+// RegisterDeviceIn applies the In predicate on the "register_device" field.
+func RegisterDeviceIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldRegisterDevice, vs...))
+}
+
+// RegisterDeviceNotIn applies the NotIn predicate on the "register_device" field.
+func RegisterDeviceNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldRegisterDevice, vs...))
+}
+
+// RegisterDeviceGT applies the GT predicate on the "register_device" field.
+func RegisterDeviceGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldRegisterDevice, v))
+}
+
+// RegisterDeviceGTE applies the GTE predicate on the "register_device" field.
+func RegisterDeviceGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldRegisterDevice, v))
+}
+
+// RegisterDeviceLT applies the LT predicate on the "register_device" field.
+func RegisterDeviceLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldRegisterDevice, v))
+}
+
+// RegisterDeviceLTE applies the LTE predicate on the "register_device" field.
+func RegisterDeviceLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldRegisterDevice, v))
+}
+
+// RegisterDeviceContains applies the Contains predicate on the "register_device" field.
+func RegisterDeviceContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldRegisterDevice, v))
+}
+
+// RegisterDeviceHasPrefix applies the HasPrefix predicate on the "register_device" field.
+func RegisterDeviceHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldRegisterDevice, v))
+}
+
+// RegisterDeviceHasSuffix applies the HasSuffix predicate on the "register_device" field.
+func RegisterDeviceHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldRegisterDevice, v))
+}
+
+// RegisterDeviceIsNil applies the IsNil predicate on the "register_device" field.
+func RegisterDeviceIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldRegisterDevice))
+}
+
+// RegisterDeviceNotNil applies the NotNil predicate on the "register_device" field.
+func RegisterDeviceNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldRegisterDevice))
+}
+
+// RegisterDeviceEqualFold applies the EqualFold predicate on the "register_device" field.
+func RegisterDeviceEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldRegisterDevice, v))
+}
+
+// RegisterDeviceContainsFold applies the ContainsFold predicate on the "register_device" field.
+func RegisterDeviceContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldRegisterDevice, v))
+}
+
 // HasAPIKeys applies the HasEdge predicate on the "api_keys" edge.
 func HasAPIKeys() predicate.User {
 	return predicate.User(func(s *sql.Selector) {
@@ -1285,6 +1515,75 @@ func HasAdminActionLogsWith(preds ...predicate.AdminActionLog) predicate.User {
 	})
 }
 
+// HasOrganizationMemberships applies the HasEdge predicate on the "organization_memberships" edge.
+func HasOrganizationMemberships() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, OrganizationMembershipsTable, OrganizationMembershipsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOrganizationMembershipsWith applies the HasEdge predicate on the "organization_memberships" edge with a given conditions (other predicates).
+func HasOrganizationMembershipsWith(preds ...predicate.OrganizationMember) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newOrganizationMembershipsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasWebauthnCredentials applies the HasEdge predicate on the "webauthn_credentials" edge.
+func HasWebauthnCredentials() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, WebauthnCredentialsTable, WebauthnCredentialsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasWebauthnCredentialsWith applies the HasEdge predicate on the "webauthn_credentials" edge with a given conditions (other predicates).
+func HasWebauthnCredentialsWith(preds ...predicate.WebAuthnCredential) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newWebauthnCredentialsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasRecoveryCodes applies the HasEdge predicate on the "recovery_codes" edge.
+func HasRecoveryCodes() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, RecoveryCodesTable, RecoveryCodesColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasRecoveryCodesWith applies the HasEdge predicate on the "recovery_codes" edge with a given conditions (other predicates).
+func HasRecoveryCodesWith(preds ...predicate.UserRecoveryCode) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newRecoveryCodesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // HasUserAllowedGroups applies the HasEdge predicate on the "user_allowed_groups" edge.
 func HasUserAllowedGroups() predicate.User {
 	return predicate.User(func(s *sql.Selector) {
@@ -16,12 +16,15 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/group"
 	"github.com/Wei-Shaw/sub2api/ent/invitation"
 	"github.com/Wei-Shaw/sub2api/ent/invitelog"
+	"github.com/Wei-Shaw/sub2api/ent/organizationmember"
 	"github.com/Wei-Shaw/sub2api/ent/promocodeusage"
 	"github.com/Wei-Shaw/sub2api/ent/redeemcode"
 	"github.com/Wei-Shaw/sub2api/ent/usagelog"
 	"github.com/Wei-Shaw/sub2api/ent/user"
 	"github.com/Wei-Shaw/sub2api/ent/userattributevalue"
+	"github.com/Wei-Shaw/sub2api/ent/userrecoverycode"
 	"github.com/Wei-Shaw/sub2api/ent/usersubscription"
+	"github.com/Wei-Shaw/sub2api/ent/webauthncredential"
 )
 
 // UserCreate is the builder for creating a User entity.
@@ -226,6 +229,68 @@ func (_c *UserCreate) SetNillableTotpEnabledAt(v *time.Time) *UserCreate {
 	return _c
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (_c *UserCreate) SetDedicatedAccountIds(v []int64) *UserCreate {
+	_c.mutation.SetDedicatedAccountIds(v)
+	return _c
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (_c *UserCreate) SetDedicatedAccountsOnly(v bool) *UserCreate {
+	_c.mutation.SetDedicatedAccountsOnly(v)
+	return _c
+}
+
+// SetNillableDedicatedAccountsOnly sets the "dedicated_accounts_only" field if the given value is not nil.
+func (_c *UserCreate) SetNillableDedicatedAccountsOnly(v *bool) *UserCreate {
+	if v != nil {
+		_c.SetDedicatedAccountsOnly(*v)
+	}
+	return _c
+}
+
+// SetCommissionBalance sets the "commission_balance" field.
+func (_c *UserCreate) SetCommissionBalance(v float64) *UserCreate {
+	_c.mutation.SetCommissionBalance(v)
+	return _c
+}
+
+// SetNillableCommissionBalance sets the "commission_balance" field if the given value is not nil.
+func (_c *UserCreate) SetNillableCommissionBalance(v *float64) *UserCreate {
+	if v != nil {
+		_c.SetCommissionBalance(*v)
+	}
+	return _c
+}
+
+// SetRegisterIP sets the "register_ip" field.
+func (_c *UserCreate) SetRegisterIP(v string) *UserCreate {
+	_c.mutation.SetRegisterIP(v)
+	return _c
+}
+
+// SetNillableRegisterIP sets the "register_ip" field if the given value is not nil.
+func (_c *UserCreate) SetNillableRegisterIP(v *string) *UserCreate {
+	if v != nil {
+		_c.SetRegisterIP(*v)
+	}
+	return _c
+}
+
+// SetRegisterDevice sets the "register_device" field.
+func (_c *UserCreate) SetRegisterDevice(v string) *UserCreate {
+	_c.mutation.SetRegisterDevice(v)
+	return _c
+}
+
+// SetNillableRegisterDevice sets the "register_device" field if the given value is not nil.
+func (_c *UserCreate) SetNillableRegisterDevice(v *string) *UserCreate {
+	if v != nil {
+		_c.SetRegisterDevice(*v)
+	}
+	return _c
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_c *UserCreate) AddAPIKeyIDs(ids ...int64) *UserCreate {
 	_c.mutation.AddAPIKeyIDs(ids...)
@@ -455,6 +520,51 @@ func (_c *UserCreate) AddAdminActionLogs(v ...*AdminActionLog) *UserCreate {
 	return _c.AddAdminActionLogIDs(ids...)
 }
 
+// AddOrganizationMembershipIDs adds the "organization_memberships" edge to the OrganizationMember entity by IDs.
+func (_c *UserCreate) AddOrganizationMembershipIDs(ids ...int64) *UserCreate {
+	_c.mutation.AddOrganizationMembershipIDs(ids...)
+	return _c
+}
+
+// AddOrganizationMemberships adds the "organization_memberships" edges to the OrganizationMember entity.
+func (_c *UserCreate) AddOrganizationMemberships(v ...*OrganizationMember) *UserCreate {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddOrganizationMembershipIDs(ids...)
+}
+
+// AddWebauthnCredentialIDs adds the "webauthn_credentials" edge to the WebAuthnCredential entity by IDs.
+func (_c *UserCreate) AddWebauthnCredentialIDs(ids ...int64) *UserCreate {
+	_c.mutation.AddWebauthnCredentialIDs(ids...)
+	return _c
+}
+
+// AddWebauthnCredentials adds the "webauthn_credentials" edges to the WebAuthnCredential entity.
+func (_c *UserCreate) AddWebauthnCredentials(v ...*WebAuthnCredential) *UserCreate {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddWebauthnCredentialIDs(ids...)
+}
+
+// AddRecoveryCodeIDs adds the "recovery_codes" edge to the UserRecoveryCode entity by IDs.
+func (_c *UserCreate) AddRecoveryCodeIDs(ids ...int64) *UserCreate {
+	_c.mutation.AddRecoveryCodeIDs(ids...)
+	return _c
+}
+
+// AddRecoveryCodes adds the "recovery_codes" edges to the UserRecoveryCode entity.
+func (_c *UserCreate) AddRecoveryCodes(v ...*UserRecoveryCode) *UserCreate {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddRecoveryCodeIDs(ids...)
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (_c *UserCreate) Mutation() *UserMutation {
 	return _c.mutation
@@ -534,6 +644,14 @@ func (_c *UserCreate) defaults() error {
 		v := user.DefaultTotpEnabled
 		_c.mutation.SetTotpEnabled(v)
 	}
+	if _, ok := _c.mutation.DedicatedAccountsOnly(); !ok {
+		v := user.DefaultDedicatedAccountsOnly
+		_c.mutation.SetDedicatedAccountsOnly(v)
+	}
+	if _, ok := _c.mutation.CommissionBalance(); !ok {
+		v := user.DefaultCommissionBalance
+		_c.mutation.SetCommissionBalance(v)
+	}
 	return nil
 }
 
@@ -602,6 +720,22 @@ func (_c *UserCreate) check() error {
 	if _, ok := _c.mutation.TotpEnabled(); !ok {
 		return &ValidationError{Name: "totp_enabled", err: errors.New(`ent: missing required field "User.totp_enabled"`)}
 	}
+	if _, ok := _c.mutation.DedicatedAccountsOnly(); !ok {
+		return &ValidationError{Name: "dedicated_accounts_only", err: errors.New(`ent: missing required field "User.dedicated_accounts_only"`)}
+	}
+	if _, ok := _c.mutation.CommissionBalance(); !ok {
+		return &ValidationError{Name: "commission_balance", err: errors.New(`ent: missing required field "User.commission_balance"`)}
+	}
+	if v, ok := _c.mutation.RegisterIP(); ok {
+		if err := user.RegisterIPValidator(v); err != nil {
+			return &ValidationError{Name: "register_ip", err: fmt.Errorf(`ent: validator failed for field "User.register_ip": %w`, err)}
+		}
+	}
+	if v, ok := _c.mutation.RegisterDevice(); ok {
+		if err := user.RegisterDeviceValidator(v); err != nil {
+			return &ValidationError{Name: "register_device", err: fmt.Errorf(`ent: validator failed for field "User.register_device": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -689,6 +823,26 @@ func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		_spec.SetField(user.FieldTotpEnabledAt, field.TypeTime, value)
 		_node.TotpEnabledAt = &value
 	}
+	if value, ok := _c.mutation.DedicatedAccountIds(); ok {
+		_spec.SetField(user.FieldDedicatedAccountIds, field.TypeJSON, value)
+		_node.DedicatedAccountIds = value
+	}
+	if value, ok := _c.mutation.DedicatedAccountsOnly(); ok {
+		_spec.SetField(user.FieldDedicatedAccountsOnly, field.TypeBool, value)
+		_node.DedicatedAccountsOnly = value
+	}
+	if value, ok := _c.mutation.CommissionBalance(); ok {
+		_spec.SetField(user.FieldCommissionBalance, field.TypeFloat64, value)
+		_node.CommissionBalance = value
+	}
+	if value, ok := _c.mutation.RegisterIP(); ok {
+		_spec.SetField(user.FieldRegisterIP, field.TypeString, value)
+		_node.RegisterIP = &value
+	}
+	if value, ok := _c.mutation.RegisterDevice(); ok {
+		_spec.SetField(user.FieldRegisterDevice, field.TypeString, value)
+		_node.RegisterDevice = &value
+	}
 	if nodes := _c.mutation.APIKeysIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -933,6 +1087,54 @@ func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.OrganizationMembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.OrganizationMembershipsTable,
+			Columns: []string{user.OrganizationMembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organizationmember.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.WebauthnCredentialsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.WebauthnCredentialsTable,
+			Columns: []string{user.WebauthnCredentialsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(webauthncredential.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.RecoveryCodesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecoveryCodesTable,
+			Columns: []string{user.RecoveryCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userrecoverycode.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -1189,6 +1391,90 @@ func (u *UserUpsert) ClearTotpEnabledAt() *UserUpsert {
 	return u
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (u *UserUpsert) SetDedicatedAccountIds(v []int64) *UserUpsert {
+	u.Set(user.FieldDedicatedAccountIds, v)
+	return u
+}
+
+// UpdateDedicatedAccountIds sets the "dedicated_account_ids" field to the value that was provided on create.
+func (u *UserUpsert) UpdateDedicatedAccountIds() *UserUpsert {
+	u.SetExcluded(user.FieldDedicatedAccountIds)
+	return u
+}
+
+// ClearDedicatedAccountIds clears the value of the "dedicated_account_ids" field.
+func (u *UserUpsert) ClearDedicatedAccountIds() *UserUpsert {
+	u.SetNull(user.FieldDedicatedAccountIds)
+	return u
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (u *UserUpsert) SetDedicatedAccountsOnly(v bool) *UserUpsert {
+	u.Set(user.FieldDedicatedAccountsOnly, v)
+	return u
+}
+
+// UpdateDedicatedAccountsOnly sets the "dedicated_accounts_only" field to the value that was provided on create.
+func (u *UserUpsert) UpdateDedicatedAccountsOnly() *UserUpsert {
+	u.SetExcluded(user.FieldDedicatedAccountsOnly)
+	return u
+}
+
+// SetCommissionBalance sets the "commission_balance" field.
+func (u *UserUpsert) SetCommissionBalance(v float64) *UserUpsert {
+	u.Set(user.FieldCommissionBalance, v)
+	return u
+}
+
+// UpdateCommissionBalance sets the "commission_balance" field to the value that was provided on create.
+func (u *UserUpsert) UpdateCommissionBalance() *UserUpsert {
+	u.SetExcluded(user.FieldCommissionBalance)
+	return u
+}
+
+// AddCommissionBalance adds v to the "commission_balance" field.
+func (u *UserUpsert) AddCommissionBalance(v float64) *UserUpsert {
+	u.Add(user.FieldCommissionBalance, v)
+	return u
+}
+
+// SetRegisterIP sets the "register_ip" field.
+func (u *UserUpsert) SetRegisterIP(v string) *UserUpsert {
+	u.Set(user.FieldRegisterIP, v)
+	return u
+}
+
+// UpdateRegisterIP sets the "register_ip" field to the value that was provided on create.
+func (u *UserUpsert) UpdateRegisterIP() *UserUpsert {
+	u.SetExcluded(user.FieldRegisterIP)
+	return u
+}
+
+// ClearRegisterIP clears the value of the "register_ip" field.
+func (u *UserUpsert) ClearRegisterIP() *UserUpsert {
+	u.SetNull(user.FieldRegisterIP)
+	return u
+}
+
+// SetRegisterDevice sets the "register_device" field.
+func (u *UserUpsert) SetRegisterDevice(v string) *UserUpsert {
+	u.Set(user.FieldRegisterDevice, v)
+	return u
+}
+
+// UpdateRegisterDevice sets the "register_device" field to the value that was provided on create.
+func (u *UserUpsert) UpdateRegisterDevice() *UserUpsert {
+	u.SetExcluded(user.FieldRegisterDevice)
+	return u
+}
+
+// ClearRegisterDevice clears the value of the "register_device" field.
+func (u *UserUpsert) ClearRegisterDevice() *UserUpsert {
+	u.SetNull(user.FieldRegisterDevice)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -1472,6 +1758,104 @@ func (u *UserUpsertOne) ClearTotpEnabledAt() *UserUpsertOne {
 	})
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (u *UserUpsertOne) SetDedicatedAccountIds(v []int64) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetDedicatedAccountIds(v)
+	})
+}
+
+// UpdateDedicatedAccountIds sets the "dedicated_account_ids" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateDedicatedAccountIds() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateDedicatedAccountIds()
+	})
+}
+
+// ClearDedicatedAccountIds clears the value of the "dedicated_account_ids" field.
+func (u *UserUpsertOne) ClearDedicatedAccountIds() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearDedicatedAccountIds()
+	})
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (u *UserUpsertOne) SetDedicatedAccountsOnly(v bool) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetDedicatedAccountsOnly(v)
+	})
+}
+
+// UpdateDedicatedAccountsOnly sets the "dedicated_accounts_only" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateDedicatedAccountsOnly() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateDedicatedAccountsOnly()
+	})
+}
+
+// SetCommissionBalance sets the "commission_balance" field.
+func (u *UserUpsertOne) SetCommissionBalance(v float64) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetCommissionBalance(v)
+	})
+}
+
+// AddCommissionBalance adds v to the "commission_balance" field.
+func (u *UserUpsertOne) AddCommissionBalance(v float64) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.AddCommissionBalance(v)
+	})
+}
+
+// UpdateCommissionBalance sets the "commission_balance" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateCommissionBalance() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateCommissionBalance()
+	})
+}
+
+// SetRegisterIP sets the "register_ip" field.
+func (u *UserUpsertOne) SetRegisterIP(v string) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetRegisterIP(v)
+	})
+}
+
+// UpdateRegisterIP sets the "register_ip" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateRegisterIP() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateRegisterIP()
+	})
+}
+
+// ClearRegisterIP clears the value of the "register_ip" field.
+func (u *UserUpsertOne) ClearRegisterIP() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearRegisterIP()
+	})
+}
+
+// SetRegisterDevice sets the "register_device" field.
+func (u *UserUpsertOne) SetRegisterDevice(v string) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetRegisterDevice(v)
+	})
+}
+
+// UpdateRegisterDevice sets the "register_device" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateRegisterDevice() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateRegisterDevice()
+	})
+}
+
+// ClearRegisterDevice clears the value of the "register_device" field.
+func (u *UserUpsertOne) ClearRegisterDevice() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearRegisterDevice()
+	})
+}
+
 // Exec executes the query.
 func (u *UserUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -1921,6 +2305,104 @@ func (u *UserUpsertBulk) ClearTotpEnabledAt() *UserUpsertBulk {
 	})
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (u *UserUpsertBulk) SetDedicatedAccountIds(v []int64) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetDedicatedAccountIds(v)
+	})
+}
+
+// UpdateDedicatedAccountIds sets the "dedicated_account_ids" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateDedicatedAccountIds() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateDedicatedAccountIds()
+	})
+}
+
+// ClearDedicatedAccountIds clears the value of the "dedicated_account_ids" field.
+func (u *UserUpsertBulk) ClearDedicatedAccountIds() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearDedicatedAccountIds()
+	})
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (u *UserUpsertBulk) SetDedicatedAccountsOnly(v bool) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetDedicatedAccountsOnly(v)
+	})
+}
+
+// UpdateDedicatedAccountsOnly sets the "dedicated_accounts_only" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateDedicatedAccountsOnly() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateDedicatedAccountsOnly()
+	})
+}
+
+// SetCommissionBalance sets the "commission_balance" field.
+func (u *UserUpsertBulk) SetCommissionBalance(v float64) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetCommissionBalance(v)
+	})
+}
+
+// AddCommissionBalance adds v to the "commission_balance" field.
+func (u *UserUpsertBulk) AddCommissionBalance(v float64) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.AddCommissionBalance(v)
+	})
+}
+
+// UpdateCommissionBalance sets the "commission_balance" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateCommissionBalance() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateCommissionBalance()
+	})
+}
+
+// SetRegisterIP sets the "register_ip" field.
+func (u *UserUpsertBulk) SetRegisterIP(v string) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetRegisterIP(v)
+	})
+}
+
+// UpdateRegisterIP sets the "register_ip" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateRegisterIP() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateRegisterIP()
+	})
+}
+
+// ClearRegisterIP clears the value of the "register_ip" field.
+func (u *UserUpsertBulk) ClearRegisterIP() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearRegisterIP()
+	})
+}
+
+// SetRegisterDevice sets the "register_device" field.
+func (u *UserUpsertBulk) SetRegisterDevice(v string) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetRegisterDevice(v)
+	})
+}
+
+// UpdateRegisterDevice sets the "register_device" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateRegisterDevice() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateRegisterDevice()
+	})
+}
+
+// ClearRegisterDevice clears the value of the "register_device" field.
+func (u *UserUpsertBulk) ClearRegisterDevice() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearRegisterDevice()
+	})
+}
+
 // Exec executes the query.
 func (u *UserUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
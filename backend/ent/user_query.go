@@ -18,6 +18,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/group"
 	"github.com/Wei-Shaw/sub2api/ent/invitation"
 	"github.com/Wei-Shaw/sub2api/ent/invitelog"
+	"github.com/Wei-Shaw/sub2api/ent/organizationmember"
 	"github.com/Wei-Shaw/sub2api/ent/predicate"
 	"github.com/Wei-Shaw/sub2api/ent/promocodeusage"
 	"github.com/Wei-Shaw/sub2api/ent/redeemcode"
@@ -25,33 +26,38 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/user"
 	"github.com/Wei-Shaw/sub2api/ent/userallowedgroup"
 	"github.com/Wei-Shaw/sub2api/ent/userattributevalue"
+	"github.com/Wei-Shaw/sub2api/ent/userrecoverycode"
 	"github.com/Wei-Shaw/sub2api/ent/usersubscription"
+	"github.com/Wei-Shaw/sub2api/ent/webauthncredential"
 )
 
 // UserQuery is the builder for querying User entities.
 type UserQuery struct {
 	config
-	ctx                       *QueryContext
-	order                     []user.OrderOption
-	inters                    []Interceptor
-	predicates                []predicate.User
-	withAPIKeys               *APIKeyQuery
-	withRedeemCodes           *RedeemCodeQuery
-	withSubscriptions         *UserSubscriptionQuery
-	withAssignedSubscriptions *UserSubscriptionQuery
-	withAllowedGroups         *GroupQuery
-	withUsageLogs             *UsageLogQuery
-	withAttributeValues       *UserAttributeValueQuery
-	withPromoCodeUsages       *PromoCodeUsageQuery
-	withSentInvitations       *InvitationQuery
-	withReceivedInvitation    *InvitationQuery
-	withConfirmedInvites      *InvitationQuery
-	withInviteLogsAsInviter   *InviteLogQuery
-	withInviteLogsAsInvitee   *InviteLogQuery
-	withInviteLogsAsAdmin     *InviteLogQuery
-	withAdminActionLogs       *AdminActionLogQuery
-	withUserAllowedGroups     *UserAllowedGroupQuery
-	modifiers                 []func(*sql.Selector)
+	ctx                         *QueryContext
+	order                       []user.OrderOption
+	inters                      []Interceptor
+	predicates                  []predicate.User
+	withAPIKeys                 *APIKeyQuery
+	withRedeemCodes             *RedeemCodeQuery
+	withSubscriptions           *UserSubscriptionQuery
+	withAssignedSubscriptions   *UserSubscriptionQuery
+	withAllowedGroups           *GroupQuery
+	withUsageLogs               *UsageLogQuery
+	withAttributeValues         *UserAttributeValueQuery
+	withPromoCodeUsages         *PromoCodeUsageQuery
+	withSentInvitations         *InvitationQuery
+	withReceivedInvitation      *InvitationQuery
+	withConfirmedInvites        *InvitationQuery
+	withInviteLogsAsInviter     *InviteLogQuery
+	withInviteLogsAsInvitee     *InviteLogQuery
+	withInviteLogsAsAdmin       *InviteLogQuery
+	withAdminActionLogs         *AdminActionLogQuery
+	withOrganizationMemberships *OrganizationMemberQuery
+	withWebauthnCredentials     *WebAuthnCredentialQuery
+	withRecoveryCodes           *UserRecoveryCodeQuery
+	withUserAllowedGroups       *UserAllowedGroupQuery
+	modifiers                   []func(*sql.Selector)
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -418,6 +424,72 @@ func (_q *UserQuery) QueryAdminActionLogs() *AdminActionLogQuery {
 	return query
 }
 
+// QueryOrganizationMemberships chains the current query on the "organization_memberships" edge.
+func (_q *UserQuery) QueryOrganizationMemberships() *OrganizationMemberQuery {
+	query := (&OrganizationMemberClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(user.Table, user.FieldID, selector),
+			sqlgraph.To(organizationmember.Table, organizationmember.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, user.OrganizationMembershipsTable, user.OrganizationMembershipsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryWebauthnCredentials chains the current query on the "webauthn_credentials" edge.
+func (_q *UserQuery) QueryWebauthnCredentials() *WebAuthnCredentialQuery {
+	query := (&WebAuthnCredentialClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(user.Table, user.FieldID, selector),
+			sqlgraph.To(webauthncredential.Table, webauthncredential.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, user.WebauthnCredentialsTable, user.WebauthnCredentialsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryRecoveryCodes chains the current query on the "recovery_codes" edge.
+func (_q *UserQuery) QueryRecoveryCodes() *UserRecoveryCodeQuery {
+	query := (&UserRecoveryCodeClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(user.Table, user.FieldID, selector),
+			sqlgraph.To(userrecoverycode.Table, userrecoverycode.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, user.RecoveryCodesTable, user.RecoveryCodesColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // QueryUserAllowedGroups chains the current query on the "user_allowed_groups" edge.
 func (_q *UserQuery) QueryUserAllowedGroups() *UserAllowedGroupQuery {
 	query := (&UserAllowedGroupClient{config: _q.config}).Query()
@@ -627,27 +699,30 @@ func (_q *UserQuery) Clone() *UserQuery {
 		return nil
 	}
 	return &UserQuery{
-		config:                    _q.config,
-		ctx:                       _q.ctx.Clone(),
-		order:                     append([]user.OrderOption{}, _q.order...),
-		inters:                    append([]Interceptor{}, _q.inters...),
-		predicates:                append([]predicate.User{}, _q.predicates...),
-		withAPIKeys:               _q.withAPIKeys.Clone(),
-		withRedeemCodes:           _q.withRedeemCodes.Clone(),
-		withSubscriptions:         _q.withSubscriptions.Clone(),
-		withAssignedSubscriptions: _q.withAssignedSubscriptions.Clone(),
-		withAllowedGroups:         _q.withAllowedGroups.Clone(),
-		withUsageLogs:             _q.withUsageLogs.Clone(),
-		withAttributeValues:       _q.withAttributeValues.Clone(),
-		withPromoCodeUsages:       _q.withPromoCodeUsages.Clone(),
-		withSentInvitations:       _q.withSentInvitations.Clone(),
-		withReceivedInvitation:    _q.withReceivedInvitation.Clone(),
-		withConfirmedInvites:      _q.withConfirmedInvites.Clone(),
-		withInviteLogsAsInviter:   _q.withInviteLogsAsInviter.Clone(),
-		withInviteLogsAsInvitee:   _q.withInviteLogsAsInvitee.Clone(),
-		withInviteLogsAsAdmin:     _q.withInviteLogsAsAdmin.Clone(),
-		withAdminActionLogs:       _q.withAdminActionLogs.Clone(),
-		withUserAllowedGroups:     _q.withUserAllowedGroups.Clone(),
+		config:                      _q.config,
+		ctx:                         _q.ctx.Clone(),
+		order:                       append([]user.OrderOption{}, _q.order...),
+		inters:                      append([]Interceptor{}, _q.inters...),
+		predicates:                  append([]predicate.User{}, _q.predicates...),
+		withAPIKeys:                 _q.withAPIKeys.Clone(),
+		withRedeemCodes:             _q.withRedeemCodes.Clone(),
+		withSubscriptions:           _q.withSubscriptions.Clone(),
+		withAssignedSubscriptions:   _q.withAssignedSubscriptions.Clone(),
+		withAllowedGroups:           _q.withAllowedGroups.Clone(),
+		withUsageLogs:               _q.withUsageLogs.Clone(),
+		withAttributeValues:         _q.withAttributeValues.Clone(),
+		withPromoCodeUsages:         _q.withPromoCodeUsages.Clone(),
+		withSentInvitations:         _q.withSentInvitations.Clone(),
+		withReceivedInvitation:      _q.withReceivedInvitation.Clone(),
+		withConfirmedInvites:        _q.withConfirmedInvites.Clone(),
+		withInviteLogsAsInviter:     _q.withInviteLogsAsInviter.Clone(),
+		withInviteLogsAsInvitee:     _q.withInviteLogsAsInvitee.Clone(),
+		withInviteLogsAsAdmin:       _q.withInviteLogsAsAdmin.Clone(),
+		withAdminActionLogs:         _q.withAdminActionLogs.Clone(),
+		withOrganizationMemberships: _q.withOrganizationMemberships.Clone(),
+		withWebauthnCredentials:     _q.withWebauthnCredentials.Clone(),
+		withRecoveryCodes:           _q.withRecoveryCodes.Clone(),
+		withUserAllowedGroups:       _q.withUserAllowedGroups.Clone(),
 		// clone intermediate query.
 		sql:  _q.sql.Clone(),
 		path: _q.path,
@@ -819,6 +894,39 @@ func (_q *UserQuery) WithAdminActionLogs(opts ...func(*AdminActionLogQuery)) *Us
 	return _q
 }
 
+// WithOrganizationMemberships tells the query-builder to eager-load the nodes that are connected to
+// the "organization_memberships" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *UserQuery) WithOrganizationMemberships(opts ...func(*OrganizationMemberQuery)) *UserQuery {
+	query := (&OrganizationMemberClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withOrganizationMemberships = query
+	return _q
+}
+
+// WithWebauthnCredentials tells the query-builder to eager-load the nodes that are connected to
+// the "webauthn_credentials" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *UserQuery) WithWebauthnCredentials(opts ...func(*WebAuthnCredentialQuery)) *UserQuery {
+	query := (&WebAuthnCredentialClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withWebauthnCredentials = query
+	return _q
+}
+
+// WithRecoveryCodes tells the query-builder to eager-load the nodes that are connected to
+// the "recovery_codes" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *UserQuery) WithRecoveryCodes(opts ...func(*UserRecoveryCodeQuery)) *UserQuery {
+	query := (&UserRecoveryCodeClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withRecoveryCodes = query
+	return _q
+}
+
 // WithUserAllowedGroups tells the query-builder to eager-load the nodes that are connected to
 // the "user_allowed_groups" edge. The optional arguments are used to configure the query builder of the edge.
 func (_q *UserQuery) WithUserAllowedGroups(opts ...func(*UserAllowedGroupQuery)) *UserQuery {
@@ -908,7 +1016,7 @@ func (_q *UserQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*User, e
 	var (
 		nodes       = []*User{}
 		_spec       = _q.querySpec()
-		loadedTypes = [16]bool{
+		loadedTypes = [19]bool{
 			_q.withAPIKeys != nil,
 			_q.withRedeemCodes != nil,
 			_q.withSubscriptions != nil,
@@ -924,6 +1032,9 @@ func (_q *UserQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*User, e
 			_q.withInviteLogsAsInvitee != nil,
 			_q.withInviteLogsAsAdmin != nil,
 			_q.withAdminActionLogs != nil,
+			_q.withOrganizationMemberships != nil,
+			_q.withWebauthnCredentials != nil,
+			_q.withRecoveryCodes != nil,
 			_q.withUserAllowedGroups != nil,
 		}
 	)
@@ -1054,6 +1165,31 @@ func (_q *UserQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*User, e
 			return nil, err
 		}
 	}
+	if query := _q.withOrganizationMemberships; query != nil {
+		if err := _q.loadOrganizationMemberships(ctx, query, nodes,
+			func(n *User) { n.Edges.OrganizationMemberships = []*OrganizationMember{} },
+			func(n *User, e *OrganizationMember) {
+				n.Edges.OrganizationMemberships = append(n.Edges.OrganizationMemberships, e)
+			}); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withWebauthnCredentials; query != nil {
+		if err := _q.loadWebauthnCredentials(ctx, query, nodes,
+			func(n *User) { n.Edges.WebauthnCredentials = []*WebAuthnCredential{} },
+			func(n *User, e *WebAuthnCredential) {
+				n.Edges.WebauthnCredentials = append(n.Edges.WebauthnCredentials, e)
+			}); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withRecoveryCodes; query != nil {
+		if err := _q.loadRecoveryCodes(ctx, query, nodes,
+			func(n *User) { n.Edges.RecoveryCodes = []*UserRecoveryCode{} },
+			func(n *User, e *UserRecoveryCode) { n.Edges.RecoveryCodes = append(n.Edges.RecoveryCodes, e) }); err != nil {
+			return nil, err
+		}
+	}
 	if query := _q.withUserAllowedGroups; query != nil {
 		if err := _q.loadUserAllowedGroups(ctx, query, nodes,
 			func(n *User) { n.Edges.UserAllowedGroups = []*UserAllowedGroup{} },
@@ -1557,6 +1693,96 @@ func (_q *UserQuery) loadAdminActionLogs(ctx context.Context, query *AdminAction
 	}
 	return nil
 }
+func (_q *UserQuery) loadOrganizationMemberships(ctx context.Context, query *OrganizationMemberQuery, nodes []*User, init func(*User), assign func(*User, *OrganizationMember)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*User)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(organizationmember.FieldUserID)
+	}
+	query.Where(predicate.OrganizationMember(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(user.OrganizationMembershipsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.UserID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "user_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (_q *UserQuery) loadWebauthnCredentials(ctx context.Context, query *WebAuthnCredentialQuery, nodes []*User, init func(*User), assign func(*User, *WebAuthnCredential)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*User)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(webauthncredential.FieldUserID)
+	}
+	query.Where(predicate.WebAuthnCredential(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(user.WebauthnCredentialsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.UserID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "user_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (_q *UserQuery) loadRecoveryCodes(ctx context.Context, query *UserRecoveryCodeQuery, nodes []*User, init func(*User), assign func(*User, *UserRecoveryCode)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*User)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(userrecoverycode.FieldUserID)
+	}
+	query.Where(predicate.UserRecoveryCode(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(user.RecoveryCodesColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.UserID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "user_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 func (_q *UserQuery) loadUserAllowedGroups(ctx context.Context, query *UserAllowedGroupQuery, nodes []*User, init func(*User), assign func(*User, *UserAllowedGroup)) error {
 	fks := make([]driver.Value, 0, len(nodes))
 	nodeids := make(map[int64]*User)
@@ -10,19 +10,23 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 	"github.com/Wei-Shaw/sub2api/ent/adminactionlog"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
 	"github.com/Wei-Shaw/sub2api/ent/group"
 	"github.com/Wei-Shaw/sub2api/ent/invitation"
 	"github.com/Wei-Shaw/sub2api/ent/invitelog"
+	"github.com/Wei-Shaw/sub2api/ent/organizationmember"
 	"github.com/Wei-Shaw/sub2api/ent/predicate"
 	"github.com/Wei-Shaw/sub2api/ent/promocodeusage"
 	"github.com/Wei-Shaw/sub2api/ent/redeemcode"
 	"github.com/Wei-Shaw/sub2api/ent/usagelog"
 	"github.com/Wei-Shaw/sub2api/ent/user"
 	"github.com/Wei-Shaw/sub2api/ent/userattributevalue"
+	"github.com/Wei-Shaw/sub2api/ent/userrecoverycode"
 	"github.com/Wei-Shaw/sub2api/ent/usersubscription"
+	"github.com/Wei-Shaw/sub2api/ent/webauthncredential"
 )
 
 // UserUpdate is the builder for updating User entities.
@@ -264,6 +268,99 @@ func (_u *UserUpdate) ClearTotpEnabledAt() *UserUpdate {
 	return _u
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (_u *UserUpdate) SetDedicatedAccountIds(v []int64) *UserUpdate {
+	_u.mutation.SetDedicatedAccountIds(v)
+	return _u
+}
+
+// AppendDedicatedAccountIds appends value to the "dedicated_account_ids" field.
+func (_u *UserUpdate) AppendDedicatedAccountIds(v []int64) *UserUpdate {
+	_u.mutation.AppendDedicatedAccountIds(v)
+	return _u
+}
+
+// ClearDedicatedAccountIds clears the value of the "dedicated_account_ids" field.
+func (_u *UserUpdate) ClearDedicatedAccountIds() *UserUpdate {
+	_u.mutation.ClearDedicatedAccountIds()
+	return _u
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (_u *UserUpdate) SetDedicatedAccountsOnly(v bool) *UserUpdate {
+	_u.mutation.SetDedicatedAccountsOnly(v)
+	return _u
+}
+
+// SetNillableDedicatedAccountsOnly sets the "dedicated_accounts_only" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableDedicatedAccountsOnly(v *bool) *UserUpdate {
+	if v != nil {
+		_u.SetDedicatedAccountsOnly(*v)
+	}
+	return _u
+}
+
+// SetCommissionBalance sets the "commission_balance" field.
+func (_u *UserUpdate) SetCommissionBalance(v float64) *UserUpdate {
+	_u.mutation.ResetCommissionBalance()
+	_u.mutation.SetCommissionBalance(v)
+	return _u
+}
+
+// SetNillableCommissionBalance sets the "commission_balance" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableCommissionBalance(v *float64) *UserUpdate {
+	if v != nil {
+		_u.SetCommissionBalance(*v)
+	}
+	return _u
+}
+
+// AddCommissionBalance adds value to the "commission_balance" field.
+func (_u *UserUpdate) AddCommissionBalance(v float64) *UserUpdate {
+	_u.mutation.AddCommissionBalance(v)
+	return _u
+}
+
+// SetRegisterIP sets the "register_ip" field.
+func (_u *UserUpdate) SetRegisterIP(v string) *UserUpdate {
+	_u.mutation.SetRegisterIP(v)
+	return _u
+}
+
+// SetNillableRegisterIP sets the "register_ip" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableRegisterIP(v *string) *UserUpdate {
+	if v != nil {
+		_u.SetRegisterIP(*v)
+	}
+	return _u
+}
+
+// ClearRegisterIP clears the value of the "register_ip" field.
+func (_u *UserUpdate) ClearRegisterIP() *UserUpdate {
+	_u.mutation.ClearRegisterIP()
+	return _u
+}
+
+// SetRegisterDevice sets the "register_device" field.
+func (_u *UserUpdate) SetRegisterDevice(v string) *UserUpdate {
+	_u.mutation.SetRegisterDevice(v)
+	return _u
+}
+
+// SetNillableRegisterDevice sets the "register_device" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableRegisterDevice(v *string) *UserUpdate {
+	if v != nil {
+		_u.SetRegisterDevice(*v)
+	}
+	return _u
+}
+
+// ClearRegisterDevice clears the value of the "register_device" field.
+func (_u *UserUpdate) ClearRegisterDevice() *UserUpdate {
+	_u.mutation.ClearRegisterDevice()
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *UserUpdate) AddAPIKeyIDs(ids ...int64) *UserUpdate {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -493,6 +590,51 @@ func (_u *UserUpdate) AddAdminActionLogs(v ...*AdminActionLog) *UserUpdate {
 	return _u.AddAdminActionLogIDs(ids...)
 }
 
+// AddOrganizationMembershipIDs adds the "organization_memberships" edge to the OrganizationMember entity by IDs.
+func (_u *UserUpdate) AddOrganizationMembershipIDs(ids ...int64) *UserUpdate {
+	_u.mutation.AddOrganizationMembershipIDs(ids...)
+	return _u
+}
+
+// AddOrganizationMemberships adds the "organization_memberships" edges to the OrganizationMember entity.
+func (_u *UserUpdate) AddOrganizationMemberships(v ...*OrganizationMember) *UserUpdate {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOrganizationMembershipIDs(ids...)
+}
+
+// AddWebauthnCredentialIDs adds the "webauthn_credentials" edge to the WebAuthnCredential entity by IDs.
+func (_u *UserUpdate) AddWebauthnCredentialIDs(ids ...int64) *UserUpdate {
+	_u.mutation.AddWebauthnCredentialIDs(ids...)
+	return _u
+}
+
+// AddWebauthnCredentials adds the "webauthn_credentials" edges to the WebAuthnCredential entity.
+func (_u *UserUpdate) AddWebauthnCredentials(v ...*WebAuthnCredential) *UserUpdate {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddWebauthnCredentialIDs(ids...)
+}
+
+// AddRecoveryCodeIDs adds the "recovery_codes" edge to the UserRecoveryCode entity by IDs.
+func (_u *UserUpdate) AddRecoveryCodeIDs(ids ...int64) *UserUpdate {
+	_u.mutation.AddRecoveryCodeIDs(ids...)
+	return _u
+}
+
+// AddRecoveryCodes adds the "recovery_codes" edges to the UserRecoveryCode entity.
+func (_u *UserUpdate) AddRecoveryCodes(v ...*UserRecoveryCode) *UserUpdate {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddRecoveryCodeIDs(ids...)
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (_u *UserUpdate) Mutation() *UserMutation {
 	return _u.mutation
@@ -798,6 +940,69 @@ func (_u *UserUpdate) RemoveAdminActionLogs(v ...*AdminActionLog) *UserUpdate {
 	return _u.RemoveAdminActionLogIDs(ids...)
 }
 
+// ClearOrganizationMemberships clears all "organization_memberships" edges to the OrganizationMember entity.
+func (_u *UserUpdate) ClearOrganizationMemberships() *UserUpdate {
+	_u.mutation.ClearOrganizationMemberships()
+	return _u
+}
+
+// RemoveOrganizationMembershipIDs removes the "organization_memberships" edge to OrganizationMember entities by IDs.
+func (_u *UserUpdate) RemoveOrganizationMembershipIDs(ids ...int64) *UserUpdate {
+	_u.mutation.RemoveOrganizationMembershipIDs(ids...)
+	return _u
+}
+
+// RemoveOrganizationMemberships removes "organization_memberships" edges to OrganizationMember entities.
+func (_u *UserUpdate) RemoveOrganizationMemberships(v ...*OrganizationMember) *UserUpdate {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveOrganizationMembershipIDs(ids...)
+}
+
+// ClearWebauthnCredentials clears all "webauthn_credentials" edges to the WebAuthnCredential entity.
+func (_u *UserUpdate) ClearWebauthnCredentials() *UserUpdate {
+	_u.mutation.ClearWebauthnCredentials()
+	return _u
+}
+
+// RemoveWebauthnCredentialIDs removes the "webauthn_credentials" edge to WebAuthnCredential entities by IDs.
+func (_u *UserUpdate) RemoveWebauthnCredentialIDs(ids ...int64) *UserUpdate {
+	_u.mutation.RemoveWebauthnCredentialIDs(ids...)
+	return _u
+}
+
+// RemoveWebauthnCredentials removes "webauthn_credentials" edges to WebAuthnCredential entities.
+func (_u *UserUpdate) RemoveWebauthnCredentials(v ...*WebAuthnCredential) *UserUpdate {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveWebauthnCredentialIDs(ids...)
+}
+
+// ClearRecoveryCodes clears all "recovery_codes" edges to the UserRecoveryCode entity.
+func (_u *UserUpdate) ClearRecoveryCodes() *UserUpdate {
+	_u.mutation.ClearRecoveryCodes()
+	return _u
+}
+
+// RemoveRecoveryCodeIDs removes the "recovery_codes" edge to UserRecoveryCode entities by IDs.
+func (_u *UserUpdate) RemoveRecoveryCodeIDs(ids ...int64) *UserUpdate {
+	_u.mutation.RemoveRecoveryCodeIDs(ids...)
+	return _u
+}
+
+// RemoveRecoveryCodes removes "recovery_codes" edges to UserRecoveryCode entities.
+func (_u *UserUpdate) RemoveRecoveryCodes(v ...*UserRecoveryCode) *UserUpdate {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveRecoveryCodeIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *UserUpdate) Save(ctx context.Context) (int, error) {
 	if err := _u.defaults(); err != nil {
@@ -872,6 +1077,16 @@ func (_u *UserUpdate) check() error {
 			return &ValidationError{Name: "username", err: fmt.Errorf(`ent: validator failed for field "User.username": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.RegisterIP(); ok {
+		if err := user.RegisterIPValidator(v); err != nil {
+			return &ValidationError{Name: "register_ip", err: fmt.Errorf(`ent: validator failed for field "User.register_ip": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.RegisterDevice(); ok {
+		if err := user.RegisterDeviceValidator(v); err != nil {
+			return &ValidationError{Name: "register_device", err: fmt.Errorf(`ent: validator failed for field "User.register_device": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -947,6 +1162,38 @@ func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.TotpEnabledAtCleared() {
 		_spec.ClearField(user.FieldTotpEnabledAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.DedicatedAccountIds(); ok {
+		_spec.SetField(user.FieldDedicatedAccountIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedDedicatedAccountIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, user.FieldDedicatedAccountIds, value)
+		})
+	}
+	if _u.mutation.DedicatedAccountIdsCleared() {
+		_spec.ClearField(user.FieldDedicatedAccountIds, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.DedicatedAccountsOnly(); ok {
+		_spec.SetField(user.FieldDedicatedAccountsOnly, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.CommissionBalance(); ok {
+		_spec.SetField(user.FieldCommissionBalance, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedCommissionBalance(); ok {
+		_spec.AddField(user.FieldCommissionBalance, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.RegisterIP(); ok {
+		_spec.SetField(user.FieldRegisterIP, field.TypeString, value)
+	}
+	if _u.mutation.RegisterIPCleared() {
+		_spec.ClearField(user.FieldRegisterIP, field.TypeString)
+	}
+	if value, ok := _u.mutation.RegisterDevice(); ok {
+		_spec.SetField(user.FieldRegisterDevice, field.TypeString, value)
+	}
+	if _u.mutation.RegisterDeviceCleared() {
+		_spec.ClearField(user.FieldRegisterDevice, field.TypeString)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1618,6 +1865,141 @@ func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OrganizationMembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.OrganizationMembershipsTable,
+			Columns: []string{user.OrganizationMembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organizationmember.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOrganizationMembershipsIDs(); len(nodes) > 0 && !_u.mutation.OrganizationMembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.OrganizationMembershipsTable,
+			Columns: []string{user.OrganizationMembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organizationmember.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OrganizationMembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.OrganizationMembershipsTable,
+			Columns: []string{user.OrganizationMembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organizationmember.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.WebauthnCredentialsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.WebauthnCredentialsTable,
+			Columns: []string{user.WebauthnCredentialsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(webauthncredential.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedWebauthnCredentialsIDs(); len(nodes) > 0 && !_u.mutation.WebauthnCredentialsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.WebauthnCredentialsTable,
+			Columns: []string{user.WebauthnCredentialsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(webauthncredential.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.WebauthnCredentialsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.WebauthnCredentialsTable,
+			Columns: []string{user.WebauthnCredentialsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(webauthncredential.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.RecoveryCodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecoveryCodesTable,
+			Columns: []string{user.RecoveryCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userrecoverycode.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedRecoveryCodesIDs(); len(nodes) > 0 && !_u.mutation.RecoveryCodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecoveryCodesTable,
+			Columns: []string{user.RecoveryCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userrecoverycode.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RecoveryCodesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecoveryCodesTable,
+			Columns: []string{user.RecoveryCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userrecoverycode.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{user.Label}
@@ -1864,6 +2246,99 @@ func (_u *UserUpdateOne) ClearTotpEnabledAt() *UserUpdateOne {
 	return _u
 }
 
+// SetDedicatedAccountIds sets the "dedicated_account_ids" field.
+func (_u *UserUpdateOne) SetDedicatedAccountIds(v []int64) *UserUpdateOne {
+	_u.mutation.SetDedicatedAccountIds(v)
+	return _u
+}
+
+// AppendDedicatedAccountIds appends value to the "dedicated_account_ids" field.
+func (_u *UserUpdateOne) AppendDedicatedAccountIds(v []int64) *UserUpdateOne {
+	_u.mutation.AppendDedicatedAccountIds(v)
+	return _u
+}
+
+// ClearDedicatedAccountIds clears the value of the "dedicated_account_ids" field.
+func (_u *UserUpdateOne) ClearDedicatedAccountIds() *UserUpdateOne {
+	_u.mutation.ClearDedicatedAccountIds()
+	return _u
+}
+
+// SetDedicatedAccountsOnly sets the "dedicated_accounts_only" field.
+func (_u *UserUpdateOne) SetDedicatedAccountsOnly(v bool) *UserUpdateOne {
+	_u.mutation.SetDedicatedAccountsOnly(v)
+	return _u
+}
+
+// SetNillableDedicatedAccountsOnly sets the "dedicated_accounts_only" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableDedicatedAccountsOnly(v *bool) *UserUpdateOne {
+	if v != nil {
+		_u.SetDedicatedAccountsOnly(*v)
+	}
+	return _u
+}
+
+// SetCommissionBalance sets the "commission_balance" field.
+func (_u *UserUpdateOne) SetCommissionBalance(v float64) *UserUpdateOne {
+	_u.mutation.ResetCommissionBalance()
+	_u.mutation.SetCommissionBalance(v)
+	return _u
+}
+
+// SetNillableCommissionBalance sets the "commission_balance" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableCommissionBalance(v *float64) *UserUpdateOne {
+	if v != nil {
+		_u.SetCommissionBalance(*v)
+	}
+	return _u
+}
+
+// AddCommissionBalance adds value to the "commission_balance" field.
+func (_u *UserUpdateOne) AddCommissionBalance(v float64) *UserUpdateOne {
+	_u.mutation.AddCommissionBalance(v)
+	return _u
+}
+
+// SetRegisterIP sets the "register_ip" field.
+func (_u *UserUpdateOne) SetRegisterIP(v string) *UserUpdateOne {
+	_u.mutation.SetRegisterIP(v)
+	return _u
+}
+
+// SetNillableRegisterIP sets the "register_ip" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableRegisterIP(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetRegisterIP(*v)
+	}
+	return _u
+}
+
+// ClearRegisterIP clears the value of the "register_ip" field.
+func (_u *UserUpdateOne) ClearRegisterIP() *UserUpdateOne {
+	_u.mutation.ClearRegisterIP()
+	return _u
+}
+
+// SetRegisterDevice sets the "register_device" field.
+func (_u *UserUpdateOne) SetRegisterDevice(v string) *UserUpdateOne {
+	_u.mutation.SetRegisterDevice(v)
+	return _u
+}
+
+// SetNillableRegisterDevice sets the "register_device" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableRegisterDevice(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetRegisterDevice(*v)
+	}
+	return _u
+}
+
+// ClearRegisterDevice clears the value of the "register_device" field.
+func (_u *UserUpdateOne) ClearRegisterDevice() *UserUpdateOne {
+	_u.mutation.ClearRegisterDevice()
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *UserUpdateOne) AddAPIKeyIDs(ids ...int64) *UserUpdateOne {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -2093,6 +2568,51 @@ func (_u *UserUpdateOne) AddAdminActionLogs(v ...*AdminActionLog) *UserUpdateOne
 	return _u.AddAdminActionLogIDs(ids...)
 }
 
+// AddOrganizationMembershipIDs adds the "organization_memberships" edge to the OrganizationMember entity by IDs.
+func (_u *UserUpdateOne) AddOrganizationMembershipIDs(ids ...int64) *UserUpdateOne {
+	_u.mutation.AddOrganizationMembershipIDs(ids...)
+	return _u
+}
+
+// AddOrganizationMemberships adds the "organization_memberships" edges to the OrganizationMember entity.
+func (_u *UserUpdateOne) AddOrganizationMemberships(v ...*OrganizationMember) *UserUpdateOne {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOrganizationMembershipIDs(ids...)
+}
+
+// AddWebauthnCredentialIDs adds the "webauthn_credentials" edge to the WebAuthnCredential entity by IDs.
+func (_u *UserUpdateOne) AddWebauthnCredentialIDs(ids ...int64) *UserUpdateOne {
+	_u.mutation.AddWebauthnCredentialIDs(ids...)
+	return _u
+}
+
+// AddWebauthnCredentials adds the "webauthn_credentials" edges to the WebAuthnCredential entity.
+func (_u *UserUpdateOne) AddWebauthnCredentials(v ...*WebAuthnCredential) *UserUpdateOne {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddWebauthnCredentialIDs(ids...)
+}
+
+// AddRecoveryCodeIDs adds the "recovery_codes" edge to the UserRecoveryCode entity by IDs.
+func (_u *UserUpdateOne) AddRecoveryCodeIDs(ids ...int64) *UserUpdateOne {
+	_u.mutation.AddRecoveryCodeIDs(ids...)
+	return _u
+}
+
+// AddRecoveryCodes adds the "recovery_codes" edges to the UserRecoveryCode entity.
+func (_u *UserUpdateOne) AddRecoveryCodes(v ...*UserRecoveryCode) *UserUpdateOne {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddRecoveryCodeIDs(ids...)
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (_u *UserUpdateOne) Mutation() *UserMutation {
 	return _u.mutation
@@ -2398,6 +2918,69 @@ func (_u *UserUpdateOne) RemoveAdminActionLogs(v ...*AdminActionLog) *UserUpdate
 	return _u.RemoveAdminActionLogIDs(ids...)
 }
 
+// ClearOrganizationMemberships clears all "organization_memberships" edges to the OrganizationMember entity.
+func (_u *UserUpdateOne) ClearOrganizationMemberships() *UserUpdateOne {
+	_u.mutation.ClearOrganizationMemberships()
+	return _u
+}
+
+// RemoveOrganizationMembershipIDs removes the "organization_memberships" edge to OrganizationMember entities by IDs.
+func (_u *UserUpdateOne) RemoveOrganizationMembershipIDs(ids ...int64) *UserUpdateOne {
+	_u.mutation.RemoveOrganizationMembershipIDs(ids...)
+	return _u
+}
+
+// RemoveOrganizationMemberships removes "organization_memberships" edges to OrganizationMember entities.
+func (_u *UserUpdateOne) RemoveOrganizationMemberships(v ...*OrganizationMember) *UserUpdateOne {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveOrganizationMembershipIDs(ids...)
+}
+
+// ClearWebauthnCredentials clears all "webauthn_credentials" edges to the WebAuthnCredential entity.
+func (_u *UserUpdateOne) ClearWebauthnCredentials() *UserUpdateOne {
+	_u.mutation.ClearWebauthnCredentials()
+	return _u
+}
+
+// RemoveWebauthnCredentialIDs removes the "webauthn_credentials" edge to WebAuthnCredential entities by IDs.
+func (_u *UserUpdateOne) RemoveWebauthnCredentialIDs(ids ...int64) *UserUpdateOne {
+	_u.mutation.RemoveWebauthnCredentialIDs(ids...)
+	return _u
+}
+
+// RemoveWebauthnCredentials removes "webauthn_credentials" edges to WebAuthnCredential entities.
+func (_u *UserUpdateOne) RemoveWebauthnCredentials(v ...*WebAuthnCredential) *UserUpdateOne {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveWebauthnCredentialIDs(ids...)
+}
+
+// ClearRecoveryCodes clears all "recovery_codes" edges to the UserRecoveryCode entity.
+func (_u *UserUpdateOne) ClearRecoveryCodes() *UserUpdateOne {
+	_u.mutation.ClearRecoveryCodes()
+	return _u
+}
+
+// RemoveRecoveryCodeIDs removes the "recovery_codes" edge to UserRecoveryCode entities by IDs.
+func (_u *UserUpdateOne) RemoveRecoveryCodeIDs(ids ...int64) *UserUpdateOne {
+	_u.mutation.RemoveRecoveryCodeIDs(ids...)
+	return _u
+}
+
+// RemoveRecoveryCodes removes "recovery_codes" edges to UserRecoveryCode entities.
+func (_u *UserUpdateOne) RemoveRecoveryCodes(v ...*UserRecoveryCode) *UserUpdateOne {
+	ids := make([]int64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveRecoveryCodeIDs(ids...)
+}
+
 // Where appends a list predicates to the UserUpdate builder.
 func (_u *UserUpdateOne) Where(ps ...predicate.User) *UserUpdateOne {
 	_u.mutation.Where(ps...)
@@ -2485,6 +3068,16 @@ func (_u *UserUpdateOne) check() error {
 			return &ValidationError{Name: "username", err: fmt.Errorf(`ent: validator failed for field "User.username": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.RegisterIP(); ok {
+		if err := user.RegisterIPValidator(v); err != nil {
+			return &ValidationError{Name: "register_ip", err: fmt.Errorf(`ent: validator failed for field "User.register_ip": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.RegisterDevice(); ok {
+		if err := user.RegisterDeviceValidator(v); err != nil {
+			return &ValidationError{Name: "register_device", err: fmt.Errorf(`ent: validator failed for field "User.register_device": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -2577,6 +3170,38 @@ func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
 	if _u.mutation.TotpEnabledAtCleared() {
 		_spec.ClearField(user.FieldTotpEnabledAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.DedicatedAccountIds(); ok {
+		_spec.SetField(user.FieldDedicatedAccountIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedDedicatedAccountIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, user.FieldDedicatedAccountIds, value)
+		})
+	}
+	if _u.mutation.DedicatedAccountIdsCleared() {
+		_spec.ClearField(user.FieldDedicatedAccountIds, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.DedicatedAccountsOnly(); ok {
+		_spec.SetField(user.FieldDedicatedAccountsOnly, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.CommissionBalance(); ok {
+		_spec.SetField(user.FieldCommissionBalance, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedCommissionBalance(); ok {
+		_spec.AddField(user.FieldCommissionBalance, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.RegisterIP(); ok {
+		_spec.SetField(user.FieldRegisterIP, field.TypeString, value)
+	}
+	if _u.mutation.RegisterIPCleared() {
+		_spec.ClearField(user.FieldRegisterIP, field.TypeString)
+	}
+	if value, ok := _u.mutation.RegisterDevice(); ok {
+		_spec.SetField(user.FieldRegisterDevice, field.TypeString, value)
+	}
+	if _u.mutation.RegisterDeviceCleared() {
+		_spec.ClearField(user.FieldRegisterDevice, field.TypeString)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -3248,6 +3873,141 @@ func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OrganizationMembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.OrganizationMembershipsTable,
+			Columns: []string{user.OrganizationMembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organizationmember.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOrganizationMembershipsIDs(); len(nodes) > 0 && !_u.mutation.OrganizationMembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.OrganizationMembershipsTable,
+			Columns: []string{user.OrganizationMembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organizationmember.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OrganizationMembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.OrganizationMembershipsTable,
+			Columns: []string{user.OrganizationMembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(organizationmember.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.WebauthnCredentialsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.WebauthnCredentialsTable,
+			Columns: []string{user.WebauthnCredentialsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(webauthncredential.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedWebauthnCredentialsIDs(); len(nodes) > 0 && !_u.mutation.WebauthnCredentialsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.WebauthnCredentialsTable,
+			Columns: []string{user.WebauthnCredentialsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(webauthncredential.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.WebauthnCredentialsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.WebauthnCredentialsTable,
+			Columns: []string{user.WebauthnCredentialsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(webauthncredential.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.RecoveryCodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecoveryCodesTable,
+			Columns: []string{user.RecoveryCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userrecoverycode.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedRecoveryCodesIDs(); len(nodes) > 0 && !_u.mutation.RecoveryCodesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecoveryCodesTable,
+			Columns: []string{user.RecoveryCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userrecoverycode.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RecoveryCodesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.RecoveryCodesTable,
+			Columns: []string{user.RecoveryCodesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userrecoverycode.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &User{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,656 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/user"
+	"github.com/Wei-Shaw/sub2api/ent/userrecoverycode"
+)
+
+// UserRecoveryCodeCreate is the builder for creating a UserRecoveryCode entity.
+type UserRecoveryCodeCreate struct {
+	config
+	mutation *UserRecoveryCodeMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *UserRecoveryCodeCreate) SetUserID(v int64) *UserRecoveryCodeCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (_c *UserRecoveryCodeCreate) SetCodeHash(v string) *UserRecoveryCodeCreate {
+	_c.mutation.SetCodeHash(v)
+	return _c
+}
+
+// SetUsedAt sets the "used_at" field.
+func (_c *UserRecoveryCodeCreate) SetUsedAt(v time.Time) *UserRecoveryCodeCreate {
+	_c.mutation.SetUsedAt(v)
+	return _c
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (_c *UserRecoveryCodeCreate) SetNillableUsedAt(v *time.Time) *UserRecoveryCodeCreate {
+	if v != nil {
+		_c.SetUsedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *UserRecoveryCodeCreate) SetCreatedAt(v time.Time) *UserRecoveryCodeCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *UserRecoveryCodeCreate) SetNillableCreatedAt(v *time.Time) *UserRecoveryCodeCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_c *UserRecoveryCodeCreate) SetUser(v *User) *UserRecoveryCodeCreate {
+	return _c.SetUserID(v.ID)
+}
+
+// Mutation returns the UserRecoveryCodeMutation object of the builder.
+func (_c *UserRecoveryCodeCreate) Mutation() *UserRecoveryCodeMutation {
+	return _c.mutation
+}
+
+// Save creates the UserRecoveryCode in the database.
+func (_c *UserRecoveryCodeCreate) Save(ctx context.Context) (*UserRecoveryCode, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *UserRecoveryCodeCreate) SaveX(ctx context.Context) *UserRecoveryCode {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *UserRecoveryCodeCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *UserRecoveryCodeCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *UserRecoveryCodeCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := userrecoverycode.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *UserRecoveryCodeCreate) check() error {
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "UserRecoveryCode.user_id"`)}
+	}
+	if _, ok := _c.mutation.CodeHash(); !ok {
+		return &ValidationError{Name: "code_hash", err: errors.New(`ent: missing required field "UserRecoveryCode.code_hash"`)}
+	}
+	if v, ok := _c.mutation.CodeHash(); ok {
+		if err := userrecoverycode.CodeHashValidator(v); err != nil {
+			return &ValidationError{Name: "code_hash", err: fmt.Errorf(`ent: validator failed for field "UserRecoveryCode.code_hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "UserRecoveryCode.created_at"`)}
+	}
+	if len(_c.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "UserRecoveryCode.user"`)}
+	}
+	return nil
+}
+
+func (_c *UserRecoveryCodeCreate) sqlSave(ctx context.Context) (*UserRecoveryCode, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int64(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *UserRecoveryCodeCreate) createSpec() (*UserRecoveryCode, *sqlgraph.CreateSpec) {
+	var (
+		_node = &UserRecoveryCode{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(userrecoverycode.Table, sqlgraph.NewFieldSpec(userrecoverycode.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.CodeHash(); ok {
+		_spec.SetField(userrecoverycode.FieldCodeHash, field.TypeString, value)
+		_node.CodeHash = value
+	}
+	if value, ok := _c.mutation.UsedAt(); ok {
+		_spec.SetField(userrecoverycode.FieldUsedAt, field.TypeTime, value)
+		_node.UsedAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(userrecoverycode.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   userrecoverycode.UserTable,
+			Columns: []string{userrecoverycode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.UserRecoveryCode.Create().
+//		SetUserID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.UserRecoveryCodeUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *UserRecoveryCodeCreate) OnConflict(opts ...sql.ConflictOption) *UserRecoveryCodeUpsertOne {
+	_c.conflict = opts
+	return &UserRecoveryCodeUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.UserRecoveryCode.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *UserRecoveryCodeCreate) OnConflictColumns(columns ...string) *UserRecoveryCodeUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &UserRecoveryCodeUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// UserRecoveryCodeUpsertOne is the builder for "upsert"-ing
+	//  one UserRecoveryCode node.
+	UserRecoveryCodeUpsertOne struct {
+		create *UserRecoveryCodeCreate
+	}
+
+	// UserRecoveryCodeUpsert is the "OnConflict" setter.
+	UserRecoveryCodeUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUserID sets the "user_id" field.
+func (u *UserRecoveryCodeUpsert) SetUserID(v int64) *UserRecoveryCodeUpsert {
+	u.Set(userrecoverycode.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *UserRecoveryCodeUpsert) UpdateUserID() *UserRecoveryCodeUpsert {
+	u.SetExcluded(userrecoverycode.FieldUserID)
+	return u
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (u *UserRecoveryCodeUpsert) SetCodeHash(v string) *UserRecoveryCodeUpsert {
+	u.Set(userrecoverycode.FieldCodeHash, v)
+	return u
+}
+
+// UpdateCodeHash sets the "code_hash" field to the value that was provided on create.
+func (u *UserRecoveryCodeUpsert) UpdateCodeHash() *UserRecoveryCodeUpsert {
+	u.SetExcluded(userrecoverycode.FieldCodeHash)
+	return u
+}
+
+// SetUsedAt sets the "used_at" field.
+func (u *UserRecoveryCodeUpsert) SetUsedAt(v time.Time) *UserRecoveryCodeUpsert {
+	u.Set(userrecoverycode.FieldUsedAt, v)
+	return u
+}
+
+// UpdateUsedAt sets the "used_at" field to the value that was provided on create.
+func (u *UserRecoveryCodeUpsert) UpdateUsedAt() *UserRecoveryCodeUpsert {
+	u.SetExcluded(userrecoverycode.FieldUsedAt)
+	return u
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (u *UserRecoveryCodeUpsert) ClearUsedAt() *UserRecoveryCodeUpsert {
+	u.SetNull(userrecoverycode.FieldUsedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.UserRecoveryCode.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *UserRecoveryCodeUpsertOne) UpdateNewValues() *UserRecoveryCodeUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(userrecoverycode.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.UserRecoveryCode.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *UserRecoveryCodeUpsertOne) Ignore() *UserRecoveryCodeUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *UserRecoveryCodeUpsertOne) DoNothing() *UserRecoveryCodeUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the UserRecoveryCodeCreate.OnConflict
+// documentation for more info.
+func (u *UserRecoveryCodeUpsertOne) Update(set func(*UserRecoveryCodeUpsert)) *UserRecoveryCodeUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&UserRecoveryCodeUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *UserRecoveryCodeUpsertOne) SetUserID(v int64) *UserRecoveryCodeUpsertOne {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *UserRecoveryCodeUpsertOne) UpdateUserID() *UserRecoveryCodeUpsertOne {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (u *UserRecoveryCodeUpsertOne) SetCodeHash(v string) *UserRecoveryCodeUpsertOne {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.SetCodeHash(v)
+	})
+}
+
+// UpdateCodeHash sets the "code_hash" field to the value that was provided on create.
+func (u *UserRecoveryCodeUpsertOne) UpdateCodeHash() *UserRecoveryCodeUpsertOne {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.UpdateCodeHash()
+	})
+}
+
+// SetUsedAt sets the "used_at" field.
+func (u *UserRecoveryCodeUpsertOne) SetUsedAt(v time.Time) *UserRecoveryCodeUpsertOne {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.SetUsedAt(v)
+	})
+}
+
+// UpdateUsedAt sets the "used_at" field to the value that was provided on create.
+func (u *UserRecoveryCodeUpsertOne) UpdateUsedAt() *UserRecoveryCodeUpsertOne {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.UpdateUsedAt()
+	})
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (u *UserRecoveryCodeUpsertOne) ClearUsedAt() *UserRecoveryCodeUpsertOne {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.ClearUsedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *UserRecoveryCodeUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for UserRecoveryCodeCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *UserRecoveryCodeUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *UserRecoveryCodeUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *UserRecoveryCodeUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// UserRecoveryCodeCreateBulk is the builder for creating many UserRecoveryCode entities in bulk.
+type UserRecoveryCodeCreateBulk struct {
+	config
+	err      error
+	builders []*UserRecoveryCodeCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the UserRecoveryCode entities in the database.
+func (_c *UserRecoveryCodeCreateBulk) Save(ctx context.Context) ([]*UserRecoveryCode, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*UserRecoveryCode, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*UserRecoveryCodeMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *UserRecoveryCodeCreateBulk) SaveX(ctx context.Context) []*UserRecoveryCode {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *UserRecoveryCodeCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *UserRecoveryCodeCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.UserRecoveryCode.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.UserRecoveryCodeUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *UserRecoveryCodeCreateBulk) OnConflict(opts ...sql.ConflictOption) *UserRecoveryCodeUpsertBulk {
+	_c.conflict = opts
+	return &UserRecoveryCodeUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.UserRecoveryCode.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *UserRecoveryCodeCreateBulk) OnConflictColumns(columns ...string) *UserRecoveryCodeUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &UserRecoveryCodeUpsertBulk{
+		create: _c,
+	}
+}
+
+// UserRecoveryCodeUpsertBulk is the builder for "upsert"-ing
+// a bulk of UserRecoveryCode nodes.
+type UserRecoveryCodeUpsertBulk struct {
+	create *UserRecoveryCodeCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.UserRecoveryCode.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *UserRecoveryCodeUpsertBulk) UpdateNewValues() *UserRecoveryCodeUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(userrecoverycode.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.UserRecoveryCode.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *UserRecoveryCodeUpsertBulk) Ignore() *UserRecoveryCodeUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *UserRecoveryCodeUpsertBulk) DoNothing() *UserRecoveryCodeUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the UserRecoveryCodeCreateBulk.OnConflict
+// documentation for more info.
+func (u *UserRecoveryCodeUpsertBulk) Update(set func(*UserRecoveryCodeUpsert)) *UserRecoveryCodeUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&UserRecoveryCodeUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *UserRecoveryCodeUpsertBulk) SetUserID(v int64) *UserRecoveryCodeUpsertBulk {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *UserRecoveryCodeUpsertBulk) UpdateUserID() *UserRecoveryCodeUpsertBulk {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (u *UserRecoveryCodeUpsertBulk) SetCodeHash(v string) *UserRecoveryCodeUpsertBulk {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.SetCodeHash(v)
+	})
+}
+
+// UpdateCodeHash sets the "code_hash" field to the value that was provided on create.
+func (u *UserRecoveryCodeUpsertBulk) UpdateCodeHash() *UserRecoveryCodeUpsertBulk {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.UpdateCodeHash()
+	})
+}
+
+// SetUsedAt sets the "used_at" field.
+func (u *UserRecoveryCodeUpsertBulk) SetUsedAt(v time.Time) *UserRecoveryCodeUpsertBulk {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.SetUsedAt(v)
+	})
+}
+
+// UpdateUsedAt sets the "used_at" field to the value that was provided on create.
+func (u *UserRecoveryCodeUpsertBulk) UpdateUsedAt() *UserRecoveryCodeUpsertBulk {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.UpdateUsedAt()
+	})
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (u *UserRecoveryCodeUpsertBulk) ClearUsedAt() *UserRecoveryCodeUpsertBulk {
+	return u.Update(func(s *UserRecoveryCodeUpsert) {
+		s.ClearUsedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *UserRecoveryCodeUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the UserRecoveryCodeCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for UserRecoveryCodeCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *UserRecoveryCodeUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,403 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+	"github.com/Wei-Shaw/sub2api/ent/user"
+	"github.com/Wei-Shaw/sub2api/ent/userrecoverycode"
+)
+
+// UserRecoveryCodeUpdate is the builder for updating UserRecoveryCode entities.
+type UserRecoveryCodeUpdate struct {
+	config
+	hooks    []Hook
+	mutation *UserRecoveryCodeMutation
+}
+
+// Where appends a list predicates to the UserRecoveryCodeUpdate builder.
+func (_u *UserRecoveryCodeUpdate) Where(ps ...predicate.UserRecoveryCode) *UserRecoveryCodeUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *UserRecoveryCodeUpdate) SetUserID(v int64) *UserRecoveryCodeUpdate {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *UserRecoveryCodeUpdate) SetNillableUserID(v *int64) *UserRecoveryCodeUpdate {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (_u *UserRecoveryCodeUpdate) SetCodeHash(v string) *UserRecoveryCodeUpdate {
+	_u.mutation.SetCodeHash(v)
+	return _u
+}
+
+// SetNillableCodeHash sets the "code_hash" field if the given value is not nil.
+func (_u *UserRecoveryCodeUpdate) SetNillableCodeHash(v *string) *UserRecoveryCodeUpdate {
+	if v != nil {
+		_u.SetCodeHash(*v)
+	}
+	return _u
+}
+
+// SetUsedAt sets the "used_at" field.
+func (_u *UserRecoveryCodeUpdate) SetUsedAt(v time.Time) *UserRecoveryCodeUpdate {
+	_u.mutation.SetUsedAt(v)
+	return _u
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (_u *UserRecoveryCodeUpdate) SetNillableUsedAt(v *time.Time) *UserRecoveryCodeUpdate {
+	if v != nil {
+		_u.SetUsedAt(*v)
+	}
+	return _u
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (_u *UserRecoveryCodeUpdate) ClearUsedAt() *UserRecoveryCodeUpdate {
+	_u.mutation.ClearUsedAt()
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *UserRecoveryCodeUpdate) SetUser(v *User) *UserRecoveryCodeUpdate {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the UserRecoveryCodeMutation object of the builder.
+func (_u *UserRecoveryCodeUpdate) Mutation() *UserRecoveryCodeMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *UserRecoveryCodeUpdate) ClearUser() *UserRecoveryCodeUpdate {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *UserRecoveryCodeUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *UserRecoveryCodeUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *UserRecoveryCodeUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *UserRecoveryCodeUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *UserRecoveryCodeUpdate) check() error {
+	if v, ok := _u.mutation.CodeHash(); ok {
+		if err := userrecoverycode.CodeHashValidator(v); err != nil {
+			return &ValidationError{Name: "code_hash", err: fmt.Errorf(`ent: validator failed for field "UserRecoveryCode.code_hash": %w`, err)}
+		}
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "UserRecoveryCode.user"`)
+	}
+	return nil
+}
+
+func (_u *UserRecoveryCodeUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(userrecoverycode.Table, userrecoverycode.Columns, sqlgraph.NewFieldSpec(userrecoverycode.FieldID, field.TypeInt64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CodeHash(); ok {
+		_spec.SetField(userrecoverycode.FieldCodeHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UsedAt(); ok {
+		_spec.SetField(userrecoverycode.FieldUsedAt, field.TypeTime, value)
+	}
+	if _u.mutation.UsedAtCleared() {
+		_spec.ClearField(userrecoverycode.FieldUsedAt, field.TypeTime)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   userrecoverycode.UserTable,
+			Columns: []string{userrecoverycode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   userrecoverycode.UserTable,
+			Columns: []string{userrecoverycode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{userrecoverycode.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// UserRecoveryCodeUpdateOne is the builder for updating a single UserRecoveryCode entity.
+type UserRecoveryCodeUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *UserRecoveryCodeMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *UserRecoveryCodeUpdateOne) SetUserID(v int64) *UserRecoveryCodeUpdateOne {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *UserRecoveryCodeUpdateOne) SetNillableUserID(v *int64) *UserRecoveryCodeUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (_u *UserRecoveryCodeUpdateOne) SetCodeHash(v string) *UserRecoveryCodeUpdateOne {
+	_u.mutation.SetCodeHash(v)
+	return _u
+}
+
+// SetNillableCodeHash sets the "code_hash" field if the given value is not nil.
+func (_u *UserRecoveryCodeUpdateOne) SetNillableCodeHash(v *string) *UserRecoveryCodeUpdateOne {
+	if v != nil {
+		_u.SetCodeHash(*v)
+	}
+	return _u
+}
+
+// SetUsedAt sets the "used_at" field.
+func (_u *UserRecoveryCodeUpdateOne) SetUsedAt(v time.Time) *UserRecoveryCodeUpdateOne {
+	_u.mutation.SetUsedAt(v)
+	return _u
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (_u *UserRecoveryCodeUpdateOne) SetNillableUsedAt(v *time.Time) *UserRecoveryCodeUpdateOne {
+	if v != nil {
+		_u.SetUsedAt(*v)
+	}
+	return _u
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (_u *UserRecoveryCodeUpdateOne) ClearUsedAt() *UserRecoveryCodeUpdateOne {
+	_u.mutation.ClearUsedAt()
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *UserRecoveryCodeUpdateOne) SetUser(v *User) *UserRecoveryCodeUpdateOne {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the UserRecoveryCodeMutation object of the builder.
+func (_u *UserRecoveryCodeUpdateOne) Mutation() *UserRecoveryCodeMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *UserRecoveryCodeUpdateOne) ClearUser() *UserRecoveryCodeUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Where appends a list predicates to the UserRecoveryCodeUpdate builder.
+func (_u *UserRecoveryCodeUpdateOne) Where(ps ...predicate.UserRecoveryCode) *UserRecoveryCodeUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *UserRecoveryCodeUpdateOne) Select(field string, fields ...string) *UserRecoveryCodeUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated UserRecoveryCode entity.
+func (_u *UserRecoveryCodeUpdateOne) Save(ctx context.Context) (*UserRecoveryCode, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *UserRecoveryCodeUpdateOne) SaveX(ctx context.Context) *UserRecoveryCode {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *UserRecoveryCodeUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *UserRecoveryCodeUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *UserRecoveryCodeUpdateOne) check() error {
+	if v, ok := _u.mutation.CodeHash(); ok {
+		if err := userrecoverycode.CodeHashValidator(v); err != nil {
+			return &ValidationError{Name: "code_hash", err: fmt.Errorf(`ent: validator failed for field "UserRecoveryCode.code_hash": %w`, err)}
+		}
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "UserRecoveryCode.user"`)
+	}
+	return nil
+}
+
+func (_u *UserRecoveryCodeUpdateOne) sqlSave(ctx context.Context) (_node *UserRecoveryCode, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(userrecoverycode.Table, userrecoverycode.Columns, sqlgraph.NewFieldSpec(userrecoverycode.FieldID, field.TypeInt64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "UserRecoveryCode.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, userrecoverycode.FieldID)
+		for _, f := range fields {
+			if !userrecoverycode.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != userrecoverycode.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CodeHash(); ok {
+		_spec.SetField(userrecoverycode.FieldCodeHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UsedAt(); ok {
+		_spec.SetField(userrecoverycode.FieldUsedAt, field.TypeTime, value)
+	}
+	if _u.mutation.UsedAtCleared() {
+		_spec.ClearField(userrecoverycode.FieldUsedAt, field.TypeTime)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   userrecoverycode.UserTable,
+			Columns: []string{userrecoverycode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   userrecoverycode.UserTable,
+			Columns: []string{userrecoverycode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &UserRecoveryCode{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{userrecoverycode.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -49,6 +49,12 @@ const (
 	FieldAssignedAt = "assigned_at"
 	// FieldNotes holds the string denoting the notes field in the database.
 	FieldNotes = "notes"
+	// FieldAutoRenew holds the string denoting the auto_renew field in the database.
+	FieldAutoRenew = "auto_renew"
+	// FieldPausedAt holds the string denoting the paused_at field in the database.
+	FieldPausedAt = "paused_at"
+	// FieldRenewalFailedAt holds the string denoting the renewal_failed_at field in the database.
+	FieldRenewalFailedAt = "renewal_failed_at"
 	// EdgeUser holds the string denoting the user edge name in mutations.
 	EdgeUser = "user"
 	// EdgeGroup holds the string denoting the group edge name in mutations.
@@ -109,6 +115,9 @@ var Columns = []string{
 	FieldAssignedBy,
 	FieldAssignedAt,
 	FieldNotes,
+	FieldAutoRenew,
+	FieldPausedAt,
+	FieldRenewalFailedAt,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -147,6 +156,8 @@ var (
 	DefaultMonthlyUsageUsd float64
 	// DefaultAssignedAt holds the default value on creation for the "assigned_at" field.
 	DefaultAssignedAt func() time.Time
+	// DefaultAutoRenew holds the default value on creation for the "auto_renew" field.
+	DefaultAutoRenew bool
 )
 
 // OrderOption defines the ordering options for the UserSubscription queries.
@@ -242,6 +253,21 @@ func ByNotes(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldNotes, opts...).ToFunc()
 }
 
+// ByAutoRenew orders the results by the auto_renew field.
+func ByAutoRenew(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAutoRenew, opts...).ToFunc()
+}
+
+// ByPausedAt orders the results by the paused_at field.
+func ByPausedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPausedAt, opts...).ToFunc()
+}
+
+// ByRenewalFailedAt orders the results by the renewal_failed_at field.
+func ByRenewalFailedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRenewalFailedAt, opts...).ToFunc()
+}
+
 // ByUserField orders the results by user field.
 func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
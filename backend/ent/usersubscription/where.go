@@ -140,6 +140,21 @@ func Notes(v string) predicate.UserSubscription {
 	return predicate.UserSubscription(sql.FieldEQ(FieldNotes, v))
 }
 
+// AutoRenew applies equality check predicate on the "auto_renew" field. It's identical to AutoRenewEQ.
+func AutoRenew(v bool) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldEQ(FieldAutoRenew, v))
+}
+
+// PausedAt applies equality check predicate on the "paused_at" field. It's identical to PausedAtEQ.
+func PausedAt(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldEQ(FieldPausedAt, v))
+}
+
+// RenewalFailedAt applies equality check predicate on the "renewal_failed_at" field. It's identical to RenewalFailedAtEQ.
+func RenewalFailedAt(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldEQ(FieldRenewalFailedAt, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.UserSubscription {
 	return predicate.UserSubscription(sql.FieldEQ(FieldCreatedAt, v))
@@ -870,6 +885,116 @@ func NotesContainsFold(v string) predicate.UserSubscription {
 	return predicate.UserSubscription(sql.FieldContainsFold(FieldNotes, v))
 }
 
+// AutoRenewEQ applies the EQ predicate on the "auto_renew" field.
+func AutoRenewEQ(v bool) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldEQ(FieldAutoRenew, v))
+}
+
+// AutoRenewNEQ applies the NEQ predicate on the "auto_renew" field.
+func AutoRenewNEQ(v bool) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldNEQ(FieldAutoRenew, v))
+}
+
+// PausedAtEQ applies the EQ predicate on the "paused_at" field.
+func PausedAtEQ(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldEQ(FieldPausedAt, v))
+}
+
+// PausedAtNEQ applies the NEQ predicate on the "paused_at" field.
+func PausedAtNEQ(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldNEQ(FieldPausedAt, v))
+}
+
+// PausedAtIn applies the In predicate on the "paused_at" field.
+func PausedAtIn(vs ...time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldIn(FieldPausedAt, vs...))
+}
+
+// PausedAtNotIn applies the NotIn predicate on the "paused_at" field.
+func PausedAtNotIn(vs ...time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldNotIn(FieldPausedAt, vs...))
+}
+
+// PausedAtGT applies the GT predicate on the "paused_at" field.
+func PausedAtGT(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldGT(FieldPausedAt, v))
+}
+
+// PausedAtGTE applies the GTE predicate on the "paused_at" field.
+func PausedAtGTE(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldGTE(FieldPausedAt, v))
+}
+
+// PausedAtLT applies the LT predicate on the "paused_at" field.
+func PausedAtLT(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldLT(FieldPausedAt, v))
+}
+
+// PausedAtLTE applies the LTE predicate on the "paused_at" field.
+func PausedAtLTE(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldLTE(FieldPausedAt, v))
+}
+
+// PausedAtIsNil applies the IsNil predicate on the "paused_at" field.
+func PausedAtIsNil() predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldIsNull(FieldPausedAt))
+}
+
+// PausedAtNotNil applies the NotNil predicate on the "paused_at" field.
+func PausedAtNotNil() predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldNotNull(FieldPausedAt))
+}
+
+// RenewalFailedAtEQ applies the EQ predicate on the "renewal_failed_at" field.
+func RenewalFailedAtEQ(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldEQ(FieldRenewalFailedAt, v))
+}
+
+// RenewalFailedAtNEQ applies the NEQ predicate on the "renewal_failed_at" field.
+func RenewalFailedAtNEQ(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldNEQ(FieldRenewalFailedAt, v))
+}
+
+// RenewalFailedAtIn applies the In predicate on the "renewal_failed_at" field.
+func RenewalFailedAtIn(vs ...time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldIn(FieldRenewalFailedAt, vs...))
+}
+
+// RenewalFailedAtNotIn applies the NotIn predicate on the "renewal_failed_at" field.
+func RenewalFailedAtNotIn(vs ...time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldNotIn(FieldRenewalFailedAt, vs...))
+}
+
+// RenewalFailedAtGT applies the GT predicate on the "renewal_failed_at" field.
+func RenewalFailedAtGT(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldGT(FieldRenewalFailedAt, v))
+}
+
+// RenewalFailedAtGTE applies the GTE predicate on the "renewal_failed_at" field.
+func RenewalFailedAtGTE(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldGTE(FieldRenewalFailedAt, v))
+}
+
+// RenewalFailedAtLT applies the LT predicate on the "renewal_failed_at" field.
+func RenewalFailedAtLT(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldLT(FieldRenewalFailedAt, v))
+}
+
+// RenewalFailedAtLTE applies the LTE predicate on the "renewal_failed_at" field.
+func RenewalFailedAtLTE(v time.Time) predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldLTE(FieldRenewalFailedAt, v))
+}
+
+// RenewalFailedAtIsNil applies the IsNil predicate on the "renewal_failed_at" field.
+func RenewalFailedAtIsNil() predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldIsNull(FieldRenewalFailedAt))
+}
+
+// RenewalFailedAtNotNil applies the NotNil predicate on the "renewal_failed_at" field.
+func RenewalFailedAtNotNil() predicate.UserSubscription {
+	return predicate.UserSubscription(sql.FieldNotNull(FieldRenewalFailedAt))
+}
+
 // HasUser applies the HasEdge predicate on the "user" edge.
 func HasUser() predicate.UserSubscription {
 	return predicate.UserSubscription(func(s *sql.Selector) {
@@ -231,6 +231,48 @@ func (_c *UserSubscriptionCreate) SetNillableNotes(v *string) *UserSubscriptionC
 	return _c
 }
 
+// SetAutoRenew sets the "auto_renew" field.
+func (_c *UserSubscriptionCreate) SetAutoRenew(v bool) *UserSubscriptionCreate {
+	_c.mutation.SetAutoRenew(v)
+	return _c
+}
+
+// SetNillableAutoRenew sets the "auto_renew" field if the given value is not nil.
+func (_c *UserSubscriptionCreate) SetNillableAutoRenew(v *bool) *UserSubscriptionCreate {
+	if v != nil {
+		_c.SetAutoRenew(*v)
+	}
+	return _c
+}
+
+// SetPausedAt sets the "paused_at" field.
+func (_c *UserSubscriptionCreate) SetPausedAt(v time.Time) *UserSubscriptionCreate {
+	_c.mutation.SetPausedAt(v)
+	return _c
+}
+
+// SetNillablePausedAt sets the "paused_at" field if the given value is not nil.
+func (_c *UserSubscriptionCreate) SetNillablePausedAt(v *time.Time) *UserSubscriptionCreate {
+	if v != nil {
+		_c.SetPausedAt(*v)
+	}
+	return _c
+}
+
+// SetRenewalFailedAt sets the "renewal_failed_at" field.
+func (_c *UserSubscriptionCreate) SetRenewalFailedAt(v time.Time) *UserSubscriptionCreate {
+	_c.mutation.SetRenewalFailedAt(v)
+	return _c
+}
+
+// SetNillableRenewalFailedAt sets the "renewal_failed_at" field if the given value is not nil.
+func (_c *UserSubscriptionCreate) SetNillableRenewalFailedAt(v *time.Time) *UserSubscriptionCreate {
+	if v != nil {
+		_c.SetRenewalFailedAt(*v)
+	}
+	return _c
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_c *UserSubscriptionCreate) SetUser(v *User) *UserSubscriptionCreate {
 	return _c.SetUserID(v.ID)
@@ -349,6 +391,10 @@ func (_c *UserSubscriptionCreate) defaults() error {
 		v := usersubscription.DefaultAssignedAt()
 		_c.mutation.SetAssignedAt(v)
 	}
+	if _, ok := _c.mutation.AutoRenew(); !ok {
+		v := usersubscription.DefaultAutoRenew
+		_c.mutation.SetAutoRenew(v)
+	}
 	return nil
 }
 
@@ -392,6 +438,9 @@ func (_c *UserSubscriptionCreate) check() error {
 	if _, ok := _c.mutation.AssignedAt(); !ok {
 		return &ValidationError{Name: "assigned_at", err: errors.New(`ent: missing required field "UserSubscription.assigned_at"`)}
 	}
+	if _, ok := _c.mutation.AutoRenew(); !ok {
+		return &ValidationError{Name: "auto_renew", err: errors.New(`ent: missing required field "UserSubscription.auto_renew"`)}
+	}
 	if len(_c.mutation.UserIDs()) == 0 {
 		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "UserSubscription.user"`)}
 	}
@@ -481,6 +530,18 @@ func (_c *UserSubscriptionCreate) createSpec() (*UserSubscription, *sqlgraph.Cre
 		_spec.SetField(usersubscription.FieldNotes, field.TypeString, value)
 		_node.Notes = &value
 	}
+	if value, ok := _c.mutation.AutoRenew(); ok {
+		_spec.SetField(usersubscription.FieldAutoRenew, field.TypeBool, value)
+		_node.AutoRenew = value
+	}
+	if value, ok := _c.mutation.PausedAt(); ok {
+		_spec.SetField(usersubscription.FieldPausedAt, field.TypeTime, value)
+		_node.PausedAt = &value
+	}
+	if value, ok := _c.mutation.RenewalFailedAt(); ok {
+		_spec.SetField(usersubscription.FieldRenewalFailedAt, field.TypeTime, value)
+		_node.RenewalFailedAt = &value
+	}
 	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -846,6 +907,54 @@ func (u *UserSubscriptionUpsert) ClearNotes() *UserSubscriptionUpsert {
 	return u
 }
 
+// SetAutoRenew sets the "auto_renew" field.
+func (u *UserSubscriptionUpsert) SetAutoRenew(v bool) *UserSubscriptionUpsert {
+	u.Set(usersubscription.FieldAutoRenew, v)
+	return u
+}
+
+// UpdateAutoRenew sets the "auto_renew" field to the value that was provided on create.
+func (u *UserSubscriptionUpsert) UpdateAutoRenew() *UserSubscriptionUpsert {
+	u.SetExcluded(usersubscription.FieldAutoRenew)
+	return u
+}
+
+// SetPausedAt sets the "paused_at" field.
+func (u *UserSubscriptionUpsert) SetPausedAt(v time.Time) *UserSubscriptionUpsert {
+	u.Set(usersubscription.FieldPausedAt, v)
+	return u
+}
+
+// UpdatePausedAt sets the "paused_at" field to the value that was provided on create.
+func (u *UserSubscriptionUpsert) UpdatePausedAt() *UserSubscriptionUpsert {
+	u.SetExcluded(usersubscription.FieldPausedAt)
+	return u
+}
+
+// ClearPausedAt clears the value of the "paused_at" field.
+func (u *UserSubscriptionUpsert) ClearPausedAt() *UserSubscriptionUpsert {
+	u.SetNull(usersubscription.FieldPausedAt)
+	return u
+}
+
+// SetRenewalFailedAt sets the "renewal_failed_at" field.
+func (u *UserSubscriptionUpsert) SetRenewalFailedAt(v time.Time) *UserSubscriptionUpsert {
+	u.Set(usersubscription.FieldRenewalFailedAt, v)
+	return u
+}
+
+// UpdateRenewalFailedAt sets the "renewal_failed_at" field to the value that was provided on create.
+func (u *UserSubscriptionUpsert) UpdateRenewalFailedAt() *UserSubscriptionUpsert {
+	u.SetExcluded(usersubscription.FieldRenewalFailedAt)
+	return u
+}
+
+// ClearRenewalFailedAt clears the value of the "renewal_failed_at" field.
+func (u *UserSubscriptionUpsert) ClearRenewalFailedAt() *UserSubscriptionUpsert {
+	u.SetNull(usersubscription.FieldRenewalFailedAt)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -1178,6 +1287,62 @@ func (u *UserSubscriptionUpsertOne) ClearNotes() *UserSubscriptionUpsertOne {
 	})
 }
 
+// SetAutoRenew sets the "auto_renew" field.
+func (u *UserSubscriptionUpsertOne) SetAutoRenew(v bool) *UserSubscriptionUpsertOne {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.SetAutoRenew(v)
+	})
+}
+
+// UpdateAutoRenew sets the "auto_renew" field to the value that was provided on create.
+func (u *UserSubscriptionUpsertOne) UpdateAutoRenew() *UserSubscriptionUpsertOne {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.UpdateAutoRenew()
+	})
+}
+
+// SetPausedAt sets the "paused_at" field.
+func (u *UserSubscriptionUpsertOne) SetPausedAt(v time.Time) *UserSubscriptionUpsertOne {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.SetPausedAt(v)
+	})
+}
+
+// UpdatePausedAt sets the "paused_at" field to the value that was provided on create.
+func (u *UserSubscriptionUpsertOne) UpdatePausedAt() *UserSubscriptionUpsertOne {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.UpdatePausedAt()
+	})
+}
+
+// ClearPausedAt clears the value of the "paused_at" field.
+func (u *UserSubscriptionUpsertOne) ClearPausedAt() *UserSubscriptionUpsertOne {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.ClearPausedAt()
+	})
+}
+
+// SetRenewalFailedAt sets the "renewal_failed_at" field.
+func (u *UserSubscriptionUpsertOne) SetRenewalFailedAt(v time.Time) *UserSubscriptionUpsertOne {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.SetRenewalFailedAt(v)
+	})
+}
+
+// UpdateRenewalFailedAt sets the "renewal_failed_at" field to the value that was provided on create.
+func (u *UserSubscriptionUpsertOne) UpdateRenewalFailedAt() *UserSubscriptionUpsertOne {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.UpdateRenewalFailedAt()
+	})
+}
+
+// ClearRenewalFailedAt clears the value of the "renewal_failed_at" field.
+func (u *UserSubscriptionUpsertOne) ClearRenewalFailedAt() *UserSubscriptionUpsertOne {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.ClearRenewalFailedAt()
+	})
+}
+
 // Exec executes the query.
 func (u *UserSubscriptionUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -1676,6 +1841,62 @@ func (u *UserSubscriptionUpsertBulk) ClearNotes() *UserSubscriptionUpsertBulk {
 	})
 }
 
+// SetAutoRenew sets the "auto_renew" field.
+func (u *UserSubscriptionUpsertBulk) SetAutoRenew(v bool) *UserSubscriptionUpsertBulk {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.SetAutoRenew(v)
+	})
+}
+
+// UpdateAutoRenew sets the "auto_renew" field to the value that was provided on create.
+func (u *UserSubscriptionUpsertBulk) UpdateAutoRenew() *UserSubscriptionUpsertBulk {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.UpdateAutoRenew()
+	})
+}
+
+// SetPausedAt sets the "paused_at" field.
+func (u *UserSubscriptionUpsertBulk) SetPausedAt(v time.Time) *UserSubscriptionUpsertBulk {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.SetPausedAt(v)
+	})
+}
+
+// UpdatePausedAt sets the "paused_at" field to the value that was provided on create.
+func (u *UserSubscriptionUpsertBulk) UpdatePausedAt() *UserSubscriptionUpsertBulk {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.UpdatePausedAt()
+	})
+}
+
+// ClearPausedAt clears the value of the "paused_at" field.
+func (u *UserSubscriptionUpsertBulk) ClearPausedAt() *UserSubscriptionUpsertBulk {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.ClearPausedAt()
+	})
+}
+
+// SetRenewalFailedAt sets the "renewal_failed_at" field.
+func (u *UserSubscriptionUpsertBulk) SetRenewalFailedAt(v time.Time) *UserSubscriptionUpsertBulk {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.SetRenewalFailedAt(v)
+	})
+}
+
+// UpdateRenewalFailedAt sets the "renewal_failed_at" field to the value that was provided on create.
+func (u *UserSubscriptionUpsertBulk) UpdateRenewalFailedAt() *UserSubscriptionUpsertBulk {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.UpdateRenewalFailedAt()
+	})
+}
+
+// ClearRenewalFailedAt clears the value of the "renewal_failed_at" field.
+func (u *UserSubscriptionUpsertBulk) ClearRenewalFailedAt() *UserSubscriptionUpsertBulk {
+	return u.Update(func(s *UserSubscriptionUpsert) {
+		s.ClearRenewalFailedAt()
+	})
+}
+
 // Exec executes the query.
 func (u *UserSubscriptionUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
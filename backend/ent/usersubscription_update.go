@@ -304,6 +304,60 @@ func (_u *UserSubscriptionUpdate) ClearNotes() *UserSubscriptionUpdate {
 	return _u
 }
 
+// SetAutoRenew sets the "auto_renew" field.
+func (_u *UserSubscriptionUpdate) SetAutoRenew(v bool) *UserSubscriptionUpdate {
+	_u.mutation.SetAutoRenew(v)
+	return _u
+}
+
+// SetNillableAutoRenew sets the "auto_renew" field if the given value is not nil.
+func (_u *UserSubscriptionUpdate) SetNillableAutoRenew(v *bool) *UserSubscriptionUpdate {
+	if v != nil {
+		_u.SetAutoRenew(*v)
+	}
+	return _u
+}
+
+// SetPausedAt sets the "paused_at" field.
+func (_u *UserSubscriptionUpdate) SetPausedAt(v time.Time) *UserSubscriptionUpdate {
+	_u.mutation.SetPausedAt(v)
+	return _u
+}
+
+// SetNillablePausedAt sets the "paused_at" field if the given value is not nil.
+func (_u *UserSubscriptionUpdate) SetNillablePausedAt(v *time.Time) *UserSubscriptionUpdate {
+	if v != nil {
+		_u.SetPausedAt(*v)
+	}
+	return _u
+}
+
+// ClearPausedAt clears the value of the "paused_at" field.
+func (_u *UserSubscriptionUpdate) ClearPausedAt() *UserSubscriptionUpdate {
+	_u.mutation.ClearPausedAt()
+	return _u
+}
+
+// SetRenewalFailedAt sets the "renewal_failed_at" field.
+func (_u *UserSubscriptionUpdate) SetRenewalFailedAt(v time.Time) *UserSubscriptionUpdate {
+	_u.mutation.SetRenewalFailedAt(v)
+	return _u
+}
+
+// SetNillableRenewalFailedAt sets the "renewal_failed_at" field if the given value is not nil.
+func (_u *UserSubscriptionUpdate) SetNillableRenewalFailedAt(v *time.Time) *UserSubscriptionUpdate {
+	if v != nil {
+		_u.SetRenewalFailedAt(*v)
+	}
+	return _u
+}
+
+// ClearRenewalFailedAt clears the value of the "renewal_failed_at" field.
+func (_u *UserSubscriptionUpdate) ClearRenewalFailedAt() *UserSubscriptionUpdate {
+	_u.mutation.ClearRenewalFailedAt()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *UserSubscriptionUpdate) SetUser(v *User) *UserSubscriptionUpdate {
 	return _u.SetUserID(v.ID)
@@ -525,6 +579,21 @@ func (_u *UserSubscriptionUpdate) sqlSave(ctx context.Context) (_node int, err e
 	if _u.mutation.NotesCleared() {
 		_spec.ClearField(usersubscription.FieldNotes, field.TypeString)
 	}
+	if value, ok := _u.mutation.AutoRenew(); ok {
+		_spec.SetField(usersubscription.FieldAutoRenew, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.PausedAt(); ok {
+		_spec.SetField(usersubscription.FieldPausedAt, field.TypeTime, value)
+	}
+	if _u.mutation.PausedAtCleared() {
+		_spec.ClearField(usersubscription.FieldPausedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.RenewalFailedAt(); ok {
+		_spec.SetField(usersubscription.FieldRenewalFailedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RenewalFailedAtCleared() {
+		_spec.ClearField(usersubscription.FieldRenewalFailedAt, field.TypeTime)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -950,6 +1019,60 @@ func (_u *UserSubscriptionUpdateOne) ClearNotes() *UserSubscriptionUpdateOne {
 	return _u
 }
 
+// SetAutoRenew sets the "auto_renew" field.
+func (_u *UserSubscriptionUpdateOne) SetAutoRenew(v bool) *UserSubscriptionUpdateOne {
+	_u.mutation.SetAutoRenew(v)
+	return _u
+}
+
+// SetNillableAutoRenew sets the "auto_renew" field if the given value is not nil.
+func (_u *UserSubscriptionUpdateOne) SetNillableAutoRenew(v *bool) *UserSubscriptionUpdateOne {
+	if v != nil {
+		_u.SetAutoRenew(*v)
+	}
+	return _u
+}
+
+// SetPausedAt sets the "paused_at" field.
+func (_u *UserSubscriptionUpdateOne) SetPausedAt(v time.Time) *UserSubscriptionUpdateOne {
+	_u.mutation.SetPausedAt(v)
+	return _u
+}
+
+// SetNillablePausedAt sets the "paused_at" field if the given value is not nil.
+func (_u *UserSubscriptionUpdateOne) SetNillablePausedAt(v *time.Time) *UserSubscriptionUpdateOne {
+	if v != nil {
+		_u.SetPausedAt(*v)
+	}
+	return _u
+}
+
+// ClearPausedAt clears the value of the "paused_at" field.
+func (_u *UserSubscriptionUpdateOne) ClearPausedAt() *UserSubscriptionUpdateOne {
+	_u.mutation.ClearPausedAt()
+	return _u
+}
+
+// SetRenewalFailedAt sets the "renewal_failed_at" field.
+func (_u *UserSubscriptionUpdateOne) SetRenewalFailedAt(v time.Time) *UserSubscriptionUpdateOne {
+	_u.mutation.SetRenewalFailedAt(v)
+	return _u
+}
+
+// SetNillableRenewalFailedAt sets the "renewal_failed_at" field if the given value is not nil.
+func (_u *UserSubscriptionUpdateOne) SetNillableRenewalFailedAt(v *time.Time) *UserSubscriptionUpdateOne {
+	if v != nil {
+		_u.SetRenewalFailedAt(*v)
+	}
+	return _u
+}
+
+// ClearRenewalFailedAt clears the value of the "renewal_failed_at" field.
+func (_u *UserSubscriptionUpdateOne) ClearRenewalFailedAt() *UserSubscriptionUpdateOne {
+	_u.mutation.ClearRenewalFailedAt()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *UserSubscriptionUpdateOne) SetUser(v *User) *UserSubscriptionUpdateOne {
 	return _u.SetUserID(v.ID)
@@ -1201,6 +1324,21 @@ func (_u *UserSubscriptionUpdateOne) sqlSave(ctx context.Context) (_node *UserSu
 	if _u.mutation.NotesCleared() {
 		_spec.ClearField(usersubscription.FieldNotes, field.TypeString)
 	}
+	if value, ok := _u.mutation.AutoRenew(); ok {
+		_spec.SetField(usersubscription.FieldAutoRenew, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.PausedAt(); ok {
+		_spec.SetField(usersubscription.FieldPausedAt, field.TypeTime, value)
+	}
+	if _u.mutation.PausedAtCleared() {
+		_spec.ClearField(usersubscription.FieldPausedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.RenewalFailedAt(); ok {
+		_spec.SetField(usersubscription.FieldRenewalFailedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RenewalFailedAtCleared() {
+		_spec.ClearField(usersubscription.FieldRenewalFailedAt, field.TypeTime)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -0,0 +1,1127 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/user"
+	"github.com/Wei-Shaw/sub2api/ent/webauthncredential"
+)
+
+// WebAuthnCredentialCreate is the builder for creating a WebAuthnCredential entity.
+type WebAuthnCredentialCreate struct {
+	config
+	mutation *WebAuthnCredentialMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *WebAuthnCredentialCreate) SetCreatedAt(v time.Time) *WebAuthnCredentialCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *WebAuthnCredentialCreate) SetNillableCreatedAt(v *time.Time) *WebAuthnCredentialCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *WebAuthnCredentialCreate) SetUpdatedAt(v time.Time) *WebAuthnCredentialCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *WebAuthnCredentialCreate) SetNillableUpdatedAt(v *time.Time) *WebAuthnCredentialCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *WebAuthnCredentialCreate) SetUserID(v int64) *WebAuthnCredentialCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetName sets the "name" field.
+func (_c *WebAuthnCredentialCreate) SetName(v string) *WebAuthnCredentialCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetCredentialID sets the "credential_id" field.
+func (_c *WebAuthnCredentialCreate) SetCredentialID(v string) *WebAuthnCredentialCreate {
+	_c.mutation.SetCredentialID(v)
+	return _c
+}
+
+// SetPublicKey sets the "public_key" field.
+func (_c *WebAuthnCredentialCreate) SetPublicKey(v []byte) *WebAuthnCredentialCreate {
+	_c.mutation.SetPublicKey(v)
+	return _c
+}
+
+// SetSignCount sets the "sign_count" field.
+func (_c *WebAuthnCredentialCreate) SetSignCount(v int64) *WebAuthnCredentialCreate {
+	_c.mutation.SetSignCount(v)
+	return _c
+}
+
+// SetNillableSignCount sets the "sign_count" field if the given value is not nil.
+func (_c *WebAuthnCredentialCreate) SetNillableSignCount(v *int64) *WebAuthnCredentialCreate {
+	if v != nil {
+		_c.SetSignCount(*v)
+	}
+	return _c
+}
+
+// SetAaguid sets the "aaguid" field.
+func (_c *WebAuthnCredentialCreate) SetAaguid(v string) *WebAuthnCredentialCreate {
+	_c.mutation.SetAaguid(v)
+	return _c
+}
+
+// SetNillableAaguid sets the "aaguid" field if the given value is not nil.
+func (_c *WebAuthnCredentialCreate) SetNillableAaguid(v *string) *WebAuthnCredentialCreate {
+	if v != nil {
+		_c.SetAaguid(*v)
+	}
+	return _c
+}
+
+// SetTransports sets the "transports" field.
+func (_c *WebAuthnCredentialCreate) SetTransports(v []string) *WebAuthnCredentialCreate {
+	_c.mutation.SetTransports(v)
+	return _c
+}
+
+// SetUserVerified sets the "user_verified" field.
+func (_c *WebAuthnCredentialCreate) SetUserVerified(v bool) *WebAuthnCredentialCreate {
+	_c.mutation.SetUserVerified(v)
+	return _c
+}
+
+// SetNillableUserVerified sets the "user_verified" field if the given value is not nil.
+func (_c *WebAuthnCredentialCreate) SetNillableUserVerified(v *bool) *WebAuthnCredentialCreate {
+	if v != nil {
+		_c.SetUserVerified(*v)
+	}
+	return _c
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (_c *WebAuthnCredentialCreate) SetLastUsedAt(v time.Time) *WebAuthnCredentialCreate {
+	_c.mutation.SetLastUsedAt(v)
+	return _c
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (_c *WebAuthnCredentialCreate) SetNillableLastUsedAt(v *time.Time) *WebAuthnCredentialCreate {
+	if v != nil {
+		_c.SetLastUsedAt(*v)
+	}
+	return _c
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_c *WebAuthnCredentialCreate) SetUser(v *User) *WebAuthnCredentialCreate {
+	return _c.SetUserID(v.ID)
+}
+
+// Mutation returns the WebAuthnCredentialMutation object of the builder.
+func (_c *WebAuthnCredentialCreate) Mutation() *WebAuthnCredentialMutation {
+	return _c.mutation
+}
+
+// Save creates the WebAuthnCredential in the database.
+func (_c *WebAuthnCredentialCreate) Save(ctx context.Context) (*WebAuthnCredential, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *WebAuthnCredentialCreate) SaveX(ctx context.Context) *WebAuthnCredential {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WebAuthnCredentialCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WebAuthnCredentialCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *WebAuthnCredentialCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := webauthncredential.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := webauthncredential.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.SignCount(); !ok {
+		v := webauthncredential.DefaultSignCount
+		_c.mutation.SetSignCount(v)
+	}
+	if _, ok := _c.mutation.Aaguid(); !ok {
+		v := webauthncredential.DefaultAaguid
+		_c.mutation.SetAaguid(v)
+	}
+	if _, ok := _c.mutation.UserVerified(); !ok {
+		v := webauthncredential.DefaultUserVerified
+		_c.mutation.SetUserVerified(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *WebAuthnCredentialCreate) check() error {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "WebAuthnCredential.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "WebAuthnCredential.updated_at"`)}
+	}
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "WebAuthnCredential.user_id"`)}
+	}
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "WebAuthnCredential.name"`)}
+	}
+	if v, ok := _c.mutation.Name(); ok {
+		if err := webauthncredential.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "WebAuthnCredential.name": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CredentialID(); !ok {
+		return &ValidationError{Name: "credential_id", err: errors.New(`ent: missing required field "WebAuthnCredential.credential_id"`)}
+	}
+	if v, ok := _c.mutation.CredentialID(); ok {
+		if err := webauthncredential.CredentialIDValidator(v); err != nil {
+			return &ValidationError{Name: "credential_id", err: fmt.Errorf(`ent: validator failed for field "WebAuthnCredential.credential_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.PublicKey(); !ok {
+		return &ValidationError{Name: "public_key", err: errors.New(`ent: missing required field "WebAuthnCredential.public_key"`)}
+	}
+	if v, ok := _c.mutation.PublicKey(); ok {
+		if err := webauthncredential.PublicKeyValidator(v); err != nil {
+			return &ValidationError{Name: "public_key", err: fmt.Errorf(`ent: validator failed for field "WebAuthnCredential.public_key": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.SignCount(); !ok {
+		return &ValidationError{Name: "sign_count", err: errors.New(`ent: missing required field "WebAuthnCredential.sign_count"`)}
+	}
+	if _, ok := _c.mutation.Aaguid(); !ok {
+		return &ValidationError{Name: "aaguid", err: errors.New(`ent: missing required field "WebAuthnCredential.aaguid"`)}
+	}
+	if v, ok := _c.mutation.Aaguid(); ok {
+		if err := webauthncredential.AaguidValidator(v); err != nil {
+			return &ValidationError{Name: "aaguid", err: fmt.Errorf(`ent: validator failed for field "WebAuthnCredential.aaguid": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.UserVerified(); !ok {
+		return &ValidationError{Name: "user_verified", err: errors.New(`ent: missing required field "WebAuthnCredential.user_verified"`)}
+	}
+	if len(_c.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "WebAuthnCredential.user"`)}
+	}
+	return nil
+}
+
+func (_c *WebAuthnCredentialCreate) sqlSave(ctx context.Context) (*WebAuthnCredential, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int64(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *WebAuthnCredentialCreate) createSpec() (*WebAuthnCredential, *sqlgraph.CreateSpec) {
+	var (
+		_node = &WebAuthnCredential{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(webauthncredential.Table, sqlgraph.NewFieldSpec(webauthncredential.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(webauthncredential.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(webauthncredential.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(webauthncredential.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.CredentialID(); ok {
+		_spec.SetField(webauthncredential.FieldCredentialID, field.TypeString, value)
+		_node.CredentialID = value
+	}
+	if value, ok := _c.mutation.PublicKey(); ok {
+		_spec.SetField(webauthncredential.FieldPublicKey, field.TypeBytes, value)
+		_node.PublicKey = value
+	}
+	if value, ok := _c.mutation.SignCount(); ok {
+		_spec.SetField(webauthncredential.FieldSignCount, field.TypeInt64, value)
+		_node.SignCount = value
+	}
+	if value, ok := _c.mutation.Aaguid(); ok {
+		_spec.SetField(webauthncredential.FieldAaguid, field.TypeString, value)
+		_node.Aaguid = value
+	}
+	if value, ok := _c.mutation.Transports(); ok {
+		_spec.SetField(webauthncredential.FieldTransports, field.TypeJSON, value)
+		_node.Transports = value
+	}
+	if value, ok := _c.mutation.UserVerified(); ok {
+		_spec.SetField(webauthncredential.FieldUserVerified, field.TypeBool, value)
+		_node.UserVerified = value
+	}
+	if value, ok := _c.mutation.LastUsedAt(); ok {
+		_spec.SetField(webauthncredential.FieldLastUsedAt, field.TypeTime, value)
+		_node.LastUsedAt = &value
+	}
+	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   webauthncredential.UserTable,
+			Columns: []string{webauthncredential.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.WebAuthnCredential.Create().
+//		SetCreatedAt(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.WebAuthnCredentialUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *WebAuthnCredentialCreate) OnConflict(opts ...sql.ConflictOption) *WebAuthnCredentialUpsertOne {
+	_c.conflict = opts
+	return &WebAuthnCredentialUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.WebAuthnCredential.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *WebAuthnCredentialCreate) OnConflictColumns(columns ...string) *WebAuthnCredentialUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &WebAuthnCredentialUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// WebAuthnCredentialUpsertOne is the builder for "upsert"-ing
+	//  one WebAuthnCredential node.
+	WebAuthnCredentialUpsertOne struct {
+		create *WebAuthnCredentialCreate
+	}
+
+	// WebAuthnCredentialUpsert is the "OnConflict" setter.
+	WebAuthnCredentialUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *WebAuthnCredentialUpsert) SetUpdatedAt(v time.Time) *WebAuthnCredentialUpsert {
+	u.Set(webauthncredential.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsert) UpdateUpdatedAt() *WebAuthnCredentialUpsert {
+	u.SetExcluded(webauthncredential.FieldUpdatedAt)
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *WebAuthnCredentialUpsert) SetUserID(v int64) *WebAuthnCredentialUpsert {
+	u.Set(webauthncredential.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsert) UpdateUserID() *WebAuthnCredentialUpsert {
+	u.SetExcluded(webauthncredential.FieldUserID)
+	return u
+}
+
+// SetName sets the "name" field.
+func (u *WebAuthnCredentialUpsert) SetName(v string) *WebAuthnCredentialUpsert {
+	u.Set(webauthncredential.FieldName, v)
+	return u
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsert) UpdateName() *WebAuthnCredentialUpsert {
+	u.SetExcluded(webauthncredential.FieldName)
+	return u
+}
+
+// SetCredentialID sets the "credential_id" field.
+func (u *WebAuthnCredentialUpsert) SetCredentialID(v string) *WebAuthnCredentialUpsert {
+	u.Set(webauthncredential.FieldCredentialID, v)
+	return u
+}
+
+// UpdateCredentialID sets the "credential_id" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsert) UpdateCredentialID() *WebAuthnCredentialUpsert {
+	u.SetExcluded(webauthncredential.FieldCredentialID)
+	return u
+}
+
+// SetPublicKey sets the "public_key" field.
+func (u *WebAuthnCredentialUpsert) SetPublicKey(v []byte) *WebAuthnCredentialUpsert {
+	u.Set(webauthncredential.FieldPublicKey, v)
+	return u
+}
+
+// UpdatePublicKey sets the "public_key" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsert) UpdatePublicKey() *WebAuthnCredentialUpsert {
+	u.SetExcluded(webauthncredential.FieldPublicKey)
+	return u
+}
+
+// SetSignCount sets the "sign_count" field.
+func (u *WebAuthnCredentialUpsert) SetSignCount(v int64) *WebAuthnCredentialUpsert {
+	u.Set(webauthncredential.FieldSignCount, v)
+	return u
+}
+
+// UpdateSignCount sets the "sign_count" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsert) UpdateSignCount() *WebAuthnCredentialUpsert {
+	u.SetExcluded(webauthncredential.FieldSignCount)
+	return u
+}
+
+// AddSignCount adds v to the "sign_count" field.
+func (u *WebAuthnCredentialUpsert) AddSignCount(v int64) *WebAuthnCredentialUpsert {
+	u.Add(webauthncredential.FieldSignCount, v)
+	return u
+}
+
+// SetAaguid sets the "aaguid" field.
+func (u *WebAuthnCredentialUpsert) SetAaguid(v string) *WebAuthnCredentialUpsert {
+	u.Set(webauthncredential.FieldAaguid, v)
+	return u
+}
+
+// UpdateAaguid sets the "aaguid" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsert) UpdateAaguid() *WebAuthnCredentialUpsert {
+	u.SetExcluded(webauthncredential.FieldAaguid)
+	return u
+}
+
+// SetTransports sets the "transports" field.
+func (u *WebAuthnCredentialUpsert) SetTransports(v []string) *WebAuthnCredentialUpsert {
+	u.Set(webauthncredential.FieldTransports, v)
+	return u
+}
+
+// UpdateTransports sets the "transports" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsert) UpdateTransports() *WebAuthnCredentialUpsert {
+	u.SetExcluded(webauthncredential.FieldTransports)
+	return u
+}
+
+// ClearTransports clears the value of the "transports" field.
+func (u *WebAuthnCredentialUpsert) ClearTransports() *WebAuthnCredentialUpsert {
+	u.SetNull(webauthncredential.FieldTransports)
+	return u
+}
+
+// SetUserVerified sets the "user_verified" field.
+func (u *WebAuthnCredentialUpsert) SetUserVerified(v bool) *WebAuthnCredentialUpsert {
+	u.Set(webauthncredential.FieldUserVerified, v)
+	return u
+}
+
+// UpdateUserVerified sets the "user_verified" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsert) UpdateUserVerified() *WebAuthnCredentialUpsert {
+	u.SetExcluded(webauthncredential.FieldUserVerified)
+	return u
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (u *WebAuthnCredentialUpsert) SetLastUsedAt(v time.Time) *WebAuthnCredentialUpsert {
+	u.Set(webauthncredential.FieldLastUsedAt, v)
+	return u
+}
+
+// UpdateLastUsedAt sets the "last_used_at" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsert) UpdateLastUsedAt() *WebAuthnCredentialUpsert {
+	u.SetExcluded(webauthncredential.FieldLastUsedAt)
+	return u
+}
+
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (u *WebAuthnCredentialUpsert) ClearLastUsedAt() *WebAuthnCredentialUpsert {
+	u.SetNull(webauthncredential.FieldLastUsedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.WebAuthnCredential.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *WebAuthnCredentialUpsertOne) UpdateNewValues() *WebAuthnCredentialUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(webauthncredential.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.WebAuthnCredential.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *WebAuthnCredentialUpsertOne) Ignore() *WebAuthnCredentialUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *WebAuthnCredentialUpsertOne) DoNothing() *WebAuthnCredentialUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the WebAuthnCredentialCreate.OnConflict
+// documentation for more info.
+func (u *WebAuthnCredentialUpsertOne) Update(set func(*WebAuthnCredentialUpsert)) *WebAuthnCredentialUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&WebAuthnCredentialUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *WebAuthnCredentialUpsertOne) SetUpdatedAt(v time.Time) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertOne) UpdateUpdatedAt() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetUserID sets the "user_id" field.
+func (u *WebAuthnCredentialUpsertOne) SetUserID(v int64) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertOne) UpdateUserID() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetName sets the "name" field.
+func (u *WebAuthnCredentialUpsertOne) SetName(v string) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertOne) UpdateName() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateName()
+	})
+}
+
+// SetCredentialID sets the "credential_id" field.
+func (u *WebAuthnCredentialUpsertOne) SetCredentialID(v string) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetCredentialID(v)
+	})
+}
+
+// UpdateCredentialID sets the "credential_id" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertOne) UpdateCredentialID() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateCredentialID()
+	})
+}
+
+// SetPublicKey sets the "public_key" field.
+func (u *WebAuthnCredentialUpsertOne) SetPublicKey(v []byte) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetPublicKey(v)
+	})
+}
+
+// UpdatePublicKey sets the "public_key" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertOne) UpdatePublicKey() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdatePublicKey()
+	})
+}
+
+// SetSignCount sets the "sign_count" field.
+func (u *WebAuthnCredentialUpsertOne) SetSignCount(v int64) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetSignCount(v)
+	})
+}
+
+// AddSignCount adds v to the "sign_count" field.
+func (u *WebAuthnCredentialUpsertOne) AddSignCount(v int64) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.AddSignCount(v)
+	})
+}
+
+// UpdateSignCount sets the "sign_count" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertOne) UpdateSignCount() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateSignCount()
+	})
+}
+
+// SetAaguid sets the "aaguid" field.
+func (u *WebAuthnCredentialUpsertOne) SetAaguid(v string) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetAaguid(v)
+	})
+}
+
+// UpdateAaguid sets the "aaguid" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertOne) UpdateAaguid() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateAaguid()
+	})
+}
+
+// SetTransports sets the "transports" field.
+func (u *WebAuthnCredentialUpsertOne) SetTransports(v []string) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetTransports(v)
+	})
+}
+
+// UpdateTransports sets the "transports" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertOne) UpdateTransports() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateTransports()
+	})
+}
+
+// ClearTransports clears the value of the "transports" field.
+func (u *WebAuthnCredentialUpsertOne) ClearTransports() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.ClearTransports()
+	})
+}
+
+// SetUserVerified sets the "user_verified" field.
+func (u *WebAuthnCredentialUpsertOne) SetUserVerified(v bool) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetUserVerified(v)
+	})
+}
+
+// UpdateUserVerified sets the "user_verified" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertOne) UpdateUserVerified() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateUserVerified()
+	})
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (u *WebAuthnCredentialUpsertOne) SetLastUsedAt(v time.Time) *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetLastUsedAt(v)
+	})
+}
+
+// UpdateLastUsedAt sets the "last_used_at" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertOne) UpdateLastUsedAt() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateLastUsedAt()
+	})
+}
+
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (u *WebAuthnCredentialUpsertOne) ClearLastUsedAt() *WebAuthnCredentialUpsertOne {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.ClearLastUsedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *WebAuthnCredentialUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for WebAuthnCredentialCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *WebAuthnCredentialUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *WebAuthnCredentialUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *WebAuthnCredentialUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// WebAuthnCredentialCreateBulk is the builder for creating many WebAuthnCredential entities in bulk.
+type WebAuthnCredentialCreateBulk struct {
+	config
+	err      error
+	builders []*WebAuthnCredentialCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the WebAuthnCredential entities in the database.
+func (_c *WebAuthnCredentialCreateBulk) Save(ctx context.Context) ([]*WebAuthnCredential, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*WebAuthnCredential, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*WebAuthnCredentialMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *WebAuthnCredentialCreateBulk) SaveX(ctx context.Context) []*WebAuthnCredential {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WebAuthnCredentialCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WebAuthnCredentialCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.WebAuthnCredential.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.WebAuthnCredentialUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *WebAuthnCredentialCreateBulk) OnConflict(opts ...sql.ConflictOption) *WebAuthnCredentialUpsertBulk {
+	_c.conflict = opts
+	return &WebAuthnCredentialUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.WebAuthnCredential.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *WebAuthnCredentialCreateBulk) OnConflictColumns(columns ...string) *WebAuthnCredentialUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &WebAuthnCredentialUpsertBulk{
+		create: _c,
+	}
+}
+
+// WebAuthnCredentialUpsertBulk is the builder for "upsert"-ing
+// a bulk of WebAuthnCredential nodes.
+type WebAuthnCredentialUpsertBulk struct {
+	create *WebAuthnCredentialCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.WebAuthnCredential.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *WebAuthnCredentialUpsertBulk) UpdateNewValues() *WebAuthnCredentialUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(webauthncredential.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.WebAuthnCredential.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *WebAuthnCredentialUpsertBulk) Ignore() *WebAuthnCredentialUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *WebAuthnCredentialUpsertBulk) DoNothing() *WebAuthnCredentialUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the WebAuthnCredentialCreateBulk.OnConflict
+// documentation for more info.
+func (u *WebAuthnCredentialUpsertBulk) Update(set func(*WebAuthnCredentialUpsert)) *WebAuthnCredentialUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&WebAuthnCredentialUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *WebAuthnCredentialUpsertBulk) SetUpdatedAt(v time.Time) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertBulk) UpdateUpdatedAt() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetUserID sets the "user_id" field.
+func (u *WebAuthnCredentialUpsertBulk) SetUserID(v int64) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertBulk) UpdateUserID() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetName sets the "name" field.
+func (u *WebAuthnCredentialUpsertBulk) SetName(v string) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertBulk) UpdateName() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateName()
+	})
+}
+
+// SetCredentialID sets the "credential_id" field.
+func (u *WebAuthnCredentialUpsertBulk) SetCredentialID(v string) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetCredentialID(v)
+	})
+}
+
+// UpdateCredentialID sets the "credential_id" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertBulk) UpdateCredentialID() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateCredentialID()
+	})
+}
+
+// SetPublicKey sets the "public_key" field.
+func (u *WebAuthnCredentialUpsertBulk) SetPublicKey(v []byte) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetPublicKey(v)
+	})
+}
+
+// UpdatePublicKey sets the "public_key" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertBulk) UpdatePublicKey() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdatePublicKey()
+	})
+}
+
+// SetSignCount sets the "sign_count" field.
+func (u *WebAuthnCredentialUpsertBulk) SetSignCount(v int64) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetSignCount(v)
+	})
+}
+
+// AddSignCount adds v to the "sign_count" field.
+func (u *WebAuthnCredentialUpsertBulk) AddSignCount(v int64) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.AddSignCount(v)
+	})
+}
+
+// UpdateSignCount sets the "sign_count" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertBulk) UpdateSignCount() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateSignCount()
+	})
+}
+
+// SetAaguid sets the "aaguid" field.
+func (u *WebAuthnCredentialUpsertBulk) SetAaguid(v string) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetAaguid(v)
+	})
+}
+
+// UpdateAaguid sets the "aaguid" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertBulk) UpdateAaguid() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateAaguid()
+	})
+}
+
+// SetTransports sets the "transports" field.
+func (u *WebAuthnCredentialUpsertBulk) SetTransports(v []string) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetTransports(v)
+	})
+}
+
+// UpdateTransports sets the "transports" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertBulk) UpdateTransports() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateTransports()
+	})
+}
+
+// ClearTransports clears the value of the "transports" field.
+func (u *WebAuthnCredentialUpsertBulk) ClearTransports() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.ClearTransports()
+	})
+}
+
+// SetUserVerified sets the "user_verified" field.
+func (u *WebAuthnCredentialUpsertBulk) SetUserVerified(v bool) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetUserVerified(v)
+	})
+}
+
+// UpdateUserVerified sets the "user_verified" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertBulk) UpdateUserVerified() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateUserVerified()
+	})
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (u *WebAuthnCredentialUpsertBulk) SetLastUsedAt(v time.Time) *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.SetLastUsedAt(v)
+	})
+}
+
+// UpdateLastUsedAt sets the "last_used_at" field to the value that was provided on create.
+func (u *WebAuthnCredentialUpsertBulk) UpdateLastUsedAt() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.UpdateLastUsedAt()
+	})
+}
+
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (u *WebAuthnCredentialUpsertBulk) ClearLastUsedAt() *WebAuthnCredentialUpsertBulk {
+	return u.Update(func(s *WebAuthnCredentialUpsert) {
+		s.ClearLastUsedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *WebAuthnCredentialUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the WebAuthnCredentialCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for WebAuthnCredentialCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *WebAuthnCredentialUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
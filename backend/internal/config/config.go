@@ -6,9 +6,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
+	"net"
 	"net/url"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/spf13/viper"
@@ -48,12 +50,14 @@ type Config struct {
 	Ops          OpsConfig                  `mapstructure:"ops"`
 	JWT          JWTConfig                  `mapstructure:"jwt"`
 	Totp         TotpConfig                 `mapstructure:"totp"`
+	WebAuthn     WebAuthnConfig             `mapstructure:"webauthn"`
 	LinuxDo      LinuxDoConnectConfig       `mapstructure:"linuxdo_connect"`
 	Default      DefaultConfig              `mapstructure:"default"`
 	RateLimit    RateLimitConfig            `mapstructure:"rate_limit"`
 	Pricing      PricingConfig              `mapstructure:"pricing"`
 	Gateway      GatewayConfig              `mapstructure:"gateway"`
 	APIKeyAuth   APIKeyAuthCacheConfig      `mapstructure:"api_key_auth_cache"`
+	APIKeyHash   APIKeyHashConfig           `mapstructure:"api_key_hash"`
 	Dashboard    DashboardCacheConfig       `mapstructure:"dashboard_cache"`
 	DashboardAgg DashboardAggregationConfig `mapstructure:"dashboard_aggregation"`
 	UsageCleanup UsageCleanupConfig         `mapstructure:"usage_cleanup"`
@@ -63,6 +67,22 @@ type Config struct {
 	Timezone     string                     `mapstructure:"timezone"` // e.g. "Asia/Shanghai", "UTC"
 	Gemini       GeminiConfig               `mapstructure:"gemini"`
 	Update       UpdateConfig               `mapstructure:"update"`
+
+	// live 指向热重载后的最新配置快照，由 Load 创建、Reloader 更新
+	live *atomic.Pointer[Config]
+}
+
+// Live 返回当前生效的配置快照。
+// 服务启动时持有的是初始配置指针，读取可热重载的配置项时应通过 Live 获取最新值；
+// 未经 Load 创建的配置（如测试中手动构造）直接返回自身。
+func (c *Config) Live() *Config {
+	if c == nil || c.live == nil {
+		return c
+	}
+	if snapshot := c.live.Load(); snapshot != nil {
+		return snapshot
+	}
+	return c
 }
 
 type GeminiConfig struct {
@@ -162,6 +182,46 @@ type SecurityConfig struct {
 	ResponseHeaders ResponseHeaderConfig `mapstructure:"response_headers"`
 	CSP             CSPConfig            `mapstructure:"csp"`
 	ProxyProbe      ProxyProbeConfig     `mapstructure:"proxy_probe"`
+	// CredentialEncryption 账号凭证、代理密码等敏感字段的静态加密
+	CredentialEncryption CredentialEncryptionConfig `mapstructure:"credential_encryption"`
+	// IPProtection 网关与认证接口的自适应 IP 封禁
+	IPProtection IPProtectionConfig `mapstructure:"ip_protection"`
+}
+
+// IPProtectionConfig 自适应 IP 封禁配置。
+// 按客户端 IP 及其所在网段统计认证失败（无效 API Key、401）与突发请求，超过阈值后封禁；
+// 同一对象在 StrikeTTLHours 内再次被封禁时按 BanDurationsSeconds 逐级延长。
+type IPProtectionConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// FailureThreshold 单个 IP 在 FailureWindowSeconds 内的认证失败次数上限，0 表示不按失败封禁
+	FailureThreshold     int `mapstructure:"failure_threshold"`
+	FailureWindowSeconds int `mapstructure:"failure_window_seconds"`
+	// NetworkFailureThreshold 同一网段（IPv4 /NetworkPrefixV4、IPv6 /NetworkPrefixV6）内的认证失败次数上限，
+	// 用于识别轮换 IP 的撞库；0 表示不按网段封禁
+	NetworkFailureThreshold int `mapstructure:"network_failure_threshold"`
+	NetworkPrefixV4         int `mapstructure:"network_prefix_v4"`
+	NetworkPrefixV6         int `mapstructure:"network_prefix_v6"`
+	// BurstThreshold 单个 IP 在 BurstWindowSeconds 内的请求数上限，0 表示不检测突发
+	BurstThreshold     int `mapstructure:"burst_threshold"`
+	BurstWindowSeconds int `mapstructure:"burst_window_seconds"`
+	// BanDurationsSeconds 逐级封禁时长（秒），超过级数后沿用最后一级
+	BanDurationsSeconds []int `mapstructure:"ban_durations_seconds"`
+	// StrikeTTLHours 封禁次数的记忆时长（小时），超过后重新从第一级开始
+	StrikeTTLHours int `mapstructure:"strike_ttl_hours"`
+}
+
+// CredentialEncryptionConfig 敏感字段信封加密配置。
+// 每个值使用随机数据密钥加密，数据密钥再由主密钥包裹；密文记录主密钥 ID，
+// 轮换时新增密钥并切换 ActiveKeyID，旧密钥需保留到后台重新加密完成。
+type CredentialEncryptionConfig struct {
+	// ActiveKeyID 加密新数据使用的主密钥 ID，为空表示不加密（已有密文仍按 Keys 解密）
+	ActiveKeyID string `mapstructure:"active_key_id"`
+	// Keys 主密钥：key ID（小写）-> 32 字节 hex 编码的 AES-256 密钥
+	Keys map[string]string `mapstructure:"keys"`
+	// ReencryptIntervalMinutes 后台重新加密任务的执行间隔（分钟），0 表示禁用
+	ReencryptIntervalMinutes int `mapstructure:"reencrypt_interval_minutes"`
+	// ReencryptBatchSize 每轮每类数据最多重新加密的记录数
+	ReencryptBatchSize int `mapstructure:"reencrypt_batch_size"`
 }
 
 type URLAllowlistConfig struct {
@@ -400,10 +460,21 @@ func (d *DatabaseConfig) DSNWithTimezone(tz string) string {
 // RedisConfig Redis 连接配置
 // 性能优化：新增连接池和超时参数，提升高并发场景下的吞吐量
 type RedisConfig struct {
+	// Mode: 部署模式，standalone（默认）/ sentinel / cluster
+	Mode     string `mapstructure:"mode"`
 	Host     string `mapstructure:"host"`
 	Port     int    `mapstructure:"port"`
 	Password string `mapstructure:"password"`
 	DB       int    `mapstructure:"db"`
+	// Addrs: sentinel 模式下为哨兵地址列表，cluster 模式下为集群种子节点列表（host:port）
+	Addrs []string `mapstructure:"addrs"`
+	// MasterName: sentinel 模式下监控的主节点名称
+	MasterName string `mapstructure:"master_name"`
+	// SentinelPassword: 哨兵节点自身的认证密码（与数据节点密码不同时配置）
+	SentinelPassword string `mapstructure:"sentinel_password"`
+	// RouteByLatency/RouteRandomly: cluster 模式下允许只读命令路由到从节点
+	RouteByLatency bool `mapstructure:"route_by_latency"`
+	RouteRandomly  bool `mapstructure:"route_randomly"`
 	// 连接池与超时配置（性能优化：可配置化连接池参数）
 	// DialTimeoutSeconds: 建立连接超时，防止慢连接阻塞
 	DialTimeoutSeconds int `mapstructure:"dial_timeout_seconds"`
@@ -417,10 +488,40 @@ type RedisConfig struct {
 	MinIdleConns int `mapstructure:"min_idle_conns"`
 }
 
+// Redis 部署模式
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
+
 func (r *RedisConfig) Address() string {
 	return fmt.Sprintf("%s:%d", r.Host, r.Port)
 }
 
+// NormalizedMode 返回规范化后的部署模式，空值视为 standalone
+func (r *RedisConfig) NormalizedMode() string {
+	mode := strings.ToLower(strings.TrimSpace(r.Mode))
+	if mode == "" {
+		return RedisModeStandalone
+	}
+	return mode
+}
+
+// NodeAddrs 返回 sentinel/cluster 模式使用的节点地址；未配置 addrs 时回退到 host:port
+func (r *RedisConfig) NodeAddrs() []string {
+	addrs := make([]string, 0, len(r.Addrs))
+	for _, addr := range r.Addrs {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 && strings.TrimSpace(r.Host) != "" {
+		addrs = append(addrs, r.Address())
+	}
+	return addrs
+}
+
 type OpsConfig struct {
 	// Enabled controls whether ops features should run.
 	//
@@ -465,6 +566,8 @@ type OpsMetricsCollectorCacheConfig struct {
 type JWTConfig struct {
 	Secret     string `mapstructure:"secret"`
 	ExpireHour int    `mapstructure:"expire_hour"`
+	// RefreshExpireHour 登录会话（refresh token）的滑动有效期，每次刷新后重新计时
+	RefreshExpireHour int `mapstructure:"refresh_expire_hour"`
 }
 
 // TotpConfig TOTP 双因素认证配置
@@ -477,6 +580,54 @@ type TotpConfig struct {
 	EncryptionKeyConfigured bool `mapstructure:"-"`
 }
 
+// WebAuthnConfig Passkey（WebAuthn）依赖方配置
+type WebAuthnConfig struct {
+	// RPID 依赖方 ID（通常为站点域名，如 example.com），passkey 与之绑定，上线后不应再修改
+	// 为空时 passkey 功能不可用
+	RPID string `mapstructure:"rp_id"`
+	// RPName 注册时向用户展示的站点名称
+	RPName string `mapstructure:"rp_name"`
+	// Origins 允许发起 WebAuthn 仪式的前端 Origin 列表；为空时默认为 https://{rp_id}
+	// 必须为 https（localhost 除外），且域名须为 rp_id 本身或其子域名
+	Origins []string `mapstructure:"origins"`
+}
+
+// validate 校验 origin 与 rp_id 的对应关系，避免 passkey 绑定到非 https 或无关域名
+func (w WebAuthnConfig) validate() error {
+	if strings.Contains(w.RPID, "://") || strings.Contains(w.RPID, "/") {
+		return fmt.Errorf("webauthn.rp_id must be a bare domain (e.g. example.com)")
+	}
+	if w.RPID == "" {
+		if len(w.Origins) > 0 {
+			return fmt.Errorf("webauthn.origins requires webauthn.rp_id")
+		}
+		return nil
+	}
+	for _, origin := range w.Origins {
+		if err := ValidateAbsoluteHTTPURL(origin); err != nil {
+			return fmt.Errorf("webauthn.origins: %w", err)
+		}
+		u, _ := url.Parse(origin)
+		host := strings.ToLower(u.Hostname())
+		if u.Scheme != "https" && !isLoopbackHost(host) {
+			return fmt.Errorf("webauthn.origins: %s must use https (only localhost may use http)", origin)
+		}
+		rpID := strings.ToLower(w.RPID)
+		if host != rpID && !strings.HasSuffix(host, "."+rpID) {
+			return fmt.Errorf("webauthn.origins: %s is not within webauthn.rp_id %s", origin, w.RPID)
+		}
+	}
+	return nil
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 type TurnstileConfig struct {
 	Required bool `mapstructure:"required"`
 }
@@ -504,6 +655,12 @@ type APIKeyAuthCacheConfig struct {
 	Singleflight       bool `mapstructure:"singleflight"`
 }
 
+// APIKeyHashConfig API Key 哈希存储配置
+type APIKeyHashConfig struct {
+	// Secret 计算 API Key HMAC-SHA256 的密钥（必填）；已有 Key 按该密钥入库后不可再更改，否则所有 Key 失效
+	Secret string `mapstructure:"secret"`
+}
+
 // DashboardCacheConfig 仪表盘统计缓存配置
 type DashboardCacheConfig struct {
 	// Enabled: 是否启用仪表盘缓存
@@ -568,6 +725,17 @@ func NormalizeRunMode(value string) string {
 }
 
 func Load() (*Config, error) {
+	cfg, err := load(nil)
+	if err != nil {
+		return nil, err
+	}
+	cfg.live = new(atomic.Pointer[Config])
+	return cfg, nil
+}
+
+// load 读取并校验配置文件。
+// previous 非空时表示热重载：未配置的 JWT/TOTP 密钥沿用当前值，避免重新生成随机密钥。
+func load(previous *Config) (*Config, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
 
@@ -628,6 +796,9 @@ func Load() (*Config, error) {
 	cfg.Security.ResponseHeaders.ForceRemove = normalizeStringSlice(cfg.Security.ResponseHeaders.ForceRemove)
 	cfg.Security.CSP.Policy = strings.TrimSpace(cfg.Security.CSP.Policy)
 
+	if cfg.JWT.Secret == "" && previous != nil {
+		cfg.JWT.Secret = previous.JWT.Secret
+	}
 	if cfg.JWT.Secret == "" {
 		secret, err := generateJWTSecret(64)
 		if err != nil {
@@ -637,9 +808,18 @@ func Load() (*Config, error) {
 		log.Println("Warning: JWT secret auto-generated. Consider setting a fixed secret for production.")
 	}
 
+	// API Key 哈希密钥不能自动生成（随机密钥会在重启后使所有 Key 失效），也不能使用公开的内置值，未配置时拒绝启动
+	cfg.APIKeyHash.Secret = strings.TrimSpace(cfg.APIKeyHash.Secret)
+	if cfg.APIKeyHash.Secret == "" {
+		return nil, fmt.Errorf("api_key_hash.secret is required: set it in config.yaml or via API_KEY_HASH_SECRET (generate with: openssl rand -hex 32); it must never change once keys are issued")
+	}
+
 	// Auto-generate TOTP encryption key if not set (32 bytes = 64 hex chars for AES-256)
 	cfg.Totp.EncryptionKey = strings.TrimSpace(cfg.Totp.EncryptionKey)
-	if cfg.Totp.EncryptionKey == "" {
+	if cfg.Totp.EncryptionKey == "" && previous != nil {
+		cfg.Totp.EncryptionKey = previous.Totp.EncryptionKey
+		cfg.Totp.EncryptionKeyConfigured = previous.Totp.EncryptionKeyConfigured
+	} else if cfg.Totp.EncryptionKey == "" {
 		key, err := generateJWTSecret(32) // Reuse the same random generation function
 		if err != nil {
 			return nil, fmt.Errorf("generate totp encryption key error: %w", err)
@@ -661,6 +841,9 @@ func Load() (*Config, error) {
 	if !cfg.Security.ResponseHeaders.Enabled {
 		log.Println("Warning: security.response_headers.enabled=false; configurable header filtering disabled (default allowlist only).")
 	}
+	if cfg.Security.IPProtection.Enabled && len(cfg.Server.TrustedProxies) == 0 {
+		log.Println("Warning: server.trusted_proxies is empty; security.ip_protection bans and IP rules use the direct peer address and ignore forwarding headers. Configure trusted_proxies when running behind a reverse proxy.")
+	}
 
 	if cfg.JWT.Secret != "" && isWeakJWTSecret(cfg.JWT.Secret) {
 		log.Println("Warning: JWT secret appears weak; use a 32+ character random secret in production.")
@@ -714,6 +897,19 @@ func setDefaults() {
 	viper.SetDefault("security.csp.enabled", true)
 	viper.SetDefault("security.csp.policy", DefaultCSPPolicy)
 	viper.SetDefault("security.proxy_probe.insecure_skip_verify", false)
+	viper.SetDefault("security.credential_encryption.active_key_id", "")
+	viper.SetDefault("security.credential_encryption.reencrypt_interval_minutes", 60)
+	viper.SetDefault("security.credential_encryption.reencrypt_batch_size", 200)
+	viper.SetDefault("security.ip_protection.enabled", true)
+	viper.SetDefault("security.ip_protection.failure_threshold", 20)
+	viper.SetDefault("security.ip_protection.failure_window_seconds", 600)
+	viper.SetDefault("security.ip_protection.network_failure_threshold", 200)
+	viper.SetDefault("security.ip_protection.network_prefix_v4", 24)
+	viper.SetDefault("security.ip_protection.network_prefix_v6", 48)
+	viper.SetDefault("security.ip_protection.burst_threshold", 1200)
+	viper.SetDefault("security.ip_protection.burst_window_seconds", 10)
+	viper.SetDefault("security.ip_protection.ban_durations_seconds", []int{300, 1800, 7200, 86400})
+	viper.SetDefault("security.ip_protection.strike_ttl_hours", 24)
 
 	// Billing
 	viper.SetDefault("billing.circuit_breaker.enabled", true)
@@ -753,6 +949,7 @@ func setDefaults() {
 	viper.SetDefault("database.conn_max_idle_time_minutes", 5)
 
 	// Redis
+	viper.SetDefault("redis.mode", RedisModeStandalone)
 	viper.SetDefault("redis.host", "localhost")
 	viper.SetDefault("redis.port", 6379)
 	viper.SetDefault("redis.password", "")
@@ -780,10 +977,19 @@ func setDefaults() {
 	// JWT
 	viper.SetDefault("jwt.secret", "")
 	viper.SetDefault("jwt.expire_hour", 24)
+	viper.SetDefault("jwt.refresh_expire_hour", 720)
+
+	// API Key 哈希
+	viper.SetDefault("api_key_hash.secret", "")
 
 	// TOTP
 	viper.SetDefault("totp.encryption_key", "")
 
+	// WebAuthn
+	viper.SetDefault("webauthn.rp_id", "")
+	viper.SetDefault("webauthn.rp_name", "Sub2API")
+	viper.SetDefault("webauthn.origins", []string{})
+
 	// Default
 	// Admin credentials are created via the setup flow (web wizard / CLI / AUTO_SETUP).
 	// Do not ship fixed defaults here to avoid insecure "known credentials" in production.
@@ -909,9 +1115,24 @@ func (c *Config) Validate() error {
 	if c.JWT.ExpireHour > 24 {
 		log.Printf("Warning: jwt.expire_hour is %d hours (> 24). Consider shorter expiration for security.", c.JWT.ExpireHour)
 	}
+	if c.JWT.RefreshExpireHour < c.JWT.ExpireHour {
+		return fmt.Errorf("jwt.refresh_expire_hour must be >= jwt.expire_hour")
+	}
+	if c.JWT.RefreshExpireHour > 8760 {
+		return fmt.Errorf("jwt.refresh_expire_hour must be <= 8760 (365 days)")
+	}
+	if err := c.WebAuthn.validate(); err != nil {
+		return err
+	}
 	if c.Security.CSP.Enabled && strings.TrimSpace(c.Security.CSP.Policy) == "" {
 		return fmt.Errorf("security.csp.policy is required when CSP is enabled")
 	}
+	if err := c.Security.CredentialEncryption.validate(); err != nil {
+		return err
+	}
+	if err := c.Security.IPProtection.validate(); err != nil {
+		return err
+	}
 	if c.LinuxDo.Enabled {
 		if strings.TrimSpace(c.LinuxDo.ClientID) == "" {
 			return fmt.Errorf("linuxdo_connect.client_id is required when linuxdo_connect.enabled=true")
@@ -993,6 +1214,25 @@ func (c *Config) Validate() error {
 	if c.Database.ConnMaxIdleTimeMinutes < 0 {
 		return fmt.Errorf("database.conn_max_idle_time_minutes must be non-negative")
 	}
+	switch c.Redis.NormalizedMode() {
+	case RedisModeStandalone:
+	case RedisModeSentinel:
+		if strings.TrimSpace(c.Redis.MasterName) == "" {
+			return fmt.Errorf("redis.master_name is required when redis.mode=sentinel")
+		}
+		if len(c.Redis.NodeAddrs()) == 0 {
+			return fmt.Errorf("redis.addrs is required when redis.mode=sentinel")
+		}
+	case RedisModeCluster:
+		if len(c.Redis.NodeAddrs()) == 0 {
+			return fmt.Errorf("redis.addrs is required when redis.mode=cluster")
+		}
+		if c.Redis.DB != 0 {
+			return fmt.Errorf("redis.db must be 0 when redis.mode=cluster")
+		}
+	default:
+		return fmt.Errorf("redis.mode must be one of: standalone, sentinel, cluster")
+	}
 	if c.Redis.DialTimeoutSeconds <= 0 {
 		return fmt.Errorf("redis.dial_timeout_seconds must be positive")
 	}
@@ -1374,3 +1614,60 @@ func warnIfInsecureURL(field, raw string) {
 		log.Printf("Warning: %s uses http scheme; use https in production to avoid token leakage.", field)
 	}
 }
+
+func (c IPProtectionConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.FailureThreshold < 0 || c.NetworkFailureThreshold < 0 || c.BurstThreshold < 0 {
+		return fmt.Errorf("security.ip_protection thresholds must be non-negative")
+	}
+	if (c.FailureThreshold > 0 || c.NetworkFailureThreshold > 0) && c.FailureWindowSeconds <= 0 {
+		return fmt.Errorf("security.ip_protection.failure_window_seconds must be positive")
+	}
+	if c.BurstThreshold > 0 && c.BurstWindowSeconds <= 0 {
+		return fmt.Errorf("security.ip_protection.burst_window_seconds must be positive")
+	}
+	if c.NetworkPrefixV4 < 8 || c.NetworkPrefixV4 > 32 {
+		return fmt.Errorf("security.ip_protection.network_prefix_v4 must be between 8 and 32")
+	}
+	if c.NetworkPrefixV6 < 16 || c.NetworkPrefixV6 > 128 {
+		return fmt.Errorf("security.ip_protection.network_prefix_v6 must be between 16 and 128")
+	}
+	if len(c.BanDurationsSeconds) == 0 {
+		return fmt.Errorf("security.ip_protection.ban_durations_seconds must not be empty")
+	}
+	for _, d := range c.BanDurationsSeconds {
+		if d <= 0 {
+			return fmt.Errorf("security.ip_protection.ban_durations_seconds must be positive")
+		}
+	}
+	if c.StrikeTTLHours <= 0 {
+		return fmt.Errorf("security.ip_protection.strike_ttl_hours must be positive")
+	}
+	return nil
+}
+
+func (c CredentialEncryptionConfig) validate() error {
+	for id, key := range c.Keys {
+		if strings.TrimSpace(id) == "" || strings.Contains(id, ":") {
+			return fmt.Errorf("security.credential_encryption.keys: invalid key id %q", id)
+		}
+		raw, err := hex.DecodeString(strings.TrimSpace(key))
+		if err != nil || len(raw) != 32 {
+			return fmt.Errorf("security.credential_encryption.keys.%s must be 32 bytes (64 hex chars)", id)
+		}
+	}
+	if c.ActiveKeyID != "" {
+		if _, ok := c.Keys[c.ActiveKeyID]; !ok {
+			return fmt.Errorf("security.credential_encryption.active_key_id %q not found in keys", c.ActiveKeyID)
+		}
+	}
+	if c.ReencryptIntervalMinutes < 0 {
+		return fmt.Errorf("security.credential_encryption.reencrypt_interval_minutes must be non-negative")
+	}
+	if c.ReencryptBatchSize < 0 {
+		return fmt.Errorf("security.credential_encryption.reencrypt_batch_size must be non-negative")
+	}
+	return nil
+}
@@ -592,6 +592,11 @@ func TestValidateConfigErrors(t *testing.T) {
 			mutate:  func(c *Config) { c.JWT.ExpireHour = 200 },
 			wantErr: "jwt.expire_hour must be <= 168",
 		},
+		{
+			name:    "jwt refresh expire hour below access",
+			mutate:  func(c *Config) { c.JWT.RefreshExpireHour = 1 },
+			wantErr: "jwt.refresh_expire_hour must be >= jwt.expire_hour",
+		},
 		{
 			name:    "csp policy required",
 			mutate:  func(c *Config) { c.Security.CSP.Enabled = true; c.Security.CSP.Policy = "" },
@@ -850,3 +855,143 @@ func TestValidateConfigErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRedisMode(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Redis.NormalizedMode() != RedisModeStandalone {
+		t.Fatalf("Redis.Mode default = %q, want %q", cfg.Redis.Mode, RedisModeStandalone)
+	}
+
+	cfg.Redis.Mode = "Sentinel"
+	cfg.Redis.MasterName = ""
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "redis.master_name") {
+		t.Fatalf("Validate() expected redis.master_name error, got: %v", err)
+	}
+	cfg.Redis.MasterName = "mymaster"
+	cfg.Redis.Addrs = []string{"10.0.0.1:26379"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() sentinel error: %v", err)
+	}
+
+	cfg.Redis.Mode = RedisModeCluster
+	cfg.Redis.DB = 2
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "redis.db") {
+		t.Fatalf("Validate() expected redis.db error, got: %v", err)
+	}
+	cfg.Redis.DB = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() cluster error: %v", err)
+	}
+
+	cfg.Redis.Mode = "replica"
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "redis.mode") {
+		t.Fatalf("Validate() expected redis.mode error, got: %v", err)
+	}
+}
+
+func TestValidateCredentialEncryption(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Security.CredentialEncryption.ReencryptIntervalMinutes != 60 {
+		t.Fatalf("ReencryptIntervalMinutes = %d, want 60", cfg.Security.CredentialEncryption.ReencryptIntervalMinutes)
+	}
+
+	cfg.Security.CredentialEncryption.Keys = map[string]string{
+		"k1": "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+	}
+	cfg.Security.CredentialEncryption.ActiveKeyID = "k1"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	cfg.Security.CredentialEncryption.ActiveKeyID = "k2"
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "active_key_id") {
+		t.Fatalf("Validate() expected active_key_id error, got: %v", err)
+	}
+
+	cfg.Security.CredentialEncryption.ActiveKeyID = "k1"
+	cfg.Security.CredentialEncryption.Keys["k1"] = "not-hex"
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "credential_encryption.keys") {
+		t.Fatalf("Validate() expected keys error, got: %v", err)
+	}
+}
+
+func TestValidateIPProtection(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !cfg.Security.IPProtection.Enabled || len(cfg.Security.IPProtection.BanDurationsSeconds) != 4 {
+		t.Fatalf("IPProtection defaults = %+v", cfg.Security.IPProtection)
+	}
+
+	cfg.Security.IPProtection.NetworkPrefixV4 = 33
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "network_prefix_v4") {
+		t.Fatalf("Validate() expected network_prefix_v4 error, got: %v", err)
+	}
+
+	cfg.Security.IPProtection.NetworkPrefixV4 = 24
+	cfg.Security.IPProtection.BanDurationsSeconds = []int{60, 0}
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "ban_durations_seconds") {
+		t.Fatalf("Validate() expected ban_durations_seconds error, got: %v", err)
+	}
+
+	cfg.Security.IPProtection.Enabled = false
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error when disabled: %v", err)
+	}
+}
+
+func TestLoadRequiresAPIKeyHashSecret(t *testing.T) {
+	viper.Reset()
+	t.Setenv("API_KEY_HASH_SECRET", "")
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "api_key_hash.secret") {
+		t.Fatalf("Load() error = %v, want api_key_hash.secret required", err)
+	}
+}
+
+func TestWebAuthnConfigValidate(t *testing.T) {
+	valid := []WebAuthnConfig{
+		{},
+		{RPID: "example.com"},
+		{RPID: "example.com", Origins: []string{"https://example.com", "https://app.example.com:8443"}},
+		{RPID: "localhost", Origins: []string{"http://localhost:5173"}},
+	}
+	for _, cfg := range valid {
+		if err := cfg.validate(); err != nil {
+			t.Fatalf("validate(%+v) error: %v", cfg, err)
+		}
+	}
+
+	invalid := []WebAuthnConfig{
+		{RPID: "https://example.com"},
+		{Origins: []string{"https://example.com"}},
+		{RPID: "example.com", Origins: []string{"http://example.com"}},
+		{RPID: "example.com", Origins: []string{"https://evil.com"}},
+		{RPID: "example.com", Origins: []string{"https://notexample.com"}},
+	}
+	for _, cfg := range invalid {
+		if err := cfg.validate(); err == nil {
+			t.Fatalf("validate(%+v) expected error", cfg)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// api_key_hash.secret 为必填项，测试统一通过环境变量提供
+	_ = os.Setenv("API_KEY_HASH_SECRET", "test-api-key-hash-secret")
+	os.Exit(m.Run())
+}
@@ -0,0 +1,278 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// 配置热重载触发来源
+const (
+	ReloadSourceSignal = "sighup"
+	ReloadSourceAdmin  = "admin"
+)
+
+const maxReloadHistory = 20
+
+// hotReloadRules 描述各配置路径是否支持热重载（按最长前缀匹配，未命中的路径需要重启）。
+// 仅当对应配置在每次使用时都通过 Config.Live() 读取时才能标记为可热重载；
+// 在启动时一次性构造（路由中间件、连接池缓存键、后台任务周期等）的配置必须保持为需要重启。
+var hotReloadRules = map[string]bool{
+	"gateway":                                          true,
+	"gateway.max_body_size":                            false,
+	"gateway.connection_pool_isolation":                false,
+	"gateway.concurrency_slot_ttl_minutes":             false,
+	"gateway.session_idle_timeout_minutes":             false,
+	"gateway.max_account_switches":                     false,
+	"gateway.max_account_switches_gemini":              false,
+	"gateway.scheduling.slot_cleanup_interval":         false,
+	"gateway.scheduling.db_fallback_max_qps":           false,
+	"gateway.scheduling.outbox_poll_interval_seconds":  false,
+	"gateway.scheduling.full_rebuild_interval_seconds": false,
+	"pricing":                             true,
+	"pricing.data_dir":                    false,
+	"pricing.hash_check_interval_minutes": false,
+	"rate_limit":                          true,
+	"security.ip_protection":              true,
+}
+
+// sensitiveConfigFields 变更日志中需要脱敏的配置项（按末级字段名匹配）
+var sensitiveConfigFields = map[string]struct{}{
+	"secret":         {},
+	"password":       {},
+	"client_secret":  {},
+	"encryption_key": {},
+	"admin_password": {},
+	"keys":           {},
+}
+
+// ConfigChange 单个配置项的变更
+type ConfigChange struct {
+	Path     string `json:"path"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// ReloadResult 一次配置重载的结果
+type ReloadResult struct {
+	Source     string    `json:"source"`
+	ReloadedAt time.Time `json:"reloaded_at"`
+	ConfigFile string    `json:"config_file,omitempty"`
+	// Applied 已原子生效的变更
+	Applied []ConfigChange `json:"applied"`
+	// RestartRequired 已检测到但需要重启才能生效的变更
+	RestartRequired []ConfigChange `json:"restart_required"`
+	Error           string         `json:"error,omitempty"`
+}
+
+// ReloadHook 配置快照切换后的回调，用于刷新由配置派生的运行期状态
+type ReloadHook func(prev, next *Config)
+
+// Reloader 负责重新读取 config.yaml、校验、比对差异并原子切换配置快照。
+//
+// 可热重载的配置项写入新快照并通过 Config.Live() 对各服务可见；
+// 需要重启的配置项保留旧值，仅在结果中报告，保证运行期快照与实际生效状态一致。
+type Reloader struct {
+	base    *Config
+	mu      sync.Mutex
+	hooksMu sync.RWMutex
+	hooks   []ReloadHook
+	history []ReloadResult
+	loadFn  func(previous *Config) (*Config, error)
+}
+
+// NewReloader 基于启动时加载的配置创建重载器
+func NewReloader(cfg *Config) *Reloader {
+	if cfg.live == nil {
+		cfg.live = new(atomic.Pointer[Config])
+	}
+	return &Reloader{base: cfg, loadFn: load}
+}
+
+// Current 返回当前生效的配置快照
+func (r *Reloader) Current() *Config {
+	return r.base.Live()
+}
+
+// OnReload 注册配置切换回调
+func (r *Reloader) OnReload(hook ReloadHook) {
+	if hook == nil {
+		return
+	}
+	r.hooksMu.Lock()
+	defer r.hooksMu.Unlock()
+	r.hooks = append(r.hooks, hook)
+}
+
+// History 返回最近的重载记录（新记录在前）
+func (r *Reloader) History() []ReloadResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]ReloadResult, 0, len(r.history))
+	for i := len(r.history) - 1; i >= 0; i-- {
+		out = append(out, r.history[i])
+	}
+	return out
+}
+
+// Reload 重新加载配置文件。校验失败时保持当前快照不变并返回错误。
+func (r *Reloader) Reload(source string) (*ReloadResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := ReloadResult{
+		Source:          source,
+		ReloadedAt:      time.Now(),
+		ConfigFile:      viper.ConfigFileUsed(),
+		Applied:         []ConfigChange{},
+		RestartRequired: []ConfigChange{},
+	}
+
+	prev := r.base.Live()
+	loaded, err := r.loadFn(prev)
+	if err != nil {
+		result.Error = err.Error()
+		r.record(result)
+		log.Printf("[ConfigReload] source=%s failed, keeping current config: %v", source, err)
+		return &result, err
+	}
+
+	next := *loaded
+	next.live = r.base.live
+	for _, change := range DiffConfig(prev, loaded) {
+		if IsHotReloadable(change.Path) {
+			result.Applied = append(result.Applied, change)
+			continue
+		}
+		result.RestartRequired = append(result.RestartRequired, change)
+		// 需要重启的配置项保留当前值
+		copyConfigPath(reflect.ValueOf(&next).Elem(), reflect.ValueOf(prev).Elem(), strings.Split(change.Path, "."))
+	}
+
+	if len(result.Applied) > 0 {
+		r.base.live.Store(&next)
+		r.hooksMu.RLock()
+		hooks := append([]ReloadHook(nil), r.hooks...)
+		r.hooksMu.RUnlock()
+		for _, hook := range hooks {
+			hook(prev, &next)
+		}
+	}
+
+	for _, change := range result.Applied {
+		log.Printf("[ConfigReload] applied %s: %s -> %s", change.Path, change.OldValue, change.NewValue)
+	}
+	for _, change := range result.RestartRequired {
+		log.Printf("[ConfigReload] restart required for %s: %s -> %s", change.Path, change.OldValue, change.NewValue)
+	}
+	log.Printf("[ConfigReload] source=%s applied=%d restart_required=%d", source, len(result.Applied), len(result.RestartRequired))
+
+	r.record(result)
+	return &result, nil
+}
+
+func (r *Reloader) record(result ReloadResult) {
+	r.history = append(r.history, result)
+	if len(r.history) > maxReloadHistory {
+		r.history = r.history[len(r.history)-maxReloadHistory:]
+	}
+}
+
+// IsHotReloadable 判断配置路径是否支持热重载
+func IsHotReloadable(path string) bool {
+	for key := path; key != ""; {
+		if hot, ok := hotReloadRules[key]; ok {
+			return hot
+		}
+		idx := strings.LastIndex(key, ".")
+		if idx < 0 {
+			break
+		}
+		key = key[:idx]
+	}
+	return false
+}
+
+// DiffConfig 按 mapstructure 路径比较两份配置，返回按路径排序的变更列表。
+// 结构体逐字段递归比较，map/slice 等作为整体比较。
+func DiffConfig(prev, next *Config) []ConfigChange {
+	var changes []ConfigChange
+	diffConfigValue(reflect.ValueOf(prev).Elem(), reflect.ValueOf(next).Elem(), "", &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func diffConfigValue(prev, next reflect.Value, path string, changes *[]ConfigChange) {
+	if prev.Kind() == reflect.Struct && prev.Type() != reflect.TypeOf(time.Time{}) {
+		t := prev.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, ok := configFieldName(t.Field(i))
+			if !ok {
+				continue
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+			diffConfigValue(prev.Field(i), next.Field(i), name, changes)
+		}
+		return
+	}
+	if reflect.DeepEqual(prev.Interface(), next.Interface()) {
+		return
+	}
+	*changes = append(*changes, ConfigChange{
+		Path:     path,
+		OldValue: formatConfigValue(path, prev),
+		NewValue: formatConfigValue(path, next),
+	})
+}
+
+// copyConfigPath 将 src 中指定路径的值复制到 dst
+func copyConfigPath(dst, src reflect.Value, segments []string) {
+	if len(segments) == 0 {
+		dst.Set(src)
+		return
+	}
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if name, ok := configFieldName(t.Field(i)); ok && name == segments[0] {
+			copyConfigPath(dst.Field(i), src.Field(i), segments[1:])
+			return
+		}
+	}
+}
+
+func configFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+	if tag == "-" {
+		return "", false
+	}
+	if tag == "" {
+		return strings.ToLower(field.Name), true
+	}
+	return tag, true
+}
+
+func formatConfigValue(path string, v reflect.Value) string {
+	leaf := path
+	if idx := strings.LastIndex(path, "."); idx >= 0 {
+		leaf = path[idx+1:]
+	}
+	if _, ok := sensitiveConfigFields[leaf]; ok {
+		if v.IsZero() {
+			return ""
+		}
+		return "******"
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestIsHotReloadable(t *testing.T) {
+	tests := map[string]bool{
+		"gateway.response_header_timeout":                 true,
+		"gateway.scheduling.sticky_session_max_waiting":   true,
+		"gateway.tls_fingerprint.profiles":                true,
+		"gateway.max_body_size":                           false,
+		"gateway.scheduling.outbox_poll_interval_seconds": false,
+		"pricing.remote_url":                              true,
+		"pricing.data_dir":                                false,
+		"rate_limit.overload_cooldown_minutes":            true,
+		"server.port":                                     false,
+		"database.host":                                   false,
+	}
+	for path, want := range tests {
+		if got := IsHotReloadable(path); got != want {
+			t.Errorf("IsHotReloadable(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestDiffConfig(t *testing.T) {
+	prev := &Config{}
+	next := &Config{}
+	next.Gateway.ResponseHeaderTimeout = 60
+	next.Database.Password = "changed"
+	next.Gateway.TLSFingerprint.Profiles = map[string]TLSProfileConfig{"chrome": {Name: "Chrome"}}
+
+	changes := DiffConfig(prev, next)
+	if len(changes) != 3 {
+		t.Fatalf("DiffConfig() returned %d changes, want 3: %+v", len(changes), changes)
+	}
+	if changes[0].Path != "database.password" || changes[0].NewValue != "******" {
+		t.Fatalf("sensitive change = %+v, want redacted database.password", changes[0])
+	}
+	if changes[1].Path != "gateway.response_header_timeout" || changes[1].OldValue != "0" || changes[1].NewValue != "60" {
+		t.Fatalf("timeout change = %+v", changes[1])
+	}
+	if changes[2].Path != "gateway.tls_fingerprint.profiles" {
+		t.Fatalf("profiles change path = %q", changes[2].Path)
+	}
+}
+
+func TestReloaderAppliesHotChangesOnly(t *testing.T) {
+	viper.Reset()
+	base, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	reloader := NewReloader(base)
+	reloader.loadFn = func(previous *Config) (*Config, error) {
+		next := *previous
+		next.Gateway.ResponseHeaderTimeout = previous.Gateway.ResponseHeaderTimeout + 30
+		next.Server.Port = previous.Server.Port + 1
+		return &next, nil
+	}
+
+	var hookCalls int
+	reloader.OnReload(func(prev, next *Config) { hookCalls++ })
+
+	result, err := reloader.Reload(ReloadSourceAdmin)
+	if err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if len(result.Applied) != 1 || result.Applied[0].Path != "gateway.response_header_timeout" {
+		t.Fatalf("Applied = %+v", result.Applied)
+	}
+	if len(result.RestartRequired) != 1 || result.RestartRequired[0].Path != "server.port" {
+		t.Fatalf("RestartRequired = %+v", result.RestartRequired)
+	}
+	if hookCalls != 1 {
+		t.Fatalf("hook calls = %d, want 1", hookCalls)
+	}
+
+	live := base.Live()
+	if live == base {
+		t.Fatalf("Live() should return the reloaded snapshot")
+	}
+	if live.Gateway.ResponseHeaderTimeout != base.Gateway.ResponseHeaderTimeout+30 {
+		t.Fatalf("live ResponseHeaderTimeout = %d", live.Gateway.ResponseHeaderTimeout)
+	}
+	if live.Server.Port != base.Server.Port {
+		t.Fatalf("restart-required field should keep current value, got port %d", live.Server.Port)
+	}
+	if live.Live() != live {
+		t.Fatalf("Live() on snapshot should return the snapshot itself")
+	}
+}
+
+func TestReloaderKeepsSnapshotOnError(t *testing.T) {
+	viper.Reset()
+	base, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	reloader := NewReloader(base)
+	reloader.loadFn = func(previous *Config) (*Config, error) {
+		return nil, errors.New("invalid config")
+	}
+
+	if _, err := reloader.Reload(ReloadSourceSignal); err == nil {
+		t.Fatalf("Reload() expected error")
+	}
+	if base.Live() != base {
+		t.Fatalf("failed reload should keep the current snapshot")
+	}
+	history := reloader.History()
+	if len(history) != 1 || history[0].Error == "" || history[0].Source != ReloadSourceSignal {
+		t.Fatalf("History() = %+v", history)
+	}
+}
+
+func TestLiveWithoutReloader(t *testing.T) {
+	var nilCfg *Config
+	if nilCfg.Live() != nil {
+		t.Fatalf("nil Live() should return nil")
+	}
+	cfg := &Config{}
+	if cfg.Live() != cfg {
+		t.Fatalf("Live() without reloader should return itself")
+	}
+}
@@ -44,6 +44,7 @@ type AccountHandler struct {
 	accountTestService      *service.AccountTestService
 	concurrencyService      *service.ConcurrencyService
 	crsSyncService          *service.CRSSyncService
+	accountTransferService  *service.AccountTransferService
 	sessionLimitCache       service.SessionLimitCache
 	tokenCacheInvalidator   service.TokenCacheInvalidator
 }
@@ -60,6 +61,7 @@ func NewAccountHandler(
 	accountTestService *service.AccountTestService,
 	concurrencyService *service.ConcurrencyService,
 	crsSyncService *service.CRSSyncService,
+	accountTransferService *service.AccountTransferService,
 	sessionLimitCache service.SessionLimitCache,
 	tokenCacheInvalidator service.TokenCacheInvalidator,
 ) *AccountHandler {
@@ -74,6 +76,7 @@ func NewAccountHandler(
 		accountTestService:      accountTestService,
 		concurrencyService:      concurrencyService,
 		crsSyncService:          crsSyncService,
+		accountTransferService:  accountTransferService,
 		sessionLimitCache:       sessionLimitCache,
 		tokenCacheInvalidator:   tokenCacheInvalidator,
 	}
@@ -685,6 +688,89 @@ func (h *AccountHandler) ClearError(c *gin.Context) {
 	response.Success(c, dto.AccountFromService(account))
 }
 
+// maxAccountImportContentBytes 导入文件内容上限
+const maxAccountImportContentBytes = 10 << 20
+
+// ImportAccountsRequest represents account file import request
+type ImportAccountsRequest struct {
+	Format                  string  `json:"format" binding:"omitempty,oneof=sub2api crs codex gemini_cli api_keys"`
+	Content                 string  `json:"content" binding:"required"`
+	DryRun                  bool    `json:"dry_run"`
+	GroupIDs                []int64 `json:"group_ids"`
+	ProxyID                 *int64  `json:"proxy_id"`
+	SyncProxies             *bool   `json:"sync_proxies"`
+	Platform                string  `json:"platform" binding:"omitempty,oneof=anthropic openai gemini antigravity"`
+	BaseURL                 string  `json:"base_url"`
+	ConfirmMixedChannelRisk *bool   `json:"confirm_mixed_channel_risk"` // 用户确认混合渠道风险
+}
+
+// Import handles importing accounts from a file (sub2api / CRS / Codex / Gemini CLI / API key list)
+// POST /api/v1/admin/accounts/import
+func (h *AccountHandler) Import(c *gin.Context) {
+	var req ImportAccountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+	if len(req.Content) > maxAccountImportContentBytes {
+		response.BadRequest(c, "Import file is too large")
+		return
+	}
+
+	// 与 CRS 同步一致，默认按文件中的代理定义复用或创建代理
+	syncProxies := true
+	if req.SyncProxies != nil {
+		syncProxies = *req.SyncProxies
+	}
+
+	result, err := h.accountTransferService.Import(c.Request.Context(), service.AccountImportInput{
+		Format:                req.Format,
+		Content:               []byte(req.Content),
+		DryRun:                req.DryRun,
+		GroupIDs:              req.GroupIDs,
+		ProxyID:               req.ProxyID,
+		SyncProxies:           syncProxies,
+		Platform:              req.Platform,
+		BaseURL:               req.BaseURL,
+		SkipMixedChannelCheck: req.ConfirmMixedChannelRisk != nil && *req.ConfirmMixedChannelRisk,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, result)
+}
+
+// Export handles exporting accounts with credentials as a JSON file
+// GET /api/v1/admin/accounts/export?format=sub2api|crs&ids=1,2&platform=
+func (h *AccountHandler) Export(c *gin.Context) {
+	var ids []int64
+	if raw := strings.TrimSpace(c.Query("ids")); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+			if err != nil || id <= 0 {
+				response.BadRequest(c, "Invalid account ID: "+part)
+				return
+			}
+			ids = append(ids, id)
+		}
+	}
+	format := c.DefaultQuery("format", service.AccountTransferFormatNative)
+
+	data, _, err := h.accountTransferService.Export(c.Request.Context(), service.AccountExportInput{
+		Format:     format,
+		AccountIDs: ids,
+		Platform:   c.Query("platform"),
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	filename := "sub2api-accounts-" + format + "-" + time.Now().Format("20060102-150405") + ".json"
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Data(200, "application/json; charset=utf-8", data)
+}
+
 // BatchCreate handles batch creating accounts
 // POST /api/v1/admin/accounts/batch
 func (h *AccountHandler) BatchCreate(c *gin.Context) {
@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BackupHandler handles instance backup and restore
+type BackupHandler struct {
+	backupService         *service.BackupService
+	adminActionLogService *service.AdminActionLogService
+}
+
+// NewBackupHandler creates a new backup handler
+func NewBackupHandler(backupService *service.BackupService, adminActionLogService *service.AdminActionLogService) *BackupHandler {
+	return &BackupHandler{
+		backupService:         backupService,
+		adminActionLogService: adminActionLogService,
+	}
+}
+
+// CreateBackupRequest represents create backup request
+type CreateBackupRequest struct {
+	Passphrase       string `json:"passphrase" binding:"required"`
+	IncludeUsageLogs bool   `json:"include_usage_logs"`
+}
+
+// Backup exports the instance as a downloadable archive
+// POST /api/v1/admin/system/backup
+func (h *BackupHandler) Backup(c *gin.Context) {
+	var req CreateBackupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	// 先写入临时文件，导出失败时仍能返回 JSON 错误而不是半截的下载
+	tmp, err := os.CreateTemp("", "sub2api-backup-*.jsonl.gz")
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	summary, err := h.backupService.Export(c.Request.Context(), tmp, service.BackupExportOptions{
+		Passphrase:       req.Passphrase,
+		IncludeUsageLogs: req.IncludeUsageLogs,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	if err := tmp.Sync(); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	h.logAction(c, "backup_export", summary)
+	filename := fmt.Sprintf("sub2api-backup-%s.jsonl.gz", time.Now().UTC().Format("20060102-150405"))
+	c.FileAttachment(tmp.Name(), filename)
+}
+
+// Restore restores an uploaded archive into an empty database
+// POST /api/v1/admin/system/restore (multipart: file, passphrase)
+func (h *BackupHandler) Restore(c *gin.Context) {
+	passphrase := c.PostForm("passphrase")
+	if passphrase == "" {
+		response.BadRequest(c, "passphrase is required")
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "file is required")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(c, "failed to read file")
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	summary, err := h.backupService.Restore(c.Request.Context(), file, passphrase)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	h.logAction(c, "backup_restore", summary)
+	response.Success(c, summary)
+}
+
+func (h *BackupHandler) logAction(c *gin.Context, action string, summary *service.BackupSummary) {
+	subject, ok := middleware.GetAuthSubjectFromContext(c)
+	if !ok {
+		return
+	}
+	h.adminActionLogService.Log(c.Request.Context(), service.AdminActionLogInput{
+		AdminID:      &subject.UserID,
+		Action:       action,
+		ResourceType: "system",
+		Payload: service.MarshalAdminActionPayload(map[string]any{
+			"schema_version": summary.SchemaVersion,
+			"counts":         summary.Counts,
+		}),
+		IPAddress: c.ClientIP(),
+		UserAgent: c.GetHeader("User-Agent"),
+	})
+}
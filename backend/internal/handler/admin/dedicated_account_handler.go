@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DedicatedAccountHandler handles admin management of user / API key dedicated accounts
+type DedicatedAccountHandler struct {
+	accountDedicationService *service.AccountDedicationService
+	adminActionLogService    *service.AdminActionLogService
+}
+
+// NewDedicatedAccountHandler creates a new admin dedicated account handler
+func NewDedicatedAccountHandler(accountDedicationService *service.AccountDedicationService, adminActionLogService *service.AdminActionLogService) *DedicatedAccountHandler {
+	return &DedicatedAccountHandler{
+		accountDedicationService: accountDedicationService,
+		adminActionLogService:    adminActionLogService,
+	}
+}
+
+// SetDedicatedAccountsRequest represents the dedicated account assignment request.
+// An empty account_ids list removes the assignment.
+type SetDedicatedAccountsRequest struct {
+	AccountIDs []int64 `json:"account_ids"`
+	Exclusive  bool    `json:"exclusive"`
+}
+
+// List handles listing dedicated account owners with account utilization
+// GET /api/v1/admin/dedicated-accounts
+func (h *DedicatedAccountHandler) List(c *gin.Context) {
+	items, err := h.accountDedicationService.ListDedications(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, items)
+}
+
+// SetUserAccounts handles setting a user's dedicated accounts
+// PUT /api/v1/admin/dedicated-accounts/users/:id
+func (h *DedicatedAccountHandler) SetUserAccounts(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	var req SetDedicatedAccountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.accountDedicationService.SetUserDedicatedAccounts(c.Request.Context(), userID, req.AccountIDs, req.Exclusive); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	h.logAction(c, "set_user_dedicated_accounts", "user", userID, req)
+	response.Success(c, gin.H{"message": "Dedicated accounts updated"})
+}
+
+// SetAPIKeyAccounts handles setting an API key's dedicated accounts
+// PUT /api/v1/admin/dedicated-accounts/api-keys/:id
+func (h *DedicatedAccountHandler) SetAPIKeyAccounts(c *gin.Context) {
+	apiKeyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid API key ID")
+		return
+	}
+
+	var req SetDedicatedAccountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.accountDedicationService.SetAPIKeyDedicatedAccounts(c.Request.Context(), apiKeyID, req.AccountIDs, req.Exclusive); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	h.logAction(c, "set_api_key_dedicated_accounts", "api_key", apiKeyID, req)
+	response.Success(c, gin.H{"message": "Dedicated accounts updated"})
+}
+
+func (h *DedicatedAccountHandler) logAction(c *gin.Context, action, resourceType string, resourceID int64, req SetDedicatedAccountsRequest) {
+	if h.adminActionLogService == nil {
+		return
+	}
+	subject, ok := middleware.GetAuthSubjectFromContext(c)
+	if !ok {
+		return
+	}
+	payload := service.MarshalAdminActionPayload(map[string]any{
+		"account_ids": req.AccountIDs,
+		"exclusive":   req.Exclusive,
+	})
+	h.adminActionLogService.Log(c.Request.Context(), service.AdminActionLogInput{
+		AdminID:      &subject.UserID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   &resourceID,
+		Payload:      payload,
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.GetHeader("User-Agent"),
+	})
+}
@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"encoding/json"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -13,13 +15,15 @@ import (
 
 // GroupHandler handles admin group management
 type GroupHandler struct {
-	adminService service.AdminService
+	adminService   service.AdminService
+	gatewayService *service.GatewayService
 }
 
 // NewGroupHandler creates a new admin group handler
-func NewGroupHandler(adminService service.AdminService) *GroupHandler {
+func NewGroupHandler(adminService service.AdminService, gatewayService *service.GatewayService) *GroupHandler {
 	return &GroupHandler{
-		adminService: adminService,
+		adminService:   adminService,
+		gatewayService: gatewayService,
 	}
 }
 
@@ -43,6 +47,24 @@ type CreateGroupRequest struct {
 	// 模型路由配置（仅 anthropic 平台使用）
 	ModelRouting        map[string][]int64 `json:"model_routing"`
 	ModelRoutingEnabled bool               `json:"model_routing_enabled"`
+	// 账号调度策略：空/default, least_loaded, weighted, quota_aware, cost_aware
+	SchedulingStrategy string `json:"scheduling_strategy"`
+	// 内容策略：黑名单、PII 检测、图片限制、外部审核
+	ContentPolicy *service.ContentPolicy `json:"content_policy"`
+	// 请求改写规则：按平台/模型匹配，改写请求体字段、系统提示词与请求头
+	RewriteRules []service.RequestRewriteRule `json:"rewrite_rules"`
+	// 模型别名：面向用户的虚拟模型名，按顺序解析为 (平台, 模型) 目标
+	ModelAliases []service.ModelAlias `json:"model_aliases"`
+	// 灰度 / A-B 分流：按会话稳定分配实验组，实验组可限定账号子集或改写目标模型
+	TrafficSplit *service.TrafficSplit `json:"traffic_split"`
+	// 订阅超额策略：reject（默认）, balance, fallback_group
+	OveragePolicy         string   `json:"overage_policy" binding:"omitempty,oneof=reject balance fallback_group"`
+	OverageRateMultiplier *float64 `json:"overage_rate_multiplier"`
+	OverageGroupID        *int64   `json:"overage_group_id"`
+	// 订阅每周期余额价格（为空表示不支持用户自助续费/切换）
+	SubscriptionPrice *float64 `json:"subscription_price"`
+	// 订阅 token / 请求次数额度：按日/周/月窗口，可限定模型
+	UsageQuotas []service.SubscriptionQuota `json:"usage_quotas"`
 }
 
 // UpdateGroupRequest represents update group request
@@ -66,6 +88,24 @@ type UpdateGroupRequest struct {
 	// 模型路由配置（仅 anthropic 平台使用）
 	ModelRouting        map[string][]int64 `json:"model_routing"`
 	ModelRoutingEnabled *bool              `json:"model_routing_enabled"`
+	// 账号调度策略：空/default, least_loaded, weighted, quota_aware, cost_aware
+	SchedulingStrategy *string `json:"scheduling_strategy"`
+	// 内容策略（审核 Webhook secret 留空时保留原值）
+	ContentPolicy *service.ContentPolicy `json:"content_policy"`
+	// 请求改写规则（不传表示不修改，空数组表示清空）
+	RewriteRules *[]service.RequestRewriteRule `json:"rewrite_rules"`
+	// 模型别名（不传表示不修改，空数组表示清空）
+	ModelAliases *[]service.ModelAlias `json:"model_aliases"`
+	// 灰度 / A-B 分流（不传表示不修改，enabled=false 且 arms 为空表示清除）
+	TrafficSplit *service.TrafficSplit `json:"traffic_split"`
+	// 订阅超额策略（overage_group_id 传 0 表示清除）
+	OveragePolicy         *string  `json:"overage_policy" binding:"omitempty,oneof=reject balance fallback_group"`
+	OverageRateMultiplier *float64 `json:"overage_rate_multiplier"`
+	OverageGroupID        *int64   `json:"overage_group_id"`
+	// 订阅每周期余额价格（负数表示清除）
+	SubscriptionPrice *float64 `json:"subscription_price"`
+	// 订阅 token / 请求次数额度（不传表示不修改，空数组表示清空）
+	UsageQuotas *[]service.SubscriptionQuota `json:"usage_quotas"`
 }
 
 // List handles listing all groups with pagination
@@ -171,6 +211,18 @@ func (h *GroupHandler) Create(c *gin.Context) {
 		FallbackGroupID:     req.FallbackGroupID,
 		ModelRouting:        req.ModelRouting,
 		ModelRoutingEnabled: req.ModelRoutingEnabled,
+		SchedulingStrategy:  req.SchedulingStrategy,
+		ContentPolicy:       req.ContentPolicy,
+		RewriteRules:        req.RewriteRules,
+		ModelAliases:        req.ModelAliases,
+		TrafficSplit:        req.TrafficSplit,
+
+		OveragePolicy:         req.OveragePolicy,
+		OverageRateMultiplier: req.OverageRateMultiplier,
+		OverageGroupID:        req.OverageGroupID,
+
+		SubscriptionPrice: req.SubscriptionPrice,
+		UsageQuotas:       req.UsageQuotas,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -213,6 +265,18 @@ func (h *GroupHandler) Update(c *gin.Context) {
 		FallbackGroupID:     req.FallbackGroupID,
 		ModelRouting:        req.ModelRouting,
 		ModelRoutingEnabled: req.ModelRoutingEnabled,
+		SchedulingStrategy:  req.SchedulingStrategy,
+		ContentPolicy:       req.ContentPolicy,
+		RewriteRules:        req.RewriteRules,
+		ModelAliases:        req.ModelAliases,
+		TrafficSplit:        req.TrafficSplit,
+
+		OveragePolicy:         req.OveragePolicy,
+		OverageRateMultiplier: req.OverageRateMultiplier,
+		OverageGroupID:        req.OverageGroupID,
+
+		SubscriptionPrice: req.SubscriptionPrice,
+		UsageQuotas:       req.UsageQuotas,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -259,6 +323,89 @@ func (h *GroupHandler) GetStats(c *gin.Context) {
 	_ = groupID // TODO: implement actual stats
 }
 
+// SchedulingSimulationRequest represents scheduling simulation request
+type SchedulingSimulationRequest struct {
+	Model string `json:"model"`
+}
+
+// SimulateScheduling previews which account each scheduling strategy would pick
+// POST /api/v1/admin/groups/:id/scheduling-simulation
+func (h *GroupHandler) SimulateScheduling(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid group ID")
+		return
+	}
+
+	var req SchedulingSimulationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+	}
+	if h.gatewayService == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Gateway service not available")
+		return
+	}
+
+	group, err := h.adminService.GetGroup(c.Request.Context(), groupID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	result, err := h.gatewayService.SimulateScheduling(c.Request.Context(), group, strings.TrimSpace(req.Model))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, result)
+}
+
+// RewriteDryRunRequest represents rewrite rules dry-run request
+type RewriteDryRunRequest struct {
+	Model  string          `json:"model"`
+	Format string          `json:"format" binding:"omitempty,oneof=anthropic openai_responses gemini"`
+	Body   json.RawMessage `json:"body" binding:"required"`
+	// Rules 传入时试运行未保存的规则，否则使用分组当前规则
+	Rules *[]service.RequestRewriteRule `json:"rules"`
+}
+
+// DryRunRewriteRules shows the rewritten body and header changes for a sample request
+// POST /api/v1/admin/groups/:id/rewrite-rules/dry-run
+func (h *GroupHandler) DryRunRewriteRules(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid group ID")
+		return
+	}
+
+	var req RewriteDryRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	group, err := h.adminService.GetGroup(c.Request.Context(), groupID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	result, err := service.DryRunRequestRewrite(group, &service.RequestRewriteDryRunInput{
+		Model:  req.Model,
+		Format: req.Format,
+		Body:   req.Body,
+		Rules:  req.Rules,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, result)
+}
+
 // GetGroupAPIKeys handles getting API keys in a group
 // GET /api/v1/admin/groups/:id/api-keys
 func (h *GroupHandler) GetGroupAPIKeys(c *gin.Context) {
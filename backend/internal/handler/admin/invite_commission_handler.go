@@ -0,0 +1,157 @@
+package admin
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProcessWithdrawalRequest represents the approve/reject commission withdrawal request payload
+type ProcessWithdrawalRequest struct {
+	Notes string `json:"notes" binding:"max=500"`
+}
+
+// UpdateInviteCommissionStatusRequest represents the invite commission status update payload
+type UpdateInviteCommissionStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active blocked"`
+}
+
+// ListWithdrawals handles listing commission withdrawals
+// GET /api/v1/admin/invites/withdrawals
+func (h *InviteHandler) ListWithdrawals(c *gin.Context) {
+	page, pageSize := response.ParsePagination(c)
+
+	filters := service.CommissionWithdrawalFilters{
+		Method: strings.TrimSpace(c.Query("method")),
+		Status: strings.TrimSpace(c.Query("status")),
+	}
+	if userIDStr := strings.TrimSpace(c.Query("user_id")); userIDStr != "" {
+		userID, err := strconv.ParseInt(userIDStr, 10, 64)
+		if err != nil {
+			response.BadRequest(c, "Invalid user_id")
+			return
+		}
+		filters.UserID = &userID
+	}
+
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+	withdrawals, result, err := h.commissionService.AdminListWithdrawals(c.Request.Context(), params, filters)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	items := make([]dto.AdminCommissionWithdrawal, 0, len(withdrawals))
+	for i := range withdrawals {
+		items = append(items, *dto.AdminCommissionWithdrawalFromService(&withdrawals[i]))
+	}
+	response.Paginated(c, items, result.Total, page, pageSize)
+}
+
+// ApproveWithdrawal handles marking a commission withdrawal as paid
+// POST /api/v1/admin/invites/withdrawals/:id/approve
+func (h *InviteHandler) ApproveWithdrawal(c *gin.Context) {
+	h.processWithdrawal(c, "approve_commission_withdrawal", h.commissionService.ApproveWithdrawal)
+}
+
+// RejectWithdrawal handles rejecting a commission withdrawal and refunding the commission balance
+// POST /api/v1/admin/invites/withdrawals/:id/reject
+func (h *InviteHandler) RejectWithdrawal(c *gin.Context) {
+	h.processWithdrawal(c, "reject_commission_withdrawal", h.commissionService.RejectWithdrawal)
+}
+
+func (h *InviteHandler) processWithdrawal(
+	c *gin.Context,
+	action string,
+	process func(ctx context.Context, id, adminID int64, notes string) (*service.CommissionWithdrawal, error),
+) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid withdrawal ID")
+		return
+	}
+
+	subject, ok := middleware.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req ProcessWithdrawalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	withdrawal, err := process(c.Request.Context(), id, subject.UserID, req.Notes)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	h.adminActionLogService.Log(c.Request.Context(), service.AdminActionLogInput{
+		AdminID:      &subject.UserID,
+		Action:       action,
+		ResourceType: "commission_withdrawal",
+		ResourceID:   &withdrawal.ID,
+		Payload: service.MarshalAdminActionPayload(map[string]any{
+			"user_id": withdrawal.UserID,
+			"amount":  withdrawal.Amount,
+			"notes":   req.Notes,
+		}),
+		IPAddress: c.ClientIP(),
+		UserAgent: c.GetHeader("User-Agent"),
+	})
+	response.Success(c, dto.AdminCommissionWithdrawalFromService(withdrawal))
+}
+
+// UpdateCommissionStatus handles blocking or resuming commission accrual for an invitee
+// PUT /api/v1/admin/invites/:invitee_id/commission-status
+func (h *InviteHandler) UpdateCommissionStatus(c *gin.Context) {
+	inviteeID, err := strconv.ParseInt(c.Param("invitee_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid invitee_id")
+		return
+	}
+
+	subject, ok := middleware.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req UpdateInviteCommissionStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.commissionService.SetInviteCommissionStatus(c.Request.Context(), inviteeID, req.Status); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	h.adminActionLogService.Log(c.Request.Context(), service.AdminActionLogInput{
+		AdminID:      &subject.UserID,
+		Action:       "update_invite_commission_status",
+		ResourceType: "invite",
+		ResourceID:   &inviteeID,
+		Payload:      service.MarshalAdminActionPayload(map[string]any{"status": req.Status}),
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.GetHeader("User-Agent"),
+	})
+	response.Success(c, gin.H{"invitee_id": inviteeID, "commission_status": req.Status})
+}
+
+func (h *InviteHandler) commissionSettings(c *gin.Context) *service.InviteCommissionSettings {
+	settings := h.commissionService.GetSettings(c.Request.Context())
+	return &settings
+}
@@ -17,19 +17,30 @@ import (
 // InviteHandler handles admin invite management
 type InviteHandler struct {
 	inviteService         *service.InviteService
+	commissionService     *service.InviteCommissionService
 	adminActionLogService *service.AdminActionLogService
 }
 
 // NewInviteHandler creates a new admin invite handler
-func NewInviteHandler(inviteService *service.InviteService, adminActionLogService *service.AdminActionLogService) *InviteHandler {
+func NewInviteHandler(inviteService *service.InviteService, commissionService *service.InviteCommissionService, adminActionLogService *service.AdminActionLogService) *InviteHandler {
 	return &InviteHandler{
 		inviteService:         inviteService,
+		commissionService:     commissionService,
 		adminActionLogService: adminActionLogService,
 	}
 }
 
 type UpdateInviteSettingsRequest struct {
 	RewardAmount float64 `json:"reward_amount" binding:"min=0"`
+
+	Commission *InviteCommissionSettingsRequest `json:"commission"`
+}
+
+type InviteCommissionSettingsRequest struct {
+	Enabled     bool    `json:"enabled"`
+	Rate        float64 `json:"rate" binding:"min=0,max=100"`
+	Days        int     `json:"days" binding:"min=0"`
+	MinWithdraw float64 `json:"min_withdraw" binding:"min=0"`
 }
 
 // GetSettings handles getting invite settings
@@ -41,7 +52,8 @@ func (h *InviteHandler) GetSettings(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, dto.InviteSettings{RewardAmount: settings.RewardAmount})
+	commission := dto.InviteCommissionSettingsFromService(h.commissionSettings(c))
+	response.Success(c, dto.InviteSettings{RewardAmount: settings.RewardAmount, Commission: &commission})
 }
 
 // UpdateSettings handles updating invite settings
@@ -59,7 +71,20 @@ func (h *InviteHandler) UpdateSettings(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, dto.InviteSettings{RewardAmount: settings.RewardAmount})
+	if req.Commission != nil {
+		if _, err := h.commissionService.UpdateSettings(c.Request.Context(), service.InviteCommissionSettings{
+			Enabled:     req.Commission.Enabled,
+			Rate:        req.Commission.Rate,
+			Days:        req.Commission.Days,
+			MinWithdraw: req.Commission.MinWithdraw,
+		}); err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+	}
+
+	commission := dto.InviteCommissionSettingsFromService(h.commissionSettings(c))
+	response.Success(c, dto.InviteSettings{RewardAmount: settings.RewardAmount, Commission: &commission})
 }
 
 // ConfirmInvite handles confirming invite reward for an invitee
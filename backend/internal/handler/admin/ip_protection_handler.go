@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IPProtectionHandler handles IP bans and allow/deny rules
+type IPProtectionHandler struct {
+	ipProtectionService   *service.IPProtectionService
+	adminActionLogService *service.AdminActionLogService
+}
+
+// NewIPProtectionHandler creates a new IP protection handler
+func NewIPProtectionHandler(ipProtectionService *service.IPProtectionService, adminActionLogService *service.AdminActionLogService) *IPProtectionHandler {
+	return &IPProtectionHandler{
+		ipProtectionService:   ipProtectionService,
+		adminActionLogService: adminActionLogService,
+	}
+}
+
+// UnbanIPRequest represents unban request
+type UnbanIPRequest struct {
+	// Subject IP 或封禁列表中展示的网段（CIDR）
+	Subject string `json:"subject" binding:"required"`
+}
+
+// UpdateIPAccessRulesRequest represents allow/deny rules update request
+type UpdateIPAccessRulesRequest struct {
+	Rules []service.IPAccessRule `json:"rules"`
+}
+
+// ListBans returns currently banned IPs and networks
+// GET /api/v1/admin/security/ip-bans
+func (h *IPProtectionHandler) ListBans(c *gin.Context) {
+	bans, err := h.ipProtectionService.ListBans(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, bans)
+}
+
+// Unban lifts a ban and resets its escalation level
+// POST /api/v1/admin/security/ip-bans/unban
+func (h *IPProtectionHandler) Unban(c *gin.Context) {
+	var req UnbanIPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+	if err := h.ipProtectionService.Unban(c.Request.Context(), req.Subject); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	h.logAction(c, "ip_unban", map[string]any{"subject": req.Subject})
+	response.Success(c, gin.H{"message": "Ban lifted"})
+}
+
+// ListRules returns the manual allow/deny list
+// GET /api/v1/admin/security/ip-rules
+func (h *IPProtectionHandler) ListRules(c *gin.Context) {
+	rules, err := h.ipProtectionService.ListAccessRules(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, rules)
+}
+
+// UpdateRules replaces the manual allow/deny list
+// PUT /api/v1/admin/security/ip-rules
+func (h *IPProtectionHandler) UpdateRules(c *gin.Context) {
+	var req UpdateIPAccessRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+	rules, err := h.ipProtectionService.UpdateAccessRules(c.Request.Context(), req.Rules)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	h.logAction(c, "ip_rules_update", map[string]any{"rules": rules})
+	response.Success(c, rules)
+}
+
+func (h *IPProtectionHandler) logAction(c *gin.Context, action string, payload map[string]any) {
+	subject, ok := middleware.GetAuthSubjectFromContext(c)
+	if !ok {
+		return
+	}
+	h.adminActionLogService.Log(c.Request.Context(), service.AdminActionLogInput{
+		AdminID:      &subject.UserID,
+		Action:       action,
+		ResourceType: "ip_protection",
+		Payload:      service.MarshalAdminActionPayload(payload),
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.GetHeader("User-Agent"),
+	})
+}
@@ -23,6 +23,11 @@ var validOpsAlertMetricTypes = []string{
 	"cpu_usage_percent",
 	"memory_usage_percent",
 	"concurrency_queue_depth",
+	service.OpsMetricUserSpendSpikeRatio,
+	service.OpsMetricAPIKeySpendSpikeRatio,
+	service.OpsMetricAPIKeyNewIPCount,
+	service.OpsMetricAPIKeyNewUserAgentCount,
+	service.OpsMetricAPIKeyOffHoursRequests,
 }
 
 var validOpsAlertMetricTypeSet = func() map[string]struct{} {
@@ -124,6 +129,10 @@ func validateOpsAlertRulePayload(raw map[string]json.RawMessage) (*opsAlertRuleV
 	if _, ok := validOpsAlertOperatorSet[operator]; !ok {
 		return nil, fmt.Errorf("operator must be one of: %s", strings.Join(validOpsAlertOperators, ", "))
 	}
+	// 异常指标按对象逐个比较阈值，只有“高于阈值”有意义
+	if service.IsUsageAnomalyMetric(metricType) && operator != ">" && operator != ">=" {
+		return nil, fmt.Errorf("operator must be > or >= for metric_type %s", metricType)
+	}
 
 	var threshold float64
 	if err := json.Unmarshal(raw["threshold"], &threshold); err != nil {
@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetCapacityPlan returns the account pool capacity planner report.
+// GET /api/v1/admin/ops/capacity-plan
+//
+// Query params:
+// - days: history window in days (default 14, max 90)
+// - horizon_days: projection horizon in days (default 30)
+// - target_utilization: desired peak utilization, 0-1 (default 0.8)
+// - platform: optional
+// - group_id: optional
+func (h *OpsHandler) GetCapacityPlan(c *gin.Context) {
+	if h.opsService == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		return
+	}
+	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	days := 14
+	if v := strings.TrimSpace(c.Query("days")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 90 {
+			response.BadRequest(c, "Invalid days (1-90)")
+			return
+		}
+		days = n
+	}
+
+	filter := &service.OpsCapacityPlanFilter{
+		Platform: strings.TrimSpace(c.Query("platform")),
+	}
+	if v := strings.TrimSpace(c.Query("horizon_days")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 365 {
+			response.BadRequest(c, "Invalid horizon_days (1-365)")
+			return
+		}
+		filter.HorizonDays = n
+	}
+	if v := strings.TrimSpace(c.Query("target_utilization")); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 || f > 1 {
+			response.BadRequest(c, "Invalid target_utilization (0-1]")
+			return
+		}
+		filter.TargetUtilization = f
+	}
+	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			response.BadRequest(c, "Invalid group_id")
+			return
+		}
+		filter.GroupID = &id
+	}
+
+	// Align to the hour so hourly usage buckets are complete.
+	end := time.Now().UTC().Truncate(time.Hour)
+	filter.EndTime = end
+	filter.StartTime = end.Add(-time.Duration(days) * 24 * time.Hour)
+
+	report, err := h.opsService.GetCapacityPlan(c.Request.Context(), filter)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, report)
+}
@@ -0,0 +1,197 @@
+package admin
+
+import (
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationHandler handles admin management of organizations
+type OrganizationHandler struct {
+	organizationService   *service.OrganizationService
+	adminActionLogService *service.AdminActionLogService
+}
+
+// NewOrganizationHandler creates a new admin organization handler
+func NewOrganizationHandler(organizationService *service.OrganizationService, adminActionLogService *service.AdminActionLogService) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService:   organizationService,
+		adminActionLogService: adminActionLogService,
+	}
+}
+
+// UpdateOrganizationRequest represents the admin update organization request payload
+type UpdateOrganizationRequest struct {
+	Name   *string `json:"name"`
+	Status *string `json:"status" binding:"omitempty,oneof=active disabled"`
+	Notes  *string `json:"notes"`
+}
+
+// UpdateOrganizationBalanceRequest represents the organization balance adjustment request payload
+type UpdateOrganizationBalanceRequest struct {
+	Balance   float64 `json:"balance" binding:"required,gt=0"`
+	Operation string  `json:"operation" binding:"required,oneof=set add subtract"`
+	Notes     string  `json:"notes"`
+}
+
+// List handles listing organizations
+// GET /api/v1/admin/organizations
+func (h *OrganizationHandler) List(c *gin.Context) {
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+
+	orgs, result, err := h.organizationService.AdminList(c.Request.Context(), params, c.Query("search"))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.AdminOrganization, 0, len(orgs))
+	for i := range orgs {
+		out = append(out, *dto.AdminOrganizationFromService(&orgs[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
+
+// GetByID handles getting an organization
+// GET /api/v1/admin/organizations/:id
+func (h *OrganizationHandler) GetByID(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	org, err := h.organizationService.AdminGet(c.Request.Context(), orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.AdminOrganizationFromService(org))
+}
+
+// ListMembers handles listing organization members
+// GET /api/v1/admin/organizations/:id/members
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.organizationService.AdminListMembers(c.Request.Context(), orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	out := make([]dto.OrganizationMember, 0, len(members))
+	for i := range members {
+		out = append(out, *dto.OrganizationMemberFromService(&members[i]))
+	}
+	response.Success(c, out)
+}
+
+// Update handles updating an organization
+// PUT /api/v1/admin/organizations/:id
+func (h *OrganizationHandler) Update(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	org, err := h.organizationService.AdminUpdate(c.Request.Context(), orgID, service.AdminUpdateOrganizationInput{
+		Name:   req.Name,
+		Status: req.Status,
+		Notes:  req.Notes,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	h.logAction(c, "update_organization", orgID, map[string]any{
+		"name":   req.Name,
+		"status": req.Status,
+	})
+	response.Success(c, dto.AdminOrganizationFromService(org))
+}
+
+// UpdateBalance handles adjusting an organization's shared balance
+// POST /api/v1/admin/organizations/:id/balance
+func (h *OrganizationHandler) UpdateBalance(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateOrganizationBalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	org, err := h.organizationService.AdminUpdateBalance(c.Request.Context(), orgID, req.Balance, req.Operation)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	h.logAction(c, "update_organization_balance", orgID, map[string]any{
+		"balance":   req.Balance,
+		"operation": req.Operation,
+		"notes":     req.Notes,
+	})
+	response.Success(c, dto.AdminOrganizationFromService(org))
+}
+
+// Delete handles deleting an organization
+// DELETE /api/v1/admin/organizations/:id
+func (h *OrganizationHandler) Delete(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.organizationService.AdminDelete(c.Request.Context(), orgID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	h.logAction(c, "delete_organization", orgID, nil)
+	response.Success(c, gin.H{"message": "Organization deleted successfully"})
+}
+
+func parseOrganizationID(c *gin.Context) (int64, bool) {
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid organization ID")
+		return 0, false
+	}
+	return orgID, true
+}
+
+func (h *OrganizationHandler) logAction(c *gin.Context, action string, orgID int64, payload map[string]any) {
+	if h.adminActionLogService == nil {
+		return
+	}
+	subject, ok := middleware.GetAuthSubjectFromContext(c)
+	if !ok {
+		return
+	}
+	h.adminActionLogService.Log(c.Request.Context(), service.AdminActionLogInput{
+		AdminID:      &subject.UserID,
+		Action:       action,
+		ResourceType: "organization",
+		ResourceID:   &orgID,
+		Payload:      service.MarshalAdminActionPayload(payload),
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.GetHeader("User-Agent"),
+	})
+}
@@ -53,6 +53,8 @@ func (h *SettingHandler) GetSettings(c *gin.Context) {
 		PasswordResetEnabled:                 settings.PasswordResetEnabled,
 		TotpEnabled:                          settings.TotpEnabled,
 		TotpEncryptionKeyConfigured:          h.settingService.IsTotpEncryptionKeyConfigured(),
+		WebAuthnEnabled:                      settings.WebAuthnEnabled,
+		RequireAdmin2FA:                      settings.RequireAdmin2FA,
 		SMTPHost:                             settings.SMTPHost,
 		SMTPPort:                             settings.SMTPPort,
 		SMTPUsername:                         settings.SMTPUsername,
@@ -103,7 +105,9 @@ type UpdateSettingsRequest struct {
 	EmailVerifyEnabled   bool `json:"email_verify_enabled"`
 	PromoCodeEnabled     bool `json:"promo_code_enabled"`
 	PasswordResetEnabled bool `json:"password_reset_enabled"`
-	TotpEnabled          bool `json:"totp_enabled"` // TOTP 双因素认证
+	TotpEnabled          bool `json:"totp_enabled"`      // TOTP 双因素认证
+	WebAuthnEnabled      bool `json:"webauthn_enabled"`  // Passkey 两步验证与免密码登录
+	RequireAdmin2FA      bool `json:"require_admin_2fa"` // 管理员必须启用两步验证
 
 	// 邮件服务设置
 	SMTPHost     string `json:"smtp_host"`
@@ -225,6 +229,12 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 		}
 	}
 
+	// Passkey 依赖方必须在配置文件中固定，不能从请求头推导
+	if req.WebAuthnEnabled && !previousSettings.WebAuthnEnabled && !h.settingService.IsWebAuthnConfigured() {
+		response.BadRequest(c, "Cannot enable passkeys: webauthn.rp_id (and webauthn.origins for non-default origins) must be configured first.")
+		return
+	}
+
 	// LinuxDo Connect 参数验证
 	if req.LinuxDoConnectEnabled {
 		req.LinuxDoConnectClientID = strings.TrimSpace(req.LinuxDoConnectClientID)
@@ -300,6 +310,8 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 		PromoCodeEnabled:            req.PromoCodeEnabled,
 		PasswordResetEnabled:        req.PasswordResetEnabled,
 		TotpEnabled:                 req.TotpEnabled,
+		WebAuthnEnabled:             req.WebAuthnEnabled,
+		RequireAdmin2FA:             req.RequireAdmin2FA,
 		SMTPHost:                    req.SMTPHost,
 		SMTPPort:                    req.SMTPPort,
 		SMTPUsername:                req.SMTPUsername,
@@ -383,6 +395,8 @@ func (h *SettingHandler) UpdateSettings(c *gin.Context) {
 		PasswordResetEnabled:                 updatedSettings.PasswordResetEnabled,
 		TotpEnabled:                          updatedSettings.TotpEnabled,
 		TotpEncryptionKeyConfigured:          h.settingService.IsTotpEncryptionKeyConfigured(),
+		WebAuthnEnabled:                      updatedSettings.WebAuthnEnabled,
+		RequireAdmin2FA:                      updatedSettings.RequireAdmin2FA,
 		SMTPHost:                             updatedSettings.SMTPHost,
 		SMTPPort:                             updatedSettings.SMTPPort,
 		SMTPUsername:                         updatedSettings.SMTPUsername,
@@ -474,6 +488,12 @@ func diffSettings(before *service.SystemSettings, after *service.SystemSettings,
 	if before.TotpEnabled != after.TotpEnabled {
 		changed = append(changed, "totp_enabled")
 	}
+	if before.WebAuthnEnabled != after.WebAuthnEnabled {
+		changed = append(changed, "webauthn_enabled")
+	}
+	if before.RequireAdmin2FA != after.RequireAdmin2FA {
+		changed = append(changed, "require_admin_2fa")
+	}
 	if before.SMTPHost != after.SMTPHost {
 		changed = append(changed, "smtp_host")
 	}
@@ -829,3 +849,61 @@ func (h *SettingHandler) UpdateStreamTimeoutSettings(c *gin.Context) {
 		ThresholdWindowMinutes: updatedSettings.ThresholdWindowMinutes,
 	})
 }
+
+// GetModelRateLimitScopeSettings 获取模型限流域配置
+// GET /api/v1/admin/settings/model-rate-limit-scopes
+func (h *SettingHandler) GetModelRateLimitScopeSettings(c *gin.Context) {
+	settings, err := h.settingService.GetModelRateLimitScopeSettings(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, modelRateLimitScopeSettingsToDTO(settings))
+}
+
+// UpdateModelRateLimitScopeSettings 更新模型限流域配置
+// PUT /api/v1/admin/settings/model-rate-limit-scopes
+func (h *SettingHandler) UpdateModelRateLimitScopeSettings(c *gin.Context) {
+	var req dto.ModelRateLimitScopeSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	settings := &service.ModelRateLimitScopeSettings{
+		Rules: make([]service.ModelRateLimitScopeRule, 0, len(req.Rules)),
+	}
+	for _, rule := range req.Rules {
+		settings.Rules = append(settings.Rules, service.ModelRateLimitScopeRule{
+			Platform: rule.Platform,
+			Pattern:  rule.Pattern,
+			Scope:    rule.Scope,
+		})
+	}
+
+	if err := h.settingService.SetModelRateLimitScopeSettings(c.Request.Context(), settings); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	updatedSettings, err := h.settingService.GetModelRateLimitScopeSettings(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, modelRateLimitScopeSettingsToDTO(updatedSettings))
+}
+
+func modelRateLimitScopeSettingsToDTO(settings *service.ModelRateLimitScopeSettings) dto.ModelRateLimitScopeSettings {
+	out := dto.ModelRateLimitScopeSettings{
+		Rules: make([]dto.ModelRateLimitScopeRule, 0, len(settings.Rules)),
+	}
+	for _, rule := range settings.Rules {
+		out.Rules = append(out.Rules, dto.ModelRateLimitScopeRule{
+			Platform: rule.Platform,
+			Pattern:  rule.Pattern,
+			Scope:    rule.Scope,
+		})
+	}
+	return out
+}
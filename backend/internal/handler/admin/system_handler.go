@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Wei-Shaw/sub2api/internal/config"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/sysutil"
 	"github.com/Wei-Shaw/sub2api/internal/service"
@@ -13,13 +14,15 @@ import (
 
 // SystemHandler handles system-related operations
 type SystemHandler struct {
-	updateSvc *service.UpdateService
+	updateSvc      *service.UpdateService
+	configReloader *config.Reloader
 }
 
 // NewSystemHandler creates a new SystemHandler
-func NewSystemHandler(updateSvc *service.UpdateService) *SystemHandler {
+func NewSystemHandler(updateSvc *service.UpdateService, configReloader *config.Reloader) *SystemHandler {
 	return &SystemHandler{
-		updateSvc: updateSvc,
+		updateSvc:      updateSvc,
+		configReloader: configReloader,
 	}
 }
 
@@ -85,3 +88,28 @@ func (h *SystemHandler) RestartService(c *gin.Context) {
 		"message": "Service restart initiated",
 	})
 }
+
+// ReloadConfig reloads config.yaml without restarting the service
+// POST /api/v1/admin/system/config/reload
+func (h *SystemHandler) ReloadConfig(c *gin.Context) {
+	if h.configReloader == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Config reload is not available")
+		return
+	}
+	result, err := h.configReloader.Reload(config.ReloadSourceAdmin)
+	if err != nil {
+		response.BadRequest(c, "Config reload failed: "+err.Error())
+		return
+	}
+	response.Success(c, result)
+}
+
+// GetConfigReloads returns recent config reload results
+// GET /api/v1/admin/system/config/reloads
+func (h *SystemHandler) GetConfigReloads(c *gin.Context) {
+	if h.configReloader == nil {
+		response.Success(c, []config.ReloadResult{})
+		return
+	}
+	response.Success(c, h.configReloader.History())
+}
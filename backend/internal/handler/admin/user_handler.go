@@ -13,13 +13,15 @@ import (
 
 // UserHandler handles admin user management
 type UserHandler struct {
-	adminService service.AdminService
+	adminService   service.AdminService
+	sessionService *service.AuthSessionService
 }
 
 // NewUserHandler creates a new admin user handler
-func NewUserHandler(adminService service.AdminService) *UserHandler {
+func NewUserHandler(adminService service.AdminService, sessionService *service.AuthSessionService) *UserHandler {
 	return &UserHandler{
-		adminService: adminService,
+		adminService:   adminService,
+		sessionService: sessionService,
 	}
 }
 
@@ -210,6 +212,42 @@ func (h *UserHandler) Delete(c *gin.Context) {
 	response.Success(c, gin.H{"message": "User deleted successfully"})
 }
 
+// ListSessions handles listing a user's login sessions
+// GET /api/v1/admin/users/:id/sessions
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.AuthSessionsFromService(sessions, ""))
+}
+
+// ForceLogout 强制用户下线：吊销全部登录会话，绑定会话的 token 随之失效
+// POST /api/v1/admin/users/:id/force-logout
+func (h *UserHandler) ForceLogout(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	revoked, err := h.sessionService.ForceLogout(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"revoked": revoked})
+}
+
 // UpdateBalance handles updating user balance
 // POST /api/v1/admin/users/:id/balance
 func (h *UserHandler) UpdateBalance(c *gin.Context) {
@@ -32,6 +32,8 @@ type CreateAPIKeyRequest struct {
 	CustomKey   *string  `json:"custom_key"`   // 可选的自定义key
 	IPWhitelist []string `json:"ip_whitelist"` // IP 白名单
 	IPBlacklist []string `json:"ip_blacklist"` // IP 黑名单
+	// OrganizationID 创建组织 Key（nullable）
+	OrganizationID *int64 `json:"organization_id"`
 }
 
 // UpdateAPIKeyRequest represents the update API key request payload
@@ -119,6 +121,8 @@ func (h *APIKeyHandler) Create(c *gin.Context) {
 		CustomKey:   req.CustomKey,
 		IPWhitelist: req.IPWhitelist,
 		IPBlacklist: req.IPBlacklist,
+
+		OrganizationID: req.OrganizationID,
 	}
 	key, err := h.apiKeyService.Create(c.Request.Context(), subject.UserID, svcReq)
 	if err != nil {
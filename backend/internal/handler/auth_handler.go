@@ -21,17 +21,24 @@ type AuthHandler struct {
 	settingSvc   *service.SettingService
 	promoService *service.PromoService
 	totpService  *service.TotpService
+
+	sessionService   *service.AuthSessionService
+	webAuthnService  *service.WebAuthnService
+	twoFactorService *service.TwoFactorService
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(cfg *config.Config, authService *service.AuthService, userService *service.UserService, settingService *service.SettingService, promoService *service.PromoService, totpService *service.TotpService) *AuthHandler {
+func NewAuthHandler(cfg *config.Config, authService *service.AuthService, userService *service.UserService, settingService *service.SettingService, promoService *service.PromoService, totpService *service.TotpService, sessionService *service.AuthSessionService, webAuthnService *service.WebAuthnService, twoFactorService *service.TwoFactorService) *AuthHandler {
 	return &AuthHandler{
-		cfg:          cfg,
-		authService:  authService,
-		userService:  userService,
-		settingSvc:   settingService,
-		promoService: promoService,
-		totpService:  totpService,
+		cfg:              cfg,
+		authService:      authService,
+		userService:      userService,
+		settingSvc:       settingService,
+		promoService:     promoService,
+		totpService:      totpService,
+		sessionService:   sessionService,
+		webAuthnService:  webAuthnService,
+		twoFactorService: twoFactorService,
 	}
 }
 
@@ -43,6 +50,7 @@ type RegisterRequest struct {
 	TurnstileToken string `json:"turnstile_token"`
 	PromoCode      string `json:"promo_code"` // 注册优惠码
 	InviteCode     string `json:"invite_code"`
+	DeviceID       string `json:"device_id"` // 前端生成的设备指纹（可选，用于邀请返佣反作弊）
 }
 
 // SendVerifyCodeRequest 发送验证码请求
@@ -62,13 +70,42 @@ type LoginRequest struct {
 	Email          string `json:"email" binding:"required,email"`
 	Password       string `json:"password" binding:"required"`
 	TurnstileToken string `json:"turnstile_token"`
+	DeviceID       string `json:"device_id"` // 前端生成的设备标识（可选，用于登录设备列表）
 }
 
 // AuthResponse 认证响应格式（匹配前端期望）
 type AuthResponse struct {
-	AccessToken string    `json:"access_token"`
-	TokenType   string    `json:"token_type"`
-	User        *dto.User `json:"user"`
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresIn    int       `json:"expires_in"`
+	TokenType    string    `json:"token_type"`
+	User         *dto.User `json:"user"`
+}
+
+// authClientInfo 提取登录请求的客户端信息
+func authClientInfo(c *gin.Context, deviceID string) service.AuthClientInfo {
+	return service.AuthClientInfo{
+		IP:        ip.GetClientIP(c),
+		UserAgent: c.GetHeader("User-Agent"),
+		DeviceID:  deviceID,
+	}
+}
+
+// respondWithSession 为用户创建登录会话并返回 token
+func (h *AuthHandler) respondWithSession(c *gin.Context, user *service.User, deviceID string) {
+	tokens, err := h.sessionService.IssueTokens(c.Request.Context(), user, authClientInfo(c, deviceID))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, AuthResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		TokenType:    "Bearer",
+		User:         dto.UserFromService(user),
+	})
 }
 
 // Register handles user registration
@@ -88,17 +125,16 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		}
 	}
 
-	token, user, err := h.authService.RegisterWithVerification(c.Request.Context(), req.Email, req.Password, req.VerifyCode, req.PromoCode, req.InviteCode)
+	_, user, err := h.authService.RegisterWithVerification(c.Request.Context(), req.Email, req.Password, req.VerifyCode, req.PromoCode, req.InviteCode, service.RegisterClientInfo{
+		IP:       ip.GetClientIP(c),
+		DeviceID: req.DeviceID,
+	})
 	if err != nil {
 		response.ErrorFrom(c, err)
 		return
 	}
 
-	response.Success(c, AuthResponse{
-		AccessToken: token,
-		TokenType:   "Bearer",
-		User:        dto.UserFromService(user),
-	})
+	h.respondWithSession(c, user, req.DeviceID)
 }
 
 // SendVerifyCode 发送邮箱验证码
@@ -143,47 +179,54 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, user, err := h.authService.Login(c.Request.Context(), req.Email, req.Password)
+	_, user, err := h.authService.Login(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
 		response.ErrorFrom(c, err)
 		return
 	}
 
-	// Check if TOTP 2FA is enabled for this user
-	if h.totpService != nil && h.settingSvc.IsTotpEnabled(c.Request.Context()) && user.TotpEnabled {
-		// Create a temporary login session for 2FA
-		tempToken, err := h.totpService.CreateLoginSession(c.Request.Context(), user.ID, user.Email)
+	// Check if 2FA (TOTP or passkey) is enabled for this user
+	if h.totpService != nil && h.twoFactorService != nil {
+		methods, err := h.twoFactorService.Methods(c.Request.Context(), user)
 		if err != nil {
-			response.InternalError(c, "Failed to create 2FA session")
+			response.ErrorFrom(c, err)
+			return
+		}
+		if len(methods) > 0 {
+			// Create a temporary login session for 2FA
+			tempToken, err := h.totpService.CreateLoginSession(c.Request.Context(), user.ID, user.Email)
+			if err != nil {
+				response.InternalError(c, "Failed to create 2FA session")
+				return
+			}
+
+			response.Success(c, TotpLoginResponse{
+				Requires2FA:     true,
+				TempToken:       tempToken,
+				UserEmailMasked: service.MaskEmail(user.Email),
+				Methods:         methods,
+			})
 			return
 		}
-
-		response.Success(c, TotpLoginResponse{
-			Requires2FA:     true,
-			TempToken:       tempToken,
-			UserEmailMasked: service.MaskEmail(user.Email),
-		})
-		return
 	}
 
-	response.Success(c, AuthResponse{
-		AccessToken: token,
-		TokenType:   "Bearer",
-		User:        dto.UserFromService(user),
-	})
+	h.respondWithSession(c, user, req.DeviceID)
 }
 
 // TotpLoginResponse represents the response when 2FA is required
 type TotpLoginResponse struct {
-	Requires2FA     bool   `json:"requires_2fa"`
-	TempToken       string `json:"temp_token,omitempty"`
-	UserEmailMasked string `json:"user_email_masked,omitempty"`
+	Requires2FA     bool     `json:"requires_2fa"`
+	TempToken       string   `json:"temp_token,omitempty"`
+	UserEmailMasked string   `json:"user_email_masked,omitempty"`
+	Methods         []string `json:"methods,omitempty"` // 可用的两步验证方式：totp / webauthn / recovery_code
 }
 
 // Login2FARequest represents the 2FA login request
 type Login2FARequest struct {
-	TempToken string `json:"temp_token" binding:"required"`
-	TotpCode  string `json:"totp_code" binding:"required,len=6"`
+	TempToken    string `json:"temp_token" binding:"required"`
+	TotpCode     string `json:"totp_code" binding:"omitempty,len=6"`
+	RecoveryCode string `json:"recovery_code"` // 无法使用 TOTP / passkey 时使用恢复码
+	DeviceID     string `json:"device_id"`
 }
 
 // Login2FA completes the login with 2FA verification
@@ -217,8 +260,16 @@ func (h *AuthHandler) Login2FA(c *gin.Context) {
 		"user_id", session.UserID,
 		"email", session.Email)
 
-	// Verify the TOTP code
-	if err := h.totpService.VerifyCode(c.Request.Context(), session.UserID, req.TotpCode); err != nil {
+	// Verify the TOTP code or consume a recovery code
+	switch {
+	case req.TotpCode != "":
+		err = h.totpService.VerifyCode(c.Request.Context(), session.UserID, req.TotpCode)
+	case req.RecoveryCode != "" && h.twoFactorService != nil:
+		err = h.twoFactorService.ConsumeRecoveryCode(c.Request.Context(), session.UserID, req.RecoveryCode)
+	default:
+		err = service.ErrTwoFactorCodeMissing
+	}
+	if err != nil {
 		slog.Debug("login_2fa_verify_failed",
 			"user_id", session.UserID,
 			"error", err)
@@ -236,20 +287,55 @@ func (h *AuthHandler) Login2FA(c *gin.Context) {
 		return
 	}
 
-	// Generate the JWT token
-	token, err := h.authService.GenerateToken(user)
+	h.respondWithSession(c, user, req.DeviceID)
+}
+
+// RefreshTokenRequest 刷新 token 请求
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RefreshToken 使用 refresh token 换取新的 token（refresh token 一次性有效，每次刷新都会轮换）
+// POST /api/v1/auth/refresh
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	tokens, user, err := h.sessionService.Refresh(c.Request.Context(), req.RefreshToken, authClientInfo(c, ""))
 	if err != nil {
-		response.InternalError(c, "Failed to generate token")
+		response.ErrorFrom(c, err)
 		return
 	}
 
 	response.Success(c, AuthResponse{
-		AccessToken: token,
-		TokenType:   "Bearer",
-		User:        dto.UserFromService(user),
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		TokenType:    "Bearer",
+		User:         dto.UserFromService(user),
 	})
 }
 
+// Logout 注销当前登录会话
+// POST /api/v1/auth/logout
+func (h *AuthHandler) Logout(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.sessionService.Logout(c.Request.Context(), subject.UserID, middleware2.GetAuthSessionIDFromContext(c)); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Logged out"})
+}
+
 // GetCurrentUser handles getting current authenticated user
 // GET /api/v1/auth/me
 func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
@@ -374,16 +460,7 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	}
 
 	// Build frontend base URL from request
-	scheme := "https"
-	if c.Request.TLS == nil {
-		// Check X-Forwarded-Proto header (common in reverse proxy setups)
-		if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
-			scheme = proto
-		} else {
-			scheme = "http"
-		}
-	}
-	frontendBaseURL := scheme + "://" + c.Request.Host
+	frontendBaseURL := requestFrontendBaseURL(c)
 
 	// Request password reset (async)
 	// Note: This returns success even if email doesn't exist (to prevent enumeration)
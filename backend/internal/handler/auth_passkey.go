@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasskeyOptionsRequest 两步验证阶段获取 passkey 断言参数
+type PasskeyOptionsRequest struct {
+	TempToken string `json:"temp_token" binding:"required"`
+}
+
+// PasskeyLoginRequest 提交 passkey 断言
+type PasskeyLoginRequest struct {
+	TempToken   string                     `json:"temp_token"` // 仅两步验证时需要
+	ChallengeID string                     `json:"challenge_id" binding:"required"`
+	Credential  *service.WebAuthnAssertion `json:"credential" binding:"required"`
+	DeviceID    string                     `json:"device_id"`
+}
+
+// PasskeyLoginOptions 获取免密码登录的断言参数
+// POST /api/v1/auth/passkey/options
+func (h *AuthHandler) PasskeyLoginOptions(c *gin.Context) {
+	opts, err := h.webAuthnService.BeginPasswordless(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, opts)
+}
+
+// PasskeyLogin 使用 passkey 免密码登录（完成用户验证的 passkey 同时满足两步验证）
+// POST /api/v1/auth/passkey/login
+func (h *AuthHandler) PasskeyLogin(c *gin.Context) {
+	var req PasskeyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	user, err := h.webAuthnService.FinishPasswordless(c.Request.Context(), req.ChallengeID, req.Credential)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	h.respondWithSession(c, user, req.DeviceID)
+}
+
+// Login2FAPasskeyOptions 密码验证通过后，获取第二因素 passkey 的断言参数
+// POST /api/v1/auth/login/2fa/webauthn/options
+func (h *AuthHandler) Login2FAPasskeyOptions(c *gin.Context) {
+	var req PasskeyOptionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	opts, err := h.webAuthnService.BeginSecondFactor(c.Request.Context(), req.TempToken)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, opts)
+}
+
+// Login2FAPasskey 使用 passkey 完成两步验证
+// POST /api/v1/auth/login/2fa/webauthn
+func (h *AuthHandler) Login2FAPasskey(c *gin.Context) {
+	var req PasskeyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+	if req.TempToken == "" {
+		response.BadRequest(c, "temp_token is required")
+		return
+	}
+
+	user, err := h.webAuthnService.FinishSecondFactor(c.Request.Context(), req.TempToken, req.ChallengeID, req.Credential)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	h.respondWithSession(c, user, req.DeviceID)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// opsContentPolicyKey 保存内容策略检查结果，供 OpsErrorLoggerMiddleware 记录运维事件
+const opsContentPolicyKey = "ops_content_policy"
+
+// opsErrorPhasePolicy 内容策略命中的运维事件阶段
+const opsErrorPhasePolicy = "policy"
+
+// applyContentPolicy 在转发前执行 API Key 所属分组的内容策略。
+// 分组未配置策略时返回 nil；Blocked 为 true 时调用方需按各平台格式返回错误，
+// Redacted 为 true 时调用方需改用 result.Body 继续转发。
+func applyContentPolicy(c *gin.Context, svc *service.ContentPolicyService, apiKey *service.APIKey, model string, body []byte) *service.ContentPolicyResult {
+	if svc == nil || apiKey == nil || apiKey.Group == nil || !apiKey.Group.ContentPolicy.IsActive() {
+		return nil
+	}
+
+	platform := apiKey.Group.Platform
+	if forcePlatform, ok := middleware2.GetForcePlatformFromContext(c); ok {
+		platform = forcePlatform
+	}
+	input := &service.ContentPolicyCheckInput{
+		Group:    apiKey.Group,
+		APIKeyID: apiKey.ID,
+		Platform: platform,
+		Model:    model,
+		Body:     body,
+	}
+	if apiKey.User != nil {
+		input.UserID = apiKey.User.ID
+	}
+
+	result := svc.Check(c.Request.Context(), input)
+	if !result.HasViolations() {
+		return result
+	}
+	c.Set(opsContentPolicyKey, result)
+	if result.Blocked {
+		// 被拦截的请求不保存请求体，避免把命中的敏感内容写入运维日志
+		c.Set(opsRequestBodyKey, []byte(nil))
+	}
+	log.Printf("[ContentPolicy] violation: group=%d api_key=%d user=%d model=%s blocked=%v rules=%s",
+		apiKey.Group.ID, apiKey.ID, input.UserID, model, result.Blocked, result.Summary())
+	return result
+}
+
+// getOpsContentPolicyResult 读取请求上记录的内容策略命中结果
+func getOpsContentPolicyResult(c *gin.Context) *service.ContentPolicyResult {
+	v, ok := c.Get(opsContentPolicyKey)
+	if !ok {
+		return nil
+	}
+	result, _ := v.(*service.ContentPolicyResult)
+	if !result.HasViolations() {
+		return nil
+	}
+	return result
+}
+
+// applyOpsContentPolicy 将运维错误日志标记为内容策略事件（用户级业务限制，不计入 SLA）
+func applyOpsContentPolicy(entry *service.OpsInsertErrorLogInput, result *service.ContentPolicyResult) {
+	entry.ErrorPhase = opsErrorPhasePolicy
+	entry.ErrorType = "content_policy_error"
+	entry.Severity = "P3"
+	entry.IsBusinessLimited = true
+	entry.IsRetryable = false
+	entry.ErrorOwner = "client"
+	entry.ErrorSource = "client_request"
+	entry.ErrorMessage = truncateString("Content policy violation: "+result.Summary(), 2048)
+}
+
+// enqueueOpsContentPolicyEvent 记录未拦截请求（redact/log 动作）的内容策略事件
+func enqueueOpsContentPolicyEvent(c *gin.Context, ops *service.OpsService, status int, result *service.ContentPolicyResult) {
+	apiKey, _ := middleware2.GetAPIKeyFromContext(c)
+	clientRequestID, _ := c.Request.Context().Value(ctxkey.ClientRequestID).(string)
+
+	var modelName string
+	if v, ok := c.Get(opsModelKey); ok {
+		modelName, _ = v.(string)
+	}
+	stream := false
+	if v, ok := c.Get(opsStreamKey); ok {
+		stream, _ = v.(bool)
+	}
+	var accountID *int64
+	if v, ok := c.Get(opsAccountIDKey); ok {
+		if id, ok := v.(int64); ok && id > 0 {
+			accountID = &id
+		}
+	}
+
+	requestID := c.Writer.Header().Get("X-Request-Id")
+	if requestID == "" {
+		requestID = c.Writer.Header().Get("x-request-id")
+	}
+
+	entry := &service.OpsInsertErrorLogInput{
+		RequestID:       requestID,
+		ClientRequestID: clientRequestID,
+		AccountID:       accountID,
+		Platform:        resolveOpsPlatform(apiKey, guessPlatformFromPath(c.Request.URL.Path)),
+		Model:           modelName,
+		RequestPath:     c.Request.URL.Path,
+		Stream:          stream,
+		UserAgent:       c.GetHeader("User-Agent"),
+		StatusCode:      status,
+		IsCountTokens:   isCountTokensRequest(c),
+		CreatedAt:       time.Now(),
+	}
+	applyOpsContentPolicy(entry, result)
+
+	if apiKey != nil {
+		entry.APIKeyID = &apiKey.ID
+		if apiKey.User != nil {
+			entry.UserID = &apiKey.User.ID
+		}
+		if apiKey.GroupID != nil {
+			entry.GroupID = apiKey.GroupID
+		}
+		if apiKey.Group != nil && apiKey.Group.Platform != "" {
+			entry.Platform = apiKey.Group.Platform
+		}
+	}
+	if clientIP := strings.TrimSpace(ip.GetClientIP(c)); clientIP != "" {
+		entry.ClientIP = &clientIP
+	}
+
+	// 不保存请求体，避免把命中的敏感内容写入运维日志
+	enqueueOpsErrorLog(ops, entry, nil)
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type AuthSession struct {
+	ID         string    `json:"id"`
+	IP         string    `json:"ip"`
+	LastIP     string    `json:"last_ip"`
+	UserAgent  string    `json:"user_agent"`
+	DeviceID   string    `json:"device_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	// Current 是否为发起请求的当前会话
+	Current bool `json:"current"`
+}
+
+func AuthSessionsFromService(sessions []service.AuthSession, currentSessionID string) []AuthSession {
+	out := make([]AuthSession, 0, len(sessions))
+	for i := range sessions {
+		s := &sessions[i]
+		out = append(out, AuthSession{
+			ID:         s.ID,
+			IP:         s.IP,
+			LastIP:     s.LastIP,
+			UserAgent:  s.UserAgent,
+			DeviceID:   s.DeviceID,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			Current:    currentSessionID != "" && s.ID == currentSessionID,
+		})
+	}
+	return out
+}
@@ -21,6 +21,9 @@ type InviteRecord struct {
 	Status       string     `json:"status"`
 	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
 	RewardAmount float64    `json:"reward_amount"`
+
+	CommissionStatus string  `json:"commission_status"`
+	CommissionTotal  float64 `json:"commission_total"`
 }
 
 type InviteRewardRecord struct {
@@ -46,6 +49,8 @@ type InviteLog struct {
 
 type InviteSettings struct {
 	RewardAmount float64 `json:"reward_amount"`
+
+	Commission *InviteCommissionSettings `json:"commission,omitempty"`
 }
 
 func InviteSummaryFromService(summary *service.InviteSummary) *InviteSummary {
@@ -76,6 +81,9 @@ func InviteRecordFromService(invite *service.Invite) *InviteRecord {
 		Status:       invite.Status,
 		ConfirmedAt:  invite.ConfirmedAt,
 		RewardAmount: invite.RewardAmount,
+
+		CommissionStatus: invite.CommissionStatus,
+		CommissionTotal:  invite.CommissionTotal,
 	}
 }
 
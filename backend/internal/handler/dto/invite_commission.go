@@ -0,0 +1,126 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type InviteCommissionSettings struct {
+	Enabled     bool    `json:"enabled"`
+	Rate        float64 `json:"rate"`
+	Days        int     `json:"days"`
+	MinWithdraw float64 `json:"min_withdraw"`
+}
+
+type InviteCommissionReport struct {
+	Settings          InviteCommissionSettings `json:"settings"`
+	Balance           float64                  `json:"balance"`
+	TotalEarned       float64                  `json:"total_earned"`
+	TodayEarned       float64                  `json:"today_earned"`
+	Last30DaysEarned  float64                  `json:"last_30_days_earned"`
+	PendingWithdrawal float64                  `json:"pending_withdrawal"`
+	TotalWithdrawn    float64                  `json:"total_withdrawn"`
+	ActiveInvitees    int                      `json:"active_invitees"`
+	BlockedInvitees   int                      `json:"blocked_invitees"`
+}
+
+type InviteCommission struct {
+	ID           int64     `json:"id"`
+	InviteeEmail string    `json:"invitee_email"`
+	BaseAmount   float64   `json:"base_amount"`
+	Rate         float64   `json:"rate"`
+	Amount       float64   `json:"amount"`
+	LogCount     int       `json:"log_count"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CommissionWithdrawal struct {
+	ID          int64      `json:"id"`
+	Amount      float64    `json:"amount"`
+	Method      string     `json:"method"`
+	Status      string     `json:"status"`
+	AccountInfo string     `json:"account_info,omitempty"`
+	AdminNotes  string     `json:"admin_notes,omitempty"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type AdminCommissionWithdrawal struct {
+	CommissionWithdrawal
+	UserID      int64  `json:"user_id"`
+	UserEmail   string `json:"user_email"`
+	ProcessedBy *int64 `json:"processed_by,omitempty"`
+}
+
+func InviteCommissionSettingsFromService(settings *service.InviteCommissionSettings) InviteCommissionSettings {
+	if settings == nil {
+		return InviteCommissionSettings{}
+	}
+	return InviteCommissionSettings{
+		Enabled:     settings.Enabled,
+		Rate:        settings.Rate,
+		Days:        settings.Days,
+		MinWithdraw: settings.MinWithdraw,
+	}
+}
+
+func InviteCommissionReportFromService(report *service.InviteCommissionReport) *InviteCommissionReport {
+	if report == nil {
+		return nil
+	}
+	return &InviteCommissionReport{
+		Settings:          InviteCommissionSettingsFromService(&report.Settings),
+		Balance:           report.Balance,
+		TotalEarned:       report.TotalEarned,
+		TodayEarned:       report.TodayEarned,
+		Last30DaysEarned:  report.Last30DaysEarned,
+		PendingWithdrawal: report.PendingWithdrawal,
+		TotalWithdrawn:    report.TotalWithdrawn,
+		ActiveInvitees:    report.ActiveInvitees,
+		BlockedInvitees:   report.BlockedInvitees,
+	}
+}
+
+func InviteCommissionFromService(c *service.InviteCommission) *InviteCommission {
+	if c == nil {
+		return nil
+	}
+	return &InviteCommission{
+		ID:           c.ID,
+		InviteeEmail: c.InviteeEmail,
+		BaseAmount:   c.BaseAmount,
+		Rate:         c.Rate,
+		Amount:       c.Amount,
+		LogCount:     c.LogCount,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+func CommissionWithdrawalFromService(w *service.CommissionWithdrawal) *CommissionWithdrawal {
+	if w == nil {
+		return nil
+	}
+	return &CommissionWithdrawal{
+		ID:          w.ID,
+		Amount:      w.Amount,
+		Method:      w.Method,
+		Status:      w.Status,
+		AccountInfo: w.AccountInfo,
+		AdminNotes:  w.AdminNotes,
+		ProcessedAt: w.ProcessedAt,
+		CreatedAt:   w.CreatedAt,
+	}
+}
+
+func AdminCommissionWithdrawalFromService(w *service.CommissionWithdrawal) *AdminCommissionWithdrawal {
+	if w == nil {
+		return nil
+	}
+	return &AdminCommissionWithdrawal{
+		CommissionWithdrawal: *CommissionWithdrawalFromService(w),
+		UserID:               w.UserID,
+		UserEmail:            w.UserEmail,
+		ProcessedBy:          w.ProcessedBy,
+	}
+}
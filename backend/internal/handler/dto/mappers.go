@@ -64,8 +64,10 @@ func UserFromServiceAdmin(u *service.User) *AdminUser {
 		return nil
 	}
 	return &AdminUser{
-		User:  *base,
-		Notes: u.Notes,
+		User:                  *base,
+		Notes:                 u.Notes,
+		DedicatedAccountIDs:   u.DedicatedAccountIDs,
+		DedicatedAccountsOnly: u.DedicatedAccountsOnly,
 	}
 }
 
@@ -77,6 +79,7 @@ func APIKeyFromService(k *service.APIKey) *APIKey {
 		ID:          k.ID,
 		UserID:      k.UserID,
 		Key:         k.Key,
+		KeyPrefix:   k.KeyPrefix,
 		Name:        k.Name,
 		GroupID:     k.GroupID,
 		Status:      k.Status,
@@ -86,6 +89,8 @@ func APIKeyFromService(k *service.APIKey) *APIKey {
 		UpdatedAt:   k.UpdatedAt,
 		User:        UserFromServiceShallow(k.User),
 		Group:       GroupFromServiceShallow(k.Group),
+
+		OrganizationID: k.OrganizationID,
 	}
 }
 
@@ -104,6 +109,113 @@ func GroupFromService(g *service.Group) *Group {
 	return GroupFromServiceShallow(g)
 }
 
+// RequestRewriteRulesFromService converts group rewrite rules to DTO (always a non-nil slice).
+func RequestRewriteRulesFromService(rules []service.RequestRewriteRule) []RequestRewriteRule {
+	out := make([]RequestRewriteRule, 0, len(rules))
+	for _, rule := range rules {
+		ops := make([]RequestRewriteOp, 0, len(rule.Operations))
+		for _, op := range rule.Operations {
+			ops = append(ops, RequestRewriteOp{Type: op.Type, Path: op.Path, Header: op.Header, Value: op.Value})
+		}
+		platforms := rule.Platforms
+		if platforms == nil {
+			platforms = []string{}
+		}
+		models := rule.Models
+		if models == nil {
+			models = []string{}
+		}
+		out = append(out, RequestRewriteRule{
+			Name:       rule.Name,
+			Enabled:    rule.Enabled,
+			Platforms:  platforms,
+			Models:     models,
+			Operations: ops,
+		})
+	}
+	return out
+}
+
+// ModelAliasesFromService converts group model aliases to DTO (always a non-nil slice).
+func ModelAliasesFromService(aliases []service.ModelAlias) []ModelAlias {
+	out := make([]ModelAlias, 0, len(aliases))
+	for _, alias := range aliases {
+		targets := make([]ModelAliasTarget, 0, len(alias.Targets))
+		for _, target := range alias.Targets {
+			targets = append(targets, ModelAliasTarget{Platform: target.Platform, Model: target.Model})
+		}
+		out = append(out, ModelAlias{Name: alias.Name, Description: alias.Description, Targets: targets})
+	}
+	return out
+}
+
+// TrafficSplitFromService converts group traffic split config to DTO.
+func TrafficSplitFromService(split *service.TrafficSplit) *TrafficSplit {
+	if split == nil {
+		return nil
+	}
+	out := &TrafficSplit{Enabled: split.Enabled, Arms: make([]TrafficSplitArm, 0, len(split.Arms))}
+	for _, arm := range split.Arms {
+		models := append([]string{}, arm.Models...)
+		accountIDs := append([]int64{}, arm.AccountIDs...)
+		out.Arms = append(out.Arms, TrafficSplitArm{
+			Name:       arm.Name,
+			Weight:     arm.Weight,
+			Models:     models,
+			Model:      arm.Model,
+			AccountIDs: accountIDs,
+		})
+	}
+	return out
+}
+
+// SubscriptionQuotasFromService converts group usage quotas to DTO (always a non-nil slice).
+func SubscriptionQuotasFromService(quotas []service.SubscriptionQuota) []SubscriptionQuota {
+	out := make([]SubscriptionQuota, 0, len(quotas))
+	for _, q := range quotas {
+		out = append(out, SubscriptionQuota{Window: q.Window, Metric: q.Metric, Limit: q.Limit, Model: q.Model})
+	}
+	return out
+}
+
+// ContentPolicyFromService converts a group content policy to DTO, hiding the moderation secret.
+func ContentPolicyFromService(p *service.ContentPolicy) *ContentPolicy {
+	if p == nil {
+		return nil
+	}
+	out := &ContentPolicy{
+		Enabled:       p.Enabled,
+		Blocklist:     make([]ContentPolicyBlocklistRule, 0, len(p.Blocklist)),
+		PIIDetectors:  make([]ContentPolicyPIIDetector, 0, len(p.PIIDetectors)),
+		MaxImages:     p.MaxImages,
+		MaxImageBytes: p.MaxImageBytes,
+	}
+	for _, rule := range p.Blocklist {
+		out.Blocklist = append(out.Blocklist, ContentPolicyBlocklistRule{
+			Name:    rule.Name,
+			Pattern: rule.Pattern,
+			Regex:   rule.Regex,
+			Action:  rule.Action,
+		})
+	}
+	for _, detector := range p.PIIDetectors {
+		out.PIIDetectors = append(out.PIIDetectors, ContentPolicyPIIDetector{
+			Type:   detector.Type,
+			Action: detector.Action,
+		})
+	}
+	if p.Moderation != nil {
+		out.Moderation = &ContentPolicyModeration{
+			Enabled:          p.Moderation.Enabled,
+			URL:              p.Moderation.URL,
+			SecretConfigured: p.Moderation.Secret != "",
+			TimeoutMs:        p.Moderation.TimeoutMs,
+			FailClosed:       p.Moderation.FailClosed,
+		}
+	}
+	return out
+}
+
 // GroupFromServiceAdmin converts a service Group to DTO for admin users.
 // It includes internal fields like model_routing and account_count.
 func GroupFromServiceAdmin(g *service.Group) *AdminGroup {
@@ -114,6 +226,11 @@ func GroupFromServiceAdmin(g *service.Group) *AdminGroup {
 		Group:               groupFromServiceBase(g),
 		ModelRouting:        g.ModelRouting,
 		ModelRoutingEnabled: g.ModelRoutingEnabled,
+		SchedulingStrategy:  g.SchedulingStrategy,
+		ContentPolicy:       ContentPolicyFromService(g.ContentPolicy),
+		RewriteRules:        RequestRewriteRulesFromService(g.RewriteRules),
+		ModelAliases:        ModelAliasesFromService(g.ModelAliases),
+		TrafficSplit:        TrafficSplitFromService(g.TrafficSplit),
 		AccountCount:        g.AccountCount,
 	}
 	if len(g.AccountGroups) > 0 {
@@ -144,8 +261,17 @@ func groupFromServiceBase(g *service.Group) Group {
 		ImagePrice4K:     g.ImagePrice4K,
 		ClaudeCodeOnly:   g.ClaudeCodeOnly,
 		FallbackGroupID:  g.FallbackGroupID,
-		CreatedAt:        g.CreatedAt,
-		UpdatedAt:        g.UpdatedAt,
+
+		OveragePolicy:         g.OveragePolicy,
+		OverageRateMultiplier: g.OverageRateMultiplier,
+		OverageGroupID:        g.OverageGroupID,
+
+		SubscriptionPrice: g.SubscriptionPrice,
+
+		UsageQuotas: SubscriptionQuotasFromService(g.UsageQuotas),
+
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
 	}
 }
 
@@ -159,7 +285,7 @@ func AccountFromServiceShallow(a *service.Account) *Account {
 		Notes:                   a.Notes,
 		Platform:                a.Platform,
 		Type:                    a.Type,
-		Credentials:             a.Credentials,
+		Credentials:             service.MaskCredentialSecrets(a.Credentials),
 		Extra:                   a.Extra,
 		ProxyID:                 a.ProxyID,
 		Concurrency:             a.Concurrency,
@@ -184,6 +310,19 @@ func AccountFromServiceShallow(a *service.Account) *Account {
 		GroupIDs:                a.GroupIDs,
 	}
 
+	for _, limit := range a.ActiveModelRateLimits() {
+		out.ModelRateLimits = append(out.ModelRateLimits, ModelRateLimit{
+			Scope:   limit.Scope,
+			Source:  limit.Source,
+			Models:  limit.Models,
+			ResetAt: limit.ResetAt,
+		})
+	}
+
+	if a.GetAvailabilitySchedule() != nil {
+		out.ScheduleState = a.ScheduleStateAt(time.Now()).String()
+	}
+
 	// 提取 5h 窗口费用控制和会话数量控制配置（仅 Anthropic OAuth/SetupToken 账号有效）
 	if a.IsAnthropicOAuthOrSetupToken() {
 		if limit := a.GetWindowCostLimit(); limit > 0 {
@@ -498,6 +637,9 @@ func userSubscriptionFromServiceBase(sub *service.UserSubscription) UserSubscrip
 		DailyUsageUSD:      sub.DailyUsageUSD,
 		WeeklyUsageUSD:     sub.WeeklyUsageUSD,
 		MonthlyUsageUSD:    sub.MonthlyUsageUSD,
+		AutoRenew:          sub.AutoRenew,
+		PausedAt:           sub.PausedAt,
+		RenewalFailedAt:    sub.RenewalFailedAt,
 		CreatedAt:          sub.CreatedAt,
 		UpdatedAt:          sub.UpdatedAt,
 		User:               UserFromServiceShallow(sub.User),
@@ -0,0 +1,154 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/usagestats"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type Organization struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	OwnerUserID int64     `json:"owner_user_id"`
+	Balance     float64   `json:"balance"`
+	Status      string    `json:"status"`
+	MemberCount int       `json:"member_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Role 当前用户在组织中的角色（仅用户侧接口返回）
+	Role string `json:"role,omitempty"`
+}
+
+type AdminOrganization struct {
+	Organization
+
+	Notes string `json:"notes"`
+}
+
+type OrganizationMember struct {
+	ID                 int64      `json:"id"`
+	OrganizationID     int64      `json:"organization_id"`
+	UserID             int64      `json:"user_id"`
+	Email              string     `json:"email,omitempty"`
+	Username           string     `json:"username,omitempty"`
+	Role               string     `json:"role"`
+	MonthlyLimitUSD    *float64   `json:"monthly_limit_usd"`
+	MonthlyUsageUSD    float64    `json:"monthly_usage_usd"`
+	MonthlyWindowStart *time.Time `json:"monthly_window_start"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+type OrganizationInvitation struct {
+	ID               int64     `json:"id"`
+	OrganizationID   int64     `json:"organization_id"`
+	OrganizationName string    `json:"organization_name,omitempty"`
+	Email            string    `json:"email"`
+	Role             string    `json:"role"`
+	Status           string    `json:"status"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type OrganizationMemberUsage struct {
+	Member        OrganizationMember `json:"member"`
+	TotalRequests int64              `json:"total_requests"`
+	TotalTokens   int64              `json:"total_tokens"`
+	TotalCost     float64            `json:"total_cost"`
+	ActualCost    float64            `json:"actual_cost"`
+}
+
+type OrganizationDashboard struct {
+	Organization *Organization             `json:"organization"`
+	Stats        *usagestats.UsageStats    `json:"stats"`
+	Members      []OrganizationMemberUsage `json:"members"`
+}
+
+func OrganizationFromService(o *service.Organization) *Organization {
+	if o == nil {
+		return nil
+	}
+	return &Organization{
+		ID:          o.ID,
+		Name:        o.Name,
+		OwnerUserID: o.OwnerUserID,
+		Balance:     o.Balance,
+		Status:      o.Status,
+		MemberCount: o.MemberCount,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
+	}
+}
+
+func AdminOrganizationFromService(o *service.Organization) *AdminOrganization {
+	if o == nil {
+		return nil
+	}
+	return &AdminOrganization{
+		Organization: *OrganizationFromService(o),
+		Notes:        o.Notes,
+	}
+}
+
+func OrganizationMemberFromService(m *service.OrganizationMember) *OrganizationMember {
+	if m == nil {
+		return nil
+	}
+	out := &OrganizationMember{
+		ID:                 m.ID,
+		OrganizationID:     m.OrganizationID,
+		UserID:             m.UserID,
+		Role:               m.Role,
+		MonthlyLimitUSD:    m.MonthlyLimitUSD,
+		MonthlyUsageUSD:    m.CurrentMonthlyUsage(time.Now()),
+		MonthlyWindowStart: m.MonthlyWindowStart,
+		CreatedAt:          m.CreatedAt,
+	}
+	if m.User != nil {
+		out.Email = m.User.Email
+		out.Username = m.User.Username
+	}
+	return out
+}
+
+func OrganizationInvitationFromService(i *service.OrganizationInvitation) *OrganizationInvitation {
+	if i == nil {
+		return nil
+	}
+	out := &OrganizationInvitation{
+		ID:             i.ID,
+		OrganizationID: i.OrganizationID,
+		Email:          i.Email,
+		Role:           i.Role,
+		Status:         i.Status,
+		ExpiresAt:      i.ExpiresAt,
+		CreatedAt:      i.CreatedAt,
+	}
+	if i.Organization != nil {
+		out.OrganizationName = i.Organization.Name
+	}
+	return out
+}
+
+func OrganizationDashboardFromService(d *service.OrganizationDashboard) *OrganizationDashboard {
+	if d == nil {
+		return nil
+	}
+	members := make([]OrganizationMemberUsage, 0, len(d.Members))
+	for i := range d.Members {
+		item := &d.Members[i]
+		members = append(members, OrganizationMemberUsage{
+			Member:        *OrganizationMemberFromService(&item.Member),
+			TotalRequests: item.Usage.TotalRequests,
+			TotalTokens:   item.Usage.TotalTokens,
+			TotalCost:     item.Usage.TotalCost,
+			ActualCost:    item.Usage.ActualCost,
+		})
+	}
+	return &OrganizationDashboard{
+		Organization: OrganizationFromService(d.Organization),
+		Stats:        d.Stats,
+		Members:      members,
+	}
+}
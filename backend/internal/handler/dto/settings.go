@@ -8,6 +8,8 @@ type SystemSettings struct {
 	PasswordResetEnabled        bool `json:"password_reset_enabled"`
 	TotpEnabled                 bool `json:"totp_enabled"`                   // TOTP 双因素认证
 	TotpEncryptionKeyConfigured bool `json:"totp_encryption_key_configured"` // TOTP 加密密钥是否已配置
+	WebAuthnEnabled             bool `json:"webauthn_enabled"`               // Passkey 两步验证与免密码登录
+	RequireAdmin2FA             bool `json:"require_admin_2fa"`              // 管理员必须启用两步验证
 
 	SMTPHost               string `json:"smtp_host"`
 	SMTPPort               int    `json:"smtp_port"`
@@ -67,6 +69,7 @@ type PublicSettings struct {
 	PromoCodeEnabled            bool   `json:"promo_code_enabled"`
 	PasswordResetEnabled        bool   `json:"password_reset_enabled"`
 	TotpEnabled                 bool   `json:"totp_enabled"` // TOTP 双因素认证
+	WebAuthnEnabled             bool   `json:"webauthn_enabled"`
 	TurnstileEnabled            bool   `json:"turnstile_enabled"`
 	TurnstileSiteKey            string `json:"turnstile_site_key"`
 	SiteName                    string `json:"site_name"`
@@ -94,3 +97,15 @@ type StreamTimeoutSettings struct {
 	ThresholdCount         int    `json:"threshold_count"`
 	ThresholdWindowMinutes int    `json:"threshold_window_minutes"`
 }
+
+// ModelRateLimitScopeRule 模型限流域规则 DTO
+type ModelRateLimitScopeRule struct {
+	Platform string `json:"platform"`
+	Pattern  string `json:"pattern"`
+	Scope    string `json:"scope"`
+}
+
+// ModelRateLimitScopeSettings 模型限流域配置 DTO
+type ModelRateLimitScopeSettings struct {
+	Rules []ModelRateLimitScopeRule `json:"rules"`
+}
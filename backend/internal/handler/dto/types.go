@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type User struct {
 	ID            int64     `json:"id"`
@@ -31,12 +34,17 @@ type AdminUser struct {
 	User
 
 	Notes string `json:"notes"`
+
+	// 专属账号（仅管理员可见）
+	DedicatedAccountIDs   []int64 `json:"dedicated_account_ids"`
+	DedicatedAccountsOnly bool    `json:"dedicated_accounts_only"`
 }
 
 type APIKey struct {
 	ID          int64     `json:"id"`
 	UserID      int64     `json:"user_id"`
-	Key         string    `json:"key"`
+	Key         string    `json:"key,omitempty"` // 明文 Key，仅在创建响应中返回一次
+	KeyPrefix   string    `json:"key_prefix"`
 	Name        string    `json:"name"`
 	GroupID     *int64    `json:"group_id"`
 	Status      string    `json:"status"`
@@ -45,6 +53,9 @@ type APIKey struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
+	// OrganizationID 组织 Key 所属组织（使用组织共享余额与订阅）
+	OrganizationID *int64 `json:"organization_id,omitempty"`
+
 	User  *User  `json:"user,omitempty"`
 	Group *Group `json:"group,omitempty"`
 }
@@ -72,6 +83,17 @@ type Group struct {
 	ClaudeCodeOnly  bool   `json:"claude_code_only"`
 	FallbackGroupID *int64 `json:"fallback_group_id"`
 
+	// 订阅超额策略：额度用尽后拒绝、按超额倍率扣余额或降级到按量计费分组
+	OveragePolicy         string  `json:"overage_policy"`
+	OverageRateMultiplier float64 `json:"overage_rate_multiplier"`
+	OverageGroupID        *int64  `json:"overage_group_id"`
+
+	// 订阅每周期余额价格，为空表示不支持自助续费/切换
+	SubscriptionPrice *float64 `json:"subscription_price"`
+
+	// 订阅 token / 请求次数额度（与 USD 限额同时生效）
+	UsageQuotas []SubscriptionQuota `json:"usage_quotas"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -85,10 +107,117 @@ type AdminGroup struct {
 	ModelRouting        map[string][]int64 `json:"model_routing"`
 	ModelRoutingEnabled bool               `json:"model_routing_enabled"`
 
+	// 账号调度策略（空字符串为默认策略）
+	SchedulingStrategy string `json:"scheduling_strategy"`
+
+	// 内容策略（nil 表示未配置）
+	ContentPolicy *ContentPolicy `json:"content_policy"`
+
+	// 请求改写规则（按顺序执行）
+	RewriteRules []RequestRewriteRule `json:"rewrite_rules"`
+
+	// 模型别名 / 虚拟模型
+	ModelAliases []ModelAlias `json:"model_aliases"`
+
+	// 灰度 / A-B 分流（nil 表示未配置）
+	TrafficSplit *TrafficSplit `json:"traffic_split"`
+
 	AccountGroups []AccountGroup `json:"account_groups,omitempty"`
 	AccountCount  int64          `json:"account_count,omitempty"`
 }
 
+// RequestRewriteRule 分组请求改写规则
+type RequestRewriteRule struct {
+	Name       string             `json:"name"`
+	Enabled    bool               `json:"enabled"`
+	Platforms  []string           `json:"platforms"`
+	Models     []string           `json:"models"`
+	Operations []RequestRewriteOp `json:"operations"`
+}
+
+// RequestRewriteOp 请求改写操作
+type RequestRewriteOp struct {
+	Type   string          `json:"type"`
+	Path   string          `json:"path,omitempty"`
+	Header string          `json:"header,omitempty"`
+	Value  json.RawMessage `json:"value,omitempty"`
+}
+
+// TrafficSplit 分组灰度 / A-B 分流配置
+type TrafficSplit struct {
+	Enabled bool              `json:"enabled"`
+	Arms    []TrafficSplitArm `json:"arms"`
+}
+
+// TrafficSplitArm 分流实验组
+type TrafficSplitArm struct {
+	Name       string   `json:"name"`
+	Weight     float64  `json:"weight"`
+	Models     []string `json:"models"`
+	Model      string   `json:"model"`
+	AccountIDs []int64  `json:"account_ids"`
+}
+
+// ModelAlias 分组虚拟模型
+type ModelAlias struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Targets     []ModelAliasTarget `json:"targets"`
+}
+
+// ModelAliasTarget 虚拟模型目标
+type ModelAliasTarget struct {
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+}
+
+// SubscriptionQuota 订阅窗口内的 token / 请求次数额度
+type SubscriptionQuota struct {
+	Window string `json:"window"`
+	Metric string `json:"metric"`
+	Limit  int64  `json:"limit"`
+	Model  string `json:"model,omitempty"`
+}
+
+// ContentPolicy 分组内容策略
+type ContentPolicy struct {
+	Enabled       bool                         `json:"enabled"`
+	Blocklist     []ContentPolicyBlocklistRule `json:"blocklist"`
+	PIIDetectors  []ContentPolicyPIIDetector   `json:"pii_detectors"`
+	MaxImages     int                          `json:"max_images"`
+	MaxImageBytes int64                        `json:"max_image_bytes"`
+	Moderation    *ContentPolicyModeration     `json:"moderation"`
+}
+
+type ContentPolicyBlocklistRule struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+	Regex   bool   `json:"regex"`
+	Action  string `json:"action"`
+}
+
+type ContentPolicyPIIDetector struct {
+	Type   string `json:"type"`
+	Action string `json:"action"`
+}
+
+// ContentPolicyModeration 外部审核 Webhook 配置（密钥不回显）
+type ContentPolicyModeration struct {
+	Enabled          bool   `json:"enabled"`
+	URL              string `json:"url"`
+	SecretConfigured bool   `json:"secret_configured"`
+	TimeoutMs        int    `json:"timeout_ms"`
+	FailClosed       bool   `json:"fail_closed"`
+}
+
+// ModelRateLimit 账号某个模型限流域的限流状态
+type ModelRateLimit struct {
+	Scope   string    `json:"scope"`
+	Source  string    `json:"source"`
+	Models  []string  `json:"models,omitempty"`
+	ResetAt time.Time `json:"reset_at"`
+}
+
 type Account struct {
 	ID                 int64          `json:"id"`
 	Name               string         `json:"name"`
@@ -122,6 +251,14 @@ type Account struct {
 	SessionWindowEnd    *time.Time `json:"session_window_end"`
 	SessionWindowStatus string     `json:"session_window_status"`
 
+	// 按模型限流域的限流状态（仅包含未过期的记录）
+	// 从 extra 字段提取，方便前端显示
+	ModelRateLimits []ModelRateLimit `json:"model_rate_limits,omitempty"`
+
+	// 可用时间窗口（extra.availability_schedule）的当前状态：open / draining / closed
+	// 未配置时为空
+	ScheduleState string `json:"schedule_state,omitempty"`
+
 	// 5h窗口费用控制（仅 Anthropic OAuth/SetupToken 账号有效）
 	// 从 extra 字段提取，方便前端显示和编辑
 	WindowCostLimit         *float64 `json:"window_cost_limit,omitempty"`
@@ -338,6 +475,10 @@ type UserSubscription struct {
 	WeeklyUsageUSD  float64 `json:"weekly_usage_usd"`
 	MonthlyUsageUSD float64 `json:"monthly_usage_usd"`
 
+	AutoRenew       bool       `json:"auto_renew"`
+	PausedAt        *time.Time `json:"paused_at"`
+	RenewalFailedAt *time.Time `json:"renewal_failed_at"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type WebAuthnCredential struct {
+	ID         int64    `json:"id"`
+	Name       string   `json:"name"`
+	AAGUID     string   `json:"aaguid,omitempty"`
+	Transports []string `json:"transports"`
+	// Passwordless 是否可用于免密码登录（注册时完成了用户验证）
+	Passwordless bool       `json:"passwordless"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func WebAuthnCredentialFromService(c *service.WebAuthnCredential) WebAuthnCredential {
+	transports := c.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+	return WebAuthnCredential{
+		ID:           c.ID,
+		Name:         c.Name,
+		AAGUID:       c.AAGUID,
+		Transports:   transports,
+		Passwordless: c.UserVerified,
+		LastUsedAt:   c.LastUsedAt,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+func WebAuthnCredentialsFromService(creds []service.WebAuthnCredential) []WebAuthnCredential {
+	out := make([]WebAuthnCredential, 0, len(creds))
+	for i := range creds {
+		out = append(out, WebAuthnCredentialFromService(&creds[i]))
+	}
+	return out
+}
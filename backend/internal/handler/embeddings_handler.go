@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Embeddings handles OpenAI compatible embeddings endpoint
+// POST /v1/embeddings
+// 目前由 Gemini 分组的账号（API Key / AI Studio OAuth）提供，请求转换为 batchEmbedContents
+func (h *GatewayHandler) Embeddings(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.embeddingsError(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		h.embeddingsError(c, http.StatusInternalServerError, "api_error", "User context not found")
+		return
+	}
+
+	if apiKey.Group == nil || apiKey.Group.Platform != service.PlatformGemini {
+		h.embeddingsError(c, http.StatusBadRequest, "invalid_request_error", "Embeddings are only available for gemini groups")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.embeddingsError(c, http.StatusRequestEntityTooLarge, "invalid_request_error", buildBodyTooLargeMessage(maxErr.Limit))
+			return
+		}
+		h.embeddingsError(c, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return
+	}
+	if len(body) == 0 {
+		h.embeddingsError(c, http.StatusBadRequest, "invalid_request_error", "Request body is empty")
+		return
+	}
+
+	setOpsRequestContext(c, "", false, body)
+
+	req, err := service.ParseOpenAIEmbeddingsRequest(body)
+	if err != nil {
+		h.embeddingsError(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	setOpsRequestContext(c, req.Model, false, body)
+
+	// 分组内容策略检查
+	if policyResult := applyContentPolicy(c, h.contentPolicyService, apiKey, req.Model, body); policyResult != nil {
+		if policyResult.Blocked {
+			h.embeddingsError(c, http.StatusBadRequest, "invalid_request_error", policyResult.BlockReason())
+			return
+		}
+		if policyResult.Redacted {
+			body = policyResult.Body
+			if req, err = service.ParseOpenAIEmbeddingsRequest(body); err != nil {
+				h.embeddingsError(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+				return
+			}
+			setOpsRequestContext(c, req.Model, false, body)
+		}
+	}
+
+	subscription, _ := middleware2.GetSubscriptionFromContext(c)
+	streamStarted := false
+
+	// 0. wait 队列检查
+	maxWait := service.CalculateMaxWait(subject.Concurrency)
+	canWait, err := h.concurrencyHelper.IncrementWaitCount(c.Request.Context(), subject.UserID, maxWait)
+	waitCounted := false
+	if err != nil {
+		log.Printf("Increment wait count failed: %v", err)
+	} else if !canWait {
+		h.embeddingsError(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later")
+		return
+	}
+	if err == nil && canWait {
+		waitCounted = true
+	}
+	defer func() {
+		if waitCounted {
+			h.concurrencyHelper.DecrementWaitCount(c.Request.Context(), subject.UserID)
+		}
+	}()
+
+	// 1. 用户并发槽位
+	userReleaseFunc, err := h.concurrencyHelper.AcquireUserSlotWithWait(c, subject.UserID, subject.Concurrency, false, &streamStarted)
+	if err != nil {
+		log.Printf("User concurrency acquire failed: %v", err)
+		h.embeddingsError(c, http.StatusTooManyRequests, "rate_limit_error", "Concurrency limit exceeded for user, please retry later")
+		return
+	}
+	if waitCounted {
+		h.concurrencyHelper.DecrementWaitCount(c.Request.Context(), subject.UserID)
+		waitCounted = false
+	}
+	userReleaseFunc = wrapReleaseOnDone(c.Request.Context(), userReleaseFunc)
+	if userReleaseFunc != nil {
+		defer userReleaseFunc()
+	}
+
+	// 2. 余额/订阅校验
+	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription, req.Model); err != nil {
+		status, code, message := billingErrorDetails(err)
+		h.embeddingsError(c, status, code, message)
+		return
+	}
+
+	maxAccountSwitches := h.maxAccountSwitchesGemini
+	switchCount := 0
+	failedAccountIDs := make(map[int64]struct{})
+	lastFailoverStatus := 0
+	skippedUnsupported := 0
+
+	for {
+		// embedding 无会话上下文，不使用粘性会话
+		selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, "", req.Model, failedAccountIDs, "")
+		if err != nil {
+			if len(failedAccountIDs) == skippedUnsupported && skippedUnsupported > 0 {
+				h.embeddingsError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+service.GeminiEmbeddingsUnsupportedMessage)
+				return
+			}
+			if len(failedAccountIDs) == 0 {
+				h.embeddingsError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error())
+				return
+			}
+			status, errType, message := h.mapUpstreamError(lastFailoverStatus)
+			h.embeddingsError(c, status, errType, message)
+			return
+		}
+		account := selection.Account
+		// 仅 AI Studio 账号支持 embedding（Antigravity、Code Assist 等账号跳过，不计入切换次数）
+		if !account.SupportsGeminiEmbeddings() {
+			if selection.Acquired && selection.ReleaseFunc != nil {
+				selection.ReleaseFunc()
+			}
+			failedAccountIDs[account.ID] = struct{}{}
+			skippedUnsupported++
+			continue
+		}
+		setOpsSelectedAccount(c, account.ID)
+
+		// 3. 账号并发槽位
+		accountReleaseFunc := selection.ReleaseFunc
+		if !selection.Acquired {
+			if selection.WaitPlan == nil {
+				h.embeddingsError(c, http.StatusServiceUnavailable, "api_error", "No available accounts")
+				return
+			}
+			accountWaitCounted := false
+			canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+			if err != nil {
+				log.Printf("Increment account wait count failed: %v", err)
+			} else if !canWait {
+				log.Printf("Account wait queue full: account=%d", account.ID)
+				h.embeddingsError(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later")
+				return
+			}
+			if err == nil && canWait {
+				accountWaitCounted = true
+			}
+			defer func() {
+				if accountWaitCounted {
+					h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+				}
+			}()
+
+			accountReleaseFunc, err = h.concurrencyHelper.AcquireAccountSlotWithWaitTimeout(
+				c,
+				account.ID,
+				selection.WaitPlan.MaxConcurrency,
+				selection.WaitPlan.Timeout,
+				false,
+				&streamStarted,
+			)
+			if err != nil {
+				log.Printf("Account concurrency acquire failed: %v", err)
+				h.embeddingsError(c, http.StatusTooManyRequests, "rate_limit_error", "Concurrency limit exceeded for account, please retry later")
+				return
+			}
+			if accountWaitCounted {
+				h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+				accountWaitCounted = false
+			}
+		}
+		accountReleaseFunc = wrapReleaseOnDone(c.Request.Context(), accountReleaseFunc)
+
+		// 4. 转发
+		result, err := h.geminiCompatService.ForwardOpenAIEmbeddings(c.Request.Context(), c, account, req)
+		if accountReleaseFunc != nil {
+			accountReleaseFunc()
+		}
+		if err != nil {
+			var failoverErr *service.UpstreamFailoverError
+			if errors.As(err, &failoverErr) {
+				failedAccountIDs[account.ID] = struct{}{}
+				lastFailoverStatus = failoverErr.StatusCode
+				if switchCount >= maxAccountSwitches {
+					status, errType, message := h.mapUpstreamError(lastFailoverStatus)
+					h.embeddingsError(c, status, errType, message)
+					return
+				}
+				switchCount++
+				log.Printf("Account %d: embeddings upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, maxAccountSwitches)
+				continue
+			}
+			// 错误响应已在 ForwardOpenAIEmbeddings 中处理
+			log.Printf("Account %d: embeddings forward failed: %v", account.ID, err)
+			return
+		}
+
+		userAgent := c.GetHeader("User-Agent")
+		clientIP := ip.GetClientIP(c)
+
+		// 5. 异步记录使用量
+		go func(result *service.ForwardResult, usedAccount *service.Account, ua, clientIP string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
+				Result:       result,
+				APIKey:       apiKey,
+				User:         apiKey.User,
+				Account:      usedAccount,
+				Subscription: subscription,
+				UserAgent:    ua,
+				IPAddress:    clientIP,
+			}); err != nil {
+				log.Printf("Record usage failed: %v", err)
+			}
+		}(result, account, userAgent, clientIP)
+		return
+	}
+}
+
+// embeddingsError 返回 OpenAI 格式的错误响应
+func (h *GatewayHandler) embeddingsError(c *gin.Context, status int, errType, message string) {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
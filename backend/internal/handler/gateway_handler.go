@@ -30,6 +30,7 @@ type GatewayHandler struct {
 	antigravityGatewayService *service.AntigravityGatewayService
 	userService               *service.UserService
 	billingCacheService       *service.BillingCacheService
+	contentPolicyService      *service.ContentPolicyService
 	concurrencyHelper         *ConcurrencyHelper
 	maxAccountSwitches        int
 	maxAccountSwitchesGemini  int
@@ -43,6 +44,7 @@ func NewGatewayHandler(
 	userService *service.UserService,
 	concurrencyService *service.ConcurrencyService,
 	billingCacheService *service.BillingCacheService,
+	contentPolicyService *service.ContentPolicyService,
 	cfg *config.Config,
 ) *GatewayHandler {
 	pingInterval := time.Duration(0)
@@ -63,6 +65,7 @@ func NewGatewayHandler(
 		antigravityGatewayService: antigravityGatewayService,
 		userService:               userService,
 		billingCacheService:       billingCacheService,
+		contentPolicyService:      contentPolicyService,
 		concurrencyHelper:         NewConcurrencyHelper(concurrencyService, SSEPingFormatClaude, pingInterval),
 		maxAccountSwitches:        maxAccountSwitches,
 		maxAccountSwitchesGemini:  maxAccountSwitchesGemini,
@@ -122,6 +125,34 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		return
 	}
 
+	// 分组内容策略检查（黑名单/PII/图片限制/外部审核）
+	if policyResult := applyContentPolicy(c, h.contentPolicyService, apiKey, reqModel, body); policyResult != nil {
+		if policyResult.Blocked {
+			h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", policyResult.BlockReason())
+			return
+		}
+		if policyResult.Redacted {
+			body = policyResult.Body
+			parsedReq, err = service.ParseGatewayRequest(body)
+			if err != nil {
+				h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+				return
+			}
+			setOpsRequestContext(c, reqModel, reqStream, body)
+		}
+	}
+
+	// 分组请求改写规则（字段/系统提示词/请求头）
+	if rewritten, changed := applyRequestRewrite(c, apiKey, service.RequestFormatAnthropic, reqModel, body); changed {
+		body = rewritten
+		parsedReq, err = service.ParseGatewayRequest(body)
+		if err != nil {
+			h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+			return
+		}
+		setOpsRequestContext(c, reqModel, reqStream, body)
+	}
+
 	// Track if we've started streaming (for error handling)
 	streamStarted := false
 
@@ -168,7 +199,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 	}
 
 	// 2. 【新增】Wait后二次检查余额/订阅
-	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
+	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription, reqModel); err != nil {
 		log.Printf("Billing eligibility check failed after wait: %v", err)
 		status, code, message := billingErrorDetails(err)
 		h.handleStreamingAwareError(c, status, code, message, streamStarted)
@@ -190,6 +221,47 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		sessionKey = "gemini:" + sessionHash
 	}
 
+	// 分组灰度 / A-B 分流：按会话稳定分配实验组，限定账号池并按需改写目标模型（先于虚拟模型解析）
+	if rewritten, armModel, err := bindTrafficRoute(c, apiKey, sessionHash, reqModel, body, "model"); err != nil {
+		log.Printf("Bind traffic route failed: %v", err)
+	} else if armModel != reqModel {
+		if req, err := service.ParseGatewayRequest(rewritten); err == nil {
+			body, parsedReq, reqModel = rewritten, req, req.Model
+			setOpsRequestContext(c, reqModel, reqStream, body)
+		} else {
+			log.Printf("Parse traffic arm request failed: %v", err)
+		}
+	}
+
+	// 分组虚拟模型：按配置顺序尝试目标，当前目标无可用账号或故障转移耗尽时切换到下一个
+	aliasRoute, aliasOK := resolveModelAliasRoute(c, apiKey, reqModel)
+	if !aliasOK {
+		h.handleStreamingAwareError(c, http.StatusBadRequest, "invalid_request_error", "Model "+reqModel+" is not available on this endpoint", streamStarted)
+		return
+	}
+	bindAliasTarget := func() bool {
+		rewritten, err := aliasRoute.bind(c, body, "model")
+		if err != nil {
+			log.Printf("Bind model alias target failed: %v", err)
+			return false
+		}
+		req, err := service.ParseGatewayRequest(rewritten)
+		if err != nil {
+			log.Printf("Parse model alias request failed: %v", err)
+			return false
+		}
+		body, parsedReq, reqModel = rewritten, req, req.Model
+		setOpsRequestContext(c, reqModel, reqStream, body)
+		return true
+	}
+	if aliasRoute != nil && !bindAliasTarget() {
+		h.handleStreamingAwareError(c, http.StatusInternalServerError, "api_error", "Failed to resolve model alias", streamStarted)
+		return
+	}
+	nextAliasTarget := func() bool {
+		return aliasRoute.next() && bindAliasTarget()
+	}
+
 	if platform == service.PlatformGemini {
 		maxAccountSwitches := h.maxAccountSwitchesGemini
 		switchCount := 0
@@ -199,6 +271,11 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		for {
 			selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionKey, reqModel, failedAccountIDs, "") // Gemini 不使用会话限制
 			if err != nil {
+				if nextAliasTarget() {
+					failedAccountIDs = make(map[int64]struct{})
+					switchCount = 0
+					continue
+				}
 				if len(failedAccountIDs) == 0 {
 					h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
 					return
@@ -292,6 +369,11 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 					failedAccountIDs[account.ID] = struct{}{}
 					lastFailoverStatus = failoverErr.StatusCode
 					if switchCount >= maxAccountSwitches {
+						if nextAliasTarget() {
+							failedAccountIDs = make(map[int64]struct{})
+							switchCount = 0
+							continue
+						}
 						h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
 						return
 					}
@@ -307,6 +389,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 			// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
 			userAgent := c.GetHeader("User-Agent")
 			clientIP := ip.GetClientIP(c)
+			trafficArm := trafficArmName(c)
 
 			// 异步记录使用量（subscription已在函数开头获取）
 			go func(result *service.ForwardResult, usedAccount *service.Account, ua, clientIP string) {
@@ -320,6 +403,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 					Subscription: subscription,
 					UserAgent:    ua,
 					IPAddress:    clientIP,
+					TrafficArm:   trafficArm,
 				}); err != nil {
 					log.Printf("Record usage failed: %v", err)
 				}
@@ -337,6 +421,11 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		// 选择支持该模型的账号
 		selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionKey, reqModel, failedAccountIDs, parsedReq.MetadataUserID)
 		if err != nil {
+			if nextAliasTarget() {
+				failedAccountIDs = make(map[int64]struct{})
+				switchCount = 0
+				continue
+			}
 			if len(failedAccountIDs) == 0 {
 				h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
 				return
@@ -428,6 +517,11 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 				failedAccountIDs[account.ID] = struct{}{}
 				lastFailoverStatus = failoverErr.StatusCode
 				if switchCount >= maxAccountSwitches {
+					if nextAliasTarget() {
+						failedAccountIDs = make(map[int64]struct{})
+						switchCount = 0
+						continue
+					}
 					h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
 					return
 				}
@@ -443,6 +537,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
 		userAgent := c.GetHeader("User-Agent")
 		clientIP := ip.GetClientIP(c)
+		trafficArm := trafficArmName(c)
 
 		// 异步记录使用量（subscription已在函数开头获取）
 		go func(result *service.ForwardResult, usedAccount *service.Account, ua, clientIP string) {
@@ -456,6 +551,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 				Subscription: subscription,
 				UserAgent:    ua,
 				IPAddress:    clientIP,
+				TrafficArm:   trafficArm,
 			}); err != nil {
 				log.Printf("Record usage failed: %v", err)
 			}
@@ -479,12 +575,15 @@ func (h *GatewayHandler) Models(c *gin.Context) {
 		platform = apiKey.Group.Platform
 	}
 
+	// 分组虚拟模型排在真实模型之前
+	aliases := listModelAliases(c, apiKey)
+
 	// Get available models from account configurations (without platform filter)
 	availableModels := h.gatewayService.GetAvailableModels(c.Request.Context(), groupID, "")
 
 	if len(availableModels) > 0 {
 		// Build model list from whitelist
-		models := make([]claude.Model, 0, len(availableModels))
+		models := claudeAliasModels(aliases)
 		for _, modelID := range availableModels {
 			models = append(models, claude.Model{
 				ID:          modelID,
@@ -504,14 +603,14 @@ func (h *GatewayHandler) Models(c *gin.Context) {
 	if platform == "openai" {
 		c.JSON(http.StatusOK, gin.H{
 			"object": "list",
-			"data":   openai.DefaultModels,
+			"data":   append(openAIAliasModels(aliases), openai.DefaultModels...),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"object": "list",
-		"data":   claude.DefaultModels,
+		"data":   append(claudeAliasModels(aliases), claude.DefaultModels...),
 	})
 }
 
@@ -539,8 +638,8 @@ func (h *GatewayHandler) Usage(c *gin.Context) {
 		return
 	}
 
-	// 订阅模式：返回订阅限额信息
-	if apiKey.Group != nil && apiKey.Group.IsSubscriptionType() {
+	// 订阅模式：返回订阅限额信息（订阅额度用尽转为超额计费时返回钱包余额）
+	if apiKey.Group != nil && apiKey.Group.IsSubscriptionType() && apiKey.SubscriptionOverage == nil {
 		subscription, ok := middleware2.GetSubscriptionFromContext(c)
 		if !ok {
 			h.errorResponse(c, http.StatusForbidden, "subscription_error", "No active subscription")
@@ -746,7 +845,7 @@ func (h *GatewayHandler) CountTokens(c *gin.Context) {
 
 	// 校验 billing eligibility（订阅/余额）
 	// 【注意】不计算并发，但需要校验订阅/余额
-	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
+	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription, parsedReq.Model); err != nil {
 		status, code, message := billingErrorDetails(err)
 		h.errorResponse(c, status, code, message)
 		return
@@ -755,8 +854,34 @@ func (h *GatewayHandler) CountTokens(c *gin.Context) {
 	// 计算粘性会话 hash
 	sessionHash := h.gatewayService.GenerateSessionHash(parsedReq)
 
+	// 分组虚拟模型：使用第一个有可用账号的目标
+	aliasRoute, aliasOK := resolveModelAliasRoute(c, apiKey, parsedReq.Model)
+	if !aliasOK {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Model "+parsedReq.Model+" is not available on this endpoint")
+		return
+	}
+	bindAliasTarget := func() bool {
+		rewritten, err := aliasRoute.bind(c, body, "model")
+		if err != nil {
+			return false
+		}
+		req, err := service.ParseGatewayRequest(rewritten)
+		if err != nil {
+			return false
+		}
+		body, parsedReq = rewritten, req
+		return true
+	}
+	if aliasRoute != nil && !bindAliasTarget() {
+		h.errorResponse(c, http.StatusInternalServerError, "api_error", "Failed to resolve model alias")
+		return
+	}
+
 	// 选择支持该模型的账号
 	account, err := h.gatewayService.SelectAccountForModel(c.Request.Context(), apiKey.GroupID, sessionHash, parsedReq.Model)
+	for err != nil && aliasRoute.next() && bindAliasTarget() {
+		account, err = h.gatewayService.SelectAccountForModel(c.Request.Context(), apiKey.GroupID, sessionHash, parsedReq.Model)
+	}
 	if err != nil {
 		h.errorResponse(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error())
 		return
@@ -962,6 +1087,9 @@ func sendMockInterceptResponse(c *gin.Context, model string, interceptType Inter
 }
 
 func billingErrorDetails(err error) (status int, code, message string) {
+	if errors.Is(err, service.ErrSubscriptionQuotaExceeded) {
+		return http.StatusTooManyRequests, "rate_limit_error", pkgerrors.Message(err)
+	}
 	if errors.Is(err, service.ErrBillingServiceUnavailable) {
 		msg := pkgerrors.Message(err)
 		if msg == "" {
@@ -49,9 +49,12 @@ func (h *GatewayHandler) GeminiV1BetaListModels(c *gin.Context) {
 		return
 	}
 
+	// 分组虚拟模型追加到模型列表末尾
+	aliases := listModelAliases(c, apiKey)
+
 	// 强制 antigravity 模式：返回 antigravity 支持的模型列表
 	if forcePlatform == service.PlatformAntigravity {
-		c.JSON(http.StatusOK, antigravity.FallbackGeminiModelsList())
+		writeGeminiModelsList(c, antigravity.FallbackGeminiModelsList(), aliases)
 		return
 	}
 
@@ -61,7 +64,7 @@ func (h *GatewayHandler) GeminiV1BetaListModels(c *gin.Context) {
 		hasAntigravity, _ := h.geminiCompatService.HasAntigravityAccounts(c.Request.Context(), apiKey.GroupID)
 		if hasAntigravity {
 			// antigravity 账户使用静态模型列表
-			c.JSON(http.StatusOK, gemini.FallbackModelsList())
+			writeGeminiModelsList(c, gemini.FallbackModelsList(), aliases)
 			return
 		}
 		googleError(c, http.StatusServiceUnavailable, "No available Gemini accounts: "+err.Error())
@@ -74,9 +77,12 @@ func (h *GatewayHandler) GeminiV1BetaListModels(c *gin.Context) {
 		return
 	}
 	if shouldFallbackGeminiModels(res) {
-		c.JSON(http.StatusOK, gemini.FallbackModelsList())
+		writeGeminiModelsList(c, gemini.FallbackModelsList(), aliases)
 		return
 	}
+	if res.StatusCode == http.StatusOK && len(aliases) > 0 {
+		res.Body = appendGeminiAliasModels(res.Body, aliases)
+	}
 	writeUpstreamResponse(c, res)
 }
 
@@ -101,6 +107,12 @@ func (h *GatewayHandler) GeminiV1BetaGetModel(c *gin.Context) {
 		return
 	}
 
+	// 分组虚拟模型直接返回别名信息，不请求上游
+	if aliasModel, ok := findGeminiAliasModel(listModelAliases(c, apiKey), modelName); ok {
+		c.JSON(http.StatusOK, aliasModel)
+		return
+	}
+
 	// 强制 antigravity 模式：返回 antigravity 模型信息
 	if forcePlatform == service.PlatformAntigravity {
 		c.JSON(http.StatusOK, antigravity.FallbackGeminiModel(modelName))
@@ -135,6 +147,9 @@ func (h *GatewayHandler) GeminiV1BetaGetModel(c *gin.Context) {
 // GeminiV1BetaModels proxies Gemini native REST endpoints like:
 // POST /v1beta/models/{model}:generateContent
 // POST /v1beta/models/{model}:streamGenerateContent?alt=sse
+// POST /v1beta/models/{model}:countTokens
+// POST /v1beta/models/{model}:embedContent
+// POST /v1beta/models/{model}:batchEmbedContents
 func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 	apiKey, ok := middleware.GetAPIKeyFromContext(c)
 	if !ok || apiKey == nil {
@@ -161,6 +176,13 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 		return
 	}
 
+	if service.IsGeminiEmbeddingAction(action) {
+		if forcePlatform, ok := middleware.GetForcePlatformFromContext(c); ok && forcePlatform == service.PlatformAntigravity {
+			googleError(c, http.StatusNotFound, "Unsupported action: "+action)
+			return
+		}
+	}
+
 	stream := action == "streamGenerateContent"
 
 	body, err := io.ReadAll(c.Request.Body)
@@ -179,6 +201,24 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 
 	setOpsRequestContext(c, modelName, stream, body)
 
+	// 分组内容策略检查
+	if policyResult := applyContentPolicy(c, h.contentPolicyService, apiKey, modelName, body); policyResult != nil {
+		if policyResult.Blocked {
+			googleError(c, http.StatusBadRequest, policyResult.BlockReason())
+			return
+		}
+		if policyResult.Redacted {
+			body = policyResult.Body
+			setOpsRequestContext(c, modelName, stream, body)
+		}
+	}
+
+	// 分组请求改写规则
+	if rewritten, changed := applyRequestRewrite(c, apiKey, service.RequestFormatGemini, modelName, body); changed {
+		body = rewritten
+		setOpsRequestContext(c, modelName, stream, body)
+	}
+
 	// Get subscription (may be nil)
 	subscription, _ := middleware.GetSubscriptionFromContext(c)
 
@@ -222,7 +262,7 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 	}
 
 	// 2) billing eligibility check (after wait)
-	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
+	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription, modelName); err != nil {
 		status, _, message := billingErrorDetails(err)
 		googleError(c, status, message)
 		return
@@ -249,14 +289,50 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 	isCLI := isGeminiCLIRequest(c, body)
 	cleanedForUnknownBinding := false
 
+	// 分组灰度 / A-B 分流：模型名在 URL 中，实验组配置了目标模型时直接替换 modelName
+	if _, armModel, err := bindTrafficRoute(c, apiKey, sessionHash, modelName, body, ""); err == nil && armModel != modelName {
+		modelName = armModel
+		setOpsRequestContext(c, modelName, stream, body)
+	}
+
+	// 分组虚拟模型：模型名在 URL 中，按目标依次替换 modelName 并限制账号平台
+	aliasRoute, aliasOK := resolveModelAliasRoute(c, apiKey, modelName)
+	if !aliasOK {
+		googleError(c, http.StatusNotFound, "Model "+modelName+" is not available on this endpoint")
+		return
+	}
+	bindAliasTarget := func() bool {
+		if aliasRoute == nil {
+			return false
+		}
+		if _, err := aliasRoute.bind(c, body, ""); err != nil {
+			return false
+		}
+		modelName = aliasRoute.target().Model
+		setOpsRequestContext(c, modelName, stream, body)
+		return true
+	}
+	bindAliasTarget()
+
 	maxAccountSwitches := h.maxAccountSwitchesGemini
 	switchCount := 0
 	failedAccountIDs := make(map[int64]struct{})
 	lastFailoverStatus := 0
+	skippedUnsupported := 0
 
 	for {
 		selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionKey, modelName, failedAccountIDs, "") // Gemini 不使用会话限制
 		if err != nil {
+			if aliasRoute.next() && bindAliasTarget() {
+				failedAccountIDs = make(map[int64]struct{})
+				switchCount = 0
+				skippedUnsupported = 0
+				continue
+			}
+			if len(failedAccountIDs) == skippedUnsupported && skippedUnsupported > 0 {
+				googleError(c, http.StatusServiceUnavailable, "No available Gemini accounts: "+service.GeminiEmbeddingsUnsupportedMessage)
+				return
+			}
 			if len(failedAccountIDs) == 0 {
 				googleError(c, http.StatusServiceUnavailable, "No available Gemini accounts: "+err.Error())
 				return
@@ -265,6 +341,15 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 			return
 		}
 		account := selection.Account
+		// 仅 AI Studio 账号支持 embedding（Antigravity、Code Assist 等账号跳过，不计入切换次数）
+		if service.IsGeminiEmbeddingAction(action) && !account.SupportsGeminiEmbeddings() {
+			if selection.Acquired && selection.ReleaseFunc != nil {
+				selection.ReleaseFunc()
+			}
+			failedAccountIDs[account.ID] = struct{}{}
+			skippedUnsupported++
+			continue
+		}
 		setOpsSelectedAccount(c, account.ID)
 
 		// 检测账号切换：如果粘性会话绑定的账号与当前选择的账号不同，清除 thoughtSignature
@@ -349,6 +434,11 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 				failedAccountIDs[account.ID] = struct{}{}
 				if switchCount >= maxAccountSwitches {
 					lastFailoverStatus = failoverErr.StatusCode
+					if aliasRoute.next() && bindAliasTarget() {
+						failedAccountIDs = make(map[int64]struct{})
+						switchCount = 0
+						continue
+					}
 					handleGeminiFailoverExhausted(c, lastFailoverStatus)
 					return
 				}
@@ -365,6 +455,7 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 		// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
 		userAgent := c.GetHeader("User-Agent")
 		clientIP := ip.GetClientIP(c)
+		trafficArm := trafficArmName(c)
 
 		// 6) record usage async
 		go func(result *service.ForwardResult, usedAccount *service.Account, ua, ip string) {
@@ -378,6 +469,7 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 				Subscription: subscription,
 				UserAgent:    ua,
 				IPAddress:    ip,
+				TrafficArm:   trafficArm,
 			}); err != nil {
 				log.Printf("Record usage failed: %v", err)
 			}
@@ -22,10 +22,14 @@ type AdminHandlers struct {
 	Setting          *admin.SettingHandler
 	Ops              *admin.OpsHandler
 	System           *admin.SystemHandler
+	Backup           *admin.BackupHandler
+	IPProtection     *admin.IPProtectionHandler
 	Subscription     *admin.SubscriptionHandler
 	Usage            *admin.UsageHandler
 	UserAttribute    *admin.UserAttributeHandler
 	Invite           *admin.InviteHandler
+	DedicatedAccount *admin.DedicatedAccountHandler
+	Organization     *admin.OrganizationHandler
 }
 
 // Handlers contains all HTTP handlers
@@ -38,11 +42,13 @@ type Handlers struct {
 	Subscription  *SubscriptionHandler
 	Invite        *InviteHandler
 	Plan          *PlanHandler
+	Organization  *OrganizationHandler
 	Admin         *AdminHandlers
 	Gateway       *GatewayHandler
 	OpenAIGateway *OpenAIGatewayHandler
 	Setting       *SettingHandler
 	Totp          *TotpHandler
+	TwoFactor     *TwoFactorHandler
 }
 
 // BuildInfo contains build-time information
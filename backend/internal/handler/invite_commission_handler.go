@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConvertCommissionRequest represents the convert commission to balance request payload
+type ConvertCommissionRequest struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// WithdrawCommissionRequest represents the commission withdrawal request payload
+type WithdrawCommissionRequest struct {
+	Amount      float64 `json:"amount" binding:"required,gt=0"`
+	AccountInfo string  `json:"account_info" binding:"required,max=500"`
+}
+
+// GetCommissionReport handles getting the commission earnings report for current user
+// GET /api/v1/invites/commission
+func (h *InviteHandler) GetCommissionReport(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	report, err := h.commissionService.GetReport(c.Request.Context(), subject.UserID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.InviteCommissionReportFromService(report))
+}
+
+// ListCommissions handles listing commission accrual records for current user
+// GET /api/v1/invites/commission/records
+func (h *InviteHandler) ListCommissions(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+
+	records, result, err := h.commissionService.ListCommissions(c.Request.Context(), subject.UserID, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	items := make([]dto.InviteCommission, 0, len(records))
+	for i := range records {
+		items = append(items, *dto.InviteCommissionFromService(&records[i]))
+	}
+	response.Paginated(c, items, result.Total, page, pageSize)
+}
+
+// ConvertCommission handles converting commission into account balance
+// POST /api/v1/invites/commission/convert
+func (h *InviteHandler) ConvertCommission(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req ConvertCommissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	withdrawal, err := h.commissionService.ConvertToBalance(c.Request.Context(), subject.UserID, req.Amount)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.CommissionWithdrawalFromService(withdrawal))
+}
+
+// WithdrawCommission handles requesting a commission payout
+// POST /api/v1/invites/commission/withdrawals
+func (h *InviteHandler) WithdrawCommission(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req WithdrawCommissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	withdrawal, err := h.commissionService.RequestWithdrawal(c.Request.Context(), subject.UserID, req.Amount, req.AccountInfo)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.CommissionWithdrawalFromService(withdrawal))
+}
+
+// ListWithdrawals handles listing commission withdrawals for current user
+// GET /api/v1/invites/commission/withdrawals
+func (h *InviteHandler) ListWithdrawals(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+
+	withdrawals, result, err := h.commissionService.ListMyWithdrawals(c.Request.Context(), subject.UserID, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	items := make([]dto.CommissionWithdrawal, 0, len(withdrawals))
+	for i := range withdrawals {
+		items = append(items, *dto.CommissionWithdrawalFromService(&withdrawals[i]))
+	}
+	response.Paginated(c, items, result.Total, page, pageSize)
+}
@@ -12,13 +12,15 @@ import (
 
 // InviteHandler handles invite-related user requests
 type InviteHandler struct {
-	inviteService *service.InviteService
+	inviteService     *service.InviteService
+	commissionService *service.InviteCommissionService
 }
 
 // NewInviteHandler creates a new InviteHandler
-func NewInviteHandler(inviteService *service.InviteService) *InviteHandler {
+func NewInviteHandler(inviteService *service.InviteService, commissionService *service.InviteCommissionService) *InviteHandler {
 	return &InviteHandler{
-		inviteService: inviteService,
+		inviteService:     inviteService,
+		commissionService: commissionService,
 	}
 }
 
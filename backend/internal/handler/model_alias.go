@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/gemini"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/openai"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/sjson"
+)
+
+// modelAliasRoute 分组虚拟模型请求的目标迭代状态；nil 表示请求的是真实模型
+type modelAliasRoute struct {
+	alias   string
+	targets []service.ModelAliasTarget
+	index   int
+}
+
+// resolveModelAliasRoute 解析 API Key 所属分组的虚拟模型。
+// 返回 ok=false 表示请求了别名但当前路由平台没有可承载的目标。
+func resolveModelAliasRoute(c *gin.Context, apiKey *service.APIKey, model string) (route *modelAliasRoute, ok bool) {
+	if apiKey == nil || apiKey.Group == nil || len(apiKey.Group.ModelAliases) == 0 {
+		return nil, true
+	}
+	platform := apiKey.Group.Platform
+	if forcePlatform, hasForce := middleware2.GetForcePlatformFromContext(c); hasForce {
+		platform = forcePlatform
+	}
+	targets, isAlias := service.ResolveModelAliasTargets(apiKey.Group, platform, model)
+	if !isAlias {
+		return nil, true
+	}
+	if len(targets) == 0 {
+		return nil, false
+	}
+	return &modelAliasRoute{alias: model, targets: targets}, true
+}
+
+// target 当前尝试的目标
+func (r *modelAliasRoute) target() service.ModelAliasTarget {
+	return r.targets[r.index]
+}
+
+// next 切换到下一个目标，没有更多目标时返回 false
+func (r *modelAliasRoute) next() bool {
+	if r == nil || r.index+1 >= len(r.targets) {
+		return false
+	}
+	r.index++
+	return true
+}
+
+// bind 将当前目标绑定到请求：调度只选择目标平台的账号；
+// bodyModelPath 非空时同时把请求体中的模型名替换为目标模型（计费按目标模型）。
+func (r *modelAliasRoute) bind(c *gin.Context, body []byte, bodyModelPath string) ([]byte, error) {
+	target := r.target()
+	c.Request = c.Request.WithContext(service.WithModelAliasTarget(c.Request.Context(), &target))
+	log.Printf("[ModelAlias] alias=%s target=%d/%d platform=%s model=%s", r.alias, r.index+1, len(r.targets), target.Platform, target.Model)
+	if bodyModelPath == "" {
+		return body, nil
+	}
+	return sjson.SetBytes(body, bodyModelPath, target.Model)
+}
+
+// listModelAliases 返回当前路由平台下可用的分组虚拟模型（用于模型列表接口）
+func listModelAliases(c *gin.Context, apiKey *service.APIKey) []service.ModelAlias {
+	if apiKey == nil || apiKey.Group == nil || len(apiKey.Group.ModelAliases) == 0 {
+		return nil
+	}
+	platform := apiKey.Group.Platform
+	if forcePlatform, ok := middleware2.GetForcePlatformFromContext(c); ok {
+		platform = forcePlatform
+	}
+	return service.ListModelAliases(apiKey.Group, platform)
+}
+
+func modelAliasDisplayName(alias service.ModelAlias) string {
+	if alias.Description != "" {
+		return alias.Description
+	}
+	return alias.Name
+}
+
+func claudeAliasModels(aliases []service.ModelAlias) []claude.Model {
+	models := make([]claude.Model, 0, len(aliases))
+	for _, alias := range aliases {
+		models = append(models, claude.Model{
+			ID:          alias.Name,
+			Type:        "model",
+			DisplayName: modelAliasDisplayName(alias),
+			CreatedAt:   "2024-01-01T00:00:00Z",
+		})
+	}
+	return models
+}
+
+func openAIAliasModels(aliases []service.ModelAlias) []openai.Model {
+	models := make([]openai.Model, 0, len(aliases))
+	for _, alias := range aliases {
+		models = append(models, openai.Model{
+			ID:          alias.Name,
+			Object:      "model",
+			Created:     1704067200,
+			OwnedBy:     "sub2api",
+			Type:        "model",
+			DisplayName: modelAliasDisplayName(alias),
+		})
+	}
+	return models
+}
+
+func geminiAliasModel(alias service.ModelAlias) gemini.Model {
+	return gemini.Model{
+		Name:                       "models/" + alias.Name,
+		DisplayName:                modelAliasDisplayName(alias),
+		Description:                alias.Description,
+		SupportedGenerationMethods: []string{"generateContent", "streamGenerateContent"},
+	}
+}
+
+// findGeminiAliasModel 按 Gemini 模型名（可带 models/ 前缀）查找分组虚拟模型
+func findGeminiAliasModel(aliases []service.ModelAlias, name string) (gemini.Model, bool) {
+	name = strings.TrimPrefix(name, "models/")
+	for _, alias := range aliases {
+		if alias.Name == name {
+			return geminiAliasModel(alias), true
+		}
+	}
+	return gemini.Model{}, false
+}
+
+// appendGeminiAliasModels 将分组虚拟模型追加到 Gemini 模型列表响应的 models 数组
+func appendGeminiAliasModels(body []byte, aliases []service.ModelAlias) []byte {
+	for _, alias := range aliases {
+		updated, err := sjson.SetBytes(body, "models.-1", geminiAliasModel(alias))
+		if err != nil {
+			return body
+		}
+		body = updated
+	}
+	return body
+}
+
+// writeGeminiModelsList 输出本地构造的 Gemini 模型列表，并追加分组虚拟模型
+func writeGeminiModelsList(c *gin.Context, list any, aliases []service.ModelAlias) {
+	if len(aliases) == 0 {
+		c.JSON(http.StatusOK, list)
+		return
+	}
+	body, err := json.Marshal(list)
+	if err != nil {
+		c.JSON(http.StatusOK, list)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", appendGeminiAliasModels(body, aliases))
+}
@@ -22,10 +22,11 @@ import (
 
 // OpenAIGatewayHandler handles OpenAI API gateway requests
 type OpenAIGatewayHandler struct {
-	gatewayService      *service.OpenAIGatewayService
-	billingCacheService *service.BillingCacheService
-	concurrencyHelper   *ConcurrencyHelper
-	maxAccountSwitches  int
+	gatewayService       *service.OpenAIGatewayService
+	billingCacheService  *service.BillingCacheService
+	contentPolicyService *service.ContentPolicyService
+	concurrencyHelper    *ConcurrencyHelper
+	maxAccountSwitches   int
 }
 
 // NewOpenAIGatewayHandler creates a new OpenAIGatewayHandler
@@ -33,6 +34,7 @@ func NewOpenAIGatewayHandler(
 	gatewayService *service.OpenAIGatewayService,
 	concurrencyService *service.ConcurrencyService,
 	billingCacheService *service.BillingCacheService,
+	contentPolicyService *service.ContentPolicyService,
 	cfg *config.Config,
 ) *OpenAIGatewayHandler {
 	pingInterval := time.Duration(0)
@@ -44,10 +46,11 @@ func NewOpenAIGatewayHandler(
 		}
 	}
 	return &OpenAIGatewayHandler{
-		gatewayService:      gatewayService,
-		billingCacheService: billingCacheService,
-		concurrencyHelper:   NewConcurrencyHelper(concurrencyService, SSEPingFormatComment, pingInterval),
-		maxAccountSwitches:  maxAccountSwitches,
+		gatewayService:       gatewayService,
+		billingCacheService:  billingCacheService,
+		contentPolicyService: contentPolicyService,
+		concurrencyHelper:    NewConcurrencyHelper(concurrencyService, SSEPingFormatComment, pingInterval),
+		maxAccountSwitches:   maxAccountSwitches,
 	}
 }
 
@@ -102,6 +105,29 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		return
 	}
 
+	// 分组内容策略检查（在注入默认 instructions 之前，仅检查客户端提交的内容）
+	if policyResult := applyContentPolicy(c, h.contentPolicyService, apiKey, reqModel, body); policyResult != nil {
+		if policyResult.Blocked {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"type":    "invalid_request_error",
+					"code":    "content_policy_violation",
+					"message": policyResult.BlockReason(),
+				},
+			})
+			return
+		}
+		if policyResult.Redacted {
+			body = policyResult.Body
+			reqBody = nil
+			if err := json.Unmarshal(body, &reqBody); err != nil {
+				h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+				return
+			}
+			setOpsRequestContext(c, reqModel, reqStream, body)
+		}
+	}
+
 	userAgent := c.GetHeader("User-Agent")
 	if !openai.IsCodexCLIRequest(userAgent) {
 		existingInstructions, _ := reqBody["instructions"].(string)
@@ -118,6 +144,16 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		}
 	}
 
+	// 分组请求改写规则（在默认 instructions 注入之后执行，便于前置/追加提示词）
+	if rewritten, changed := applyRequestRewrite(c, apiKey, service.RequestFormatOpenAIResponses, reqModel, body); changed {
+		body = rewritten
+		reqBody = nil
+		if err := json.Unmarshal(body, &reqBody); err != nil {
+			h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+			return
+		}
+	}
+
 	setOpsRequestContext(c, reqModel, reqStream, body)
 
 	// 提前校验 function_call_output 是否具备可关联上下文，避免上游 400。
@@ -185,7 +221,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 	}
 
 	// 2. Re-check billing eligibility after wait
-	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
+	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription, reqModel); err != nil {
 		log.Printf("Billing eligibility check failed after wait: %v", err)
 		status, code, message := billingErrorDetails(err)
 		h.handleStreamingAwareError(c, status, code, message, streamStarted)
@@ -195,6 +231,42 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 	// Generate session hash (header first; fallback to prompt_cache_key)
 	sessionHash := h.gatewayService.GenerateSessionHash(c, reqBody)
 
+	// 分组灰度 / A-B 分流：按会话稳定分配实验组，限定账号池并按需改写目标模型（先于虚拟模型解析）
+	if rewritten, armModel, err := bindTrafficRoute(c, apiKey, sessionHash, reqModel, body, "model"); err != nil {
+		log.Printf("[OpenAI Handler] Bind traffic route failed: %v", err)
+	} else if armModel != reqModel {
+		body = rewritten
+		reqModel = armModel
+		reqBody["model"] = reqModel
+		setOpsRequestContext(c, reqModel, reqStream, body)
+	}
+
+	// 分组虚拟模型：按配置顺序尝试目标，当前目标无可用账号或故障转移耗尽时切换到下一个
+	aliasRoute, aliasOK := resolveModelAliasRoute(c, apiKey, reqModel)
+	if !aliasOK {
+		h.handleStreamingAwareError(c, http.StatusBadRequest, "invalid_request_error", "Model "+reqModel+" is not available on this endpoint", streamStarted)
+		return
+	}
+	bindAliasTarget := func() bool {
+		rewritten, err := aliasRoute.bind(c, body, "model")
+		if err != nil {
+			log.Printf("[OpenAI Handler] Bind model alias target failed: %v", err)
+			return false
+		}
+		body = rewritten
+		reqModel = aliasRoute.target().Model
+		reqBody["model"] = reqModel
+		setOpsRequestContext(c, reqModel, reqStream, body)
+		return true
+	}
+	if aliasRoute != nil && !bindAliasTarget() {
+		h.handleStreamingAwareError(c, http.StatusInternalServerError, "api_error", "Failed to resolve model alias", streamStarted)
+		return
+	}
+	nextAliasTarget := func() bool {
+		return aliasRoute.next() && bindAliasTarget()
+	}
+
 	maxAccountSwitches := h.maxAccountSwitches
 	switchCount := 0
 	failedAccountIDs := make(map[int64]struct{})
@@ -206,6 +278,11 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionHash, reqModel, failedAccountIDs)
 		if err != nil {
 			log.Printf("[OpenAI Handler] SelectAccount failed: %v", err)
+			if nextAliasTarget() {
+				failedAccountIDs = make(map[int64]struct{})
+				switchCount = 0
+				continue
+			}
 			if len(failedAccountIDs) == 0 {
 				h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
 				return
@@ -277,6 +354,11 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 				failedAccountIDs[account.ID] = struct{}{}
 				if switchCount >= maxAccountSwitches {
 					lastFailoverStatus = failoverErr.StatusCode
+					if nextAliasTarget() {
+						failedAccountIDs = make(map[int64]struct{})
+						switchCount = 0
+						continue
+					}
 					h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
 					return
 				}
@@ -293,6 +375,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
 		userAgent := c.GetHeader("User-Agent")
 		clientIP := ip.GetClientIP(c)
+		trafficArm := trafficArmName(c)
 
 		// Async record usage
 		go func(result *service.OpenAIForwardResult, usedAccount *service.Account, ua, ip string) {
@@ -306,6 +389,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 				Subscription: subscription,
 				UserAgent:    ua,
 				IPAddress:    ip,
+				TrafficArm:   trafficArm,
 			}); err != nil {
 				log.Printf("Record usage failed: %v", err)
 			}
@@ -319,6 +319,11 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 
 		status := c.Writer.Status()
 		if status < 400 {
+			// 内容策略命中但未拦截（redact/log）时单独记录一条策略事件
+			if policyResult := getOpsContentPolicyResult(c); policyResult != nil {
+				enqueueOpsContentPolicyEvent(c, ops, status, policyResult)
+			}
+
 			// Even when the client request succeeds, we still want to persist upstream error attempts
 			// (retries/failover) so ops can observe upstream instability that gets "covered" by retries.
 			var events []*service.OpsUpstreamErrorEvent
@@ -484,6 +489,8 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 				Stream:    stream,
 				UserAgent: c.GetHeader("User-Agent"),
 
+				TrafficArm: trafficArmName(c),
+
 				ErrorPhase: "upstream",
 				ErrorType:  "upstream_error",
 				// Severity/retryability should reflect the upstream failure, not the final client status (200).
@@ -600,6 +607,8 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 			Stream:    stream,
 			UserAgent: c.GetHeader("User-Agent"),
 
+			TrafficArm: trafficArmName(c),
+
 			ErrorPhase:        phase,
 			ErrorType:         normalizeOpsErrorType(parsed.ErrorType, parsed.Code),
 			Severity:          classifyOpsSeverity(parsed.ErrorType, status),
@@ -619,6 +628,10 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 			CreatedAt:   time.Now(),
 		}
 
+		if policyResult := getOpsContentPolicyResult(c); policyResult != nil && policyResult.Blocked {
+			applyOpsContentPolicy(entry, policyResult)
+		}
+
 		// Capture upstream error context set by gateway services (if present).
 		// This does NOT affect the client response; it enriches Ops troubleshooting data.
 		{
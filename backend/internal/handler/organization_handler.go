@@ -0,0 +1,380 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/usagestats"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationHandler handles organization (team) requests for the current user
+type OrganizationHandler struct {
+	organizationService *service.OrganizationService
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler
+func NewOrganizationHandler(organizationService *service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{organizationService: organizationService}
+}
+
+// CreateOrganizationRequest represents the create organization request payload
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// UpdateOrganizationMemberRequest represents the update member request payload
+type UpdateOrganizationMemberRequest struct {
+	Role              *string  `json:"role"`
+	MonthlyLimitUSD   *float64 `json:"monthly_limit_usd"`
+	ClearMonthlyLimit bool     `json:"clear_monthly_limit"`
+}
+
+// InviteOrganizationMemberRequest represents the invite member request payload
+type InviteOrganizationMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role"`
+}
+
+// TransferOrganizationRequest represents the transfer ownership request payload
+type TransferOrganizationRequest struct {
+	UserID int64 `json:"user_id" binding:"required"`
+}
+
+// AcceptOrganizationInvitationRequest represents the accept invitation request payload
+type AcceptOrganizationInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// List handles listing organizations the current user belongs to
+// GET /api/v1/organizations
+func (h *OrganizationHandler) List(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	orgs, err := h.organizationService.ListMine(c.Request.Context(), subject.UserID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.Organization, 0, len(orgs))
+	for i := range orgs {
+		out = append(out, *dto.OrganizationFromService(&orgs[i]))
+	}
+	response.Success(c, out)
+}
+
+// Create handles creating an organization; the creator becomes its owner
+// POST /api/v1/organizations
+func (h *OrganizationHandler) Create(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	org, err := h.organizationService.Create(c.Request.Context(), subject.UserID, req.Name)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	out := dto.OrganizationFromService(org)
+	out.Role = service.OrganizationRoleOwner
+	response.Success(c, out)
+}
+
+// Get handles getting an organization the current user belongs to
+// GET /api/v1/organizations/:id
+func (h *OrganizationHandler) Get(c *gin.Context) {
+	subject, orgID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	org, member, err := h.organizationService.Get(c.Request.Context(), subject.UserID, orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	out := dto.OrganizationFromService(org)
+	out.Role = member.Role
+	response.Success(c, out)
+}
+
+// ListMembers handles listing organization members
+// GET /api/v1/organizations/:id/members
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	subject, orgID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.organizationService.ListMembers(c.Request.Context(), subject.UserID, orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, organizationMembersToDTO(members))
+}
+
+// UpdateMember handles updating a member's role or monthly spending cap
+// PUT /api/v1/organizations/:id/members/:user_id
+func (h *OrganizationHandler) UpdateMember(c *gin.Context) {
+	subject, orgID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	var req UpdateOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	member, err := h.organizationService.UpdateMember(c.Request.Context(), subject.UserID, orgID, userID, service.UpdateOrganizationMemberInput{
+		Role:              req.Role,
+		MonthlyLimitUSD:   req.MonthlyLimitUSD,
+		ClearMonthlyLimit: req.ClearMonthlyLimit,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationMemberFromService(member))
+}
+
+// RemoveMember handles removing a member (or leaving when user_id is the current user)
+// DELETE /api/v1/organizations/:id/members/:user_id
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	subject, orgID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	if err := h.organizationService.RemoveMember(c.Request.Context(), subject.UserID, orgID, userID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Member removed"})
+}
+
+// Transfer handles transferring organization ownership
+// POST /api/v1/organizations/:id/transfer
+func (h *OrganizationHandler) Transfer(c *gin.Context) {
+	subject, orgID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req TransferOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	org, err := h.organizationService.TransferOwnership(c.Request.Context(), subject.UserID, orgID, req.UserID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationFromService(org))
+}
+
+// ListInvitations handles listing pending invitations
+// GET /api/v1/organizations/:id/invitations
+func (h *OrganizationHandler) ListInvitations(c *gin.Context) {
+	subject, orgID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.organizationService.ListInvitations(c.Request.Context(), subject.UserID, orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	out := make([]dto.OrganizationInvitation, 0, len(invitations))
+	for i := range invitations {
+		out = append(out, *dto.OrganizationInvitationFromService(&invitations[i]))
+	}
+	response.Success(c, out)
+}
+
+// Invite handles inviting a user by email
+// POST /api/v1/organizations/:id/invitations
+func (h *OrganizationHandler) Invite(c *gin.Context) {
+	subject, orgID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req InviteOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	invitation, err := h.organizationService.Invite(c.Request.Context(), subject.UserID, orgID, req.Email, req.Role, requestFrontendBaseURL(c))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationInvitationFromService(invitation))
+}
+
+// RevokeInvitation handles revoking a pending invitation
+// DELETE /api/v1/organizations/:id/invitations/:invitation_id
+func (h *OrganizationHandler) RevokeInvitation(c *gin.Context) {
+	subject, orgID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+	invitationID, err := strconv.ParseInt(c.Param("invitation_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid invitation ID")
+		return
+	}
+
+	if err := h.organizationService.RevokeInvitation(c.Request.Context(), subject.UserID, orgID, invitationID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Invitation revoked"})
+}
+
+// AcceptInvitation handles accepting an invitation token
+// POST /api/v1/organizations/invitations/accept
+func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req AcceptOrganizationInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	org, err := h.organizationService.AcceptInvitation(c.Request.Context(), subject.UserID, req.Token)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationFromService(org))
+}
+
+// Dashboard handles organization usage dashboard (owner/admin only)
+// GET /api/v1/organizations/:id/dashboard
+func (h *OrganizationHandler) Dashboard(c *gin.Context) {
+	subject, orgID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	startTime, endTime := parseUserTimeRange(c)
+	dashboard, err := h.organizationService.GetDashboard(c.Request.Context(), subject.UserID, orgID, startTime, endTime)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationDashboardFromService(dashboard))
+}
+
+// Usage handles listing organization usage records (owner/admin only)
+// GET /api/v1/organizations/:id/usage
+func (h *OrganizationHandler) Usage(c *gin.Context) {
+	subject, orgID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+
+	filters := usagestats.UsageLogFilters{Model: c.Query("model")}
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := strconv.ParseInt(userIDStr, 10, 64)
+		if err != nil {
+			response.BadRequest(c, "Invalid user_id")
+			return
+		}
+		filters.UserID = userID
+	}
+	startTime, endTime := parseUserTimeRange(c)
+	filters.StartTime = &startTime
+	filters.EndTime = &endTime
+
+	logs, result, err := h.organizationService.ListUsage(c.Request.Context(), subject.UserID, orgID, params, filters)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	out := make([]dto.UsageLog, 0, len(logs))
+	for i := range logs {
+		out = append(out, *dto.UsageLogFromService(&logs[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
+
+func (h *OrganizationHandler) parseRequest(c *gin.Context) (middleware2.AuthSubject, int64, bool) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return subject, 0, false
+	}
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid organization ID")
+		return subject, 0, false
+	}
+	return subject, orgID, true
+}
+
+func organizationMembersToDTO(members []service.OrganizationMember) []dto.OrganizationMember {
+	out := make([]dto.OrganizationMember, 0, len(members))
+	for i := range members {
+		out = append(out, *dto.OrganizationMemberFromService(&members[i]))
+	}
+	return out
+}
+
+// requestFrontendBaseURL builds the frontend base URL from the incoming request
+func requestFrontendBaseURL(c *gin.Context) string {
+	scheme := "https"
+	if c.Request.TLS == nil {
+		// Check X-Forwarded-Proto header (common in reverse proxy setups)
+		if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+			scheme = proto
+		} else {
+			scheme = "http"
+		}
+	}
+	return scheme + "://" + c.Request.Host
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"log"
+
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// applyRequestRewrite 执行 API Key 所属分组的请求改写规则。
+// 请求头改写挂到 c.Request 的 context 上，由 HTTPUpstream 在发送上游请求前应用；
+// 返回的 changed 为 true 时调用方需改用返回的 body 并重新解析。
+func applyRequestRewrite(c *gin.Context, apiKey *service.APIKey, format, model string, body []byte) ([]byte, bool) {
+	if apiKey == nil || apiKey.Group == nil || !service.HasActiveRewriteRules(apiKey.Group.RewriteRules) {
+		return body, false
+	}
+
+	platform := apiKey.Group.Platform
+	if forcePlatform, ok := middleware2.GetForcePlatformFromContext(c); ok {
+		platform = forcePlatform
+	}
+	result := service.ApplyRequestRewriteRules(apiKey.Group.RewriteRules, &service.RequestRewriteInput{
+		Platform: platform,
+		Format:   format,
+		Model:    model,
+		Body:     body,
+	})
+	if len(result.AppliedRules) == 0 {
+		return body, false
+	}
+
+	c.Request = c.Request.WithContext(service.WithRewriteHeaders(c.Request.Context(), result.Headers))
+	log.Printf("[RequestRewrite] applied: group=%d api_key=%d model=%s rules=%v body_changed=%v",
+		apiKey.Group.ID, apiKey.ID, model, result.AppliedRules, result.Changed)
+	return result.Body, result.Changed
+}
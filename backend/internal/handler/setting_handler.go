@@ -37,6 +37,7 @@ func (h *SettingHandler) GetPublicSettings(c *gin.Context) {
 		PromoCodeEnabled:            settings.PromoCodeEnabled,
 		PasswordResetEnabled:        settings.PasswordResetEnabled,
 		TotpEnabled:                 settings.TotpEnabled,
+		WebAuthnEnabled:             settings.WebAuthnEnabled,
 		TurnstileEnabled:            settings.TurnstileEnabled,
 		TurnstileSiteKey:            settings.TurnstileSiteKey,
 		SiteName:                    settings.SiteName,
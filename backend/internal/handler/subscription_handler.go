@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
@@ -37,16 +38,19 @@ type SubscriptionProgressInfo struct {
 type SubscriptionHandler struct {
 	subscriptionService *service.SubscriptionService
 	reminderService     *service.SubscriptionReminderService
+	lifecycleService    *service.SubscriptionLifecycleService
 }
 
 // NewSubscriptionHandler creates a new user subscription handler
 func NewSubscriptionHandler(
 	subscriptionService *service.SubscriptionService,
 	reminderService *service.SubscriptionReminderService,
+	lifecycleService *service.SubscriptionLifecycleService,
 ) *SubscriptionHandler {
 	return &SubscriptionHandler{
 		subscriptionService: subscriptionService,
 		reminderService:     reminderService,
+		lifecycleService:    lifecycleService,
 	}
 }
 
@@ -217,3 +221,171 @@ func (h *SubscriptionHandler) GetSummary(c *gin.Context) {
 
 	response.Success(c, summary)
 }
+
+// SetAutoRenewRequest represents the auto-renew toggle request
+type SetAutoRenewRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// SwitchPlanRequest represents the switch plan request
+type SwitchPlanRequest struct {
+	GroupID int64 `json:"group_id" binding:"required"`
+}
+
+// SwitchPlanResponse represents the switch plan result
+type SwitchPlanResponse struct {
+	Subscription *dto.UserSubscription            `json:"subscription"`
+	Quote        *service.SubscriptionSwitchQuote `json:"quote"`
+}
+
+// SetAutoRenew handles toggling auto-renewal from balance
+// PUT /api/v1/subscriptions/:id/auto-renew
+func (h *SubscriptionHandler) SetAutoRenew(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not found in context")
+		return
+	}
+	subscriptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid subscription ID")
+		return
+	}
+
+	var req SetAutoRenewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	sub, err := h.lifecycleService.SetAutoRenew(c.Request.Context(), subject.UserID, subscriptionID, req.Enabled)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.UserSubscriptionFromService(sub))
+}
+
+// Renew handles renewing a subscription for one period from balance
+// POST /api/v1/subscriptions/:id/renew
+func (h *SubscriptionHandler) Renew(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not found in context")
+		return
+	}
+	subscriptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid subscription ID")
+		return
+	}
+
+	sub, err := h.lifecycleService.Renew(c.Request.Context(), subject.UserID, subscriptionID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.UserSubscriptionFromService(sub))
+}
+
+// SwitchQuote handles previewing the prorated cost of switching plans
+// GET /api/v1/subscriptions/:id/switch-quote?group_id=
+func (h *SubscriptionHandler) SwitchQuote(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not found in context")
+		return
+	}
+	subscriptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid subscription ID")
+		return
+	}
+	groupID, err := strconv.ParseInt(c.Query("group_id"), 10, 64)
+	if err != nil || groupID <= 0 {
+		response.BadRequest(c, "Invalid group ID")
+		return
+	}
+
+	quote, err := h.lifecycleService.QuoteSwitch(c.Request.Context(), subject.UserID, subscriptionID, groupID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, quote)
+}
+
+// Switch handles switching a subscription to another plan/group with prorated credit
+// POST /api/v1/subscriptions/:id/switch
+func (h *SubscriptionHandler) Switch(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not found in context")
+		return
+	}
+	subscriptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid subscription ID")
+		return
+	}
+
+	var req SwitchPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	sub, quote, err := h.lifecycleService.SwitchPlan(c.Request.Context(), subject.UserID, subscriptionID, req.GroupID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, SwitchPlanResponse{
+		Subscription: dto.UserSubscriptionFromService(sub),
+		Quote:        quote,
+	})
+}
+
+// Pause handles pausing a subscription
+// POST /api/v1/subscriptions/:id/pause
+func (h *SubscriptionHandler) Pause(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not found in context")
+		return
+	}
+	subscriptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid subscription ID")
+		return
+	}
+
+	sub, err := h.lifecycleService.Pause(c.Request.Context(), subject.UserID, subscriptionID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.UserSubscriptionFromService(sub))
+}
+
+// Resume handles resuming a paused subscription
+// POST /api/v1/subscriptions/:id/resume
+func (h *SubscriptionHandler) Resume(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not found in context")
+		return
+	}
+	subscriptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid subscription ID")
+		return
+	}
+
+	sub, err := h.lifecycleService.Resume(c.Request.Context(), subject.UserID, subscriptionID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.UserSubscriptionFromService(sub))
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/sjson"
+)
+
+// opsTrafficRouteKey 请求的分流结果（*service.TrafficRoute），用于用量与错误日志记录实验组
+const opsTrafficRouteKey = "ops_traffic_route"
+
+// bindTrafficRoute 按分组分流配置为请求分配实验组并写入 context，调度时只选择实验组的账号池。
+// 实验组配置了目标模型时返回改写后的请求体与模型；bodyModelPath 为空时只返回新模型名（如 Gemini 模型在 URL 中）。
+// subject 为会话标识，为空时退化为 API Key，同一 Key 的无会话请求落在同一实验组。
+func bindTrafficRoute(c *gin.Context, apiKey *service.APIKey, subject, model string, body []byte, bodyModelPath string) ([]byte, string, error) {
+	if apiKey == nil || apiKey.Group == nil || apiKey.Group.TrafficSplit == nil || !apiKey.Group.TrafficSplit.Enabled {
+		return body, model, nil
+	}
+	if subject == "" {
+		subject = "key:" + strconv.FormatInt(apiKey.ID, 10)
+	}
+	arm := service.AssignTrafficArm(apiKey.Group, subject, model)
+	route := service.NewTrafficRoute(apiKey.Group, arm)
+	c.Request = c.Request.WithContext(service.WithTrafficRoute(c.Request.Context(), route))
+	c.Set(opsTrafficRouteKey, route)
+	if arm == nil || arm.Model == "" || arm.Model == model {
+		return body, model, nil
+	}
+	if bodyModelPath != "" {
+		rewritten, err := sjson.SetBytes(body, bodyModelPath, arm.Model)
+		if err != nil {
+			return body, model, err
+		}
+		body = rewritten
+	}
+	return body, arm.Model, nil
+}
+
+// trafficArmName 请求实际生效的实验组名称，分组未启用分流时为空
+func trafficArmName(c *gin.Context) string {
+	v, _ := c.Get(opsTrafficRouteKey)
+	route, _ := v.(*service.TrafficRoute)
+	return route.Name()
+}
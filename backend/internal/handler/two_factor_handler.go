@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+// TwoFactorHandler handles passkey management and 2FA recovery codes
+type TwoFactorHandler struct {
+	webAuthnService  *service.WebAuthnService
+	twoFactorService *service.TwoFactorService
+}
+
+// NewTwoFactorHandler creates a new TwoFactorHandler
+func NewTwoFactorHandler(webAuthnService *service.WebAuthnService, twoFactorService *service.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		webAuthnService:  webAuthnService,
+		twoFactorService: twoFactorService,
+	}
+}
+
+// IdentityVerifyRequest 敏感操作的身份验证参数（开启邮件验证时使用邮箱验证码，否则使用密码）
+type IdentityVerifyRequest struct {
+	EmailCode string `json:"email_code"`
+	Password  string `json:"password"`
+}
+
+// PasskeyListResponse passkey 列表
+type PasskeyListResponse struct {
+	FeatureEnabled bool                     `json:"feature_enabled"`
+	Credentials    []dto.WebAuthnCredential `json:"credentials"`
+}
+
+// ListPasskeys lists the passkeys of the current user
+// GET /api/v1/user/webauthn/credentials
+func (h *TwoFactorHandler) ListPasskeys(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	creds, err := h.webAuthnService.ListCredentials(c.Request.Context(), subject.UserID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, PasskeyListResponse{
+		FeatureEnabled: h.webAuthnService.IsEnabled(c.Request.Context()),
+		Credentials:    dto.WebAuthnCredentialsFromService(creds),
+	})
+}
+
+// BeginPasskeyRegistration returns creation options for a new passkey
+// POST /api/v1/user/webauthn/register/options
+func (h *TwoFactorHandler) BeginPasskeyRegistration(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req IdentityVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	opts, err := h.webAuthnService.BeginRegistration(c.Request.Context(), subject.UserID, req.EmailCode, req.Password)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, opts)
+}
+
+// FinishPasskeyRegistrationRequest 提交注册结果
+type FinishPasskeyRegistrationRequest struct {
+	ChallengeID string                       `json:"challenge_id" binding:"required"`
+	Name        string                       `json:"name" binding:"required"`
+	Credential  *service.WebAuthnAttestation `json:"credential" binding:"required"`
+}
+
+// FinishPasskeyRegistration verifies and stores a new passkey
+// POST /api/v1/user/webauthn/register
+func (h *TwoFactorHandler) FinishPasskeyRegistration(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req FinishPasskeyRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	cred, err := h.webAuthnService.FinishRegistration(c.Request.Context(), subject.UserID, req.ChallengeID, req.Name, req.Credential)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.WebAuthnCredentialFromService(cred))
+}
+
+// RenamePasskeyRequest 重命名 passkey
+type RenamePasskeyRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// RenamePasskey renames a passkey
+// PUT /api/v1/user/webauthn/credentials/:id
+func (h *TwoFactorHandler) RenamePasskey(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid passkey ID")
+		return
+	}
+
+	var req RenamePasskeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.webAuthnService.RenameCredential(c.Request.Context(), subject.UserID, id, req.Name); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"success": true})
+}
+
+// DeletePasskey removes a passkey after re-verifying the user's identity
+// POST /api/v1/user/webauthn/credentials/:id/delete
+func (h *TwoFactorHandler) DeletePasskey(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid passkey ID")
+		return
+	}
+
+	var req IdentityVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	if err := h.webAuthnService.DeleteCredential(c.Request.Context(), subject.UserID, id, req.EmailCode, req.Password); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"success": true})
+}
+
+// GetRecoveryCodeStatus returns how many unused recovery codes remain
+// GET /api/v1/user/2fa/recovery-codes
+func (h *TwoFactorHandler) GetRecoveryCodeStatus(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	status, err := h.twoFactorService.RecoveryCodeStatus(c.Request.Context(), subject.UserID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, status)
+}
+
+// GenerateRecoveryCodes issues a new set of recovery codes, invalidating the old ones
+// POST /api/v1/user/2fa/recovery-codes
+func (h *TwoFactorHandler) GenerateRecoveryCodes(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req IdentityVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	codes, err := h.twoFactorService.GenerateRecoveryCodes(c.Request.Context(), subject.UserID, req.EmailCode, req.Password)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"codes": codes})
+}
@@ -11,13 +11,15 @@ import (
 
 // UserHandler handles user-related requests
 type UserHandler struct {
-	userService *service.UserService
+	userService    *service.UserService
+	sessionService *service.AuthSessionService
 }
 
 // NewUserHandler creates a new UserHandler
-func NewUserHandler(userService *service.UserService) *UserHandler {
+func NewUserHandler(userService *service.UserService, sessionService *service.AuthSessionService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:    userService,
+		sessionService: sessionService,
 	}
 }
 
@@ -104,3 +106,56 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	response.Success(c, dto.UserFromService(updatedUser))
 }
+
+// ListSessions 列出当前用户的登录会话（设备列表）
+// GET /api/v1/user/sessions
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), subject.UserID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.AuthSessionsFromService(sessions, middleware2.GetAuthSessionIDFromContext(c)))
+}
+
+// RevokeSession 吊销当前用户的某个登录会话
+// DELETE /api/v1/user/sessions/:id
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), subject.UserID, c.Param("id")); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Session revoked"})
+}
+
+// RevokeOtherSessions 吊销除当前会话外的全部登录会话
+// DELETE /api/v1/user/sessions
+func (h *UserHandler) RevokeOtherSessions(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	revoked, err := h.sessionService.RevokeAllSessions(c.Request.Context(), subject.UserID, middleware2.GetAuthSessionIDFromContext(c))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"revoked": revoked})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"github.com/Wei-Shaw/sub2api/internal/config"
 	"github.com/Wei-Shaw/sub2api/internal/handler/admin"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
@@ -25,10 +26,14 @@ func ProvideAdminHandlers(
 	settingHandler *admin.SettingHandler,
 	opsHandler *admin.OpsHandler,
 	systemHandler *admin.SystemHandler,
+	backupHandler *admin.BackupHandler,
+	ipProtectionHandler *admin.IPProtectionHandler,
 	subscriptionHandler *admin.SubscriptionHandler,
 	usageHandler *admin.UsageHandler,
 	userAttributeHandler *admin.UserAttributeHandler,
 	inviteHandler *admin.InviteHandler,
+	dedicatedAccountHandler *admin.DedicatedAccountHandler,
+	organizationHandler *admin.OrganizationHandler,
 ) *AdminHandlers {
 	return &AdminHandlers{
 		Dashboard:        dashboardHandler,
@@ -47,16 +52,20 @@ func ProvideAdminHandlers(
 		Setting:          settingHandler,
 		Ops:              opsHandler,
 		System:           systemHandler,
+		Backup:           backupHandler,
+		IPProtection:     ipProtectionHandler,
 		Subscription:     subscriptionHandler,
 		Usage:            usageHandler,
 		UserAttribute:    userAttributeHandler,
 		Invite:           inviteHandler,
+		DedicatedAccount: dedicatedAccountHandler,
+		Organization:     organizationHandler,
 	}
 }
 
-// ProvideSystemHandler creates admin.SystemHandler with UpdateService
-func ProvideSystemHandler(updateService *service.UpdateService) *admin.SystemHandler {
-	return admin.NewSystemHandler(updateService)
+// ProvideSystemHandler creates admin.SystemHandler with UpdateService and config reloader
+func ProvideSystemHandler(updateService *service.UpdateService, configReloader *config.Reloader) *admin.SystemHandler {
+	return admin.NewSystemHandler(updateService, configReloader)
 }
 
 // ProvideSettingHandler creates SettingHandler with version from BuildInfo
@@ -74,11 +83,13 @@ func ProvideHandlers(
 	subscriptionHandler *SubscriptionHandler,
 	inviteHandler *InviteHandler,
 	planHandler *PlanHandler,
+	organizationHandler *OrganizationHandler,
 	adminHandlers *AdminHandlers,
 	gatewayHandler *GatewayHandler,
 	openaiGatewayHandler *OpenAIGatewayHandler,
 	settingHandler *SettingHandler,
 	totpHandler *TotpHandler,
+	twoFactorHandler *TwoFactorHandler,
 ) *Handlers {
 	return &Handlers{
 		Auth:          authHandler,
@@ -89,11 +100,13 @@ func ProvideHandlers(
 		Subscription:  subscriptionHandler,
 		Invite:        inviteHandler,
 		Plan:          planHandler,
+		Organization:  organizationHandler,
 		Admin:         adminHandlers,
 		Gateway:       gatewayHandler,
 		OpenAIGateway: openaiGatewayHandler,
 		Setting:       settingHandler,
 		Totp:          totpHandler,
+		TwoFactor:     twoFactorHandler,
 	}
 }
 
@@ -109,6 +122,7 @@ var ProviderSet = wire.NewSet(
 	NewGatewayHandler,
 	NewOpenAIGatewayHandler,
 	NewTotpHandler,
+	NewTwoFactorHandler,
 	ProvideSettingHandler,
 
 	// Admin handlers
@@ -128,12 +142,17 @@ var ProviderSet = wire.NewSet(
 	admin.NewSettingHandler,
 	admin.NewOpsHandler,
 	ProvideSystemHandler,
+	admin.NewBackupHandler,
+	admin.NewIPProtectionHandler,
 	admin.NewSubscriptionHandler,
 	admin.NewUsageHandler,
 	admin.NewUserAttributeHandler,
 	admin.NewInviteHandler,
+	admin.NewDedicatedAccountHandler,
+	admin.NewOrganizationHandler,
 	NewInviteHandler,
 	NewPlanHandler,
+	NewOrganizationHandler,
 
 	// AdminHandlers and Handlers constructors
 	ProvideAdminHandlers,
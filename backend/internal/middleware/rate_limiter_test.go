@@ -66,7 +66,7 @@ func TestRateLimiterSuccessAndLimit(t *testing.T) {
 	originalRun := rateLimitRun
 	counts := []int64{1, 2}
 	callIndex := 0
-	rateLimitRun = func(ctx context.Context, client *redis.Client, key string, windowMillis int64) (int64, bool, error) {
+	rateLimitRun = func(ctx context.Context, client redis.UniversalClient, key string, windowMillis int64) (int64, bool, error) {
 		if callIndex >= len(counts) {
 			return counts[len(counts)-1], false, nil
 		}
@@ -18,4 +18,12 @@ const (
 	IsClaudeCodeClient Key = "ctx_is_claude_code_client"
 	// Group 认证后的分组信息，由 API Key 认证中间件设置
 	Group Key = "ctx_group"
+	// AccountAffinity 用户/API Key 专属账号配置，由 API Key 认证中间件设置
+	AccountAffinity Key = "ctx_account_affinity"
+	// RewriteHeaders 分组改写规则产生的上游请求头操作，由网关 handler 设置
+	RewriteHeaders Key = "ctx_rewrite_headers"
+	// ModelAliasTarget 当前尝试的分组虚拟模型目标，由网关 handler 设置
+	ModelAliasTarget Key = "ctx_model_alias_target"
+	// TrafficRoute 分组灰度 / A-B 分流结果，由网关 handler 设置
+	TrafficRoute Key = "ctx_traffic_route"
 )
@@ -2,19 +2,67 @@
 package ip
 
 import (
+	"fmt"
 	"net"
+	"net/netip"
 	"strings"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 )
 
+// trustedProxies 可信代理网段；为 nil 表示未配置，沿用兼容模式（无条件信任转发头）
+var trustedProxies atomic.Pointer[[]netip.Prefix]
+
+// SetTrustedProxies 配置可信代理（IP 或 CIDR）。
+// 配置后仅当直连对端属于可信代理时才解析转发头，X-Forwarded-For 从右向左跳过可信代理取第一个地址，
+// 避免客户端伪造头部绕过按 IP 的限流与封禁。传入空列表恢复兼容模式。
+func SetTrustedProxies(patterns []string) error {
+	if len(patterns) == 0 {
+		trustedProxies.Store(nil)
+		return nil
+	}
+	prefixes := make([]netip.Prefix, 0, len(patterns))
+	for _, pattern := range patterns {
+		prefix, err := ParsePrefix(pattern)
+		if err != nil {
+			return err
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	trustedProxies.Store(&prefixes)
+	return nil
+}
+
+// ParsePrefix 将单个 IP 或 CIDR 解析为网段（单个 IP 视为 /32 或 /128）。
+func ParsePrefix(pattern string) (netip.Prefix, error) {
+	pattern = strings.TrimSpace(pattern)
+	if strings.Contains(pattern, "/") {
+		prefix, err := netip.ParsePrefix(pattern)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid CIDR %q", pattern)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(pattern)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP %q", pattern)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
 // GetClientIP 从 Gin Context 中提取客户端真实 IP 地址。
-// 按以下优先级检查 Header：
+// 配置了可信代理时按可信链解析（见 SetTrustedProxies），否则按以下优先级检查 Header：
 // 1. CF-Connecting-IP (Cloudflare)
 // 2. X-Real-IP (Nginx)
 // 3. X-Forwarded-For (取第一个非私有 IP)
 // 4. c.ClientIP() (Gin 内置方法)
 func GetClientIP(c *gin.Context) string {
+	if trusted := trustedProxies.Load(); trusted != nil {
+		return clientIPFromTrustedChain(c, *trusted)
+	}
+
 	// 1. Cloudflare
 	if ip := c.GetHeader("CF-Connecting-IP"); ip != "" {
 		return normalizeIP(ip)
@@ -44,6 +92,56 @@ func GetClientIP(c *gin.Context) string {
 	return normalizeIP(c.ClientIP())
 }
 
+// GetTrustedClientIP 提取不可被客户端伪造的 IP，供封禁、黑白名单等安全判定使用。
+// 配置了可信代理时与 GetClientIP 一致；未配置时不采信任何转发头，直接使用直连对端地址。
+func GetTrustedClientIP(c *gin.Context) string {
+	if trusted := trustedProxies.Load(); trusted != nil {
+		return clientIPFromTrustedChain(c, *trusted)
+	}
+	return normalizeIP(c.Request.RemoteAddr)
+}
+
+// clientIPFromTrustedChain 仅在直连对端为可信代理时采信转发头
+func clientIPFromTrustedChain(c *gin.Context, trusted []netip.Prefix) string {
+	peer := normalizeIP(c.Request.RemoteAddr)
+	if !isTrusted(peer, trusted) {
+		return peer
+	}
+	for _, header := range []string{"CF-Connecting-IP", "X-Real-IP"} {
+		if v := normalizeIP(c.GetHeader(header)); v != "" && net.ParseIP(v) != nil {
+			return v
+		}
+	}
+	if xff := c.GetHeader("X-Forwarded-For"); xff != "" {
+		hops := strings.Split(xff, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := normalizeIP(hops[i])
+			if net.ParseIP(hop) == nil {
+				// 无法解析的条目之前的内容都不可信
+				break
+			}
+			if i == 0 || !isTrusted(hop, trusted) {
+				return hop
+			}
+		}
+	}
+	return peer
+}
+
+func isTrusted(ipStr string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ipStr)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 // normalizeIP 规范化 IP 地址，去除端口号和空格。
 func normalizeIP(ip string) string {
 	ip = strings.TrimSpace(ip)
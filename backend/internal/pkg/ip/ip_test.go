@@ -0,0 +1,60 @@
+//go:build unit
+
+package ip
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newIPTestContext(remoteAddr string, headers map[string]string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	c.Request.RemoteAddr = remoteAddr
+	for k, v := range headers {
+		c.Request.Header.Set(k, v)
+	}
+	return c
+}
+
+func TestGetClientIPWithTrustedProxies(t *testing.T) {
+	require.NoError(t, SetTrustedProxies([]string{"10.0.0.0/8", "192.0.2.10"}))
+	t.Cleanup(func() { _ = SetTrustedProxies(nil) })
+
+	// 非可信对端的转发头一律忽略
+	c := newIPTestContext("198.51.100.5:1234", map[string]string{"X-Forwarded-For": "1.1.1.1", "X-Real-IP": "2.2.2.2"})
+	require.Equal(t, "198.51.100.5", GetClientIP(c))
+
+	// 从右向左跳过可信代理，客户端伪造的最左侧条目被忽略
+	c = newIPTestContext("10.0.0.2:443", map[string]string{"X-Forwarded-For": "6.6.6.6, 203.0.113.9, 192.0.2.10"})
+	require.Equal(t, "203.0.113.9", GetClientIP(c))
+
+	c = newIPTestContext("10.0.0.2:443", map[string]string{"X-Real-IP": "203.0.113.1"})
+	require.Equal(t, "203.0.113.1", GetClientIP(c))
+
+	c = newIPTestContext("[2001:db8::1]:443", nil)
+	require.Equal(t, "2001:db8::1", GetClientIP(c))
+
+	require.Error(t, SetTrustedProxies([]string{"bad"}))
+}
+
+func TestGetClientIPLegacyMode(t *testing.T) {
+	c := newIPTestContext("198.51.100.5:1234", map[string]string{"X-Forwarded-For": "10.0.0.1, 203.0.113.9"})
+	require.Equal(t, "203.0.113.9", GetClientIP(c))
+}
+
+func TestGetTrustedClientIPIgnoresHeadersWithoutTrustedProxies(t *testing.T) {
+	c := newIPTestContext("198.51.100.5:1234", map[string]string{
+		"CF-Connecting-IP": "1.1.1.1",
+		"X-Real-IP":        "2.2.2.2",
+		"X-Forwarded-For":  "203.0.113.9",
+	})
+	require.Equal(t, "198.51.100.5", GetTrustedClientIP(c))
+
+	require.NoError(t, SetTrustedProxies([]string{"198.51.100.0/24"}))
+	t.Cleanup(func() { _ = SetTrustedProxies(nil) })
+	require.Equal(t, "1.1.1.1", GetTrustedClientIP(c))
+}
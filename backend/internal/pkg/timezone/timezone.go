@@ -6,6 +6,7 @@ package timezone
 import (
 	"fmt"
 	"log"
+	"sync"
 	"time"
 )
 
@@ -14,6 +15,10 @@ var (
 	location *time.Location
 	// tzName stores the timezone name for logging/debugging
 	tzName string
+
+	// locationCache caches named locations loaded by LoadLocation (hot paths
+	// such as account scheduling must not read tzdata on every call)
+	locationCache sync.Map // map[string]*time.Location
 )
 
 // Init initializes the global timezone setting.
@@ -73,6 +78,23 @@ func Location() *time.Location {
 	return location
 }
 
+// LoadLocation returns the named location, falling back to the configured
+// timezone when name is empty. Loaded locations are cached.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return Location(), nil
+	}
+	if v, ok := locationCache.Load(name); ok {
+		return v.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	locationCache.Store(name, loc)
+	return loc, nil
+}
+
 // Name returns the configured timezone name.
 func Name() string {
 	if tzName == "" {
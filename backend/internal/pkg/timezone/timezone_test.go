@@ -135,3 +135,27 @@ func TestDSTAwareness(t *testing.T) {
 	_ = Now()
 	_ = StartOfDay(Now())
 }
+
+func TestLoadLocation(t *testing.T) {
+	if err := Init("Asia/Shanghai"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	loc, err := LoadLocation("")
+	if err != nil || loc.String() != "Asia/Shanghai" {
+		t.Errorf("LoadLocation(\"\") should return configured timezone, got %v, err %v", loc, err)
+	}
+
+	loc, err = LoadLocation("America/New_York")
+	if err != nil || loc.String() != "America/New_York" {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+	cached, _ := LoadLocation("America/New_York")
+	if cached != loc {
+		t.Error("LoadLocation should return the cached location")
+	}
+
+	if _, err := LoadLocation("Invalid/Timezone"); err == nil {
+		t.Error("LoadLocation should fail with invalid timezone")
+	}
+}
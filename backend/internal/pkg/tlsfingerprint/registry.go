@@ -147,6 +147,17 @@ func (r *Registry) ProfileNames() []string {
 	return names
 }
 
+// ReloadFromConfig rebuilds the registry's profiles from configuration.
+// The built-in default profile is always kept. Used when the config is hot-reloaded.
+func (r *Registry) ReloadFromConfig(cfg *config.TLSFingerprintConfig) {
+	fresh := NewRegistryFromConfig(cfg)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.profiles = fresh.profiles
+	r.profileNames = fresh.profileNames
+}
+
 // Global registry instance for convenience
 var globalRegistry *Registry
 var globalRegistryOnce sync.Once
@@ -144,6 +144,8 @@ type UsageLogFilters struct {
 	BillingType *int8
 	StartTime   *time.Time
 	EndTime     *time.Time
+	// OrganizationID 按组织过滤（组织下全部 Key 的用量）
+	OrganizationID int64
 }
 
 // UsageStats represents usage statistics
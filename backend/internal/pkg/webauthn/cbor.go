@@ -0,0 +1,142 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// 最小化的 CBOR 解码器，仅覆盖 WebAuthn attestationObject / COSE 公钥所需的子集：
+// 整数、字节串、文本串、数组、映射以及 true/false/null。不支持不定长编码与浮点数。
+
+const maxCBORDepth = 16
+
+var errCBORTruncated = errors.New("cbor: unexpected end of data")
+
+// decodeCBOR 解码 data 开头的一个 CBOR 数据项，返回解码值与消耗的字节数。
+// 整数统一解码为 int64，映射解码为 map[any]any（键为 int64 或 string）。
+func decodeCBOR(data []byte) (any, int, error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (any, int, error) {
+	if depth > maxCBORDepth {
+		return nil, 0, errors.New("cbor: nesting too deep")
+	}
+	if len(data) == 0 {
+		return nil, 0, errCBORTruncated
+	}
+
+	major := data[0] >> 5
+	info := data[0] & 0x1f
+	arg, n, err := readCBORArgument(data, info)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch major {
+	case 0: // unsigned int
+		if arg > 1<<63-1 {
+			return nil, 0, errors.New("cbor: integer overflow")
+		}
+		return int64(arg), n, nil
+	case 1: // negative int
+		if arg > 1<<63-1 {
+			return nil, 0, errors.New("cbor: integer overflow")
+		}
+		return -1 - int64(arg), n, nil
+	case 2, 3: // byte / text string
+		if arg > uint64(len(data)-n) {
+			return nil, 0, errCBORTruncated
+		}
+		end := n + int(arg)
+		if major == 2 {
+			out := make([]byte, arg)
+			copy(out, data[n:end])
+			return out, end, nil
+		}
+		return string(data[n:end]), end, nil
+	case 4: // array
+		if arg > uint64(len(data)) {
+			return nil, 0, errCBORTruncated
+		}
+		items := make([]any, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, used, err := decodeCBORItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, item)
+			n += used
+		}
+		return items, n, nil
+	case 5: // map
+		if arg > uint64(len(data)) {
+			return nil, 0, errCBORTruncated
+		}
+		m := make(map[any]any, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, used, err := decodeCBORItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			n += used
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, 0, fmt.Errorf("cbor: unsupported map key type %T", key)
+			}
+			value, used, err := decodeCBORItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			n += used
+			m[key] = value
+		}
+		return m, n, nil
+	case 6: // tag：忽略标签，直接返回被标记的数据项
+		item, used, err := decodeCBORItem(data[n:], depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		return item, n + used, nil
+	default: // 7: simple values
+		switch info {
+		case 20:
+			return false, n, nil
+		case 21:
+			return true, n, nil
+		case 22, 23:
+			return nil, n, nil
+		}
+		return nil, 0, fmt.Errorf("cbor: unsupported simple value %d", info)
+	}
+}
+
+func readCBORArgument(data []byte, info byte) (uint64, int, error) {
+	switch {
+	case info < 24:
+		return uint64(info), 1, nil
+	case info == 24:
+		if len(data) < 2 {
+			return 0, 0, errCBORTruncated
+		}
+		return uint64(data[1]), 2, nil
+	case info == 25:
+		if len(data) < 3 {
+			return 0, 0, errCBORTruncated
+		}
+		return uint64(binary.BigEndian.Uint16(data[1:3])), 3, nil
+	case info == 26:
+		if len(data) < 5 {
+			return 0, 0, errCBORTruncated
+		}
+		return uint64(binary.BigEndian.Uint32(data[1:5])), 5, nil
+	case info == 27:
+		if len(data) < 9 {
+			return 0, 0, errCBORTruncated
+		}
+		return binary.BigEndian.Uint64(data[1:9]), 9, nil
+	}
+	return 0, 0, fmt.Errorf("cbor: unsupported additional info %d", info)
+}
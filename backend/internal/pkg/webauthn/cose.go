@@ -0,0 +1,118 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE 算法标识（RFC 8152 / IANA COSE Algorithms）
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// SupportedAlgorithms 返回注册时向浏览器声明的公钥算法，按偏好排序
+func SupportedAlgorithms() []int64 {
+	return []int64{AlgES256, AlgEdDSA, AlgRS256}
+}
+
+const (
+	coseKeyTypeOKP int64 = 1
+	coseKeyTypeEC2 int64 = 2
+	coseKeyTypeRSA int64 = 3
+
+	coseCurveP256    int64 = 1
+	coseCurveEd25519 int64 = 6
+)
+
+var ErrUnsupportedKey = errors.New("webauthn: unsupported public key")
+
+// coseKey 是解析后的 COSE_Key，只保留验签所需的部分
+type coseKey struct {
+	alg    int64
+	public crypto.PublicKey
+}
+
+func parseCOSEKey(raw []byte) (*coseKey, error) {
+	value, n, err := decodeCBOR(raw)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: decode public key: %w", err)
+	}
+	if n != len(raw) {
+		return nil, errors.New("webauthn: trailing data after public key")
+	}
+	m, ok := value.(map[any]any)
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+
+	kty, _ := m[int64(1)].(int64)
+	alg, _ := m[int64(3)].(int64)
+	switch kty {
+	case coseKeyTypeEC2:
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		y, _ := m[int64(-3)].([]byte)
+		if alg != AlgES256 || crv != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, ErrUnsupportedKey
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errors.New("webauthn: public key point is not on curve")
+		}
+		return &coseKey{alg: alg, public: pub}, nil
+	case coseKeyTypeOKP:
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		if alg != AlgEdDSA || crv != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, ErrUnsupportedKey
+		}
+		return &coseKey{alg: alg, public: ed25519.PublicKey(x)}, nil
+	case coseKeyTypeRSA:
+		nBytes, _ := m[int64(-1)].([]byte)
+		eBytes, _ := m[int64(-2)].([]byte)
+		if alg != AlgRS256 || len(nBytes) < 256 || len(eBytes) == 0 || len(eBytes) > 4 {
+			return nil, ErrUnsupportedKey
+		}
+		e := 0
+		for _, b := range eBytes {
+			e = e<<8 | int(b)
+		}
+		return &coseKey{alg: alg, public: &rsa.PublicKey{N: new(big.Int).SetBytes(nBytes), E: e}}, nil
+	}
+	return nil, ErrUnsupportedKey
+}
+
+// VerifySignature 使用 COSE 编码的公钥校验 data 上的签名
+func VerifySignature(publicKey, data, sig []byte) error {
+	key, err := parseCOSEKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	switch pub := key.public.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if ecdsa.VerifyASN1(pub, digest[:], sig) {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if ed25519.Verify(pub, data, sig) {
+			return nil
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
@@ -0,0 +1,268 @@
+// Package webauthn 实现 WebAuthn（passkey）注册与断言的服务端校验。
+//
+// 仅支持 "none" 证明策略：不校验 attestation statement，也不信任 AAGUID 声明的设备型号，
+// 只依赖 RP ID、origin、challenge 与签名来确认凭证的归属。
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// clientDataJSON 中的 type 取值
+const (
+	ClientDataTypeCreate = "webauthn.create"
+	ClientDataTypeGet    = "webauthn.get"
+)
+
+// authenticatorData flags
+const (
+	FlagUserPresent            byte = 0x01
+	FlagUserVerified           byte = 0x04
+	FlagBackupEligible         byte = 0x08
+	FlagBackupState            byte = 0x10
+	FlagAttestedCredentialData byte = 0x40
+	FlagExtensionData          byte = 0x80
+)
+
+const (
+	authDataMinLength = 37
+	aaguidLength      = 16
+	maxCredentialID   = 1023
+)
+
+var (
+	ErrInvalidClientData     = errors.New("webauthn: invalid client data")
+	ErrChallengeMismatch     = errors.New("webauthn: challenge mismatch")
+	ErrOriginMismatch        = errors.New("webauthn: origin not allowed")
+	ErrRPIDMismatch          = errors.New("webauthn: rp id hash mismatch")
+	ErrUserNotPresent        = errors.New("webauthn: user presence flag not set")
+	ErrUserNotVerified       = errors.New("webauthn: user verification required")
+	ErrInvalidAuthData       = errors.New("webauthn: invalid authenticator data")
+	ErrInvalidAttestation    = errors.New("webauthn: invalid attestation object")
+	ErrMissingCredentialData = errors.New("webauthn: attested credential data missing")
+	ErrInvalidSignature      = errors.New("webauthn: invalid signature")
+)
+
+// ClientData 是浏览器生成的 clientDataJSON
+type ClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// AuthenticatorData 是解析后的 authenticatorData
+type AuthenticatorData struct {
+	RPIDHash  []byte
+	Flags     byte
+	SignCount uint32
+
+	// 以下字段仅在注册（AT flag 置位）时存在
+	AAGUID       []byte
+	CredentialID []byte
+	PublicKey    []byte // COSE_Key 原始编码
+}
+
+func (a *AuthenticatorData) UserPresent() bool  { return a.Flags&FlagUserPresent != 0 }
+func (a *AuthenticatorData) UserVerified() bool { return a.Flags&FlagUserVerified != 0 }
+
+// Expectation 描述一次仪式（ceremony）中服务端期望的参数
+type Expectation struct {
+	Challenge               []byte
+	RPID                    string
+	Origins                 []string
+	RequireUserVerification bool
+}
+
+// Credential 是注册成功后需要持久化的凭证信息
+type Credential struct {
+	ID             []byte
+	PublicKey      []byte
+	SignCount      uint32
+	AAGUID         []byte
+	UserVerified   bool
+	BackupEligible bool
+}
+
+// ParseClientData 解析 clientDataJSON
+func ParseClientData(raw []byte) (*ClientData, error) {
+	var cd ClientData
+	if err := json.Unmarshal(raw, &cd); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidClientData, err)
+	}
+	return &cd, nil
+}
+
+// ParseAuthenticatorData 解析 authenticatorData 二进制结构
+func ParseAuthenticatorData(raw []byte) (*AuthenticatorData, error) {
+	if len(raw) < authDataMinLength {
+		return nil, ErrInvalidAuthData
+	}
+	ad := &AuthenticatorData{
+		RPIDHash:  raw[:32],
+		Flags:     raw[32],
+		SignCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	rest := raw[authDataMinLength:]
+
+	if ad.Flags&FlagAttestedCredentialData != 0 {
+		if len(rest) < aaguidLength+2 {
+			return nil, ErrInvalidAuthData
+		}
+		ad.AAGUID = rest[:aaguidLength]
+		idLen := int(binary.BigEndian.Uint16(rest[aaguidLength : aaguidLength+2]))
+		rest = rest[aaguidLength+2:]
+		if idLen == 0 || idLen > maxCredentialID || len(rest) < idLen {
+			return nil, ErrInvalidAuthData
+		}
+		ad.CredentialID = rest[:idLen]
+		rest = rest[idLen:]
+
+		// 公钥是一个 CBOR 数据项，后面可能紧跟扩展数据，需要按实际消耗长度切分
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAuthData, err)
+		}
+		ad.PublicKey = rest[:n]
+		rest = rest[n:]
+	}
+
+	if ad.Flags&FlagExtensionData != 0 {
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAuthData, err)
+		}
+		rest = rest[n:]
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%w: trailing data", ErrInvalidAuthData)
+	}
+	return ad, nil
+}
+
+// VerifyRegistration 校验 navigator.credentials.create() 的结果并返回新凭证
+func VerifyRegistration(exp Expectation, clientDataJSON, attestationObject []byte) (*Credential, error) {
+	if err := verifyClientData(exp, ClientDataTypeCreate, clientDataJSON); err != nil {
+		return nil, err
+	}
+
+	value, n, err := decodeCBOR(attestationObject)
+	if err != nil || n != len(attestationObject) {
+		return nil, ErrInvalidAttestation
+	}
+	obj, ok := value.(map[any]any)
+	if !ok {
+		return nil, ErrInvalidAttestation
+	}
+	rawAuthData, ok := obj["authData"].([]byte)
+	if !ok {
+		return nil, ErrInvalidAttestation
+	}
+
+	authData, err := ParseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyAuthData(exp, authData); err != nil {
+		return nil, err
+	}
+	if authData.Flags&FlagAttestedCredentialData == 0 || len(authData.PublicKey) == 0 {
+		return nil, ErrMissingCredentialData
+	}
+	// 提前解析公钥，拒绝不支持的算法，避免保存一个之后无法用于登录的凭证
+	if _, err := parseCOSEKey(authData.PublicKey); err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:             bytes.Clone(authData.CredentialID),
+		PublicKey:      bytes.Clone(authData.PublicKey),
+		SignCount:      authData.SignCount,
+		AAGUID:         bytes.Clone(authData.AAGUID),
+		UserVerified:   authData.UserVerified(),
+		BackupEligible: authData.Flags&FlagBackupEligible != 0,
+	}, nil
+}
+
+// VerifyAssertion 校验 navigator.credentials.get() 的结果，返回解析后的 authenticatorData
+// 以便调用方检查签名计数器。
+func VerifyAssertion(exp Expectation, publicKey, clientDataJSON, rawAuthData, signature []byte) (*AuthenticatorData, error) {
+	if err := verifyClientData(exp, ClientDataTypeGet, clientDataJSON); err != nil {
+		return nil, err
+	}
+
+	authData, err := ParseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyAuthData(exp, authData); err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := make([]byte, 0, len(rawAuthData)+len(clientDataHash))
+	signed = append(signed, rawAuthData...)
+	signed = append(signed, clientDataHash[:]...)
+	if err := VerifySignature(publicKey, signed, signature); err != nil {
+		return nil, err
+	}
+	return authData, nil
+}
+
+func verifyClientData(exp Expectation, expectedType string, raw []byte) error {
+	cd, err := ParseClientData(raw)
+	if err != nil {
+		return err
+	}
+	if cd.Type != expectedType {
+		return fmt.Errorf("%w: unexpected type %q", ErrInvalidClientData, cd.Type)
+	}
+
+	challenge, err := DecodeBase64URL(cd.Challenge)
+	if err != nil || len(exp.Challenge) == 0 || subtle.ConstantTimeCompare(challenge, exp.Challenge) != 1 {
+		return ErrChallengeMismatch
+	}
+
+	origin := strings.TrimRight(cd.Origin, "/")
+	for _, allowed := range exp.Origins {
+		if strings.EqualFold(origin, strings.TrimRight(allowed, "/")) {
+			return nil
+		}
+	}
+	return ErrOriginMismatch
+}
+
+func verifyAuthData(exp Expectation, authData *AuthenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(exp.RPID))
+	if subtle.ConstantTimeCompare(authData.RPIDHash, rpIDHash[:]) != 1 {
+		return ErrRPIDMismatch
+	}
+	if !authData.UserPresent() {
+		return ErrUserNotPresent
+	}
+	if exp.RequireUserVerification && !authData.UserVerified() {
+		return ErrUserNotVerified
+	}
+	return nil
+}
+
+// EncodeBase64URL 以无填充的 base64url 编码二进制数据（WebAuthn JSON 约定）
+func EncodeBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeBase64URL 解码 base64url，兼容带填充及标准 base64 字母表的输入
+func DecodeBase64URL(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,165 @@
+//go:build unit
+
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 测试用的最小 CBOR 编码器，只覆盖本包解码器支持的类型
+
+func cborHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n < 1<<8:
+		return []byte{major<<5 | 24, byte(n)}
+	case n < 1<<16:
+		b := []byte{major<<5 | 25, 0, 0}
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		return b
+	default:
+		b := []byte{major<<5 | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		return b
+	}
+}
+
+func cborEncode(v any) []byte {
+	switch x := v.(type) {
+	case int:
+		if x >= 0 {
+			return cborHead(0, uint64(x))
+		}
+		return cborHead(1, uint64(-1-x))
+	case []byte:
+		return append(cborHead(2, uint64(len(x))), x...)
+	case string:
+		return append(cborHead(3, uint64(len(x))), x...)
+	case [][2]any: // 有序 map
+		out := cborHead(5, uint64(len(x)))
+		for _, kv := range x {
+			out = append(out, cborEncode(kv[0])...)
+			out = append(out, cborEncode(kv[1])...)
+		}
+		return out
+	}
+	panic("unsupported")
+}
+
+func es256COSEKey(pub *ecdsa.PublicKey) []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	pub.X.FillBytes(x)
+	pub.Y.FillBytes(y)
+	return cborEncode([][2]any{{1, 2}, {3, -7}, {-1, 1}, {-2, x}, {-3, y}})
+}
+
+func buildAuthData(rpID string, flags byte, signCount uint32, credID, coseKey []byte) []byte {
+	rpHash := sha256.Sum256([]byte(rpID))
+	out := append([]byte{}, rpHash[:]...)
+	out = append(out, flags)
+	out = binary.BigEndian.AppendUint32(out, signCount)
+	if credID != nil {
+		out = append(out, make([]byte, aaguidLength)...)
+		out = binary.BigEndian.AppendUint16(out, uint16(len(credID)))
+		out = append(out, credID...)
+		out = append(out, coseKey...)
+	}
+	return out
+}
+
+func clientDataJSON(t *testing.T, typ string, challenge []byte, origin string) []byte {
+	raw, err := json.Marshal(ClientData{Type: typ, Challenge: EncodeBase64URL(challenge), Origin: origin})
+	require.NoError(t, err)
+	return raw
+}
+
+func TestVerifyRegistrationAndAssertion_ES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	credID := []byte("credential-1")
+	exp := Expectation{Challenge: []byte("register-challenge"), RPID: "example.com", Origins: []string{"https://example.com"}}
+
+	authData := buildAuthData("example.com", FlagUserPresent|FlagUserVerified|FlagAttestedCredentialData, 0, credID, es256COSEKey(&key.PublicKey))
+	attObj := cborEncode([][2]any{{"fmt", "none"}, {"attStmt", [][2]any{}}, {"authData", authData}})
+
+	cred, err := VerifyRegistration(exp, clientDataJSON(t, ClientDataTypeCreate, exp.Challenge, "https://example.com"), attObj)
+	require.NoError(t, err)
+	require.Equal(t, credID, cred.ID)
+	require.True(t, cred.UserVerified)
+
+	// 断言：对 authData || sha256(clientData) 签名
+	loginExp := Expectation{Challenge: []byte("login-challenge"), RPID: "example.com", Origins: []string{"https://example.com/"}, RequireUserVerification: true}
+	cd := clientDataJSON(t, ClientDataTypeGet, loginExp.Challenge, "https://example.com")
+	assertData := buildAuthData("example.com", FlagUserPresent|FlagUserVerified, 7, nil, nil)
+	cdHash := sha256.Sum256(cd)
+	digest := sha256.Sum256(append(append([]byte{}, assertData...), cdHash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	parsed, err := VerifyAssertion(loginExp, cred.PublicKey, cd, assertData, sig)
+	require.NoError(t, err)
+	require.Equal(t, uint32(7), parsed.SignCount)
+
+	sig[len(sig)-1] ^= 0xff
+	_, err = VerifyAssertion(loginExp, cred.PublicKey, cd, assertData, sig)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestVerifyAssertion_RejectsWrongContext(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	coseKey := cborEncode([][2]any{{1, 1}, {3, -8}, {-1, 6}, {-2, []byte(pub)}})
+	exp := Expectation{Challenge: []byte("c"), RPID: "example.com", Origins: []string{"https://example.com"}, RequireUserVerification: true}
+
+	sign := func(authData, cd []byte) []byte {
+		h := sha256.Sum256(cd)
+		return ed25519.Sign(priv, append(append([]byte{}, authData...), h[:]...))
+	}
+
+	good := buildAuthData("example.com", FlagUserPresent|FlagUserVerified, 1, nil, nil)
+	cd := clientDataJSON(t, ClientDataTypeGet, exp.Challenge, "https://example.com")
+	_, err = VerifyAssertion(exp, coseKey, cd, good, sign(good, cd))
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		authData []byte
+		cd       []byte
+		want     error
+	}{
+		{"wrong type", good, clientDataJSON(t, ClientDataTypeCreate, exp.Challenge, "https://example.com"), ErrInvalidClientData},
+		{"wrong challenge", good, clientDataJSON(t, ClientDataTypeGet, []byte("other"), "https://example.com"), ErrChallengeMismatch},
+		{"wrong origin", good, clientDataJSON(t, ClientDataTypeGet, exp.Challenge, "https://evil.com"), ErrOriginMismatch},
+		{"wrong rp id", buildAuthData("evil.com", FlagUserPresent|FlagUserVerified, 1, nil, nil), cd, ErrRPIDMismatch},
+		{"no user verification", buildAuthData("example.com", FlagUserPresent, 1, nil, nil), cd, ErrUserNotVerified},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := VerifyAssertion(exp, coseKey, tc.cd, tc.authData, sign(tc.authData, tc.cd))
+			require.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestParseAuthenticatorData_RejectsMalformed(t *testing.T) {
+	_, err := ParseAuthenticatorData(make([]byte, 10))
+	require.ErrorIs(t, err, ErrInvalidAuthData)
+
+	withTrailing := append(buildAuthData("example.com", FlagUserPresent, 0, nil, nil), 0x01)
+	_, err = ParseAuthenticatorData(withTrailing)
+	require.ErrorIs(t, err, ErrInvalidAuthData)
+
+	_, _, err = decodeCBOR([]byte{0x5a, 0xff, 0xff, 0xff, 0xff})
+	require.Error(t, err)
+}
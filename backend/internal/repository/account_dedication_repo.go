@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/apikey"
+	"github.com/Wei-Shaw/sub2api/ent/user"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type accountDedicationRepository struct {
+	client *dbent.Client
+}
+
+func NewAccountDedicationRepository(client *dbent.Client) service.AccountDedicationRepository {
+	return &accountDedicationRepository{client: client}
+}
+
+func (r *accountDedicationRepository) ListDedications(ctx context.Context) ([]service.AccountDedication, error) {
+	users, err := r.client.User.Query().
+		Where(user.DeletedAtIsNil(), user.DedicatedAccountIdsNotNil()).
+		Select(user.FieldID, user.FieldEmail, user.FieldDedicatedAccountIds, user.FieldDedicatedAccountsOnly).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := r.client.APIKey.Query().
+		Where(apikey.DeletedAtIsNil(), apikey.DedicatedAccountIdsNotNil()).
+		Select(apikey.FieldID, apikey.FieldUserID, apikey.FieldName, apikey.FieldDedicatedAccountIds, apikey.FieldDedicatedAccountsOnly).
+		WithUser(func(q *dbent.UserQuery) {
+			q.Select(user.FieldID, user.FieldEmail)
+		}).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]service.AccountDedication, 0, len(users)+len(keys))
+	for _, u := range users {
+		if len(u.DedicatedAccountIds) == 0 {
+			continue
+		}
+		out = append(out, service.AccountDedication{
+			UserID:     u.ID,
+			UserEmail:  u.Email,
+			AccountIDs: u.DedicatedAccountIds,
+			Exclusive:  u.DedicatedAccountsOnly,
+		})
+	}
+	for _, k := range keys {
+		if len(k.DedicatedAccountIds) == 0 {
+			continue
+		}
+		keyID := k.ID
+		item := service.AccountDedication{
+			UserID:     k.UserID,
+			APIKeyID:   &keyID,
+			APIKeyName: k.Name,
+			AccountIDs: k.DedicatedAccountIds,
+			Exclusive:  k.DedicatedAccountsOnly,
+		}
+		if k.Edges.User != nil {
+			item.UserEmail = k.Edges.User.Email
+		}
+		out = append(out, item)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].UserID != out[j].UserID {
+			return out[i].UserID < out[j].UserID
+		}
+		// 用户级配置排在该用户的 API Key 配置之前
+		if (out[i].APIKeyID == nil) != (out[j].APIKeyID == nil) {
+			return out[i].APIKeyID == nil
+		}
+		return out[i].APIKeyID != nil && *out[i].APIKeyID < *out[j].APIKeyID
+	})
+	return out, nil
+}
+
+func (r *accountDedicationRepository) SetUserDedicatedAccounts(ctx context.Context, userID int64, accountIDs []int64, exclusive bool) error {
+	builder := r.client.User.Update().
+		Where(user.IDEQ(userID), user.DeletedAtIsNil()).
+		SetDedicatedAccountsOnly(exclusive)
+	if len(accountIDs) > 0 {
+		builder.SetDedicatedAccountIds(accountIDs)
+	} else {
+		builder.ClearDedicatedAccountIds()
+	}
+	affected, err := builder.Save(ctx)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return service.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *accountDedicationRepository) SetAPIKeyDedicatedAccounts(ctx context.Context, apiKeyID int64, accountIDs []int64, exclusive bool) error {
+	builder := r.client.APIKey.Update().
+		Where(apikey.IDEQ(apiKeyID), apikey.DeletedAtIsNil()).
+		SetDedicatedAccountsOnly(exclusive)
+	if len(accountIDs) > 0 {
+		builder.SetDedicatedAccountIds(accountIDs)
+	} else {
+		builder.ClearDedicatedAccountIds()
+	}
+	affected, err := builder.Save(ctx)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return service.ErrAPIKeyNotFound
+	}
+	return nil
+}
@@ -48,6 +48,8 @@ type accountRepository struct {
 	// Used to proactively sync account snapshot to cache when status changes,
 	// ensuring sticky sessions can promptly detect unavailable accounts.
 	schedulerCache service.SchedulerCache
+	// cipher 凭证敏感字段的信封加密（nil 表示不加密，用于测试）
+	cipher service.SecretCipher
 }
 
 type tempUnschedSnapshot struct {
@@ -57,8 +59,10 @@ type tempUnschedSnapshot struct {
 
 // NewAccountRepository 创建账户仓储实例。
 // 这是对外暴露的构造函数，返回接口类型以便于依赖注入。
-func NewAccountRepository(client *dbent.Client, sqlDB *sql.DB, schedulerCache service.SchedulerCache) service.AccountRepository {
-	return newAccountRepositoryWithSQL(client, sqlDB, schedulerCache)
+func NewAccountRepository(client *dbent.Client, sqlDB *sql.DB, schedulerCache service.SchedulerCache, cipher service.SecretCipher) service.AccountRepository {
+	repo := newAccountRepositoryWithSQL(client, sqlDB, schedulerCache)
+	repo.cipher = cipher
+	return repo
 }
 
 // newAccountRepositoryWithSQL 是内部构造函数，支持依赖注入 SQL 执行器。
@@ -72,12 +76,17 @@ func (r *accountRepository) Create(ctx context.Context, account *service.Account
 		return service.ErrAccountNilInput
 	}
 
+	credentials, err := service.EncryptCredentialSecrets(r.cipher, normalizeJSONMap(account.Credentials))
+	if err != nil {
+		return err
+	}
+
 	builder := r.client.Account.Create().
 		SetName(account.Name).
 		SetNillableNotes(account.Notes).
 		SetPlatform(account.Platform).
 		SetType(account.Type).
-		SetCredentials(normalizeJSONMap(account.Credentials)).
+		SetCredentials(credentials).
 		SetExtra(normalizeJSONMap(account.Extra)).
 		SetConcurrency(account.Concurrency).
 		SetPriority(account.Priority).
@@ -205,10 +214,12 @@ func (r *accountRepository) GetByIDs(ctx context.Context, ids []int64) ([]*servi
 		if out == nil {
 			continue
 		}
+		decryptAccountSecrets(r.cipher, out)
 
 		// Prefer the preloaded proxy edge when available.
 		if entAcc.Edges.Proxy != nil {
 			out.Proxy = proxyEntityToService(entAcc.Edges.Proxy)
+			decryptProxySecret(r.cipher, out.Proxy)
 		}
 
 		if groups, ok := groupsByAccount[entAcc.ID]; ok {
@@ -287,12 +298,17 @@ func (r *accountRepository) Update(ctx context.Context, account *service.Account
 		return nil
 	}
 
+	credentials, err := service.EncryptCredentialSecrets(r.cipher, normalizeJSONMap(account.Credentials))
+	if err != nil {
+		return err
+	}
+
 	builder := r.client.Account.UpdateOneID(account.ID).
 		SetName(account.Name).
 		SetNillableNotes(account.Notes).
 		SetPlatform(account.Platform).
 		SetType(account.Type).
-		SetCredentials(normalizeJSONMap(account.Credentials)).
+		SetCredentials(credentials).
 		SetExtra(normalizeJSONMap(account.Extra)).
 		SetConcurrency(account.Concurrency).
 		SetPriority(account.Priority).
@@ -835,15 +851,18 @@ func (r *accountRepository) SetAntigravityQuotaScopeLimit(ctx context.Context, i
 	return nil
 }
 
-func (r *accountRepository) SetModelRateLimit(ctx context.Context, id int64, scope string, resetAt time.Time) error {
+func (r *accountRepository) SetModelRateLimit(ctx context.Context, id int64, scope string, models []string, resetAt time.Time) error {
 	if scope == "" {
 		return nil
 	}
 	now := time.Now().UTC()
-	payload := map[string]string{
+	payload := map[string]any{
 		"rate_limited_at":     now.Format(time.RFC3339),
 		"rate_limit_reset_at": resetAt.UTC().Format(time.RFC3339),
 	}
+	if len(models) > 0 {
+		payload["models"] = models
+	}
 	raw, err := json.Marshal(payload)
 	if err != nil {
 		return err
@@ -1142,7 +1161,11 @@ func (r *accountRepository) BulkUpdate(ctx context.Context, ids []int64, updates
 	}
 	// JSONB 需要合并而非覆盖，使用 raw SQL 保持旧行为。
 	if len(updates.Credentials) > 0 {
-		payload, err := json.Marshal(updates.Credentials)
+		credentials, err := service.EncryptCredentialSecrets(r.cipher, updates.Credentials)
+		if err != nil {
+			return 0, err
+		}
+		payload, err := json.Marshal(credentials)
 		if err != nil {
 			return 0, err
 		}
@@ -1299,6 +1322,7 @@ func (r *accountRepository) accountsToService(ctx context.Context, accounts []*d
 		if out == nil {
 			continue
 		}
+		decryptAccountSecrets(r.cipher, out)
 		if acc.ProxyID != nil {
 			if proxy, ok := proxyMap[*acc.ProxyID]; ok {
 				out.Proxy = proxy
@@ -1396,6 +1420,7 @@ func (r *accountRepository) loadProxies(ctx context.Context, proxyIDs []int64) (
 
 	for _, p := range proxies {
 		proxyMap[p.ID] = proxyEntityToService(p)
+		decryptProxySecret(r.cipher, proxyMap[p.ID])
 	}
 	return proxyMap, nil
 }
@@ -1492,7 +1517,7 @@ func accountEntityToService(m *dbent.Account) *service.Account {
 
 	rateMultiplier := m.RateMultiplier
 
-	return &service.Account{
+	account := &service.Account{
 		ID:                  m.ID,
 		Name:                m.Name,
 		Notes:               m.Notes,
@@ -1519,6 +1544,8 @@ func accountEntityToService(m *dbent.Account) *service.Account {
 		SessionWindowEnd:    m.SessionWindowEnd,
 		SessionWindowStatus: derefString(m.SessionWindowStatus),
 	}
+	account.CompileAvailabilitySchedule()
+	return account
 }
 
 func normalizeJSONMap(in map[string]any) map[string]any {
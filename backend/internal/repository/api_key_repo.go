@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"log"
 	"time"
 
 	dbent "github.com/Wei-Shaw/sub2api/ent"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
 	"github.com/Wei-Shaw/sub2api/ent/group"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
 	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
 	"github.com/Wei-Shaw/sub2api/ent/user"
 	"github.com/Wei-Shaw/sub2api/internal/service"
@@ -30,11 +33,18 @@ func (r *apiKeyRepository) activeQuery() *dbent.APIKeyQuery {
 func (r *apiKeyRepository) Create(ctx context.Context, key *service.APIKey) error {
 	builder := r.client.APIKey.Create().
 		SetUserID(key.UserID).
-		SetKey(key.Key).
+		SetKeyPrefix(key.KeyPrefix).
 		SetName(key.Name).
 		SetStatus(key.Status).
-		SetNillableGroupID(key.GroupID)
+		SetNillableGroupID(key.GroupID).
+		SetNillableOrganizationID(key.OrganizationID)
 
+	// 只持久化哈希；未提供哈希时按旧格式写入明文，交由在线迁移处理
+	if key.KeyHash != "" {
+		builder.SetKeyHash(key.KeyHash)
+	} else {
+		builder.SetKey(key.Key)
+	}
 	if len(key.IPWhitelist) > 0 {
 		builder.SetIPWhitelist(key.IPWhitelist)
 	}
@@ -66,43 +76,29 @@ func (r *apiKeyRepository) GetByID(ctx context.Context, id int64) (*service.APIK
 	return apiKeyEntityToService(m), nil
 }
 
-// GetKeyAndOwnerID 根据 API Key ID 获取其 key 与所有者（用户）ID。
+// GetCredentialAndOwnerID 根据 API Key ID 获取其缓存凭据与所有者（用户）ID。
 // 相比 GetByID，此方法性能更优，因为：
 //   - 使用 Select() 只查询必要字段，减少数据传输量
 //   - 不加载完整的 API Key 实体及其关联数据（User、Group 等）
-//   - 适用于删除等只需 key 与用户 ID 的场景
-func (r *apiKeyRepository) GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
+//   - 适用于删除等只需凭据与用户 ID 的场景
+func (r *apiKeyRepository) GetCredentialAndOwnerID(ctx context.Context, id int64) (service.APIKeyCredential, int64, error) {
 	m, err := r.activeQuery().
 		Where(apikey.IDEQ(id)).
-		Select(apikey.FieldKey, apikey.FieldUserID).
-		Only(ctx)
-	if err != nil {
-		if dbent.IsNotFound(err) {
-			return "", 0, service.ErrAPIKeyNotFound
-		}
-		return "", 0, err
-	}
-	return m.Key, m.UserID, nil
-}
-
-func (r *apiKeyRepository) GetByKey(ctx context.Context, key string) (*service.APIKey, error) {
-	m, err := r.activeQuery().
-		Where(apikey.KeyEQ(key)).
-		WithUser().
-		WithGroup().
+		Select(apikey.FieldID, apikey.FieldKey, apikey.FieldKeyHash, apikey.FieldUserID).
 		Only(ctx)
 	if err != nil {
 		if dbent.IsNotFound(err) {
-			return nil, service.ErrAPIKeyNotFound
+			return service.APIKeyCredential{}, 0, service.ErrAPIKeyNotFound
 		}
-		return nil, err
+		return service.APIKeyCredential{}, 0, err
 	}
-	return apiKeyEntityToService(m), nil
+	return apiKeyCredentialFromEntity(m), m.UserID, nil
 }
 
-func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*service.APIKey, error) {
+// GetByKeyForAuth 按哈希查找 Key；在线迁移完成前的旧记录仍按明文匹配，两者合并为一次查询
+func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, keyHash, key string) (*service.APIKey, error) {
 	m, err := r.activeQuery().
-		Where(apikey.KeyEQ(key)).
+		Where(apikey.Or(apikey.KeyHashEQ(keyHash), apikey.KeyEQ(key))).
 		Select(
 			apikey.FieldID,
 			apikey.FieldUserID,
@@ -110,6 +106,9 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 			apikey.FieldStatus,
 			apikey.FieldIPWhitelist,
 			apikey.FieldIPBlacklist,
+			apikey.FieldDedicatedAccountIds,
+			apikey.FieldDedicatedAccountsOnly,
+			apikey.FieldOrganizationID,
 		).
 		WithUser(func(q *dbent.UserQuery) {
 			q.Select(
@@ -118,6 +117,8 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 				user.FieldRole,
 				user.FieldBalance,
 				user.FieldConcurrency,
+				user.FieldDedicatedAccountIds,
+				user.FieldDedicatedAccountsOnly,
 			)
 		}).
 		WithGroup(func(q *dbent.GroupQuery) {
@@ -137,7 +138,24 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 				group.FieldClaudeCodeOnly,
 				group.FieldFallbackGroupID,
 				group.FieldModelRoutingEnabled,
+				group.FieldSchedulingStrategy,
 				group.FieldModelRouting,
+				group.FieldContentPolicy,
+				group.FieldRewriteRules,
+				group.FieldModelAliases,
+				group.FieldOveragePolicy,
+				group.FieldOverageRateMultiplier,
+				group.FieldOverageGroupID,
+				group.FieldUsageQuotas,
+				group.FieldTrafficSplit,
+			)
+		}).
+		WithOrganization(func(q *dbent.OrganizationQuery) {
+			q.Select(
+				organization.FieldID,
+				organization.FieldOwnerUserID,
+				organization.FieldStatus,
+				organization.FieldBalance,
 			)
 		}).
 		Only(ctx)
@@ -266,8 +284,10 @@ func (r *apiKeyRepository) CountByUserID(ctx context.Context, userID int64) (int
 	return int64(count), err
 }
 
-func (r *apiKeyRepository) ExistsByKey(ctx context.Context, key string) (bool, error) {
-	count, err := r.activeQuery().Where(apikey.KeyEQ(key)).Count(ctx)
+func (r *apiKeyRepository) ExistsByKey(ctx context.Context, keyHash, key string) (bool, error) {
+	count, err := r.activeQuery().
+		Where(apikey.Or(apikey.KeyHashEQ(keyHash), apikey.KeyEQ(key))).
+		Count(ctx)
 	return count > 0, err
 }
 
@@ -335,26 +355,65 @@ func (r *apiKeyRepository) CountByGroupID(ctx context.Context, groupID int64) (i
 	return int64(count), err
 }
 
-func (r *apiKeyRepository) ListKeysByUserID(ctx context.Context, userID int64) ([]string, error) {
-	keys, err := r.activeQuery().
-		Where(apikey.UserIDEQ(userID)).
-		Select(apikey.FieldKey).
-		Strings(ctx)
+func (r *apiKeyRepository) ListCredentialsByUserID(ctx context.Context, userID int64) ([]service.APIKeyCredential, error) {
+	return r.listCredentials(ctx, r.activeQuery().Where(apikey.UserIDEQ(userID)))
+}
+
+func (r *apiKeyRepository) ListCredentialsByGroupID(ctx context.Context, groupID int64) ([]service.APIKeyCredential, error) {
+	return r.listCredentials(ctx, r.activeQuery().Where(apikey.GroupIDEQ(groupID)))
+}
+
+func (r *apiKeyRepository) listCredentials(ctx context.Context, q *dbent.APIKeyQuery) ([]service.APIKeyCredential, error) {
+	keys, err := q.
+		Select(apikey.FieldID, apikey.FieldKey, apikey.FieldKeyHash).
+		All(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return keys, nil
+	out := make([]service.APIKeyCredential, 0, len(keys))
+	for _, m := range keys {
+		out = append(out, apiKeyCredentialFromEntity(m))
+	}
+	return out, nil
 }
 
-func (r *apiKeyRepository) ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error) {
-	keys, err := r.activeQuery().
-		Where(apikey.GroupIDEQ(groupID)).
-		Select(apikey.FieldKey).
-		Strings(ctx)
+// ListLegacyKeys 列出仍以明文存储的 Key；软删除记录同样需要清除明文
+func (r *apiKeyRepository) ListLegacyKeys(ctx context.Context, afterID int64, limit int) ([]service.APIKeyCredential, error) {
+	keys, err := r.client.APIKey.Query().
+		Where(apikey.IDGT(afterID), apikey.KeyNotNil(), apikey.KeyHashIsNil()).
+		Select(apikey.FieldID, apikey.FieldKey).
+		Order(dbent.Asc(apikey.FieldID)).
+		Limit(limit).
+		All(mixins.SkipSoftDelete(ctx))
 	if err != nil {
 		return nil, err
 	}
-	return keys, nil
+	out := make([]service.APIKeyCredential, 0, len(keys))
+	for _, m := range keys {
+		out = append(out, apiKeyCredentialFromEntity(m))
+	}
+	return out, nil
+}
+
+// MigrateKeyHash 以原明文作为条件更新，避免与并发的迁移实例互相覆盖
+func (r *apiKeyRepository) MigrateKeyHash(ctx context.Context, id int64, legacyKey, keyHash, keyPrefix string) error {
+	_, err := r.client.APIKey.Update().
+		Where(apikey.IDEQ(id), apikey.KeyEQ(legacyKey), apikey.KeyHashIsNil()).
+		SetKeyHash(keyHash).
+		SetKeyPrefix(keyPrefix).
+		ClearKey().
+		Save(mixins.SkipSoftDelete(ctx))
+	return err
+}
+
+func apiKeyCredentialFromEntity(m *dbent.APIKey) service.APIKeyCredential {
+	cred := service.APIKeyCredential{ID: m.ID}
+	if m.KeyHash != nil {
+		cred.KeyHash = *m.KeyHash
+	} else if m.Key != nil {
+		cred.LegacyKey = *m.Key
+	}
+	return cred
 }
 
 func apiKeyEntityToService(m *dbent.APIKey) *service.APIKey {
@@ -362,16 +421,24 @@ func apiKeyEntityToService(m *dbent.APIKey) *service.APIKey {
 		return nil
 	}
 	out := &service.APIKey{
-		ID:          m.ID,
-		UserID:      m.UserID,
-		Key:         m.Key,
-		Name:        m.Name,
-		Status:      m.Status,
-		IPWhitelist: m.IPWhitelist,
-		IPBlacklist: m.IPBlacklist,
-		CreatedAt:   m.CreatedAt,
-		UpdatedAt:   m.UpdatedAt,
-		GroupID:     m.GroupID,
+		ID:                    m.ID,
+		UserID:                m.UserID,
+		KeyHash:               derefString(m.KeyHash),
+		KeyPrefix:             m.KeyPrefix,
+		Name:                  m.Name,
+		Status:                m.Status,
+		IPWhitelist:           m.IPWhitelist,
+		IPBlacklist:           m.IPBlacklist,
+		DedicatedAccountIDs:   m.DedicatedAccountIds,
+		DedicatedAccountsOnly: m.DedicatedAccountsOnly,
+		CreatedAt:             m.CreatedAt,
+		UpdatedAt:             m.UpdatedAt,
+		GroupID:               m.GroupID,
+		OrganizationID:        m.OrganizationID,
+	}
+	if m.KeyHash == nil && m.Key != nil {
+		out.LegacyKey = *m.Key
+		out.KeyPrefix = service.APIKeyDisplayPrefix(*m.Key)
 	}
 	if m.Edges.User != nil {
 		out.User = userEntityToService(m.Edges.User)
@@ -379,6 +446,9 @@ func apiKeyEntityToService(m *dbent.APIKey) *service.APIKey {
 	if m.Edges.Group != nil {
 		out.Group = groupEntityToService(m.Edges.Group)
 	}
+	if m.Edges.Organization != nil {
+		out.Organization = organizationEntityToService(m.Edges.Organization)
+	}
 	return out
 }
 
@@ -387,21 +457,25 @@ func userEntityToService(u *dbent.User) *service.User {
 		return nil
 	}
 	return &service.User{
-		ID:                  u.ID,
-		Email:               u.Email,
-		Username:            u.Username,
-		Notes:               u.Notes,
-		PasswordHash:        u.PasswordHash,
-		Role:                u.Role,
-		Balance:             u.Balance,
-		InviteCode:          derefString(u.InviteCode),
-		Concurrency:         u.Concurrency,
-		Status:              u.Status,
-		TotpSecretEncrypted: u.TotpSecretEncrypted,
-		TotpEnabled:         u.TotpEnabled,
-		TotpEnabledAt:       u.TotpEnabledAt,
-		CreatedAt:           u.CreatedAt,
-		UpdatedAt:           u.UpdatedAt,
+		ID:                    u.ID,
+		Email:                 u.Email,
+		Username:              u.Username,
+		Notes:                 u.Notes,
+		PasswordHash:          u.PasswordHash,
+		Role:                  u.Role,
+		Balance:               u.Balance,
+		InviteCode:            derefString(u.InviteCode),
+		Concurrency:           u.Concurrency,
+		Status:                u.Status,
+		TotpSecretEncrypted:   u.TotpSecretEncrypted,
+		TotpEnabled:           u.TotpEnabled,
+		TotpEnabledAt:         u.TotpEnabledAt,
+		DedicatedAccountIDs:   u.DedicatedAccountIds,
+		DedicatedAccountsOnly: u.DedicatedAccountsOnly,
+		RegisterIP:            u.RegisterIP,
+		RegisterDevice:        u.RegisterDevice,
+		CreatedAt:             u.CreatedAt,
+		UpdatedAt:             u.UpdatedAt,
 	}
 }
 
@@ -410,29 +484,104 @@ func groupEntityToService(g *dbent.Group) *service.Group {
 		return nil
 	}
 	return &service.Group{
-		ID:                  g.ID,
-		Name:                g.Name,
-		Description:         derefString(g.Description),
-		Platform:            g.Platform,
-		RateMultiplier:      g.RateMultiplier,
-		IsExclusive:         g.IsExclusive,
-		Status:              g.Status,
-		Hydrated:            true,
-		SubscriptionType:    g.SubscriptionType,
-		DailyLimitUSD:       g.DailyLimitUsd,
-		WeeklyLimitUSD:      g.WeeklyLimitUsd,
-		MonthlyLimitUSD:     g.MonthlyLimitUsd,
-		ImagePrice1K:        g.ImagePrice1k,
-		ImagePrice2K:        g.ImagePrice2k,
-		ImagePrice4K:        g.ImagePrice4k,
-		DefaultValidityDays: g.DefaultValidityDays,
-		ClaudeCodeOnly:      g.ClaudeCodeOnly,
-		FallbackGroupID:     g.FallbackGroupID,
-		ModelRouting:        g.ModelRouting,
-		ModelRoutingEnabled: g.ModelRoutingEnabled,
-		CreatedAt:           g.CreatedAt,
-		UpdatedAt:           g.UpdatedAt,
+		ID:                    g.ID,
+		Name:                  g.Name,
+		Description:           derefString(g.Description),
+		Platform:              g.Platform,
+		RateMultiplier:        g.RateMultiplier,
+		IsExclusive:           g.IsExclusive,
+		Status:                g.Status,
+		Hydrated:              true,
+		SubscriptionType:      g.SubscriptionType,
+		DailyLimitUSD:         g.DailyLimitUsd,
+		WeeklyLimitUSD:        g.WeeklyLimitUsd,
+		MonthlyLimitUSD:       g.MonthlyLimitUsd,
+		ImagePrice1K:          g.ImagePrice1k,
+		ImagePrice2K:          g.ImagePrice2k,
+		ImagePrice4K:          g.ImagePrice4k,
+		DefaultValidityDays:   g.DefaultValidityDays,
+		ClaudeCodeOnly:        g.ClaudeCodeOnly,
+		FallbackGroupID:       g.FallbackGroupID,
+		ModelRouting:          g.ModelRouting,
+		ModelRoutingEnabled:   g.ModelRoutingEnabled,
+		SchedulingStrategy:    g.SchedulingStrategy,
+		ContentPolicy:         contentPolicyFromJSON(g.ID, g.ContentPolicy),
+		RewriteRules:          rewriteRulesFromJSON(g.ID, g.RewriteRules),
+		ModelAliases:          modelAliasesFromJSON(g.ID, g.ModelAliases),
+		OveragePolicy:         g.OveragePolicy,
+		OverageRateMultiplier: g.OverageRateMultiplier,
+		OverageGroupID:        g.OverageGroupID,
+		SubscriptionPrice:     g.SubscriptionPrice,
+		UsageQuotas:           usageQuotasFromJSON(g.ID, g.UsageQuotas),
+		TrafficSplit:          trafficSplitFromJSON(g.ID, g.TrafficSplit),
+		CreatedAt:             g.CreatedAt,
+		UpdatedAt:             g.UpdatedAt,
+	}
+}
+
+// contentPolicyFromJSON 解析分组内容策略；解析失败时记录日志并视为未配置
+func contentPolicyFromJSON(groupID int64, raw json.RawMessage) *service.ContentPolicy {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var policy service.ContentPolicy
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		log.Printf("[GroupRepo] invalid content_policy: group=%d err=%v", groupID, err)
+		return nil
+	}
+	return &policy
+}
+
+// rewriteRulesFromJSON 解析分组请求改写规则；解析失败时记录日志并视为未配置
+func rewriteRulesFromJSON(groupID int64, raw json.RawMessage) []service.RequestRewriteRule {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var rules []service.RequestRewriteRule
+	if err := json.Unmarshal(raw, &rules); err != nil {
+		log.Printf("[GroupRepo] invalid rewrite_rules: group=%d err=%v", groupID, err)
+		return nil
+	}
+	return rules
+}
+
+// modelAliasesFromJSON 解析分组模型别名；解析失败时记录日志并视为未配置
+func modelAliasesFromJSON(groupID int64, raw json.RawMessage) []service.ModelAlias {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var aliases []service.ModelAlias
+	if err := json.Unmarshal(raw, &aliases); err != nil {
+		log.Printf("[GroupRepo] invalid model_aliases: group=%d err=%v", groupID, err)
+		return nil
+	}
+	return aliases
+}
+
+// usageQuotasFromJSON 解析分组订阅额度；解析失败时记录日志并视为未配置
+func usageQuotasFromJSON(groupID int64, raw json.RawMessage) []service.SubscriptionQuota {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var quotas []service.SubscriptionQuota
+	if err := json.Unmarshal(raw, &quotas); err != nil {
+		log.Printf("[GroupRepo] invalid usage_quotas: group=%d err=%v", groupID, err)
+		return nil
+	}
+	return quotas
+}
+
+// trafficSplitFromJSON 解析分组灰度分流配置；解析失败时记录日志并视为未配置
+func trafficSplitFromJSON(groupID int64, raw json.RawMessage) *service.TrafficSplit {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var split service.TrafficSplit
+	if err := json.Unmarshal(raw, &split); err != nil {
+		log.Printf("[GroupRepo] invalid traffic_split: group=%d err=%v", groupID, err)
+		return nil
 	}
+	return &split
 }
 
 func derefString(s *string) string {
@@ -30,7 +30,7 @@ func TestAPIKeyRepoSuite(t *testing.T) {
 	suite.Run(t, new(APIKeyRepoSuite))
 }
 
-// --- Create / GetByID / GetByKey ---
+// --- Create / GetByID / GetByKeyForAuth ---
 
 func (s *APIKeyRepoSuite) TestCreate() {
 	user := s.mustCreateUser("create@test.com")
@@ -48,7 +48,34 @@ func (s *APIKeyRepoSuite) TestCreate() {
 
 	got, err := s.repo.GetByID(s.ctx, key.ID)
 	s.Require().NoError(err, "GetByID")
-	s.Require().Equal("sk-create-test", got.Key)
+	s.Require().Empty(got.Key, "plaintext key must not be returned")
+	s.Require().Equal("sk-create-test", got.LegacyKey)
+	s.Require().Equal("sk-c", got.KeyPrefix)
+}
+
+func (s *APIKeyRepoSuite) TestCreate_HashedKey() {
+	user := s.mustCreateUser("create-hash@test.com")
+
+	key := &service.APIKey{
+		UserID:    user.ID,
+		Key:       "sk-create-hashed",
+		KeyHash:   "hash-create-hashed",
+		KeyPrefix: "sk-c",
+		Name:      "Hashed Key",
+		Status:    service.StatusActive,
+	}
+	s.Require().NoError(s.repo.Create(s.ctx, key), "Create")
+
+	got, err := s.repo.GetByID(s.ctx, key.ID)
+	s.Require().NoError(err, "GetByID")
+	s.Require().Empty(got.Key)
+	s.Require().Empty(got.LegacyKey, "plaintext must not be stored when hash is provided")
+	s.Require().Equal("hash-create-hashed", got.KeyHash)
+	s.Require().Equal("sk-c", got.KeyPrefix)
+
+	byHash, err := s.repo.GetByKeyForAuth(s.ctx, "hash-create-hashed", "sk-create-hashed")
+	s.Require().NoError(err, "GetByKeyForAuth")
+	s.Require().Equal(key.ID, byHash.ID)
 }
 
 func (s *APIKeyRepoSuite) TestGetByID_NotFound() {
@@ -56,7 +83,7 @@ func (s *APIKeyRepoSuite) TestGetByID_NotFound() {
 	s.Require().Error(err, "expected error for non-existent ID")
 }
 
-func (s *APIKeyRepoSuite) TestGetByKey() {
+func (s *APIKeyRepoSuite) TestGetByKeyForAuth_LegacyKey() {
 	user := s.mustCreateUser("getbykey@test.com")
 	group := s.mustCreateGroup("g-key")
 
@@ -69,8 +96,8 @@ func (s *APIKeyRepoSuite) TestGetByKey() {
 	}
 	s.Require().NoError(s.repo.Create(s.ctx, key))
 
-	got, err := s.repo.GetByKey(s.ctx, key.Key)
-	s.Require().NoError(err, "GetByKey")
+	got, err := s.repo.GetByKeyForAuth(s.ctx, "unmigrated-hash", key.Key)
+	s.Require().NoError(err, "GetByKeyForAuth")
 	s.Require().Equal(key.ID, got.ID)
 	s.Require().NotNil(got.User, "expected User preload")
 	s.Require().Equal(user.ID, got.User.ID)
@@ -78,8 +105,8 @@ func (s *APIKeyRepoSuite) TestGetByKey() {
 	s.Require().Equal(group.ID, got.Group.ID)
 }
 
-func (s *APIKeyRepoSuite) TestGetByKey_NotFound() {
-	_, err := s.repo.GetByKey(s.ctx, "non-existent-key")
+func (s *APIKeyRepoSuite) TestGetByKeyForAuth_NotFound() {
+	_, err := s.repo.GetByKeyForAuth(s.ctx, "non-existent-hash", "non-existent-key")
 	s.Require().Error(err, "expected error for non-existent key")
 }
 
@@ -102,7 +129,7 @@ func (s *APIKeyRepoSuite) TestUpdate() {
 
 	got, err := s.repo.GetByID(s.ctx, key.ID)
 	s.Require().NoError(err, "GetByID after update")
-	s.Require().Equal("sk-update", got.Key, "Update should not change key")
+	s.Require().Equal("sk-update", got.LegacyKey, "Update should not change key")
 	s.Require().Equal(user.ID, got.UserID, "Update should not change user_id")
 	s.Require().Equal("Renamed", got.Name)
 	s.Require().Equal(service.StatusDisabled, got.Status)
@@ -218,15 +245,46 @@ func (s *APIKeyRepoSuite) TestExistsByKey() {
 	user := s.mustCreateUser("exists@test.com")
 	s.mustCreateApiKey(user.ID, "sk-exists", "K", nil)
 
-	exists, err := s.repo.ExistsByKey(s.ctx, "sk-exists")
+	exists, err := s.repo.ExistsByKey(s.ctx, "hash-exists", "sk-exists")
 	s.Require().NoError(err, "ExistsByKey")
 	s.Require().True(exists)
 
-	notExists, err := s.repo.ExistsByKey(s.ctx, "sk-not-exists")
+	notExists, err := s.repo.ExistsByKey(s.ctx, "hash-not-exists", "sk-not-exists")
 	s.Require().NoError(err)
 	s.Require().False(notExists)
 }
 
+// --- Hash migration ---
+
+func (s *APIKeyRepoSuite) TestMigrateKeyHash() {
+	user := s.mustCreateUser("migrate@test.com")
+	group := s.mustCreateGroup("g-migrate")
+	k1 := s.mustCreateApiKey(user.ID, "sk-migrate-1", "K1", &group.ID)
+	k2 := s.mustCreateApiKey(user.ID, "sk-migrate-2", "K2", nil)
+	s.Require().NoError(s.repo.Delete(s.ctx, k2.ID))
+
+	legacy, err := s.repo.ListLegacyKeys(s.ctx, 0, 10)
+	s.Require().NoError(err, "ListLegacyKeys")
+	s.Require().Len(legacy, 2, "soft-deleted keys must be migrated too")
+	s.Require().Equal("sk-migrate-1", legacy[0].LegacyKey)
+
+	for _, cred := range legacy {
+		s.Require().NoError(s.repo.MigrateKeyHash(s.ctx, cred.ID, cred.LegacyKey, "hash-"+cred.LegacyKey, "sk-m"))
+	}
+
+	legacy, err = s.repo.ListLegacyKeys(s.ctx, 0, 10)
+	s.Require().NoError(err)
+	s.Require().Empty(legacy)
+
+	got, err := s.repo.GetByKeyForAuth(s.ctx, "hash-sk-migrate-1", "sk-migrate-1")
+	s.Require().NoError(err, "GetByKeyForAuth after migration")
+	s.Require().Equal(k1.ID, got.ID)
+
+	creds, err := s.repo.ListCredentialsByGroupID(s.ctx, group.ID)
+	s.Require().NoError(err, "ListCredentialsByGroupID")
+	s.Require().Equal([]service.APIKeyCredential{{ID: k1.ID, KeyHash: "hash-sk-migrate-1"}}, creds)
+}
+
 // --- SearchAPIKeys ---
 
 func (s *APIKeyRepoSuite) TestSearchAPIKeys() {
@@ -290,8 +348,8 @@ func (s *APIKeyRepoSuite) TestCRUD_Search_ClearGroupID() {
 	key := s.mustCreateApiKey(user.ID, "sk-test-1", "My Key", &group.ID)
 	key.GroupID = &group.ID
 
-	got, err := s.repo.GetByKey(s.ctx, key.Key)
-	s.Require().NoError(err, "GetByKey")
+	got, err := s.repo.GetByKeyForAuth(s.ctx, "hash-test-1", key.Key)
+	s.Require().NoError(err, "GetByKeyForAuth")
 	s.Require().Equal(key.ID, got.ID)
 	s.Require().NotNil(got.User)
 	s.Require().Equal(user.ID, got.User.ID)
@@ -305,7 +363,7 @@ func (s *APIKeyRepoSuite) TestCRUD_Search_ClearGroupID() {
 
 	got2, err := s.repo.GetByID(s.ctx, key.ID)
 	s.Require().NoError(err, "GetByID")
-	s.Require().Equal("sk-test-1", got2.Key, "Update should not change key")
+	s.Require().Equal("sk-test-1", got2.LegacyKey, "Update should not change key")
 	s.Require().Equal(user.ID, got2.UserID, "Update should not change user_id")
 	s.Require().Equal("Renamed", got2.Name)
 	s.Require().Equal(service.StatusDisabled, got2.Status)
@@ -316,7 +374,7 @@ func (s *APIKeyRepoSuite) TestCRUD_Search_ClearGroupID() {
 	s.Require().Equal(int64(1), page.Total)
 	s.Require().Len(keys, 1)
 
-	exists, err := s.repo.ExistsByKey(s.ctx, "sk-test-1")
+	exists, err := s.repo.ExistsByKey(s.ctx, "hash-test-1", "sk-test-1")
 	s.Require().NoError(err, "ExistsByKey")
 	s.Require().True(exists, "expected key to exist")
 
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+const (
+	authSessionKeyPrefix      = "auth:session:"
+	authUserSessionsKeyPrefix = "auth:user_sessions:"
+)
+
+var (
+	// rotateRefreshScript 比较并替换 refresh 哈希，保证同一 refresh token 只能成功使用一次
+	// KEYS[1] = 会话 key
+	// ARGV[1] = 旧哈希, ARGV[2] = 新哈希, ARGV[3] = 当前时间（秒）, ARGV[4] = 客户端 IP, ARGV[5] = TTL（毫秒）
+	rotateRefreshScript = redis.NewScript(`
+		if redis.call('HGET', KEYS[1], 'refresh_hash') ~= ARGV[1] then
+			return 0
+		end
+		redis.call('HSET', KEYS[1], 'refresh_hash', ARGV[2], 'prev_refresh_hash', ARGV[1],
+			'rotated_at', ARGV[3], 'last_seen_at', ARGV[3], 'last_ip', ARGV[4])
+		redis.call('PEXPIRE', KEYS[1], ARGV[5])
+		return 1
+	`)
+
+	// touchSessionScript 仅在会话仍存在时更新活跃信息，避免为已吊销的会话重建残缺的 hash
+	// KEYS[1] = 会话 key
+	// ARGV[1] = 当前时间（秒）, ARGV[2] = 客户端 IP
+	touchSessionScript = redis.NewScript(`
+		if redis.call('EXISTS', KEYS[1]) == 0 then
+			return 0
+		end
+		redis.call('HSET', KEYS[1], 'last_seen_at', ARGV[1], 'last_ip', ARGV[2])
+		return 1
+	`)
+)
+
+// AuthSessionCache implements service.AuthSessionCache using Redis.
+// 每个会话是一个 hash（auth:session:{id}），另用 set（auth:user_sessions:{uid}）索引用户的会话。
+type AuthSessionCache struct {
+	rdb redis.UniversalClient
+}
+
+// NewAuthSessionCache creates a new login session cache
+func NewAuthSessionCache(rdb redis.UniversalClient) service.AuthSessionCache {
+	return &AuthSessionCache{rdb: rdb}
+}
+
+func authSessionKey(sessionID string) string {
+	return authSessionKeyPrefix + sessionID
+}
+
+func authUserSessionsKey(userID int64) string {
+	return fmt.Sprintf("%s%d", authUserSessionsKeyPrefix, userID)
+}
+
+// CreateSession stores a new login session and indexes it under the user
+func (c *AuthSessionCache) CreateSession(ctx context.Context, session *service.AuthSession, ttl time.Duration) error {
+	key := authSessionKey(session.ID)
+	userKey := authUserSessionsKey(session.UserID)
+
+	// 会话 key 与用户索引 key 可能位于不同的 Cluster slot，使用普通 pipeline 而非事务
+	pipe := c.rdb.Pipeline()
+	pipe.HSet(ctx, key, map[string]any{
+		"user_id":      session.UserID,
+		"refresh_hash": session.RefreshHash,
+		"ip":           session.IP,
+		"last_ip":      session.LastIP,
+		"user_agent":   session.UserAgent,
+		"device_id":    session.DeviceID,
+		"created_at":   session.CreatedAt.Unix(),
+		"last_seen_at": session.LastSeenAt.Unix(),
+	})
+	pipe.PExpire(ctx, key, ttl)
+	pipe.SAdd(ctx, userKey, session.ID)
+	pipe.PExpire(ctx, userKey, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("create session: %w", err)
+	}
+	return nil
+}
+
+// GetSession retrieves a login session, returning nil when it does not exist
+func (c *AuthSessionCache) GetSession(ctx context.Context, sessionID string) (*service.AuthSession, error) {
+	fields, err := c.rdb.HGetAll(ctx, authSessionKey(sessionID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	userID, err := strconv.ParseInt(fields["user_id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse session user_id: %w", err)
+	}
+
+	return &service.AuthSession{
+		ID:              sessionID,
+		UserID:          userID,
+		RefreshHash:     fields["refresh_hash"],
+		PrevRefreshHash: fields["prev_refresh_hash"],
+		RotatedAt:       parseUnixField(fields["rotated_at"]),
+		IP:              fields["ip"],
+		LastIP:          fields["last_ip"],
+		UserAgent:       fields["user_agent"],
+		DeviceID:        fields["device_id"],
+		CreatedAt:       parseUnixField(fields["created_at"]),
+		LastSeenAt:      parseUnixField(fields["last_seen_at"]),
+	}, nil
+}
+
+// RotateRefreshHash atomically swaps the refresh hash if it still matches oldHash
+func (c *AuthSessionCache) RotateRefreshHash(ctx context.Context, userID int64, sessionID, oldHash, newHash, ip string, now time.Time, ttl time.Duration) (bool, error) {
+	result, err := rotateRefreshScript.Run(ctx, c.rdb, []string{authSessionKey(sessionID)},
+		oldHash, newHash, now.Unix(), ip, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("rotate refresh token: %w", err)
+	}
+	if result != 1 {
+		return false, nil
+	}
+	// 用户索引与会话 key 可能位于不同的 Cluster slot，单独续期；
+	// 所有会话 TTL 相同，按本次续期后的过期时间覆盖不会早于其他会话
+	if err := c.rdb.PExpire(ctx, authUserSessionsKey(userID), ttl).Err(); err != nil {
+		log.Printf("[AuthSession] Failed to extend session index for user %d: %v", userID, err)
+	}
+	return true, nil
+}
+
+// TouchSession updates the last-seen time and IP of an existing session
+func (c *AuthSessionCache) TouchSession(ctx context.Context, sessionID, ip string, now time.Time) error {
+	return touchSessionScript.Run(ctx, c.rdb, []string{authSessionKey(sessionID)}, now.Unix(), ip).Err()
+}
+
+// DeleteSession removes a login session and its index entry
+func (c *AuthSessionCache) DeleteSession(ctx context.Context, userID int64, sessionID string) error {
+	if err := c.rdb.Del(ctx, authSessionKey(sessionID)).Err(); err != nil {
+		return err
+	}
+	return c.rdb.SRem(ctx, authUserSessionsKey(userID), sessionID).Err()
+}
+
+// ListSessionIDs returns the indexed session IDs of a user (may include expired ones)
+func (c *AuthSessionCache) ListSessionIDs(ctx context.Context, userID int64) ([]string, error) {
+	return c.rdb.SMembers(ctx, authUserSessionsKey(userID)).Result()
+}
+
+// RemoveSessionIDs drops session IDs from the user index
+func (c *AuthSessionCache) RemoveSessionIDs(ctx context.Context, userID int64, sessionIDs ...string) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(sessionIDs))
+	for _, id := range sessionIDs {
+		members = append(members, id)
+	}
+	return c.rdb.SRem(ctx, authUserSessionsKey(userID), members...).Err()
+}
+
+func parseUnixField(value string) time.Time {
+	sec, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/lib/pq"
+)
+
+type backupRepository struct {
+	db *sql.DB
+}
+
+// NewBackupRepository 创建实例备份仓储。行数据以 to_jsonb 原样导出，
+// 恢复时通过 jsonb_populate_record 写回，新增列自动参与，无需随 schema 维护字段清单。
+func NewBackupRepository(sqlDB *sql.DB) service.BackupRepository {
+	return &backupRepository{db: sqlDB}
+}
+
+func (r *backupRepository) SchemaVersion(ctx context.Context) (string, error) {
+	var filename string
+	err := r.db.QueryRowContext(ctx, "SELECT filename FROM schema_migrations ORDER BY filename DESC LIMIT 1").Scan(&filename)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return filename, err
+}
+
+func (r *backupRepository) CountRows(ctx context.Context, table string) (int64, error) {
+	var n int64
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+pq.QuoteIdentifier(table)).Scan(&n)
+	return n, err
+}
+
+func (r *backupRepository) DumpTable(ctx context.Context, table string, fn func(row map[string]any) error) error {
+	columns, err := tableColumns(ctx, r.db, table)
+	if err != nil {
+		return err
+	}
+	// 有自增主键的表按 ID 导出，保证自引用/依赖顺序稳定；关联表按全部列排序
+	order := "1"
+	if _, ok := columns["id"]; ok {
+		order = "t.id"
+	}
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf("SELECT to_jsonb(t)::text FROM %s t ORDER BY %s", pq.QuoteIdentifier(table), order))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var raw string
+		if err := rows.Scan(&raw); err != nil {
+			return err
+		}
+		dec := json.NewDecoder(strings.NewReader(raw))
+		dec.UseNumber()
+		var row map[string]any
+		if err := dec.Decode(&row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *backupRepository) Restore(ctx context.Context, fn func(tx service.BackupRestoreTx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(&backupRestoreTx{tx: tx, columns: make(map[string]map[string]struct{})}); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+type backupRestoreTx struct {
+	tx      *sql.Tx
+	columns map[string]map[string]struct{}
+}
+
+func (t *backupRestoreTx) tableColumns(ctx context.Context, table string) (map[string]struct{}, error) {
+	if cols, ok := t.columns[table]; ok {
+		return cols, nil
+	}
+	cols, err := tableColumns(ctx, t.tx, table)
+	if err != nil {
+		return nil, err
+	}
+	t.columns[table] = cols
+	return cols, nil
+}
+
+// InsertRow 只写入归档与当前表共有的列（旧版本归档缺失的列使用默认值，已删除的列忽略）
+func (t *backupRestoreTx) InsertRow(ctx context.Context, table string, row map[string]any, returnID bool) (int64, error) {
+	cols, err := t.tableColumns(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+	names := make([]string, 0, len(row))
+	for name := range row {
+		if _, ok := cols[name]; ok && name != "id" {
+			names = append(names, pq.QuoteIdentifier(name))
+		}
+	}
+	if len(names) == 0 {
+		return 0, fmt.Errorf("no restorable columns for %s", table)
+	}
+	sort.Strings(names)
+	payload, err := json.Marshal(row)
+	if err != nil {
+		return 0, err
+	}
+	quoted := pq.QuoteIdentifier(table)
+	columnList := strings.Join(names, ", ")
+	query := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM jsonb_populate_record(NULL::%s, $1::jsonb)", quoted, columnList, columnList, quoted)
+	if !returnID {
+		_, err := t.tx.ExecContext(ctx, query, string(payload))
+		return 0, err
+	}
+	var id int64
+	err = t.tx.QueryRowContext(ctx, query+" RETURNING id", string(payload)).Scan(&id)
+	return id, err
+}
+
+func (t *backupRestoreTx) UpsertSetting(ctx context.Context, row map[string]any) error {
+	key, _ := row["key"].(string)
+	if key == "" {
+		return errors.New("setting without key")
+	}
+	value, _ := row["value"].(string)
+	_, err := t.tx.ExecContext(ctx, `
+		INSERT INTO settings (key, value, updated_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()
+	`, key, value)
+	return err
+}
+
+func (t *backupRestoreTx) SetColumn(ctx context.Context, table string, id int64, column string, value any) error {
+	_, err := t.tx.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET %s = $1 WHERE id = $2", pq.QuoteIdentifier(table), pq.QuoteIdentifier(column)),
+		value, id)
+	return err
+}
+
+func (t *backupRestoreTx) FindUserIDByEmail(ctx context.Context, email string) (int64, error) {
+	var id int64
+	err := t.tx.QueryRowContext(ctx, "SELECT id FROM users WHERE email = $1 AND deleted_at IS NULL LIMIT 1", email).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
+}
+
+type backupQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func tableColumns(ctx context.Context, q backupQuerier, table string) (map[string]struct{}, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1
+	`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	cols := make(map[string]struct{})
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		cols[name] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("table %s not found", table)
+	}
+	return cols, nil
+}
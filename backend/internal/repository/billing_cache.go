@@ -14,7 +14,9 @@ import (
 
 const (
 	billingBalanceKeyPrefix = "billing:balance:"
+	billingOrgBalancePrefix = "billing:org_balance:"
 	billingSubKeyPrefix     = "billing:sub:"
+	billingSubQuotaPrefix   = "billing:subquota:"
 	billingCacheTTL         = 5 * time.Minute
 )
 
@@ -23,11 +25,32 @@ func billingBalanceKey(userID int64) string {
 	return fmt.Sprintf("%s%d", billingBalanceKeyPrefix, userID)
 }
 
+// billingOrgBalanceKey generates the Redis key for organization balance cache.
+func billingOrgBalanceKey(orgID int64) string {
+	return fmt.Sprintf("%s%d", billingOrgBalancePrefix, orgID)
+}
+
 // billingSubKey generates the Redis key for subscription cache.
 func billingSubKey(userID, groupID int64) string {
 	return fmt.Sprintf("%s%d:%d", billingSubKeyPrefix, userID, groupID)
 }
 
+// billingSubQuotaKey generates the Redis key for a subscription quota counter.
+// The window start is part of the key so a window reset starts a fresh counter.
+func billingSubQuotaKey(key service.SubscriptionQuotaKey) string {
+	model := key.Model
+	if model == "" {
+		model = "*"
+	}
+	return fmt.Sprintf("%s%d:%s:%d:%s", billingSubQuotaPrefix, key.SubscriptionID, key.Window, key.WindowStart.Unix(), model)
+}
+
+const (
+	quotaFieldRequests     = "requests"
+	quotaFieldInputTokens  = "input_tokens"
+	quotaFieldOutputTokens = "output_tokens"
+)
+
 const (
 	subFieldStatus       = "status"
 	subFieldExpiresAt    = "expires_at"
@@ -61,13 +84,25 @@ var (
 		redis.call('EXPIRE', KEYS[1], ARGV[2])
 		return 1
 	`)
+
+	// 额度计数器只在已缓存时累加（TTL 为窗口结束时间，不续期），未缓存时由资格检查从数据库回填
+	incrSubQuotaScript = redis.NewScript(`
+		local exists = redis.call('EXISTS', KEYS[1])
+		if exists == 0 then
+			return 0
+		end
+		redis.call('HINCRBY', KEYS[1], 'requests', ARGV[1])
+		redis.call('HINCRBY', KEYS[1], 'input_tokens', ARGV[2])
+		redis.call('HINCRBY', KEYS[1], 'output_tokens', ARGV[3])
+		return 1
+	`)
 )
 
 type billingCache struct {
-	rdb *redis.Client
+	rdb redis.UniversalClient
 }
 
-func NewBillingCache(rdb *redis.Client) service.BillingCache {
+func NewBillingCache(rdb redis.UniversalClient) service.BillingCache {
 	return &billingCache{rdb: rdb}
 }
 
@@ -99,6 +134,31 @@ func (c *billingCache) InvalidateUserBalance(ctx context.Context, userID int64)
 	return c.rdb.Del(ctx, key).Err()
 }
 
+func (c *billingCache) GetOrganizationBalance(ctx context.Context, orgID int64) (float64, error) {
+	val, err := c.rdb.Get(ctx, billingOrgBalanceKey(orgID)).Result()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(val, 64)
+}
+
+func (c *billingCache) SetOrganizationBalance(ctx context.Context, orgID int64, balance float64) error {
+	return c.rdb.Set(ctx, billingOrgBalanceKey(orgID), balance, billingCacheTTL).Err()
+}
+
+func (c *billingCache) DeductOrganizationBalance(ctx context.Context, orgID int64, amount float64) error {
+	key := billingOrgBalanceKey(orgID)
+	_, err := deductBalanceScript.Run(ctx, c.rdb, []string{key}, amount, int(billingCacheTTL.Seconds())).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		log.Printf("Warning: deduct balance cache failed for organization %d: %v", orgID, err)
+	}
+	return nil
+}
+
+func (c *billingCache) InvalidateOrganizationBalance(ctx context.Context, orgID int64) error {
+	return c.rdb.Del(ctx, billingOrgBalanceKey(orgID)).Err()
+}
+
 func (c *billingCache) GetSubscriptionCache(ctx context.Context, userID, groupID int64) (*service.SubscriptionCacheData, error) {
 	key := billingSubKey(userID, groupID)
 	result, err := c.rdb.HGetAll(ctx, key).Result()
@@ -181,3 +241,39 @@ func (c *billingCache) InvalidateSubscriptionCache(ctx context.Context, userID,
 	key := billingSubKey(userID, groupID)
 	return c.rdb.Del(ctx, key).Err()
 }
+
+func (c *billingCache) GetSubscriptionQuotaUsage(ctx context.Context, key service.SubscriptionQuotaKey) (*service.SubscriptionQuotaUsage, error) {
+	result, err := c.rdb.HGetAll(ctx, billingSubQuotaKey(key)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, redis.Nil
+	}
+	usage := &service.SubscriptionQuotaUsage{}
+	usage.Requests, _ = strconv.ParseInt(result[quotaFieldRequests], 10, 64)
+	usage.InputTokens, _ = strconv.ParseInt(result[quotaFieldInputTokens], 10, 64)
+	usage.OutputTokens, _ = strconv.ParseInt(result[quotaFieldOutputTokens], 10, 64)
+	return usage, nil
+}
+
+func (c *billingCache) SetSubscriptionQuotaUsage(ctx context.Context, key service.SubscriptionQuotaKey, usage service.SubscriptionQuotaUsage, ttl time.Duration) error {
+	redisKey := billingSubQuotaKey(key)
+	pipe := c.rdb.Pipeline()
+	pipe.HSet(ctx, redisKey, map[string]any{
+		quotaFieldRequests:     usage.Requests,
+		quotaFieldInputTokens:  usage.InputTokens,
+		quotaFieldOutputTokens: usage.OutputTokens,
+	})
+	pipe.Expire(ctx, redisKey, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *billingCache) IncrSubscriptionQuotaUsage(ctx context.Context, key service.SubscriptionQuotaKey, delta service.SubscriptionQuotaUsage) error {
+	_, err := incrSubQuotaScript.Run(ctx, c.rdb, []string{billingSubQuotaKey(key)}, delta.Requests, delta.InputTokens, delta.OutputTokens).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+	return nil
+}
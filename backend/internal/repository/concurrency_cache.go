@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/Wei-Shaw/sub2api/internal/service"
 	"github.com/redis/go-redis/v9"
@@ -23,6 +22,8 @@ import (
 // 4. 单次 Redis 调用完成计数，减少网络往返
 const (
 	// 并发槽位键前缀（有序集合）
+	// ID 部分使用 Redis Cluster hash tag（花括号），保证同一账号的槽位键与等待队列键落在同一槽位，
+	// 从而可以在同一个 Lua 脚本中访问。
 	// 格式: concurrency:account:{accountID}
 	accountSlotKeyPrefix = "concurrency:account:"
 	// 格式: concurrency:user:{userID}
@@ -147,51 +148,40 @@ var (
 			return 1
 		`)
 
-	// getAccountsLoadBatchScript - batch load query with expired slot cleanup
+	// getAccountLoadScript - single account load query with expired slot cleanup
+	// 批量查询时按账号在 pipeline 中逐个执行：两个 key 使用相同的 hash tag（{accountID}），
+	// 在 Redis Cluster 下始终落在同一槽位，避免 CROSSSLOT 错误。
+	// KEYS[1] = concurrency:account:{accountID}
+	// KEYS[2] = wait:account:{accountID}
 	// ARGV[1] = slot TTL (seconds)
-	// ARGV[2..n] = accountID1, maxConcurrency1, accountID2, maxConcurrency2, ...
-	getAccountsLoadBatchScript = redis.NewScript(`
-			local result = {}
+	// ARGV[2] = maxConcurrency
+	// 返回 {currentConcurrency, waitingCount, loadRate}
+	getAccountLoadScript = redis.NewScript(`
 			local slotTTL = tonumber(ARGV[1])
+			local maxConcurrency = tonumber(ARGV[2])
 
 			-- Get current server time
 			local timeResult = redis.call('TIME')
 			local nowSeconds = tonumber(timeResult[1])
 			local cutoffTime = nowSeconds - slotTTL
 
-			local i = 2
-			while i <= #ARGV do
-				local accountID = ARGV[i]
-				local maxConcurrency = tonumber(ARGV[i + 1])
-
-				local slotKey = 'concurrency:account:' .. accountID
-
-				-- Clean up expired slots before counting
-				redis.call('ZREMRANGEBYSCORE', slotKey, '-inf', cutoffTime)
-				local currentConcurrency = redis.call('ZCARD', slotKey)
-
-				local waitKey = 'wait:account:' .. accountID
-				local waitingCount = redis.call('GET', waitKey)
-				if waitingCount == false then
-					waitingCount = 0
-				else
-					waitingCount = tonumber(waitingCount)
-				end
+			-- Clean up expired slots before counting
+			redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', cutoffTime)
+			local currentConcurrency = redis.call('ZCARD', KEYS[1])
 
-				local loadRate = 0
-				if maxConcurrency > 0 then
-					loadRate = math.floor((currentConcurrency + waitingCount) * 100 / maxConcurrency)
-				end
-
-				table.insert(result, accountID)
-				table.insert(result, currentConcurrency)
-				table.insert(result, waitingCount)
-				table.insert(result, loadRate)
+			local waitingCount = redis.call('GET', KEYS[2])
+			if waitingCount == false then
+				waitingCount = 0
+			else
+				waitingCount = tonumber(waitingCount)
+			end
 
-				i = i + 2
+			local loadRate = 0
+			if maxConcurrency > 0 then
+				loadRate = math.floor((currentConcurrency + waitingCount) * 100 / maxConcurrency)
 			end
 
-			return result
+			return {currentConcurrency, waitingCount, loadRate}
 		`)
 
 	// cleanupExpiredSlotsScript - remove expired slots
@@ -208,7 +198,7 @@ var (
 )
 
 type concurrencyCache struct {
-	rdb                 *redis.Client
+	rdb                 redis.UniversalClient
 	slotTTLSeconds      int // 槽位过期时间（秒）
 	waitQueueTTLSeconds int // 等待队列过期时间（秒）
 }
@@ -216,7 +206,7 @@ type concurrencyCache struct {
 // NewConcurrencyCache 创建并发控制缓存
 // slotTTLMinutes: 槽位过期时间（分钟），0 或负数使用默认值 15 分钟
 // waitQueueTTLSeconds: 等待队列过期时间（秒），0 或负数使用 slot TTL
-func NewConcurrencyCache(rdb *redis.Client, slotTTLMinutes int, waitQueueTTLSeconds int) service.ConcurrencyCache {
+func NewConcurrencyCache(rdb redis.UniversalClient, slotTTLMinutes int, waitQueueTTLSeconds int) service.ConcurrencyCache {
 	if slotTTLMinutes <= 0 {
 		slotTTLMinutes = defaultSlotTTLMinutes
 	}
@@ -232,19 +222,19 @@ func NewConcurrencyCache(rdb *redis.Client, slotTTLMinutes int, waitQueueTTLSeco
 
 // Helper functions for key generation
 func accountSlotKey(accountID int64) string {
-	return fmt.Sprintf("%s%d", accountSlotKeyPrefix, accountID)
+	return fmt.Sprintf("%s{%d}", accountSlotKeyPrefix, accountID)
 }
 
 func userSlotKey(userID int64) string {
-	return fmt.Sprintf("%s%d", userSlotKeyPrefix, userID)
+	return fmt.Sprintf("%s{%d}", userSlotKeyPrefix, userID)
 }
 
 func waitQueueKey(userID int64) string {
-	return fmt.Sprintf("%s%d", waitQueueKeyPrefix, userID)
+	return fmt.Sprintf("%s{%d}", waitQueueKeyPrefix, userID)
 }
 
 func accountWaitKey(accountID int64) string {
-	return fmt.Sprintf("%s%d", accountWaitKeyPrefix, accountID)
+	return fmt.Sprintf("%s{%d}", accountWaitKeyPrefix, accountID)
 }
 
 // Account slot operations
@@ -352,38 +342,48 @@ func (c *concurrencyCache) GetAccountsLoadBatch(ctx context.Context, accounts []
 		return map[int64]*service.AccountLoadInfo{}, nil
 	}
 
-	args := []any{c.slotTTLSeconds}
-	for _, acc := range accounts {
-		args = append(args, acc.ID, acc.MaxConcurrency)
+	cmds, err := c.runAccountLoadPipeline(ctx, accounts, false)
+	if err != nil && redis.HasErrorPrefix(err, "NOSCRIPT") {
+		// 脚本缓存未命中（首次执行或节点重启/故障切换后）时改用 EVAL 重试
+		cmds, err = c.runAccountLoadPipeline(ctx, accounts, true)
 	}
-
-	result, err := getAccountsLoadBatchScript.Run(ctx, c.rdb, []string{}, args...).Slice()
 	if err != nil {
 		return nil, err
 	}
 
-	loadMap := make(map[int64]*service.AccountLoadInfo)
-	for i := 0; i < len(result); i += 4 {
-		if i+3 >= len(result) {
-			break
+	loadMap := make(map[int64]*service.AccountLoadInfo, len(accounts))
+	for i, acc := range accounts {
+		result, err := cmds[i].Int64Slice()
+		if err != nil || len(result) < 3 {
+			continue
 		}
-
-		accountID, _ := strconv.ParseInt(fmt.Sprintf("%v", result[i]), 10, 64)
-		currentConcurrency, _ := strconv.Atoi(fmt.Sprintf("%v", result[i+1]))
-		waitingCount, _ := strconv.Atoi(fmt.Sprintf("%v", result[i+2]))
-		loadRate, _ := strconv.Atoi(fmt.Sprintf("%v", result[i+3]))
-
-		loadMap[accountID] = &service.AccountLoadInfo{
-			AccountID:          accountID,
-			CurrentConcurrency: currentConcurrency,
-			WaitingCount:       waitingCount,
-			LoadRate:           loadRate,
+		loadMap[acc.ID] = &service.AccountLoadInfo{
+			AccountID:          acc.ID,
+			CurrentConcurrency: int(result[0]),
+			WaitingCount:       int(result[1]),
+			LoadRate:           int(result[2]),
 		}
 	}
 
 	return loadMap, nil
 }
 
+// runAccountLoadPipeline 在 pipeline 中逐个账号执行负载查询脚本（cluster 模式下按节点自动拆分）
+func (c *concurrencyCache) runAccountLoadPipeline(ctx context.Context, accounts []service.AccountWithConcurrency, eval bool) ([]*redis.Cmd, error) {
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.Cmd, len(accounts))
+	for i, acc := range accounts {
+		keys := []string{accountSlotKey(acc.ID), accountWaitKey(acc.ID)}
+		if eval {
+			cmds[i] = getAccountLoadScript.Eval(ctx, pipe, keys, c.slotTTLSeconds, acc.MaxConcurrency)
+		} else {
+			cmds[i] = getAccountLoadScript.EvalSha(ctx, pipe, keys, c.slotTTLSeconds, acc.MaxConcurrency)
+		}
+	}
+	_, err := pipe.Exec(ctx)
+	return cmds, err
+}
+
 func (c *concurrencyCache) CleanupExpiredAccountSlots(ctx context.Context, accountID int64) error {
 	key := accountSlotKey(accountID)
 	_, err := cleanupExpiredSlotsScript.Run(ctx, c.rdb, []string{key}, c.slotTTLSeconds).Result()
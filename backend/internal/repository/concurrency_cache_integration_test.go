@@ -4,7 +4,6 @@ package repository
 
 import (
 	"errors"
-	"fmt"
 	"testing"
 	"time"
 
@@ -60,7 +59,7 @@ func (s *ConcurrencyCacheSuite) TestAccountSlot_AcquireAndRelease() {
 func (s *ConcurrencyCacheSuite) TestAccountSlot_TTL() {
 	accountID := int64(11)
 	reqID := "req_ttl_test"
-	slotKey := fmt.Sprintf("%s%d", accountSlotKeyPrefix, accountID)
+	slotKey := accountSlotKey(accountID)
 
 	ok, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 5, reqID)
 	require.NoError(s.T(), err, "AcquireAccountSlot")
@@ -145,7 +144,7 @@ func (s *ConcurrencyCacheSuite) TestUserSlot_AcquireAndRelease() {
 func (s *ConcurrencyCacheSuite) TestUserSlot_TTL() {
 	userID := int64(200)
 	reqID := "req_ttl_test"
-	slotKey := fmt.Sprintf("%s%d", userSlotKeyPrefix, userID)
+	slotKey := userSlotKey(userID)
 
 	ok, err := s.cache.AcquireUserSlot(s.ctx, userID, 5, reqID)
 	require.NoError(s.T(), err, "AcquireUserSlot")
@@ -158,7 +157,7 @@ func (s *ConcurrencyCacheSuite) TestUserSlot_TTL() {
 
 func (s *ConcurrencyCacheSuite) TestWaitQueue_IncrementAndDecrement() {
 	userID := int64(20)
-	waitKey := fmt.Sprintf("%s%d", waitQueueKeyPrefix, userID)
+	waitKey := waitQueueKey(userID)
 
 	ok, err := s.cache.IncrementWaitCount(s.ctx, userID, 2)
 	require.NoError(s.T(), err, "IncrementWaitCount 1")
@@ -187,7 +186,7 @@ func (s *ConcurrencyCacheSuite) TestWaitQueue_IncrementAndDecrement() {
 
 func (s *ConcurrencyCacheSuite) TestWaitQueue_DecrementNoNegative() {
 	userID := int64(300)
-	waitKey := fmt.Sprintf("%s%d", waitQueueKeyPrefix, userID)
+	waitKey := waitQueueKey(userID)
 
 	// Test decrement on non-existent key - should not error and should not create negative value
 	require.NoError(s.T(), s.cache.DecrementWaitCount(s.ctx, userID), "DecrementWaitCount on non-existent key")
@@ -220,7 +219,7 @@ func (s *ConcurrencyCacheSuite) TestWaitQueue_DecrementNoNegative() {
 
 func (s *ConcurrencyCacheSuite) TestAccountWaitQueue_IncrementAndDecrement() {
 	accountID := int64(30)
-	waitKey := fmt.Sprintf("%s%d", accountWaitKeyPrefix, accountID)
+	waitKey := accountWaitKey(accountID)
 
 	ok, err := s.cache.IncrementAccountWaitCount(s.ctx, accountID, 2)
 	require.NoError(s.T(), err, "IncrementAccountWaitCount 1")
@@ -249,7 +248,7 @@ func (s *ConcurrencyCacheSuite) TestAccountWaitQueue_IncrementAndDecrement() {
 
 func (s *ConcurrencyCacheSuite) TestAccountWaitQueue_DecrementNoNegative() {
 	accountID := int64(301)
-	waitKey := fmt.Sprintf("%s%d", accountWaitKeyPrefix, accountID)
+	waitKey := accountWaitKey(accountID)
 
 	require.NoError(s.T(), s.cache.DecrementAccountWaitCount(s.ctx, accountID), "DecrementAccountWaitCount on non-existent key")
 
@@ -344,7 +343,7 @@ func (s *ConcurrencyCacheSuite) TestGetAccountsLoadBatch_Empty() {
 
 func (s *ConcurrencyCacheSuite) TestCleanupExpiredAccountSlots() {
 	accountID := int64(200)
-	slotKey := fmt.Sprintf("%s%d", accountSlotKeyPrefix, accountID)
+	slotKey := accountSlotKey(accountID)
 
 	// Acquire 3 slots
 	ok, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req1")
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+// EnvelopeCipher implements service.SecretCipher using AES-256-GCM envelope encryption.
+// 每个值生成随机 32 字节数据密钥（DEK）加密明文，DEK 再由主密钥（KEK）加密；
+// 输出格式：enc:v1:<key_id>:base64(nonce+wrapped_dek):base64(nonce+ciphertext)
+type EnvelopeCipher struct {
+	activeKeyID string
+	keys        map[string][]byte
+}
+
+// NewEnvelopeCipher creates the envelope cipher from security.credential_encryption
+func NewEnvelopeCipher(cfg *config.Config) (service.SecretCipher, error) {
+	encCfg := cfg.Security.CredentialEncryption
+	keys := make(map[string][]byte, len(encCfg.Keys))
+	for id, hexKey := range encCfg.Keys {
+		key, err := hex.DecodeString(strings.TrimSpace(hexKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential encryption key %q: %w", id, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("credential encryption key %q must be 32 bytes (64 hex chars), got %d bytes", id, len(key))
+		}
+		keys[id] = key
+	}
+	if encCfg.ActiveKeyID != "" {
+		if _, ok := keys[encCfg.ActiveKeyID]; !ok {
+			return nil, fmt.Errorf("credential encryption active key %q not configured", encCfg.ActiveKeyID)
+		}
+	}
+	return &EnvelopeCipher{activeKeyID: encCfg.ActiveKeyID, keys: keys}, nil
+}
+
+func (e *EnvelopeCipher) Enabled() bool {
+	return e != nil && e.activeKeyID != ""
+}
+
+func (e *EnvelopeCipher) ActiveKeyID() string {
+	if e == nil {
+		return ""
+	}
+	return e.activeKeyID
+}
+
+// Encrypt encrypts plaintext with a fresh data key wrapped by the active master key
+func (e *EnvelopeCipher) Encrypt(plaintext string) (string, error) {
+	if !e.Enabled() || plaintext == "" {
+		return plaintext, nil
+	}
+
+	dek := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return "", fmt.Errorf("generate data key: %w", err)
+	}
+	wrapped, err := gcmSeal(e.keys[e.activeKeyID], dek)
+	if err != nil {
+		return "", fmt.Errorf("wrap data key: %w", err)
+	}
+	data, err := gcmSeal(dek, []byte(plaintext))
+	if err != nil {
+		return "", fmt.Errorf("encrypt: %w", err)
+	}
+
+	return service.EncryptedSecretPrefix + e.activeKeyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(data), nil
+}
+
+// Decrypt decrypts an envelope; values without the envelope prefix are returned unchanged
+func (e *EnvelopeCipher) Decrypt(value string) (string, error) {
+	if !service.IsEncryptedSecret(value) {
+		return value, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(value, service.EncryptedSecretPrefix), ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	kek, ok := e.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("credential encryption key %q not configured", parts[0])
+	}
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("decode data key: %w", err)
+	}
+	data, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("decode ciphertext: %w", err)
+	}
+	dek, err := gcmOpen(kek, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("unwrap data key: %w", err)
+	}
+	plaintext, err := gcmOpen(dek, data)
+	if err != nil {
+		return "", fmt.Errorf("decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReencrypt reports whether value is plaintext or sealed with a non-active key
+func (e *EnvelopeCipher) NeedsReencrypt(value string) bool {
+	if !e.Enabled() || value == "" {
+		return false
+	}
+	return service.EncryptedSecretKeyID(value) != e.activeKeyID
+}
+
+// gcmSeal output format: nonce + ciphertext + tag
+func gcmSeal(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func gcmOpen(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+}
+
+// decryptAccountSecrets 解密账号凭证中的密文字段。
+// 解密失败时记录日志并保留原值，避免单条损坏数据（或缺失的旧主密钥）导致整个列表查询失败。
+func decryptAccountSecrets(secretCipher service.SecretCipher, account *service.Account) {
+	if secretCipher == nil || account == nil {
+		return
+	}
+	if err := service.DecryptCredentialSecrets(secretCipher, account.Credentials); err != nil {
+		log.Printf("[CredentialEncryption] decrypt account credentials failed: account=%d err=%v", account.ID, err)
+	}
+}
+
+// decryptProxySecret 解密代理密码
+func decryptProxySecret(secretCipher service.SecretCipher, p *service.Proxy) {
+	if secretCipher == nil || p == nil || !service.IsEncryptedSecret(p.Password) {
+		return
+	}
+	plain, err := secretCipher.Decrypt(p.Password)
+	if err != nil {
+		log.Printf("[CredentialEncryption] decrypt proxy password failed: proxy=%d err=%v", p.ID, err)
+		return
+	}
+	p.Password = plain
+}
+
+// encryptSecret 使用 cipher 加密单个值；cipher 为 nil 时原样返回
+func encryptSecret(secretCipher service.SecretCipher, value string) (string, error) {
+	if secretCipher == nil || value == "" || service.IsEncryptedSecret(value) {
+		return value, nil
+	}
+	return secretCipher.Encrypt(value)
+}
@@ -0,0 +1,95 @@
+//go:build unit
+
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEnvelopeKeyA = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	testEnvelopeKeyB = "1f1e1d1c1b1a191817161514131211100f0e0d0c0b0a09080706050403020100"
+)
+
+func newTestEnvelopeCipher(t *testing.T, active string, keys map[string]string) service.SecretCipher {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Security.CredentialEncryption.ActiveKeyID = active
+	cfg.Security.CredentialEncryption.Keys = keys
+	c, err := NewEnvelopeCipher(cfg)
+	require.NoError(t, err)
+	return c
+}
+
+func TestEnvelopeCipher_RoundTrip(t *testing.T) {
+	c := newTestEnvelopeCipher(t, "k1", map[string]string{"k1": testEnvelopeKeyA})
+	require.True(t, c.Enabled())
+
+	ciphertext, err := c.Encrypt("sk-ant-secret")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(ciphertext, "enc:v1:k1:"))
+	require.NotContains(t, ciphertext, "sk-ant-secret")
+
+	again, err := c.Encrypt("sk-ant-secret")
+	require.NoError(t, err)
+	require.NotEqual(t, ciphertext, again, "每次加密应使用新的数据密钥")
+
+	plain, err := c.Decrypt(ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, "sk-ant-secret", plain)
+
+	// 历史明文原样返回
+	plain, err = c.Decrypt("legacy-plaintext")
+	require.NoError(t, err)
+	require.Equal(t, "legacy-plaintext", plain)
+}
+
+func TestEnvelopeCipher_Rotation(t *testing.T) {
+	oldCipher := newTestEnvelopeCipher(t, "k1", map[string]string{"k1": testEnvelopeKeyA})
+	ciphertext, err := oldCipher.Encrypt("refresh-token")
+	require.NoError(t, err)
+
+	rotated := newTestEnvelopeCipher(t, "k2", map[string]string{"k1": testEnvelopeKeyA, "k2": testEnvelopeKeyB})
+	require.True(t, rotated.NeedsReencrypt(ciphertext))
+	require.True(t, rotated.NeedsReencrypt("plaintext"))
+
+	plain, err := rotated.Decrypt(ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, "refresh-token", plain)
+
+	reencrypted, err := rotated.Encrypt(plain)
+	require.NoError(t, err)
+	require.Equal(t, "k2", service.EncryptedSecretKeyID(reencrypted))
+	require.False(t, rotated.NeedsReencrypt(reencrypted))
+
+	// 旧 key 移除后无法再解密旧密文
+	onlyNew := newTestEnvelopeCipher(t, "k2", map[string]string{"k2": testEnvelopeKeyB})
+	_, err = onlyNew.Decrypt(ciphertext)
+	require.Error(t, err)
+}
+
+func TestEnvelopeCipher_Disabled(t *testing.T) {
+	c := newTestEnvelopeCipher(t, "", nil)
+	require.False(t, c.Enabled())
+	out, err := c.Encrypt("secret")
+	require.NoError(t, err)
+	require.Equal(t, "secret", out)
+	require.False(t, c.NeedsReencrypt("secret"))
+}
+
+func TestNewEnvelopeCipher_InvalidKey(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Security.CredentialEncryption.ActiveKeyID = "k1"
+	cfg.Security.CredentialEncryption.Keys = map[string]string{"k1": "abcd"}
+	_, err := NewEnvelopeCipher(cfg)
+	require.Error(t, err)
+
+	cfg.Security.CredentialEncryption.Keys = map[string]string{"k2": testEnvelopeKeyA}
+	_, err = NewEnvelopeCipher(cfg)
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"log"
 
@@ -50,13 +51,63 @@ func (r *groupRepository) Create(ctx context.Context, groupIn *service.Group) er
 		SetDefaultValidityDays(groupIn.DefaultValidityDays).
 		SetClaudeCodeOnly(groupIn.ClaudeCodeOnly).
 		SetNillableFallbackGroupID(groupIn.FallbackGroupID).
-		SetModelRoutingEnabled(groupIn.ModelRoutingEnabled)
+		SetModelRoutingEnabled(groupIn.ModelRoutingEnabled).
+		SetSchedulingStrategy(groupIn.SchedulingStrategy).
+		SetOveragePolicy(overagePolicyOrDefault(groupIn.OveragePolicy)).
+		SetOverageRateMultiplier(overageRateMultiplierOrDefault(groupIn.OverageRateMultiplier)).
+		SetNillableOverageGroupID(groupIn.OverageGroupID).
+		SetNillableSubscriptionPrice(groupIn.SubscriptionPrice)
 
 	// 设置模型路由配置
 	if groupIn.ModelRouting != nil {
 		builder = builder.SetModelRouting(groupIn.ModelRouting)
 	}
 
+	// 设置内容策略
+	if groupIn.ContentPolicy != nil {
+		raw, err := json.Marshal(groupIn.ContentPolicy)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetContentPolicy(raw)
+	}
+
+	// 设置请求改写规则
+	if len(groupIn.RewriteRules) > 0 {
+		raw, err := json.Marshal(groupIn.RewriteRules)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetRewriteRules(raw)
+	}
+
+	// 设置模型别名
+	if len(groupIn.ModelAliases) > 0 {
+		raw, err := json.Marshal(groupIn.ModelAliases)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetModelAliases(raw)
+	}
+
+	// 设置订阅额度
+	if len(groupIn.UsageQuotas) > 0 {
+		raw, err := json.Marshal(groupIn.UsageQuotas)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetUsageQuotas(raw)
+	}
+
+	// 设置灰度分流
+	if groupIn.TrafficSplit != nil {
+		raw, err := json.Marshal(groupIn.TrafficSplit)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetTrafficSplit(raw)
+	}
+
 	created, err := builder.Save(ctx)
 	if err == nil {
 		groupIn.ID = created.ID
@@ -108,7 +159,10 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 		SetNillableImagePrice4k(groupIn.ImagePrice4K).
 		SetDefaultValidityDays(groupIn.DefaultValidityDays).
 		SetClaudeCodeOnly(groupIn.ClaudeCodeOnly).
-		SetModelRoutingEnabled(groupIn.ModelRoutingEnabled)
+		SetModelRoutingEnabled(groupIn.ModelRoutingEnabled).
+		SetSchedulingStrategy(groupIn.SchedulingStrategy).
+		SetOveragePolicy(overagePolicyOrDefault(groupIn.OveragePolicy)).
+		SetOverageRateMultiplier(overageRateMultiplierOrDefault(groupIn.OverageRateMultiplier))
 
 	// 处理 FallbackGroupID：nil 时清除，否则设置
 	if groupIn.FallbackGroupID != nil {
@@ -117,6 +171,20 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 		builder = builder.ClearFallbackGroupID()
 	}
 
+	// 处理 OverageGroupID：nil 时清除，否则设置
+	if groupIn.OverageGroupID != nil {
+		builder = builder.SetOverageGroupID(*groupIn.OverageGroupID)
+	} else {
+		builder = builder.ClearOverageGroupID()
+	}
+
+	// 处理 SubscriptionPrice：nil 时清除，否则设置
+	if groupIn.SubscriptionPrice != nil {
+		builder = builder.SetSubscriptionPrice(*groupIn.SubscriptionPrice)
+	} else {
+		builder = builder.ClearSubscriptionPrice()
+	}
+
 	// 处理 ModelRouting：nil 时清除，否则设置
 	if groupIn.ModelRouting != nil {
 		builder = builder.SetModelRouting(groupIn.ModelRouting)
@@ -124,6 +192,61 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 		builder = builder.ClearModelRouting()
 	}
 
+	// 处理 ContentPolicy：nil 时清除，否则设置
+	if groupIn.ContentPolicy != nil {
+		raw, err := json.Marshal(groupIn.ContentPolicy)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetContentPolicy(raw)
+	} else {
+		builder = builder.ClearContentPolicy()
+	}
+
+	// 处理 RewriteRules：为空时清除
+	if len(groupIn.RewriteRules) > 0 {
+		raw, err := json.Marshal(groupIn.RewriteRules)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetRewriteRules(raw)
+	} else {
+		builder = builder.ClearRewriteRules()
+	}
+
+	// 处理 ModelAliases：为空时清除
+	if len(groupIn.ModelAliases) > 0 {
+		raw, err := json.Marshal(groupIn.ModelAliases)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetModelAliases(raw)
+	} else {
+		builder = builder.ClearModelAliases()
+	}
+
+	// 处理 UsageQuotas：为空时清除
+	if len(groupIn.UsageQuotas) > 0 {
+		raw, err := json.Marshal(groupIn.UsageQuotas)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetUsageQuotas(raw)
+	} else {
+		builder = builder.ClearUsageQuotas()
+	}
+
+	// 处理 TrafficSplit：nil 时清除
+	if groupIn.TrafficSplit != nil {
+		raw, err := json.Marshal(groupIn.TrafficSplit)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetTrafficSplit(raw)
+	} else {
+		builder = builder.ClearTrafficSplit()
+	}
+
 	updated, err := builder.Save(ctx)
 	if err != nil {
 		return translatePersistenceError(err, service.ErrGroupNotFound, service.ErrGroupExists)
@@ -425,3 +548,18 @@ func (r *groupRepository) loadAccountCounts(ctx context.Context, groupIDs []int6
 
 	return counts, nil
 }
+
+// overagePolicyOrDefault 未设置超额策略时按 reject 入库（直接构造 Group 的调用方可能未经过服务层规范化）
+func overagePolicyOrDefault(policy string) string {
+	if policy == "" {
+		return service.OveragePolicyReject
+	}
+	return policy
+}
+
+func overageRateMultiplierOrDefault(multiplier float64) float64 {
+	if multiplier <= 0 {
+		return 1
+	}
+	return multiplier
+}
@@ -126,6 +126,8 @@ func (s *httpUpstreamService) Do(req *http.Request, proxyURL string, accountID i
 	if err := s.validateRequestHost(req); err != nil {
 		return nil, err
 	}
+	// 分组请求改写规则中的请求头操作（由网关 handler 挂到请求 context 上）
+	service.ApplyRewriteHeaders(req)
 
 	// 获取或创建对应的客户端，并标记请求占用
 	entry, err := s.acquireClient(proxyURL, accountID, accountConcurrency)
@@ -186,6 +188,8 @@ func (s *httpUpstreamService) DoWithTLS(req *http.Request, proxyURL string, acco
 	if err := s.validateRequestHost(req); err != nil {
 		return nil, err
 	}
+	// 分组请求改写规则中的请求头操作（由网关 handler 挂到请求 context 上）
+	service.ApplyRewriteHeaders(req)
 
 	// 获取 TLS 指纹 Profile
 	registry := tlsfingerprint.GlobalRegistry()
@@ -587,8 +591,8 @@ func (s *httpUpstreamService) maxUpstreamClients() int {
 	if s.cfg == nil {
 		return defaultMaxUpstreamClients
 	}
-	if s.cfg.Gateway.MaxUpstreamClients > 0 {
-		return s.cfg.Gateway.MaxUpstreamClients
+	if s.cfg.Live().Gateway.MaxUpstreamClients > 0 {
+		return s.cfg.Live().Gateway.MaxUpstreamClients
 	}
 	return defaultMaxUpstreamClients
 }
@@ -599,8 +603,8 @@ func (s *httpUpstreamService) clientIdleTTL() time.Duration {
 	if s.cfg == nil {
 		return time.Duration(defaultClientIdleTTLSeconds) * time.Second
 	}
-	if s.cfg.Gateway.ClientIdleTTLSeconds > 0 {
-		return time.Duration(s.cfg.Gateway.ClientIdleTTLSeconds) * time.Second
+	if s.cfg.Live().Gateway.ClientIdleTTLSeconds > 0 {
+		return time.Duration(s.cfg.Live().Gateway.ClientIdleTTLSeconds) * time.Second
 	}
 	return time.Duration(defaultClientIdleTTLSeconds) * time.Second
 }
@@ -619,7 +623,7 @@ func (s *httpUpstreamService) clientIdleTTL() time.Duration {
 //   - 账户隔离模式下，连接池大小与账户并发数对应
 //   - 这确保了单账户不会占用过多连接资源
 func (s *httpUpstreamService) resolvePoolSettings(isolation string, accountConcurrency int) poolSettings {
-	settings := defaultPoolSettings(s.cfg)
+	settings := defaultPoolSettings(s.cfg.Live())
 	// 账户隔离模式下，根据账户并发数调整连接池大小
 	if (isolation == config.ConnectionPoolIsolationAccount || isolation == config.ConnectionPoolIsolationAccountProxy) && accountConcurrency > 0 {
 		settings.maxIdleConns = accountConcurrency
@@ -639,12 +643,17 @@ func (s *httpUpstreamService) resolvePoolSettings(isolation string, accountConcu
 // 返回:
 //   - string: 配置键
 func (s *httpUpstreamService) buildPoolKey(isolation string, accountConcurrency int) string {
+	key := "default"
 	if isolation == config.ConnectionPoolIsolationAccount || isolation == config.ConnectionPoolIsolationAccountProxy {
 		if accountConcurrency > 0 {
-			return fmt.Sprintf("account:%d", accountConcurrency)
+			key = fmt.Sprintf("account:%d", accountConcurrency)
 		}
 	}
-	return "default"
+	// 连接池参数纳入池键：配置热重载后新请求按新参数重建客户端，进行中的请求不受影响
+	settings := defaultPoolSettings(s.cfg.Live())
+	return fmt.Sprintf("%s|%d/%d/%d/%s/%s", key,
+		settings.maxIdleConns, settings.maxIdleConnsPerHost, settings.maxConnsPerHost,
+		settings.idleConnTimeout, settings.responseHeaderTimeout)
 }
 
 // buildCacheKey 构建客户端缓存键
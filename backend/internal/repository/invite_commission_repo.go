@@ -0,0 +1,490 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/commissionwithdrawal"
+	"github.com/Wei-Shaw/sub2api/ent/invitecommission"
+	"github.com/Wei-Shaw/sub2api/ent/user"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type inviteCommissionRepository struct {
+	client *dbent.Client
+}
+
+func NewInviteCommissionRepository(client *dbent.Client) service.InviteCommissionRepository {
+	return &inviteCommissionRepository{client: client}
+}
+
+// GetSettledUsageLogWatermark 按 ID 倒序找到第一条 settleBefore 之前写入的日志。
+// 游标只按 ID 推进，settle 延迟只用于选取 ID 上限，不参与逐行过滤。
+func (r *inviteCommissionRepository) GetSettledUsageLogWatermark(ctx context.Context, settleBefore time.Time) (int64, error) {
+	rows, err := r.client.QueryContext(ctx, `
+		SELECT id FROM usage_logs
+		WHERE created_at < $1
+		ORDER BY id DESC
+		LIMIT 1
+	`, settleBefore)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var watermark int64
+	if rows.Next() {
+		if err := rows.Scan(&watermark); err != nil {
+			return 0, err
+		}
+	}
+	return watermark, rows.Err()
+}
+
+// ListAccrualCandidates 查找游标与 watermark 之间存在扣余额消费的邀请关系。
+// days > 0 时仅统计邀请关系建立后 days 天内的消费。
+func (r *inviteCommissionRepository) ListAccrualCandidates(ctx context.Context, watermark int64, days int, limit int) ([]int64, error) {
+	rows, err := r.client.QueryContext(ctx, `
+		SELECT i.id
+		FROM user_invites i
+		WHERE i.commission_status = $1
+			AND i.commission_cursor < $2
+			AND EXISTS (
+				SELECT 1 FROM usage_logs ul
+				WHERE ul.user_id = i.invitee_id
+					AND ul.id > i.commission_cursor
+					AND ul.id <= $2
+					AND ul.billing_type IN ($5, $6)
+					AND ($3::int = 0 OR ul.created_at < i.created_at + make_interval(days => $3::int))
+			)
+		ORDER BY i.id
+		LIMIT $4
+	`, service.InviteCommissionStatusActive, watermark, days, limit,
+		service.BillingTypeBalance, service.BillingTypeSubscriptionOverage)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *inviteCommissionRepository) GetTargetForUpdate(ctx context.Context, inviteID int64) (*service.InviteCommissionTarget, error) {
+	client := clientFromContext(ctx, r.client)
+	rows, err := client.QueryContext(ctx, `
+		SELECT id, inviter_id, invitee_id, commission_status, commission_cursor, created_at
+		FROM user_invites
+		WHERE id = $1
+		FOR UPDATE
+	`, inviteID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, service.ErrInviteNotFound
+	}
+	var out service.InviteCommissionTarget
+	if err := rows.Scan(&out.InviteID, &out.InviterID, &out.InviteeID, &out.Status, &out.Cursor, &out.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &out, rows.Err()
+}
+
+// SumInviteeUsage 只统计实际扣余额的消费（余额计费与订阅超额），订阅额度内的请求不计佣
+func (r *inviteCommissionRepository) SumInviteeUsage(ctx context.Context, inviteeID, afterLogID, watermark int64, windowEnd *time.Time) (*service.CommissionUsageBatch, error) {
+	client := clientFromContext(ctx, r.client)
+	rows, err := client.QueryContext(ctx, `
+		SELECT COALESCE(SUM(actual_cost), 0), COALESCE(MIN(id), 0), COALESCE(MAX(id), 0), COUNT(*)
+		FROM usage_logs
+		WHERE user_id = $1 AND id > $2 AND id <= $3
+			AND billing_type IN ($4, $5)
+			AND ($6::timestamptz IS NULL OR created_at < $6)
+	`, inviteeID, afterLogID, watermark, service.BillingTypeBalance, service.BillingTypeSubscriptionOverage, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out service.CommissionUsageBatch
+	if rows.Next() {
+		if err := rows.Scan(&out.BaseAmount, &out.FromLogID, &out.ToLogID, &out.LogCount); err != nil {
+			return nil, err
+		}
+	}
+	return &out, rows.Err()
+}
+
+func (r *inviteCommissionRepository) CreateCommission(ctx context.Context, commission *service.InviteCommission) error {
+	if commission == nil {
+		return nil
+	}
+	client := clientFromContext(ctx, r.client)
+	created, err := client.InviteCommission.Create().
+		SetInviteID(commission.InviteID).
+		SetInviterID(commission.InviterID).
+		SetInviteeID(commission.InviteeID).
+		SetBaseAmount(commission.BaseAmount).
+		SetRate(commission.Rate).
+		SetAmount(commission.Amount).
+		SetFromLogID(commission.FromLogID).
+		SetToLogID(commission.ToLogID).
+		SetLogCount(commission.LogCount).
+		Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, nil, nil)
+	}
+	commission.ID = created.ID
+	commission.CreatedAt = created.CreatedAt
+	return nil
+}
+
+func (r *inviteCommissionRepository) AdvanceCursor(ctx context.Context, inviteID, cursor int64, amount float64) error {
+	client := clientFromContext(ctx, r.client)
+	result, err := client.ExecContext(ctx, `
+		UPDATE user_invites
+		SET commission_cursor = GREATEST(commission_cursor, $2),
+			commission_total = commission_total + $3
+		WHERE id = $1
+	`, inviteID, cursor, amount)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return service.ErrInviteNotFound
+	}
+	return nil
+}
+
+func (r *inviteCommissionRepository) ListCommissions(ctx context.Context, inviterID int64, params pagination.PaginationParams) ([]service.InviteCommission, *pagination.PaginationResult, error) {
+	q := r.client.InviteCommission.Query().Where(invitecommission.InviterIDEQ(inviterID))
+
+	total, err := q.Clone().Count(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, err := q.
+		Offset(params.Offset()).
+		Limit(params.Limit()).
+		Order(dbent.Desc(invitecommission.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inviteeIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		inviteeIDs = append(inviteeIDs, item.InviteeID)
+	}
+	emails, err := r.userEmails(ctx, inviteeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := make([]service.InviteCommission, 0, len(items))
+	for _, item := range items {
+		v := inviteCommissionEntityToService(item)
+		v.InviteeEmail = emails[item.InviteeID]
+		out = append(out, *v)
+	}
+	return out, paginationResultFromTotal(int64(total), params), nil
+}
+
+func (r *inviteCommissionRepository) GetStats(ctx context.Context, inviterID int64, todayStart, last30Start time.Time) (*service.InviteCommissionStats, error) {
+	rows, err := r.client.QueryContext(ctx, `
+		SELECT
+			COALESCE((SELECT commission_balance FROM users WHERE id = $1), 0),
+			COALESCE((SELECT SUM(amount) FROM invite_commissions WHERE inviter_id = $1), 0),
+			COALESCE((SELECT SUM(amount) FROM invite_commissions WHERE inviter_id = $1 AND created_at >= $2), 0),
+			COALESCE((SELECT SUM(amount) FROM invite_commissions WHERE inviter_id = $1 AND created_at >= $3), 0),
+			COALESCE((SELECT SUM(amount) FROM commission_withdrawals WHERE user_id = $1 AND status = $4), 0),
+			COALESCE((SELECT SUM(amount) FROM commission_withdrawals WHERE user_id = $1 AND status = $5), 0),
+			(SELECT COUNT(*) FROM user_invites WHERE inviter_id = $1 AND commission_status = $6),
+			(SELECT COUNT(*) FROM user_invites WHERE inviter_id = $1 AND commission_status = $7)
+	`, inviterID, todayStart, last30Start,
+		service.CommissionWithdrawalStatusPending, service.CommissionWithdrawalStatusCompleted,
+		service.InviteCommissionStatusActive, service.InviteCommissionStatusBlocked)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out service.InviteCommissionStats
+	if rows.Next() {
+		if err := rows.Scan(
+			&out.Balance,
+			&out.TotalEarned,
+			&out.TodayEarned,
+			&out.Last30DaysEarned,
+			&out.PendingWithdrawal,
+			&out.TotalWithdrawn,
+			&out.ActiveInvitees,
+			&out.BlockedInvitees,
+		); err != nil {
+			return nil, err
+		}
+	}
+	return &out, rows.Err()
+}
+
+func (r *inviteCommissionRepository) SetCommissionStatus(ctx context.Context, inviteeID int64, status, reason string) error {
+	client := clientFromContext(ctx, r.client)
+	result, err := client.ExecContext(ctx, `
+		UPDATE user_invites
+		SET commission_status = $2, commission_block_reason = $3
+		WHERE invitee_id = $1
+	`, inviteeID, status, reason)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return service.ErrInviteNotFound
+	}
+	return nil
+}
+
+// FindRegistrationConflict 检查被邀请人的注册 IP/设备是否与邀请人本人或邀请人名下其他被邀请人重复
+func (r *inviteCommissionRepository) FindRegistrationConflict(ctx context.Context, inviterID, inviteeID int64, registerIP, registerDevice string) (*service.RegistrationConflict, error) {
+	out := &service.RegistrationConflict{}
+	if registerIP == "" && registerDevice == "" {
+		return out, nil
+	}
+	client := clientFromContext(ctx, r.client)
+	rows, err := client.QueryContext(ctx, `
+		SELECT
+			COALESCE(BOOL_OR($3 <> '' AND u.register_ip = $3), FALSE),
+			COALESCE(BOOL_OR($4 <> '' AND u.register_device = $4), FALSE)
+		FROM users u
+		WHERE u.id <> $2
+			AND (u.id = $1 OR u.id IN (SELECT invitee_id FROM user_invites WHERE inviter_id = $1))
+	`, inviterID, inviteeID, registerIP, registerDevice)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if rows.Next() {
+		if err := rows.Scan(&out.SameIP, &out.SameDevice); err != nil {
+			return nil, err
+		}
+	}
+	return out, rows.Err()
+}
+
+func (r *inviteCommissionRepository) GetCommissionBalance(ctx context.Context, userID int64) (float64, error) {
+	client := clientFromContext(ctx, r.client)
+	rows, err := client.QueryContext(ctx, `SELECT commission_balance FROM users WHERE id = $1 AND deleted_at IS NULL`, userID)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, service.ErrUserNotFound
+	}
+	var balance float64
+	if err := rows.Scan(&balance); err != nil {
+		return 0, err
+	}
+	return balance, rows.Err()
+}
+
+func (r *inviteCommissionRepository) UpdateCommissionBalance(ctx context.Context, userID int64, amount float64) error {
+	client := clientFromContext(ctx, r.client)
+	result, err := client.ExecContext(ctx, `
+		UPDATE users
+		SET commission_balance = commission_balance + $2, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL AND commission_balance + $2 >= 0
+	`, userID, amount)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		if amount < 0 {
+			return service.ErrCommissionInsufficientBalance
+		}
+		return service.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *inviteCommissionRepository) CreateWithdrawal(ctx context.Context, withdrawal *service.CommissionWithdrawal) error {
+	if withdrawal == nil {
+		return nil
+	}
+	client := clientFromContext(ctx, r.client)
+	created, err := client.CommissionWithdrawal.Create().
+		SetUserID(withdrawal.UserID).
+		SetAmount(withdrawal.Amount).
+		SetMethod(withdrawal.Method).
+		SetStatus(withdrawal.Status).
+		SetAccountInfo(withdrawal.AccountInfo).
+		SetNillableProcessedBy(withdrawal.ProcessedBy).
+		SetAdminNotes(withdrawal.AdminNotes).
+		SetNillableProcessedAt(withdrawal.ProcessedAt).
+		Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, nil, nil)
+	}
+	withdrawal.ID = created.ID
+	withdrawal.CreatedAt = created.CreatedAt
+	return nil
+}
+
+func (r *inviteCommissionRepository) GetWithdrawalForUpdate(ctx context.Context, id int64) (*service.CommissionWithdrawal, error) {
+	client := clientFromContext(ctx, r.client)
+	m, err := client.CommissionWithdrawal.Query().
+		Where(commissionwithdrawal.IDEQ(id)).
+		ForUpdate().
+		Only(ctx)
+	if err != nil {
+		return nil, translatePersistenceError(err, service.ErrCommissionWithdrawalNotFound, nil)
+	}
+	return commissionWithdrawalEntityToService(m), nil
+}
+
+func (r *inviteCommissionRepository) UpdateWithdrawal(ctx context.Context, withdrawal *service.CommissionWithdrawal) error {
+	if withdrawal == nil {
+		return nil
+	}
+	client := clientFromContext(ctx, r.client)
+	_, err := client.CommissionWithdrawal.UpdateOneID(withdrawal.ID).
+		SetStatus(withdrawal.Status).
+		SetNillableProcessedBy(withdrawal.ProcessedBy).
+		SetAdminNotes(withdrawal.AdminNotes).
+		SetNillableProcessedAt(withdrawal.ProcessedAt).
+		Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, service.ErrCommissionWithdrawalNotFound, nil)
+	}
+	return nil
+}
+
+func (r *inviteCommissionRepository) ListWithdrawals(ctx context.Context, params pagination.PaginationParams, filters service.CommissionWithdrawalFilters) ([]service.CommissionWithdrawal, *pagination.PaginationResult, error) {
+	q := r.client.CommissionWithdrawal.Query()
+	if filters.UserID != nil {
+		q = q.Where(commissionwithdrawal.UserIDEQ(*filters.UserID))
+	}
+	if filters.Method != "" {
+		q = q.Where(commissionwithdrawal.MethodEQ(filters.Method))
+	}
+	if filters.Status != "" {
+		q = q.Where(commissionwithdrawal.StatusEQ(filters.Status))
+	}
+
+	total, err := q.Clone().Count(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, err := q.
+		Offset(params.Offset()).
+		Limit(params.Limit()).
+		Order(dbent.Desc(commissionwithdrawal.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		userIDs = append(userIDs, item.UserID)
+	}
+	emails, err := r.userEmails(ctx, userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := make([]service.CommissionWithdrawal, 0, len(items))
+	for _, item := range items {
+		v := commissionWithdrawalEntityToService(item)
+		v.UserEmail = emails[item.UserID]
+		out = append(out, *v)
+	}
+	return out, paginationResultFromTotal(int64(total), params), nil
+}
+
+func (r *inviteCommissionRepository) userEmails(ctx context.Context, ids []int64) (map[int64]string, error) {
+	out := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	users, err := r.client.User.Query().
+		Where(user.IDIn(ids...)).
+		Select(user.FieldID, user.FieldEmail).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		out[u.ID] = u.Email
+	}
+	return out, nil
+}
+
+func inviteCommissionEntityToService(m *dbent.InviteCommission) *service.InviteCommission {
+	if m == nil {
+		return nil
+	}
+	return &service.InviteCommission{
+		ID:         m.ID,
+		InviteID:   m.InviteID,
+		InviterID:  m.InviterID,
+		InviteeID:  m.InviteeID,
+		BaseAmount: m.BaseAmount,
+		Rate:       m.Rate,
+		Amount:     m.Amount,
+		FromLogID:  m.FromLogID,
+		ToLogID:    m.ToLogID,
+		LogCount:   m.LogCount,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func commissionWithdrawalEntityToService(m *dbent.CommissionWithdrawal) *service.CommissionWithdrawal {
+	if m == nil {
+		return nil
+	}
+	return &service.CommissionWithdrawal{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		Amount:      m.Amount,
+		Method:      m.Method,
+		Status:      m.Status,
+		AccountInfo: m.AccountInfo,
+		ProcessedBy: m.ProcessedBy,
+		AdminNotes:  m.AdminNotes,
+		ProcessedAt: m.ProcessedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
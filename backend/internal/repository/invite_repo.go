@@ -23,15 +23,19 @@ func (r *inviteRepository) Create(ctx context.Context, invite *service.Invite) e
 		return nil
 	}
 	client := clientFromContext(ctx, r.client)
-	created, err := client.Invitation.Create().
+	builder := client.Invitation.Create().
 		SetInviterID(invite.InviterID).
 		SetInviteeID(invite.InviteeID).
 		SetInviteCode(invite.InviteCode).
 		SetRewardAmount(invite.RewardAmount).
 		SetStatus(invite.Status).
 		SetNillableConfirmedBy(invite.ConfirmedBy).
-		SetNillableConfirmedAt(invite.ConfirmedAt).
-		Save(ctx)
+		SetNillableConfirmedAt(invite.ConfirmedAt)
+	if invite.CommissionStatus != "" {
+		builder.SetCommissionStatus(invite.CommissionStatus).
+			SetCommissionBlockReason(invite.CommissionBlockReason)
+	}
+	created, err := builder.Save(ctx)
 	if err != nil {
 		return translatePersistenceError(err, nil, service.ErrInviteAlreadyBound)
 	}
@@ -154,6 +158,10 @@ func inviteEntityToService(m *ent.Invitation) *service.Invite {
 		ConfirmedBy:  m.ConfirmedBy,
 		ConfirmedAt:  m.ConfirmedAt,
 		CreatedAt:    m.CreatedAt,
+
+		CommissionStatus:      m.CommissionStatus,
+		CommissionBlockReason: m.CommissionBlockReason,
+		CommissionTotal:       m.CommissionTotal,
 	}
 	if m.Edges.Inviter != nil {
 		out.Inviter = userEntityToService(m.Edges.Inviter)
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	ipProtectionPrefix = "ipguard:"
+	// ipProtectionBanIndexKey 生效中封禁的索引（ZSET，score 为过期时间毫秒），用于管理端列表，避免 SCAN
+	ipProtectionBanIndexKey = ipProtectionPrefix + "bans"
+)
+
+// ipProtectionCounterScript 固定窗口计数：首次写入时设置过期时间
+var ipProtectionCounterScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// ipProtectionStrikeScript 封禁次数：每次封禁都刷新记忆期
+var ipProtectionStrikeScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[1])
+return count
+`)
+
+type ipProtectionCache struct {
+	rdb redis.UniversalClient
+}
+
+// NewIPProtectionCache 创建 IP 防护缓存
+func NewIPProtectionCache(rdb redis.UniversalClient) service.IPProtectionCache {
+	return &ipProtectionCache{rdb: rdb}
+}
+
+// 同一对象的 key 使用相同的 hash tag，保证 Redis Cluster 下位于同一槽位
+func ipProtectionBanKey(subject string) string {
+	return ipProtectionPrefix + "ban:{" + subject + "}"
+}
+
+func ipProtectionStrikeKey(subject string) string {
+	return ipProtectionPrefix + "strike:{" + subject + "}"
+}
+
+func ipProtectionCounterKey(kind, subject string, window time.Duration) string {
+	bucket := time.Now().UnixMilli() / window.Milliseconds()
+	return fmt.Sprintf("%scnt:%s:{%s}:%d", ipProtectionPrefix, kind, subject, bucket)
+}
+
+func (c *ipProtectionCache) GetBan(ctx context.Context, subjects []string) (*service.IPBan, error) {
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.StringCmd, len(subjects))
+	for i, subject := range subjects {
+		cmds[i] = pipe.Get(ctx, ipProtectionBanKey(subject))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	for _, cmd := range cmds {
+		raw, err := cmd.Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var ban service.IPBan
+		if err := json.Unmarshal([]byte(raw), &ban); err != nil {
+			return nil, fmt.Errorf("decode ip ban: %w", err)
+		}
+		return &ban, nil
+	}
+	return nil, nil
+}
+
+func (c *ipProtectionCache) IncrCounter(ctx context.Context, kind, subject string, window time.Duration) (int64, error) {
+	if window < time.Second {
+		window = time.Second
+	}
+	key := ipProtectionCounterKey(kind, subject, window)
+	return ipProtectionCounterScript.Run(ctx, c.rdb, []string{key}, window.Milliseconds()).Int64()
+}
+
+func (c *ipProtectionCache) IncrStrike(ctx context.Context, subject string, ttl time.Duration) (int64, error) {
+	return ipProtectionStrikeScript.Run(ctx, c.rdb, []string{ipProtectionStrikeKey(subject)}, ttl.Milliseconds()).Int64()
+}
+
+func (c *ipProtectionCache) SetBan(ctx context.Context, ban *service.IPBan) error {
+	ttl := time.Until(ban.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	raw, err := json.Marshal(ban)
+	if err != nil {
+		return err
+	}
+	if err := c.rdb.Set(ctx, ipProtectionBanKey(ban.Subject), raw, ttl).Err(); err != nil {
+		return err
+	}
+	return c.rdb.ZAdd(ctx, ipProtectionBanIndexKey, redis.Z{
+		Score:  float64(ban.ExpiresAt.UnixMilli()),
+		Member: ban.Subject,
+	}).Err()
+}
+
+func (c *ipProtectionCache) ListBans(ctx context.Context) ([]*service.IPBan, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if err := c.rdb.ZRemRangeByScore(ctx, ipProtectionBanIndexKey, "-inf", "("+now).Err(); err != nil {
+		return nil, err
+	}
+	subjects, err := c.rdb.ZRangeByScore(ctx, ipProtectionBanIndexKey, &redis.ZRangeBy{Min: now, Max: "+inf"}).Result()
+	if err != nil {
+		return nil, err
+	}
+	bans := make([]*service.IPBan, 0, len(subjects))
+	for _, subject := range subjects {
+		ban, err := c.GetBan(ctx, []string{subject})
+		if err != nil {
+			return nil, err
+		}
+		if ban != nil {
+			bans = append(bans, ban)
+		}
+	}
+	return bans, nil
+}
+
+func (c *ipProtectionCache) DeleteBan(ctx context.Context, subject string) (bool, error) {
+	deleted, err := c.rdb.Del(ctx, ipProtectionBanKey(subject), ipProtectionStrikeKey(subject)).Result()
+	if err != nil {
+		return false, err
+	}
+	removed, err := c.rdb.ZRem(ctx, ipProtectionBanIndexKey, subject).Result()
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0 || removed > 0, nil
+}
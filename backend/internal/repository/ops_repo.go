@@ -62,9 +62,10 @@ INSERT INTO ops_error_logs (
   request_headers,
   is_retryable,
   retry_count,
-  created_at
+  created_at,
+  traffic_arm
 ) VALUES (
-  $1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30,$31,$32,$33,$34
+  $1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30,$31,$32,$33,$34,$35
 ) RETURNING id`
 
 	var id int64
@@ -105,6 +106,7 @@ INSERT INTO ops_error_logs (
 		input.IsRetryable,
 		input.RetryCount,
 		input.CreatedAt,
+		opsNullString(input.TrafficArm),
 	).Scan(&id)
 	if err != nil {
 		return 0, err
@@ -946,8 +948,9 @@ func buildOpsErrorLogsWhere(filter *service.OpsErrorLogFilter) (string, []any) {
 	if filter != nil {
 		resolvedFilter = filter.Resolved
 	}
-	// Keep list endpoints scoped to client errors unless explicitly filtering upstream phase.
-	if phaseFilter != "upstream" {
+	// Keep list endpoints scoped to client errors unless explicitly filtering upstream/policy phase
+	// (policy events from redact/log actions are recorded with the final client status).
+	if phaseFilter != "upstream" && phaseFilter != "policy" {
 		clauses = append(clauses, "COALESCE(status_code, 0) >= 400")
 	}
 
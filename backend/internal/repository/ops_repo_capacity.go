@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+func (r *opsRepository) InsertCapacitySnapshots(ctx context.Context, items []*service.OpsCapacitySnapshot) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("nil ops repository")
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	const cols = 10
+	placeholders := make([]string, 0, len(items))
+	args := make([]any, 0, len(items)*cols)
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		createdAt := item.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now().UTC()
+		}
+		window := item.WindowSeconds
+		if window <= 0 {
+			window = 60
+		}
+
+		base := len(args)
+		ph := make([]string, cols)
+		for i := 0; i < cols; i++ {
+			ph[i] = fmt.Sprintf("$%d", base+i+1)
+		}
+		placeholders = append(placeholders, "("+strings.Join(ph, ",")+")")
+		args = append(args,
+			createdAt,
+			window,
+			item.Platform,
+			opsNullInt64(item.GroupID),
+			item.TotalAccounts,
+			item.AvailableAccounts,
+			item.RateLimitedAccounts,
+			item.MaxConcurrency,
+			item.CurrentInUse,
+			item.WaitingInQueue,
+		)
+	}
+	if len(placeholders) == 0 {
+		return nil
+	}
+
+	q := `
+INSERT INTO ops_capacity_snapshots (
+  created_at,
+  window_seconds,
+  platform,
+  group_id,
+  total_accounts,
+  available_accounts,
+  rate_limited_accounts,
+  max_concurrency,
+  current_in_use,
+  waiting_in_queue
+) VALUES ` + strings.Join(placeholders, ",")
+
+	_, err := r.db.ExecContext(ctx, q, args...)
+	return err
+}
+
+func (r *opsRepository) GetCapacitySnapshotStats(ctx context.Context, startTime, endTime time.Time) ([]*service.OpsCapacitySnapshotStats, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("nil ops repository")
+	}
+
+	q := `
+SELECT
+  platform,
+  group_id,
+  COUNT(*) AS sample_count,
+  COALESCE(MAX(current_in_use), 0) AS peak_in_use,
+  COALESCE(AVG(current_in_use), 0) AS avg_in_use,
+  COALESCE(MAX(waiting_in_queue), 0) AS peak_waiting,
+  COALESCE(SUM(window_seconds) FILTER (WHERE available_accounts = 0 AND total_accounts > 0), 0) AS zero_available_seconds,
+  COALESCE(SUM(window_seconds), 0) AS covered_seconds
+FROM ops_capacity_snapshots
+WHERE created_at >= $1 AND created_at < $2
+GROUP BY platform, group_id`
+
+	rows, err := r.db.QueryContext(ctx, q, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]*service.OpsCapacitySnapshotStats, 0, 16)
+	for rows.Next() {
+		var item service.OpsCapacitySnapshotStats
+		var groupID sql.NullInt64
+		if err := rows.Scan(
+			&item.Platform,
+			&groupID,
+			&item.SampleCount,
+			&item.PeakInUse,
+			&item.AvgInUse,
+			&item.PeakWaiting,
+			&item.ZeroAvailableSecond,
+			&item.CoveredSeconds,
+		); err != nil {
+			return nil, err
+		}
+		if groupID.Valid {
+			v := groupID.Int64
+			item.GroupID = &v
+		}
+		out = append(out, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *opsRepository) GetCapacityUsageHourly(ctx context.Context, startTime, endTime time.Time) ([]*service.OpsCapacityUsageHourly, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("nil ops repository")
+	}
+
+	q := `
+SELECT
+  ul.group_id,
+  COALESCE(a.platform, '') AS platform,
+  date_trunc('hour', ul.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS hour_start,
+  COUNT(*) AS requests,
+  COALESCE(SUM(ul.total_cost), 0) AS total_cost
+FROM usage_logs ul
+LEFT JOIN accounts a ON a.id = ul.account_id
+WHERE ul.created_at >= $1 AND ul.created_at < $2
+GROUP BY ul.group_id, a.platform, hour_start
+ORDER BY hour_start ASC`
+
+	rows, err := r.db.QueryContext(ctx, q, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]*service.OpsCapacityUsageHourly, 0, 256)
+	for rows.Next() {
+		var item service.OpsCapacityUsageHourly
+		var groupID sql.NullInt64
+		if err := rows.Scan(
+			&groupID,
+			&item.Platform,
+			&item.HourStart,
+			&item.Requests,
+			&item.TotalCost,
+		); err != nil {
+			return nil, err
+		}
+		if groupID.Valid {
+			v := groupID.Int64
+			item.GroupID = &v
+		}
+		item.HourStart = item.HourStart.UTC()
+		out = append(out, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAccountRateLimitHitsDaily counts upstream 429s per account per UTC day.
+//
+// Each upstream error event (ops_error_logs.upstream_errors) counts as one hit, so a request that
+// failed over across several rate-limited accounts attributes the 429 to every account involved.
+// Rows without upstream events fall back to the row-level account_id/upstream_status_code.
+func (r *opsRepository) GetAccountRateLimitHitsDaily(ctx context.Context, startTime, endTime time.Time) ([]*service.OpsAccountRateLimitDaily, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("nil ops repository")
+	}
+
+	q := `
+WITH hits AS (
+  SELECT
+    (ev->>'account_id')::bigint AS account_id,
+    e.created_at
+  FROM ops_error_logs e
+  CROSS JOIN LATERAL jsonb_array_elements(
+    CASE WHEN jsonb_typeof(e.upstream_errors) = 'array' THEN e.upstream_errors ELSE '[]'::jsonb END
+  ) AS ev
+  WHERE e.created_at >= $1 AND e.created_at < $2
+    AND COALESCE(ev->>'upstream_status_code', '') = '429'
+    AND COALESCE(ev->>'account_id', '') ~ '^[0-9]+$'
+
+  UNION ALL
+
+  SELECT e.account_id, e.created_at
+  FROM ops_error_logs e
+  WHERE e.created_at >= $1 AND e.created_at < $2
+    AND e.account_id IS NOT NULL
+    AND e.upstream_status_code = 429
+    AND (e.upstream_errors IS NULL OR jsonb_typeof(e.upstream_errors) <> 'array' OR jsonb_array_length(e.upstream_errors) = 0)
+)
+SELECT
+  h.account_id,
+  COALESCE(a.name, '') AS account_name,
+  COALESCE(a.platform, '') AS platform,
+  date_trunc('day', h.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day,
+  COUNT(*) AS hits
+FROM hits h
+LEFT JOIN accounts a ON a.id = h.account_id
+WHERE h.account_id > 0
+GROUP BY h.account_id, a.name, a.platform, day
+ORDER BY day ASC, hits DESC`
+
+	rows, err := r.db.QueryContext(ctx, q, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]*service.OpsAccountRateLimitDaily, 0, 64)
+	for rows.Next() {
+		var item service.OpsAccountRateLimitDaily
+		if err := rows.Scan(
+			&item.AccountID,
+			&item.AccountName,
+			&item.Platform,
+			&item.Day,
+			&item.Hits,
+		); err != nil {
+			return nil, err
+		}
+		item.Day = item.Day.UTC()
+		out = append(out, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
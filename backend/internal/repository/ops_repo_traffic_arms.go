@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+func (r *opsRepository) GetTrafficArmStats(ctx context.Context, filter *service.OpsDashboardFilter) (*service.OpsTrafficArmsResponse, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("nil ops repository")
+	}
+	if filter == nil || filter.GroupID == nil || *filter.GroupID <= 0 {
+		return nil, fmt.Errorf("group_id required")
+	}
+	if filter.StartTime.IsZero() || filter.EndTime.IsZero() {
+		return nil, fmt.Errorf("start_time/end_time required")
+	}
+
+	start := filter.StartTime.UTC()
+	end := filter.EndTime.UTC()
+	byArm := map[string]*service.OpsTrafficArmStats{}
+	armOf := func(name string) *service.OpsTrafficArmStats {
+		if s, ok := byArm[name]; ok {
+			return s
+		}
+		s := &service.OpsTrafficArmStats{Arm: name}
+		byArm[name] = s
+		return s
+	}
+
+	// 成功请求：usage_logs（分流启用后写入 traffic_arm，可命中 (group_id, traffic_arm, created_at) 部分索引）
+	join, where, args, _ := buildUsageWhere(filter, start, end, 1)
+	usageQ := `
+SELECT
+  ul.traffic_arm,
+  COUNT(*) AS success_count,
+  COALESCE(SUM(ul.input_tokens + ul.output_tokens + ul.cache_creation_tokens + ul.cache_read_tokens), 0) AS token_consumed,
+  COALESCE(SUM(ul.actual_cost), 0) AS total_cost,
+  AVG(ul.duration_ms) AS duration_avg,
+  percentile_cont(0.50) WITHIN GROUP (ORDER BY ul.duration_ms) AS duration_p50,
+  percentile_cont(0.95) WITHIN GROUP (ORDER BY ul.duration_ms) AS duration_p95,
+  AVG(ul.first_token_ms) AS ttft_avg,
+  percentile_cont(0.95) WITHIN GROUP (ORDER BY ul.first_token_ms) AS ttft_p95
+FROM usage_logs ul
+` + join + `
+` + where + `
+  AND ul.traffic_arm IS NOT NULL
+GROUP BY ul.traffic_arm`
+
+	rows, err := r.db.QueryContext(ctx, usageQ, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var arm string
+		var success, tokens int64
+		var cost float64
+		var durAvg, durP50, durP95, ttftAvg, ttftP95 sql.NullFloat64
+		if err := rows.Scan(&arm, &success, &tokens, &cost, &durAvg, &durP50, &durP95, &ttftAvg, &ttftP95); err != nil {
+			return nil, err
+		}
+		s := armOf(arm)
+		s.SuccessCount = success
+		s.TokenConsumed = tokens
+		s.TotalCost = cost
+		s.DurationAvgMs = floatToIntPtr(durAvg)
+		s.DurationP50Ms = floatToIntPtr(durP50)
+		s.DurationP95Ms = floatToIntPtr(durP95)
+		s.TTFTAvgMs = floatToIntPtr(ttftAvg)
+		s.TTFTP95Ms = floatToIntPtr(ttftP95)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// 失败请求：ops_error_logs，口径与 SLA 错误一致（排除业务限流）
+	errWhere, errArgs, _ := buildErrorWhere(filter, start, end, 1)
+	errQ := `
+SELECT
+  traffic_arm,
+  COUNT(*) FILTER (WHERE NOT is_business_limited) AS error_sla
+FROM ops_error_logs
+` + errWhere + `
+  AND traffic_arm IS NOT NULL
+  AND COALESCE(status_code, 0) >= 400
+GROUP BY traffic_arm`
+
+	errRows, err := r.db.QueryContext(ctx, errQ, errArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = errRows.Close() }()
+	for errRows.Next() {
+		var arm string
+		var errCount int64
+		if err := errRows.Scan(&arm, &errCount); err != nil {
+			return nil, err
+		}
+		armOf(arm).ErrorCountSLA = errCount
+	}
+	if err := errRows.Err(); err != nil {
+		return nil, err
+	}
+
+	out := &service.OpsTrafficArmsResponse{GroupID: *filter.GroupID, Arms: make([]*service.OpsTrafficArmStats, 0, len(byArm))}
+	for _, s := range byArm {
+		s.RequestCount = s.SuccessCount + s.ErrorCountSLA
+		s.ErrorRate = roundTo4DP(safeDivideFloat64(float64(s.ErrorCountSLA), float64(s.RequestCount)))
+		s.CostPerRequest = safeDivideFloat64(s.TotalCost, float64(s.SuccessCount))
+		s.CostPerMillionToks = safeDivideFloat64(s.TotalCost*1e6, float64(s.TokenConsumed))
+		out.Arms = append(out.Arms, s)
+	}
+	// control 在前作为基线，其余按名称排序
+	sort.Slice(out.Arms, func(i, j int) bool {
+		ai, aj := out.Arms[i].Arm == service.TrafficArmControl, out.Arms[j].Arm == service.TrafficArmControl
+		if ai != aj {
+			return ai
+		}
+		return out.Arms[i].Arm < out.Arms[j].Arm
+	})
+	return out, nil
+}
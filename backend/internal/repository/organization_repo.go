@@ -0,0 +1,477 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/apikey"
+	"github.com/Wei-Shaw/sub2api/ent/organization"
+	"github.com/Wei-Shaw/sub2api/ent/organizationinvitation"
+	"github.com/Wei-Shaw/sub2api/ent/organizationmember"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type organizationRepository struct {
+	client *dbent.Client
+	sql    sqlExecutor
+}
+
+func NewOrganizationRepository(client *dbent.Client, sqlDB *sql.DB) service.OrganizationRepository {
+	return &organizationRepository{client: client, sql: sqlDB}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, org *service.Organization) error {
+	if org == nil {
+		return nil
+	}
+	client := clientFromContext(ctx, r.client)
+	created, err := client.Organization.Create().
+		SetName(org.Name).
+		SetOwnerUserID(org.OwnerUserID).
+		SetBalance(org.Balance).
+		SetStatus(org.Status).
+		SetNotes(org.Notes).
+		Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, nil, nil)
+	}
+	org.ID = created.ID
+	org.CreatedAt = created.CreatedAt
+	org.UpdatedAt = created.UpdatedAt
+	return nil
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id int64) (*service.Organization, error) {
+	client := clientFromContext(ctx, r.client)
+	m, err := client.Organization.Query().
+		Where(organization.IDEQ(id)).
+		Only(ctx)
+	if err != nil {
+		return nil, translatePersistenceError(err, service.ErrOrganizationNotFound, nil)
+	}
+	out := organizationEntityToService(m)
+	count, err := client.OrganizationMember.Query().
+		Where(organizationmember.OrganizationIDEQ(id)).
+		Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out.MemberCount = count
+	return out, nil
+}
+
+func (r *organizationRepository) Update(ctx context.Context, org *service.Organization) error {
+	if org == nil {
+		return nil
+	}
+	client := clientFromContext(ctx, r.client)
+	updated, err := client.Organization.UpdateOneID(org.ID).
+		SetName(org.Name).
+		SetOwnerUserID(org.OwnerUserID).
+		SetStatus(org.Status).
+		SetNotes(org.Notes).
+		Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, service.ErrOrganizationNotFound, nil)
+	}
+	org.UpdatedAt = updated.UpdatedAt
+	return nil
+}
+
+func (r *organizationRepository) Delete(ctx context.Context, id int64) error {
+	client := clientFromContext(ctx, r.client)
+	n, err := client.Organization.Delete().Where(organization.IDEQ(id)).Exec(ctx)
+	if err != nil {
+		return translatePersistenceError(err, service.ErrOrganizationNotFound, nil)
+	}
+	if n == 0 {
+		return service.ErrOrganizationNotFound
+	}
+	return nil
+}
+
+func (r *organizationRepository) List(ctx context.Context, params pagination.PaginationParams, search string) ([]service.Organization, *pagination.PaginationResult, error) {
+	q := r.client.Organization.Query()
+	if search != "" {
+		q = q.Where(organization.NameContainsFold(search))
+	}
+
+	total, err := q.Clone().Count(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orgs, err := q.
+		Offset(params.Offset()).
+		Limit(params.Limit()).
+		Order(dbent.Desc(organization.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out, err := r.withMemberCounts(ctx, orgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, paginationResultFromTotal(int64(total), params), nil
+}
+
+func (r *organizationRepository) ListByUserID(ctx context.Context, userID int64) ([]service.Organization, error) {
+	orgs, err := r.client.Organization.Query().
+		Where(organization.HasMembersWith(organizationmember.UserIDEQ(userID))).
+		Order(dbent.Asc(organization.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.withMemberCounts(ctx, orgs)
+}
+
+func (r *organizationRepository) withMemberCounts(ctx context.Context, orgs []*dbent.Organization) ([]service.Organization, error) {
+	out := make([]service.Organization, 0, len(orgs))
+	if len(orgs) == 0 {
+		return out, nil
+	}
+	ids := make([]int64, 0, len(orgs))
+	for _, o := range orgs {
+		ids = append(ids, o.ID)
+	}
+
+	var rows []struct {
+		OrganizationID int64 `json:"organization_id"`
+		Count          int   `json:"count"`
+	}
+	if err := r.client.OrganizationMember.Query().
+		Where(organizationmember.OrganizationIDIn(ids...)).
+		GroupBy(organizationmember.FieldOrganizationID).
+		Aggregate(dbent.Count()).
+		Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+	counts := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		counts[row.OrganizationID] = row.Count
+	}
+
+	for _, o := range orgs {
+		item := organizationEntityToService(o)
+		item.MemberCount = counts[o.ID]
+		out = append(out, *item)
+	}
+	return out, nil
+}
+
+func (r *organizationRepository) UpdateBalance(ctx context.Context, id int64, amount float64) error {
+	client := clientFromContext(ctx, r.client)
+	n, err := client.Organization.Update().Where(organization.IDEQ(id)).AddBalance(amount).Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, service.ErrOrganizationNotFound, nil)
+	}
+	if n == 0 {
+		return service.ErrOrganizationNotFound
+	}
+	return nil
+}
+
+// DeductBalance 扣除组织余额
+// 透支策略与用户余额一致：允许变为负数，后续请求由余额检查拦截
+func (r *organizationRepository) DeductBalance(ctx context.Context, id int64, amount float64) error {
+	return r.UpdateBalance(ctx, id, -amount)
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *service.OrganizationMember) error {
+	if member == nil {
+		return nil
+	}
+	client := clientFromContext(ctx, r.client)
+	created, err := client.OrganizationMember.Create().
+		SetOrganizationID(member.OrganizationID).
+		SetUserID(member.UserID).
+		SetRole(member.Role).
+		SetNillableMonthlyLimitUsd(member.MonthlyLimitUSD).
+		Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, nil, service.ErrOrganizationMemberExists)
+	}
+	member.ID = created.ID
+	member.CreatedAt = created.CreatedAt
+	member.UpdatedAt = created.UpdatedAt
+	return nil
+}
+
+func (r *organizationRepository) GetMember(ctx context.Context, orgID, userID int64) (*service.OrganizationMember, error) {
+	client := clientFromContext(ctx, r.client)
+	m, err := client.OrganizationMember.Query().
+		Where(
+			organizationmember.OrganizationIDEQ(orgID),
+			organizationmember.UserIDEQ(userID),
+		).
+		Only(ctx)
+	if err != nil {
+		return nil, translatePersistenceError(err, service.ErrOrganizationMemberNotFound, nil)
+	}
+	return organizationMemberEntityToService(m), nil
+}
+
+func (r *organizationRepository) ListMembers(ctx context.Context, orgID int64) ([]service.OrganizationMember, error) {
+	members, err := r.client.OrganizationMember.Query().
+		Where(organizationmember.OrganizationIDEQ(orgID)).
+		WithUser().
+		Order(dbent.Asc(organizationmember.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]service.OrganizationMember, 0, len(members))
+	for _, m := range members {
+		out = append(out, *organizationMemberEntityToService(m))
+	}
+	return out, nil
+}
+
+func (r *organizationRepository) UpdateMember(ctx context.Context, member *service.OrganizationMember) error {
+	if member == nil {
+		return nil
+	}
+	client := clientFromContext(ctx, r.client)
+	builder := client.OrganizationMember.UpdateOneID(member.ID).
+		SetRole(member.Role)
+	if member.MonthlyLimitUSD != nil {
+		builder.SetMonthlyLimitUsd(*member.MonthlyLimitUSD)
+	} else {
+		builder.ClearMonthlyLimitUsd()
+	}
+	updated, err := builder.Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, service.ErrOrganizationMemberNotFound, nil)
+	}
+	member.UpdatedAt = updated.UpdatedAt
+	return nil
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	client := clientFromContext(ctx, r.client)
+	n, err := client.OrganizationMember.Delete().
+		Where(
+			organizationmember.OrganizationIDEQ(orgID),
+			organizationmember.UserIDEQ(userID),
+		).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return service.ErrOrganizationMemberNotFound
+	}
+	return nil
+}
+
+// IncrementMemberUsage 在单条 SQL 中完成月度窗口重置与累加，避免并发请求丢失更新
+func (r *organizationRepository) IncrementMemberUsage(ctx context.Context, orgID, userID int64, amount float64, windowStart time.Time) error {
+	var exec sqlExecutor = r.sql
+	if tx := dbent.TxFromContext(ctx); tx != nil {
+		exec = tx.Client()
+	}
+	_, err := exec.ExecContext(ctx, `
+		UPDATE organization_members
+		SET monthly_usage_usd = CASE
+				WHEN monthly_window_start IS NULL OR monthly_window_start < $3 THEN $4
+				ELSE monthly_usage_usd + $4
+			END,
+			monthly_window_start = CASE
+				WHEN monthly_window_start IS NULL OR monthly_window_start < $3 THEN $3
+				ELSE monthly_window_start
+			END,
+			updated_at = NOW()
+		WHERE organization_id = $1 AND user_id = $2
+	`, orgID, userID, windowStart, amount)
+	return err
+}
+
+func (r *organizationRepository) DetachAPIKeys(ctx context.Context, orgID, userID int64) (int, error) {
+	client := clientFromContext(ctx, r.client)
+	q := client.APIKey.Update().
+		Where(apikey.OrganizationIDEQ(orgID))
+	if userID > 0 {
+		q = q.Where(apikey.UserIDEQ(userID))
+	}
+	return q.
+		ClearOrganizationID().
+		SetStatus(service.StatusDisabled).
+		Save(ctx)
+}
+
+func (r *organizationRepository) GetMemberUsage(ctx context.Context, orgID int64, startTime, endTime time.Time) ([]service.OrganizationMemberUsage, error) {
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT
+			ul.user_id,
+			COUNT(*) AS total_requests,
+			COALESCE(SUM(ul.input_tokens + ul.output_tokens + ul.cache_creation_tokens + ul.cache_read_tokens), 0) AS total_tokens,
+			COALESCE(SUM(ul.total_cost), 0) AS total_cost,
+			COALESCE(SUM(ul.actual_cost), 0) AS actual_cost
+		FROM usage_logs ul
+		WHERE ul.api_key_id IN (SELECT id FROM api_keys WHERE organization_id = $1)
+			AND ul.created_at >= $2 AND ul.created_at < $3
+		GROUP BY ul.user_id
+		ORDER BY actual_cost DESC
+	`, orgID, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]service.OrganizationMemberUsage, 0)
+	for rows.Next() {
+		var item service.OrganizationMemberUsage
+		if err := rows.Scan(&item.UserID, &item.TotalRequests, &item.TotalTokens, &item.TotalCost, &item.ActualCost); err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *organizationRepository) CreateInvitation(ctx context.Context, invitation *service.OrganizationInvitation) error {
+	if invitation == nil {
+		return nil
+	}
+	client := clientFromContext(ctx, r.client)
+	created, err := client.OrganizationInvitation.Create().
+		SetOrganizationID(invitation.OrganizationID).
+		SetEmail(invitation.Email).
+		SetRole(invitation.Role).
+		SetTokenHash(invitation.TokenHash).
+		SetInvitedBy(invitation.InvitedBy).
+		SetStatus(invitation.Status).
+		SetExpiresAt(invitation.ExpiresAt).
+		Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, nil, nil)
+	}
+	invitation.ID = created.ID
+	invitation.CreatedAt = created.CreatedAt
+	return nil
+}
+
+func (r *organizationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*service.OrganizationInvitation, error) {
+	client := clientFromContext(ctx, r.client)
+	m, err := client.OrganizationInvitation.Query().
+		Where(organizationinvitation.TokenHashEQ(tokenHash)).
+		WithOrganization().
+		Only(ctx)
+	if err != nil {
+		return nil, translatePersistenceError(err, service.ErrOrganizationInvitationNotFound, nil)
+	}
+	return organizationInvitationEntityToService(m), nil
+}
+
+func (r *organizationRepository) GetInvitation(ctx context.Context, id int64) (*service.OrganizationInvitation, error) {
+	client := clientFromContext(ctx, r.client)
+	m, err := client.OrganizationInvitation.Query().
+		Where(organizationinvitation.IDEQ(id)).
+		Only(ctx)
+	if err != nil {
+		return nil, translatePersistenceError(err, service.ErrOrganizationInvitationNotFound, nil)
+	}
+	return organizationInvitationEntityToService(m), nil
+}
+
+func (r *organizationRepository) ListInvitations(ctx context.Context, orgID int64, status string) ([]service.OrganizationInvitation, error) {
+	q := r.client.OrganizationInvitation.Query().
+		Where(organizationinvitation.OrganizationIDEQ(orgID))
+	if status != "" {
+		q = q.Where(organizationinvitation.StatusEQ(status))
+	}
+	items, err := q.Order(dbent.Desc(organizationinvitation.FieldID)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]service.OrganizationInvitation, 0, len(items))
+	for _, m := range items {
+		out = append(out, *organizationInvitationEntityToService(m))
+	}
+	return out, nil
+}
+
+func (r *organizationRepository) UpdateInvitation(ctx context.Context, invitation *service.OrganizationInvitation) error {
+	if invitation == nil {
+		return nil
+	}
+	client := clientFromContext(ctx, r.client)
+	_, err := client.OrganizationInvitation.UpdateOneID(invitation.ID).
+		SetStatus(invitation.Status).
+		SetNillableAcceptedUserID(invitation.AcceptedUserID).
+		SetNillableAcceptedAt(invitation.AcceptedAt).
+		Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, service.ErrOrganizationInvitationNotFound, nil)
+	}
+	return nil
+}
+
+func organizationEntityToService(m *dbent.Organization) *service.Organization {
+	if m == nil {
+		return nil
+	}
+	return &service.Organization{
+		ID:          m.ID,
+		Name:        m.Name,
+		OwnerUserID: m.OwnerUserID,
+		Balance:     m.Balance,
+		Status:      m.Status,
+		Notes:       m.Notes,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func organizationMemberEntityToService(m *dbent.OrganizationMember) *service.OrganizationMember {
+	if m == nil {
+		return nil
+	}
+	out := &service.OrganizationMember{
+		ID:                 m.ID,
+		OrganizationID:     m.OrganizationID,
+		UserID:             m.UserID,
+		Role:               m.Role,
+		MonthlyLimitUSD:    m.MonthlyLimitUsd,
+		MonthlyUsageUSD:    m.MonthlyUsageUsd,
+		MonthlyWindowStart: m.MonthlyWindowStart,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+	if m.Edges.User != nil {
+		out.User = userEntityToService(m.Edges.User)
+	}
+	return out
+}
+
+func organizationInvitationEntityToService(m *dbent.OrganizationInvitation) *service.OrganizationInvitation {
+	if m == nil {
+		return nil
+	}
+	out := &service.OrganizationInvitation{
+		ID:             m.ID,
+		OrganizationID: m.OrganizationID,
+		Email:          m.Email,
+		Role:           m.Role,
+		TokenHash:      m.TokenHash,
+		InvitedBy:      m.InvitedBy,
+		Status:         m.Status,
+		ExpiresAt:      m.ExpiresAt,
+		AcceptedUserID: m.AcceptedUserID,
+		AcceptedAt:     m.AcceptedAt,
+		CreatedAt:      m.CreatedAt,
+	}
+	if m.Edges.Organization != nil {
+		out.Organization = organizationEntityToService(m.Edges.Organization)
+	}
+	return out
+}
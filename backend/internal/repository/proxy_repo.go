@@ -18,10 +18,14 @@ type sqlQuerier interface {
 type proxyRepository struct {
 	client *dbent.Client
 	sql    sqlQuerier
+	// cipher 代理密码的信封加密（nil 表示不加密，用于测试）
+	cipher service.SecretCipher
 }
 
-func NewProxyRepository(client *dbent.Client, sqlDB *sql.DB) service.ProxyRepository {
-	return newProxyRepositoryWithSQL(client, sqlDB)
+func NewProxyRepository(client *dbent.Client, sqlDB *sql.DB, cipher service.SecretCipher) service.ProxyRepository {
+	repo := newProxyRepositoryWithSQL(client, sqlDB)
+	repo.cipher = cipher
+	return repo
 }
 
 func newProxyRepositoryWithSQL(client *dbent.Client, sqlq sqlQuerier) *proxyRepository {
@@ -39,7 +43,11 @@ func (r *proxyRepository) Create(ctx context.Context, proxyIn *service.Proxy) er
 		builder.SetUsername(proxyIn.Username)
 	}
 	if proxyIn.Password != "" {
-		builder.SetPassword(proxyIn.Password)
+		password, err := encryptSecret(r.cipher, proxyIn.Password)
+		if err != nil {
+			return err
+		}
+		builder.SetPassword(password)
 	}
 
 	created, err := builder.Save(ctx)
@@ -57,7 +65,7 @@ func (r *proxyRepository) GetByID(ctx context.Context, id int64) (*service.Proxy
 		}
 		return nil, err
 	}
-	return proxyEntityToService(m), nil
+	return r.toService(m), nil
 }
 
 func (r *proxyRepository) Update(ctx context.Context, proxyIn *service.Proxy) error {
@@ -73,7 +81,11 @@ func (r *proxyRepository) Update(ctx context.Context, proxyIn *service.Proxy) er
 		builder.ClearUsername()
 	}
 	if proxyIn.Password != "" {
-		builder.SetPassword(proxyIn.Password)
+		password, err := encryptSecret(r.cipher, proxyIn.Password)
+		if err != nil {
+			return err
+		}
+		builder.SetPassword(password)
 	} else {
 		builder.ClearPassword()
 	}
@@ -127,7 +139,7 @@ func (r *proxyRepository) ListWithFilters(ctx context.Context, params pagination
 
 	outProxies := make([]service.Proxy, 0, len(proxies))
 	for i := range proxies {
-		outProxies = append(outProxies, *proxyEntityToService(proxies[i]))
+		outProxies = append(outProxies, *r.toService(proxies[i]))
 	}
 
 	return outProxies, paginationResultFromTotal(int64(total), params), nil
@@ -169,7 +181,7 @@ func (r *proxyRepository) ListWithFiltersAndAccountCount(ctx context.Context, pa
 	// Build result with account counts
 	result := make([]service.ProxyWithAccountCount, 0, len(proxies))
 	for i := range proxies {
-		proxyOut := proxyEntityToService(proxies[i])
+		proxyOut := r.toService(proxies[i])
 		if proxyOut == nil {
 			continue
 		}
@@ -191,7 +203,7 @@ func (r *proxyRepository) ListActive(ctx context.Context) ([]service.Proxy, erro
 	}
 	outProxies := make([]service.Proxy, 0, len(proxies))
 	for i := range proxies {
-		outProxies = append(outProxies, *proxyEntityToService(proxies[i]))
+		outProxies = append(outProxies, *r.toService(proxies[i]))
 	}
 	return outProxies, nil
 }
@@ -208,12 +220,21 @@ func (r *proxyRepository) ExistsByHostPortAuth(ctx context.Context, host string,
 	}
 	if password == "" {
 		q = q.Where(proxy.Or(proxy.PasswordIsNil(), proxy.PasswordEQ("")))
-	} else {
-		q = q.Where(proxy.PasswordEQ(password))
+		count, err := q.Count(ctx)
+		return count > 0, err
 	}
 
-	count, err := q.Count(ctx)
-	return count > 0, err
+	// 密码可能以密文存储（每次加密结果不同），只能取出同 host/port/username 的候选后解密比较
+	candidates, err := q.Where(proxy.PasswordNotNil()).All(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range candidates {
+		if r.toService(m).Password == password {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // CountAccountsByProxyID returns the number of accounts using a specific proxy
@@ -313,7 +334,7 @@ func (r *proxyRepository) ListActiveWithAccountCount(ctx context.Context) ([]ser
 	// Build result with account counts
 	result := make([]service.ProxyWithAccountCount, 0, len(proxies))
 	for i := range proxies {
-		proxyOut := proxyEntityToService(proxies[i])
+		proxyOut := r.toService(proxies[i])
 		if proxyOut == nil {
 			continue
 		}
@@ -326,6 +347,13 @@ func (r *proxyRepository) ListActiveWithAccountCount(ctx context.Context) ([]ser
 	return result, nil
 }
 
+// toService 转换实体并解密代理密码
+func (r *proxyRepository) toService(m *dbent.Proxy) *service.Proxy {
+	out := proxyEntityToService(m)
+	decryptProxySecret(r.cipher, out)
+	return out
+}
+
 func proxyEntityToService(m *dbent.Proxy) *service.Proxy {
 	if m == nil {
 		return nil
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
@@ -19,8 +21,20 @@ import (
 // 1. PoolSize: 控制最大并发连接数（默认 128）
 // 2. MinIdleConns: 保持最小空闲连接，减少冷启动延迟（默认 10）
 // 3. DialTimeout/ReadTimeout/WriteTimeout: 精确控制各阶段超时
-func InitRedis(cfg *config.Config) *redis.Client {
-	return redis.NewClient(buildRedisOptions(cfg))
+//
+// 部署模式（redis.mode）：
+// 1. standalone: 单节点，使用 host/port
+// 2. sentinel: 通过哨兵（redis.addrs + redis.master_name）自动发现主节点并在故障时切换
+// 3. cluster: Redis Cluster，redis.addrs 为种子节点；多 key 的 Lua 脚本均使用 hash tag 保证同槽
+func InitRedis(cfg *config.Config) redis.UniversalClient {
+	switch cfg.Redis.NormalizedMode() {
+	case config.RedisModeSentinel:
+		return redis.NewFailoverClient(buildRedisFailoverOptions(cfg))
+	case config.RedisModeCluster:
+		return redis.NewClusterClient(buildRedisClusterOptions(cfg))
+	default:
+		return redis.NewClient(buildRedisOptions(cfg))
+	}
 }
 
 // buildRedisOptions 构建 Redis 连接选项
@@ -37,3 +51,61 @@ func buildRedisOptions(cfg *config.Config) *redis.Options {
 		MinIdleConns: cfg.Redis.MinIdleConns,                                     // 最小空闲连接
 	}
 }
+
+// buildRedisFailoverOptions 构建 sentinel 模式连接选项
+func buildRedisFailoverOptions(cfg *config.Config) *redis.FailoverOptions {
+	return &redis.FailoverOptions{
+		MasterName:       cfg.Redis.MasterName,
+		SentinelAddrs:    cfg.Redis.NodeAddrs(),
+		SentinelPassword: cfg.Redis.SentinelPassword,
+		Password:         cfg.Redis.Password,
+		DB:               cfg.Redis.DB,
+		DialTimeout:      time.Duration(cfg.Redis.DialTimeoutSeconds) * time.Second,
+		ReadTimeout:      time.Duration(cfg.Redis.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout:     time.Duration(cfg.Redis.WriteTimeoutSeconds) * time.Second,
+		PoolSize:         cfg.Redis.PoolSize,
+		MinIdleConns:     cfg.Redis.MinIdleConns,
+	}
+}
+
+// buildRedisClusterOptions 构建 cluster 模式连接选项
+// PoolSize/MinIdleConns 为每个节点的连接池配置
+func buildRedisClusterOptions(cfg *config.Config) *redis.ClusterOptions {
+	return &redis.ClusterOptions{
+		Addrs:          cfg.Redis.NodeAddrs(),
+		Password:       cfg.Redis.Password,
+		RouteByLatency: cfg.Redis.RouteByLatency,
+		RouteRandomly:  cfg.Redis.RouteRandomly,
+		DialTimeout:    time.Duration(cfg.Redis.DialTimeoutSeconds) * time.Second,
+		ReadTimeout:    time.Duration(cfg.Redis.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout:   time.Duration(cfg.Redis.WriteTimeoutSeconds) * time.Second,
+		PoolSize:       cfg.Redis.PoolSize,
+		MinIdleConns:   cfg.Redis.MinIdleConns,
+	}
+}
+
+// redisMGet 批量读取字符串键。
+// cluster 模式下跨槽位的 MGET 会返回 CROSSSLOT 错误，改为管道逐个 GET（go-redis 会按节点拆分管道）；
+// 返回值与 MGET 一致：不存在的键对应 nil。
+func redisMGet(ctx context.Context, rdb redis.UniversalClient, keys ...string) ([]any, error) {
+	if _, ok := rdb.(*redis.ClusterClient); !ok {
+		return rdb.MGet(ctx, keys...).Result()
+	}
+	pipe := rdb.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	values := make([]any, len(keys))
+	for i, cmd := range cmds {
+		val, err := cmd.Result()
+		if err != nil {
+			continue
+		}
+		values[i] = val
+	}
+	return values, nil
+}
@@ -0,0 +1,169 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+// 多节点 Redis 集成测试需要本地预先启动集群/哨兵，通过环境变量指定地址：
+//
+//	docker run -d --name redis-cluster -e IP=0.0.0.0 -p 7000-7005:7000-7005 grokzen/redis-cluster:7.0.10
+//	REDIS_CLUSTER_ADDRS=127.0.0.1:7000,127.0.0.1:7001,127.0.0.1:7002 go test -tags=integration -run RedisCluster ./internal/repository/
+//
+//	REDIS_SENTINEL_ADDRS=127.0.0.1:26379 REDIS_SENTINEL_MASTER=mymaster go test -tags=integration -run RedisSentinel ./internal/repository/
+//
+// 未设置时跳过。
+func multiNodeRedisConfig(t *testing.T, mode string) *config.Config {
+	t.Helper()
+
+	var addrsEnv string
+	cfg := &config.Config{}
+	switch mode {
+	case config.RedisModeCluster:
+		addrsEnv = os.Getenv("REDIS_CLUSTER_ADDRS")
+	case config.RedisModeSentinel:
+		addrsEnv = os.Getenv("REDIS_SENTINEL_ADDRS")
+		cfg.Redis.MasterName = os.Getenv("REDIS_SENTINEL_MASTER")
+		if cfg.Redis.MasterName == "" {
+			cfg.Redis.MasterName = "mymaster"
+		}
+	}
+	if strings.TrimSpace(addrsEnv) == "" {
+		t.Skipf("multi-node redis (%s) not configured", mode)
+	}
+
+	cfg.Redis.Mode = mode
+	cfg.Redis.Addrs = strings.Split(addrsEnv, ",")
+	cfg.Redis.Password = os.Getenv("REDIS_PASSWORD")
+	cfg.Redis.DialTimeoutSeconds = 5
+	cfg.Redis.ReadTimeoutSeconds = 3
+	cfg.Redis.WriteTimeoutSeconds = 3
+	cfg.Redis.PoolSize = 16
+	cfg.Redis.MinIdleConns = 1
+	return cfg
+}
+
+func newMultiNodeRedis(t *testing.T, mode string) redis.UniversalClient {
+	t.Helper()
+
+	rdb := InitRedis(multiNodeRedisConfig(t, mode))
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, rdb.Ping(ctx).Err(), "ping multi-node redis")
+	return rdb
+}
+
+func TestRedisCluster_InitClientType(t *testing.T) {
+	rdb := newMultiNodeRedis(t, config.RedisModeCluster)
+	_, ok := rdb.(*redis.ClusterClient)
+	require.True(t, ok, "expected cluster client")
+}
+
+func TestRedisCluster_ConcurrencyCache(t *testing.T) {
+	rdb := newMultiNodeRedis(t, config.RedisModeCluster)
+	ctx := context.Background()
+	cache := NewConcurrencyCache(rdb, defaultSlotTTLMinutes, 60)
+
+	// 足够多的账号以覆盖多个槽位/节点
+	base := time.Now().UnixNano() % 1_000_000_000
+	accounts := make([]service.AccountWithConcurrency, 0, 32)
+	for i := int64(0); i < 32; i++ {
+		id := base + i
+		accounts = append(accounts, service.AccountWithConcurrency{ID: id, MaxConcurrency: 4})
+		t.Cleanup(func() {
+			_ = rdb.Del(context.Background(), accountSlotKey(id)).Err()
+			_ = rdb.Del(context.Background(), accountWaitKey(id)).Err()
+		})
+	}
+
+	for _, acc := range accounts {
+		ok, err := cache.AcquireAccountSlot(ctx, acc.ID, acc.MaxConcurrency, fmt.Sprintf("req-%d", acc.ID))
+		require.NoError(t, err)
+		require.True(t, ok)
+		ok, err = cache.IncrementAccountWaitCount(ctx, acc.ID, 10)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	// 首次执行时节点上没有脚本缓存，覆盖 NOSCRIPT 回退路径
+	require.NoError(t, rdb.ScriptFlush(ctx).Err())
+
+	loads, err := cache.GetAccountsLoadBatch(ctx, accounts)
+	require.NoError(t, err)
+	require.Len(t, loads, len(accounts))
+	for _, acc := range accounts {
+		load := loads[acc.ID]
+		require.NotNil(t, load)
+		require.Equal(t, 1, load.CurrentConcurrency)
+		require.Equal(t, 1, load.WaitingCount)
+		require.Equal(t, 50, load.LoadRate)
+	}
+
+	require.NoError(t, cache.ReleaseAccountSlot(ctx, accounts[0].ID, fmt.Sprintf("req-%d", accounts[0].ID)))
+	count, err := cache.GetAccountConcurrency(ctx, accounts[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func TestRedisCluster_BillingAndSessionCaches(t *testing.T) {
+	rdb := newMultiNodeRedis(t, config.RedisModeCluster)
+	ctx := context.Background()
+	base := time.Now().UnixNano() % 1_000_000_000
+
+	billing := NewBillingCache(rdb)
+	require.NoError(t, billing.SetUserBalance(ctx, base, 10))
+	t.Cleanup(func() { _ = billing.InvalidateUserBalance(context.Background(), base) })
+	require.NoError(t, billing.DeductUserBalance(ctx, base, 2.5))
+	balance, err := billing.GetUserBalance(ctx, base)
+	require.NoError(t, err)
+	require.InDelta(t, 7.5, balance, 1e-9)
+
+	// 跨槽位的批量读取不应触发 CROSSSLOT
+	sessions := NewSessionLimitCache(rdb, 5)
+	ids := make([]int64, 0, 16)
+	for i := int64(0); i < 16; i++ {
+		id := base + i
+		ids = append(ids, id)
+		require.NoError(t, sessions.SetWindowCost(ctx, id, float64(i)))
+		t.Cleanup(func() { _ = rdb.Del(context.Background(), windowCostKey(id)).Err() })
+	}
+	costs, err := sessions.GetWindowCostBatch(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, costs, len(ids))
+	require.InDelta(t, 15.0, costs[base+15], 1e-9)
+}
+
+func TestRedisSentinel_MasterDiscovery(t *testing.T) {
+	rdb := newMultiNodeRedis(t, config.RedisModeSentinel)
+	ctx := context.Background()
+
+	key := fmt.Sprintf("sentinel:test:%d", time.Now().UnixNano())
+	t.Cleanup(func() { _ = rdb.Del(context.Background(), key).Err() })
+	require.NoError(t, rdb.Set(ctx, key, "ok", time.Minute).Err())
+	val, err := rdb.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, "ok", val)
+
+	cache := NewConcurrencyCache(rdb, defaultSlotTTLMinutes, 60)
+	id := time.Now().UnixNano() % 1_000_000_000
+	t.Cleanup(func() { _ = rdb.Del(context.Background(), accountSlotKey(id)).Err() })
+	ok, err := cache.AcquireAccountSlot(ctx, id, 1, "req-1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = cache.AcquireAccountSlot(ctx, id, 1, "req-2")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
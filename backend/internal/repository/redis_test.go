@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,3 +34,53 @@ func TestBuildRedisOptions(t *testing.T) {
 	require.Equal(t, 100, opts.PoolSize)
 	require.Equal(t, 10, opts.MinIdleConns)
 }
+
+func TestInitRedis_Modes(t *testing.T) {
+	cfg := &config.Config{
+		Redis: config.RedisConfig{
+			Host:                "localhost",
+			Port:                6379,
+			Password:            "secret",
+			DB:                  1,
+			Addrs:               []string{" 10.0.0.1:26379 ", "", "10.0.0.2:26379"},
+			MasterName:          "mymaster",
+			SentinelPassword:    "sentinel-secret",
+			DialTimeoutSeconds:  5,
+			ReadTimeoutSeconds:  3,
+			WriteTimeoutSeconds: 4,
+			PoolSize:            64,
+			MinIdleConns:        8,
+		},
+	}
+
+	failover := buildRedisFailoverOptions(cfg)
+	require.Equal(t, "mymaster", failover.MasterName)
+	require.Equal(t, []string{"10.0.0.1:26379", "10.0.0.2:26379"}, failover.SentinelAddrs)
+	require.Equal(t, "sentinel-secret", failover.SentinelPassword)
+	require.Equal(t, "secret", failover.Password)
+	require.Equal(t, 1, failover.DB)
+	require.Equal(t, 64, failover.PoolSize)
+
+	cfg.Redis.Addrs = nil
+	cluster := buildRedisClusterOptions(cfg)
+	require.Equal(t, []string{"localhost:6379"}, cluster.Addrs)
+	require.Equal(t, 4*time.Second, cluster.WriteTimeout)
+	require.Equal(t, 8, cluster.MinIdleConns)
+
+	client := InitRedis(cfg)
+	_, ok := client.(*redis.Client)
+	require.True(t, ok)
+	_ = client.Close()
+
+	cfg.Redis.Mode = config.RedisModeSentinel
+	client = InitRedis(cfg)
+	_, ok = client.(*redis.Client)
+	require.True(t, ok, "failover client is a *redis.Client")
+	_ = client.Close()
+
+	cfg.Redis.Mode = config.RedisModeCluster
+	client = InitRedis(cfg)
+	_, ok = client.(*redis.ClusterClient)
+	require.True(t, ok)
+	_ = client.Close()
+}
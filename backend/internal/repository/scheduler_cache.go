@@ -23,11 +23,13 @@ const (
 )
 
 type schedulerCache struct {
-	rdb *redis.Client
+	rdb redis.UniversalClient
+	// cipher 写入缓存前加密凭证敏感字段与代理密码，读取时再解密，避免明文密钥落入 Redis
+	cipher service.SecretCipher
 }
 
-func NewSchedulerCache(rdb *redis.Client) service.SchedulerCache {
-	return &schedulerCache{rdb: rdb}
+func NewSchedulerCache(rdb redis.UniversalClient, cipher service.SecretCipher) service.SchedulerCache {
+	return &schedulerCache{rdb: rdb, cipher: cipher}
 }
 
 func (c *schedulerCache) GetSnapshot(ctx context.Context, bucket service.SchedulerBucket) ([]*service.Account, bool, error) {
@@ -67,7 +69,7 @@ func (c *schedulerCache) GetSnapshot(ctx context.Context, bucket service.Schedul
 	for _, id := range ids {
 		keys = append(keys, schedulerAccountKey(id))
 	}
-	values, err := c.rdb.MGet(ctx, keys...).Result()
+	values, err := redisMGet(ctx, c.rdb, keys...)
 	if err != nil {
 		return nil, false, err
 	}
@@ -77,7 +79,7 @@ func (c *schedulerCache) GetSnapshot(ctx context.Context, bucket service.Schedul
 		if val == nil {
 			return nil, false, nil
 		}
-		account, err := decodeCachedAccount(val)
+		account, err := c.decodeAccount(val)
 		if err != nil {
 			return nil, false, err
 		}
@@ -101,8 +103,9 @@ func (c *schedulerCache) SetSnapshot(ctx context.Context, bucket service.Schedul
 	snapshotKey := schedulerSnapshotKey(bucket, versionStr)
 
 	pipe := c.rdb.Pipeline()
-	for _, account := range accounts {
-		payload, err := json.Marshal(account)
+	for i := range accounts {
+		account := &accounts[i]
+		payload, err := c.encodeAccount(account)
 		if err != nil {
 			return err
 		}
@@ -144,14 +147,14 @@ func (c *schedulerCache) GetAccount(ctx context.Context, accountID int64) (*serv
 	if err != nil {
 		return nil, err
 	}
-	return decodeCachedAccount(val)
+	return c.decodeAccount(val)
 }
 
 func (c *schedulerCache) SetAccount(ctx context.Context, account *service.Account) error {
 	if account == nil || account.ID <= 0 {
 		return nil
 	}
-	payload, err := json.Marshal(account)
+	payload, err := c.encodeAccount(account)
 	if err != nil {
 		return err
 	}
@@ -179,7 +182,7 @@ func (c *schedulerCache) UpdateLastUsed(ctx context.Context, updates map[int64]t
 		ids = append(ids, id)
 	}
 
-	values, err := c.rdb.MGet(ctx, keys...).Result()
+	values, err := redisMGet(ctx, c.rdb, keys...)
 	if err != nil {
 		return err
 	}
@@ -260,6 +263,37 @@ func ptrTime(t time.Time) *time.Time {
 	return &t
 }
 
+// encodeAccount 序列化账号快照，凭证敏感字段与代理密码保持密文（未启用加密时与数据库一致为明文）。
+func (c *schedulerCache) encodeAccount(account *service.Account) ([]byte, error) {
+	cached := *account
+	credentials, err := service.EncryptCredentialSecrets(c.cipher, account.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	cached.Credentials = credentials
+	if account.Proxy != nil {
+		proxy := *account.Proxy
+		if proxy.Password, err = encryptSecret(c.cipher, proxy.Password); err != nil {
+			return nil, err
+		}
+		cached.Proxy = &proxy
+	}
+	return json.Marshal(&cached)
+}
+
+// decodeAccount 反序列化账号快照、解密敏感字段并预解析可用时间窗口
+func (c *schedulerCache) decodeAccount(val any) (*service.Account, error) {
+	account, err := decodeCachedAccount(val)
+	if err != nil {
+		return nil, err
+	}
+	decryptAccountSecrets(c.cipher, account)
+	decryptProxySecret(c.cipher, account.Proxy)
+	account.CompileAvailabilitySchedule()
+	return account, nil
+}
+
+// decodeCachedAccount 仅反序列化，不解密；UpdateLastUsed 原样回写时使用。
 func decodeCachedAccount(val any) (*service.Account, error) {
 	var payload []byte
 	switch raw := val.(type) {
@@ -0,0 +1,40 @@
+//go:build unit
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerCacheAccountPayloadKeepsSecretsEncrypted(t *testing.T) {
+	c := &schedulerCache{cipher: newTestEnvelopeCipher(t, "k1", map[string]string{"k1": testEnvelopeKeyA})}
+	account := &service.Account{
+		ID:          7,
+		Credentials: map[string]any{"access_token": "at-secret", "refresh_token": "rt-secret", "base_url": "https://api.example.com"},
+		Proxy:       &service.Proxy{ID: 3, Username: "u", Password: "proxy-secret"},
+	}
+
+	payload, err := c.encodeAccount(account)
+	require.NoError(t, err)
+	for _, secret := range []string{"at-secret", "rt-secret", "proxy-secret"} {
+		require.NotContains(t, string(payload), secret)
+	}
+	require.Contains(t, string(payload), "https://api.example.com")
+	// 原对象不应被修改
+	require.Equal(t, "at-secret", account.Credentials["access_token"])
+	require.Equal(t, "proxy-secret", account.Proxy.Password)
+
+	// UpdateLastUsed 原样回写的路径保持密文
+	raw, err := decodeCachedAccount(payload)
+	require.NoError(t, err)
+	require.True(t, service.IsEncryptedSecret(raw.Credentials["access_token"].(string)))
+
+	decoded, err := c.decodeAccount(payload)
+	require.NoError(t, err)
+	require.Equal(t, "at-secret", decoded.GetCredential("access_token"))
+	require.Equal(t, "rt-secret", decoded.GetCredential("refresh_token"))
+	require.Equal(t, "proxy-secret", decoded.Proxy.Password)
+}
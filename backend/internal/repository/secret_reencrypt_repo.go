@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/lib/pq"
+)
+
+type secretReencryptRepository struct {
+	sql    sqlExecutor
+	cipher service.SecretCipher
+}
+
+// NewSecretReencryptRepository 创建敏感字段重新加密仓储（覆盖账号凭证、代理密码与敏感系统设置）
+func NewSecretReencryptRepository(sqlDB *sql.DB, cipher service.SecretCipher) service.SecretReencryptRepository {
+	return &secretReencryptRepository{sql: sqlDB, cipher: cipher}
+}
+
+// ReencryptAccounts 处理 afterID 之后的一批账号（含软删除记录），返回重新加密数量与本批最大 ID（无数据时为 0）。
+// 更新以旧值为条件，期间被令牌刷新等并发写入修改过的记录会被跳过，由下一轮处理。
+func (r *secretReencryptRepository) ReencryptAccounts(ctx context.Context, afterID int64, limit int) (int, int64, error) {
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT id, credentials::text FROM accounts
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return 0, 0, err
+	}
+	type item struct {
+		id  int64
+		raw string
+	}
+	var items []item
+	for rows.Next() {
+		var it item
+		if err := rows.Scan(&it.id, &it.raw); err != nil {
+			_ = rows.Close()
+			return 0, 0, err
+		}
+		items = append(items, it)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, 0, err
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	updated := 0
+	var lastID int64
+	for _, it := range items {
+		lastID = it.id
+		var credentials map[string]any
+		if err := json.Unmarshal([]byte(it.raw), &credentials); err != nil || !service.CredentialsNeedReencrypt(r.cipher, credentials) {
+			continue
+		}
+		if err := service.DecryptCredentialSecrets(r.cipher, credentials); err != nil {
+			log.Printf("[SecretReencrypt] skip account %d: %v", it.id, err)
+			continue
+		}
+		encrypted, err := service.EncryptCredentialSecrets(r.cipher, credentials)
+		if err != nil {
+			return updated, lastID, err
+		}
+		payload, err := json.Marshal(encrypted)
+		if err != nil {
+			return updated, lastID, err
+		}
+		res, err := r.sql.ExecContext(ctx, `
+			UPDATE accounts SET credentials = $1::jsonb
+			WHERE id = $2 AND credentials = $3::jsonb
+		`, string(payload), it.id, it.raw)
+		if err != nil {
+			return updated, lastID, err
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			updated++
+		}
+	}
+	return updated, lastID, nil
+}
+
+// ReencryptProxies 重新加密全部代理密码，返回重新加密数量
+func (r *secretReencryptRepository) ReencryptProxies(ctx context.Context) (int, error) {
+	return r.reencryptColumn(ctx,
+		`UPDATE proxies SET password = $1 WHERE id = $2::bigint AND password = $3`,
+		`SELECT id::text, password FROM proxies WHERE password IS NOT NULL AND password <> ''`)
+}
+
+// ReencryptSettings 重新加密敏感系统设置项，返回重新加密数量
+func (r *secretReencryptRepository) ReencryptSettings(ctx context.Context) (int, error) {
+	return r.reencryptColumn(ctx,
+		`UPDATE settings SET value = $1 WHERE key = $2 AND value = $3`,
+		`SELECT key, value FROM settings WHERE key = ANY($1) AND value <> ''`,
+		pq.Array([]string{service.SettingKeySMTPPassword, service.SettingKeyLinuxDoConnectClientSecret}))
+}
+
+// reencryptColumn 对 (标识, 值) 查询结果逐条解密后用当前主密钥重新加密
+func (r *secretReencryptRepository) reencryptColumn(ctx context.Context, updateQuery, selectQuery string, args ...any) (int, error) {
+	rows, err := r.sql.QueryContext(ctx, selectQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+	type item struct{ id, value string }
+	var items []item
+	for rows.Next() {
+		var it item
+		if err := rows.Scan(&it.id, &it.value); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		if r.cipher.NeedsReencrypt(it.value) {
+			items = append(items, it)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, it := range items {
+		plain, err := r.cipher.Decrypt(it.value)
+		if err != nil {
+			log.Printf("[SecretReencrypt] skip %s: %v", it.id, err)
+			continue
+		}
+		encrypted, err := r.cipher.Encrypt(plain)
+		if err != nil {
+			return updated, err
+		}
+		res, err := r.sql.ExecContext(ctx, updateQuery, encrypted, it.id, it.value)
+		if err != nil {
+			return updated, err
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			updated++
+		}
+	}
+	return updated, nil
+}
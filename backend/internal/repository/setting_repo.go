@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/ent"
@@ -11,10 +12,33 @@ import (
 
 type settingRepository struct {
 	client *ent.Client
+	// cipher SMTP 密码等敏感设置项的信封加密（nil 表示不加密）
+	cipher service.SecretCipher
 }
 
-func NewSettingRepository(client *ent.Client) service.SettingRepository {
-	return &settingRepository{client: client}
+func NewSettingRepository(client *ent.Client, cipher service.SecretCipher) service.SettingRepository {
+	return &settingRepository{client: client, cipher: cipher}
+}
+
+// encryptValue 敏感设置项写入前加密
+func (r *settingRepository) encryptValue(key, value string) (string, error) {
+	if !service.IsEncryptedSettingKey(key) {
+		return value, nil
+	}
+	return encryptSecret(r.cipher, value)
+}
+
+// decryptValue 读取时解密；失败时记录日志并返回空值，避免把密文当作密码使用
+func (r *settingRepository) decryptValue(key, value string) string {
+	if r.cipher == nil || !service.IsEncryptedSecret(value) {
+		return value
+	}
+	plain, err := r.cipher.Decrypt(value)
+	if err != nil {
+		log.Printf("[CredentialEncryption] decrypt setting failed: key=%s err=%v", key, err)
+		return ""
+	}
+	return plain
 }
 
 func (r *settingRepository) Get(ctx context.Context, key string) (*service.Setting, error) {
@@ -28,7 +52,7 @@ func (r *settingRepository) Get(ctx context.Context, key string) (*service.Setti
 	return &service.Setting{
 		ID:        m.ID,
 		Key:       m.Key,
-		Value:     m.Value,
+		Value:     r.decryptValue(m.Key, m.Value),
 		UpdatedAt: m.UpdatedAt,
 	}, nil
 }
@@ -42,6 +66,10 @@ func (r *settingRepository) GetValue(ctx context.Context, key string) (string, e
 }
 
 func (r *settingRepository) Set(ctx context.Context, key, value string) error {
+	value, err := r.encryptValue(key, value)
+	if err != nil {
+		return err
+	}
 	now := time.Now()
 	return r.client.Setting.
 		Create().
@@ -64,7 +92,7 @@ func (r *settingRepository) GetMultiple(ctx context.Context, keys []string) (map
 
 	result := make(map[string]string)
 	for _, s := range settings {
-		result[s.Key] = s.Value
+		result[s.Key] = r.decryptValue(s.Key, s.Value)
 	}
 	return result, nil
 }
@@ -77,6 +105,10 @@ func (r *settingRepository) SetMultiple(ctx context.Context, settings map[string
 	now := time.Now()
 	builders := make([]*ent.SettingCreate, 0, len(settings))
 	for key, value := range settings {
+		value, err := r.encryptValue(key, value)
+		if err != nil {
+			return err
+		}
 		builders = append(builders, r.client.Setting.Create().SetKey(key).SetValue(value).SetUpdatedAt(now))
 	}
 	return r.client.Setting.
@@ -94,7 +126,7 @@ func (r *settingRepository) GetAll(ctx context.Context) (map[string]string, erro
 
 	result := make(map[string]string)
 	for _, s := range settings {
-		result[s.Key] = s.Value
+		result[s.Key] = r.decryptValue(s.Key, s.Value)
 	}
 	return result, nil
 }
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+// usageAnomalyMaxRows 单次查询返回的最大行数，避免异常流量下结果集过大
+const usageAnomalyMaxRows = 1000
+
+type usageAnomalyRepository struct {
+	sql sqlExecutor
+}
+
+// NewUsageAnomalyRepository 创建用户 / API Key 异常检测仓储（基于 usage_logs 聚合）
+func NewUsageAnomalyRepository(sqlDB *sql.DB) service.UsageAnomalyRepository {
+	return &usageAnomalyRepository{sql: sqlDB}
+}
+
+func (r *usageAnomalyRepository) ListSpend(ctx context.Context, perAPIKey bool, windowStart, windowEnd, baselineStart time.Time, minSpend float64) ([]service.UsageSpendSample, error) {
+	// 先按窗口聚合出候选对象，再按对象回查基线期，基线查询可命中 (api_key_id|user_id, created_at) 索引
+	groupCols, keyCol, joinCond := "user_id", "0::bigint", "u.user_id = w.user_id"
+	if perAPIKey {
+		groupCols, keyCol, joinCond = "user_id, api_key_id", "api_key_id", "u.api_key_id = w.api_key_id"
+	}
+	query := fmt.Sprintf(`
+		WITH w AS (
+			SELECT user_id, %s AS api_key_id, SUM(actual_cost) AS spend, COUNT(*) AS requests
+			FROM usage_logs
+			WHERE created_at >= $1 AND created_at < $2
+			GROUP BY %s
+			HAVING SUM(actual_cost) >= $4
+		)
+		SELECT w.user_id, w.api_key_id, w.spend, w.requests, b.spend, b.first_seen
+		FROM w
+		LEFT JOIN LATERAL (
+			SELECT COALESCE(SUM(u.actual_cost), 0) AS spend, MIN(u.created_at) AS first_seen
+			FROM usage_logs u
+			WHERE %s AND u.created_at >= $3 AND u.created_at < $1
+		) b ON true
+		ORDER BY w.spend DESC
+		LIMIT %d
+	`, keyCol, groupCols, joinCond, usageAnomalyMaxRows)
+
+	rows, err := r.sql.QueryContext(ctx, query, windowStart, windowEnd, baselineStart, minSpend)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []service.UsageSpendSample
+	for rows.Next() {
+		var s service.UsageSpendSample
+		var firstSeen sql.NullTime
+		if err := rows.Scan(&s.UserID, &s.APIKeyID, &s.Spend, &s.Requests, &s.BaselineSpend, &firstSeen); err != nil {
+			return nil, err
+		}
+		if firstSeen.Valid {
+			t := firstSeen.Time
+			s.FirstSeenAt = &t
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func (r *usageAnomalyRepository) ListNewValues(ctx context.Context, field string, windowStart, windowEnd, baselineStart, historyBefore time.Time) ([]service.UsageNewValueSample, error) {
+	// field 拼接进 SQL，必须来自白名单
+	switch field {
+	case service.UsageAnomalyFieldIP, service.UsageAnomalyFieldUserAgent:
+	default:
+		return nil, fmt.Errorf("unsupported usage anomaly field: %s", field)
+	}
+	// 基线集合按 (api_key_id, value) 去重后只聚合一次，再与窗口结果反连接，避免逐行相关子查询
+	query := fmt.Sprintf(`
+		WITH w AS (
+			SELECT user_id, api_key_id, %[1]s AS value, COUNT(*) AS requests
+			FROM usage_logs
+			WHERE created_at >= $1 AND created_at < $2
+				AND %[1]s IS NOT NULL AND %[1]s <> ''
+			GROUP BY user_id, api_key_id, %[1]s
+		),
+		k AS (
+			SELECT DISTINCT api_key_id FROM w
+		),
+		mature AS (
+			SELECT DISTINCT h.api_key_id
+			FROM usage_logs h
+			JOIN k ON k.api_key_id = h.api_key_id
+			WHERE h.created_at >= $3 AND h.created_at < $4
+		),
+		seen AS (
+			SELECT DISTINCT h.api_key_id, h.%[1]s AS value
+			FROM usage_logs h
+			JOIN k ON k.api_key_id = h.api_key_id
+			WHERE h.created_at >= $3 AND h.created_at < $1
+				AND h.%[1]s IS NOT NULL AND h.%[1]s <> ''
+		)
+		SELECT w.user_id, w.api_key_id, w.value, w.requests
+		FROM w
+		JOIN mature m ON m.api_key_id = w.api_key_id
+		LEFT JOIN seen s ON s.api_key_id = w.api_key_id AND s.value = w.value
+		WHERE s.api_key_id IS NULL
+		ORDER BY w.api_key_id, w.requests DESC
+		LIMIT %[2]d
+	`, field, usageAnomalyMaxRows)
+
+	rows, err := r.sql.QueryContext(ctx, query, windowStart, windowEnd, baselineStart, earlierOf(historyBefore, windowStart))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []service.UsageNewValueSample
+	for rows.Next() {
+		var s service.UsageNewValueSample
+		if err := rows.Scan(&s.UserID, &s.APIKeyID, &s.Value, &s.Requests); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func (r *usageAnomalyRepository) ListOffHoursRequests(ctx context.Context, windowStart, windowEnd, baselineStart, historyBefore time.Time, tz string) ([]service.UsageOffHoursSample, error) {
+	// 基线活跃小时按 (api_key_id, hr) 预先去重，与窗口小时反连接
+	rows, err := r.sql.QueryContext(ctx, `
+		WITH w AS (
+			SELECT user_id, api_key_id, EXTRACT(HOUR FROM created_at AT TIME ZONE $5)::int AS hr, COUNT(*) AS requests
+			FROM usage_logs
+			WHERE created_at >= $1 AND created_at < $2
+			GROUP BY user_id, api_key_id, hr
+		),
+		k AS (
+			SELECT DISTINCT api_key_id FROM w
+		),
+		mature AS (
+			SELECT DISTINCT h.api_key_id
+			FROM usage_logs h
+			JOIN k ON k.api_key_id = h.api_key_id
+			WHERE h.created_at >= $3 AND h.created_at < $4
+		),
+		seen AS (
+			SELECT DISTINCT h.api_key_id, EXTRACT(HOUR FROM h.created_at AT TIME ZONE $5)::int AS hr
+			FROM usage_logs h
+			JOIN k ON k.api_key_id = h.api_key_id
+			WHERE h.created_at >= $3 AND h.created_at < $1
+		)
+		SELECT w.user_id, w.api_key_id, SUM(w.requests)::bigint
+		FROM w
+		JOIN mature m ON m.api_key_id = w.api_key_id
+		LEFT JOIN seen s ON s.api_key_id = w.api_key_id AND s.hr = w.hr
+		WHERE s.api_key_id IS NULL
+		GROUP BY w.user_id, w.api_key_id
+		LIMIT $6
+	`, windowStart, windowEnd, baselineStart, earlierOf(historyBefore, windowStart), tz, usageAnomalyMaxRows)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []service.UsageOffHoursSample
+	for rows.Next() {
+		var s service.UsageOffHoursSample
+		if err := rows.Scan(&s.UserID, &s.APIKeyID, &s.Requests); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// earlierOf 历史判定截止时间不晚于窗口开始，避免窗口内的首次使用被当作历史
+func earlierOf(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
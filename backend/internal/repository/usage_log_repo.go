@@ -114,14 +114,15 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 			ip_address,
 			image_count,
 			image_size,
-			created_at
+			created_at,
+			traffic_arm
 		) VALUES (
 			$1, $2, $3, $4, $5,
 			$6, $7,
 			$8, $9, $10, $11,
 			$12, $13,
 			$14, $15, $16, $17, $18, $19,
-			$20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30
+			$20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31
 		)
 		ON CONFLICT (request_id, api_key_id) DO NOTHING
 		RETURNING id, created_at
@@ -134,6 +135,7 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 	userAgent := nullString(log.UserAgent)
 	ipAddress := nullString(log.IPAddress)
 	imageSize := nullString(log.ImageSize)
+	trafficArm := nullString(log.TrafficArm)
 
 	var requestIDArg any
 	if requestID != "" {
@@ -171,6 +173,7 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 		log.ImageCount,
 		imageSize,
 		createdAt,
+		trafficArm,
 	}
 	if err := scanSingleRow(ctx, sqlq, query, args, &log.ID, &log.CreatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) && requestID != "" {
@@ -1226,6 +1229,10 @@ func (r *usageLogRepository) ListWithFilters(ctx context.Context, params paginat
 		conditions = append(conditions, fmt.Sprintf("group_id = $%d", len(args)+1))
 		args = append(args, filters.GroupID)
 	}
+	if filters.OrganizationID > 0 {
+		conditions = append(conditions, fmt.Sprintf("api_key_id IN (SELECT id FROM api_keys WHERE organization_id = $%d)", len(args)+1))
+		args = append(args, filters.OrganizationID)
+	}
 	if filters.Model != "" {
 		conditions = append(conditions, fmt.Sprintf("model = $%d", len(args)+1))
 		args = append(args, filters.Model)
@@ -1610,6 +1617,10 @@ func (r *usageLogRepository) GetStatsWithFilters(ctx context.Context, filters Us
 		conditions = append(conditions, fmt.Sprintf("group_id = $%d", len(args)+1))
 		args = append(args, filters.GroupID)
 	}
+	if filters.OrganizationID > 0 {
+		conditions = append(conditions, fmt.Sprintf("api_key_id IN (SELECT id FROM api_keys WHERE organization_id = $%d)", len(args)+1))
+		args = append(args, filters.OrganizationID)
+	}
 	if filters.Model != "" {
 		conditions = append(conditions, fmt.Sprintf("model = $%d", len(args)+1))
 		args = append(args, filters.Model)
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/userrecoverycode"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type recoveryCodeRepository struct {
+	client *dbent.Client
+}
+
+// NewRecoveryCodeRepository creates a new 2FA recovery code repository
+func NewRecoveryCodeRepository(client *dbent.Client) service.RecoveryCodeRepository {
+	return &recoveryCodeRepository{client: client}
+}
+
+func (r *recoveryCodeRepository) ReplaceAll(ctx context.Context, userID int64, codeHashes []string) error {
+	tx, err := r.client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.UserRecoveryCode.Delete().
+		Where(userrecoverycode.UserIDEQ(userID)).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	builders := make([]*dbent.UserRecoveryCodeCreate, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		builders = append(builders, tx.UserRecoveryCode.Create().
+			SetUserID(userID).
+			SetCodeHash(hash))
+	}
+	if len(builders) > 0 {
+		if _, err := tx.UserRecoveryCode.CreateBulk(builders...).Save(ctx); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Consume 通过带条件的 UPDATE 保证同一恢复码在并发请求下也只能成功使用一次
+func (r *recoveryCodeRepository) Consume(ctx context.Context, userID int64, codeHash string, usedAt time.Time) (bool, error) {
+	client := clientFromContext(ctx, r.client)
+	n, err := client.UserRecoveryCode.Update().
+		Where(
+			userrecoverycode.UserIDEQ(userID),
+			userrecoverycode.CodeHashEQ(codeHash),
+			userrecoverycode.UsedAtIsNil(),
+		).
+		SetUsedAt(usedAt).
+		Save(ctx)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *recoveryCodeRepository) CountUnused(ctx context.Context, userID int64) (int, error) {
+	client := clientFromContext(ctx, r.client)
+	return client.UserRecoveryCode.Query().
+		Where(
+			userrecoverycode.UserIDEQ(userID),
+			userrecoverycode.UsedAtIsNil(),
+		).
+		Count(ctx)
+}
@@ -60,7 +60,9 @@ func (r *userRepository) Create(ctx context.Context, userIn *service.User) error
 		SetRole(userIn.Role).
 		SetBalance(userIn.Balance).
 		SetConcurrency(userIn.Concurrency).
-		SetStatus(userIn.Status)
+		SetStatus(userIn.Status).
+		SetNillableRegisterIP(userIn.RegisterIP).
+		SetNillableRegisterDevice(userIn.RegisterDevice)
 	if userIn.InviteCode != "" {
 		builder.SetInviteCode(userIn.InviteCode)
 	}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -394,6 +395,68 @@ func (r *userSubscriptionRepository) IncrementUsage(ctx context.Context, id int6
 	return service.ErrSubscriptionNotFound
 }
 
+// SumOverageCost 统计订阅超额计费的实际扣费金额（usage_logs.billing_type = 超额）
+func (r *userSubscriptionRepository) SumOverageCost(ctx context.Context, id int64, since time.Time) (float64, error) {
+	client := clientFromContext(ctx, r.client)
+	rows, err := client.QueryContext(ctx, `
+		SELECT COALESCE(SUM(actual_cost), 0)
+		FROM usage_logs
+		WHERE subscription_id = $1 AND billing_type = $2 AND created_at >= $3
+	`, id, service.BillingTypeSubscriptionOverage, since)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var total float64
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+	return total, rows.Err()
+}
+
+// SumQuotaUsage 统计订阅额度用量（仅 billing_type = 订阅，超额计费的请求不计入订阅额度）
+func (r *userSubscriptionRepository) SumQuotaUsage(ctx context.Context, id int64, since time.Time, modelPattern string) (service.SubscriptionQuotaUsage, error) {
+	query := `
+		SELECT COUNT(*),
+			COALESCE(SUM(input_tokens + cache_creation_tokens + cache_read_tokens), 0),
+			COALESCE(SUM(output_tokens), 0)
+		FROM usage_logs
+		WHERE subscription_id = $1 AND billing_type = $2 AND created_at >= $3`
+	args := []any{id, service.BillingTypeSubscription, since}
+	if modelPattern != "" {
+		if prefix, ok := strings.CutSuffix(modelPattern, "*"); ok {
+			query += ` AND model LIKE $4 ESCAPE '\'`
+			args = append(args, escapeLikePattern(prefix)+"%")
+		} else {
+			query += ` AND model = $4`
+			args = append(args, modelPattern)
+		}
+	}
+
+	client := clientFromContext(ctx, r.client)
+	rows, err := client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return service.SubscriptionQuotaUsage{}, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var usage service.SubscriptionQuotaUsage
+	if rows.Next() {
+		if err := rows.Scan(&usage.Requests, &usage.InputTokens, &usage.OutputTokens); err != nil {
+			return service.SubscriptionQuotaUsage{}, err
+		}
+	}
+	return usage, rows.Err()
+}
+
+// escapeLikePattern 转义 LIKE 通配符，模型名中的 _ 与 % 按字面匹配
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
 func (r *userSubscriptionRepository) BatchUpdateExpiredStatus(ctx context.Context) (int64, error) {
 	client := clientFromContext(ctx, r.client)
 	n, err := client.UserSubscription.Update().
@@ -406,6 +469,106 @@ func (r *userSubscriptionRepository) BatchUpdateExpiredStatus(ctx context.Contex
 	return int64(n), err
 }
 
+func (r *userSubscriptionRepository) SetAutoRenew(ctx context.Context, id int64, enabled bool) error {
+	client := clientFromContext(ctx, r.client)
+	_, err := client.UserSubscription.UpdateOneID(id).
+		SetAutoRenew(enabled).
+		Save(ctx)
+	return translatePersistenceError(err, service.ErrSubscriptionNotFound, nil)
+}
+
+func (r *userSubscriptionRepository) Pause(ctx context.Context, id int64, pausedAt time.Time) error {
+	client := clientFromContext(ctx, r.client)
+	n, err := client.UserSubscription.Update().
+		Where(
+			usersubscription.IDEQ(id),
+			usersubscription.StatusEQ(service.SubscriptionStatusActive),
+			usersubscription.ExpiresAtGT(pausedAt),
+		).
+		SetStatus(service.SubscriptionStatusPaused).
+		SetPausedAt(pausedAt).
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return service.ErrSubscriptionNotActive
+	}
+	return nil
+}
+
+func (r *userSubscriptionRepository) Resume(ctx context.Context, id int64, newExpiresAt time.Time) error {
+	client := clientFromContext(ctx, r.client)
+	n, err := client.UserSubscription.Update().
+		Where(
+			usersubscription.IDEQ(id),
+			usersubscription.StatusEQ(service.SubscriptionStatusPaused),
+		).
+		SetStatus(service.SubscriptionStatusActive).
+		SetExpiresAt(newExpiresAt).
+		ClearPausedAt().
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return service.ErrSubscriptionNotPaused
+	}
+	return nil
+}
+
+func (r *userSubscriptionRepository) RecordRenewal(ctx context.Context, id int64, prevExpiresAt, newExpiresAt time.Time) error {
+	client := clientFromContext(ctx, r.client)
+	n, err := client.UserSubscription.Update().
+		Where(
+			usersubscription.IDEQ(id),
+			usersubscription.ExpiresAtEQ(prevExpiresAt),
+			usersubscription.StatusIn(service.SubscriptionStatusActive, service.SubscriptionStatusExpired),
+		).
+		SetExpiresAt(newExpiresAt).
+		SetStatus(service.SubscriptionStatusActive).
+		ClearRenewalFailedAt().
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return service.ErrSubscriptionRenewalConflict
+	}
+	return nil
+}
+
+func (r *userSubscriptionRepository) MarkRenewalFailed(ctx context.Context, id int64, failedAt time.Time) error {
+	client := clientFromContext(ctx, r.client)
+	_, err := client.UserSubscription.UpdateOneID(id).
+		SetRenewalFailedAt(failedAt).
+		Save(ctx)
+	return translatePersistenceError(err, service.ErrSubscriptionNotFound, nil)
+}
+
+func (r *userSubscriptionRepository) ListAutoRenewDue(ctx context.Context, dueBefore, retryBefore time.Time, limit int) ([]service.UserSubscription, error) {
+	client := clientFromContext(ctx, r.client)
+	subs, err := client.UserSubscription.Query().
+		Where(
+			usersubscription.AutoRenewEQ(true),
+			usersubscription.StatusEQ(service.SubscriptionStatusActive),
+			usersubscription.ExpiresAtLTE(dueBefore),
+			usersubscription.Or(
+				usersubscription.RenewalFailedAtIsNil(),
+				usersubscription.RenewalFailedAtLTE(retryBefore),
+			),
+		).
+		WithUser().
+		WithGroup().
+		Order(dbent.Asc(usersubscription.FieldExpiresAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return userSubscriptionEntitiesToService(subs), nil
+}
+
 // Extra repository helpers (currently used only by integration tests).
 
 func (r *userSubscriptionRepository) ListExpired(ctx context.Context) ([]service.UserSubscription, error) {
@@ -466,6 +629,9 @@ func userSubscriptionEntityToService(m *dbent.UserSubscription) *service.UserSub
 		AssignedBy:         m.AssignedBy,
 		AssignedAt:         m.AssignedAt,
 		Notes:              derefString(m.Notes),
+		AutoRenew:          m.AutoRenew,
+		PausedAt:           m.PausedAt,
+		RenewalFailedAt:    m.RenewalFailedAt,
 		CreatedAt:          m.CreatedAt,
 		UpdatedAt:          m.UpdatedAt,
 	}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+const webAuthnChallengeKeyPrefix = "webauthn:challenge:"
+
+// WebAuthnChallengeCache implements service.WebAuthnChallengeCache using Redis
+type WebAuthnChallengeCache struct {
+	rdb redis.UniversalClient
+}
+
+// NewWebAuthnChallengeCache creates a new WebAuthn challenge cache
+func NewWebAuthnChallengeCache(rdb redis.UniversalClient) service.WebAuthnChallengeCache {
+	return &WebAuthnChallengeCache{rdb: rdb}
+}
+
+// SetChallenge stores a WebAuthn challenge
+func (c *WebAuthnChallengeCache) SetChallenge(ctx context.Context, challengeID string, challenge *service.WebAuthnChallenge, ttl time.Duration) error {
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return fmt.Errorf("marshal webauthn challenge: %w", err)
+	}
+	return c.rdb.Set(ctx, webAuthnChallengeKeyPrefix+challengeID, data, ttl).Err()
+}
+
+// ConsumeChallenge atomically reads and deletes a challenge so it can only be used once
+func (c *WebAuthnChallengeCache) ConsumeChallenge(ctx context.Context, challengeID string) (*service.WebAuthnChallenge, error) {
+	data, err := c.rdb.GetDel(ctx, webAuthnChallengeKeyPrefix+challengeID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get webauthn challenge: %w", err)
+	}
+
+	var challenge service.WebAuthnChallenge
+	if err := json.Unmarshal(data, &challenge); err != nil {
+		return nil, fmt.Errorf("unmarshal webauthn challenge: %w", err)
+	}
+	return &challenge, nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/webauthncredential"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type webAuthnCredentialRepository struct {
+	client *dbent.Client
+}
+
+// NewWebAuthnCredentialRepository creates a new passkey repository
+func NewWebAuthnCredentialRepository(client *dbent.Client) service.WebAuthnCredentialRepository {
+	return &webAuthnCredentialRepository{client: client}
+}
+
+func (r *webAuthnCredentialRepository) Create(ctx context.Context, cred *service.WebAuthnCredential) error {
+	client := clientFromContext(ctx, r.client)
+	created, err := client.WebAuthnCredential.Create().
+		SetUserID(cred.UserID).
+		SetName(cred.Name).
+		SetCredentialID(cred.CredentialID).
+		SetPublicKey(cred.PublicKey).
+		SetSignCount(cred.SignCount).
+		SetAaguid(cred.AAGUID).
+		SetTransports(cred.Transports).
+		SetUserVerified(cred.UserVerified).
+		Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, nil, service.ErrWebAuthnCredentialExists)
+	}
+	cred.ID = created.ID
+	cred.CreatedAt = created.CreatedAt
+	cred.UpdatedAt = created.UpdatedAt
+	return nil
+}
+
+func (r *webAuthnCredentialRepository) GetByCredentialID(ctx context.Context, credentialID string) (*service.WebAuthnCredential, error) {
+	client := clientFromContext(ctx, r.client)
+	m, err := client.WebAuthnCredential.Query().
+		Where(webauthncredential.CredentialIDEQ(credentialID)).
+		Only(ctx)
+	if err != nil {
+		return nil, translatePersistenceError(err, service.ErrWebAuthnCredentialNotFound, nil)
+	}
+	return webAuthnCredentialEntityToService(m), nil
+}
+
+func (r *webAuthnCredentialRepository) ListByUserID(ctx context.Context, userID int64) ([]service.WebAuthnCredential, error) {
+	client := clientFromContext(ctx, r.client)
+	items, err := client.WebAuthnCredential.Query().
+		Where(webauthncredential.UserIDEQ(userID)).
+		Order(dbent.Asc(webauthncredential.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]service.WebAuthnCredential, 0, len(items))
+	for _, m := range items {
+		out = append(out, *webAuthnCredentialEntityToService(m))
+	}
+	return out, nil
+}
+
+func (r *webAuthnCredentialRepository) CountByUserID(ctx context.Context, userID int64) (int, error) {
+	client := clientFromContext(ctx, r.client)
+	return client.WebAuthnCredential.Query().
+		Where(webauthncredential.UserIDEQ(userID)).
+		Count(ctx)
+}
+
+func (r *webAuthnCredentialRepository) UpdateName(ctx context.Context, userID, id int64, name string) error {
+	client := clientFromContext(ctx, r.client)
+	n, err := client.WebAuthnCredential.Update().
+		Where(webauthncredential.IDEQ(id), webauthncredential.UserIDEQ(userID)).
+		SetName(name).
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return service.ErrWebAuthnCredentialNotFound
+	}
+	return nil
+}
+
+func (r *webAuthnCredentialRepository) UpdateUsage(ctx context.Context, id int64, signCount int64, usedAt time.Time) error {
+	client := clientFromContext(ctx, r.client)
+	_, err := client.WebAuthnCredential.UpdateOneID(id).
+		SetSignCount(signCount).
+		SetLastUsedAt(usedAt).
+		Save(ctx)
+	return translatePersistenceError(err, service.ErrWebAuthnCredentialNotFound, nil)
+}
+
+func (r *webAuthnCredentialRepository) Delete(ctx context.Context, userID, id int64) error {
+	client := clientFromContext(ctx, r.client)
+	n, err := client.WebAuthnCredential.Delete().
+		Where(webauthncredential.IDEQ(id), webauthncredential.UserIDEQ(userID)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return service.ErrWebAuthnCredentialNotFound
+	}
+	return nil
+}
+
+func webAuthnCredentialEntityToService(m *dbent.WebAuthnCredential) *service.WebAuthnCredential {
+	return &service.WebAuthnCredential{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		Name:         m.Name,
+		CredentialID: m.CredentialID,
+		PublicKey:    m.PublicKey,
+		SignCount:    m.SignCount,
+		AAGUID:       m.Aaguid,
+		Transports:   m.Transports,
+		UserVerified: m.UserVerified,
+		LastUsedAt:   m.LastUsedAt,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
@@ -3,10 +3,12 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"reflect"
 
 	entsql "entgo.io/ent/dialect/sql"
 	"github.com/Wei-Shaw/sub2api/ent"
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/tlsfingerprint"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 	"github.com/google/wire"
 	"github.com/redis/go-redis/v9"
@@ -14,7 +16,7 @@ import (
 
 // ProvideConcurrencyCache 创建并发控制缓存，从配置读取 TTL 参数
 // 性能优化：TTL 可配置，支持长时间运行的 LLM 请求场景
-func ProvideConcurrencyCache(rdb *redis.Client, cfg *config.Config) service.ConcurrencyCache {
+func ProvideConcurrencyCache(rdb redis.UniversalClient, cfg *config.Config) service.ConcurrencyCache {
 	waitTTLSeconds := int(cfg.Gateway.Scheduling.StickySessionWaitTimeout.Seconds())
 	if cfg.Gateway.Scheduling.FallbackWaitTimeout > cfg.Gateway.Scheduling.StickySessionWaitTimeout {
 		waitTTLSeconds = int(cfg.Gateway.Scheduling.FallbackWaitTimeout.Seconds())
@@ -39,7 +41,7 @@ func ProvidePricingRemoteClient(cfg *config.Config) service.PricingRemoteClient
 
 // ProvideSessionLimitCache 创建会话限制缓存
 // 用于 Anthropic OAuth/SetupToken 账号的并发会话数量控制
-func ProvideSessionLimitCache(rdb *redis.Client, cfg *config.Config) service.SessionLimitCache {
+func ProvideSessionLimitCache(rdb redis.UniversalClient, cfg *config.Config) service.SessionLimitCache {
 	defaultIdleTimeoutMinutes := 5 // 默认 5 分钟空闲超时
 	if cfg != nil && cfg.Gateway.SessionIdleTimeoutMinutes > 0 {
 		defaultIdleTimeoutMinutes = cfg.Gateway.SessionIdleTimeoutMinutes
@@ -47,6 +49,18 @@ func ProvideSessionLimitCache(rdb *redis.Client, cfg *config.Config) service.Ses
 	return NewSessionLimitCache(rdb, defaultIdleTimeoutMinutes)
 }
 
+// ProvideHTTPUpstream 创建上游 HTTP 服务
+// 启动时按配置加载 TLS 指纹模板，并在配置热重载后刷新
+func ProvideHTTPUpstream(cfg *config.Config, reloader *config.Reloader) service.HTTPUpstream {
+	tlsfingerprint.GlobalRegistry().ReloadFromConfig(&cfg.Gateway.TLSFingerprint)
+	reloader.OnReload(func(prev, next *config.Config) {
+		if !reflect.DeepEqual(prev.Gateway.TLSFingerprint, next.Gateway.TLSFingerprint) {
+			tlsfingerprint.GlobalRegistry().ReloadFromConfig(&next.Gateway.TLSFingerprint)
+		}
+	})
+	return NewHTTPUpstream(cfg)
+}
+
 // ProviderSet is the Wire provider set for all repositories
 var ProviderSet = wire.NewSet(
 	NewUserRepository,
@@ -58,16 +72,25 @@ var ProviderSet = wire.NewSet(
 	NewPromoCodeRepository,
 	NewPlanRepository,
 	NewInviteRepository,
+	NewInviteCommissionRepository,
+	NewOrganizationRepository,
 	NewInviteLogRepository,
 	NewAdminActionLogRepository,
 	NewUsageLogRepository,
 	NewUsageCleanupRepository,
 	NewDashboardAggregationRepository,
 	NewSettingRepository,
+	NewEnvelopeCipher,
+	NewSecretReencryptRepository,
+	NewUsageAnomalyRepository,
+	NewBackupRepository,
 	NewOpsRepository,
 	NewUserSubscriptionRepository,
 	NewUserAttributeDefinitionRepository,
 	NewUserAttributeValueRepository,
+	NewAccountDedicationRepository,
+	NewWebAuthnCredentialRepository,
+	NewRecoveryCodeRepository,
 
 	// Cache implementations
 	NewGatewayCache,
@@ -75,6 +98,7 @@ var ProviderSet = wire.NewSet(
 	NewAPIKeyCache,
 	NewTempUnschedCache,
 	NewTimeoutCounterCache,
+	NewIPProtectionCache,
 	ProvideConcurrencyCache,
 	ProvideSessionLimitCache,
 	NewDashboardCache,
@@ -87,6 +111,8 @@ var ProviderSet = wire.NewSet(
 	NewSchedulerOutboxRepository,
 	NewProxyLatencyCache,
 	NewTotpCache,
+	NewAuthSessionCache,
+	NewWebAuthnChallengeCache,
 
 	// Encryptors
 	NewAESEncryptor,
@@ -98,7 +124,7 @@ var ProviderSet = wire.NewSet(
 	NewProxyExitInfoProber,
 	NewClaudeUsageFetcher,
 	NewClaudeOAuthClient,
-	NewHTTPUpstream,
+	ProvideHTTPUpstream,
 	NewOpenAIOAuthClient,
 	NewGeminiOAuthClient,
 	NewGeminiCliCodeAssistClient,
@@ -153,7 +179,7 @@ func ProvideSQLDB(client *ent.Client) (*sql.DB, error) {
 //   - 实时统计数据
 //
 // 依赖：config.Config
-// 提供：*redis.Client
-func ProvideRedis(cfg *config.Config) *redis.Client {
+// 提供：redis.UniversalClient
+func ProvideRedis(cfg *config.Config) redis.UniversalClient {
 	return InitRedis(cfg)
 }
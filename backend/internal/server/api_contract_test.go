@@ -180,6 +180,11 @@ func TestAPIContracts(t *testing.T) {
 						"image_price_4k": null,
 						"claude_code_only": false,
 						"fallback_group_id": null,
+						"overage_policy": "",
+						"overage_rate_multiplier": 0,
+						"overage_group_id": null,
+						"subscription_price": null,
+						"usage_quotas": [],
 						"created_at": "2025-01-02T03:04:05Z",
 						"updated_at": "2025-01-02T03:04:05Z"
 					}
@@ -230,6 +235,9 @@ func TestAPIContracts(t *testing.T) {
 						"daily_usage_usd": 1.23,
 						"weekly_usage_usd": 2.34,
 						"monthly_usage_usd": 3.45,
+						"auto_renew": false,
+						"paused_at": null,
+						"renewal_failed_at": null,
 						"created_at": "2025-01-02T03:04:05Z",
 						"updated_at": "2025-01-02T03:04:05Z"
 					}
@@ -593,7 +601,7 @@ func newContractDeps(t *testing.T) *contractDeps {
 	usageService := service.NewUsageService(usageRepo, userRepo, nil, nil)
 
 	subscriptionService := service.NewSubscriptionService(groupRepo, userSubRepo, nil)
-	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionService)
+	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionService, nil, nil)
 
 	redeemService := service.NewRedeemService(redeemRepo, userRepo, subscriptionService, nil, nil, nil, nil)
 	redeemHandler := handler.NewRedeemHandler(redeemService)
@@ -602,11 +610,11 @@ func newContractDeps(t *testing.T) *contractDeps {
 	settingService := service.NewSettingService(settingRepo, cfg)
 
 	adminService := service.NewAdminService(userRepo, groupRepo, &accountRepo, proxyRepo, apiKeyRepo, redeemRepo, nil, nil, nil, nil)
-	authHandler := handler.NewAuthHandler(cfg, nil, userService, settingService, nil, nil)
+	authHandler := handler.NewAuthHandler(cfg, nil, userService, settingService, nil, nil, nil, nil, nil)
 	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
 	usageHandler := handler.NewUsageHandler(usageService, apiKeyService)
 	adminSettingHandler := adminhandler.NewSettingHandler(settingService, nil, nil, nil)
-	adminAccountHandler := adminhandler.NewAccountHandler(adminService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	adminAccountHandler := adminhandler.NewAccountHandler(adminService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	jwtAuth := func(c *gin.Context) {
 		c.Set(string(middleware.ContextKeyUser), middleware.AuthSubject{
@@ -994,7 +1002,7 @@ func (s *stubAccountRepo) SetAntigravityQuotaScopeLimit(ctx context.Context, id
 	return errors.New("not implemented")
 }
 
-func (s *stubAccountRepo) SetModelRateLimit(ctx context.Context, id int64, scope string, resetAt time.Time) error {
+func (s *stubAccountRepo) SetModelRateLimit(ctx context.Context, id int64, scope string, models []string, resetAt time.Time) error {
 	return errors.New("not implemented")
 }
 
@@ -1228,6 +1236,30 @@ func (stubUserSubscriptionRepo) IncrementUsage(ctx context.Context, id int64, co
 func (stubUserSubscriptionRepo) BatchUpdateExpiredStatus(ctx context.Context) (int64, error) {
 	return 0, errors.New("not implemented")
 }
+func (stubUserSubscriptionRepo) SumOverageCost(ctx context.Context, id int64, since time.Time) (float64, error) {
+	return 0, errors.New("not implemented")
+}
+func (stubUserSubscriptionRepo) SumQuotaUsage(ctx context.Context, id int64, since time.Time, modelPattern string) (service.SubscriptionQuotaUsage, error) {
+	return service.SubscriptionQuotaUsage{}, errors.New("not implemented")
+}
+func (stubUserSubscriptionRepo) SetAutoRenew(ctx context.Context, id int64, enabled bool) error {
+	return errors.New("not implemented")
+}
+func (stubUserSubscriptionRepo) Pause(ctx context.Context, id int64, pausedAt time.Time) error {
+	return errors.New("not implemented")
+}
+func (stubUserSubscriptionRepo) Resume(ctx context.Context, id int64, newExpiresAt time.Time) error {
+	return errors.New("not implemented")
+}
+func (stubUserSubscriptionRepo) RecordRenewal(ctx context.Context, id int64, prevExpiresAt, newExpiresAt time.Time) error {
+	return errors.New("not implemented")
+}
+func (stubUserSubscriptionRepo) MarkRenewalFailed(ctx context.Context, id int64, failedAt time.Time) error {
+	return errors.New("not implemented")
+}
+func (stubUserSubscriptionRepo) ListAutoRenewDue(ctx context.Context, dueBefore, retryBefore time.Time, limit int) ([]service.UserSubscription, error) {
+	return nil, errors.New("not implemented")
+}
 
 type stubApiKeyRepo struct {
 	now time.Time
@@ -1284,12 +1316,12 @@ func (r *stubApiKeyRepo) GetByID(ctx context.Context, id int64) (*service.APIKey
 	return &clone, nil
 }
 
-func (r *stubApiKeyRepo) GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
+func (r *stubApiKeyRepo) GetCredentialAndOwnerID(ctx context.Context, id int64) (service.APIKeyCredential, int64, error) {
 	key, ok := r.byID[id]
 	if !ok {
-		return "", 0, service.ErrAPIKeyNotFound
+		return service.APIKeyCredential{}, 0, service.ErrAPIKeyNotFound
 	}
-	return key.Key, key.UserID, nil
+	return key.Credential(), key.UserID, nil
 }
 
 func (r *stubApiKeyRepo) GetByKey(ctx context.Context, key string) (*service.APIKey, error) {
@@ -1301,7 +1333,7 @@ func (r *stubApiKeyRepo) GetByKey(ctx context.Context, key string) (*service.API
 	return &clone, nil
 }
 
-func (r *stubApiKeyRepo) GetByKeyForAuth(ctx context.Context, key string) (*service.APIKey, error) {
+func (r *stubApiKeyRepo) GetByKeyForAuth(ctx context.Context, keyHash, key string) (*service.APIKey, error) {
 	return r.GetByKey(ctx, key)
 }
 
@@ -1398,7 +1430,7 @@ func (r *stubApiKeyRepo) CountByUserID(ctx context.Context, userID int64) (int64
 	return count, nil
 }
 
-func (r *stubApiKeyRepo) ExistsByKey(ctx context.Context, key string) (bool, error) {
+func (r *stubApiKeyRepo) ExistsByKey(ctx context.Context, keyHash, key string) (bool, error) {
 	_, ok := r.byKey[key]
 	return ok, nil
 }
@@ -1419,14 +1451,22 @@ func (r *stubApiKeyRepo) CountByGroupID(ctx context.Context, groupID int64) (int
 	return 0, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) ListKeysByUserID(ctx context.Context, userID int64) ([]string, error) {
+func (r *stubApiKeyRepo) ListCredentialsByUserID(ctx context.Context, userID int64) ([]service.APIKeyCredential, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *stubApiKeyRepo) ListCredentialsByGroupID(ctx context.Context, groupID int64) ([]service.APIKeyCredential, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error) {
+func (r *stubApiKeyRepo) ListLegacyKeys(ctx context.Context, afterID int64, limit int) ([]service.APIKeyCredential, error) {
 	return nil, errors.New("not implemented")
 }
 
+func (r *stubApiKeyRepo) MigrateKeyHash(ctx context.Context, id int64, legacyKey, keyHash, keyPrefix string) error {
+	return errors.New("not implemented")
+}
+
 type stubUsageLogRepo struct {
 	userLogs map[int64][]service.UsageLog
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -16,8 +17,10 @@ func NewAdminAuthMiddleware(
 	authService *service.AuthService,
 	userService *service.UserService,
 	settingService *service.SettingService,
+	sessionService *service.AuthSessionService,
+	twoFactorService *service.TwoFactorService,
 ) AdminAuthMiddleware {
-	return AdminAuthMiddleware(adminAuth(authService, userService, settingService))
+	return AdminAuthMiddleware(adminAuth(authService, userService, settingService, sessionService, twoFactorService))
 }
 
 // adminAuth 管理员认证中间件实现
@@ -28,6 +31,8 @@ func adminAuth(
 	authService *service.AuthService,
 	userService *service.UserService,
 	settingService *service.SettingService,
+	sessionService *service.AuthSessionService,
+	twoFactorService *service.TwoFactorService,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// WebSocket upgrade requests cannot set Authorization headers in browsers.
@@ -36,7 +41,7 @@ func adminAuth(
 		//   Sec-WebSocket-Protocol: sub2api-admin, jwt.<token>
 		if isWebSocketUpgradeRequest(c) {
 			if token := extractJWTFromWebSocketSubprotocol(c); token != "" {
-				if !validateJWTForAdmin(c, token, authService, userService) {
+				if !validateJWTForAdmin(c, token, authService, userService, sessionService, twoFactorService) {
 					return
 				}
 				c.Next()
@@ -59,7 +64,7 @@ func adminAuth(
 		if authHeader != "" {
 			parts := strings.SplitN(authHeader, " ", 2)
 			if len(parts) == 2 && parts[0] == "Bearer" {
-				if !validateJWTForAdmin(c, parts[1], authService, userService) {
+				if !validateJWTForAdmin(c, parts[1], authService, userService, sessionService, twoFactorService) {
 					return
 				}
 				c.Next()
@@ -151,6 +156,8 @@ func validateJWTForAdmin(
 	token string,
 	authService *service.AuthService,
 	userService *service.UserService,
+	sessionService *service.AuthSessionService,
+	twoFactorService *service.TwoFactorService,
 ) bool {
 	// 验证 JWT token
 	claims, err := authService.ValidateToken(token)
@@ -182,11 +189,34 @@ func validateJWTForAdmin(
 		return false
 	}
 
+	if claims.TokenVersion != user.TokenVersion {
+		AbortWithError(c, 401, "TOKEN_REVOKED", "Token has been revoked")
+		return false
+	}
+
+	if err := sessionService.ValidateAccessToken(c.Request.Context(), claims, ip.GetClientIP(c)); err != nil {
+		abortWithSessionError(c, err)
+		return false
+	}
+
+	// 管理员两步验证策略（Admin API Key 属于机器凭证，不受此限制）
+	if twoFactorService != nil {
+		if err := twoFactorService.CheckAdminPolicy(c.Request.Context(), user); err != nil {
+			if errors.Is(err, service.ErrAdmin2FARequired) {
+				AbortWithError(c, 403, "ADMIN_2FA_REQUIRED", "Two-factor authentication is required for admin access")
+				return false
+			}
+			AbortWithError(c, 500, "INTERNAL_ERROR", "Internal server error")
+			return false
+		}
+	}
+
 	c.Set(string(ContextKeyUser), AuthSubject{
 		UserID:      user.ID,
 		Concurrency: user.Concurrency,
 	})
 	c.Set(string(ContextKeyUserRole), user.Role)
+	c.Set(string(ContextKeyAuthSessionID), claims.SessionID)
 	c.Set("auth_method", "jwt")
 
 	return true
@@ -13,7 +13,7 @@ func TestAdminAuthRequiresAuthorization(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	router := gin.New()
-	router.Use(adminAuth(nil, nil, nil))
+	router.Use(adminAuth(nil, nil, nil, nil, nil))
 	router.GET("/admin/test", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -106,18 +106,21 @@ func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscripti
 			})
 			c.Set(string(ContextKeyUserRole), apiKey.User.Role)
 			setGroupContext(c, apiKey.Group)
+			setAccountAffinityContext(c, apiKey)
 			c.Next()
 			return
 		}
 
 		// 判断计费方式：订阅模式 vs 余额模式
 		isSubscriptionType := apiKey.Group != nil && apiKey.Group.IsSubscriptionType()
+		balanceBilling := !isSubscriptionType || subscriptionService == nil
 
 		if isSubscriptionType && subscriptionService != nil {
 			// 订阅模式：验证订阅
+			// 组织 Key 使用组织所有者名下的订阅
 			subscription, err := subscriptionService.GetActiveSubscription(
 				c.Request.Context(),
-				apiKey.User.ID,
+				apiKey.BillingUserID(),
 				apiKey.Group.ID,
 			)
 			if err != nil {
@@ -143,17 +146,37 @@ func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscripti
 
 			// 预检查用量限制（使用0作为额外费用进行预检查）
 			if err := subscriptionService.CheckUsageLimits(c.Request.Context(), subscription, apiKey.Group, 0); err != nil {
-				AbortWithError(c, 429, "USAGE_LIMIT_EXCEEDED", err.Error())
-				return
+				// 按分组超额策略放行：改为余额计费或降级到按量计费分组（请求级 APIKey 副本）
+				overage, fallbackGroup, overageErr := subscriptionService.ResolveOverage(c.Request.Context(), subscription, apiKey.Group, err)
+				if overageErr != nil {
+					AbortWithError(c, 429, "USAGE_LIMIT_EXCEEDED", overageErr.Error())
+					return
+				}
+				apiKey = apiKey.WithSubscriptionOverage(overage, fallbackGroup)
+				balanceBilling = true
+			} else {
+				// 将订阅信息存入上下文
+				c.Set(string(ContextKeySubscription), subscription)
 			}
+		}
 
-			// 将订阅信息存入上下文
-			c.Set(string(ContextKeySubscription), subscription)
-		} else {
-			// 余额模式：检查用户余额
-			if apiKey.User.Balance <= 0 {
-				AbortWithError(c, 403, "INSUFFICIENT_BALANCE", "Insufficient account balance")
-				return
+		if balanceBilling {
+			if apiKey.IsOrganizationKey() {
+				// 组织 Key：检查组织状态与共享余额
+				if !apiKey.Organization.IsActive() {
+					AbortWithError(c, 403, "ORGANIZATION_INACTIVE", "Organization is not active")
+					return
+				}
+				if apiKey.Organization.Balance <= 0 {
+					AbortWithError(c, 403, "INSUFFICIENT_BALANCE", "Insufficient organization balance")
+					return
+				}
+			} else {
+				// 余额模式：检查用户余额
+				if apiKey.User.Balance <= 0 {
+					AbortWithError(c, 403, "INSUFFICIENT_BALANCE", "Insufficient account balance")
+					return
+				}
 			}
 		}
 
@@ -165,6 +188,7 @@ func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscripti
 		})
 		c.Set(string(ContextKeyUserRole), apiKey.User.Role)
 		setGroupContext(c, apiKey.Group)
+		setAccountAffinityContext(c, apiKey)
 
 		c.Next()
 	}
@@ -200,3 +224,13 @@ func setGroupContext(c *gin.Context, group *service.Group) {
 	ctx := context.WithValue(c.Request.Context(), ctxkey.Group, group)
 	c.Request = c.Request.WithContext(ctx)
 }
+
+// setAccountAffinityContext 将 API Key / 用户的专属账号配置写入请求上下文，供网关调度使用
+func setAccountAffinityContext(c *gin.Context, apiKey *service.APIKey) {
+	affinity := service.ResolveAccountAffinity(apiKey)
+	if affinity == nil {
+		return
+	}
+	ctx := context.WithValue(c.Request.Context(), ctxkey.AccountAffinity, affinity)
+	c.Request = c.Request.WithContext(ctx)
+}
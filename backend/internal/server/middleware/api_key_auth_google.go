@@ -64,15 +64,17 @@ func APIKeyAuthWithSubscriptionGoogle(apiKeyService *service.APIKeyService, subs
 			})
 			c.Set(string(ContextKeyUserRole), apiKey.User.Role)
 			setGroupContext(c, apiKey.Group)
+			setAccountAffinityContext(c, apiKey)
 			c.Next()
 			return
 		}
 
 		isSubscriptionType := apiKey.Group != nil && apiKey.Group.IsSubscriptionType()
+		balanceBilling := !isSubscriptionType || subscriptionService == nil
 		if isSubscriptionType && subscriptionService != nil {
 			subscription, err := subscriptionService.GetActiveSubscription(
 				c.Request.Context(),
-				apiKey.User.ID,
+				apiKey.BillingUserID(),
 				apiKey.Group.ID,
 			)
 			if err != nil {
@@ -86,14 +88,32 @@ func APIKeyAuthWithSubscriptionGoogle(apiKeyService *service.APIKeyService, subs
 			_ = subscriptionService.CheckAndActivateWindow(c.Request.Context(), subscription)
 			_ = subscriptionService.CheckAndResetWindows(c.Request.Context(), subscription)
 			if err := subscriptionService.CheckUsageLimits(c.Request.Context(), subscription, apiKey.Group, 0); err != nil {
-				abortWithGoogleError(c, 429, err.Error())
-				return
+				overage, fallbackGroup, overageErr := subscriptionService.ResolveOverage(c.Request.Context(), subscription, apiKey.Group, err)
+				if overageErr != nil {
+					abortWithGoogleError(c, 429, overageErr.Error())
+					return
+				}
+				apiKey = apiKey.WithSubscriptionOverage(overage, fallbackGroup)
+				balanceBilling = true
+			} else {
+				c.Set(string(ContextKeySubscription), subscription)
 			}
-			c.Set(string(ContextKeySubscription), subscription)
-		} else {
-			if apiKey.User.Balance <= 0 {
-				abortWithGoogleError(c, 403, "Insufficient account balance")
-				return
+		}
+		if balanceBilling {
+			if apiKey.IsOrganizationKey() {
+				if !apiKey.Organization.IsActive() {
+					abortWithGoogleError(c, 403, "Organization is not active")
+					return
+				}
+				if apiKey.Organization.Balance <= 0 {
+					abortWithGoogleError(c, 403, "Insufficient organization balance")
+					return
+				}
+			} else {
+				if apiKey.User.Balance <= 0 {
+					abortWithGoogleError(c, 403, "Insufficient account balance")
+					return
+				}
 			}
 		}
 
@@ -104,6 +124,7 @@ func APIKeyAuthWithSubscriptionGoogle(apiKeyService *service.APIKeyService, subs
 		})
 		c.Set(string(ContextKeyUserRole), apiKey.User.Role)
 		setGroupContext(c, apiKey.Group)
+		setAccountAffinityContext(c, apiKey)
 		c.Next()
 	}
 }
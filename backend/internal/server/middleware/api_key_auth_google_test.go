@@ -27,8 +27,8 @@ func (f fakeAPIKeyRepo) Create(ctx context.Context, key *service.APIKey) error {
 func (f fakeAPIKeyRepo) GetByID(ctx context.Context, id int64) (*service.APIKey, error) {
 	return nil, errors.New("not implemented")
 }
-func (f fakeAPIKeyRepo) GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
-	return "", 0, errors.New("not implemented")
+func (f fakeAPIKeyRepo) GetCredentialAndOwnerID(ctx context.Context, id int64) (service.APIKeyCredential, int64, error) {
+	return service.APIKeyCredential{}, 0, errors.New("not implemented")
 }
 func (f fakeAPIKeyRepo) GetByKey(ctx context.Context, key string) (*service.APIKey, error) {
 	if f.getByKey == nil {
@@ -36,7 +36,7 @@ func (f fakeAPIKeyRepo) GetByKey(ctx context.Context, key string) (*service.APIK
 	}
 	return f.getByKey(ctx, key)
 }
-func (f fakeAPIKeyRepo) GetByKeyForAuth(ctx context.Context, key string) (*service.APIKey, error) {
+func (f fakeAPIKeyRepo) GetByKeyForAuth(ctx context.Context, keyHash, key string) (*service.APIKey, error) {
 	return f.GetByKey(ctx, key)
 }
 func (f fakeAPIKeyRepo) Update(ctx context.Context, key *service.APIKey) error {
@@ -54,7 +54,7 @@ func (f fakeAPIKeyRepo) VerifyOwnership(ctx context.Context, userID int64, apiKe
 func (f fakeAPIKeyRepo) CountByUserID(ctx context.Context, userID int64) (int64, error) {
 	return 0, errors.New("not implemented")
 }
-func (f fakeAPIKeyRepo) ExistsByKey(ctx context.Context, key string) (bool, error) {
+func (f fakeAPIKeyRepo) ExistsByKey(ctx context.Context, keyHash, key string) (bool, error) {
 	return false, errors.New("not implemented")
 }
 func (f fakeAPIKeyRepo) ListByGroupID(ctx context.Context, groupID int64, params pagination.PaginationParams) ([]service.APIKey, *pagination.PaginationResult, error) {
@@ -69,12 +69,18 @@ func (f fakeAPIKeyRepo) ClearGroupIDByGroupID(ctx context.Context, groupID int64
 func (f fakeAPIKeyRepo) CountByGroupID(ctx context.Context, groupID int64) (int64, error) {
 	return 0, errors.New("not implemented")
 }
-func (f fakeAPIKeyRepo) ListKeysByUserID(ctx context.Context, userID int64) ([]string, error) {
+func (f fakeAPIKeyRepo) ListCredentialsByUserID(ctx context.Context, userID int64) ([]service.APIKeyCredential, error) {
 	return nil, errors.New("not implemented")
 }
-func (f fakeAPIKeyRepo) ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error) {
+func (f fakeAPIKeyRepo) ListCredentialsByGroupID(ctx context.Context, groupID int64) ([]service.APIKeyCredential, error) {
 	return nil, errors.New("not implemented")
 }
+func (f fakeAPIKeyRepo) ListLegacyKeys(ctx context.Context, afterID int64, limit int) ([]service.APIKeyCredential, error) {
+	return nil, errors.New("not implemented")
+}
+func (f fakeAPIKeyRepo) MigrateKeyHash(ctx context.Context, id int64, legacyKey, keyHash, keyPrefix string) error {
+	return errors.New("not implemented")
+}
 
 type googleErrorResponse struct {
 	Error struct {
@@ -256,8 +256,8 @@ func (r *stubApiKeyRepo) GetByID(ctx context.Context, id int64) (*service.APIKey
 	return nil, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
-	return "", 0, errors.New("not implemented")
+func (r *stubApiKeyRepo) GetCredentialAndOwnerID(ctx context.Context, id int64) (service.APIKeyCredential, int64, error) {
+	return service.APIKeyCredential{}, 0, errors.New("not implemented")
 }
 
 func (r *stubApiKeyRepo) GetByKey(ctx context.Context, key string) (*service.APIKey, error) {
@@ -267,7 +267,7 @@ func (r *stubApiKeyRepo) GetByKey(ctx context.Context, key string) (*service.API
 	return nil, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) GetByKeyForAuth(ctx context.Context, key string) (*service.APIKey, error) {
+func (r *stubApiKeyRepo) GetByKeyForAuth(ctx context.Context, keyHash, key string) (*service.APIKey, error) {
 	return r.GetByKey(ctx, key)
 }
 
@@ -291,7 +291,7 @@ func (r *stubApiKeyRepo) CountByUserID(ctx context.Context, userID int64) (int64
 	return 0, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) ExistsByKey(ctx context.Context, key string) (bool, error) {
+func (r *stubApiKeyRepo) ExistsByKey(ctx context.Context, keyHash, key string) (bool, error) {
 	return false, errors.New("not implemented")
 }
 
@@ -311,14 +311,22 @@ func (r *stubApiKeyRepo) CountByGroupID(ctx context.Context, groupID int64) (int
 	return 0, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) ListKeysByUserID(ctx context.Context, userID int64) ([]string, error) {
+func (r *stubApiKeyRepo) ListCredentialsByUserID(ctx context.Context, userID int64) ([]service.APIKeyCredential, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error) {
+func (r *stubApiKeyRepo) ListCredentialsByGroupID(ctx context.Context, groupID int64) ([]service.APIKeyCredential, error) {
 	return nil, errors.New("not implemented")
 }
 
+func (r *stubApiKeyRepo) ListLegacyKeys(ctx context.Context, afterID int64, limit int) ([]service.APIKeyCredential, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *stubApiKeyRepo) MigrateKeyHash(ctx context.Context, id int64, legacyKey, keyHash, keyPrefix string) error {
+	return errors.New("not implemented")
+}
+
 type stubUserSubscriptionRepo struct {
 	getActive      func(ctx context.Context, userID, groupID int64) (*service.UserSubscription, error)
 	updateStatus   func(ctx context.Context, subscriptionID int64, status string) error
@@ -425,3 +433,35 @@ func (r *stubUserSubscriptionRepo) IncrementUsage(ctx context.Context, id int64,
 func (r *stubUserSubscriptionRepo) BatchUpdateExpiredStatus(ctx context.Context) (int64, error) {
 	return 0, errors.New("not implemented")
 }
+
+func (r *stubUserSubscriptionRepo) SumOverageCost(ctx context.Context, id int64, since time.Time) (float64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (r *stubUserSubscriptionRepo) SumQuotaUsage(ctx context.Context, id int64, since time.Time, modelPattern string) (service.SubscriptionQuotaUsage, error) {
+	return service.SubscriptionQuotaUsage{}, errors.New("not implemented")
+}
+
+func (r *stubUserSubscriptionRepo) SetAutoRenew(ctx context.Context, id int64, enabled bool) error {
+	return errors.New("not implemented")
+}
+
+func (r *stubUserSubscriptionRepo) Pause(ctx context.Context, id int64, pausedAt time.Time) error {
+	return errors.New("not implemented")
+}
+
+func (r *stubUserSubscriptionRepo) Resume(ctx context.Context, id int64, newExpiresAt time.Time) error {
+	return errors.New("not implemented")
+}
+
+func (r *stubUserSubscriptionRepo) RecordRenewal(ctx context.Context, id int64, prevExpiresAt, newExpiresAt time.Time) error {
+	return errors.New("not implemented")
+}
+
+func (r *stubUserSubscriptionRepo) MarkRenewalFailed(ctx context.Context, id int64, failedAt time.Time) error {
+	return errors.New("not implemented")
+}
+
+func (r *stubUserSubscriptionRepo) ListAutoRenewDue(ctx context.Context, dueBefore, retryBefore time.Time, limit int) ([]service.UserSubscription, error) {
+	return nil, errors.New("not implemented")
+}
@@ -26,3 +26,13 @@ func GetUserRoleFromContext(c *gin.Context) (string, bool) {
 	role, ok := value.(string)
 	return role, ok
 }
+
+// GetAuthSessionIDFromContext 返回当前请求 JWT 绑定的登录会话 ID
+func GetAuthSessionIDFromContext(c *gin.Context) string {
+	value, exists := c.Get(string(ContextKeyAuthSessionID))
+	if !exists {
+		return ""
+	}
+	sessionID, _ := value.(string)
+	return sessionID
+}
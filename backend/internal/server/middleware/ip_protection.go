@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// NewIPProtectionMiddleware 创建 IP 防护中间件（网关与认证接口）
+func NewIPProtectionMiddleware(ipProtectionService *service.IPProtectionService) IPProtectionMiddleware {
+	return IPProtectionMiddleware(ipProtection(ipProtectionService))
+}
+
+// ipProtection 拒绝黑名单与封禁中的 IP，并在响应为 401 时记录认证失败
+func ipProtection(ipProtectionService *service.IPProtectionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ipProtectionService == nil {
+			c.Next()
+			return
+		}
+
+		// 未配置 server.trusted_proxies 时转发头可被伪造，封禁与黑白名单只按直连对端判定
+		clientIP := ip.GetTrustedClientIP(c)
+		decision := ipProtectionService.Check(c.Request.Context(), clientIP)
+		if decision.Denied {
+			AbortWithError(c, http.StatusForbidden, "IP_DENIED", "Access from this IP address is not allowed")
+			return
+		}
+		if decision.Blocked() {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds()))))
+			AbortWithError(c, http.StatusTooManyRequests, "IP_BANNED", "Too many abnormal requests from this IP address, please try again later")
+			return
+		}
+
+		c.Next()
+
+		if !decision.Trusted && c.Writer.Status() == http.StatusUnauthorized {
+			// 客户端可能已断开，计数不应随请求取消
+			ipProtectionService.RecordFailure(context.WithoutCancel(c.Request.Context()), clientIP)
+		}
+	}
+}
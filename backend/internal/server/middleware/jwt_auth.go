@@ -4,18 +4,19 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 // NewJWTAuthMiddleware 创建 JWT 认证中间件
-func NewJWTAuthMiddleware(authService *service.AuthService, userService *service.UserService) JWTAuthMiddleware {
-	return JWTAuthMiddleware(jwtAuth(authService, userService))
+func NewJWTAuthMiddleware(authService *service.AuthService, userService *service.UserService, sessionService *service.AuthSessionService) JWTAuthMiddleware {
+	return JWTAuthMiddleware(jwtAuth(authService, userService, sessionService))
 }
 
 // jwtAuth JWT认证中间件实现
-func jwtAuth(authService *service.AuthService, userService *service.UserService) gin.HandlerFunc {
+func jwtAuth(authService *service.AuthService, userService *service.UserService, sessionService *service.AuthSessionService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从Authorization header中提取token
 		authHeader := c.GetHeader("Authorization")
@@ -68,14 +69,29 @@ func jwtAuth(authService *service.AuthService, userService *service.UserService)
 			return
 		}
 
+		// 登录会话被用户或管理员吊销后，绑定该会话的 token 立即失效
+		if err := sessionService.ValidateAccessToken(c.Request.Context(), claims, ip.GetClientIP(c)); err != nil {
+			abortWithSessionError(c, err)
+			return
+		}
+
 		c.Set(string(ContextKeyUser), AuthSubject{
 			UserID:      user.ID,
 			Concurrency: user.Concurrency,
 		})
 		c.Set(string(ContextKeyUserRole), user.Role)
+		c.Set(string(ContextKeyAuthSessionID), claims.SessionID)
 
 		c.Next()
 	}
 }
 
+func abortWithSessionError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrAuthSessionRevoked) {
+		AbortWithError(c, 401, "SESSION_REVOKED", "Login session has been revoked")
+		return
+	}
+	AbortWithError(c, 503, "SERVICE_UNAVAILABLE", "Service temporarily unavailable")
+}
+
 // Deprecated: prefer GetAuthSubjectFromContext in auth_subject.go.
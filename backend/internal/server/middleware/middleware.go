@@ -21,6 +21,8 @@ const (
 	ContextKeySubscription ContextKey = "subscription"
 	// ContextKeyForcePlatform 强制平台（用于 /antigravity 路由）
 	ContextKeyForcePlatform ContextKey = "force_platform"
+	// ContextKeyAuthSessionID 当前 JWT 绑定的登录会话 ID（string，旧 token 为空）
+	ContextKeyAuthSessionID ContextKey = "auth_session_id"
 )
 
 // ForcePlatform 返回设置强制平台的中间件
@@ -24,12 +24,13 @@ func SetupRouter(
 	jwtAuth middleware2.JWTAuthMiddleware,
 	adminAuth middleware2.AdminAuthMiddleware,
 	apiKeyAuth middleware2.APIKeyAuthMiddleware,
+	ipProtection middleware2.IPProtectionMiddleware,
 	apiKeyService *service.APIKeyService,
 	subscriptionService *service.SubscriptionService,
 	opsService *service.OpsService,
 	settingService *service.SettingService,
 	cfg *config.Config,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 ) *gin.Engine {
 	// 应用中间件
 	r.Use(middleware2.Logger())
@@ -66,7 +67,7 @@ func SetupRouter(
 	}
 
 	// 注册路由
-	registerRoutes(r, handlers, jwtAuth, adminAuth, apiKeyAuth, apiKeyService, subscriptionService, opsService, cfg, redisClient)
+	registerRoutes(r, handlers, jwtAuth, adminAuth, apiKeyAuth, ipProtection, apiKeyService, subscriptionService, opsService, cfg, redisClient)
 
 	return r
 }
@@ -103,11 +104,12 @@ func registerRoutes(
 	jwtAuth middleware2.JWTAuthMiddleware,
 	adminAuth middleware2.AdminAuthMiddleware,
 	apiKeyAuth middleware2.APIKeyAuthMiddleware,
+	ipProtection middleware2.IPProtectionMiddleware,
 	apiKeyService *service.APIKeyService,
 	subscriptionService *service.SubscriptionService,
 	opsService *service.OpsService,
 	cfg *config.Config,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 ) {
 	// 通用路由（健康检查、状态等）
 	routes.RegisterCommonRoutes(r)
@@ -117,8 +119,8 @@ func registerRoutes(
 
 	// 注册各模块路由
 	routes.RegisterPublicRoutes(v1, h)
-	routes.RegisterAuthRoutes(v1, h, jwtAuth, redisClient)
+	routes.RegisterAuthRoutes(v1, h, jwtAuth, ipProtection, redisClient)
 	routes.RegisterUserRoutes(v1, h, jwtAuth)
 	routes.RegisterAdminRoutes(v1, h, adminAuth, redisClient)
-	routes.RegisterGatewayRoutes(r, h, apiKeyAuth, apiKeyService, subscriptionService, opsService, cfg)
+	routes.RegisterGatewayRoutes(r, h, apiKeyAuth, ipProtection, apiKeyService, subscriptionService, opsService, cfg)
 }
@@ -17,7 +17,7 @@ func RegisterAdminRoutes(
 	v1 *gin.RouterGroup,
 	h *handler.Handlers,
 	adminAuth servermiddleware.AdminAuthMiddleware,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 ) {
 	// Create rate limiter for admin endpoints that require protection
 	rateLimiter := middleware.NewRateLimiter(redisClient)
@@ -37,6 +37,12 @@ func RegisterAdminRoutes(
 		// 账号管理
 		registerAccountRoutes(admin, h)
 
+		// 专属账号
+		registerDedicatedAccountRoutes(admin, h)
+
+		// 组织管理
+		registerOrganizationRoutes(admin, h)
+
 		// OpenAI OAuth
 		registerOpenAIOAuthRoutes(admin, h)
 
@@ -70,6 +76,9 @@ func RegisterAdminRoutes(
 		// 系统管理
 		registerSystemRoutes(admin, h)
 
+		// IP 防护
+		registerSecurityRoutes(admin, h)
+
 		// 订阅管理
 		registerSubscriptionRoutes(admin, h)
 
@@ -116,6 +125,9 @@ func registerOpsRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		ops.GET("/account-availability", h.Admin.Ops.GetAccountAvailability)
 		ops.GET("/realtime-traffic", h.Admin.Ops.GetRealtimeTrafficSummary)
 
+		// Capacity planning
+		ops.GET("/capacity-plan", h.Admin.Ops.GetCapacityPlan)
+
 		// Alerts (rules + events)
 		ops.GET("/alert-rules", h.Admin.Ops.ListAlertRules)
 		ops.POST("/alert-rules", h.Admin.Ops.CreateAlertRule)
@@ -184,6 +196,7 @@ func registerOpsRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		ops.GET("/dashboard/latency-histogram", h.Admin.Ops.GetDashboardLatencyHistogram)
 		ops.GET("/dashboard/error-trend", h.Admin.Ops.GetDashboardErrorTrend)
 		ops.GET("/dashboard/error-distribution", h.Admin.Ops.GetDashboardErrorDistribution)
+		ops.GET("/dashboard/traffic-arms", h.Admin.Ops.GetDashboardTrafficArms)
 	}
 }
 
@@ -213,6 +226,8 @@ func registerUserManagementRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		users.POST("/:id/balance", h.Admin.User.UpdateBalance)
 		users.GET("/:id/api-keys", h.Admin.User.GetUserAPIKeys)
 		users.GET("/:id/usage", h.Admin.User.GetUserUsage)
+		users.GET("/:id/sessions", h.Admin.User.ListSessions)
+		users.POST("/:id/force-logout", h.Admin.User.ForceLogout)
 
 		// User attribute values
 		users.GET("/:id/attributes", h.Admin.UserAttribute.GetUserAttributes)
@@ -226,6 +241,10 @@ func registerInviteRoutes(admin *gin.RouterGroup, h *handler.Handlers, rateLimit
 		invites.GET("/settings", h.Admin.Invite.GetSettings)
 		invites.PUT("/settings", h.Admin.Invite.UpdateSettings)
 		invites.GET("/logs", h.Admin.Invite.ListLogs)
+		invites.GET("/withdrawals", h.Admin.Invite.ListWithdrawals)
+		invites.POST("/withdrawals/:id/approve", h.Admin.Invite.ApproveWithdrawal)
+		invites.POST("/withdrawals/:id/reject", h.Admin.Invite.RejectWithdrawal)
+		invites.PUT("/:invitee_id/commission-status", h.Admin.Invite.UpdateCommissionStatus)
 		invites.POST("/:invitee_id/confirm",
 			rateLimiter.LimitWithOptions("admin-invite-confirm", 10, time.Minute, middleware.RateLimitOptions{
 				FailureMode: middleware.RateLimitFailClose,
@@ -246,6 +265,29 @@ func registerGroupRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		groups.DELETE("/:id", h.Admin.Group.Delete)
 		groups.GET("/:id/stats", h.Admin.Group.GetStats)
 		groups.GET("/:id/api-keys", h.Admin.Group.GetGroupAPIKeys)
+		groups.POST("/:id/scheduling-simulation", h.Admin.Group.SimulateScheduling)
+		groups.POST("/:id/rewrite-rules/dry-run", h.Admin.Group.DryRunRewriteRules)
+	}
+}
+
+func registerDedicatedAccountRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	dedicated := admin.Group("/dedicated-accounts")
+	{
+		dedicated.GET("", h.Admin.DedicatedAccount.List)
+		dedicated.PUT("/users/:id", h.Admin.DedicatedAccount.SetUserAccounts)
+		dedicated.PUT("/api-keys/:id", h.Admin.DedicatedAccount.SetAPIKeyAccounts)
+	}
+}
+
+func registerOrganizationRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	organizations := admin.Group("/organizations")
+	{
+		organizations.GET("", h.Admin.Organization.List)
+		organizations.GET("/:id", h.Admin.Organization.GetByID)
+		organizations.PUT("/:id", h.Admin.Organization.Update)
+		organizations.DELETE("/:id", h.Admin.Organization.Delete)
+		organizations.GET("/:id/members", h.Admin.Organization.ListMembers)
+		organizations.POST("/:id/balance", h.Admin.Organization.UpdateBalance)
 	}
 }
 
@@ -256,6 +298,8 @@ func registerAccountRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		accounts.GET("/:id", h.Admin.Account.GetByID)
 		accounts.POST("", h.Admin.Account.Create)
 		accounts.POST("/sync/crs", h.Admin.Account.SyncFromCRS)
+		accounts.POST("/import", h.Admin.Account.Import)
+		accounts.GET("/export", h.Admin.Account.Export)
 		accounts.PUT("/:id", h.Admin.Account.Update)
 		accounts.DELETE("/:id", h.Admin.Account.Delete)
 		accounts.POST("/:id/test", h.Admin.Account.Test)
@@ -370,6 +414,9 @@ func registerSettingsRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		// 流超时处理配置
 		adminSettings.GET("/stream-timeout", h.Admin.Setting.GetStreamTimeoutSettings)
 		adminSettings.PUT("/stream-timeout", h.Admin.Setting.UpdateStreamTimeoutSettings)
+		// 模型限流域配置
+		adminSettings.GET("/model-rate-limit-scopes", h.Admin.Setting.GetModelRateLimitScopeSettings)
+		adminSettings.PUT("/model-rate-limit-scopes", h.Admin.Setting.UpdateModelRateLimitScopeSettings)
 	}
 }
 
@@ -381,6 +428,20 @@ func registerSystemRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		system.POST("/update", h.Admin.System.PerformUpdate)
 		system.POST("/rollback", h.Admin.System.Rollback)
 		system.POST("/restart", h.Admin.System.RestartService)
+		system.POST("/config/reload", h.Admin.System.ReloadConfig)
+		system.GET("/config/reloads", h.Admin.System.GetConfigReloads)
+		system.POST("/backup", h.Admin.Backup.Backup)
+		system.POST("/restore", h.Admin.Backup.Restore)
+	}
+}
+
+func registerSecurityRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	security := admin.Group("/security")
+	{
+		security.GET("/ip-bans", h.Admin.IPProtection.ListBans)
+		security.POST("/ip-bans/unban", h.Admin.IPProtection.Unban)
+		security.GET("/ip-rules", h.Admin.IPProtection.ListRules)
+		security.PUT("/ip-rules", h.Admin.IPProtection.UpdateRules)
 	}
 }
 
@@ -16,13 +16,16 @@ func RegisterAuthRoutes(
 	v1 *gin.RouterGroup,
 	h *handler.Handlers,
 	jwtAuth servermiddleware.JWTAuthMiddleware,
-	redisClient *redis.Client,
+	ipProtection servermiddleware.IPProtectionMiddleware,
+	redisClient redis.UniversalClient,
 ) {
 	// 创建速率限制器
 	rateLimiter := middleware.NewRateLimiter(redisClient)
 
 	// 公开接口
 	auth := v1.Group("/auth")
+	// 登录/刷新等失败（401）计入 IP 封禁统计
+	auth.Use(gin.HandlerFunc(ipProtection))
 	{
 		auth.POST("/register",
 			rateLimiter.LimitWithOptions("register", 3, time.Minute, middleware.RateLimitOptions{
@@ -42,6 +45,38 @@ func RegisterAuthRoutes(
 			}),
 			h.Auth.Login2FA,
 		)
+		// Passkey：作为第二因素，或免密码登录
+		auth.POST("/login/2fa/webauthn/options",
+			rateLimiter.LimitWithOptions("login-2fa", 5, time.Minute, middleware.RateLimitOptions{
+				FailureMode: middleware.RateLimitFailClose,
+			}),
+			h.Auth.Login2FAPasskeyOptions,
+		)
+		auth.POST("/login/2fa/webauthn",
+			rateLimiter.LimitWithOptions("login-2fa", 5, time.Minute, middleware.RateLimitOptions{
+				FailureMode: middleware.RateLimitFailClose,
+			}),
+			h.Auth.Login2FAPasskey,
+		)
+		auth.POST("/passkey/options",
+			rateLimiter.LimitWithOptions("passkey-login", 10, time.Minute, middleware.RateLimitOptions{
+				FailureMode: middleware.RateLimitFailClose,
+			}),
+			h.Auth.PasskeyLoginOptions,
+		)
+		auth.POST("/passkey/login",
+			rateLimiter.LimitWithOptions("passkey-login", 10, time.Minute, middleware.RateLimitOptions{
+				FailureMode: middleware.RateLimitFailClose,
+			}),
+			h.Auth.PasskeyLogin,
+		)
+		// refresh token 一次性有效，每次刷新都会轮换
+		auth.POST("/refresh",
+			rateLimiter.LimitWithOptions("refresh", 30, time.Minute, middleware.RateLimitOptions{
+				FailureMode: middleware.RateLimitFailClose,
+			}),
+			h.Auth.RefreshToken,
+		)
 		auth.POST("/send-verify-code", h.Auth.SendVerifyCode)
 		// 优惠码验证接口添加速率限制：每分钟最多 10 次（Redis 故障时 fail-close）
 		auth.POST("/validate-promo-code", rateLimiter.LimitWithOptions("validate-promo", 10, time.Minute, middleware.RateLimitOptions{
@@ -70,5 +105,6 @@ func RegisterAuthRoutes(
 	authenticated.Use(gin.HandlerFunc(jwtAuth))
 	{
 		authenticated.GET("/auth/me", h.Auth.GetCurrentUser)
+		authenticated.POST("/auth/logout", h.Auth.Logout)
 	}
 }
@@ -23,6 +23,11 @@ func RegisterUserRoutes(
 			user.PUT("/password", h.User.ChangePassword)
 			user.PUT("", h.User.UpdateProfile)
 
+			// 登录会话（设备列表）
+			user.GET("/sessions", h.User.ListSessions)
+			user.DELETE("/sessions", h.User.RevokeOtherSessions)
+			user.DELETE("/sessions/:id", h.User.RevokeSession)
+
 			// TOTP 双因素认证
 			totp := user.Group("/totp")
 			{
@@ -33,6 +38,23 @@ func RegisterUserRoutes(
 				totp.POST("/enable", h.Totp.Enable)
 				totp.POST("/disable", h.Totp.Disable)
 			}
+
+			// Passkey（WebAuthn）
+			webauthn := user.Group("/webauthn")
+			{
+				webauthn.GET("/credentials", h.TwoFactor.ListPasskeys)
+				webauthn.POST("/register/options", h.TwoFactor.BeginPasskeyRegistration)
+				webauthn.POST("/register", h.TwoFactor.FinishPasskeyRegistration)
+				webauthn.PUT("/credentials/:id", h.TwoFactor.RenamePasskey)
+				webauthn.POST("/credentials/:id/delete", h.TwoFactor.DeletePasskey)
+			}
+
+			// 两步验证恢复码（TOTP 与 passkey 共用）
+			twoFactor := user.Group("/2fa")
+			{
+				twoFactor.GET("/recovery-codes", h.TwoFactor.GetRecoveryCodeStatus)
+				twoFactor.POST("/recovery-codes", h.TwoFactor.GenerateRecoveryCodes)
+			}
 		}
 
 		// API Key管理
@@ -78,6 +100,12 @@ func RegisterUserRoutes(
 			subscriptions.GET("/active", h.Subscription.GetActive)
 			subscriptions.GET("/progress", h.Subscription.GetProgress)
 			subscriptions.GET("/summary", h.Subscription.GetSummary)
+			subscriptions.PUT("/:id/auto-renew", h.Subscription.SetAutoRenew)
+			subscriptions.POST("/:id/renew", h.Subscription.Renew)
+			subscriptions.GET("/:id/switch-quote", h.Subscription.SwitchQuote)
+			subscriptions.POST("/:id/switch", h.Subscription.Switch)
+			subscriptions.POST("/:id/pause", h.Subscription.Pause)
+			subscriptions.POST("/:id/resume", h.Subscription.Resume)
 		}
 
 		// 邀请记录
@@ -86,6 +114,29 @@ func RegisterUserRoutes(
 			invites.GET("/summary", h.Invite.GetSummary)
 			invites.GET("/records", h.Invite.ListRecords)
 			invites.GET("/rewards", h.Invite.ListRewards)
+			invites.GET("/commission", h.Invite.GetCommissionReport)
+			invites.GET("/commission/records", h.Invite.ListCommissions)
+			invites.POST("/commission/convert", h.Invite.ConvertCommission)
+			invites.GET("/commission/withdrawals", h.Invite.ListWithdrawals)
+			invites.POST("/commission/withdrawals", h.Invite.WithdrawCommission)
+		}
+
+		// 组织（团队）
+		organizations := authenticated.Group("/organizations")
+		{
+			organizations.GET("", h.Organization.List)
+			organizations.POST("", h.Organization.Create)
+			organizations.POST("/invitations/accept", h.Organization.AcceptInvitation)
+			organizations.GET("/:id", h.Organization.Get)
+			organizations.GET("/:id/members", h.Organization.ListMembers)
+			organizations.PUT("/:id/members/:user_id", h.Organization.UpdateMember)
+			organizations.DELETE("/:id/members/:user_id", h.Organization.RemoveMember)
+			organizations.POST("/:id/transfer", h.Organization.Transfer)
+			organizations.GET("/:id/invitations", h.Organization.ListInvitations)
+			organizations.POST("/:id/invitations", h.Organization.Invite)
+			organizations.DELETE("/:id/invitations/:invitation_id", h.Organization.RevokeInvitation)
+			organizations.GET("/:id/dashboard", h.Organization.Dashboard)
+			organizations.GET("/:id/usage", h.Organization.Usage)
 		}
 
 		// NOTE: /api/v1/plans is public (see routes/common.go)
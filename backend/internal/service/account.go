@@ -47,6 +47,9 @@ type Account struct {
 	AccountGroups []AccountGroup
 	GroupIDs      []int64
 	Groups        []*Group
+
+	// availability 加载时预解析的可用时间窗口（见 CompileAvailabilitySchedule），调度热路径直接复用
+	availability *compiledAvailabilitySchedule
 }
 
 type TempUnschedulableRule struct {
@@ -91,6 +94,10 @@ func (a *Account) IsSchedulable() bool {
 	if a.TempUnschedulableUntil != nil && now.Before(*a.TempUnschedulableUntil) {
 		return false
 	}
+	// 可用时间窗口之外不可调度（排空期仍可调度，由新会话选择路径排除）
+	if a.ScheduleStateAt(now) == AccountScheduleClosed {
+		return false
+	}
 	return true
 }
 
@@ -655,6 +662,24 @@ func (a *Account) GetWindowCostLimit() float64 {
 	return 0
 }
 
+// GetSchedulingWeight 获取加权轮询调度权重（weighted 策略使用）
+// 默认值为 1，最大 100
+func (a *Account) GetSchedulingWeight() int {
+	if a.Extra == nil {
+		return 1
+	}
+	if v, ok := a.Extra["scheduling_weight"]; ok {
+		val := parseExtraInt(v)
+		if val > 100 {
+			return 100
+		}
+		if val > 0 {
+			return val
+		}
+	}
+	return 1
+}
+
 // GetWindowCostStickyReserve 获取粘性会话预留额度（美元）
 // 默认值为 10
 func (a *Account) GetWindowCostStickyReserve() float64 {
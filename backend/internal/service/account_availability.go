@@ -0,0 +1,372 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+	"github.com/robfig/cron/v3"
+)
+
+// accountAvailabilityExtraKey 账号可用时间窗口配置在 extra 中的键
+const accountAvailabilityExtraKey = "availability_schedule"
+
+const (
+	maxAvailabilityWindows          = 32
+	maxAvailabilityCronWindows      = 16
+	maxAvailabilityCronDurationMins = 7 * 24 * 60
+	maxAvailabilityDrainMinutes     = 24 * 60
+	maxSessionResetDrainMinutes     = 5 * 60
+	// availabilityWindowChainLimit 计算连续可用区间终点时最多衔接的相邻窗口数
+	availabilityWindowChainLimit = 16
+)
+
+var accountAvailabilityCronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// AccountScheduleState 账号在某一时刻按可用时间窗口的调度状态
+type AccountScheduleState int
+
+const (
+	// AccountScheduleOpen 可正常调度
+	AccountScheduleOpen AccountScheduleState = iota
+	// AccountScheduleDraining 排空中：不再分配新会话，已绑定的粘性会话继续使用
+	AccountScheduleDraining
+	// AccountScheduleClosed 不在可用时间窗口内，不可调度
+	AccountScheduleClosed
+)
+
+func (a AccountScheduleState) String() string {
+	switch a {
+	case AccountScheduleDraining:
+		return "draining"
+	case AccountScheduleClosed:
+		return "closed"
+	default:
+		return "open"
+	}
+}
+
+// AccountAvailabilitySchedule 账号可用时间窗口（存储于 extra.availability_schedule）。
+//
+// Windows 与 Cron 任一命中即处于可用时间窗口；两者都为空表示全天可用（仅会话重置前排空生效）。
+// 窗口结束前 DrainMinutes 分钟进入排空期，5h 会话窗口重置前 DrainBeforeSessionResetMinutes 分钟同样进入排空期。
+type AccountAvailabilitySchedule struct {
+	// Timezone IANA 时区，为空使用服务端配置的时区
+	Timezone string                      `json:"timezone,omitempty"`
+	Windows  []AccountAvailabilityWindow `json:"windows,omitempty"`
+	Cron     []AccountAvailabilityCron   `json:"cron,omitempty"`
+
+	DrainMinutes                   int `json:"drain_minutes,omitempty"`
+	DrainBeforeSessionResetMinutes int `json:"drain_before_session_reset_minutes,omitempty"`
+
+	loc    *time.Location
+	weekly []weeklyAvailabilityRange
+	crons  []cronAvailabilityRange
+}
+
+// AccountAvailabilityWindow 每周固定时间段
+type AccountAvailabilityWindow struct {
+	// Days 星期（0=周日 ... 6=周六），为空表示每天
+	Days []int `json:"days,omitempty"`
+	// Start / End 为 HH:MM，End 可为 24:00；End 不晚于 Start 表示跨零点，归属 Start 所在日
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// AccountAvailabilityCron cron 风格窗口：表达式匹配的时刻为窗口开始，持续 DurationMinutes 分钟
+type AccountAvailabilityCron struct {
+	Expr            string `json:"expr"`
+	DurationMinutes int    `json:"duration_minutes"`
+}
+
+type weeklyAvailabilityRange struct {
+	days       [7]bool
+	start, end int // 分钟
+}
+
+type cronAvailabilityRange struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+func invalidAvailabilitySchedule(format string, a ...any) error {
+	return infraerrors.Newf(http.StatusBadRequest, "ACCOUNT_AVAILABILITY_SCHEDULE_INVALID", "invalid availability schedule: "+format, a...)
+}
+
+// ValidateAccountAvailabilitySchedule 校验 extra 中的可用时间窗口配置（未配置时返回 nil）
+func ValidateAccountAvailabilitySchedule(extra map[string]any) error {
+	raw, ok := extra[accountAvailabilityExtraKey]
+	if !ok || raw == nil {
+		return nil
+	}
+	_, err := parseAccountAvailabilitySchedule(raw)
+	return err
+}
+
+func parseAccountAvailabilitySchedule(raw any) (*AccountAvailabilitySchedule, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, invalidAvailabilitySchedule("%v", err)
+	}
+	var sched AccountAvailabilitySchedule
+	if err := json.Unmarshal(data, &sched); err != nil {
+		return nil, invalidAvailabilitySchedule("%v", err)
+	}
+	if err := sched.compile(); err != nil {
+		return nil, err
+	}
+	return &sched, nil
+}
+
+func (s *AccountAvailabilitySchedule) compile() error {
+	loc, err := timezone.LoadLocation(strings.TrimSpace(s.Timezone))
+	if err != nil {
+		return invalidAvailabilitySchedule("%v", err)
+	}
+	s.loc = loc
+
+	if len(s.Windows) > maxAvailabilityWindows {
+		return invalidAvailabilitySchedule("at most %d windows", maxAvailabilityWindows)
+	}
+	s.weekly = make([]weeklyAvailabilityRange, 0, len(s.Windows))
+	for i, w := range s.Windows {
+		var r weeklyAvailabilityRange
+		if r.start, err = parseClockMinutes(w.Start, false); err != nil {
+			return invalidAvailabilitySchedule("windows[%d].start: %v", i, err)
+		}
+		if r.end, err = parseClockMinutes(w.End, true); err != nil {
+			return invalidAvailabilitySchedule("windows[%d].end: %v", i, err)
+		}
+		if len(w.Days) == 0 {
+			r.days = [7]bool{true, true, true, true, true, true, true}
+		}
+		for _, d := range w.Days {
+			if d < 0 || d > 6 {
+				return invalidAvailabilitySchedule("windows[%d].days: %d out of range 0-6", i, d)
+			}
+			r.days[d] = true
+		}
+		s.weekly = append(s.weekly, r)
+	}
+
+	if len(s.Cron) > maxAvailabilityCronWindows {
+		return invalidAvailabilitySchedule("at most %d cron windows", maxAvailabilityCronWindows)
+	}
+	s.crons = make([]cronAvailabilityRange, 0, len(s.Cron))
+	for i, c := range s.Cron {
+		schedule, err := accountAvailabilityCronParser.Parse(strings.TrimSpace(c.Expr))
+		if err != nil {
+			return invalidAvailabilitySchedule("cron[%d].expr: %v", i, err)
+		}
+		if c.DurationMinutes <= 0 || c.DurationMinutes > maxAvailabilityCronDurationMins {
+			return invalidAvailabilitySchedule("cron[%d].duration_minutes must be in 1-%d", i, maxAvailabilityCronDurationMins)
+		}
+		s.crons = append(s.crons, cronAvailabilityRange{schedule: schedule, duration: time.Duration(c.DurationMinutes) * time.Minute})
+	}
+
+	if s.DrainMinutes < 0 || s.DrainMinutes > maxAvailabilityDrainMinutes {
+		return invalidAvailabilitySchedule("drain_minutes must be in 0-%d", maxAvailabilityDrainMinutes)
+	}
+	if s.DrainBeforeSessionResetMinutes < 0 || s.DrainBeforeSessionResetMinutes > maxSessionResetDrainMinutes {
+		return invalidAvailabilitySchedule("drain_before_session_reset_minutes must be in 0-%d", maxSessionResetDrainMinutes)
+	}
+	return nil
+}
+
+// parseClockMinutes 解析 HH:MM 为当天分钟数；allow24 允许 24:00 表示当天结束
+func parseClockMinutes(v string, allow24 bool) (int, error) {
+	hh, mm, ok := strings.Cut(strings.TrimSpace(v), ":")
+	if !ok {
+		return 0, fmt.Errorf("%q is not HH:MM", v)
+	}
+	h, errH := strconv.Atoi(hh)
+	m, errM := strconv.Atoi(mm)
+	if errH != nil || errM != nil || h < 0 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("%q is not HH:MM", v)
+	}
+	if h == 24 && m == 0 && allow24 {
+		return 24 * 60, nil
+	}
+	if h > 23 {
+		return 0, fmt.Errorf("%q is not HH:MM", v)
+	}
+	return h*60 + m, nil
+}
+
+// alwaysOn 未配置任何窗口时全天可用
+func (s *AccountAvailabilitySchedule) alwaysOn() bool {
+	return len(s.weekly) == 0 && len(s.crons) == 0
+}
+
+// windowEnd 返回 t 所在可用窗口的结束时间（命中多个窗口时取最晚结束），不在任何窗口内时返回 false
+func (s *AccountAvailabilitySchedule) windowEnd(t time.Time) (time.Time, bool) {
+	lt := t.In(s.loc)
+	y, mo, d := lt.Date()
+	mins := lt.Hour()*60 + lt.Minute()
+	wd := int(lt.Weekday())
+	prev := (wd + 6) % 7
+	at := func(dayOffset, minutes int) time.Time {
+		return time.Date(y, mo, d+dayOffset, 0, minutes, 0, 0, s.loc)
+	}
+
+	var end time.Time
+	found := false
+	consider := func(e time.Time) {
+		if !found || e.After(end) {
+			end, found = e, true
+		}
+	}
+	for _, r := range s.weekly {
+		if r.end > r.start {
+			if r.days[wd] && mins >= r.start && mins < r.end {
+				consider(at(0, r.end))
+			}
+			continue
+		}
+		// 跨零点（End 不晚于 Start）：当天 Start 之后，或前一天窗口延续到今天 End 之前
+		if r.days[wd] && mins >= r.start {
+			consider(at(1, r.end))
+		}
+		if r.days[prev] && mins < r.end {
+			consider(at(0, r.end))
+		}
+	}
+	for _, c := range s.crons {
+		// 窗口开始时刻落在 (t-duration, t] 内即处于窗口中
+		if start := c.schedule.Next(lt.Add(-c.duration)); !start.After(lt) {
+			consider(start.Add(c.duration))
+		}
+	}
+	return end, found
+}
+
+// scheduleStateAt 按时间窗口判断可用状态（不含会话重置前排空）
+func (s *AccountAvailabilitySchedule) scheduleStateAt(now time.Time) AccountScheduleState {
+	if s.alwaysOn() {
+		return AccountScheduleOpen
+	}
+	end, ok := s.windowEnd(now)
+	if !ok {
+		return AccountScheduleClosed
+	}
+	if s.DrainMinutes <= 0 {
+		return AccountScheduleOpen
+	}
+	// 相邻/重叠窗口视为连续可用，排空期按连续区间的终点计算
+	horizon := now.Add(time.Duration(s.DrainMinutes) * time.Minute)
+	for i := 0; i < availabilityWindowChainLimit && end.Before(horizon); i++ {
+		next, ok := s.windowEnd(end)
+		if !ok || !next.After(end) {
+			break
+		}
+		end = next
+	}
+	if end.After(horizon) {
+		return AccountScheduleOpen
+	}
+	return AccountScheduleDraining
+}
+
+// compiledAvailabilitySchedule 预解析结果；raw 为解析时配置的 JSON，sched 为 nil 表示未配置或配置无效
+type compiledAvailabilitySchedule struct {
+	raw   string
+	sched *AccountAvailabilitySchedule
+}
+
+// CompileAvailabilitySchedule 解析并缓存账号的可用时间窗口配置。
+// 仓储层加载账号（数据库与调度缓存）时调用；缓存以配置原文为键，Extra 被替换后自动按新配置重新解析。
+func (a *Account) CompileAvailabilitySchedule() {
+	if a == nil {
+		return
+	}
+	raw, ok := a.availabilityScheduleRaw()
+	if !ok {
+		a.availability = nil
+		return
+	}
+	a.availability = &compiledAvailabilitySchedule{raw: raw, sched: parseAvailabilityScheduleJSON(raw)}
+}
+
+// GetAvailabilitySchedule 获取账号可用时间窗口配置；未配置或配置无效时返回 nil（视为全天可用）
+func (a *Account) GetAvailabilitySchedule() *AccountAvailabilitySchedule {
+	if a == nil {
+		return nil
+	}
+	raw, ok := a.availabilityScheduleRaw()
+	if !ok {
+		return nil
+	}
+	// 账号可能被多个调度协程共享，配置不一致时仅临时解析，不回写缓存
+	if a.availability != nil && a.availability.raw == raw {
+		return a.availability.sched
+	}
+	return parseAvailabilityScheduleJSON(raw)
+}
+
+// availabilityScheduleRaw 返回 Extra 中可用时间窗口配置的 JSON 原文；未配置或无法序列化时返回 false
+func (a *Account) availabilityScheduleRaw() (string, bool) {
+	if a.Extra == nil {
+		return "", false
+	}
+	raw, ok := a.Extra[accountAvailabilityExtraKey]
+	if !ok || raw == nil {
+		return "", false
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+func parseAvailabilityScheduleJSON(raw string) *AccountAvailabilitySchedule {
+	sched, err := parseAccountAvailabilitySchedule(json.RawMessage(raw))
+	if err != nil {
+		return nil
+	}
+	return sched
+}
+
+// ScheduleStateAt 账号在 now 时刻按可用时间窗口的调度状态
+func (a *Account) ScheduleStateAt(now time.Time) AccountScheduleState {
+	sched := a.GetAvailabilitySchedule()
+	if sched == nil {
+		return AccountScheduleOpen
+	}
+	state := sched.scheduleStateAt(now)
+	if state != AccountScheduleOpen {
+		return state
+	}
+	// 5h 会话窗口即将重置：提前排空，让新会话落到其他账号
+	if drain := sched.DrainBeforeSessionResetMinutes; drain > 0 && a.SessionWindowEnd != nil &&
+		now.Before(*a.SessionWindowEnd) && a.SessionWindowEnd.Sub(now) <= time.Duration(drain)*time.Minute {
+		return AccountScheduleDraining
+	}
+	return AccountScheduleOpen
+}
+
+// IsDraining 账号是否处于排空期（不接受新会话，已有粘性会话继续）
+func (a *Account) IsDraining() bool {
+	return a.ScheduleStateAt(time.Now()) == AccountScheduleDraining
+}
+
+// IsSchedulableForNewSession 账号是否可分配给新会话（可调度且不在排空期）
+func (a *Account) IsSchedulableForNewSession() bool {
+	return a.IsSchedulable() && !a.IsDraining()
+}
+
+// filterUnavailableAccounts 剔除当前不在可用时间窗口内的账号（排空中的账号保留，供粘性会话使用）
+func filterUnavailableAccounts(accounts []Account, now time.Time) []Account {
+	out := accounts[:0:0]
+	for i := range accounts {
+		if accounts[i].ScheduleStateAt(now) != AccountScheduleClosed {
+			out = append(out, accounts[i])
+		}
+	}
+	return out
+}
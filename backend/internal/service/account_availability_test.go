@@ -0,0 +1,173 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func accountWithSchedule(schedule map[string]any) *Account {
+	return &Account{ID: 1, Status: StatusActive, Schedulable: true, Extra: map[string]any{accountAvailabilityExtraKey: schedule}}
+}
+
+func TestValidateAccountAvailabilitySchedule(t *testing.T) {
+	require.NoError(t, ValidateAccountAvailabilitySchedule(nil))
+	require.NoError(t, ValidateAccountAvailabilitySchedule(map[string]any{
+		accountAvailabilityExtraKey: map[string]any{
+			"timezone": "Asia/Shanghai",
+			"windows":  []any{map[string]any{"days": []any{1, 2}, "start": "22:00", "end": "08:00"}},
+			"cron":     []any{map[string]any{"expr": "0 1 * * 6", "duration_minutes": 120}},
+		},
+	}))
+
+	invalid := []map[string]any{
+		{"timezone": "Mars/Olympus"},
+		{"windows": []any{map[string]any{"start": "25:00", "end": "08:00"}}},
+		{"windows": []any{map[string]any{"start": "24:00", "end": "08:00"}}},
+		{"windows": []any{map[string]any{"days": []any{7}, "start": "01:00", "end": "08:00"}}},
+		{"cron": []any{map[string]any{"expr": "not a cron", "duration_minutes": 10}}},
+		{"cron": []any{map[string]any{"expr": "0 1 * * *", "duration_minutes": 0}}},
+		{"drain_minutes": -1},
+	}
+	for i, sched := range invalid {
+		require.Error(t, ValidateAccountAvailabilitySchedule(map[string]any{accountAvailabilityExtraKey: sched}), "case %d", i)
+	}
+}
+
+func TestAccountScheduleStateWeeklyWindow(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	// 工作日夜间 22:00-08:00（跨零点，归属开始日），窗口结束前 30 分钟排空
+	acc := accountWithSchedule(map[string]any{
+		"timezone":      "Asia/Shanghai",
+		"windows":       []any{map[string]any{"days": []any{1, 2, 3, 4, 5}, "start": "22:00", "end": "08:00"}},
+		"drain_minutes": 30,
+	})
+
+	at := func(day, hour, minute int) time.Time {
+		// 2026-01-05 为周一
+		return time.Date(2026, 1, day, hour, minute, 0, 0, loc)
+	}
+	require.Equal(t, AccountScheduleClosed, acc.ScheduleStateAt(at(5, 12, 0)))
+	require.Equal(t, AccountScheduleOpen, acc.ScheduleStateAt(at(5, 22, 0)))
+	require.Equal(t, AccountScheduleOpen, acc.ScheduleStateAt(at(6, 3, 0)))
+	require.Equal(t, AccountScheduleDraining, acc.ScheduleStateAt(at(6, 7, 40)))
+	require.Equal(t, AccountScheduleClosed, acc.ScheduleStateAt(at(6, 8, 0)))
+	// 周五晚的窗口延续到周六早上，周六晚不可用
+	require.Equal(t, AccountScheduleOpen, acc.ScheduleStateAt(at(10, 2, 0)))
+	require.Equal(t, AccountScheduleClosed, acc.ScheduleStateAt(at(10, 23, 0)))
+	// 周日晚不在窗口内，周一凌晨也不属于周日窗口
+	require.Equal(t, AccountScheduleClosed, acc.ScheduleStateAt(at(12, 2, 0)))
+	// 时区以配置为准：UTC 14:30 = 上海 22:30
+	require.Equal(t, AccountScheduleOpen, acc.ScheduleStateAt(time.Date(2026, 1, 5, 14, 30, 0, 0, time.UTC)))
+}
+
+func TestAccountScheduleStateAdjacentWindowsDoNotDrain(t *testing.T) {
+	acc := accountWithSchedule(map[string]any{
+		"timezone": "UTC",
+		"windows": []any{
+			map[string]any{"days": []any{1}, "start": "00:00", "end": "24:00"},
+			map[string]any{"days": []any{2}, "start": "00:00", "end": "12:00"},
+		},
+		"drain_minutes": 60,
+	})
+	require.Equal(t, AccountScheduleOpen, acc.ScheduleStateAt(time.Date(2026, 1, 5, 23, 30, 0, 0, time.UTC)))
+	require.Equal(t, AccountScheduleDraining, acc.ScheduleStateAt(time.Date(2026, 1, 6, 11, 30, 0, 0, time.UTC)))
+}
+
+func TestAccountScheduleStateCronWindow(t *testing.T) {
+	acc := accountWithSchedule(map[string]any{
+		"timezone":      "UTC",
+		"cron":          []any{map[string]any{"expr": "0 22 * * *", "duration_minutes": 600}},
+		"drain_minutes": 30,
+	})
+	require.Equal(t, AccountScheduleOpen, acc.ScheduleStateAt(time.Date(2026, 1, 5, 22, 0, 0, 0, time.UTC)))
+	require.Equal(t, AccountScheduleOpen, acc.ScheduleStateAt(time.Date(2026, 1, 6, 3, 0, 0, 0, time.UTC)))
+	require.Equal(t, AccountScheduleDraining, acc.ScheduleStateAt(time.Date(2026, 1, 6, 7, 45, 0, 0, time.UTC)))
+	require.Equal(t, AccountScheduleClosed, acc.ScheduleStateAt(time.Date(2026, 1, 6, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestAccountScheduleStateDrainBeforeSessionReset(t *testing.T) {
+	now := time.Now()
+	end := now.Add(10 * time.Minute)
+	acc := accountWithSchedule(map[string]any{"drain_before_session_reset_minutes": 15})
+	acc.SessionWindowEnd = &end
+	require.Equal(t, AccountScheduleDraining, acc.ScheduleStateAt(now))
+	require.True(t, acc.IsSchedulable())
+	require.False(t, acc.IsSchedulableForNewSession())
+
+	later := now.Add(2 * time.Hour)
+	acc.SessionWindowEnd = &later
+	require.Equal(t, AccountScheduleOpen, acc.ScheduleStateAt(now))
+	require.True(t, acc.IsSchedulableForNewSession())
+}
+
+func TestAccountScheduleHonouredBySchedulingChecks(t *testing.T) {
+	now := time.Now().UTC()
+	clock := func(t time.Time) string { return t.Format("15:04") }
+
+	// 当前时刻之后才开始的窗口：不可调度，粘性会话需要清理
+	closed := accountWithSchedule(map[string]any{
+		"timezone": "UTC",
+		"windows":  []any{map[string]any{"start": clock(now.Add(2 * time.Hour)), "end": clock(now.Add(3 * time.Hour))}},
+	})
+	require.False(t, closed.IsSchedulable())
+	require.True(t, shouldClearStickySession(closed))
+
+	// 10 分钟后结束、排空 30 分钟：仅粘性会话可用
+	draining := accountWithSchedule(map[string]any{
+		"timezone":      "UTC",
+		"windows":       []any{map[string]any{"start": clock(now.Add(-time.Hour)), "end": clock(now.Add(10 * time.Minute))}},
+		"drain_minutes": 30,
+	})
+	draining.ID = 2
+	require.True(t, draining.IsSchedulable())
+	require.True(t, draining.IsDraining())
+	require.False(t, draining.IsSchedulableForNewSession())
+	require.False(t, shouldClearStickySession(draining))
+
+	unscheduled := &Account{ID: 3, Status: StatusActive, Schedulable: true}
+	require.True(t, unscheduled.IsSchedulableForNewSession())
+
+	// 快照读取时剔除窗口外账号，排空中的账号保留给粘性会话
+	filtered := filterUnavailableAccounts([]Account{*closed, *draining, *unscheduled}, time.Now())
+	require.Len(t, filtered, 2)
+	require.Equal(t, int64(2), filtered[0].ID)
+	require.Equal(t, int64(3), filtered[1].ID)
+}
+
+func TestAccountCompileAvailabilityScheduleReusesParsedSchedule(t *testing.T) {
+	acc := accountWithSchedule(map[string]any{
+		"timezone": "UTC",
+		"windows":  []any{map[string]any{"days": []any{1}, "start": "09:00", "end": "18:00"}},
+	})
+	// 2026-01-04 为周日，不在窗口内
+	sunday := time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC)
+
+	acc.CompileAvailabilitySchedule()
+	first := acc.GetAvailabilitySchedule()
+	require.NotNil(t, first)
+	require.Same(t, first, acc.GetAvailabilitySchedule())
+	copied := *acc
+	require.Same(t, first, copied.GetAvailabilitySchedule())
+	require.Equal(t, AccountScheduleClosed, acc.ScheduleStateAt(sunday))
+
+	// 替换 Extra 后无需重新编译即按新配置生效
+	acc.Extra = map[string]any{accountAvailabilityExtraKey: map[string]any{
+		"timezone": "UTC",
+		"windows":  []any{map[string]any{"days": []any{0}, "start": "09:00", "end": "18:00"}},
+	}}
+	changed := acc.GetAvailabilitySchedule()
+	require.NotNil(t, changed)
+	require.NotSame(t, first, changed)
+	require.Equal(t, AccountScheduleOpen, acc.ScheduleStateAt(sunday))
+	acc.CompileAvailabilitySchedule()
+	require.Same(t, acc.GetAvailabilitySchedule(), acc.GetAvailabilitySchedule())
+
+	acc.Extra = nil
+	require.Nil(t, acc.GetAvailabilitySchedule())
+	require.Equal(t, AccountScheduleOpen, acc.ScheduleStateAt(sunday))
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+var (
+	ErrDedicatedAccountNotFound = infraerrors.BadRequest("DEDICATED_ACCOUNT_NOT_FOUND", "dedicated account not found")
+	ErrTooManyDedicatedAccounts = infraerrors.BadRequest("TOO_MANY_DEDICATED_ACCOUNTS", "too many dedicated accounts")
+)
+
+const (
+	maxDedicatedAccounts = 50
+	// 专属账号保留集合的本地缓存时间；管理员修改后本实例立即失效，其他实例最多延迟该时长
+	accountDedicationCacheTTL = 30 * time.Second
+)
+
+// AccountAffinity 请求的专属账号配置（API Key 级配置优先于用户级配置）
+type AccountAffinity struct {
+	AccountIDs []int64
+	// Exclusive 为 true 时仅使用专属账号，专属账号不可用时不回退到分组公共账号
+	Exclusive bool
+}
+
+// Contains 判断账号是否为本请求的专属账号
+func (a *AccountAffinity) Contains(accountID int64) bool {
+	if a == nil {
+		return false
+	}
+	for _, id := range a.AccountIDs {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveAccountAffinity 根据 API Key 与所属用户解析专属账号配置，未配置时返回 nil
+func ResolveAccountAffinity(apiKey *APIKey) *AccountAffinity {
+	if apiKey == nil {
+		return nil
+	}
+	if len(apiKey.DedicatedAccountIDs) > 0 {
+		return &AccountAffinity{AccountIDs: apiKey.DedicatedAccountIDs, Exclusive: apiKey.DedicatedAccountsOnly}
+	}
+	if apiKey.User != nil && len(apiKey.User.DedicatedAccountIDs) > 0 {
+		return &AccountAffinity{AccountIDs: apiKey.User.DedicatedAccountIDs, Exclusive: apiKey.User.DedicatedAccountsOnly}
+	}
+	return nil
+}
+
+// AccountAffinityFromContext 读取 API Key 认证中间件写入的专属账号配置
+func AccountAffinityFromContext(ctx context.Context) *AccountAffinity {
+	if ctx == nil {
+		return nil
+	}
+	affinity, _ := ctx.Value(ctxkey.AccountAffinity).(*AccountAffinity)
+	return affinity
+}
+
+// moveSchedulingCandidateToFront 将指定账号移到候选列表首位，其余顺序不变
+func moveSchedulingCandidateToFront(candidates []SchedulingCandidate, accountID int64) {
+	if accountID <= 0 {
+		return
+	}
+	for i := 1; i < len(candidates); i++ {
+		if candidates[i].Account.ID == accountID {
+			chosen := candidates[i]
+			copy(candidates[1:i+1], candidates[0:i])
+			candidates[0] = chosen
+			return
+		}
+	}
+}
+
+// AccountDedication 一条专属账号配置（用户级或 API Key 级）
+type AccountDedication struct {
+	UserID     int64
+	UserEmail  string
+	APIKeyID   *int64
+	APIKeyName string
+	AccountIDs []int64
+	Exclusive  bool
+}
+
+// AccountDedicationRepository 专属账号配置存储
+type AccountDedicationRepository interface {
+	// ListDedications 返回所有配置了专属账号的用户与 API Key（不含已删除记录）
+	ListDedications(ctx context.Context) ([]AccountDedication, error)
+	SetUserDedicatedAccounts(ctx context.Context, userID int64, accountIDs []int64, exclusive bool) error
+	SetAPIKeyDedicatedAccounts(ctx context.Context, apiKeyID int64, accountIDs []int64, exclusive bool) error
+}
+
+// DedicatedAccountUsage 专属账号及其当前利用率
+type DedicatedAccountUsage struct {
+	AccountID          int64   `json:"account_id"`
+	Name               string  `json:"name"`
+	Platform           string  `json:"platform"`
+	Status             string  `json:"status"`
+	Schedulable        bool    `json:"schedulable"`
+	Concurrency        int     `json:"concurrency"`
+	CurrentConcurrency int     `json:"current_concurrency"`
+	WaitingCount       int     `json:"waiting_count"`
+	LoadRate           int     `json:"load_rate"`
+	TodayRequests      int64   `json:"today_requests"`
+	TodayTokens        int64   `json:"today_tokens"`
+	TodayCost          float64 `json:"today_cost"`
+	Missing            bool    `json:"missing,omitempty"`
+}
+
+// AccountDedicationView 管理后台展示的专属账号归属
+type AccountDedicationView struct {
+	OwnerType  string                   `json:"owner_type"` // user | api_key
+	UserID     int64                    `json:"user_id"`
+	UserEmail  string                   `json:"user_email"`
+	APIKeyID   *int64                   `json:"api_key_id,omitempty"`
+	APIKeyName string                   `json:"api_key_name,omitempty"`
+	Exclusive  bool                     `json:"exclusive"`
+	Accounts   []*DedicatedAccountUsage `json:"accounts"`
+}
+
+const (
+	AccountDedicationOwnerUser   = "user"
+	AccountDedicationOwnerAPIKey = "api_key"
+)
+
+// AccountDedicationService 管理用户/API Key 专属账号，并为网关调度提供账号隔离判断
+type AccountDedicationService struct {
+	repo                 AccountDedicationRepository
+	accountRepo          AccountRepository
+	apiKeyRepo           APIKeyRepository
+	usageLogRepo         UsageLogRepository
+	concurrencyService   *ConcurrencyService
+	authCacheInvalidator APIKeyAuthCacheInvalidator
+
+	mu       sync.RWMutex
+	reserved map[int64]struct{}
+	loadedAt time.Time
+}
+
+// NewAccountDedicationService creates a new AccountDedicationService
+func NewAccountDedicationService(
+	repo AccountDedicationRepository,
+	accountRepo AccountRepository,
+	apiKeyRepo APIKeyRepository,
+	usageLogRepo UsageLogRepository,
+	concurrencyService *ConcurrencyService,
+	authCacheInvalidator APIKeyAuthCacheInvalidator,
+) *AccountDedicationService {
+	return &AccountDedicationService{
+		repo:                 repo,
+		accountRepo:          accountRepo,
+		apiKeyRepo:           apiKeyRepo,
+		usageLogRepo:         usageLogRepo,
+		concurrencyService:   concurrencyService,
+		authCacheInvalidator: authCacheInvalidator,
+	}
+}
+
+// IsAccountAllowed 判断账号能否被当前请求调度：
+//   - 本请求的专属账号始终允许
+//   - 独占模式下其他账号一律不允许
+//   - 专属于其他用户/API Key 的账号不允许
+func (s *AccountDedicationService) IsAccountAllowed(ctx context.Context, affinity *AccountAffinity, accountID int64) bool {
+	if affinity.Contains(accountID) {
+		return true
+	}
+	if affinity != nil && affinity.Exclusive {
+		return false
+	}
+	if s == nil {
+		return true
+	}
+	_, reserved := s.reservedAccounts(ctx)[accountID]
+	return !reserved
+}
+
+// FilterAccounts 按 IsAccountAllowed 过滤候选账号（不修改入参切片）
+func (s *AccountDedicationService) FilterAccounts(ctx context.Context, affinity *AccountAffinity, accounts []Account) []Account {
+	if affinity == nil && (s == nil || len(s.reservedAccounts(ctx)) == 0) {
+		return accounts
+	}
+	filtered := make([]Account, 0, len(accounts))
+	for i := range accounts {
+		if s.IsAccountAllowed(ctx, affinity, accounts[i].ID) {
+			filtered = append(filtered, accounts[i])
+		}
+	}
+	return filtered
+}
+
+func (s *AccountDedicationService) reservedAccounts(ctx context.Context) map[int64]struct{} {
+	if s.repo == nil {
+		return nil
+	}
+	s.mu.RLock()
+	reserved, loadedAt := s.reserved, s.loadedAt
+	s.mu.RUnlock()
+	if reserved != nil && time.Since(loadedAt) < accountDedicationCacheTTL {
+		return reserved
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reserved != nil && time.Since(s.loadedAt) < accountDedicationCacheTTL {
+		return s.reserved
+	}
+	dedications, err := s.repo.ListDedications(ctx)
+	if err != nil {
+		log.Printf("[AccountDedication] load dedications failed: %v", err)
+		if s.reserved == nil {
+			s.reserved = map[int64]struct{}{}
+		}
+		// 保留旧数据，TTL 到期后重试，避免数据库故障时每次调度都查询
+		s.loadedAt = time.Now()
+		return s.reserved
+	}
+	next := make(map[int64]struct{})
+	for _, d := range dedications {
+		for _, id := range d.AccountIDs {
+			next[id] = struct{}{}
+		}
+	}
+	s.reserved = next
+	s.loadedAt = time.Now()
+	return next
+}
+
+func (s *AccountDedicationService) invalidateReserved() {
+	s.mu.Lock()
+	s.reserved = nil
+	s.mu.Unlock()
+}
+
+// SetUserDedicatedAccounts 设置用户级专属账号（空列表表示清除）
+func (s *AccountDedicationService) SetUserDedicatedAccounts(ctx context.Context, userID int64, accountIDs []int64, exclusive bool) error {
+	ids, err := s.normalizeAccountIDs(ctx, accountIDs)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetUserDedicatedAccounts(ctx, userID, ids, exclusive && len(ids) > 0); err != nil {
+		return err
+	}
+	s.invalidateReserved()
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, userID)
+	}
+	return nil
+}
+
+// SetAPIKeyDedicatedAccounts 设置 API Key 级专属账号（空列表表示清除，回退到用户级配置）
+func (s *AccountDedicationService) SetAPIKeyDedicatedAccounts(ctx context.Context, apiKeyID int64, accountIDs []int64, exclusive bool) error {
+	cred, _, err := s.apiKeyRepo.GetCredentialAndOwnerID(ctx, apiKeyID)
+	if err != nil {
+		return err
+	}
+	ids, err := s.normalizeAccountIDs(ctx, accountIDs)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetAPIKeyDedicatedAccounts(ctx, apiKeyID, ids, exclusive && len(ids) > 0); err != nil {
+		return err
+	}
+	s.invalidateReserved()
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByCredential(ctx, cred)
+	}
+	return nil
+}
+
+// normalizeAccountIDs 去重、排序并校验账号存在
+func (s *AccountDedicationService) normalizeAccountIDs(ctx context.Context, accountIDs []int64) ([]int64, error) {
+	seen := make(map[int64]struct{}, len(accountIDs))
+	ids := make([]int64, 0, len(accountIDs))
+	for _, id := range accountIDs {
+		if id <= 0 {
+			return nil, ErrDedicatedAccountNotFound
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) > maxDedicatedAccounts {
+		return nil, ErrTooManyDedicatedAccounts
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	accounts, err := s.accountRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) != len(ids) {
+		return nil, ErrDedicatedAccountNotFound
+	}
+	return ids, nil
+}
+
+// ListDedications 列出所有专属账号归属及账号利用率（并发负载 + 今日用量）
+func (s *AccountDedicationService) ListDedications(ctx context.Context) ([]*AccountDedicationView, error) {
+	dedications, err := s.repo.ListDedications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idSet := make(map[int64]struct{})
+	for _, d := range dedications {
+		for _, id := range d.AccountIDs {
+			idSet[id] = struct{}{}
+		}
+	}
+	ids := make([]int64, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	usageByID := make(map[int64]*DedicatedAccountUsage, len(ids))
+	if len(ids) > 0 {
+		accounts, err := s.accountRepo.GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		var loadMap map[int64]*AccountLoadInfo
+		if s.concurrencyService != nil {
+			batch := make([]AccountWithConcurrency, 0, len(accounts))
+			for _, acc := range accounts {
+				batch = append(batch, AccountWithConcurrency{ID: acc.ID, MaxConcurrency: acc.Concurrency})
+			}
+			if m, err := s.concurrencyService.GetAccountsLoadBatch(ctx, batch); err == nil {
+				loadMap = m
+			}
+		}
+
+		for _, acc := range accounts {
+			usage := &DedicatedAccountUsage{
+				AccountID:   acc.ID,
+				Name:        acc.Name,
+				Platform:    acc.Platform,
+				Status:      acc.Status,
+				Schedulable: acc.IsSchedulable(),
+				Concurrency: acc.Concurrency,
+			}
+			if load := loadMap[acc.ID]; load != nil {
+				usage.CurrentConcurrency = load.CurrentConcurrency
+				usage.WaitingCount = load.WaitingCount
+				usage.LoadRate = load.LoadRate
+			}
+			if s.usageLogRepo != nil {
+				if stats, err := s.usageLogRepo.GetAccountTodayStats(ctx, acc.ID); err == nil && stats != nil {
+					usage.TodayRequests = stats.Requests
+					usage.TodayTokens = stats.Tokens
+					usage.TodayCost = stats.Cost
+				}
+			}
+			usageByID[acc.ID] = usage
+		}
+	}
+
+	out := make([]*AccountDedicationView, 0, len(dedications))
+	for _, d := range dedications {
+		view := &AccountDedicationView{
+			OwnerType:  AccountDedicationOwnerUser,
+			UserID:     d.UserID,
+			UserEmail:  d.UserEmail,
+			APIKeyID:   d.APIKeyID,
+			APIKeyName: d.APIKeyName,
+			Exclusive:  d.Exclusive,
+			Accounts:   make([]*DedicatedAccountUsage, 0, len(d.AccountIDs)),
+		}
+		if d.APIKeyID != nil {
+			view.OwnerType = AccountDedicationOwnerAPIKey
+		}
+		for _, id := range d.AccountIDs {
+			usage, ok := usageByID[id]
+			if !ok {
+				usage = &DedicatedAccountUsage{AccountID: id, Missing: true}
+			}
+			view.Accounts = append(view.Accounts, usage)
+		}
+		out = append(out, view)
+	}
+	return out, nil
+}
@@ -0,0 +1,136 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/stretchr/testify/require"
+)
+
+type accountDedicationRepoStub struct {
+	dedications []AccountDedication
+	err         error
+	listCalls   int
+}
+
+func (s *accountDedicationRepoStub) ListDedications(ctx context.Context) ([]AccountDedication, error) {
+	s.listCalls++
+	return s.dedications, s.err
+}
+
+func (s *accountDedicationRepoStub) SetUserDedicatedAccounts(ctx context.Context, userID int64, accountIDs []int64, exclusive bool) error {
+	return nil
+}
+
+func (s *accountDedicationRepoStub) SetAPIKeyDedicatedAccounts(ctx context.Context, apiKeyID int64, accountIDs []int64, exclusive bool) error {
+	return nil
+}
+
+func TestResolveAccountAffinity(t *testing.T) {
+	require.Nil(t, ResolveAccountAffinity(nil))
+	require.Nil(t, ResolveAccountAffinity(&APIKey{User: &User{}}))
+
+	userOnly := &APIKey{User: &User{DedicatedAccountIDs: []int64{1, 2}, DedicatedAccountsOnly: true}}
+	affinity := ResolveAccountAffinity(userOnly)
+	require.NotNil(t, affinity)
+	require.Equal(t, []int64{1, 2}, affinity.AccountIDs)
+	require.True(t, affinity.Exclusive)
+
+	// API Key 级配置覆盖用户级配置
+	keyLevel := &APIKey{
+		DedicatedAccountIDs: []int64{9},
+		User:                &User{DedicatedAccountIDs: []int64{1, 2}, DedicatedAccountsOnly: true},
+	}
+	affinity = ResolveAccountAffinity(keyLevel)
+	require.Equal(t, []int64{9}, affinity.AccountIDs)
+	require.False(t, affinity.Exclusive)
+}
+
+func TestAccountAffinityFromContext(t *testing.T) {
+	require.Nil(t, AccountAffinityFromContext(context.Background()))
+
+	affinity := &AccountAffinity{AccountIDs: []int64{3}}
+	ctx := context.WithValue(context.Background(), ctxkey.AccountAffinity, affinity)
+	require.Same(t, affinity, AccountAffinityFromContext(ctx))
+}
+
+func TestAccountDedicationService_IsAccountAllowed(t *testing.T) {
+	repo := &accountDedicationRepoStub{dedications: []AccountDedication{
+		{UserID: 1, AccountIDs: []int64{10, 11}},
+		{UserID: 2, AccountIDs: []int64{20}, Exclusive: true},
+	}}
+	svc := &AccountDedicationService{repo: repo}
+	ctx := context.Background()
+
+	// 无专属配置：其他用户的专属账号不可用，公共账号可用
+	require.False(t, svc.IsAccountAllowed(ctx, nil, 10))
+	require.False(t, svc.IsAccountAllowed(ctx, nil, 20))
+	require.True(t, svc.IsAccountAllowed(ctx, nil, 30))
+
+	owner := &AccountAffinity{AccountIDs: []int64{10, 11}}
+	require.True(t, svc.IsAccountAllowed(ctx, owner, 10))
+	require.False(t, svc.IsAccountAllowed(ctx, owner, 20))
+	require.True(t, svc.IsAccountAllowed(ctx, owner, 30))
+
+	exclusive := &AccountAffinity{AccountIDs: []int64{20}, Exclusive: true}
+	require.True(t, svc.IsAccountAllowed(ctx, exclusive, 20))
+	require.False(t, svc.IsAccountAllowed(ctx, exclusive, 30))
+
+	// 保留集合被缓存
+	require.Equal(t, 1, repo.listCalls)
+
+	// nil 服务仍遵守独占模式
+	var nilSvc *AccountDedicationService
+	require.True(t, nilSvc.IsAccountAllowed(ctx, nil, 10))
+	require.False(t, nilSvc.IsAccountAllowed(ctx, exclusive, 30))
+}
+
+func TestAccountDedicationService_FilterAccounts(t *testing.T) {
+	svc := &AccountDedicationService{repo: &accountDedicationRepoStub{dedications: []AccountDedication{
+		{UserID: 1, AccountIDs: []int64{2}},
+	}}}
+	ctx := context.Background()
+	accounts := []Account{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	ids := func(list []Account) []int64 {
+		out := make([]int64, 0, len(list))
+		for _, a := range list {
+			out = append(out, a.ID)
+		}
+		return out
+	}
+
+	require.Equal(t, []int64{1, 3}, ids(svc.FilterAccounts(ctx, nil, accounts)))
+	require.Equal(t, []int64{1, 2, 3}, ids(svc.FilterAccounts(ctx, &AccountAffinity{AccountIDs: []int64{2}}, accounts)))
+	require.Equal(t, []int64{2}, ids(svc.FilterAccounts(ctx, &AccountAffinity{AccountIDs: []int64{2}, Exclusive: true}, accounts)))
+	// 入参切片不被修改
+	require.Equal(t, []int64{1, 2, 3}, ids(accounts))
+}
+
+func TestAccountDedicationService_LoadErrorKeepsPreviousSet(t *testing.T) {
+	repo := &accountDedicationRepoStub{err: errors.New("db down")}
+	svc := &AccountDedicationService{repo: repo}
+	ctx := context.Background()
+
+	require.True(t, svc.IsAccountAllowed(ctx, nil, 1))
+	require.True(t, svc.IsAccountAllowed(ctx, nil, 1))
+	// 加载失败后在 TTL 内不重复查询
+	require.Equal(t, 1, repo.listCalls)
+}
+
+func TestMoveSchedulingCandidateToFront(t *testing.T) {
+	candidates := []SchedulingCandidate{
+		schedulingTestCandidate(1, 1, 0, 0),
+		schedulingTestCandidate(2, 1, 0, 0),
+		schedulingTestCandidate(3, 1, 0, 0),
+	}
+	moveSchedulingCandidateToFront(candidates, 3)
+	require.Equal(t, []int64{3, 1, 2}, schedulingCandidateIDs(candidates))
+
+	moveSchedulingCandidateToFront(candidates, 99)
+	require.Equal(t, []int64{3, 1, 2}, schedulingCandidateIDs(candidates))
+}
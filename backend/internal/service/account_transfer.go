@@ -0,0 +1,823 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+// 账号文件导入/导出格式
+const (
+	AccountTransferFormatNative    = "sub2api"    // sub2api 原生 JSON（导出/导入）
+	AccountTransferFormatCRS       = "crs"        // claude-relay-service 导出数据（导出/导入）
+	AccountTransferFormatCodex     = "codex"      // Codex CLI auth.json（仅导入）
+	AccountTransferFormatGeminiCLI = "gemini_cli" // Gemini CLI oauth_creds.json（仅导入）
+	AccountTransferFormatAPIKeys   = "api_keys"   // 每行一个 API Key（仅导入）
+
+	accountTransferDocumentFormat  = "sub2api-accounts"
+	accountTransferDocumentVersion = 1
+
+	// MaxAccountImportEntries 单次导入的账号上限
+	MaxAccountImportEntries = 1000
+
+	accountExportPageSize = 500
+)
+
+// 导入结果动作
+const (
+	AccountImportActionCreated     = "created"
+	AccountImportActionWouldCreate = "would_create"
+	AccountImportActionDuplicate   = "duplicate"
+	AccountImportActionFailed      = "failed"
+)
+
+var (
+	ErrAccountImportUnknownFormat     = infraerrors.BadRequest("ACCOUNT_IMPORT_UNKNOWN_FORMAT", "unable to detect account file format")
+	ErrAccountImportEmpty             = infraerrors.BadRequest("ACCOUNT_IMPORT_EMPTY", "no accounts found in file")
+	ErrAccountImportTooMany           = infraerrors.BadRequest("ACCOUNT_IMPORT_TOO_MANY", fmt.Sprintf("at most %d accounts can be imported at once", MaxAccountImportEntries))
+	ErrAccountImportPlatformRequired  = infraerrors.BadRequest("ACCOUNT_IMPORT_PLATFORM_REQUIRED", "platform is required when importing an api key list")
+	ErrAccountExportUnsupportedFormat = infraerrors.BadRequest("ACCOUNT_EXPORT_UNSUPPORTED_FORMAT", "accounts can only be exported as sub2api or crs")
+)
+
+// AccountTransferProxy 文件中的代理定义（字段与 CRS 导出一致）
+type AccountTransferProxy struct {
+	Protocol string `json:"protocol"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// AccountTransferEntry 原生格式中的单个账号，也是各导入格式解析后的统一表示
+type AccountTransferEntry struct {
+	Name           string                `json:"name"`
+	Notes          *string               `json:"notes,omitempty"`
+	Platform       string                `json:"platform"`
+	Type           string                `json:"type"`
+	Credentials    map[string]any        `json:"credentials"`
+	Extra          map[string]any        `json:"extra,omitempty"`
+	Concurrency    int                   `json:"concurrency,omitempty"`
+	Priority       int                   `json:"priority,omitempty"`
+	RateMultiplier *float64              `json:"rate_multiplier,omitempty"`
+	Schedulable    *bool                 `json:"schedulable,omitempty"`
+	Proxy          *AccountTransferProxy `json:"proxy,omitempty"`
+}
+
+// AccountTransferDocument 原生导出文件
+type AccountTransferDocument struct {
+	Format     string                 `json:"format"`
+	Version    int                    `json:"version"`
+	ExportedAt time.Time              `json:"exported_at"`
+	Accounts   []AccountTransferEntry `json:"accounts"`
+}
+
+// AccountImportInput 导入参数
+type AccountImportInput struct {
+	// Format 为空时按内容自动识别
+	Format  string
+	Content []byte
+	// DryRun 只校验与查重，不写入
+	DryRun   bool
+	GroupIDs []int64
+	// ProxyID 指定时所有账号使用该代理，忽略文件中的代理定义
+	ProxyID *int64
+	// SyncProxies 按文件中的代理定义复用或创建代理
+	SyncProxies bool
+	// Platform/BaseURL 仅用于 api_keys 格式
+	Platform              string
+	BaseURL               string
+	SkipMixedChannelCheck bool
+}
+
+// AccountImportItem 单个账号的导入结果
+type AccountImportItem struct {
+	Index       int    `json:"index"`
+	Name        string `json:"name"`
+	Platform    string `json:"platform"`
+	Type        string `json:"type"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Action      string `json:"action"`
+	AccountID   int64  `json:"account_id,omitempty"`
+	// DuplicateOf 重复的已有账号 ID；文件内重复时为 0，见 Error
+	DuplicateOf int64  `json:"duplicate_of,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// AccountImportResult 导入结果；DryRun 时 Created 为预计创建数
+type AccountImportResult struct {
+	Format     string              `json:"format"`
+	DryRun     bool                `json:"dry_run"`
+	Created    int                 `json:"created"`
+	Duplicates int                 `json:"duplicates"`
+	Failed     int                 `json:"failed"`
+	Items      []AccountImportItem `json:"items"`
+}
+
+// AccountExportInput 导出参数；AccountIDs 为空时按平台（或全部）导出
+type AccountExportInput struct {
+	Format     string
+	AccountIDs []int64
+	Platform   string
+}
+
+// AccountTransferService 账号文件导入/导出
+type AccountTransferService struct {
+	adminService AdminService
+	accountRepo  AccountRepository
+	proxyRepo    ProxyRepository
+}
+
+// NewAccountTransferService 创建账号导入/导出服务
+func NewAccountTransferService(adminService AdminService, accountRepo AccountRepository, proxyRepo ProxyRepository) *AccountTransferService {
+	return &AccountTransferService{
+		adminService: adminService,
+		accountRepo:  accountRepo,
+		proxyRepo:    proxyRepo,
+	}
+}
+
+// Import 解析并导入账号。凭证指纹与已有账号或文件内其它条目重复的账号会被跳过。
+func (s *AccountTransferService) Import(ctx context.Context, input AccountImportInput) (*AccountImportResult, error) {
+	format := strings.TrimSpace(input.Format)
+	if format == "" {
+		format = DetectAccountImportFormat(input.Content)
+	}
+	entries, err := ParseAccountImport(format, input.Content, input.Platform, input.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrAccountImportEmpty
+	}
+	if len(entries) > MaxAccountImportEntries {
+		return nil, ErrAccountImportTooMany
+	}
+
+	platforms := make(map[string]struct{})
+	for _, e := range entries {
+		platforms[strings.ToLower(strings.TrimSpace(e.Platform))] = struct{}{}
+	}
+	existing, err := s.existingFingerprints(ctx, platforms)
+	if err != nil {
+		return nil, err
+	}
+	var proxies []Proxy
+	if input.SyncProxies && input.ProxyID == nil && !input.DryRun {
+		proxies, _ = s.proxyRepo.ListActive(ctx)
+	}
+
+	result := &AccountImportResult{Format: format, DryRun: input.DryRun, Items: make([]AccountImportItem, 0, len(entries))}
+	seen := make(map[string]int)
+	for i := range entries {
+		entry := &entries[i]
+		item := s.importEntry(ctx, i, entry, input, existing, seen, &proxies)
+		switch item.Action {
+		case AccountImportActionCreated, AccountImportActionWouldCreate:
+			result.Created++
+		case AccountImportActionDuplicate:
+			result.Duplicates++
+		default:
+			result.Failed++
+		}
+		result.Items = append(result.Items, item)
+	}
+	return result, nil
+}
+
+func (s *AccountTransferService) importEntry(ctx context.Context, index int, entry *AccountTransferEntry, input AccountImportInput, existing map[string]int64, seen map[string]int, proxies *[]Proxy) AccountImportItem {
+	normalizeAccountTransferEntry(entry)
+	item := AccountImportItem{Index: index, Name: entry.Name, Platform: entry.Platform, Type: entry.Type}
+	fail := func(msg string) AccountImportItem {
+		item.Action = AccountImportActionFailed
+		item.Error = msg
+		return item
+	}
+	if err := validateAccountTransferEntry(entry); err != nil {
+		return fail(err.Error())
+	}
+
+	fingerprints := AccountCredentialFingerprints(entry.Platform, entry.Credentials)
+	if fingerprints[0] == "" {
+		return fail("no identifiable credential")
+	}
+	item.Fingerprint = fingerprints[0][:12]
+	if entry.Name == "" {
+		entry.Name = fmt.Sprintf("%s-%s-%s", entry.Platform, entry.Type, item.Fingerprint[:8])
+		item.Name = entry.Name
+	}
+	for _, fp := range fingerprints {
+		if id, ok := existing[fp]; ok {
+			item.Action = AccountImportActionDuplicate
+			item.DuplicateOf = id
+			return item
+		}
+		if prev, ok := seen[fp]; ok {
+			item.Action = AccountImportActionDuplicate
+			item.Error = fmt.Sprintf("duplicate of entry #%d in file", prev)
+			return item
+		}
+	}
+	for _, fp := range fingerprints {
+		seen[fp] = index
+	}
+
+	if input.DryRun {
+		item.Action = AccountImportActionWouldCreate
+		return item
+	}
+
+	proxyID := input.ProxyID
+	if proxyID == nil && input.SyncProxies && entry.Proxy != nil {
+		id, err := matchOrCreateProxy(ctx, s.proxyRepo, proxies, (*crsProxy)(entry.Proxy), "import-"+entry.Name)
+		if err != nil {
+			return fail("proxy sync failed: " + err.Error())
+		}
+		proxyID = id
+	}
+
+	account, err := s.adminService.CreateAccount(ctx, &CreateAccountInput{
+		Name:                  entry.Name,
+		Notes:                 entry.Notes,
+		Platform:              entry.Platform,
+		Type:                  entry.Type,
+		Credentials:           entry.Credentials,
+		Extra:                 entry.Extra,
+		ProxyID:               proxyID,
+		Concurrency:           entry.Concurrency,
+		Priority:              entry.Priority,
+		RateMultiplier:        entry.RateMultiplier,
+		GroupIDs:              input.GroupIDs,
+		SkipMixedChannelCheck: input.SkipMixedChannelCheck,
+	})
+	if err != nil {
+		return fail("create failed: " + err.Error())
+	}
+	if entry.Schedulable != nil && !*entry.Schedulable {
+		_ = s.accountRepo.SetSchedulable(ctx, account.ID, false)
+	}
+	for _, fp := range fingerprints {
+		existing[fp] = account.ID
+	}
+	item.Action = AccountImportActionCreated
+	item.AccountID = account.ID
+	return item
+}
+
+func (s *AccountTransferService) existingFingerprints(ctx context.Context, platforms map[string]struct{}) (map[string]int64, error) {
+	out := make(map[string]int64)
+	for platform := range platforms {
+		accounts, err := s.listAccounts(ctx, platform)
+		if err != nil {
+			return nil, fmt.Errorf("list %s accounts: %w", platform, err)
+		}
+		for i := range accounts {
+			for _, fp := range AccountCredentialFingerprints(accounts[i].Platform, accounts[i].Credentials) {
+				if fp != "" {
+					out[fp] = accounts[i].ID
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// listAccounts 分页读取平台下全部账号（含停用/异常账号）
+func (s *AccountTransferService) listAccounts(ctx context.Context, platform string) ([]Account, error) {
+	var all []Account
+	for page := 1; ; page++ {
+		accounts, result, err := s.accountRepo.ListWithFilters(ctx, pagination.PaginationParams{Page: page, PageSize: accountExportPageSize}, platform, "", "", "")
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, accounts...)
+		if len(accounts) < accountExportPageSize || result == nil || int64(len(all)) >= result.Total {
+			return all, nil
+		}
+	}
+}
+
+// Export 导出账号（含明文凭证），返回 JSON 文件内容与导出数量。crs 格式不支持的平台会被跳过。
+func (s *AccountTransferService) Export(ctx context.Context, input AccountExportInput) ([]byte, int, error) {
+	format := strings.TrimSpace(input.Format)
+	if format == "" {
+		format = AccountTransferFormatNative
+	}
+	if format != AccountTransferFormatNative && format != AccountTransferFormatCRS {
+		return nil, 0, ErrAccountExportUnsupportedFormat
+	}
+
+	var accounts []Account
+	switch {
+	case len(input.AccountIDs) > 0:
+		found, err := s.accountRepo.GetByIDs(ctx, input.AccountIDs)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, a := range found {
+			if a != nil {
+				accounts = append(accounts, *a)
+			}
+		}
+	default:
+		list, err := s.listAccounts(ctx, strings.TrimSpace(input.Platform))
+		if err != nil {
+			return nil, 0, err
+		}
+		accounts = list
+	}
+
+	proxies := make(map[int64]*AccountTransferProxy)
+	entries := make([]AccountTransferEntry, 0, len(accounts))
+	for i := range accounts {
+		entry := accountToTransferEntry(&accounts[i])
+		if entry.Proxy == nil && accounts[i].ProxyID != nil {
+			entry.Proxy = s.exportProxy(ctx, *accounts[i].ProxyID, proxies)
+		}
+		entries = append(entries, entry)
+	}
+
+	if format == AccountTransferFormatCRS {
+		data, n := buildCRSExport(accounts, entries)
+		payload, err := json.MarshalIndent(data, "", "  ")
+		return payload, n, err
+	}
+	payload, err := json.MarshalIndent(AccountTransferDocument{
+		Format:     accountTransferDocumentFormat,
+		Version:    accountTransferDocumentVersion,
+		ExportedAt: time.Now().UTC(),
+		Accounts:   entries,
+	}, "", "  ")
+	return payload, len(entries), err
+}
+
+func (s *AccountTransferService) exportProxy(ctx context.Context, id int64, cache map[int64]*AccountTransferProxy) *AccountTransferProxy {
+	if p, ok := cache[id]; ok {
+		return p
+	}
+	var out *AccountTransferProxy
+	if proxy, err := s.proxyRepo.GetByID(ctx, id); err == nil && proxy != nil {
+		out = proxyToTransfer(proxy)
+	}
+	cache[id] = out
+	return out
+}
+
+func proxyToTransfer(p *Proxy) *AccountTransferProxy {
+	return &AccountTransferProxy{Protocol: p.Protocol, Host: p.Host, Port: p.Port, Username: p.Username, Password: p.Password}
+}
+
+func accountToTransferEntry(a *Account) AccountTransferEntry {
+	schedulable := a.Schedulable && a.Status == StatusActive
+	entry := AccountTransferEntry{
+		Name:           a.Name,
+		Notes:          a.Notes,
+		Platform:       a.Platform,
+		Type:           a.Type,
+		Credentials:    a.Credentials,
+		Extra:          a.Extra,
+		Concurrency:    a.Concurrency,
+		Priority:       a.Priority,
+		RateMultiplier: a.RateMultiplier,
+		Schedulable:    &schedulable,
+	}
+	if a.Proxy != nil {
+		entry.Proxy = proxyToTransfer(a.Proxy)
+	}
+	return entry
+}
+
+// buildCRSExport 按 CRS 导出结构分类账号；antigravity 等 CRS 不支持的账号跳过
+func buildCRSExport(accounts []Account, entries []AccountTransferEntry) (crsExportData, int) {
+	data := crsExportData{ExportedAt: time.Now().UTC().Format(time.RFC3339)}
+	n := 0
+	for i := range accounts {
+		a, e := &accounts[i], entries[i]
+		id := strconv.FormatInt(a.ID, 10)
+		active := a.Status == StatusActive
+		credentials := mergeMap(nil, e.Credentials)
+		if ts, ok := credentials["expires_at"].(float64); ok {
+			credentials["expires_at"] = time.Unix(int64(ts), 0).UTC().Format(time.RFC3339)
+		}
+		proxy := (*crsProxy)(e.Proxy)
+		switch {
+		case a.Platform == PlatformAnthropic && a.Type == AccountTypeAPIKey:
+			data.ClaudeConsoleAccounts = append(data.ClaudeConsoleAccounts, crsConsoleAccount{
+				Kind: "claude-console", ID: id, Name: a.Name, Platform: "claude-console", IsActive: active,
+				Schedulable: a.Schedulable, Priority: a.Priority, Status: a.Status, MaxConcurrentTasks: a.Concurrency,
+				Proxy: proxy, Credentials: credentials,
+			})
+		case a.Platform == PlatformAnthropic:
+			data.ClaudeAccounts = append(data.ClaudeAccounts, crsClaudeAccount{
+				Kind: "claude", ID: id, Name: a.Name, Platform: "claude", AuthType: a.Type, IsActive: active,
+				Schedulable: a.Schedulable, Priority: a.Priority, Status: a.Status, Proxy: proxy,
+				Credentials: credentials, Extra: a.Extra,
+			})
+		case a.Platform == PlatformOpenAI && a.Type == AccountTypeAPIKey:
+			data.OpenAIResponsesAccounts = append(data.OpenAIResponsesAccounts, crsOpenAIResponsesAccount{
+				Kind: "openai-responses", ID: id, Name: a.Name, Platform: "openai-responses", IsActive: active,
+				Schedulable: a.Schedulable, Priority: a.Priority, Status: a.Status, Proxy: proxy, Credentials: credentials,
+			})
+		case a.Platform == PlatformOpenAI:
+			data.OpenAIOAuthAccounts = append(data.OpenAIOAuthAccounts, crsOpenAIOAuthAccount{
+				Kind: "openai", ID: id, Name: a.Name, Platform: "openai", AuthType: AccountTypeOAuth, IsActive: active,
+				Schedulable: a.Schedulable, Priority: a.Priority, Status: a.Status, Proxy: proxy,
+				Credentials: credentials, Extra: a.Extra,
+			})
+		case a.Platform == PlatformGemini && a.Type == AccountTypeAPIKey:
+			data.GeminiAPIKeyAccounts = append(data.GeminiAPIKeyAccounts, crsGeminiAPIKeyAccount{
+				Kind: "gemini-api", ID: id, Name: a.Name, Platform: "gemini-api", IsActive: active,
+				Schedulable: a.Schedulable, Priority: a.Priority, Status: a.Status, Proxy: proxy,
+				Credentials: credentials, Extra: a.Extra,
+			})
+		case a.Platform == PlatformGemini:
+			data.GeminiOAuthAccounts = append(data.GeminiOAuthAccounts, crsGeminiOAuthAccount{
+				Kind: "gemini", ID: id, Name: a.Name, Platform: "gemini", AuthType: AccountTypeOAuth, IsActive: active,
+				Schedulable: a.Schedulable, Priority: a.Priority, Status: a.Status, Proxy: proxy,
+				Credentials: credentials, Extra: a.Extra,
+			})
+		default:
+			continue
+		}
+		n++
+	}
+	return data, n
+}
+
+// ============================================
+// 凭证指纹
+// ============================================
+
+// accountFingerprintFields 用于识别同一上游账号的凭证字段。
+// OAuth 令牌会轮换，任一字段相同即视为重复。
+var accountFingerprintFields = []string{"refresh_token", "api_key", "session_key", "access_token"}
+
+// AccountCredentialFingerprints 计算账号凭证指纹（sha256，按平台隔离），无可识别字段时返回 [""]
+func AccountCredentialFingerprints(platform string, credentials map[string]any) []string {
+	var out []string
+	for _, field := range accountFingerprintFields {
+		value, _ := credentials[field].(string)
+		value = strings.TrimSpace(value)
+		if value == "" || IsEncryptedSecret(value) {
+			continue
+		}
+		sum := sha256.Sum256([]byte(platform + "\x00" + field + "\x00" + value))
+		out = append(out, hex.EncodeToString(sum[:]))
+	}
+	if len(out) == 0 {
+		return []string{""}
+	}
+	return out
+}
+
+// ============================================
+// 解析
+// ============================================
+
+// DetectAccountImportFormat 按内容识别导入格式，无法识别时返回空字符串
+func DetectAccountImportFormat(content []byte) string {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 {
+		return ""
+	}
+	switch trimmed[0] {
+	case '[':
+		return AccountTransferFormatNative
+	case '{':
+	default:
+		return AccountTransferFormatAPIKeys
+	}
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return ""
+	}
+	has := func(keys ...string) bool {
+		for _, k := range keys {
+			if _, ok := probe[k]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("accounts"):
+		return AccountTransferFormatNative
+	case has("data", "claudeAccounts", "claudeConsoleAccounts", "openaiOAuthAccounts", "openaiResponsesAccounts", "geminiOAuthAccounts", "geminiApiKeyAccounts"):
+		return AccountTransferFormatCRS
+	case has("tokens", "OPENAI_API_KEY"):
+		return AccountTransferFormatCodex
+	case has("refresh_token") && has("expiry_date", "scope"):
+		return AccountTransferFormatGeminiCLI
+	}
+	return ""
+}
+
+// ParseAccountImport 将导入文件解析为统一的账号条目（不做校验）
+func ParseAccountImport(format string, content []byte, platform, baseURL string) ([]AccountTransferEntry, error) {
+	switch format {
+	case AccountTransferFormatNative:
+		return parseNativeAccounts(content)
+	case AccountTransferFormatCRS:
+		return parseCRSAccounts(content)
+	case AccountTransferFormatCodex:
+		return parseCodexAuth(content)
+	case AccountTransferFormatGeminiCLI:
+		return parseGeminiCLICreds(content)
+	case AccountTransferFormatAPIKeys:
+		return parseAPIKeyList(content, platform, baseURL)
+	default:
+		return nil, ErrAccountImportUnknownFormat
+	}
+}
+
+func invalidAccountFile(format string, err error) error {
+	return infraerrors.BadRequest("ACCOUNT_IMPORT_INVALID_FILE", fmt.Sprintf("invalid %s file: %v", format, err))
+}
+
+func parseNativeAccounts(content []byte) ([]AccountTransferEntry, error) {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var entries []AccountTransferEntry
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, invalidAccountFile(AccountTransferFormatNative, err)
+		}
+		return entries, nil
+	}
+	var doc AccountTransferDocument
+	if err := json.Unmarshal(trimmed, &doc); err != nil {
+		return nil, invalidAccountFile(AccountTransferFormatNative, err)
+	}
+	if doc.Version > accountTransferDocumentVersion {
+		return nil, invalidAccountFile(AccountTransferFormatNative, fmt.Errorf("unsupported version %d", doc.Version))
+	}
+	return doc.Accounts, nil
+}
+
+// parseCRSAccounts 兼容完整导出响应（{"data":{...}}）与仅包含 data 的文件，字段映射与 CRS 在线同步一致
+func parseCRSAccounts(content []byte) ([]AccountTransferEntry, error) {
+	var wrapped crsExportResponse
+	if err := json.Unmarshal(content, &wrapped); err != nil {
+		return nil, invalidAccountFile(AccountTransferFormatCRS, err)
+	}
+	data := wrapped.Data
+	if crsExportEmpty(data) {
+		if err := json.Unmarshal(content, &data); err != nil {
+			return nil, invalidAccountFile(AccountTransferFormatCRS, err)
+		}
+	}
+
+	var entries []AccountTransferEntry
+	add := func(kind, id, name, platform, accountType string, isActive, schedulable bool, priority, concurrency int, proxy *crsProxy, credentials, extra map[string]any) {
+		merged := make(map[string]any, len(extra)+2)
+		for k, v := range extra {
+			merged[k] = v
+		}
+		merged["crs_account_id"] = id
+		merged["crs_kind"] = kind
+		active := schedulable && isActive
+		if concurrency <= 0 {
+			concurrency = 3
+		}
+		entries = append(entries, AccountTransferEntry{
+			Name:        defaultName(name, id),
+			Platform:    platform,
+			Type:        accountType,
+			Credentials: credentials,
+			Extra:       merged,
+			Concurrency: concurrency,
+			Priority:    clampPriority(priority),
+			Schedulable: &active,
+			Proxy:       (*AccountTransferProxy)(proxy),
+		})
+	}
+
+	for _, src := range data.ClaudeAccounts {
+		accountType := strings.TrimSpace(src.AuthType)
+		if accountType == "" {
+			accountType = AccountTypeOAuth
+		}
+		credentials := sanitizeCredentialsMap(src.Credentials)
+		cleanBaseURL(credentials, "/v1")
+		crsExpiresAtToUnix(credentials)
+		if _, exists := credentials["intercept_warmup_requests"]; !exists {
+			credentials["intercept_warmup_requests"] = false
+		}
+		extra := mergeMap(nil, src.Extra)
+		if v, ok := src.Credentials["org_uuid"]; ok {
+			extra["org_uuid"] = v
+		}
+		if v, ok := src.Credentials["account_uuid"]; ok {
+			extra["account_uuid"] = v
+		}
+		add(src.Kind, src.ID, src.Name, PlatformAnthropic, accountType, src.IsActive, src.Schedulable, src.Priority, 0, src.Proxy, credentials, extra)
+	}
+	for _, src := range data.ClaudeConsoleAccounts {
+		add(src.Kind, src.ID, src.Name, PlatformAnthropic, AccountTypeAPIKey, src.IsActive, src.Schedulable, src.Priority, src.MaxConcurrentTasks, src.Proxy, sanitizeCredentialsMap(src.Credentials), nil)
+	}
+	for _, src := range data.OpenAIOAuthAccounts {
+		credentials := sanitizeCredentialsMap(src.Credentials)
+		if v, ok := credentials["token_type"].(string); !ok || strings.TrimSpace(v) == "" {
+			credentials["token_type"] = "Bearer"
+		}
+		crsExpiresAtToUnix(credentials)
+		extra := mergeMap(nil, src.Extra)
+		if v, ok := src.Extra["crs_email"]; ok {
+			extra["email"] = v
+		}
+		add(src.Kind, src.ID, src.Name, PlatformOpenAI, AccountTypeOAuth, src.IsActive, src.Schedulable, src.Priority, 0, src.Proxy, credentials, extra)
+	}
+	for _, src := range data.OpenAIResponsesAccounts {
+		credentials := sanitizeCredentialsMap(src.Credentials)
+		if v, ok := credentials["base_url"].(string); !ok || strings.TrimSpace(v) == "" {
+			credentials["base_url"] = "https://api.openai.com"
+		}
+		cleanBaseURL(credentials, "/v1")
+		add(src.Kind, src.ID, src.Name, PlatformOpenAI, AccountTypeAPIKey, src.IsActive, src.Schedulable, src.Priority, 0, src.Proxy, credentials, nil)
+	}
+	for _, src := range data.GeminiOAuthAccounts {
+		credentials := sanitizeCredentialsMap(src.Credentials)
+		if v, ok := credentials["oauth_type"].(string); !ok || strings.TrimSpace(v) == "" {
+			credentials["oauth_type"] = "code_assist"
+		}
+		add(src.Kind, src.ID, src.Name, PlatformGemini, AccountTypeOAuth, src.IsActive, src.Schedulable, src.Priority, 0, src.Proxy, credentials, src.Extra)
+	}
+	for _, src := range data.GeminiAPIKeyAccounts {
+		add(src.Kind, src.ID, src.Name, PlatformGemini, AccountTypeAPIKey, src.IsActive, src.Schedulable, src.Priority, 0, src.Proxy, sanitizeCredentialsMap(src.Credentials), src.Extra)
+	}
+	return entries, nil
+}
+
+func crsExportEmpty(d crsExportData) bool {
+	return len(d.ClaudeAccounts)+len(d.ClaudeConsoleAccounts)+len(d.OpenAIOAuthAccounts)+
+		len(d.OpenAIResponsesAccounts)+len(d.GeminiOAuthAccounts)+len(d.GeminiAPIKeyAccounts) == 0
+}
+
+// crsExpiresAtToUnix CRS 导出的 expires_at 为 ISO 时间，sub2api 使用 Unix 秒
+func crsExpiresAtToUnix(credentials map[string]any) {
+	if s, ok := credentials["expires_at"].(string); ok && s != "" {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			credentials["expires_at"] = t.Unix()
+		}
+	}
+}
+
+// codexAuthFile Codex CLI 的 ~/.codex/auth.json
+type codexAuthFile struct {
+	OpenAIAPIKey *string `json:"OPENAI_API_KEY"`
+	Tokens       *struct {
+		IDToken      string `json:"id_token"`
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		AccountID    string `json:"account_id"`
+	} `json:"tokens"`
+}
+
+func parseCodexAuth(content []byte) ([]AccountTransferEntry, error) {
+	var file codexAuthFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, invalidAccountFile(AccountTransferFormatCodex, err)
+	}
+	if t := file.Tokens; t != nil && (t.RefreshToken != "" || t.AccessToken != "") {
+		credentials := map[string]any{"token_type": "Bearer"}
+		setNonEmpty(credentials, "access_token", t.AccessToken)
+		setNonEmpty(credentials, "refresh_token", t.RefreshToken)
+		setNonEmpty(credentials, "id_token", t.IDToken)
+		setNonEmpty(credentials, "chatgpt_account_id", t.AccountID)
+		name := ""
+		if t.AccountID != "" {
+			name = "codex-" + t.AccountID
+		}
+		return []AccountTransferEntry{{Name: name, Platform: PlatformOpenAI, Type: AccountTypeOAuth, Credentials: credentials}}, nil
+	}
+	if file.OpenAIAPIKey != nil && strings.TrimSpace(*file.OpenAIAPIKey) != "" {
+		return []AccountTransferEntry{{
+			Platform:    PlatformOpenAI,
+			Type:        AccountTypeAPIKey,
+			Credentials: map[string]any{"api_key": strings.TrimSpace(*file.OpenAIAPIKey), "base_url": "https://api.openai.com"},
+		}}, nil
+	}
+	return nil, nil
+}
+
+// geminiCLICreds Gemini CLI 的 ~/.gemini/oauth_creds.json（expiry_date 为毫秒）
+type geminiCLICreds struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+	TokenType    string `json:"token_type"`
+	ExpiryDate   int64  `json:"expiry_date"`
+}
+
+func parseGeminiCLICreds(content []byte) ([]AccountTransferEntry, error) {
+	var file geminiCLICreds
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, invalidAccountFile(AccountTransferFormatGeminiCLI, err)
+	}
+	if file.RefreshToken == "" && file.AccessToken == "" {
+		return nil, nil
+	}
+	// Gemini CLI 使用内置 OAuth 客户端，对应 code_assist 模式；project_id 在首次刷新时自动获取
+	credentials := map[string]any{"oauth_type": "code_assist"}
+	setNonEmpty(credentials, "access_token", file.AccessToken)
+	setNonEmpty(credentials, "refresh_token", file.RefreshToken)
+	setNonEmpty(credentials, "scope", file.Scope)
+	setNonEmpty(credentials, "token_type", file.TokenType)
+	if file.ExpiryDate > 0 {
+		credentials["expires_at"] = strconv.FormatInt(file.ExpiryDate/1000, 10)
+	}
+	return []AccountTransferEntry{{Platform: PlatformGemini, Type: AccountTypeOAuth, Credentials: credentials}}, nil
+}
+
+// parseAPIKeyList 每行一个 Key，可选 "key,base_url"；空行与 # 开头的行忽略
+func parseAPIKeyList(content []byte, platform, baseURL string) ([]AccountTransferEntry, error) {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	if platform == "" {
+		return nil, ErrAccountImportPlatformRequired
+	}
+	baseURL = strings.TrimSpace(baseURL)
+	if baseURL == "" && platform == PlatformOpenAI {
+		baseURL = "https://api.openai.com"
+	}
+	var entries []AccountTransferEntry
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, lineBaseURL := line, baseURL
+		if idx := strings.IndexByte(line, ','); idx >= 0 {
+			key = strings.TrimSpace(line[:idx])
+			if u := strings.TrimSpace(line[idx+1:]); u != "" {
+				lineBaseURL = u
+			}
+		}
+		credentials := map[string]any{"api_key": key}
+		setNonEmpty(credentials, "base_url", lineBaseURL)
+		entries = append(entries, AccountTransferEntry{Platform: platform, Type: AccountTypeAPIKey, Credentials: credentials})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, invalidAccountFile(AccountTransferFormatAPIKeys, err)
+	}
+	return entries, nil
+}
+
+func setNonEmpty(m map[string]any, key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		m[key] = value
+	}
+}
+
+func normalizeAccountTransferEntry(e *AccountTransferEntry) {
+	e.Name = strings.TrimSpace(e.Name)
+	e.Platform = strings.ToLower(strings.TrimSpace(e.Platform))
+	e.Type = strings.ToLower(strings.TrimSpace(e.Type))
+	e.Credentials = sanitizeCredentialsMap(e.Credentials)
+	if e.Concurrency <= 0 {
+		e.Concurrency = 3
+	}
+	e.Priority = clampPriority(e.Priority)
+}
+
+func validateAccountTransferEntry(e *AccountTransferEntry) error {
+	switch e.Platform {
+	case PlatformAnthropic, PlatformOpenAI, PlatformGemini, PlatformAntigravity:
+	default:
+		return fmt.Errorf("unsupported platform %q", e.Platform)
+	}
+	credential := func(key string) string {
+		v, _ := e.Credentials[key].(string)
+		return strings.TrimSpace(v)
+	}
+	switch e.Type {
+	case AccountTypeAPIKey:
+		if credential("api_key") == "" {
+			return fmt.Errorf("missing api_key")
+		}
+	case AccountTypeOAuth, AccountTypeSetupToken:
+		if credential("access_token") == "" && credential("refresh_token") == "" {
+			return fmt.Errorf("missing access_token or refresh_token")
+		}
+	default:
+		return fmt.Errorf("unsupported account type %q", e.Type)
+	}
+	for _, v := range e.Credentials {
+		if s, ok := v.(string); ok && IsEncryptedSecret(s) {
+			return fmt.Errorf("credentials are encrypted with another instance's key")
+		}
+	}
+	if e.RateMultiplier != nil && *e.RateMultiplier < 0 {
+		return fmt.Errorf("rate_multiplier must be >= 0")
+	}
+	return nil
+}
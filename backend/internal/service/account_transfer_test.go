@@ -0,0 +1,130 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+type transferAccountRepoStub struct {
+	AccountRepository
+	accounts    []Account
+	unscheduled []int64
+}
+
+func (r *transferAccountRepoStub) ListWithFilters(ctx context.Context, params pagination.PaginationParams, platform, accountType, status, search string) ([]Account, *pagination.PaginationResult, error) {
+	var out []Account
+	for _, a := range r.accounts {
+		if platform == "" || a.Platform == platform {
+			out = append(out, a)
+		}
+	}
+	return out, &pagination.PaginationResult{Total: int64(len(out))}, nil
+}
+
+func (r *transferAccountRepoStub) SetSchedulable(ctx context.Context, id int64, schedulable bool) error {
+	if !schedulable {
+		r.unscheduled = append(r.unscheduled, id)
+	}
+	return nil
+}
+
+type transferAdminServiceStub struct {
+	AdminService
+	created []*CreateAccountInput
+}
+
+func (s *transferAdminServiceStub) CreateAccount(ctx context.Context, input *CreateAccountInput) (*Account, error) {
+	s.created = append(s.created, input)
+	return &Account{ID: int64(1000 + len(s.created))}, nil
+}
+
+func TestDetectAccountImportFormat(t *testing.T) {
+	cases := map[string]string{
+		`{"format":"sub2api-accounts","version":1,"accounts":[]}`:              AccountTransferFormatNative,
+		`[{"platform":"openai"}]`:                                              AccountTransferFormatNative,
+		`{"success":true,"data":{"claudeAccounts":[]}}`:                        AccountTransferFormatCRS,
+		`{"OPENAI_API_KEY":null,"tokens":{"refresh_token":"r"}}`:               AccountTransferFormatCodex,
+		`{"access_token":"a","refresh_token":"r","expiry_date":1700000000000}`: AccountTransferFormatGeminiCLI,
+		"sk-ant-1\nsk-ant-2\n":                                                 AccountTransferFormatAPIKeys,
+		`{"unknown":true}`:                                                     "",
+	}
+	for content, want := range cases {
+		require.Equal(t, want, DetectAccountImportFormat([]byte(content)), content)
+	}
+}
+
+func TestParseAccountImportFormats(t *testing.T) {
+	entries, err := ParseAccountImport(AccountTransferFormatCRS, []byte(`{"data":{
+		"claudeAccounts":[{"id":"c1","name":"claude","authType":"oauth","isActive":true,"schedulable":true,"priority":10,
+			"credentials":{"access_token":"at","refresh_token":"rt","expires_at":"2025-01-01T00:00:00Z","org_uuid":"org"},
+			"proxy":{"protocol":"socks5","host":"1.2.3.4","port":1080}}],
+		"openaiResponsesAccounts":[{"id":"o1","isActive":false,"schedulable":true,"credentials":{"api_key":"sk","base_url":"https://relay.example.com/v1"}}]
+	}}`), "", "")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, PlatformAnthropic, entries[0].Platform)
+	require.Equal(t, int64(1735689600), entries[0].Credentials["expires_at"])
+	require.Equal(t, "org", entries[0].Extra["org_uuid"])
+	require.Equal(t, "1.2.3.4", entries[0].Proxy.Host)
+	require.Equal(t, "https://relay.example.com", entries[1].Credentials["base_url"])
+	require.False(t, *entries[1].Schedulable)
+
+	entries, err = ParseAccountImport(AccountTransferFormatCodex, []byte(`{"OPENAI_API_KEY":null,"tokens":{"id_token":"id","access_token":"at","refresh_token":"rt","account_id":"acc-1"}}`), "", "")
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeOAuth, entries[0].Type)
+	require.Equal(t, "acc-1", entries[0].Credentials["chatgpt_account_id"])
+
+	entries, err = ParseAccountImport(AccountTransferFormatGeminiCLI, []byte(`{"access_token":"at","refresh_token":"rt","expiry_date":1700000000000}`), "", "")
+	require.NoError(t, err)
+	require.Equal(t, "1700000000", entries[0].Credentials["expires_at"])
+	require.Equal(t, "code_assist", entries[0].Credentials["oauth_type"])
+
+	_, err = ParseAccountImport(AccountTransferFormatAPIKeys, []byte("k1"), "", "")
+	require.ErrorIs(t, err, ErrAccountImportPlatformRequired)
+	entries, err = ParseAccountImport(AccountTransferFormatAPIKeys, []byte("# comment\nk1\n\nk2, https://relay.example.com\n"), "openai", "")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "https://api.openai.com", entries[0].Credentials["base_url"])
+	require.Equal(t, "https://relay.example.com", entries[1].Credentials["base_url"])
+}
+
+func TestAccountImportDeduplicatesByFingerprint(t *testing.T) {
+	ctx := context.Background()
+	repo := &transferAccountRepoStub{accounts: []Account{
+		{ID: 9, Platform: PlatformOpenAI, Credentials: map[string]any{"api_key": "existing"}},
+	}}
+	admin := &transferAdminServiceStub{}
+	svc := NewAccountTransferService(admin, repo, nil)
+	content := []byte("existing\nfresh\nfresh\n")
+
+	result, err := svc.Import(ctx, AccountImportInput{Content: content, Platform: PlatformOpenAI, DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, AccountTransferFormatAPIKeys, result.Format)
+	require.Equal(t, 1, result.Created)
+	require.Equal(t, 2, result.Duplicates)
+	require.Equal(t, int64(9), result.Items[0].DuplicateOf)
+	require.Equal(t, AccountImportActionWouldCreate, result.Items[1].Action)
+	require.Contains(t, result.Items[2].Error, "entry #1")
+	require.Empty(t, admin.created)
+
+	doc, _ := json.Marshal(AccountTransferDocument{Format: accountTransferDocumentFormat, Version: 1, Accounts: []AccountTransferEntry{
+		{Name: "a", Platform: "OpenAI", Type: "apikey", Credentials: map[string]any{"api_key": "fresh"}, Schedulable: new(bool)},
+		{Name: "b", Platform: "openai", Type: "oauth", Credentials: map[string]any{"id_token": "only"}},
+		{Name: "c", Platform: "openai", Type: "apikey", Credentials: map[string]any{"api_key": EncryptedSecretPrefix + "k:x"}},
+	}})
+	result, err = svc.Import(ctx, AccountImportInput{Content: doc, GroupIDs: []int64{3}})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Created)
+	require.Equal(t, 2, result.Failed)
+	require.Len(t, admin.created, 1)
+	require.Equal(t, PlatformOpenAI, admin.created[0].Platform)
+	require.Equal(t, []int64{3}, admin.created[0].GroupIDs)
+	require.Equal(t, 3, admin.created[0].Concurrency)
+	require.Equal(t, []int64{result.Items[0].AccountID}, repo.unscheduled)
+}
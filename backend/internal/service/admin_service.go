@@ -110,6 +110,24 @@ type CreateGroupInput struct {
 	// 模型路由配置（仅 anthropic 平台使用）
 	ModelRouting        map[string][]int64
 	ModelRoutingEnabled bool // 是否启用模型路由
+	// 账号调度策略（空字符串为默认策略）
+	SchedulingStrategy string
+	// 内容策略（nil 表示不配置）
+	ContentPolicy *ContentPolicy
+	// 请求改写规则（为空表示不配置）
+	RewriteRules []RequestRewriteRule
+	// 模型别名 / 虚拟模型（为空表示不配置）
+	ModelAliases []ModelAlias
+	// 订阅超额策略（空字符串为 reject）
+	OveragePolicy         string
+	OverageRateMultiplier *float64
+	OverageGroupID        *int64
+	// 订阅每周期余额价格（nil 表示不支持自助续费/切换）
+	SubscriptionPrice *float64
+	// 订阅 token / 请求次数额度（为空表示不配置）
+	UsageQuotas []SubscriptionQuota
+	// 灰度 / A-B 分流（nil 表示不配置）
+	TrafficSplit *TrafficSplit
 }
 
 type UpdateGroupInput struct {
@@ -132,6 +150,24 @@ type UpdateGroupInput struct {
 	// 模型路由配置（仅 anthropic 平台使用）
 	ModelRouting        map[string][]int64
 	ModelRoutingEnabled *bool // 是否启用模型路由
+	// 账号调度策略（空字符串重置为默认策略）
+	SchedulingStrategy *string
+	// 内容策略（nil 表示不修改；审核 Webhook 密钥留空时保留原值）
+	ContentPolicy *ContentPolicy
+	// 请求改写规则（nil 表示不修改，空数组表示清空）
+	RewriteRules *[]RequestRewriteRule
+	// 模型别名（nil 表示不修改，空数组表示清空）
+	ModelAliases *[]ModelAlias
+	// 订阅超额策略（nil 表示不修改；OverageGroupID 传 0 表示清除）
+	OveragePolicy         *string
+	OverageRateMultiplier *float64
+	OverageGroupID        *int64
+	// 订阅每周期余额价格（nil 表示不修改，负数表示清除）
+	SubscriptionPrice *float64
+	// 订阅 token / 请求次数额度（nil 表示不修改，空数组表示清空）
+	UsageQuotas *[]SubscriptionQuota
+	// 灰度 / A-B 分流（nil 表示不修改，未启用且无实验组表示清除）
+	TrafficSplit *TrafficSplit
 }
 
 type CreateAccountInput struct {
@@ -580,6 +616,31 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 		}
 	}
 
+	schedulingStrategy, err := NormalizeSchedulingStrategy(input.SchedulingStrategy)
+	if err != nil {
+		return nil, err
+	}
+	contentPolicy, err := NormalizeContentPolicy(input.ContentPolicy)
+	if err != nil {
+		return nil, err
+	}
+	rewriteRules, err := NormalizeRequestRewriteRules(input.RewriteRules)
+	if err != nil {
+		return nil, err
+	}
+	modelAliases, err := NormalizeModelAliases(platform, input.ModelAliases)
+	if err != nil {
+		return nil, err
+	}
+	usageQuotas, err := NormalizeSubscriptionQuotas(input.UsageQuotas)
+	if err != nil {
+		return nil, err
+	}
+	trafficSplit, err := NormalizeTrafficSplit(input.TrafficSplit)
+	if err != nil {
+		return nil, err
+	}
+
 	group := &Group{
 		Name:             input.Name,
 		Description:      input.Description,
@@ -597,6 +658,26 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 		ClaudeCodeOnly:   input.ClaudeCodeOnly,
 		FallbackGroupID:  input.FallbackGroupID,
 		ModelRouting:     input.ModelRouting,
+
+		SchedulingStrategy: schedulingStrategy,
+		ContentPolicy:      contentPolicy,
+		RewriteRules:       rewriteRules,
+		ModelAliases:       modelAliases,
+
+		OveragePolicy:         input.OveragePolicy,
+		OverageRateMultiplier: 1,
+		OverageGroupID:        input.OverageGroupID,
+
+		SubscriptionPrice: normalizePrice(input.SubscriptionPrice),
+		UsageQuotas:       usageQuotas,
+
+		TrafficSplit: trafficSplit,
+	}
+	if input.OverageRateMultiplier != nil {
+		group.OverageRateMultiplier = *input.OverageRateMultiplier
+	}
+	if err := s.normalizeGroupOverage(ctx, group); err != nil {
+		return nil, err
 	}
 	if err := s.groupRepo.Create(ctx, group); err != nil {
 		return nil, err
@@ -733,6 +814,91 @@ func (s *adminServiceImpl) UpdateGroup(ctx context.Context, id int64, input *Upd
 		group.ModelRoutingEnabled = *input.ModelRoutingEnabled
 	}
 
+	// 调度策略
+	if input.SchedulingStrategy != nil {
+		strategy, err := NormalizeSchedulingStrategy(*input.SchedulingStrategy)
+		if err != nil {
+			return nil, err
+		}
+		group.SchedulingStrategy = strategy
+	}
+
+	// 内容策略
+	if input.ContentPolicy != nil {
+		policy, err := NormalizeContentPolicy(input.ContentPolicy)
+		if err != nil {
+			return nil, err
+		}
+		if policy.Moderation != nil && policy.Moderation.Secret == "" &&
+			group.ContentPolicy != nil && group.ContentPolicy.Moderation != nil {
+			policy.Moderation.Secret = group.ContentPolicy.Moderation.Secret
+		}
+		group.ContentPolicy = policy
+	}
+
+	// 请求改写规则
+	if input.RewriteRules != nil {
+		rules, err := NormalizeRequestRewriteRules(*input.RewriteRules)
+		if err != nil {
+			return nil, err
+		}
+		group.RewriteRules = rules
+	}
+
+	// 模型别名（平台变更时同样重新校验已有别名的目标平台）
+	if input.ModelAliases != nil || (input.Platform != "" && len(group.ModelAliases) > 0) {
+		aliases := group.ModelAliases
+		if input.ModelAliases != nil {
+			aliases = *input.ModelAliases
+		}
+		normalized, err := NormalizeModelAliases(group.Platform, aliases)
+		if err != nil {
+			return nil, err
+		}
+		group.ModelAliases = normalized
+	}
+
+	// 订阅超额策略
+	if input.OveragePolicy != nil {
+		group.OveragePolicy = *input.OveragePolicy
+	}
+	if input.OverageRateMultiplier != nil {
+		group.OverageRateMultiplier = *input.OverageRateMultiplier
+	}
+	if input.OverageGroupID != nil {
+		if *input.OverageGroupID > 0 {
+			group.OverageGroupID = input.OverageGroupID
+		} else {
+			group.OverageGroupID = nil
+		}
+	}
+	if err := s.normalizeGroupOverage(ctx, group); err != nil {
+		return nil, err
+	}
+
+	// 订阅自助续费价格
+	if input.SubscriptionPrice != nil {
+		group.SubscriptionPrice = normalizePrice(input.SubscriptionPrice)
+	}
+
+	// 订阅 token / 请求次数额度
+	if input.UsageQuotas != nil {
+		quotas, err := NormalizeSubscriptionQuotas(*input.UsageQuotas)
+		if err != nil {
+			return nil, err
+		}
+		group.UsageQuotas = quotas
+	}
+
+	// 灰度 / A-B 分流
+	if input.TrafficSplit != nil {
+		split, err := NormalizeTrafficSplit(input.TrafficSplit)
+		if err != nil {
+			return nil, err
+		}
+		group.TrafficSplit = split
+	}
+
 	if err := s.groupRepo.Update(ctx, group); err != nil {
 		return nil, err
 	}
@@ -743,11 +909,11 @@ func (s *adminServiceImpl) UpdateGroup(ctx context.Context, id int64, input *Upd
 }
 
 func (s *adminServiceImpl) DeleteGroup(ctx context.Context, id int64) error {
-	var groupKeys []string
+	var groupKeys []APIKeyCredential
 	if s.authCacheInvalidator != nil {
-		keys, err := s.apiKeyRepo.ListKeysByGroupID(ctx, id)
+		creds, err := s.apiKeyRepo.ListCredentialsByGroupID(ctx, id)
 		if err == nil {
-			groupKeys = keys
+			groupKeys = creds
 		}
 	}
 
@@ -770,8 +936,8 @@ func (s *adminServiceImpl) DeleteGroup(ctx context.Context, id int64) error {
 		}()
 	}
 	if s.authCacheInvalidator != nil {
-		for _, key := range groupKeys {
-			s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, key)
+		for _, cred := range groupKeys {
+			s.authCacheInvalidator.InvalidateAuthCacheByCredential(ctx, cred)
 		}
 	}
 
@@ -815,6 +981,10 @@ func (s *adminServiceImpl) GetAccountsByIDs(ctx context.Context, ids []int64) ([
 }
 
 func (s *adminServiceImpl) CreateAccount(ctx context.Context, input *CreateAccountInput) (*Account, error) {
+	if err := ValidateAccountAvailabilitySchedule(input.Extra); err != nil {
+		return nil, err
+	}
+
 	// 绑定分组
 	groupIDs := input.GroupIDs
 	// 如果没有指定分组,自动绑定对应平台的默认分组
@@ -896,10 +1066,15 @@ func (s *adminServiceImpl) UpdateAccount(ctx context.Context, id int64, input *U
 		account.Notes = normalizeAccountNotes(input.Notes)
 	}
 	if len(input.Credentials) > 0 {
-		account.Credentials = input.Credentials
+		// 管理端拿到的是脱敏凭证，编辑表单原样回传的掩码需还原为原值
+		account.Credentials = RestoreMaskedCredentials(input.Credentials, account.Credentials)
 	}
 	if len(input.Extra) > 0 {
+		if err := ValidateAccountAvailabilitySchedule(input.Extra); err != nil {
+			return nil, err
+		}
 		account.Extra = input.Extra
+		account.CompileAvailabilitySchedule()
 	}
 	if input.ProxyID != nil {
 		// 0 表示清除代理（前端发送 0 而不是 null 来表达清除意图）
@@ -1002,10 +1177,13 @@ func (s *adminServiceImpl) BulkUpdateAccounts(ctx context.Context, input *BulkUp
 			return nil, errors.New("rate_multiplier must be >= 0")
 		}
 	}
+	if err := ValidateAccountAvailabilitySchedule(input.Extra); err != nil {
+		return nil, err
+	}
 
 	// Prepare bulk updates for columns and JSONB fields.
 	repoUpdates := AccountBulkUpdate{
-		Credentials: input.Credentials,
+		Credentials: StripMaskedCredentials(input.Credentials),
 		Extra:       input.Extra,
 	}
 	if input.Name != "" {
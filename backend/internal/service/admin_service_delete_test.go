@@ -319,6 +319,22 @@ func (s *billingCacheStub) InvalidateUserBalance(ctx context.Context, userID int
 	panic("unexpected InvalidateUserBalance call")
 }
 
+func (s *billingCacheStub) GetOrganizationBalance(ctx context.Context, orgID int64) (float64, error) {
+	panic("unexpected GetOrganizationBalance call")
+}
+
+func (s *billingCacheStub) SetOrganizationBalance(ctx context.Context, orgID int64, balance float64) error {
+	panic("unexpected SetOrganizationBalance call")
+}
+
+func (s *billingCacheStub) DeductOrganizationBalance(ctx context.Context, orgID int64, amount float64) error {
+	panic("unexpected DeductOrganizationBalance call")
+}
+
+func (s *billingCacheStub) InvalidateOrganizationBalance(ctx context.Context, orgID int64) error {
+	panic("unexpected InvalidateOrganizationBalance call")
+}
+
 func (s *billingCacheStub) GetSubscriptionCache(ctx context.Context, userID, groupID int64) (*SubscriptionCacheData, error) {
 	panic("unexpected GetSubscriptionCache call")
 }
@@ -336,6 +352,18 @@ func (s *billingCacheStub) InvalidateSubscriptionCache(ctx context.Context, user
 	return nil
 }
 
+func (s *billingCacheStub) GetSubscriptionQuotaUsage(ctx context.Context, key SubscriptionQuotaKey) (*SubscriptionQuotaUsage, error) {
+	panic("unexpected GetSubscriptionQuotaUsage call")
+}
+
+func (s *billingCacheStub) SetSubscriptionQuotaUsage(ctx context.Context, key SubscriptionQuotaKey, usage SubscriptionQuotaUsage, ttl time.Duration) error {
+	panic("unexpected SetSubscriptionQuotaUsage call")
+}
+
+func (s *billingCacheStub) IncrSubscriptionQuotaUsage(ctx context.Context, key SubscriptionQuotaKey, delta SubscriptionQuotaUsage) error {
+	panic("unexpected IncrSubscriptionQuotaUsage call")
+}
+
 func waitForInvalidations(t *testing.T, ch <-chan subscriptionInvalidateCall, expected int) []subscriptionInvalidateCall {
 	t.Helper()
 	calls := make([]subscriptionInvalidateCall, 0, expected)
@@ -50,8 +50,8 @@ type authCacheInvalidatorStub struct {
 	keys     []string
 }
 
-func (s *authCacheInvalidatorStub) InvalidateAuthCacheByKey(ctx context.Context, key string) {
-	s.keys = append(s.keys, key)
+func (s *authCacheInvalidatorStub) InvalidateAuthCacheByCredential(ctx context.Context, cred APIKeyCredential) {
+	s.keys = append(s.keys, cred.KeyHash)
 }
 
 func (s *authCacheInvalidatorStub) InvalidateAuthCacheByUserID(ctx context.Context, userID int64) {
@@ -39,12 +39,13 @@ type antigravityRetryLoopParams struct {
 	proxyURL       string
 	accessToken    string
 	action         string
+	model          string // 映射后的上游模型，用于 429 限流域判定
 	body           []byte
 	quotaScope     AntigravityQuotaScope
 	c              *gin.Context
 	httpUpstream   HTTPUpstream
 	settingService *SettingService
-	handleError    func(ctx context.Context, prefix string, account *Account, statusCode int, headers http.Header, body []byte, quotaScope AntigravityQuotaScope)
+	handleError    func(ctx context.Context, prefix string, account *Account, requestedModel string, statusCode int, headers http.Header, body []byte, quotaScope AntigravityQuotaScope)
 }
 
 // antigravityRetryLoopResult 重试循环的结果
@@ -61,10 +62,10 @@ func antigravityRetryLoop(p antigravityRetryLoopParams) (*antigravityRetryLoopRe
 
 	var resp *http.Response
 	var usedBaseURL string
-	logBody := p.settingService != nil && p.settingService.cfg != nil && p.settingService.cfg.Gateway.LogUpstreamErrorBody
+	logBody := p.settingService != nil && p.settingService.cfg != nil && p.settingService.cfg.Live().Gateway.LogUpstreamErrorBody
 	maxBytes := 2048
-	if p.settingService != nil && p.settingService.cfg != nil && p.settingService.cfg.Gateway.LogUpstreamErrorBodyMaxBytes > 0 {
-		maxBytes = p.settingService.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+	if p.settingService != nil && p.settingService.cfg != nil && p.settingService.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes > 0 {
+		maxBytes = p.settingService.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 	}
 	getUpstreamDetail := func(body []byte) string {
 		if !logBody {
@@ -156,7 +157,7 @@ urlFallbackLoop:
 				}
 
 				// 重试用尽，标记账户限流
-				p.handleError(p.ctx, p.prefix, p.account, resp.StatusCode, resp.Header, respBody, p.quotaScope)
+				p.handleError(p.ctx, p.prefix, p.account, p.model, resp.StatusCode, resp.Header, respBody, p.quotaScope)
 				log.Printf("%s status=429 rate_limited base_url=%s body=%s", p.prefix, baseURL, truncateForLog(respBody, 200))
 				resp = &http.Response{
 					StatusCode: resp.StatusCode,
@@ -753,6 +754,7 @@ func (s *AntigravityGatewayService) Forward(ctx context.Context, c *gin.Context,
 		proxyURL:       proxyURL,
 		accessToken:    accessToken,
 		action:         action,
+		model:          mappedModel,
 		body:           geminiBody,
 		quotaScope:     quotaScope,
 		c:              c,
@@ -775,10 +777,10 @@ func (s *AntigravityGatewayService) Forward(ctx context.Context, c *gin.Context,
 		if resp.StatusCode == http.StatusBadRequest && isSignatureRelatedError(respBody) {
 			upstreamMsg := strings.TrimSpace(extractAntigravityErrorMessage(respBody))
 			upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
-			logBody := s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Gateway.LogUpstreamErrorBody
+			logBody := s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.LogUpstreamErrorBody
 			maxBytes := 2048
-			if s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Gateway.LogUpstreamErrorBodyMaxBytes > 0 {
-				maxBytes = s.settingService.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+			if s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes > 0 {
+				maxBytes = s.settingService.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 			}
 			upstreamDetail := ""
 			if logBody {
@@ -829,6 +831,7 @@ func (s *AntigravityGatewayService) Forward(ctx context.Context, c *gin.Context,
 					proxyURL:       proxyURL,
 					accessToken:    accessToken,
 					action:         action,
+					model:          mappedModel,
 					body:           retryGeminiBody,
 					quotaScope:     quotaScope,
 					c:              c,
@@ -910,15 +913,15 @@ func (s *AntigravityGatewayService) Forward(ctx context.Context, c *gin.Context,
 
 		// 处理错误响应（重试后仍失败或不触发重试）
 		if resp.StatusCode >= 400 {
-			s.handleUpstreamError(ctx, prefix, account, resp.StatusCode, resp.Header, respBody, quotaScope)
+			s.handleUpstreamError(ctx, prefix, account, mappedModel, resp.StatusCode, resp.Header, respBody, quotaScope)
 
 			if s.shouldFailoverUpstreamError(resp.StatusCode) {
 				upstreamMsg := strings.TrimSpace(extractAntigravityErrorMessage(respBody))
 				upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
-				logBody := s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Gateway.LogUpstreamErrorBody
+				logBody := s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.LogUpstreamErrorBody
 				maxBytes := 2048
-				if s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Gateway.LogUpstreamErrorBodyMaxBytes > 0 {
-					maxBytes = s.settingService.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+				if s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes > 0 {
+					maxBytes = s.settingService.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 				}
 				upstreamDetail := ""
 				if logBody {
@@ -1331,6 +1334,7 @@ func (s *AntigravityGatewayService) ForwardGemini(ctx context.Context, c *gin.Co
 		proxyURL:       proxyURL,
 		accessToken:    accessToken,
 		action:         upstreamAction,
+		model:          mappedModel,
 		body:           wrappedBody,
 		quotaScope:     quotaScope,
 		c:              c,
@@ -1393,14 +1397,14 @@ func (s *AntigravityGatewayService) ForwardGemini(ctx context.Context, c *gin.Co
 		if unwrapErr != nil || len(unwrappedForOps) == 0 {
 			unwrappedForOps = respBody
 		}
-		s.handleUpstreamError(ctx, prefix, account, resp.StatusCode, resp.Header, respBody, quotaScope)
+		s.handleUpstreamError(ctx, prefix, account, mappedModel, resp.StatusCode, resp.Header, respBody, quotaScope)
 		upstreamMsg := strings.TrimSpace(extractAntigravityErrorMessage(unwrappedForOps))
 		upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
 
-		logBody := s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Gateway.LogUpstreamErrorBody
+		logBody := s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.LogUpstreamErrorBody
 		maxBytes := 2048
-		if s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Gateway.LogUpstreamErrorBodyMaxBytes > 0 {
-			maxBytes = s.settingService.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+		if s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes > 0 {
+			maxBytes = s.settingService.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 		}
 		upstreamDetail := ""
 		if logBody {
@@ -1533,7 +1537,7 @@ func antigravityUseScopeRateLimit() bool {
 	return v == "1" || v == "true" || v == "yes" || v == "on"
 }
 
-func (s *AntigravityGatewayService) handleUpstreamError(ctx context.Context, prefix string, account *Account, statusCode int, headers http.Header, body []byte, quotaScope AntigravityQuotaScope) {
+func (s *AntigravityGatewayService) handleUpstreamError(ctx context.Context, prefix string, account *Account, requestedModel string, statusCode int, headers http.Header, body []byte, quotaScope AntigravityQuotaScope) {
 	// 429 使用 Gemini 格式解析（从 body 解析重置时间）
 	if statusCode == 429 {
 		useScopeLimit := antigravityUseScopeRateLimit() && quotaScope != ""
@@ -1541,8 +1545,8 @@ func (s *AntigravityGatewayService) handleUpstreamError(ctx context.Context, pre
 		if resetAt == nil {
 			// 解析失败：使用配置的 fallback 时间，直接限流整个账户
 			fallbackMinutes := 5
-			if s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Gateway.AntigravityFallbackCooldownMinutes > 0 {
-				fallbackMinutes = s.settingService.cfg.Gateway.AntigravityFallbackCooldownMinutes
+			if s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.AntigravityFallbackCooldownMinutes > 0 {
+				fallbackMinutes = s.settingService.cfg.Live().Gateway.AntigravityFallbackCooldownMinutes
 			}
 			defaultDur := time.Duration(fallbackMinutes) * time.Minute
 			ra := time.Now().Add(defaultDur)
@@ -1551,6 +1555,8 @@ func (s *AntigravityGatewayService) handleUpstreamError(ctx context.Context, pre
 				if err := s.accountRepo.SetAntigravityQuotaScopeLimit(ctx, account.ID, quotaScope, ra); err != nil {
 					log.Printf("%s status=429 rate_limit_set_failed scope=%s error=%v", prefix, quotaScope, err)
 				}
+			} else if s.rateLimitService != nil && s.rateLimitService.trySetModelScopedRateLimit(ctx, account, requestedModel, body, ra) {
+				log.Printf("%s status=429 rate_limited model_scope reset_in=%v (fallback)", prefix, defaultDur)
 			} else {
 				log.Printf("%s status=429 rate_limited account=%d reset_in=%v (fallback)", prefix, account.ID, defaultDur)
 				if err := s.accountRepo.SetRateLimited(ctx, account.ID, ra); err != nil {
@@ -1565,6 +1571,8 @@ func (s *AntigravityGatewayService) handleUpstreamError(ctx context.Context, pre
 			if err := s.accountRepo.SetAntigravityQuotaScopeLimit(ctx, account.ID, quotaScope, resetTime); err != nil {
 				log.Printf("%s status=429 rate_limit_set_failed scope=%s error=%v", prefix, quotaScope, err)
 			}
+		} else if s.rateLimitService != nil && s.rateLimitService.trySetModelScopedRateLimit(ctx, account, requestedModel, body, resetTime) {
+			log.Printf("%s status=429 rate_limited model_scope reset_at=%v reset_in=%v", prefix, resetTime.Format("15:04:05"), time.Until(resetTime).Truncate(time.Second))
 		} else {
 			log.Printf("%s status=429 rate_limited account=%d reset_at=%v reset_in=%v", prefix, account.ID, resetTime.Format("15:04:05"), time.Until(resetTime).Truncate(time.Second))
 			if err := s.accountRepo.SetRateLimited(ctx, account.ID, resetTime); err != nil {
@@ -1577,7 +1585,7 @@ func (s *AntigravityGatewayService) handleUpstreamError(ctx context.Context, pre
 	if s.rateLimitService == nil {
 		return
 	}
-	shouldDisable := s.rateLimitService.HandleUpstreamError(ctx, account, statusCode, headers, body)
+	shouldDisable := s.rateLimitService.HandleUpstreamError(ctx, account, requestedModel, statusCode, headers, body)
 	if shouldDisable {
 		log.Printf("%s status=%d marked_error", prefix, statusCode)
 	}
@@ -1608,8 +1616,8 @@ func (s *AntigravityGatewayService) handleGeminiStreamingResponse(c *gin.Context
 	// 使用 Scanner 并限制单行大小，避免 ReadString 无上限导致 OOM
 	scanner := bufio.NewScanner(resp.Body)
 	maxLineSize := defaultMaxLineSize
-	if s.settingService.cfg != nil && s.settingService.cfg.Gateway.MaxLineSize > 0 {
-		maxLineSize = s.settingService.cfg.Gateway.MaxLineSize
+	if s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.MaxLineSize > 0 {
+		maxLineSize = s.settingService.cfg.Live().Gateway.MaxLineSize
 	}
 	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
 	usage := &ClaudeUsage{}
@@ -1648,8 +1656,8 @@ func (s *AntigravityGatewayService) handleGeminiStreamingResponse(c *gin.Context
 
 	// 上游数据间隔超时保护（防止上游挂起长期占用连接）
 	streamInterval := time.Duration(0)
-	if s.settingService.cfg != nil && s.settingService.cfg.Gateway.StreamDataIntervalTimeout > 0 {
-		streamInterval = time.Duration(s.settingService.cfg.Gateway.StreamDataIntervalTimeout) * time.Second
+	if s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.StreamDataIntervalTimeout > 0 {
+		streamInterval = time.Duration(s.settingService.cfg.Live().Gateway.StreamDataIntervalTimeout) * time.Second
 	}
 	var intervalTicker *time.Ticker
 	if streamInterval > 0 {
@@ -1765,8 +1773,8 @@ func (s *AntigravityGatewayService) handleGeminiStreamingResponse(c *gin.Context
 func (s *AntigravityGatewayService) handleGeminiStreamToNonStreaming(c *gin.Context, resp *http.Response, startTime time.Time) (*antigravityStreamResult, error) {
 	scanner := bufio.NewScanner(resp.Body)
 	maxLineSize := defaultMaxLineSize
-	if s.settingService.cfg != nil && s.settingService.cfg.Gateway.MaxLineSize > 0 {
-		maxLineSize = s.settingService.cfg.Gateway.MaxLineSize
+	if s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.MaxLineSize > 0 {
+		maxLineSize = s.settingService.cfg.Live().Gateway.MaxLineSize
 	}
 	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
 
@@ -1812,8 +1820,8 @@ func (s *AntigravityGatewayService) handleGeminiStreamToNonStreaming(c *gin.Cont
 
 	// 上游数据间隔超时保护（防止上游挂起长期占用连接）
 	streamInterval := time.Duration(0)
-	if s.settingService.cfg != nil && s.settingService.cfg.Gateway.StreamDataIntervalTimeout > 0 {
-		streamInterval = time.Duration(s.settingService.cfg.Gateway.StreamDataIntervalTimeout) * time.Second
+	if s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.StreamDataIntervalTimeout > 0 {
+		streamInterval = time.Duration(s.settingService.cfg.Live().Gateway.StreamDataIntervalTimeout) * time.Second
 	}
 	var intervalTicker *time.Ticker
 	if streamInterval > 0 {
@@ -2115,10 +2123,10 @@ func (s *AntigravityGatewayService) writeMappedClaudeError(c *gin.Context, accou
 	upstreamMsg := strings.TrimSpace(extractUpstreamErrorMessage(body))
 	upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
 
-	logBody := s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Gateway.LogUpstreamErrorBody
+	logBody := s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.LogUpstreamErrorBody
 	maxBytes := 2048
-	if s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Gateway.LogUpstreamErrorBodyMaxBytes > 0 {
-		maxBytes = s.settingService.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+	if s.settingService != nil && s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes > 0 {
+		maxBytes = s.settingService.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 	}
 
 	upstreamDetail := ""
@@ -2212,8 +2220,8 @@ func (s *AntigravityGatewayService) writeGoogleError(c *gin.Context, status int,
 func (s *AntigravityGatewayService) handleClaudeStreamToNonStreaming(c *gin.Context, resp *http.Response, startTime time.Time, originalModel string) (*antigravityStreamResult, error) {
 	scanner := bufio.NewScanner(resp.Body)
 	maxLineSize := defaultMaxLineSize
-	if s.settingService.cfg != nil && s.settingService.cfg.Gateway.MaxLineSize > 0 {
-		maxLineSize = s.settingService.cfg.Gateway.MaxLineSize
+	if s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.MaxLineSize > 0 {
+		maxLineSize = s.settingService.cfg.Live().Gateway.MaxLineSize
 	}
 	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
 
@@ -2257,8 +2265,8 @@ func (s *AntigravityGatewayService) handleClaudeStreamToNonStreaming(c *gin.Cont
 
 	// 上游数据间隔超时保护（防止上游挂起长期占用连接）
 	streamInterval := time.Duration(0)
-	if s.settingService.cfg != nil && s.settingService.cfg.Gateway.StreamDataIntervalTimeout > 0 {
-		streamInterval = time.Duration(s.settingService.cfg.Gateway.StreamDataIntervalTimeout) * time.Second
+	if s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.StreamDataIntervalTimeout > 0 {
+		streamInterval = time.Duration(s.settingService.cfg.Live().Gateway.StreamDataIntervalTimeout) * time.Second
 	}
 	var intervalTicker *time.Ticker
 	if streamInterval > 0 {
@@ -2392,8 +2400,8 @@ func (s *AntigravityGatewayService) handleClaudeStreamingResponse(c *gin.Context
 	// 使用 Scanner 并限制单行大小，避免 ReadString 无上限导致 OOM
 	scanner := bufio.NewScanner(resp.Body)
 	maxLineSize := defaultMaxLineSize
-	if s.settingService.cfg != nil && s.settingService.cfg.Gateway.MaxLineSize > 0 {
-		maxLineSize = s.settingService.cfg.Gateway.MaxLineSize
+	if s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.MaxLineSize > 0 {
+		maxLineSize = s.settingService.cfg.Live().Gateway.MaxLineSize
 	}
 	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
 
@@ -2442,8 +2450,8 @@ func (s *AntigravityGatewayService) handleClaudeStreamingResponse(c *gin.Context
 	defer close(done)
 
 	streamInterval := time.Duration(0)
-	if s.settingService.cfg != nil && s.settingService.cfg.Gateway.StreamDataIntervalTimeout > 0 {
-		streamInterval = time.Duration(s.settingService.cfg.Gateway.StreamDataIntervalTimeout) * time.Second
+	if s.settingService.cfg != nil && s.settingService.cfg.Live().Gateway.StreamDataIntervalTimeout > 0 {
+		streamInterval = time.Duration(s.settingService.cfg.Live().Gateway.StreamDataIntervalTimeout) * time.Second
 	}
 	var intervalTicker *time.Ticker
 	if streamInterval > 0 {
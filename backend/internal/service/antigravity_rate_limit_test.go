@@ -103,7 +103,7 @@ func TestAntigravityRetryLoop_URLFallback_UsesLatestSuccess(t *testing.T) {
 		body:         []byte(`{"input":"test"}`),
 		quotaScope:   AntigravityQuotaScopeClaude,
 		httpUpstream: upstream,
-		handleError: func(ctx context.Context, prefix string, account *Account, statusCode int, headers http.Header, body []byte, quotaScope AntigravityQuotaScope) {
+		handleError: func(ctx context.Context, prefix string, account *Account, requestedModel string, statusCode int, headers http.Header, body []byte, quotaScope AntigravityQuotaScope) {
 			handleErrorCalled = true
 		},
 	})
@@ -130,7 +130,7 @@ func TestAntigravityHandleUpstreamError_UsesScopeLimitWhenEnabled(t *testing.T)
 	account := &Account{ID: 9, Name: "acc-9", Platform: PlatformAntigravity}
 
 	body := buildGeminiRateLimitBody("3s")
-	svc.handleUpstreamError(context.Background(), "[test]", account, http.StatusTooManyRequests, http.Header{}, body, AntigravityQuotaScopeClaude)
+	svc.handleUpstreamError(context.Background(), "[test]", account, "claude-sonnet-4-5", http.StatusTooManyRequests, http.Header{}, body, AntigravityQuotaScopeClaude)
 
 	require.Len(t, repo.scopeCalls, 1)
 	require.Empty(t, repo.rateCalls)
@@ -147,7 +147,7 @@ func TestAntigravityHandleUpstreamError_UsesAccountLimitWhenScopeDisabled(t *tes
 	account := &Account{ID: 10, Name: "acc-10", Platform: PlatformAntigravity}
 
 	body := buildGeminiRateLimitBody("2s")
-	svc.handleUpstreamError(context.Background(), "[test]", account, http.StatusTooManyRequests, http.Header{}, body, AntigravityQuotaScopeClaude)
+	svc.handleUpstreamError(context.Background(), "[test]", account, "claude-sonnet-4-5", http.StatusTooManyRequests, http.Header{}, body, AntigravityQuotaScopeClaude)
 
 	require.Len(t, repo.rateCalls, 1)
 	require.Empty(t, repo.scopeCalls)
@@ -3,20 +3,55 @@ package service
 import "time"
 
 type APIKey struct {
-	ID          int64
-	UserID      int64
-	Key         string
+	ID     int64
+	UserID int64
+	// Key 明文 Key：仅在创建响应与认证路径上持有，不落库、不在列表中返回
+	Key string
+	// KeyHash 入库的 HMAC-SHA256 哈希；KeyPrefix 为列表展示用的前缀
+	KeyHash   string
+	KeyPrefix string
+	// LegacyKey 在线迁移完成前旧记录的明文 Key，仅用于定位认证缓存，不得对外展示
+	LegacyKey   string
 	Name        string
 	GroupID     *int64
 	Status      string
 	IPWhitelist []string
 	IPBlacklist []string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	User        *User
-	Group       *Group
+	// 专属账号（非空时覆盖用户级配置）
+	DedicatedAccountIDs   []int64
+	DedicatedAccountsOnly bool
+	// 所属组织：非空时消费从组织余额扣除，订阅分组使用组织所有者的订阅
+	OrganizationID *int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	User           *User
+	Group          *Group
+	Organization   *Organization
+	// SubscriptionOverage 请求级状态：订阅额度用尽并按分组超额策略放行时由认证中间件设置，不落库
+	SubscriptionOverage *SubscriptionOverage
+}
+
+// Credential 返回定位该 Key 认证缓存所需的凭据
+func (k *APIKey) Credential() APIKeyCredential {
+	return APIKeyCredential{ID: k.ID, KeyHash: k.KeyHash, LegacyKey: k.LegacyKey}
 }
 
 func (k *APIKey) IsActive() bool {
 	return k.Status == StatusActive
 }
+
+// IsOrganizationKey 是否为组织 Key
+func (k *APIKey) IsOrganizationKey() bool {
+	return k != nil && k.OrganizationID != nil && *k.OrganizationID > 0
+}
+
+// BillingUserID 返回订阅归属的用户：组织 Key 使用组织所有者，否则为 Key 所属用户
+func (k *APIKey) BillingUserID() int64 {
+	if k.IsOrganizationKey() && k.Organization != nil && k.Organization.OwnerUserID > 0 {
+		return k.Organization.OwnerUserID
+	}
+	if k.User != nil {
+		return k.User.ID
+	}
+	return k.UserID
+}
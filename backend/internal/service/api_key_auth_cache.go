@@ -2,14 +2,28 @@ package service
 
 // APIKeyAuthSnapshot API Key 认证缓存快照（仅包含认证所需字段）
 type APIKeyAuthSnapshot struct {
-	APIKeyID    int64                    `json:"api_key_id"`
-	UserID      int64                    `json:"user_id"`
-	GroupID     *int64                   `json:"group_id,omitempty"`
-	Status      string                   `json:"status"`
-	IPWhitelist []string                 `json:"ip_whitelist,omitempty"`
-	IPBlacklist []string                 `json:"ip_blacklist,omitempty"`
-	User        APIKeyAuthUserSnapshot   `json:"user"`
-	Group       *APIKeyAuthGroupSnapshot `json:"group,omitempty"`
+	APIKeyID    int64    `json:"api_key_id"`
+	UserID      int64    `json:"user_id"`
+	GroupID     *int64   `json:"group_id,omitempty"`
+	Status      string   `json:"status"`
+	IPWhitelist []string `json:"ip_whitelist,omitempty"`
+	IPBlacklist []string `json:"ip_blacklist,omitempty"`
+	// 专属账号（非空时覆盖用户级配置）
+	DedicatedAccountIDs   []int64                  `json:"dedicated_account_ids,omitempty"`
+	DedicatedAccountsOnly bool                     `json:"dedicated_accounts_only,omitempty"`
+	User                  APIKeyAuthUserSnapshot   `json:"user"`
+	Group                 *APIKeyAuthGroupSnapshot `json:"group,omitempty"`
+	// 组织 Key：消费从组织余额扣除
+	OrganizationID *int64                          `json:"organization_id,omitempty"`
+	Organization   *APIKeyAuthOrganizationSnapshot `json:"organization,omitempty"`
+}
+
+// APIKeyAuthOrganizationSnapshot 组织快照
+type APIKeyAuthOrganizationSnapshot struct {
+	ID          int64   `json:"id"`
+	OwnerUserID int64   `json:"owner_user_id"`
+	Status      string  `json:"status"`
+	Balance     float64 `json:"balance"`
 }
 
 // APIKeyAuthUserSnapshot 用户快照
@@ -19,6 +33,9 @@ type APIKeyAuthUserSnapshot struct {
 	Role        string  `json:"role"`
 	Balance     float64 `json:"balance"`
 	Concurrency int     `json:"concurrency"`
+
+	DedicatedAccountIDs   []int64 `json:"dedicated_account_ids,omitempty"`
+	DedicatedAccountsOnly bool    `json:"dedicated_accounts_only,omitempty"`
 }
 
 // APIKeyAuthGroupSnapshot 分组快照
@@ -42,6 +59,29 @@ type APIKeyAuthGroupSnapshot struct {
 	// Only anthropic groups use these fields; others may leave them empty.
 	ModelRouting        map[string][]int64 `json:"model_routing,omitempty"`
 	ModelRoutingEnabled bool               `json:"model_routing_enabled"`
+
+	// SchedulingStrategy selects the account ordering strategy in gateway selection.
+	SchedulingStrategy string `json:"scheduling_strategy,omitempty"`
+
+	// ContentPolicy is evaluated by gateway handlers before forwarding.
+	ContentPolicy *ContentPolicy `json:"content_policy,omitempty"`
+
+	// RewriteRules are applied by gateway handlers before forwarding.
+	RewriteRules []RequestRewriteRule `json:"rewrite_rules,omitempty"`
+
+	// ModelAliases are resolved by gateway handlers into ordered upstream targets.
+	ModelAliases []ModelAlias `json:"model_aliases,omitempty"`
+
+	// Overage policy is applied by the auth middleware when subscription limits are exceeded.
+	OveragePolicy         string  `json:"overage_policy,omitempty"`
+	OverageRateMultiplier float64 `json:"overage_rate_multiplier,omitempty"`
+	OverageGroupID        *int64  `json:"overage_group_id,omitempty"`
+
+	// UsageQuotas are enforced by the billing eligibility check per request model.
+	UsageQuotas []SubscriptionQuota `json:"usage_quotas,omitempty"`
+
+	// TrafficSplit assigns requests to canary / A-B arms before scheduling.
+	TrafficSplit *TrafficSplit `json:"traffic_split,omitempty"`
 }
 
 // APIKeyAuthCacheEntry 缓存条目，支持负缓存
@@ -2,8 +2,6 @@ package service
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -108,9 +106,9 @@ func (s *APIKeyService) StartAuthCacheInvalidationSubscriber(ctx context.Context
 	}
 }
 
+// authCacheKey 认证缓存键即 Key 的入库哈希
 func (s *APIKeyService) authCacheKey(key string) string {
-	sum := sha256.Sum256([]byte(key))
-	return hex.EncodeToString(sum[:])
+	return s.hashKey(key)
 }
 
 func (s *APIKeyService) getAuthCacheEntry(ctx context.Context, cacheKey string) (*APIKeyAuthCacheEntry, bool) {
@@ -168,7 +166,7 @@ func (s *APIKeyService) deleteAuthCache(ctx context.Context, cacheKey string) {
 }
 
 func (s *APIKeyService) loadAuthCacheEntry(ctx context.Context, key, cacheKey string) (*APIKeyAuthCacheEntry, error) {
-	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, key)
+	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, cacheKey, key)
 	if err != nil {
 		if errors.Is(err, ErrAPIKeyNotFound) {
 			entry := &APIKeyAuthCacheEntry{NotFound: true}
@@ -207,18 +205,22 @@ func (s *APIKeyService) snapshotFromAPIKey(apiKey *APIKey) *APIKeyAuthSnapshot {
 		return nil
 	}
 	snapshot := &APIKeyAuthSnapshot{
-		APIKeyID:    apiKey.ID,
-		UserID:      apiKey.UserID,
-		GroupID:     apiKey.GroupID,
-		Status:      apiKey.Status,
-		IPWhitelist: apiKey.IPWhitelist,
-		IPBlacklist: apiKey.IPBlacklist,
+		APIKeyID:              apiKey.ID,
+		UserID:                apiKey.UserID,
+		GroupID:               apiKey.GroupID,
+		Status:                apiKey.Status,
+		IPWhitelist:           apiKey.IPWhitelist,
+		IPBlacklist:           apiKey.IPBlacklist,
+		DedicatedAccountIDs:   apiKey.DedicatedAccountIDs,
+		DedicatedAccountsOnly: apiKey.DedicatedAccountsOnly,
 		User: APIKeyAuthUserSnapshot{
-			ID:          apiKey.User.ID,
-			Status:      apiKey.User.Status,
-			Role:        apiKey.User.Role,
-			Balance:     apiKey.User.Balance,
-			Concurrency: apiKey.User.Concurrency,
+			ID:                    apiKey.User.ID,
+			Status:                apiKey.User.Status,
+			Role:                  apiKey.User.Role,
+			Balance:               apiKey.User.Balance,
+			Concurrency:           apiKey.User.Concurrency,
+			DedicatedAccountIDs:   apiKey.User.DedicatedAccountIDs,
+			DedicatedAccountsOnly: apiKey.User.DedicatedAccountsOnly,
 		},
 	}
 	if apiKey.Group != nil {
@@ -239,6 +241,28 @@ func (s *APIKeyService) snapshotFromAPIKey(apiKey *APIKey) *APIKeyAuthSnapshot {
 			FallbackGroupID:     apiKey.Group.FallbackGroupID,
 			ModelRouting:        apiKey.Group.ModelRouting,
 			ModelRoutingEnabled: apiKey.Group.ModelRoutingEnabled,
+			SchedulingStrategy:  apiKey.Group.SchedulingStrategy,
+			ContentPolicy:       apiKey.Group.ContentPolicy,
+			RewriteRules:        apiKey.Group.RewriteRules,
+			ModelAliases:        apiKey.Group.ModelAliases,
+
+			OveragePolicy:         apiKey.Group.OveragePolicy,
+			OverageRateMultiplier: apiKey.Group.OverageRateMultiplier,
+			OverageGroupID:        apiKey.Group.OverageGroupID,
+
+			UsageQuotas:  apiKey.Group.UsageQuotas,
+			TrafficSplit: apiKey.Group.TrafficSplit,
+		}
+	}
+	if apiKey.OrganizationID != nil {
+		snapshot.OrganizationID = apiKey.OrganizationID
+		if apiKey.Organization != nil {
+			snapshot.Organization = &APIKeyAuthOrganizationSnapshot{
+				ID:          apiKey.Organization.ID,
+				OwnerUserID: apiKey.Organization.OwnerUserID,
+				Status:      apiKey.Organization.Status,
+				Balance:     apiKey.Organization.Balance,
+			}
 		}
 	}
 	return snapshot
@@ -249,19 +273,23 @@ func (s *APIKeyService) snapshotToAPIKey(key string, snapshot *APIKeyAuthSnapsho
 		return nil
 	}
 	apiKey := &APIKey{
-		ID:          snapshot.APIKeyID,
-		UserID:      snapshot.UserID,
-		GroupID:     snapshot.GroupID,
-		Key:         key,
-		Status:      snapshot.Status,
-		IPWhitelist: snapshot.IPWhitelist,
-		IPBlacklist: snapshot.IPBlacklist,
+		ID:                    snapshot.APIKeyID,
+		UserID:                snapshot.UserID,
+		GroupID:               snapshot.GroupID,
+		Key:                   key,
+		Status:                snapshot.Status,
+		IPWhitelist:           snapshot.IPWhitelist,
+		IPBlacklist:           snapshot.IPBlacklist,
+		DedicatedAccountIDs:   snapshot.DedicatedAccountIDs,
+		DedicatedAccountsOnly: snapshot.DedicatedAccountsOnly,
 		User: &User{
-			ID:          snapshot.User.ID,
-			Status:      snapshot.User.Status,
-			Role:        snapshot.User.Role,
-			Balance:     snapshot.User.Balance,
-			Concurrency: snapshot.User.Concurrency,
+			ID:                    snapshot.User.ID,
+			Status:                snapshot.User.Status,
+			Role:                  snapshot.User.Role,
+			Balance:               snapshot.User.Balance,
+			Concurrency:           snapshot.User.Concurrency,
+			DedicatedAccountIDs:   snapshot.User.DedicatedAccountIDs,
+			DedicatedAccountsOnly: snapshot.User.DedicatedAccountsOnly,
 		},
 	}
 	if snapshot.Group != nil {
@@ -283,6 +311,28 @@ func (s *APIKeyService) snapshotToAPIKey(key string, snapshot *APIKeyAuthSnapsho
 			FallbackGroupID:     snapshot.Group.FallbackGroupID,
 			ModelRouting:        snapshot.Group.ModelRouting,
 			ModelRoutingEnabled: snapshot.Group.ModelRoutingEnabled,
+			SchedulingStrategy:  snapshot.Group.SchedulingStrategy,
+			ContentPolicy:       snapshot.Group.ContentPolicy,
+			RewriteRules:        snapshot.Group.RewriteRules,
+			ModelAliases:        snapshot.Group.ModelAliases,
+
+			OveragePolicy:         snapshot.Group.OveragePolicy,
+			OverageRateMultiplier: snapshot.Group.OverageRateMultiplier,
+			OverageGroupID:        snapshot.Group.OverageGroupID,
+
+			UsageQuotas:  snapshot.Group.UsageQuotas,
+			TrafficSplit: snapshot.Group.TrafficSplit,
+		}
+	}
+	if snapshot.OrganizationID != nil {
+		apiKey.OrganizationID = snapshot.OrganizationID
+		if snapshot.Organization != nil {
+			apiKey.Organization = &Organization{
+				ID:          snapshot.Organization.ID,
+				OwnerUserID: snapshot.Organization.OwnerUserID,
+				Status:      snapshot.Organization.Status,
+				Balance:     snapshot.Organization.Balance,
+			}
 		}
 	}
 	return apiKey
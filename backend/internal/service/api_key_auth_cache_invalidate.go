@@ -11,16 +11,25 @@ func (s *APIKeyService) InvalidateAuthCacheByKey(ctx context.Context, key string
 	s.deleteAuthCache(ctx, cacheKey)
 }
 
+// InvalidateAuthCacheByCredential 按存储凭据清除 API Key 的认证缓存
+func (s *APIKeyService) InvalidateAuthCacheByCredential(ctx context.Context, cred APIKeyCredential) {
+	cacheKey := s.credentialCacheKey(cred)
+	if cacheKey == "" {
+		return
+	}
+	s.deleteAuthCache(ctx, cacheKey)
+}
+
 // InvalidateAuthCacheByUserID 清除用户相关的 API Key 认证缓存
 func (s *APIKeyService) InvalidateAuthCacheByUserID(ctx context.Context, userID int64) {
 	if userID <= 0 {
 		return
 	}
-	keys, err := s.apiKeyRepo.ListKeysByUserID(ctx, userID)
+	creds, err := s.apiKeyRepo.ListCredentialsByUserID(ctx, userID)
 	if err != nil {
 		return
 	}
-	s.deleteAuthCacheByKeys(ctx, keys)
+	s.deleteAuthCacheByCredentials(ctx, creds)
 }
 
 // InvalidateAuthCacheByGroupID 清除分组相关的 API Key 认证缓存
@@ -28,21 +37,18 @@ func (s *APIKeyService) InvalidateAuthCacheByGroupID(ctx context.Context, groupI
 	if groupID <= 0 {
 		return
 	}
-	keys, err := s.apiKeyRepo.ListKeysByGroupID(ctx, groupID)
+	creds, err := s.apiKeyRepo.ListCredentialsByGroupID(ctx, groupID)
 	if err != nil {
 		return
 	}
-	s.deleteAuthCacheByKeys(ctx, keys)
+	s.deleteAuthCacheByCredentials(ctx, creds)
 }
 
-func (s *APIKeyService) deleteAuthCacheByKeys(ctx context.Context, keys []string) {
-	if len(keys) == 0 {
+func (s *APIKeyService) deleteAuthCacheByCredentials(ctx context.Context, creds []APIKeyCredential) {
+	if len(creds) == 0 {
 		return
 	}
-	for _, key := range keys {
-		if key == "" {
-			continue
-		}
-		s.deleteAuthCache(ctx, s.authCacheKey(key))
+	for _, cred := range creds {
+		s.InvalidateAuthCacheByCredential(ctx, cred)
 	}
 }
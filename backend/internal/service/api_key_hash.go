@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+const (
+	// apiKeyHashMigrationBatchSize 在线迁移每批处理的明文 Key 数量
+	apiKeyHashMigrationBatchSize = 500
+	apiKeyHashMigrationTimeout   = 30 * time.Second
+)
+
+// APIKeyCredential 定位 API Key 认证缓存所需的凭据。
+// 已迁移记录只有 KeyHash；在线迁移完成前的旧记录只有明文 LegacyKey。
+type APIKeyCredential struct {
+	ID        int64
+	KeyHash   string
+	LegacyKey string
+}
+
+// apiKeyHashSecret 返回配置的哈希密钥；未配置时为空，配置加载阶段已拒绝启动
+func apiKeyHashSecret(cfg *config.Config) []byte {
+	if cfg == nil {
+		return nil
+	}
+	return []byte(cfg.APIKeyHash.Secret)
+}
+
+// hashKey 计算 Key 的入库哈希，同时作为认证缓存键，认证时无需额外查询
+func (s *APIKeyService) hashKey(key string) string {
+	mac := hmac.New(sha256.New, s.keyHashSecret)
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// credentialCacheKey 返回凭据对应的认证缓存键；旧记录按明文现算哈希
+func (s *APIKeyService) credentialCacheKey(cred APIKeyCredential) string {
+	if cred.KeyHash != "" {
+		return cred.KeyHash
+	}
+	if cred.LegacyKey != "" {
+		return s.hashKey(cred.LegacyKey)
+	}
+	return ""
+}
+
+// APIKeyDisplayPrefix 返回列表展示用的 Key 前缀；较短的自定义 Key 只保留 4 位，避免泄露过多信息
+func APIKeyDisplayPrefix(key string) string {
+	n := 8
+	if len(key) < 32 {
+		n = 4
+	}
+	if n > len(key) {
+		n = len(key)
+	}
+	return key[:n]
+}
+
+// APIKeyHashMigrationService 在线把旧版明文存储的 API Key 迁移为哈希存储。
+// 启动后在后台分批执行，全部迁移完成即退出；迁移期间认证同时匹配哈希与明文，不影响服务。
+type APIKeyHashMigrationService struct {
+	apiKeyRepo    APIKeyRepository
+	apiKeyService *APIKeyService
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewAPIKeyHashMigrationService 创建 API Key 哈希迁移服务
+func NewAPIKeyHashMigrationService(apiKeyRepo APIKeyRepository, apiKeyService *APIKeyService) *APIKeyHashMigrationService {
+	return &APIKeyHashMigrationService{
+		apiKeyRepo:    apiKeyRepo,
+		apiKeyService: apiKeyService,
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// Start 启动后台迁移
+func (s *APIKeyHashMigrationService) Start() {
+	if s == nil || s.apiKeyRepo == nil || s.apiKeyService == nil {
+		return
+	}
+	// 迁移不可逆：没有配置密钥时保留明文，等待配置后再迁移
+	if len(s.apiKeyService.keyHashSecret) == 0 {
+		log.Printf("[APIKeyHashMigration] api_key_hash.secret not configured; plaintext keys are left untouched")
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		migrated, err := s.MigrateAll()
+		if err != nil {
+			log.Printf("[APIKeyHashMigration] stopped after %d keys: %v", migrated, err)
+			return
+		}
+		if migrated > 0 {
+			log.Printf("[APIKeyHashMigration] migrated %d plaintext keys", migrated)
+		}
+	}()
+}
+
+// Stop 停止后台迁移，未处理的 Key 在下次启动时继续
+func (s *APIKeyHashMigrationService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+}
+
+// MigrateAll 分批迁移全部明文 Key，返回迁移数量
+func (s *APIKeyHashMigrationService) MigrateAll() (int, error) {
+	var afterID int64
+	migrated := 0
+	for {
+		select {
+		case <-s.stopCh:
+			return migrated, nil
+		default:
+		}
+
+		n, lastID, err := s.migrateBatch(afterID)
+		migrated += n
+		if err != nil {
+			return migrated, err
+		}
+		if lastID == 0 {
+			return migrated, nil
+		}
+		afterID = lastID
+	}
+}
+
+// migrateBatch 处理 afterID 之后的一批 Key，返回迁移数量与本批最大 ID（无数据时为 0）
+func (s *APIKeyHashMigrationService) migrateBatch(afterID int64) (int, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), apiKeyHashMigrationTimeout)
+	defer cancel()
+
+	legacy, err := s.apiKeyRepo.ListLegacyKeys(ctx, afterID, apiKeyHashMigrationBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(legacy) == 0 {
+		return 0, 0, nil
+	}
+
+	migrated := 0
+	var lastID int64
+	for _, cred := range legacy {
+		lastID = cred.ID
+		if cred.LegacyKey == "" {
+			continue
+		}
+		// 哈希与迁移前的认证缓存键一致，迁移后无需失效缓存
+		keyHash := s.apiKeyService.hashKey(cred.LegacyKey)
+		if err := s.apiKeyRepo.MigrateKeyHash(ctx, cred.ID, cred.LegacyKey, keyHash, APIKeyDisplayPrefix(cred.LegacyKey)); err != nil {
+			return migrated, lastID, err
+		}
+		migrated++
+	}
+	return migrated, lastID, nil
+}
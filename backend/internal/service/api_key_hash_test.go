@@ -0,0 +1,78 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type legacyKeyRepoStub struct {
+	APIKeyRepository
+	legacy   []APIKeyCredential
+	migrated map[int64][3]string
+}
+
+func (s *legacyKeyRepoStub) ListLegacyKeys(ctx context.Context, afterID int64, limit int) ([]APIKeyCredential, error) {
+	out := make([]APIKeyCredential, 0, limit)
+	for _, cred := range s.legacy {
+		if cred.ID > afterID && len(out) < limit {
+			out = append(out, cred)
+		}
+	}
+	return out, nil
+}
+
+func (s *legacyKeyRepoStub) MigrateKeyHash(ctx context.Context, id int64, legacyKey, keyHash, keyPrefix string) error {
+	s.migrated[id] = [3]string{legacyKey, keyHash, keyPrefix}
+	return nil
+}
+
+func TestAPIKeyService_HashKeyUsesConfiguredSecret(t *testing.T) {
+	defaultSvc := NewAPIKeyService(nil, nil, nil, nil, nil, &config.Config{})
+	keyedSvc := NewAPIKeyService(nil, nil, nil, nil, nil, &config.Config{APIKeyHash: config.APIKeyHashConfig{Secret: "s3cret"}})
+
+	hash := keyedSvc.hashKey("sk-abc")
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, keyedSvc.hashKey("sk-abc"))
+	require.NotEqual(t, hash, defaultSvc.hashKey("sk-abc"))
+	require.Equal(t, hash, keyedSvc.authCacheKey("sk-abc"), "cache key must equal stored hash")
+	require.Equal(t, hash, keyedSvc.credentialCacheKey(APIKeyCredential{LegacyKey: "sk-abc"}))
+}
+
+func TestAPIKeyDisplayPrefix(t *testing.T) {
+	require.Equal(t, "sk-0123a", APIKeyDisplayPrefix("sk-0123abcdef0123abcdef0123abcdef0123"))
+	require.Equal(t, "cust", APIKeyDisplayPrefix("custom-key-123456"))
+	require.Equal(t, "ab", APIKeyDisplayPrefix("ab"))
+}
+
+func TestAPIKeyHashMigration_MigratesAllBatches(t *testing.T) {
+	repo := &legacyKeyRepoStub{migrated: map[int64][3]string{}}
+	for i := int64(1); i <= apiKeyHashMigrationBatchSize+3; i++ {
+		repo.legacy = append(repo.legacy, APIKeyCredential{ID: i, LegacyKey: "sk-legacy-key-0000000000000000000" + string(rune('a'+i%26))})
+	}
+	apiKeySvc := NewAPIKeyService(repo, nil, nil, nil, nil, &config.Config{APIKeyHash: config.APIKeyHashConfig{Secret: "s3cret"}})
+	migration := NewAPIKeyHashMigrationService(repo, apiKeySvc)
+
+	migrated, err := migration.MigrateAll()
+	require.NoError(t, err)
+	require.Equal(t, len(repo.legacy), migrated)
+
+	got := repo.migrated[2]
+	require.Equal(t, repo.legacy[1].LegacyKey, got[0])
+	require.Equal(t, apiKeySvc.hashKey(got[0]), got[1])
+	require.Equal(t, "sk-legac", got[2])
+}
+
+func TestAPIKeyHashMigration_StartSkipsWithoutSecret(t *testing.T) {
+	repo := &legacyKeyRepoStub{migrated: map[int64][3]string{}, legacy: []APIKeyCredential{{ID: 1, LegacyKey: "sk-legacy"}}}
+	apiKeySvc := NewAPIKeyService(repo, nil, nil, nil, nil, &config.Config{})
+	migration := NewAPIKeyHashMigrationService(repo, apiKeySvc)
+
+	migration.Start()
+	migration.Stop()
+	require.Empty(t, repo.migrated, "plaintext keys must not be hashed without a configured secret")
+}
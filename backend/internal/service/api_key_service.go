@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -33,24 +34,28 @@ const (
 type APIKeyRepository interface {
 	Create(ctx context.Context, key *APIKey) error
 	GetByID(ctx context.Context, id int64) (*APIKey, error)
-	// GetKeyAndOwnerID 仅获取 API Key 的 key 与所有者 ID，用于删除等轻量场景
-	GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error)
-	GetByKey(ctx context.Context, key string) (*APIKey, error)
-	// GetByKeyForAuth 认证专用查询，返回最小字段集
-	GetByKeyForAuth(ctx context.Context, key string) (*APIKey, error)
+	// GetCredentialAndOwnerID 仅获取 API Key 的缓存凭据与所有者 ID，用于删除等轻量场景
+	GetCredentialAndOwnerID(ctx context.Context, id int64) (APIKeyCredential, int64, error)
+	// GetByKeyForAuth 认证专用查询，返回最小字段集；按哈希匹配，未迁移的旧记录按明文匹配
+	GetByKeyForAuth(ctx context.Context, keyHash, key string) (*APIKey, error)
 	Update(ctx context.Context, key *APIKey) error
 	Delete(ctx context.Context, id int64) error
 
 	ListByUserID(ctx context.Context, userID int64, params pagination.PaginationParams) ([]APIKey, *pagination.PaginationResult, error)
 	VerifyOwnership(ctx context.Context, userID int64, apiKeyIDs []int64) ([]int64, error)
 	CountByUserID(ctx context.Context, userID int64) (int64, error)
-	ExistsByKey(ctx context.Context, key string) (bool, error)
+	ExistsByKey(ctx context.Context, keyHash, key string) (bool, error)
 	ListByGroupID(ctx context.Context, groupID int64, params pagination.PaginationParams) ([]APIKey, *pagination.PaginationResult, error)
 	SearchAPIKeys(ctx context.Context, userID int64, keyword string, limit int) ([]APIKey, error)
 	ClearGroupIDByGroupID(ctx context.Context, groupID int64) (int64, error)
 	CountByGroupID(ctx context.Context, groupID int64) (int64, error)
-	ListKeysByUserID(ctx context.Context, userID int64) ([]string, error)
-	ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error)
+	ListCredentialsByUserID(ctx context.Context, userID int64) ([]APIKeyCredential, error)
+	ListCredentialsByGroupID(ctx context.Context, groupID int64) ([]APIKeyCredential, error)
+
+	// ListLegacyKeys 列出 afterID 之后仍以明文存储的 Key（含软删除记录），用于在线迁移
+	ListLegacyKeys(ctx context.Context, afterID int64, limit int) ([]APIKeyCredential, error)
+	// MigrateKeyHash 写入哈希与展示前缀并清除明文；仅当记录仍为 legacyKey 时生效
+	MigrateKeyHash(ctx context.Context, id int64, legacyKey, keyHash, keyPrefix string) error
 }
 
 // APIKeyCache defines cache operations for API key service
@@ -73,7 +78,7 @@ type APIKeyCache interface {
 
 // APIKeyAuthCacheInvalidator 提供认证缓存失效能力
 type APIKeyAuthCacheInvalidator interface {
-	InvalidateAuthCacheByKey(ctx context.Context, key string)
+	InvalidateAuthCacheByCredential(ctx context.Context, cred APIKeyCredential)
 	InvalidateAuthCacheByUserID(ctx context.Context, userID int64)
 	InvalidateAuthCacheByGroupID(ctx context.Context, groupID int64)
 }
@@ -85,6 +90,8 @@ type CreateAPIKeyRequest struct {
 	CustomKey   *string  `json:"custom_key"`   // 可选的自定义key
 	IPWhitelist []string `json:"ip_whitelist"` // IP 白名单
 	IPBlacklist []string `json:"ip_blacklist"` // IP 黑名单
+	// OrganizationID 创建组织 Key（需为组织成员），使用组织共享余额与订阅
+	OrganizationID *int64 `json:"organization_id"`
 }
 
 // UpdateAPIKeyRequest 更新API Key请求
@@ -102,11 +109,14 @@ type APIKeyService struct {
 	userRepo    UserRepository
 	groupRepo   GroupRepository
 	userSubRepo UserSubscriptionRepository
+	orgRepo     OrganizationRepository
 	cache       APIKeyCache
 	cfg         *config.Config
 	authCacheL1 *ristretto.Cache
 	authCfg     apiKeyAuthCacheConfig
 	authGroup   singleflight.Group
+	// keyHashSecret 计算 Key 入库哈希的 HMAC 密钥
+	keyHashSecret []byte
 }
 
 // NewAPIKeyService 创建API Key服务实例
@@ -125,11 +135,18 @@ func NewAPIKeyService(
 		userSubRepo: userSubRepo,
 		cache:       cache,
 		cfg:         cfg,
+
+		keyHashSecret: apiKeyHashSecret(cfg),
 	}
 	svc.initAuthCache(cfg)
 	return svc
 }
 
+// SetOrganizationRepository 注入组织仓储（可选，未注入时不允许创建组织 Key）
+func (s *APIKeyService) SetOrganizationRepository(orgRepo OrganizationRepository) {
+	s.orgRepo = orgRepo
+}
+
 // GenerateKey 生成随机API Key
 func (s *APIKeyService) GenerateKey() (string, error) {
 	// 生成32字节随机数据
@@ -210,6 +227,36 @@ func (s *APIKeyService) canUserBindGroup(ctx context.Context, user *User, group
 	return user.CanBindGroup(group.ID, group.IsExclusive)
 }
 
+// resolveOrganizationForKey 校验用户可以在组织下创建 Key，返回组织信息
+func (s *APIKeyService) resolveOrganizationForKey(ctx context.Context, userID, orgID int64) (*Organization, error) {
+	if s.orgRepo == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !org.IsActive() {
+		return nil, ErrOrganizationInactive
+	}
+	if _, err := s.orgRepo.GetMember(ctx, orgID, userID); err != nil {
+		if errors.Is(err, ErrOrganizationMemberNotFound) {
+			return nil, ErrOrganizationPermissionDenied
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
+// canBindGroupForKey 校验分组绑定权限；组织 Key 的订阅分组使用组织所有者的订阅
+func (s *APIKeyService) canBindGroupForKey(ctx context.Context, user *User, group *Group, org *Organization) bool {
+	if org != nil && group.IsSubscriptionType() {
+		_, err := s.userSubRepo.GetActiveByUserIDAndGroupID(ctx, org.OwnerUserID, group.ID)
+		return err == nil
+	}
+	return s.canUserBindGroup(ctx, user, group)
+}
+
 // Create 创建API Key
 func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIKeyRequest) (*APIKey, error) {
 	// 验证用户存在
@@ -232,6 +279,15 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 		}
 	}
 
+	// 验证组织成员身份（如果创建组织 Key）
+	var org *Organization
+	if req.OrganizationID != nil {
+		org, err = s.resolveOrganizationForKey(ctx, userID, *req.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// 验证分组权限（如果指定了分组）
 	if req.GroupID != nil {
 		group, err := s.groupRepo.GetByID(ctx, *req.GroupID)
@@ -240,7 +296,7 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 		}
 
 		// 检查用户是否可以绑定该分组
-		if !s.canUserBindGroup(ctx, user, group) {
+		if !s.canBindGroupForKey(ctx, user, group, org) {
 			return nil, ErrGroupNotAllowed
 		}
 	}
@@ -260,7 +316,7 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 		}
 
 		// 检查Key是否已存在
-		exists, err := s.apiKeyRepo.ExistsByKey(ctx, *req.CustomKey)
+		exists, err := s.apiKeyRepo.ExistsByKey(ctx, s.hashKey(*req.CustomKey), *req.CustomKey)
 		if err != nil {
 			return nil, fmt.Errorf("check key exists: %w", err)
 		}
@@ -280,15 +336,19 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 		}
 	}
 
-	// 创建API Key记录
+	// 创建API Key记录：只持久化哈希与展示前缀，明文仅在本次响应中返回
 	apiKey := &APIKey{
 		UserID:      userID,
 		Key:         key,
+		KeyHash:     s.hashKey(key),
+		KeyPrefix:   APIKeyDisplayPrefix(key),
 		Name:        req.Name,
 		GroupID:     req.GroupID,
 		Status:      StatusActive,
 		IPWhitelist: req.IPWhitelist,
 		IPBlacklist: req.IPBlacklist,
+
+		OrganizationID: req.OrganizationID,
 	}
 
 	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
@@ -370,7 +430,7 @@ func (s *APIKeyService) GetByKey(ctx context.Context, key string) (*APIKey, erro
 		}
 	}
 
-	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, key)
+	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, cacheKey, key)
 	if err != nil {
 		return nil, fmt.Errorf("get api key: %w", err)
 	}
@@ -421,7 +481,14 @@ func (s *APIKeyService) Update(ctx context.Context, id int64, userID int64, req
 			return nil, fmt.Errorf("get group: %w", err)
 		}
 
-		if !s.canUserBindGroup(ctx, user, group) {
+		var org *Organization
+		if apiKey.IsOrganizationKey() {
+			if org, err = s.resolveOrganizationForKey(ctx, userID, *apiKey.OrganizationID); err != nil {
+				return nil, err
+			}
+		}
+
+		if !s.canBindGroupForKey(ctx, user, group, org) {
 			return nil, ErrGroupNotAllowed
 		}
 
@@ -444,14 +511,14 @@ func (s *APIKeyService) Update(ctx context.Context, id int64, userID int64, req
 		return nil, fmt.Errorf("update api key: %w", err)
 	}
 
-	s.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	s.InvalidateAuthCacheByCredential(ctx, apiKey.Credential())
 
 	return apiKey, nil
 }
 
 // Delete 删除API Key
 func (s *APIKeyService) Delete(ctx context.Context, id int64, userID int64) error {
-	key, ownerID, err := s.apiKeyRepo.GetKeyAndOwnerID(ctx, id)
+	cred, ownerID, err := s.apiKeyRepo.GetCredentialAndOwnerID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("get api key: %w", err)
 	}
@@ -465,7 +532,7 @@ func (s *APIKeyService) Delete(ctx context.Context, id int64, userID int64) erro
 	if s.cache != nil {
 		_ = s.cache.DeleteCreateAttemptCount(ctx, userID)
 	}
-	s.InvalidateAuthCacheByKey(ctx, key)
+	s.InvalidateAuthCacheByCredential(ctx, cred)
 
 	if err := s.apiKeyRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("delete api key: %w", err)
@@ -474,6 +541,23 @@ func (s *APIKeyService) Delete(ctx context.Context, id int64, userID int64) erro
 	return nil
 }
 
+// Disable 由系统停用 API Key（如异常检测自动处置），不校验所有权
+func (s *APIKeyService) Disable(ctx context.Context, id int64) error {
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get api key: %w", err)
+	}
+	if apiKey.Status == StatusDisabled {
+		return nil
+	}
+	apiKey.Status = StatusDisabled
+	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		return fmt.Errorf("update api key: %w", err)
+	}
+	s.InvalidateAuthCacheByCredential(ctx, apiKey.Credential())
+	return nil
+}
+
 // ValidateKey 验证API Key是否有效（用于认证中间件）
 func (s *APIKeyService) ValidateKey(ctx context.Context, key string) (*APIKey, *User, error) {
 	// 获取API Key
@@ -18,8 +18,8 @@ import (
 
 type authRepoStub struct {
 	getByKeyForAuth   func(ctx context.Context, key string) (*APIKey, error)
-	listKeysByUserID  func(ctx context.Context, userID int64) ([]string, error)
-	listKeysByGroupID func(ctx context.Context, groupID int64) ([]string, error)
+	listKeysByUserID  func(ctx context.Context, userID int64) ([]APIKeyCredential, error)
+	listKeysByGroupID func(ctx context.Context, groupID int64) ([]APIKeyCredential, error)
 }
 
 func (s *authRepoStub) Create(ctx context.Context, key *APIKey) error {
@@ -30,15 +30,15 @@ func (s *authRepoStub) GetByID(ctx context.Context, id int64) (*APIKey, error) {
 	panic("unexpected GetByID call")
 }
 
-func (s *authRepoStub) GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
-	panic("unexpected GetKeyAndOwnerID call")
+func (s *authRepoStub) GetCredentialAndOwnerID(ctx context.Context, id int64) (APIKeyCredential, int64, error) {
+	panic("unexpected GetCredentialAndOwnerID call")
 }
 
 func (s *authRepoStub) GetByKey(ctx context.Context, key string) (*APIKey, error) {
 	panic("unexpected GetByKey call")
 }
 
-func (s *authRepoStub) GetByKeyForAuth(ctx context.Context, key string) (*APIKey, error) {
+func (s *authRepoStub) GetByKeyForAuth(ctx context.Context, keyHash, key string) (*APIKey, error) {
 	if s.getByKeyForAuth == nil {
 		panic("unexpected GetByKeyForAuth call")
 	}
@@ -65,7 +65,7 @@ func (s *authRepoStub) CountByUserID(ctx context.Context, userID int64) (int64,
 	panic("unexpected CountByUserID call")
 }
 
-func (s *authRepoStub) ExistsByKey(ctx context.Context, key string) (bool, error) {
+func (s *authRepoStub) ExistsByKey(ctx context.Context, keyHash, key string) (bool, error) {
 	panic("unexpected ExistsByKey call")
 }
 
@@ -85,20 +85,28 @@ func (s *authRepoStub) CountByGroupID(ctx context.Context, groupID int64) (int64
 	panic("unexpected CountByGroupID call")
 }
 
-func (s *authRepoStub) ListKeysByUserID(ctx context.Context, userID int64) ([]string, error) {
+func (s *authRepoStub) ListCredentialsByUserID(ctx context.Context, userID int64) ([]APIKeyCredential, error) {
 	if s.listKeysByUserID == nil {
-		panic("unexpected ListKeysByUserID call")
+		panic("unexpected ListCredentialsByUserID call")
 	}
 	return s.listKeysByUserID(ctx, userID)
 }
 
-func (s *authRepoStub) ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error) {
+func (s *authRepoStub) ListCredentialsByGroupID(ctx context.Context, groupID int64) ([]APIKeyCredential, error) {
 	if s.listKeysByGroupID == nil {
-		panic("unexpected ListKeysByGroupID call")
+		panic("unexpected ListCredentialsByGroupID call")
 	}
 	return s.listKeysByGroupID(ctx, groupID)
 }
 
+func (s *authRepoStub) ListLegacyKeys(ctx context.Context, afterID int64, limit int) ([]APIKeyCredential, error) {
+	panic("unexpected ListLegacyKeys call")
+}
+
+func (s *authRepoStub) MigrateKeyHash(ctx context.Context, id int64, legacyKey, keyHash, keyPrefix string) error {
+	panic("unexpected MigrateKeyHash call")
+}
+
 type authCacheStub struct {
 	getAuthCache   func(ctx context.Context, key string) (*APIKeyAuthCacheEntry, error)
 	setAuthKeys    []string
@@ -306,8 +314,8 @@ func TestAPIKeyService_GetByKey_UsesL1Cache(t *testing.T) {
 func TestAPIKeyService_InvalidateAuthCacheByUserID(t *testing.T) {
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		listKeysByUserID: func(ctx context.Context, userID int64) ([]string, error) {
-			return []string{"k1", "k2"}, nil
+		listKeysByUserID: func(ctx context.Context, userID int64) ([]APIKeyCredential, error) {
+			return []APIKeyCredential{{ID: 1, KeyHash: "hash-1"}, {ID: 2, LegacyKey: "k2"}}, nil
 		},
 	}
 	cfg := &config.Config{
@@ -319,14 +327,14 @@ func TestAPIKeyService_InvalidateAuthCacheByUserID(t *testing.T) {
 	svc := NewAPIKeyService(repo, nil, nil, nil, cache, cfg)
 
 	svc.InvalidateAuthCacheByUserID(context.Background(), 7)
-	require.Len(t, cache.deleteAuthKeys, 2)
+	require.Equal(t, []string{"hash-1", svc.authCacheKey("k2")}, cache.deleteAuthKeys)
 }
 
 func TestAPIKeyService_InvalidateAuthCacheByGroupID(t *testing.T) {
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		listKeysByGroupID: func(ctx context.Context, groupID int64) ([]string, error) {
-			return []string{"k1", "k2"}, nil
+		listKeysByGroupID: func(ctx context.Context, groupID int64) ([]APIKeyCredential, error) {
+			return []APIKeyCredential{{ID: 1, KeyHash: "hash-1"}, {ID: 2, KeyHash: "hash-2"}}, nil
 		},
 	}
 	cfg := &config.Config{
@@ -343,7 +351,7 @@ func TestAPIKeyService_InvalidateAuthCacheByGroupID(t *testing.T) {
 func TestAPIKeyService_InvalidateAuthCacheByKey(t *testing.T) {
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		listKeysByUserID: func(ctx context.Context, userID int64) ([]string, error) {
+		listKeysByUserID: func(ctx context.Context, userID int64) ([]APIKeyCredential, error) {
 			return nil, nil
 		},
 	}
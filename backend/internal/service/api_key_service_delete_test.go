@@ -20,12 +20,12 @@ import (
 // 用于隔离测试 APIKeyService.Delete 方法，避免依赖真实数据库。
 //
 // 设计说明：
-//   - apiKey/getByIDErr: 模拟 GetKeyAndOwnerID 返回的记录与错误
+//   - apiKey/getByIDErr: 模拟 GetCredentialAndOwnerID 返回的记录与错误
 //   - deleteErr: 模拟 Delete 返回的错误
 //   - deletedIDs: 记录被调用删除的 API Key ID，用于断言验证
 type apiKeyRepoStub struct {
-	apiKey     *APIKey // GetKeyAndOwnerID 的返回值
-	getByIDErr error   // GetKeyAndOwnerID 的错误返回值
+	apiKey     *APIKey // GetCredentialAndOwnerID 的返回值
+	getByIDErr error   // GetCredentialAndOwnerID 的错误返回值
 	deleteErr  error   // Delete 的错误返回值
 	deletedIDs []int64 // 记录已删除的 API Key ID 列表
 }
@@ -47,21 +47,21 @@ func (s *apiKeyRepoStub) GetByID(ctx context.Context, id int64) (*APIKey, error)
 	panic("unexpected GetByID call")
 }
 
-func (s *apiKeyRepoStub) GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
+func (s *apiKeyRepoStub) GetCredentialAndOwnerID(ctx context.Context, id int64) (APIKeyCredential, int64, error) {
 	if s.getByIDErr != nil {
-		return "", 0, s.getByIDErr
+		return APIKeyCredential{}, 0, s.getByIDErr
 	}
 	if s.apiKey != nil {
-		return s.apiKey.Key, s.apiKey.UserID, nil
+		return s.apiKey.Credential(), s.apiKey.UserID, nil
 	}
-	return "", 0, ErrAPIKeyNotFound
+	return APIKeyCredential{}, 0, ErrAPIKeyNotFound
 }
 
 func (s *apiKeyRepoStub) GetByKey(ctx context.Context, key string) (*APIKey, error) {
 	panic("unexpected GetByKey call")
 }
 
-func (s *apiKeyRepoStub) GetByKeyForAuth(ctx context.Context, key string) (*APIKey, error) {
+func (s *apiKeyRepoStub) GetByKeyForAuth(ctx context.Context, keyHash, key string) (*APIKey, error) {
 	panic("unexpected GetByKeyForAuth call")
 }
 
@@ -90,7 +90,7 @@ func (s *apiKeyRepoStub) CountByUserID(ctx context.Context, userID int64) (int64
 	panic("unexpected CountByUserID call")
 }
 
-func (s *apiKeyRepoStub) ExistsByKey(ctx context.Context, key string) (bool, error) {
+func (s *apiKeyRepoStub) ExistsByKey(ctx context.Context, keyHash, key string) (bool, error) {
 	panic("unexpected ExistsByKey call")
 }
 
@@ -110,12 +110,20 @@ func (s *apiKeyRepoStub) CountByGroupID(ctx context.Context, groupID int64) (int
 	panic("unexpected CountByGroupID call")
 }
 
-func (s *apiKeyRepoStub) ListKeysByUserID(ctx context.Context, userID int64) ([]string, error) {
-	panic("unexpected ListKeysByUserID call")
+func (s *apiKeyRepoStub) ListCredentialsByUserID(ctx context.Context, userID int64) ([]APIKeyCredential, error) {
+	panic("unexpected ListCredentialsByUserID call")
 }
 
-func (s *apiKeyRepoStub) ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error) {
-	panic("unexpected ListKeysByGroupID call")
+func (s *apiKeyRepoStub) ListCredentialsByGroupID(ctx context.Context, groupID int64) ([]APIKeyCredential, error) {
+	panic("unexpected ListCredentialsByGroupID call")
+}
+
+func (s *apiKeyRepoStub) ListLegacyKeys(ctx context.Context, afterID int64, limit int) ([]APIKeyCredential, error) {
+	panic("unexpected ListLegacyKeys call")
+}
+
+func (s *apiKeyRepoStub) MigrateKeyHash(ctx context.Context, id int64, legacyKey, keyHash, keyPrefix string) error {
+	panic("unexpected MigrateKeyHash call")
 }
 
 // apiKeyCacheStub 是 APIKeyCache 接口的测试桩实现。
@@ -178,14 +186,14 @@ func (s *apiKeyCacheStub) SubscribeAuthCacheInvalidation(ctx context.Context, ha
 
 // TestApiKeyService_Delete_OwnerMismatch 测试非所有者尝试删除时返回权限错误。
 // 预期行为：
-//   - GetKeyAndOwnerID 返回所有者 ID 为 1
+//   - GetCredentialAndOwnerID 返回所有者 ID 为 1
 //   - 调用者 userID 为 2（不匹配）
 //   - 返回 ErrInsufficientPerms 错误
 //   - Delete 方法不被调用
 //   - 缓存不被清除
 func TestApiKeyService_Delete_OwnerMismatch(t *testing.T) {
 	repo := &apiKeyRepoStub{
-		apiKey: &APIKey{ID: 10, UserID: 1, Key: "k"},
+		apiKey: &APIKey{ID: 10, UserID: 1, LegacyKey: "k"},
 	}
 	cache := &apiKeyCacheStub{}
 	svc := &APIKeyService{apiKeyRepo: repo, cache: cache}
@@ -199,14 +207,14 @@ func TestApiKeyService_Delete_OwnerMismatch(t *testing.T) {
 
 // TestApiKeyService_Delete_Success 测试所有者成功删除 API Key 的场景。
 // 预期行为：
-//   - GetKeyAndOwnerID 返回所有者 ID 为 7
+//   - GetCredentialAndOwnerID 返回所有者 ID 为 7
 //   - 调用者 userID 为 7（匹配）
 //   - Delete 成功执行
 //   - 缓存被正确清除（使用 ownerID）
 //   - 返回 nil 错误
 func TestApiKeyService_Delete_Success(t *testing.T) {
 	repo := &apiKeyRepoStub{
-		apiKey: &APIKey{ID: 42, UserID: 7, Key: "k"},
+		apiKey: &APIKey{ID: 42, UserID: 7, LegacyKey: "k"},
 	}
 	cache := &apiKeyCacheStub{}
 	svc := &APIKeyService{apiKeyRepo: repo, cache: cache}
@@ -220,7 +228,7 @@ func TestApiKeyService_Delete_Success(t *testing.T) {
 
 // TestApiKeyService_Delete_NotFound 测试删除不存在的 API Key 时返回正确的错误。
 // 预期行为：
-//   - GetKeyAndOwnerID 返回 ErrAPIKeyNotFound 错误
+//   - GetCredentialAndOwnerID 返回 ErrAPIKeyNotFound 错误
 //   - 返回 ErrAPIKeyNotFound 错误（被 fmt.Errorf 包装）
 //   - Delete 方法不被调用
 //   - 缓存不被清除
@@ -238,14 +246,14 @@ func TestApiKeyService_Delete_NotFound(t *testing.T) {
 
 // TestApiKeyService_Delete_DeleteFails 测试删除操作失败时的错误处理。
 // 预期行为：
-//   - GetKeyAndOwnerID 返回正确的所有者 ID
+//   - GetCredentialAndOwnerID 返回正确的所有者 ID
 //   - 所有权验证通过
 //   - 缓存被清除（在删除之前）
 //   - Delete 被调用但返回错误
 //   - 返回包含 "delete api key" 的错误信息
 func TestApiKeyService_Delete_DeleteFails(t *testing.T) {
 	repo := &apiKeyRepoStub{
-		apiKey:    &APIKey{ID: 42, UserID: 3, Key: "k"},
+		apiKey:    &APIKey{ID: 42, UserID: 3, LegacyKey: "k"},
 		deleteErr: errors.New("delete failed"),
 	}
 	cache := &apiKeyCacheStub{}
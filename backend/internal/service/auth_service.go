@@ -41,6 +41,7 @@ type JWTClaims struct {
 	Email        string `json:"email"`
 	Role         string `json:"role"`
 	TokenVersion int64  `json:"token_version"` // Used to invalidate tokens on password change
+	SessionID    string `json:"sid,omitempty"` // 登录会话 ID，为空表示会话机制上线前签发的旧 token
 	jwt.RegisteredClaims
 }
 
@@ -54,6 +55,7 @@ type AuthService struct {
 	emailQueueService *EmailQueueService
 	promoService      *PromoService
 	inviteService     *InviteService
+	sessionRevoker    AuthSessionRevoker
 }
 
 // NewAuthService 创建认证服务实例
@@ -79,13 +81,24 @@ func NewAuthService(
 	}
 }
 
+// SetSessionRevoker 注入会话吊销（AuthSessionService 依赖 AuthService，使用 setter 避免循环依赖）
+func (s *AuthService) SetSessionRevoker(revoker AuthSessionRevoker) {
+	s.sessionRevoker = revoker
+}
+
+// RegisterClientInfo 注册请求来源，用于邀请返佣的同 IP / 同设备检测
+type RegisterClientInfo struct {
+	IP       string
+	DeviceID string
+}
+
 // Register 用户注册，返回token和用户
 func (s *AuthService) Register(ctx context.Context, email, password string) (string, *User, error) {
-	return s.RegisterWithVerification(ctx, email, password, "", "", "")
+	return s.RegisterWithVerification(ctx, email, password, "", "", "", RegisterClientInfo{})
 }
 
 // RegisterWithVerification 用户注册（支持邮件验证和优惠码），返回token和用户
-func (s *AuthService) RegisterWithVerification(ctx context.Context, email, password, verifyCode, promoCode, inviteCode string) (string, *User, error) {
+func (s *AuthService) RegisterWithVerification(ctx context.Context, email, password, verifyCode, promoCode, inviteCode string, client RegisterClientInfo) (string, *User, error) {
 	// 检查是否开放注册（默认关闭：settingService 未配置时不允许注册）
 	if s.settingService == nil || !s.settingService.IsRegistrationEnabled(ctx) {
 		return "", nil, ErrRegDisabled
@@ -169,6 +182,15 @@ func (s *AuthService) RegisterWithVerification(ctx context.Context, email, passw
 		Concurrency:  defaultConcurrency,
 		Status:       StatusActive,
 	}
+	if ip := strings.TrimSpace(client.IP); ip != "" {
+		user.RegisterIP = &ip
+	}
+	if device := strings.TrimSpace(client.DeviceID); device != "" {
+		if len(device) > 128 {
+			device = device[:128]
+		}
+		user.RegisterDevice = &device
+	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		// 优先检查邮箱冲突错误（竞态条件下可能发生）
@@ -542,6 +564,11 @@ func isReservedEmail(email string) bool {
 
 // GenerateToken 生成JWT token
 func (s *AuthService) GenerateToken(user *User) (string, error) {
+	return s.generateToken(user, "")
+}
+
+// generateToken 生成 JWT token；sessionID 非空时 token 绑定到对应登录会话
+func (s *AuthService) generateToken(user *User, sessionID string) (string, error) {
 	now := time.Now()
 	expiresAt := now.Add(time.Duration(s.cfg.JWT.ExpireHour) * time.Hour)
 
@@ -550,6 +577,7 @@ func (s *AuthService) GenerateToken(user *User) (string, error) {
 		Email:        user.Email,
 		Role:         user.Role,
 		TokenVersion: user.TokenVersion,
+		SessionID:    sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -710,7 +738,7 @@ func (s *AuthService) RequestPasswordResetAsync(ctx context.Context, email, fron
 }
 
 // ResetPassword 重置密码
-// Security: Increments TokenVersion to invalidate all existing JWT tokens
+// Security: 吊销该用户的全部登录会话，已签发的 refresh token 随之失效
 func (s *AuthService) ResetPassword(ctx context.Context, email, token, newPassword string) error {
 	// Check if password reset is enabled
 	if !s.IsPasswordResetEnabled(ctx) {
@@ -755,6 +783,7 @@ func (s *AuthService) ResetPassword(ctx context.Context, email, token, newPasswo
 		log.Printf("[Auth] Database error updating password for user %d: %v", user.ID, err)
 		return ErrServiceUnavailable
 	}
+	revokeUserSessions(ctx, s.sessionRevoker, user.ID)
 
 	log.Printf("[Auth] Password reset successful for user: %s", email)
 	return nil
@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+var (
+	ErrAuthSessionNotFound = infraerrors.NotFound("AUTH_SESSION_NOT_FOUND", "login session not found")
+	ErrAuthSessionRevoked  = infraerrors.Unauthorized("SESSION_REVOKED", "login session has been revoked")
+	ErrRefreshTokenInvalid = infraerrors.Unauthorized("INVALID_REFRESH_TOKEN", "invalid refresh token")
+	ErrRefreshTokenReused  = infraerrors.Unauthorized("REFRESH_TOKEN_REUSED", "refresh token has already been used, session revoked")
+	ErrRefreshTokenRotated = infraerrors.Unauthorized("REFRESH_TOKEN_ROTATED", "refresh token was just rotated by another request")
+)
+
+const (
+	defaultRefreshExpireHour = 720
+	// authSessionTouchInterval 最近活跃时间的最小更新间隔，避免每个请求都写 Redis
+	authSessionTouchInterval = time.Minute
+	// refreshRotateGracePeriod 刚轮换掉的 refresh token 在宽限期内重放只拒绝不吊销，
+	// 兼容多个标签页同时刷新的竞争
+	refreshRotateGracePeriod = 30 * time.Second
+
+	maxSessionUserAgentLen = 512
+	maxSessionDeviceIDLen  = 128
+)
+
+// AuthSession 服务端登录会话，每次登录创建一个，保存在 Redis 中
+type AuthSession struct {
+	ID     string
+	UserID int64
+	// RefreshHash 当前有效 refresh token 的 SHA-256；PrevRefreshHash 为上一次轮换掉的值
+	RefreshHash     string
+	PrevRefreshHash string
+	RotatedAt       time.Time
+	IP              string // 登录时的 IP
+	LastIP          string
+	UserAgent       string
+	DeviceID        string
+	CreatedAt       time.Time
+	LastSeenAt      time.Time
+}
+
+// AuthClientInfo 登录请求的客户端信息，记录到会话用于设备列表展示
+type AuthClientInfo struct {
+	IP        string
+	UserAgent string
+	DeviceID  string
+}
+
+// AuthTokens 登录/刷新后下发给客户端的令牌
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int // access token 有效期（秒）
+	SessionID    string
+}
+
+// AuthSessionCache 登录会话存储
+type AuthSessionCache interface {
+	CreateSession(ctx context.Context, session *AuthSession, ttl time.Duration) error
+	// GetSession 会话不存在时返回 nil, nil
+	GetSession(ctx context.Context, sessionID string) (*AuthSession, error)
+	// RotateRefreshHash 仅当当前 refresh 哈希等于 oldHash 时替换为 newHash 并续期（含用户会话索引），返回是否替换成功
+	RotateRefreshHash(ctx context.Context, userID int64, sessionID, oldHash, newHash, ip string, now time.Time, ttl time.Duration) (bool, error)
+	// TouchSession 更新最近活跃时间与 IP，会话不存在时不做任何事
+	TouchSession(ctx context.Context, sessionID, ip string, now time.Time) error
+	DeleteSession(ctx context.Context, userID int64, sessionID string) error
+	ListSessionIDs(ctx context.Context, userID int64) ([]string, error)
+	RemoveSessionIDs(ctx context.Context, userID int64, sessionIDs ...string) error
+}
+
+// AuthSessionRevoker 吊销用户的全部登录会话，修改/重置密码时使用（由 AuthSessionService 实现）
+type AuthSessionRevoker interface {
+	RevokeAllSessions(ctx context.Context, userID int64, exceptSessionID string) (int, error)
+}
+
+// revokeUserSessions 密码变更后吊销全部会话；密码已写入，失败只记录日志
+func revokeUserSessions(ctx context.Context, revoker AuthSessionRevoker, userID int64) {
+	if revoker == nil {
+		return
+	}
+	if _, err := revoker.RevokeAllSessions(ctx, userID, ""); err != nil {
+		log.Printf("[AuthSession] Failed to revoke sessions after password change for user %d: %v", userID, err)
+	}
+}
+
+// AuthSessionService 登录会话管理：签发绑定会话的 token、轮换 refresh token、列出与吊销会话
+type AuthSessionService struct {
+	cache       AuthSessionCache
+	userRepo    UserRepository
+	authService *AuthService
+	cfg         *config.Config
+}
+
+// NewAuthSessionService 创建登录会话服务
+func NewAuthSessionService(cache AuthSessionCache, userRepo UserRepository, authService *AuthService, cfg *config.Config) *AuthSessionService {
+	return &AuthSessionService{
+		cache:       cache,
+		userRepo:    userRepo,
+		authService: authService,
+		cfg:         cfg,
+	}
+}
+
+func (s *AuthSessionService) refreshTTL() time.Duration {
+	hours := defaultRefreshExpireHour
+	if s.cfg != nil && s.cfg.JWT.RefreshExpireHour > 0 {
+		hours = s.cfg.JWT.RefreshExpireHour
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// IssueTokens 为已通过认证的用户创建登录会话并签发 access/refresh token
+func (s *AuthSessionService) IssueTokens(ctx context.Context, user *User, client AuthClientInfo) (*AuthTokens, error) {
+	sessionID, err := randomHexString(16)
+	if err != nil {
+		return nil, fmt.Errorf("generate session id: %w", err)
+	}
+	secret, err := randomHexString(32)
+	if err != nil {
+		return nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	now := time.Now()
+	session := &AuthSession{
+		ID:          sessionID,
+		UserID:      user.ID,
+		RefreshHash: hashRefreshSecret(secret),
+		IP:          client.IP,
+		LastIP:      client.IP,
+		UserAgent:   truncateString(client.UserAgent, maxSessionUserAgentLen),
+		DeviceID:    truncateString(client.DeviceID, maxSessionDeviceIDLen),
+		CreatedAt:   now,
+		LastSeenAt:  now,
+	}
+	if err := s.cache.CreateSession(ctx, session, s.refreshTTL()); err != nil {
+		log.Printf("[AuthSession] Failed to create session for user %d: %v", user.ID, err)
+		return nil, ErrServiceUnavailable
+	}
+	return s.buildTokens(user, sessionID, secret)
+}
+
+func (s *AuthSessionService) buildTokens(user *User, sessionID, secret string) (*AuthTokens, error) {
+	accessToken, err := s.authService.generateToken(user, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("generate token: %w", err)
+	}
+	return &AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: sessionID + "." + secret,
+		ExpiresIn:    s.cfg.JWT.ExpireHour * 3600,
+		SessionID:    sessionID,
+	}, nil
+}
+
+// Refresh 使用 refresh token 换取新的 access/refresh token。
+// refresh token 一次性有效：每次刷新都会轮换，已使用过的 token 再次出现视为泄露，整个会话立即吊销。
+func (s *AuthSessionService) Refresh(ctx context.Context, refreshToken string, client AuthClientInfo) (*AuthTokens, *User, error) {
+	sessionID, secret, ok := parseRefreshToken(refreshToken)
+	if !ok {
+		return nil, nil, ErrRefreshTokenInvalid
+	}
+
+	session, err := s.cache.GetSession(ctx, sessionID)
+	if err != nil {
+		log.Printf("[AuthSession] Failed to load session: %v", err)
+		return nil, nil, ErrServiceUnavailable
+	}
+	if session == nil {
+		return nil, nil, ErrRefreshTokenInvalid
+	}
+
+	now := time.Now()
+	presented := hashRefreshSecret(secret)
+	if !hashEqual(presented, session.RefreshHash) {
+		if hashEqual(presented, session.PrevRefreshHash) && now.Sub(session.RotatedAt) < refreshRotateGracePeriod {
+			return nil, nil, ErrRefreshTokenRotated
+		}
+		s.revoke(ctx, session)
+		return nil, nil, ErrRefreshTokenReused
+	}
+
+	user, err := s.userRepo.GetByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			s.revoke(ctx, session)
+			return nil, nil, ErrRefreshTokenInvalid
+		}
+		log.Printf("[AuthSession] Database error refreshing session: %v", err)
+		return nil, nil, ErrServiceUnavailable
+	}
+	if !user.IsActive() {
+		return nil, nil, ErrUserNotActive
+	}
+
+	newSecret, err := randomHexString(32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+	rotated, err := s.cache.RotateRefreshHash(ctx, session.UserID, sessionID, presented, hashRefreshSecret(newSecret), client.IP, now, s.refreshTTL())
+	if err != nil {
+		log.Printf("[AuthSession] Failed to rotate refresh token: %v", err)
+		return nil, nil, ErrServiceUnavailable
+	}
+	if !rotated {
+		// 并发请求已先一步完成轮换
+		return nil, nil, ErrRefreshTokenRotated
+	}
+
+	tokens, err := s.buildTokens(user, sessionID, newSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, user, nil
+}
+
+// ValidateAccessToken 校验 access token 绑定的会话仍然存在，并按间隔刷新最近活跃时间。
+// 会话机制上线前签发的 token 不含 sid，不做会话校验，待其自然过期。
+func (s *AuthSessionService) ValidateAccessToken(ctx context.Context, claims *JWTClaims, clientIP string) error {
+	if s == nil || claims == nil || claims.SessionID == "" {
+		return nil
+	}
+	session, err := s.cache.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		log.Printf("[AuthSession] Failed to load session: %v", err)
+		return ErrServiceUnavailable
+	}
+	if session == nil || session.UserID != claims.UserID {
+		return ErrAuthSessionRevoked
+	}
+
+	now := time.Now()
+	if now.Sub(session.LastSeenAt) >= authSessionTouchInterval {
+		if err := s.cache.TouchSession(ctx, session.ID, clientIP, now); err != nil {
+			log.Printf("[AuthSession] Failed to touch session: %v", err)
+		}
+	}
+	return nil
+}
+
+// ListSessions 列出用户的有效会话，按最近活跃时间倒序；顺带清理索引中已过期的会话
+func (s *AuthSessionService) ListSessions(ctx context.Context, userID int64) ([]AuthSession, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	ids, err := s.cache.ListSessionIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+
+	sessions := make([]AuthSession, 0, len(ids))
+	var stale []string
+	for _, id := range ids {
+		session, err := s.cache.GetSession(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get session: %w", err)
+		}
+		if session == nil || session.UserID != userID {
+			stale = append(stale, id)
+			continue
+		}
+		sessions = append(sessions, *session)
+	}
+	if len(stale) > 0 {
+		if err := s.cache.RemoveSessionIDs(ctx, userID, stale...); err != nil {
+			log.Printf("[AuthSession] Failed to prune stale sessions for user %d: %v", userID, err)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+	return sessions, nil
+}
+
+// RevokeSession 吊销用户自己的某个会话
+func (s *AuthSessionService) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	session, err := s.cache.GetSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+	if session == nil || session.UserID != userID {
+		return ErrAuthSessionNotFound
+	}
+	if err := s.cache.DeleteSession(ctx, userID, sessionID); err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	return nil
+}
+
+// RevokeAllSessions 吊销用户的全部会话，exceptSessionID 非空时保留该会话（用于"退出其他设备"）
+func (s *AuthSessionService) RevokeAllSessions(ctx context.Context, userID int64, exceptSessionID string) (int, error) {
+	ids, err := s.cache.ListSessionIDs(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("list sessions: %w", err)
+	}
+	revoked := 0
+	for _, id := range ids {
+		if id == exceptSessionID {
+			continue
+		}
+		if err := s.cache.DeleteSession(ctx, userID, id); err != nil {
+			return revoked, fmt.Errorf("delete session: %w", err)
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// Logout 注销当前会话；旧版无 sid 的 token 无服务端状态，直接返回
+func (s *AuthSessionService) Logout(ctx context.Context, userID int64, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	err := s.RevokeSession(ctx, userID, sessionID)
+	if errors.Is(err, ErrAuthSessionNotFound) {
+		return nil
+	}
+	return err
+}
+
+// ForceLogout 管理员强制用户下线：吊销全部会话，绑定这些会话的 access token 随即失效。
+// 会话机制上线前签发的 token 不含 sid，无法在服务端吊销，只能等待其自然过期。
+func (s *AuthSessionService) ForceLogout(ctx context.Context, userID int64) (int, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return 0, err
+	}
+	return s.RevokeAllSessions(ctx, userID, "")
+}
+
+func (s *AuthSessionService) revoke(ctx context.Context, session *AuthSession) {
+	if err := s.cache.DeleteSession(ctx, session.UserID, session.ID); err != nil {
+		log.Printf("[AuthSession] Failed to revoke session %s: %v", session.ID, err)
+	}
+}
+
+// parseRefreshToken 拆分 "<session_id>.<secret>" 格式的 refresh token
+func parseRefreshToken(token string) (string, string, bool) {
+	token = strings.TrimSpace(token)
+	if len(token) > maxTokenLength {
+		return "", "", false
+	}
+	sessionID, secret, ok := strings.Cut(token, ".")
+	if !ok || sessionID == "" || secret == "" {
+		return "", "", false
+	}
+	return sessionID, secret, true
+}
+
+func hashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashEqual(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,201 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type authSessionCacheStub struct {
+	sessions map[string]*AuthSession
+	index    map[int64]map[string]struct{}
+	// indexTTL 记录最近一次为用户索引设置的 TTL
+	indexTTL map[int64]time.Duration
+}
+
+func newAuthSessionCacheStub() *authSessionCacheStub {
+	return &authSessionCacheStub{
+		sessions: map[string]*AuthSession{},
+		index:    map[int64]map[string]struct{}{},
+		indexTTL: map[int64]time.Duration{},
+	}
+}
+
+func (s *authSessionCacheStub) CreateSession(ctx context.Context, session *AuthSession, ttl time.Duration) error {
+	cp := *session
+	s.sessions[session.ID] = &cp
+	if s.index[session.UserID] == nil {
+		s.index[session.UserID] = map[string]struct{}{}
+	}
+	s.index[session.UserID][session.ID] = struct{}{}
+	s.indexTTL[session.UserID] = ttl
+	return nil
+}
+
+func (s *authSessionCacheStub) GetSession(ctx context.Context, sessionID string) (*AuthSession, error) {
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *session
+	return &cp, nil
+}
+
+func (s *authSessionCacheStub) RotateRefreshHash(ctx context.Context, userID int64, sessionID, oldHash, newHash, ip string, now time.Time, ttl time.Duration) (bool, error) {
+	session, ok := s.sessions[sessionID]
+	if !ok || session.RefreshHash != oldHash {
+		return false, nil
+	}
+	session.PrevRefreshHash = oldHash
+	session.RefreshHash = newHash
+	session.RotatedAt = now
+	session.LastSeenAt = now
+	session.LastIP = ip
+	s.indexTTL[userID] = ttl
+	return true, nil
+}
+
+func (s *authSessionCacheStub) TouchSession(ctx context.Context, sessionID, ip string, now time.Time) error {
+	if session, ok := s.sessions[sessionID]; ok {
+		session.LastSeenAt = now
+		session.LastIP = ip
+	}
+	return nil
+}
+
+func (s *authSessionCacheStub) DeleteSession(ctx context.Context, userID int64, sessionID string) error {
+	delete(s.sessions, sessionID)
+	delete(s.index[userID], sessionID)
+	return nil
+}
+
+func (s *authSessionCacheStub) ListSessionIDs(ctx context.Context, userID int64) ([]string, error) {
+	ids := make([]string, 0, len(s.index[userID]))
+	for id := range s.index[userID] {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (s *authSessionCacheStub) RemoveSessionIDs(ctx context.Context, userID int64, sessionIDs ...string) error {
+	for _, id := range sessionIDs {
+		delete(s.index[userID], id)
+	}
+	return nil
+}
+
+type sessionUserRepoStub struct {
+	userRepoStub
+	updates int
+}
+
+func (s *sessionUserRepoStub) Update(ctx context.Context, user *User) error {
+	s.user = user
+	s.updates++
+	return nil
+}
+
+func newAuthSessionTestService(user *User) (*AuthSessionService, *authSessionCacheStub, *sessionUserRepoStub) {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", ExpireHour: 1, RefreshExpireHour: 24}}
+	repo := &sessionUserRepoStub{userRepoStub: userRepoStub{user: user}}
+	cache := newAuthSessionCacheStub()
+	authService := NewAuthService(repo, cfg, nil, nil, nil, nil, nil, nil)
+	return NewAuthSessionService(cache, repo, authService, cfg), cache, repo
+}
+
+func TestAuthSessionRefresh_RotatesAndDetectsReuse(t *testing.T) {
+	user := &User{ID: 1, Email: "a@test.com", Role: RoleUser, Status: StatusActive}
+	svc, cache, _ := newAuthSessionTestService(user)
+	ctx := context.Background()
+
+	issued, err := svc.IssueTokens(ctx, user, AuthClientInfo{IP: "1.1.1.1", UserAgent: "ua", DeviceID: "dev"})
+	require.NoError(t, err)
+	claims, err := svc.authService.ValidateToken(issued.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, issued.SessionID, claims.SessionID)
+	require.Equal(t, 3600, issued.ExpiresIn)
+
+	delete(cache.indexTTL, user.ID)
+	refreshed, _, err := svc.Refresh(ctx, issued.RefreshToken, AuthClientInfo{IP: "2.2.2.2"})
+	require.NoError(t, err)
+	require.Equal(t, issued.SessionID, refreshed.SessionID)
+	require.NotEqual(t, issued.RefreshToken, refreshed.RefreshToken)
+	require.Equal(t, "2.2.2.2", cache.sessions[issued.SessionID].LastIP)
+	// 轮换时同步续期用户会话索引，长期刷新的会话不会从索引中消失
+	require.Equal(t, 24*time.Hour, cache.indexTTL[user.ID])
+
+	// 刚轮换掉的 token 在宽限期内重放：拒绝但不吊销
+	_, _, err = svc.Refresh(ctx, issued.RefreshToken, AuthClientInfo{})
+	require.ErrorIs(t, err, ErrRefreshTokenRotated)
+	require.Contains(t, cache.sessions, issued.SessionID)
+
+	// 超过宽限期后重放视为泄露，整个会话被吊销
+	cache.sessions[issued.SessionID].RotatedAt = time.Now().Add(-time.Hour)
+	_, _, err = svc.Refresh(ctx, issued.RefreshToken, AuthClientInfo{})
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+	require.NotContains(t, cache.sessions, issued.SessionID)
+
+	_, _, err = svc.Refresh(ctx, refreshed.RefreshToken, AuthClientInfo{})
+	require.ErrorIs(t, err, ErrRefreshTokenInvalid)
+	require.ErrorIs(t, svc.ValidateAccessToken(ctx, claims, ""), ErrAuthSessionRevoked)
+}
+
+func TestAuthSessionRevokedOnPasswordChange(t *testing.T) {
+	user := &User{ID: 1, Status: StatusActive}
+	require.NoError(t, user.SetPassword("old-password"))
+	svc, cache, repo := newAuthSessionTestService(user)
+	userService := NewUserService(repo, nil)
+	userService.SetSessionRevoker(svc)
+	ctx := context.Background()
+
+	issued, err := svc.IssueTokens(ctx, user, AuthClientInfo{})
+	require.NoError(t, err)
+	_, err = svc.IssueTokens(ctx, user, AuthClientInfo{})
+	require.NoError(t, err)
+
+	require.NoError(t, userService.ChangePassword(ctx, 1, ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"}))
+	require.Empty(t, cache.sessions)
+	_, _, err = svc.Refresh(ctx, issued.RefreshToken, AuthClientInfo{})
+	require.ErrorIs(t, err, ErrRefreshTokenInvalid)
+
+	_, _, err = svc.Refresh(ctx, "malformed", AuthClientInfo{})
+	require.ErrorIs(t, err, ErrRefreshTokenInvalid)
+}
+
+func TestAuthSessionRevokeAndForceLogout(t *testing.T) {
+	user := &User{ID: 1, Status: StatusActive}
+	svc, cache, repo := newAuthSessionTestService(user)
+	ctx := context.Background()
+
+	first, err := svc.IssueTokens(ctx, user, AuthClientInfo{UserAgent: "first"})
+	require.NoError(t, err)
+	second, err := svc.IssueTokens(ctx, user, AuthClientInfo{UserAgent: "second"})
+	require.NoError(t, err)
+
+	sessions, err := svc.ListSessions(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	require.ErrorIs(t, svc.RevokeSession(ctx, 2, first.SessionID), ErrAuthSessionNotFound)
+	require.NoError(t, svc.RevokeSession(ctx, 1, first.SessionID))
+	require.NotContains(t, cache.sessions, first.SessionID)
+	require.Contains(t, cache.sessions, second.SessionID)
+
+	revoked, err := svc.ForceLogout(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, revoked)
+	// 强制下线只吊销会话，不回写用户行（避免覆盖并发的余额扣减）
+	require.Zero(t, repo.updates)
+	require.Empty(t, cache.sessions)
+}
+
+func TestAuthSessionValidate_AllowsLegacyTokenWithoutSession(t *testing.T) {
+	svc, _, _ := newAuthSessionTestService(&User{ID: 1, Status: StatusActive})
+	require.NoError(t, svc.ValidateAccessToken(context.Background(), &JWTClaims{UserID: 1}, ""))
+	require.ErrorIs(t, svc.ValidateAccessToken(context.Background(), &JWTClaims{UserID: 1, SessionID: "missing"}, ""), ErrAuthSessionRevoked)
+}
@@ -0,0 +1,938 @@
+package service
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// 备份归档格式：gzip 压缩的 JSON Lines。
+// 第一行为 BackupHeader，随后每行一条数据记录 {"table","row"}，最后一行为结束标记 {"end":true,"counts":{}}，
+// 缺少结束标记视为归档被截断。行数据为数据库原始列（to_jsonb），恢复时按外键关系重新分配 ID。
+const (
+	BackupFormatName    = "sub2api-backup"
+	BackupFormatVersion = 1
+
+	// MinBackupPassphraseLength 口令最小长度；口令用于加密账号凭证等敏感字段
+	MinBackupPassphraseLength = 8
+
+	backupSealedPrefix   = "bak:v1:"
+	backupCheckPlaintext = "sub2api-backup-check"
+	backupKDFArgon2id    = "argon2id"
+	backupMaxLineSize    = 64 << 20
+)
+
+var (
+	ErrBackupPassphraseTooShort = infraerrors.BadRequest("BACKUP_PASSPHRASE_TOO_SHORT", fmt.Sprintf("backup passphrase must be at least %d characters", MinBackupPassphraseLength))
+	ErrBackupInvalidArchive     = infraerrors.BadRequest("BACKUP_INVALID_ARCHIVE", "invalid or truncated backup archive")
+	ErrBackupWrongPassphrase    = infraerrors.BadRequest("BACKUP_WRONG_PASSPHRASE", "backup passphrase is incorrect")
+	ErrBackupTargetNotEmpty     = infraerrors.Conflict("BACKUP_TARGET_NOT_EMPTY", "restore requires an empty database")
+	ErrBackupAPIKeyHashMismatch = infraerrors.Conflict("BACKUP_API_KEY_HASH_MISMATCH", "api_key_hash.secret differs from the backed-up instance; restored API keys would not authenticate")
+)
+
+// BackupRepository 备份仓储：按表导出原始行，并在单个事务内写回
+type BackupRepository interface {
+	// SchemaVersion 最新已应用的迁移文件名（如 059_add_group_usage_quotas.sql）
+	SchemaVersion(ctx context.Context) (string, error)
+	CountRows(ctx context.Context, table string) (int64, error)
+	// DumpTable 按主键顺序逐行回调（数值以 json.Number 表示，避免精度损失）
+	DumpTable(ctx context.Context, table string, fn func(row map[string]any) error) error
+	// Restore 在事务内执行 fn，fn 返回错误时整体回滚
+	Restore(ctx context.Context, fn func(tx BackupRestoreTx) error) error
+}
+
+// BackupRestoreTx 恢复事务内的写操作
+type BackupRestoreTx interface {
+	// InsertRow 写入一行（仅写入 row 中存在的列，缺失列使用默认值）；returnID 为 true 时返回新主键
+	InsertRow(ctx context.Context, table string, row map[string]any, returnID bool) (int64, error)
+	// UpsertSetting 按 key 覆盖系统设置
+	UpsertSetting(ctx context.Context, row map[string]any) error
+	// SetColumn 更新单列（用于自引用外键的二次回填）
+	SetColumn(ctx context.Context, table string, id int64, column string, value any) error
+	// FindUserIDByEmail 查找未删除的同邮箱用户，不存在时返回 0
+	FindUserIDByEmail(ctx context.Context, email string) (int64, error)
+}
+
+// BackupHeader 归档头
+type BackupHeader struct {
+	Format           string    `json:"format"`
+	FormatVersion    int       `json:"format_version"`
+	AppVersion       string    `json:"app_version"`
+	SchemaVersion    string    `json:"schema_version"`
+	CreatedAt        time.Time `json:"created_at"`
+	IncludeUsageLogs bool      `json:"include_usage_logs"`
+	KDF              BackupKDF `json:"kdf"`
+	// Check 用口令密钥加密的固定串，恢复前校验口令
+	Check string `json:"check"`
+	// APIKeyHashCheck API Key 哈希密钥指纹；Key 只以 HMAC 形式入库，目标实例必须使用相同的 api_key_hash.secret
+	APIKeyHashCheck string `json:"api_key_hash_check"`
+}
+
+// BackupKDF 口令派生参数
+type BackupKDF struct {
+	Algorithm string `json:"algorithm"`
+	Salt      string `json:"salt"`
+	Time      uint32 `json:"time"`
+	MemoryKiB uint32 `json:"memory_kib"`
+	Threads   uint8  `json:"threads"`
+}
+
+// BackupExportOptions 导出选项
+type BackupExportOptions struct {
+	Passphrase       string
+	IncludeUsageLogs bool
+}
+
+// BackupSummary 导出/恢复结果
+type BackupSummary struct {
+	SchemaVersion string           `json:"schema_version"`
+	AppVersion    string           `json:"app_version"`
+	Counts        map[string]int64 `json:"counts"`
+	// Warnings 不影响恢复的提示（如无法迁移的 TOTP 密钥、丢弃的失效引用）
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type backupLine struct {
+	Table  string           `json:"table,omitempty"`
+	Row    map[string]any   `json:"row,omitempty"`
+	End    bool             `json:"end,omitempty"`
+	Counts map[string]int64 `json:"counts,omitempty"`
+}
+
+// backupTable 参与备份的表。按依赖顺序排列，恢复时被引用的表先写入。
+type backupTable struct {
+	name string
+	// hasID 是否有自增主键；关联表（复合主键）恢复时不分配新 ID
+	hasID bool
+	// refs 外键列 -> 被引用表
+	refs map[string]string
+	// jsonRefs JSON 列中的 ID 引用 -> 被引用表（ID 数组，或值为 ID 数组的对象）；失效 ID 直接丢弃
+	jsonRefs map[string]string
+	// jsonRemaps 结构不适用 jsonRefs 的 JSON 列（ID 嵌在对象数组中），使用专用映射函数
+	jsonRemaps map[string]backupJSONRemapper
+	// logCursors 记录 usage_logs.id 水位的列（如返佣游标），见 resolveLogCursors
+	logCursors []string
+	// usageLogs 仅在包含使用记录时导出
+	usageLogs bool
+}
+
+var backupTables = []backupTable{
+	{name: "settings"},
+	{name: "proxies", hasID: true},
+	{name: "accounts", hasID: true, refs: map[string]string{"proxy_id": "proxies"}},
+	{name: "groups", hasID: true,
+		refs:       map[string]string{"fallback_group_id": "groups", "overage_group_id": "groups"},
+		jsonRefs:   map[string]string{"model_routing": "accounts"},
+		jsonRemaps: map[string]backupJSONRemapper{"traffic_split": remapBackupTrafficSplit}},
+	{name: "account_groups", refs: map[string]string{"account_id": "accounts", "group_id": "groups"}},
+	{name: "users", hasID: true, jsonRefs: map[string]string{"dedicated_account_ids": "accounts"}},
+	{name: "user_allowed_groups", refs: map[string]string{"user_id": "users", "group_id": "groups"}},
+	{name: "webauthn_credentials", hasID: true, refs: map[string]string{"user_id": "users"}},
+	{name: "user_recovery_codes", hasID: true, refs: map[string]string{"user_id": "users"}},
+	{name: "user_invites", hasID: true,
+		refs:       map[string]string{"inviter_id": "users", "invitee_id": "users", "confirmed_by": "users"},
+		logCursors: []string{"commission_cursor"}},
+	{name: "invite_logs", hasID: true, refs: map[string]string{"invite_id": "user_invites", "inviter_id": "users", "invitee_id": "users", "admin_id": "users"}},
+	{name: "invite_commissions", hasID: true, refs: map[string]string{"invite_id": "user_invites", "inviter_id": "users", "invitee_id": "users"}},
+	{name: "commission_withdrawals", hasID: true, refs: map[string]string{"user_id": "users", "processed_by": "users"}},
+	{name: "organizations", hasID: true, refs: map[string]string{"owner_user_id": "users"}},
+	{name: "organization_members", hasID: true, refs: map[string]string{"organization_id": "organizations", "user_id": "users"}},
+	{name: "organization_invitations", hasID: true, refs: map[string]string{"organization_id": "organizations", "invited_by": "users", "accepted_user_id": "users"}},
+	{name: "api_keys", hasID: true,
+		refs:     map[string]string{"user_id": "users", "group_id": "groups", "organization_id": "organizations"},
+		jsonRefs: map[string]string{"dedicated_account_ids": "accounts"}},
+	{name: "user_subscriptions", hasID: true, refs: map[string]string{"user_id": "users", "group_id": "groups", "assigned_by": "users"}},
+	{name: "redeem_codes", hasID: true, refs: map[string]string{"group_id": "groups", "used_by": "users"}},
+	{name: "promo_codes", hasID: true},
+	{name: "promo_code_usages", hasID: true, refs: map[string]string{"promo_code_id": "promo_codes", "user_id": "users"}},
+	{name: "usage_logs", hasID: true, usageLogs: true, refs: map[string]string{
+		"user_id": "users", "api_key_id": "api_keys", "account_id": "accounts", "group_id": "groups", "subscription_id": "user_subscriptions",
+	}},
+}
+
+// backupEmptyCheckTables 恢复目标必须为空的表（users 允许保留安装向导创建的管理员，按邮箱合并）
+var backupEmptyCheckTables = []string{"proxies", "accounts", "groups", "user_invites", "invite_commissions", "commission_withdrawals", "organizations", "api_keys", "user_subscriptions", "redeem_codes", "promo_codes", "usage_logs"}
+
+// BackupService 实例备份与恢复
+type BackupService struct {
+	repo          BackupRepository
+	secretCipher  SecretCipher
+	totpEncryptor SecretEncryptor
+	keyHashSecret []byte
+	appVersion    string
+}
+
+// NewBackupService 创建备份服务；TOTP 密钥不可用时 TOTP 密钥不参与备份（恢复后需重新绑定）
+func NewBackupService(repo BackupRepository, secretCipher SecretCipher, totpEncryptor SecretEncryptor, cfg *config.Config, buildInfo BuildInfo) *BackupService {
+	// 未显式配置 totp.encryption_key 时密钥为进程内随机生成，无法跨实例迁移
+	if cfg != nil && !cfg.Totp.EncryptionKeyConfigured {
+		totpEncryptor = nil
+	}
+	return &BackupService{
+		repo:          repo,
+		secretCipher:  secretCipher,
+		totpEncryptor: totpEncryptor,
+		keyHashSecret: apiKeyHashSecret(cfg),
+		appVersion:    buildInfo.Version,
+	}
+}
+
+// apiKeyHashCheck 哈希密钥指纹（对固定串做 HMAC，不泄露密钥本身）
+func (s *BackupService) apiKeyHashCheck() string {
+	mac := hmac.New(sha256.New, s.keyHashSecret)
+	mac.Write([]byte(backupCheckPlaintext))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Export 导出实例数据到 w。敏感字段先用实例主密钥解密，再用口令派生密钥重新加密。
+func (s *BackupService) Export(ctx context.Context, w io.Writer, opts BackupExportOptions) (*BackupSummary, error) {
+	if len(opts.Passphrase) < MinBackupPassphraseLength {
+		return nil, ErrBackupPassphraseTooShort
+	}
+	schemaVersion, err := s.repo.SchemaVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+	kdf, err := newBackupKDF()
+	if err != nil {
+		return nil, err
+	}
+	sealer, err := newBackupSealer(opts.Passphrase, kdf)
+	if err != nil {
+		return nil, err
+	}
+	check, err := sealer.seal(backupCheckPlaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+	header := BackupHeader{
+		Format:           BackupFormatName,
+		FormatVersion:    BackupFormatVersion,
+		AppVersion:       s.appVersion,
+		SchemaVersion:    schemaVersion,
+		CreatedAt:        time.Now().UTC(),
+		IncludeUsageLogs: opts.IncludeUsageLogs,
+		KDF:              kdf,
+		Check:            check,
+		APIKeyHashCheck:  s.apiKeyHashCheck(),
+	}
+	if err := enc.Encode(header); err != nil {
+		return nil, err
+	}
+
+	summary := &BackupSummary{SchemaVersion: schemaVersion, AppVersion: s.appVersion, Counts: make(map[string]int64)}
+	for _, table := range backupTables {
+		if table.usageLogs && !opts.IncludeUsageLogs {
+			continue
+		}
+		err := s.repo.DumpTable(ctx, table.name, func(row map[string]any) error {
+			if err := s.sealRowSecrets(table.name, row, sealer, summary); err != nil {
+				return fmt.Errorf("%s: %w", table.name, err)
+			}
+			summary.Counts[table.name]++
+			return enc.Encode(backupLine{Table: table.name, Row: row})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", table.name, err)
+		}
+	}
+	if err := enc.Encode(backupLine{End: true, Counts: summary.Counts}); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// Restore 将归档恢复到空数据库。所有 ID 重新分配并按外键关系映射；
+// 归档 schema 版本不得高于当前实例（旧版本归档缺失的列使用默认值）。整个恢复在单个事务内完成。
+func (s *BackupService) Restore(ctx context.Context, r io.Reader, passphrase string) (*BackupSummary, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrBackupInvalidArchive.WithCause(err)
+	}
+	defer func() { _ = gz.Close() }()
+	reader := bufio.NewReaderSize(gz, 1<<20)
+
+	var header BackupHeader
+	if err := readBackupLine(reader, &header); err != nil {
+		return nil, err
+	}
+	if header.Format != BackupFormatName {
+		return nil, ErrBackupInvalidArchive
+	}
+	if header.FormatVersion > BackupFormatVersion {
+		return nil, infraerrors.Newf(http.StatusBadRequest, "BACKUP_UNSUPPORTED_FORMAT",
+			"backup format version %d is not supported by this build (max %d)", header.FormatVersion, BackupFormatVersion)
+	}
+	sealer, err := newBackupSealer(passphrase, header.KDF)
+	if err != nil {
+		return nil, err
+	}
+	if check, err := sealer.open(header.Check); err != nil || check != backupCheckPlaintext {
+		return nil, ErrBackupWrongPassphrase
+	}
+
+	if header.APIKeyHashCheck != "" && !hmac.Equal([]byte(header.APIKeyHashCheck), []byte(s.apiKeyHashCheck())) {
+		return nil, ErrBackupAPIKeyHashMismatch
+	}
+
+	schemaVersion, err := s.repo.SchemaVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+	if err := checkBackupSchemaVersion(header.SchemaVersion, schemaVersion); err != nil {
+		return nil, err
+	}
+	if err := s.ensureEmptyTarget(ctx); err != nil {
+		return nil, err
+	}
+
+	summary := &BackupSummary{SchemaVersion: header.SchemaVersion, AppVersion: header.AppVersion, Counts: make(map[string]int64)}
+	err = s.repo.Restore(ctx, func(tx BackupRestoreTx) error {
+		restorer := newBackupRestorer(s, tx, sealer, summary)
+		for {
+			var line backupLine
+			if err := readBackupLine(reader, &line); err != nil {
+				return err
+			}
+			if line.End {
+				return restorer.finish(ctx, line.Counts)
+			}
+			if err := restorer.restoreRow(ctx, line.Table, line.Row); err != nil {
+				return err
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// checkBackupSchemaVersion 迁移文件名以零填充序号开头，按字典序比较即可判断先后
+func checkBackupSchemaVersion(archive, current string) error {
+	if archive == "" {
+		return ErrBackupInvalidArchive
+	}
+	if archive > current {
+		return infraerrors.Newf(http.StatusConflict, "BACKUP_SCHEMA_TOO_NEW",
+			"backup schema %s is newer than this instance (%s); upgrade sub2api before restoring", archive, current)
+	}
+	return nil
+}
+
+func (s *BackupService) ensureEmptyTarget(ctx context.Context) error {
+	var nonEmpty []string
+	for _, table := range backupEmptyCheckTables {
+		n, err := s.repo.CountRows(ctx, table)
+		if err != nil {
+			return fmt.Errorf("count %s: %w", table, err)
+		}
+		if n > 0 {
+			nonEmpty = append(nonEmpty, fmt.Sprintf("%s=%d", table, n))
+		}
+	}
+	if n, err := s.repo.CountRows(ctx, "users"); err != nil {
+		return fmt.Errorf("count users: %w", err)
+	} else if n > 1 {
+		nonEmpty = append(nonEmpty, fmt.Sprintf("users=%d", n))
+	}
+	if len(nonEmpty) > 0 {
+		return ErrBackupTargetNotEmpty.WithMetadata(map[string]string{"tables": strings.Join(nonEmpty, ",")})
+	}
+	return nil
+}
+
+// ============================================
+// 敏感字段：导出时用口令密钥加密，恢复时用目标实例主密钥加密
+// ============================================
+
+func (s *BackupService) sealRowSecrets(table string, row map[string]any, sealer *backupSealer, summary *BackupSummary) error {
+	switch table {
+	case "accounts":
+		credentials, ok := row["credentials"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		if err := DecryptCredentialSecrets(s.secretCipher, credentials); err != nil {
+			return fmt.Errorf("decrypt account %v credentials: %w", row["id"], err)
+		}
+		for k, v := range credentials {
+			if str, ok := v.(string); ok && str != "" && IsCredentialSecretField(k) {
+				sealed, err := sealer.seal(str)
+				if err != nil {
+					return err
+				}
+				credentials[k] = sealed
+			}
+		}
+	case "proxies":
+		return s.sealStringColumn(row, "password", sealer)
+	case "settings":
+		if key, _ := row["key"].(string); IsEncryptedSettingKey(key) {
+			return s.sealStringColumn(row, "value", sealer)
+		}
+	case "api_keys":
+		return s.sealStringColumn(row, "key", sealer)
+	case "users":
+		secret, _ := row["totp_secret_encrypted"].(string)
+		if secret == "" {
+			return nil
+		}
+		if s.totpEncryptor == nil {
+			summary.Warnings = appendBackupWarning(summary.Warnings, "TOTP encryption key unavailable; exported users must re-enable two-factor authentication")
+			disableBackupTOTP(row)
+			return nil
+		}
+		plain, err := s.totpEncryptor.Decrypt(secret)
+		if err != nil {
+			summary.Warnings = appendBackupWarning(summary.Warnings, fmt.Sprintf("user %v: TOTP secret could not be decrypted and was dropped", row["id"]))
+			disableBackupTOTP(row)
+			return nil
+		}
+		sealed, err := sealer.seal(plain)
+		if err != nil {
+			return err
+		}
+		row["totp_secret_encrypted"] = sealed
+	}
+	return nil
+}
+
+func (s *BackupService) sealStringColumn(row map[string]any, column string, sealer *backupSealer) error {
+	value, _ := row[column].(string)
+	if value == "" {
+		return nil
+	}
+	plain, err := s.decryptSecret(value)
+	if err != nil {
+		return fmt.Errorf("decrypt %s: %w", column, err)
+	}
+	sealed, err := sealer.seal(plain)
+	if err != nil {
+		return err
+	}
+	row[column] = sealed
+	return nil
+}
+
+func (s *BackupService) decryptSecret(value string) (string, error) {
+	if s.secretCipher == nil {
+		return value, nil
+	}
+	return s.secretCipher.Decrypt(value)
+}
+
+func (s *BackupService) encryptSecret(value string) (string, error) {
+	if s.secretCipher == nil || value == "" {
+		return value, nil
+	}
+	return s.secretCipher.Encrypt(value)
+}
+
+func (s *BackupService) openRowSecrets(table string, row map[string]any, sealer *backupSealer, summary *BackupSummary) error {
+	switch table {
+	case "accounts":
+		credentials, ok := row["credentials"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		for k, v := range credentials {
+			if str, ok := v.(string); ok && isBackupSealed(str) {
+				plain, err := sealer.open(str)
+				if err != nil {
+					return fmt.Errorf("open account credentials: %w", err)
+				}
+				credentials[k] = plain
+			}
+		}
+		encrypted, err := EncryptCredentialSecrets(s.secretCipher, credentials)
+		if err != nil {
+			return err
+		}
+		row["credentials"] = encrypted
+	case "proxies":
+		return s.openStringColumn(row, "password", sealer, true)
+	case "settings":
+		if key, _ := row["key"].(string); IsEncryptedSettingKey(key) {
+			return s.openStringColumn(row, "value", sealer, true)
+		}
+	case "api_keys":
+		return s.openStringColumn(row, "key", sealer, false)
+	case "users":
+		secret, _ := row["totp_secret_encrypted"].(string)
+		if !isBackupSealed(secret) {
+			return nil
+		}
+		plain, err := sealer.open(secret)
+		if err != nil {
+			return fmt.Errorf("open totp secret: %w", err)
+		}
+		if s.totpEncryptor == nil {
+			summary.Warnings = appendBackupWarning(summary.Warnings, "TOTP encryption key unavailable; restored users must re-enable two-factor authentication")
+			disableBackupTOTP(row)
+			return nil
+		}
+		encrypted, err := s.totpEncryptor.Encrypt(plain)
+		if err != nil {
+			return err
+		}
+		row["totp_secret_encrypted"] = encrypted
+	}
+	return nil
+}
+
+func (s *BackupService) openStringColumn(row map[string]any, column string, sealer *backupSealer, encrypt bool) error {
+	value, _ := row[column].(string)
+	if !isBackupSealed(value) {
+		return nil
+	}
+	plain, err := sealer.open(value)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", column, err)
+	}
+	if encrypt {
+		if plain, err = s.encryptSecret(plain); err != nil {
+			return err
+		}
+	}
+	row[column] = plain
+	return nil
+}
+
+func disableBackupTOTP(row map[string]any) {
+	row["totp_secret_encrypted"] = nil
+	row["totp_enabled"] = false
+	row["totp_enabled_at"] = nil
+}
+
+func appendBackupWarning(warnings []string, warning string) []string {
+	for _, w := range warnings {
+		if w == warning {
+			return warnings
+		}
+	}
+	return append(warnings, warning)
+}
+
+// ============================================
+// 恢复：按表顺序写入并映射 ID
+// ============================================
+
+type backupRestorer struct {
+	svc     *BackupService
+	tx      BackupRestoreTx
+	sealer  *backupSealer
+	summary *BackupSummary
+
+	tableIndex map[string]int
+	current    int
+	// idMap 表名 -> 旧 ID -> 新 ID
+	idMap map[string]map[int64]int64
+	// deferred 自引用外键，整表写入后回填
+	deferred []backupDeferredRef
+	// logCursors usage_logs 水位列，全部写入后回填
+	logCursors []backupDeferredRef
+}
+
+type backupDeferredRef struct {
+	table  string
+	newID  int64
+	column string
+	oldRef int64
+}
+
+func newBackupRestorer(svc *BackupService, tx BackupRestoreTx, sealer *backupSealer, summary *BackupSummary) *backupRestorer {
+	index := make(map[string]int, len(backupTables))
+	for i, t := range backupTables {
+		index[t.name] = i
+	}
+	return &backupRestorer{
+		svc:        svc,
+		tx:         tx,
+		sealer:     sealer,
+		summary:    summary,
+		tableIndex: index,
+		current:    -1,
+		idMap:      make(map[string]map[int64]int64),
+	}
+}
+
+func (r *backupRestorer) restoreRow(ctx context.Context, table string, row map[string]any) error {
+	idx, ok := r.tableIndex[table]
+	if !ok || row == nil {
+		return ErrBackupInvalidArchive.WithMetadata(map[string]string{"table": table})
+	}
+	// 归档按依赖顺序写出，乱序意味着被引用的行可能尚未写入
+	if idx < r.current {
+		return ErrBackupInvalidArchive.WithMetadata(map[string]string{"table": table, "reason": "out of order"})
+	}
+	if idx > r.current {
+		if err := r.flushDeferred(ctx); err != nil {
+			return err
+		}
+		r.current = idx
+	}
+	spec := backupTables[idx]
+
+	if err := r.svc.openRowSecrets(table, row, r.sealer, r.summary); err != nil {
+		return fmt.Errorf("%s: %w", table, err)
+	}
+
+	if table == "settings" {
+		delete(row, "id")
+		if err := r.tx.UpsertSetting(ctx, row); err != nil {
+			return fmt.Errorf("restore settings: %w", err)
+		}
+		r.summary.Counts[table]++
+		return nil
+	}
+
+	var oldID int64
+	if spec.hasID {
+		id, ok := backupInt64(row["id"])
+		if !ok {
+			return ErrBackupInvalidArchive.WithMetadata(map[string]string{"table": table, "reason": "missing id"})
+		}
+		oldID = id
+		delete(row, "id")
+	}
+
+	var selfRefs []backupDeferredRef
+	for column, refTable := range spec.refs {
+		oldRef, ok := backupInt64(row[column])
+		if !ok {
+			continue
+		}
+		if refTable == table {
+			selfRefs = append(selfRefs, backupDeferredRef{table: table, column: column, oldRef: oldRef})
+			row[column] = nil
+			continue
+		}
+		newRef, ok := r.idMap[refTable][oldRef]
+		if !ok {
+			return fmt.Errorf("restore %s %d: %s references missing %s %d", table, oldID, column, refTable, oldRef)
+		}
+		row[column] = newRef
+	}
+	for column, refTable := range spec.jsonRefs {
+		row[column] = remapBackupJSONRefs(row[column], r.idMap[refTable])
+	}
+	for column, remap := range spec.jsonRemaps {
+		row[column] = remap(row[column], r.idMap)
+	}
+	var cursors []backupDeferredRef
+	for _, column := range spec.logCursors {
+		if oldRef, ok := backupInt64(row[column]); ok && oldRef > 0 {
+			cursors = append(cursors, backupDeferredRef{table: table, column: column, oldRef: oldRef})
+		}
+		row[column] = 0
+	}
+
+	// 安装向导创建的管理员按邮箱合并，保留目标实例的登录信息
+	if table == "users" {
+		if email, _ := row["email"].(string); email != "" && row["deleted_at"] == nil {
+			existing, err := r.tx.FindUserIDByEmail(ctx, email)
+			if err != nil {
+				return err
+			}
+			if existing > 0 {
+				r.mapID(table, oldID, existing)
+				r.summary.Warnings = append(r.summary.Warnings, fmt.Sprintf("user %s already exists; kept the existing account", email))
+				return nil
+			}
+		}
+	}
+
+	newID, err := r.tx.InsertRow(ctx, table, row, spec.hasID)
+	if err != nil {
+		return fmt.Errorf("restore %s %d: %w", table, oldID, err)
+	}
+	if spec.hasID {
+		r.mapID(table, oldID, newID)
+		for _, ref := range selfRefs {
+			ref.newID = newID
+			r.deferred = append(r.deferred, ref)
+		}
+		for _, ref := range cursors {
+			ref.newID = newID
+			r.logCursors = append(r.logCursors, ref)
+		}
+	}
+	r.summary.Counts[table]++
+	return nil
+}
+
+func (r *backupRestorer) mapID(table string, oldID, newID int64) {
+	m := r.idMap[table]
+	if m == nil {
+		m = make(map[int64]int64)
+		r.idMap[table] = m
+	}
+	m[oldID] = newID
+}
+
+func (r *backupRestorer) flushDeferred(ctx context.Context) error {
+	for _, ref := range r.deferred {
+		newRef, ok := r.idMap[ref.table][ref.oldRef]
+		if !ok {
+			r.summary.Warnings = append(r.summary.Warnings, fmt.Sprintf("%s %d: dropped %s reference to missing id %d", ref.table, ref.newID, ref.column, ref.oldRef))
+			continue
+		}
+		if err := r.tx.SetColumn(ctx, ref.table, ref.newID, ref.column, newRef); err != nil {
+			return fmt.Errorf("restore %s.%s: %w", ref.table, ref.column, err)
+		}
+	}
+	r.deferred = nil
+	return nil
+}
+
+// finish 校验结束标记中的行数，防止归档被截断或拼接
+func (r *backupRestorer) finish(ctx context.Context, expected map[string]int64) error {
+	if err := r.flushDeferred(ctx); err != nil {
+		return err
+	}
+	if err := r.resolveLogCursors(ctx); err != nil {
+		return err
+	}
+	for table, n := range expected {
+		if got := r.summary.Counts[table]; got != n {
+			// 合并到已有用户的行不计入写入数
+			if table == "users" && got <= n {
+				continue
+			}
+			return ErrBackupInvalidArchive.WithMetadata(map[string]string{"table": table, "expected": strconv.FormatInt(n, 10), "restored": strconv.FormatInt(got, 10)})
+		}
+	}
+	return nil
+}
+
+// resolveLogCursors 将 usage_logs 水位映射为不超过原值的最大已恢复日志的新 ID。
+// 使用记录按 ID 顺序写入，新旧 ID 单调对应；未包含使用记录时水位保持为 0，恢复后的新日志正常计入。
+func (r *backupRestorer) resolveLogCursors(ctx context.Context) error {
+	logIDs := r.idMap["usage_logs"]
+	if len(r.logCursors) == 0 || len(logIDs) == 0 {
+		r.logCursors = nil
+		return nil
+	}
+	oldIDs := make([]int64, 0, len(logIDs))
+	for id := range logIDs {
+		oldIDs = append(oldIDs, id)
+	}
+	sort.Slice(oldIDs, func(i, j int) bool { return oldIDs[i] < oldIDs[j] })
+	for _, ref := range r.logCursors {
+		// 第一个大于原水位的位置，前一个即不超过原水位的最大日志
+		idx := sort.Search(len(oldIDs), func(i int) bool { return oldIDs[i] > ref.oldRef })
+		if idx == 0 {
+			continue
+		}
+		if err := r.tx.SetColumn(ctx, ref.table, ref.newID, ref.column, logIDs[oldIDs[idx-1]]); err != nil {
+			return fmt.Errorf("restore %s.%s: %w", ref.table, ref.column, err)
+		}
+	}
+	r.logCursors = nil
+	return nil
+}
+
+// remapBackupJSONRefs 映射 JSON 列中的 ID：支持 ID 数组与 {key: [ID...]} 对象，失效 ID 丢弃
+func remapBackupJSONRefs(value any, ids map[int64]int64) any {
+	switch v := value.(type) {
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, item := range v {
+			if old, ok := backupInt64(item); ok {
+				if newID, ok := ids[old]; ok {
+					out = append(out, newID)
+				}
+			}
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = remapBackupJSONRefs(item, ids)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// backupJSONRemapper 映射 JSON 列中的 ID 引用，idMap 为各表的旧 ID -> 新 ID
+type backupJSONRemapper func(value any, idMap map[string]map[int64]int64) any
+
+// remapBackupTrafficSplit 映射 traffic_split.arms[].account_ids，保留实验组的其余字段；失效 ID 丢弃
+func remapBackupTrafficSplit(value any, idMap map[string]map[int64]int64) any {
+	split, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	arms, ok := split["arms"].([]any)
+	if !ok {
+		return value
+	}
+	out := make(map[string]any, len(split))
+	for k, v := range split {
+		out[k] = v
+	}
+	mappedArms := make([]any, 0, len(arms))
+	for _, item := range arms {
+		arm, ok := item.(map[string]any)
+		if !ok {
+			mappedArms = append(mappedArms, item)
+			continue
+		}
+		mapped := make(map[string]any, len(arm))
+		for k, v := range arm {
+			mapped[k] = v
+		}
+		if ids, ok := arm["account_ids"]; ok {
+			if remapped, _ := remapBackupJSONRefs(ids, idMap["accounts"]).([]any); len(remapped) > 0 {
+				mapped["account_ids"] = remapped
+			} else {
+				delete(mapped, "account_ids")
+			}
+		}
+		mappedArms = append(mappedArms, mapped)
+	}
+	out["arms"] = mappedArms
+	return out
+}
+
+func backupInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	case float64:
+		return int64(n), true
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func readBackupLine(r *bufio.Reader, v any) error {
+	line, err := r.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		// 超长行（大型凭证/JSON 字段）逐段拼接
+		buf := append([]byte(nil), line...)
+		for errors.Is(err, bufio.ErrBufferFull) && len(buf) < backupMaxLineSize {
+			line, err = r.ReadSlice('\n')
+			buf = append(buf, line...)
+		}
+		line = buf
+	}
+	if err != nil && !(errors.Is(err, io.EOF) && len(line) > 0) {
+		return ErrBackupInvalidArchive.WithCause(err)
+	}
+	dec := json.NewDecoder(strings.NewReader(string(line)))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return ErrBackupInvalidArchive.WithCause(err)
+	}
+	return nil
+}
+
+// ============================================
+// 口令加密
+// ============================================
+
+type backupSealer struct {
+	aead cipher.AEAD
+}
+
+func newBackupKDF() (BackupKDF, error) {
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return BackupKDF{}, fmt.Errorf("generate salt: %w", err)
+	}
+	return BackupKDF{
+		Algorithm: backupKDFArgon2id,
+		Salt:      base64.RawStdEncoding.EncodeToString(salt),
+		Time:      3,
+		MemoryKiB: 64 * 1024,
+		Threads:   4,
+	}, nil
+}
+
+func newBackupSealer(passphrase string, kdf BackupKDF) (*backupSealer, error) {
+	if len(passphrase) < MinBackupPassphraseLength {
+		return nil, ErrBackupPassphraseTooShort
+	}
+	if kdf.Algorithm != backupKDFArgon2id || kdf.Time == 0 || kdf.MemoryKiB == 0 || kdf.Threads == 0 {
+		return nil, ErrBackupInvalidArchive
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(kdf.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, ErrBackupInvalidArchive
+	}
+	key := argon2.IDKey([]byte(passphrase), salt, kdf.Time, kdf.MemoryKiB, kdf.Threads, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &backupSealer{aead: aead}, nil
+}
+
+func (s *backupSealer) seal(plaintext string) (string, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return backupSealedPrefix + base64.RawStdEncoding.EncodeToString(s.aead.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+}
+
+func (s *backupSealer) open(value string) (string, error) {
+	if !isBackupSealed(value) {
+		return "", errors.New("value is not sealed")
+	}
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, backupSealedPrefix))
+	if err != nil {
+		return "", err
+	}
+	if len(data) < s.aead.NonceSize() {
+		return "", errors.New("sealed value too short")
+	}
+	plain, err := s.aead.Open(nil, data[:s.aead.NonceSize()], data[s.aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func isBackupSealed(value string) bool {
+	return strings.HasPrefix(value, backupSealedPrefix)
+}
@@ -0,0 +1,282 @@
+//go:build unit
+
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type backupTestCipher struct{ keyID string }
+
+func (c backupTestCipher) Enabled() bool       { return true }
+func (c backupTestCipher) ActiveKeyID() string { return c.keyID }
+func (c backupTestCipher) Encrypt(plaintext string) (string, error) {
+	return EncryptedSecretPrefix + c.keyID + ":" + plaintext, nil
+}
+func (c backupTestCipher) Decrypt(value string) (string, error) {
+	if !IsEncryptedSecret(value) {
+		return value, nil
+	}
+	rest := strings.TrimPrefix(value, EncryptedSecretPrefix)
+	return rest[strings.IndexByte(rest, ':')+1:], nil
+}
+func (c backupTestCipher) NeedsReencrypt(value string) bool { return false }
+
+// backupRepoStub 内存版备份仓储：tables 为导出数据，inserted 记录恢复写入（新 ID 从 100 起）
+type backupRepoStub struct {
+	schema   string
+	tables   map[string][]map[string]any
+	counts   map[string]int64
+	inserted map[string][]map[string]any
+	nextID   int64
+	emails   map[string]int64
+}
+
+func (r *backupRepoStub) SchemaVersion(ctx context.Context) (string, error) { return r.schema, nil }
+func (r *backupRepoStub) CountRows(ctx context.Context, table string) (int64, error) {
+	return r.counts[table], nil
+}
+func (r *backupRepoStub) DumpTable(ctx context.Context, table string, fn func(row map[string]any) error) error {
+	for _, row := range r.tables[table] {
+		// 模拟 to_jsonb 解码：数值为 json.Number
+		raw, _ := json.Marshal(row)
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var copied map[string]any
+		_ = dec.Decode(&copied)
+		if err := fn(copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (r *backupRepoStub) Restore(ctx context.Context, fn func(tx BackupRestoreTx) error) error {
+	return fn(r)
+}
+func (r *backupRepoStub) InsertRow(ctx context.Context, table string, row map[string]any, returnID bool) (int64, error) {
+	if r.inserted == nil {
+		r.inserted = make(map[string][]map[string]any)
+	}
+	var id int64
+	if returnID {
+		r.nextID++
+		id = 100 + r.nextID
+		row["id"] = id
+	}
+	r.inserted[table] = append(r.inserted[table], row)
+	return id, nil
+}
+func (r *backupRepoStub) UpsertSetting(ctx context.Context, row map[string]any) error {
+	_, err := r.InsertRow(ctx, "settings", row, false)
+	return err
+}
+func (r *backupRepoStub) SetColumn(ctx context.Context, table string, id int64, column string, value any) error {
+	for _, row := range r.inserted[table] {
+		if row["id"] == id {
+			row[column] = value
+		}
+	}
+	return nil
+}
+func (r *backupRepoStub) FindUserIDByEmail(ctx context.Context, email string) (int64, error) {
+	return r.emails[email], nil
+}
+
+func newBackupSource() *backupRepoStub {
+	return &backupRepoStub{
+		schema: "059_add_group_usage_quotas.sql",
+		tables: map[string][]map[string]any{
+			"settings": {
+				{"id": 1, "key": SettingKeySMTPPassword, "value": "enc:v1:old:smtp-secret"},
+				{"id": 2, "key": "site_name", "value": "Sub2API"},
+			},
+			"proxies":  {{"id": 7, "name": "p", "password": "enc:v1:old:proxy-pass"}},
+			"accounts": {{"id": 3, "proxy_id": 7, "credentials": map[string]any{"access_token": "enc:v1:old:tok", "model": "x"}}},
+			"groups": {
+				{"id": 10, "name": "a", "fallback_group_id": 11, "model_routing": map[string]any{"claude-*": []any{3, 99}},
+					"traffic_split": map[string]any{"enabled": true, "arms": []any{
+						map[string]any{"name": "canary", "weight": 10, "models": []any{"claude-*"}, "account_ids": []any{3, 99}},
+						map[string]any{"name": "rewrite", "weight": 5, "model": "claude-haiku", "account_ids": []any{99}},
+					}}},
+				{"id": 11, "name": "b"},
+			},
+			"account_groups": {{"account_id": 3, "group_id": 11}},
+			"users": {
+				{"id": 5, "email": "admin@example.com"},
+				{"id": 6, "email": "u@example.com", "dedicated_account_ids": []any{3}},
+			},
+			"api_keys": {{"id": 20, "user_id": 6, "group_id": 10, "key": "", "key_hash": "h"}},
+		},
+	}
+}
+
+func TestBackupExportRestoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{}
+	src := NewBackupService(newBackupSource(), backupTestCipher{keyID: "old"}, nil, cfg, BuildInfo{Version: "1.0.0"})
+
+	var buf bytes.Buffer
+	summary, err := src.Export(ctx, &buf, BackupExportOptions{Passphrase: "correct horse"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), summary.Counts["groups"])
+	require.NotContains(t, gunzipBackup(t, buf.Bytes()), "proxy-pass")
+
+	target := &backupRepoStub{schema: "059_add_group_usage_quotas.sql", counts: map[string]int64{"users": 1},
+		emails: map[string]int64{"admin@example.com": 1}}
+	dst := NewBackupService(target, backupTestCipher{keyID: "new"}, nil, cfg, BuildInfo{})
+
+	_, err = dst.Restore(ctx, bytes.NewReader(buf.Bytes()), "wrong passphrase")
+	require.ErrorIs(t, err, ErrBackupWrongPassphrase)
+
+	restored, err := dst.Restore(ctx, bytes.NewReader(buf.Bytes()), "correct horse")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), restored.Counts["users"])
+
+	require.Equal(t, "enc:v1:new:smtp-secret", target.inserted["settings"][0]["value"])
+	require.Equal(t, "enc:v1:new:proxy-pass", target.inserted["proxies"][0]["password"])
+	proxyID := target.inserted["proxies"][0]["id"]
+	account := target.inserted["accounts"][0]
+	require.Equal(t, proxyID, account["proxy_id"])
+	require.Equal(t, "enc:v1:new:tok", account["credentials"].(map[string]any)["access_token"])
+	accountID := account["id"]
+
+	groupA, groupB := target.inserted["groups"][0], target.inserted["groups"][1]
+	require.Equal(t, groupB["id"], groupA["fallback_group_id"])
+	require.Equal(t, map[string]any{"claude-*": []any{accountID}}, groupA["model_routing"])
+	split := groupA["traffic_split"].(map[string]any)
+	require.Equal(t, true, split["enabled"])
+	arms := split["arms"].([]any)
+	require.Len(t, arms, 2)
+	canary := arms[0].(map[string]any)
+	require.Equal(t, "canary", canary["name"])
+	require.Equal(t, json.Number("10"), canary["weight"])
+	require.Equal(t, []any{"claude-*"}, canary["models"])
+	require.Equal(t, []any{accountID}, canary["account_ids"])
+	// 专用账号全部失效时移除 account_ids，其余配置保留
+	rewrite := arms[1].(map[string]any)
+	require.Equal(t, "claude-haiku", rewrite["model"])
+	require.NotContains(t, rewrite, "account_ids")
+	require.Equal(t, groupB["id"], target.inserted["account_groups"][0]["group_id"])
+
+	// 管理员按邮箱合并，只新建普通用户
+	require.Len(t, target.inserted["users"], 1)
+	user := target.inserted["users"][0]
+	require.Equal(t, []any{accountID}, user["dedicated_account_ids"])
+	apiKey := target.inserted["api_keys"][0]
+	require.Equal(t, user["id"], apiKey["user_id"])
+	require.Equal(t, groupA["id"], apiKey["group_id"])
+}
+
+func TestBackupRestoreUserSecurityAndCommissionTables(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{}
+	source := newBackupSource()
+	source.tables["webauthn_credentials"] = []map[string]any{{"id": 30, "user_id": 6, "name": "laptop", "credential_id": "cred", "public_key": "\\x0102"}}
+	source.tables["user_recovery_codes"] = []map[string]any{{"id": 31, "user_id": 6, "code_hash": "h1"}}
+	source.tables["user_invites"] = []map[string]any{{"id": 40, "inviter_id": 5, "invitee_id": 6, "invite_code": "ABCDEF", "commission_cursor": 55}}
+	source.tables["invite_logs"] = []map[string]any{{"id": 41, "invite_id": 40, "action": "bind", "inviter_id": 5, "invitee_id": 6}}
+	source.tables["invite_commissions"] = []map[string]any{{"id": 42, "invite_id": 40, "inviter_id": 5, "invitee_id": 6, "amount": 1.5}}
+	source.tables["commission_withdrawals"] = []map[string]any{{"id": 43, "user_id": 5, "amount": 1, "processed_by": 5}}
+	source.tables["organizations"] = []map[string]any{{"id": 50, "name": "org", "owner_user_id": 6}}
+	source.tables["organization_invitations"] = []map[string]any{{"id": 51, "organization_id": 50, "email": "x@example.com", "invited_by": 6, "accepted_user_id": nil}}
+	source.tables["usage_logs"] = []map[string]any{
+		{"id": 50, "user_id": 6, "api_key_id": 20, "account_id": 3, "group_id": 10},
+		{"id": 54, "user_id": 6, "api_key_id": 20, "account_id": 3, "group_id": 10},
+		{"id": 60, "user_id": 6, "api_key_id": 20, "account_id": 3, "group_id": 10},
+	}
+	src := NewBackupService(source, backupTestCipher{keyID: "old"}, nil, cfg, BuildInfo{})
+
+	restore := func(includeUsageLogs bool) *backupRepoStub {
+		var buf bytes.Buffer
+		_, err := src.Export(ctx, &buf, BackupExportOptions{Passphrase: "correct horse", IncludeUsageLogs: includeUsageLogs})
+		require.NoError(t, err)
+		target := &backupRepoStub{schema: "059_add_group_usage_quotas.sql", counts: map[string]int64{"users": 1},
+			emails: map[string]int64{"admin@example.com": 1}}
+		_, err = NewBackupService(target, backupTestCipher{keyID: "new"}, nil, cfg, BuildInfo{}).Restore(ctx, bytes.NewReader(buf.Bytes()), "correct horse")
+		require.NoError(t, err)
+		return target
+	}
+
+	target := restore(true)
+	userID := target.inserted["users"][0]["id"]
+	require.Equal(t, userID, target.inserted["webauthn_credentials"][0]["user_id"])
+	require.Equal(t, userID, target.inserted["user_recovery_codes"][0]["user_id"])
+	invite := target.inserted["user_invites"][0]
+	require.Equal(t, int64(1), invite["inviter_id"])
+	require.Equal(t, userID, invite["invitee_id"])
+	require.Equal(t, invite["id"], target.inserted["invite_logs"][0]["invite_id"])
+	require.Equal(t, invite["id"], target.inserted["invite_commissions"][0]["invite_id"])
+	require.Equal(t, int64(1), target.inserted["commission_withdrawals"][0]["processed_by"])
+	org := target.inserted["organizations"][0]
+	require.Equal(t, org["id"], target.inserted["organization_invitations"][0]["organization_id"])
+	require.Equal(t, userID, target.inserted["organization_invitations"][0]["invited_by"])
+	// 返佣游标映射到不超过原值（55）的最大日志（54）的新 ID
+	require.Equal(t, target.inserted["usage_logs"][1]["id"], invite["commission_cursor"])
+
+	// 不含使用记录时游标归零，恢复后产生的日志正常计佣
+	target = restore(false)
+	require.Equal(t, 0, target.inserted["user_invites"][0]["commission_cursor"])
+}
+
+func TestBackupRestoreRejectsUnsafeTargets(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{}
+	src := NewBackupService(newBackupSource(), backupTestCipher{keyID: "old"}, nil, cfg, BuildInfo{})
+	var buf bytes.Buffer
+	_, err := src.Export(ctx, &buf, BackupExportOptions{Passphrase: "correct horse"})
+	require.NoError(t, err)
+	archive := buf.Bytes()
+
+	_, err = src.Export(ctx, &bytes.Buffer{}, BackupExportOptions{Passphrase: "short"})
+	require.ErrorIs(t, err, ErrBackupPassphraseTooShort)
+
+	older := NewBackupService(&backupRepoStub{schema: "058_add_subscription_renewal.sql"}, nil, nil, cfg, BuildInfo{})
+	_, err = older.Restore(ctx, bytes.NewReader(archive), "correct horse")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "newer than this instance")
+
+	nonEmpty := NewBackupService(&backupRepoStub{schema: "059_add_group_usage_quotas.sql", counts: map[string]int64{"accounts": 2}}, nil, nil, cfg, BuildInfo{})
+	_, err = nonEmpty.Restore(ctx, bytes.NewReader(archive), "correct horse")
+	require.ErrorIs(t, err, ErrBackupTargetNotEmpty)
+
+	otherHash := NewBackupService(&backupRepoStub{schema: "059_add_group_usage_quotas.sql"}, nil, nil,
+		&config.Config{APIKeyHash: config.APIKeyHashConfig{Secret: "other"}}, BuildInfo{})
+	_, err = otherHash.Restore(ctx, bytes.NewReader(archive), "correct horse")
+	require.ErrorIs(t, err, ErrBackupAPIKeyHashMismatch)
+
+	// 截断的归档缺少结束标记
+	var truncated bytes.Buffer
+	_, err = src.Export(ctx, &truncated, BackupExportOptions{Passphrase: "correct horse"})
+	require.NoError(t, err)
+	plain := gunzipBackup(t, truncated.Bytes())
+	lines := strings.Split(strings.TrimSpace(plain), "\n")
+	target := &backupRepoStub{schema: "059_add_group_usage_quotas.sql"}
+	_, err = NewBackupService(target, nil, nil, cfg, BuildInfo{}).Restore(ctx, gzipBackup(t, strings.Join(lines[:len(lines)-1], "\n")+"\n"), "correct horse")
+	require.ErrorIs(t, err, ErrBackupInvalidArchive)
+}
+
+func gunzipBackup(t *testing.T, data []byte) string {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	plain, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	return string(plain)
+}
+
+func gzipBackup(t *testing.T, plain string) io.Reader {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(plain))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return &buf
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
 	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
 )
 
 // 错误定义
@@ -38,6 +40,10 @@ const (
 	cacheWriteSetSubscription
 	cacheWriteUpdateSubscriptionUsage
 	cacheWriteDeductBalance
+	cacheWriteSetOrgBalance
+	cacheWriteDeductOrgBalance
+	cacheWriteSetSubscriptionQuota
+	cacheWriteIncrSubscriptionQuota
 )
 
 // 异步缓存写入工作池配置
@@ -65,9 +71,12 @@ type cacheWriteTask struct {
 	kind             cacheWriteKind
 	userID           int64
 	groupID          int64
+	orgID            int64
 	balance          float64
 	amount           float64
 	subscriptionData *subscriptionCacheData
+	quotaKey         SubscriptionQuotaKey
+	quotaUsage       SubscriptionQuotaUsage
 }
 
 // BillingCacheService 计费缓存服务
@@ -76,6 +85,7 @@ type BillingCacheService struct {
 	cache          BillingCache
 	userRepo       UserRepository
 	subRepo        UserSubscriptionRepository
+	orgRepo        OrganizationRepository
 	cfg            *config.Config
 	circuitBreaker *billingCircuitBreaker
 
@@ -90,11 +100,12 @@ type BillingCacheService struct {
 }
 
 // NewBillingCacheService 创建计费缓存服务
-func NewBillingCacheService(cache BillingCache, userRepo UserRepository, subRepo UserSubscriptionRepository, cfg *config.Config) *BillingCacheService {
+func NewBillingCacheService(cache BillingCache, userRepo UserRepository, subRepo UserSubscriptionRepository, orgRepo OrganizationRepository, cfg *config.Config) *BillingCacheService {
 	svc := &BillingCacheService{
 		cache:    cache,
 		userRepo: userRepo,
 		subRepo:  subRepo,
+		orgRepo:  orgRepo,
 		cfg:      cfg,
 	}
 	svc.circuitBreaker = newBillingCircuitBreaker(cfg.Billing.CircuitBreaker)
@@ -165,6 +176,22 @@ func (s *BillingCacheService) cacheWriteWorker() {
 					log.Printf("Warning: deduct balance cache failed for user %d: %v", task.userID, err)
 				}
 			}
+		case cacheWriteSetOrgBalance:
+			s.setOrganizationBalanceCache(ctx, task.orgID, task.balance)
+		case cacheWriteDeductOrgBalance:
+			if s.cache != nil {
+				if err := s.cache.DeductOrganizationBalance(ctx, task.orgID, task.amount); err != nil {
+					log.Printf("Warning: deduct organization balance cache failed for org %d: %v", task.orgID, err)
+				}
+			}
+		case cacheWriteSetSubscriptionQuota:
+			s.setSubscriptionQuotaCache(ctx, task.quotaKey, task.quotaUsage)
+		case cacheWriteIncrSubscriptionQuota:
+			if s.cache != nil {
+				if err := s.cache.IncrSubscriptionQuotaUsage(ctx, task.quotaKey, task.quotaUsage); err != nil {
+					log.Printf("Warning: incr subscription quota cache failed for subscription %d: %v", task.quotaKey.SubscriptionID, err)
+				}
+			}
 		}
 		cancel()
 	}
@@ -181,6 +208,14 @@ func cacheWriteKindName(kind cacheWriteKind) string {
 		return "update_subscription_usage"
 	case cacheWriteDeductBalance:
 		return "deduct_balance"
+	case cacheWriteSetOrgBalance:
+		return "set_org_balance"
+	case cacheWriteDeductOrgBalance:
+		return "deduct_org_balance"
+	case cacheWriteSetSubscriptionQuota:
+		return "set_subscription_quota"
+	case cacheWriteIncrSubscriptionQuota:
+		return "incr_subscription_quota"
 	default:
 		return "unknown"
 	}
@@ -318,6 +353,107 @@ func (s *BillingCacheService) InvalidateUserBalance(ctx context.Context, userID
 	return nil
 }
 
+// ============================================
+// 组织余额缓存方法
+// ============================================
+
+// GetOrganizationBalance 获取组织余额（优先从缓存读取）
+func (s *BillingCacheService) GetOrganizationBalance(ctx context.Context, orgID int64) (float64, error) {
+	if s.cache == nil {
+		return s.getOrganizationBalanceFromDB(ctx, orgID)
+	}
+
+	balance, err := s.cache.GetOrganizationBalance(ctx, orgID)
+	if err == nil {
+		return balance, nil
+	}
+
+	balance, err = s.getOrganizationBalanceFromDB(ctx, orgID)
+	if err != nil {
+		return 0, err
+	}
+
+	_ = s.enqueueCacheWrite(cacheWriteTask{
+		kind:    cacheWriteSetOrgBalance,
+		orgID:   orgID,
+		balance: balance,
+	})
+
+	return balance, nil
+}
+
+// getOrganizationBalanceFromDB 从数据库获取组织余额
+func (s *BillingCacheService) getOrganizationBalanceFromDB(ctx context.Context, orgID int64) (float64, error) {
+	if s.orgRepo == nil {
+		return 0, fmt.Errorf("get organization balance: organization repository not configured")
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return 0, fmt.Errorf("get organization balance: %w", err)
+	}
+	return org.Balance, nil
+}
+
+// setOrganizationBalanceCache 设置组织余额缓存
+func (s *BillingCacheService) setOrganizationBalanceCache(ctx context.Context, orgID int64, balance float64) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.SetOrganizationBalance(ctx, orgID, balance); err != nil {
+		log.Printf("Warning: set organization balance cache failed for org %d: %v", orgID, err)
+	}
+}
+
+// QueueDeductOrganizationBalance 异步扣减组织余额缓存
+func (s *BillingCacheService) QueueDeductOrganizationBalance(orgID int64, amount float64) {
+	if s.cache == nil {
+		return
+	}
+	if s.enqueueCacheWrite(cacheWriteTask{
+		kind:   cacheWriteDeductOrgBalance,
+		orgID:  orgID,
+		amount: amount,
+	}) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cacheWriteTimeout)
+	defer cancel()
+	if err := s.cache.DeductOrganizationBalance(ctx, orgID, amount); err != nil {
+		log.Printf("Warning: deduct organization balance cache fallback failed for org %d: %v", orgID, err)
+	}
+}
+
+// InvalidateOrganizationBalance 失效组织余额缓存
+func (s *BillingCacheService) InvalidateOrganizationBalance(ctx context.Context, orgID int64) error {
+	if s.cache == nil {
+		return nil
+	}
+	if err := s.cache.InvalidateOrganizationBalance(ctx, orgID); err != nil {
+		log.Printf("Warning: invalidate organization balance cache failed for org %d: %v", orgID, err)
+		return err
+	}
+	return nil
+}
+
+// ChargeOrganizationUsage 组织 Key 的计费落库：
+// deductBalance 为 true 时扣除组织余额（余额模式），并累加成员月度消费（用于成员消费上限）。
+func (s *BillingCacheService) ChargeOrganizationUsage(ctx context.Context, apiKey *APIKey, userID int64, cost float64, deductBalance bool) error {
+	if !apiKey.IsOrganizationKey() || s.orgRepo == nil || cost <= 0 {
+		return nil
+	}
+	orgID := *apiKey.OrganizationID
+	if deductBalance {
+		if err := s.orgRepo.DeductBalance(ctx, orgID, cost); err != nil {
+			return fmt.Errorf("deduct organization balance: %w", err)
+		}
+		s.QueueDeductOrganizationBalance(orgID, cost)
+	}
+	if err := s.orgRepo.IncrementMemberUsage(ctx, orgID, userID, cost, timezone.StartOfMonth(time.Now())); err != nil {
+		return fmt.Errorf("increment organization member usage: %w", err)
+	}
+	return nil
+}
+
 // ============================================
 // 订阅缓存方法
 // ============================================
@@ -447,8 +583,8 @@ func (s *BillingCacheService) InvalidateSubscription(ctx context.Context, userID
 
 // CheckBillingEligibility 检查用户是否有资格发起请求
 // 余额模式：检查缓存余额 > 0
-// 订阅模式：检查缓存用量未超过限额（Group限额从参数传入）
-func (s *BillingCacheService) CheckBillingEligibility(ctx context.Context, user *User, apiKey *APIKey, group *Group, subscription *UserSubscription) error {
+// 订阅模式：检查缓存用量未超过限额（Group限额从参数传入），以及请求模型适用的 token / 请求次数额度
+func (s *BillingCacheService) CheckBillingEligibility(ctx context.Context, user *User, apiKey *APIKey, group *Group, subscription *UserSubscription, model string) error {
 	// 简易模式：跳过所有计费检查
 	if s.cfg.RunMode == config.RunModeSimple {
 		return nil
@@ -460,13 +596,66 @@ func (s *BillingCacheService) CheckBillingEligibility(ctx context.Context, user
 	// 判断计费模式
 	isSubscriptionMode := group != nil && group.IsSubscriptionType() && subscription != nil
 
+	if apiKey.IsOrganizationKey() {
+		if err := s.checkOrganizationMemberEligibility(ctx, user.ID, apiKey); err != nil {
+			return err
+		}
+		if isSubscriptionMode {
+			// 组织订阅挂在组织所有者名下
+			return s.checkSubscriptionEligibility(ctx, subscription.UserID, group, subscription, model)
+		}
+		return s.checkOrganizationBalanceEligibility(ctx, *apiKey.OrganizationID)
+	}
+
 	if isSubscriptionMode {
-		return s.checkSubscriptionEligibility(ctx, user.ID, group, subscription)
+		return s.checkSubscriptionEligibility(ctx, user.ID, group, subscription, model)
 	}
 
 	return s.checkBalanceEligibility(ctx, user.ID)
 }
 
+// checkOrganizationMemberEligibility 检查组织状态与成员月度消费上限
+func (s *BillingCacheService) checkOrganizationMemberEligibility(ctx context.Context, userID int64, apiKey *APIKey) error {
+	if apiKey.Organization != nil && !apiKey.Organization.IsActive() {
+		return ErrOrganizationInactive
+	}
+	if s.orgRepo == nil {
+		return nil
+	}
+	member, err := s.orgRepo.GetMember(ctx, *apiKey.OrganizationID, userID)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationMemberNotFound) {
+			return ErrOrganizationPermissionDenied
+		}
+		log.Printf("ALERT: organization member check failed for org %d user %d: %v", *apiKey.OrganizationID, userID, err)
+		return ErrBillingServiceUnavailable.WithCause(err)
+	}
+	if member.MonthlyLimitReached(time.Now()) {
+		return ErrOrganizationMemberLimit
+	}
+	return nil
+}
+
+// checkOrganizationBalanceEligibility 检查组织余额模式资格
+func (s *BillingCacheService) checkOrganizationBalanceEligibility(ctx context.Context, orgID int64) error {
+	balance, err := s.GetOrganizationBalance(ctx, orgID)
+	if err != nil {
+		if s.circuitBreaker != nil {
+			s.circuitBreaker.OnFailure(err)
+		}
+		log.Printf("ALERT: billing balance check failed for organization %d: %v", orgID, err)
+		return ErrBillingServiceUnavailable.WithCause(err)
+	}
+	if s.circuitBreaker != nil {
+		s.circuitBreaker.OnSuccess()
+	}
+
+	if balance <= 0 {
+		return ErrOrganizationInsufficientFunds
+	}
+	return nil
+}
+
 // checkBalanceEligibility 检查余额模式资格
 func (s *BillingCacheService) checkBalanceEligibility(ctx context.Context, userID int64) error {
 	balance, err := s.GetUserBalance(ctx, userID)
@@ -489,7 +678,7 @@ func (s *BillingCacheService) checkBalanceEligibility(ctx context.Context, userI
 }
 
 // checkSubscriptionEligibility 检查订阅模式资格
-func (s *BillingCacheService) checkSubscriptionEligibility(ctx context.Context, userID int64, group *Group, subscription *UserSubscription) error {
+func (s *BillingCacheService) checkSubscriptionEligibility(ctx context.Context, userID int64, group *Group, subscription *UserSubscription, model string) error {
 	// 获取订阅缓存数据
 	subData, err := s.GetSubscriptionStatus(ctx, userID, group.ID)
 	if err != nil {
@@ -526,7 +715,7 @@ func (s *BillingCacheService) checkSubscriptionEligibility(ctx context.Context,
 		return ErrMonthlyLimitExceeded
 	}
 
-	return nil
+	return s.checkSubscriptionQuotas(ctx, group, subscription, model)
 }
 
 type billingCircuitBreakerState int
@@ -6,6 +6,7 @@ import (
 
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
 )
@@ -18,11 +19,22 @@ type BillingCache interface {
 	DeductUserBalance(ctx context.Context, userID int64, amount float64) error
 	InvalidateUserBalance(ctx context.Context, userID int64) error
 
+	// Organization balance operations
+	GetOrganizationBalance(ctx context.Context, orgID int64) (float64, error)
+	SetOrganizationBalance(ctx context.Context, orgID int64, balance float64) error
+	DeductOrganizationBalance(ctx context.Context, orgID int64, amount float64) error
+	InvalidateOrganizationBalance(ctx context.Context, orgID int64) error
+
 	// Subscription operations
 	GetSubscriptionCache(ctx context.Context, userID, groupID int64) (*SubscriptionCacheData, error)
 	SetSubscriptionCache(ctx context.Context, userID, groupID int64, data *SubscriptionCacheData) error
 	UpdateSubscriptionUsage(ctx context.Context, userID, groupID int64, cost float64) error
 	InvalidateSubscriptionCache(ctx context.Context, userID, groupID int64) error
+
+	// Subscription quota operations (token / request counters per window)
+	GetSubscriptionQuotaUsage(ctx context.Context, key SubscriptionQuotaKey) (*SubscriptionQuotaUsage, error)
+	SetSubscriptionQuotaUsage(ctx context.Context, key SubscriptionQuotaKey, usage SubscriptionQuotaUsage, ttl time.Duration) error
+	IncrSubscriptionQuotaUsage(ctx context.Context, key SubscriptionQuotaKey, delta SubscriptionQuotaUsage) error
 }
 
 // ModelPricing 模型价格配置（per-token价格，与LiteLLM格式一致）
@@ -133,12 +145,24 @@ func (s *BillingService) initFallbackPricing() {
 		CacheReadPricePerToken:     0.03e-6, // $0.03 per MTok
 		SupportsCacheBreakdown:     false,
 	}
+
+	// Gemini Embedding（仅输入计费）
+	s.fallbackPrices["gemini-embedding"] = &ModelPricing{
+		InputPricePerToken:     0.15e-6, // $0.15 per MTok
+		OutputPricePerToken:    0,
+		SupportsCacheBreakdown: false,
+	}
 }
 
 // getFallbackPricing 根据模型系列获取回退价格
 func (s *BillingService) getFallbackPricing(model string) *ModelPricing {
 	modelLower := strings.ToLower(model)
 
+	// Gemini embedding 模型不能回退到对话模型价格
+	if isGeminiEmbeddingModel(modelLower) {
+		return s.fallbackPrices["gemini-embedding"]
+	}
+
 	// 按模型系列匹配
 	if strings.Contains(modelLower, "opus") {
 		if strings.Contains(modelLower, "4.5") || strings.Contains(modelLower, "4-5") {
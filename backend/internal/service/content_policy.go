@@ -0,0 +1,725 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/httpclient"
+	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// 内容策略命中后的处理动作
+const (
+	ContentPolicyActionBlock  = "block"  // 拦截请求
+	ContentPolicyActionRedact = "redact" // 脱敏后继续转发
+	ContentPolicyActionLog    = "log"    // 仅记录运维事件
+)
+
+// PII 检测器类型
+const (
+	ContentPolicyPIIEmail      = "email"
+	ContentPolicyPIIPhone      = "phone"
+	ContentPolicyPIICreditCard = "credit_card"
+)
+
+const (
+	maxContentPolicyBlocklistRules   = 200
+	maxContentPolicyPatternLength    = 1024
+	defaultContentPolicyModerationMs = 3000
+	maxContentPolicyModerationMs     = 30000
+	maxContentPolicyModerationBody   = 64 * 1024
+	contentPolicyRedactedText        = "[REDACTED]"
+)
+
+// invalidContentPolicy 返回带具体原因的 400 错误
+func invalidContentPolicy(format string, a ...any) error {
+	return infraerrors.Newf(http.StatusBadRequest, "CONTENT_POLICY_INVALID", "invalid content policy: "+format, a...)
+}
+
+// ContentPolicy 分组内容策略，在网关转发前对请求内容进行检查
+type ContentPolicy struct {
+	Enabled bool `json:"enabled"`
+	// Blocklist 关键词/正则黑名单
+	Blocklist []ContentPolicyBlocklistRule `json:"blocklist,omitempty"`
+	// PIIDetectors 个人敏感信息检测（邮箱、手机号、银行卡号）
+	PIIDetectors []ContentPolicyPIIDetector `json:"pii_detectors,omitempty"`
+	// MaxImages 单次请求允许的最大图片数（0 表示不限制）
+	MaxImages int `json:"max_images,omitempty"`
+	// MaxImageBytes 单张内联图片的最大字节数（按 base64 解码后估算，0 表示不限制）
+	MaxImageBytes int64 `json:"max_image_bytes,omitempty"`
+	// Moderation 外部审核 Webhook（可选）
+	Moderation *ContentPolicyModeration `json:"moderation,omitempty"`
+}
+
+// ContentPolicyBlocklistRule 黑名单规则：Regex=false 时按关键词（大小写不敏感）匹配
+type ContentPolicyBlocklistRule struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+	Regex   bool   `json:"regex"`
+	Action  string `json:"action"` // block(默认) / redact / log
+}
+
+// ContentPolicyPIIDetector PII 检测器配置
+type ContentPolicyPIIDetector struct {
+	Type   string `json:"type"`   // email / phone / credit_card
+	Action string `json:"action"` // block / redact / log
+}
+
+// ContentPolicyModeration 外部审核 Webhook 配置。
+// 请求体为 JSON：{"platform","model","group_id","user_id","api_key_id","input":[...]}，
+// 期望响应 {"flagged": bool, "reason": string}。
+type ContentPolicyModeration struct {
+	Enabled   bool   `json:"enabled"`
+	URL       string `json:"url"`
+	Secret    string `json:"secret,omitempty"` // 以 Authorization: Bearer 发送
+	TimeoutMs int    `json:"timeout_ms,omitempty"`
+	// FailClosed 审核服务不可用时拦截请求（默认放行）
+	FailClosed bool `json:"fail_closed"`
+}
+
+// IsActive 策略是否启用且包含至少一项检查
+func (p *ContentPolicy) IsActive() bool {
+	if p == nil || !p.Enabled {
+		return false
+	}
+	return len(p.Blocklist) > 0 || len(p.PIIDetectors) > 0 || p.MaxImages > 0 || p.MaxImageBytes > 0 ||
+		(p.Moderation != nil && p.Moderation.Enabled)
+}
+
+// NormalizeContentPolicy 校验并规范化内容策略；nil 表示不启用
+func NormalizeContentPolicy(policy *ContentPolicy) (*ContentPolicy, error) {
+	if policy == nil {
+		return nil, nil
+	}
+	out := &ContentPolicy{
+		Enabled:       policy.Enabled,
+		MaxImages:     policy.MaxImages,
+		MaxImageBytes: policy.MaxImageBytes,
+	}
+	if out.MaxImages < 0 || out.MaxImageBytes < 0 {
+		return nil, invalidContentPolicy("max_images and max_image_bytes must be >= 0")
+	}
+	if len(policy.Blocklist) > maxContentPolicyBlocklistRules {
+		return nil, invalidContentPolicy("at most %d blocklist rules", maxContentPolicyBlocklistRules)
+	}
+
+	for i, rule := range policy.Blocklist {
+		rule.Name = strings.TrimSpace(rule.Name)
+		rule.Pattern = strings.TrimSpace(rule.Pattern)
+		if rule.Pattern == "" || len(rule.Pattern) > maxContentPolicyPatternLength {
+			return nil, invalidContentPolicy("blocklist[%d]: pattern is required (max %d chars)", i, maxContentPolicyPatternLength)
+		}
+		if rule.Name == "" {
+			rule.Name = "rule_" + strconv.Itoa(i+1)
+		}
+		action, err := normalizeContentPolicyAction(rule.Action)
+		if err != nil {
+			return nil, invalidContentPolicy("blocklist[%d]: %v", i, err)
+		}
+		rule.Action = action
+		if _, err := compileContentPolicyRule(rule); err != nil {
+			return nil, invalidContentPolicy("blocklist[%d]: invalid regex: %v", i, err)
+		}
+		out.Blocklist = append(out.Blocklist, rule)
+	}
+
+	seen := make(map[string]struct{}, len(policy.PIIDetectors))
+	for i, detector := range policy.PIIDetectors {
+		detector.Type = strings.ToLower(strings.TrimSpace(detector.Type))
+		if _, ok := contentPolicyPIIPatterns[detector.Type]; !ok {
+			return nil, invalidContentPolicy("pii_detectors[%d]: unsupported type %q", i, detector.Type)
+		}
+		if _, dup := seen[detector.Type]; dup {
+			return nil, invalidContentPolicy("pii_detectors[%d]: duplicate type %q", i, detector.Type)
+		}
+		seen[detector.Type] = struct{}{}
+		action, err := normalizeContentPolicyAction(detector.Action)
+		if err != nil {
+			return nil, invalidContentPolicy("pii_detectors[%d]: %v", i, err)
+		}
+		detector.Action = action
+		out.PIIDetectors = append(out.PIIDetectors, detector)
+	}
+
+	if m := policy.Moderation; m != nil {
+		mod := &ContentPolicyModeration{
+			Enabled:    m.Enabled,
+			URL:        strings.TrimSpace(m.URL),
+			Secret:     strings.TrimSpace(m.Secret),
+			TimeoutMs:  m.TimeoutMs,
+			FailClosed: m.FailClosed,
+		}
+		if mod.TimeoutMs <= 0 {
+			mod.TimeoutMs = defaultContentPolicyModerationMs
+		}
+		if mod.TimeoutMs > maxContentPolicyModerationMs {
+			mod.TimeoutMs = maxContentPolicyModerationMs
+		}
+		if mod.Enabled || mod.URL != "" {
+			normalized, err := urlvalidator.ValidateURLFormat(mod.URL, true)
+			if err != nil {
+				return nil, invalidContentPolicy("moderation: %v", err)
+			}
+			mod.URL = normalized
+		}
+		out.Moderation = mod
+	}
+	return out, nil
+}
+
+func normalizeContentPolicyAction(action string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "", ContentPolicyActionBlock:
+		return ContentPolicyActionBlock, nil
+	case ContentPolicyActionRedact:
+		return ContentPolicyActionRedact, nil
+	case ContentPolicyActionLog:
+		return ContentPolicyActionLog, nil
+	default:
+		return "", fmt.Errorf("unsupported action %q", action)
+	}
+}
+
+// ContentPolicyViolation 单条策略命中记录
+type ContentPolicyViolation struct {
+	Rule   string `json:"rule"` // blocklist:<name> / pii:<type> / max_images / max_image_bytes / moderation
+	Action string `json:"action"`
+	Count  int    `json:"count,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ContentPolicyResult 策略检查结果
+type ContentPolicyResult struct {
+	Blocked    bool
+	Redacted   bool
+	Body       []byte // 脱敏后的请求体；未脱敏时为原始请求体
+	Violations []ContentPolicyViolation
+}
+
+// HasViolations 是否存在命中记录
+func (r *ContentPolicyResult) HasViolations() bool {
+	return r != nil && len(r.Violations) > 0
+}
+
+// Summary 返回命中规则摘要（用于错误信息和运维事件）
+func (r *ContentPolicyResult) Summary() string {
+	if r == nil || len(r.Violations) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(r.Violations))
+	for _, v := range r.Violations {
+		part := v.Rule + "(" + v.Action + ")"
+		if v.Detail != "" {
+			part += ": " + v.Detail
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// BlockReason 返回面向客户端的拦截原因（不包含命中的原文）
+func (r *ContentPolicyResult) BlockReason() string {
+	if r == nil {
+		return ""
+	}
+	var rules []string
+	for _, v := range r.Violations {
+		if v.Action == ContentPolicyActionBlock {
+			rules = append(rules, v.Rule)
+		}
+	}
+	return "Request blocked by content policy: " + strings.Join(rules, ", ")
+}
+
+// --- 检查引擎 ---
+
+var contentPolicyPIIPatterns = map[string]*regexp.Regexp{
+	ContentPolicyPIIEmail:      regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`),
+	ContentPolicyPIIPhone:      regexp.MustCompile(`\+?\(?\d[\d\s().\-]{5,20}\d`),
+	ContentPolicyPIICreditCard: regexp.MustCompile(`\d(?:[ \-]?\d){12,18}`),
+}
+
+var contentPolicyRedactions = map[string]string{
+	ContentPolicyPIIEmail:      "[REDACTED_EMAIL]",
+	ContentPolicyPIIPhone:      "[REDACTED_PHONE]",
+	ContentPolicyPIICreditCard: "[REDACTED_CARD]",
+}
+
+// contentPolicyTextKeys 需要检查的文本字段（覆盖 Anthropic / OpenAI / Gemini 请求格式）
+var contentPolicyTextKeys = map[string]struct{}{
+	"text":         {},
+	"content":      {},
+	"system":       {},
+	"input":        {},
+	"instructions": {},
+	"prompt":       {},
+}
+
+// contentPolicyRegexCache 缓存已编译的黑名单正则（key 为规则类型+模式）
+var contentPolicyRegexCache sync.Map
+
+func compileContentPolicyRule(rule ContentPolicyBlocklistRule) (*regexp.Regexp, error) {
+	key := "k:" + rule.Pattern
+	expr := "(?i)" + regexp.QuoteMeta(rule.Pattern)
+	if rule.Regex {
+		key = "r:" + rule.Pattern
+		expr = rule.Pattern
+	}
+	if cached, ok := contentPolicyRegexCache.Load(key); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	contentPolicyRegexCache.Store(key, re)
+	return re, nil
+}
+
+type contentPolicyTextField struct {
+	path  string
+	value string
+}
+
+type contentPolicyImage struct {
+	bytes int64 // 内联图片估算大小；URL 图片为 -1
+}
+
+// EvaluateContentPolicy 对请求体执行本地规则检查（黑名单、PII、图片限制），不包含外部审核
+func EvaluateContentPolicy(policy *ContentPolicy, body []byte) *ContentPolicyResult {
+	result := &ContentPolicyResult{Body: body}
+	if !policy.IsActive() || !gjson.ValidBytes(body) {
+		return result
+	}
+
+	var fields []contentPolicyTextField
+	var images []contentPolicyImage
+	collectContentPolicyTargets(gjson.ParseBytes(body), nil, "", &fields, &images)
+
+	counts := make(map[string]int)
+	actions := make(map[string]string)
+	var order []string
+	hit := func(rule, action string, n int) {
+		if _, ok := counts[rule]; !ok {
+			order = append(order, rule)
+			actions[rule] = action
+		}
+		counts[rule] += n
+	}
+
+	redacted := body
+	for _, field := range fields {
+		text := field.value
+		changed := false
+		for _, rule := range policy.Blocklist {
+			re, err := compileContentPolicyRule(rule)
+			if err != nil {
+				continue
+			}
+			matches := re.FindAllStringIndex(text, -1)
+			if len(matches) == 0 {
+				continue
+			}
+			hit("blocklist:"+rule.Name, rule.Action, len(matches))
+			if rule.Action == ContentPolicyActionRedact {
+				text = replaceContentPolicyMatches(text, matches, contentPolicyRedactedText)
+				changed = true
+			}
+		}
+		for _, detector := range policy.PIIDetectors {
+			matches := findContentPolicyPII(detector.Type, text)
+			if len(matches) == 0 {
+				continue
+			}
+			hit("pii:"+detector.Type, detector.Action, len(matches))
+			if detector.Action == ContentPolicyActionRedact {
+				text = replaceContentPolicyMatches(text, matches, contentPolicyRedactions[detector.Type])
+				changed = true
+			}
+		}
+		if changed {
+			next, err := sjson.SetBytes(redacted, field.path, text)
+			if err != nil {
+				log.Printf("[ContentPolicy] redact failed: path=%s err=%v", field.path, err)
+				continue
+			}
+			redacted = next
+			result.Redacted = true
+		}
+	}
+
+	for _, rule := range order {
+		result.Violations = append(result.Violations, ContentPolicyViolation{Rule: rule, Action: actions[rule], Count: counts[rule]})
+		if actions[rule] == ContentPolicyActionBlock {
+			result.Blocked = true
+		}
+	}
+
+	if policy.MaxImages > 0 && len(images) > policy.MaxImages {
+		result.Blocked = true
+		result.Violations = append(result.Violations, ContentPolicyViolation{
+			Rule:   "max_images",
+			Action: ContentPolicyActionBlock,
+			Count:  len(images),
+			Detail: fmt.Sprintf("%d images exceeds limit %d", len(images), policy.MaxImages),
+		})
+	}
+	if policy.MaxImageBytes > 0 {
+		oversized := 0
+		var largest int64
+		for _, img := range images {
+			if img.bytes > policy.MaxImageBytes {
+				oversized++
+				if img.bytes > largest {
+					largest = img.bytes
+				}
+			}
+		}
+		if oversized > 0 {
+			result.Blocked = true
+			result.Violations = append(result.Violations, ContentPolicyViolation{
+				Rule:   "max_image_bytes",
+				Action: ContentPolicyActionBlock,
+				Count:  oversized,
+				Detail: fmt.Sprintf("image of %d bytes exceeds limit %d", largest, policy.MaxImageBytes),
+			})
+		}
+	}
+
+	if result.Redacted {
+		result.Body = redacted
+	}
+	return result
+}
+
+// collectContentPolicyTargets 递归收集文本字段与图片
+func collectContentPolicyTargets(node gjson.Result, path []string, key string, fields *[]contentPolicyTextField, images *[]contentPolicyImage) {
+	switch {
+	case node.IsObject():
+		if img, ok := detectContentPolicyImage(node); ok {
+			*images = append(*images, img)
+		}
+		node.ForEach(func(k, v gjson.Result) bool {
+			collectContentPolicyTargets(v, appendContentPolicyPath(path, escapeContentPolicyPathKey(k.String())), k.String(), fields, images)
+			return true
+		})
+	case node.IsArray():
+		idx := 0
+		node.ForEach(func(_, v gjson.Result) bool {
+			collectContentPolicyTargets(v, appendContentPolicyPath(path, strconv.Itoa(idx)), key, fields, images)
+			idx++
+			return true
+		})
+	case node.Type == gjson.String:
+		if _, ok := contentPolicyTextKeys[key]; ok && node.String() != "" {
+			*fields = append(*fields, contentPolicyTextField{path: strings.Join(path, "."), value: node.String()})
+		}
+	}
+}
+
+func appendContentPolicyPath(path []string, part string) []string {
+	out := make([]string, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, part)
+}
+
+func escapeContentPolicyPathKey(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		switch r {
+		case '.', '*', '?', '|', '#', '@', '\\', '!', '=', '<', '>', '%', ':':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// detectContentPolicyImage 识别图片块：
+// Anthropic {"type":"image","source":{...}}、OpenAI {"type":"input_image"|"image_url"}、Gemini {"inlineData"|"fileData":{...}}
+func detectContentPolicyImage(node gjson.Result) (contentPolicyImage, bool) {
+	switch node.Get("type").String() {
+	case "image":
+		if node.Get("source.type").String() == "base64" {
+			return contentPolicyImage{bytes: estimateBase64Bytes(node.Get("source.data").String())}, true
+		}
+		return contentPolicyImage{bytes: -1}, true
+	case "input_image":
+		return contentPolicyImage{bytes: estimateDataURLBytes(node.Get("image_url").String())}, true
+	case "image_url":
+		raw := node.Get("image_url")
+		if raw.IsObject() {
+			raw = raw.Get("url")
+		}
+		return contentPolicyImage{bytes: estimateDataURLBytes(raw.String())}, true
+	}
+	for _, k := range []string{"inlineData", "inline_data"} {
+		if v := node.Get(k); v.Exists() {
+			return contentPolicyImage{bytes: estimateBase64Bytes(v.Get("data").String())}, true
+		}
+	}
+	for _, k := range []string{"fileData", "file_data"} {
+		if node.Get(k).Exists() {
+			return contentPolicyImage{bytes: -1}, true
+		}
+	}
+	return contentPolicyImage{}, false
+}
+
+func estimateDataURLBytes(raw string) int64 {
+	if !strings.HasPrefix(raw, "data:") {
+		return -1
+	}
+	idx := strings.Index(raw, ",")
+	if idx < 0 {
+		return -1
+	}
+	return estimateBase64Bytes(raw[idx+1:])
+}
+
+func estimateBase64Bytes(data string) int64 {
+	data = strings.TrimRight(strings.TrimSpace(data), "=")
+	return int64(len(data)) * 3 / 4
+}
+
+// findContentPolicyPII 查找 PII 命中位置；电话/卡号要求前后不是数字，卡号需通过 Luhn 校验
+func findContentPolicyPII(kind, text string) [][]int {
+	re, ok := contentPolicyPIIPatterns[kind]
+	if !ok {
+		return nil
+	}
+	var out [][]int
+	for _, m := range re.FindAllStringIndex(text, -1) {
+		if kind == ContentPolicyPIIEmail {
+			out = append(out, m)
+			continue
+		}
+		if (m[0] > 0 && isASCIIDigit(text[m[0]-1])) || (m[1] < len(text) && isASCIIDigit(text[m[1]])) {
+			continue
+		}
+		digits := extractDigits(text[m[0]:m[1]])
+		switch kind {
+		case ContentPolicyPIICreditCard:
+			if len(digits) >= 13 && len(digits) <= 19 && luhnValid(digits) {
+				out = append(out, m)
+			}
+		case ContentPolicyPIIPhone:
+			// 13 位以上且通过 Luhn 的数字串交给卡号检测器
+			if len(digits) >= 10 && len(digits) <= 15 && (len(digits) < 13 || !luhnValid(digits)) {
+				out = append(out, m)
+			}
+		}
+	}
+	return out
+}
+
+func replaceContentPolicyMatches(text string, matches [][]int, replacement string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m[0]])
+		b.WriteString(replacement)
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func extractDigits(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if isASCIIDigit(s[i]) {
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// --- 服务（含外部审核） ---
+
+// ContentPolicyService 执行分组内容策略
+type ContentPolicyService struct {
+	cfg *config.Config
+}
+
+// NewContentPolicyService 创建内容策略服务
+func NewContentPolicyService(cfg *config.Config) *ContentPolicyService {
+	return &ContentPolicyService{cfg: cfg}
+}
+
+// ContentPolicyCheckInput 策略检查输入
+type ContentPolicyCheckInput struct {
+	Group    *Group
+	UserID   int64
+	APIKeyID int64
+	Platform string
+	Model    string
+	Body     []byte
+}
+
+// Check 执行分组内容策略；分组未配置策略时返回 nil
+func (s *ContentPolicyService) Check(ctx context.Context, input *ContentPolicyCheckInput) *ContentPolicyResult {
+	if s == nil || input == nil || input.Group == nil || !input.Group.ContentPolicy.IsActive() {
+		return nil
+	}
+	policy := input.Group.ContentPolicy
+	result := EvaluateContentPolicy(policy, input.Body)
+	if result.Blocked || policy.Moderation == nil || !policy.Moderation.Enabled {
+		return result
+	}
+
+	var fields []contentPolicyTextField
+	var images []contentPolicyImage
+	collectContentPolicyTargets(gjson.ParseBytes(result.Body), nil, "", &fields, &images)
+	texts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		texts = append(texts, f.value)
+	}
+	if len(texts) == 0 {
+		return result
+	}
+
+	flagged, reason, err := s.moderate(ctx, policy.Moderation, input, texts)
+	switch {
+	case err != nil:
+		log.Printf("[ContentPolicy] moderation webhook failed: group=%d err=%v", input.Group.ID, err)
+		action := ContentPolicyActionLog
+		if policy.Moderation.FailClosed {
+			action = ContentPolicyActionBlock
+			result.Blocked = true
+		}
+		result.Violations = append(result.Violations, ContentPolicyViolation{Rule: "moderation", Action: action, Detail: "moderation unavailable"})
+	case flagged:
+		result.Blocked = true
+		result.Violations = append(result.Violations, ContentPolicyViolation{Rule: "moderation", Action: ContentPolicyActionBlock, Detail: reason})
+	}
+	return result
+}
+
+type contentPolicyModerationRequest struct {
+	Platform string   `json:"platform"`
+	Model    string   `json:"model"`
+	GroupID  int64    `json:"group_id"`
+	UserID   int64    `json:"user_id"`
+	APIKeyID int64    `json:"api_key_id"`
+	Input    []string `json:"input"`
+}
+
+type contentPolicyModerationResponse struct {
+	Flagged bool   `json:"flagged"`
+	Reason  string `json:"reason"`
+}
+
+func (s *ContentPolicyService) moderate(ctx context.Context, mod *ContentPolicyModeration, input *ContentPolicyCheckInput, texts []string) (bool, string, error) {
+	targetURL, err := s.validateModerationURL(mod.URL)
+	if err != nil {
+		return false, "", err
+	}
+	payload, err := json.Marshal(contentPolicyModerationRequest{
+		Platform: input.Platform,
+		Model:    input.Model,
+		GroupID:  input.Group.ID,
+		UserID:   input.UserID,
+		APIKeyID: input.APIKeyID,
+		Input:    texts,
+	})
+	if err != nil {
+		return false, "", err
+	}
+
+	timeout := time.Duration(mod.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultContentPolicyModerationMs * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(payload))
+	if err != nil {
+		return false, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if mod.Secret != "" {
+		req.Header.Set("Authorization", "Bearer "+mod.Secret)
+	}
+
+	client, err := httpclient.GetClient(httpclient.Options{
+		Timeout:            timeout,
+		ValidateResolvedIP: s.cfg != nil && s.cfg.Security.URLAllowlist.Enabled,
+		AllowPrivateHosts:  s.cfg != nil && s.cfg.Security.URLAllowlist.AllowPrivateHosts,
+	})
+	if err != nil {
+		return false, "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxContentPolicyModerationBody))
+	if err != nil {
+		return false, "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return false, "", fmt.Errorf("moderation webhook returned status %d", resp.StatusCode)
+	}
+	var parsed contentPolicyModerationResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return false, "", fmt.Errorf("invalid moderation response: %w", err)
+	}
+	return parsed.Flagged, strings.TrimSpace(parsed.Reason), nil
+}
+
+func (s *ContentPolicyService) validateModerationURL(raw string) (string, error) {
+	if s.cfg == nil || !s.cfg.Security.URLAllowlist.Enabled {
+		allowInsecure := s.cfg != nil && s.cfg.Security.URLAllowlist.AllowInsecureHTTP
+		normalized, err := urlvalidator.ValidateURLFormat(raw, allowInsecure)
+		if err != nil {
+			return "", fmt.Errorf("invalid moderation url: %w", err)
+		}
+		return normalized, nil
+	}
+	normalized, err := urlvalidator.ValidateHTTPSURL(raw, urlvalidator.ValidationOptions{
+		AllowPrivate: s.cfg.Security.URLAllowlist.AllowPrivateHosts,
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid moderation url: %w", err)
+	}
+	return normalized, nil
+}
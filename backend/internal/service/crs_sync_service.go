@@ -76,18 +76,20 @@ type crsLoginResponse struct {
 }
 
 type crsExportResponse struct {
-	Success bool   `json:"success"`
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Data    struct {
-		ExportedAt              string                      `json:"exportedAt"`
-		ClaudeAccounts          []crsClaudeAccount          `json:"claudeAccounts"`
-		ClaudeConsoleAccounts   []crsConsoleAccount         `json:"claudeConsoleAccounts"`
-		OpenAIOAuthAccounts     []crsOpenAIOAuthAccount     `json:"openaiOAuthAccounts"`
-		OpenAIResponsesAccounts []crsOpenAIResponsesAccount `json:"openaiResponsesAccounts"`
-		GeminiOAuthAccounts     []crsGeminiOAuthAccount     `json:"geminiOAuthAccounts"`
-		GeminiAPIKeyAccounts    []crsGeminiAPIKeyAccount    `json:"geminiApiKeyAccounts"`
-	} `json:"data"`
+	Success bool          `json:"success"`
+	Error   string        `json:"error"`
+	Message string        `json:"message"`
+	Data    crsExportData `json:"data"`
+}
+
+type crsExportData struct {
+	ExportedAt              string                      `json:"exportedAt"`
+	ClaudeAccounts          []crsClaudeAccount          `json:"claudeAccounts"`
+	ClaudeConsoleAccounts   []crsConsoleAccount         `json:"claudeConsoleAccounts"`
+	OpenAIOAuthAccounts     []crsOpenAIOAuthAccount     `json:"openaiOAuthAccounts"`
+	OpenAIResponsesAccounts []crsOpenAIResponsesAccount `json:"openaiResponsesAccounts"`
+	GeminiOAuthAccounts     []crsGeminiOAuthAccount     `json:"geminiOAuthAccounts"`
+	GeminiAPIKeyAccounts    []crsGeminiAPIKeyAccount    `json:"geminiApiKeyAccounts"`
 }
 
 type crsProxy struct {
@@ -975,7 +977,15 @@ func mergeMap(existing map[string]any, updates map[string]any) map[string]any {
 }
 
 func (s *CRSSyncService) mapOrCreateProxy(ctx context.Context, enabled bool, cached *[]Proxy, src *crsProxy, defaultName string) (*int64, error) {
-	if !enabled || src == nil {
+	if !enabled {
+		return nil, nil
+	}
+	return matchOrCreateProxy(ctx, s.proxyRepo, cached, src, defaultName)
+}
+
+// matchOrCreateProxy 按协议/地址/认证信息复用已有代理，不存在时创建；src 无效时返回 nil
+func matchOrCreateProxy(ctx context.Context, proxyRepo ProxyRepository, cached *[]Proxy, src *crsProxy, defaultName string) (*int64, error) {
+	if src == nil {
 		return nil, nil
 	}
 	protocol := strings.ToLower(strings.TrimSpace(src.Protocol))
@@ -1019,7 +1029,7 @@ func (s *CRSSyncService) mapOrCreateProxy(ctx context.Context, enabled bool, cac
 		Password: password,
 		Status:   StatusActive,
 	}
-	if err := s.proxyRepo.Create(ctx, proxy); err != nil {
+	if err := proxyRepo.Create(ctx, proxy); err != nil {
 		return nil, err
 	}
 
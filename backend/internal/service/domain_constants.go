@@ -16,6 +16,23 @@ const (
 	InviteStatusConfirmed = "confirmed"
 )
 
+// Invite commission constants
+const (
+	InviteCommissionStatusActive  = "active"  // 正常计佣
+	InviteCommissionStatusBlocked = "blocked" // 停止计佣（反作弊命中或管理员手动停止）
+
+	InviteCommissionBlockSameIP     = "same_register_ip"     // 与邀请人/其他被邀请人注册 IP 相同
+	InviteCommissionBlockSameDevice = "same_register_device" // 与邀请人/其他被邀请人注册设备相同
+	InviteCommissionBlockAdmin      = "admin"                // 管理员手动停止
+
+	CommissionWithdrawalMethodBalance  = "balance"  // 转入账户余额
+	CommissionWithdrawalMethodWithdraw = "withdraw" // 提现（线下打款）
+
+	CommissionWithdrawalStatusPending   = "pending"
+	CommissionWithdrawalStatusCompleted = "completed"
+	CommissionWithdrawalStatusRejected  = "rejected"
+)
+
 // Role constants
 const (
 	RoleAdmin = "admin"
@@ -55,6 +72,7 @@ const (
 	AdjustmentTypeAdminBalance     = "admin_balance"     // 管理员调整余额
 	AdjustmentTypeAdminConcurrency = "admin_concurrency" // 管理员调整并发数
 	AdjustmentTypeInviteReward     = "invite_reward"     // 邀请奖励余额
+	AdjustmentTypeInviteCommission = "invite_commission" // 返佣转入余额
 )
 
 // Invite log action constants
@@ -74,6 +92,7 @@ const (
 	SubscriptionStatusActive    = "active"
 	SubscriptionStatusExpired   = "expired"
 	SubscriptionStatusSuspended = "suspended"
+	SubscriptionStatusPaused    = "paused"
 )
 
 // LinuxDoConnectSyntheticEmailDomain 是 LinuxDo Connect 用户的合成邮箱后缀（RFC 保留域名）。
@@ -104,6 +123,10 @@ const (
 	// TOTP 双因素认证设置
 	SettingKeyTotpEnabled = "totp_enabled" // 是否启用 TOTP 2FA 功能
 
+	// WebAuthn / Passkey 设置
+	SettingKeyWebAuthnEnabled = "webauthn_enabled"  // 是否启用 passkey（两步验证与免密码登录）
+	SettingKeyRequireAdmin2FA = "require_admin_2fa" // 管理员访问后台是否必须启用两步验证（TOTP 或 passkey）
+
 	// LinuxDo Connect OAuth 登录设置
 	SettingKeyLinuxDoConnectEnabled      = "linuxdo_connect_enabled"
 	SettingKeyLinuxDoConnectClientID     = "linuxdo_connect_client_id"
@@ -130,6 +153,12 @@ const (
 	SettingKeyDefaultBalance     = "default_balance"      // 新用户默认余额
 	SettingKeyInviteRewardAmount = "invite_reward_amount" // 邀请奖励固定额度
 
+	// 返佣（按被邀请人消费比例）
+	SettingKeyInviteCommissionEnabled     = "invite_commission_enabled"      // 是否开启返佣
+	SettingKeyInviteCommissionRate        = "invite_commission_rate"         // 返佣比例（百分比）
+	SettingKeyInviteCommissionDays        = "invite_commission_days"         // 返佣有效天数（0 表示永久）
+	SettingKeyInviteCommissionMinWithdraw = "invite_commission_min_withdraw" // 最低提现额度
+
 	// 管理员 API Key
 	SettingKeyAdminAPIKey = "admin_api_key" // 全局管理员 API Key（用于外部系统集成）
 
@@ -178,6 +207,20 @@ const (
 
 	// SettingKeyStreamTimeoutSettings stores JSON config for stream timeout handling.
 	SettingKeyStreamTimeoutSettings = "stream_timeout_settings"
+
+	// =========================
+	// Model Rate Limit Scopes
+	// =========================
+
+	// SettingKeyModelRateLimitScopes stores JSON rules mapping model patterns to per-platform rate limit scopes.
+	SettingKeyModelRateLimitScopes = "model_rate_limit_scopes"
+
+	// =========================
+	// IP Protection
+	// =========================
+
+	// SettingKeyIPAccessRules stores JSON allow/deny rules (IP or CIDR) enforced on gateway and auth routes.
+	SettingKeyIPAccessRules = "ip_access_rules"
 )
 
 // AdminAPIKeyPrefix is the prefix for admin API keys (distinct from user "sk-" keys).
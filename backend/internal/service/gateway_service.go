@@ -146,6 +146,9 @@ func shouldClearStickySession(account *Account) bool {
 	if account.TempUnschedulableUntil != nil && time.Now().Before(*account.TempUnschedulableUntil) {
 		return true
 	}
+	if account.ScheduleStateAt(time.Now()) == AccountScheduleClosed {
+		return true
+	}
 	return false
 }
 
@@ -214,6 +217,8 @@ type GatewayService struct {
 	concurrencyService  *ConcurrencyService
 	claudeTokenProvider *ClaudeTokenProvider
 	sessionLimitCache   SessionLimitCache // 会话数量限制缓存（仅 Anthropic OAuth/SetupToken）
+
+	accountDedicationService *AccountDedicationService // 用户/API Key 专属账号隔离
 }
 
 // NewGatewayService creates a new GatewayService
@@ -235,6 +240,7 @@ func NewGatewayService(
 	deferredService *DeferredService,
 	claudeTokenProvider *ClaudeTokenProvider,
 	sessionLimitCache SessionLimitCache,
+	accountDedicationService *AccountDedicationService,
 ) *GatewayService {
 	return &GatewayService{
 		accountRepo:         accountRepo,
@@ -254,6 +260,8 @@ func NewGatewayService(
 		deferredService:     deferredService,
 		claudeTokenProvider: claudeTokenProvider,
 		sessionLimitCache:   sessionLimitCache,
+
+		accountDedicationService: accountDedicationService,
 	}
 }
 
@@ -583,6 +591,66 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 		return excluded
 	}
 
+	// ============ Layer 0: 用户/API Key 专属账号优先 ============
+	// 专属账号有空闲槽位时直接使用，全部繁忙时继续后续层级。
+	// 独占模式下 accounts 已只包含专属账号，后续层级只会在专属账号上排队。
+	if affinity := AccountAffinityFromContext(ctx); affinity != nil {
+		var dedicated []*Account
+		for i := range accounts {
+			acc := &accounts[i]
+			if !affinity.Contains(acc.ID) || isExcluded(acc.ID) {
+				continue
+			}
+			if !acc.IsSchedulable() ||
+				(acc.ID != stickyAccountID && acc.IsDraining()) ||
+				!s.isAccountAllowedForPlatform(acc, platform, useMixed) ||
+				!acc.IsSchedulableForModel(requestedModel) ||
+				(requestedModel != "" && !s.isModelSupportedByAccount(acc, requestedModel)) ||
+				!s.isAccountSchedulableForWindowCost(ctx, acc, acc.ID == stickyAccountID) {
+				continue
+			}
+			dedicated = append(dedicated, acc)
+		}
+		if len(dedicated) > 0 {
+			dedicatedLoads := make([]AccountWithConcurrency, 0, len(dedicated))
+			for _, acc := range dedicated {
+				dedicatedLoads = append(dedicatedLoads, AccountWithConcurrency{ID: acc.ID, MaxConcurrency: acc.Concurrency})
+			}
+			dedicatedLoadMap, _ := s.concurrencyService.GetAccountsLoadBatch(ctx, dedicatedLoads)
+			var dedicatedAvailable []SchedulingCandidate
+			for _, acc := range dedicated {
+				loadInfo := dedicatedLoadMap[acc.ID]
+				if loadInfo == nil {
+					loadInfo = &AccountLoadInfo{AccountID: acc.ID}
+				}
+				if loadInfo.LoadRate < 100 {
+					dedicatedAvailable = append(dedicatedAvailable, SchedulingCandidate{Account: acc, Load: loadInfo})
+				}
+			}
+			s.orderSchedulingCandidates(ctx, group, dedicatedAvailable, preferOAuth)
+			// 粘性会话绑定的专属账号优先，保持会话连续
+			moveSchedulingCandidateToFront(dedicatedAvailable, stickyAccountID)
+
+			for _, item := range dedicatedAvailable {
+				result, err := s.tryAcquireAccountSlot(ctx, item.Account.ID, item.Account.Concurrency)
+				if err == nil && result.Acquired {
+					if !s.checkAndRegisterSession(ctx, item.Account, sessionHash) {
+						result.ReleaseFunc() // 释放槽位，继续尝试下一个账号
+						continue
+					}
+					if sessionHash != "" && s.cache != nil {
+						_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, item.Account.ID, stickySessionTTL)
+					}
+					return &AccountSelectionResult{
+						Account:     item.Account,
+						Acquired:    true,
+						ReleaseFunc: result.ReleaseFunc,
+					}, nil
+				}
+			}
+		}
+	}
+
 	// 提前构建 accountByID（供 Layer 1 和 Layer 1.5 使用）
 	accountByID := make(map[int64]*Account, len(accounts))
 	for i := range accounts {
@@ -622,7 +690,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 				continue
 			}
 			account, ok := accountByID[routingAccountID]
-			if !ok || !account.IsSchedulable() {
+			if !ok || !account.IsSchedulableForNewSession() {
 				if !ok {
 					filteredMissing++
 				} else {
@@ -723,60 +791,38 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 			routingLoadMap, _ := s.concurrencyService.GetAccountsLoadBatch(ctx, routingLoads)
 
 			// 3. 按负载感知排序
-			type accountWithLoad struct {
-				account  *Account
-				loadInfo *AccountLoadInfo
-			}
-			var routingAvailable []accountWithLoad
+			var routingAvailable []SchedulingCandidate
 			for _, acc := range routingCandidates {
 				loadInfo := routingLoadMap[acc.ID]
 				if loadInfo == nil {
 					loadInfo = &AccountLoadInfo{AccountID: acc.ID}
 				}
 				if loadInfo.LoadRate < 100 {
-					routingAvailable = append(routingAvailable, accountWithLoad{account: acc, loadInfo: loadInfo})
+					routingAvailable = append(routingAvailable, SchedulingCandidate{Account: acc, Load: loadInfo})
 				}
 			}
 
 			if len(routingAvailable) > 0 {
-				// 排序：优先级 > 负载率 > 最后使用时间
-				sort.SliceStable(routingAvailable, func(i, j int) bool {
-					a, b := routingAvailable[i], routingAvailable[j]
-					if a.account.Priority != b.account.Priority {
-						return a.account.Priority < b.account.Priority
-					}
-					if a.loadInfo.LoadRate != b.loadInfo.LoadRate {
-						return a.loadInfo.LoadRate < b.loadInfo.LoadRate
-					}
-					switch {
-					case a.account.LastUsedAt == nil && b.account.LastUsedAt != nil:
-						return true
-					case a.account.LastUsedAt != nil && b.account.LastUsedAt == nil:
-						return false
-					case a.account.LastUsedAt == nil && b.account.LastUsedAt == nil:
-						return false
-					default:
-						return a.account.LastUsedAt.Before(*b.account.LastUsedAt)
-					}
-				})
+				// 排序：优先级 > 分组调度策略（默认：负载率 > 最后使用时间）
+				s.orderSchedulingCandidates(ctx, group, routingAvailable, false)
 
 				// 4. 尝试获取槽位
 				for _, item := range routingAvailable {
-					result, err := s.tryAcquireAccountSlot(ctx, item.account.ID, item.account.Concurrency)
+					result, err := s.tryAcquireAccountSlot(ctx, item.Account.ID, item.Account.Concurrency)
 					if err == nil && result.Acquired {
 						// 会话数量限制检查
-						if !s.checkAndRegisterSession(ctx, item.account, sessionHash) {
+						if !s.checkAndRegisterSession(ctx, item.Account, sessionHash) {
 							result.ReleaseFunc() // 释放槽位，继续尝试下一个账号
 							continue
 						}
 						if sessionHash != "" && s.cache != nil {
-							_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, item.account.ID, stickySessionTTL)
+							_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, item.Account.ID, stickySessionTTL)
 						}
 						if s.debugModelRoutingEnabled() {
-							log.Printf("[ModelRoutingDebug] routed select: group_id=%v model=%s session=%s account=%d", derefGroupID(groupID), requestedModel, shortSessionHash(sessionHash), item.account.ID)
+							log.Printf("[ModelRoutingDebug] routed select: group_id=%v model=%s session=%s account=%d", derefGroupID(groupID), requestedModel, shortSessionHash(sessionHash), item.Account.ID)
 						}
 						return &AccountSelectionResult{
-							Account:     item.account,
+							Account:     item.Account,
 							Acquired:    true,
 							ReleaseFunc: result.ReleaseFunc,
 						}, nil
@@ -786,17 +832,17 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 				// 5. 所有路由账号槽位满，尝试返回等待计划（选择负载最低的）
 				// 遍历找到第一个满足会话限制的账号
 				for _, item := range routingAvailable {
-					if !s.checkAndRegisterSession(ctx, item.account, sessionHash) {
+					if !s.checkAndRegisterSession(ctx, item.Account, sessionHash) {
 						continue // 会话限制已满，尝试下一个
 					}
 					if s.debugModelRoutingEnabled() {
-						log.Printf("[ModelRoutingDebug] routed wait: group_id=%v model=%s session=%s account=%d", derefGroupID(groupID), requestedModel, shortSessionHash(sessionHash), item.account.ID)
+						log.Printf("[ModelRoutingDebug] routed wait: group_id=%v model=%s session=%s account=%d", derefGroupID(groupID), requestedModel, shortSessionHash(sessionHash), item.Account.ID)
 					}
 					return &AccountSelectionResult{
-						Account: item.account,
+						Account: item.Account,
 						WaitPlan: &AccountWaitPlan{
-							AccountID:      item.account.ID,
-							MaxConcurrency: item.account.Concurrency,
+							AccountID:      item.Account.ID,
+							MaxConcurrency: item.Account.Concurrency,
 							Timeout:        cfg.StickySessionWaitTimeout,
 							MaxWaiting:     cfg.StickySessionMaxWaiting,
 						},
@@ -876,7 +922,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 		// Scheduler snapshots can be temporarily stale (bucket rebuild is throttled);
 		// re-check schedulability here so recently rate-limited/overloaded accounts
 		// are not selected again before the bucket is rebuilt.
-		if !acc.IsSchedulable() {
+		if !acc.IsSchedulableForNewSession() {
 			continue
 		}
 		if !s.isAccountAllowedForPlatform(acc, platform, useMixed) {
@@ -913,61 +959,33 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 			return result, nil
 		}
 	} else {
-		type accountWithLoad struct {
-			account  *Account
-			loadInfo *AccountLoadInfo
-		}
-		var available []accountWithLoad
+		var available []SchedulingCandidate
 		for _, acc := range candidates {
 			loadInfo := loadMap[acc.ID]
 			if loadInfo == nil {
 				loadInfo = &AccountLoadInfo{AccountID: acc.ID}
 			}
 			if loadInfo.LoadRate < 100 {
-				available = append(available, accountWithLoad{
-					account:  acc,
-					loadInfo: loadInfo,
-				})
+				available = append(available, SchedulingCandidate{Account: acc, Load: loadInfo})
 			}
 		}
 
 		if len(available) > 0 {
-			sort.SliceStable(available, func(i, j int) bool {
-				a, b := available[i], available[j]
-				if a.account.Priority != b.account.Priority {
-					return a.account.Priority < b.account.Priority
-				}
-				if a.loadInfo.LoadRate != b.loadInfo.LoadRate {
-					return a.loadInfo.LoadRate < b.loadInfo.LoadRate
-				}
-				switch {
-				case a.account.LastUsedAt == nil && b.account.LastUsedAt != nil:
-					return true
-				case a.account.LastUsedAt != nil && b.account.LastUsedAt == nil:
-					return false
-				case a.account.LastUsedAt == nil && b.account.LastUsedAt == nil:
-					if preferOAuth && a.account.Type != b.account.Type {
-						return a.account.Type == AccountTypeOAuth
-					}
-					return false
-				default:
-					return a.account.LastUsedAt.Before(*b.account.LastUsedAt)
-				}
-			})
+			s.orderSchedulingCandidates(ctx, group, available, preferOAuth)
 
 			for _, item := range available {
-				result, err := s.tryAcquireAccountSlot(ctx, item.account.ID, item.account.Concurrency)
+				result, err := s.tryAcquireAccountSlot(ctx, item.Account.ID, item.Account.Concurrency)
 				if err == nil && result.Acquired {
 					// 会话数量限制检查
-					if !s.checkAndRegisterSession(ctx, item.account, sessionHash) {
+					if !s.checkAndRegisterSession(ctx, item.Account, sessionHash) {
 						result.ReleaseFunc() // 释放槽位，继续尝试下一个账号
 						continue
 					}
 					if sessionHash != "" && s.cache != nil {
-						_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, item.account.ID, stickySessionTTL)
+						_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, item.Account.ID, stickySessionTTL)
 					}
 					return &AccountSelectionResult{
-						Account:     item.account,
+						Account:     item.Account,
 						Acquired:    true,
 						ReleaseFunc: result.ReleaseFunc,
 					}, nil
@@ -1024,7 +1042,7 @@ func (s *GatewayService) tryAcquireByLegacyOrder(ctx context.Context, candidates
 
 func (s *GatewayService) schedulingConfig() config.GatewaySchedulingConfig {
 	if s.cfg != nil {
-		return s.cfg.Gateway.Scheduling
+		return s.cfg.Live().Gateway.Scheduling
 	}
 	return config.GatewaySchedulingConfig{
 		StickySessionMaxWaiting:  3,
@@ -1159,7 +1177,24 @@ func (s *GatewayService) resolvePlatform(ctx context.Context, groupID *int64, gr
 	return PlatformAnthropic, false, nil
 }
 
+// listSchedulableAccounts 获取可调度账号，并按请求的专属账号配置过滤（其他用户的专属账号不参与调度）；
+// 虚拟模型请求只保留当前目标平台的账号，分流请求只保留所属实验组的账号池
 func (s *GatewayService) listSchedulableAccounts(ctx context.Context, groupID *int64, platform string, hasForcePlatform bool) ([]Account, bool, error) {
+	accounts, useMixed, err := s.listGroupSchedulableAccounts(ctx, groupID, platform, hasForcePlatform)
+	if err != nil {
+		return accounts, useMixed, err
+	}
+	accounts = filterAccountsForModelAliasTarget(ctx, accounts)
+	accounts = filterAccountsForTrafficRoute(ctx, accounts)
+	return s.accountDedicationService.FilterAccounts(ctx, AccountAffinityFromContext(ctx), accounts), useMixed, nil
+}
+
+// isAccountDedicationAllowed 专属账号隔离检查（用于粘性会话等按账号 ID 直接取账号的路径）
+func (s *GatewayService) isAccountDedicationAllowed(ctx context.Context, accountID int64) bool {
+	return s.accountDedicationService.IsAccountAllowed(ctx, AccountAffinityFromContext(ctx), accountID)
+}
+
+func (s *GatewayService) listGroupSchedulableAccounts(ctx context.Context, groupID *int64, platform string, hasForcePlatform bool) ([]Account, bool, error) {
 	if s.schedulerSnapshot != nil {
 		accounts, useMixed, err := s.schedulerSnapshot.ListSchedulableAccounts(ctx, groupID, platform, hasForcePlatform)
 		if err == nil {
@@ -1305,36 +1340,11 @@ func (s *GatewayService) isAccountSchedulableForWindowCost(ctx context.Context,
 		return true // 未启用窗口费用限制
 	}
 
-	// 尝试从缓存获取窗口费用
-	var currentCost float64
-	if s.sessionLimitCache != nil {
-		if cost, hit, err := s.sessionLimitCache.GetWindowCost(ctx, account.ID); err == nil && hit {
-			currentCost = cost
-			goto checkSchedulability
-		}
-	}
-
-	// 缓存未命中，从数据库查询
-	{
-		// 使用统一的窗口开始时间计算逻辑（考虑窗口过期情况）
-		startTime := account.GetCurrentWindowStartTime()
-
-		stats, err := s.usageLogRepo.GetAccountWindowStats(ctx, account.ID, startTime)
-		if err != nil {
-			// 失败开放：查询失败时允许调度
-			return true
-		}
-
-		// 使用标准费用（不含账号倍率）
-		currentCost = stats.StandardCost
-
-		// 设置缓存（忽略错误）
-		if s.sessionLimitCache != nil {
-			_ = s.sessionLimitCache.SetWindowCost(ctx, account.ID, currentCost)
-		}
+	currentCost, ok := s.getAccountWindowCost(ctx, account)
+	if !ok {
+		// 失败开放：查询失败时允许调度
+		return true
 	}
-
-checkSchedulability:
 	schedulability := account.CheckWindowCostSchedulability(currentCost)
 
 	switch schedulability {
@@ -1348,6 +1358,35 @@ checkSchedulability:
 	return true
 }
 
+// getAccountWindowCost 获取账号当前 5h 窗口的标准费用（不含账号倍率）
+// 优先读缓存，未命中时查询数据库并回填缓存；查询失败返回 false
+func (s *GatewayService) getAccountWindowCost(ctx context.Context, account *Account) (float64, bool) {
+	if s.sessionLimitCache != nil {
+		if cost, hit, err := s.sessionLimitCache.GetWindowCost(ctx, account.ID); err == nil && hit {
+			return cost, true
+		}
+	}
+	if s.usageLogRepo == nil {
+		return 0, false
+	}
+
+	// 使用统一的窗口开始时间计算逻辑（考虑窗口过期情况）
+	startTime := account.GetCurrentWindowStartTime()
+	stats, err := s.usageLogRepo.GetAccountWindowStats(ctx, account.ID, startTime)
+	if err != nil {
+		return 0, false
+	}
+
+	// 使用标准费用（不含账号倍率）
+	currentCost := stats.StandardCost
+
+	// 设置缓存（忽略错误）
+	if s.sessionLimitCache != nil {
+		_ = s.sessionLimitCache.SetWindowCost(ctx, account.ID, currentCost)
+	}
+	return currentCost, true
+}
+
 // checkAndRegisterSession 检查并注册会话，用于会话数量限制
 // 仅适用于 Anthropic OAuth/SetupToken 账号
 // sessionID: 会话标识符（使用粘性会话的 hash）
@@ -1484,7 +1523,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 						if clearSticky {
 							_ = s.cache.DeleteSessionAccountID(ctx, derefGroupID(groupID), sessionHash)
 						}
-						if !clearSticky && s.isAccountInGroup(account, groupID) && account.Platform == platform && account.IsSchedulableForModel(requestedModel) && (requestedModel == "" || s.isModelSupportedByAccount(account, requestedModel)) {
+						if !clearSticky && s.isAccountInGroup(account, groupID) && s.isAccountDedicationAllowed(ctx, account.ID) && isAccountAllowedForModelAliasTarget(ctx, account) && isAccountAllowedForTrafficRoute(ctx, account.ID) && account.Platform == platform && account.IsSchedulableForModel(requestedModel) && (requestedModel == "" || s.isModelSupportedByAccount(account, requestedModel)) {
 							if err := s.cache.RefreshSessionTTL(ctx, derefGroupID(groupID), sessionHash, stickySessionTTL); err != nil {
 								log.Printf("refresh session ttl failed: session=%s err=%v", sessionHash, err)
 							}
@@ -1528,7 +1567,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 			}
 			// Scheduler snapshots can be temporarily stale; re-check schedulability here to
 			// avoid selecting accounts that were recently rate-limited/overloaded.
-			if !acc.IsSchedulable() {
+			if !acc.IsSchedulableForNewSession() {
 				continue
 			}
 			if !acc.IsSchedulableForModel(requestedModel) {
@@ -1587,7 +1626,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 					if clearSticky {
 						_ = s.cache.DeleteSessionAccountID(ctx, derefGroupID(groupID), sessionHash)
 					}
-					if !clearSticky && s.isAccountInGroup(account, groupID) && account.Platform == platform && account.IsSchedulableForModel(requestedModel) && (requestedModel == "" || s.isModelSupportedByAccount(account, requestedModel)) {
+					if !clearSticky && s.isAccountInGroup(account, groupID) && s.isAccountDedicationAllowed(ctx, account.ID) && isAccountAllowedForModelAliasTarget(ctx, account) && isAccountAllowedForTrafficRoute(ctx, account.ID) && account.Platform == platform && account.IsSchedulableForModel(requestedModel) && (requestedModel == "" || s.isModelSupportedByAccount(account, requestedModel)) {
 						if err := s.cache.RefreshSessionTTL(ctx, derefGroupID(groupID), sessionHash, stickySessionTTL); err != nil {
 							log.Printf("refresh session ttl failed: session=%s err=%v", sessionHash, err)
 						}
@@ -1620,7 +1659,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 		}
 		// Scheduler snapshots can be temporarily stale; re-check schedulability here to
 		// avoid selecting accounts that were recently rate-limited/overloaded.
-		if !acc.IsSchedulable() {
+		if !acc.IsSchedulableForNewSession() {
 			continue
 		}
 		if !acc.IsSchedulableForModel(requestedModel) {
@@ -1697,7 +1736,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 						if clearSticky {
 							_ = s.cache.DeleteSessionAccountID(ctx, derefGroupID(groupID), sessionHash)
 						}
-						if !clearSticky && s.isAccountInGroup(account, groupID) && account.IsSchedulableForModel(requestedModel) && (requestedModel == "" || s.isModelSupportedByAccount(account, requestedModel)) {
+						if !clearSticky && s.isAccountInGroup(account, groupID) && s.isAccountDedicationAllowed(ctx, account.ID) && isAccountAllowedForModelAliasTarget(ctx, account) && isAccountAllowedForTrafficRoute(ctx, account.ID) && account.IsSchedulableForModel(requestedModel) && (requestedModel == "" || s.isModelSupportedByAccount(account, requestedModel)) {
 							if account.Platform == nativePlatform || (account.Platform == PlatformAntigravity && account.IsMixedSchedulingEnabled()) {
 								if err := s.cache.RefreshSessionTTL(ctx, derefGroupID(groupID), sessionHash, stickySessionTTL); err != nil {
 									log.Printf("refresh session ttl failed: session=%s err=%v", sessionHash, err)
@@ -1739,7 +1778,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 			}
 			// Scheduler snapshots can be temporarily stale; re-check schedulability here to
 			// avoid selecting accounts that were recently rate-limited/overloaded.
-			if !acc.IsSchedulable() {
+			if !acc.IsSchedulableForNewSession() {
 				continue
 			}
 			// 过滤：原生平台直接通过，antigravity 需要启用混合调度
@@ -1802,7 +1841,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 					if clearSticky {
 						_ = s.cache.DeleteSessionAccountID(ctx, derefGroupID(groupID), sessionHash)
 					}
-					if !clearSticky && s.isAccountInGroup(account, groupID) && account.IsSchedulableForModel(requestedModel) && (requestedModel == "" || s.isModelSupportedByAccount(account, requestedModel)) {
+					if !clearSticky && s.isAccountInGroup(account, groupID) && s.isAccountDedicationAllowed(ctx, account.ID) && isAccountAllowedForModelAliasTarget(ctx, account) && isAccountAllowedForTrafficRoute(ctx, account.ID) && account.IsSchedulableForModel(requestedModel) && (requestedModel == "" || s.isModelSupportedByAccount(account, requestedModel)) {
 						if account.Platform == nativePlatform || (account.Platform == PlatformAntigravity && account.IsMixedSchedulingEnabled()) {
 							if err := s.cache.RefreshSessionTTL(ctx, derefGroupID(groupID), sessionHash, stickySessionTTL); err != nil {
 								log.Printf("refresh session ttl failed: session=%s err=%v", sessionHash, err)
@@ -1833,7 +1872,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 		}
 		// Scheduler snapshots can be temporarily stale; re-check schedulability here to
 		// avoid selecting accounts that were recently rate-limited/overloaded.
-		if !acc.IsSchedulable() {
+		if !acc.IsSchedulableForNewSession() {
 			continue
 		}
 		// 过滤：原生平台直接通过，antigravity 需要启用混合调度
@@ -2373,8 +2412,8 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 						Kind:               "signature_error",
 						Message:            extractUpstreamErrorMessage(respBody),
 						Detail: func() string {
-							if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-								return truncateString(string(respBody), s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes)
+							if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+								return truncateString(string(respBody), s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes)
 							}
 							return ""
 						}(),
@@ -2425,8 +2464,8 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 									Kind:               "signature_retry_thinking",
 									Message:            extractUpstreamErrorMessage(retryRespBody),
 									Detail: func() string {
-										if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-											return truncateString(string(retryRespBody), s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes)
+										if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+											return truncateString(string(retryRespBody), s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes)
 										}
 										return ""
 									}(),
@@ -2513,8 +2552,8 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 					Kind:               "retry",
 					Message:            extractUpstreamErrorMessage(respBody),
 					Detail: func() string {
-						if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-							return truncateString(string(respBody), s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes)
+						if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+							return truncateString(string(respBody), s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes)
 						}
 						return ""
 					}(),
@@ -2556,7 +2595,7 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 			log.Printf("[Forward] Upstream error (retry exhausted, failover): Account=%d(%s) Status=%d RequestID=%s Body=%s",
 				account.ID, account.Name, resp.StatusCode, resp.Header.Get("x-request-id"), truncateString(string(respBody), 1000))
 
-			s.handleRetryExhaustedSideEffects(ctx, resp, account)
+			s.handleRetryExhaustedSideEffects(ctx, resp, account, reqModel)
 			appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
 				Platform:           account.Platform,
 				AccountID:          account.ID,
@@ -2566,15 +2605,15 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 				Kind:               "retry_exhausted_failover",
 				Message:            extractUpstreamErrorMessage(respBody),
 				Detail: func() string {
-					if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-						return truncateString(string(respBody), s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes)
+					if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+						return truncateString(string(respBody), s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes)
 					}
 					return ""
 				}(),
 			})
 			return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode}
 		}
-		return s.handleRetryExhaustedError(ctx, resp, c, account)
+		return s.handleRetryExhaustedError(ctx, resp, c, account, reqModel)
 	}
 
 	// 处理可切换账号的错误
@@ -2587,7 +2626,7 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 		log.Printf("[Forward] Upstream error (failover): Account=%d(%s) Status=%d RequestID=%s Body=%s",
 			account.ID, account.Name, resp.StatusCode, resp.Header.Get("x-request-id"), truncateString(string(respBody), 1000))
 
-		s.handleFailoverSideEffects(ctx, resp, account)
+		s.handleFailoverSideEffects(ctx, resp, account, reqModel)
 		appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
 			Platform:           account.Platform,
 			AccountID:          account.ID,
@@ -2596,8 +2635,8 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 			Kind:               "failover",
 			Message:            extractUpstreamErrorMessage(respBody),
 			Detail: func() string {
-				if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-					return truncateString(string(respBody), s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes)
+				if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+					return truncateString(string(respBody), s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes)
 				}
 				return ""
 			}(),
@@ -2608,11 +2647,11 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 	// 处理错误响应（不可重试的错误）
 	if resp.StatusCode >= 400 {
 		// 可选：对部分 400 触发 failover（默认关闭以保持语义）
-		if resp.StatusCode == 400 && s.cfg != nil && s.cfg.Gateway.FailoverOn400 {
+		if resp.StatusCode == 400 && s.cfg != nil && s.cfg.Live().Gateway.FailoverOn400 {
 			respBody, readErr := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 			if readErr != nil {
 				// ReadAll failed, fall back to normal error handling without consuming the stream
-				return s.handleErrorResponse(ctx, resp, c, account)
+				return s.handleErrorResponse(ctx, resp, c, account, reqModel)
 			}
 			_ = resp.Body.Close()
 			resp.Body = io.NopCloser(bytes.NewReader(respBody))
@@ -2621,8 +2660,8 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 				upstreamMsg := strings.TrimSpace(extractUpstreamErrorMessage(respBody))
 				upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
 				upstreamDetail := ""
-				if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-					maxBytes := s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+				if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+					maxBytes := s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 					if maxBytes <= 0 {
 						maxBytes = 2048
 					}
@@ -2639,20 +2678,20 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 					Detail:             upstreamDetail,
 				})
 
-				if s.cfg.Gateway.LogUpstreamErrorBody {
+				if s.cfg.Live().Gateway.LogUpstreamErrorBody {
 					log.Printf(
 						"Account %d: 400 error, attempting failover: %s",
 						account.ID,
-						truncateForLog(respBody, s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes),
+						truncateForLog(respBody, s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes),
 					)
 				} else {
 					log.Printf("Account %d: 400 error, attempting failover", account.ID)
 				}
-				s.handleFailoverSideEffects(ctx, resp, account)
+				s.handleFailoverSideEffects(ctx, resp, account, reqModel)
 				return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode}
 			}
 		}
-		return s.handleErrorResponse(ctx, resp, c, account)
+		return s.handleErrorResponse(ctx, resp, c, account, reqModel)
 	}
 
 	// 处理正常响应
@@ -2764,7 +2803,7 @@ func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Contex
 	// 处理anthropic-beta header（OAuth账号需要特殊处理）
 	if tokenType == "oauth" {
 		req.Header.Set("anthropic-beta", s.getBetaHeader(modelID, c.GetHeader("anthropic-beta")))
-	} else if s.cfg != nil && s.cfg.Gateway.InjectBetaForAPIKey && req.Header.Get("anthropic-beta") == "" {
+	} else if s.cfg != nil && s.cfg.Live().Gateway.InjectBetaForAPIKey && req.Header.Get("anthropic-beta") == "" {
 		// API-key：仅在请求显式使用 beta 特性且客户端未提供时，按需补齐（默认关闭）
 		if requestNeedsBetaFeatures(body) {
 			if beta := defaultAPIKeyBetaHeader(body); beta != "" {
@@ -2935,7 +2974,7 @@ func extractUpstreamErrorMessage(body []byte) string {
 	return gjson.GetBytes(body, "message").String()
 }
 
-func (s *GatewayService) handleErrorResponse(ctx context.Context, resp *http.Response, c *gin.Context, account *Account) (*ForwardResult, error) {
+func (s *GatewayService) handleErrorResponse(ctx context.Context, resp *http.Response, c *gin.Context, account *Account, requestedModel string) (*ForwardResult, error) {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 
 	// 调试日志：打印上游错误响应
@@ -2947,8 +2986,8 @@ func (s *GatewayService) handleErrorResponse(ctx context.Context, resp *http.Res
 
 	// Enrich Ops error logs with upstream status + message, and optionally a truncated body snippet.
 	upstreamDetail := ""
-	if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-		maxBytes := s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+	if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+		maxBytes := s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 		if maxBytes <= 0 {
 			maxBytes = 2048
 		}
@@ -2968,21 +3007,21 @@ func (s *GatewayService) handleErrorResponse(ctx context.Context, resp *http.Res
 	// 处理上游错误，标记账号状态
 	shouldDisable := false
 	if s.rateLimitService != nil {
-		shouldDisable = s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, body)
+		shouldDisable = s.rateLimitService.HandleUpstreamError(ctx, account, requestedModel, resp.StatusCode, resp.Header, body)
 	}
 	if shouldDisable {
 		return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode}
 	}
 
 	// 记录上游错误响应体摘要便于排障（可选：由配置控制；不回显到客户端）
-	if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
+	if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
 		log.Printf(
 			"Upstream error %d (account=%d platform=%s type=%s): %s",
 			resp.StatusCode,
 			account.ID,
 			account.Platform,
 			account.Type,
-			truncateForLog(body, s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes),
+			truncateForLog(body, s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes),
 		)
 	}
 
@@ -3042,13 +3081,13 @@ func (s *GatewayService) handleErrorResponse(ctx context.Context, resp *http.Res
 	return nil, fmt.Errorf("upstream error: %d message=%s", resp.StatusCode, upstreamMsg)
 }
 
-func (s *GatewayService) handleRetryExhaustedSideEffects(ctx context.Context, resp *http.Response, account *Account) {
+func (s *GatewayService) handleRetryExhaustedSideEffects(ctx context.Context, resp *http.Response, account *Account, requestedModel string) {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 	statusCode := resp.StatusCode
 
 	// OAuth/Setup Token 账号的 403：标记账号异常
 	if account.IsOAuth() && statusCode == 403 {
-		s.rateLimitService.HandleUpstreamError(ctx, account, statusCode, resp.Header, body)
+		s.rateLimitService.HandleUpstreamError(ctx, account, requestedModel, statusCode, resp.Header, body)
 		log.Printf("Account %d: marked as error after %d retries for status %d", account.ID, maxRetryAttempts, statusCode)
 	} else {
 		// API Key 未配置错误码：不标记账号状态
@@ -3056,27 +3095,27 @@ func (s *GatewayService) handleRetryExhaustedSideEffects(ctx context.Context, re
 	}
 }
 
-func (s *GatewayService) handleFailoverSideEffects(ctx context.Context, resp *http.Response, account *Account) {
+func (s *GatewayService) handleFailoverSideEffects(ctx context.Context, resp *http.Response, account *Account, requestedModel string) {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
-	s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, body)
+	s.rateLimitService.HandleUpstreamError(ctx, account, requestedModel, resp.StatusCode, resp.Header, body)
 }
 
 // handleRetryExhaustedError 处理重试耗尽后的错误
 // OAuth 403：标记账号异常
 // API Key 未配置错误码：仅返回错误，不标记账号
-func (s *GatewayService) handleRetryExhaustedError(ctx context.Context, resp *http.Response, c *gin.Context, account *Account) (*ForwardResult, error) {
+func (s *GatewayService) handleRetryExhaustedError(ctx context.Context, resp *http.Response, c *gin.Context, account *Account, requestedModel string) (*ForwardResult, error) {
 	// Capture upstream error body before side-effects consume the stream.
 	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 	_ = resp.Body.Close()
 	resp.Body = io.NopCloser(bytes.NewReader(respBody))
 
-	s.handleRetryExhaustedSideEffects(ctx, resp, account)
+	s.handleRetryExhaustedSideEffects(ctx, resp, account, requestedModel)
 
 	upstreamMsg := strings.TrimSpace(extractUpstreamErrorMessage(respBody))
 	upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
 	upstreamDetail := ""
-	if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-		maxBytes := s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+	if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+		maxBytes := s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 		if maxBytes <= 0 {
 			maxBytes = 2048
 		}
@@ -3093,14 +3132,14 @@ func (s *GatewayService) handleRetryExhaustedError(ctx context.Context, resp *ht
 		Detail:             upstreamDetail,
 	})
 
-	if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
+	if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
 		log.Printf(
 			"Upstream error %d retries_exhausted (account=%d platform=%s type=%s): %s",
 			resp.StatusCode,
 			account.ID,
 			account.Platform,
 			account.Type,
-			truncateForLog(respBody, s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes),
+			truncateForLog(respBody, s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes),
 		)
 	}
 
@@ -3156,8 +3195,8 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 	scanner := bufio.NewScanner(resp.Body)
 	// 设置更大的buffer以处理长行
 	maxLineSize := defaultMaxLineSize
-	if s.cfg != nil && s.cfg.Gateway.MaxLineSize > 0 {
-		maxLineSize = s.cfg.Gateway.MaxLineSize
+	if s.cfg != nil && s.cfg.Live().Gateway.MaxLineSize > 0 {
+		maxLineSize = s.cfg.Live().Gateway.MaxLineSize
 	}
 	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
 
@@ -3193,8 +3232,8 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 	defer close(done)
 
 	streamInterval := time.Duration(0)
-	if s.cfg != nil && s.cfg.Gateway.StreamDataIntervalTimeout > 0 {
-		streamInterval = time.Duration(s.cfg.Gateway.StreamDataIntervalTimeout) * time.Second
+	if s.cfg != nil && s.cfg.Live().Gateway.StreamDataIntervalTimeout > 0 {
+		streamInterval = time.Duration(s.cfg.Live().Gateway.StreamDataIntervalTimeout) * time.Second
 	}
 	// 仅监控上游数据间隔超时，避免下游写入阻塞导致误判
 	var intervalTicker *time.Ticker
@@ -3457,6 +3496,7 @@ type RecordUsageInput struct {
 	Subscription *UserSubscription // 可选：订阅信息
 	UserAgent    string            // 请求的 User-Agent
 	IPAddress    string            // 请求的客户端 IP 地址
+	TrafficArm   string            // 灰度 / A-B 分流实验组（未启用分流时为空）
 }
 
 // RecordUsage 记录使用量并扣费（或更新订阅用量）
@@ -3472,6 +3512,7 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 	if apiKey.GroupID != nil && apiKey.Group != nil {
 		multiplier = apiKey.Group.RateMultiplier
 	}
+	multiplier = overageRateMultiplier(apiKey, multiplier)
 
 	var cost *CostBreakdown
 
@@ -3553,6 +3594,9 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 	if input.IPAddress != "" {
 		usageLog.IPAddress = &input.IPAddress
 	}
+	if input.TrafficArm != "" {
+		usageLog.TrafficArm = &input.TrafficArm
+	}
 
 	// 添加分组和订阅关联
 	if apiKey.GroupID != nil {
@@ -3561,6 +3605,7 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 	if subscription != nil {
 		usageLog.SubscriptionID = &subscription.ID
 	}
+	applySubscriptionOverage(apiKey, usageLog)
 
 	inserted, err := s.usageLogRepo.Create(ctx, usageLog)
 	if err != nil {
@@ -3577,13 +3622,28 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 
 	// 根据计费类型执行扣费
 	if isSubscriptionBilling {
+		// 订阅 token / 请求次数额度：零费用请求同样计入
+		if shouldBill {
+			recordSubscriptionQuotaUsage(s.billingCacheService, subscription, apiKey.Group, usageLog)
+		}
 		// 订阅模式：更新订阅用量（使用 TotalCost 原始费用，不考虑倍率）
 		if shouldBill && cost.TotalCost > 0 {
 			if err := s.userSubRepo.IncrementUsage(ctx, subscription.ID, cost.TotalCost); err != nil {
 				log.Printf("Increment subscription usage failed: %v", err)
 			}
-			// 异步更新订阅缓存
-			s.billingCacheService.QueueUpdateSubscriptionUsage(user.ID, *apiKey.GroupID, cost.TotalCost)
+			// 异步更新订阅缓存（组织订阅挂在所有者名下）
+			s.billingCacheService.QueueUpdateSubscriptionUsage(subscription.UserID, *apiKey.GroupID, cost.TotalCost)
+			// 组织 Key：累计成员月度消费
+			if err := s.billingCacheService.ChargeOrganizationUsage(ctx, apiKey, user.ID, cost.TotalCost, false); err != nil {
+				log.Printf("Charge organization usage failed: %v", err)
+			}
+		}
+	} else if apiKey.IsOrganizationKey() {
+		// 组织 Key：扣除组织共享余额并累计成员月度消费
+		if shouldBill && cost.ActualCost > 0 {
+			if err := s.billingCacheService.ChargeOrganizationUsage(ctx, apiKey, user.ID, cost.ActualCost, true); err != nil {
+				log.Printf("Charge organization usage failed: %v", err)
+			}
 		}
 	} else {
 		// 余额模式：扣除用户余额（使用 ActualCost 考虑倍率后的费用）
@@ -3690,13 +3750,13 @@ func (s *GatewayService) ForwardCountTokens(ctx context.Context, c *gin.Context,
 	// 处理错误响应
 	if resp.StatusCode >= 400 {
 		// 标记账号状态（429/529等）
-		s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, respBody)
+		s.rateLimitService.HandleUpstreamError(ctx, account, reqModel, resp.StatusCode, resp.Header, respBody)
 
 		upstreamMsg := strings.TrimSpace(extractUpstreamErrorMessage(respBody))
 		upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
 		upstreamDetail := ""
-		if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-			maxBytes := s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+		if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+			maxBytes := s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 			if maxBytes <= 0 {
 				maxBytes = 2048
 			}
@@ -3705,14 +3765,14 @@ func (s *GatewayService) ForwardCountTokens(ctx context.Context, c *gin.Context,
 		setOpsUpstreamError(c, resp.StatusCode, upstreamMsg, upstreamDetail)
 
 		// 记录上游错误摘要便于排障（不回显请求内容）
-		if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
+		if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
 			log.Printf(
 				"count_tokens upstream error %d (account=%d platform=%s type=%s): %s",
 				resp.StatusCode,
 				account.ID,
 				account.Platform,
 				account.Type,
-				truncateForLog(respBody, s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes),
+				truncateForLog(respBody, s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes),
 			)
 		}
 
@@ -3806,7 +3866,7 @@ func (s *GatewayService) buildCountTokensRequest(ctx context.Context, c *gin.Con
 	// OAuth 账号：处理 anthropic-beta header
 	if tokenType == "oauth" {
 		req.Header.Set("anthropic-beta", s.getBetaHeader(modelID, c.GetHeader("anthropic-beta")))
-	} else if s.cfg != nil && s.cfg.Gateway.InjectBetaForAPIKey && req.Header.Get("anthropic-beta") == "" {
+	} else if s.cfg != nil && s.cfg.Live().Gateway.InjectBetaForAPIKey && req.Header.Get("anthropic-beta") == "" {
 		// API-key：与 messages 同步的按需 beta 注入（默认关闭）
 		if requestNeedsBetaFeatures(body) {
 			if beta := defaultAPIKeyBetaHeader(body); beta != "" {
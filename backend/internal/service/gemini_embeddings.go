@@ -0,0 +1,418 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/geminicli"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// Gemini embedding actions（/v1beta/models/{model}:{action}）
+const (
+	GeminiActionEmbedContent       = "embedContent"
+	GeminiActionBatchEmbedContents = "batchEmbedContents"
+)
+
+// IsGeminiEmbeddingAction 判断是否为 embedding 类 action
+func IsGeminiEmbeddingAction(action string) bool {
+	return action == GeminiActionEmbedContent || action == GeminiActionBatchEmbedContents
+}
+
+// isGeminiEmbeddingModel 判断是否为 Gemini embedding 系列模型（gemini-embedding-*、text-embedding-004/005、embedding-001 等）。
+// 其他厂商的 embedding 模型（如 OpenAI text-embedding-3-*）不属于该系列。
+func isGeminiEmbeddingModel(model string) bool {
+	model = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(model)), "models/")
+	switch {
+	case strings.HasPrefix(model, "gemini-embedding"),
+		strings.HasPrefix(model, "text-embedding-00"),
+		strings.HasPrefix(model, "text-multilingual-embedding"),
+		strings.HasPrefix(model, "embedding-00"):
+		return true
+	default:
+		return false
+	}
+}
+
+// SupportsGeminiEmbeddings 判断账号能否调用 embedding 接口。
+// embedding 仅由 AI Studio（generativelanguage）提供：API Key 账号和 AI Studio OAuth 账号可用，
+// Code Assist / Google One OAuth 账号只能访问 Code Assist 端点，不支持 embedding。
+func (a *Account) SupportsGeminiEmbeddings() bool {
+	if a == nil || a.Platform != PlatformGemini {
+		return false
+	}
+	switch a.Type {
+	case AccountTypeAPIKey:
+		return true
+	case AccountTypeOAuth:
+		oauthType := a.GeminiOAuthType()
+		return oauthType == "ai_studio" || oauthType == ""
+	default:
+		return false
+	}
+}
+
+// GeminiEmbeddingsUnsupportedMessage 账号不支持 embedding 时返回给客户端的提示
+const GeminiEmbeddingsUnsupportedMessage = "Embeddings require a Gemini AI Studio account (API key or AI Studio OAuth); Code Assist / Google One accounts are not supported"
+
+// OpenAIEmbeddingsRequest OpenAI 兼容的 /v1/embeddings 请求
+type OpenAIEmbeddingsRequest struct {
+	Model          string
+	Inputs         []string
+	EncodingFormat string // "float"（默认）或 "base64"
+	Dimensions     *int
+}
+
+// ParseOpenAIEmbeddingsRequest 解析 OpenAI /v1/embeddings 请求体。
+// input 支持字符串或字符串数组；token 数组形式上游不支持，直接拒绝。
+func ParseOpenAIEmbeddingsRequest(body []byte) (*OpenAIEmbeddingsRequest, error) {
+	if !gjson.ValidBytes(body) {
+		return nil, errors.New("invalid JSON body")
+	}
+	model := strings.TrimSpace(gjson.GetBytes(body, "model").String())
+	if model == "" {
+		return nil, errors.New("model is required")
+	}
+
+	input := gjson.GetBytes(body, "input")
+	var inputs []string
+	switch {
+	case input.Type == gjson.String:
+		inputs = []string{input.String()}
+	case input.IsArray():
+		for _, item := range input.Array() {
+			if item.Type != gjson.String {
+				return nil, errors.New("input must be a string or an array of strings")
+			}
+			inputs = append(inputs, item.String())
+		}
+	default:
+		return nil, errors.New("input is required")
+	}
+	if len(inputs) == 0 {
+		return nil, errors.New("input must not be empty")
+	}
+
+	req := &OpenAIEmbeddingsRequest{
+		Model:          model,
+		Inputs:         inputs,
+		EncodingFormat: strings.TrimSpace(gjson.GetBytes(body, "encoding_format").String()),
+	}
+	switch req.EncodingFormat {
+	case "", "float", "base64":
+	default:
+		return nil, fmt.Errorf("unsupported encoding_format: %s", req.EncodingFormat)
+	}
+	if dims := gjson.GetBytes(body, "dimensions"); dims.Exists() {
+		v := int(dims.Int())
+		if v <= 0 {
+			return nil, errors.New("dimensions must be a positive integer")
+		}
+		req.Dimensions = &v
+	}
+	return req, nil
+}
+
+// buildGeminiBatchEmbedBody 将 OpenAI embeddings 请求转换为 Gemini batchEmbedContents 请求体
+func buildGeminiBatchEmbedBody(model string, req *OpenAIEmbeddingsRequest) ([]byte, error) {
+	modelRef := "models/" + strings.TrimPrefix(model, "models/")
+	requests := make([]map[string]any, 0, len(req.Inputs))
+	for _, text := range req.Inputs {
+		item := map[string]any{
+			"model": modelRef,
+			"content": map[string]any{
+				"parts": []map[string]any{{"text": text}},
+			},
+		}
+		if req.Dimensions != nil {
+			item["outputDimensionality"] = *req.Dimensions
+		}
+		requests = append(requests, item)
+	}
+	return json.Marshal(map[string]any{"requests": requests})
+}
+
+// convertGeminiBatchEmbedToOpenAI 将 Gemini batchEmbedContents 响应转换为 OpenAI embeddings 响应
+func convertGeminiBatchEmbedToOpenAI(respBody []byte, model string, encodingFormat string, promptTokens int) ([]byte, error) {
+	embeddings := gjson.GetBytes(respBody, "embeddings")
+	if !embeddings.IsArray() {
+		return nil, errors.New("upstream response missing embeddings")
+	}
+
+	data := make([]map[string]any, 0, len(embeddings.Array()))
+	for i, item := range embeddings.Array() {
+		values := item.Get("values").Array()
+		var embedding any
+		if encodingFormat == "base64" {
+			buf := make([]byte, 4*len(values))
+			for j, v := range values {
+				binary.LittleEndian.PutUint32(buf[j*4:], math.Float32bits(float32(v.Float())))
+			}
+			embedding = base64.StdEncoding.EncodeToString(buf)
+		} else {
+			floats := make([]float64, len(values))
+			for j, v := range values {
+				floats[j] = v.Float()
+			}
+			embedding = floats
+		}
+		data = append(data, map[string]any{
+			"object":    "embedding",
+			"index":     i,
+			"embedding": embedding,
+		})
+	}
+
+	return json.Marshal(map[string]any{
+		"object": "list",
+		"data":   data,
+		"model":  model,
+		"usage": map[string]any{
+			"prompt_tokens": promptTokens,
+			"total_tokens":  promptTokens,
+		},
+	})
+}
+
+// rewriteGeminiBatchEmbedModel 将 batchEmbedContents 中每个子请求的 model 替换为映射后的模型。
+// 上游要求子请求 model 与 URL 中的模型一致。
+func rewriteGeminiBatchEmbedModel(body []byte, mappedModel string) []byte {
+	requests := gjson.GetBytes(body, "requests")
+	if !requests.IsArray() {
+		return body
+	}
+	modelRef := "models/" + strings.TrimPrefix(mappedModel, "models/")
+	out := body
+	for i := range requests.Array() {
+		if next, err := sjson.SetBytes(out, fmt.Sprintf("requests.%d.model", i), modelRef); err == nil {
+			out = next
+		}
+	}
+	return out
+}
+
+// estimateGeminiEmbeddingTokens 估算 embedContent / batchEmbedContents 请求的输入 token 数。
+// Gemini embedding 响应不返回 usageMetadata，计费只能基于请求内容估算。
+func estimateGeminiEmbeddingTokens(body []byte) int {
+	total := 0
+	countParts := func(content gjson.Result) {
+		for _, part := range content.Get("parts").Array() {
+			total += estimateTokensForText(part.Get("text").String())
+		}
+	}
+	if requests := gjson.GetBytes(body, "requests"); requests.IsArray() {
+		for _, req := range requests.Array() {
+			countParts(req.Get("content"))
+		}
+		return total
+	}
+	countParts(gjson.GetBytes(body, "content"))
+	return total
+}
+
+// buildGeminiAIStudioRequest 构建 AI Studio（generativelanguage）POST 请求。
+// API Key 账号使用 x-goog-api-key；OAuth 账号使用 Bearer token（embedding 不走 Code Assist）。
+func (s *GeminiMessagesCompatService) buildGeminiAIStudioRequest(ctx context.Context, account *Account, model string, action string, body []byte) (*http.Request, error) {
+	baseURL := strings.TrimSpace(account.GetCredential("base_url"))
+	if baseURL == "" {
+		baseURL = geminicli.AIStudioBaseURL
+	}
+	normalizedBaseURL, err := s.validateUpstreamBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	fullURL := fmt.Sprintf("%s/v1beta/models/%s:%s", strings.TrimRight(normalizedBaseURL, "/"), model, action)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	switch account.Type {
+	case AccountTypeAPIKey:
+		apiKey := strings.TrimSpace(account.GetCredential("api_key"))
+		if apiKey == "" {
+			return nil, errors.New("gemini api_key not configured")
+		}
+		req.Header.Set("x-goog-api-key", apiKey)
+	case AccountTypeOAuth:
+		if s.tokenProvider == nil {
+			return nil, errors.New("gemini token provider not configured")
+		}
+		accessToken, err := s.tokenProvider.GetAccessToken(ctx, account)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	default:
+		return nil, fmt.Errorf("unsupported account type: %s", account.Type)
+	}
+	return req, nil
+}
+
+// ForwardOpenAIEmbeddings 通过 Gemini 账号处理 OpenAI 兼容的 /v1/embeddings 请求。
+// 请求被转换为 batchEmbedContents，响应再转换回 OpenAI 格式；按估算的输入 token 计费。
+func (s *GeminiMessagesCompatService) ForwardOpenAIEmbeddings(ctx context.Context, c *gin.Context, account *Account, req *OpenAIEmbeddingsRequest) (*ForwardResult, error) {
+	startTime := time.Now()
+
+	if !account.SupportsGeminiEmbeddings() {
+		return nil, writeOpenAIEmbeddingsError(c, http.StatusBadRequest, "invalid_request_error", GeminiEmbeddingsUnsupportedMessage)
+	}
+
+	mappedModel := req.Model
+	if account.Type == AccountTypeAPIKey {
+		mappedModel = account.GetMappedModel(req.Model)
+	}
+	mappedModel = strings.TrimPrefix(mappedModel, "models/")
+
+	body, err := buildGeminiBatchEmbedBody(mappedModel, req)
+	if err != nil {
+		return nil, writeOpenAIEmbeddingsError(c, http.StatusBadRequest, "invalid_request_error", "Failed to build upstream request")
+	}
+	if c != nil {
+		c.Set(OpsUpstreamRequestBodyKey, string(body))
+	}
+
+	proxyURL := ""
+	if account.ProxyID != nil && account.Proxy != nil {
+		proxyURL = account.Proxy.URL()
+	}
+
+	var resp *http.Response
+	var respBody []byte
+	for attempt := 1; attempt <= geminiMaxRetries; attempt++ {
+		upstreamReq, err := s.buildGeminiAIStudioRequest(ctx, account, mappedModel, GeminiActionBatchEmbedContents, body)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+			return nil, writeOpenAIEmbeddingsError(c, http.StatusBadGateway, "upstream_error", err.Error())
+		}
+
+		resp, err = s.httpUpstream.Do(upstreamReq, proxyURL, account.ID, account.Concurrency)
+		if err != nil {
+			safeErr := sanitizeUpstreamErrorMessage(err.Error())
+			appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+				Platform:           account.Platform,
+				AccountID:          account.ID,
+				AccountName:        account.Name,
+				UpstreamStatusCode: 0,
+				Kind:               "request_error",
+				Message:            safeErr,
+			})
+			if attempt < geminiMaxRetries {
+				log.Printf("Gemini account %d: embeddings request failed, retry %d/%d: %v", account.ID, attempt, geminiMaxRetries, err)
+				sleepGeminiBackoff(attempt)
+				continue
+			}
+			setOpsUpstreamError(c, 0, safeErr, "")
+			return nil, writeOpenAIEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "Upstream request failed after retries: "+safeErr)
+		}
+
+		respBody, _ = io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+		_ = resp.Body.Close()
+
+		// 429 直接交给上层 failover 换号，其余可重试错误原地重试
+		if resp.StatusCode >= 400 && resp.StatusCode != http.StatusTooManyRequests &&
+			s.shouldRetryGeminiUpstreamError(account, resp.StatusCode) && attempt < geminiMaxRetries {
+			log.Printf("Gemini account %d: embeddings upstream status %d, retry %d/%d", account.ID, resp.StatusCode, attempt, geminiMaxRetries)
+			sleepGeminiBackoff(attempt)
+			continue
+		}
+		break
+	}
+
+	requestID := resp.Header.Get("x-request-id")
+	if requestID == "" {
+		requestID = resp.Header.Get("x-goog-request-id")
+	}
+	if requestID != "" {
+		c.Header("x-request-id", requestID)
+	}
+
+	if resp.StatusCode >= 400 {
+		if s.rateLimitService != nil {
+			s.rateLimitService.HandleTempUnschedulable(ctx, account, resp.StatusCode, respBody)
+		}
+		s.handleGeminiUpstreamError(ctx, account, mappedModel, resp.StatusCode, resp.Header, respBody)
+
+		upstreamMsg := sanitizeUpstreamErrorMessage(strings.TrimSpace(extractUpstreamErrorMessage(respBody)))
+		upstreamDetail := ""
+		if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+			maxBytes := s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
+			if maxBytes <= 0 {
+				maxBytes = 2048
+			}
+			upstreamDetail = truncateString(string(respBody), maxBytes)
+		}
+
+		if s.shouldFailoverGeminiUpstreamError(resp.StatusCode) {
+			appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+				Platform:           account.Platform,
+				AccountID:          account.ID,
+				AccountName:        account.Name,
+				UpstreamStatusCode: resp.StatusCode,
+				UpstreamRequestID:  requestID,
+				Kind:               "failover",
+				Message:            upstreamMsg,
+				Detail:             upstreamDetail,
+			})
+			return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode}
+		}
+
+		setOpsUpstreamError(c, resp.StatusCode, upstreamMsg, upstreamDetail)
+		appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+			Platform:           account.Platform,
+			AccountID:          account.ID,
+			AccountName:        account.Name,
+			UpstreamStatusCode: resp.StatusCode,
+			UpstreamRequestID:  requestID,
+			Kind:               "http_error",
+			Message:            upstreamMsg,
+			Detail:             upstreamDetail,
+		})
+		if upstreamMsg == "" {
+			upstreamMsg = "Upstream request failed"
+		}
+		return nil, writeOpenAIEmbeddingsError(c, resp.StatusCode, "invalid_request_error", upstreamMsg)
+	}
+
+	promptTokens := estimateGeminiEmbeddingTokens(body)
+	out, err := convertGeminiBatchEmbedToOpenAI(respBody, req.Model, req.EncodingFormat, promptTokens)
+	if err != nil {
+		return nil, writeOpenAIEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "Failed to parse upstream response")
+	}
+	c.Data(http.StatusOK, "application/json", out)
+
+	return &ForwardResult{
+		RequestID: requestID,
+		Usage:     ClaudeUsage{InputTokens: promptTokens},
+		Model:     req.Model,
+		Stream:    false,
+		Duration:  time.Since(startTime),
+	}, nil
+}
+
+func writeOpenAIEmbeddingsError(c *gin.Context, status int, errType, message string) error {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+	return fmt.Errorf("%s", message)
+}
@@ -0,0 +1,141 @@
+//go:build unit
+
+package service
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestParseOpenAIEmbeddingsRequest(t *testing.T) {
+	req, err := ParseOpenAIEmbeddingsRequest([]byte(`{"model":"gemini-embedding-001","input":"hello"}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"hello"}, req.Inputs)
+	require.Nil(t, req.Dimensions)
+
+	req, err = ParseOpenAIEmbeddingsRequest([]byte(`{"model":"gemini-embedding-001","input":["a","b"],"dimensions":256,"encoding_format":"base64"}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, req.Inputs)
+	require.NotNil(t, req.Dimensions)
+	require.Equal(t, 256, *req.Dimensions)
+	require.Equal(t, "base64", req.EncodingFormat)
+
+	_, err = ParseOpenAIEmbeddingsRequest([]byte(`{"input":"hello"}`))
+	require.Error(t, err)
+
+	// token 数组输入不支持
+	_, err = ParseOpenAIEmbeddingsRequest([]byte(`{"model":"m","input":[[1,2,3]]}`))
+	require.Error(t, err)
+
+	_, err = ParseOpenAIEmbeddingsRequest([]byte(`{"model":"m","input":"x","encoding_format":"int8"}`))
+	require.Error(t, err)
+}
+
+func TestBuildGeminiBatchEmbedBody(t *testing.T) {
+	dims := 128
+	body, err := buildGeminiBatchEmbedBody("gemini-embedding-001", &OpenAIEmbeddingsRequest{
+		Inputs:     []string{"a", "b"},
+		Dimensions: &dims,
+	})
+	require.NoError(t, err)
+
+	requests := gjson.GetBytes(body, "requests").Array()
+	require.Len(t, requests, 2)
+	require.Equal(t, "models/gemini-embedding-001", requests[0].Get("model").String())
+	require.Equal(t, "b", requests[1].Get("content.parts.0.text").String())
+	require.Equal(t, int64(128), requests[0].Get("outputDimensionality").Int())
+}
+
+func TestConvertGeminiBatchEmbedToOpenAI(t *testing.T) {
+	upstream := []byte(`{"embeddings":[{"values":[0.5,-1]},{"values":[0.25]}]}`)
+
+	out, err := convertGeminiBatchEmbedToOpenAI(upstream, "gemini-embedding-001", "", 7)
+	require.NoError(t, err)
+
+	var parsed struct {
+		Object string `json:"object"`
+		Data   []struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+		Usage struct {
+			PromptTokens int `json:"prompt_tokens"`
+		} `json:"usage"`
+	}
+	require.NoError(t, json.Unmarshal(out, &parsed))
+	require.Equal(t, "list", parsed.Object)
+	require.Len(t, parsed.Data, 2)
+	require.Equal(t, []float64{0.5, -1}, parsed.Data[0].Embedding)
+	require.Equal(t, 1, parsed.Data[1].Index)
+	require.Equal(t, 7, parsed.Usage.PromptTokens)
+
+	out, err = convertGeminiBatchEmbedToOpenAI(upstream, "gemini-embedding-001", "base64", 7)
+	require.NoError(t, err)
+	encoded := gjson.GetBytes(out, "data.0.embedding").String()
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	require.Len(t, raw, 8)
+	require.Equal(t, float32(0.5), math.Float32frombits(binary.LittleEndian.Uint32(raw[0:4])))
+	require.Equal(t, float32(-1), math.Float32frombits(binary.LittleEndian.Uint32(raw[4:8])))
+
+	_, err = convertGeminiBatchEmbedToOpenAI([]byte(`{}`), "m", "", 0)
+	require.Error(t, err)
+}
+
+func TestRewriteGeminiBatchEmbedModel(t *testing.T) {
+	body := []byte(`{"requests":[{"model":"models/a","content":{"parts":[{"text":"x"}]}},{"model":"models/a"}]}`)
+	out := rewriteGeminiBatchEmbedModel(body, "b")
+	for _, r := range gjson.GetBytes(out, "requests").Array() {
+		require.Equal(t, "models/b", r.Get("model").String())
+	}
+}
+
+func TestEstimateGeminiEmbeddingTokens(t *testing.T) {
+	single := []byte(`{"content":{"parts":[{"text":"abcdefgh"}]}}`)
+	require.Equal(t, 2, estimateGeminiEmbeddingTokens(single))
+
+	batch := []byte(`{"requests":[{"content":{"parts":[{"text":"abcd"}]}},{"content":{"parts":[{"text":"abcdefgh"}]}}]}`)
+	require.Equal(t, 3, estimateGeminiEmbeddingTokens(batch))
+}
+
+func TestBillingFallbackPricing_Embedding(t *testing.T) {
+	svc := NewBillingService(nil, nil)
+	pricing, err := svc.GetModelPricing("gemini-embedding-001")
+	require.NoError(t, err)
+	require.InDelta(t, 0.15e-6, pricing.InputPricePerToken, 1e-12)
+	require.Zero(t, pricing.OutputPricePerToken)
+}
+
+func TestBillingFallbackPricing_NonGeminiEmbedding(t *testing.T) {
+	svc := NewBillingService(nil, nil)
+	pricing, err := svc.GetModelPricing("text-embedding-3-small")
+	require.NoError(t, err)
+	require.NotEqual(t, svc.fallbackPrices["gemini-embedding"], pricing)
+
+	for _, model := range []string{"text-embedding-004", "models/embedding-001", "gemini-embedding-exp-03-07"} {
+		require.True(t, isGeminiEmbeddingModel(model), model)
+	}
+	require.False(t, isGeminiEmbeddingModel("text-embedding-3-large"))
+}
+
+func TestAccountSupportsGeminiEmbeddings(t *testing.T) {
+	apiKey := &Account{Platform: PlatformGemini, Type: AccountTypeAPIKey}
+	aiStudio := &Account{Platform: PlatformGemini, Type: AccountTypeOAuth, Credentials: map[string]any{"oauth_type": "ai_studio"}}
+	codeAssist := &Account{Platform: PlatformGemini, Type: AccountTypeOAuth, Credentials: map[string]any{"oauth_type": "code_assist", "project_id": "p"}}
+	googleOne := &Account{Platform: PlatformGemini, Type: AccountTypeOAuth, Credentials: map[string]any{"oauth_type": "google_one"}}
+	legacyCodeAssist := &Account{Platform: PlatformGemini, Type: AccountTypeOAuth, Credentials: map[string]any{"project_id": "p"}}
+	antigravity := &Account{Platform: PlatformAntigravity, Type: AccountTypeOAuth}
+
+	require.True(t, apiKey.SupportsGeminiEmbeddings())
+	require.True(t, aiStudio.SupportsGeminiEmbeddings())
+	require.False(t, codeAssist.SupportsGeminiEmbeddings())
+	require.False(t, googleOne.SupportsGeminiEmbeddings())
+	require.False(t, legacyCodeAssist.SupportsGeminiEmbeddings())
+	require.False(t, antigravity.SupportsGeminiEmbeddings())
+}
@@ -36,6 +36,32 @@ type Group struct {
 	ModelRouting        map[string][]int64
 	ModelRoutingEnabled bool
 
+	// SchedulingStrategy 账号调度策略（空字符串为默认策略），见 scheduling_strategy.go
+	SchedulingStrategy string
+
+	// ContentPolicy 内容策略（nil 表示未配置），见 content_policy.go
+	ContentPolicy *ContentPolicy
+
+	// RewriteRules 请求改写规则（按顺序执行），见 request_rewrite.go
+	RewriteRules []RequestRewriteRule
+
+	// ModelAliases 面向用户的虚拟模型（按顺序故障转移的目标列表），见 model_alias.go
+	ModelAliases []ModelAlias
+
+	// 订阅超额策略（仅订阅分组生效），见 subscription_overage.go
+	OveragePolicy         string
+	OverageRateMultiplier float64
+	OverageGroupID        *int64
+
+	// SubscriptionPrice 每个 DefaultValidityDays 周期的余额价格，nil 表示不支持自助续费/切换，见 subscription_lifecycle.go
+	SubscriptionPrice *float64
+
+	// UsageQuotas 订阅 token / 请求次数额度（与 USD 限额同时生效），见 subscription_quota.go
+	UsageQuotas []SubscriptionQuota
+
+	// TrafficSplit 灰度 / A-B 分流配置（nil 表示未配置），见 traffic_split.go
+	TrafficSplit *TrafficSplit
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
@@ -24,6 +24,11 @@ type Invite struct {
 	ConfirmedAt  *time.Time
 	CreatedAt    time.Time
 
+	// 返佣状态
+	CommissionStatus      string
+	CommissionBlockReason string
+	CommissionTotal       float64
+
 	Inviter        *User
 	Invitee        *User
 	ConfirmedByUser *User
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+var (
+	ErrCommissionDisabled            = infraerrors.Forbidden("COMMISSION_DISABLED", "invite commission is disabled")
+	ErrCommissionInsufficientBalance = infraerrors.BadRequest("COMMISSION_INSUFFICIENT_BALANCE", "insufficient commission balance")
+	ErrCommissionInvalidAmount       = infraerrors.BadRequest("COMMISSION_INVALID_AMOUNT", "invalid commission amount")
+	ErrCommissionBelowMinWithdraw    = infraerrors.BadRequest("COMMISSION_BELOW_MIN_WITHDRAW", "amount is below the minimum withdrawal")
+	ErrCommissionAccountRequired     = infraerrors.BadRequest("COMMISSION_ACCOUNT_REQUIRED", "payout account is required")
+	ErrCommissionWithdrawalNotFound  = infraerrors.NotFound("COMMISSION_WITHDRAWAL_NOT_FOUND", "commission withdrawal not found")
+	ErrCommissionWithdrawalProcessed = infraerrors.Conflict("COMMISSION_WITHDRAWAL_PROCESSED", "commission withdrawal already processed")
+	ErrInviteCommissionStatusInvalid = infraerrors.BadRequest("INVITE_COMMISSION_STATUS_INVALID", "invalid invite commission status")
+)
+
+// InviteCommissionSettings 返佣配置
+type InviteCommissionSettings struct {
+	Enabled     bool
+	Rate        float64 // 百分比，如 10 表示被邀请人实际消费的 10%
+	Days        int     // 自邀请关系建立起的计佣天数，0 表示永久
+	MinWithdraw float64
+}
+
+// InviteCommission 一次增量计佣记录
+type InviteCommission struct {
+	ID         int64
+	InviteID   int64
+	InviterID  int64
+	InviteeID  int64
+	BaseAmount float64
+	Rate       float64
+	Amount     float64
+	FromLogID  int64
+	ToLogID    int64
+	LogCount   int
+	CreatedAt  time.Time
+
+	InviteeEmail string
+}
+
+// CommissionWithdrawal 佣金转出记录
+type CommissionWithdrawal struct {
+	ID          int64
+	UserID      int64
+	Amount      float64
+	Method      string
+	Status      string
+	AccountInfo string
+	ProcessedBy *int64
+	AdminNotes  string
+	ProcessedAt *time.Time
+	CreatedAt   time.Time
+
+	UserEmail string
+}
+
+func (w *CommissionWithdrawal) IsPending() bool {
+	return w != nil && w.Status == CommissionWithdrawalStatusPending
+}
+
+type CommissionWithdrawalFilters struct {
+	UserID *int64
+	Method string
+	Status string
+}
+
+// InviteCommissionStats 邀请人维度的返佣统计
+type InviteCommissionStats struct {
+	Balance           float64
+	TotalEarned       float64
+	TodayEarned       float64
+	Last30DaysEarned  float64
+	PendingWithdrawal float64
+	TotalWithdrawn    float64
+	ActiveInvitees    int
+	BlockedInvitees   int
+}
+
+// InviteCommissionReport 用户收益报表
+type InviteCommissionReport struct {
+	Settings InviteCommissionSettings
+	InviteCommissionStats
+}
+
+// InviteCommissionTarget 待计佣的邀请关系（计佣时加行锁读取）
+type InviteCommissionTarget struct {
+	InviteID  int64
+	InviterID int64
+	InviteeID int64
+	Status    string
+	Cursor    int64
+	CreatedAt time.Time
+}
+
+// CommissionUsageBatch 被邀请人在游标之后的一批待计佣消费
+type CommissionUsageBatch struct {
+	BaseAmount float64
+	FromLogID  int64
+	ToLogID    int64
+	LogCount   int
+}
+
+// RegistrationConflict 注册来源与邀请人或其他被邀请人重复的情况
+type RegistrationConflict struct {
+	SameIP     bool
+	SameDevice bool
+}
+
+type InviteCommissionRepository interface {
+	// GetSettledUsageLogWatermark 返回 settleBefore 之前写入的最大使用记录 ID，作为本轮计佣的 ID 上限
+	GetSettledUsageLogWatermark(ctx context.Context, settleBefore time.Time) (int64, error)
+	// ListAccrualCandidates 列出游标与 watermark 之间存在未计佣消费的 active 邀请关系 ID
+	ListAccrualCandidates(ctx context.Context, watermark int64, days int, limit int) ([]int64, error)
+	// GetTargetForUpdate 加行锁读取邀请关系的计佣状态（需在事务中调用）
+	GetTargetForUpdate(ctx context.Context, inviteID int64) (*InviteCommissionTarget, error)
+	// SumInviteeUsage 汇总被邀请人 ID 在 (afterLogID, watermark] 内、扣余额计费的实际消费；
+	// windowEnd 非空时仅统计该时间之前的消费
+	SumInviteeUsage(ctx context.Context, inviteeID, afterLogID, watermark int64, windowEnd *time.Time) (*CommissionUsageBatch, error)
+	CreateCommission(ctx context.Context, commission *InviteCommission) error
+	// AdvanceCursor 推进计佣游标并累加该邀请关系的佣金总额
+	AdvanceCursor(ctx context.Context, inviteID, cursor int64, amount float64) error
+	ListCommissions(ctx context.Context, inviterID int64, params pagination.PaginationParams) ([]InviteCommission, *pagination.PaginationResult, error)
+	GetStats(ctx context.Context, inviterID int64, todayStart, last30Start time.Time) (*InviteCommissionStats, error)
+	SetCommissionStatus(ctx context.Context, inviteeID int64, status, reason string) error
+	FindRegistrationConflict(ctx context.Context, inviterID, inviteeID int64, registerIP, registerDevice string) (*RegistrationConflict, error)
+
+	GetCommissionBalance(ctx context.Context, userID int64) (float64, error)
+	// UpdateCommissionBalance 原子增减佣金余额；扣减后余额不足时返回 ErrCommissionInsufficientBalance
+	UpdateCommissionBalance(ctx context.Context, userID int64, amount float64) error
+
+	CreateWithdrawal(ctx context.Context, withdrawal *CommissionWithdrawal) error
+	GetWithdrawalForUpdate(ctx context.Context, id int64) (*CommissionWithdrawal, error)
+	UpdateWithdrawal(ctx context.Context, withdrawal *CommissionWithdrawal) error
+	ListWithdrawals(ctx context.Context, params pagination.PaginationParams, filters CommissionWithdrawalFilters) ([]CommissionWithdrawal, *pagination.PaginationResult, error)
+}
@@ -0,0 +1,441 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+)
+
+const (
+	// 计佣 ID 上限取 settle 之前写入的最大日志 ID，给并发写入的日志留出提交时间，避免乱序提交被游标跳过
+	commissionSettleDelay = time.Minute
+	// 单轮最多处理的邀请关系数量，剩余的留到下一轮
+	commissionAccrualBatchSize = 200
+)
+
+// InviteCommissionService 邀请返佣：后台按被邀请人实际消费增量计佣，并提供佣金转余额 / 提现
+type InviteCommissionService struct {
+	entClient            *dbent.Client
+	commissionRepo       InviteCommissionRepository
+	userRepo             UserRepository
+	redeemRepo           RedeemCodeRepository
+	settingService       *SettingService
+	billingCacheService  *BillingCacheService
+	authCacheInvalidator APIKeyAuthCacheInvalidator
+
+	interval time.Duration
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func NewInviteCommissionService(
+	entClient *dbent.Client,
+	commissionRepo InviteCommissionRepository,
+	userRepo UserRepository,
+	redeemRepo RedeemCodeRepository,
+	settingService *SettingService,
+	billingCacheService *BillingCacheService,
+	authCacheInvalidator APIKeyAuthCacheInvalidator,
+	interval time.Duration,
+) *InviteCommissionService {
+	return &InviteCommissionService{
+		entClient:            entClient,
+		commissionRepo:       commissionRepo,
+		userRepo:             userRepo,
+		redeemRepo:           redeemRepo,
+		settingService:       settingService,
+		billingCacheService:  billingCacheService,
+		authCacheInvalidator: authCacheInvalidator,
+		interval:             interval,
+		stopCh:               make(chan struct{}),
+	}
+}
+
+func (s *InviteCommissionService) Start() {
+	if s == nil || s.commissionRepo == nil || s.interval <= 0 {
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.runOnce()
+		for {
+			select {
+			case <-ticker.C:
+				s.runOnce()
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (s *InviteCommissionService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+}
+
+func (s *InviteCommissionService) runOnce() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	accrued, total, err := s.AccrueCommissions(ctx, time.Now())
+	if err != nil {
+		log.Printf("[InviteCommission] Accrue commissions failed: %v", err)
+		return
+	}
+	if accrued > 0 {
+		log.Printf("[InviteCommission] Accrued %.8f commission for %d invites", total, accrued)
+	}
+}
+
+// GetSettings 获取返佣配置
+func (s *InviteCommissionService) GetSettings(ctx context.Context) InviteCommissionSettings {
+	if s.settingService == nil {
+		return InviteCommissionSettings{}
+	}
+	return s.settingService.GetInviteCommissionSettings(ctx)
+}
+
+// UpdateSettings 更新返佣配置；比例调整只影响之后的计佣批次
+func (s *InviteCommissionService) UpdateSettings(ctx context.Context, settings InviteCommissionSettings) (*InviteCommissionSettings, error) {
+	if settings.Rate < 0 || settings.Rate > 100 || settings.Days < 0 || settings.MinWithdraw < 0 {
+		return nil, ErrCommissionInvalidAmount
+	}
+	if s.settingService == nil {
+		return &settings, nil
+	}
+	if err := s.settingService.SetInviteCommissionSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// AccrueCommissions 对所有存在未计佣消费的邀请关系增量计佣，返回计佣的邀请关系数与佣金总额
+func (s *InviteCommissionService) AccrueCommissions(ctx context.Context, now time.Time) (int, float64, error) {
+	settings := s.GetSettings(ctx)
+	if !settings.Enabled || settings.Rate <= 0 {
+		return 0, 0, nil
+	}
+
+	watermark, err := s.commissionRepo.GetSettledUsageLogWatermark(ctx, now.Add(-commissionSettleDelay))
+	if err != nil {
+		return 0, 0, err
+	}
+	if watermark <= 0 {
+		return 0, 0, nil
+	}
+	inviteIDs, err := s.commissionRepo.ListAccrualCandidates(ctx, watermark, settings.Days, commissionAccrualBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	accrued := 0
+	total := 0.0
+	for _, inviteID := range inviteIDs {
+		amount, err := s.accrueInvite(ctx, inviteID, settings, watermark)
+		if err != nil {
+			log.Printf("[InviteCommission] Accrue invite %d failed: %v", inviteID, err)
+			continue
+		}
+		if amount > 0 {
+			accrued++
+			total += amount
+		}
+	}
+	return accrued, total, nil
+}
+
+func (s *InviteCommissionService) accrueInvite(ctx context.Context, inviteID int64, settings InviteCommissionSettings, watermark int64) (float64, error) {
+	amount := 0.0
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		target, err := s.commissionRepo.GetTargetForUpdate(txCtx, inviteID)
+		if err != nil {
+			return err
+		}
+		if target.Status != InviteCommissionStatusActive {
+			return nil
+		}
+
+		if target.Cursor >= watermark {
+			return nil
+		}
+		var windowEnd *time.Time
+		if settings.Days > 0 {
+			end := target.CreatedAt.AddDate(0, 0, settings.Days)
+			windowEnd = &end
+		}
+
+		batch, err := s.commissionRepo.SumInviteeUsage(txCtx, target.InviteeID, target.Cursor, watermark, windowEnd)
+		if err != nil {
+			return err
+		}
+
+		amount = roundCommission(batch.BaseAmount * settings.Rate / 100)
+		if amount > 0 {
+			if err := s.commissionRepo.CreateCommission(txCtx, &InviteCommission{
+				InviteID:   target.InviteID,
+				InviterID:  target.InviterID,
+				InviteeID:  target.InviteeID,
+				BaseAmount: batch.BaseAmount,
+				Rate:       settings.Rate,
+				Amount:     amount,
+				FromLogID:  batch.FromLogID,
+				ToLogID:    batch.ToLogID,
+				LogCount:   batch.LogCount,
+			}); err != nil {
+				return err
+			}
+			if err := s.commissionRepo.UpdateCommissionBalance(txCtx, target.InviterID, amount); err != nil {
+				return err
+			}
+		}
+		// watermark 之前的日志均已落定，游标直接推进到 watermark；金额为 0 时同样推进，避免重复扫描免费请求
+		return s.commissionRepo.AdvanceCursor(txCtx, target.InviteID, watermark, amount)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
+// GetReport 用户收益报表
+func (s *InviteCommissionService) GetReport(ctx context.Context, userID int64) (*InviteCommissionReport, error) {
+	now := timezone.Now()
+	todayStart := timezone.StartOfDay(now)
+	stats, err := s.commissionRepo.GetStats(ctx, userID, todayStart, todayStart.AddDate(0, 0, -29))
+	if err != nil {
+		return nil, err
+	}
+	return &InviteCommissionReport{
+		Settings:              s.GetSettings(ctx),
+		InviteCommissionStats: *stats,
+	}, nil
+}
+
+func (s *InviteCommissionService) ListCommissions(ctx context.Context, userID int64, params pagination.PaginationParams) ([]InviteCommission, *pagination.PaginationResult, error) {
+	return s.commissionRepo.ListCommissions(ctx, userID, params)
+}
+
+func (s *InviteCommissionService) ListMyWithdrawals(ctx context.Context, userID int64, params pagination.PaginationParams) ([]CommissionWithdrawal, *pagination.PaginationResult, error) {
+	return s.commissionRepo.ListWithdrawals(ctx, params, CommissionWithdrawalFilters{UserID: &userID})
+}
+
+// ConvertToBalance 将佣金即时转入账户余额
+func (s *InviteCommissionService) ConvertToBalance(ctx context.Context, userID int64, amount float64) (*CommissionWithdrawal, error) {
+	amount = roundCommission(amount)
+	if amount <= 0 {
+		return nil, ErrCommissionInvalidAmount
+	}
+
+	now := time.Now()
+	withdrawal := &CommissionWithdrawal{
+		UserID:      userID,
+		Amount:      amount,
+		Method:      CommissionWithdrawalMethodBalance,
+		Status:      CommissionWithdrawalStatusCompleted,
+		ProcessedAt: &now,
+	}
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		if err := s.commissionRepo.UpdateCommissionBalance(txCtx, userID, -amount); err != nil {
+			return err
+		}
+		if err := s.userRepo.UpdateBalance(txCtx, userID, amount); err != nil {
+			return err
+		}
+		code, err := GenerateRedeemCode()
+		if err != nil {
+			return fmt.Errorf("generate commission adjustment code: %w", err)
+		}
+		if err := s.redeemRepo.Create(txCtx, &RedeemCode{
+			Code:   code,
+			Type:   AdjustmentTypeInviteCommission,
+			Value:  amount,
+			Status: StatusUsed,
+			UsedBy: &userID,
+			UsedAt: &now,
+			Notes:  "Invite commission converted to balance",
+		}); err != nil {
+			return err
+		}
+		return s.commissionRepo.CreateWithdrawal(txCtx, withdrawal)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateBalanceCaches(userID)
+	return withdrawal, nil
+}
+
+// RequestWithdrawal 申请提现：佣金先冻结扣除，管理员驳回时退回
+func (s *InviteCommissionService) RequestWithdrawal(ctx context.Context, userID int64, amount float64, accountInfo string) (*CommissionWithdrawal, error) {
+	amount = roundCommission(amount)
+	if amount <= 0 {
+		return nil, ErrCommissionInvalidAmount
+	}
+	accountInfo = strings.TrimSpace(accountInfo)
+	if accountInfo == "" {
+		return nil, ErrCommissionAccountRequired
+	}
+	if amount < s.GetSettings(ctx).MinWithdraw {
+		return nil, ErrCommissionBelowMinWithdraw
+	}
+
+	withdrawal := &CommissionWithdrawal{
+		UserID:      userID,
+		Amount:      amount,
+		Method:      CommissionWithdrawalMethodWithdraw,
+		Status:      CommissionWithdrawalStatusPending,
+		AccountInfo: accountInfo,
+	}
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		if err := s.commissionRepo.UpdateCommissionBalance(txCtx, userID, -amount); err != nil {
+			return err
+		}
+		return s.commissionRepo.CreateWithdrawal(txCtx, withdrawal)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return withdrawal, nil
+}
+
+func (s *InviteCommissionService) AdminListWithdrawals(ctx context.Context, params pagination.PaginationParams, filters CommissionWithdrawalFilters) ([]CommissionWithdrawal, *pagination.PaginationResult, error) {
+	return s.commissionRepo.ListWithdrawals(ctx, params, filters)
+}
+
+// ApproveWithdrawal 管理员确认已线下打款
+func (s *InviteCommissionService) ApproveWithdrawal(ctx context.Context, id, adminID int64, notes string) (*CommissionWithdrawal, error) {
+	return s.processWithdrawal(ctx, id, adminID, notes, CommissionWithdrawalStatusCompleted)
+}
+
+// RejectWithdrawal 管理员驳回提现，冻结的佣金退回佣金余额
+func (s *InviteCommissionService) RejectWithdrawal(ctx context.Context, id, adminID int64, notes string) (*CommissionWithdrawal, error) {
+	return s.processWithdrawal(ctx, id, adminID, notes, CommissionWithdrawalStatusRejected)
+}
+
+func (s *InviteCommissionService) processWithdrawal(ctx context.Context, id, adminID int64, notes, status string) (*CommissionWithdrawal, error) {
+	var out *CommissionWithdrawal
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		withdrawal, err := s.commissionRepo.GetWithdrawalForUpdate(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if !withdrawal.IsPending() {
+			return ErrCommissionWithdrawalProcessed
+		}
+
+		now := time.Now()
+		withdrawal.Status = status
+		withdrawal.ProcessedBy = &adminID
+		withdrawal.ProcessedAt = &now
+		withdrawal.AdminNotes = strings.TrimSpace(notes)
+		if err := s.commissionRepo.UpdateWithdrawal(txCtx, withdrawal); err != nil {
+			return err
+		}
+		if status == CommissionWithdrawalStatusRejected {
+			if err := s.commissionRepo.UpdateCommissionBalance(txCtx, withdrawal.UserID, withdrawal.Amount); err != nil {
+				return err
+			}
+		}
+		out = withdrawal
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetInviteCommissionStatus 管理员手动停止或恢复某个被邀请人的返佣
+func (s *InviteCommissionService) SetInviteCommissionStatus(ctx context.Context, inviteeID int64, status string) error {
+	reason := ""
+	switch status {
+	case InviteCommissionStatusActive:
+	case InviteCommissionStatusBlocked:
+		reason = InviteCommissionBlockAdmin
+	default:
+		return ErrInviteCommissionStatusInvalid
+	}
+	return s.commissionRepo.SetCommissionStatus(ctx, inviteeID, status, reason)
+}
+
+func (s *InviteCommissionService) withTx(ctx context.Context, fn func(txCtx context.Context) error) error {
+	if s.entClient == nil {
+		return fn(ctx)
+	}
+	tx, err := s.entClient.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(dbent.NewTxContext(ctx, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *InviteCommissionService) invalidateBalanceCaches(userID int64) {
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByUserID(context.Background(), userID)
+	}
+	if s.billingCacheService != nil {
+		go func() {
+			cacheCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := s.billingCacheService.InvalidateUserBalance(cacheCtx, userID); err != nil {
+				log.Printf("invalidate commission balance cache failed: user_id=%d err=%v", userID, err)
+			}
+		}()
+	}
+}
+
+// evaluateRegistrationRisk 根据注册 IP/设备判断新邀请关系是否应停止计佣
+func evaluateRegistrationRisk(ctx context.Context, repo InviteCommissionRepository, inviterID int64, invitee *User) (string, string) {
+	if repo == nil || invitee == nil {
+		return InviteCommissionStatusActive, ""
+	}
+	registerIP, registerDevice := "", ""
+	if invitee.RegisterIP != nil {
+		registerIP = *invitee.RegisterIP
+	}
+	if invitee.RegisterDevice != nil {
+		registerDevice = *invitee.RegisterDevice
+	}
+	conflict, err := repo.FindRegistrationConflict(ctx, inviterID, invitee.ID, registerIP, registerDevice)
+	if err != nil {
+		// 检测失败不影响注册，管理员可在后台手动停止计佣
+		log.Printf("[InviteCommission] Registration risk check failed: inviter_id=%d invitee_id=%d err=%v", inviterID, invitee.ID, err)
+		return InviteCommissionStatusActive, ""
+	}
+	switch {
+	case conflict.SameDevice:
+		return InviteCommissionStatusBlocked, InviteCommissionBlockSameDevice
+	case conflict.SameIP:
+		return InviteCommissionStatusBlocked, InviteCommissionBlockSameIP
+	}
+	return InviteCommissionStatusActive, ""
+}
+
+func roundCommission(v float64) float64 {
+	return math.Round(v*1e8) / 1e8
+}
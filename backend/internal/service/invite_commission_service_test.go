@@ -0,0 +1,278 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+type commissionRepoStub struct {
+	candidates  []int64
+	targets     map[int64]*InviteCommissionTarget
+	watermark   int64
+	batch       *CommissionUsageBatch
+	sumWindow   *time.Time
+	commissions []InviteCommission
+	cursors     map[int64]int64
+	balances    map[int64]float64
+	withdrawals map[int64]*CommissionWithdrawal
+	conflict    *RegistrationConflict
+}
+
+func newCommissionRepoStub() *commissionRepoStub {
+	return &commissionRepoStub{
+		targets:     map[int64]*InviteCommissionTarget{},
+		cursors:     map[int64]int64{},
+		balances:    map[int64]float64{},
+		withdrawals: map[int64]*CommissionWithdrawal{},
+		conflict:    &RegistrationConflict{},
+		watermark:   1000,
+	}
+}
+
+func (s *commissionRepoStub) GetSettledUsageLogWatermark(ctx context.Context, settleBefore time.Time) (int64, error) {
+	return s.watermark, nil
+}
+
+func (s *commissionRepoStub) ListAccrualCandidates(ctx context.Context, watermark int64, days int, limit int) ([]int64, error) {
+	return s.candidates, nil
+}
+
+func (s *commissionRepoStub) GetTargetForUpdate(ctx context.Context, inviteID int64) (*InviteCommissionTarget, error) {
+	target, ok := s.targets[inviteID]
+	if !ok {
+		return nil, ErrInviteNotFound
+	}
+	cp := *target
+	return &cp, nil
+}
+
+func (s *commissionRepoStub) SumInviteeUsage(ctx context.Context, inviteeID, afterLogID, watermark int64, windowEnd *time.Time) (*CommissionUsageBatch, error) {
+	s.sumWindow = windowEnd
+	if s.batch == nil {
+		return &CommissionUsageBatch{}, nil
+	}
+	return s.batch, nil
+}
+
+func (s *commissionRepoStub) CreateCommission(ctx context.Context, commission *InviteCommission) error {
+	commission.ID = int64(len(s.commissions) + 1)
+	s.commissions = append(s.commissions, *commission)
+	return nil
+}
+
+func (s *commissionRepoStub) AdvanceCursor(ctx context.Context, inviteID, cursor int64, amount float64) error {
+	s.cursors[inviteID] = cursor
+	return nil
+}
+
+func (s *commissionRepoStub) ListCommissions(ctx context.Context, inviterID int64, params pagination.PaginationParams) ([]InviteCommission, *pagination.PaginationResult, error) {
+	panic("unexpected ListCommissions call")
+}
+
+func (s *commissionRepoStub) GetStats(ctx context.Context, inviterID int64, todayStart, last30Start time.Time) (*InviteCommissionStats, error) {
+	panic("unexpected GetStats call")
+}
+
+func (s *commissionRepoStub) SetCommissionStatus(ctx context.Context, inviteeID int64, status, reason string) error {
+	panic("unexpected SetCommissionStatus call")
+}
+
+func (s *commissionRepoStub) FindRegistrationConflict(ctx context.Context, inviterID, inviteeID int64, registerIP, registerDevice string) (*RegistrationConflict, error) {
+	return s.conflict, nil
+}
+
+func (s *commissionRepoStub) GetCommissionBalance(ctx context.Context, userID int64) (float64, error) {
+	return s.balances[userID], nil
+}
+
+func (s *commissionRepoStub) UpdateCommissionBalance(ctx context.Context, userID int64, amount float64) error {
+	if s.balances[userID]+amount < 0 {
+		return ErrCommissionInsufficientBalance
+	}
+	s.balances[userID] += amount
+	return nil
+}
+
+func (s *commissionRepoStub) CreateWithdrawal(ctx context.Context, withdrawal *CommissionWithdrawal) error {
+	withdrawal.ID = int64(len(s.withdrawals) + 1)
+	cp := *withdrawal
+	s.withdrawals[withdrawal.ID] = &cp
+	return nil
+}
+
+func (s *commissionRepoStub) GetWithdrawalForUpdate(ctx context.Context, id int64) (*CommissionWithdrawal, error) {
+	w, ok := s.withdrawals[id]
+	if !ok {
+		return nil, ErrCommissionWithdrawalNotFound
+	}
+	cp := *w
+	return &cp, nil
+}
+
+func (s *commissionRepoStub) UpdateWithdrawal(ctx context.Context, withdrawal *CommissionWithdrawal) error {
+	cp := *withdrawal
+	s.withdrawals[withdrawal.ID] = &cp
+	return nil
+}
+
+func (s *commissionRepoStub) ListWithdrawals(ctx context.Context, params pagination.PaginationParams, filters CommissionWithdrawalFilters) ([]CommissionWithdrawal, *pagination.PaginationResult, error) {
+	panic("unexpected ListWithdrawals call")
+}
+
+type inviteRepoCaptureStub struct {
+	InviteRepository
+	created *Invite
+}
+
+func (s *inviteRepoCaptureStub) Create(ctx context.Context, invite *Invite) error {
+	invite.ID = 1
+	s.created = invite
+	return nil
+}
+
+type inviteLogRepoNoopStub struct {
+	InviteLogRepository
+}
+
+func (s *inviteLogRepoNoopStub) Create(ctx context.Context, logRecord *InviteLog) error {
+	return nil
+}
+
+func newCommissionTestService(repo *commissionRepoStub, settings map[string]string) *InviteCommissionService {
+	settingService := NewSettingService(&settingRepoStub{values: settings}, nil)
+	return NewInviteCommissionService(nil, repo, nil, nil, settingService, nil, nil, 0)
+}
+
+func enabledCommissionSettings(rate, days, minWithdraw string) map[string]string {
+	return map[string]string{
+		SettingKeyInviteCommissionEnabled:     "true",
+		SettingKeyInviteCommissionRate:        rate,
+		SettingKeyInviteCommissionDays:        days,
+		SettingKeyInviteCommissionMinWithdraw: minWithdraw,
+	}
+}
+
+func TestInviteCommissionAccrue_AppliesRateAndAdvancesCursor(t *testing.T) {
+	repo := newCommissionRepoStub()
+	repo.candidates = []int64{7}
+	repo.targets[7] = &InviteCommissionTarget{InviteID: 7, InviterID: 1, InviteeID: 2, Status: InviteCommissionStatusActive, Cursor: 100, CreatedAt: time.Now().Add(-time.Hour)}
+	repo.batch = &CommissionUsageBatch{BaseAmount: 12.5, FromLogID: 101, ToLogID: 140, LogCount: 5}
+	svc := newCommissionTestService(repo, enabledCommissionSettings("10", "0", "0"))
+
+	accrued, total, err := svc.AccrueCommissions(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Equal(t, 1, accrued)
+	require.InDelta(t, 1.25, total, 1e-9)
+	require.Len(t, repo.commissions, 1)
+	require.Equal(t, int64(1), repo.commissions[0].InviterID)
+	require.InDelta(t, 1.25, repo.balances[1], 1e-9)
+	// 游标推进到 watermark，而不是本批最大日志 ID
+	require.Equal(t, int64(1000), repo.cursors[7])
+}
+
+func TestInviteCommissionAccrue_AdvancesCursorWithoutBillableUsage(t *testing.T) {
+	repo := newCommissionRepoStub()
+	repo.candidates = []int64{7}
+	repo.targets[7] = &InviteCommissionTarget{InviteID: 7, InviterID: 1, InviteeID: 2, Status: InviteCommissionStatusActive, Cursor: 100, CreatedAt: time.Now().Add(-time.Hour)}
+	svc := newCommissionTestService(repo, enabledCommissionSettings("10", "0", "0"))
+
+	accrued, _, err := svc.AccrueCommissions(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Zero(t, accrued)
+	require.Empty(t, repo.commissions)
+	require.Equal(t, int64(1000), repo.cursors[7])
+
+	// 尚无落定日志时不计佣
+	repo.watermark = 0
+	repo.cursors = map[int64]int64{}
+	_, _, err = svc.AccrueCommissions(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Empty(t, repo.cursors)
+}
+
+func TestInviteCommissionAccrue_StopsAtWindowEnd(t *testing.T) {
+	repo := newCommissionRepoStub()
+	inviteCreated := time.Now().AddDate(0, 0, -45)
+	repo.candidates = []int64{7}
+	repo.targets[7] = &InviteCommissionTarget{InviteID: 7, InviterID: 1, InviteeID: 2, Status: InviteCommissionStatusActive, CreatedAt: inviteCreated}
+	repo.batch = &CommissionUsageBatch{BaseAmount: 1, FromLogID: 1, ToLogID: 2, LogCount: 2}
+	svc := newCommissionTestService(repo, enabledCommissionSettings("5", "30", "0"))
+
+	_, _, err := svc.AccrueCommissions(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.NotNil(t, repo.sumWindow)
+	require.True(t, repo.sumWindow.Equal(inviteCreated.AddDate(0, 0, 30)))
+}
+
+func TestInviteCommissionAccrue_SkipsBlockedAndDisabled(t *testing.T) {
+	repo := newCommissionRepoStub()
+	repo.candidates = []int64{7}
+	repo.targets[7] = &InviteCommissionTarget{InviteID: 7, InviterID: 1, InviteeID: 2, Status: InviteCommissionStatusBlocked}
+	repo.batch = &CommissionUsageBatch{BaseAmount: 10, FromLogID: 1, ToLogID: 2, LogCount: 2}
+
+	svc := newCommissionTestService(repo, enabledCommissionSettings("10", "0", "0"))
+	accrued, _, err := svc.AccrueCommissions(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Zero(t, accrued)
+	require.Empty(t, repo.commissions)
+
+	repo.targets[7].Status = InviteCommissionStatusActive
+	disabled := newCommissionTestService(repo, map[string]string{SettingKeyInviteCommissionRate: "10"})
+	accrued, _, err = disabled.AccrueCommissions(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Zero(t, accrued)
+	require.Empty(t, repo.commissions)
+}
+
+func TestInviteCommissionWithdrawal_MinimumAndRejectRefund(t *testing.T) {
+	repo := newCommissionRepoStub()
+	repo.balances[1] = 50
+	svc := newCommissionTestService(repo, enabledCommissionSettings("10", "0", "20"))
+	ctx := context.Background()
+
+	_, err := svc.RequestWithdrawal(ctx, 1, 10, "alipay:foo")
+	require.ErrorIs(t, err, ErrCommissionBelowMinWithdraw)
+
+	_, err = svc.RequestWithdrawal(ctx, 1, 80, "alipay:foo")
+	require.ErrorIs(t, err, ErrCommissionInsufficientBalance)
+
+	withdrawal, err := svc.RequestWithdrawal(ctx, 1, 30, "alipay:foo")
+	require.NoError(t, err)
+	require.Equal(t, CommissionWithdrawalStatusPending, withdrawal.Status)
+	require.InDelta(t, 20, repo.balances[1], 1e-9)
+
+	rejected, err := svc.RejectWithdrawal(ctx, withdrawal.ID, 99, "invalid account")
+	require.NoError(t, err)
+	require.Equal(t, CommissionWithdrawalStatusRejected, rejected.Status)
+	require.InDelta(t, 50, repo.balances[1], 1e-9)
+
+	_, err = svc.ApproveWithdrawal(ctx, withdrawal.ID, 99, "")
+	require.ErrorIs(t, err, ErrCommissionWithdrawalProcessed)
+}
+
+func TestInviteServiceBindInvite_BlocksCommissionOnSameRegisterDevice(t *testing.T) {
+	device := "device-1"
+	repo := newCommissionRepoStub()
+	repo.conflict = &RegistrationConflict{SameIP: true, SameDevice: true}
+	inviteRepo := &inviteRepoCaptureStub{}
+	svc := NewInviteService(nil, inviteRepo, &inviteLogRepoNoopStub{}, repo, &userRepoStub{user: &User{ID: 2, RegisterDevice: &device}}, nil, nil, nil, nil)
+
+	invite, err := svc.BindInvite(context.Background(), 1, 2, "ABCDEF")
+	require.NoError(t, err)
+	require.Equal(t, InviteCommissionStatusBlocked, invite.CommissionStatus)
+	require.Equal(t, InviteCommissionBlockSameDevice, invite.CommissionBlockReason)
+
+	repo.conflict = &RegistrationConflict{}
+	invite, err = svc.BindInvite(context.Background(), 1, 2, "ABCDEF")
+	require.NoError(t, err)
+	require.Equal(t, InviteCommissionStatusActive, invite.CommissionStatus)
+
+	_, err = svc.BindInvite(context.Background(), 2, 2, "ABCDEF")
+	require.ErrorIs(t, err, ErrInviteCodeInvalid)
+}
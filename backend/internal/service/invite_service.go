@@ -35,6 +35,7 @@ type InviteService struct {
 	entClient            *ent.Client
 	inviteRepo           InviteRepository
 	inviteLogRepo        InviteLogRepository
+	commissionRepo       InviteCommissionRepository
 	userRepo             UserRepository
 	redeemRepo           RedeemCodeRepository
 	settingService       *SettingService
@@ -46,6 +47,7 @@ func NewInviteService(
 	entClient *ent.Client,
 	inviteRepo InviteRepository,
 	inviteLogRepo InviteLogRepository,
+	commissionRepo InviteCommissionRepository,
 	userRepo UserRepository,
 	redeemRepo RedeemCodeRepository,
 	settingService *SettingService,
@@ -56,6 +58,7 @@ func NewInviteService(
 		entClient:            entClient,
 		inviteRepo:           inviteRepo,
 		inviteLogRepo:        inviteLogRepo,
+		commissionRepo:       commissionRepo,
 		userRepo:             userRepo,
 		redeemRepo:           redeemRepo,
 		settingService:       settingService,
@@ -102,13 +105,16 @@ func (s *InviteService) BindInvite(ctx context.Context, inviterID, inviteeID int
 		return nil, ErrInviteCodeInvalid
 	}
 	rewardAmount := s.getInviteRewardAmount(ctx)
+	commissionStatus, blockReason := s.evaluateCommissionRisk(ctx, inviterID, inviteeID)
 
 	invite := &Invite{
-		InviterID:    inviterID,
-		InviteeID:    inviteeID,
-		InviteCode:   inviteCode,
-		RewardAmount: rewardAmount,
-		Status:       InviteStatusPending,
+		InviterID:             inviterID,
+		InviteeID:             inviteeID,
+		InviteCode:            inviteCode,
+		RewardAmount:          rewardAmount,
+		Status:                InviteStatusPending,
+		CommissionStatus:      commissionStatus,
+		CommissionBlockReason: blockReason,
 	}
 
 	if s.entClient == nil {
@@ -310,6 +316,19 @@ func randomInviteCode() (string, error) {
 	return string(b), nil
 }
 
+// evaluateCommissionRisk 同 IP/同设备注册的邀请关系不计佣（邀请奖励仍由管理员人工确认）
+func (s *InviteService) evaluateCommissionRisk(ctx context.Context, inviterID, inviteeID int64) (string, string) {
+	if s.commissionRepo == nil {
+		return InviteCommissionStatusActive, ""
+	}
+	invitee, err := s.userRepo.GetByID(ctx, inviteeID)
+	if err != nil {
+		log.Printf("[Invite] Load invitee for commission risk check failed: invitee_id=%d err=%v", inviteeID, err)
+		return InviteCommissionStatusActive, ""
+	}
+	return evaluateRegistrationRisk(ctx, s.commissionRepo, inviterID, invitee)
+}
+
 func (s *InviteService) getInviteRewardAmount(ctx context.Context) float64 {
 	if s.settingService == nil {
 		return 0
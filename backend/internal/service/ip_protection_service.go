@@ -0,0 +1,505 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+)
+
+// IP 封禁原因
+const (
+	IPBanReasonAuthFailure        = "auth_failure"
+	IPBanReasonNetworkAuthFailure = "network_auth_failure"
+	IPBanReasonRequestBurst       = "request_burst"
+)
+
+// IP 访问规则动作
+const (
+	IPAccessActionAllow = "allow"
+	IPAccessActionDeny  = "deny"
+)
+
+// IP 计数类型
+const (
+	ipCounterFailure        = "fail"
+	ipCounterNetworkFailure = "netfail"
+	ipCounterRequest        = "req"
+)
+
+const (
+	ipAccessRuleRefreshInterval = 30 * time.Second
+	maxIPAccessRules            = 1000
+	maxIPAccessRuleNoteLength   = 200
+)
+
+var (
+	ErrIPBanNotFound = infraerrors.NotFound("IP_BAN_NOT_FOUND", "no active ban for this address")
+)
+
+// IPBan 一条生效中的封禁
+type IPBan struct {
+	// Subject 被封禁的 IP 或网段（CIDR）
+	Subject string `json:"subject"`
+	Reason  string `json:"reason"`
+	// Level 记忆期内第几次封禁（从 1 开始），决定封禁时长
+	Level int `json:"level"`
+	// Count 触发封禁时窗口内的计数
+	Count     int64     `json:"count"`
+	BannedAt  time.Time `json:"banned_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IPAccessRule 管理员维护的 IP 白/黑名单条目
+type IPAccessRule struct {
+	// Pattern 单个 IP 或 CIDR
+	Pattern   string     `json:"pattern"`
+	Action    string     `json:"action"`
+	Note      string     `json:"note,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// IPProtectionCache 封禁状态与计数存储（Redis），多实例共享
+type IPProtectionCache interface {
+	// GetBan 返回 subjects 中第一个仍生效的封禁，均未封禁时返回 nil
+	GetBan(ctx context.Context, subjects []string) (*IPBan, error)
+	// IncrCounter 在固定窗口内累加计数并返回当前值
+	IncrCounter(ctx context.Context, kind, subject string, window time.Duration) (int64, error)
+	// IncrStrike 累加封禁次数并返回当前值，ttl 内无新封禁则清零
+	IncrStrike(ctx context.Context, subject string, ttl time.Duration) (int64, error)
+	SetBan(ctx context.Context, ban *IPBan) error
+	ListBans(ctx context.Context) ([]*IPBan, error)
+	// DeleteBan 解除封禁并清空封禁次数，返回封禁是否存在
+	DeleteBan(ctx context.Context, subject string) (bool, error)
+}
+
+// IPAccessDecision 请求准入结果
+type IPAccessDecision struct {
+	// Trusted 命中白名单：直接放行且不参与统计
+	Trusted bool
+	// Denied 命中黑名单
+	Denied bool
+	// Ban 命中的封禁（突发超限的并发请求可能只有 RetryAfter）
+	Ban        *IPBan
+	RetryAfter time.Duration
+}
+
+// Blocked 是否拒绝本次请求
+func (d IPAccessDecision) Blocked() bool {
+	return d.Denied || d.RetryAfter > 0
+}
+
+type ipAccessMatcher struct {
+	prefix    netip.Prefix
+	expiresAt *time.Time
+}
+
+type ipAccessRuleSet struct {
+	allow []ipAccessMatcher
+	deny  []ipAccessMatcher
+}
+
+func (s *ipAccessRuleSet) match(list []ipAccessMatcher, addr netip.Addr, now time.Time) bool {
+	for _, m := range list {
+		if m.expiresAt != nil && !now.Before(*m.expiresAt) {
+			continue
+		}
+		if m.prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IPProtectionService 网关与认证接口的自适应 IP 封禁。
+//
+// 按 IP 与所在网段统计认证失败（无效 API Key 等 401 响应），按 IP 统计突发请求，超过阈值后逐级封禁；
+// 管理员维护的白/黑名单保存在 settings 中，本地缓存并定期刷新，以便多实例间同步。
+type IPProtectionService struct {
+	cache       IPProtectionCache
+	settingRepo SettingRepository
+	cfg         *config.Config
+
+	rules    atomic.Pointer[ipAccessRuleSet]
+	updateMu sync.Mutex
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewIPProtectionService 创建 IP 防护服务
+func NewIPProtectionService(cache IPProtectionCache, settingRepo SettingRepository, cfg *config.Config) *IPProtectionService {
+	s := &IPProtectionService{
+		cache:       cache,
+		settingRepo: settingRepo,
+		cfg:         cfg,
+		stopCh:      make(chan struct{}),
+	}
+	s.rules.Store(&ipAccessRuleSet{})
+	return s
+}
+
+// Start 加载白/黑名单并启动定期刷新
+func (s *IPProtectionService) Start() {
+	if s == nil {
+		return
+	}
+	if err := s.reloadRules(context.Background()); err != nil {
+		log.Printf("[IPProtection] load access rules failed: %v", err)
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(ipAccessRuleRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				if err := s.reloadRules(ctx); err != nil {
+					log.Printf("[IPProtection] refresh access rules failed: %v", err)
+				}
+				cancel()
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台刷新
+func (s *IPProtectionService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() { close(s.stopCh) })
+	s.wg.Wait()
+}
+
+func (s *IPProtectionService) settings() config.IPProtectionConfig {
+	if s.cfg == nil {
+		return config.IPProtectionConfig{}
+	}
+	return s.cfg.Live().Security.IPProtection
+}
+
+// Check 判断请求是否放行，并累加突发请求计数。Redis 故障时放行（fail-open）。
+func (s *IPProtectionService) Check(ctx context.Context, clientIP string) IPAccessDecision {
+	if s == nil {
+		return IPAccessDecision{}
+	}
+	addr, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return IPAccessDecision{}
+	}
+	addr = addr.Unmap()
+	now := time.Now()
+	rules := s.rules.Load()
+	// 黑名单优先于白名单，且不受 enabled 开关影响
+	if rules.match(rules.deny, addr, now) {
+		return IPAccessDecision{Denied: true}
+	}
+	if rules.match(rules.allow, addr, now) {
+		return IPAccessDecision{Trusted: true}
+	}
+
+	cfg := s.settings()
+	if !cfg.Enabled || s.cache == nil {
+		return IPAccessDecision{}
+	}
+	subject := addr.String()
+	ban, err := s.cache.GetBan(ctx, []string{subject, networkSubject(addr, cfg)})
+	if err != nil {
+		log.Printf("[IPProtection] check ban failed: ip=%s err=%v", subject, err)
+		return IPAccessDecision{}
+	}
+	if ban != nil {
+		return IPAccessDecision{Ban: ban, RetryAfter: time.Until(ban.ExpiresAt)}
+	}
+
+	if cfg.BurstThreshold > 0 {
+		window := time.Duration(cfg.BurstWindowSeconds) * time.Second
+		count, err := s.cache.IncrCounter(ctx, ipCounterRequest, subject, window)
+		if err != nil {
+			log.Printf("[IPProtection] count request failed: ip=%s err=%v", subject, err)
+			return IPAccessDecision{}
+		}
+		threshold := int64(cfg.BurstThreshold)
+		if count > threshold {
+			// 仅越过阈值的那一次请求创建封禁，避免并发请求重复升级封禁级别
+			if count == threshold+1 {
+				if ban := s.ban(ctx, subject, IPBanReasonRequestBurst, count, cfg); ban != nil {
+					return IPAccessDecision{Ban: ban, RetryAfter: time.Until(ban.ExpiresAt)}
+				}
+			}
+			return IPAccessDecision{RetryAfter: window}
+		}
+	}
+	return IPAccessDecision{}
+}
+
+// RecordFailure 记录一次认证失败（无效 API Key、登录失败等 401 响应）
+func (s *IPProtectionService) RecordFailure(ctx context.Context, clientIP string) {
+	if s == nil || s.cache == nil {
+		return
+	}
+	cfg := s.settings()
+	if !cfg.Enabled {
+		return
+	}
+	addr, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return
+	}
+	addr = addr.Unmap()
+	window := time.Duration(cfg.FailureWindowSeconds) * time.Second
+
+	if cfg.FailureThreshold > 0 {
+		subject := addr.String()
+		count, err := s.cache.IncrCounter(ctx, ipCounterFailure, subject, window)
+		if err != nil {
+			log.Printf("[IPProtection] count failure failed: ip=%s err=%v", subject, err)
+			return
+		}
+		if count == int64(cfg.FailureThreshold) {
+			s.ban(ctx, subject, IPBanReasonAuthFailure, count, cfg)
+		}
+	}
+	if cfg.NetworkFailureThreshold > 0 {
+		subject := networkSubject(addr, cfg)
+		count, err := s.cache.IncrCounter(ctx, ipCounterNetworkFailure, subject, window)
+		if err != nil {
+			log.Printf("[IPProtection] count network failure failed: network=%s err=%v", subject, err)
+			return
+		}
+		if count == int64(cfg.NetworkFailureThreshold) {
+			s.ban(ctx, subject, IPBanReasonNetworkAuthFailure, count, cfg)
+		}
+	}
+}
+
+// ban 按记忆期内的封禁次数逐级延长封禁时长
+func (s *IPProtectionService) ban(ctx context.Context, subject, reason string, count int64, cfg config.IPProtectionConfig) *IPBan {
+	if len(cfg.BanDurationsSeconds) == 0 {
+		return nil
+	}
+	strikes, err := s.cache.IncrStrike(ctx, subject, time.Duration(cfg.StrikeTTLHours)*time.Hour)
+	if err != nil {
+		log.Printf("[IPProtection] increment strike failed: subject=%s err=%v", subject, err)
+		return nil
+	}
+	level := int(strikes)
+	if level < 1 {
+		level = 1
+	}
+	idx := level - 1
+	if idx >= len(cfg.BanDurationsSeconds) {
+		idx = len(cfg.BanDurationsSeconds) - 1
+	}
+	now := time.Now()
+	ban := &IPBan{
+		Subject:   subject,
+		Reason:    reason,
+		Level:     level,
+		Count:     count,
+		BannedAt:  now,
+		ExpiresAt: now.Add(time.Duration(cfg.BanDurationsSeconds[idx]) * time.Second),
+	}
+	if err := s.cache.SetBan(ctx, ban); err != nil {
+		log.Printf("[IPProtection] set ban failed: subject=%s err=%v", subject, err)
+		return nil
+	}
+	log.Printf("[IPProtection] banned subject=%s reason=%s level=%d count=%d until=%s",
+		subject, reason, level, count, ban.ExpiresAt.Format(time.RFC3339))
+	return ban
+}
+
+// networkSubject 返回 IP 所在的聚合网段（IPv4 /NetworkPrefixV4，IPv6 /NetworkPrefixV6）
+func networkSubject(addr netip.Addr, cfg config.IPProtectionConfig) string {
+	bits := cfg.NetworkPrefixV6
+	if addr.Is4() {
+		bits = cfg.NetworkPrefixV4
+	}
+	if bits <= 0 || bits > addr.BitLen() {
+		bits = addr.BitLen()
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return addr.String()
+	}
+	return prefix.String()
+}
+
+// ListBans 返回所有生效中的封禁（按封禁时间倒序）
+func (s *IPProtectionService) ListBans(ctx context.Context) ([]*IPBan, error) {
+	bans, err := s.cache.ListBans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].BannedAt.After(bans[j].BannedAt) })
+	return bans, nil
+}
+
+// Unban 解除封禁并重置封禁级别；subject 为 IP 或列表中展示的网段
+func (s *IPProtectionService) Unban(ctx context.Context, subject string) error {
+	prefix, err := ip.ParsePrefix(subject)
+	if err != nil {
+		return invalidIPAccessRule("%v", err)
+	}
+	normalized := prefix.String()
+	if prefix.IsSingleIP() {
+		normalized = prefix.Addr().String()
+	}
+	found, err := s.cache.DeleteBan(ctx, normalized)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrIPBanNotFound
+	}
+	return nil
+}
+
+// ListAccessRules 返回管理员维护的白/黑名单
+func (s *IPProtectionService) ListAccessRules(ctx context.Context) ([]IPAccessRule, error) {
+	return s.loadRules(ctx)
+}
+
+// UpdateAccessRules 整体替换白/黑名单并立即在本实例生效（其他实例在下次刷新时生效）
+func (s *IPProtectionService) UpdateAccessRules(ctx context.Context, rules []IPAccessRule) ([]IPAccessRule, error) {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	existing, err := s.loadRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := make(map[string]time.Time, len(existing))
+	for _, r := range existing {
+		createdAt[r.Pattern+"|"+r.Action] = r.CreatedAt
+	}
+
+	normalized, err := normalizeIPAccessRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for i := range normalized {
+		if t, ok := createdAt[normalized[i].Pattern+"|"+normalized[i].Action]; ok && !t.IsZero() {
+			normalized[i].CreatedAt = t
+		} else {
+			normalized[i].CreatedAt = now
+		}
+	}
+
+	raw, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.settingRepo.Set(ctx, SettingKeyIPAccessRules, string(raw)); err != nil {
+		return nil, fmt.Errorf("save ip access rules: %w", err)
+	}
+	s.rules.Store(compileIPAccessRules(normalized))
+	return normalized, nil
+}
+
+// invalidIPAccessRule 返回带具体原因的 400 错误
+func invalidIPAccessRule(format string, a ...any) error {
+	return infraerrors.Newf(http.StatusBadRequest, "IP_ACCESS_RULE_INVALID", "invalid IP access rule: "+format, a...)
+}
+
+func normalizeIPAccessRules(rules []IPAccessRule) ([]IPAccessRule, error) {
+	if len(rules) > maxIPAccessRules {
+		return nil, invalidIPAccessRule("at most %d rules are allowed", maxIPAccessRules)
+	}
+	out := make([]IPAccessRule, 0, len(rules))
+	seen := make(map[string]string, len(rules))
+	for _, r := range rules {
+		prefix, err := ip.ParsePrefix(r.Pattern)
+		if err != nil {
+			return nil, invalidIPAccessRule("invalid pattern %q: must be an IP address or CIDR", r.Pattern)
+		}
+		pattern := prefix.String()
+		if prefix.IsSingleIP() {
+			pattern = prefix.Addr().String()
+		}
+		action := strings.ToLower(strings.TrimSpace(r.Action))
+		if action != IPAccessActionAllow && action != IPAccessActionDeny {
+			return nil, invalidIPAccessRule("invalid action %q for %s: must be allow or deny", r.Action, pattern)
+		}
+		if prev, ok := seen[pattern]; ok {
+			if prev != action {
+				return nil, invalidIPAccessRule("%s is listed as both allow and deny", pattern)
+			}
+			continue
+		}
+		seen[pattern] = action
+		note := strings.TrimSpace(r.Note)
+		if len([]rune(note)) > maxIPAccessRuleNoteLength {
+			note = string([]rune(note)[:maxIPAccessRuleNoteLength])
+		}
+		out = append(out, IPAccessRule{Pattern: pattern, Action: action, Note: note, ExpiresAt: r.ExpiresAt})
+	}
+	return out, nil
+}
+
+func compileIPAccessRules(rules []IPAccessRule) *ipAccessRuleSet {
+	set := &ipAccessRuleSet{}
+	for _, r := range rules {
+		prefix, err := ip.ParsePrefix(r.Pattern)
+		if err != nil {
+			continue
+		}
+		m := ipAccessMatcher{prefix: prefix, expiresAt: r.ExpiresAt}
+		if r.Action == IPAccessActionDeny {
+			set.deny = append(set.deny, m)
+		} else {
+			set.allow = append(set.allow, m)
+		}
+	}
+	return set
+}
+
+func (s *IPProtectionService) loadRules(ctx context.Context) ([]IPAccessRule, error) {
+	if s.settingRepo == nil {
+		return []IPAccessRule{}, nil
+	}
+	raw, err := s.settingRepo.GetValue(ctx, SettingKeyIPAccessRules)
+	if err != nil {
+		if errors.Is(err, ErrSettingNotFound) {
+			return []IPAccessRule{}, nil
+		}
+		return nil, err
+	}
+	rules := []IPAccessRule{}
+	if strings.TrimSpace(raw) == "" {
+		return rules, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil, fmt.Errorf("decode ip access rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (s *IPProtectionService) reloadRules(ctx context.Context) error {
+	rules, err := s.loadRules(ctx)
+	if err != nil {
+		return err
+	}
+	s.rules.Store(compileIPAccessRules(rules))
+	return nil
+}
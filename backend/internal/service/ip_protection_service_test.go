@@ -0,0 +1,171 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type ipProtectionCacheStub struct {
+	counters map[string]int64
+	strikes  map[string]int64
+	bans     map[string]*IPBan
+}
+
+func newIPProtectionCacheStub() *ipProtectionCacheStub {
+	return &ipProtectionCacheStub{
+		counters: map[string]int64{},
+		strikes:  map[string]int64{},
+		bans:     map[string]*IPBan{},
+	}
+}
+
+func (c *ipProtectionCacheStub) GetBan(ctx context.Context, subjects []string) (*IPBan, error) {
+	for _, s := range subjects {
+		if ban, ok := c.bans[s]; ok {
+			return ban, nil
+		}
+	}
+	return nil, nil
+}
+func (c *ipProtectionCacheStub) IncrCounter(ctx context.Context, kind, subject string, window time.Duration) (int64, error) {
+	c.counters[kind+":"+subject]++
+	return c.counters[kind+":"+subject], nil
+}
+func (c *ipProtectionCacheStub) IncrStrike(ctx context.Context, subject string, ttl time.Duration) (int64, error) {
+	c.strikes[subject]++
+	return c.strikes[subject], nil
+}
+func (c *ipProtectionCacheStub) SetBan(ctx context.Context, ban *IPBan) error {
+	c.bans[ban.Subject] = ban
+	return nil
+}
+func (c *ipProtectionCacheStub) ListBans(ctx context.Context) ([]*IPBan, error) {
+	out := make([]*IPBan, 0, len(c.bans))
+	for _, b := range c.bans {
+		out = append(out, b)
+	}
+	return out, nil
+}
+func (c *ipProtectionCacheStub) DeleteBan(ctx context.Context, subject string) (bool, error) {
+	_, ok := c.bans[subject]
+	delete(c.bans, subject)
+	delete(c.strikes, subject)
+	return ok, nil
+}
+
+type ipRulesSettingRepoStub struct {
+	SettingRepository
+	values map[string]string
+}
+
+func (r *ipRulesSettingRepoStub) GetValue(ctx context.Context, key string) (string, error) {
+	v, ok := r.values[key]
+	if !ok {
+		return "", ErrSettingNotFound
+	}
+	return v, nil
+}
+func (r *ipRulesSettingRepoStub) Set(ctx context.Context, key, value string) error {
+	r.values[key] = value
+	return nil
+}
+
+func newIPProtectionTestConfig() *config.Config {
+	return &config.Config{Security: config.SecurityConfig{IPProtection: config.IPProtectionConfig{
+		Enabled:                 true,
+		FailureThreshold:        3,
+		FailureWindowSeconds:    60,
+		NetworkFailureThreshold: 5,
+		NetworkPrefixV4:         24,
+		NetworkPrefixV6:         48,
+		BurstThreshold:          4,
+		BurstWindowSeconds:      10,
+		BanDurationsSeconds:     []int{60, 600},
+		StrikeTTLHours:          24,
+	}}}
+}
+
+func TestIPProtectionEscalatesFailureBans(t *testing.T) {
+	ctx := context.Background()
+	cache := newIPProtectionCacheStub()
+	svc := NewIPProtectionService(cache, nil, newIPProtectionTestConfig())
+
+	for i := 0; i < 3; i++ {
+		require.False(t, svc.Check(ctx, "203.0.113.7").Blocked())
+		svc.RecordFailure(ctx, "203.0.113.7")
+	}
+	decision := svc.Check(ctx, "203.0.113.7")
+	require.True(t, decision.Blocked())
+	require.Equal(t, IPBanReasonAuthFailure, decision.Ban.Reason)
+	require.Equal(t, 1, decision.Ban.Level)
+	require.InDelta(t, time.Minute.Seconds(), decision.RetryAfter.Seconds(), 2)
+
+	// 同网段的其他 IP 未达到网段阈值
+	require.False(t, svc.Check(ctx, "203.0.113.8").Blocked())
+
+	// 第二次封禁升级到下一级时长，超出级数后沿用最后一级
+	delete(cache.bans, "203.0.113.7")
+	delete(cache.counters, ipCounterFailure+":203.0.113.7")
+	for i := 0; i < 3; i++ {
+		svc.RecordFailure(ctx, "203.0.113.7")
+	}
+	require.Equal(t, 2, cache.bans["203.0.113.7"].Level)
+	require.InDelta(t, (10 * time.Minute).Seconds(), time.Until(cache.bans["203.0.113.7"].ExpiresAt).Seconds(), 2)
+
+	// 轮换 IP 的失败按网段累计（6 次 → 第 5 次已触发）
+	require.NotNil(t, cache.bans["203.0.113.0/24"])
+	require.NotNil(t, cache.bans["203.0.113.0/24"])
+	decision = svc.Check(ctx, "203.0.113.200")
+	require.True(t, decision.Blocked())
+	require.Equal(t, IPBanReasonNetworkAuthFailure, decision.Ban.Reason)
+
+	require.NoError(t, svc.Unban(ctx, "203.0.113.7"))
+	require.Zero(t, cache.strikes["203.0.113.7"])
+	require.ErrorIs(t, svc.Unban(ctx, "198.51.100.1"), ErrIPBanNotFound)
+}
+
+func TestIPProtectionBurstAndAccessRules(t *testing.T) {
+	ctx := context.Background()
+	cache := newIPProtectionCacheStub()
+	repo := &ipRulesSettingRepoStub{values: map[string]string{}}
+	svc := NewIPProtectionService(cache, repo, newIPProtectionTestConfig())
+
+	for i := 0; i < 4; i++ {
+		require.False(t, svc.Check(ctx, "2001:db8::1").Blocked())
+	}
+	decision := svc.Check(ctx, "2001:db8::1")
+	require.True(t, decision.Blocked())
+	require.Equal(t, IPBanReasonRequestBurst, decision.Ban.Reason)
+
+	_, err := svc.UpdateAccessRules(ctx, []IPAccessRule{{Pattern: "10.0.0.1/8", Action: "allow"}, {Pattern: "10.0.0.0/8", Action: "deny"}})
+	require.Error(t, err)
+	_, err = svc.UpdateAccessRules(ctx, []IPAccessRule{{Pattern: "not-an-ip", Action: "deny"}})
+	require.Error(t, err)
+
+	rules, err := svc.UpdateAccessRules(ctx, []IPAccessRule{
+		{Pattern: "10.1.2.3/8", Action: "Allow", Note: " office "},
+		{Pattern: "::ffff:198.51.100.9", Action: "deny"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.0/8", rules[0].Pattern)
+	require.Equal(t, "office", rules[0].Note)
+	require.Equal(t, "198.51.100.9", rules[1].Pattern)
+
+	require.True(t, svc.Check(ctx, "198.51.100.9").Denied)
+	// 白名单不计数、不封禁
+	for i := 0; i < 10; i++ {
+		require.True(t, svc.Check(ctx, "10.9.9.9").Trusted)
+	}
+	require.Zero(t, cache.counters[ipCounterRequest+":10.9.9.9"])
+
+	// 其他实例从 settings 重新加载后生效
+	other := NewIPProtectionService(cache, repo, newIPProtectionTestConfig())
+	require.NoError(t, other.reloadRules(ctx))
+	require.True(t, other.Check(ctx, "198.51.100.9").Denied)
+}
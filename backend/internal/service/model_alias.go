@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+const (
+	maxModelAliases       = 100
+	maxModelAliasTargets  = 8
+	maxModelAliasNameSize = 128
+)
+
+// ModelAlias 分组级虚拟模型：用户以 Name 请求，网关按 Targets 顺序解析为真实上游模型。
+// 前一个目标无可用账号或账号故障转移耗尽时切换到下一个目标；计费按实际转发的目标模型。
+type ModelAlias struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Targets     []ModelAliasTarget `json:"targets"`
+}
+
+// ModelAliasTarget 虚拟模型的一个候选目标。Platform 为账号平台，
+// 必须是分组能够调度到的平台（例如 anthropic 分组通过混合调度使用 antigravity 账号）；
+// 网关不做跨协议转换，保存时即拒绝分组无法承载的目标。
+type ModelAliasTarget struct {
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+}
+
+func invalidModelAliases(format string, a ...any) error {
+	return infraerrors.Newf(http.StatusBadRequest, "MODEL_ALIASES_INVALID", "invalid model aliases: "+format, a...)
+}
+
+// NormalizeModelAliases 校验并规范化模型别名配置（去除空白、平台小写、拒绝重复、自引用与分组无法承载的目标平台）
+func NormalizeModelAliases(groupPlatform string, aliases []ModelAlias) ([]ModelAlias, error) {
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+	if len(aliases) > maxModelAliases {
+		return nil, invalidModelAliases("at most %d aliases", maxModelAliases)
+	}
+
+	seen := make(map[string]struct{}, len(aliases))
+	out := make([]ModelAlias, 0, len(aliases))
+	for i, alias := range aliases {
+		alias.Name = strings.TrimSpace(alias.Name)
+		alias.Description = strings.TrimSpace(alias.Description)
+		if alias.Name == "" {
+			return nil, invalidModelAliases("aliases[%d]: name is required", i)
+		}
+		if len(alias.Name) > maxModelAliasNameSize {
+			return nil, invalidModelAliases("aliases[%d]: name is too long", i)
+		}
+		// 名称会出现在 Gemini 路径 models/{model}:action 中，不允许分隔符与通配符
+		if strings.ContainsAny(alias.Name, " \t/:*?#") {
+			return nil, invalidModelAliases("aliases[%d]: name %q contains invalid characters", i, alias.Name)
+		}
+		if _, dup := seen[alias.Name]; dup {
+			return nil, invalidModelAliases("aliases[%d]: duplicate name %q", i, alias.Name)
+		}
+		seen[alias.Name] = struct{}{}
+
+		if len(alias.Targets) == 0 {
+			return nil, invalidModelAliases("aliases[%d]: at least one target is required", i)
+		}
+		if len(alias.Targets) > maxModelAliasTargets {
+			return nil, invalidModelAliases("aliases[%d]: at most %d targets", i, maxModelAliasTargets)
+		}
+		targets := make([]ModelAliasTarget, 0, len(alias.Targets))
+		seenTargets := make(map[ModelAliasTarget]struct{}, len(alias.Targets))
+		for j, target := range alias.Targets {
+			target.Platform = strings.ToLower(strings.TrimSpace(target.Platform))
+			target.Model = strings.TrimSpace(target.Model)
+			switch target.Platform {
+			case PlatformAnthropic, PlatformOpenAI, PlatformGemini, PlatformAntigravity:
+			default:
+				return nil, invalidModelAliases("aliases[%d].targets[%d]: unsupported platform %q", i, j, target.Platform)
+			}
+			if !ModelAliasTargetServable(groupPlatform, target.Platform) {
+				return nil, invalidModelAliases("aliases[%d].targets[%d]: platform %q cannot be served by a %s group", i, j, target.Platform, groupPlatform)
+			}
+			if target.Model == "" {
+				return nil, invalidModelAliases("aliases[%d].targets[%d]: model is required", i, j)
+			}
+			if target.Model == alias.Name {
+				return nil, invalidModelAliases("aliases[%d].targets[%d]: target cannot refer to the alias itself", i, j)
+			}
+			if _, dup := seenTargets[target]; dup {
+				return nil, invalidModelAliases("aliases[%d].targets[%d]: duplicate target", i, j)
+			}
+			seenTargets[target] = struct{}{}
+			targets = append(targets, target)
+		}
+		alias.Targets = targets
+		out = append(out, alias)
+	}
+
+	// 别名之间不允许互相引用，避免用户误以为支持多级解析
+	for i, alias := range out {
+		for j, target := range alias.Targets {
+			if _, ok := seen[target.Model]; ok {
+				return nil, invalidModelAliases("aliases[%d].targets[%d]: target %q is itself an alias", i, j, target.Model)
+			}
+		}
+	}
+	return out, nil
+}
+
+// FindModelAlias 按名称查找分组虚拟模型，未配置时返回 nil
+func (g *Group) FindModelAlias(name string) *ModelAlias {
+	if g == nil || name == "" {
+		return nil
+	}
+	for i := range g.ModelAliases {
+		if g.ModelAliases[i].Name == name {
+			return &g.ModelAliases[i]
+		}
+	}
+	return nil
+}
+
+// ModelAliasTargetServable 判断在给定路由平台（强制平台或分组平台）下能否调度到目标平台的账号
+func ModelAliasTargetServable(routePlatform, targetPlatform string) bool {
+	if routePlatform == targetPlatform {
+		return true
+	}
+	// anthropic/gemini 分组可通过混合调度使用 antigravity 账号
+	return targetPlatform == PlatformAntigravity &&
+		(routePlatform == PlatformAnthropic || routePlatform == PlatformGemini)
+}
+
+// ResolveModelAliasTargets 解析请求模型。model 不是分组别名时 isAlias 为 false；
+// 是别名时返回当前路由平台可承载的目标（按配置顺序），可能为空。
+func ResolveModelAliasTargets(group *Group, routePlatform, model string) (targets []ModelAliasTarget, isAlias bool) {
+	alias := group.FindModelAlias(model)
+	if alias == nil {
+		return nil, false
+	}
+	for _, target := range alias.Targets {
+		if ModelAliasTargetServable(routePlatform, target.Platform) {
+			targets = append(targets, target)
+		}
+	}
+	return targets, true
+}
+
+// ListModelAliases 返回当前路由平台下至少有一个可用目标的分组别名（用于模型列表接口）
+func ListModelAliases(group *Group, routePlatform string) []ModelAlias {
+	if group == nil {
+		return nil
+	}
+	var out []ModelAlias
+	for _, alias := range group.ModelAliases {
+		if targets, _ := ResolveModelAliasTargets(group, routePlatform, alias.Name); len(targets) > 0 {
+			out = append(out, alias)
+		}
+	}
+	return out
+}
+
+// WithModelAliasTarget 将当前尝试的别名目标写入 context，调度时只选择目标平台的账号
+func WithModelAliasTarget(ctx context.Context, target *ModelAliasTarget) context.Context {
+	if target == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxkey.ModelAliasTarget, target)
+}
+
+// ModelAliasTargetFromContext 读取网关 handler 写入的别名目标，非别名请求返回 nil
+func ModelAliasTargetFromContext(ctx context.Context) *ModelAliasTarget {
+	if ctx == nil {
+		return nil
+	}
+	target, _ := ctx.Value(ctxkey.ModelAliasTarget).(*ModelAliasTarget)
+	return target
+}
+
+// isAccountAllowedForModelAliasTarget 别名请求只允许目标平台的账号参与调度
+func isAccountAllowedForModelAliasTarget(ctx context.Context, account *Account) bool {
+	target := ModelAliasTargetFromContext(ctx)
+	return target == nil || account.Platform == target.Platform
+}
+
+// filterAccountsForModelAliasTarget 按别名目标平台过滤候选账号
+func filterAccountsForModelAliasTarget(ctx context.Context, accounts []Account) []Account {
+	if ModelAliasTargetFromContext(ctx) == nil {
+		return accounts
+	}
+	out := accounts[:0:0]
+	for i := range accounts {
+		if isAccountAllowedForModelAliasTarget(ctx, &accounts[i]) {
+			out = append(out, accounts[i])
+		}
+	}
+	return out
+}
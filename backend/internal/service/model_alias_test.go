@@ -0,0 +1,104 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeModelAliases(t *testing.T) {
+	aliases, err := NormalizeModelAliases(PlatformAnthropic, []ModelAlias{{
+		Name:        " team-fast ",
+		Description: " Fast tier ",
+		Targets: []ModelAliasTarget{
+			{Platform: " Anthropic ", Model: " claude-haiku-4-5 "},
+			{Platform: "antigravity", Model: "gemini-2.5-flash"},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, aliases, 1)
+	require.Equal(t, "team-fast", aliases[0].Name)
+	require.Equal(t, "Fast tier", aliases[0].Description)
+	require.Equal(t, ModelAliasTarget{Platform: PlatformAnthropic, Model: "claude-haiku-4-5"}, aliases[0].Targets[0])
+
+	target := func(platform, model string) []ModelAliasTarget {
+		return []ModelAliasTarget{{Platform: platform, Model: model}}
+	}
+	invalid := [][]ModelAlias{
+		{{Name: "", Targets: target(PlatformAnthropic, "m")}},
+		{{Name: "a:b", Targets: target(PlatformAnthropic, "m")}},
+		{{Name: "fast"}},
+		{{Name: "fast", Targets: target("azure", "m")}},
+		{{Name: "fast", Targets: target(PlatformAnthropic, "")}},
+		{{Name: "fast", Targets: target(PlatformAnthropic, "fast")}},
+		{{Name: "fast", Targets: []ModelAliasTarget{{Platform: PlatformAnthropic, Model: "m"}, {Platform: "anthropic", Model: "m"}}}},
+		{{Name: "fast", Targets: target(PlatformAnthropic, "m")}, {Name: "fast", Targets: target(PlatformAnthropic, "n")}},
+		{{Name: "fast", Targets: target(PlatformAnthropic, "slow")}, {Name: "slow", Targets: target(PlatformAnthropic, "n")}},
+		// 网关不做跨协议转换：anthropic 分组不能使用 gemini/openai 目标
+		{{Name: "fast", Targets: []ModelAliasTarget{{Platform: PlatformAnthropic, Model: "claude-haiku-4-5"}, {Platform: PlatformGemini, Model: "gemini-2.5-flash"}}}},
+		{{Name: "fast", Targets: target(PlatformOpenAI, "gpt-5")}},
+	}
+	for _, input := range invalid {
+		_, err := NormalizeModelAliases(PlatformAnthropic, input)
+		require.Error(t, err, "aliases %+v should be rejected", input)
+	}
+
+	aliases, err = NormalizeModelAliases(PlatformAnthropic, nil)
+	require.NoError(t, err)
+	require.Nil(t, aliases)
+}
+
+func TestResolveModelAliasTargets(t *testing.T) {
+	group := &Group{
+		Platform: PlatformAnthropic,
+		ModelAliases: []ModelAlias{
+			{Name: "team-fast", Targets: []ModelAliasTarget{
+				{Platform: PlatformAnthropic, Model: "claude-haiku-4-5"},
+				{Platform: PlatformGemini, Model: "gemini-2.5-flash"},
+				{Platform: PlatformAntigravity, Model: "gemini-2.5-flash"},
+			}},
+			{Name: "gemini-only", Targets: []ModelAliasTarget{{Platform: PlatformGemini, Model: "gemini-2.5-pro"}}},
+		},
+	}
+
+	targets, isAlias := ResolveModelAliasTargets(group, PlatformAnthropic, "team-fast")
+	require.True(t, isAlias)
+	require.Equal(t, []ModelAliasTarget{
+		{Platform: PlatformAnthropic, Model: "claude-haiku-4-5"},
+		{Platform: PlatformAntigravity, Model: "gemini-2.5-flash"},
+	}, targets)
+
+	// 强制 antigravity 路由只保留 antigravity 目标
+	targets, _ = ResolveModelAliasTargets(group, PlatformAntigravity, "team-fast")
+	require.Equal(t, []ModelAliasTarget{{Platform: PlatformAntigravity, Model: "gemini-2.5-flash"}}, targets)
+
+	targets, isAlias = ResolveModelAliasTargets(group, PlatformAnthropic, "gemini-only")
+	require.True(t, isAlias)
+	require.Empty(t, targets)
+
+	_, isAlias = ResolveModelAliasTargets(group, PlatformAnthropic, "claude-sonnet-4-5")
+	require.False(t, isAlias)
+
+	listed := ListModelAliases(group, PlatformAnthropic)
+	require.Len(t, listed, 1)
+	require.Equal(t, "team-fast", listed[0].Name)
+	require.Len(t, ListModelAliases(group, PlatformGemini), 2)
+}
+
+func TestFilterAccountsForModelAliasTarget(t *testing.T) {
+	accounts := []Account{
+		{ID: 1, Platform: PlatformAnthropic},
+		{ID: 2, Platform: PlatformAntigravity},
+	}
+	require.Len(t, filterAccountsForModelAliasTarget(context.Background(), accounts), 2)
+
+	ctx := WithModelAliasTarget(context.Background(), &ModelAliasTarget{Platform: PlatformAntigravity, Model: "gemini-2.5-flash"})
+	filtered := filterAccountsForModelAliasTarget(ctx, accounts)
+	require.Len(t, filtered, 1)
+	require.Equal(t, int64(2), filtered[0].ID)
+	require.False(t, isAccountAllowedForModelAliasTarget(ctx, &accounts[0]))
+	require.Len(t, accounts, 2)
+}
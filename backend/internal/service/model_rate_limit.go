@@ -1,50 +1,185 @@
 package service
 
 import (
+	"regexp"
+	"sort"
 	"strings"
 	"time"
+
+	"github.com/tidwall/gjson"
 )
 
 const modelRateLimitsKey = "model_rate_limits"
 const modelRateLimitScopeClaudeSonnet = "claude_sonnet"
 
-func resolveModelRateLimitScope(requestedModel string) (string, bool) {
-	model := strings.ToLower(strings.TrimSpace(requestedModel))
-	if model == "" {
-		return "", false
-	}
-	model = strings.TrimPrefix(model, "models/")
-	if strings.Contains(model, "sonnet") {
-		return modelRateLimitScopeClaudeSonnet, true
+// maxModelRateLimitScopeRules 模型限流域规则数量上限
+const maxModelRateLimitScopeRules = 100
+
+// modelRateLimitScopeNamePattern 限流域名称仅允许小写字母、数字、下划线和连字符（用作 JSONB 路径）
+var modelRateLimitScopeNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// ModelRateLimitScopeRule 模型限流域规则：某平台上匹配 Pattern 的模型共享名为 Scope 的限流域。
+// Pattern 支持 * 通配符（可出现在任意位置，如 "*opus*"、"gemini-2.5-pro*"），大小写不敏感。
+type ModelRateLimitScopeRule struct {
+	Platform string `json:"platform"`
+	Pattern  string `json:"pattern"`
+	Scope    string `json:"scope"`
+}
+
+// ModelRateLimitScopeSettings 模型限流域配置
+type ModelRateLimitScopeSettings struct {
+	Rules []ModelRateLimitScopeRule `json:"rules"`
+}
+
+// DefaultModelRateLimitScopeSettings 返回默认的模型限流域配置
+func DefaultModelRateLimitScopeSettings() *ModelRateLimitScopeSettings {
+	return &ModelRateLimitScopeSettings{
+		Rules: []ModelRateLimitScopeRule{
+			{Platform: PlatformAnthropic, Pattern: "*opus*", Scope: "claude_opus"},
+			{Platform: PlatformAnthropic, Pattern: "*sonnet*", Scope: modelRateLimitScopeClaudeSonnet},
+			{Platform: PlatformAnthropic, Pattern: "*haiku*", Scope: "claude_haiku"},
+		},
 	}
-	return "", false
 }
 
-func (a *Account) isModelRateLimited(requestedModel string) bool {
-	scope, ok := resolveModelRateLimitScope(requestedModel)
-	if !ok {
+// ModelRateLimitStatus 账号某个限流域的当前限流状态（用于管理后台展示）
+type ModelRateLimitStatus struct {
+	Scope   string    `json:"scope"`
+	Source  string    `json:"source"` // model / antigravity
+	Models  []string  `json:"models,omitempty"`
+	ResetAt time.Time `json:"reset_at"`
+}
+
+// 限流域状态来源
+const (
+	ModelRateLimitSourceModel       = "model"
+	ModelRateLimitSourceAntigravity = "antigravity"
+)
+
+// matchModelScopePattern 大小写不敏感的 * 通配匹配
+func matchModelScopePattern(pattern, value string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	value = strings.ToLower(strings.TrimSpace(value))
+	if pattern == "" {
 		return false
 	}
-	resetAt := a.modelRateLimitResetAt(scope)
-	if resetAt == nil {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
 		return false
 	}
-	return time.Now().Before(*resetAt)
+	value = value[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(value, part)
+		if idx < 0 {
+			return false
+		}
+		value = value[idx+len(part):]
+	}
+	return strings.HasSuffix(value, last)
 }
 
-func (a *Account) modelRateLimitResetAt(scope string) *time.Time {
-	if a == nil || a.Extra == nil || scope == "" {
-		return nil
+// resolveModelRateLimitScope 按平台规则解析模型所属限流域，返回域名及该域的全部模型模式。
+// subject 可以是模型名，也可以是上游错误信息（如 "rate limit for Claude Opus"），
+// 通配模式在整段文本上匹配。
+func resolveModelRateLimitScope(rules []ModelRateLimitScopeRule, platform, subject string) (string, []string, bool) {
+	subject = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(subject)), "models/")
+	if subject == "" {
+		return "", nil, false
+	}
+	for _, rule := range rules {
+		if rule.Platform != platform || !matchModelScopePattern(rule.Pattern, subject) {
+			continue
+		}
+		var patterns []string
+		for _, r := range rules {
+			if r.Platform == platform && r.Scope == rule.Scope {
+				patterns = append(patterns, strings.ToLower(strings.TrimSpace(r.Pattern)))
+			}
+		}
+		return rule.Scope, patterns, true
+	}
+	return "", nil, false
+}
+
+// extractRateLimitedModel 从上游 429 响应体中解析被限流的模型名
+func extractRateLimitedModel(body []byte) string {
+	for _, path := range []string{
+		"error.model",
+		"model",
+		"error.details.#.metadata.model",
+		"error.details.#.violations.#.quotaDimensions.model",
+	} {
+		for _, v := range flattenGJSONStrings(gjson.GetBytes(body, path)) {
+			if v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+func flattenGJSONStrings(r gjson.Result) []string {
+	if !r.IsArray() {
+		return []string{strings.TrimSpace(r.String())}
+	}
+	var out []string
+	for _, item := range r.Array() {
+		out = append(out, flattenGJSONStrings(item)...)
+	}
+	return out
+}
+
+func (a *Account) isModelRateLimited(requestedModel string) bool {
+	model := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(requestedModel)), "models/")
+	if model == "" || a == nil || a.Extra == nil {
+		return false
 	}
 	rawLimits, ok := a.Extra[modelRateLimitsKey].(map[string]any)
 	if !ok {
-		return nil
+		return false
 	}
-	rawLimit, ok := rawLimits[scope].(map[string]any)
-	if !ok {
-		return nil
+	now := time.Now()
+	for scope, raw := range rawLimits {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		resetAt := parseModelRateLimitResetAt(entry)
+		if resetAt == nil || !now.Before(*resetAt) {
+			continue
+		}
+		if modelRateLimitEntryMatches(scope, entry, model) {
+			return true
+		}
 	}
-	resetAtRaw, ok := rawLimit["rate_limit_reset_at"].(string)
+	return false
+}
+
+// modelRateLimitEntryMatches 判断限流记录是否覆盖该模型。
+// 记录中保存了限流时生效的模型模式；旧记录（无模式）按默认规则解析。
+func modelRateLimitEntryMatches(scope string, entry map[string]any, model string) bool {
+	if rawModels, ok := entry["models"].([]any); ok && len(rawModels) > 0 {
+		for _, rawModel := range rawModels {
+			if pattern, ok := rawModel.(string); ok && matchModelScopePattern(pattern, model) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, rule := range DefaultModelRateLimitScopeSettings().Rules {
+		if rule.Scope == scope && matchModelScopePattern(rule.Pattern, model) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseModelRateLimitResetAt(entry map[string]any) *time.Time {
+	resetAtRaw, ok := entry["rate_limit_reset_at"].(string)
 	if !ok || strings.TrimSpace(resetAtRaw) == "" {
 		return nil
 	}
@@ -54,3 +189,46 @@ func (a *Account) modelRateLimitResetAt(scope string) *time.Time {
 	}
 	return &resetAt
 }
+
+// ActiveModelRateLimits 返回账号当前仍生效的按模型域限流记录（含 Antigravity 配额域），按重置时间排序
+func (a *Account) ActiveModelRateLimits() []ModelRateLimitStatus {
+	if a == nil || a.Extra == nil {
+		return nil
+	}
+	now := time.Now()
+	var out []ModelRateLimitStatus
+	collect := func(key, source string) {
+		rawScopes, ok := a.Extra[key].(map[string]any)
+		if !ok {
+			return
+		}
+		for scope, raw := range rawScopes {
+			entry, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			resetAt := parseModelRateLimitResetAt(entry)
+			if resetAt == nil || !now.Before(*resetAt) {
+				continue
+			}
+			status := ModelRateLimitStatus{Scope: scope, Source: source, ResetAt: *resetAt}
+			if rawModels, ok := entry["models"].([]any); ok {
+				for _, rawModel := range rawModels {
+					if m, ok := rawModel.(string); ok {
+						status.Models = append(status.Models, m)
+					}
+				}
+			}
+			out = append(out, status)
+		}
+	}
+	collect(modelRateLimitsKey, ModelRateLimitSourceModel)
+	collect(antigravityQuotaScopesKey, ModelRateLimitSourceAntigravity)
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].ResetAt.Equal(out[j].ResetAt) {
+			return out[i].ResetAt.Before(out[j].ResetAt)
+		}
+		return out[i].Scope < out[j].Scope
+	})
+	return out
+}
@@ -0,0 +1,144 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type modelRateLimitRepoStub struct {
+	mockAccountRepoForGemini
+	scope        string
+	models       []string
+	modelCalls   int
+	accountCalls int
+}
+
+func (r *modelRateLimitRepoStub) SetModelRateLimit(ctx context.Context, id int64, scope string, models []string, resetAt time.Time) error {
+	r.modelCalls++
+	r.scope = scope
+	r.models = models
+	return nil
+}
+
+func (r *modelRateLimitRepoStub) SetRateLimited(ctx context.Context, id int64, resetAt time.Time) error {
+	r.accountCalls++
+	return nil
+}
+
+func TestMatchModelScopePattern(t *testing.T) {
+	require.True(t, matchModelScopePattern("*opus*", "claude-opus-4-5"))
+	require.True(t, matchModelScopePattern("*OPUS*", "Rate limit for Claude Opus"))
+	require.True(t, matchModelScopePattern("gemini-2.5-pro*", "gemini-2.5-pro-preview"))
+	require.True(t, matchModelScopePattern("claude-*-4*", "claude-sonnet-4-5"))
+	require.True(t, matchModelScopePattern("gpt-5", "gpt-5"))
+	require.False(t, matchModelScopePattern("gpt-5", "gpt-5-codex"))
+	require.False(t, matchModelScopePattern("*haiku*", "claude-sonnet-4-5"))
+	require.False(t, matchModelScopePattern("", "anything"))
+}
+
+func TestResolveModelRateLimitScope(t *testing.T) {
+	rules := []ModelRateLimitScopeRule{
+		{Platform: PlatformGemini, Pattern: "gemini-2.5-pro*", Scope: "gemini_pro"},
+		{Platform: PlatformGemini, Pattern: "gemini-3-pro*", Scope: "gemini_pro"},
+		{Platform: PlatformGemini, Pattern: "*flash*", Scope: "gemini_flash"},
+	}
+
+	scope, models, ok := resolveModelRateLimitScope(rules, PlatformGemini, "models/gemini-2.5-pro")
+	require.True(t, ok)
+	require.Equal(t, "gemini_pro", scope)
+	require.Equal(t, []string{"gemini-2.5-pro*", "gemini-3-pro*"}, models)
+
+	_, _, ok = resolveModelRateLimitScope(rules, PlatformAnthropic, "gemini-2.5-flash")
+	require.False(t, ok)
+	_, _, ok = resolveModelRateLimitScope(rules, PlatformGemini, "")
+	require.False(t, ok)
+}
+
+func TestExtractRateLimitedModel(t *testing.T) {
+	body := []byte(`{"error":{"code":429,"message":"Quota exceeded","details":[{"@type":"type.googleapis.com/google.rpc.QuotaFailure","violations":[{"quotaMetric":"generate_content_requests","quotaDimensions":{"location":"global","model":"gemini-2.5-pro"}}]}]}}`)
+	require.Equal(t, "gemini-2.5-pro", extractRateLimitedModel(body))
+
+	require.Equal(t, "claude-opus-4-5", extractRateLimitedModel([]byte(`{"error":{"type":"rate_limit_error","model":"claude-opus-4-5"}}`)))
+	require.Equal(t, "", extractRateLimitedModel([]byte(`{"error":{"message":"rate limited"}}`)))
+}
+
+func TestAccountIsModelRateLimited_PerScope(t *testing.T) {
+	future := time.Now().Add(10 * time.Minute).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339)
+
+	account := &Account{
+		Platform:    PlatformAnthropic,
+		Status:      StatusActive,
+		Schedulable: true,
+		Extra: map[string]any{
+			modelRateLimitsKey: map[string]any{
+				"claude_opus": map[string]any{
+					"rate_limit_reset_at": future,
+					"models":              []any{"*opus*"},
+				},
+				// 旧记录没有 models，按默认规则解析
+				modelRateLimitScopeClaudeSonnet: map[string]any{
+					"rate_limit_reset_at": future,
+				},
+				"claude_haiku": map[string]any{
+					"rate_limit_reset_at": past,
+					"models":              []any{"*haiku*"},
+				},
+			},
+		},
+	}
+
+	require.False(t, account.IsSchedulableForModel("claude-opus-4-5"))
+	require.False(t, account.IsSchedulableForModel("claude-sonnet-4-5"))
+	require.True(t, account.IsSchedulableForModel("claude-haiku-4-5"))
+	require.True(t, account.IsSchedulableForModel(""))
+
+	limits := account.ActiveModelRateLimits()
+	require.Len(t, limits, 2)
+	require.Equal(t, "claude_opus", limits[0].Scope)
+	require.Equal(t, []string{"*opus*"}, limits[0].Models)
+	require.Equal(t, ModelRateLimitSourceModel, limits[0].Source)
+}
+
+func TestRateLimitService_Handle429_ScopesByModel(t *testing.T) {
+	repo := &modelRateLimitRepoStub{}
+	svc := NewRateLimitService(repo, nil, &config.Config{}, nil, nil)
+	account := &Account{ID: 1, Platform: PlatformAnthropic}
+
+	headers := http.Header{}
+	headers.Set("anthropic-ratelimit-unified-reset", "4102444800")
+	svc.handle429(context.Background(), account, "", headers, []byte(`{"type":"error","error":{"type":"rate_limit_error","message":"This request would exceed your Opus rate limit"}}`))
+	require.Equal(t, 1, repo.modelCalls)
+	require.Equal(t, 0, repo.accountCalls)
+	require.Equal(t, "claude_opus", repo.scope)
+	require.Equal(t, []string{"*opus*"}, repo.models)
+
+	// 无法识别模型时限流整个账号
+	svc.handle429(context.Background(), account, "", http.Header{}, []byte(`{"error":{"message":"too many requests"}}`))
+	require.Equal(t, 1, repo.modelCalls)
+	require.Equal(t, 1, repo.accountCalls)
+}
+
+func TestRateLimitService_Handle429_PrefersRequestedModel(t *testing.T) {
+	repo := &modelRateLimitRepoStub{}
+	svc := NewRateLimitService(repo, nil, &config.Config{}, nil, nil)
+	account := &Account{ID: 1, Platform: PlatformAnthropic}
+
+	// 响应体没有模型信息时，按请求的模型限流对应域
+	svc.handle429(context.Background(), account, "claude-sonnet-4-5", http.Header{}, []byte(`{"error":{"message":"too many requests"}}`))
+	require.Equal(t, 1, repo.modelCalls)
+	require.Equal(t, 0, repo.accountCalls)
+	require.Equal(t, modelRateLimitScopeClaudeSonnet, repo.scope)
+
+	// 请求的模型优先于响应中的文字描述
+	svc.handle429(context.Background(), account, "claude-haiku-4-5", http.Header{}, []byte(`{"error":{"message":"This request would exceed your Opus rate limit"}}`))
+	require.Equal(t, 2, repo.modelCalls)
+	require.Equal(t, "claude_haiku", repo.scope)
+}
@@ -13,13 +13,13 @@ import (
 	"log"
 	"net/http"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/openai"
 	"github.com/Wei-Shaw/sub2api/internal/util/responseheaders"
 	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
@@ -181,6 +181,8 @@ type OpenAIGatewayService struct {
 	deferredService     *DeferredService
 	openAITokenProvider *OpenAITokenProvider
 	toolCorrector       *CodexToolCorrector
+
+	accountDedicationService *AccountDedicationService // 用户/API Key 专属账号隔离
 }
 
 // NewOpenAIGatewayService creates a new OpenAIGatewayService
@@ -199,6 +201,7 @@ func NewOpenAIGatewayService(
 	httpUpstream HTTPUpstream,
 	deferredService *DeferredService,
 	openAITokenProvider *OpenAITokenProvider,
+	accountDedicationService *AccountDedicationService,
 ) *OpenAIGatewayService {
 	return &OpenAIGatewayService{
 		accountRepo:         accountRepo,
@@ -216,6 +219,8 @@ func NewOpenAIGatewayService(
 		deferredService:     deferredService,
 		openAITokenProvider: openAITokenProvider,
 		toolCorrector:       NewCodexToolCorrector(),
+
+		accountDedicationService: accountDedicationService,
 	}
 }
 
@@ -321,6 +326,10 @@ func (s *OpenAIGatewayService) tryStickySessionHit(ctx context.Context, groupID
 	if _, excluded := excludedIDs[accountID]; excluded {
 		return nil
 	}
+	if !s.accountDedicationService.IsAccountAllowed(ctx, AccountAffinityFromContext(ctx), accountID) ||
+		!isAccountAllowedForTrafficRoute(ctx, accountID) {
+		return nil
+	}
 
 	account, err := s.getSchedulableAccount(ctx, accountID)
 	if err != nil {
@@ -336,7 +345,7 @@ func (s *OpenAIGatewayService) tryStickySessionHit(ctx context.Context, groupID
 
 	// 验证账号是否可用于当前请求
 	// Verify account is usable for current request
-	if !account.IsSchedulable() || !account.IsOpenAI() {
+	if !account.IsSchedulableForModel(requestedModel) || !account.IsOpenAI() {
 		return nil
 	}
 	if requestedModel != "" && !account.IsModelSupported(requestedModel) {
@@ -368,7 +377,7 @@ func (s *OpenAIGatewayService) selectBestAccount(accounts []Account, requestedMo
 
 		// 调度器快照可能暂时过时，这里重新检查可调度性和平台
 		// Scheduler snapshots can be temporarily stale; re-check schedulability and platform
-		if !acc.IsSchedulable() || !acc.IsOpenAI() {
+		if !acc.IsSchedulableForModel(requestedModel) || !acc.IsOpenAI() || acc.IsDraining() {
 			continue
 		}
 
@@ -489,17 +498,69 @@ func (s *OpenAIGatewayService) SelectAccountWithLoadAwareness(ctx context.Contex
 		return excluded
 	}
 
+	// ============ Layer 0: Dedicated accounts ============
+	// 专属账号有空闲槽位时直接使用，全部繁忙时继续后续层级。
+	// 独占模式下 accounts 已只包含专属账号，后续层级只会在专属账号上排队。
+	if affinity := AccountAffinityFromContext(ctx); affinity != nil {
+		var dedicated []*Account
+		for i := range accounts {
+			acc := &accounts[i]
+			if !affinity.Contains(acc.ID) || isExcluded(acc.ID) || !acc.IsSchedulableForModel(requestedModel) || acc.IsDraining() {
+				continue
+			}
+			if requestedModel != "" && !acc.IsModelSupported(requestedModel) {
+				continue
+			}
+			dedicated = append(dedicated, acc)
+		}
+		if len(dedicated) > 0 {
+			dedicatedLoads := make([]AccountWithConcurrency, 0, len(dedicated))
+			for _, acc := range dedicated {
+				dedicatedLoads = append(dedicatedLoads, AccountWithConcurrency{ID: acc.ID, MaxConcurrency: acc.Concurrency})
+			}
+			dedicatedLoadMap, _ := s.concurrencyService.GetAccountsLoadBatch(ctx, dedicatedLoads)
+			var dedicatedAvailable []SchedulingCandidate
+			for _, acc := range dedicated {
+				loadInfo := dedicatedLoadMap[acc.ID]
+				if loadInfo == nil {
+					loadInfo = &AccountLoadInfo{AccountID: acc.ID}
+				}
+				if loadInfo.LoadRate < 100 {
+					dedicatedAvailable = append(dedicatedAvailable, SchedulingCandidate{Account: acc, Load: loadInfo})
+				}
+			}
+			s.orderSchedulingCandidates(ctx, groupID, dedicatedAvailable)
+			moveSchedulingCandidateToFront(dedicatedAvailable, stickyAccountID)
+
+			for _, item := range dedicatedAvailable {
+				result, err := s.tryAcquireAccountSlot(ctx, item.Account.ID, item.Account.Concurrency)
+				if err == nil && result.Acquired {
+					if sessionHash != "" {
+						_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), "openai:"+sessionHash, item.Account.ID, openaiStickySessionTTL)
+					}
+					return &AccountSelectionResult{
+						Account:     item.Account,
+						Acquired:    true,
+						ReleaseFunc: result.ReleaseFunc,
+					}, nil
+				}
+			}
+		}
+	}
+
 	// ============ Layer 1: Sticky session ============
 	if sessionHash != "" {
 		accountID, err := s.cache.GetSessionAccountID(ctx, derefGroupID(groupID), "openai:"+sessionHash)
-		if err == nil && accountID > 0 && !isExcluded(accountID) {
+		if err == nil && accountID > 0 && !isExcluded(accountID) &&
+			s.accountDedicationService.IsAccountAllowed(ctx, AccountAffinityFromContext(ctx), accountID) &&
+			isAccountAllowedForTrafficRoute(ctx, accountID) {
 			account, err := s.getSchedulableAccount(ctx, accountID)
 			if err == nil {
 				clearSticky := shouldClearStickySession(account)
 				if clearSticky {
 					_ = s.cache.DeleteSessionAccountID(ctx, derefGroupID(groupID), "openai:"+sessionHash)
 				}
-				if !clearSticky && account.IsSchedulable() && account.IsOpenAI() &&
+				if !clearSticky && account.IsSchedulableForModel(requestedModel) && account.IsOpenAI() &&
 					(requestedModel == "" || account.IsModelSupported(requestedModel)) {
 					result, err := s.tryAcquireAccountSlot(ctx, accountID, account.Concurrency)
 					if err == nil && result.Acquired {
@@ -538,7 +599,8 @@ func (s *OpenAIGatewayService) SelectAccountWithLoadAwareness(ctx context.Contex
 		// Scheduler snapshots can be temporarily stale (bucket rebuild is throttled);
 		// re-check schedulability here so recently rate-limited/overloaded accounts
 		// are not selected again before the bucket is rebuilt.
-		if !acc.IsSchedulable() {
+		// 排空中的账号只服务已有粘性会话，不参与新会话选择
+		if !acc.IsSchedulableForModel(requestedModel) || acc.IsDraining() {
 			continue
 		}
 		if requestedModel != "" && !acc.IsModelSupported(requestedModel) {
@@ -577,53 +639,28 @@ func (s *OpenAIGatewayService) SelectAccountWithLoadAwareness(ctx context.Contex
 			}
 		}
 	} else {
-		type accountWithLoad struct {
-			account  *Account
-			loadInfo *AccountLoadInfo
-		}
-		var available []accountWithLoad
+		var available []SchedulingCandidate
 		for _, acc := range candidates {
 			loadInfo := loadMap[acc.ID]
 			if loadInfo == nil {
 				loadInfo = &AccountLoadInfo{AccountID: acc.ID}
 			}
 			if loadInfo.LoadRate < 100 {
-				available = append(available, accountWithLoad{
-					account:  acc,
-					loadInfo: loadInfo,
-				})
+				available = append(available, SchedulingCandidate{Account: acc, Load: loadInfo})
 			}
 		}
 
 		if len(available) > 0 {
-			sort.SliceStable(available, func(i, j int) bool {
-				a, b := available[i], available[j]
-				if a.account.Priority != b.account.Priority {
-					return a.account.Priority < b.account.Priority
-				}
-				if a.loadInfo.LoadRate != b.loadInfo.LoadRate {
-					return a.loadInfo.LoadRate < b.loadInfo.LoadRate
-				}
-				switch {
-				case a.account.LastUsedAt == nil && b.account.LastUsedAt != nil:
-					return true
-				case a.account.LastUsedAt != nil && b.account.LastUsedAt == nil:
-					return false
-				case a.account.LastUsedAt == nil && b.account.LastUsedAt == nil:
-					return false
-				default:
-					return a.account.LastUsedAt.Before(*b.account.LastUsedAt)
-				}
-			})
+			s.orderSchedulingCandidates(ctx, groupID, available)
 
 			for _, item := range available {
-				result, err := s.tryAcquireAccountSlot(ctx, item.account.ID, item.account.Concurrency)
+				result, err := s.tryAcquireAccountSlot(ctx, item.Account.ID, item.Account.Concurrency)
 				if err == nil && result.Acquired {
 					if sessionHash != "" {
-						_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), "openai:"+sessionHash, item.account.ID, openaiStickySessionTTL)
+						_ = s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), "openai:"+sessionHash, item.Account.ID, openaiStickySessionTTL)
 					}
 					return &AccountSelectionResult{
-						Account:     item.account,
+						Account:     item.Account,
 						Acquired:    true,
 						ReleaseFunc: result.ReleaseFunc,
 					}, nil
@@ -649,7 +686,36 @@ func (s *OpenAIGatewayService) SelectAccountWithLoadAwareness(ctx context.Contex
 	return nil, errors.New("no available accounts")
 }
 
+// orderSchedulingCandidates 按分组调度策略排序候选账号（分组来自请求上下文，Codex 用量作为配额信号）
+func (s *OpenAIGatewayService) orderSchedulingCandidates(ctx context.Context, groupID *int64, candidates []SchedulingCandidate) {
+	var group *Group
+	if groupID != nil {
+		if ctxGroup, ok := ctx.Value(ctxkey.Group).(*Group); ok && IsGroupContextValid(ctxGroup) && ctxGroup.ID == *groupID {
+			group = ctxGroup
+		}
+	}
+	strategy := resolveSchedulingStrategy(group)
+	if strategy.NeedsQuota() {
+		now := time.Now()
+		for i := range candidates {
+			candidates[i].QuotaRemaining = codexQuotaRemaining(candidates[i].Account, now)
+		}
+	}
+	strategy.Order(schedulingScope(group), candidates, SchedulingOrderOptions{})
+}
+
+// listSchedulableAccounts 获取可调度的 OpenAI 账号，并按请求的专属账号配置过滤（其他用户的专属账号不参与调度）；
+// 分流请求只保留所属实验组的账号池
 func (s *OpenAIGatewayService) listSchedulableAccounts(ctx context.Context, groupID *int64) ([]Account, error) {
+	accounts, err := s.listGroupSchedulableAccounts(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	accounts = filterAccountsForTrafficRoute(ctx, accounts)
+	return s.accountDedicationService.FilterAccounts(ctx, AccountAffinityFromContext(ctx), accounts), nil
+}
+
+func (s *OpenAIGatewayService) listGroupSchedulableAccounts(ctx context.Context, groupID *int64) ([]Account, error) {
 	if s.schedulerSnapshot != nil {
 		accounts, _, err := s.schedulerSnapshot.ListSchedulableAccounts(ctx, groupID, PlatformOpenAI, false)
 		return accounts, err
@@ -685,7 +751,7 @@ func (s *OpenAIGatewayService) getSchedulableAccount(ctx context.Context, accoun
 
 func (s *OpenAIGatewayService) schedulingConfig() config.GatewaySchedulingConfig {
 	if s.cfg != nil {
-		return s.cfg.Gateway.Scheduling
+		return s.cfg.Live().Gateway.Scheduling
 	}
 	return config.GatewaySchedulingConfig{
 		StickySessionMaxWaiting:  3,
@@ -735,9 +801,9 @@ func (s *OpenAIGatewayService) shouldFailoverUpstreamError(statusCode int) bool
 	}
 }
 
-func (s *OpenAIGatewayService) handleFailoverSideEffects(ctx context.Context, resp *http.Response, account *Account) {
+func (s *OpenAIGatewayService) handleFailoverSideEffects(ctx context.Context, resp *http.Response, account *Account, requestedModel string) {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
-	s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, body)
+	s.rateLimitService.HandleUpstreamError(ctx, account, requestedModel, resp.StatusCode, resp.Header, body)
 }
 
 // Forward forwards request to OpenAI API
@@ -910,8 +976,8 @@ func (s *OpenAIGatewayService) Forward(ctx context.Context, c *gin.Context, acco
 			upstreamMsg := strings.TrimSpace(extractUpstreamErrorMessage(respBody))
 			upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
 			upstreamDetail := ""
-			if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-				maxBytes := s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+			if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+				maxBytes := s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 				if maxBytes <= 0 {
 					maxBytes = 2048
 				}
@@ -928,10 +994,10 @@ func (s *OpenAIGatewayService) Forward(ctx context.Context, c *gin.Context, acco
 				Detail:             upstreamDetail,
 			})
 
-			s.handleFailoverSideEffects(ctx, resp, account)
+			s.handleFailoverSideEffects(ctx, resp, account, mappedModel)
 			return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode}
 		}
-		return s.handleErrorResponse(ctx, resp, c, account)
+		return s.handleErrorResponse(ctx, resp, c, account, mappedModel)
 	}
 
 	// Handle normal response
@@ -1047,14 +1113,14 @@ func (s *OpenAIGatewayService) buildUpstreamRequest(ctx context.Context, c *gin.
 	return req, nil
 }
 
-func (s *OpenAIGatewayService) handleErrorResponse(ctx context.Context, resp *http.Response, c *gin.Context, account *Account) (*OpenAIForwardResult, error) {
+func (s *OpenAIGatewayService) handleErrorResponse(ctx context.Context, resp *http.Response, c *gin.Context, account *Account, requestedModel string) (*OpenAIForwardResult, error) {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 
 	upstreamMsg := strings.TrimSpace(extractUpstreamErrorMessage(body))
 	upstreamMsg = sanitizeUpstreamErrorMessage(upstreamMsg)
 	upstreamDetail := ""
-	if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
-		maxBytes := s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+	if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
+		maxBytes := s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes
 		if maxBytes <= 0 {
 			maxBytes = 2048
 		}
@@ -1062,14 +1128,14 @@ func (s *OpenAIGatewayService) handleErrorResponse(ctx context.Context, resp *ht
 	}
 	setOpsUpstreamError(c, resp.StatusCode, upstreamMsg, upstreamDetail)
 
-	if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
+	if s.cfg != nil && s.cfg.Live().Gateway.LogUpstreamErrorBody {
 		log.Printf(
 			"OpenAI upstream error %d (account=%d platform=%s type=%s): %s",
 			resp.StatusCode,
 			account.ID,
 			account.Platform,
 			account.Type,
-			truncateForLog(body, s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes),
+			truncateForLog(body, s.cfg.Live().Gateway.LogUpstreamErrorBodyMaxBytes),
 		)
 	}
 
@@ -1100,7 +1166,7 @@ func (s *OpenAIGatewayService) handleErrorResponse(ctx context.Context, resp *ht
 	// Handle upstream error (mark account status)
 	shouldDisable := false
 	if s.rateLimitService != nil {
-		shouldDisable = s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, body)
+		shouldDisable = s.rateLimitService.HandleUpstreamError(ctx, account, requestedModel, resp.StatusCode, resp.Header, body)
 	}
 	kind := "http_error"
 	if shouldDisable {
@@ -1192,8 +1258,8 @@ func (s *OpenAIGatewayService) handleStreamingResponse(ctx context.Context, resp
 	var firstTokenMs *int
 	scanner := bufio.NewScanner(resp.Body)
 	maxLineSize := defaultMaxLineSize
-	if s.cfg != nil && s.cfg.Gateway.MaxLineSize > 0 {
-		maxLineSize = s.cfg.Gateway.MaxLineSize
+	if s.cfg != nil && s.cfg.Live().Gateway.MaxLineSize > 0 {
+		maxLineSize = s.cfg.Live().Gateway.MaxLineSize
 	}
 	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
 
@@ -1229,8 +1295,8 @@ func (s *OpenAIGatewayService) handleStreamingResponse(ctx context.Context, resp
 	defer close(done)
 
 	streamInterval := time.Duration(0)
-	if s.cfg != nil && s.cfg.Gateway.StreamDataIntervalTimeout > 0 {
-		streamInterval = time.Duration(s.cfg.Gateway.StreamDataIntervalTimeout) * time.Second
+	if s.cfg != nil && s.cfg.Live().Gateway.StreamDataIntervalTimeout > 0 {
+		streamInterval = time.Duration(s.cfg.Live().Gateway.StreamDataIntervalTimeout) * time.Second
 	}
 	// 仅监控上游数据间隔超时，不被下游写入阻塞影响
 	var intervalTicker *time.Ticker
@@ -1244,8 +1310,8 @@ func (s *OpenAIGatewayService) handleStreamingResponse(ctx context.Context, resp
 	}
 
 	keepaliveInterval := time.Duration(0)
-	if s.cfg != nil && s.cfg.Gateway.StreamKeepaliveInterval > 0 {
-		keepaliveInterval = time.Duration(s.cfg.Gateway.StreamKeepaliveInterval) * time.Second
+	if s.cfg != nil && s.cfg.Live().Gateway.StreamKeepaliveInterval > 0 {
+		keepaliveInterval = time.Duration(s.cfg.Live().Gateway.StreamKeepaliveInterval) * time.Second
 	}
 	// 下游 keepalive 仅用于防止代理空闲断开
 	var keepaliveTicker *time.Ticker
@@ -1635,6 +1701,7 @@ type OpenAIRecordUsageInput struct {
 	Subscription *UserSubscription
 	UserAgent    string // 请求的 User-Agent
 	IPAddress    string // 请求的客户端 IP 地址
+	TrafficArm   string // 灰度 / A-B 分流实验组（未启用分流时为空）
 }
 
 // RecordUsage records usage and deducts balance
@@ -1665,6 +1732,7 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 	if apiKey.GroupID != nil && apiKey.Group != nil {
 		multiplier = apiKey.Group.RateMultiplier
 	}
+	multiplier = overageRateMultiplier(apiKey, multiplier)
 
 	cost, err := s.billingService.CalculateCost(result.Model, tokens, multiplier)
 	if err != nil {
@@ -1715,6 +1783,9 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 	if input.IPAddress != "" {
 		usageLog.IPAddress = &input.IPAddress
 	}
+	if input.TrafficArm != "" {
+		usageLog.TrafficArm = &input.TrafficArm
+	}
 
 	if apiKey.GroupID != nil {
 		usageLog.GroupID = apiKey.GroupID
@@ -1722,6 +1793,7 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 	if subscription != nil {
 		usageLog.SubscriptionID = &subscription.ID
 	}
+	applySubscriptionOverage(apiKey, usageLog)
 
 	inserted, err := s.usageLogRepo.Create(ctx, usageLog)
 	if s.cfg != nil && s.cfg.RunMode == config.RunModeSimple {
@@ -1734,9 +1806,21 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 
 	// Deduct based on billing type
 	if isSubscriptionBilling {
+		if shouldBill {
+			recordSubscriptionQuotaUsage(s.billingCacheService, subscription, apiKey.Group, usageLog)
+		}
 		if shouldBill && cost.TotalCost > 0 {
 			_ = s.userSubRepo.IncrementUsage(ctx, subscription.ID, cost.TotalCost)
-			s.billingCacheService.QueueUpdateSubscriptionUsage(user.ID, *apiKey.GroupID, cost.TotalCost)
+			s.billingCacheService.QueueUpdateSubscriptionUsage(subscription.UserID, *apiKey.GroupID, cost.TotalCost)
+			if err := s.billingCacheService.ChargeOrganizationUsage(ctx, apiKey, user.ID, cost.TotalCost, false); err != nil {
+				log.Printf("Charge organization usage failed: %v", err)
+			}
+		}
+	} else if apiKey.IsOrganizationKey() {
+		if shouldBill && cost.ActualCost > 0 {
+			if err := s.billingCacheService.ChargeOrganizationUsage(ctx, apiKey, user.ID, cost.ActualCost, true); err != nil {
+				log.Printf("Charge organization usage failed: %v", err)
+			}
 		}
 	} else {
 		if shouldBill && cost.ActualCost > 0 {
@@ -36,7 +36,10 @@ type OpsAlertEvaluatorService struct {
 	opsRepo      OpsRepository
 	emailService *EmailService
 
-	redisClient *redis.Client
+	// usageAnomalyService 计算用户 / API Key 维度的异常指标并执行自动处置
+	usageAnomalyService *UsageAnomalyService
+
+	redisClient redis.UniversalClient
 	cfg         *config.Config
 	instanceID  string
 
@@ -65,18 +68,20 @@ func NewOpsAlertEvaluatorService(
 	opsService *OpsService,
 	opsRepo OpsRepository,
 	emailService *EmailService,
-	redisClient *redis.Client,
+	usageAnomalyService *UsageAnomalyService,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsAlertEvaluatorService {
 	return &OpsAlertEvaluatorService{
-		opsService:   opsService,
-		opsRepo:      opsRepo,
-		emailService: emailService,
-		redisClient:  redisClient,
-		cfg:          cfg,
-		instanceID:   uuid.NewString(),
-		ruleStates:   map[int64]*opsAlertRuleState{},
-		emailLimiter: newSlidingWindowLimiter(0, time.Hour),
+		opsService:          opsService,
+		opsRepo:             opsRepo,
+		emailService:        emailService,
+		usageAnomalyService: usageAnomalyService,
+		redisClient:         redisClient,
+		cfg:                 cfg,
+		instanceID:          uuid.NewString(),
+		ruleStates:          map[int64]*opsAlertRuleState{},
+		emailLimiter:        newSlidingWindowLimiter(0, time.Hour),
 	}
 }
 
@@ -223,7 +228,16 @@ func (s *OpsAlertEvaluatorService) evaluateOnce(interval time.Duration) {
 		windowStart := safeEnd.Add(-time.Duration(windowMinutes) * time.Minute)
 		windowEnd := safeEnd
 
-		metricValue, ok := s.computeRuleMetric(ctx, rule, systemMetrics, windowStart, windowEnd, scopePlatform, scopeGroupID)
+		var (
+			metricValue float64
+			ok          bool
+			anomalies   []UsageAnomaly
+		)
+		if IsUsageAnomalyMetric(rule.MetricType) {
+			metricValue, anomalies, ok = s.computeUsageAnomalyMetric(ctx, rule, windowStart, windowEnd)
+		} else {
+			metricValue, ok = s.computeRuleMetric(ctx, rule, systemMetrics, windowStart, windowEnd, scopePlatform, scopeGroupID)
+		}
 		if !ok {
 			s.resetRuleState(rule.ID, now)
 			continue
@@ -241,6 +255,10 @@ func (s *OpsAlertEvaluatorService) evaluateOnce(interval time.Duration) {
 		}
 
 		if breachedNow && consecutive >= required {
+			// 异常对象逐个处置（自带去重），不受告警事件去重 / 冷却影响，避免后续出现的泄露 Key 被漏掉
+			if len(anomalies) > 0 && s.usageAnomalyService != nil {
+				s.usageAnomalyService.HandleAnomalies(ctx, rule, anomalies)
+			}
 			if activeEvent != nil {
 				continue
 			}
@@ -273,10 +291,10 @@ func (s *OpsAlertEvaluatorService) evaluateOnce(interval time.Duration) {
 				Severity:       strings.TrimSpace(rule.Severity),
 				Status:         OpsAlertStatusFiring,
 				Title:          fmt.Sprintf("%s: %s", strings.TrimSpace(rule.Severity), strings.TrimSpace(rule.Name)),
-				Description:    buildOpsAlertDescription(rule, metricValue, windowMinutes, scopePlatform, scopeGroupID),
+				Description:    truncateString(buildOpsAlertDescription(rule, metricValue, windowMinutes, scopePlatform, scopeGroupID)+describeUsageAnomalies(anomalies), 2048),
 				MetricValue:    float64Ptr(metricValue),
 				ThresholdValue: float64Ptr(rule.Threshold),
-				Dimensions:     buildOpsAlertDimensions(scopePlatform, scopeGroupID),
+				Dimensions:     withUsageAnomalyDimensions(buildOpsAlertDimensions(scopePlatform, scopeGroupID), anomalies),
 				FiredAt:        now,
 				CreatedAt:      now,
 			}
@@ -429,6 +447,19 @@ func parseOpsAlertRuleScope(filters map[string]any) (platform string, groupID *i
 	return platform, groupID, region
 }
 
+// computeUsageAnomalyMetric 计算用户 / API Key 异常指标，返回所有对象中的最大值与触发阈值的对象
+func (s *OpsAlertEvaluatorService) computeUsageAnomalyMetric(ctx context.Context, rule *OpsAlertRule, start, end time.Time) (float64, []UsageAnomaly, bool) {
+	if s.usageAnomalyService == nil {
+		return 0, nil, false
+	}
+	value, anomalies, err := s.usageAnomalyService.Detect(ctx, rule, start, end)
+	if err != nil {
+		log.Printf("[OpsAlertEvaluator] detect usage anomalies failed (rule=%d): %v", rule.ID, err)
+		return 0, nil, false
+	}
+	return value, anomalies, true
+}
+
 func (s *OpsAlertEvaluatorService) computeRuleMetric(
 	ctx context.Context,
 	rule *OpsAlertRule,
@@ -576,6 +607,23 @@ func buildOpsAlertDimensions(platform string, groupID *int64) map[string]any {
 	return dims
 }
 
+// withUsageAnomalyDimensions 将触发的对象（最多 10 个）写入事件维度
+func withUsageAnomalyDimensions(dims map[string]any, anomalies []UsageAnomaly) map[string]any {
+	if len(anomalies) == 0 {
+		return dims
+	}
+	if dims == nil {
+		dims = map[string]any{}
+	}
+	subjects := anomalies
+	if len(subjects) > 10 {
+		subjects = subjects[:10]
+	}
+	dims["subjects"] = subjects
+	dims["subjects_total"] = len(anomalies)
+	return dims
+}
+
 func buildOpsAlertDescription(rule *OpsAlertRule, value float64, windowMinutes int, platform string, groupID *int64) string {
 	if rule == nil {
 		return ""
@@ -0,0 +1,629 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+const (
+	opsCapacityDefaultHorizonDays       = 30
+	opsCapacityMaxHorizonDays           = 365
+	opsCapacityDefaultTargetUtilization = 0.8
+	opsCapacityMaxRange                 = 90 * 24 * time.Hour
+
+	opsCapacityBasisConcurrency = "concurrency"
+	opsCapacityBasisWindowCost  = "window_cost"
+	opsCapacityBasisCodexQuota  = "codex_quota"
+
+	// opsCapacityWindowCostHours 与 Anthropic 5h 会话窗口对齐
+	opsCapacityWindowCostHours = 5
+	// opsCapacityMaxGrowthFactor 防止短样本下的线性外推失控
+	opsCapacityMaxGrowthFactor = 10
+)
+
+// collectCapacitySnapshots samples the account pool (per group + per platform) and persists it.
+//
+// Best-effort: failures are logged and never break the metrics collector.
+func (c *OpsMetricsCollector) collectCapacitySnapshots(parentCtx context.Context, createdAt time.Time) {
+	if c == nil || c.opsRepo == nil || c.accountRepo == nil {
+		return
+	}
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parentCtx, 5*time.Second)
+	defer cancel()
+
+	accounts, err := listAllAccountsPaged(ctx, c.accountRepo, "")
+	if err != nil {
+		log.Printf("[OpsMetricsCollector] capacity snapshot list accounts error: %v", err)
+		return
+	}
+	loadMap := loadAccountsLoadMapBestEffort(ctx, c.concurrencyService, accounts)
+
+	windowSeconds := int(c.getInterval().Seconds())
+	items := buildOpsCapacitySnapshots(accounts, loadMap, createdAt, windowSeconds)
+	if len(items) == 0 {
+		return
+	}
+	if err := c.opsRepo.InsertCapacitySnapshots(ctx, items); err != nil {
+		log.Printf("[OpsMetricsCollector] capacity snapshot insert error: %v", err)
+	}
+}
+
+// buildOpsCapacitySnapshots aggregates the current account pool into per-group and per-platform rows.
+//
+// Availability uses the same rule as the ops availability view:
+// active + schedulable + not rate-limited/overloaded/temp-unschedulable.
+func buildOpsCapacitySnapshots(accounts []Account, loadMap map[int64]*AccountLoadInfo, now time.Time, windowSeconds int) []*OpsCapacitySnapshot {
+	if windowSeconds <= 0 {
+		windowSeconds = 60
+	}
+
+	platforms := make(map[string]*OpsCapacitySnapshot)
+	groups := make(map[int64]*OpsCapacitySnapshot)
+
+	add := func(snap *OpsCapacitySnapshot, acc *Account, available, rateLimited bool, load *AccountLoadInfo) {
+		snap.TotalAccounts++
+		if available {
+			snap.AvailableAccounts++
+		}
+		if rateLimited {
+			snap.RateLimitedAccounts++
+		}
+		if acc.Concurrency > 0 {
+			snap.MaxConcurrency += int64(acc.Concurrency)
+		}
+		if load != nil {
+			if load.CurrentConcurrency > 0 {
+				snap.CurrentInUse += int64(load.CurrentConcurrency)
+			}
+			if load.WaitingCount > 0 {
+				snap.WaitingInQueue += int64(load.WaitingCount)
+			}
+		}
+	}
+
+	for i := range accounts {
+		acc := &accounts[i]
+		if acc.ID <= 0 {
+			continue
+		}
+
+		isRateLimited := acc.RateLimitResetAt != nil && now.Before(*acc.RateLimitResetAt)
+		isOverloaded := acc.OverloadUntil != nil && now.Before(*acc.OverloadUntil)
+		isTempUnsched := acc.TempUnschedulableUntil != nil && now.Before(*acc.TempUnschedulableUntil)
+		if acc.Status == StatusError {
+			isRateLimited = false
+			isOverloaded = false
+		}
+		isAvailable := acc.Status == StatusActive && acc.Schedulable && !isRateLimited && !isOverloaded && !isTempUnsched
+
+		var load *AccountLoadInfo
+		if loadMap != nil {
+			load = loadMap[acc.ID]
+		}
+
+		if acc.Platform != "" {
+			snap, ok := platforms[acc.Platform]
+			if !ok {
+				snap = &OpsCapacitySnapshot{CreatedAt: now, WindowSeconds: windowSeconds, Platform: acc.Platform}
+				platforms[acc.Platform] = snap
+			}
+			add(snap, acc, isAvailable, isRateLimited, load)
+		}
+
+		for _, grp := range acc.Groups {
+			if grp == nil || grp.ID <= 0 {
+				continue
+			}
+			snap, ok := groups[grp.ID]
+			if !ok {
+				groupID := grp.ID
+				platform := grp.Platform
+				if platform == "" {
+					platform = acc.Platform
+				}
+				snap = &OpsCapacitySnapshot{CreatedAt: now, WindowSeconds: windowSeconds, Platform: platform, GroupID: &groupID}
+				groups[grp.ID] = snap
+			}
+			add(snap, acc, isAvailable, isRateLimited, load)
+		}
+	}
+
+	out := make([]*OpsCapacitySnapshot, 0, len(platforms)+len(groups))
+	for _, snap := range platforms {
+		out = append(out, snap)
+	}
+	for _, snap := range groups {
+		out = append(out, snap)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		gi, gj := int64(0), int64(0)
+		if out[i].GroupID != nil {
+			gi = *out[i].GroupID
+		}
+		if out[j].GroupID != nil {
+			gj = *out[j].GroupID
+		}
+		if gi != gj {
+			return gi < gj
+		}
+		return out[i].Platform < out[j].Platform
+	})
+	return out
+}
+
+// GetCapacityPlan builds the capacity planner report for the given range.
+//
+// For each group and platform it combines:
+// - account pool snapshots (peak concurrency, time with zero available accounts)
+// - upstream 429 hits per account
+// - demand history (daily requests/cost) and its linear growth trend
+// and projects how many accounts are needed after HorizonDays at TargetUtilization.
+func (s *OpsService) GetCapacityPlan(ctx context.Context, filter *OpsCapacityPlanFilter) (*OpsCapacityPlanReport, error) {
+	if err := s.RequireMonitoringEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if s.opsRepo == nil {
+		return nil, infraerrors.ServiceUnavailable("OPS_REPO_UNAVAILABLE", "Ops repository not available")
+	}
+	if filter == nil {
+		return nil, infraerrors.BadRequest("OPS_FILTER_REQUIRED", "filter is required")
+	}
+	if filter.StartTime.IsZero() || filter.EndTime.IsZero() {
+		return nil, infraerrors.BadRequest("OPS_TIME_RANGE_REQUIRED", "start_time/end_time are required")
+	}
+	if !filter.StartTime.Before(filter.EndTime) {
+		return nil, infraerrors.BadRequest("OPS_TIME_RANGE_INVALID", "start_time must be before end_time")
+	}
+	if filter.EndTime.Sub(filter.StartTime) > opsCapacityMaxRange {
+		return nil, infraerrors.BadRequest("OPS_TIME_RANGE_TOO_LARGE", "time range must be within 90 days")
+	}
+
+	horizon := filter.HorizonDays
+	if horizon <= 0 {
+		horizon = opsCapacityDefaultHorizonDays
+	}
+	if horizon > opsCapacityMaxHorizonDays {
+		horizon = opsCapacityMaxHorizonDays
+	}
+	target := filter.TargetUtilization
+	if target <= 0 || target > 1 {
+		target = opsCapacityDefaultTargetUtilization
+	}
+
+	start := filter.StartTime.UTC()
+	end := filter.EndTime.UTC()
+	platformFilter := strings.TrimSpace(filter.Platform)
+
+	accounts, err := s.listAllAccountsForOps(ctx, platformFilter)
+	if err != nil {
+		return nil, err
+	}
+	snapshotStats, err := s.opsRepo.GetCapacitySnapshotStats(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.opsRepo.GetCapacityUsageHourly(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	rateLimits, err := s.opsRepo.GetAccountRateLimitHitsDaily(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildOpsCapacityPlan(accounts, snapshotStats, usage, rateLimits, start, end, horizon, target)
+
+	// Scope filtering happens after the build so platform aggregates stay complete.
+	if platformFilter != "" {
+		report.Groups = filterOpsCapacityEntries(report.Groups, func(e *OpsCapacityPlanEntry) bool { return e.Platform == platformFilter })
+		report.Platforms = filterOpsCapacityEntries(report.Platforms, func(e *OpsCapacityPlanEntry) bool { return e.Platform == platformFilter })
+		filteredRL := make([]*OpsAccountRateLimitDaily, 0, len(report.AccountRateLimits))
+		for _, item := range report.AccountRateLimits {
+			if item != nil && item.Platform == platformFilter {
+				filteredRL = append(filteredRL, item)
+			}
+		}
+		report.AccountRateLimits = filteredRL
+	}
+	if filter.GroupID != nil && *filter.GroupID > 0 {
+		groupID := *filter.GroupID
+		report.Groups = filterOpsCapacityEntries(report.Groups, func(e *OpsCapacityPlanEntry) bool {
+			return e.GroupID != nil && *e.GroupID == groupID
+		})
+		inGroup := make(map[int64]struct{})
+		for i := range accounts {
+			for _, grp := range accounts[i].Groups {
+				if grp != nil && grp.ID == groupID {
+					inGroup[accounts[i].ID] = struct{}{}
+					break
+				}
+			}
+		}
+		filteredRL := make([]*OpsAccountRateLimitDaily, 0, len(report.AccountRateLimits))
+		for _, item := range report.AccountRateLimits {
+			if item == nil {
+				continue
+			}
+			if _, ok := inGroup[item.AccountID]; ok {
+				filteredRL = append(filteredRL, item)
+			}
+		}
+		report.AccountRateLimits = filteredRL
+	}
+
+	return report, nil
+}
+
+func filterOpsCapacityEntries(entries []*OpsCapacityPlanEntry, keep func(*OpsCapacityPlanEntry) bool) []*OpsCapacityPlanEntry {
+	out := make([]*OpsCapacityPlanEntry, 0, len(entries))
+	for _, e := range entries {
+		if e != nil && keep(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// opsCapacityScope accumulates raw inputs for one group/platform before projection.
+type opsCapacityScope struct {
+	entry *OpsCapacityPlanEntry
+
+	accountIDs map[int64]struct{}
+
+	windowCostLimitSum float64
+	codexUsedSum       float64
+
+	hourlyCost    map[int64]float64 // unix hour -> cost
+	dailyRequests []float64
+	dailyCost     []float64
+}
+
+func buildOpsCapacityPlan(
+	accounts []Account,
+	snapshotStats []*OpsCapacitySnapshotStats,
+	usage []*OpsCapacityUsageHourly,
+	rateLimits []*OpsAccountRateLimitDaily,
+	start, end time.Time,
+	horizonDays int,
+	targetUtilization float64,
+) *OpsCapacityPlanReport {
+	days := int(math.Ceil(end.Sub(start).Hours() / 24))
+	if days <= 0 {
+		days = 1
+	}
+
+	newScope := func(platform string, groupID *int64, groupName string) *opsCapacityScope {
+		return &opsCapacityScope{
+			entry: &OpsCapacityPlanEntry{
+				GroupID:   groupID,
+				GroupName: groupName,
+				Platform:  platform,
+			},
+			accountIDs:    make(map[int64]struct{}),
+			hourlyCost:    make(map[int64]float64),
+			dailyRequests: make([]float64, days),
+			dailyCost:     make([]float64, days),
+		}
+	}
+
+	platforms := make(map[string]*opsCapacityScope)
+	groups := make(map[int64]*opsCapacityScope)
+
+	addAccount := func(sc *opsCapacityScope, acc *Account) {
+		if _, seen := sc.accountIDs[acc.ID]; seen {
+			return
+		}
+		sc.accountIDs[acc.ID] = struct{}{}
+		e := sc.entry
+		e.AccountCount++
+		if acc.IsSchedulable() {
+			e.SchedulableAccounts++
+		}
+		if acc.Concurrency > 0 {
+			e.TotalConcurrency += int64(acc.Concurrency)
+		}
+		if limit := acc.GetWindowCostLimit(); limit > 0 {
+			e.WindowCostLimitAccounts++
+			sc.windowCostLimitSum += limit
+		}
+		if acc.Extra != nil {
+			if v, ok := acc.Extra["codex_5h_used_percent"]; ok {
+				e.CodexAccounts++
+				sc.codexUsedSum += parseExtraFloat64(v)
+			}
+		}
+	}
+
+	for i := range accounts {
+		acc := &accounts[i]
+		if acc.ID <= 0 {
+			continue
+		}
+		if acc.Platform != "" {
+			sc, ok := platforms[acc.Platform]
+			if !ok {
+				sc = newScope(acc.Platform, nil, "")
+				platforms[acc.Platform] = sc
+			}
+			addAccount(sc, acc)
+		}
+		for _, grp := range acc.Groups {
+			if grp == nil || grp.ID <= 0 {
+				continue
+			}
+			sc, ok := groups[grp.ID]
+			if !ok {
+				groupID := grp.ID
+				platform := grp.Platform
+				if platform == "" {
+					platform = acc.Platform
+				}
+				sc = newScope(platform, &groupID, grp.Name)
+				groups[grp.ID] = sc
+			}
+			addAccount(sc, acc)
+		}
+	}
+
+	for _, st := range snapshotStats {
+		if st == nil {
+			continue
+		}
+		var sc *opsCapacityScope
+		if st.GroupID != nil {
+			sc = groups[*st.GroupID]
+		} else {
+			sc = platforms[st.Platform]
+		}
+		if sc == nil {
+			continue
+		}
+		e := sc.entry
+		e.PeakConcurrency = st.PeakInUse
+		e.AvgConcurrency = roundTo2DP(st.AvgInUse)
+		e.PeakWaiting = st.PeakWaiting
+		e.ZeroAvailableHours = roundTo2DP(float64(st.ZeroAvailableSecond) / 3600)
+		e.SnapshotCoverageHours = roundTo2DP(float64(st.CoveredSeconds) / 3600)
+	}
+
+	for _, u := range usage {
+		if u == nil {
+			continue
+		}
+		idx := int(u.HourStart.Sub(start).Hours() / 24)
+		if idx < 0 || idx >= days {
+			continue
+		}
+		hourKey := u.HourStart.Unix() / 3600
+		apply := func(sc *opsCapacityScope) {
+			if sc == nil {
+				return
+			}
+			sc.dailyRequests[idx] += float64(u.Requests)
+			sc.dailyCost[idx] += u.TotalCost
+			sc.hourlyCost[hourKey] += u.TotalCost
+		}
+		if u.GroupID != nil {
+			apply(groups[*u.GroupID])
+		}
+		if u.Platform != "" {
+			apply(platforms[u.Platform])
+		}
+	}
+
+	for _, rl := range rateLimits {
+		if rl == nil {
+			continue
+		}
+		for _, sc := range platforms {
+			if _, ok := sc.accountIDs[rl.AccountID]; ok {
+				sc.entry.RateLimitHits += rl.Hits
+			}
+		}
+		for _, sc := range groups {
+			if _, ok := sc.accountIDs[rl.AccountID]; ok {
+				sc.entry.RateLimitHits += rl.Hits
+			}
+		}
+	}
+
+	finalize := func(sc *opsCapacityScope) *OpsCapacityPlanEntry {
+		e := sc.entry
+		if e.TotalConcurrency > 0 {
+			e.PeakUtilizationPct = roundTo2DP(float64(e.PeakConcurrency) / float64(e.TotalConcurrency) * 100)
+		}
+		if e.AccountCount > 0 {
+			e.RateLimitHitsPerAccountPerDay = roundTo2DP(float64(e.RateLimitHits) / float64(e.AccountCount) / float64(days))
+		}
+		if e.WindowCostLimitAccounts > 0 {
+			e.AvgWindowCostLimit = roundTo2DP(sc.windowCostLimitSum / float64(e.WindowCostLimitAccounts))
+		}
+		if e.CodexAccounts > 0 {
+			avg := roundTo2DP(sc.codexUsedSum / float64(e.CodexAccounts))
+			e.CodexAvg5hUsedPercent = &avg
+		}
+		e.Peak5hWindowCost = roundTo2DP(opsCapacityPeakSlidingSum(sc.hourlyCost, opsCapacityWindowCostHours))
+		e.DailyRequestsAvg = roundTo2DP(opsCapacityMean(sc.dailyRequests))
+		e.DailyCostAvg = roundTo2DP(opsCapacityMean(sc.dailyCost))
+
+		growthPct, factor := opsCapacityGrowth(sc.dailyRequests, horizonDays)
+		e.DailyGrowthPct = roundTo2DP(growthPct)
+		e.GrowthFactor = roundTo2DP(factor)
+
+		projectOpsCapacityEntry(e, factor, targetUtilization)
+		return e
+	}
+
+	report := &OpsCapacityPlanReport{
+		StartTime:         start,
+		EndTime:           end,
+		HorizonDays:       horizonDays,
+		TargetUtilization: targetUtilization,
+		Groups:            make([]*OpsCapacityPlanEntry, 0, len(groups)),
+		Platforms:         make([]*OpsCapacityPlanEntry, 0, len(platforms)),
+		AccountRateLimits: rateLimits,
+	}
+	if report.AccountRateLimits == nil {
+		report.AccountRateLimits = []*OpsAccountRateLimitDaily{}
+	}
+	for _, sc := range groups {
+		report.Groups = append(report.Groups, finalize(sc))
+	}
+	for _, sc := range platforms {
+		report.Platforms = append(report.Platforms, finalize(sc))
+	}
+	sort.Slice(report.Groups, func(i, j int) bool { return *report.Groups[i].GroupID < *report.Groups[j].GroupID })
+	sort.Slice(report.Platforms, func(i, j int) bool { return report.Platforms[i].Platform < report.Platforms[j].Platform })
+	return report
+}
+
+// projectOpsCapacityEntry fills the projection fields.
+//
+// The requirement is the max over independent constraints:
+//   - concurrency: projected peak in-flight requests / (avg concurrency per account * target)
+//   - window_cost: projected peak 5h cost / (avg window_cost_limit * target)
+//   - codex_quota: current 5h quota consumption (in account-equivalents) * growth / target
+func projectOpsCapacityEntry(e *OpsCapacityPlanEntry, growthFactor float64, targetUtilization float64) {
+	if e == nil {
+		return
+	}
+	if targetUtilization <= 0 || targetUtilization > 1 {
+		targetUtilization = opsCapacityDefaultTargetUtilization
+	}
+	if growthFactor < 0 {
+		growthFactor = 0
+	}
+
+	needed := 0
+	basis := ""
+
+	projectedPeak := float64(e.PeakConcurrency) * growthFactor
+	e.ProjectedPeakConcurrency = roundTo2DP(projectedPeak)
+	if e.AccountCount > 0 && e.TotalConcurrency > 0 {
+		perAccount := float64(e.TotalConcurrency) / float64(e.AccountCount)
+		n := int(math.Ceil(projectedPeak / (perAccount * targetUtilization)))
+		if n > needed || basis == "" {
+			needed = n
+			basis = opsCapacityBasisConcurrency
+		}
+	}
+
+	if e.WindowCostLimitAccounts > 0 && e.AvgWindowCostLimit > 0 {
+		n := int(math.Ceil(e.Peak5hWindowCost * growthFactor / (e.AvgWindowCostLimit * targetUtilization)))
+		if n > needed {
+			needed = n
+			basis = opsCapacityBasisWindowCost
+		}
+	}
+
+	if e.CodexAccounts > 0 && e.CodexAvg5hUsedPercent != nil {
+		consumed := *e.CodexAvg5hUsedPercent / 100 * float64(e.CodexAccounts)
+		n := int(math.Ceil(consumed * growthFactor / targetUtilization))
+		if n > needed {
+			needed = n
+			basis = opsCapacityBasisCodexQuota
+		}
+	}
+
+	if basis == "" {
+		basis = opsCapacityBasisConcurrency
+	}
+	e.ProjectedAccountsNeeded = needed
+	e.ProjectionBasis = basis
+	if needed > e.AccountCount {
+		e.AdditionalAccountsNeeded = needed - e.AccountCount
+	} else {
+		e.AdditionalAccountsNeeded = 0
+	}
+}
+
+// opsCapacityGrowth fits a least-squares line over the daily series and returns
+// (daily growth % relative to the mean, projected demand factor after horizonDays).
+func opsCapacityGrowth(daily []float64, horizonDays int) (float64, float64) {
+	n := len(daily)
+	mean := opsCapacityMean(daily)
+	if n < 2 || mean <= 0 {
+		return 0, 1
+	}
+
+	var sumX, sumXX, sumXY float64
+	for i, y := range daily {
+		x := float64(i)
+		sumX += x
+		sumXX += x * x
+		sumXY += x * y
+	}
+	fn := float64(n)
+	denom := fn*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0, 1
+	}
+	slope := (fn*sumXY - sumX*mean*fn) / denom
+	intercept := mean - slope*sumX/fn
+
+	// 以最近一天的拟合值为基准外推，避免原始数据中单日波动放大结果
+	latest := intercept + slope*float64(n-1)
+	if latest <= 0 {
+		latest = mean
+	}
+	projected := intercept + slope*float64(n-1+horizonDays)
+	factor := projected / latest
+	if factor < 0 {
+		factor = 0
+	}
+	if factor > opsCapacityMaxGrowthFactor {
+		factor = opsCapacityMaxGrowthFactor
+	}
+	return slope / mean * 100, factor
+}
+
+// opsCapacityPeakSlidingSum returns the max sum over any `hours` consecutive hourly buckets.
+func opsCapacityPeakSlidingSum(hourly map[int64]float64, hours int) float64 {
+	if len(hourly) == 0 || hours <= 0 {
+		return 0
+	}
+	keys := make([]int64, 0, len(hourly))
+	for k := range hourly {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	peak := 0.0
+	sum := 0.0
+	left := 0
+	for right := 0; right < len(keys); right++ {
+		sum += hourly[keys[right]]
+		for keys[right]-keys[left] >= int64(hours) {
+			sum -= hourly[keys[left]]
+			left++
+		}
+		if sum > peak {
+			peak = sum
+		}
+	}
+	return peak
+}
+
+func opsCapacityMean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
+
+func roundTo2DP(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,122 @@
+package service
+
+import "time"
+
+// OpsCapacitySnapshot is one periodic sample of an account pool (group or platform scope).
+//
+// GroupID == nil means the row is a platform-level aggregate.
+type OpsCapacitySnapshot struct {
+	CreatedAt     time.Time
+	WindowSeconds int
+
+	Platform string
+	GroupID  *int64
+
+	TotalAccounts       int
+	AvailableAccounts   int
+	RateLimitedAccounts int
+
+	MaxConcurrency int64
+	CurrentInUse   int64
+	WaitingInQueue int64
+}
+
+// OpsCapacitySnapshotStats aggregates snapshots over a time range for one scope.
+type OpsCapacitySnapshotStats struct {
+	Platform string
+	GroupID  *int64
+
+	SampleCount         int64
+	PeakInUse           int64
+	AvgInUse            float64
+	PeakWaiting         int64
+	ZeroAvailableSecond int64
+	CoveredSeconds      int64
+}
+
+// OpsCapacityUsageHourly is hourly usage for one (group, platform) pair.
+type OpsCapacityUsageHourly struct {
+	GroupID   *int64
+	Platform  string
+	HourStart time.Time
+	Requests  int64
+	TotalCost float64
+}
+
+// OpsAccountRateLimitDaily counts upstream 429 hits for an account on a given (UTC) day.
+type OpsAccountRateLimitDaily struct {
+	AccountID   int64     `json:"account_id"`
+	AccountName string    `json:"account_name"`
+	Platform    string    `json:"platform"`
+	Day         time.Time `json:"day"`
+	Hits        int64     `json:"hits"`
+}
+
+// OpsCapacityPlanFilter selects the scope of the capacity planner report.
+type OpsCapacityPlanFilter struct {
+	StartTime time.Time
+	EndTime   time.Time
+
+	Platform string
+	GroupID  *int64
+
+	// HorizonDays is how far ahead the growth trend is projected.
+	HorizonDays int
+	// TargetUtilization is the desired peak utilization of the pool (0-1].
+	TargetUtilization float64
+}
+
+// OpsCapacityPlanEntry is the capacity planning result for one group or platform.
+type OpsCapacityPlanEntry struct {
+	GroupID   *int64 `json:"group_id,omitempty"`
+	GroupName string `json:"group_name,omitempty"`
+	Platform  string `json:"platform"`
+
+	// Current pool
+	AccountCount          int     `json:"account_count"`
+	SchedulableAccounts   int     `json:"schedulable_accounts"`
+	TotalConcurrency      int64   `json:"total_concurrency"`
+	PeakConcurrency       int64   `json:"peak_concurrency"`
+	PeakWaiting           int64   `json:"peak_waiting"`
+	AvgConcurrency        float64 `json:"avg_concurrency"`
+	PeakUtilizationPct    float64 `json:"peak_utilization_pct"`
+	ZeroAvailableHours    float64 `json:"zero_available_hours"`
+	SnapshotCoverageHours float64 `json:"snapshot_coverage_hours"`
+
+	// Rate limits
+	RateLimitHits                 int64   `json:"rate_limit_hits"`
+	RateLimitHitsPerAccountPerDay float64 `json:"rate_limit_hits_per_account_per_day"`
+
+	// Quota signals
+	WindowCostLimitAccounts int      `json:"window_cost_limit_accounts"`
+	AvgWindowCostLimit      float64  `json:"avg_window_cost_limit"`
+	Peak5hWindowCost        float64  `json:"peak_5h_window_cost"`
+	CodexAccounts           int      `json:"codex_accounts"`
+	CodexAvg5hUsedPercent   *float64 `json:"codex_avg_5h_used_percent,omitempty"`
+
+	// Demand trend
+	DailyRequestsAvg float64 `json:"daily_requests_avg"`
+	DailyCostAvg     float64 `json:"daily_cost_avg"`
+	DailyGrowthPct   float64 `json:"daily_growth_pct"`
+	GrowthFactor     float64 `json:"growth_factor"`
+
+	// Projection
+	ProjectedPeakConcurrency float64 `json:"projected_peak_concurrency"`
+	ProjectedAccountsNeeded  int     `json:"projected_accounts_needed"`
+	AdditionalAccountsNeeded int     `json:"additional_accounts_needed"`
+	// ProjectionBasis is the constraint that drives the projection: concurrency | window_cost | codex_quota.
+	ProjectionBasis string `json:"projection_basis"`
+}
+
+// OpsCapacityPlanReport is the admin capacity planner report.
+type OpsCapacityPlanReport struct {
+	StartTime         time.Time `json:"start_time"`
+	EndTime           time.Time `json:"end_time"`
+	HorizonDays       int       `json:"horizon_days"`
+	TargetUtilization float64   `json:"target_utilization"`
+
+	Groups    []*OpsCapacityPlanEntry `json:"groups"`
+	Platforms []*OpsCapacityPlanEntry `json:"platforms"`
+
+	AccountRateLimits []*OpsAccountRateLimitDaily `json:"account_rate_limits"`
+}
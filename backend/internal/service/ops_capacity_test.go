@@ -0,0 +1,175 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildOpsCapacitySnapshots(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	future := now.Add(time.Hour)
+	g1 := &Group{ID: 1, Name: "g1", Platform: PlatformAnthropic}
+
+	accounts := []Account{
+		{ID: 1, Platform: PlatformAnthropic, Status: StatusActive, Schedulable: true, Concurrency: 5, Groups: []*Group{g1}},
+		{ID: 2, Platform: PlatformAnthropic, Status: StatusActive, Schedulable: true, Concurrency: 3, RateLimitResetAt: &future, Groups: []*Group{g1}},
+		{ID: 3, Platform: PlatformOpenAI, Status: StatusActive, Schedulable: false, Concurrency: 2},
+	}
+	loadMap := map[int64]*AccountLoadInfo{
+		1: {AccountID: 1, CurrentConcurrency: 4, WaitingCount: 2},
+		2: {AccountID: 2, CurrentConcurrency: 1},
+	}
+
+	snaps := buildOpsCapacitySnapshots(accounts, loadMap, now, 60)
+	require.Len(t, snaps, 3)
+
+	byKey := map[string]*OpsCapacitySnapshot{}
+	for _, s := range snaps {
+		key := s.Platform
+		if s.GroupID != nil {
+			key = "group"
+		}
+		byKey[key] = s
+	}
+
+	grp := byKey["group"]
+	require.Equal(t, int64(1), *grp.GroupID)
+	require.Equal(t, 2, grp.TotalAccounts)
+	require.Equal(t, 1, grp.AvailableAccounts)
+	require.Equal(t, 1, grp.RateLimitedAccounts)
+	require.Equal(t, int64(8), grp.MaxConcurrency)
+	require.Equal(t, int64(5), grp.CurrentInUse)
+	require.Equal(t, int64(2), grp.WaitingInQueue)
+
+	openai := byKey[PlatformOpenAI]
+	require.Equal(t, 1, openai.TotalAccounts)
+	require.Equal(t, 0, openai.AvailableAccounts)
+	require.Equal(t, 60, openai.WindowSeconds)
+}
+
+func TestOpsCapacityGrowth(t *testing.T) {
+	// 稳定流量：无增长
+	pct, factor := opsCapacityGrowth([]float64{100, 100, 100, 100}, 30)
+	require.Zero(t, pct)
+	require.InDelta(t, 1.0, factor, 1e-9)
+
+	// 每天 +10：最近拟合值 130，30 天后 430
+	pct, factor = opsCapacityGrowth([]float64{100, 110, 120, 130}, 30)
+	require.InDelta(t, 10.0/115*100, pct, 1e-9)
+	require.InDelta(t, 430.0/130, factor, 1e-9)
+
+	// 样本不足
+	_, factor = opsCapacityGrowth([]float64{50}, 30)
+	require.Equal(t, 1.0, factor)
+
+	// 持续下降不应产生负值
+	_, factor = opsCapacityGrowth([]float64{100, 50, 10, 1}, 365)
+	require.GreaterOrEqual(t, factor, 0.0)
+}
+
+func TestOpsCapacityPeakSlidingSum(t *testing.T) {
+	hourly := map[int64]float64{
+		0:  1,
+		1:  2,
+		2:  3,
+		10: 5,
+		12: 4,
+		16: 1,
+	}
+	// [10,14] -> 9; [0,4] -> 6; [12,16] -> 5
+	require.Equal(t, 9.0, opsCapacityPeakSlidingSum(hourly, 5))
+	require.Equal(t, 5.0, opsCapacityPeakSlidingSum(hourly, 1))
+	require.Zero(t, opsCapacityPeakSlidingSum(nil, 5))
+}
+
+func TestProjectOpsCapacityEntry(t *testing.T) {
+	e := &OpsCapacityPlanEntry{
+		AccountCount:     4,
+		TotalConcurrency: 20,
+		PeakConcurrency:  16,
+	}
+	projectOpsCapacityEntry(e, 1.5, 0.8)
+	// 16*1.5=24 并发，每账号 5，目标 80% -> ceil(24/4)=6
+	require.Equal(t, 6, e.ProjectedAccountsNeeded)
+	require.Equal(t, 2, e.AdditionalAccountsNeeded)
+	require.Equal(t, opsCapacityBasisConcurrency, e.ProjectionBasis)
+
+	// 5h 窗口费用成为瓶颈
+	e = &OpsCapacityPlanEntry{
+		AccountCount:            2,
+		TotalConcurrency:        20,
+		PeakConcurrency:         2,
+		WindowCostLimitAccounts: 2,
+		AvgWindowCostLimit:      50,
+		Peak5hWindowCost:        120,
+	}
+	projectOpsCapacityEntry(e, 1, 0.8)
+	require.Equal(t, 3, e.ProjectedAccountsNeeded)
+	require.Equal(t, opsCapacityBasisWindowCost, e.ProjectionBasis)
+
+	// Codex 5h 配额
+	used := 90.0
+	e = &OpsCapacityPlanEntry{
+		AccountCount:          3,
+		TotalConcurrency:      30,
+		CodexAccounts:         3,
+		CodexAvg5hUsedPercent: &used,
+	}
+	projectOpsCapacityEntry(e, 2, 0.8)
+	// 0.9*3=2.7 账号当量 * 2 / 0.8 = 6.75 -> 7
+	require.Equal(t, 7, e.ProjectedAccountsNeeded)
+	require.Equal(t, 4, e.AdditionalAccountsNeeded)
+	require.Equal(t, opsCapacityBasisCodexQuota, e.ProjectionBasis)
+}
+
+func TestBuildOpsCapacityPlan(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * 24 * time.Hour)
+	g1 := &Group{ID: 7, Name: "g7", Platform: PlatformAnthropic}
+	groupID := int64(7)
+
+	accounts := []Account{
+		{ID: 1, Platform: PlatformAnthropic, Status: StatusActive, Schedulable: true, Concurrency: 4, Groups: []*Group{g1}},
+		{ID: 2, Platform: PlatformAnthropic, Status: StatusActive, Schedulable: true, Concurrency: 4, Groups: []*Group{g1}},
+	}
+	stats := []*OpsCapacitySnapshotStats{
+		{Platform: PlatformAnthropic, GroupID: &groupID, SampleCount: 10, PeakInUse: 6, AvgInUse: 3, ZeroAvailableSecond: 1800, CoveredSeconds: 7200},
+	}
+	usage := []*OpsCapacityUsageHourly{
+		{GroupID: &groupID, Platform: PlatformAnthropic, HourStart: start.Add(time.Hour), Requests: 100, TotalCost: 2},
+		{GroupID: &groupID, Platform: PlatformAnthropic, HourStart: start.Add(25 * time.Hour), Requests: 100, TotalCost: 3},
+	}
+	rateLimits := []*OpsAccountRateLimitDaily{
+		{AccountID: 1, Platform: PlatformAnthropic, Day: start, Hits: 8},
+	}
+
+	report := buildOpsCapacityPlan(accounts, stats, usage, rateLimits, start, end, 30, 0.8)
+	require.Len(t, report.Groups, 1)
+	require.Len(t, report.Platforms, 1)
+
+	g := report.Groups[0]
+	require.Equal(t, "g7", g.GroupName)
+	require.Equal(t, 2, g.AccountCount)
+	require.Equal(t, int64(8), g.TotalConcurrency)
+	require.Equal(t, int64(6), g.PeakConcurrency)
+	require.Equal(t, 75.0, g.PeakUtilizationPct)
+	require.Equal(t, 0.5, g.ZeroAvailableHours)
+	require.Equal(t, 2.0, g.SnapshotCoverageHours)
+	require.Equal(t, int64(8), g.RateLimitHits)
+	require.Equal(t, 2.0, g.RateLimitHitsPerAccountPerDay)
+	require.Equal(t, 100.0, g.DailyRequestsAvg)
+	require.Equal(t, 2.5, g.DailyCostAvg)
+	require.Equal(t, 3.0, g.Peak5hWindowCost)
+	// 6 并发 / (每账号 4 * 0.8) -> 2
+	require.Equal(t, 2, g.ProjectedAccountsNeeded)
+	require.Zero(t, g.AdditionalAccountsNeeded)
+
+	p := report.Platforms[0]
+	require.Nil(t, p.GroupID)
+	require.Equal(t, int64(8), p.RateLimitHits)
+	require.Equal(t, 100.0, p.DailyRequestsAvg)
+}
@@ -39,7 +39,7 @@ return 0
 type OpsCleanupService struct {
 	opsRepo     OpsRepository
 	db          *sql.DB
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 	cfg         *config.Config
 
 	instanceID string
@@ -55,7 +55,7 @@ type OpsCleanupService struct {
 func NewOpsCleanupService(
 	opsRepo OpsRepository,
 	db *sql.DB,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsCleanupService {
 	return &OpsCleanupService{
@@ -154,23 +154,25 @@ func (s *OpsCleanupService) runScheduled() {
 }
 
 type opsCleanupDeletedCounts struct {
-	errorLogs     int64
-	retryAttempts int64
-	alertEvents   int64
-	systemMetrics int64
-	hourlyPreagg  int64
-	dailyPreagg   int64
+	errorLogs         int64
+	retryAttempts     int64
+	alertEvents       int64
+	systemMetrics     int64
+	hourlyPreagg      int64
+	dailyPreagg       int64
+	capacitySnapshots int64
 }
 
 func (c opsCleanupDeletedCounts) String() string {
 	return fmt.Sprintf(
-		"error_logs=%d retry_attempts=%d alert_events=%d system_metrics=%d hourly_preagg=%d daily_preagg=%d",
+		"error_logs=%d retry_attempts=%d alert_events=%d system_metrics=%d hourly_preagg=%d daily_preagg=%d capacity_snapshots=%d",
 		c.errorLogs,
 		c.retryAttempts,
 		c.alertEvents,
 		c.systemMetrics,
 		c.hourlyPreagg,
 		c.dailyPreagg,
+		c.capacitySnapshots,
 	)
 }
 
@@ -230,6 +232,13 @@ func (s *OpsCleanupService) runCleanupOnce(ctx context.Context) (opsCleanupDelet
 			return out, err
 		}
 		out.dailyPreagg = n
+
+		// Capacity snapshots back long-range planning, so they follow the hourly retention.
+		n, err = deleteOldRowsByID(ctx, s.db, "ops_capacity_snapshots", "created_at", cutoff, batchSize, false)
+		if err != nil {
+			return out, err
+		}
+		out.capacitySnapshots = n
 	}
 
 	return out, nil
@@ -14,14 +14,22 @@ const (
 )
 
 func (s *OpsService) listAllAccountsForOps(ctx context.Context, platformFilter string) ([]Account, error) {
-	if s == nil || s.accountRepo == nil {
+	if s == nil {
+		return []Account{}, nil
+	}
+	return listAllAccountsPaged(ctx, s.accountRepo, platformFilter)
+}
+
+// listAllAccountsPaged pages through all accounts (optionally filtered by platform).
+func listAllAccountsPaged(ctx context.Context, accountRepo AccountRepository, platformFilter string) ([]Account, error) {
+	if accountRepo == nil {
 		return []Account{}, nil
 	}
 
 	out := make([]Account, 0, 128)
 	page := 1
 	for {
-		accounts, pageInfo, err := s.accountRepo.ListWithFilters(ctx, pagination.PaginationParams{
+		accounts, pageInfo, err := accountRepo.ListWithFilters(ctx, pagination.PaginationParams{
 			Page:     page,
 			PageSize: opsAccountsPageSize,
 		}, platformFilter, "", "", "")
@@ -51,7 +59,14 @@ func (s *OpsService) listAllAccountsForOps(ctx context.Context, platformFilter s
 }
 
 func (s *OpsService) getAccountsLoadMapBestEffort(ctx context.Context, accounts []Account) map[int64]*AccountLoadInfo {
-	if s == nil || s.concurrencyService == nil {
+	if s == nil {
+		return map[int64]*AccountLoadInfo{}
+	}
+	return loadAccountsLoadMapBestEffort(ctx, s.concurrencyService, accounts)
+}
+
+func loadAccountsLoadMapBestEffort(ctx context.Context, concurrencyService *ConcurrencyService, accounts []Account) map[int64]*AccountLoadInfo {
+	if concurrencyService == nil {
 		return map[int64]*AccountLoadInfo{}
 	}
 	if len(accounts) == 0 {
@@ -83,7 +98,7 @@ func (s *OpsService) getAccountsLoadMapBestEffort(ctx context.Context, accounts
 		if end > len(batch) {
 			end = len(batch)
 		}
-		part, err := s.concurrencyService.GetAccountsLoadBatch(ctx, batch[i:end])
+		part, err := concurrencyService.GetAccountsLoadBatch(ctx, batch[i:end])
 		if err != nil {
 			// Best-effort: return zeros rather than failing the ops UI.
 			log.Printf("[Ops] GetAccountsLoadBatch failed: %v", err)
@@ -48,7 +48,7 @@ type OpsMetricsCollector struct {
 	concurrencyService *ConcurrencyService
 
 	db          *sql.DB
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 	instanceID  string
 
 	lastCgroupCPUUsageNanos uint64
@@ -68,7 +68,7 @@ func NewOpsMetricsCollector(
 	accountRepo AccountRepository,
 	concurrencyService *ConcurrencyService,
 	db *sql.DB,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsMetricsCollector {
 	return &OpsMetricsCollector{
@@ -354,7 +354,13 @@ func (c *OpsMetricsCollector) collectAndPersist(ctx context.Context) error {
 		ConcurrencyQueueDepth: concurrencyQueueDepth,
 	}
 
-	return c.opsRepo.InsertSystemMetrics(ctx, input)
+	if err := c.opsRepo.InsertSystemMetrics(ctx, input); err != nil {
+		return err
+	}
+
+	// Capacity snapshots are best-effort and must not fail the system metrics run.
+	c.collectCapacitySnapshots(ctx, windowEnd)
+	return nil
 }
 
 func (c *OpsMetricsCollector) collectConcurrencyQueueDepth(parentCtx context.Context) *int {
@@ -27,6 +27,8 @@ type OpsRepository interface {
 	GetLatencyHistogram(ctx context.Context, filter *OpsDashboardFilter) (*OpsLatencyHistogramResponse, error)
 	GetErrorTrend(ctx context.Context, filter *OpsDashboardFilter, bucketSeconds int) (*OpsErrorTrendResponse, error)
 	GetErrorDistribution(ctx context.Context, filter *OpsDashboardFilter) (*OpsErrorDistributionResponse, error)
+	// Per-arm comparison for group traffic splits (canary / A-B).
+	GetTrafficArmStats(ctx context.Context, filter *OpsDashboardFilter) (*OpsTrafficArmsResponse, error)
 
 	InsertSystemMetrics(ctx context.Context, input *OpsInsertSystemMetricsInput) error
 	GetLatestSystemMetrics(ctx context.Context, windowMinutes int) (*OpsSystemMetricsSnapshot, error)
@@ -57,6 +59,12 @@ type OpsRepository interface {
 	UpsertDailyMetrics(ctx context.Context, startTime, endTime time.Time) error
 	GetLatestHourlyBucketStart(ctx context.Context) (time.Time, bool, error)
 	GetLatestDailyBucketDate(ctx context.Context) (time.Time, bool, error)
+
+	// Capacity planning (account pool snapshots + demand history).
+	InsertCapacitySnapshots(ctx context.Context, items []*OpsCapacitySnapshot) error
+	GetCapacitySnapshotStats(ctx context.Context, startTime, endTime time.Time) ([]*OpsCapacitySnapshotStats, error)
+	GetCapacityUsageHourly(ctx context.Context, startTime, endTime time.Time) ([]*OpsCapacityUsageHourly, error)
+	GetAccountRateLimitHitsDaily(ctx context.Context, startTime, endTime time.Time) ([]*OpsAccountRateLimitDaily, error)
 }
 
 type OpsInsertErrorLogInput struct {
@@ -74,6 +82,8 @@ type OpsInsertErrorLogInput struct {
 	RequestPath string
 	Stream      bool
 	UserAgent   string
+	// TrafficArm 灰度 / A-B 分流实验组（未启用分流时为空）
+	TrafficArm string
 
 	ErrorPhase        string
 	ErrorType         string
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// OpsTrafficArmStats 分组灰度 / A-B 分流中单个实验组在时间窗口内的指标
+type OpsTrafficArmStats struct {
+	Arm string `json:"arm"`
+
+	SuccessCount  int64   `json:"success_count"`
+	ErrorCountSLA int64   `json:"error_count_sla"`
+	RequestCount  int64   `json:"request_count"`
+	ErrorRate     float64 `json:"error_rate"`
+	TokenConsumed int64   `json:"token_consumed"`
+
+	DurationAvgMs *int `json:"duration_avg_ms"`
+	DurationP50Ms *int `json:"duration_p50_ms"`
+	DurationP95Ms *int `json:"duration_p95_ms"`
+	TTFTAvgMs     *int `json:"ttft_avg_ms"`
+	TTFTP95Ms     *int `json:"ttft_p95_ms"`
+
+	TotalCost          float64 `json:"total_cost"`
+	CostPerRequest     float64 `json:"cost_per_request"`
+	CostPerMillionToks float64 `json:"cost_per_million_tokens"`
+}
+
+// OpsTrafficArmsResponse 分组各实验组对比（control 为基线）
+type OpsTrafficArmsResponse struct {
+	GroupID int64                 `json:"group_id"`
+	Arms    []*OpsTrafficArmStats `json:"arms"`
+}
+
+// GetTrafficArmStats 按实验组对比分组在时间窗口内的错误率、延迟、首 token 耗时与成本。
+// 只统计分组启用分流后记录了实验组的请求。
+func (s *OpsService) GetTrafficArmStats(ctx context.Context, filter *OpsDashboardFilter) (*OpsTrafficArmsResponse, error) {
+	if err := s.RequireMonitoringEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if s.opsRepo == nil {
+		return nil, infraerrors.ServiceUnavailable("OPS_REPO_UNAVAILABLE", "Ops repository not available")
+	}
+	if filter == nil {
+		return nil, infraerrors.BadRequest("OPS_FILTER_REQUIRED", "filter is required")
+	}
+	if filter.GroupID == nil || *filter.GroupID <= 0 {
+		return nil, infraerrors.BadRequest("OPS_GROUP_REQUIRED", "group_id is required")
+	}
+	if filter.StartTime.IsZero() || filter.EndTime.IsZero() {
+		return nil, infraerrors.BadRequest("OPS_TIME_RANGE_REQUIRED", "start_time/end_time are required")
+	}
+	if filter.StartTime.After(filter.EndTime) {
+		return nil, infraerrors.BadRequest("OPS_TIME_RANGE_INVALID", "start_time must be <= end_time")
+	}
+	return s.opsRepo.GetTrafficArmStats(ctx, filter)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+)
+
+// 组织成员角色
+const (
+	OrganizationRoleOwner  = "owner"
+	OrganizationRoleAdmin  = "admin"
+	OrganizationRoleMember = "member"
+)
+
+// 组织邀请状态
+const (
+	OrganizationInvitationStatusPending  = "pending"
+	OrganizationInvitationStatusAccepted = "accepted"
+	OrganizationInvitationStatusRevoked  = "revoked"
+)
+
+var (
+	ErrOrganizationNotFound           = infraerrors.NotFound("ORGANIZATION_NOT_FOUND", "organization not found")
+	ErrOrganizationInactive           = infraerrors.Forbidden("ORGANIZATION_INACTIVE", "organization is not active")
+	ErrOrganizationMemberNotFound     = infraerrors.NotFound("ORGANIZATION_MEMBER_NOT_FOUND", "organization member not found")
+	ErrOrganizationMemberExists       = infraerrors.Conflict("ORGANIZATION_MEMBER_EXISTS", "user is already a member of this organization")
+	ErrOrganizationPermissionDenied   = infraerrors.Forbidden("ORGANIZATION_PERMISSION_DENIED", "insufficient organization permissions")
+	ErrOrganizationInvalidRole        = infraerrors.BadRequest("ORGANIZATION_INVALID_ROLE", "role must be one of: owner, admin, member")
+	ErrOrganizationOwnerRequired      = infraerrors.BadRequest("ORGANIZATION_OWNER_REQUIRED", "organization must keep exactly one owner; transfer ownership first")
+	ErrOrganizationInvitationNotFound = infraerrors.NotFound("ORGANIZATION_INVITATION_NOT_FOUND", "invitation not found")
+	ErrOrganizationInvitationInvalid  = infraerrors.BadRequest("ORGANIZATION_INVITATION_INVALID", "invitation is invalid or expired")
+	ErrOrganizationInvitationEmail    = infraerrors.Forbidden("ORGANIZATION_INVITATION_EMAIL_MISMATCH", "invitation was sent to a different email address")
+	ErrOrganizationInsufficientFunds  = infraerrors.Forbidden("ORGANIZATION_INSUFFICIENT_BALANCE", "insufficient organization balance")
+	ErrOrganizationMemberLimit        = infraerrors.Forbidden("ORGANIZATION_MEMBER_SPENDING_LIMIT_EXCEEDED", "monthly organization spending limit exceeded for this member")
+)
+
+// Organization 组织：成员共享组织余额与订阅
+//
+// 订阅：组织订阅挂在组织所有者名下（UserSubscription.UserID = OwnerUserID），
+// 组织 Key 在订阅分组下使用所有者的订阅与限额。
+type Organization struct {
+	ID          int64
+	Name        string
+	OwnerUserID int64
+	Balance     float64
+	Status      string
+	Notes       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	MemberCount int
+	Owner       *User
+}
+
+func (o *Organization) IsActive() bool {
+	return o != nil && o.Status == StatusActive
+}
+
+// OrganizationMember 组织成员
+type OrganizationMember struct {
+	ID             int64
+	OrganizationID int64
+	UserID         int64
+	Role           string
+	// MonthlyLimitUSD 成员月度消费上限，nil 表示不限制
+	MonthlyLimitUSD    *float64
+	MonthlyUsageUSD    float64
+	MonthlyWindowStart *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+
+	User *User
+}
+
+// CanManage 是否可以管理组织成员与邀请（owner/admin）
+func (m *OrganizationMember) CanManage() bool {
+	return m != nil && (m.Role == OrganizationRoleOwner || m.Role == OrganizationRoleAdmin)
+}
+
+// CurrentMonthlyUsage 返回当前自然月内的消费；窗口已过期时视为 0
+func (m *OrganizationMember) CurrentMonthlyUsage(now time.Time) float64 {
+	if m == nil || m.MonthlyWindowStart == nil {
+		return 0
+	}
+	if m.MonthlyWindowStart.Before(timezone.StartOfMonth(now)) {
+		return 0
+	}
+	return m.MonthlyUsageUSD
+}
+
+// MonthlyLimitReached 当前自然月消费是否已达到上限
+func (m *OrganizationMember) MonthlyLimitReached(now time.Time) bool {
+	if m == nil || m.MonthlyLimitUSD == nil {
+		return false
+	}
+	return m.CurrentMonthlyUsage(now) >= *m.MonthlyLimitUSD
+}
+
+// OrganizationInvitation 组织邀请（通过邮件发送一次性令牌）
+type OrganizationInvitation struct {
+	ID             int64
+	OrganizationID int64
+	Email          string
+	Role           string
+	TokenHash      string
+	InvitedBy      int64
+	Status         string
+	ExpiresAt      time.Time
+	AcceptedUserID *int64
+	AcceptedAt     *time.Time
+	CreatedAt      time.Time
+
+	Organization *Organization
+}
+
+// IsUsable 邀请是否仍可接受
+func (i *OrganizationInvitation) IsUsable(now time.Time) bool {
+	return i != nil && i.Status == OrganizationInvitationStatusPending && now.Before(i.ExpiresAt)
+}
+
+// OrganizationMemberUsage 组织成员用量汇总
+type OrganizationMemberUsage struct {
+	UserID        int64
+	TotalRequests int64
+	TotalTokens   int64
+	TotalCost     float64
+	ActualCost    float64
+}
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *Organization) error
+	GetByID(ctx context.Context, id int64) (*Organization, error)
+	Update(ctx context.Context, org *Organization) error
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, params pagination.PaginationParams, search string) ([]Organization, *pagination.PaginationResult, error)
+	ListByUserID(ctx context.Context, userID int64) ([]Organization, error)
+
+	// UpdateBalance 原子增减组织余额（amount 可为负）
+	UpdateBalance(ctx context.Context, id int64, amount float64) error
+	// DeductBalance 扣除组织余额（允许透支，与用户余额策略一致）
+	DeductBalance(ctx context.Context, id int64, amount float64) error
+
+	AddMember(ctx context.Context, member *OrganizationMember) error
+	GetMember(ctx context.Context, orgID, userID int64) (*OrganizationMember, error)
+	ListMembers(ctx context.Context, orgID int64) ([]OrganizationMember, error)
+	UpdateMember(ctx context.Context, member *OrganizationMember) error
+	RemoveMember(ctx context.Context, orgID, userID int64) error
+	// IncrementMemberUsage 累加成员月度消费，windowStart 之前的旧窗口会先被重置
+	IncrementMemberUsage(ctx context.Context, orgID, userID int64, amount float64, windowStart time.Time) error
+	// DetachAPIKeys 解除组织 Key 的归属并禁用（userID 为 0 时处理组织下全部 Key）
+	DetachAPIKeys(ctx context.Context, orgID, userID int64) (int, error)
+	GetMemberUsage(ctx context.Context, orgID int64, startTime, endTime time.Time) ([]OrganizationMemberUsage, error)
+
+	CreateInvitation(ctx context.Context, invitation *OrganizationInvitation) error
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*OrganizationInvitation, error)
+	GetInvitation(ctx context.Context, id int64) (*OrganizationInvitation, error)
+	ListInvitations(ctx context.Context, orgID int64, status string) ([]OrganizationInvitation, error)
+	UpdateInvitation(ctx context.Context, invitation *OrganizationInvitation) error
+}
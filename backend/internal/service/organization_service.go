@@ -0,0 +1,707 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/usagestats"
+)
+
+// organizationInvitationTTL 邀请链接有效期
+const organizationInvitationTTL = 7 * 24 * time.Hour
+
+var ErrOrganizationNameRequired = infraerrors.BadRequest("ORGANIZATION_NAME_REQUIRED", "organization name is required")
+
+// OrganizationDashboard 组织用量看板
+type OrganizationDashboard struct {
+	Organization *Organization
+	Stats        *usagestats.UsageStats
+	Members      []OrganizationMemberDashboardItem
+}
+
+// OrganizationMemberDashboardItem 看板中的成员用量
+type OrganizationMemberDashboardItem struct {
+	Member OrganizationMember
+	Usage  OrganizationMemberUsage
+}
+
+// UpdateOrganizationMemberInput 更新成员角色/消费上限
+type UpdateOrganizationMemberInput struct {
+	Role              *string
+	MonthlyLimitUSD   *float64
+	ClearMonthlyLimit bool
+}
+
+// AdminUpdateOrganizationInput 管理员更新组织
+type AdminUpdateOrganizationInput struct {
+	Name   *string
+	Status *string
+	Notes  *string
+}
+
+// OrganizationService 组织管理：成员、邀请、共享余额与用量看板
+type OrganizationService struct {
+	orgRepo              OrganizationRepository
+	userRepo             UserRepository
+	entClient            *dbent.Client
+	billingCacheService  *BillingCacheService
+	authCacheInvalidator APIKeyAuthCacheInvalidator
+	emailQueueService    *EmailQueueService
+	settingService       *SettingService
+	usageService         *UsageService
+}
+
+// NewOrganizationService 创建组织服务
+func NewOrganizationService(
+	orgRepo OrganizationRepository,
+	userRepo UserRepository,
+	entClient *dbent.Client,
+	billingCacheService *BillingCacheService,
+	authCacheInvalidator APIKeyAuthCacheInvalidator,
+	emailQueueService *EmailQueueService,
+	settingService *SettingService,
+	usageService *UsageService,
+) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:              orgRepo,
+		userRepo:             userRepo,
+		entClient:            entClient,
+		billingCacheService:  billingCacheService,
+		authCacheInvalidator: authCacheInvalidator,
+		emailQueueService:    emailQueueService,
+		settingService:       settingService,
+		usageService:         usageService,
+	}
+}
+
+// ============================================
+// 用户侧：组织与成员
+// ============================================
+
+// Create 创建组织，创建者成为所有者
+func (s *OrganizationService) Create(ctx context.Context, userID int64, name string) (*Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrOrganizationNameRequired
+	}
+
+	org := &Organization{
+		Name:        name,
+		OwnerUserID: userID,
+		Status:      StatusActive,
+	}
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		if err := s.orgRepo.Create(txCtx, org); err != nil {
+			return err
+		}
+		return s.orgRepo.AddMember(txCtx, &OrganizationMember{
+			OrganizationID: org.ID,
+			UserID:         userID,
+			Role:           OrganizationRoleOwner,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create organization: %w", err)
+	}
+	org.MemberCount = 1
+	return org, nil
+}
+
+// ListMine 返回用户加入的组织
+func (s *OrganizationService) ListMine(ctx context.Context, userID int64) ([]Organization, error) {
+	return s.orgRepo.ListByUserID(ctx, userID)
+}
+
+// Get 返回组织详情及当前用户的成员身份
+func (s *OrganizationService) Get(ctx context.Context, userID, orgID int64) (*Organization, *OrganizationMember, error) {
+	member, err := s.requireMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return org, member, nil
+}
+
+// ListMembers 列出组织成员（成员可见）
+func (s *OrganizationService) ListMembers(ctx context.Context, userID, orgID int64) ([]OrganizationMember, error) {
+	if _, err := s.requireMember(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListMembers(ctx, orgID)
+}
+
+// UpdateMember 更新成员角色或月度消费上限
+// 管理员只能调整普通成员；授予/撤销管理员需要所有者；所有权通过 TransferOwnership 转移。
+func (s *OrganizationService) UpdateMember(ctx context.Context, actorID, orgID, targetUserID int64, input UpdateOrganizationMemberInput) (*OrganizationMember, error) {
+	actor, err := s.requireManager(ctx, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.orgRepo.GetMember(ctx, orgID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageMember(actor, target) {
+		return nil, ErrOrganizationPermissionDenied
+	}
+
+	if input.Role != nil && *input.Role != target.Role {
+		role := *input.Role
+		if role == OrganizationRoleOwner || target.Role == OrganizationRoleOwner {
+			return nil, ErrOrganizationOwnerRequired
+		}
+		if !isValidOrganizationRole(role) {
+			return nil, ErrOrganizationInvalidRole
+		}
+		if role == OrganizationRoleAdmin && actor.Role != OrganizationRoleOwner {
+			return nil, ErrOrganizationPermissionDenied
+		}
+		target.Role = role
+	}
+	if input.ClearMonthlyLimit {
+		target.MonthlyLimitUSD = nil
+	} else if input.MonthlyLimitUSD != nil {
+		if *input.MonthlyLimitUSD < 0 {
+			return nil, infraerrors.BadRequest("ORGANIZATION_INVALID_LIMIT", "monthly limit must be non-negative")
+		}
+		limit := *input.MonthlyLimitUSD
+		target.MonthlyLimitUSD = &limit
+	}
+
+	if err := s.orgRepo.UpdateMember(ctx, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// RemoveMember 移除成员（targetUserID == actorID 时为主动退出）
+// 被移除成员的组织 Key 会解除归属并禁用，避免继续消耗组织余额。
+func (s *OrganizationService) RemoveMember(ctx context.Context, actorID, orgID, targetUserID int64) error {
+	target, err := s.orgRepo.GetMember(ctx, orgID, targetUserID)
+	if err != nil {
+		return err
+	}
+	if target.Role == OrganizationRoleOwner {
+		return ErrOrganizationOwnerRequired
+	}
+	if actorID != targetUserID {
+		actor, err := s.requireManager(ctx, orgID, actorID)
+		if err != nil {
+			return err
+		}
+		if !canManageMember(actor, target) {
+			return ErrOrganizationPermissionDenied
+		}
+	}
+
+	err = s.withTx(ctx, func(txCtx context.Context) error {
+		if err := s.orgRepo.RemoveMember(txCtx, orgID, targetUserID); err != nil {
+			return err
+		}
+		_, err := s.orgRepo.DetachAPIKeys(txCtx, orgID, targetUserID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("remove organization member: %w", err)
+	}
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, targetUserID)
+	}
+	return nil
+}
+
+// TransferOwnership 所有者将组织转让给其他成员，原所有者降为管理员
+func (s *OrganizationService) TransferOwnership(ctx context.Context, actorID, orgID, newOwnerID int64) (*Organization, error) {
+	actor, err := s.requireMember(ctx, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != OrganizationRoleOwner {
+		return nil, ErrOrganizationPermissionDenied
+	}
+	if newOwnerID == actorID {
+		return s.orgRepo.GetByID(ctx, orgID)
+	}
+	target, err := s.orgRepo.GetMember(ctx, orgID, newOwnerID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.withTx(ctx, func(txCtx context.Context) error {
+		org.OwnerUserID = newOwnerID
+		if err := s.orgRepo.Update(txCtx, org); err != nil {
+			return err
+		}
+		target.Role = OrganizationRoleOwner
+		if err := s.orgRepo.UpdateMember(txCtx, target); err != nil {
+			return err
+		}
+		actor.Role = OrganizationRoleAdmin
+		return s.orgRepo.UpdateMember(txCtx, actor)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transfer organization ownership: %w", err)
+	}
+	// 订阅归属随所有者变化，需刷新全部成员的认证缓存
+	s.invalidateMembersAuthCache(ctx, orgID)
+	return org, nil
+}
+
+// ============================================
+// 邀请
+// ============================================
+
+// Invite 通过邮件邀请用户加入组织
+func (s *OrganizationService) Invite(ctx context.Context, actorID, orgID int64, email, role, frontendBaseURL string) (*OrganizationInvitation, error) {
+	actor, err := s.requireManager(ctx, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !org.IsActive() {
+		return nil, ErrOrganizationInactive
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, infraerrors.BadRequest("ORGANIZATION_INVITATION_EMAIL_INVALID", "a valid email is required")
+	}
+	if role == "" {
+		role = OrganizationRoleMember
+	}
+	if role != OrganizationRoleAdmin && role != OrganizationRoleMember {
+		return nil, ErrOrganizationInvalidRole
+	}
+	if role == OrganizationRoleAdmin && actor.Role != OrganizationRoleOwner {
+		return nil, ErrOrganizationPermissionDenied
+	}
+
+	if existing, err := s.userRepo.GetByEmail(ctx, email); err == nil && existing != nil {
+		if _, err := s.orgRepo.GetMember(ctx, orgID, existing.ID); err == nil {
+			return nil, ErrOrganizationMemberExists
+		}
+	}
+
+	token, err := generateOrganizationInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+	invitation := &OrganizationInvitation{
+		OrganizationID: orgID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      hashOrganizationInvitationToken(token),
+		InvitedBy:      actorID,
+		Status:         OrganizationInvitationStatusPending,
+		ExpiresAt:      time.Now().Add(organizationInvitationTTL),
+	}
+	if err := s.orgRepo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("create organization invitation: %w", err)
+	}
+	invitation.Organization = org
+
+	s.sendInvitationEmail(ctx, org, invitation, token, frontendBaseURL)
+	return invitation, nil
+}
+
+// ListInvitations 列出待接受的邀请
+func (s *OrganizationService) ListInvitations(ctx context.Context, actorID, orgID int64) ([]OrganizationInvitation, error) {
+	if _, err := s.requireManager(ctx, orgID, actorID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListInvitations(ctx, orgID, OrganizationInvitationStatusPending)
+}
+
+// RevokeInvitation 撤销邀请
+func (s *OrganizationService) RevokeInvitation(ctx context.Context, actorID, orgID, invitationID int64) error {
+	if _, err := s.requireManager(ctx, orgID, actorID); err != nil {
+		return err
+	}
+	invitation, err := s.orgRepo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation.OrganizationID != orgID {
+		return ErrOrganizationInvitationNotFound
+	}
+	if invitation.Status != OrganizationInvitationStatusPending {
+		return ErrOrganizationInvitationInvalid
+	}
+	invitation.Status = OrganizationInvitationStatusRevoked
+	return s.orgRepo.UpdateInvitation(ctx, invitation)
+}
+
+// AcceptInvitation 接受邀请；邀请邮箱必须与当前用户邮箱一致
+func (s *OrganizationService) AcceptInvitation(ctx context.Context, userID int64, token string) (*Organization, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrOrganizationInvitationInvalid
+	}
+	invitation, err := s.orgRepo.GetInvitationByTokenHash(ctx, hashOrganizationInvitationToken(token))
+	if err != nil {
+		if errors.Is(err, ErrOrganizationInvitationNotFound) {
+			return nil, ErrOrganizationInvitationInvalid
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if !invitation.IsUsable(now) {
+		return nil, ErrOrganizationInvitationInvalid
+	}
+	if invitation.Organization != nil && !invitation.Organization.IsActive() {
+		return nil, ErrOrganizationInactive
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(user.Email), invitation.Email) {
+		return nil, ErrOrganizationInvitationEmail
+	}
+
+	err = s.withTx(ctx, func(txCtx context.Context) error {
+		if err := s.orgRepo.AddMember(txCtx, &OrganizationMember{
+			OrganizationID: invitation.OrganizationID,
+			UserID:         userID,
+			Role:           invitation.Role,
+		}); err != nil {
+			return err
+		}
+		invitation.Status = OrganizationInvitationStatusAccepted
+		invitation.AcceptedUserID = &userID
+		invitation.AcceptedAt = &now
+		return s.orgRepo.UpdateInvitation(txCtx, invitation)
+	})
+	if err != nil {
+		if errors.Is(err, ErrOrganizationMemberExists) {
+			return nil, ErrOrganizationMemberExists
+		}
+		return nil, fmt.Errorf("accept organization invitation: %w", err)
+	}
+	return s.orgRepo.GetByID(ctx, invitation.OrganizationID)
+}
+
+// ============================================
+// 用量看板
+// ============================================
+
+// GetDashboard 返回组织整体用量与各成员用量（所有者/管理员可见）
+func (s *OrganizationService) GetDashboard(ctx context.Context, actorID, orgID int64, startTime, endTime time.Time) (*OrganizationDashboard, error) {
+	if _, err := s.requireManager(ctx, orgID, actorID); err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.usageService.GetStatsWithFilters(ctx, usagestats.UsageLogFilters{
+		OrganizationID: orgID,
+		StartTime:      &startTime,
+		EndTime:        &endTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get organization usage stats: %w", err)
+	}
+
+	members, err := s.orgRepo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	usages, err := s.orgRepo.GetMemberUsage(ctx, orgID, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("get organization member usage: %w", err)
+	}
+	usageByUser := make(map[int64]OrganizationMemberUsage, len(usages))
+	for _, u := range usages {
+		usageByUser[u.UserID] = u
+	}
+
+	items := make([]OrganizationMemberDashboardItem, 0, len(members))
+	for _, m := range members {
+		usage, ok := usageByUser[m.UserID]
+		if !ok {
+			usage = OrganizationMemberUsage{UserID: m.UserID}
+		}
+		items = append(items, OrganizationMemberDashboardItem{Member: m, Usage: usage})
+	}
+
+	return &OrganizationDashboard{
+		Organization: org,
+		Stats:        stats,
+		Members:      items,
+	}, nil
+}
+
+// ListUsage 分页查询组织的使用记录（所有者/管理员可见）
+func (s *OrganizationService) ListUsage(ctx context.Context, actorID, orgID int64, params pagination.PaginationParams, filters usagestats.UsageLogFilters) ([]UsageLog, *pagination.PaginationResult, error) {
+	if _, err := s.requireManager(ctx, orgID, actorID); err != nil {
+		return nil, nil, err
+	}
+	filters.OrganizationID = orgID
+	return s.usageService.ListWithFilters(ctx, params, filters)
+}
+
+// ============================================
+// 管理员
+// ============================================
+
+// AdminList 分页查询组织
+func (s *OrganizationService) AdminList(ctx context.Context, params pagination.PaginationParams, search string) ([]Organization, *pagination.PaginationResult, error) {
+	return s.orgRepo.List(ctx, params, strings.TrimSpace(search))
+}
+
+// AdminGet 获取组织详情
+func (s *OrganizationService) AdminGet(ctx context.Context, orgID int64) (*Organization, error) {
+	return s.orgRepo.GetByID(ctx, orgID)
+}
+
+// AdminListMembers 列出组织成员
+func (s *OrganizationService) AdminListMembers(ctx context.Context, orgID int64) ([]OrganizationMember, error) {
+	if _, err := s.orgRepo.GetByID(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListMembers(ctx, orgID)
+}
+
+// AdminUpdate 更新组织名称/状态/备注
+func (s *OrganizationService) AdminUpdate(ctx context.Context, orgID int64, input AdminUpdateOrganizationInput) (*Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	statusChanged := false
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, ErrOrganizationNameRequired
+		}
+		org.Name = name
+	}
+	if input.Status != nil && *input.Status != org.Status {
+		if *input.Status != StatusActive && *input.Status != StatusDisabled {
+			return nil, infraerrors.BadRequest("ORGANIZATION_INVALID_STATUS", "status must be active or disabled")
+		}
+		org.Status = *input.Status
+		statusChanged = true
+	}
+	if input.Notes != nil {
+		org.Notes = *input.Notes
+	}
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	if statusChanged {
+		s.invalidateMembersAuthCache(ctx, orgID)
+	}
+	return org, nil
+}
+
+// AdminUpdateBalance 调整组织余额（operation: set/add/subtract）
+func (s *OrganizationService) AdminUpdateBalance(ctx context.Context, orgID int64, amount float64, operation string) (*Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var delta float64
+	switch operation {
+	case "set":
+		delta = amount - org.Balance
+	case "add":
+		delta = amount
+	case "subtract":
+		delta = -amount
+	default:
+		return nil, infraerrors.BadRequest("INVALID_BALANCE_OPERATION", "operation must be one of: set, add, subtract")
+	}
+	if org.Balance+delta < 0 {
+		return nil, fmt.Errorf("balance cannot be negative, current balance: %.2f, requested operation would result in: %.2f", org.Balance, org.Balance+delta)
+	}
+	if delta == 0 {
+		return org, nil
+	}
+
+	if err := s.orgRepo.UpdateBalance(ctx, orgID, delta); err != nil {
+		return nil, err
+	}
+	if s.billingCacheService != nil {
+		if err := s.billingCacheService.InvalidateOrganizationBalance(ctx, orgID); err != nil {
+			log.Printf("invalidate organization balance cache failed: org_id=%d err=%v", orgID, err)
+		}
+	}
+	s.invalidateMembersAuthCache(ctx, orgID)
+	return s.orgRepo.GetByID(ctx, orgID)
+}
+
+// AdminDelete 删除组织：组织 Key 解除归属并禁用
+func (s *OrganizationService) AdminDelete(ctx context.Context, orgID int64) error {
+	members, err := s.orgRepo.ListMembers(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	err = s.withTx(ctx, func(txCtx context.Context) error {
+		if _, err := s.orgRepo.DetachAPIKeys(txCtx, orgID, 0); err != nil {
+			return err
+		}
+		return s.orgRepo.Delete(txCtx, orgID)
+	})
+	if err != nil {
+		return err
+	}
+	if s.billingCacheService != nil {
+		_ = s.billingCacheService.InvalidateOrganizationBalance(ctx, orgID)
+	}
+	if s.authCacheInvalidator != nil {
+		for _, m := range members {
+			s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, m.UserID)
+		}
+	}
+	return nil
+}
+
+// ============================================
+// 内部方法
+// ============================================
+
+func (s *OrganizationService) requireMember(ctx context.Context, orgID, userID int64) (*OrganizationMember, error) {
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationMemberNotFound) {
+			// 非成员统一返回组织不存在，避免泄露组织信息
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *OrganizationService) requireManager(ctx context.Context, orgID, userID int64) (*OrganizationMember, error) {
+	member, err := s.requireMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.CanManage() {
+		return nil, ErrOrganizationPermissionDenied
+	}
+	return member, nil
+}
+
+func (s *OrganizationService) invalidateMembersAuthCache(ctx context.Context, orgID int64) {
+	if s.authCacheInvalidator == nil {
+		return
+	}
+	members, err := s.orgRepo.ListMembers(ctx, orgID)
+	if err != nil {
+		log.Printf("[Organization] list members for cache invalidation failed: org_id=%d err=%v", orgID, err)
+		return
+	}
+	for _, m := range members {
+		s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, m.UserID)
+	}
+}
+
+func (s *OrganizationService) withTx(ctx context.Context, fn func(txCtx context.Context) error) error {
+	if s.entClient == nil || dbent.TxFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	tx, err := s.entClient.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(dbent.NewTxContext(ctx, tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (s *OrganizationService) sendInvitationEmail(ctx context.Context, org *Organization, invitation *OrganizationInvitation, token, frontendBaseURL string) {
+	if s.emailQueueService == nil {
+		log.Printf("[Organization] email queue not configured, invitation %d not sent", invitation.ID)
+		return
+	}
+	siteName := "Sub2API"
+	if s.settingService != nil {
+		siteName = s.settingService.GetSiteName(ctx)
+	}
+	acceptURL := fmt.Sprintf("%s/organizations/accept?token=%s", strings.TrimSuffix(frontendBaseURL, "/"), url.QueryEscape(token))
+	subject := fmt.Sprintf("[%s] 组织邀请：%s", siteName, org.Name)
+	if err := s.emailQueueService.EnqueueEmail(invitation.Email, subject, buildOrganizationInvitationEmailBody(org.Name, invitation.Role, acceptURL, invitation.ExpiresAt)); err != nil {
+		log.Printf("[Organization] enqueue invitation email failed: org_id=%d email=%s err=%v", org.ID, invitation.Email, err)
+	}
+}
+
+func buildOrganizationInvitationEmailBody(orgName, role, acceptURL string, expiresAt time.Time) string {
+	return fmt.Sprintf(
+		"<p>你被邀请以 <b>%s</b> 身份加入组织 <b>%s</b>。</p><p><a href=\"%s\">接受邀请</a></p><p>链接有效期至：%s</p>",
+		html.EscapeString(role),
+		html.EscapeString(orgName),
+		html.EscapeString(acceptURL),
+		expiresAt.Format(time.RFC3339),
+	)
+}
+
+func generateOrganizationInvitationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate invitation token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashOrganizationInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func isValidOrganizationRole(role string) bool {
+	switch role {
+	case OrganizationRoleOwner, OrganizationRoleAdmin, OrganizationRoleMember:
+		return true
+	default:
+		return false
+	}
+}
+
+// canManageMember 所有者可管理所有非所有者成员；管理员只能管理普通成员
+func canManageMember(actor, target *OrganizationMember) bool {
+	if actor == nil || target == nil || target.Role == OrganizationRoleOwner {
+		return false
+	}
+	switch actor.Role {
+	case OrganizationRoleOwner:
+		return true
+	case OrganizationRoleAdmin:
+		return target.Role == OrganizationRoleMember
+	default:
+		return false
+	}
+}
@@ -0,0 +1,343 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+	"github.com/stretchr/testify/require"
+)
+
+type orgRepoStub struct {
+	orgs        map[int64]*Organization
+	members     map[int64]map[int64]*OrganizationMember
+	invitations []*OrganizationInvitation
+	detached    []int64
+	nextID      int64
+}
+
+func newOrgRepoStub() *orgRepoStub {
+	return &orgRepoStub{
+		orgs:    make(map[int64]*Organization),
+		members: make(map[int64]map[int64]*OrganizationMember),
+	}
+}
+
+func (s *orgRepoStub) id() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *orgRepoStub) Create(ctx context.Context, org *Organization) error {
+	org.ID = s.id()
+	clone := *org
+	s.orgs[org.ID] = &clone
+	return nil
+}
+
+func (s *orgRepoStub) GetByID(ctx context.Context, id int64) (*Organization, error) {
+	org, ok := s.orgs[id]
+	if !ok {
+		return nil, ErrOrganizationNotFound
+	}
+	clone := *org
+	clone.MemberCount = len(s.members[id])
+	return &clone, nil
+}
+
+func (s *orgRepoStub) Update(ctx context.Context, org *Organization) error {
+	clone := *org
+	s.orgs[org.ID] = &clone
+	return nil
+}
+
+func (s *orgRepoStub) Delete(ctx context.Context, id int64) error {
+	delete(s.orgs, id)
+	return nil
+}
+
+func (s *orgRepoStub) List(ctx context.Context, params pagination.PaginationParams, search string) ([]Organization, *pagination.PaginationResult, error) {
+	panic("unexpected List call")
+}
+
+func (s *orgRepoStub) ListByUserID(ctx context.Context, userID int64) ([]Organization, error) {
+	panic("unexpected ListByUserID call")
+}
+
+func (s *orgRepoStub) UpdateBalance(ctx context.Context, id int64, amount float64) error {
+	s.orgs[id].Balance += amount
+	return nil
+}
+
+func (s *orgRepoStub) DeductBalance(ctx context.Context, id int64, amount float64) error {
+	return s.UpdateBalance(ctx, id, -amount)
+}
+
+func (s *orgRepoStub) AddMember(ctx context.Context, member *OrganizationMember) error {
+	if s.members[member.OrganizationID] == nil {
+		s.members[member.OrganizationID] = make(map[int64]*OrganizationMember)
+	}
+	if _, ok := s.members[member.OrganizationID][member.UserID]; ok {
+		return ErrOrganizationMemberExists
+	}
+	member.ID = s.id()
+	clone := *member
+	s.members[member.OrganizationID][member.UserID] = &clone
+	return nil
+}
+
+func (s *orgRepoStub) GetMember(ctx context.Context, orgID, userID int64) (*OrganizationMember, error) {
+	m, ok := s.members[orgID][userID]
+	if !ok {
+		return nil, ErrOrganizationMemberNotFound
+	}
+	clone := *m
+	return &clone, nil
+}
+
+func (s *orgRepoStub) ListMembers(ctx context.Context, orgID int64) ([]OrganizationMember, error) {
+	out := make([]OrganizationMember, 0, len(s.members[orgID]))
+	for _, m := range s.members[orgID] {
+		out = append(out, *m)
+	}
+	return out, nil
+}
+
+func (s *orgRepoStub) UpdateMember(ctx context.Context, member *OrganizationMember) error {
+	clone := *member
+	s.members[member.OrganizationID][member.UserID] = &clone
+	return nil
+}
+
+func (s *orgRepoStub) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	if _, ok := s.members[orgID][userID]; !ok {
+		return ErrOrganizationMemberNotFound
+	}
+	delete(s.members[orgID], userID)
+	return nil
+}
+
+func (s *orgRepoStub) IncrementMemberUsage(ctx context.Context, orgID, userID int64, amount float64, windowStart time.Time) error {
+	m := s.members[orgID][userID]
+	if m.MonthlyWindowStart == nil || m.MonthlyWindowStart.Before(windowStart) {
+		m.MonthlyUsageUSD = 0
+		m.MonthlyWindowStart = &windowStart
+	}
+	m.MonthlyUsageUSD += amount
+	return nil
+}
+
+func (s *orgRepoStub) DetachAPIKeys(ctx context.Context, orgID, userID int64) (int, error) {
+	s.detached = append(s.detached, userID)
+	return 1, nil
+}
+
+func (s *orgRepoStub) GetMemberUsage(ctx context.Context, orgID int64, startTime, endTime time.Time) ([]OrganizationMemberUsage, error) {
+	panic("unexpected GetMemberUsage call")
+}
+
+func (s *orgRepoStub) CreateInvitation(ctx context.Context, invitation *OrganizationInvitation) error {
+	invitation.ID = s.id()
+	clone := *invitation
+	s.invitations = append(s.invitations, &clone)
+	return nil
+}
+
+func (s *orgRepoStub) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*OrganizationInvitation, error) {
+	for _, inv := range s.invitations {
+		if inv.TokenHash == tokenHash {
+			clone := *inv
+			return &clone, nil
+		}
+	}
+	return nil, ErrOrganizationInvitationNotFound
+}
+
+func (s *orgRepoStub) GetInvitation(ctx context.Context, id int64) (*OrganizationInvitation, error) {
+	for _, inv := range s.invitations {
+		if inv.ID == id {
+			clone := *inv
+			return &clone, nil
+		}
+	}
+	return nil, ErrOrganizationInvitationNotFound
+}
+
+func (s *orgRepoStub) ListInvitations(ctx context.Context, orgID int64, status string) ([]OrganizationInvitation, error) {
+	panic("unexpected ListInvitations call")
+}
+
+func (s *orgRepoStub) UpdateInvitation(ctx context.Context, invitation *OrganizationInvitation) error {
+	for i, inv := range s.invitations {
+		if inv.ID == invitation.ID {
+			clone := *invitation
+			s.invitations[i] = &clone
+		}
+	}
+	return nil
+}
+
+// orgUserRepoStub 覆盖 GetByEmail，邀请时用于检查是否已是成员
+type orgUserRepoStub struct {
+	*userRepoStub
+}
+
+func (s *orgUserRepoStub) GetByEmail(ctx context.Context, email string) (*User, error) {
+	return nil, ErrUserNotFound
+}
+
+func seedOrganization(t *testing.T, repo *orgRepoStub, balance float64) *Organization {
+	t.Helper()
+	org := &Organization{Name: "team", OwnerUserID: 1, Balance: balance, Status: StatusActive}
+	require.NoError(t, repo.Create(context.Background(), org))
+	require.NoError(t, repo.AddMember(context.Background(), &OrganizationMember{OrganizationID: org.ID, UserID: 1, Role: OrganizationRoleOwner}))
+	return org
+}
+
+func TestOrganizationService_InviteAndAccept(t *testing.T) {
+	ctx := context.Background()
+	repo := newOrgRepoStub()
+	org := seedOrganization(t, repo, 0)
+
+	queue := &EmailQueueService{taskChan: make(chan EmailTask, 1)}
+	users := &orgUserRepoStub{userRepoStub: &userRepoStub{user: &User{ID: 2, Email: "Bob@Example.com"}}}
+	svc := NewOrganizationService(repo, users, nil, nil, nil, queue, nil, nil)
+
+	invitation, err := svc.Invite(ctx, 1, org.ID, " bob@example.com ", "", "https://sub2api.test/")
+	require.NoError(t, err)
+	require.Equal(t, "bob@example.com", invitation.Email)
+	require.Equal(t, OrganizationRoleMember, invitation.Role)
+
+	task := <-queue.taskChan
+	require.Equal(t, "bob@example.com", task.Email)
+	match := regexp.MustCompile(`/organizations/accept\?token=([0-9a-f]{64})`).FindStringSubmatch(task.Body)
+	require.Len(t, match, 2)
+	require.NotEqual(t, match[1], invitation.TokenHash, "只保存令牌哈希")
+
+	_, err = svc.AcceptInvitation(ctx, 2, "bogus")
+	require.ErrorIs(t, err, ErrOrganizationInvitationInvalid)
+
+	joined, err := svc.AcceptInvitation(ctx, 2, match[1])
+	require.NoError(t, err)
+	require.Equal(t, org.ID, joined.ID)
+	member, err := repo.GetMember(ctx, org.ID, 2)
+	require.NoError(t, err)
+	require.Equal(t, OrganizationRoleMember, member.Role)
+
+	// 令牌只能使用一次
+	_, err = svc.AcceptInvitation(ctx, 2, match[1])
+	require.ErrorIs(t, err, ErrOrganizationInvitationInvalid)
+}
+
+func TestOrganizationService_AcceptInvitationEmailMismatch(t *testing.T) {
+	ctx := context.Background()
+	repo := newOrgRepoStub()
+	org := seedOrganization(t, repo, 0)
+
+	queue := &EmailQueueService{taskChan: make(chan EmailTask, 1)}
+	users := &orgUserRepoStub{userRepoStub: &userRepoStub{user: &User{ID: 3, Email: "eve@example.com"}}}
+	svc := NewOrganizationService(repo, users, nil, nil, nil, queue, nil, nil)
+
+	_, err := svc.Invite(ctx, 1, org.ID, "bob@example.com", OrganizationRoleMember, "https://sub2api.test")
+	require.NoError(t, err)
+	task := <-queue.taskChan
+	token := regexp.MustCompile(`token=([0-9a-f]{64})`).FindStringSubmatch(task.Body)[1]
+
+	_, err = svc.AcceptInvitation(ctx, 3, token)
+	require.ErrorIs(t, err, ErrOrganizationInvitationEmail)
+}
+
+func TestOrganizationService_MemberPermissions(t *testing.T) {
+	ctx := context.Background()
+	repo := newOrgRepoStub()
+	org := seedOrganization(t, repo, 0)
+	require.NoError(t, repo.AddMember(ctx, &OrganizationMember{OrganizationID: org.ID, UserID: 2, Role: OrganizationRoleAdmin}))
+	require.NoError(t, repo.AddMember(ctx, &OrganizationMember{OrganizationID: org.ID, UserID: 3, Role: OrganizationRoleAdmin}))
+	require.NoError(t, repo.AddMember(ctx, &OrganizationMember{OrganizationID: org.ID, UserID: 4, Role: OrganizationRoleMember}))
+
+	invalidator := &authCacheInvalidatorStub{}
+	svc := NewOrganizationService(repo, nil, nil, nil, invalidator, nil, nil, nil)
+
+	// 管理员不能授予管理员角色，也不能管理其他管理员
+	admin := OrganizationRoleAdmin
+	_, err := svc.UpdateMember(ctx, 2, org.ID, 4, UpdateOrganizationMemberInput{Role: &admin})
+	require.ErrorIs(t, err, ErrOrganizationPermissionDenied)
+	require.ErrorIs(t, svc.RemoveMember(ctx, 2, org.ID, 3), ErrOrganizationPermissionDenied)
+	require.ErrorIs(t, svc.RemoveMember(ctx, 2, org.ID, 1), ErrOrganizationOwnerRequired)
+
+	limit := 25.0
+	member, err := svc.UpdateMember(ctx, 2, org.ID, 4, UpdateOrganizationMemberInput{MonthlyLimitUSD: &limit})
+	require.NoError(t, err)
+	require.Equal(t, 25.0, *member.MonthlyLimitUSD)
+
+	// 移除成员时解除其组织 Key 并刷新认证缓存
+	require.NoError(t, svc.RemoveMember(ctx, 2, org.ID, 4))
+	require.Equal(t, []int64{4}, repo.detached)
+	require.Equal(t, []int64{4}, invalidator.userIDs)
+
+	// 非成员视为组织不存在
+	_, _, err = svc.Get(ctx, 99, org.ID)
+	require.ErrorIs(t, err, ErrOrganizationNotFound)
+}
+
+func TestOrganizationService_TransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	repo := newOrgRepoStub()
+	org := seedOrganization(t, repo, 0)
+	require.NoError(t, repo.AddMember(ctx, &OrganizationMember{OrganizationID: org.ID, UserID: 2, Role: OrganizationRoleMember}))
+
+	svc := NewOrganizationService(repo, nil, nil, nil, &authCacheInvalidatorStub{}, nil, nil, nil)
+
+	_, err := svc.TransferOwnership(ctx, 2, org.ID, 2)
+	require.ErrorIs(t, err, ErrOrganizationPermissionDenied)
+
+	updated, err := svc.TransferOwnership(ctx, 1, org.ID, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updated.OwnerUserID)
+
+	oldOwner, _ := repo.GetMember(ctx, org.ID, 1)
+	newOwner, _ := repo.GetMember(ctx, org.ID, 2)
+	require.Equal(t, OrganizationRoleAdmin, oldOwner.Role)
+	require.Equal(t, OrganizationRoleOwner, newOwner.Role)
+}
+
+func TestBillingCacheService_OrganizationKeyEligibility(t *testing.T) {
+	ctx := context.Background()
+	repo := newOrgRepoStub()
+	org := seedOrganization(t, repo, 0)
+	limit := 5.0
+	require.NoError(t, repo.AddMember(ctx, &OrganizationMember{OrganizationID: org.ID, UserID: 2, Role: OrganizationRoleMember, MonthlyLimitUSD: &limit}))
+
+	svc := NewBillingCacheService(nil, nil, nil, repo, &config.Config{})
+	t.Cleanup(svc.Stop)
+
+	orgID := org.ID
+	apiKey := &APIKey{ID: 10, UserID: 2, OrganizationID: &orgID, Organization: &Organization{ID: orgID, OwnerUserID: 1, Status: StatusActive}}
+	member := &User{ID: 2, Balance: 100}
+
+	// 组织 Key 只看组织余额，不使用成员个人余额
+	require.ErrorIs(t, svc.CheckBillingEligibility(ctx, member, apiKey, nil, nil, ""), ErrOrganizationInsufficientFunds)
+
+	repo.orgs[orgID].Balance = 10
+	require.NoError(t, svc.CheckBillingEligibility(ctx, member, apiKey, nil, nil, ""))
+
+	require.NoError(t, svc.ChargeOrganizationUsage(ctx, apiKey, member.ID, 5, true))
+	require.InDelta(t, 5.0, repo.orgs[orgID].Balance, 1e-9)
+	require.InDelta(t, 5.0, repo.members[orgID][2].MonthlyUsageUSD, 1e-9)
+	require.Equal(t, timezone.StartOfMonth(time.Now()), *repo.members[orgID][2].MonthlyWindowStart)
+
+	// 达到成员月度上限后拒绝
+	require.ErrorIs(t, svc.CheckBillingEligibility(ctx, member, apiKey, nil, nil, ""), ErrOrganizationMemberLimit)
+
+	// 非成员不能使用组织 Key
+	require.ErrorIs(t, svc.CheckBillingEligibility(ctx, &User{ID: 9}, apiKey, nil, nil, ""), ErrOrganizationPermissionDenied)
+
+	apiKey.Organization.Status = StatusDisabled
+	require.ErrorIs(t, svc.CheckBillingEligibility(ctx, member, apiKey, nil, nil, ""), ErrOrganizationInactive)
+}
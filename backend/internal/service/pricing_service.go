@@ -83,7 +83,7 @@ func NewPricingService(cfg *config.Config, remoteClient PricingRemoteClient) *Pr
 // Initialize 初始化价格服务
 func (s *PricingService) Initialize() error {
 	// 确保数据目录存在
-	if err := os.MkdirAll(s.cfg.Pricing.DataDir, 0755); err != nil {
+	if err := os.MkdirAll(s.cfg.Live().Pricing.DataDir, 0755); err != nil {
 		log.Printf("[Pricing] Failed to create data directory: %v", err)
 	}
 
@@ -112,7 +112,7 @@ func (s *PricingService) Stop() {
 // startUpdateScheduler 启动定时更新调度器
 func (s *PricingService) startUpdateScheduler() {
 	// 定期检查哈希更新
-	hashInterval := time.Duration(s.cfg.Pricing.HashCheckIntervalMinutes) * time.Minute
+	hashInterval := time.Duration(s.cfg.Live().Pricing.HashCheckIntervalMinutes) * time.Minute
 	if hashInterval < time.Minute {
 		hashInterval = 10 * time.Minute
 	}
@@ -155,7 +155,7 @@ func (s *PricingService) checkAndUpdatePricing() error {
 	}
 
 	fileAge := time.Since(info.ModTime())
-	maxAge := time.Duration(s.cfg.Pricing.UpdateIntervalHours) * time.Hour
+	maxAge := time.Duration(s.cfg.Live().Pricing.UpdateIntervalHours) * time.Hour
 
 	if fileAge > maxAge {
 		log.Printf("[Pricing] Local file is %v old, updating...", fileAge.Round(time.Hour))
@@ -180,7 +180,7 @@ func (s *PricingService) syncWithRemote() error {
 	}
 
 	// 如果配置了哈希URL，从远程获取哈希进行比对
-	if s.cfg.Pricing.HashURL != "" {
+	if s.cfg.Live().Pricing.HashURL != "" {
 		remoteHash, err := s.fetchRemoteHash()
 		if err != nil {
 			log.Printf("[Pricing] Failed to fetch remote hash: %v", err)
@@ -202,7 +202,7 @@ func (s *PricingService) syncWithRemote() error {
 	}
 
 	fileAge := time.Since(info.ModTime())
-	maxAge := time.Duration(s.cfg.Pricing.UpdateIntervalHours) * time.Hour
+	maxAge := time.Duration(s.cfg.Live().Pricing.UpdateIntervalHours) * time.Hour
 
 	if fileAge > maxAge {
 		log.Printf("[Pricing] File is %v old, downloading...", fileAge.Round(time.Hour))
@@ -214,7 +214,7 @@ func (s *PricingService) syncWithRemote() error {
 
 // downloadPricingData 从远程下载价格数据
 func (s *PricingService) downloadPricingData() error {
-	remoteURL, err := s.validatePricingURL(s.cfg.Pricing.RemoteURL)
+	remoteURL, err := s.validatePricingURL(s.cfg.Live().Pricing.RemoteURL)
 	if err != nil {
 		return err
 	}
@@ -224,7 +224,7 @@ func (s *PricingService) downloadPricingData() error {
 	defer cancel()
 
 	var expectedHash string
-	if strings.TrimSpace(s.cfg.Pricing.HashURL) != "" {
+	if strings.TrimSpace(s.cfg.Live().Pricing.HashURL) != "" {
 		expectedHash, err = s.fetchRemoteHash()
 		if err != nil {
 			return fmt.Errorf("fetch remote hash: %w", err)
@@ -374,7 +374,7 @@ func (s *PricingService) loadPricingData(filePath string) error {
 
 // useFallbackPricing 使用回退价格文件
 func (s *PricingService) useFallbackPricing() error {
-	fallbackFile := s.cfg.Pricing.FallbackFile
+	fallbackFile := s.cfg.Live().Pricing.FallbackFile
 
 	if _, err := os.Stat(fallbackFile); os.IsNotExist(err) {
 		return fmt.Errorf("fallback file not found: %s", fallbackFile)
@@ -398,7 +398,7 @@ func (s *PricingService) useFallbackPricing() error {
 
 // fetchRemoteHash 从远程获取哈希值
 func (s *PricingService) fetchRemoteHash() (string, error) {
-	hashURL, err := s.validatePricingURL(s.cfg.Pricing.HashURL)
+	hashURL, err := s.validatePricingURL(s.cfg.Live().Pricing.HashURL)
 	if err != nil {
 		return "", err
 	}
@@ -726,12 +726,12 @@ func (s *PricingService) ForceUpdate() error {
 
 // getPricingFilePath 获取价格文件路径
 func (s *PricingService) getPricingFilePath() string {
-	return filepath.Join(s.cfg.Pricing.DataDir, "model_pricing.json")
+	return filepath.Join(s.cfg.Live().Pricing.DataDir, "model_pricing.json")
 }
 
 // getHashFilePath 获取哈希文件路径
 func (s *PricingService) getHashFilePath() string {
-	return filepath.Join(s.cfg.Pricing.DataDir, "model_pricing.sha256")
+	return filepath.Join(s.cfg.Live().Pricing.DataDir, "model_pricing.sha256")
 }
 
 // isNumeric 检查字符串是否为纯数字
@@ -63,8 +63,9 @@ func (s *RateLimitService) SetTokenCacheInvalidator(invalidator TokenCacheInvali
 }
 
 // HandleUpstreamError 处理上游错误响应，标记账号状态
+// requestedModel 为实际发往上游的模型（映射后），429 时优先按该模型确定限流域
 // 返回是否应该停止该账号的调度
-func (s *RateLimitService) HandleUpstreamError(ctx context.Context, account *Account, statusCode int, headers http.Header, responseBody []byte) (shouldDisable bool) {
+func (s *RateLimitService) HandleUpstreamError(ctx context.Context, account *Account, requestedModel string, statusCode int, headers http.Header, responseBody []byte) (shouldDisable bool) {
 	// apikey 类型账号：检查自定义错误码配置
 	// 如果启用且错误码不在列表中，则不处理（不停止调度、不标记限流/过载）
 	customErrorCodesEnabled := account.IsCustomErrorCodesEnabled()
@@ -139,7 +140,7 @@ func (s *RateLimitService) HandleUpstreamError(ctx context.Context, account *Acc
 		s.handleAuthError(ctx, account, msg)
 		shouldDisable = true
 	case 429:
-		s.handle429(ctx, account, headers, responseBody)
+		s.handle429(ctx, account, requestedModel, headers, responseBody)
 		shouldDisable = false
 	case 529:
 		s.handle529(ctx, account)
@@ -342,7 +343,7 @@ func (s *RateLimitService) handleCustomErrorCode(ctx context.Context, account *A
 
 // handle429 处理429限流错误
 // 解析响应头获取重置时间，标记账号为限流状态
-func (s *RateLimitService) handle429(ctx context.Context, account *Account, headers http.Header, responseBody []byte) {
+func (s *RateLimitService) handle429(ctx context.Context, account *Account, requestedModel string, headers http.Header, responseBody []byte) {
 	// 1. OpenAI 平台：优先尝试解析 x-codex-* 响应头（用于 rate_limit_exceeded）
 	if account.Platform == PlatformOpenAI {
 		if resetAt := s.calculateOpenAI429ResetTime(headers); resetAt != nil {
@@ -376,6 +377,9 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 			// 尝试解析 Gemini 格式（用于其他平台）
 			if resetAt := ParseGeminiRateLimitResetTime(responseBody); resetAt != nil {
 				resetTime := time.Unix(*resetAt, 0)
+				if s.trySetModelScopedRateLimit(ctx, account, requestedModel, responseBody, resetTime) {
+					return
+				}
 				if err := s.accountRepo.SetRateLimited(ctx, account.ID, resetTime); err != nil {
 					slog.Warn("rate_limit_set_failed", "account_id", account.ID, "error", err)
 					return
@@ -387,12 +391,7 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 
 		// 没有重置时间，使用默认5分钟
 		resetAt := time.Now().Add(5 * time.Minute)
-		if s.shouldScopeClaudeSonnetRateLimit(account, responseBody) {
-			if err := s.accountRepo.SetModelRateLimit(ctx, account.ID, modelRateLimitScopeClaudeSonnet, resetAt); err != nil {
-				slog.Warn("model_rate_limit_set_failed", "account_id", account.ID, "scope", modelRateLimitScopeClaudeSonnet, "error", err)
-			} else {
-				slog.Info("account_model_rate_limited", "account_id", account.ID, "scope", modelRateLimitScopeClaudeSonnet, "reset_at", resetAt)
-			}
+		if s.trySetModelScopedRateLimit(ctx, account, requestedModel, responseBody, resetAt) {
 			return
 		}
 		slog.Warn("rate_limit_no_reset_time", "account_id", account.ID, "platform", account.Platform, "using_default", "5m")
@@ -407,12 +406,7 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 	if err != nil {
 		slog.Warn("rate_limit_reset_parse_failed", "reset_timestamp", resetTimestamp, "error", err)
 		resetAt := time.Now().Add(5 * time.Minute)
-		if s.shouldScopeClaudeSonnetRateLimit(account, responseBody) {
-			if err := s.accountRepo.SetModelRateLimit(ctx, account.ID, modelRateLimitScopeClaudeSonnet, resetAt); err != nil {
-				slog.Warn("model_rate_limit_set_failed", "account_id", account.ID, "scope", modelRateLimitScopeClaudeSonnet, "error", err)
-			} else {
-				slog.Info("account_model_rate_limited", "account_id", account.ID, "scope", modelRateLimitScopeClaudeSonnet, "reset_at", resetAt)
-			}
+		if s.trySetModelScopedRateLimit(ctx, account, requestedModel, responseBody, resetAt) {
 			return
 		}
 		if err := s.accountRepo.SetRateLimited(ctx, account.ID, resetAt); err != nil {
@@ -423,12 +417,7 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 
 	resetAt := time.Unix(ts, 0)
 
-	if s.shouldScopeClaudeSonnetRateLimit(account, responseBody) {
-		if err := s.accountRepo.SetModelRateLimit(ctx, account.ID, modelRateLimitScopeClaudeSonnet, resetAt); err != nil {
-			slog.Warn("model_rate_limit_set_failed", "account_id", account.ID, "scope", modelRateLimitScopeClaudeSonnet, "error", err)
-			return
-		}
-		slog.Info("account_model_rate_limited", "account_id", account.ID, "scope", modelRateLimitScopeClaudeSonnet, "reset_at", resetAt)
+	if s.trySetModelScopedRateLimit(ctx, account, requestedModel, responseBody, resetAt) {
 		return
 	}
 
@@ -448,15 +437,44 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 	slog.Info("account_rate_limited", "account_id", account.ID, "reset_at", resetAt)
 }
 
-func (s *RateLimitService) shouldScopeClaudeSonnetRateLimit(account *Account, responseBody []byte) bool {
-	if account == nil || account.Platform != PlatformAnthropic {
+// trySetModelScopedRateLimit 若能识别被限流模型所属的限流域，则只限流该域并返回 true；
+// 否则返回 false，由调用方限流整个账号。
+func (s *RateLimitService) trySetModelScopedRateLimit(ctx context.Context, account *Account, requestedModel string, responseBody []byte, resetAt time.Time) bool {
+	scope, models, ok := s.resolveRateLimitedScope(ctx, account, requestedModel, responseBody)
+	if !ok {
 		return false
 	}
-	msg := strings.ToLower(strings.TrimSpace(extractUpstreamErrorMessage(responseBody)))
-	if msg == "" {
-		return false
+	if err := s.accountRepo.SetModelRateLimit(ctx, account.ID, scope, models, resetAt); err != nil {
+		slog.Warn("model_rate_limit_set_failed", "account_id", account.ID, "scope", scope, "error", err)
+		return true
+	}
+	slog.Info("account_model_rate_limited", "account_id", account.ID, "platform", account.Platform, "scope", scope, "reset_at", resetAt)
+	return true
+}
+
+// resolveRateLimitedScope 确定被限流的模型并按平台规则映射到限流域：
+// 优先使用请求的模型，其次解析 429 响应（结构化字段、错误信息）
+func (s *RateLimitService) resolveRateLimitedScope(ctx context.Context, account *Account, requestedModel string, responseBody []byte) (string, []string, bool) {
+	if account == nil {
+		return "", nil, false
+	}
+	rules := DefaultModelRateLimitScopeSettings().Rules
+	if s.settingService != nil {
+		if settings, err := s.settingService.GetModelRateLimitScopeSettings(ctx); err == nil {
+			rules = settings.Rules
+		} else {
+			slog.Warn("model_rate_limit_scopes_load_failed", "error", err)
+		}
+	}
+	if model := strings.TrimSpace(requestedModel); model != "" {
+		if scope, models, ok := resolveModelRateLimitScope(rules, account.Platform, model); ok {
+			return scope, models, true
+		}
+	}
+	if model := extractRateLimitedModel(responseBody); model != "" {
+		return resolveModelRateLimitScope(rules, account.Platform, model)
 	}
-	return strings.Contains(msg, "sonnet")
+	return resolveModelRateLimitScope(rules, account.Platform, extractUpstreamErrorMessage(responseBody))
 }
 
 // calculateOpenAI429ResetTime 从 OpenAI 429 响应头计算正确的重置时间
@@ -564,7 +582,7 @@ func parseOpenAIRateLimitResetTime(body []byte) *int64 {
 // handle529 处理529过载错误
 // 根据配置设置过载冷却时间
 func (s *RateLimitService) handle529(ctx context.Context, account *Account) {
-	cooldownMinutes := s.cfg.RateLimit.OverloadCooldownMinutes
+	cooldownMinutes := s.cfg.Live().RateLimit.OverloadCooldownMinutes
 	if cooldownMinutes <= 0 {
 		cooldownMinutes = 10 // 默认10分钟
 	}
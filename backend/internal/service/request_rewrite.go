@@ -0,0 +1,571 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// 请求改写操作类型
+const (
+	RewriteOpSet           = "set"            // 设置 JSON 字段（覆盖已有值）
+	RewriteOpSetDefault    = "set_default"    // 字段不存在时才设置
+	RewriteOpRemove        = "remove"         // 删除 JSON 字段
+	RewriteOpCap           = "cap"            // 数值字段上限：存在且大于 value 时改为 value
+	RewriteOpSystemPrepend = "system_prepend" // 在系统提示词前插入文本
+	RewriteOpSystemAppend  = "system_append"  // 在系统提示词后追加文本
+	RewriteOpHeaderSet     = "header_set"     // 设置上游请求头
+	RewriteOpHeaderRemove  = "header_remove"  // 删除上游请求头
+)
+
+// 请求体格式：决定系统提示词所在的位置
+const (
+	RequestFormatAnthropic       = "anthropic"        // /v1/messages：system
+	RequestFormatOpenAIResponses = "openai_responses" // /v1/responses：instructions
+	RequestFormatGemini          = "gemini"           // /v1beta/models：systemInstruction.parts
+)
+
+const (
+	maxRewriteRules        = 50
+	maxRewriteOpsPerRule   = 20
+	maxRewritePathLength   = 256
+	maxRewriteValueLength  = 16 * 1024
+	maxRewriteHeaderLength = 4096
+)
+
+// rewriteProtectedPaths 不允许改写的顶层字段：模型与流式标记在改写前已用于路由和计费
+var rewriteProtectedPaths = map[string]struct{}{
+	"model":  {},
+	"stream": {},
+}
+
+// rewriteProtectedHeaders 不允许改写的请求头：认证信息与传输相关头由网关自行管理
+var rewriteProtectedHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"X-Api-Key":           {},
+	"X-Goog-Api-Key":      {},
+	"Cookie":              {},
+	"Host":                {},
+	"Content-Length":      {},
+	"Content-Type":        {},
+	"Content-Encoding":    {},
+	"Transfer-Encoding":   {},
+	"Connection":          {},
+	"Proxy-Authorization": {},
+}
+
+// invalidRewriteRules 返回带具体原因的 400 错误
+func invalidRewriteRules(format string, a ...any) error {
+	return infraerrors.Newf(http.StatusBadRequest, "REWRITE_RULES_INVALID", "invalid rewrite rules: "+format, a...)
+}
+
+// RequestRewriteRule 分组请求改写规则。Platforms/Models 为空表示不限制；
+// Models 支持末尾 * 通配（与模型路由一致），按请求中的原始模型名匹配。
+type RequestRewriteRule struct {
+	Name       string             `json:"name"`
+	Enabled    bool               `json:"enabled"`
+	Platforms  []string           `json:"platforms,omitempty"`
+	Models     []string           `json:"models,omitempty"`
+	Operations []RequestRewriteOp `json:"operations"`
+}
+
+// RequestRewriteOp 单个改写操作。
+// set/set_default/remove/cap 使用 Path（gjson 点分路径，如 "thinking"、"generationConfig.maxOutputTokens"）；
+// system_prepend/system_append 的 Value 为 JSON 字符串；header_set/header_remove 使用 Header，header_set 的 Value 为 JSON 字符串。
+type RequestRewriteOp struct {
+	Type   string          `json:"type"`
+	Path   string          `json:"path,omitempty"`
+	Header string          `json:"header,omitempty"`
+	Value  json.RawMessage `json:"value,omitempty"`
+}
+
+// NormalizeRequestRewriteRules 校验并规范化改写规则；返回 nil 表示不配置
+func NormalizeRequestRewriteRules(rules []RequestRewriteRule) ([]RequestRewriteRule, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if len(rules) > maxRewriteRules {
+		return nil, invalidRewriteRules("at most %d rules", maxRewriteRules)
+	}
+
+	out := make([]RequestRewriteRule, 0, len(rules))
+	for i, rule := range rules {
+		rule.Name = strings.TrimSpace(rule.Name)
+		if rule.Name == "" {
+			rule.Name = "rule_" + strconv.Itoa(i+1)
+		}
+		rule.Platforms = normalizeRewriteList(rule.Platforms, true)
+		for _, platform := range rule.Platforms {
+			switch platform {
+			case PlatformAnthropic, PlatformOpenAI, PlatformGemini, PlatformAntigravity:
+			default:
+				return nil, invalidRewriteRules("rules[%d]: unsupported platform %q", i, platform)
+			}
+		}
+		rule.Models = normalizeRewriteList(rule.Models, false)
+
+		if len(rule.Operations) == 0 {
+			return nil, invalidRewriteRules("rules[%d]: at least one operation is required", i)
+		}
+		if len(rule.Operations) > maxRewriteOpsPerRule {
+			return nil, invalidRewriteRules("rules[%d]: at most %d operations", i, maxRewriteOpsPerRule)
+		}
+		ops := make([]RequestRewriteOp, 0, len(rule.Operations))
+		for j, op := range rule.Operations {
+			normalized, err := normalizeRewriteOp(op)
+			if err != nil {
+				return nil, invalidRewriteRules("rules[%d].operations[%d]: %v", i, j, err)
+			}
+			ops = append(ops, normalized)
+		}
+		rule.Operations = ops
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
+func normalizeRewriteList(values []string, lower bool) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if lower {
+			v = strings.ToLower(v)
+		}
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func normalizeRewriteOp(op RequestRewriteOp) (RequestRewriteOp, error) {
+	op.Type = strings.ToLower(strings.TrimSpace(op.Type))
+	op.Path = strings.TrimSpace(op.Path)
+	op.Header = strings.TrimSpace(op.Header)
+	if len(op.Value) > maxRewriteValueLength {
+		return op, fmt.Errorf("value exceeds %d bytes", maxRewriteValueLength)
+	}
+
+	switch op.Type {
+	case RewriteOpSet, RewriteOpSetDefault, RewriteOpRemove, RewriteOpCap:
+		if err := validateRewritePath(op.Path); err != nil {
+			return op, err
+		}
+		op.Header = ""
+		switch op.Type {
+		case RewriteOpRemove:
+			op.Value = nil
+		case RewriteOpCap:
+			if v := gjson.ParseBytes(op.Value); len(op.Value) == 0 || v.Type != gjson.Number {
+				return op, fmt.Errorf("cap requires a numeric value")
+			}
+		default:
+			if len(op.Value) == 0 || !json.Valid(op.Value) {
+				return op, fmt.Errorf("%s requires a valid JSON value", op.Type)
+			}
+		}
+	case RewriteOpSystemPrepend, RewriteOpSystemAppend:
+		text, err := rewriteStringValue(op.Value)
+		if err != nil {
+			return op, err
+		}
+		if strings.TrimSpace(text) == "" {
+			return op, fmt.Errorf("%s requires non-empty text", op.Type)
+		}
+		op.Path, op.Header = "", ""
+	case RewriteOpHeaderSet, RewriteOpHeaderRemove:
+		if op.Header == "" || len(op.Header) > 128 || strings.ContainsAny(op.Header, " :\r\n\t") {
+			return op, fmt.Errorf("invalid header name %q", op.Header)
+		}
+		op.Header = http.CanonicalHeaderKey(op.Header)
+		if _, protected := rewriteProtectedHeaders[op.Header]; protected {
+			return op, fmt.Errorf("header %q cannot be rewritten", op.Header)
+		}
+		op.Path = ""
+		if op.Type == RewriteOpHeaderRemove {
+			op.Value = nil
+			break
+		}
+		value, err := rewriteStringValue(op.Value)
+		if err != nil {
+			return op, err
+		}
+		if len(value) > maxRewriteHeaderLength || strings.ContainsAny(value, "\r\n") {
+			return op, fmt.Errorf("invalid header value")
+		}
+	default:
+		return op, fmt.Errorf("unsupported operation type %q", op.Type)
+	}
+	return op, nil
+}
+
+// validateRewritePath 仅允许简单点分路径（可含数组下标），禁止 gjson 查询/修饰符语法
+func validateRewritePath(path string) error {
+	if path == "" || len(path) > maxRewritePathLength {
+		return fmt.Errorf("path is required (max %d chars)", maxRewritePathLength)
+	}
+	if strings.ContainsAny(path, "*?#|@!=<>\\") {
+		return fmt.Errorf("path %q contains unsupported characters", path)
+	}
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			return fmt.Errorf("path %q contains an empty segment", path)
+		}
+	}
+	root := strings.SplitN(path, ".", 2)[0]
+	if _, protected := rewriteProtectedPaths[root]; protected {
+		return fmt.Errorf("path %q cannot be rewritten", path)
+	}
+	return nil
+}
+
+func rewriteStringValue(raw json.RawMessage) (string, error) {
+	var text string
+	if len(raw) == 0 || json.Unmarshal(raw, &text) != nil {
+		return "", fmt.Errorf("value must be a JSON string")
+	}
+	return text, nil
+}
+
+// RequestRewriteInput 改写输入
+type RequestRewriteInput struct {
+	Platform string // 分组平台（/antigravity 路由下为强制平台）
+	Format   string // 请求体格式，见 RequestFormat*
+	Model    string // 请求中的原始模型名
+	Body     []byte
+}
+
+// RequestRewriteResult 改写结果
+type RequestRewriteResult struct {
+	Body         []byte            `json:"-"`
+	Changed      bool              `json:"changed"`
+	AppliedRules []string          `json:"applied_rules"`
+	Headers      *RewriteHeaderOps `json:"headers,omitempty"`
+}
+
+// RewriteHeaderOps 需要应用到上游请求的请求头改写，按规则顺序执行
+type RewriteHeaderOps struct {
+	Set    map[string]string `json:"set,omitempty"`
+	Remove []string          `json:"remove,omitempty"`
+}
+
+// Matches 规则是否适用于给定平台和模型
+func (r *RequestRewriteRule) Matches(platform, model string) bool {
+	if !r.Enabled {
+		return false
+	}
+	if len(r.Platforms) > 0 && !containsString(r.Platforms, platform) {
+		return false
+	}
+	if len(r.Models) == 0 {
+		return true
+	}
+	for _, pattern := range r.Models {
+		if matchModelPattern(pattern, model) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// HasActiveRewriteRules 是否存在启用的改写规则
+func HasActiveRewriteRules(rules []RequestRewriteRule) bool {
+	for i := range rules {
+		if rules[i].Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyRequestRewriteRules 按顺序执行匹配的改写规则；单个操作失败时跳过该操作，不影响请求转发
+func ApplyRequestRewriteRules(rules []RequestRewriteRule, input *RequestRewriteInput) *RequestRewriteResult {
+	result := &RequestRewriteResult{Body: input.Body, AppliedRules: []string{}}
+	if len(rules) == 0 || !gjson.ValidBytes(input.Body) {
+		return result
+	}
+
+	body := input.Body
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Matches(input.Platform, input.Model) {
+			continue
+		}
+		applied := false
+		for _, op := range rule.Operations {
+			next, changed, err := applyRewriteOp(body, input.Format, op, result)
+			if err != nil {
+				continue
+			}
+			if changed {
+				body = next
+				result.Changed = true
+			}
+			applied = true
+		}
+		if applied {
+			result.AppliedRules = append(result.AppliedRules, rule.Name)
+		}
+	}
+	result.Body = body
+	return result
+}
+
+func applyRewriteOp(body []byte, format string, op RequestRewriteOp, result *RequestRewriteResult) ([]byte, bool, error) {
+	switch op.Type {
+	case RewriteOpSet:
+		next, err := sjson.SetRawBytes(body, op.Path, op.Value)
+		return next, err == nil, err
+	case RewriteOpSetDefault:
+		if gjson.GetBytes(body, op.Path).Exists() {
+			return body, false, nil
+		}
+		next, err := sjson.SetRawBytes(body, op.Path, op.Value)
+		return next, err == nil, err
+	case RewriteOpRemove:
+		if !gjson.GetBytes(body, op.Path).Exists() {
+			return body, false, nil
+		}
+		next, err := sjson.DeleteBytes(body, op.Path)
+		return next, err == nil, err
+	case RewriteOpCap:
+		current := gjson.GetBytes(body, op.Path)
+		limit := gjson.ParseBytes(op.Value)
+		if current.Type != gjson.Number || current.Float() <= limit.Float() {
+			return body, false, nil
+		}
+		next, err := sjson.SetRawBytes(body, op.Path, []byte(limit.Raw))
+		return next, err == nil, err
+	case RewriteOpSystemPrepend, RewriteOpSystemAppend:
+		text, err := rewriteStringValue(op.Value)
+		if err != nil {
+			return body, false, err
+		}
+		next, err := rewriteSystemPrompt(body, format, text, op.Type == RewriteOpSystemPrepend)
+		return next, err == nil, err
+	case RewriteOpHeaderSet:
+		value, err := rewriteStringValue(op.Value)
+		if err != nil {
+			return body, false, err
+		}
+		headers := result.headerOps()
+		headers.Set[op.Header] = value
+		headers.Remove = removeString(headers.Remove, op.Header)
+		return body, false, nil
+	case RewriteOpHeaderRemove:
+		headers := result.headerOps()
+		delete(headers.Set, op.Header)
+		if !containsString(headers.Remove, op.Header) {
+			headers.Remove = append(headers.Remove, op.Header)
+		}
+		return body, false, nil
+	}
+	return body, false, fmt.Errorf("unsupported operation type %q", op.Type)
+}
+
+func (r *RequestRewriteResult) headerOps() *RewriteHeaderOps {
+	if r.Headers == nil {
+		r.Headers = &RewriteHeaderOps{Set: map[string]string{}}
+	}
+	return r.Headers
+}
+
+func removeString(values []string, target string) []string {
+	out := values[:0]
+	for _, v := range values {
+		if v != target {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// rewriteSystemPrompt 按请求格式在系统提示词前/后插入文本
+func rewriteSystemPrompt(body []byte, format, text string, prepend bool) ([]byte, error) {
+	switch format {
+	case RequestFormatAnthropic:
+		return rewriteTextOrBlocks(body, "system", text, prepend, func(t string) ([]byte, error) {
+			return json.Marshal(map[string]string{"type": "text", "text": t})
+		})
+	case RequestFormatOpenAIResponses:
+		return rewriteTextOrBlocks(body, "instructions", text, prepend, nil)
+	case RequestFormatGemini:
+		key := "systemInstruction"
+		if !gjson.GetBytes(body, key).Exists() && gjson.GetBytes(body, "system_instruction").Exists() {
+			key = "system_instruction"
+		}
+		part, err := json.Marshal(map[string]string{"text": text})
+		if err != nil {
+			return nil, err
+		}
+		parts := gjson.GetBytes(body, key+".parts")
+		if !parts.IsArray() {
+			return sjson.SetRawBytes(body, key, []byte(`{"parts":[`+string(part)+`]}`))
+		}
+		return sjson.SetRawBytes(body, key+".parts", insertRawArrayElement(parts, part, prepend))
+	}
+	return nil, fmt.Errorf("unsupported request format %q", format)
+}
+
+// rewriteTextOrBlocks 处理字符串或内容块数组形式的提示词字段；newBlock 为 nil 表示只支持字符串
+func rewriteTextOrBlocks(body []byte, key, text string, prepend bool, newBlock func(string) ([]byte, error)) ([]byte, error) {
+	current := gjson.GetBytes(body, key)
+	switch {
+	case !current.Exists() || current.Type == gjson.Null || (current.Type == gjson.String && current.String() == ""):
+		return sjson.SetBytes(body, key, text)
+	case current.Type == gjson.String:
+		if prepend {
+			return sjson.SetBytes(body, key, text+"\n\n"+current.String())
+		}
+		return sjson.SetBytes(body, key, current.String()+"\n\n"+text)
+	case current.IsArray() && newBlock != nil:
+		block, err := newBlock(text)
+		if err != nil {
+			return nil, err
+		}
+		return sjson.SetRawBytes(body, key, insertRawArrayElement(current, block, prepend))
+	}
+	return nil, fmt.Errorf("unsupported %s type", key)
+}
+
+func insertRawArrayElement(array gjson.Result, elem []byte, prepend bool) []byte {
+	items := array.Array()
+	raws := make([]string, 0, len(items)+1)
+	if prepend {
+		raws = append(raws, string(elem))
+	}
+	for _, item := range items {
+		raws = append(raws, item.Raw)
+	}
+	if !prepend {
+		raws = append(raws, string(elem))
+	}
+	return []byte("[" + strings.Join(raws, ",") + "]")
+}
+
+// WithRewriteHeaders 将请求头改写挂到 context 上，由 HTTPUpstream 在发送上游请求前应用
+func WithRewriteHeaders(ctx context.Context, headers *RewriteHeaderOps) context.Context {
+	if headers == nil || (len(headers.Set) == 0 && len(headers.Remove) == 0) {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxkey.RewriteHeaders, headers)
+}
+
+// ApplyRewriteHeaders 对即将发送的上游请求应用分组改写规则中的请求头操作
+func ApplyRewriteHeaders(req *http.Request) {
+	if req == nil {
+		return
+	}
+	headers, ok := req.Context().Value(ctxkey.RewriteHeaders).(*RewriteHeaderOps)
+	if !ok || headers == nil {
+		return
+	}
+	for _, name := range headers.Remove {
+		req.Header.Del(name)
+	}
+	for name, value := range headers.Set {
+		req.Header.Set(name, value)
+	}
+}
+
+// RequestFormatForPlatform 返回平台默认的请求体格式（用于改写规则试运行）
+func RequestFormatForPlatform(platform string) string {
+	switch platform {
+	case PlatformOpenAI:
+		return RequestFormatOpenAIResponses
+	case PlatformGemini:
+		return RequestFormatGemini
+	default:
+		return RequestFormatAnthropic
+	}
+}
+
+// RequestRewriteDryRunInput 改写规则试运行输入
+type RequestRewriteDryRunInput struct {
+	Model  string
+	Format string // 为空时按分组平台推断
+	Body   []byte
+	// Rules 非 nil 时使用传入的（未保存的）规则，否则使用分组已保存的规则
+	Rules *[]RequestRewriteRule
+}
+
+// RequestRewriteDryRunResult 试运行结果
+type RequestRewriteDryRunResult struct {
+	Platform     string            `json:"platform"`
+	Format       string            `json:"format"`
+	Model        string            `json:"model"`
+	Changed      bool              `json:"changed"`
+	AppliedRules []string          `json:"applied_rules"`
+	Headers      *RewriteHeaderOps `json:"headers,omitempty"`
+	Body         json.RawMessage   `json:"body"`
+}
+
+// DryRunRequestRewrite 对示例请求执行分组改写规则，返回改写后的请求体（不转发上游）
+func DryRunRequestRewrite(group *Group, input *RequestRewriteDryRunInput) (*RequestRewriteDryRunResult, error) {
+	if !gjson.ValidBytes(input.Body) || !gjson.ParseBytes(input.Body).IsObject() {
+		return nil, infraerrors.BadRequest("REWRITE_DRY_RUN_INVALID_BODY", "body must be a JSON object")
+	}
+
+	rules := group.RewriteRules
+	if input.Rules != nil {
+		normalized, err := NormalizeRequestRewriteRules(*input.Rules)
+		if err != nil {
+			return nil, err
+		}
+		rules = normalized
+	}
+
+	format := strings.TrimSpace(input.Format)
+	if format == "" {
+		format = RequestFormatForPlatform(group.Platform)
+	}
+	switch format {
+	case RequestFormatAnthropic, RequestFormatOpenAIResponses, RequestFormatGemini:
+	default:
+		return nil, infraerrors.BadRequest("REWRITE_DRY_RUN_INVALID_FORMAT", "unsupported format: "+format)
+	}
+
+	model := strings.TrimSpace(input.Model)
+	if model == "" {
+		model = gjson.GetBytes(input.Body, "model").String()
+	}
+
+	result := ApplyRequestRewriteRules(rules, &RequestRewriteInput{
+		Platform: group.Platform,
+		Format:   format,
+		Model:    model,
+		Body:     input.Body,
+	})
+	return &RequestRewriteDryRunResult{
+		Platform:     group.Platform,
+		Format:       format,
+		Model:        model,
+		Changed:      result.Changed,
+		AppliedRules: result.AppliedRules,
+		Headers:      result.Headers,
+		Body:         json.RawMessage(result.Body),
+	}, nil
+}
@@ -0,0 +1,177 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func rewriteOp(typ, path string, value any) RequestRewriteOp {
+	op := RequestRewriteOp{Type: typ, Path: path}
+	if value != nil {
+		raw, _ := json.Marshal(value)
+		op.Value = raw
+	}
+	return op
+}
+
+func TestNormalizeRequestRewriteRules(t *testing.T) {
+	rules, err := NormalizeRequestRewriteRules([]RequestRewriteRule{{
+		Enabled:   true,
+		Platforms: []string{" Anthropic "},
+		Models:    []string{"claude-*", " "},
+		Operations: []RequestRewriteOp{
+			rewriteOp(" CAP ", "max_tokens", 4096),
+			{Type: "header_remove", Header: "anthropic-beta"},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Equal(t, "rule_1", rules[0].Name)
+	require.Equal(t, []string{PlatformAnthropic}, rules[0].Platforms)
+	require.Equal(t, []string{"claude-*"}, rules[0].Models)
+	require.Equal(t, RewriteOpCap, rules[0].Operations[0].Type)
+	require.Equal(t, "Anthropic-Beta", rules[0].Operations[1].Header)
+
+	invalid := []RequestRewriteOp{
+		rewriteOp("set", "model", "x"),
+		rewriteOp("set", "messages.#.content", "x"),
+		rewriteOp("cap", "max_tokens", "big"),
+		rewriteOp("system_prepend", "", 1),
+		{Type: "header_set", Header: "Authorization", Value: json.RawMessage(`"Bearer x"`)},
+		{Type: "header_set", Header: "X-Test", Value: json.RawMessage(`"a\r\nb"`)},
+		rewriteOp("rename", "a", nil),
+	}
+	for _, op := range invalid {
+		_, err := NormalizeRequestRewriteRules([]RequestRewriteRule{{Enabled: true, Operations: []RequestRewriteOp{op}}})
+		require.Error(t, err, "op %+v should be rejected", op)
+	}
+
+	rules, err = NormalizeRequestRewriteRules(nil)
+	require.NoError(t, err)
+	require.Nil(t, rules)
+}
+
+func TestApplyRequestRewriteRules_Anthropic(t *testing.T) {
+	rules, err := NormalizeRequestRewriteRules([]RequestRewriteRule{
+		{
+			Name:    "limits",
+			Enabled: true,
+			Models:  []string{"claude-sonnet-*"},
+			Operations: []RequestRewriteOp{
+				rewriteOp("cap", "max_tokens", 1024),
+				rewriteOp("set", "temperature", 0.2),
+				rewriteOp("remove", "thinking", nil),
+				rewriteOp("set_default", "metadata.user_id", "gw"),
+				rewriteOp("system_prepend", "", "Be brief."),
+				{Type: "header_set", Header: "x-team", Value: json.RawMessage(`"core"`)},
+			},
+		},
+		{
+			Name:       "openai only",
+			Enabled:    true,
+			Platforms:  []string{PlatformOpenAI},
+			Operations: []RequestRewriteOp{rewriteOp("set", "temperature", 1)},
+		},
+		{
+			Name:       "disabled",
+			Operations: []RequestRewriteOp{rewriteOp("set", "top_k", 5)},
+		},
+	})
+	require.NoError(t, err)
+
+	body := []byte(`{"model":"claude-sonnet-4-5","max_tokens":8192,"thinking":{"type":"enabled"},"system":[{"type":"text","text":"You are helpful."}],"messages":[]}`)
+	result := ApplyRequestRewriteRules(rules, &RequestRewriteInput{
+		Platform: PlatformAnthropic,
+		Format:   RequestFormatAnthropic,
+		Model:    "claude-sonnet-4-5",
+		Body:     body,
+	})
+
+	require.True(t, result.Changed)
+	require.Equal(t, []string{"limits"}, result.AppliedRules)
+	require.Equal(t, int64(1024), gjson.GetBytes(result.Body, "max_tokens").Int())
+	require.Equal(t, 0.2, gjson.GetBytes(result.Body, "temperature").Float())
+	require.False(t, gjson.GetBytes(result.Body, "thinking").Exists())
+	require.Equal(t, "gw", gjson.GetBytes(result.Body, "metadata.user_id").String())
+	require.Equal(t, "Be brief.", gjson.GetBytes(result.Body, "system.0.text").String())
+	require.Equal(t, "You are helpful.", gjson.GetBytes(result.Body, "system.1.text").String())
+	require.False(t, gjson.GetBytes(result.Body, "top_k").Exists())
+	require.Equal(t, "core", result.Headers.Set["X-Team"])
+
+	// 不匹配的模型保持原样
+	untouched := ApplyRequestRewriteRules(rules, &RequestRewriteInput{
+		Platform: PlatformAnthropic,
+		Format:   RequestFormatAnthropic,
+		Model:    "claude-opus-4-5",
+		Body:     body,
+	})
+	require.False(t, untouched.Changed)
+	require.Empty(t, untouched.AppliedRules)
+	require.Equal(t, body, untouched.Body)
+}
+
+func TestRewriteSystemPrompt_Formats(t *testing.T) {
+	out, err := rewriteSystemPrompt([]byte(`{"model":"m"}`), RequestFormatAnthropic, "sys", true)
+	require.NoError(t, err)
+	require.Equal(t, "sys", gjson.GetBytes(out, "system").String())
+
+	out, err = rewriteSystemPrompt([]byte(`{"instructions":"base"}`), RequestFormatOpenAIResponses, "extra", false)
+	require.NoError(t, err)
+	require.Equal(t, "base\n\nextra", gjson.GetBytes(out, "instructions").String())
+
+	out, err = rewriteSystemPrompt([]byte(`{"contents":[]}`), RequestFormatGemini, "g", true)
+	require.NoError(t, err)
+	require.Equal(t, "g", gjson.GetBytes(out, "systemInstruction.parts.0.text").String())
+
+	out, err = rewriteSystemPrompt([]byte(`{"system_instruction":{"parts":[{"text":"a"}]}}`), RequestFormatGemini, "b", false)
+	require.NoError(t, err)
+	require.Equal(t, "a", gjson.GetBytes(out, "system_instruction.parts.0.text").String())
+	require.Equal(t, "b", gjson.GetBytes(out, "system_instruction.parts.1.text").String())
+	require.False(t, gjson.GetBytes(out, "systemInstruction").Exists())
+}
+
+func TestApplyRewriteHeaders(t *testing.T) {
+	headers := &RewriteHeaderOps{Set: map[string]string{"X-Team": "core"}, Remove: []string{"Anthropic-Beta"}}
+	req, err := http.NewRequestWithContext(WithRewriteHeaders(context.Background(), headers), http.MethodPost, "https://example.com", nil)
+	require.NoError(t, err)
+	req.Header.Set("anthropic-beta", "a,b")
+
+	ApplyRewriteHeaders(req)
+	require.Equal(t, "core", req.Header.Get("X-Team"))
+	require.Empty(t, req.Header.Get("anthropic-beta"))
+
+	// 未挂载改写时不做任何修改
+	plain, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	plain.Header.Set("anthropic-beta", "a")
+	ApplyRewriteHeaders(plain)
+	require.Equal(t, "a", plain.Header.Get("anthropic-beta"))
+}
+
+func TestDryRunRequestRewrite(t *testing.T) {
+	group := &Group{Platform: PlatformGemini}
+	rules := []RequestRewriteRule{{
+		Enabled:    true,
+		Operations: []RequestRewriteOp{rewriteOp("cap", "generationConfig.maxOutputTokens", 256)},
+	}}
+
+	result, err := DryRunRequestRewrite(group, &RequestRewriteDryRunInput{
+		Model: "gemini-2.5-pro",
+		Body:  []byte(`{"generationConfig":{"maxOutputTokens":1000}}`),
+		Rules: &rules,
+	})
+	require.NoError(t, err)
+	require.Equal(t, RequestFormatGemini, result.Format)
+	require.True(t, result.Changed)
+	require.Equal(t, []string{"rule_1"}, result.AppliedRules)
+	require.Equal(t, int64(256), gjson.GetBytes(result.Body, "generationConfig.maxOutputTokens").Int())
+
+	_, err = DryRunRequestRewrite(group, &RequestRewriteDryRunInput{Body: []byte(`[]`)})
+	require.Error(t, err)
+}
@@ -105,7 +105,8 @@ func (s *SchedulerSnapshotService) ListSchedulableAccounts(ctx context.Context,
 		if err != nil {
 			log.Printf("[Scheduler] cache read failed: bucket=%s err=%v", bucket.String(), err)
 		} else if hit {
-			return derefAccounts(cached), useMixed, nil
+			// 可用时间窗口随时间变化，快照中保留全部账号，读取时按当前时刻剔除窗口外的账号
+			return filterUnavailableAccounts(derefAccounts(cached), time.Now()), useMixed, nil
 		}
 	}
 
@@ -127,7 +128,7 @@ func (s *SchedulerSnapshotService) ListSchedulableAccounts(ctx context.Context,
 		}
 	}
 
-	return accounts, useMixed, nil
+	return filterUnavailableAccounts(accounts, time.Now()), useMixed, nil
 }
 
 func (s *SchedulerSnapshotService) GetAccount(ctx context.Context, accountID int64) (*Account, error) {
@@ -475,17 +476,17 @@ func (s *SchedulerSnapshotService) checkOutboxLag(ctx context.Context, oldest Sc
 	}
 
 	lag := time.Since(oldest.CreatedAt)
-	if lagSeconds := int(lag.Seconds()); lagSeconds >= s.cfg.Gateway.Scheduling.OutboxLagWarnSeconds && s.cfg.Gateway.Scheduling.OutboxLagWarnSeconds > 0 {
+	if lagSeconds := int(lag.Seconds()); lagSeconds >= s.cfg.Live().Gateway.Scheduling.OutboxLagWarnSeconds && s.cfg.Live().Gateway.Scheduling.OutboxLagWarnSeconds > 0 {
 		log.Printf("[Scheduler] outbox lag warning: %ds", lagSeconds)
 	}
 
-	if s.cfg.Gateway.Scheduling.OutboxLagRebuildSeconds > 0 && int(lag.Seconds()) >= s.cfg.Gateway.Scheduling.OutboxLagRebuildSeconds {
+	if s.cfg.Live().Gateway.Scheduling.OutboxLagRebuildSeconds > 0 && int(lag.Seconds()) >= s.cfg.Live().Gateway.Scheduling.OutboxLagRebuildSeconds {
 		s.lagMu.Lock()
 		s.lagFailures++
 		failures := s.lagFailures
 		s.lagMu.Unlock()
 
-		if failures >= s.cfg.Gateway.Scheduling.OutboxLagRebuildFailures {
+		if failures >= s.cfg.Live().Gateway.Scheduling.OutboxLagRebuildFailures {
 			log.Printf("[Scheduler] outbox lag rebuild triggered: lag=%s failures=%d", lag, failures)
 			s.lagMu.Lock()
 			s.lagFailures = 0
@@ -500,7 +501,7 @@ func (s *SchedulerSnapshotService) checkOutboxLag(ctx context.Context, oldest Sc
 		s.lagMu.Unlock()
 	}
 
-	threshold := s.cfg.Gateway.Scheduling.OutboxBacklogRebuildRows
+	threshold := s.cfg.Live().Gateway.Scheduling.OutboxBacklogRebuildRows
 	if threshold <= 0 || s.outboxRepo == nil {
 		return
 	}
@@ -607,7 +608,7 @@ func (s *SchedulerSnapshotService) resolveMode(platform string, hasForcePlatform
 }
 
 func (s *SchedulerSnapshotService) guardFallback(ctx context.Context) error {
-	if s.cfg == nil || s.cfg.Gateway.Scheduling.DbFallbackEnabled {
+	if s.cfg == nil || s.cfg.Live().Gateway.Scheduling.DbFallbackEnabled {
 		if s.fallbackLimit == nil || s.fallbackLimit.Allow() {
 			return nil
 		}
@@ -617,10 +618,10 @@ func (s *SchedulerSnapshotService) guardFallback(ctx context.Context) error {
 }
 
 func (s *SchedulerSnapshotService) withFallbackTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
-	if s.cfg == nil || s.cfg.Gateway.Scheduling.DbFallbackTimeoutSeconds <= 0 {
+	if s.cfg == nil || s.cfg.Live().Gateway.Scheduling.DbFallbackTimeoutSeconds <= 0 {
 		return context.WithCancel(ctx)
 	}
-	timeout := time.Duration(s.cfg.Gateway.Scheduling.DbFallbackTimeoutSeconds) * time.Second
+	timeout := time.Duration(s.cfg.Live().Gateway.Scheduling.DbFallbackTimeoutSeconds) * time.Second
 	if deadline, ok := ctx.Deadline(); ok {
 		remaining := time.Until(deadline)
 		if remaining <= 0 {
@@ -641,7 +642,7 @@ func (s *SchedulerSnapshotService) outboxPollInterval() time.Duration {
 	if s.cfg == nil {
 		return time.Second
 	}
-	sec := s.cfg.Gateway.Scheduling.OutboxPollIntervalSeconds
+	sec := s.cfg.Live().Gateway.Scheduling.OutboxPollIntervalSeconds
 	if sec <= 0 {
 		return time.Second
 	}
@@ -652,7 +653,7 @@ func (s *SchedulerSnapshotService) fullRebuildInterval() time.Duration {
 	if s.cfg == nil {
 		return 0
 	}
-	sec := s.cfg.Gateway.Scheduling.FullRebuildIntervalSeconds
+	sec := s.cfg.Live().Gateway.Scheduling.FullRebuildIntervalSeconds
 	if sec <= 0 {
 		return 0
 	}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// SchedulingSimulationAccount 模拟结果中的账号信息
+type SchedulingSimulationAccount struct {
+	AccountID          int64      `json:"account_id"`
+	Name               string     `json:"name"`
+	Platform           string     `json:"platform"`
+	Type               string     `json:"type"`
+	Priority           int        `json:"priority"`
+	Concurrency        int        `json:"concurrency"`
+	CurrentConcurrency int        `json:"current_concurrency"`
+	WaitingCount       int        `json:"waiting_count"`
+	LoadRate           int        `json:"load_rate"`
+	RateMultiplier     float64    `json:"rate_multiplier"`
+	Weight             int        `json:"weight"`
+	QuotaRemaining     *float64   `json:"quota_remaining,omitempty"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty"`
+}
+
+// SchedulingSimulationExcluded 未进入候选列表的账号及原因
+type SchedulingSimulationExcluded struct {
+	AccountID int64  `json:"account_id"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+}
+
+// SchedulingStrategySimulation 单个策略的模拟结果
+type SchedulingStrategySimulation struct {
+	Strategy string                         `json:"strategy"`
+	Current  bool                           `json:"current"`
+	Selected *SchedulingSimulationAccount   `json:"selected,omitempty"`
+	Ranking  []*SchedulingSimulationAccount `json:"ranking"`
+}
+
+// SchedulingSimulationResult 调度模拟结果
+type SchedulingSimulationResult struct {
+	GroupID         int64                           `json:"group_id"`
+	Platform        string                          `json:"platform"`
+	Model           string                          `json:"model"`
+	CurrentStrategy string                          `json:"current_strategy"`
+	CandidateCount  int                             `json:"candidate_count"`
+	Excluded        []*SchedulingSimulationExcluded `json:"excluded"`
+	Strategies      []*SchedulingStrategySimulation `json:"strategies"`
+}
+
+// 排除原因
+const (
+	schedulingExcludedUnschedulable = "unschedulable"
+	schedulingExcludedOffSchedule   = "outside_availability_schedule"
+	schedulingExcludedDraining      = "draining"
+	schedulingExcludedPlatform      = "platform_not_allowed"
+	schedulingExcludedModelScope    = "model_rate_limited"
+	schedulingExcludedModel         = "model_not_supported"
+	schedulingExcludedWindowCost    = "window_cost_limit"
+	schedulingExcludedFull          = "concurrency_full"
+)
+
+// SimulateScheduling 预览假设请求在各调度策略下的账号选择（Layer 2 负载感知阶段）。
+//
+// 模拟不获取并发槽位、不写粘性会话，也不推进加权轮询状态；
+// 粘性会话与模型路由的优先选择不在模拟范围内。
+func (s *GatewayService) SimulateScheduling(ctx context.Context, group *Group, requestedModel string) (*SchedulingSimulationResult, error) {
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+	ctx = s.withGroupContext(ctx, group)
+	groupID := group.ID
+
+	accounts, useMixed, err := s.listSchedulableAccounts(ctx, &groupID, group.Platform, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SchedulingSimulationResult{
+		GroupID:         group.ID,
+		Platform:        group.Platform,
+		Model:           requestedModel,
+		CurrentStrategy: group.SchedulingStrategy,
+		Excluded:        []*SchedulingSimulationExcluded{},
+		Strategies:      []*SchedulingStrategySimulation{},
+	}
+
+	exclude := func(acc *Account, reason string) {
+		result.Excluded = append(result.Excluded, &SchedulingSimulationExcluded{AccountID: acc.ID, Name: acc.Name, Reason: reason})
+	}
+
+	candidates := make([]*Account, 0, len(accounts))
+	for i := range accounts {
+		acc := &accounts[i]
+		switch {
+		case acc.IsActive() && acc.Schedulable && acc.ScheduleStateAt(time.Now()) == AccountScheduleClosed:
+			exclude(acc, schedulingExcludedOffSchedule)
+		case !acc.IsSchedulable():
+			exclude(acc, schedulingExcludedUnschedulable)
+		case acc.IsDraining():
+			exclude(acc, schedulingExcludedDraining)
+		case !s.isAccountAllowedForPlatform(acc, group.Platform, useMixed):
+			exclude(acc, schedulingExcludedPlatform)
+		case !acc.IsSchedulableForModel(requestedModel):
+			exclude(acc, schedulingExcludedModelScope)
+		case requestedModel != "" && !s.isModelSupportedByAccount(acc, requestedModel):
+			exclude(acc, schedulingExcludedModel)
+		case !s.isAccountSchedulableForWindowCost(ctx, acc, false):
+			exclude(acc, schedulingExcludedWindowCost)
+		default:
+			candidates = append(candidates, acc)
+		}
+	}
+
+	loadMap := map[int64]*AccountLoadInfo{}
+	if s.concurrencyService != nil && len(candidates) > 0 {
+		batch := make([]AccountWithConcurrency, 0, len(candidates))
+		for _, acc := range candidates {
+			batch = append(batch, AccountWithConcurrency{ID: acc.ID, MaxConcurrency: acc.Concurrency})
+		}
+		if m, err := s.concurrencyService.GetAccountsLoadBatch(ctx, batch); err == nil && m != nil {
+			loadMap = m
+		}
+	}
+
+	available := make([]SchedulingCandidate, 0, len(candidates))
+	for _, acc := range candidates {
+		load := loadMap[acc.ID]
+		if load == nil {
+			load = &AccountLoadInfo{AccountID: acc.ID}
+		}
+		if load.LoadRate >= 100 {
+			exclude(acc, schedulingExcludedFull)
+			continue
+		}
+		available = append(available, SchedulingCandidate{
+			Account:        acc,
+			Load:           load,
+			QuotaRemaining: s.accountQuotaRemaining(ctx, acc),
+		})
+	}
+	result.CandidateCount = len(available)
+
+	opts := SchedulingOrderOptions{PreferOAuth: group.Platform == PlatformGemini, DryRun: true}
+	for _, name := range SchedulingStrategyNames() {
+		strategy, _ := defaultSchedulingStrategies.get(name)
+		ordered := append([]SchedulingCandidate(nil), available...)
+		strategy.Order(group.ID, ordered, opts)
+
+		sim := &SchedulingStrategySimulation{
+			Strategy: name,
+			Current:  name == group.SchedulingStrategy,
+			Ranking:  make([]*SchedulingSimulationAccount, 0, len(ordered)),
+		}
+		for _, c := range ordered {
+			sim.Ranking = append(sim.Ranking, schedulingSimulationAccount(c))
+		}
+		if len(sim.Ranking) > 0 {
+			sim.Selected = sim.Ranking[0]
+		}
+		result.Strategies = append(result.Strategies, sim)
+	}
+	return result, nil
+}
+
+func schedulingSimulationAccount(c SchedulingCandidate) *SchedulingSimulationAccount {
+	acc := c.Account
+	out := &SchedulingSimulationAccount{
+		AccountID:      acc.ID,
+		Name:           acc.Name,
+		Platform:       acc.Platform,
+		Type:           acc.Type,
+		Priority:       acc.Priority,
+		Concurrency:    acc.Concurrency,
+		RateMultiplier: acc.BillingRateMultiplier(),
+		Weight:         acc.GetSchedulingWeight(),
+		QuotaRemaining: c.QuotaRemaining,
+		LastUsedAt:     acc.LastUsedAt,
+	}
+	if c.Load != nil {
+		out.CurrentConcurrency = c.Load.CurrentConcurrency
+		out.WaitingCount = c.Load.WaitingCount
+		out.LoadRate = c.Load.LoadRate
+	}
+	return out
+}
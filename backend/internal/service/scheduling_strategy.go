@@ -0,0 +1,401 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// 分组可选的账号调度策略。
+//
+// 所有策略都先按账号优先级（Priority）分层，策略只决定同一优先级内的尝试顺序，
+// 因此管理员配置的优先级始终生效。粘性会话与模型路由的优先逻辑不受策略影响。
+const (
+	// SchedulingStrategyDefault 默认策略：负载率 > 最近最少使用
+	SchedulingStrategyDefault = ""
+	// SchedulingStrategyLeastLoaded 按当前占用槽位数最少优先
+	SchedulingStrategyLeastLoaded = "least_loaded"
+	// SchedulingStrategyWeighted 按账号权重（extra.scheduling_weight）平滑加权轮询
+	SchedulingStrategyWeighted = "weighted"
+	// SchedulingStrategyQuotaAware 按剩余配额（5h 窗口费用 / Codex 用量百分比）最多优先
+	SchedulingStrategyQuotaAware = "quota_aware"
+	// SchedulingStrategyCostAware 按账号计费倍率（RateMultiplier）最低优先
+	SchedulingStrategyCostAware = "cost_aware"
+)
+
+var ErrInvalidSchedulingStrategy = infraerrors.BadRequest("INVALID_SCHEDULING_STRATEGY", "invalid scheduling strategy")
+
+// SchedulingCandidate 参与排序的候选账号及其实时信号
+type SchedulingCandidate struct {
+	Account *Account
+	Load    *AccountLoadInfo
+	// QuotaRemaining 剩余配额比例 [0,1]；nil 表示账号没有可追踪的配额
+	QuotaRemaining *float64
+}
+
+// SchedulingOrderOptions 排序选项
+type SchedulingOrderOptions struct {
+	// PreferOAuth 同等条件下优先 OAuth 账号（gemini 分组）
+	PreferOAuth bool
+	// DryRun 仅预览排序结果，不推进有状态策略（如加权轮询）的内部状态
+	DryRun bool
+}
+
+// SchedulingStrategy 账号调度策略插件
+type SchedulingStrategy interface {
+	Name() string
+	// NeedsQuota 是否需要 QuotaRemaining 信号（避免默认路径上的额外查询）
+	NeedsQuota() bool
+	// Order 就地排序候选账号，结果即槽位尝试顺序。
+	// scope 用于隔离有状态策略的内部状态（通常为分组 ID）。
+	Order(scope int64, candidates []SchedulingCandidate, opts SchedulingOrderOptions)
+}
+
+// NormalizeSchedulingStrategy 校验并规范化策略名称（"default" 等价于空字符串）
+func NormalizeSchedulingStrategy(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "default" {
+		name = SchedulingStrategyDefault
+	}
+	if _, ok := defaultSchedulingStrategies.get(name); !ok {
+		return "", ErrInvalidSchedulingStrategy
+	}
+	return name, nil
+}
+
+// SchedulingStrategyNames 返回所有已注册策略名称（默认策略为空字符串，排在首位）
+func SchedulingStrategyNames() []string {
+	return defaultSchedulingStrategies.names()
+}
+
+// resolveSchedulingStrategy 获取分组使用的策略；未知策略回退到默认策略
+func resolveSchedulingStrategy(group *Group) SchedulingStrategy {
+	name := ""
+	if group != nil {
+		name = group.SchedulingStrategy
+	}
+	if strategy, ok := defaultSchedulingStrategies.get(name); ok {
+		return strategy
+	}
+	strategy, _ := defaultSchedulingStrategies.get(SchedulingStrategyDefault)
+	return strategy
+}
+
+type schedulingStrategyRegistry struct {
+	order      []string
+	strategies map[string]SchedulingStrategy
+}
+
+func newSchedulingStrategyRegistry(strategies ...SchedulingStrategy) *schedulingStrategyRegistry {
+	r := &schedulingStrategyRegistry{strategies: make(map[string]SchedulingStrategy, len(strategies))}
+	for _, st := range strategies {
+		r.order = append(r.order, st.Name())
+		r.strategies[st.Name()] = st
+	}
+	return r
+}
+
+func (r *schedulingStrategyRegistry) get(name string) (SchedulingStrategy, bool) {
+	st, ok := r.strategies[name]
+	return st, ok
+}
+
+func (r *schedulingStrategyRegistry) names() []string {
+	return append([]string(nil), r.order...)
+}
+
+var defaultSchedulingStrategies = newSchedulingStrategyRegistry(
+	defaultSchedulingStrategy{},
+	leastLoadedSchedulingStrategy{},
+	newWeightedSchedulingStrategy(),
+	quotaAwareSchedulingStrategy{},
+	costAwareSchedulingStrategy{},
+)
+
+// ---- 通用比较 ----
+
+func candidateLoadRate(c SchedulingCandidate) int {
+	if c.Load == nil {
+		return 0
+	}
+	return c.Load.LoadRate
+}
+
+func candidateInUse(c SchedulingCandidate) int {
+	if c.Load == nil {
+		return 0
+	}
+	return c.Load.CurrentConcurrency
+}
+
+func candidateQuota(c SchedulingCandidate) float64 {
+	if c.QuotaRemaining == nil {
+		return 1
+	}
+	return *c.QuotaRemaining
+}
+
+// lessByLastUsed 最近最少使用优先（从未使用的账号最优先）
+func lessByLastUsed(a, b *Account, preferOAuth bool) bool {
+	switch {
+	case a.LastUsedAt == nil && b.LastUsedAt != nil:
+		return true
+	case a.LastUsedAt != nil && b.LastUsedAt == nil:
+		return false
+	case a.LastUsedAt == nil && b.LastUsedAt == nil:
+		if preferOAuth && a.Type != b.Type {
+			return a.Type == AccountTypeOAuth
+		}
+		return false
+	default:
+		return a.LastUsedAt.Before(*b.LastUsedAt)
+	}
+}
+
+// sortCandidatesWithinPriority 按优先级分层，层内使用 less 排序
+func sortCandidatesWithinPriority(candidates []SchedulingCandidate, less func(a, b SchedulingCandidate) bool) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Account.Priority != b.Account.Priority {
+			return a.Account.Priority < b.Account.Priority
+		}
+		return less(a, b)
+	})
+}
+
+// ---- default ----
+
+type defaultSchedulingStrategy struct{}
+
+func (defaultSchedulingStrategy) Name() string     { return SchedulingStrategyDefault }
+func (defaultSchedulingStrategy) NeedsQuota() bool { return false }
+
+func (defaultSchedulingStrategy) Order(_ int64, candidates []SchedulingCandidate, opts SchedulingOrderOptions) {
+	sortCandidatesWithinPriority(candidates, func(a, b SchedulingCandidate) bool {
+		if la, lb := candidateLoadRate(a), candidateLoadRate(b); la != lb {
+			return la < lb
+		}
+		return lessByLastUsed(a.Account, b.Account, opts.PreferOAuth)
+	})
+}
+
+// ---- least_loaded ----
+
+type leastLoadedSchedulingStrategy struct{}
+
+func (leastLoadedSchedulingStrategy) Name() string     { return SchedulingStrategyLeastLoaded }
+func (leastLoadedSchedulingStrategy) NeedsQuota() bool { return false }
+
+func (leastLoadedSchedulingStrategy) Order(_ int64, candidates []SchedulingCandidate, opts SchedulingOrderOptions) {
+	sortCandidatesWithinPriority(candidates, func(a, b SchedulingCandidate) bool {
+		if ua, ub := candidateInUse(a), candidateInUse(b); ua != ub {
+			return ua < ub
+		}
+		if la, lb := candidateLoadRate(a), candidateLoadRate(b); la != lb {
+			return la < lb
+		}
+		return lessByLastUsed(a.Account, b.Account, opts.PreferOAuth)
+	})
+}
+
+// ---- quota_aware ----
+
+type quotaAwareSchedulingStrategy struct{}
+
+func (quotaAwareSchedulingStrategy) Name() string     { return SchedulingStrategyQuotaAware }
+func (quotaAwareSchedulingStrategy) NeedsQuota() bool { return true }
+
+func (quotaAwareSchedulingStrategy) Order(_ int64, candidates []SchedulingCandidate, opts SchedulingOrderOptions) {
+	sortCandidatesWithinPriority(candidates, func(a, b SchedulingCandidate) bool {
+		if qa, qb := candidateQuota(a), candidateQuota(b); qa != qb {
+			return qa > qb
+		}
+		if la, lb := candidateLoadRate(a), candidateLoadRate(b); la != lb {
+			return la < lb
+		}
+		return lessByLastUsed(a.Account, b.Account, opts.PreferOAuth)
+	})
+}
+
+// ---- cost_aware ----
+
+type costAwareSchedulingStrategy struct{}
+
+func (costAwareSchedulingStrategy) Name() string     { return SchedulingStrategyCostAware }
+func (costAwareSchedulingStrategy) NeedsQuota() bool { return false }
+
+func (costAwareSchedulingStrategy) Order(_ int64, candidates []SchedulingCandidate, opts SchedulingOrderOptions) {
+	sortCandidatesWithinPriority(candidates, func(a, b SchedulingCandidate) bool {
+		if ra, rb := a.Account.BillingRateMultiplier(), b.Account.BillingRateMultiplier(); ra != rb {
+			return ra < rb
+		}
+		if la, lb := candidateLoadRate(a), candidateLoadRate(b); la != lb {
+			return la < lb
+		}
+		return lessByLastUsed(a.Account, b.Account, opts.PreferOAuth)
+	})
+}
+
+// ---- weighted ----
+
+// weightedSchedulingStrategy 平滑加权轮询（Nginx SWRR）。
+// 每次选择在最高优先级层内挑出一个账号放在首位，其余账号按默认策略排序作为后备。
+// 状态仅保存在本实例内存中，多实例部署时各实例独立轮询。
+type weightedSchedulingStrategy struct {
+	mu    sync.Mutex
+	state map[int64]map[int64]int // scope -> accountID -> current weight
+}
+
+const weightedSchedulingMaxStateEntries = 10000
+
+func newWeightedSchedulingStrategy() *weightedSchedulingStrategy {
+	return &weightedSchedulingStrategy{state: make(map[int64]map[int64]int)}
+}
+
+func (*weightedSchedulingStrategy) Name() string     { return SchedulingStrategyWeighted }
+func (*weightedSchedulingStrategy) NeedsQuota() bool { return false }
+
+func (w *weightedSchedulingStrategy) Order(scope int64, candidates []SchedulingCandidate, opts SchedulingOrderOptions) {
+	defaultSchedulingStrategy{}.Order(scope, candidates, opts)
+	if len(candidates) < 2 {
+		return
+	}
+
+	// 最高优先级层
+	tierEnd := 1
+	for tierEnd < len(candidates) && candidates[tierEnd].Account.Priority == candidates[0].Account.Priority {
+		tierEnd++
+	}
+	if tierEnd < 2 {
+		return
+	}
+
+	w.mu.Lock()
+	current := w.state[scope]
+	if current == nil || len(current) > weightedSchedulingMaxStateEntries {
+		current = make(map[int64]int)
+		w.state[scope] = current
+	}
+	if opts.DryRun {
+		preview := make(map[int64]int, len(current))
+		for k, v := range current {
+			preview[k] = v
+		}
+		current = preview
+	}
+
+	total := 0
+	pick := -1
+	for i := 0; i < tierEnd; i++ {
+		acc := candidates[i].Account
+		weight := acc.GetSchedulingWeight()
+		total += weight
+		current[acc.ID] += weight
+		if pick < 0 || current[acc.ID] > current[candidates[pick].Account.ID] {
+			pick = i
+		}
+	}
+	current[candidates[pick].Account.ID] -= total
+	w.mu.Unlock()
+
+	if pick > 0 {
+		chosen := candidates[pick]
+		copy(candidates[1:pick+1], candidates[0:pick])
+		candidates[0] = chosen
+	}
+}
+
+// ---- quota signals ----
+
+// accountQuotaRemaining 计算账号剩余配额比例 [0,1]。
+//   - Anthropic OAuth/SetupToken 且配置了 window_cost_limit：1 - 当前 5h 窗口费用 / 阈值
+//   - OpenAI Codex：1 - max(5h 用量%, 7d 用量%)（已过重置时间的窗口视为 0%）
+//
+// 其他账号返回 nil（无可追踪配额）。
+func (s *GatewayService) accountQuotaRemaining(ctx context.Context, account *Account) *float64 {
+	if account == nil {
+		return nil
+	}
+	if account.IsAnthropicOAuthOrSetupToken() {
+		limit := account.GetWindowCostLimit()
+		if limit <= 0 {
+			return nil
+		}
+		cost, ok := s.getAccountWindowCost(ctx, account)
+		if !ok {
+			return nil
+		}
+		remaining := clampUnit(1 - cost/limit)
+		return &remaining
+	}
+	return codexQuotaRemaining(account, time.Now())
+}
+
+func codexQuotaRemaining(account *Account, now time.Time) *float64 {
+	if account == nil || account.Extra == nil {
+		return nil
+	}
+	var updatedAt time.Time
+	if raw, ok := account.Extra["codex_usage_updated_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			updatedAt = t
+		}
+	}
+
+	used := -1.0
+	for _, window := range []string{"5h", "7d"} {
+		v, ok := account.Extra["codex_"+window+"_used_percent"]
+		if !ok {
+			continue
+		}
+		percent := parseExtraFloat64(v)
+		if !updatedAt.IsZero() {
+			if reset, ok := account.Extra["codex_"+window+"_reset_after_seconds"]; ok {
+				resetAt := updatedAt.Add(time.Duration(parseExtraInt(reset)) * time.Second)
+				if !now.Before(resetAt) {
+					percent = 0
+				}
+			}
+		}
+		if percent > used {
+			used = percent
+		}
+	}
+	if used < 0 {
+		return nil
+	}
+	remaining := clampUnit(1 - used/100)
+	return &remaining
+}
+
+func clampUnit(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+// orderSchedulingCandidates 按分组调度策略排序候选账号
+func (s *GatewayService) orderSchedulingCandidates(ctx context.Context, group *Group, candidates []SchedulingCandidate, preferOAuth bool) {
+	strategy := resolveSchedulingStrategy(group)
+	if strategy.NeedsQuota() {
+		for i := range candidates {
+			candidates[i].QuotaRemaining = s.accountQuotaRemaining(ctx, candidates[i].Account)
+		}
+	}
+	strategy.Order(schedulingScope(group), candidates, SchedulingOrderOptions{PreferOAuth: preferOAuth})
+}
+
+func schedulingScope(group *Group) int64 {
+	if group == nil {
+		return 0
+	}
+	return group.ID
+}
@@ -0,0 +1,161 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func schedulingTestCandidate(id int64, priority, inUse, loadRate int) SchedulingCandidate {
+	return SchedulingCandidate{
+		Account: &Account{ID: id, Priority: priority, Concurrency: 10},
+		Load:    &AccountLoadInfo{AccountID: id, CurrentConcurrency: inUse, LoadRate: loadRate},
+	}
+}
+
+func schedulingCandidateIDs(candidates []SchedulingCandidate) []int64 {
+	ids := make([]int64, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.Account.ID)
+	}
+	return ids
+}
+
+func TestNormalizeSchedulingStrategy(t *testing.T) {
+	for input, want := range map[string]string{
+		"":             SchedulingStrategyDefault,
+		"default":      SchedulingStrategyDefault,
+		" Weighted ":   SchedulingStrategyWeighted,
+		"least_loaded": SchedulingStrategyLeastLoaded,
+		"quota_aware":  SchedulingStrategyQuotaAware,
+		"cost_aware":   SchedulingStrategyCostAware,
+	} {
+		got, err := NormalizeSchedulingStrategy(input)
+		require.NoError(t, err, input)
+		require.Equal(t, want, got, input)
+	}
+
+	_, err := NormalizeSchedulingStrategy("random")
+	require.ErrorIs(t, err, ErrInvalidSchedulingStrategy)
+}
+
+func TestSchedulingStrategies_PriorityFirst(t *testing.T) {
+	for _, name := range SchedulingStrategyNames() {
+		strategy, ok := defaultSchedulingStrategies.get(name)
+		require.True(t, ok)
+
+		candidates := []SchedulingCandidate{
+			schedulingTestCandidate(1, 2, 0, 0),
+			schedulingTestCandidate(2, 1, 9, 90),
+		}
+		strategy.Order(0, candidates, SchedulingOrderOptions{DryRun: true})
+		require.Equal(t, int64(2), candidates[0].Account.ID, name)
+	}
+}
+
+func TestSchedulingStrategies_Ordering(t *testing.T) {
+	build := func() []SchedulingCandidate {
+		a := schedulingTestCandidate(1, 1, 2, 10) // 低负载率、较多并发
+		b := schedulingTestCandidate(2, 1, 1, 50) // 高负载率、较少并发
+		c := schedulingTestCandidate(3, 1, 3, 30)
+		low, mid, high := 0.2, 0.5, 0.9
+		a.QuotaRemaining = &low
+		b.QuotaRemaining = &high
+		c.QuotaRemaining = &mid
+		cheap, pricey := 0.5, 2.0
+		a.Account.RateMultiplier = &pricey
+		c.Account.RateMultiplier = &cheap
+		return []SchedulingCandidate{a, b, c}
+	}
+
+	cases := map[string][]int64{
+		SchedulingStrategyDefault:     {1, 3, 2},
+		SchedulingStrategyLeastLoaded: {2, 1, 3},
+		SchedulingStrategyQuotaAware:  {2, 3, 1},
+		SchedulingStrategyCostAware:   {3, 2, 1},
+	}
+	for name, want := range cases {
+		strategy, ok := defaultSchedulingStrategies.get(name)
+		require.True(t, ok)
+		candidates := build()
+		strategy.Order(0, candidates, SchedulingOrderOptions{})
+		require.Equal(t, want, schedulingCandidateIDs(candidates), name)
+	}
+}
+
+func TestWeightedSchedulingStrategy_Distribution(t *testing.T) {
+	w := newWeightedSchedulingStrategy()
+	build := func() []SchedulingCandidate {
+		a := schedulingTestCandidate(1, 1, 0, 0)
+		a.Account.Extra = map[string]any{"scheduling_weight": 3}
+		b := schedulingTestCandidate(2, 1, 0, 0)
+		c := schedulingTestCandidate(3, 2, 0, 0)
+		c.Account.Extra = map[string]any{"scheduling_weight": 50}
+		return []SchedulingCandidate{a, b, c}
+	}
+
+	counts := map[int64]int{}
+	for i := 0; i < 8; i++ {
+		candidates := build()
+		w.Order(7, candidates, SchedulingOrderOptions{})
+		counts[candidates[0].Account.ID]++
+	}
+	// 低优先级账号即使权重更高也不参与首选
+	require.Equal(t, map[int64]int{1: 6, 2: 2}, counts)
+}
+
+func TestWeightedSchedulingStrategy_DryRunDoesNotAdvance(t *testing.T) {
+	w := newWeightedSchedulingStrategy()
+	build := func() []SchedulingCandidate {
+		return []SchedulingCandidate{
+			schedulingTestCandidate(1, 1, 0, 0),
+			schedulingTestCandidate(2, 1, 0, 0),
+		}
+	}
+
+	first := build()
+	w.Order(1, first, SchedulingOrderOptions{DryRun: true})
+	again := build()
+	w.Order(1, again, SchedulingOrderOptions{DryRun: true})
+	require.Equal(t, first[0].Account.ID, again[0].Account.ID)
+
+	live := build()
+	w.Order(1, live, SchedulingOrderOptions{})
+	require.Equal(t, first[0].Account.ID, live[0].Account.ID)
+
+	next := build()
+	w.Order(1, next, SchedulingOrderOptions{})
+	require.NotEqual(t, live[0].Account.ID, next[0].Account.ID)
+}
+
+func TestAccountGetSchedulingWeight(t *testing.T) {
+	require.Equal(t, 1, (&Account{}).GetSchedulingWeight())
+	require.Equal(t, 1, (&Account{Extra: map[string]any{"scheduling_weight": 0}}).GetSchedulingWeight())
+	require.Equal(t, 5, (&Account{Extra: map[string]any{"scheduling_weight": float64(5)}}).GetSchedulingWeight())
+	require.Equal(t, 100, (&Account{Extra: map[string]any{"scheduling_weight": 500}}).GetSchedulingWeight())
+}
+
+func TestCodexQuotaRemaining(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	require.Nil(t, codexQuotaRemaining(&Account{}, now))
+
+	acc := &Account{Extra: map[string]any{
+		"codex_5h_used_percent":        40.0,
+		"codex_7d_used_percent":        70.0,
+		"codex_5h_reset_after_seconds": 600,
+		"codex_7d_reset_after_seconds": 3600,
+		"codex_usage_updated_at":       now.Add(-30 * time.Minute).Format(time.RFC3339),
+	}}
+	got := codexQuotaRemaining(acc, now)
+	require.NotNil(t, got)
+	require.InDelta(t, 0.3, *got, 1e-9)
+
+	// 两个窗口均已过重置时间
+	got = codexQuotaRemaining(acc, now.Add(2*time.Hour))
+	require.NotNil(t, got)
+	require.InDelta(t, 1.0, *got, 1e-9)
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"strings"
+)
+
+// SecretCipher 敏感字段静态加密（信封加密）接口，实现见 repository/envelope_cipher.go。
+// 密文自带主密钥 ID：主密钥轮换后旧密文仍可解密，并由 SecretReencryptService 在后台改用新密钥重新加密。
+// 解密只发生在仓储层，服务层拿到的始终是明文，管理接口输出前再统一脱敏。
+type SecretCipher interface {
+	// Enabled 是否配置了用于加密新数据的主密钥
+	Enabled() bool
+	// ActiveKeyID 当前主密钥 ID（未启用时为空）
+	ActiveKeyID() string
+	// Encrypt 使用当前主密钥加密；未启用时原样返回
+	Encrypt(plaintext string) (string, error)
+	// Decrypt 解密密文；非密文（历史明文数据）原样返回
+	Decrypt(value string) (string, error)
+	// NeedsReencrypt 启用加密时，明文或非当前主密钥加密的密文需要重新加密
+	NeedsReencrypt(value string) bool
+}
+
+// EncryptedSecretPrefix 密文前缀，格式：enc:v1:<key_id>:<wrapped_dek>:<ciphertext>
+const EncryptedSecretPrefix = "enc:v1:"
+
+// IsEncryptedSecret 判断值是否为信封加密后的密文
+func IsEncryptedSecret(value string) bool {
+	return strings.HasPrefix(value, EncryptedSecretPrefix)
+}
+
+// EncryptedSecretKeyID 返回密文使用的主密钥 ID，非密文返回空字符串
+func EncryptedSecretKeyID(value string) string {
+	if !IsEncryptedSecret(value) {
+		return ""
+	}
+	rest := strings.TrimPrefix(value, EncryptedSecretPrefix)
+	if idx := strings.IndexByte(rest, ':'); idx > 0 {
+		return rest[:idx]
+	}
+	return ""
+}
+
+// credentialSecretFields 账号凭证中需要加密存储、并在管理接口中脱敏的字段
+var credentialSecretFields = map[string]struct{}{
+	"access_token":  {},
+	"refresh_token": {},
+	"id_token":      {},
+	"api_key":       {},
+	"session_key":   {},
+	"client_secret": {},
+	"private_key":   {},
+}
+
+// encryptedSettingKeys 需要加密存储的系统设置项
+var encryptedSettingKeys = map[string]struct{}{
+	SettingKeySMTPPassword:               {},
+	SettingKeyLinuxDoConnectClientSecret: {},
+}
+
+// IsCredentialSecretField 判断凭证字段是否为敏感字段
+func IsCredentialSecretField(key string) bool {
+	_, ok := credentialSecretFields[key]
+	return ok
+}
+
+// IsEncryptedSettingKey 判断系统设置项是否需要加密存储
+func IsEncryptedSettingKey(key string) bool {
+	_, ok := encryptedSettingKeys[key]
+	return ok
+}
+
+// EncryptCredentialSecrets 返回敏感字段已加密的凭证副本（非敏感字段如 base_url、model_mapping 保持明文，
+// 以便 JSONB 合并更新继续可用）。cipher 为 nil 或未启用时原样返回。
+func EncryptCredentialSecrets(cipher SecretCipher, credentials map[string]any) (map[string]any, error) {
+	if cipher == nil || !cipher.Enabled() || len(credentials) == 0 {
+		return credentials, nil
+	}
+	out := make(map[string]any, len(credentials))
+	for k, v := range credentials {
+		s, ok := v.(string)
+		if !ok || s == "" || !IsCredentialSecretField(k) || IsEncryptedSecret(s) {
+			out[k] = v
+			continue
+		}
+		encrypted, err := cipher.Encrypt(s)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = encrypted
+	}
+	return out, nil
+}
+
+// DecryptCredentialSecrets 原地解密凭证中的密文字段。cipher 为 nil 时不做处理。
+func DecryptCredentialSecrets(cipher SecretCipher, credentials map[string]any) error {
+	if cipher == nil {
+		return nil
+	}
+	for k, v := range credentials {
+		s, ok := v.(string)
+		if !ok || !IsEncryptedSecret(s) {
+			continue
+		}
+		plain, err := cipher.Decrypt(s)
+		if err != nil {
+			return err
+		}
+		credentials[k] = plain
+	}
+	return nil
+}
+
+// CredentialsNeedReencrypt 判断凭证中是否有敏感字段需要（重新）加密
+func CredentialsNeedReencrypt(cipher SecretCipher, credentials map[string]any) bool {
+	if cipher == nil {
+		return false
+	}
+	for k, v := range credentials {
+		s, ok := v.(string)
+		if !ok || s == "" {
+			continue
+		}
+		if IsCredentialSecretField(k) || IsEncryptedSecret(s) {
+			if cipher.NeedsReencrypt(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MaskSecretValue 脱敏显示：只保留末 4 位
+func MaskSecretValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	const mask = "********"
+	if len(value) <= 12 {
+		return mask
+	}
+	return mask + value[len(value)-4:]
+}
+
+// MaskCredentialSecrets 返回敏感字段脱敏后的凭证副本，用于管理接口输出
+func MaskCredentialSecrets(credentials map[string]any) map[string]any {
+	if credentials == nil {
+		return nil
+	}
+	out := make(map[string]any, len(credentials))
+	for k, v := range credentials {
+		if s, ok := v.(string); ok && IsCredentialSecretField(k) {
+			out[k] = MaskSecretValue(s)
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// RestoreMaskedCredentials 管理端回传的凭证中，与脱敏值相同的敏感字段还原为原值，
+// 使前端"原样回传"编辑表单时不会把密钥覆盖成掩码。
+func RestoreMaskedCredentials(next, current map[string]any) map[string]any {
+	if len(next) == 0 || len(current) == 0 {
+		return next
+	}
+	for k, v := range next {
+		s, ok := v.(string)
+		if !ok || !IsCredentialSecretField(k) {
+			continue
+		}
+		old, ok := current[k].(string)
+		if ok && old != "" && s == MaskSecretValue(old) {
+			next[k] = old
+		}
+	}
+	return next
+}
+
+// StripMaskedCredentials 批量更新时无法逐账号还原掩码，直接丢弃仍为掩码形式的敏感字段
+func StripMaskedCredentials(credentials map[string]any) map[string]any {
+	for k, v := range credentials {
+		if s, ok := v.(string); ok && IsCredentialSecretField(k) && strings.HasPrefix(s, "********") {
+			delete(credentials, k)
+		}
+	}
+	return credentials
+}
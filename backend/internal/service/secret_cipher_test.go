@@ -0,0 +1,75 @@
+//go:build unit
+
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// prefixCipher 测试用 cipher：以固定前缀模拟密文
+type prefixCipher struct{ keyID string }
+
+func (c prefixCipher) Enabled() bool       { return c.keyID != "" }
+func (c prefixCipher) ActiveKeyID() string { return c.keyID }
+func (c prefixCipher) Encrypt(plaintext string) (string, error) {
+	return EncryptedSecretPrefix + c.keyID + ":x:" + plaintext, nil
+}
+func (c prefixCipher) Decrypt(value string) (string, error) {
+	if !IsEncryptedSecret(value) {
+		return value, nil
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, EncryptedSecretPrefix), ":", 3)
+	return parts[2], nil
+}
+func (c prefixCipher) NeedsReencrypt(value string) bool {
+	return c.Enabled() && value != "" && EncryptedSecretKeyID(value) != c.keyID
+}
+
+func TestEncryptCredentialSecrets(t *testing.T) {
+	c := prefixCipher{keyID: "k1"}
+	creds := map[string]any{
+		"access_token":  "at-123",
+		"refresh_token": "rt-456",
+		"base_url":      "https://api.example.com",
+		"model_mapping": map[string]any{"a": "b"},
+	}
+
+	encrypted, err := EncryptCredentialSecrets(c, creds)
+	require.NoError(t, err)
+	require.Equal(t, "enc:v1:k1:x:at-123", encrypted["access_token"])
+	require.Equal(t, "https://api.example.com", encrypted["base_url"])
+	require.Equal(t, "at-123", creds["access_token"], "输入不应被修改")
+	require.False(t, CredentialsNeedReencrypt(c, encrypted))
+	require.True(t, CredentialsNeedReencrypt(prefixCipher{keyID: "k2"}, encrypted))
+
+	require.NoError(t, DecryptCredentialSecrets(c, encrypted))
+	require.Equal(t, creds, encrypted)
+
+	same, err := EncryptCredentialSecrets(prefixCipher{}, creds)
+	require.NoError(t, err)
+	require.Equal(t, "at-123", same["access_token"])
+}
+
+func TestMaskAndRestoreCredentials(t *testing.T) {
+	current := map[string]any{
+		"api_key":  "sk-ant-api03-abcdefgh1234",
+		"base_url": "https://api.example.com",
+	}
+	masked := MaskCredentialSecrets(current)
+	require.Equal(t, "********1234", masked["api_key"])
+	require.Equal(t, "https://api.example.com", masked["base_url"])
+	require.Equal(t, "********", MaskSecretValue("short"))
+
+	// 前端原样回传掩码时还原，修改过的值保留新值
+	restored := RestoreMaskedCredentials(map[string]any{"api_key": "********1234", "base_url": "https://new"}, current)
+	require.Equal(t, "sk-ant-api03-abcdefgh1234", restored["api_key"])
+	restored = RestoreMaskedCredentials(map[string]any{"api_key": "sk-new"}, current)
+	require.Equal(t, "sk-new", restored["api_key"])
+
+	stripped := StripMaskedCredentials(map[string]any{"api_key": "********1234", "base_url": "https://new"})
+	require.NotContains(t, stripped, "api_key")
+	require.Equal(t, "https://new", stripped["base_url"])
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+const secretReencryptBatchTimeout = 30 * time.Second
+
+// SecretReencryptRepository 用当前主密钥重新加密历史明文与旧主密钥密文
+type SecretReencryptRepository interface {
+	// ReencryptAccounts 处理 afterID 之后的一批账号，返回重新加密数量与本批最大 ID（无数据时为 0）
+	ReencryptAccounts(ctx context.Context, afterID int64, limit int) (int, int64, error)
+	ReencryptProxies(ctx context.Context) (int, error)
+	ReencryptSettings(ctx context.Context) (int, error)
+}
+
+// SecretReencryptService 周期性扫描敏感字段，把明文或旧主密钥加密的数据改用当前主密钥加密。
+// 主密钥轮换流程：新增 key 并切换 active_key_id，等待本任务完成（日志中重新加密数量归零）后再移除旧 key。
+type SecretReencryptService struct {
+	repo      SecretReencryptRepository
+	cipher    SecretCipher
+	interval  time.Duration
+	batchSize int
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewSecretReencryptService 创建敏感字段重新加密服务
+func NewSecretReencryptService(repo SecretReencryptRepository, cipher SecretCipher, cfg *config.Config) *SecretReencryptService {
+	encCfg := cfg.Security.CredentialEncryption
+	batchSize := encCfg.ReencryptBatchSize
+	if batchSize <= 0 {
+		batchSize = 200
+	}
+	return &SecretReencryptService{
+		repo:      repo,
+		cipher:    cipher,
+		interval:  time.Duration(encCfg.ReencryptIntervalMinutes) * time.Minute,
+		batchSize: batchSize,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start 启动后台任务；未启用加密或 interval 为 0 时不运行
+func (s *SecretReencryptService) Start() {
+	if s == nil || s.repo == nil || s.cipher == nil || !s.cipher.Enabled() || s.interval <= 0 {
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.runOnce()
+		for {
+			select {
+			case <-ticker.C:
+				s.runOnce()
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台任务
+func (s *SecretReencryptService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+}
+
+func (s *SecretReencryptService) runOnce() {
+	accounts, err := s.ReencryptAccounts()
+	if err != nil {
+		log.Printf("[SecretReencrypt] accounts stopped after %d: %v", accounts, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretReencryptBatchTimeout)
+	defer cancel()
+	proxies, err := s.repo.ReencryptProxies(ctx)
+	if err != nil {
+		log.Printf("[SecretReencrypt] proxies failed: %v", err)
+	}
+	settings, err := s.repo.ReencryptSettings(ctx)
+	if err != nil {
+		log.Printf("[SecretReencrypt] settings failed: %v", err)
+	}
+
+	if accounts+proxies+settings > 0 {
+		log.Printf("[SecretReencrypt] re-encrypted with key %s: accounts=%d proxies=%d settings=%d",
+			s.cipher.ActiveKeyID(), accounts, proxies, settings)
+	}
+}
+
+// ReencryptAccounts 分批处理全部账号，返回重新加密数量
+func (s *SecretReencryptService) ReencryptAccounts() (int, error) {
+	var afterID int64
+	total := 0
+	for {
+		select {
+		case <-s.stopCh:
+			return total, nil
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), secretReencryptBatchTimeout)
+		n, lastID, err := s.repo.ReencryptAccounts(ctx, afterID, s.batchSize)
+		cancel()
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if lastID == 0 {
+			return total, nil
+		}
+		afterID = lastID
+	}
+}
@@ -103,6 +103,7 @@ func (s *SettingService) GetPublicSettings(ctx context.Context) (*PublicSettings
 		SettingKeyPromoCodeEnabled,
 		SettingKeyPasswordResetEnabled,
 		SettingKeyTotpEnabled,
+		SettingKeyWebAuthnEnabled,
 		SettingKeyTurnstileEnabled,
 		SettingKeyTurnstileSiteKey,
 		SettingKeySiteName,
@@ -143,6 +144,7 @@ func (s *SettingService) GetPublicSettings(ctx context.Context) (*PublicSettings
 		PromoCodeEnabled:            settings[SettingKeyPromoCodeEnabled] != "false", // 默认启用
 		PasswordResetEnabled:        passwordResetEnabled,
 		TotpEnabled:                 settings[SettingKeyTotpEnabled] == "true",
+		WebAuthnEnabled:             settings[SettingKeyWebAuthnEnabled] == "true" && s.IsWebAuthnConfigured(),
 		TurnstileEnabled:            settings[SettingKeyTurnstileEnabled] == "true",
 		TurnstileSiteKey:            settings[SettingKeyTurnstileSiteKey],
 		SiteName:                    s.getStringOrDefault(settings, SettingKeySiteName, "YesCodex"),
@@ -188,6 +190,7 @@ func (s *SettingService) GetPublicSettingsForInjection(ctx context.Context) (any
 		PromoCodeEnabled            bool   `json:"promo_code_enabled"`
 		PasswordResetEnabled        bool   `json:"password_reset_enabled"`
 		TotpEnabled                 bool   `json:"totp_enabled"`
+		WebAuthnEnabled             bool   `json:"webauthn_enabled"`
 		TurnstileEnabled            bool   `json:"turnstile_enabled"`
 		TurnstileSiteKey            string `json:"turnstile_site_key,omitempty"`
 		SiteName                    string `json:"site_name"`
@@ -211,6 +214,7 @@ func (s *SettingService) GetPublicSettingsForInjection(ctx context.Context) (any
 		PromoCodeEnabled:            settings.PromoCodeEnabled,
 		PasswordResetEnabled:        settings.PasswordResetEnabled,
 		TotpEnabled:                 settings.TotpEnabled,
+		WebAuthnEnabled:             settings.WebAuthnEnabled,
 		TurnstileEnabled:            settings.TurnstileEnabled,
 		TurnstileSiteKey:            settings.TurnstileSiteKey,
 		SiteName:                    settings.SiteName,
@@ -241,6 +245,8 @@ func (s *SettingService) UpdateSettings(ctx context.Context, settings *SystemSet
 	updates[SettingKeyPromoCodeEnabled] = strconv.FormatBool(settings.PromoCodeEnabled)
 	updates[SettingKeyPasswordResetEnabled] = strconv.FormatBool(settings.PasswordResetEnabled)
 	updates[SettingKeyTotpEnabled] = strconv.FormatBool(settings.TotpEnabled)
+	updates[SettingKeyWebAuthnEnabled] = strconv.FormatBool(settings.WebAuthnEnabled)
+	updates[SettingKeyRequireAdmin2FA] = strconv.FormatBool(settings.RequireAdmin2FA)
 
 	// 邮件服务设置（只有非空才更新密码）
 	updates[SettingKeySMTPHost] = settings.SMTPHost
@@ -364,6 +370,33 @@ func (s *SettingService) IsTotpEnabled(ctx context.Context) bool {
 	return value == "true"
 }
 
+// IsWebAuthnEnabled 检查是否启用 passkey（WebAuthn）功能；未配置 webauthn.rp_id 时视为关闭
+func (s *SettingService) IsWebAuthnEnabled(ctx context.Context) bool {
+	if !s.IsWebAuthnConfigured() {
+		return false
+	}
+	value, err := s.settingRepo.GetValue(ctx, SettingKeyWebAuthnEnabled)
+	if err != nil {
+		return false // 默认关闭
+	}
+	return value == "true"
+}
+
+// IsAdmin2FARequired 检查管理员访问后台是否必须启用两步验证
+func (s *SettingService) IsAdmin2FARequired(ctx context.Context) bool {
+	value, err := s.settingRepo.GetValue(ctx, SettingKeyRequireAdmin2FA)
+	if err != nil {
+		return false // 默认关闭
+	}
+	return value == "true"
+}
+
+// IsWebAuthnConfigured 检查是否配置了 passkey 依赖方（webauthn.rp_id）
+// RP ID 不能从请求头推导，只有配置后才允许在管理后台启用 passkey
+func (s *SettingService) IsWebAuthnConfigured() bool {
+	return s.cfg != nil && s.cfg.WebAuthn.RPID != ""
+}
+
 // IsTotpEncryptionKeyConfigured 检查 TOTP 加密密钥是否已手动配置
 // 只有手动配置了密钥才允许在管理后台启用 TOTP 功能
 func (s *SettingService) IsTotpEncryptionKeyConfigured() bool {
@@ -423,6 +456,41 @@ func (s *SettingService) SetInviteRewardAmount(ctx context.Context, amount float
 	return s.settingRepo.Set(ctx, SettingKeyInviteRewardAmount, strconv.FormatFloat(amount, 'f', 8, 64))
 }
 
+// GetInviteCommissionSettings 获取返佣配置
+func (s *SettingService) GetInviteCommissionSettings(ctx context.Context) InviteCommissionSettings {
+	out := InviteCommissionSettings{}
+	values, err := s.settingRepo.GetMultiple(ctx, []string{
+		SettingKeyInviteCommissionEnabled,
+		SettingKeyInviteCommissionRate,
+		SettingKeyInviteCommissionDays,
+		SettingKeyInviteCommissionMinWithdraw,
+	})
+	if err != nil {
+		return out
+	}
+	out.Enabled = values[SettingKeyInviteCommissionEnabled] == "true"
+	if v, err := strconv.ParseFloat(values[SettingKeyInviteCommissionRate], 64); err == nil && v >= 0 && v <= 100 {
+		out.Rate = v
+	}
+	if v, err := strconv.Atoi(values[SettingKeyInviteCommissionDays]); err == nil && v >= 0 {
+		out.Days = v
+	}
+	if v, err := strconv.ParseFloat(values[SettingKeyInviteCommissionMinWithdraw], 64); err == nil && v >= 0 {
+		out.MinWithdraw = v
+	}
+	return out
+}
+
+// SetInviteCommissionSettings 更新返佣配置
+func (s *SettingService) SetInviteCommissionSettings(ctx context.Context, settings InviteCommissionSettings) error {
+	return s.settingRepo.SetMultiple(ctx, map[string]string{
+		SettingKeyInviteCommissionEnabled:     strconv.FormatBool(settings.Enabled),
+		SettingKeyInviteCommissionRate:        strconv.FormatFloat(settings.Rate, 'f', 4, 64),
+		SettingKeyInviteCommissionDays:        strconv.Itoa(settings.Days),
+		SettingKeyInviteCommissionMinWithdraw: strconv.FormatFloat(settings.MinWithdraw, 'f', 8, 64),
+	})
+}
+
 // InitializeDefaultSettings 初始化默认设置
 func (s *SettingService) InitializeDefaultSettings(ctx context.Context) error {
 	// 检查是否已有设置
@@ -451,6 +519,10 @@ func (s *SettingService) InitializeDefaultSettings(ctx context.Context) error {
 		SettingKeyDefaultConcurrency:          strconv.Itoa(s.cfg.Default.UserConcurrency),
 		SettingKeyDefaultBalance:              strconv.FormatFloat(s.cfg.Default.UserBalance, 'f', 8, 64),
 		SettingKeyInviteRewardAmount:          "0",
+		SettingKeyInviteCommissionEnabled:     "false",
+		SettingKeyInviteCommissionRate:        "0",
+		SettingKeyInviteCommissionDays:        "0",
+		SettingKeyInviteCommissionMinWithdraw: "0",
 		SettingKeySMTPPort:                    "587",
 		SettingKeySMTPUseTLS:                  "false",
 		// Model fallback defaults
@@ -482,6 +554,8 @@ func (s *SettingService) parseSettings(settings map[string]string) *SystemSettin
 		PromoCodeEnabled:             settings[SettingKeyPromoCodeEnabled] != "false", // 默认启用
 		PasswordResetEnabled:         emailVerifyEnabled && settings[SettingKeyPasswordResetEnabled] == "true",
 		TotpEnabled:                  settings[SettingKeyTotpEnabled] == "true",
+		WebAuthnEnabled:              settings[SettingKeyWebAuthnEnabled] == "true",
+		RequireAdmin2FA:              settings[SettingKeyRequireAdmin2FA] == "true",
 		SMTPHost:                     settings[SettingKeySMTPHost],
 		SMTPUsername:                 settings[SettingKeySMTPUsername],
 		SMTPFrom:                     settings[SettingKeySMTPFrom],
@@ -922,3 +996,62 @@ func (s *SettingService) SetStreamTimeoutSettings(ctx context.Context, settings
 
 	return s.settingRepo.Set(ctx, SettingKeyStreamTimeoutSettings, string(data))
 }
+
+// GetModelRateLimitScopeSettings 获取模型限流域配置
+func (s *SettingService) GetModelRateLimitScopeSettings(ctx context.Context) (*ModelRateLimitScopeSettings, error) {
+	value, err := s.settingRepo.GetValue(ctx, SettingKeyModelRateLimitScopes)
+	if err != nil {
+		if errors.Is(err, ErrSettingNotFound) {
+			return DefaultModelRateLimitScopeSettings(), nil
+		}
+		return nil, fmt.Errorf("get model rate limit scope settings: %w", err)
+	}
+	if value == "" {
+		return DefaultModelRateLimitScopeSettings(), nil
+	}
+
+	var settings ModelRateLimitScopeSettings
+	if err := json.Unmarshal([]byte(value), &settings); err != nil {
+		return DefaultModelRateLimitScopeSettings(), nil
+	}
+	if settings.Rules == nil {
+		settings.Rules = []ModelRateLimitScopeRule{}
+	}
+	return &settings, nil
+}
+
+// SetModelRateLimitScopeSettings 设置模型限流域配置
+func (s *SettingService) SetModelRateLimitScopeSettings(ctx context.Context, settings *ModelRateLimitScopeSettings) error {
+	if settings == nil {
+		return fmt.Errorf("settings cannot be nil")
+	}
+	if len(settings.Rules) > maxModelRateLimitScopeRules {
+		return fmt.Errorf("at most %d rules are allowed", maxModelRateLimitScopeRules)
+	}
+
+	rules := make([]ModelRateLimitScopeRule, 0, len(settings.Rules))
+	for i, rule := range settings.Rules {
+		rule.Platform = strings.ToLower(strings.TrimSpace(rule.Platform))
+		rule.Pattern = strings.ToLower(strings.TrimSpace(rule.Pattern))
+		rule.Scope = strings.ToLower(strings.TrimSpace(rule.Scope))
+		switch rule.Platform {
+		case PlatformAnthropic, PlatformOpenAI, PlatformGemini, PlatformAntigravity:
+		default:
+			return fmt.Errorf("rule %d: invalid platform: %s", i+1, rule.Platform)
+		}
+		if rule.Pattern == "" || strings.Trim(rule.Pattern, "*") == "" {
+			return fmt.Errorf("rule %d: pattern must contain more than wildcards", i+1)
+		}
+		if !modelRateLimitScopeNamePattern.MatchString(rule.Scope) {
+			return fmt.Errorf("rule %d: scope must be 1-64 characters of a-z, 0-9, _ or -", i+1)
+		}
+		rules = append(rules, rule)
+	}
+
+	data, err := json.Marshal(ModelRateLimitScopeSettings{Rules: rules})
+	if err != nil {
+		return fmt.Errorf("marshal model rate limit scope settings: %w", err)
+	}
+
+	return s.settingRepo.Set(ctx, SettingKeyModelRateLimitScopes, string(data))
+}
@@ -6,6 +6,8 @@ type SystemSettings struct {
 	PromoCodeEnabled     bool
 	PasswordResetEnabled bool
 	TotpEnabled          bool // TOTP 双因素认证
+	WebAuthnEnabled      bool // Passkey 两步验证与免密码登录
+	RequireAdmin2FA      bool // 管理员必须启用两步验证
 
 	SMTPHost               string
 	SMTPPort               int
@@ -69,6 +71,7 @@ type PublicSettings struct {
 	PromoCodeEnabled     bool
 	PasswordResetEnabled bool
 	TotpEnabled          bool // TOTP 双因素认证
+	WebAuthnEnabled      bool // Passkey 登录
 	TurnstileEnabled     bool
 	TurnstileSiteKey     string
 	SiteName             string
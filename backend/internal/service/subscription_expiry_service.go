@@ -7,18 +7,21 @@ import (
 	"time"
 )
 
-// SubscriptionExpiryService periodically updates expired subscription status.
+// SubscriptionExpiryService periodically auto-renews subscriptions that are about to
+// expire (when the user opted in) and updates expired subscription status.
 type SubscriptionExpiryService struct {
 	userSubRepo UserSubscriptionRepository
+	lifecycle   *SubscriptionLifecycleService
 	interval    time.Duration
 	stopCh      chan struct{}
 	stopOnce    sync.Once
 	wg          sync.WaitGroup
 }
 
-func NewSubscriptionExpiryService(userSubRepo UserSubscriptionRepository, interval time.Duration) *SubscriptionExpiryService {
+func NewSubscriptionExpiryService(userSubRepo UserSubscriptionRepository, lifecycle *SubscriptionLifecycleService, interval time.Duration) *SubscriptionExpiryService {
 	return &SubscriptionExpiryService{
 		userSubRepo: userSubRepo,
+		lifecycle:   lifecycle,
 		interval:    interval,
 		stopCh:      make(chan struct{}),
 	}
@@ -57,6 +60,9 @@ func (s *SubscriptionExpiryService) Stop() {
 }
 
 func (s *SubscriptionExpiryService) runOnce() {
+	// 先续费再标记过期，避免刚到期的自动续费订阅被短暂置为 expired
+	s.runRenewals()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -69,3 +75,20 @@ func (s *SubscriptionExpiryService) runOnce() {
 		log.Printf("[SubscriptionExpiry] Updated %d expired subscriptions", updated)
 	}
 }
+
+func (s *SubscriptionExpiryService) runRenewals() {
+	if s.lifecycle == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	renewed, failed, err := s.lifecycle.ProcessAutoRenewals(ctx)
+	if err != nil {
+		log.Printf("[SubscriptionExpiry] Auto renew subscriptions failed: %v", err)
+		return
+	}
+	if renewed > 0 || failed > 0 {
+		log.Printf("[SubscriptionExpiry] Auto renewed %d subscriptions, %d failed", renewed, failed)
+	}
+}
@@ -0,0 +1,477 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+const (
+	// SubscriptionRenewalLeadTime 自动续费提前量：到期前 24 小时内开始尝试扣费续期
+	SubscriptionRenewalLeadTime = 24 * time.Hour
+	// SubscriptionRenewalRetryInterval 自动续费失败后的重试间隔（失败邮件每个到期周期只发一次）
+	SubscriptionRenewalRetryInterval = 6 * time.Hour
+
+	subscriptionRenewalBatchSize = 100
+)
+
+var (
+	ErrSubscriptionNotActive       = infraerrors.Conflict("SUBSCRIPTION_NOT_ACTIVE", "subscription is not active")
+	ErrSubscriptionNotPaused       = infraerrors.Conflict("SUBSCRIPTION_NOT_PAUSED", "subscription is not paused")
+	ErrSubscriptionPaused          = infraerrors.Forbidden("SUBSCRIPTION_PAUSED", "subscription is paused")
+	ErrSubscriptionNotPurchasable  = infraerrors.BadRequest("SUBSCRIPTION_NOT_PURCHASABLE", "group does not support self-service subscription")
+	ErrSubscriptionSwitchSameGroup = infraerrors.BadRequest("SUBSCRIPTION_SWITCH_SAME_GROUP", "target group is the current subscription group")
+	ErrSubscriptionRenewalConflict = infraerrors.Conflict("SUBSCRIPTION_RENEWAL_CONFLICT", "subscription was modified concurrently, please retry")
+)
+
+// SubscriptionPeriodDays 自助续费/切换时一个计费周期的天数
+func (g *Group) SubscriptionPeriodDays() int {
+	days := g.DefaultValidityDays
+	if days <= 0 {
+		days = 30
+	}
+	if days > MaxValidityDays {
+		days = MaxValidityDays
+	}
+	return days
+}
+
+// IsSelfServiceSubscription 分组是否允许用户用余额自助续费/切换（订阅类型、启用且配置了价格）
+func (g *Group) IsSelfServiceSubscription() bool {
+	return g != nil && g.IsSubscriptionType() && g.IsActive() && g.SubscriptionPrice != nil && *g.SubscriptionPrice >= 0
+}
+
+// IsPaused 订阅是否处于暂停状态
+func (s *UserSubscription) IsPaused() bool {
+	return s.Status == SubscriptionStatusPaused
+}
+
+// RemainingDuration 订阅剩余可用时长；暂停中的订阅按暂停时刻冻结计算
+func (s *UserSubscription) RemainingDuration(now time.Time) time.Duration {
+	ref := now
+	if s.IsPaused() && s.PausedAt != nil {
+		ref = *s.PausedAt
+	}
+	if remaining := s.ExpiresAt.Sub(ref); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// SubscriptionSwitchQuote 切换订阅的报价：原订阅未使用天数按原价折算为抵扣额，
+// 抵扣额先冲抵新套餐价格，剩余部分按新套餐日单价折算为额外天数（不退回余额）。
+type SubscriptionSwitchQuote struct {
+	FromGroupID  int64   `json:"from_group_id"`
+	ToGroupID    int64   `json:"to_group_id"`
+	Price        float64 `json:"price"`
+	Credit       float64 `json:"credit"`
+	Charge       float64 `json:"charge"`
+	PeriodDays   int     `json:"period_days"`
+	BonusDays    int     `json:"bonus_days"`
+	ValidityDays int     `json:"validity_days"`
+}
+
+// computeSwitchQuote 计算切换报价；to 必须已通过 IsSelfServiceSubscription 校验
+func computeSwitchQuote(sub *UserSubscription, from, to *Group, now time.Time) *SubscriptionSwitchQuote {
+	quote := &SubscriptionSwitchQuote{
+		FromGroupID: sub.GroupID,
+		ToGroupID:   to.ID,
+		Price:       *to.SubscriptionPrice,
+		PeriodDays:  to.SubscriptionPeriodDays(),
+	}
+
+	// 原分组未定价（管理员/兑换码发放）时不产生抵扣
+	if from != nil && from.SubscriptionPrice != nil && *from.SubscriptionPrice > 0 {
+		remainingDays := sub.RemainingDuration(now).Hours() / 24
+		credit := remainingDays / float64(from.SubscriptionPeriodDays()) * *from.SubscriptionPrice
+		quote.Credit = math.Floor(credit*100) / 100
+	}
+
+	if quote.Credit >= quote.Price {
+		quote.Charge = 0
+		if quote.Price > 0 {
+			perDay := quote.Price / float64(quote.PeriodDays)
+			quote.BonusDays = int(math.Floor((quote.Credit - quote.Price) / perDay))
+		}
+	} else {
+		quote.Charge = math.Round((quote.Price-quote.Credit)*1e8) / 1e8
+	}
+
+	quote.ValidityDays = quote.PeriodDays + quote.BonusDays
+	if quote.ValidityDays > MaxValidityDays {
+		quote.ValidityDays = MaxValidityDays
+		quote.BonusDays = quote.ValidityDays - quote.PeriodDays
+	}
+	return quote
+}
+
+// SubscriptionLifecycleService 用户自助的订阅生命周期：余额续费/自动续费、切换套餐、暂停与恢复
+type SubscriptionLifecycleService struct {
+	entClient            *dbent.Client
+	userRepo             UserRepository
+	groupRepo            GroupRepository
+	userSubRepo          UserSubscriptionRepository
+	subscriptionService  *SubscriptionService
+	billingCacheService  *BillingCacheService
+	emailQueueService    *EmailQueueService
+	authCacheInvalidator APIKeyAuthCacheInvalidator
+}
+
+// NewSubscriptionLifecycleService 创建订阅生命周期服务
+func NewSubscriptionLifecycleService(
+	entClient *dbent.Client,
+	userRepo UserRepository,
+	groupRepo GroupRepository,
+	userSubRepo UserSubscriptionRepository,
+	subscriptionService *SubscriptionService,
+	billingCacheService *BillingCacheService,
+	emailQueueService *EmailQueueService,
+	authCacheInvalidator APIKeyAuthCacheInvalidator,
+) *SubscriptionLifecycleService {
+	return &SubscriptionLifecycleService{
+		entClient:            entClient,
+		userRepo:             userRepo,
+		groupRepo:            groupRepo,
+		userSubRepo:          userSubRepo,
+		subscriptionService:  subscriptionService,
+		billingCacheService:  billingCacheService,
+		emailQueueService:    emailQueueService,
+		authCacheInvalidator: authCacheInvalidator,
+	}
+}
+
+// getOwnedSubscription 获取属于该用户的订阅（不属于时按不存在处理）
+func (s *SubscriptionLifecycleService) getOwnedSubscription(ctx context.Context, userID, subscriptionID int64) (*UserSubscription, error) {
+	sub, err := s.userSubRepo.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if sub.UserID != userID {
+		return nil, ErrSubscriptionNotFound
+	}
+	if sub.Group == nil {
+		group, err := s.groupRepo.GetByID(ctx, sub.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		sub.Group = group
+	}
+	return sub, nil
+}
+
+// SetAutoRenew 开启/关闭自动续费；开启要求分组支持自助续费
+func (s *SubscriptionLifecycleService) SetAutoRenew(ctx context.Context, userID, subscriptionID int64, enabled bool) (*UserSubscription, error) {
+	sub, err := s.getOwnedSubscription(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled && !sub.Group.IsSelfServiceSubscription() {
+		return nil, ErrSubscriptionNotPurchasable
+	}
+	if err := s.userSubRepo.SetAutoRenew(ctx, sub.ID, enabled); err != nil {
+		return nil, err
+	}
+	return s.userSubRepo.GetByID(ctx, sub.ID)
+}
+
+// Renew 用户手动从余额续费一个周期
+func (s *SubscriptionLifecycleService) Renew(ctx context.Context, userID, subscriptionID int64) (*UserSubscription, error) {
+	sub, err := s.getOwnedSubscription(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.renew(ctx, sub); err != nil {
+		return nil, err
+	}
+	return s.userSubRepo.GetByID(ctx, sub.ID)
+}
+
+// renew 扣除余额并顺延一个周期，返回新的到期时间。
+// 未过期从原到期时间累加，已过期从当前时间开始；暂停/停用的订阅不允许续费。
+// 到期时间以读取时的值做条件更新，并发续费只有一个成功，其余返回 ErrSubscriptionRenewalConflict 并回滚扣费。
+func (s *SubscriptionLifecycleService) renew(ctx context.Context, sub *UserSubscription) (time.Time, error) {
+	if sub.Status != SubscriptionStatusActive && sub.Status != SubscriptionStatusExpired {
+		return time.Time{}, ErrSubscriptionNotActive
+	}
+	group := sub.Group
+	if !group.IsSelfServiceSubscription() {
+		return time.Time{}, ErrSubscriptionNotPurchasable
+	}
+	price := *group.SubscriptionPrice
+
+	now := time.Now()
+	base := now
+	if sub.ExpiresAt.After(now) {
+		base = sub.ExpiresAt
+	}
+	newExpiresAt := base.AddDate(0, 0, group.SubscriptionPeriodDays())
+	if newExpiresAt.After(MaxExpiresAt) {
+		newExpiresAt = MaxExpiresAt
+	}
+
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		if err := s.userSubRepo.RecordRenewal(txCtx, sub.ID, sub.ExpiresAt, newExpiresAt); err != nil {
+			return err
+		}
+		return s.chargeBalance(txCtx, sub.UserID, price)
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	s.invalidateCaches(ctx, sub.UserID, price > 0, sub.GroupID)
+	return newExpiresAt, nil
+}
+
+// QuoteSwitch 预览切换到目标分组的费用
+func (s *SubscriptionLifecycleService) QuoteSwitch(ctx context.Context, userID, subscriptionID, targetGroupID int64) (*SubscriptionSwitchQuote, error) {
+	sub, target, err := s.prepareSwitch(ctx, userID, subscriptionID, targetGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return computeSwitchQuote(sub, sub.Group, target, time.Now()), nil
+}
+
+// SwitchPlan 切换到目标分组：按报价扣费，新订阅从现在起生效（已有目标分组订阅则在其基础上累加），原订阅撤销
+func (s *SubscriptionLifecycleService) SwitchPlan(ctx context.Context, userID, subscriptionID, targetGroupID int64) (*UserSubscription, *SubscriptionSwitchQuote, error) {
+	sub, target, err := s.prepareSwitch(ctx, userID, subscriptionID, targetGroupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	quote := computeSwitchQuote(sub, sub.Group, target, time.Now())
+
+	var newSubID int64
+	err = s.withTx(ctx, func(txCtx context.Context) error {
+		if err := s.chargeBalance(txCtx, userID, quote.Charge); err != nil {
+			return err
+		}
+		newSub, _, err := s.subscriptionService.AssignOrExtendSubscription(txCtx, &AssignSubscriptionInput{
+			UserID:       userID,
+			GroupID:      target.ID,
+			ValidityDays: quote.ValidityDays,
+			Notes:        fmt.Sprintf("由订阅 #%d（%s）切换，抵扣 $%.2f，扣费 $%.2f", sub.ID, sub.Group.Name, quote.Credit, quote.Charge),
+		})
+		if err != nil {
+			return err
+		}
+		newSubID = newSub.ID
+		if sub.AutoRenew {
+			if err := s.userSubRepo.SetAutoRenew(txCtx, newSubID, true); err != nil {
+				return err
+			}
+		}
+		return s.userSubRepo.Delete(txCtx, sub.ID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.invalidateCaches(ctx, userID, quote.Charge > 0, sub.GroupID, target.ID)
+
+	newSub, err := s.userSubRepo.GetByID(ctx, newSubID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newSub, quote, nil
+}
+
+func (s *SubscriptionLifecycleService) prepareSwitch(ctx context.Context, userID, subscriptionID, targetGroupID int64) (*UserSubscription, *Group, error) {
+	sub, err := s.getOwnedSubscription(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sub.Status != SubscriptionStatusActive && sub.Status != SubscriptionStatusPaused {
+		return nil, nil, ErrSubscriptionNotActive
+	}
+	if sub.RemainingDuration(time.Now()) <= 0 {
+		return nil, nil, ErrSubscriptionExpired
+	}
+	if targetGroupID == sub.GroupID {
+		return nil, nil, ErrSubscriptionSwitchSameGroup
+	}
+
+	target, err := s.groupRepo.GetByID(ctx, targetGroupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !target.IsSelfServiceSubscription() {
+		return nil, nil, ErrSubscriptionNotPurchasable
+	}
+	if target.IsExclusive {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !user.CanBindGroup(target.ID, target.IsExclusive) {
+			return nil, nil, ErrSubscriptionNotPurchasable
+		}
+	}
+	return sub, target, nil
+}
+
+// Pause 暂停订阅：暂停期间不可用，剩余时长冻结
+func (s *SubscriptionLifecycleService) Pause(ctx context.Context, userID, subscriptionID int64) (*UserSubscription, error) {
+	sub, err := s.getOwnedSubscription(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userSubRepo.Pause(ctx, sub.ID, time.Now()); err != nil {
+		return nil, err
+	}
+	s.invalidateCaches(ctx, sub.UserID, false, sub.GroupID)
+	return s.userSubRepo.GetByID(ctx, sub.ID)
+}
+
+// Resume 恢复订阅：到期时间按暂停时长顺延
+func (s *SubscriptionLifecycleService) Resume(ctx context.Context, userID, subscriptionID int64) (*UserSubscription, error) {
+	sub, err := s.getOwnedSubscription(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !sub.IsPaused() {
+		return nil, ErrSubscriptionNotPaused
+	}
+	newExpiresAt := time.Now().Add(sub.RemainingDuration(time.Now()))
+	if newExpiresAt.After(MaxExpiresAt) {
+		newExpiresAt = MaxExpiresAt
+	}
+	if err := s.userSubRepo.Resume(ctx, sub.ID, newExpiresAt); err != nil {
+		return nil, err
+	}
+	s.invalidateCaches(ctx, sub.UserID, false, sub.GroupID)
+	return s.userSubRepo.GetByID(ctx, sub.ID)
+}
+
+// ProcessAutoRenewals 处理即将到期且开启自动续费的订阅（由 SubscriptionExpiryService 定期调用）。
+// 成功发送续费成功邮件；失败记录失败时间并按 SubscriptionRenewalRetryInterval 重试，失败邮件每个周期只发一次。
+func (s *SubscriptionLifecycleService) ProcessAutoRenewals(ctx context.Context) (renewed, failed int, err error) {
+	now := time.Now()
+	subs, err := s.userSubRepo.ListAutoRenewDue(ctx, now.Add(SubscriptionRenewalLeadTime), now.Add(-SubscriptionRenewalRetryInterval), subscriptionRenewalBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i := range subs {
+		sub := &subs[i]
+		if sub.Group == nil {
+			continue
+		}
+		newExpiresAt, renewErr := s.renew(ctx, sub)
+		if renewErr == nil {
+			renewed++
+			s.notify(sub, fmt.Sprintf("订阅已自动续费：%s", sub.Group.Name), buildRenewalSuccessEmailBody(sub, newExpiresAt))
+			continue
+		}
+		if errors.Is(renewErr, ErrSubscriptionRenewalConflict) {
+			// 已被用户手动续费或管理员调整，下一轮按新的到期时间重新判断
+			log.Printf("[SubscriptionRenewal] Auto renew skipped, subscription changed concurrently: subscription=%d", sub.ID)
+			continue
+		}
+
+		failed++
+		log.Printf("[SubscriptionRenewal] Auto renew failed: subscription=%d user=%d err=%v", sub.ID, sub.UserID, renewErr)
+		if markErr := s.userSubRepo.MarkRenewalFailed(ctx, sub.ID, now); markErr != nil {
+			log.Printf("[SubscriptionRenewal] Mark renewal failed: subscription=%d err=%v", sub.ID, markErr)
+		}
+		if sub.RenewalFailedAt == nil {
+			s.notify(sub, fmt.Sprintf("订阅自动续费失败：%s", sub.Group.Name), buildRenewalFailedEmailBody(sub, renewErr))
+		}
+	}
+	return renewed, failed, nil
+}
+
+// chargeBalance 在事务内扣除余额；扣除后余额为负则回滚（不同于请求计费的透支策略）
+func (s *SubscriptionLifecycleService) chargeBalance(txCtx context.Context, userID int64, amount float64) error {
+	if amount <= 0 {
+		return nil
+	}
+	if err := s.userRepo.DeductBalance(txCtx, userID, amount); err != nil {
+		return fmt.Errorf("deduct balance: %w", err)
+	}
+	user, err := s.userRepo.GetByID(txCtx, userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	if user.Balance < 0 {
+		return ErrInsufficientBalance
+	}
+	return nil
+}
+
+func (s *SubscriptionLifecycleService) withTx(ctx context.Context, fn func(txCtx context.Context) error) error {
+	if s.entClient == nil {
+		return fn(ctx)
+	}
+	tx, err := s.entClient.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := fn(dbent.NewTxContext(ctx, tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (s *SubscriptionLifecycleService) invalidateCaches(ctx context.Context, userID int64, balanceChanged bool, groupIDs ...int64) {
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, userID)
+	}
+	if s.billingCacheService == nil {
+		return
+	}
+	go func() {
+		cacheCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if balanceChanged {
+			_ = s.billingCacheService.InvalidateUserBalance(cacheCtx, userID)
+		}
+		for _, groupID := range groupIDs {
+			_ = s.billingCacheService.InvalidateSubscription(cacheCtx, userID, groupID)
+		}
+	}()
+}
+
+func (s *SubscriptionLifecycleService) notify(sub *UserSubscription, subject, body string) {
+	if s.emailQueueService == nil || sub.User == nil || sub.User.Email == "" {
+		return
+	}
+	if err := s.emailQueueService.EnqueueEmail(sub.User.Email, subject, body); err != nil {
+		log.Printf("[SubscriptionRenewal] enqueue email failed: email=%s err=%v", sub.User.Email, err)
+	}
+}
+
+func buildRenewalSuccessEmailBody(sub *UserSubscription, newExpiresAt time.Time) string {
+	return fmt.Sprintf(
+		"<p>你的订阅（%s）已自动续费 %d 天，扣除余额 <b>$%.2f</b>。</p><p>新的到期时间：%s</p>",
+		sub.Group.Name,
+		sub.Group.SubscriptionPeriodDays(),
+		*sub.Group.SubscriptionPrice,
+		newExpiresAt.Format(time.RFC3339),
+	)
+}
+
+func buildRenewalFailedEmailBody(sub *UserSubscription, renewErr error) string {
+	reason := "系统繁忙，将稍后自动重试"
+	switch {
+	case errors.Is(renewErr, ErrInsufficientBalance):
+		reason = "账户余额不足，请充值后系统将自动重试"
+	case errors.Is(renewErr, ErrSubscriptionNotPurchasable):
+		reason = "该订阅套餐已不支持自助续费，请联系管理员"
+	}
+	return fmt.Sprintf(
+		"<p>你的订阅（%s）自动续费失败：%s。</p><p>到期时间：%s</p>",
+		sub.Group.Name,
+		reason,
+		sub.ExpiresAt.Format(time.RFC3339),
+	)
+}
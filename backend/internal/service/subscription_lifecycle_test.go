@@ -0,0 +1,179 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func pricedGroup(id int64, price float64, days int) *Group {
+	return &Group{
+		ID:                  id,
+		Name:                "plan",
+		Status:              StatusActive,
+		SubscriptionType:    SubscriptionTypeSubscription,
+		DefaultValidityDays: days,
+		SubscriptionPrice:   &price,
+	}
+}
+
+func TestComputeSwitchQuote(t *testing.T) {
+	now := time.Now()
+	sub := &UserSubscription{GroupID: 1, Status: SubscriptionStatusActive, ExpiresAt: now.Add(15 * 24 * time.Hour)}
+
+	t.Run("upgrade charges difference", func(t *testing.T) {
+		quote := computeSwitchQuote(sub, pricedGroup(1, 30, 30), pricedGroup(2, 60, 30), now)
+		require.InDelta(t, 15, quote.Credit, 0.01)
+		require.InDelta(t, 45, quote.Charge, 0.01)
+		require.Equal(t, 0, quote.BonusDays)
+		require.Equal(t, 30, quote.ValidityDays)
+	})
+
+	t.Run("downgrade converts leftover credit to days", func(t *testing.T) {
+		quote := computeSwitchQuote(sub, pricedGroup(1, 300, 30), pricedGroup(2, 30, 30), now)
+		require.InDelta(t, 150, quote.Credit, 0.01)
+		require.Zero(t, quote.Charge)
+		require.Equal(t, 120, quote.BonusDays)
+		require.Equal(t, 150, quote.ValidityDays)
+	})
+
+	t.Run("unpriced source gives no credit", func(t *testing.T) {
+		from := &Group{ID: 1, SubscriptionType: SubscriptionTypeSubscription}
+		quote := computeSwitchQuote(sub, from, pricedGroup(2, 20, 7), now)
+		require.Zero(t, quote.Credit)
+		require.InDelta(t, 20, quote.Charge, 1e-9)
+		require.Equal(t, 7, quote.ValidityDays)
+	})
+}
+
+func TestUserSubscriptionRemainingDurationWhilePaused(t *testing.T) {
+	now := time.Now()
+	pausedAt := now.Add(-10 * 24 * time.Hour)
+	sub := &UserSubscription{Status: SubscriptionStatusPaused, PausedAt: &pausedAt, ExpiresAt: now.Add(-5 * 24 * time.Hour)}
+	require.Equal(t, 5*24*time.Hour, sub.RemainingDuration(now))
+
+	sub.Status = SubscriptionStatusActive
+	require.Zero(t, sub.RemainingDuration(now))
+}
+
+type lifecycleSubRepoStub struct {
+	UserSubscriptionRepository
+	subs         map[int64]*UserSubscription
+	due          []UserSubscription
+	resumedTo    time.Time
+	renewedTo    map[int64]time.Time
+	failedMarked []int64
+}
+
+func (r *lifecycleSubRepoStub) GetByID(ctx context.Context, id int64) (*UserSubscription, error) {
+	sub, ok := r.subs[id]
+	if !ok {
+		return nil, ErrSubscriptionNotFound
+	}
+	cp := *sub
+	return &cp, nil
+}
+
+func (r *lifecycleSubRepoStub) Resume(ctx context.Context, id int64, newExpiresAt time.Time) error {
+	r.resumedTo = newExpiresAt
+	return nil
+}
+
+func (r *lifecycleSubRepoStub) ListAutoRenewDue(ctx context.Context, dueBefore, retryBefore time.Time, limit int) ([]UserSubscription, error) {
+	return r.due, nil
+}
+
+func (r *lifecycleSubRepoStub) RecordRenewal(ctx context.Context, id int64, prevExpiresAt, newExpiresAt time.Time) error {
+	if r.renewedTo == nil {
+		r.renewedTo = map[int64]time.Time{}
+	}
+	if current, ok := r.renewedTo[id]; ok && !current.Equal(prevExpiresAt) {
+		return ErrSubscriptionRenewalConflict
+	}
+	r.renewedTo[id] = newExpiresAt
+	return nil
+}
+
+func (r *lifecycleSubRepoStub) MarkRenewalFailed(ctx context.Context, id int64, failedAt time.Time) error {
+	r.failedMarked = append(r.failedMarked, id)
+	return nil
+}
+
+type lifecycleUserRepoStub struct {
+	UserRepository
+	balances map[int64]float64
+}
+
+func (r *lifecycleUserRepoStub) DeductBalance(ctx context.Context, id int64, amount float64) error {
+	r.balances[id] -= amount
+	return nil
+}
+
+func (r *lifecycleUserRepoStub) GetByID(ctx context.Context, id int64) (*User, error) {
+	return &User{ID: id, Balance: r.balances[id]}, nil
+}
+
+func TestSubscriptionLifecycleResumeShiftsExpiry(t *testing.T) {
+	pausedAt := time.Now().Add(-48 * time.Hour)
+	repo := &lifecycleSubRepoStub{subs: map[int64]*UserSubscription{
+		5: {ID: 5, UserID: 9, GroupID: 1, Status: SubscriptionStatusPaused, PausedAt: &pausedAt, ExpiresAt: pausedAt.Add(72 * time.Hour), Group: pricedGroup(1, 10, 30)},
+	}}
+	svc := NewSubscriptionLifecycleService(nil, nil, nil, repo, nil, nil, nil, nil)
+
+	_, err := svc.Resume(context.Background(), 9, 5)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(72*time.Hour), repo.resumedTo, time.Minute)
+
+	_, err = svc.Resume(context.Background(), 10, 5)
+	require.ErrorIs(t, err, ErrSubscriptionNotFound)
+}
+
+func TestSubscriptionLifecycleProcessAutoRenewals(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	group := pricedGroup(1, 10, 30)
+	repo := &lifecycleSubRepoStub{due: []UserSubscription{
+		{ID: 1, UserID: 100, GroupID: 1, Status: SubscriptionStatusActive, AutoRenew: true, ExpiresAt: expiresAt, Group: group},
+		{ID: 2, UserID: 200, GroupID: 1, Status: SubscriptionStatusActive, AutoRenew: true, ExpiresAt: expiresAt, Group: group},
+	}}
+	users := &lifecycleUserRepoStub{balances: map[int64]float64{100: 25, 200: 5}}
+	svc := NewSubscriptionLifecycleService(nil, users, nil, repo, nil, nil, nil, nil)
+
+	renewed, failed, err := svc.ProcessAutoRenewals(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, renewed)
+	require.Equal(t, 1, failed)
+	require.Equal(t, expiresAt.AddDate(0, 0, 30), repo.renewedTo[1])
+	require.Equal(t, []int64{2}, repo.failedMarked)
+	require.InDelta(t, 15, users.balances[100], 1e-9)
+}
+
+func TestSubscriptionLifecycleRenewRejectsStaleExpiry(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	group := pricedGroup(1, 10, 30)
+	repo := &lifecycleSubRepoStub{}
+	users := &lifecycleUserRepoStub{balances: map[int64]float64{100: 50}}
+	svc := NewSubscriptionLifecycleService(nil, users, nil, repo, nil, nil, nil, nil)
+
+	// 两个并发续费读取到相同的到期时间，只有先写入的一个生效并扣费
+	first := &UserSubscription{ID: 1, UserID: 100, GroupID: 1, Status: SubscriptionStatusActive, ExpiresAt: expiresAt, Group: group}
+	second := *first
+	_, err := svc.renew(context.Background(), first)
+	require.NoError(t, err)
+	_, err = svc.renew(context.Background(), &second)
+	require.ErrorIs(t, err, ErrSubscriptionRenewalConflict)
+	require.Equal(t, expiresAt.AddDate(0, 0, 30), repo.renewedTo[1])
+	require.InDelta(t, 40, users.balances[100], 1e-9)
+
+	// 自动续费遇到并发修改时跳过，不记为失败
+	repo.due = []UserSubscription{second}
+	renewed, failed, err := svc.ProcessAutoRenewals(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, renewed)
+	require.Zero(t, failed)
+	require.Empty(t, repo.failedMarked)
+	require.InDelta(t, 40, users.balances[100], 1e-9)
+}
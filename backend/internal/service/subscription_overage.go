@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+const (
+	// OveragePolicyReject 订阅额度用尽时拒绝请求（默认）
+	OveragePolicyReject = "reject"
+	// OveragePolicyBalance 订阅额度用尽后改为扣余额，按 OverageRateMultiplier 计费
+	OveragePolicyBalance = "balance"
+	// OveragePolicyFallbackGroup 订阅额度用尽后降级到 OverageGroupID 指定的按量计费分组
+	OveragePolicyFallbackGroup = "fallback_group"
+)
+
+var ErrInvalidOveragePolicy = infraerrors.BadRequest("INVALID_OVERAGE_POLICY", "invalid overage policy")
+
+// SubscriptionOverage 订阅超额请求的计费上下文。
+// 由认证中间件挂到请求级 APIKey 副本上，网关记账时据此按余额扣费并把使用记录标记为超额。
+type SubscriptionOverage struct {
+	Policy         string
+	SubscriptionID int64
+	// SourceGroupID 原订阅分组（fallback_group 策略下 APIKey.Group 已替换为降级分组）
+	SourceGroupID int64
+	// RateMultiplier balance 策略的计费倍率（替代订阅分组倍率）；fallback_group 策略按降级分组倍率计费，不使用此值
+	RateMultiplier float64
+}
+
+// NormalizeOveragePolicy 校验并规范化超额策略（空字符串等价于 reject）
+func NormalizeOveragePolicy(policy string) (string, error) {
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	switch policy {
+	case "":
+		return OveragePolicyReject, nil
+	case OveragePolicyReject, OveragePolicyBalance, OveragePolicyFallbackGroup:
+		return policy, nil
+	default:
+		return "", ErrInvalidOveragePolicy
+	}
+}
+
+// EffectiveOveragePolicy 分组实际生效的超额策略：非订阅分组或配置不完整时按 reject 处理
+func (g *Group) EffectiveOveragePolicy() string {
+	if g == nil || !g.IsSubscriptionType() {
+		return OveragePolicyReject
+	}
+	switch g.OveragePolicy {
+	case OveragePolicyBalance:
+		return OveragePolicyBalance
+	case OveragePolicyFallbackGroup:
+		if g.OverageGroupID != nil && *g.OverageGroupID > 0 {
+			return OveragePolicyFallbackGroup
+		}
+	}
+	return OveragePolicyReject
+}
+
+// ResolveOverage 订阅超限（limitErr 来自 CheckUsageLimits）时按分组超额策略决定是否放行。
+// reject 策略原样返回 limitErr；其余策略返回超额计费上下文，fallback_group 策略同时返回降级分组。
+func (s *SubscriptionService) ResolveOverage(ctx context.Context, sub *UserSubscription, group *Group, limitErr error) (*SubscriptionOverage, *Group, error) {
+	if sub == nil || group == nil {
+		return nil, nil, limitErr
+	}
+	switch group.EffectiveOveragePolicy() {
+	case OveragePolicyBalance:
+		multiplier := group.OverageRateMultiplier
+		if multiplier <= 0 {
+			multiplier = 1
+		}
+		return &SubscriptionOverage{
+			Policy:         OveragePolicyBalance,
+			SubscriptionID: sub.ID,
+			SourceGroupID:  group.ID,
+			RateMultiplier: multiplier,
+		}, nil, nil
+	case OveragePolicyFallbackGroup:
+		fallback, err := s.groupRepo.GetByID(ctx, *group.OverageGroupID)
+		if err != nil || !fallback.IsActive() || fallback.IsSubscriptionType() || fallback.Platform != group.Platform {
+			// 降级分组失效时退回拒绝，避免把请求路由到不可用或不可计费的分组
+			return nil, nil, limitErr
+		}
+		return &SubscriptionOverage{
+			Policy:         OveragePolicyFallbackGroup,
+			SubscriptionID: sub.ID,
+			SourceGroupID:  group.ID,
+		}, fallback, nil
+	default:
+		return nil, nil, limitErr
+	}
+}
+
+// WithSubscriptionOverage 返回挂载超额上下文的请求级 APIKey 副本（不修改认证缓存中的原对象）；
+// fallbackGroup 非空时同时把分组替换为降级分组，调度与计费均按降级分组进行。
+func (k *APIKey) WithSubscriptionOverage(overage *SubscriptionOverage, fallbackGroup *Group) *APIKey {
+	clone := *k
+	clone.SubscriptionOverage = overage
+	if fallbackGroup != nil {
+		groupID := fallbackGroup.ID
+		clone.GroupID = &groupID
+		clone.Group = fallbackGroup
+	}
+	return &clone
+}
+
+// overageRateMultiplier balance 超额请求改用超额倍率计费。
+// 订阅分组的倍率只用于展示（订阅用量按原始费用累计），免费订阅分组倍率为 0，不能直接沿用。
+func overageRateMultiplier(apiKey *APIKey, multiplier float64) float64 {
+	if apiKey == nil || apiKey.SubscriptionOverage == nil || apiKey.SubscriptionOverage.Policy != OveragePolicyBalance {
+		return multiplier
+	}
+	return apiKey.SubscriptionOverage.RateMultiplier
+}
+
+// applySubscriptionOverage 超额请求的使用记录标记为超额计费并关联原订阅
+func applySubscriptionOverage(apiKey *APIKey, usageLog *UsageLog) {
+	if apiKey == nil || apiKey.SubscriptionOverage == nil || usageLog == nil {
+		return
+	}
+	usageLog.BillingType = BillingTypeSubscriptionOverage
+	subscriptionID := apiKey.SubscriptionOverage.SubscriptionID
+	usageLog.SubscriptionID = &subscriptionID
+}
+
+// normalizeGroupOverage 规范化分组超额配置；fallback_group 策略必须指定有效的降级分组
+func (s *adminServiceImpl) normalizeGroupOverage(ctx context.Context, group *Group) error {
+	policy, err := NormalizeOveragePolicy(group.OveragePolicy)
+	if err != nil {
+		return err
+	}
+	group.OveragePolicy = policy
+	if group.OverageRateMultiplier < 0 {
+		return infraerrors.BadRequest("INVALID_OVERAGE_RATE_MULTIPLIER", "overage rate multiplier must be >= 0")
+	}
+	if group.OverageRateMultiplier == 0 {
+		group.OverageRateMultiplier = 1
+	}
+	if group.OverageGroupID != nil && *group.OverageGroupID <= 0 {
+		group.OverageGroupID = nil
+	}
+	if policy != OveragePolicyFallbackGroup {
+		return nil
+	}
+	if group.OverageGroupID == nil {
+		return infraerrors.BadRequest("OVERAGE_GROUP_REQUIRED", "overage group is required for fallback_group policy")
+	}
+	if err := s.validateOverageGroup(ctx, group, *group.OverageGroupID); err != nil {
+		return infraerrors.BadRequest("INVALID_OVERAGE_GROUP", err.Error())
+	}
+	return nil
+}
+
+// validateOverageGroup 校验超额降级分组：必须存在、为按量计费分组且平台一致
+func (s *adminServiceImpl) validateOverageGroup(ctx context.Context, group *Group, overageGroupID int64) error {
+	if overageGroupID == group.ID {
+		return fmt.Errorf("overage group cannot be the group itself")
+	}
+	target, err := s.groupRepo.GetByIDLite(ctx, overageGroupID)
+	if err != nil {
+		if errors.Is(err, ErrGroupNotFound) {
+			return fmt.Errorf("overage group not found")
+		}
+		return err
+	}
+	if target.IsSubscriptionType() {
+		return fmt.Errorf("overage group must be a standard (balance) group")
+	}
+	if target.Platform != group.Platform {
+		return fmt.Errorf("overage group must use the same platform")
+	}
+	return nil
+}
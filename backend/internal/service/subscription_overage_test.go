@@ -0,0 +1,88 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveOveragePolicy(t *testing.T) {
+	fallbackID := int64(7)
+	cases := []struct {
+		name  string
+		group *Group
+		want  string
+	}{
+		{"nil group", nil, OveragePolicyReject},
+		{"standard group ignores policy", &Group{SubscriptionType: SubscriptionTypeStandard, OveragePolicy: OveragePolicyBalance}, OveragePolicyReject},
+		{"balance", &Group{SubscriptionType: SubscriptionTypeSubscription, OveragePolicy: OveragePolicyBalance}, OveragePolicyBalance},
+		{"fallback without group", &Group{SubscriptionType: SubscriptionTypeSubscription, OveragePolicy: OveragePolicyFallbackGroup}, OveragePolicyReject},
+		{"fallback", &Group{SubscriptionType: SubscriptionTypeSubscription, OveragePolicy: OveragePolicyFallbackGroup, OverageGroupID: &fallbackID}, OveragePolicyFallbackGroup},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.group.EffectiveOveragePolicy())
+		})
+	}
+
+	policy, err := NormalizeOveragePolicy(" Balance ")
+	require.NoError(t, err)
+	require.Equal(t, OveragePolicyBalance, policy)
+	_, err = NormalizeOveragePolicy("free")
+	require.ErrorIs(t, err, ErrInvalidOveragePolicy)
+}
+
+func TestResolveOverage(t *testing.T) {
+	sub := &UserSubscription{ID: 42}
+	fallbackID := int64(7)
+	repo := &groupRepoStubForAdmin{getByID: &Group{ID: 7, Platform: PlatformAnthropic, Status: StatusActive, SubscriptionType: SubscriptionTypeStandard, RateMultiplier: 0.5}}
+	svc := &SubscriptionService{groupRepo: repo}
+	ctx := context.Background()
+
+	group := &Group{ID: 1, Platform: PlatformAnthropic, SubscriptionType: SubscriptionTypeSubscription, OveragePolicy: OveragePolicyReject}
+	_, _, err := svc.ResolveOverage(ctx, sub, group, ErrDailyLimitExceeded)
+	require.ErrorIs(t, err, ErrDailyLimitExceeded)
+
+	group.OveragePolicy = OveragePolicyBalance
+	group.OverageRateMultiplier = 1.5
+	overage, fallback, err := svc.ResolveOverage(ctx, sub, group, ErrDailyLimitExceeded)
+	require.NoError(t, err)
+	require.Nil(t, fallback)
+	require.Equal(t, &SubscriptionOverage{Policy: OveragePolicyBalance, SubscriptionID: 42, SourceGroupID: 1, RateMultiplier: 1.5}, overage)
+
+	group.OveragePolicy = OveragePolicyFallbackGroup
+	group.OverageGroupID = &fallbackID
+	overage, fallback, err = svc.ResolveOverage(ctx, sub, group, ErrDailyLimitExceeded)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), fallback.ID)
+	require.Equal(t, OveragePolicyFallbackGroup, overage.Policy)
+
+	// 降级分组平台不一致时退回拒绝
+	repo.getByID.Platform = PlatformOpenAI
+	_, _, err = svc.ResolveOverage(ctx, sub, group, ErrDailyLimitExceeded)
+	require.ErrorIs(t, err, ErrDailyLimitExceeded)
+}
+
+func TestSubscriptionOverageBilling(t *testing.T) {
+	groupID := int64(1)
+	apiKey := &APIKey{ID: 3, GroupID: &groupID, Group: &Group{ID: 1, RateMultiplier: 0}}
+	require.Equal(t, 0.0, overageRateMultiplier(apiKey, 0))
+
+	overageKey := apiKey.WithSubscriptionOverage(&SubscriptionOverage{Policy: OveragePolicyBalance, SubscriptionID: 42, SourceGroupID: 1, RateMultiplier: 1.2}, nil)
+	require.Nil(t, apiKey.SubscriptionOverage, "原 APIKey 不应被修改")
+	require.Equal(t, 1.2, overageRateMultiplier(overageKey, 0))
+
+	usageLog := &UsageLog{BillingType: BillingTypeBalance}
+	applySubscriptionOverage(overageKey, usageLog)
+	require.Equal(t, BillingTypeSubscriptionOverage, usageLog.BillingType)
+	require.Equal(t, int64(42), *usageLog.SubscriptionID)
+
+	fallback := &Group{ID: 7, RateMultiplier: 0.5}
+	fallbackKey := apiKey.WithSubscriptionOverage(&SubscriptionOverage{Policy: OveragePolicyFallbackGroup, SubscriptionID: 42, SourceGroupID: 1}, fallback)
+	require.Equal(t, int64(7), *fallbackKey.GroupID)
+	require.Equal(t, int64(1), *apiKey.GroupID)
+	require.Equal(t, 0.5, overageRateMultiplier(fallbackKey, fallback.RateMultiplier))
+}
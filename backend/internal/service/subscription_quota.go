@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// 订阅额度窗口，与 UserSubscription 的日/周/月滑动窗口对齐
+const (
+	SubscriptionQuotaWindowDaily   = "daily"
+	SubscriptionQuotaWindowWeekly  = "weekly"
+	SubscriptionQuotaWindowMonthly = "monthly"
+)
+
+// 订阅额度计量方式。输入 token 包含缓存创建与缓存读取 token，与上游计费口径一致。
+const (
+	SubscriptionQuotaMetricRequests     = "requests"
+	SubscriptionQuotaMetricInputTokens  = "input_tokens"
+	SubscriptionQuotaMetricOutputTokens = "output_tokens"
+	SubscriptionQuotaMetricTotalTokens  = "total_tokens"
+)
+
+const (
+	maxSubscriptionQuotas          = 20
+	maxSubscriptionQuotaModelSize  = 128
+	subscriptionQuotaMinCacheTTL   = time.Minute
+	subscriptionQuotaUsageLogLabel = "[SubscriptionQuota]"
+)
+
+var ErrSubscriptionQuotaExceeded = infraerrors.TooManyRequests("SUBSCRIPTION_QUOTA_EXCEEDED", "subscription quota exceeded")
+
+// SubscriptionQuota 订阅分组的 token / 请求次数额度，与 USD 限额同时生效，不受模型价格调整影响。
+// Model 为空表示统计所有模型，否则仅统计匹配的模型（支持末尾 * 通配，如 claude-opus-*）。
+type SubscriptionQuota struct {
+	Window string `json:"window"`
+	Metric string `json:"metric"`
+	Limit  int64  `json:"limit"`
+	Model  string `json:"model,omitempty"`
+}
+
+// Matches 额度是否作用于指定模型
+func (q SubscriptionQuota) Matches(model string) bool {
+	return q.Model == "" || matchModelPattern(q.Model, model)
+}
+
+// SubscriptionQuotaUsage 窗口内的 token / 请求次数用量
+type SubscriptionQuotaUsage struct {
+	Requests     int64
+	InputTokens  int64
+	OutputTokens int64
+}
+
+// Value 按计量方式取用量
+func (u SubscriptionQuotaUsage) Value(metric string) int64 {
+	switch metric {
+	case SubscriptionQuotaMetricRequests:
+		return u.Requests
+	case SubscriptionQuotaMetricInputTokens:
+		return u.InputTokens
+	case SubscriptionQuotaMetricOutputTokens:
+		return u.OutputTokens
+	case SubscriptionQuotaMetricTotalTokens:
+		return u.InputTokens + u.OutputTokens
+	default:
+		return 0
+	}
+}
+
+// SubscriptionQuotaUsageFromLog 单次请求计入额度的用量
+func SubscriptionQuotaUsageFromLog(usageLog *UsageLog) SubscriptionQuotaUsage {
+	return SubscriptionQuotaUsage{
+		Requests:     1,
+		InputTokens:  int64(usageLog.InputTokens + usageLog.CacheCreationTokens + usageLog.CacheReadTokens),
+		OutputTokens: int64(usageLog.OutputTokens),
+	}
+}
+
+// SubscriptionQuotaKey 额度计数器标识。同一窗口、同一模型范围的多条额度（如请求数与 token 数）共用一个计数器；
+// WindowStart 随窗口重置变化，旧窗口的计数器自然失效。
+type SubscriptionQuotaKey struct {
+	SubscriptionID int64
+	Window         string
+	WindowStart    time.Time
+	Model          string
+}
+
+// UsageQuotaProgress 订阅额度进度
+type UsageQuotaProgress struct {
+	Window          string    `json:"window"`
+	Metric          string    `json:"metric"`
+	Model           string    `json:"model,omitempty"`
+	Limit           int64     `json:"limit"`
+	Used            int64     `json:"used"`
+	Remaining       int64     `json:"remaining"`
+	Percentage      float64   `json:"percentage"`
+	WindowStart     time.Time `json:"window_start"`
+	ResetsAt        time.Time `json:"resets_at"`
+	ResetsInSeconds int64     `json:"resets_in_seconds"`
+}
+
+func invalidSubscriptionQuotas(format string, a ...any) error {
+	return infraerrors.Newf(http.StatusBadRequest, "SUBSCRIPTION_QUOTAS_INVALID", "invalid usage quotas: "+format, a...)
+}
+
+// NormalizeSubscriptionQuotas 校验并规范化订阅额度配置（窗口/计量方式小写、拒绝重复与非正额度）
+func NormalizeSubscriptionQuotas(quotas []SubscriptionQuota) ([]SubscriptionQuota, error) {
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+	if len(quotas) > maxSubscriptionQuotas {
+		return nil, invalidSubscriptionQuotas("at most %d quotas", maxSubscriptionQuotas)
+	}
+
+	type quotaIdentity struct{ window, metric, model string }
+	seen := make(map[quotaIdentity]struct{}, len(quotas))
+	out := make([]SubscriptionQuota, 0, len(quotas))
+	for i, q := range quotas {
+		q.Window = strings.ToLower(strings.TrimSpace(q.Window))
+		q.Metric = strings.ToLower(strings.TrimSpace(q.Metric))
+		q.Model = strings.TrimSpace(q.Model)
+		if _, ok := subscriptionQuotaWindowLength(q.Window); !ok {
+			return nil, invalidSubscriptionQuotas("quotas[%d]: unsupported window %q", i, q.Window)
+		}
+		switch q.Metric {
+		case SubscriptionQuotaMetricRequests, SubscriptionQuotaMetricInputTokens, SubscriptionQuotaMetricOutputTokens, SubscriptionQuotaMetricTotalTokens:
+		default:
+			return nil, invalidSubscriptionQuotas("quotas[%d]: unsupported metric %q", i, q.Metric)
+		}
+		if q.Limit <= 0 {
+			return nil, invalidSubscriptionQuotas("quotas[%d]: limit must be > 0", i)
+		}
+		if len(q.Model) > maxSubscriptionQuotaModelSize {
+			return nil, invalidSubscriptionQuotas("quotas[%d]: model is too long", i)
+		}
+		if q.Model == "*" {
+			q.Model = ""
+		}
+		if strings.Contains(strings.TrimSuffix(q.Model, "*"), "*") {
+			return nil, invalidSubscriptionQuotas("quotas[%d]: model wildcard is only allowed as suffix", i)
+		}
+		id := quotaIdentity{q.Window, q.Metric, q.Model}
+		if _, dup := seen[id]; dup {
+			return nil, invalidSubscriptionQuotas("quotas[%d]: duplicate %s %s quota", i, q.Window, q.Metric)
+		}
+		seen[id] = struct{}{}
+		out = append(out, q)
+	}
+	return out, nil
+}
+
+// HasUsageQuotas 分组是否配置了 token / 请求次数额度
+func (g *Group) HasUsageQuotas() bool {
+	return g != nil && g.IsSubscriptionType() && len(g.UsageQuotas) > 0
+}
+
+func subscriptionQuotaWindowLength(window string) (time.Duration, bool) {
+	switch window {
+	case SubscriptionQuotaWindowDaily:
+		return 24 * time.Hour, true
+	case SubscriptionQuotaWindowWeekly:
+		return 7 * 24 * time.Hour, true
+	case SubscriptionQuotaWindowMonthly:
+		return 30 * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
+
+// QuotaWindow 额度窗口的起止时间；窗口未激活时返回 false（尚无用量）
+func (s *UserSubscription) QuotaWindow(window string) (start, end time.Time, ok bool) {
+	length, ok := subscriptionQuotaWindowLength(window)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	var windowStart *time.Time
+	switch window {
+	case SubscriptionQuotaWindowDaily:
+		windowStart = s.DailyWindowStart
+	case SubscriptionQuotaWindowWeekly:
+		windowStart = s.WeeklyWindowStart
+	case SubscriptionQuotaWindowMonthly:
+		windowStart = s.MonthlyWindowStart
+	}
+	if windowStart == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return *windowStart, windowStart.Add(length), true
+}
+
+// subscriptionQuotaKeys 返回作用于指定模型的额度计数器（去重）
+func subscriptionQuotaKeys(sub *UserSubscription, quotas []SubscriptionQuota, model string) []SubscriptionQuotaKey {
+	var keys []SubscriptionQuotaKey
+	seen := make(map[SubscriptionQuotaKey]struct{})
+	for _, q := range quotas {
+		if !q.Matches(model) {
+			continue
+		}
+		start, _, ok := sub.QuotaWindow(q.Window)
+		if !ok {
+			continue
+		}
+		key := SubscriptionQuotaKey{SubscriptionID: sub.ID, Window: q.Window, WindowStart: start, Model: q.Model}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// checkSubscriptionQuotas 检查请求模型适用的 token / 请求次数额度。
+// 计数器优先读缓存，未命中时从 usage_logs 汇总并回填；额度按“已用量 >= 额度”判定，与 USD 限额一致。
+func (s *BillingCacheService) checkSubscriptionQuotas(ctx context.Context, group *Group, subscription *UserSubscription, model string) error {
+	if !group.HasUsageQuotas() {
+		return nil
+	}
+	usages := make(map[SubscriptionQuotaKey]SubscriptionQuotaUsage)
+	for _, key := range subscriptionQuotaKeys(subscription, group.UsageQuotas, model) {
+		usage, err := s.getSubscriptionQuotaUsage(ctx, key)
+		if err != nil {
+			if s.circuitBreaker != nil {
+				s.circuitBreaker.OnFailure(err)
+			}
+			log.Printf("ALERT: %s usage check failed for subscription %d: %v", subscriptionQuotaUsageLogLabel, subscription.ID, err)
+			return ErrBillingServiceUnavailable.WithCause(err)
+		}
+		usages[key] = usage
+	}
+
+	for _, q := range group.UsageQuotas {
+		if !q.Matches(model) {
+			continue
+		}
+		start, _, ok := subscription.QuotaWindow(q.Window)
+		if !ok {
+			continue
+		}
+		usage := usages[SubscriptionQuotaKey{SubscriptionID: subscription.ID, Window: q.Window, WindowStart: start, Model: q.Model}]
+		if usage.Value(q.Metric) >= q.Limit {
+			return subscriptionQuotaExceeded(q)
+		}
+	}
+	return nil
+}
+
+func subscriptionQuotaExceeded(q SubscriptionQuota) error {
+	scope := "all models"
+	if q.Model != "" {
+		scope = q.Model
+	}
+	return infraerrors.Newf(http.StatusTooManyRequests, "SUBSCRIPTION_QUOTA_EXCEEDED",
+		"%s %s quota exceeded for %s (limit %d)", q.Window, strings.ReplaceAll(q.Metric, "_", " "), scope, q.Limit,
+	).WithMetadata(map[string]string{
+		"window": q.Window,
+		"metric": q.Metric,
+		"model":  q.Model,
+		"limit":  strconv.FormatInt(q.Limit, 10),
+	})
+}
+
+func (s *BillingCacheService) getSubscriptionQuotaUsage(ctx context.Context, key SubscriptionQuotaKey) (SubscriptionQuotaUsage, error) {
+	if s.cache != nil {
+		if usage, err := s.cache.GetSubscriptionQuotaUsage(ctx, key); err == nil && usage != nil {
+			return *usage, nil
+		}
+	}
+	usage, err := s.subRepo.SumQuotaUsage(ctx, key.SubscriptionID, key.WindowStart, key.Model)
+	if err != nil {
+		return SubscriptionQuotaUsage{}, err
+	}
+	if s.cache != nil {
+		_ = s.enqueueCacheWrite(cacheWriteTask{
+			kind:       cacheWriteSetSubscriptionQuota,
+			quotaKey:   key,
+			quotaUsage: usage,
+		})
+	}
+	return usage, nil
+}
+
+func (s *BillingCacheService) setSubscriptionQuotaCache(ctx context.Context, key SubscriptionQuotaKey, usage SubscriptionQuotaUsage) {
+	if s.cache == nil {
+		return
+	}
+	length, ok := subscriptionQuotaWindowLength(key.Window)
+	if !ok {
+		return
+	}
+	ttl := time.Until(key.WindowStart.Add(length))
+	if ttl < subscriptionQuotaMinCacheTTL {
+		ttl = subscriptionQuotaMinCacheTTL
+	}
+	if err := s.cache.SetSubscriptionQuotaUsage(ctx, key, usage, ttl); err != nil {
+		log.Printf("Warning: set subscription quota cache failed for subscription %d: %v", key.SubscriptionID, err)
+	}
+}
+
+// QueueRecordSubscriptionQuotaUsage 异步累加订阅额度计数器。
+// usage_logs 为权威数据，计数器仅在已缓存时累加，未缓存时由下一次资格检查从数据库回填。
+func (s *BillingCacheService) QueueRecordSubscriptionQuotaUsage(subscription *UserSubscription, group *Group, model string, delta SubscriptionQuotaUsage) {
+	if s.cache == nil || subscription == nil || !group.HasUsageQuotas() {
+		return
+	}
+	for _, key := range subscriptionQuotaKeys(subscription, group.UsageQuotas, model) {
+		task := cacheWriteTask{
+			kind:       cacheWriteIncrSubscriptionQuota,
+			userID:     subscription.UserID,
+			groupID:    group.ID,
+			quotaKey:   key,
+			quotaUsage: delta,
+		}
+		if s.enqueueCacheWrite(task) {
+			continue
+		}
+		// 队列满时同步回退，避免额度计数长期偏小
+		ctx, cancel := context.WithTimeout(context.Background(), cacheWriteTimeout)
+		if err := s.cache.IncrSubscriptionQuotaUsage(ctx, key, delta); err != nil {
+			log.Printf("Warning: incr subscription quota cache fallback failed for subscription %d: %v", key.SubscriptionID, err)
+		}
+		cancel()
+	}
+}
+
+// recordSubscriptionQuotaUsage 网关记账时累加订阅额度（超额计费的请求不计入订阅额度）
+func recordSubscriptionQuotaUsage(billingCache *BillingCacheService, subscription *UserSubscription, group *Group, usageLog *UsageLog) {
+	if billingCache == nil || usageLog == nil || usageLog.BillingType != BillingTypeSubscription {
+		return
+	}
+	billingCache.QueueRecordSubscriptionQuotaUsage(subscription, group, usageLog.Model, SubscriptionQuotaUsageFromLog(usageLog))
+}
+
+// fillQuotaProgress 计算订阅额度进度；窗口未激活的额度按 0 用量展示
+func (s *SubscriptionService) fillQuotaProgress(ctx context.Context, sub *UserSubscription, group *Group, progress *SubscriptionProgress) {
+	if !group.HasUsageQuotas() {
+		return
+	}
+	now := time.Now()
+	usages := make(map[SubscriptionQuotaKey]SubscriptionQuotaUsage)
+	progress.Quotas = make([]UsageQuotaProgress, 0, len(group.UsageQuotas))
+	for _, q := range group.UsageQuotas {
+		item := UsageQuotaProgress{Window: q.Window, Metric: q.Metric, Model: q.Model, Limit: q.Limit, Remaining: q.Limit}
+		if start, end, ok := sub.QuotaWindow(q.Window); ok {
+			key := SubscriptionQuotaKey{SubscriptionID: sub.ID, Window: q.Window, WindowStart: start, Model: q.Model}
+			usage, cached := usages[key]
+			if !cached {
+				var err error
+				usage, err = s.userSubRepo.SumQuotaUsage(ctx, sub.ID, start, q.Model)
+				if err != nil {
+					log.Printf("%s sum usage failed: subscription=%d err=%v", subscriptionQuotaUsageLogLabel, sub.ID, err)
+				}
+				usages[key] = usage
+			}
+			item.Used = usage.Value(q.Metric)
+			item.Remaining = q.Limit - item.Used
+			if item.Remaining < 0 {
+				item.Remaining = 0
+			}
+			item.Percentage = float64(item.Used) / float64(q.Limit) * 100
+			if item.Percentage > 100 {
+				item.Percentage = 100
+			}
+			item.WindowStart = start
+			item.ResetsAt = end
+			item.ResetsInSeconds = int64(end.Sub(now).Seconds())
+			if item.ResetsInSeconds < 0 {
+				item.ResetsInSeconds = 0
+			}
+		}
+		progress.Quotas = append(progress.Quotas, item)
+	}
+}
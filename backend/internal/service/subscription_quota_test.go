@@ -0,0 +1,109 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSubscriptionQuotas(t *testing.T) {
+	quotas, err := NormalizeSubscriptionQuotas([]SubscriptionQuota{
+		{Window: " Daily ", Metric: "REQUESTS", Limit: 50, Model: " claude-opus-* "},
+		{Window: "monthly", Metric: "total_tokens", Limit: 1000000, Model: "*"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []SubscriptionQuota{
+		{Window: SubscriptionQuotaWindowDaily, Metric: SubscriptionQuotaMetricRequests, Limit: 50, Model: "claude-opus-*"},
+		{Window: SubscriptionQuotaWindowMonthly, Metric: SubscriptionQuotaMetricTotalTokens, Limit: 1000000},
+	}, quotas)
+
+	invalid := [][]SubscriptionQuota{
+		{{Window: "hourly", Metric: "requests", Limit: 1}},
+		{{Window: "daily", Metric: "cost", Limit: 1}},
+		{{Window: "daily", Metric: "requests", Limit: 0}},
+		{{Window: "daily", Metric: "requests", Limit: 1, Model: "claude-*-opus"}},
+		{{Window: "daily", Metric: "requests", Limit: 1}, {Window: "DAILY", Metric: "requests", Limit: 2}},
+	}
+	for _, in := range invalid {
+		_, err := NormalizeSubscriptionQuotas(in)
+		require.Error(t, err, "%+v", in)
+	}
+}
+
+type quotaSubRepoStub struct {
+	UserSubscriptionRepository
+	sub    *UserSubscription
+	usages map[string]SubscriptionQuotaUsage
+	sums   int
+}
+
+func (r *quotaSubRepoStub) GetActiveByUserIDAndGroupID(ctx context.Context, userID, groupID int64) (*UserSubscription, error) {
+	return r.sub, nil
+}
+
+func (r *quotaSubRepoStub) SumQuotaUsage(ctx context.Context, id int64, since time.Time, modelPattern string) (SubscriptionQuotaUsage, error) {
+	r.sums++
+	return r.usages[modelPattern], nil
+}
+
+func TestCheckBillingEligibilitySubscriptionQuotas(t *testing.T) {
+	ctx := context.Background()
+	windowStart := time.Now().Add(-time.Hour)
+	sub := &UserSubscription{
+		ID:               7,
+		UserID:           1,
+		GroupID:          3,
+		Status:           SubscriptionStatusActive,
+		ExpiresAt:        time.Now().Add(24 * time.Hour),
+		DailyWindowStart: &windowStart,
+	}
+	group := &Group{
+		ID:               3,
+		SubscriptionType: SubscriptionTypeSubscription,
+		UsageQuotas: []SubscriptionQuota{
+			{Window: SubscriptionQuotaWindowDaily, Metric: SubscriptionQuotaMetricRequests, Limit: 50, Model: "claude-opus-*"},
+			{Window: SubscriptionQuotaWindowDaily, Metric: SubscriptionQuotaMetricTotalTokens, Limit: 10000},
+			// 周窗口未激活，不参与检查
+			{Window: SubscriptionQuotaWindowWeekly, Metric: SubscriptionQuotaMetricRequests, Limit: 1},
+		},
+	}
+	repo := &quotaSubRepoStub{sub: sub, usages: map[string]SubscriptionQuotaUsage{
+		"claude-opus-*": {Requests: 50, InputTokens: 100, OutputTokens: 100},
+		"":              {Requests: 80, InputTokens: 4000, OutputTokens: 1000},
+	}}
+	svc := NewBillingCacheService(nil, nil, repo, nil, &config.Config{})
+	t.Cleanup(svc.Stop)
+	user := &User{ID: 1}
+	apiKey := &APIKey{ID: 1, UserID: 1}
+
+	err := svc.CheckBillingEligibility(ctx, user, apiKey, group, sub, "claude-opus-4-5")
+	require.ErrorIs(t, err, ErrSubscriptionQuotaExceeded)
+	require.NoError(t, svc.CheckBillingEligibility(ctx, user, apiKey, group, sub, "claude-sonnet-4-5"))
+
+	repo.usages[""] = SubscriptionQuotaUsage{Requests: 81, InputTokens: 6000, OutputTokens: 4000}
+	err = svc.CheckBillingEligibility(ctx, user, apiKey, group, sub, "claude-sonnet-4-5")
+	require.ErrorIs(t, err, ErrSubscriptionQuotaExceeded)
+}
+
+func TestSubscriptionQuotaKeysShareCounters(t *testing.T) {
+	windowStart := time.Now()
+	sub := &UserSubscription{ID: 1, DailyWindowStart: &windowStart, MonthlyWindowStart: &windowStart}
+	quotas := []SubscriptionQuota{
+		{Window: SubscriptionQuotaWindowDaily, Metric: SubscriptionQuotaMetricRequests, Limit: 10},
+		{Window: SubscriptionQuotaWindowDaily, Metric: SubscriptionQuotaMetricOutputTokens, Limit: 10},
+		{Window: SubscriptionQuotaWindowDaily, Metric: SubscriptionQuotaMetricRequests, Limit: 5, Model: "gpt-5*"},
+		{Window: SubscriptionQuotaWindowMonthly, Metric: SubscriptionQuotaMetricInputTokens, Limit: 10},
+	}
+	require.Len(t, subscriptionQuotaKeys(sub, quotas, "claude-opus-4-5"), 2)
+	require.Len(t, subscriptionQuotaKeys(sub, quotas, "gpt-5.1"), 3)
+
+	usage := SubscriptionQuotaUsageFromLog(&UsageLog{InputTokens: 10, CacheCreationTokens: 5, CacheReadTokens: 20, OutputTokens: 7})
+	require.Equal(t, int64(1), usage.Value(SubscriptionQuotaMetricRequests))
+	require.Equal(t, int64(35), usage.Value(SubscriptionQuotaMetricInputTokens))
+	require.Equal(t, int64(42), usage.Value(SubscriptionQuotaMetricTotalTokens))
+}
@@ -18,14 +18,14 @@ import (
 type SubscriptionReminderService struct {
 	userRepo          UserRepository
 	userSubRepo       UserSubscriptionRepository
-	redis             *redis.Client
+	redis             redis.UniversalClient
 	emailQueueService *EmailQueueService
 }
 
 func NewSubscriptionReminderService(
 	userRepo UserRepository,
 	userSubRepo UserSubscriptionRepository,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	emailQueueService *EmailQueueService,
 ) *SubscriptionReminderService {
 	return &SubscriptionReminderService{
@@ -558,6 +558,10 @@ type SubscriptionProgress struct {
 	Daily         *UsageWindowProgress `json:"daily,omitempty"`
 	Weekly        *UsageWindowProgress `json:"weekly,omitempty"`
 	Monthly       *UsageWindowProgress `json:"monthly,omitempty"`
+	// OveragePolicy 额度用尽后的处理方式：reject / balance / fallback_group
+	OveragePolicy string `json:"overage_policy"`
+	// Quotas 分组配置的 token / 请求次数额度进度
+	Quotas []UsageQuotaProgress `json:"quotas,omitempty"`
 }
 
 // UsageWindowProgress 使用窗口进度
@@ -569,6 +573,8 @@ type UsageWindowProgress struct {
 	WindowStart     time.Time `json:"window_start"`
 	ResetsAt        time.Time `json:"resets_at"`
 	ResetsInSeconds int64     `json:"resets_in_seconds"`
+	// OverageUSD 本窗口内额度用尽后按超额策略扣除的余额
+	OverageUSD float64 `json:"overage_usd"`
 }
 
 // GetSubscriptionProgress 获取订阅使用进度
@@ -591,6 +597,7 @@ func (s *SubscriptionService) GetSubscriptionProgress(ctx context.Context, subsc
 		GroupName:     group.Name,
 		ExpiresAt:     sub.ExpiresAt,
 		ExpiresInDays: sub.DaysRemaining(),
+		OveragePolicy: group.EffectiveOveragePolicy(),
 	}
 
 	// 日进度
@@ -665,9 +672,28 @@ func (s *SubscriptionService) GetSubscriptionProgress(ctx context.Context, subsc
 		}
 	}
 
+	s.fillOverageProgress(ctx, sub.ID, progress.Daily, progress.Weekly, progress.Monthly)
+	s.fillQuotaProgress(ctx, sub, group, progress)
+
 	return progress, nil
 }
 
+// fillOverageProgress 统计各窗口内的超额扣费；超额记录按订阅关联在 usage_logs 中，
+// 即使分组后来改回 reject 策略也照常展示历史超额。
+func (s *SubscriptionService) fillOverageProgress(ctx context.Context, subscriptionID int64, windows ...*UsageWindowProgress) {
+	for _, w := range windows {
+		if w == nil {
+			continue
+		}
+		overage, err := s.userSubRepo.SumOverageCost(ctx, subscriptionID, w.WindowStart)
+		if err != nil {
+			log.Printf("[Subscription] sum overage cost failed: subscription=%d err=%v", subscriptionID, err)
+			continue
+		}
+		w.OverageUSD = overage
+	}
+}
+
 // GetUserSubscriptionsWithProgress 获取用户所有订阅及进度
 func (s *SubscriptionService) GetUserSubscriptionsWithProgress(ctx context.Context, userID int64) ([]SubscriptionProgress, error) {
 	subs, err := s.userSubRepo.ListActiveByUserID(ctx, userID)
@@ -695,6 +721,9 @@ func (s *SubscriptionService) ValidateSubscription(ctx context.Context, sub *Use
 	if sub.Status == SubscriptionStatusSuspended {
 		return ErrSubscriptionSuspended
 	}
+	if sub.Status == SubscriptionStatusPaused {
+		return ErrSubscriptionPaused
+	}
 	if sub.IsExpired() {
 		// 更新状态
 		_ = s.userSubRepo.UpdateStatus(ctx, sub.ID, SubscriptionStatusExpired)
@@ -150,22 +150,8 @@ func (s *TotpService) InitiateSetup(ctx context.Context, userID int64, emailCode
 	}
 
 	// Verify identity based on email verification setting
-	if s.settingService.IsEmailVerifyEnabled(ctx) {
-		// Email verification enabled - verify email code
-		if emailCode == "" {
-			return nil, ErrVerifyCodeRequired
-		}
-		if err := s.emailService.VerifyCode(ctx, user.Email, emailCode); err != nil {
-			return nil, err
-		}
-	} else {
-		// Email verification disabled - verify password
-		if password == "" {
-			return nil, ErrPasswordRequired
-		}
-		if !user.CheckPassword(password) {
-			return nil, ErrPasswordIncorrect
-		}
+	if err := s.VerifyIdentity(ctx, user, emailCode, password); err != nil {
+		return nil, err
 	}
 
 	// Generate a new TOTP key
@@ -297,29 +283,37 @@ func (s *TotpService) Disable(ctx context.Context, userID int64, emailCode, pass
 	}
 
 	// Verify identity based on email verification setting
+	if err := s.VerifyIdentity(ctx, user, emailCode, password); err != nil {
+		return err
+	}
+
+	// Disable TOTP
+	if err := s.userRepo.DisableTotp(ctx, userID); err != nil {
+		return fmt.Errorf("disable totp: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyIdentity re-authenticates a user before a sensitive 2FA operation
+// (TOTP setup/disable, passkey management, recovery code generation).
+// If email verification is enabled, emailCode is required; otherwise password is required
+func (s *TotpService) VerifyIdentity(ctx context.Context, user *User, emailCode, password string) error {
 	if s.settingService.IsEmailVerifyEnabled(ctx) {
 		// Email verification enabled - verify email code
 		if emailCode == "" {
 			return ErrVerifyCodeRequired
 		}
-		if err := s.emailService.VerifyCode(ctx, user.Email, emailCode); err != nil {
-			return err
-		}
-	} else {
-		// Email verification disabled - verify password
-		if password == "" {
-			return ErrPasswordRequired
-		}
-		if !user.CheckPassword(password) {
-			return ErrPasswordIncorrect
-		}
+		return s.emailService.VerifyCode(ctx, user.Email, emailCode)
 	}
 
-	// Disable TOTP
-	if err := s.userRepo.DisableTotp(ctx, userID); err != nil {
-		return fmt.Errorf("disable totp: %w", err)
+	// Email verification disabled - verify password
+	if password == "" {
+		return ErrPasswordRequired
+	}
+	if !user.CheckPassword(password) {
+		return ErrPasswordIncorrect
 	}
-
 	return nil
 }
 
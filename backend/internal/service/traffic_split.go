@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+const (
+	// TrafficArmControl 未命中任何实验组的流量（基线）
+	TrafficArmControl = "control"
+
+	maxTrafficSplitArms      = 8
+	maxTrafficArmNameSize    = 64
+	maxTrafficArmAccountIDs  = 200
+	maxTrafficArmModels      = 50
+	trafficSplitBucketCount  = 10000
+	trafficSplitWeightFactor = trafficSplitBucketCount / 100
+)
+
+// TrafficSplit 分组灰度 / A-B 分流配置。
+//
+// 每个请求按 (分组, 会话) 哈希到 [0, 10000) 的桶，按 Arms 顺序累加权重划分区间，
+// 同一会话始终落在同一实验组，粘性会话不会在实验组之间漂移；未落入任何实验组的流量为 control。
+// 实验组的账号子集只服务该实验组：control 流量不会调度到任何实验组声明的账号。
+type TrafficSplit struct {
+	Enabled bool              `json:"enabled"`
+	Arms    []TrafficSplitArm `json:"arms"`
+}
+
+// TrafficSplitArm 一个实验组。AccountIDs 与 Model 至少配置一项。
+type TrafficSplitArm struct {
+	Name string `json:"name"`
+	// Weight 流量占比（百分比，支持两位小数），所有实验组之和不超过 100
+	Weight float64 `json:"weight"`
+	// Models 仅对这些请求模型生效（支持末尾 * 通配），为空表示所有模型；
+	// 桶区间与模型无关，落入区间但模型不匹配的请求归入 control
+	Models []string `json:"models,omitempty"`
+	// Model 目标模型：非空时把请求模型改写为该模型（计费按目标模型）
+	Model string `json:"model,omitempty"`
+	// AccountIDs 实验组专用账号；为空表示使用 control 账号池。
+	// 专用账号全部不可调度时回退到 control 账号池（仅改写模型的部分仍然生效）
+	AccountIDs []int64 `json:"account_ids,omitempty"`
+}
+
+func invalidTrafficSplit(format string, a ...any) error {
+	return infraerrors.Newf(http.StatusBadRequest, "TRAFFIC_SPLIT_INVALID", "invalid traffic split: "+format, a...)
+}
+
+// NormalizeTrafficSplit 校验并规范化分流配置；未启用且没有实验组时返回 nil（视为未配置）
+func NormalizeTrafficSplit(split *TrafficSplit) (*TrafficSplit, error) {
+	if split == nil || (!split.Enabled && len(split.Arms) == 0) {
+		return nil, nil
+	}
+	if len(split.Arms) > maxTrafficSplitArms {
+		return nil, invalidTrafficSplit("at most %d arms", maxTrafficSplitArms)
+	}
+
+	out := &TrafficSplit{Enabled: split.Enabled, Arms: make([]TrafficSplitArm, 0, len(split.Arms))}
+	seenNames := map[string]struct{}{}
+	seenAccounts := map[int64]int{}
+	totalBuckets := 0
+	for i, arm := range split.Arms {
+		arm.Name = strings.TrimSpace(arm.Name)
+		arm.Model = strings.TrimSpace(arm.Model)
+		if arm.Name == "" {
+			return nil, invalidTrafficSplit("arms[%d]: name is required", i)
+		}
+		if len(arm.Name) > maxTrafficArmNameSize {
+			return nil, invalidTrafficSplit("arms[%d]: name is too long", i)
+		}
+		if strings.EqualFold(arm.Name, TrafficArmControl) {
+			return nil, invalidTrafficSplit("arms[%d]: name %q is reserved", i, TrafficArmControl)
+		}
+		if _, dup := seenNames[arm.Name]; dup {
+			return nil, invalidTrafficSplit("arms[%d]: duplicate name %q", i, arm.Name)
+		}
+		seenNames[arm.Name] = struct{}{}
+
+		if arm.Weight <= 0 || arm.Weight > 100 {
+			return nil, invalidTrafficSplit("arms[%d]: weight must be in (0, 100]", i)
+		}
+		totalBuckets += trafficArmBuckets(arm.Weight)
+		if totalBuckets > trafficSplitBucketCount {
+			return nil, invalidTrafficSplit("total weight of arms must not exceed 100")
+		}
+
+		if len(arm.Models) > maxTrafficArmModels {
+			return nil, invalidTrafficSplit("arms[%d]: at most %d models", i, maxTrafficArmModels)
+		}
+		models := make([]string, 0, len(arm.Models))
+		for _, m := range arm.Models {
+			if m = strings.TrimSpace(m); m != "" {
+				models = append(models, m)
+			}
+		}
+		arm.Models = models
+
+		if len(arm.AccountIDs) > maxTrafficArmAccountIDs {
+			return nil, invalidTrafficSplit("arms[%d]: at most %d accounts", i, maxTrafficArmAccountIDs)
+		}
+		accountIDs := make([]int64, 0, len(arm.AccountIDs))
+		for _, id := range arm.AccountIDs {
+			if id <= 0 {
+				return nil, invalidTrafficSplit("arms[%d]: invalid account id %d", i, id)
+			}
+			if prev, dup := seenAccounts[id]; dup {
+				if prev == i {
+					continue
+				}
+				return nil, invalidTrafficSplit("arms[%d]: account %d already belongs to arms[%d]", i, id, prev)
+			}
+			seenAccounts[id] = i
+			accountIDs = append(accountIDs, id)
+		}
+		arm.AccountIDs = accountIDs
+
+		if arm.Model == "" && len(arm.AccountIDs) == 0 {
+			return nil, invalidTrafficSplit("arms[%d]: either model or account_ids is required", i)
+		}
+		out.Arms = append(out.Arms, arm)
+	}
+	return out, nil
+}
+
+func trafficArmBuckets(weight float64) int {
+	return int(weight*trafficSplitWeightFactor + 0.5)
+}
+
+// matchesModel 判断请求模型是否进入该实验组
+func (a *TrafficSplitArm) matchesModel(model string) bool {
+	if len(a.Models) == 0 {
+		return true
+	}
+	for _, pattern := range a.Models {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(model, prefix) {
+				return true
+			}
+		} else if pattern == model {
+			return true
+		}
+	}
+	return false
+}
+
+// trafficSplitBucket 分组内会话的稳定桶号
+func trafficSplitBucket(groupID int64, subject string) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strconv.FormatInt(groupID, 10)))
+	_, _ = h.Write([]byte{':'})
+	_, _ = h.Write([]byte(subject))
+	return int(h.Sum64() % trafficSplitBucketCount)
+}
+
+// AssignTrafficArm 为请求分配实验组，返回 nil 表示 control（或分组未启用分流）。
+// subject 为会话标识（无会话时由调用方传入 API Key 等稳定标识）。
+func AssignTrafficArm(group *Group, subject, model string) *TrafficSplitArm {
+	if group == nil || group.TrafficSplit == nil || !group.TrafficSplit.Enabled || len(group.TrafficSplit.Arms) == 0 {
+		return nil
+	}
+	bucket := trafficSplitBucket(group.ID, subject)
+	upper := 0
+	for i := range group.TrafficSplit.Arms {
+		arm := &group.TrafficSplit.Arms[i]
+		upper += trafficArmBuckets(arm.Weight)
+		if bucket < upper {
+			if arm.matchesModel(model) {
+				return arm
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// TrafficRoute 请求的分流结果，经 context 传递给调度器
+type TrafficRoute struct {
+	arm *TrafficSplitArm
+	// armAccounts 实验组专用账号；reserved 所有实验组声明的账号（control 不可使用）
+	armAccounts map[int64]struct{}
+	reserved    map[int64]struct{}
+	fellBack    atomic.Bool
+}
+
+// NewTrafficRoute 为请求构造分流结果；分组未启用分流时返回 nil
+func NewTrafficRoute(group *Group, arm *TrafficSplitArm) *TrafficRoute {
+	if group == nil || group.TrafficSplit == nil || !group.TrafficSplit.Enabled {
+		return nil
+	}
+	route := &TrafficRoute{arm: arm, reserved: map[int64]struct{}{}}
+	for _, a := range group.TrafficSplit.Arms {
+		for _, id := range a.AccountIDs {
+			route.reserved[id] = struct{}{}
+		}
+	}
+	if arm != nil && len(arm.AccountIDs) > 0 {
+		route.armAccounts = make(map[int64]struct{}, len(arm.AccountIDs))
+		for _, id := range arm.AccountIDs {
+			route.armAccounts[id] = struct{}{}
+		}
+	}
+	return route
+}
+
+// Arm 分配到的实验组，control 时为 nil
+func (r *TrafficRoute) Arm() *TrafficSplitArm {
+	if r == nil {
+		return nil
+	}
+	return r.arm
+}
+
+// Name 实际生效的实验组名称：仅指定账号子集的实验组回退到 control 账号池后记为 control
+func (r *TrafficRoute) Name() string {
+	if r == nil {
+		return ""
+	}
+	if r.arm == nil || (r.fellBack.Load() && r.arm.Model == "") {
+		return TrafficArmControl
+	}
+	return r.arm.Name
+}
+
+func (r *TrafficRoute) usesArmAccounts() bool {
+	return r.armAccounts != nil && !r.fellBack.Load()
+}
+
+// AllowsAccount 判断账号是否属于当前请求的账号池
+func (r *TrafficRoute) AllowsAccount(accountID int64) bool {
+	if r == nil {
+		return true
+	}
+	if r.usesArmAccounts() {
+		_, ok := r.armAccounts[accountID]
+		return ok
+	}
+	_, reserved := r.reserved[accountID]
+	return !reserved
+}
+
+// filter 按账号池过滤候选账号；实验组专用账号全部不可调度时回退到 control 账号池
+func (r *TrafficRoute) filter(accounts []Account) []Account {
+	if r.usesArmAccounts() {
+		out := accounts[:0:0]
+		for i := range accounts {
+			if _, ok := r.armAccounts[accounts[i].ID]; ok {
+				out = append(out, accounts[i])
+			}
+		}
+		if len(out) > 0 {
+			return out
+		}
+		if r.fellBack.CompareAndSwap(false, true) {
+			log.Printf("[TrafficSplit] arm=%s has no schedulable accounts, falling back to control pool", r.arm.Name)
+		}
+	}
+	if len(r.reserved) == 0 {
+		return accounts
+	}
+	out := accounts[:0:0]
+	for i := range accounts {
+		if _, reserved := r.reserved[accounts[i].ID]; !reserved {
+			out = append(out, accounts[i])
+		}
+	}
+	return out
+}
+
+// WithTrafficRoute 将分流结果写入 context
+func WithTrafficRoute(ctx context.Context, route *TrafficRoute) context.Context {
+	if route == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxkey.TrafficRoute, route)
+}
+
+// TrafficRouteFromContext 读取网关 handler 写入的分流结果，未启用分流时返回 nil
+func TrafficRouteFromContext(ctx context.Context) *TrafficRoute {
+	if ctx == nil {
+		return nil
+	}
+	route, _ := ctx.Value(ctxkey.TrafficRoute).(*TrafficRoute)
+	return route
+}
+
+// isAccountAllowedForTrafficRoute 粘性会话命中的账号必须属于当前请求的账号池
+func isAccountAllowedForTrafficRoute(ctx context.Context, accountID int64) bool {
+	return TrafficRouteFromContext(ctx).AllowsAccount(accountID)
+}
+
+// filterAccountsForTrafficRoute 按分流结果过滤候选账号
+func filterAccountsForTrafficRoute(ctx context.Context, accounts []Account) []Account {
+	route := TrafficRouteFromContext(ctx)
+	if route == nil {
+		return accounts
+	}
+	return route.filter(accounts)
+}
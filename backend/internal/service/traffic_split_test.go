@@ -0,0 +1,106 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTrafficSplit(t *testing.T) {
+	split, err := NormalizeTrafficSplit(&TrafficSplit{})
+	require.NoError(t, err)
+	require.Nil(t, split)
+
+	split, err = NormalizeTrafficSplit(&TrafficSplit{Enabled: true, Arms: []TrafficSplitArm{
+		{Name: " canary ", Weight: 5, Models: []string{" claude-*", ""}, AccountIDs: []int64{3, 3}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "canary", split.Arms[0].Name)
+	require.Equal(t, []string{"claude-*"}, split.Arms[0].Models)
+	require.Equal(t, []int64{3}, split.Arms[0].AccountIDs)
+
+	invalid := []*TrafficSplit{
+		{Enabled: true, Arms: []TrafficSplitArm{{Name: "control", Weight: 5, Model: "m"}}},
+		{Enabled: true, Arms: []TrafficSplitArm{{Name: "a", Weight: 5, Model: "m"}, {Name: "a", Weight: 5, Model: "m"}}},
+		{Enabled: true, Arms: []TrafficSplitArm{{Name: "a", Weight: 0, Model: "m"}}},
+		{Enabled: true, Arms: []TrafficSplitArm{{Name: "a", Weight: 60, Model: "m"}, {Name: "b", Weight: 50, Model: "m"}}},
+		{Enabled: true, Arms: []TrafficSplitArm{{Name: "a", Weight: 5}}},
+		{Enabled: true, Arms: []TrafficSplitArm{{Name: "a", Weight: 5, AccountIDs: []int64{1}}, {Name: "b", Weight: 5, AccountIDs: []int64{1}}}},
+	}
+	for i, in := range invalid {
+		_, err := NormalizeTrafficSplit(in)
+		require.Error(t, err, "case %d", i)
+	}
+}
+
+func TestAssignTrafficArmDeterministic(t *testing.T) {
+	group := &Group{ID: 7, TrafficSplit: &TrafficSplit{Enabled: true, Arms: []TrafficSplitArm{
+		{Name: "canary", Weight: 5, Model: "claude-new"},
+	}}}
+
+	hits := 0
+	const n = 20000
+	for i := 0; i < n; i++ {
+		subject := fmt.Sprintf("session-%d", i)
+		arm := AssignTrafficArm(group, subject, "claude-old")
+		// 同一会话多次分配结果一致
+		require.Equal(t, arm, AssignTrafficArm(group, subject, "claude-old"))
+		if arm != nil {
+			hits++
+		}
+	}
+	require.InDelta(t, 0.05, float64(hits)/n, 0.01)
+
+	group.TrafficSplit.Enabled = false
+	require.Nil(t, AssignTrafficArm(group, "session-1", "claude-old"))
+}
+
+func TestAssignTrafficArmModelFilter(t *testing.T) {
+	group := &Group{ID: 1, TrafficSplit: &TrafficSplit{Enabled: true, Arms: []TrafficSplitArm{
+		{Name: "all", Weight: 100, Models: []string{"claude-sonnet-*"}, AccountIDs: []int64{9}},
+	}}}
+	require.NotNil(t, AssignTrafficArm(group, "s", "claude-sonnet-4-5"))
+	// 落入区间但模型不匹配归入 control
+	require.Nil(t, AssignTrafficArm(group, "s", "gpt-5"))
+}
+
+func TestTrafficRouteFilter(t *testing.T) {
+	group := &Group{ID: 1, TrafficSplit: &TrafficSplit{Enabled: true, Arms: []TrafficSplitArm{
+		{Name: "proxy-b", Weight: 10, AccountIDs: []int64{3, 4}},
+	}}}
+	accounts := []Account{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	ids := func(list []Account) []int64 {
+		out := make([]int64, 0, len(list))
+		for _, a := range list {
+			out = append(out, a.ID)
+		}
+		return out
+	}
+
+	// control 不使用实验组账号
+	control := NewTrafficRoute(group, nil)
+	require.Equal(t, TrafficArmControl, control.Name())
+	require.Equal(t, []int64{1, 2}, ids(filterAccountsForTrafficRoute(WithTrafficRoute(context.Background(), control), accounts)))
+	require.False(t, control.AllowsAccount(3))
+
+	// 实验组只使用专用账号
+	route := NewTrafficRoute(group, &group.TrafficSplit.Arms[0])
+	ctx := WithTrafficRoute(context.Background(), route)
+	require.Equal(t, []int64{3, 4}, ids(filterAccountsForTrafficRoute(ctx, accounts)))
+	require.True(t, isAccountAllowedForTrafficRoute(ctx, 3))
+	require.False(t, isAccountAllowedForTrafficRoute(ctx, 1))
+	require.Equal(t, "proxy-b", route.Name())
+
+	// 专用账号全部不可调度时回退到 control 账号池，并记为 control
+	require.Equal(t, []int64{1, 2}, ids(filterAccountsForTrafficRoute(ctx, accounts[:2])))
+	require.Equal(t, TrafficArmControl, route.Name())
+	require.True(t, route.AllowsAccount(1))
+
+	// 未启用分流时不过滤
+	require.Nil(t, NewTrafficRoute(&Group{ID: 2}, nil))
+	require.Len(t, filterAccountsForTrafficRoute(context.Background(), accounts), 4)
+}
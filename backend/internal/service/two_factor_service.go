@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+var (
+	ErrRecoveryCodeInvalid  = infraerrors.BadRequest("RECOVERY_CODE_INVALID", "invalid or already used recovery code")
+	ErrTwoFactorNotEnabled  = infraerrors.BadRequest("TWO_FACTOR_NOT_ENABLED", "enable totp or a passkey before generating recovery codes")
+	ErrAdmin2FARequired     = infraerrors.Forbidden("ADMIN_2FA_REQUIRED", "administrators must enable two-factor authentication (totp or passkey) to access the admin console")
+	ErrTwoFactorCodeMissing = infraerrors.BadRequest("TWO_FACTOR_CODE_REQUIRED", "totp code or recovery code is required")
+)
+
+// 两步验证方式
+const (
+	TwoFactorMethodTotp         = "totp"
+	TwoFactorMethodWebAuthn     = "webauthn"
+	TwoFactorMethodRecoveryCode = "recovery_code"
+)
+
+const (
+	recoveryCodeCount = 10
+	// 恢复码字母表去掉了易混淆的 0/1/i/l/o
+	recoveryCodeAlphabet = "23456789abcdefghjkmnpqrstuvwxyz"
+	recoveryCodeLength   = 10
+)
+
+// RecoveryCodeRepository 持久化恢复码摘要
+type RecoveryCodeRepository interface {
+	// ReplaceAll 删除用户现有恢复码并写入新的摘要
+	ReplaceAll(ctx context.Context, userID int64, codeHashes []string) error
+	// Consume 将未使用的恢复码标记为已使用，返回是否命中
+	Consume(ctx context.Context, userID int64, codeHash string, usedAt time.Time) (bool, error)
+	CountUnused(ctx context.Context, userID int64) (int, error)
+}
+
+// RecoveryCodeStatus 恢复码状态
+type RecoveryCodeStatus struct {
+	Remaining int `json:"remaining"`
+}
+
+// TwoFactorService 汇总 TOTP 与 passkey 两类第二因素：可用方式、恢复码与管理员强制策略
+type TwoFactorService struct {
+	recoveryRepo   RecoveryCodeRepository
+	credRepo       WebAuthnCredentialRepository
+	userRepo       UserRepository
+	settingService *SettingService
+	totpService    *TotpService
+}
+
+// NewTwoFactorService creates a new TwoFactorService
+func NewTwoFactorService(
+	recoveryRepo RecoveryCodeRepository,
+	credRepo WebAuthnCredentialRepository,
+	userRepo UserRepository,
+	settingService *SettingService,
+	totpService *TotpService,
+) *TwoFactorService {
+	return &TwoFactorService{
+		recoveryRepo:   recoveryRepo,
+		credRepo:       credRepo,
+		userRepo:       userRepo,
+		settingService: settingService,
+		totpService:    totpService,
+	}
+}
+
+// Methods 返回用户在登录时可用的两步验证方式；为空表示无需两步验证。
+// 功能开关关闭时对应方式不生效（与原先 TOTP 的行为一致）。
+func (s *TwoFactorService) Methods(ctx context.Context, user *User) ([]string, error) {
+	methods := make([]string, 0, 3)
+	if user.TotpEnabled && s.settingService.IsTotpEnabled(ctx) {
+		methods = append(methods, TwoFactorMethodTotp)
+	}
+	if s.settingService.IsWebAuthnEnabled(ctx) {
+		count, err := s.credRepo.CountByUserID(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("count passkeys: %w", err)
+		}
+		if count > 0 {
+			methods = append(methods, TwoFactorMethodWebAuthn)
+		}
+	}
+	if len(methods) == 0 {
+		return methods, nil
+	}
+
+	remaining, err := s.recoveryRepo.CountUnused(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("count recovery codes: %w", err)
+	}
+	if remaining > 0 {
+		methods = append(methods, TwoFactorMethodRecoveryCode)
+	}
+	return methods, nil
+}
+
+// hasSecondFactor reports whether the user has an active TOTP or passkey
+func (s *TwoFactorService) hasSecondFactor(ctx context.Context, user *User) (bool, error) {
+	if user.TotpEnabled && s.settingService.IsTotpEnabled(ctx) {
+		return true, nil
+	}
+	if !s.settingService.IsWebAuthnEnabled(ctx) {
+		return false, nil
+	}
+	count, err := s.credRepo.CountByUserID(ctx, user.ID)
+	if err != nil {
+		return false, fmt.Errorf("count passkeys: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CheckAdminPolicy 在开启"管理员必须启用两步验证"时，拒绝未配置第二因素的管理员访问后台
+func (s *TwoFactorService) CheckAdminPolicy(ctx context.Context, user *User) error {
+	if !user.IsAdmin() || !s.settingService.IsAdmin2FARequired(ctx) {
+		return nil
+	}
+	ok, err := s.hasSecondFactor(ctx, user)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAdmin2FARequired
+	}
+	return nil
+}
+
+// RecoveryCodeStatus 返回剩余可用恢复码数量
+func (s *TwoFactorService) RecoveryCodeStatus(ctx context.Context, userID int64) (*RecoveryCodeStatus, error) {
+	remaining, err := s.recoveryRepo.CountUnused(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &RecoveryCodeStatus{Remaining: remaining}, nil
+}
+
+// GenerateRecoveryCodes 生成一组新的恢复码（旧的全部作废），明文只返回这一次
+func (s *TwoFactorService) GenerateRecoveryCodes(ctx context.Context, userID int64, emailCode, password string) ([]string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if err := s.totpService.VerifyIdentity(ctx, user, emailCode, password); err != nil {
+		return nil, err
+	}
+	ok, err := s.hasSecondFactor(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("generate recovery code: %w", err)
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	if err := s.recoveryRepo.ReplaceAll(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("save recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// ConsumeRecoveryCode 在登录两步验证时使用恢复码，失败计入 TOTP 的尝试次数限制
+func (s *TwoFactorService) ConsumeRecoveryCode(ctx context.Context, userID int64, code string) error {
+	normalized := normalizeRecoveryCode(code)
+	if len(normalized) != recoveryCodeLength {
+		return ErrRecoveryCodeInvalid
+	}
+	if attempts, err := s.totpService.cache.GetVerifyAttempts(ctx, userID); err == nil && attempts >= maxTotpAttempts {
+		return ErrTotpTooManyAttempts
+	}
+
+	ok, err := s.recoveryRepo.Consume(ctx, userID, hashRecoveryCode(normalized), time.Now())
+	if err != nil {
+		return fmt.Errorf("consume recovery code: %w", err)
+	}
+	if !ok {
+		_, _ = s.totpService.cache.IncrementVerifyAttempts(ctx, userID)
+		return ErrRecoveryCodeInvalid
+	}
+	_ = s.totpService.cache.ClearVerifyAttempts(ctx, userID)
+	return nil
+}
+
+// generateRecoveryCode 生成形如 "abcde-fghjk" 的恢复码
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for i, v := range b {
+		if i == recoveryCodeLength/2 {
+			sb.WriteByte('-')
+		}
+		// 256 % 31 != 0 会带来极小的偏差，对 50 bit 熵的一次性恢复码可以忽略
+		sb.WriteByte(recoveryCodeAlphabet[int(v)%len(recoveryCodeAlphabet)])
+	}
+	return sb.String(), nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
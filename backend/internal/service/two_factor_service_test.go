@@ -0,0 +1,315 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/webauthn"
+	"github.com/stretchr/testify/require"
+)
+
+type recoveryCodeRepoStub struct {
+	codes map[string]bool // hash -> used
+}
+
+func (s *recoveryCodeRepoStub) ReplaceAll(ctx context.Context, userID int64, codeHashes []string) error {
+	s.codes = map[string]bool{}
+	for _, h := range codeHashes {
+		s.codes[h] = false
+	}
+	return nil
+}
+
+func (s *recoveryCodeRepoStub) Consume(ctx context.Context, userID int64, codeHash string, usedAt time.Time) (bool, error) {
+	used, ok := s.codes[codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	s.codes[codeHash] = true
+	return true, nil
+}
+
+func (s *recoveryCodeRepoStub) CountUnused(ctx context.Context, userID int64) (int, error) {
+	n := 0
+	for _, used := range s.codes {
+		if !used {
+			n++
+		}
+	}
+	return n, nil
+}
+
+type webAuthnCredRepoStub struct {
+	creds []WebAuthnCredential
+}
+
+func (s *webAuthnCredRepoStub) Create(ctx context.Context, cred *WebAuthnCredential) error {
+	cred.ID = int64(len(s.creds) + 1)
+	s.creds = append(s.creds, *cred)
+	return nil
+}
+
+func (s *webAuthnCredRepoStub) GetByCredentialID(ctx context.Context, credentialID string) (*WebAuthnCredential, error) {
+	for i := range s.creds {
+		if s.creds[i].CredentialID == credentialID {
+			cp := s.creds[i]
+			return &cp, nil
+		}
+	}
+	return nil, ErrWebAuthnCredentialNotFound
+}
+
+func (s *webAuthnCredRepoStub) ListByUserID(ctx context.Context, userID int64) ([]WebAuthnCredential, error) {
+	var out []WebAuthnCredential
+	for _, c := range s.creds {
+		if c.UserID == userID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (s *webAuthnCredRepoStub) CountByUserID(ctx context.Context, userID int64) (int, error) {
+	list, _ := s.ListByUserID(ctx, userID)
+	return len(list), nil
+}
+
+func (s *webAuthnCredRepoStub) UpdateName(ctx context.Context, userID, id int64, name string) error {
+	panic("unexpected UpdateName call")
+}
+
+func (s *webAuthnCredRepoStub) UpdateUsage(ctx context.Context, id int64, signCount int64, usedAt time.Time) error {
+	for i := range s.creds {
+		if s.creds[i].ID == id {
+			s.creds[i].SignCount = signCount
+			s.creds[i].LastUsedAt = &usedAt
+		}
+	}
+	return nil
+}
+
+func (s *webAuthnCredRepoStub) Delete(ctx context.Context, userID, id int64) error {
+	panic("unexpected Delete call")
+}
+
+type webAuthnChallengeCacheStub struct {
+	items map[string]*WebAuthnChallenge
+}
+
+func (s *webAuthnChallengeCacheStub) SetChallenge(ctx context.Context, challengeID string, challenge *WebAuthnChallenge, ttl time.Duration) error {
+	cp := *challenge
+	s.items[challengeID] = &cp
+	return nil
+}
+
+func (s *webAuthnChallengeCacheStub) ConsumeChallenge(ctx context.Context, challengeID string) (*WebAuthnChallenge, error) {
+	ch := s.items[challengeID]
+	delete(s.items, challengeID)
+	return ch, nil
+}
+
+type totpAttemptCacheStub struct {
+	TotpCache
+	attempts int
+}
+
+func (s *totpAttemptCacheStub) GetVerifyAttempts(ctx context.Context, userID int64) (int, error) {
+	return s.attempts, nil
+}
+
+func (s *totpAttemptCacheStub) IncrementVerifyAttempts(ctx context.Context, userID int64) (int, error) {
+	s.attempts++
+	return s.attempts, nil
+}
+
+func (s *totpAttemptCacheStub) ClearVerifyAttempts(ctx context.Context, userID int64) error {
+	s.attempts = 0
+	return nil
+}
+
+type twoFactorFixture struct {
+	settings *settingRepoStub
+	creds    *webAuthnCredRepoStub
+	codes    *recoveryCodeRepoStub
+	cache    *webAuthnChallengeCacheStub
+	svc      *TwoFactorService
+	webauthn *WebAuthnService
+}
+
+func newTwoFactorFixture(t *testing.T, user *User) *twoFactorFixture {
+	require.NoError(t, user.SetPassword("secret123"))
+	f := &twoFactorFixture{
+		settings: &settingRepoStub{values: map[string]string{
+			SettingKeyTotpEnabled:     "true",
+			SettingKeyWebAuthnEnabled: "true",
+		}},
+		creds: &webAuthnCredRepoStub{},
+		codes: &recoveryCodeRepoStub{},
+		cache: &webAuthnChallengeCacheStub{items: map[string]*WebAuthnChallenge{}},
+	}
+	cfg := &config.Config{WebAuthn: config.WebAuthnConfig{RPID: "example.com"}}
+	settingService := NewSettingService(f.settings, cfg)
+	userRepo := &userRepoStub{user: user}
+	totpService := NewTotpService(userRepo, nil, &totpAttemptCacheStub{}, settingService, nil, nil)
+	f.svc = NewTwoFactorService(f.codes, f.creds, userRepo, settingService, totpService)
+	f.webauthn = NewWebAuthnService(f.creds, f.cache, userRepo, settingService, totpService, cfg)
+	return f
+}
+
+func TestTwoFactorMethodsAndRecoveryCodes(t *testing.T) {
+	user := &User{ID: 1, Role: RoleUser, Status: StatusActive}
+	f := newTwoFactorFixture(t, user)
+	ctx := context.Background()
+
+	methods, err := f.svc.Methods(ctx, user)
+	require.NoError(t, err)
+	require.Empty(t, methods)
+
+	_, err = f.svc.GenerateRecoveryCodes(ctx, 1, "", "secret123")
+	require.ErrorIs(t, err, ErrTwoFactorNotEnabled)
+
+	f.creds.creds = append(f.creds.creds, WebAuthnCredential{ID: 1, UserID: 1, CredentialID: "cred"})
+	_, err = f.svc.GenerateRecoveryCodes(ctx, 1, "", "wrong")
+	require.ErrorIs(t, err, ErrPasswordIncorrect)
+
+	codes, err := f.svc.GenerateRecoveryCodes(ctx, 1, "", "secret123")
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	require.Len(t, codes[0], recoveryCodeLength+1)
+
+	methods, err = f.svc.Methods(ctx, user)
+	require.NoError(t, err)
+	require.Equal(t, []string{TwoFactorMethodWebAuthn, TwoFactorMethodRecoveryCode}, methods)
+
+	// 恢复码不区分大小写与分隔符，且只能使用一次
+	input := " " + codes[3][:5] + " " + codes[3][6:] + " "
+	require.NoError(t, f.svc.ConsumeRecoveryCode(ctx, 1, input))
+	require.ErrorIs(t, f.svc.ConsumeRecoveryCode(ctx, 1, codes[3]), ErrRecoveryCodeInvalid)
+
+	status, err := f.svc.RecoveryCodeStatus(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, recoveryCodeCount-1, status.Remaining)
+
+	// passkey 功能关闭后不再要求 passkey 两步验证
+	f.settings.values[SettingKeyWebAuthnEnabled] = "false"
+	methods, err = f.svc.Methods(ctx, user)
+	require.NoError(t, err)
+	require.Empty(t, methods)
+}
+
+func TestTwoFactorAdminPolicy(t *testing.T) {
+	admin := &User{ID: 1, Role: RoleAdmin, Status: StatusActive}
+	f := newTwoFactorFixture(t, admin)
+	ctx := context.Background()
+
+	require.NoError(t, f.svc.CheckAdminPolicy(ctx, admin), "policy disabled by default")
+
+	f.settings.values[SettingKeyRequireAdmin2FA] = "true"
+	require.ErrorIs(t, f.svc.CheckAdminPolicy(ctx, admin), ErrAdmin2FARequired)
+	require.NoError(t, f.svc.CheckAdminPolicy(ctx, &User{ID: 2, Role: RoleUser}))
+
+	admin.TotpEnabled = true
+	require.NoError(t, f.svc.CheckAdminPolicy(ctx, admin))
+
+	// TOTP 功能被全局关闭时，TOTP 不再视为有效的第二因素
+	f.settings.values[SettingKeyTotpEnabled] = "false"
+	require.ErrorIs(t, f.svc.CheckAdminPolicy(ctx, admin), ErrAdmin2FARequired)
+
+	f.creds.creds = append(f.creds.creds, WebAuthnCredential{ID: 1, UserID: 1, CredentialID: "cred"})
+	require.NoError(t, f.svc.CheckAdminPolicy(ctx, admin))
+}
+
+func TestWebAuthnPasswordlessLogin(t *testing.T) {
+	user := &User{ID: 7, Role: RoleUser, Status: StatusActive}
+	f := newTwoFactorFixture(t, user)
+	ctx := context.Background()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	// 手工编码的 COSE EC2 公钥：{1: 2, 3: -7, -1: 1, -2: x, -3: y}
+	coseKey := []byte{0xa5, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x21, 0x58, 0x20}
+	coseKey = append(coseKey, key.X.FillBytes(make([]byte, 32))...)
+	coseKey = append(coseKey, 0x22, 0x58, 0x20)
+	coseKey = append(coseKey, key.Y.FillBytes(make([]byte, 32))...)
+	credID := webauthn.EncodeBase64URL([]byte("passkey-1"))
+	f.creds.creds = append(f.creds.creds, WebAuthnCredential{ID: 1, UserID: 7, CredentialID: credID, PublicKey: coseKey, SignCount: 3})
+
+	assert := func(opts *WebAuthnRequestOptions, flags byte, signCount uint32) *WebAuthnAssertion {
+		challenge, err := webauthn.DecodeBase64URL(opts.PublicKey.Challenge)
+		require.NoError(t, err)
+		clientData, err := json.Marshal(webauthn.ClientData{Type: webauthn.ClientDataTypeGet, Challenge: webauthn.EncodeBase64URL(challenge), Origin: "https://example.com"})
+		require.NoError(t, err)
+		rpHash := sha256.Sum256([]byte("example.com"))
+		authData := append(append([]byte{}, rpHash[:]...), flags)
+		authData = binary.BigEndian.AppendUint32(authData, signCount)
+		cdHash := sha256.Sum256(clientData)
+		digest := sha256.Sum256(append(append([]byte{}, authData...), cdHash[:]...))
+		sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		return &WebAuthnAssertion{
+			ID:                credID,
+			ClientDataJSON:    webauthn.EncodeBase64URL(clientData),
+			AuthenticatorData: webauthn.EncodeBase64URL(authData),
+			Signature:         webauthn.EncodeBase64URL(sig),
+			UserHandle:        webauthn.EncodeBase64URL(webAuthnUserHandle(7)),
+		}
+	}
+
+	// 免密码登录必须完成用户验证
+	opts, err := f.webauthn.BeginPasswordless(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "example.com", opts.PublicKey.RPID)
+	_, err = f.webauthn.FinishPasswordless(ctx, opts.ChallengeID, assert(opts, webauthn.FlagUserPresent, 4))
+	require.ErrorIs(t, err, ErrWebAuthnVerifyFailed)
+
+	// challenge 只能使用一次
+	_, err = f.webauthn.FinishPasswordless(ctx, opts.ChallengeID, assert(opts, webauthn.FlagUserPresent|webauthn.FlagUserVerified, 4))
+	require.ErrorIs(t, err, ErrWebAuthnChallengeExpired)
+
+	opts, err = f.webauthn.BeginPasswordless(ctx)
+	require.NoError(t, err)
+	got, err := f.webauthn.FinishPasswordless(ctx, opts.ChallengeID, assert(opts, webauthn.FlagUserPresent|webauthn.FlagUserVerified, 4))
+	require.NoError(t, err)
+	require.Equal(t, int64(7), got.ID)
+	require.Equal(t, int64(4), f.creds.creds[0].SignCount)
+	require.NotNil(t, f.creds.creds[0].LastUsedAt)
+
+	// 签名计数器回退视为凭证被克隆
+	opts, err = f.webauthn.BeginPasswordless(ctx)
+	require.NoError(t, err)
+	_, err = f.webauthn.FinishPasswordless(ctx, opts.ChallengeID, assert(opts, webauthn.FlagUserPresent|webauthn.FlagUserVerified, 4))
+	require.ErrorIs(t, err, ErrWebAuthnVerifyFailed)
+
+	f.settings.values[SettingKeyWebAuthnEnabled] = "false"
+	_, err = f.webauthn.BeginPasswordless(ctx)
+	require.ErrorIs(t, err, ErrWebAuthnNotEnabled)
+}
+
+func TestWebAuthnRequiresConfiguredRelyingParty(t *testing.T) {
+	f := newTwoFactorFixture(t, &User{ID: 7, Role: RoleUser, Status: StatusActive})
+	ctx := context.Background()
+
+	// 未配置 rp_id 时不能从请求推导依赖方，功能视为关闭
+	f.webauthn.cfg.WebAuthn.RPID = ""
+	require.False(t, f.webauthn.IsEnabled(ctx))
+	_, err := f.webauthn.BeginPasswordless(ctx)
+	require.ErrorIs(t, err, ErrWebAuthnNotEnabled)
+	_, _, err = f.webauthn.relyingParty()
+	require.ErrorIs(t, err, ErrWebAuthnNotConfigured)
+
+	f.webauthn.cfg.WebAuthn.RPID = "example.com"
+	rpID, origins, err := f.webauthn.relyingParty()
+	require.NoError(t, err)
+	require.Equal(t, "example.com", rpID)
+	require.Equal(t, []string{"https://example.com"}, origins)
+}
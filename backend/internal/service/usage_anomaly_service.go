@@ -0,0 +1,399 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+	"github.com/redis/go-redis/v9"
+)
+
+// 用户 / API Key 维度的异常指标（用于运维告警规则）
+const (
+	// OpsMetricUserSpendSpikeRatio 用户窗口内消费 / 基线期同等时长的平均消费
+	OpsMetricUserSpendSpikeRatio = "user_spend_spike_ratio"
+	// OpsMetricAPIKeySpendSpikeRatio API Key 窗口内消费 / 基线期同等时长的平均消费
+	OpsMetricAPIKeySpendSpikeRatio = "api_key_spend_spike_ratio"
+	// OpsMetricAPIKeyNewIPCount API Key 窗口内出现的、基线期内未出现过的客户端 IP 数（按 IP 精确匹配，不含国家/地区维度）
+	OpsMetricAPIKeyNewIPCount = "api_key_new_ip_count"
+	// OpsMetricAPIKeyNewUserAgentCount API Key 窗口内出现的、基线期内未出现过的 User-Agent 数
+	OpsMetricAPIKeyNewUserAgentCount = "api_key_new_user_agent_count"
+	// OpsMetricAPIKeyOffHoursRequests API Key 窗口内落在基线期从未使用过的小时段的请求数
+	OpsMetricAPIKeyOffHoursRequests = "api_key_off_hours_requests"
+)
+
+// usage_logs 中可用于识别新来源的字段
+const (
+	UsageAnomalyFieldIP        = "ip_address"
+	UsageAnomalyFieldUserAgent = "user_agent"
+)
+
+const (
+	defaultUsageAnomalyBaselineDays    = 7
+	maxUsageAnomalyBaselineDays        = 30
+	defaultUsageAnomalyMinSpendUSD     = 1.0
+	defaultUsageAnomalyMinHistoryHours = 72
+	// usageAnomalyBaselineFloorUSD 基线消费下限，避免基线接近 0 时比值失真
+	usageAnomalyBaselineFloorUSD = 0.01
+	usageAnomalyMaxSamples       = 5
+	usageAnomalyMinActionTTL     = time.Hour
+)
+
+var usageAnomalyMetricTypes = map[string]struct{}{
+	OpsMetricUserSpendSpikeRatio:     {},
+	OpsMetricAPIKeySpendSpikeRatio:   {},
+	OpsMetricAPIKeyNewIPCount:        {},
+	OpsMetricAPIKeyNewUserAgentCount: {},
+	OpsMetricAPIKeyOffHoursRequests:  {},
+}
+
+// IsUsageAnomalyMetric 判断告警指标是否为用户 / API Key 维度的异常指标
+func IsUsageAnomalyMetric(metricType string) bool {
+	_, ok := usageAnomalyMetricTypes[strings.TrimSpace(metricType)]
+	return ok
+}
+
+// UsageSpendSample 窗口内某个用户或 API Key 的消费及其基线
+type UsageSpendSample struct {
+	UserID   int64
+	APIKeyID int64
+	Spend    float64
+	Requests int64
+	// BaselineSpend 基线期（窗口开始前）的总消费
+	BaselineSpend float64
+	// FirstSeenAt 基线期内最早的使用时间，为 nil 表示基线期内无使用
+	FirstSeenAt *time.Time
+}
+
+// UsageNewValueSample 窗口内某个 API Key 首次出现的 IP / User-Agent
+type UsageNewValueSample struct {
+	UserID   int64
+	APIKeyID int64
+	Value    string
+	Requests int64
+}
+
+// UsageOffHoursSample 窗口内某个 API Key 在非常用时段的请求
+type UsageOffHoursSample struct {
+	UserID   int64
+	APIKeyID int64
+	Requests int64
+}
+
+// UsageAnomalyRepository 异常检测所需的 usage_logs 聚合查询
+type UsageAnomalyRepository interface {
+	// ListSpend 按 API Key（perAPIKey=true）或用户汇总窗口内消费 >= minSpend 的对象，附带基线期消费与最早使用时间
+	ListSpend(ctx context.Context, perAPIKey bool, windowStart, windowEnd, baselineStart time.Time, minSpend float64) ([]UsageSpendSample, error)
+	// ListNewValues 返回窗口内出现、但该 API Key 在基线期从未出现过的 field 取值；
+	// 仅统计在 historyBefore 之前已有使用记录的 API Key，避免新 Key 的所有来源都被视为新来源
+	ListNewValues(ctx context.Context, field string, windowStart, windowEnd, baselineStart, historyBefore time.Time) ([]UsageNewValueSample, error)
+	// ListOffHoursRequests 返回窗口内落在该 API Key 基线期从未使用过的小时段（按 tz）的请求数
+	ListOffHoursRequests(ctx context.Context, windowStart, windowEnd, baselineStart, historyBefore time.Time, tz string) ([]UsageOffHoursSample, error)
+}
+
+// UsageAnomaly 一个触发阈值的用户 / API Key
+type UsageAnomaly struct {
+	Metric   string  `json:"metric"`
+	UserID   int64   `json:"user_id"`
+	APIKeyID int64   `json:"api_key_id,omitempty"`
+	Value    float64 `json:"value"`
+	// Baseline 消费类指标的基线（同等时长的平均消费，USD）
+	Baseline float64 `json:"baseline,omitempty"`
+	Spend    float64 `json:"spend,omitempty"`
+	// Samples 新出现的 IP / User-Agent（最多 5 个）
+	Samples []string `json:"samples,omitempty"`
+}
+
+// usageAnomalyOptions 规则 filters 中的异常检测参数
+type usageAnomalyOptions struct {
+	BaselineDays      int
+	MinSpendUSD       float64
+	MinHistoryHours   int
+	AutoDisableAPIKey bool
+	NotifyUser        bool
+}
+
+func parseUsageAnomalyOptions(filters map[string]any) usageAnomalyOptions {
+	opts := usageAnomalyOptions{
+		BaselineDays:    defaultUsageAnomalyBaselineDays,
+		MinSpendUSD:     defaultUsageAnomalyMinSpendUSD,
+		MinHistoryHours: defaultUsageAnomalyMinHistoryHours,
+	}
+	if v, ok := filters["baseline_days"].(float64); ok && v >= 1 {
+		opts.BaselineDays = int(v)
+		if opts.BaselineDays > maxUsageAnomalyBaselineDays {
+			opts.BaselineDays = maxUsageAnomalyBaselineDays
+		}
+	}
+	if v, ok := filters["min_spend_usd"].(float64); ok && v >= 0 {
+		opts.MinSpendUSD = v
+	}
+	if v, ok := filters["min_history_hours"].(float64); ok && v >= 0 {
+		opts.MinHistoryHours = int(v)
+	}
+	if v, ok := filters["auto_disable_api_key"].(bool); ok {
+		opts.AutoDisableAPIKey = v
+	}
+	if v, ok := filters["notify_user"].(bool); ok {
+		opts.NotifyUser = v
+	}
+	return opts
+}
+
+// UsageAnomalyService 用户 / API Key 维度的异常检测与处置。
+//
+// 检测以 usage_logs 为数据源，与基线期（默认前 7 天）对比：消费突增、新 IP、新 User-Agent、非常用时段请求。
+// 由运维告警评估器按规则调用；触发后按规则配置自动禁用 API Key 并邮件通知用户，
+// 同一对象在冷却期内只处置一次。
+//
+// 不提供“新国家/地区”检测：usage_logs 只记录客户端 IP、不记录地理位置，项目也不内置 GeoIP 数据库，
+// 来源变化仅由 api_key_new_ip_count 按 IP 精确匹配识别。
+type UsageAnomalyService struct {
+	repo              UsageAnomalyRepository
+	apiKeyService     *APIKeyService
+	userRepo          UserRepository
+	emailQueueService *EmailQueueService
+	redisClient       redis.UniversalClient
+}
+
+// NewUsageAnomalyService 创建异常检测服务
+func NewUsageAnomalyService(
+	repo UsageAnomalyRepository,
+	apiKeyService *APIKeyService,
+	userRepo UserRepository,
+	emailQueueService *EmailQueueService,
+	redisClient redis.UniversalClient,
+) *UsageAnomalyService {
+	return &UsageAnomalyService{
+		repo:              repo,
+		apiKeyService:     apiKeyService,
+		userRepo:          userRepo,
+		emailQueueService: emailQueueService,
+		redisClient:       redisClient,
+	}
+}
+
+// Detect 计算规则指标：返回所有对象中的最大值，以及按规则阈值触发的对象（按指标值降序）
+func (s *UsageAnomalyService) Detect(ctx context.Context, rule *OpsAlertRule, windowStart, windowEnd time.Time) (float64, []UsageAnomaly, error) {
+	if s == nil || s.repo == nil || rule == nil {
+		return 0, nil, nil
+	}
+	opts := parseUsageAnomalyOptions(rule.Filters)
+	baselineStart := windowStart.Add(-time.Duration(opts.BaselineDays) * 24 * time.Hour)
+	historyBefore := windowEnd.Add(-time.Duration(opts.MinHistoryHours) * time.Hour)
+	metric := strings.TrimSpace(rule.MetricType)
+
+	var candidates []UsageAnomaly
+	switch metric {
+	case OpsMetricUserSpendSpikeRatio, OpsMetricAPIKeySpendSpikeRatio:
+		samples, err := s.repo.ListSpend(ctx, metric == OpsMetricAPIKeySpendSpikeRatio, windowStart, windowEnd, baselineStart, opts.MinSpendUSD)
+		if err != nil {
+			return 0, nil, err
+		}
+		for _, sample := range samples {
+			if sample.FirstSeenAt == nil || sample.FirstSeenAt.After(historyBefore) {
+				continue
+			}
+			baseline := spendBaselinePerWindow(sample, baselineStart, windowStart, windowEnd)
+			candidates = append(candidates, UsageAnomaly{
+				Metric:   metric,
+				UserID:   sample.UserID,
+				APIKeyID: sample.APIKeyID,
+				Value:    sample.Spend / max(baseline, usageAnomalyBaselineFloorUSD),
+				Baseline: baseline,
+				Spend:    sample.Spend,
+			})
+		}
+	case OpsMetricAPIKeyNewIPCount, OpsMetricAPIKeyNewUserAgentCount:
+		field := UsageAnomalyFieldIP
+		if metric == OpsMetricAPIKeyNewUserAgentCount {
+			field = UsageAnomalyFieldUserAgent
+		}
+		samples, err := s.repo.ListNewValues(ctx, field, windowStart, windowEnd, baselineStart, historyBefore)
+		if err != nil {
+			return 0, nil, err
+		}
+		byKey := map[int64]*UsageAnomaly{}
+		var order []int64
+		for _, sample := range samples {
+			a, ok := byKey[sample.APIKeyID]
+			if !ok {
+				a = &UsageAnomaly{Metric: metric, UserID: sample.UserID, APIKeyID: sample.APIKeyID}
+				byKey[sample.APIKeyID] = a
+				order = append(order, sample.APIKeyID)
+			}
+			a.Value++
+			if len(a.Samples) < usageAnomalyMaxSamples {
+				a.Samples = append(a.Samples, sample.Value)
+			}
+		}
+		for _, id := range order {
+			candidates = append(candidates, *byKey[id])
+		}
+	case OpsMetricAPIKeyOffHoursRequests:
+		samples, err := s.repo.ListOffHoursRequests(ctx, windowStart, windowEnd, baselineStart, historyBefore, timezone.Name())
+		if err != nil {
+			return 0, nil, err
+		}
+		for _, sample := range samples {
+			candidates = append(candidates, UsageAnomaly{
+				Metric:   metric,
+				UserID:   sample.UserID,
+				APIKeyID: sample.APIKeyID,
+				Value:    float64(sample.Requests),
+			})
+		}
+	default:
+		return 0, nil, fmt.Errorf("unsupported usage anomaly metric: %s", metric)
+	}
+
+	var maxValue float64
+	var flagged []UsageAnomaly
+	for _, a := range candidates {
+		maxValue = max(maxValue, a.Value)
+		if compareMetric(a.Value, rule.Operator, rule.Threshold) {
+			flagged = append(flagged, a)
+		}
+	}
+	sort.SliceStable(flagged, func(i, j int) bool { return flagged[i].Value > flagged[j].Value })
+	return maxValue, flagged, nil
+}
+
+// spendBaselinePerWindow 将基线期消费折算为与窗口等长的平均消费；基线期从对象首次使用时算起
+func spendBaselinePerWindow(sample UsageSpendSample, baselineStart, windowStart, windowEnd time.Time) float64 {
+	start := baselineStart
+	if sample.FirstSeenAt != nil && sample.FirstSeenAt.After(start) {
+		start = *sample.FirstSeenAt
+	}
+	baselineDuration := windowStart.Sub(start)
+	window := windowEnd.Sub(windowStart)
+	if baselineDuration <= 0 || window <= 0 {
+		return 0
+	}
+	return sample.BaselineSpend * float64(window) / float64(baselineDuration)
+}
+
+// HandleAnomalies 按规则配置处置触发的对象（禁用 API Key、通知用户），返回禁用与通知的数量。
+// 同一规则下的同一对象在冷却期（至少 1 小时）内只处置一次。
+func (s *UsageAnomalyService) HandleAnomalies(ctx context.Context, rule *OpsAlertRule, anomalies []UsageAnomaly) (disabled int, notified int) {
+	if s == nil || rule == nil || len(anomalies) == 0 {
+		return 0, 0
+	}
+	opts := parseUsageAnomalyOptions(rule.Filters)
+	if !opts.AutoDisableAPIKey && !opts.NotifyUser {
+		return 0, 0
+	}
+	ttl := time.Duration(rule.CooldownMinutes) * time.Minute
+	if ttl < usageAnomalyMinActionTTL {
+		ttl = usageAnomalyMinActionTTL
+	}
+
+	for _, a := range anomalies {
+		if !s.tryMarkOnce(ctx, fmt.Sprintf("ops:anomaly:handled:%d:%d:%d", rule.ID, a.UserID, a.APIKeyID), ttl) {
+			continue
+		}
+
+		var apiKey *APIKey
+		if a.APIKeyID > 0 && s.apiKeyService != nil {
+			if key, err := s.apiKeyService.GetByID(ctx, a.APIKeyID); err == nil {
+				apiKey = key
+			}
+		}
+
+		keyDisabled := false
+		if opts.AutoDisableAPIKey && apiKey != nil && apiKey.Status == StatusActive {
+			if err := s.apiKeyService.Disable(ctx, apiKey.ID); err != nil {
+				log.Printf("[UsageAnomaly] disable api key failed (key=%d): %v", apiKey.ID, err)
+			} else {
+				keyDisabled = true
+				disabled++
+				log.Printf("[UsageAnomaly] disabled api key=%d user=%d metric=%s value=%.2f", apiKey.ID, a.UserID, a.Metric, a.Value)
+			}
+		}
+
+		if opts.NotifyUser && s.notifyUser(ctx, a, apiKey, keyDisabled) {
+			notified++
+		}
+	}
+	return disabled, notified
+}
+
+func (s *UsageAnomalyService) notifyUser(ctx context.Context, a UsageAnomaly, apiKey *APIKey, keyDisabled bool) bool {
+	if s.emailQueueService == nil || s.userRepo == nil {
+		return false
+	}
+	user, err := s.userRepo.GetByID(ctx, a.UserID)
+	if err != nil || user == nil || strings.TrimSpace(user.Email) == "" {
+		return false
+	}
+	subject := "安全提醒：检测到账户异常使用"
+	if keyDisabled {
+		subject = "安全提醒：API Key 因异常使用已被停用"
+	}
+	if err := s.emailQueueService.EnqueueEmail(user.Email, subject, buildUsageAnomalyEmailBody(a, apiKey, keyDisabled)); err != nil {
+		log.Printf("[UsageAnomaly] enqueue email failed (user=%d): %v", a.UserID, err)
+		return false
+	}
+	return true
+}
+
+func buildUsageAnomalyEmailBody(a UsageAnomaly, apiKey *APIKey, keyDisabled bool) string {
+	var b strings.Builder
+	target := "你的账户"
+	if apiKey != nil {
+		target = fmt.Sprintf("你的 API Key「%s」", html.EscapeString(apiKey.Name))
+	}
+	switch a.Metric {
+	case OpsMetricUserSpendSpikeRatio, OpsMetricAPIKeySpendSpikeRatio:
+		fmt.Fprintf(&b, "<p>%s近期消费 <b>$%.2f</b>，约为平时同等时长的 <b>%.1f</b> 倍。</p>", target, a.Spend, a.Value)
+	case OpsMetricAPIKeyNewIPCount:
+		fmt.Fprintf(&b, "<p>%s出现了 <b>%.0f</b> 个此前未使用过的 IP 地址：%s</p>", target, a.Value, html.EscapeString(strings.Join(a.Samples, ", ")))
+	case OpsMetricAPIKeyNewUserAgentCount:
+		fmt.Fprintf(&b, "<p>%s出现了 <b>%.0f</b> 个此前未使用过的客户端（User-Agent）：%s</p>", target, a.Value, html.EscapeString(strings.Join(a.Samples, ", ")))
+	case OpsMetricAPIKeyOffHoursRequests:
+		fmt.Fprintf(&b, "<p>%s在平时不使用的时段产生了 <b>%.0f</b> 次请求。</p>", target, a.Value)
+	}
+	if keyDisabled {
+		b.WriteString("<p>为保护你的余额，该 API Key 已被自动停用。如确认为本人操作，可在控制台重新启用；否则请删除该 Key 并创建新的 Key。</p>")
+	} else {
+		b.WriteString("<p>如非本人操作，请尽快在控制台停用或删除相关 API Key。</p>")
+	}
+	return b.String()
+}
+
+func (s *UsageAnomalyService) tryMarkOnce(ctx context.Context, key string, ttl time.Duration) bool {
+	if s.redisClient == nil {
+		return true
+	}
+	ok, err := s.redisClient.SetNX(ctx, key, "1", ttl).Result()
+	if err != nil {
+		log.Printf("[UsageAnomaly] setnx failed: key=%s err=%v", key, err)
+		return false
+	}
+	return ok
+}
+
+// describeUsageAnomalies 生成告警描述中的对象摘要（最多 10 个）
+func describeUsageAnomalies(anomalies []UsageAnomaly) string {
+	if len(anomalies) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(anomalies))
+	for i, a := range anomalies {
+		if i >= 10 {
+			parts = append(parts, fmt.Sprintf("... +%d more", len(anomalies)-i))
+			break
+		}
+		if a.APIKeyID > 0 {
+			parts = append(parts, fmt.Sprintf("user=%d key=%d (%.2f)", a.UserID, a.APIKeyID, a.Value))
+		} else {
+			parts = append(parts, fmt.Sprintf("user=%d (%.2f)", a.UserID, a.Value))
+		}
+	}
+	return "; flagged: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,130 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type usageAnomalyRepoStub struct {
+	spend     []UsageSpendSample
+	newValues []UsageNewValueSample
+	perAPIKey bool
+	field     string
+}
+
+func (r *usageAnomalyRepoStub) ListSpend(ctx context.Context, perAPIKey bool, windowStart, windowEnd, baselineStart time.Time, minSpend float64) ([]UsageSpendSample, error) {
+	r.perAPIKey = perAPIKey
+	return r.spend, nil
+}
+
+func (r *usageAnomalyRepoStub) ListNewValues(ctx context.Context, field string, windowStart, windowEnd, baselineStart, historyBefore time.Time) ([]UsageNewValueSample, error) {
+	r.field = field
+	return r.newValues, nil
+}
+
+func (r *usageAnomalyRepoStub) ListOffHoursRequests(ctx context.Context, windowStart, windowEnd, baselineStart, historyBefore time.Time, tz string) ([]UsageOffHoursSample, error) {
+	return nil, nil
+}
+
+type usageAnomalyAPIKeyRepoStub struct {
+	APIKeyRepository
+	keys    map[int64]*APIKey
+	updated []int64
+}
+
+func (r *usageAnomalyAPIKeyRepoStub) GetByID(ctx context.Context, id int64) (*APIKey, error) {
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, ErrAPIKeyNotFound
+	}
+	cp := *key
+	return &cp, nil
+}
+
+func (r *usageAnomalyAPIKeyRepoStub) Update(ctx context.Context, key *APIKey) error {
+	r.updated = append(r.updated, key.ID)
+	r.keys[key.ID] = key
+	return nil
+}
+
+func TestUsageAnomalyDetectSpendSpike(t *testing.T) {
+	windowEnd := time.Date(2026, 1, 8, 12, 0, 0, 0, time.UTC)
+	windowStart := windowEnd.Add(-time.Hour)
+	longAgo := windowStart.Add(-30 * 24 * time.Hour)
+	recent := windowEnd.Add(-2 * time.Hour)
+	halfBaseline := windowStart.Add(-84 * time.Hour)
+
+	repo := &usageAnomalyRepoStub{spend: []UsageSpendSample{
+		// 7 天基线共 16.8 USD => 每小时 0.1，窗口消费 5 => 50 倍
+		{UserID: 1, APIKeyID: 11, Spend: 5, BaselineSpend: 16.8, FirstSeenAt: &longAgo},
+		// 基线期从首次使用算起：3.5 天共 8.4 => 每小时 0.1，窗口 0.5 => 5 倍
+		{UserID: 2, APIKeyID: 21, Spend: 0.5, BaselineSpend: 8.4, FirstSeenAt: &halfBaseline},
+		// 历史不足 72 小时，跳过
+		{UserID: 3, APIKeyID: 31, Spend: 100, FirstSeenAt: &recent},
+		// 基线期无使用，跳过
+		{UserID: 4, APIKeyID: 41, Spend: 100},
+	}}
+	svc := NewUsageAnomalyService(repo, nil, nil, nil, nil)
+	rule := &OpsAlertRule{MetricType: OpsMetricAPIKeySpendSpikeRatio, Operator: ">=", Threshold: 10}
+
+	value, flagged, err := svc.Detect(context.Background(), rule, windowStart, windowEnd)
+	require.NoError(t, err)
+	require.True(t, repo.perAPIKey)
+	require.InDelta(t, 50, value, 0.001)
+	require.Len(t, flagged, 1)
+	require.Equal(t, int64(11), flagged[0].APIKeyID)
+	require.InDelta(t, 0.1, flagged[0].Baseline, 0.001)
+
+	rule.Threshold = 4
+	_, flagged, err = svc.Detect(context.Background(), rule, windowStart, windowEnd)
+	require.NoError(t, err)
+	require.Len(t, flagged, 2)
+	require.InDelta(t, 5, flagged[1].Value, 0.001)
+}
+
+func TestUsageAnomalyDetectNewValues(t *testing.T) {
+	repo := &usageAnomalyRepoStub{newValues: []UsageNewValueSample{
+		{UserID: 1, APIKeyID: 11, Value: "1.1.1.1"},
+		{UserID: 1, APIKeyID: 11, Value: "2.2.2.2"},
+		{UserID: 1, APIKeyID: 11, Value: "3.3.3.3"},
+		{UserID: 2, APIKeyID: 21, Value: "4.4.4.4"},
+	}}
+	svc := NewUsageAnomalyService(repo, nil, nil, nil, nil)
+	rule := &OpsAlertRule{MetricType: OpsMetricAPIKeyNewIPCount, Operator: ">", Threshold: 2}
+
+	now := time.Now()
+	value, flagged, err := svc.Detect(context.Background(), rule, now.Add(-5*time.Minute), now)
+	require.NoError(t, err)
+	require.Equal(t, UsageAnomalyFieldIP, repo.field)
+	require.Equal(t, float64(3), value)
+	require.Len(t, flagged, 1)
+	require.Equal(t, []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}, flagged[0].Samples)
+}
+
+func TestUsageAnomalyHandleDisablesAPIKey(t *testing.T) {
+	keyRepo := &usageAnomalyAPIKeyRepoStub{keys: map[int64]*APIKey{
+		11: {ID: 11, UserID: 1, Status: StatusActive},
+		21: {ID: 21, UserID: 2, Status: StatusDisabled},
+	}}
+	svc := NewUsageAnomalyService(nil, &APIKeyService{apiKeyRepo: keyRepo}, nil, nil, nil)
+	anomalies := []UsageAnomaly{
+		{Metric: OpsMetricAPIKeySpendSpikeRatio, UserID: 1, APIKeyID: 11, Value: 50},
+		{Metric: OpsMetricAPIKeySpendSpikeRatio, UserID: 2, APIKeyID: 21, Value: 20},
+	}
+
+	disabled, _ := svc.HandleAnomalies(context.Background(), &OpsAlertRule{ID: 1}, anomalies)
+	require.Zero(t, disabled)
+	require.Empty(t, keyRepo.updated)
+
+	rule := &OpsAlertRule{ID: 1, Filters: map[string]any{"auto_disable_api_key": true}}
+	disabled, notified := svc.HandleAnomalies(context.Background(), rule, anomalies)
+	require.Equal(t, 1, disabled)
+	require.Zero(t, notified)
+	require.Equal(t, []int64{11}, keyRepo.updated)
+	require.Equal(t, StatusDisabled, keyRepo.keys[11].Status)
+}
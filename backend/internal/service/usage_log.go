@@ -3,8 +3,9 @@ package service
 import "time"
 
 const (
-	BillingTypeBalance      int8 = 0 // 钱包余额
-	BillingTypeSubscription int8 = 1 // 订阅套餐
+	BillingTypeBalance             int8 = 0 // 钱包余额
+	BillingTypeSubscription        int8 = 1 // 订阅套餐
+	BillingTypeSubscriptionOverage int8 = 2 // 订阅额度用尽后的超额计费（扣余额）
 )
 
 type UsageLog struct {
@@ -42,6 +43,8 @@ type UsageLog struct {
 	FirstTokenMs *int
 	UserAgent    *string
 	IPAddress    *string
+	// TrafficArm 灰度 / A-B 分流实验组（分组未启用分流时为 nil），见 traffic_split.go
+	TrafficArm *string
 
 	// 图片生成字段
 	ImageCount int
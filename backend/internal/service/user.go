@@ -28,11 +28,19 @@ type User struct {
 	InviteConfirmedAt  *time.Time
 	InviteRewardAmount *float64
 
+	// 专属账号：调度时优先使用，且不会被其他用户调度到
+	DedicatedAccountIDs   []int64
+	DedicatedAccountsOnly bool
+
 	// TOTP 双因素认证字段
 	TotpSecretEncrypted *string    // AES-256-GCM 加密的 TOTP 密钥
 	TotpEnabled         bool       // 是否启用 TOTP
 	TotpEnabledAt       *time.Time // TOTP 启用时间
 
+	// 注册来源（返佣反作弊使用）
+	RegisterIP     *string
+	RegisterDevice *string
+
 	APIKeys       []APIKey
 	Subscriptions []UserSubscription
 }
@@ -65,6 +65,7 @@ type ChangePasswordRequest struct {
 type UserService struct {
 	userRepo             UserRepository
 	authCacheInvalidator APIKeyAuthCacheInvalidator
+	sessionRevoker       AuthSessionRevoker
 }
 
 // NewUserService 创建用户服务实例
@@ -75,6 +76,11 @@ func NewUserService(userRepo UserRepository, authCacheInvalidator APIKeyAuthCach
 	}
 }
 
+// SetSessionRevoker 注入会话吊销（AuthSessionService 依赖本服务所在的认证链路，使用 setter 避免循环依赖）
+func (s *UserService) SetSessionRevoker(revoker AuthSessionRevoker) {
+	s.sessionRevoker = revoker
+}
+
 // GetFirstAdmin 获取首个管理员用户（用于 Admin API Key 认证）
 func (s *UserService) GetFirstAdmin(ctx context.Context) (*User, error) {
 	admin, err := s.userRepo.GetFirstAdmin(ctx)
@@ -133,7 +139,7 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID int64, req Updat
 }
 
 // ChangePassword 修改密码
-// Security: Increments TokenVersion to invalidate all existing JWT tokens
+// Security: 吊销该用户的全部登录会话（包括当前会话），已签发的 refresh token 随之失效
 func (s *UserService) ChangePassword(ctx context.Context, userID int64, req ChangePasswordRequest) error {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -157,6 +163,7 @@ func (s *UserService) ChangePassword(ctx context.Context, userID int64, req Chan
 		return fmt.Errorf("update user: %w", err)
 	}
 
+	revokeUserSessions(ctx, s.sessionRevoker, userID)
 	return nil
 }
 
@@ -23,6 +23,12 @@ type UserSubscription struct {
 	AssignedAt time.Time
 	Notes      string
 
+	// AutoRenew 到期前自动从余额续费；PausedAt 非空表示已暂停；
+	// RenewalFailedAt 为最近一次自动续费失败时间。见 subscription_lifecycle.go
+	AutoRenew       bool
+	PausedAt        *time.Time
+	RenewalFailedAt *time.Time
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
@@ -30,6 +30,23 @@ type UserSubscriptionRepository interface {
 	ResetWeeklyUsage(ctx context.Context, id int64, newWindowStart time.Time) error
 	ResetMonthlyUsage(ctx context.Context, id int64, newWindowStart time.Time) error
 	IncrementUsage(ctx context.Context, id int64, costUSD float64) error
+	// SumOverageCost 统计订阅自 since 起超额计费（扣余额）的实际费用
+	SumOverageCost(ctx context.Context, id int64, since time.Time) (float64, error)
+	// SumQuotaUsage 统计订阅自 since 起按订阅计费的请求数与 token 数；modelPattern 为空表示所有模型，支持末尾 * 通配
+	SumQuotaUsage(ctx context.Context, id int64, since time.Time, modelPattern string) (SubscriptionQuotaUsage, error)
 
 	BatchUpdateExpiredStatus(ctx context.Context) (int64, error)
+
+	// 自动续费与暂停/恢复，见 subscription_lifecycle.go
+	SetAutoRenew(ctx context.Context, id int64, enabled bool) error
+	// Pause 仅对 active 且未过期的订阅生效，否则返回 ErrSubscriptionNotActive
+	Pause(ctx context.Context, id int64, pausedAt time.Time) error
+	// Resume 仅对 paused 的订阅生效，恢复为 active 并写入顺延后的到期时间
+	Resume(ctx context.Context, id int64, newExpiresAt time.Time) error
+	// RecordRenewal 写入续费后的到期时间，恢复 active 并清除续费失败标记。
+	// 仅当当前到期时间仍为 prevExpiresAt 时生效，否则返回 ErrSubscriptionRenewalConflict（并发续费/调整）
+	RecordRenewal(ctx context.Context, id int64, prevExpiresAt, newExpiresAt time.Time) error
+	MarkRenewalFailed(ctx context.Context, id int64, failedAt time.Time) error
+	// ListAutoRenewDue 列出开启自动续费、在 dueBefore 前到期且上次失败早于 retryBefore（或从未失败）的 active 订阅
+	ListAutoRenewDue(ctx context.Context, dueBefore, retryBefore time.Time, limit int) ([]UserSubscription, error)
 }
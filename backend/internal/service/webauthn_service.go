@@ -0,0 +1,636 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/webauthn"
+)
+
+var (
+	ErrWebAuthnNotEnabled         = infraerrors.BadRequest("WEBAUTHN_NOT_ENABLED", "passkey feature is not enabled")
+	ErrWebAuthnNotConfigured      = infraerrors.ServiceUnavailable("WEBAUTHN_NOT_CONFIGURED", "passkey requires webauthn.rp_id to be configured")
+	ErrWebAuthnCredentialNotFound = infraerrors.NotFound("WEBAUTHN_CREDENTIAL_NOT_FOUND", "passkey not found")
+	ErrWebAuthnCredentialExists   = infraerrors.Conflict("WEBAUTHN_CREDENTIAL_EXISTS", "this passkey is already registered")
+	ErrWebAuthnCredentialLimit    = infraerrors.BadRequest("WEBAUTHN_CREDENTIAL_LIMIT", "maximum number of passkeys reached")
+	ErrWebAuthnChallengeExpired   = infraerrors.BadRequest("WEBAUTHN_CHALLENGE_EXPIRED", "passkey request expired, please try again")
+	ErrWebAuthnVerifyFailed       = infraerrors.Unauthorized("WEBAUTHN_VERIFY_FAILED", "passkey verification failed")
+	ErrWebAuthnNoCredentials      = infraerrors.BadRequest("WEBAUTHN_NO_CREDENTIALS", "no passkey registered for this account")
+	ErrWebAuthnInvalidName        = infraerrors.BadRequest("WEBAUTHN_INVALID_NAME", "passkey name must be 1-64 characters")
+	ErrTwoFactorSessionInvalid    = infraerrors.BadRequest("TWO_FACTOR_SESSION_INVALID", "invalid or expired 2FA session")
+)
+
+// WebAuthn 仪式用途
+const (
+	WebAuthnPurposeRegister     = "register"
+	WebAuthnPurposeSecondStep   = "2fa"
+	WebAuthnPurposePasswordless = "passwordless"
+)
+
+const (
+	webAuthnChallengeTTL    = 5 * time.Minute
+	webAuthnTimeoutMillis   = 60000
+	webAuthnChallengeBytes  = 32
+	maxWebAuthnCredentials  = 10
+	maxWebAuthnNameLength   = 64
+	defaultWebAuthnRPName   = "Sub2API"
+	webAuthnCredentialsType = "public-key"
+)
+
+// WebAuthnCredential 用户注册的 passkey
+type WebAuthnCredential struct {
+	ID           int64
+	UserID       int64
+	Name         string
+	CredentialID string // base64url
+	PublicKey    []byte // COSE_Key
+	SignCount    int64
+	AAGUID       string
+	Transports   []string
+	UserVerified bool
+	LastUsedAt   *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// WebAuthnCredentialRepository 持久化 passkey
+type WebAuthnCredentialRepository interface {
+	Create(ctx context.Context, cred *WebAuthnCredential) error
+	GetByCredentialID(ctx context.Context, credentialID string) (*WebAuthnCredential, error)
+	ListByUserID(ctx context.Context, userID int64) ([]WebAuthnCredential, error)
+	CountByUserID(ctx context.Context, userID int64) (int, error)
+	UpdateName(ctx context.Context, userID, id int64, name string) error
+	UpdateUsage(ctx context.Context, id int64, signCount int64, usedAt time.Time) error
+	Delete(ctx context.Context, userID, id int64) error
+}
+
+// WebAuthnChallenge 一次 WebAuthn 仪式的服务端状态，保存在 Redis 中且只能消费一次
+type WebAuthnChallenge struct {
+	Challenge string   `json:"challenge"` // base64url
+	Purpose   string   `json:"purpose"`
+	UserID    int64    `json:"user_id,omitempty"`
+	TempToken string   `json:"temp_token,omitempty"` // 两步验证时绑定的登录临时令牌
+	RPID      string   `json:"rp_id"`
+	Origins   []string `json:"origins"`
+}
+
+// WebAuthnChallengeCache 存取 WebAuthn challenge
+type WebAuthnChallengeCache interface {
+	SetChallenge(ctx context.Context, challengeID string, challenge *WebAuthnChallenge, ttl time.Duration) error
+	// ConsumeChallenge 读取并删除 challenge，不存在时返回 nil, nil
+	ConsumeChallenge(ctx context.Context, challengeID string) (*WebAuthnChallenge, error)
+}
+
+// WebAuthnCredentialDescriptor 对应 PublicKeyCredentialDescriptor
+type WebAuthnCredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// WebAuthnCreationOptions 是 navigator.credentials.create() 的参数（二进制字段均为 base64url）
+type WebAuthnCreationOptions struct {
+	ChallengeID string                  `json:"challenge_id"`
+	PublicKey   WebAuthnPublicKeyCreate `json:"public_key"`
+}
+
+type WebAuthnPublicKeyCreate struct {
+	Challenge string `json:"challenge"`
+	RP        struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"rp"`
+	User struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+	} `json:"user"`
+	PubKeyCredParams       []WebAuthnCredentialParam      `json:"pubKeyCredParams"`
+	Timeout                int                            `json:"timeout"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection struct {
+		ResidentKey      string `json:"residentKey"`
+		UserVerification string `json:"userVerification"`
+	} `json:"authenticatorSelection"`
+	Attestation string `json:"attestation"`
+}
+
+type WebAuthnCredentialParam struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// WebAuthnRequestOptions 是 navigator.credentials.get() 的参数
+type WebAuthnRequestOptions struct {
+	ChallengeID string               `json:"challenge_id"`
+	PublicKey   WebAuthnPublicKeyGet `json:"public_key"`
+}
+
+type WebAuthnPublicKeyGet struct {
+	Challenge        string                         `json:"challenge"`
+	RPID             string                         `json:"rpId"`
+	Timeout          int                            `json:"timeout"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+// WebAuthnAttestation 是浏览器注册结果（前端已将 ArrayBuffer 转为 base64url）
+type WebAuthnAttestation struct {
+	ID                string   `json:"id"`
+	ClientDataJSON    string   `json:"client_data_json"`
+	AttestationObject string   `json:"attestation_object"`
+	Transports        []string `json:"transports"`
+}
+
+// WebAuthnAssertion 是浏览器断言结果（前端已将 ArrayBuffer 转为 base64url）
+type WebAuthnAssertion struct {
+	ID                string `json:"id"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AuthenticatorData string `json:"authenticator_data"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"user_handle"`
+}
+
+// WebAuthnService 处理 passkey 注册、两步验证与免密码登录
+type WebAuthnService struct {
+	credRepo       WebAuthnCredentialRepository
+	cache          WebAuthnChallengeCache
+	userRepo       UserRepository
+	settingService *SettingService
+	totpService    *TotpService
+	cfg            *config.Config
+}
+
+// NewWebAuthnService creates a new WebAuthnService
+func NewWebAuthnService(
+	credRepo WebAuthnCredentialRepository,
+	cache WebAuthnChallengeCache,
+	userRepo UserRepository,
+	settingService *SettingService,
+	totpService *TotpService,
+	cfg *config.Config,
+) *WebAuthnService {
+	return &WebAuthnService{
+		credRepo:       credRepo,
+		cache:          cache,
+		userRepo:       userRepo,
+		settingService: settingService,
+		totpService:    totpService,
+		cfg:            cfg,
+	}
+}
+
+// IsEnabled reports whether the passkey feature is enabled by the admin
+func (s *WebAuthnService) IsEnabled(ctx context.Context) bool {
+	return s.settingService != nil && s.settingService.IsWebAuthnEnabled(ctx)
+}
+
+// relyingParty 返回配置的 RP ID 与允许的 origin。
+// 不从请求头推导：Origin 由客户端提供，不能作为信任依据；origin 的合法性在配置校验时已检查。
+func (s *WebAuthnService) relyingParty() (string, []string, error) {
+	if s.cfg == nil || s.cfg.WebAuthn.RPID == "" {
+		return "", nil, ErrWebAuthnNotConfigured
+	}
+	rpID := s.cfg.WebAuthn.RPID
+	origins := s.cfg.WebAuthn.Origins
+	if len(origins) == 0 {
+		origins = []string{"https://" + rpID}
+	}
+	return rpID, origins, nil
+}
+
+func (s *WebAuthnService) rpName() string {
+	if s.cfg != nil && strings.TrimSpace(s.cfg.WebAuthn.RPName) != "" {
+		return s.cfg.WebAuthn.RPName
+	}
+	return defaultWebAuthnRPName
+}
+
+// webAuthnUserHandle 是与用户绑定的不透明 user handle（8 字节用户 ID），用于可发现凭证
+func webAuthnUserHandle(userID int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(userID))
+	return b
+}
+
+func (s *WebAuthnService) newChallenge(ctx context.Context, ch *WebAuthnChallenge) (string, error) {
+	raw := make([]byte, webAuthnChallengeBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate challenge: %w", err)
+	}
+	challengeID, err := generateRandomToken(16)
+	if err != nil {
+		return "", fmt.Errorf("generate challenge id: %w", err)
+	}
+	ch.Challenge = webauthn.EncodeBase64URL(raw)
+	if err := s.cache.SetChallenge(ctx, challengeID, ch, webAuthnChallengeTTL); err != nil {
+		return "", fmt.Errorf("store challenge: %w", err)
+	}
+	return challengeID, nil
+}
+
+func (s *WebAuthnService) consumeChallenge(ctx context.Context, challengeID, purpose string) (*WebAuthnChallenge, error) {
+	ch, err := s.cache.ConsumeChallenge(ctx, challengeID)
+	if err != nil {
+		return nil, fmt.Errorf("consume challenge: %w", err)
+	}
+	if ch == nil || ch.Purpose != purpose {
+		return nil, ErrWebAuthnChallengeExpired
+	}
+	return ch, nil
+}
+
+func (ch *WebAuthnChallenge) expectation(requireUV bool) (webauthn.Expectation, error) {
+	raw, err := webauthn.DecodeBase64URL(ch.Challenge)
+	if err != nil {
+		return webauthn.Expectation{}, ErrWebAuthnChallengeExpired
+	}
+	return webauthn.Expectation{
+		Challenge:               raw,
+		RPID:                    ch.RPID,
+		Origins:                 ch.Origins,
+		RequireUserVerification: requireUV,
+	}, nil
+}
+
+func credentialDescriptors(creds []WebAuthnCredential) []WebAuthnCredentialDescriptor {
+	out := make([]WebAuthnCredentialDescriptor, 0, len(creds))
+	for _, cred := range creds {
+		out = append(out, WebAuthnCredentialDescriptor{
+			Type:       webAuthnCredentialsType,
+			ID:         cred.CredentialID,
+			Transports: cred.Transports,
+		})
+	}
+	return out
+}
+
+func normalizeWebAuthnName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxWebAuthnNameLength {
+		return "", ErrWebAuthnInvalidName
+	}
+	return name, nil
+}
+
+// BeginRegistration 生成注册参数。注册 passkey 属于敏感操作，需要先通过身份验证。
+func (s *WebAuthnService) BeginRegistration(ctx context.Context, userID int64, emailCode, password string) (*WebAuthnCreationOptions, error) {
+	if !s.IsEnabled(ctx) {
+		return nil, ErrWebAuthnNotEnabled
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if err := s.totpService.VerifyIdentity(ctx, user, emailCode, password); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.credRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list passkeys: %w", err)
+	}
+	if len(existing) >= maxWebAuthnCredentials {
+		return nil, ErrWebAuthnCredentialLimit
+	}
+
+	rpID, origins, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	ch := &WebAuthnChallenge{Purpose: WebAuthnPurposeRegister, UserID: userID, RPID: rpID, Origins: origins}
+	challengeID, err := s.newChallenge(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &WebAuthnCreationOptions{ChallengeID: challengeID}
+	pk := &opts.PublicKey
+	pk.Challenge = ch.Challenge
+	pk.RP.ID = rpID
+	pk.RP.Name = s.rpName()
+	pk.User.ID = webauthn.EncodeBase64URL(webAuthnUserHandle(userID))
+	pk.User.Name = user.Email
+	pk.User.DisplayName = user.Email
+	if user.Username != "" {
+		pk.User.DisplayName = user.Username
+	}
+	for _, alg := range webauthn.SupportedAlgorithms() {
+		pk.PubKeyCredParams = append(pk.PubKeyCredParams, WebAuthnCredentialParam{Type: webAuthnCredentialsType, Alg: alg})
+	}
+	pk.Timeout = webAuthnTimeoutMillis
+	pk.ExcludeCredentials = credentialDescriptors(existing)
+	// 优先创建可发现凭证，使其同时可用于免密码登录
+	pk.AuthenticatorSelection.ResidentKey = "preferred"
+	pk.AuthenticatorSelection.UserVerification = "preferred"
+	pk.Attestation = "none"
+	return opts, nil
+}
+
+// FinishRegistration 校验注册结果并保存 passkey
+func (s *WebAuthnService) FinishRegistration(ctx context.Context, userID int64, challengeID, name string, att *WebAuthnAttestation) (*WebAuthnCredential, error) {
+	if !s.IsEnabled(ctx) {
+		return nil, ErrWebAuthnNotEnabled
+	}
+	name, err := normalizeWebAuthnName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := s.consumeChallenge(ctx, challengeID, WebAuthnPurposeRegister)
+	if err != nil {
+		return nil, err
+	}
+	if ch.UserID != userID {
+		return nil, ErrWebAuthnChallengeExpired
+	}
+	exp, err := ch.expectation(false)
+	if err != nil {
+		return nil, err
+	}
+
+	clientData, err1 := webauthn.DecodeBase64URL(att.ClientDataJSON)
+	attObj, err2 := webauthn.DecodeBase64URL(att.AttestationObject)
+	if err1 != nil || err2 != nil {
+		return nil, ErrWebAuthnVerifyFailed
+	}
+	verified, err := webauthn.VerifyRegistration(exp, clientData, attObj)
+	if err != nil {
+		slog.Info("webauthn_registration_rejected", "user_id", userID, "error", err)
+		return nil, ErrWebAuthnVerifyFailed
+	}
+
+	credentialID := webauthn.EncodeBase64URL(verified.ID)
+	if existing, err := s.credRepo.GetByCredentialID(ctx, credentialID); err == nil && existing != nil {
+		return nil, ErrWebAuthnCredentialExists
+	} else if err != nil && !errors.Is(err, ErrWebAuthnCredentialNotFound) {
+		return nil, fmt.Errorf("check passkey: %w", err)
+	}
+
+	count, err := s.credRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("count passkeys: %w", err)
+	}
+	if count >= maxWebAuthnCredentials {
+		return nil, ErrWebAuthnCredentialLimit
+	}
+
+	cred := &WebAuthnCredential{
+		UserID:       userID,
+		Name:         name,
+		CredentialID: credentialID,
+		PublicKey:    verified.PublicKey,
+		SignCount:    int64(verified.SignCount),
+		AAGUID:       formatAAGUID(verified.AAGUID),
+		Transports:   sanitizeTransports(att.Transports),
+		UserVerified: verified.UserVerified,
+	}
+	if err := s.credRepo.Create(ctx, cred); err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+// ListCredentials 列出用户的 passkey
+func (s *WebAuthnService) ListCredentials(ctx context.Context, userID int64) ([]WebAuthnCredential, error) {
+	return s.credRepo.ListByUserID(ctx, userID)
+}
+
+// RenameCredential 修改 passkey 名称
+func (s *WebAuthnService) RenameCredential(ctx context.Context, userID, id int64, name string) error {
+	name, err := normalizeWebAuthnName(name)
+	if err != nil {
+		return err
+	}
+	return s.credRepo.UpdateName(ctx, userID, id, name)
+}
+
+// DeleteCredential 删除 passkey，与关闭 TOTP 一样需要身份验证
+func (s *WebAuthnService) DeleteCredential(ctx context.Context, userID, id int64, emailCode, password string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	if err := s.totpService.VerifyIdentity(ctx, user, emailCode, password); err != nil {
+		return err
+	}
+	return s.credRepo.Delete(ctx, userID, id)
+}
+
+// HasCredentials reports whether the user has at least one passkey
+func (s *WebAuthnService) HasCredentials(ctx context.Context, userID int64) (bool, error) {
+	count, err := s.credRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// BeginSecondFactor 为密码登录后的两步验证生成断言参数，只允许该用户的 passkey
+func (s *WebAuthnService) BeginSecondFactor(ctx context.Context, tempToken string) (*WebAuthnRequestOptions, error) {
+	if !s.IsEnabled(ctx) {
+		return nil, ErrWebAuthnNotEnabled
+	}
+	session, err := s.totpService.GetLoginSession(ctx, tempToken)
+	if err != nil || session == nil {
+		return nil, ErrTwoFactorSessionInvalid
+	}
+
+	creds, err := s.credRepo.ListByUserID(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("list passkeys: %w", err)
+	}
+	if len(creds) == 0 {
+		return nil, ErrWebAuthnNoCredentials
+	}
+
+	rpID, origins, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	ch := &WebAuthnChallenge{Purpose: WebAuthnPurposeSecondStep, UserID: session.UserID, TempToken: tempToken, RPID: rpID, Origins: origins}
+	challengeID, err := s.newChallenge(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnRequestOptions{
+		ChallengeID: challengeID,
+		PublicKey: WebAuthnPublicKeyGet{
+			Challenge:        ch.Challenge,
+			RPID:             rpID,
+			Timeout:          webAuthnTimeoutMillis,
+			AllowCredentials: credentialDescriptors(creds),
+			UserVerification: "preferred",
+		},
+	}, nil
+}
+
+// FinishSecondFactor 校验两步验证断言，成功后消费登录临时令牌并返回用户
+func (s *WebAuthnService) FinishSecondFactor(ctx context.Context, tempToken, challengeID string, assertion *WebAuthnAssertion) (*User, error) {
+	if !s.IsEnabled(ctx) {
+		return nil, ErrWebAuthnNotEnabled
+	}
+	ch, err := s.consumeChallenge(ctx, challengeID, WebAuthnPurposeSecondStep)
+	if err != nil {
+		return nil, err
+	}
+	if ch.TempToken != tempToken {
+		return nil, ErrWebAuthnChallengeExpired
+	}
+	session, err := s.totpService.GetLoginSession(ctx, tempToken)
+	if err != nil || session == nil || session.UserID != ch.UserID {
+		return nil, ErrTwoFactorSessionInvalid
+	}
+
+	// 第二因素只需证明持有凭证，用户验证（PIN/生物识别）不做强制要求
+	if _, err := s.verifyAssertion(ctx, ch, assertion, session.UserID, false); err != nil {
+		return nil, err
+	}
+	_ = s.totpService.DeleteLoginSession(ctx, tempToken)
+
+	return s.userRepo.GetByID(ctx, session.UserID)
+}
+
+// BeginPasswordless 生成免密码登录的断言参数，由浏览器列出可发现凭证供用户选择
+func (s *WebAuthnService) BeginPasswordless(ctx context.Context) (*WebAuthnRequestOptions, error) {
+	if !s.IsEnabled(ctx) {
+		return nil, ErrWebAuthnNotEnabled
+	}
+	rpID, origins, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	ch := &WebAuthnChallenge{Purpose: WebAuthnPurposePasswordless, RPID: rpID, Origins: origins}
+	challengeID, err := s.newChallenge(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnRequestOptions{
+		ChallengeID: challengeID,
+		PublicKey: WebAuthnPublicKeyGet{
+			Challenge:        ch.Challenge,
+			RPID:             rpID,
+			Timeout:          webAuthnTimeoutMillis,
+			AllowCredentials: []WebAuthnCredentialDescriptor{},
+			UserVerification: "required",
+		},
+	}, nil
+}
+
+// FinishPasswordless 校验免密码登录断言并返回用户。
+// 免密码登录同时满足"持有"与"验证"两个因素，因此要求认证器完成用户验证，并跳过后续两步验证。
+func (s *WebAuthnService) FinishPasswordless(ctx context.Context, challengeID string, assertion *WebAuthnAssertion) (*User, error) {
+	if !s.IsEnabled(ctx) {
+		return nil, ErrWebAuthnNotEnabled
+	}
+	ch, err := s.consumeChallenge(ctx, challengeID, WebAuthnPurposePasswordless)
+	if err != nil {
+		return nil, err
+	}
+
+	cred, err := s.verifyAssertion(ctx, ch, assertion, 0, true)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, cred.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if !user.IsActive() {
+		return nil, ErrUserNotActive
+	}
+	return user, nil
+}
+
+// verifyAssertion 查找凭证并校验签名；userID 非 0 时要求凭证属于该用户
+func (s *WebAuthnService) verifyAssertion(ctx context.Context, ch *WebAuthnChallenge, assertion *WebAuthnAssertion, userID int64, requireUV bool) (*WebAuthnCredential, error) {
+	if assertion == nil || assertion.ID == "" {
+		return nil, ErrWebAuthnVerifyFailed
+	}
+	rawID, err := webauthn.DecodeBase64URL(assertion.ID)
+	if err != nil {
+		return nil, ErrWebAuthnVerifyFailed
+	}
+	cred, err := s.credRepo.GetByCredentialID(ctx, webauthn.EncodeBase64URL(rawID))
+	if err != nil {
+		if errors.Is(err, ErrWebAuthnCredentialNotFound) {
+			return nil, ErrWebAuthnVerifyFailed
+		}
+		return nil, fmt.Errorf("get passkey: %w", err)
+	}
+	if userID != 0 && cred.UserID != userID {
+		return nil, ErrWebAuthnVerifyFailed
+	}
+	if assertion.UserHandle != "" {
+		handle, err := webauthn.DecodeBase64URL(assertion.UserHandle)
+		if err != nil || string(handle) != string(webAuthnUserHandle(cred.UserID)) {
+			return nil, ErrWebAuthnVerifyFailed
+		}
+	}
+
+	exp, err := ch.expectation(requireUV)
+	if err != nil {
+		return nil, err
+	}
+	clientData, err1 := webauthn.DecodeBase64URL(assertion.ClientDataJSON)
+	authData, err2 := webauthn.DecodeBase64URL(assertion.AuthenticatorData)
+	signature, err3 := webauthn.DecodeBase64URL(assertion.Signature)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return nil, ErrWebAuthnVerifyFailed
+	}
+
+	parsed, err := webauthn.VerifyAssertion(exp, cred.PublicKey, clientData, authData, signature)
+	if err != nil {
+		slog.Info("webauthn_assertion_rejected", "user_id", cred.UserID, "credential", cred.ID, "error", err)
+		return nil, ErrWebAuthnVerifyFailed
+	}
+
+	// 签名计数器：两端均非 0 且未递增，说明凭证可能被克隆
+	newCount := int64(parsed.SignCount)
+	if (newCount != 0 || cred.SignCount != 0) && newCount <= cred.SignCount {
+		slog.Warn("webauthn_sign_count_regressed", "user_id", cred.UserID, "credential", cred.ID,
+			"stored", cred.SignCount, "received", newCount)
+		return nil, ErrWebAuthnVerifyFailed
+	}
+
+	now := time.Now()
+	if err := s.credRepo.UpdateUsage(ctx, cred.ID, newCount, now); err != nil {
+		return nil, fmt.Errorf("update passkey usage: %w", err)
+	}
+	cred.SignCount = newCount
+	cred.LastUsedAt = &now
+	return cred, nil
+}
+
+func formatAAGUID(b []byte) string {
+	if len(b) != 16 {
+		return ""
+	}
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// sanitizeTransports 只保留规范定义的 transport 取值，避免保存任意客户端输入
+func sanitizeTransports(in []string) []string {
+	allowed := map[string]bool{"usb": true, "nfc": true, "ble": true, "internal": true, "hybrid": true, "smart-card": true}
+	out := make([]string, 0, len(in))
+	for _, t := range in {
+		if allowed[t] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
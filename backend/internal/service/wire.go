@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"time"
 
+	dbent "github.com/Wei-Shaw/sub2api/ent"
 	"github.com/Wei-Shaw/sub2api/internal/config"
 	"github.com/google/wire"
 	"github.com/redis/go-redis/v9"
@@ -73,8 +74,23 @@ func ProvideAccountExpiryService(accountRepo AccountRepository) *AccountExpirySe
 }
 
 // ProvideSubscriptionExpiryService creates and starts SubscriptionExpiryService.
-func ProvideSubscriptionExpiryService(userSubRepo UserSubscriptionRepository) *SubscriptionExpiryService {
-	svc := NewSubscriptionExpiryService(userSubRepo, time.Minute)
+func ProvideSubscriptionExpiryService(userSubRepo UserSubscriptionRepository, lifecycle *SubscriptionLifecycleService) *SubscriptionExpiryService {
+	svc := NewSubscriptionExpiryService(userSubRepo, lifecycle, time.Minute)
+	svc.Start()
+	return svc
+}
+
+// ProvideInviteCommissionService creates and starts InviteCommissionService.
+func ProvideInviteCommissionService(
+	entClient *dbent.Client,
+	commissionRepo InviteCommissionRepository,
+	userRepo UserRepository,
+	redeemRepo RedeemCodeRepository,
+	settingService *SettingService,
+	billingCacheService *BillingCacheService,
+	authCacheInvalidator APIKeyAuthCacheInvalidator,
+) *InviteCommissionService {
+	svc := NewInviteCommissionService(entClient, commissionRepo, userRepo, redeemRepo, settingService, billingCacheService, authCacheInvalidator, 5*time.Minute)
 	svc.Start()
 	return svc
 }
@@ -118,6 +134,21 @@ func ProvideSchedulerSnapshotService(
 	return svc
 }
 
+// ProvideAPIKeyService creates APIKeyService with organization support.
+func ProvideAPIKeyService(
+	apiKeyRepo APIKeyRepository,
+	userRepo UserRepository,
+	groupRepo GroupRepository,
+	userSubRepo UserSubscriptionRepository,
+	orgRepo OrganizationRepository,
+	cache APIKeyCache,
+	cfg *config.Config,
+) *APIKeyService {
+	svc := NewAPIKeyService(apiKeyRepo, userRepo, groupRepo, userSubRepo, cache, cfg)
+	svc.SetOrganizationRepository(orgRepo)
+	return svc
+}
+
 // ProvideRateLimitService creates RateLimitService with optional dependencies.
 func ProvideRateLimitService(
 	accountRepo AccountRepository,
@@ -143,7 +174,7 @@ func ProvideOpsMetricsCollector(
 	accountRepo AccountRepository,
 	concurrencyService *ConcurrencyService,
 	db *sql.DB,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsMetricsCollector {
 	collector := NewOpsMetricsCollector(opsRepo, settingRepo, accountRepo, concurrencyService, db, redisClient, cfg)
@@ -156,7 +187,7 @@ func ProvideOpsAggregationService(
 	opsRepo OpsRepository,
 	settingRepo SettingRepository,
 	db *sql.DB,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsAggregationService {
 	svc := NewOpsAggregationService(opsRepo, settingRepo, db, redisClient, cfg)
@@ -169,10 +200,11 @@ func ProvideOpsAlertEvaluatorService(
 	opsService *OpsService,
 	opsRepo OpsRepository,
 	emailService *EmailService,
-	redisClient *redis.Client,
+	usageAnomalyService *UsageAnomalyService,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsAlertEvaluatorService {
-	svc := NewOpsAlertEvaluatorService(opsService, opsRepo, emailService, redisClient, cfg)
+	svc := NewOpsAlertEvaluatorService(opsService, opsRepo, emailService, usageAnomalyService, redisClient, cfg)
 	svc.Start()
 	return svc
 }
@@ -181,7 +213,7 @@ func ProvideOpsAlertEvaluatorService(
 func ProvideOpsCleanupService(
 	opsRepo OpsRepository,
 	db *sql.DB,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsCleanupService {
 	svc := NewOpsCleanupService(opsRepo, db, redisClient, cfg)
@@ -194,7 +226,7 @@ func ProvideOpsScheduledReportService(
 	opsService *OpsService,
 	userService *UserService,
 	emailService *EmailService,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsScheduledReportService {
 	svc := NewOpsScheduledReportService(opsService, userService, emailService, redisClient, cfg)
@@ -202,6 +234,35 @@ func ProvideOpsScheduledReportService(
 	return svc
 }
 
+// ProvideAPIKeyHashMigrationService creates and starts the plaintext API key hash migration.
+func ProvideAPIKeyHashMigrationService(apiKeyRepo APIKeyRepository, apiKeyService *APIKeyService) *APIKeyHashMigrationService {
+	svc := NewAPIKeyHashMigrationService(apiKeyRepo, apiKeyService)
+	svc.Start()
+	return svc
+}
+
+// ProvideSecretReencryptService creates and starts the credential re-encryption job.
+func ProvideSecretReencryptService(repo SecretReencryptRepository, cipher SecretCipher, cfg *config.Config) *SecretReencryptService {
+	svc := NewSecretReencryptService(repo, cipher, cfg)
+	svc.Start()
+	return svc
+}
+
+// ProvideIPProtectionService creates the IP protection service and starts access rule refresh.
+func ProvideIPProtectionService(cache IPProtectionCache, settingRepo SettingRepository, cfg *config.Config) *IPProtectionService {
+	svc := NewIPProtectionService(cache, settingRepo, cfg)
+	svc.Start()
+	return svc
+}
+
+// ProvideAuthSessionService 创建登录会话服务，并注册为修改/重置密码时的会话吊销实现
+func ProvideAuthSessionService(cache AuthSessionCache, userRepo UserRepository, authService *AuthService, userService *UserService, cfg *config.Config) *AuthSessionService {
+	svc := NewAuthSessionService(cache, userRepo, authService, cfg)
+	authService.SetSessionRevoker(svc)
+	userService.SetSessionRevoker(svc)
+	return svc
+}
+
 // ProvideAPIKeyAuthCacheInvalidator 提供 API Key 认证缓存失效能力
 func ProvideAPIKeyAuthCacheInvalidator(apiKeyService *APIKeyService) APIKeyAuthCacheInvalidator {
 	// Start Pub/Sub subscriber for L1 cache invalidation across instances
@@ -214,7 +275,7 @@ var ProviderSet = wire.NewSet(
 	// Core services
 	NewAuthService,
 	NewUserService,
-	NewAPIKeyService,
+	ProvideAPIKeyService,
 	ProvideAPIKeyAuthCacheInvalidator,
 	NewGroupService,
 	NewAccountService,
@@ -230,6 +291,7 @@ var ProviderSet = wire.NewSet(
 	NewBillingService,
 	NewBillingCacheService,
 	NewAdminService,
+	NewAccountDedicationService,
 	NewGatewayService,
 	NewOpenAIGatewayService,
 	NewOAuthService,
@@ -249,10 +311,12 @@ var ProviderSet = wire.NewSet(
 	NewAccountUsageService,
 	NewAccountTestService,
 	NewSettingService,
+	NewContentPolicyService,
 	NewOpsService,
 	ProvideOpsMetricsCollector,
 	ProvideOpsAggregationService,
 	ProvideOpsAlertEvaluatorService,
+	NewUsageAnomalyService,
 	ProvideOpsCleanupService,
 	ProvideOpsScheduledReportService,
 	NewEmailService,
@@ -260,15 +324,23 @@ var ProviderSet = wire.NewSet(
 	NewTurnstileService,
 	NewSubscriptionService,
 	NewSubscriptionReminderService,
+	NewSubscriptionLifecycleService,
+	NewOrganizationService,
 	NewUploadService,
 	ProvideConcurrencyService,
 	ProvideSchedulerSnapshotService,
 	NewIdentityService,
 	NewCRSSyncService,
+	NewAccountTransferService,
+	NewBackupService,
 	ProvideUpdateService,
 	ProvideTokenRefreshService,
 	ProvideAccountExpiryService,
 	ProvideSubscriptionExpiryService,
+	ProvideInviteCommissionService,
+	ProvideAPIKeyHashMigrationService,
+	ProvideSecretReencryptService,
+	ProvideIPProtectionService,
 	ProvideTimingWheelService,
 	ProvideDashboardAggregationService,
 	ProvideUsageCleanupService,
@@ -277,4 +349,7 @@ var ProviderSet = wire.NewSet(
 	NewUserAttributeService,
 	NewUsageCache,
 	NewTotpService,
+	ProvideAuthSessionService,
+	NewWebAuthnService,
+	NewTwoFactorService,
 )
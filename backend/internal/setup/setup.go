@@ -66,7 +66,9 @@ type SetupConfig struct {
 	Admin    AdminConfig    `json:"admin" yaml:"-"` // Not stored in config file
 	Server   ServerConfig   `json:"server" yaml:"server"`
 	JWT      JWTConfig      `json:"jwt" yaml:"jwt"`
-	Timezone string         `json:"timezone" yaml:"timezone"` // e.g. "Asia/Shanghai", "UTC"
+	// APIKeyHash is generated during installation, never accepted from the client
+	APIKeyHash APIKeyHashConfig `json:"-" yaml:"api_key_hash"`
+	Timezone   string           `json:"timezone" yaml:"timezone"` // e.g. "Asia/Shanghai", "UTC"
 }
 
 type DatabaseConfig struct {
@@ -101,6 +103,10 @@ type JWTConfig struct {
 	ExpireHour int    `json:"expire_hour" yaml:"expire_hour"`
 }
 
+type APIKeyHashConfig struct {
+	Secret string `yaml:"secret"`
+}
+
 // NeedsSetup checks if the system needs initial setup
 // Uses multiple checks to prevent attackers from forcing re-setup by deleting config
 func NeedsSetup() bool {
@@ -237,6 +243,15 @@ func Install(cfg *SetupConfig) error {
 		log.Println("Warning: JWT secret auto-generated. Consider setting a fixed secret for production.")
 	}
 
+	// Generate API key hash secret if not provided; it must never change once keys are issued
+	if cfg.APIKeyHash.Secret == "" {
+		secret, err := generateSecret(32)
+		if err != nil {
+			return fmt.Errorf("failed to generate api key hash secret: %w", err)
+		}
+		cfg.APIKeyHash.Secret = secret
+	}
+
 	// Test connections
 	if err := TestDatabaseConnection(&cfg.Database); err != nil {
 		return fmt.Errorf("database connection failed: %w", err)
@@ -375,7 +390,8 @@ func writeConfigFile(cfg *SetupConfig) error {
 			Secret     string `yaml:"secret"`
 			ExpireHour int    `yaml:"expire_hour"`
 		} `yaml:"jwt"`
-		Default struct {
+		APIKeyHash APIKeyHashConfig `yaml:"api_key_hash"`
+		Default    struct {
 			UserConcurrency int     `yaml:"user_concurrency"`
 			UserBalance     float64 `yaml:"user_balance"`
 			APIKeyPrefix    string  `yaml:"api_key_prefix"`
@@ -397,6 +413,7 @@ func writeConfigFile(cfg *SetupConfig) error {
 			Secret:     cfg.JWT.Secret,
 			ExpireHour: cfg.JWT.ExpireHour,
 		},
+		APIKeyHash: cfg.APIKeyHash,
 		Default: struct {
 			UserConcurrency int     `yaml:"user_concurrency"`
 			UserBalance     float64 `yaml:"user_balance"`
@@ -503,6 +520,9 @@ func AutoSetupFromEnv() error {
 			Secret:     getEnvOrDefault("JWT_SECRET", ""),
 			ExpireHour: getEnvIntOrDefault("JWT_EXPIRE_HOUR", 24),
 		},
+		APIKeyHash: APIKeyHashConfig{
+			Secret: getEnvOrDefault("API_KEY_HASH_SECRET", ""),
+		},
 		Timezone: tz,
 	}
 
@@ -516,6 +536,15 @@ func AutoSetupFromEnv() error {
 		log.Println("Warning: JWT secret auto-generated. Consider setting a fixed secret for production.")
 	}
 
+	// Generate API key hash secret if not provided; it must never change once keys are issued
+	if cfg.APIKeyHash.Secret == "" {
+		secret, err := generateSecret(32)
+		if err != nil {
+			return fmt.Errorf("failed to generate api key hash secret: %w", err)
+		}
+		cfg.APIKeyHash.Secret = secret
+	}
+
 	// Generate admin password if not provided
 	if cfg.Admin.Password == "" {
 		password, err := generateSecret(16)
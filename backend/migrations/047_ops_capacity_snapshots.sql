@@ -0,0 +1,30 @@
+-- Ops capacity snapshots: periodic per-group / per-platform account pool samples.
+--
+-- Written by the ops metrics collector alongside ops_system_metrics; used by the
+-- capacity planner report (peak concurrency, zero-availability time).
+-- Rows with group_id IS NULL are platform-level aggregates.
+
+CREATE TABLE IF NOT EXISTS ops_capacity_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    window_seconds INT NOT NULL DEFAULT 60,
+
+    platform VARCHAR(32) NOT NULL DEFAULT '',
+    group_id BIGINT,
+
+    total_accounts INT NOT NULL DEFAULT 0,
+    available_accounts INT NOT NULL DEFAULT 0,
+    rate_limited_accounts INT NOT NULL DEFAULT 0,
+
+    max_concurrency BIGINT NOT NULL DEFAULT 0,
+    current_in_use BIGINT NOT NULL DEFAULT 0,
+    waiting_in_queue BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_ops_capacity_snapshots_created_at ON ops_capacity_snapshots(created_at);
+CREATE INDEX IF NOT EXISTS idx_ops_capacity_snapshots_group_created_at ON ops_capacity_snapshots(group_id, created_at);
+
+COMMENT ON TABLE ops_capacity_snapshots IS 'Ops account pool capacity samples (per group / per platform) for capacity planning.';
+COMMENT ON COLUMN ops_capacity_snapshots.window_seconds IS 'Sampling interval the row represents (seconds).';
+COMMENT ON COLUMN ops_capacity_snapshots.group_id IS 'Group ID; NULL means platform-level aggregate.';
+COMMENT ON COLUMN ops_capacity_snapshots.available_accounts IS 'Accounts that were schedulable (active, not rate-limited/overloaded/temp-unschedulable) at sample time.';
@@ -0,0 +1,8 @@
+-- 分组账号调度策略
+-- 空字符串表示默认策略（优先级 > 负载率 > 最近最少使用）
+-- 可选值：least_loaded, weighted, quota_aware, cost_aware
+
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS scheduling_strategy VARCHAR(32) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN groups.scheduling_strategy IS '账号调度策略：空=默认, least_loaded, weighted, quota_aware, cost_aware';
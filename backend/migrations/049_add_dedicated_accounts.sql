@@ -0,0 +1,17 @@
+-- 用户 / API Key 专属账号
+-- dedicated_account_ids: 专属账号 ID 列表，调度时优先使用；这些账号不会被其他用户调度到
+-- dedicated_accounts_only: 为 true 时仅使用专属账号（专属账号不可用时不回退到分组公共账号）
+-- API Key 上配置的专属账号优先于用户级配置
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS dedicated_account_ids JSONB,
+    ADD COLUMN IF NOT EXISTS dedicated_accounts_only BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE api_keys
+    ADD COLUMN IF NOT EXISTS dedicated_account_ids JSONB,
+    ADD COLUMN IF NOT EXISTS dedicated_accounts_only BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN users.dedicated_account_ids IS '用户专属账号 ID 列表';
+COMMENT ON COLUMN users.dedicated_accounts_only IS '是否仅使用专属账号';
+COMMENT ON COLUMN api_keys.dedicated_account_ids IS 'API Key 专属账号 ID 列表（非空时覆盖用户级配置）';
+COMMENT ON COLUMN api_keys.dedicated_accounts_only IS '是否仅使用专属账号';
@@ -0,0 +1,9 @@
+-- 分组内容策略
+-- 在网关转发前执行：关键词/正则黑名单、PII 检测（邮箱/手机号/银行卡号，支持 block/redact/log）、
+-- 图片数量与大小限制、可选的外部审核 Webhook
+-- NULL 表示未配置
+
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS content_policy JSONB;
+
+COMMENT ON COLUMN groups.content_policy IS '内容策略配置（JSON），NULL 表示未配置';
@@ -0,0 +1,69 @@
+-- 组织 / 团队：共享余额、成员角色、成员消费上限与邮件邀请
+-- 组织 Key（api_keys.organization_id 非空）的消费从组织余额扣除；
+-- 订阅分组下使用组织所有者名下的订阅。
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    owner_user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    balance DECIMAL(20,8) NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_organizations_owner_user_id ON organizations(owner_user_id);
+CREATE INDEX IF NOT EXISTS idx_organizations_status ON organizations(status);
+CREATE INDEX IF NOT EXISTS idx_organizations_deleted_at ON organizations(deleted_at);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    monthly_limit_usd DECIMAL(20,8) DEFAULT NULL,
+    monthly_usage_usd DECIMAL(20,10) NOT NULL DEFAULT 0,
+    monthly_window_start TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_members_org_user
+    ON organization_members(organization_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    invited_by BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_user_id BIGINT DEFAULT NULL,
+    accepted_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_org_id ON organization_invitations(organization_id);
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_email ON organization_invitations(email);
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_status ON organization_invitations(status);
+
+ALTER TABLE api_keys
+    ADD COLUMN IF NOT EXISTS organization_id BIGINT DEFAULT NULL REFERENCES organizations(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_organization_id ON api_keys(organization_id);
+
+COMMENT ON TABLE organizations IS '组织（共享余额）';
+COMMENT ON COLUMN organizations.owner_user_id IS '组织所有者用户ID（组织订阅挂在其名下）';
+COMMENT ON COLUMN organizations.balance IS '组织共享余额';
+COMMENT ON TABLE organization_members IS '组织成员';
+COMMENT ON COLUMN organization_members.role IS '角色: owner, admin, member';
+COMMENT ON COLUMN organization_members.monthly_limit_usd IS '成员月度消费上限（NULL 表示不限制）';
+COMMENT ON COLUMN organization_members.monthly_usage_usd IS '成员当前自然月消费';
+COMMENT ON TABLE organization_invitations IS '组织邮件邀请';
+COMMENT ON COLUMN organization_invitations.token_hash IS '邀请令牌 SHA-256 摘要';
+COMMENT ON COLUMN api_keys.organization_id IS '所属组织ID（非空时从组织余额扣费）';
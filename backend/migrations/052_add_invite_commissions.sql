@@ -0,0 +1,63 @@
+-- 邀请返佣：按被邀请人实际消费（usage_logs.actual_cost）比例计佣
+-- 后台任务基于 user_invites.commission_cursor 增量累计，佣金计入 users.commission_balance，
+-- 可转入账户余额或申请提现（管理员审核）。
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS commission_balance DECIMAL(20,8) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS register_ip VARCHAR(45) DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS register_device VARCHAR(128) DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_register_ip ON users(register_ip);
+CREATE INDEX IF NOT EXISTS idx_users_register_device ON users(register_device);
+
+ALTER TABLE user_invites
+    ADD COLUMN IF NOT EXISTS commission_status VARCHAR(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS commission_block_reason VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS commission_cursor BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS commission_total DECIMAL(20,8) NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_user_invites_commission_status ON user_invites(commission_status);
+
+-- 已存在的邀请关系从当前最大日志 ID 开始计佣，避免开启功能后对历史消费补发佣金
+UPDATE user_invites
+SET commission_cursor = COALESCE((SELECT MAX(id) FROM usage_logs), 0)
+WHERE commission_cursor = 0;
+
+CREATE TABLE IF NOT EXISTS invite_commissions (
+    id BIGSERIAL PRIMARY KEY,
+    invite_id BIGINT NOT NULL REFERENCES user_invites(id) ON DELETE CASCADE,
+    inviter_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invitee_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    base_amount DECIMAL(20,10) NOT NULL,
+    rate DECIMAL(10,4) NOT NULL,
+    amount DECIMAL(20,8) NOT NULL,
+    from_log_id BIGINT NOT NULL,
+    to_log_id BIGINT NOT NULL,
+    log_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_invite_commissions_invite_id ON invite_commissions(invite_id);
+CREATE INDEX IF NOT EXISTS idx_invite_commissions_inviter_created ON invite_commissions(inviter_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_invite_commissions_invitee_id ON invite_commissions(invitee_id);
+
+CREATE TABLE IF NOT EXISTS commission_withdrawals (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount DECIMAL(20,8) NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    account_info TEXT NOT NULL DEFAULT '',
+    processed_by BIGINT DEFAULT NULL,
+    admin_notes TEXT NOT NULL DEFAULT '',
+    processed_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_commission_withdrawals_user_id ON commission_withdrawals(user_id);
+CREATE INDEX IF NOT EXISTS idx_commission_withdrawals_status ON commission_withdrawals(status);
+CREATE INDEX IF NOT EXISTS idx_commission_withdrawals_created_at ON commission_withdrawals(created_at);
+
+COMMENT ON TABLE invite_commissions IS '邀请返佣计佣明细';
+COMMENT ON TABLE commission_withdrawals IS '佣金转出记录（转余额/提现）';
+COMMENT ON COLUMN user_invites.commission_cursor IS '已计佣的最后一条 usage_logs.id';
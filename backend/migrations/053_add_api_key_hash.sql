@@ -0,0 +1,12 @@
+-- API Key 哈希存储：key_hash 保存 HMAC-SHA256，key_prefix 保存创建时展示的前缀
+-- 旧记录的明文 key 由服务启动后的后台任务在线迁移为哈希并置空，迁移期间认证同时匹配两列
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS key_hash VARCHAR(64) DEFAULT NULL;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS key_prefix VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE api_keys ALTER COLUMN key DROP NOT NULL;
+
+-- 与 key 一致，软删除后的哈希同样不可复用
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys(key_hash);
+
+COMMENT ON COLUMN api_keys.key_hash IS 'HMAC-SHA256 of the key, hex encoded';
+COMMENT ON COLUMN api_keys.key_prefix IS 'Visible key prefix shown in listings';
@@ -0,0 +1,38 @@
+-- WebAuthn / passkey 两步验证与免密码登录，以及两步验证恢复码
+-- passkey 可作为 TOTP 之外的第二因素；完成过用户验证（生物识别 / PIN）的 passkey 可直接免密码登录。
+-- 恢复码仅保存 SHA-256 摘要，TOTP 与 passkey 共用。
+
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    credential_id VARCHAR(1400) NOT NULL UNIQUE,
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    aaguid VARCHAR(36) NOT NULL DEFAULT '',
+    transports JSONB,
+    user_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthn_credentials_user_id ON webauthn_credentials(user_id);
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_recovery_codes_user_code
+    ON user_recovery_codes(user_id, code_hash);
+
+COMMENT ON TABLE webauthn_credentials IS 'WebAuthn 凭证（passkey / 安全密钥）';
+COMMENT ON COLUMN webauthn_credentials.credential_id IS '凭证ID（base64url）';
+COMMENT ON COLUMN webauthn_credentials.public_key IS 'COSE 编码的公钥';
+COMMENT ON COLUMN webauthn_credentials.sign_count IS '认证器签名计数器（用于检测克隆）';
+COMMENT ON COLUMN webauthn_credentials.user_verified IS '注册时是否完成用户验证（允许免密码登录）';
+COMMENT ON TABLE user_recovery_codes IS '两步验证恢复码（SHA-256 摘要，一次性）';
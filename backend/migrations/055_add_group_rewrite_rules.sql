@@ -0,0 +1,8 @@
+-- 分组请求改写规则
+-- 网关转发前按平台/模型匹配执行：设置/删除/限制 JSON 字段、前置/追加系统提示词、增删上游请求头
+-- NULL 表示未配置
+
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS rewrite_rules JSONB;
+
+COMMENT ON COLUMN groups.rewrite_rules IS '请求改写规则（JSON 数组），NULL 表示未配置';
@@ -0,0 +1,8 @@
+-- 分组模型别名（虚拟模型）
+-- 用户请求别名时按顺序尝试 (平台, 模型) 目标，前一个目标无可用账号或故障转移耗尽时切换到下一个
+-- NULL 表示未配置
+
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS model_aliases JSONB;
+
+COMMENT ON COLUMN groups.model_aliases IS '模型别名（JSON 数组），NULL 表示未配置';
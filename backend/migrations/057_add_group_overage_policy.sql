@@ -0,0 +1,12 @@
+-- 订阅超额策略
+-- 订阅日/周/月额度用尽后：reject 拒绝请求（默认，保持原有行为）；
+-- balance 改为按超额倍率扣余额；fallback_group 降级到指定的按量计费分组
+
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS overage_policy VARCHAR(32) NOT NULL DEFAULT 'reject',
+    ADD COLUMN IF NOT EXISTS overage_rate_multiplier DECIMAL(10,4) NOT NULL DEFAULT 1.0,
+    ADD COLUMN IF NOT EXISTS overage_group_id BIGINT;
+
+COMMENT ON COLUMN groups.overage_policy IS '订阅超额策略：reject / balance / fallback_group';
+COMMENT ON COLUMN groups.overage_rate_multiplier IS 'balance 策略的计费倍率（替代订阅分组倍率）';
+COMMENT ON COLUMN groups.overage_group_id IS 'fallback_group 策略的降级分组 ID';
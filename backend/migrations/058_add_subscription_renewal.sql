@@ -0,0 +1,22 @@
+-- 订阅自助续费、切换与暂停
+-- groups.subscription_price：每个 default_validity_days 周期的余额价格，为空表示仅管理员/兑换码发放
+-- user_subscriptions.auto_renew：到期前由 SubscriptionExpiryService 自动从余额续费
+-- user_subscriptions.paused_at：暂停期间订阅不可用，恢复时按暂停时长顺延到期时间
+-- user_subscriptions.renewal_failed_at：最近一次自动续费失败时间（重试节流、避免重复发送失败邮件）
+
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS subscription_price DECIMAL(20,8);
+
+ALTER TABLE user_subscriptions
+    ADD COLUMN IF NOT EXISTS auto_renew BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS paused_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS renewal_failed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_user_subscriptions_auto_renew_expires
+    ON user_subscriptions (expires_at)
+    WHERE auto_renew = TRUE AND deleted_at IS NULL;
+
+COMMENT ON COLUMN groups.subscription_price IS '订阅每周期余额价格（USD），为空表示不支持自助续费/切换';
+COMMENT ON COLUMN user_subscriptions.auto_renew IS '到期前自动从余额续费';
+COMMENT ON COLUMN user_subscriptions.paused_at IS '暂停时间';
+COMMENT ON COLUMN user_subscriptions.renewal_failed_at IS '最近一次自动续费失败时间';
@@ -0,0 +1,11 @@
+-- 订阅 token / 请求次数额度
+-- 与 daily/weekly/monthly_limit_usd 同时生效，不受模型价格调整影响。
+-- 格式：[{"window":"daily","metric":"requests","limit":50,"model":"claude-opus-*"}]
+-- window: daily / weekly / monthly（与订阅用量窗口对齐）
+-- metric: requests / input_tokens / output_tokens / total_tokens
+-- model: 可选，支持末尾 * 通配；为空表示所有模型
+
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS usage_quotas JSONB;
+
+COMMENT ON COLUMN groups.usage_quotas IS '订阅窗口内的 token / 请求次数额度（可按模型限定）';
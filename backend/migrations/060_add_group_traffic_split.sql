@@ -0,0 +1,24 @@
+-- 分组灰度 / A-B 分流
+-- 请求按 (分组, 会话) 稳定哈希分配到实验组，实验组可指定账号子集或改写目标模型，未命中的流量记为 control
+-- 格式：{"enabled":true,"arms":[{"name":"opus-next","weight":5,"models":["claude-opus-*"],"model":"claude-opus-next","account_ids":[12,13]}]}
+-- NULL 表示未配置
+
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS traffic_split JSONB;
+
+COMMENT ON COLUMN groups.traffic_split IS '灰度 / A-B 分流配置（JSON），NULL 表示未配置';
+
+-- 记录请求所属实验组，用于运维看板按实验组对比错误率、延迟、首 token 耗时与成本
+ALTER TABLE usage_logs
+    ADD COLUMN IF NOT EXISTS traffic_arm VARCHAR(64);
+
+ALTER TABLE ops_error_logs
+    ADD COLUMN IF NOT EXISTS traffic_arm VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_usage_logs_group_traffic_arm_created_at
+    ON usage_logs (group_id, traffic_arm, created_at)
+    WHERE traffic_arm IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_ops_error_logs_group_traffic_arm_created_at
+    ON ops_error_logs (group_id, traffic_arm, created_at)
+    WHERE traffic_arm IS NOT NULL;
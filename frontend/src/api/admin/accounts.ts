@@ -13,7 +13,8 @@ import type {
   WindowStats,
   ClaudeModel,
   AccountUsageStatsResponse,
-  TempUnschedulableStatus
+  TempUnschedulableStatus,
+  AccountPlatform
 } from '@/types'
 
 /**
@@ -347,6 +348,64 @@ export async function syncFromCrs(params: {
   return data
 }
 
+export type AccountTransferFormat = 'sub2api' | 'crs' | 'codex' | 'gemini_cli' | 'api_keys'
+
+export interface AccountImportResult {
+  format: AccountTransferFormat
+  dry_run: boolean
+  created: number
+  duplicates: number
+  failed: number
+  items: Array<{
+    index: number
+    name: string
+    platform: string
+    type: string
+    fingerprint?: string
+    action: 'created' | 'would_create' | 'duplicate' | 'failed'
+    account_id?: number
+    duplicate_of?: number
+    error?: string
+  }>
+}
+
+/**
+ * Import accounts from a file (format is auto-detected when omitted)
+ */
+export async function importAccounts(params: {
+  content: string
+  format?: AccountTransferFormat
+  dry_run?: boolean
+  group_ids?: number[]
+  proxy_id?: number | null
+  sync_proxies?: boolean
+  platform?: AccountPlatform
+  base_url?: string
+  confirm_mixed_channel_risk?: boolean
+}): Promise<AccountImportResult> {
+  const { data } = await apiClient.post<AccountImportResult>('/admin/accounts/import', params)
+  return data
+}
+
+/**
+ * Export accounts (including credentials) as a JSON file
+ */
+export async function exportAccounts(params?: {
+  format?: 'sub2api' | 'crs'
+  ids?: number[]
+  platform?: string
+}): Promise<Blob> {
+  const response = await apiClient.get('/admin/accounts/export', {
+    params: {
+      format: params?.format,
+      ids: params?.ids?.join(','),
+      platform: params?.platform
+    },
+    responseType: 'blob'
+  })
+  return response.data
+}
+
 export const accountsAPI = {
   list,
   getById,
@@ -370,7 +429,9 @@ export const accountsAPI = {
   batchCreate,
   batchUpdateCredentials,
   bulkUpdate,
-  syncFromCrs
+  syncFromCrs,
+  importAccounts,
+  exportAccounts
 }
 
 export default accountsAPI
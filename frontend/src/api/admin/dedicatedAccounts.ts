@@ -0,0 +1,72 @@
+/**
+ * Admin Dedicated Accounts API endpoints
+ * Manages user / API key dedicated (pinned) upstream accounts
+ */
+
+import { apiClient } from '../client'
+
+export interface DedicatedAccountUsage {
+  account_id: number
+  name: string
+  platform: string
+  status: string
+  schedulable: boolean
+  concurrency: number
+  current_concurrency: number
+  waiting_count: number
+  load_rate: number
+  today_requests: number
+  today_tokens: number
+  today_cost: number
+  missing?: boolean
+}
+
+export interface AccountDedication {
+  owner_type: 'user' | 'api_key'
+  user_id: number
+  user_email: string
+  api_key_id?: number
+  api_key_name?: string
+  exclusive: boolean
+  accounts: DedicatedAccountUsage[]
+}
+
+export interface SetDedicatedAccountsRequest {
+  account_ids: number[]
+  exclusive: boolean
+}
+
+export async function list(): Promise<AccountDedication[]> {
+  const { data } = await apiClient.get<AccountDedication[]>('/admin/dedicated-accounts')
+  return data
+}
+
+export async function setUserAccounts(
+  userId: number,
+  payload: SetDedicatedAccountsRequest
+): Promise<{ message: string }> {
+  const { data } = await apiClient.put<{ message: string }>(
+    `/admin/dedicated-accounts/users/${userId}`,
+    payload
+  )
+  return data
+}
+
+export async function setApiKeyAccounts(
+  apiKeyId: number,
+  payload: SetDedicatedAccountsRequest
+): Promise<{ message: string }> {
+  const { data } = await apiClient.put<{ message: string }>(
+    `/admin/dedicated-accounts/api-keys/${apiKeyId}`,
+    payload
+  )
+  return data
+}
+
+export const dedicatedAccountsAPI = {
+  list,
+  setUserAccounts,
+  setApiKeyAccounts
+}
+
+export default dedicatedAccountsAPI
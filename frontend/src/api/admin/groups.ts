@@ -9,6 +9,10 @@ import type {
   GroupPlatform,
   CreateGroupRequest,
   UpdateGroupRequest,
+  SchedulingSimulationResult,
+  RequestRewriteRule,
+  RequestBodyFormat,
+  RewriteDryRunResult,
   PaginatedResponse
 } from '@/types'
 
@@ -153,6 +157,42 @@ export async function getGroupApiKeys(
   return data
 }
 
+/**
+ * Preview which account each scheduling strategy would pick for a request
+ * @param id - Group ID
+ * @param model - Optional requested model
+ * @returns Per-strategy ranking of candidate accounts
+ */
+export async function simulateScheduling(
+  id: number,
+  model?: string
+): Promise<SchedulingSimulationResult> {
+  const { data } = await apiClient.post<SchedulingSimulationResult>(
+    `/admin/groups/${id}/scheduling-simulation`,
+    { model: model ?? '' }
+  )
+  return data
+}
+
+/**
+ * Dry-run the group's request rewrite rules against a sample request
+ * @param id - Group ID
+ * @param body - Sample request body
+ * @param options - Optional model, body format and unsaved rules to test
+ * @returns Rewritten body, matched rules and header changes
+ */
+export async function dryRunRewriteRules(
+  id: number,
+  body: Record<string, unknown>,
+  options?: { model?: string; format?: RequestBodyFormat; rules?: RequestRewriteRule[] }
+): Promise<RewriteDryRunResult> {
+  const { data } = await apiClient.post<RewriteDryRunResult>(
+    `/admin/groups/${id}/rewrite-rules/dry-run`,
+    { body, ...options }
+  )
+  return data
+}
+
 export const groupsAPI = {
   list,
   getAll,
@@ -163,7 +203,9 @@ export const groupsAPI = {
   delete: deleteGroup,
   toggleStatus,
   getStats,
-  getGroupApiKeys
+  getGroupApiKeys,
+  simulateScheduling,
+  dryRunRewriteRules
 }
 
 export default groupsAPI
@@ -21,6 +21,9 @@ import opsAPI from './ops'
 import invitesAdminAPI from './invites'
 import plansAPI from './plans'
 import uploadsAPI from './uploads'
+import dedicatedAccountsAPI from './dedicatedAccounts'
+import organizationsAdminAPI from './organizations'
+import securityAPI from './security'
 
 /**
  * Unified admin API object for convenient access
@@ -43,7 +46,10 @@ export const adminAPI = {
   antigravity: antigravityAPI,
   userAttributes: userAttributesAPI,
   ops: opsAPI,
-  invites: invitesAdminAPI
+  invites: invitesAdminAPI,
+  dedicatedAccounts: dedicatedAccountsAPI,
+  organizations: organizationsAdminAPI,
+  security: securityAPI
 }
 
 export {
@@ -64,7 +70,10 @@ export {
   opsAPI,
   invitesAdminAPI,
   plansAPI,
-  uploadsAPI
+  uploadsAPI,
+  dedicatedAccountsAPI,
+  organizationsAdminAPI,
+  securityAPI
 }
 
 export default adminAPI
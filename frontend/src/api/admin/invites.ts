@@ -3,7 +3,16 @@
  */
 
 import { apiClient } from '../client'
-import type { InviteLog, InviteRecord, InviteSettings, PaginatedResponse } from '@/types'
+import type {
+  AdminCommissionWithdrawal,
+  CommissionWithdrawalMethod,
+  CommissionWithdrawalStatus,
+  InviteCommissionStatus,
+  InviteLog,
+  InviteRecord,
+  InviteSettings,
+  PaginatedResponse
+} from '@/types'
 
 export interface InviteLogQuery {
   page?: number
@@ -17,6 +26,14 @@ export interface InviteLogQuery {
   end_time?: string
 }
 
+export interface CommissionWithdrawalQuery {
+  page?: number
+  page_size?: number
+  user_id?: number
+  method?: CommissionWithdrawalMethod
+  status?: CommissionWithdrawalStatus
+}
+
 export async function getInviteSettings(): Promise<InviteSettings> {
   const { data } = await apiClient.get<InviteSettings>('/admin/invites/settings')
   return data
@@ -39,11 +56,45 @@ export async function confirmInvite(inviteeId: number): Promise<InviteRecord> {
   return data
 }
 
+export async function listCommissionWithdrawals(
+  query: CommissionWithdrawalQuery
+): Promise<PaginatedResponse<AdminCommissionWithdrawal>> {
+  const { data } = await apiClient.get<PaginatedResponse<AdminCommissionWithdrawal>>('/admin/invites/withdrawals', {
+    params: query
+  })
+  return data
+}
+
+export async function approveCommissionWithdrawal(id: number, notes = ''): Promise<AdminCommissionWithdrawal> {
+  const { data } = await apiClient.post<AdminCommissionWithdrawal>(`/admin/invites/withdrawals/${id}/approve`, { notes })
+  return data
+}
+
+export async function rejectCommissionWithdrawal(id: number, notes = ''): Promise<AdminCommissionWithdrawal> {
+  const { data } = await apiClient.post<AdminCommissionWithdrawal>(`/admin/invites/withdrawals/${id}/reject`, { notes })
+  return data
+}
+
+export async function updateInviteCommissionStatus(
+  inviteeId: number,
+  status: InviteCommissionStatus
+): Promise<{ invitee_id: number; commission_status: InviteCommissionStatus }> {
+  const { data } = await apiClient.put<{ invitee_id: number; commission_status: InviteCommissionStatus }>(
+    `/admin/invites/${inviteeId}/commission-status`,
+    { status }
+  )
+  return data
+}
+
 export const invitesAdminAPI = {
   getInviteSettings,
   updateInviteSettings,
   listInviteLogs,
-  confirmInvite
+  confirmInvite,
+  listCommissionWithdrawals,
+  approveCommissionWithdrawal,
+  rejectCommissionWithdrawal,
+  updateInviteCommissionStatus
 }
 
 export default invitesAdminAPI
@@ -258,6 +258,28 @@ export interface OpsErrorDistributionResponse {
   items: OpsErrorDistributionItem[]
 }
 
+export interface OpsTrafficArmStats {
+  arm: string
+  success_count: number
+  error_count_sla: number
+  request_count: number
+  error_rate: number
+  token_consumed: number
+  duration_avg_ms: number | null
+  duration_p50_ms: number | null
+  duration_p95_ms: number | null
+  ttft_avg_ms: number | null
+  ttft_p95_ms: number | null
+  total_cost: number
+  cost_per_request: number
+  cost_per_million_tokens: number
+}
+
+export interface OpsTrafficArmsResponse {
+  group_id: number
+  arms: OpsTrafficArmStats[]
+}
+
 export interface OpsSystemMetricsSnapshot {
   id: number
   created_at: string
@@ -404,6 +426,79 @@ export async function getAccountAvailabilityStats(platform?: string, groupId?: n
   return data
 }
 
+export interface OpsCapacityPlanEntry {
+  group_id?: number | null
+  group_name?: string
+  platform: string
+
+  account_count: number
+  schedulable_accounts: number
+  total_concurrency: number
+  peak_concurrency: number
+  peak_waiting: number
+  avg_concurrency: number
+  peak_utilization_pct: number
+  zero_available_hours: number
+  snapshot_coverage_hours: number
+
+  rate_limit_hits: number
+  rate_limit_hits_per_account_per_day: number
+
+  window_cost_limit_accounts: number
+  avg_window_cost_limit: number
+  peak_5h_window_cost: number
+  codex_accounts: number
+  codex_avg_5h_used_percent?: number | null
+
+  daily_requests_avg: number
+  daily_cost_avg: number
+  daily_growth_pct: number
+  growth_factor: number
+
+  projected_peak_concurrency: number
+  projected_accounts_needed: number
+  additional_accounts_needed: number
+  projection_basis: 'concurrency' | 'window_cost' | 'codex_quota'
+}
+
+export interface OpsAccountRateLimitDaily {
+  account_id: number
+  account_name: string
+  platform: string
+  day: string
+  hits: number
+}
+
+export interface OpsCapacityPlanReport {
+  start_time: string
+  end_time: string
+  horizon_days: number
+  target_utilization: number
+  groups: OpsCapacityPlanEntry[]
+  platforms: OpsCapacityPlanEntry[]
+  account_rate_limits: OpsAccountRateLimitDaily[]
+}
+
+export interface OpsCapacityPlanParams {
+  days?: number
+  horizon_days?: number
+  target_utilization?: number
+  platform?: string
+  group_id?: number | null
+}
+
+export async function getCapacityPlan(params: OpsCapacityPlanParams = {}): Promise<OpsCapacityPlanReport> {
+  const query: Record<string, any> = {}
+  if (params.days) query.days = params.days
+  if (params.horizon_days) query.horizon_days = params.horizon_days
+  if (params.target_utilization) query.target_utilization = params.target_utilization
+  if (params.platform) query.platform = params.platform
+  if (typeof params.group_id === 'number' && params.group_id > 0) query.group_id = params.group_id
+
+  const { data } = await apiClient.get<OpsCapacityPlanReport>('/admin/ops/capacity-plan', { params: query })
+  return data
+}
+
 export interface OpsRateSummary {
   current: number
   peak: number
@@ -678,6 +773,11 @@ export type MetricType =
   | 'account_error_count'
   | 'account_error_ratio'
   | 'overload_account_count'
+  | 'user_spend_spike_ratio'
+  | 'api_key_spend_spike_ratio'
+  | 'api_key_new_ip_count'
+  | 'api_key_new_user_agent_count'
+  | 'api_key_off_hours_requests'
 export type Operator = '>' | '>=' | '<' | '<=' | '==' | '!='
 
 export interface AlertRule {
@@ -1158,15 +1258,33 @@ async function updateMetricThresholds(thresholds: OpsMetricThresholds): Promise<
   await apiClient.put('/admin/ops/settings/metric-thresholds', thresholds)
 }
 
+export async function getTrafficArms(
+  params: {
+    group_id: number
+    time_range?: '5m' | '30m' | '1h' | '6h' | '24h'
+    start_time?: string
+    end_time?: string
+  },
+  options: OpsRequestOptions = {}
+): Promise<OpsTrafficArmsResponse> {
+  const { data } = await apiClient.get<OpsTrafficArmsResponse>('/admin/ops/dashboard/traffic-arms', {
+    params,
+    signal: options.signal
+  })
+  return data
+}
+
 export const opsAPI = {
   getDashboardOverview,
   getThroughputTrend,
   getLatencyHistogram,
   getErrorTrend,
   getErrorDistribution,
+  getTrafficArms,
   getConcurrencyStats,
   getAccountAvailabilityStats,
   getRealtimeTrafficSummary,
+  getCapacityPlan,
   subscribeQPS,
 
   // Legacy unified endpoints
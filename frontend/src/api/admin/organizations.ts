@@ -0,0 +1,69 @@
+/**
+ * Admin Organizations API endpoints
+ * Manages organizations (teams) and their shared balance
+ */
+
+import { apiClient } from '../client'
+import type { AdminOrganization, OrganizationMember, PaginatedResponse } from '@/types'
+
+export interface UpdateOrganizationRequest {
+  name?: string
+  status?: 'active' | 'disabled'
+  notes?: string
+}
+
+export async function list(
+  page: number = 1,
+  pageSize: number = 20,
+  search?: string
+): Promise<PaginatedResponse<AdminOrganization>> {
+  const { data } = await apiClient.get<PaginatedResponse<AdminOrganization>>('/admin/organizations', {
+    params: { page, page_size: pageSize, search }
+  })
+  return data
+}
+
+export async function getById(id: number): Promise<AdminOrganization> {
+  const { data } = await apiClient.get<AdminOrganization>(`/admin/organizations/${id}`)
+  return data
+}
+
+export async function listMembers(id: number): Promise<OrganizationMember[]> {
+  const { data } = await apiClient.get<OrganizationMember[]>(`/admin/organizations/${id}/members`)
+  return data
+}
+
+export async function update(id: number, payload: UpdateOrganizationRequest): Promise<AdminOrganization> {
+  const { data } = await apiClient.put<AdminOrganization>(`/admin/organizations/${id}`, payload)
+  return data
+}
+
+export async function updateBalance(
+  id: number,
+  balance: number,
+  operation: 'set' | 'add' | 'subtract' = 'add',
+  notes?: string
+): Promise<AdminOrganization> {
+  const { data } = await apiClient.post<AdminOrganization>(`/admin/organizations/${id}/balance`, {
+    balance,
+    operation,
+    notes: notes || ''
+  })
+  return data
+}
+
+export async function deleteOrganization(id: number): Promise<{ message: string }> {
+  const { data } = await apiClient.delete<{ message: string }>(`/admin/organizations/${id}`)
+  return data
+}
+
+export const organizationsAPI = {
+  list,
+  getById,
+  listMembers,
+  update,
+  updateBalance,
+  delete: deleteOrganization
+}
+
+export default organizationsAPI
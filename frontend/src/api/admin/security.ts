@@ -0,0 +1,57 @@
+/**
+ * Admin Security API endpoints
+ * IP bans and manual allow/deny rules for gateway and auth routes
+ */
+
+import { apiClient } from '../client'
+
+export type IPBanReason = 'auth_failure' | 'network_auth_failure' | 'request_burst'
+
+export interface IPBan {
+  /** Banned IP or network (CIDR) */
+  subject: string
+  reason: IPBanReason
+  /** Escalation level within the strike memory window (starts at 1) */
+  level: number
+  count: number
+  banned_at: string
+  expires_at: string
+}
+
+export interface IPAccessRule {
+  /** Single IP or CIDR */
+  pattern: string
+  action: 'allow' | 'deny'
+  note?: string
+  created_at?: string
+  expires_at?: string | null
+}
+
+export async function listIPBans(): Promise<IPBan[]> {
+  const { data } = await apiClient.get<IPBan[]>('/admin/security/ip-bans')
+  return data
+}
+
+export async function unbanIP(subject: string): Promise<{ message: string }> {
+  const { data } = await apiClient.post<{ message: string }>('/admin/security/ip-bans/unban', { subject })
+  return data
+}
+
+export async function listIPAccessRules(): Promise<IPAccessRule[]> {
+  const { data } = await apiClient.get<IPAccessRule[]>('/admin/security/ip-rules')
+  return data
+}
+
+export async function updateIPAccessRules(rules: IPAccessRule[]): Promise<IPAccessRule[]> {
+  const { data } = await apiClient.put<IPAccessRule[]>('/admin/security/ip-rules', { rules })
+  return data
+}
+
+export const securityAPI = {
+  listIPBans,
+  unbanIP,
+  listIPAccessRules,
+  updateIPAccessRules
+}
+
+export default securityAPI
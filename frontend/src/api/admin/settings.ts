@@ -16,6 +16,8 @@ export interface SystemSettings {
   password_reset_enabled: boolean
   totp_enabled: boolean // TOTP 双因素认证
   totp_encryption_key_configured: boolean // TOTP 加密密钥是否已配置
+  webauthn_enabled: boolean // Passkey 登录 / 两步验证
+  require_admin_2fa: boolean // 管理员必须启用两步验证
   // Default settings
   default_balance: number
   default_concurrency: number
@@ -75,6 +77,8 @@ export interface UpdateSettingsRequest {
   promo_code_enabled?: boolean
   password_reset_enabled?: boolean
   totp_enabled?: boolean // TOTP 双因素认证
+  webauthn_enabled?: boolean
+  require_admin_2fa?: boolean
   default_balance?: number
   default_concurrency?: number
   site_name?: string
@@ -253,6 +257,45 @@ export async function updateStreamTimeoutSettings(
   return data
 }
 
+/**
+ * Model rate limit scope rule: models matching pattern on the platform share one rate limit scope
+ */
+export interface ModelRateLimitScopeRule {
+  platform: 'anthropic' | 'openai' | 'gemini' | 'antigravity'
+  pattern: string
+  scope: string
+}
+
+export interface ModelRateLimitScopeSettings {
+  rules: ModelRateLimitScopeRule[]
+}
+
+/**
+ * Get model rate limit scope settings
+ * @returns Model rate limit scope settings
+ */
+export async function getModelRateLimitScopeSettings(): Promise<ModelRateLimitScopeSettings> {
+  const { data } = await apiClient.get<ModelRateLimitScopeSettings>(
+    '/admin/settings/model-rate-limit-scopes'
+  )
+  return data
+}
+
+/**
+ * Update model rate limit scope settings
+ * @param settings - Model rate limit scope settings to update
+ * @returns Updated settings
+ */
+export async function updateModelRateLimitScopeSettings(
+  settings: ModelRateLimitScopeSettings
+): Promise<ModelRateLimitScopeSettings> {
+  const { data } = await apiClient.put<ModelRateLimitScopeSettings>(
+    '/admin/settings/model-rate-limit-scopes',
+    settings
+  )
+  return data
+}
+
 export const settingsAPI = {
   getSettings,
   updateSettings,
@@ -262,7 +305,9 @@ export const settingsAPI = {
   regenerateAdminApiKey,
   deleteAdminApiKey,
   getStreamTimeoutSettings,
-  updateStreamTimeoutSettings
+  updateStreamTimeoutSettings,
+  getModelRateLimitScopeSettings,
+  updateModelRateLimitScopeSettings
 }
 
 export default settingsAPI
@@ -70,12 +70,83 @@ export async function restartService(): Promise<{ message: string }> {
   return data
 }
 
+export interface ConfigChange {
+  path: string
+  old_value: string
+  new_value: string
+}
+
+export interface ConfigReloadResult {
+  source: 'sighup' | 'admin'
+  reloaded_at: string
+  config_file?: string
+  applied: ConfigChange[]
+  restart_required: ConfigChange[]
+  error?: string
+}
+
+/**
+ * Reload config.yaml without restarting the service
+ */
+export async function reloadConfig(): Promise<ConfigReloadResult> {
+  const { data } = await apiClient.post<ConfigReloadResult>('/admin/system/config/reload')
+  return data
+}
+
+/**
+ * Get recent config reload results (newest first)
+ */
+export async function getConfigReloads(): Promise<ConfigReloadResult[]> {
+  const { data } = await apiClient.get<ConfigReloadResult[]>('/admin/system/config/reloads')
+  return data
+}
+
+export interface BackupSummary {
+  schema_version: string
+  app_version: string
+  counts: Record<string, number>
+  warnings?: string[]
+}
+
+/**
+ * Export the instance as an encrypted backup archive
+ * @param passphrase - Passphrase used to encrypt credentials and other secrets
+ * @param includeUsageLogs - Whether to include usage logs
+ */
+export async function createBackup(passphrase: string, includeUsageLogs = false): Promise<Blob> {
+  const { data } = await apiClient.post<Blob>(
+    '/admin/system/backup',
+    { passphrase, include_usage_logs: includeUsageLogs },
+    { responseType: 'blob' }
+  )
+  return data
+}
+
+/**
+ * Restore a backup archive into an empty instance
+ */
+export async function restoreBackup(file: File, passphrase: string): Promise<BackupSummary> {
+  const formData = new FormData()
+  formData.append('file', file)
+  formData.append('passphrase', passphrase)
+  const { data } = await apiClient.post<BackupSummary>('/admin/system/restore', formData, {
+    headers: {
+      'Content-Type': 'multipart/form-data'
+    }
+  })
+  return data
+}
+
 export const systemAPI = {
   getVersion,
   checkUpdates,
   performUpdate,
   rollback,
-  restartService
+  restartService,
+  reloadConfig,
+  getConfigReloads,
+  createBackup,
+  restoreBackup
 }
 
 export default systemAPI
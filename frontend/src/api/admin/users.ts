@@ -174,6 +174,16 @@ export async function getUserUsageStats(
   return data
 }
 
+/**
+ * Force logout a user: revoke all login sessions and invalidate issued tokens
+ * @param id - User ID
+ * @returns Number of revoked sessions
+ */
+export async function forceLogout(id: number): Promise<{ revoked: number }> {
+  const { data } = await apiClient.post<{ revoked: number }>(`/admin/users/${id}/force-logout`)
+  return data
+}
+
 export const usersAPI = {
   list,
   getById,
@@ -184,7 +194,8 @@ export const usersAPI = {
   updateConcurrency,
   toggleStatus,
   getUserApiKeys,
-  getUserUsageStats
+  getUserUsageStats,
+  forceLogout
 }
 
 export default usersAPI
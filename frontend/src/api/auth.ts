@@ -3,7 +3,7 @@
  * Handles user login, registration, and logout operations
  */
 
-import { apiClient } from './client'
+import { apiClient, REFRESH_TOKEN_KEY } from './client'
 import type {
   LoginRequest,
   RegisterRequest,
@@ -13,7 +13,9 @@ import type {
   SendVerifyCodeResponse,
   PublicSettings,
   TotpLoginResponse,
-  TotpLogin2FARequest
+  TotpLogin2FARequest,
+  WebAuthnRequestOptions,
+  WebAuthnAssertion
 } from '@/types'
 
 /**
@@ -29,10 +31,13 @@ export function isTotp2FARequired(response: LoginResponse): response is TotpLogi
 }
 
 /**
- * Store authentication token in localStorage
+ * Store authentication token (and the rotating refresh token, if provided) in localStorage
  */
-export function setAuthToken(token: string): void {
+export function setAuthToken(token: string, refreshToken?: string): void {
   localStorage.setItem('auth_token', token)
+  if (refreshToken) {
+    localStorage.setItem(REFRESH_TOKEN_KEY, refreshToken)
+  }
 }
 
 /**
@@ -47,6 +52,7 @@ export function getAuthToken(): string | null {
  */
 export function clearAuthToken(): void {
   localStorage.removeItem('auth_token')
+  localStorage.removeItem(REFRESH_TOKEN_KEY)
   localStorage.removeItem('auth_user')
 }
 
@@ -60,7 +66,7 @@ export async function login(credentials: LoginRequest): Promise<LoginResponse> {
 
   // Only store token if 2FA is not required
   if (!isTotp2FARequired(data)) {
-    setAuthToken(data.access_token)
+    setAuthToken(data.access_token, data.refresh_token)
     localStorage.setItem('auth_user', JSON.stringify(data.user))
   }
 
@@ -76,7 +82,59 @@ export async function login2FA(request: TotpLogin2FARequest): Promise<AuthRespon
   const { data } = await apiClient.post<AuthResponse>('/auth/login/2fa', request)
 
   // Store token and user data
-  setAuthToken(data.access_token)
+  setAuthToken(data.access_token, data.refresh_token)
+  localStorage.setItem('auth_user', JSON.stringify(data.user))
+
+  return data
+}
+
+/**
+ * Passkey login request (passwordless or as second factor)
+ */
+export interface PasskeyLoginRequest {
+  temp_token?: string
+  challenge_id: string
+  credential: WebAuthnAssertion
+  device_id?: string
+}
+
+/**
+ * Get WebAuthn assertion options for passwordless passkey login
+ */
+export async function getPasskeyLoginOptions(): Promise<WebAuthnRequestOptions> {
+  const { data } = await apiClient.post<WebAuthnRequestOptions>('/auth/passkey/options')
+  return data
+}
+
+/**
+ * Passwordless login with a passkey
+ */
+export async function loginWithPasskey(request: PasskeyLoginRequest): Promise<AuthResponse> {
+  const { data } = await apiClient.post<AuthResponse>('/auth/passkey/login', request)
+
+  setAuthToken(data.access_token, data.refresh_token)
+  localStorage.setItem('auth_user', JSON.stringify(data.user))
+
+  return data
+}
+
+/**
+ * Get WebAuthn assertion options for the 2FA step of a pending login
+ */
+export async function getLogin2FAPasskeyOptions(tempToken: string): Promise<WebAuthnRequestOptions> {
+  const { data } = await apiClient.post<WebAuthnRequestOptions>('/auth/login/2fa/webauthn/options', {
+    temp_token: tempToken
+  })
+  return data
+}
+
+/**
+ * Complete login with a passkey as the second factor
+ */
+export async function login2FAWithPasskey(request: PasskeyLoginRequest): Promise<AuthResponse> {
+  const { data } = await apiClient.post<AuthResponse>('/auth/login/2fa/webauthn', request)
+
+  setAuthToken(data.access_token, data.refresh_token)
   localStorage.setItem('auth_user', JSON.stringify(data.user))
 
   return data
@@ -91,7 +149,7 @@ export async function register(userData: RegisterRequest): Promise<AuthResponse>
   const { data } = await apiClient.post<AuthResponse>('/auth/register', userData)
 
   // Store token and user data
-  setAuthToken(data.access_token)
+  setAuthToken(data.access_token, data.refresh_token)
   localStorage.setItem('auth_user', JSON.stringify(data.user))
 
   return data
@@ -105,14 +163,32 @@ export async function getCurrentUser() {
   return apiClient.get<CurrentUserResponse>('/auth/me')
 }
 
+/**
+ * Exchange the refresh token for a new token pair (the refresh token is single-use and rotates)
+ * @param refreshToken - Current refresh token
+ * @returns Authentication response with new tokens and user data
+ */
+export async function refresh(refreshToken: string): Promise<AuthResponse> {
+  const { data } = await apiClient.post<AuthResponse>('/auth/refresh', { refresh_token: refreshToken })
+  setAuthToken(data.access_token, data.refresh_token)
+  return data
+}
+
 /**
  * User logout
- * Clears authentication token and user data from localStorage
+ * Revokes the current login session on the server, then clears local token and user data
  */
-export function logout(): void {
+export async function logout(): Promise<void> {
+  const token = getAuthToken()
   clearAuthToken()
-  // Optionally redirect to login page
-  // window.location.href = '/login';
+  if (!token) {
+    return
+  }
+  try {
+    await apiClient.post('/auth/logout', null, { headers: { Authorization: `Bearer ${token}` } })
+  } catch {
+    // Session may already be expired or revoked; local state is cleared either way
+  }
 }
 
 /**
@@ -218,9 +294,14 @@ export async function resetPassword(request: ResetPasswordRequest): Promise<Rese
 export const authAPI = {
   login,
   login2FA,
+  getPasskeyLoginOptions,
+  loginWithPasskey,
+  getLogin2FAPasskeyOptions,
+  login2FAWithPasskey,
   isTotp2FARequired,
   register,
   getCurrentUser,
+  refresh,
   logout,
   isAuthenticated,
   setAuthToken,
@@ -58,6 +58,53 @@ apiClient.interceptors.request.use(
   }
 )
 
+// ==================== Token Refresh ====================
+
+const AUTH_TOKEN_KEY = 'auth_token'
+export const REFRESH_TOKEN_KEY = 'auth_refresh_token'
+
+type RetriableRequestConfig = InternalAxiosRequestConfig & { _retried?: boolean }
+
+// 同一时刻只发起一次刷新，其余请求复用结果（refresh token 一次性有效，并发刷新会互相作废）
+let refreshPromise: Promise<string | null> | null = null
+
+async function doRefreshAccessToken(): Promise<string | null> {
+  const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY)
+  if (!refreshToken) {
+    return null
+  }
+  try {
+    const response = await axios.post<ApiResponse<{ access_token: string; refresh_token: string }>>(
+      `${API_BASE_URL}/auth/refresh`,
+      { refresh_token: refreshToken }
+    )
+    const payload = response.data
+    if (!payload || payload.code !== 0 || !payload.data) {
+      return null
+    }
+    localStorage.setItem(AUTH_TOKEN_KEY, payload.data.access_token)
+    localStorage.setItem(REFRESH_TOKEN_KEY, payload.data.refresh_token)
+    window.dispatchEvent(new CustomEvent('auth-token-refreshed', { detail: payload.data.access_token }))
+    return payload.data.access_token
+  } catch {
+    // 其他标签页可能刚完成轮换：本地 refresh token 已更新时直接沿用新的 access token
+    const latest = localStorage.getItem(REFRESH_TOKEN_KEY)
+    if (latest && latest !== refreshToken) {
+      return localStorage.getItem(AUTH_TOKEN_KEY)
+    }
+    return null
+  }
+}
+
+export function refreshAccessToken(): Promise<string | null> {
+  if (!refreshPromise) {
+    refreshPromise = doRefreshAccessToken().finally(() => {
+      refreshPromise = null
+    })
+  }
+  return refreshPromise
+}
+
 // ==================== Response Interceptor ====================
 
 apiClient.interceptors.response.use(
@@ -79,7 +126,7 @@ apiClient.interceptors.response.use(
     }
     return response
   },
-  (error: AxiosError<ApiResponse<unknown>>) => {
+  async (error: AxiosError<ApiResponse<unknown>>) => {
     // Request cancellation: keep the original axios cancellation error so callers can ignore it.
     // Otherwise we'd misclassify it as a generic "network error".
     if (error.code === 'ERR_CANCELED' || axios.isCancel(error)) {
@@ -120,12 +167,23 @@ apiClient.interceptors.response.use(
         })
       }
 
-      // 401: Unauthorized - clear token and redirect to login
+      // 401: Unauthorized - try refreshing the session once, otherwise clear token and redirect to login
       if (status === 401) {
         const hasToken = !!localStorage.getItem('auth_token')
         const url = error.config?.url || ''
         const isAuthEndpoint =
           url.includes('/auth/login') || url.includes('/auth/register') || url.includes('/auth/refresh')
+
+        const original = error.config as RetriableRequestConfig | undefined
+        if (original && !original._retried && !isAuthEndpoint && apiData.code === 'TOKEN_EXPIRED') {
+          original._retried = true
+          const newToken = await refreshAccessToken()
+          if (newToken) {
+            original.headers.Authorization = `Bearer ${newToken}`
+            return apiClient(original)
+          }
+        }
+
         const headers = error.config?.headers as Record<string, unknown> | undefined
         const authHeader = headers?.Authorization ?? headers?.authorization
         const sentAuth =
@@ -136,6 +194,7 @@ apiClient.interceptors.response.use(
             : !!authHeader
 
         localStorage.removeItem('auth_token')
+        localStorage.removeItem(REFRESH_TOKEN_KEY)
         localStorage.removeItem('auth_user')
         if ((hasToken || sentAuth) && !isAuthEndpoint) {
           sessionStorage.setItem('auth_expired', '1')
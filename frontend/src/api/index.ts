@@ -18,6 +18,8 @@ export { userGroupsAPI } from './groups'
 export { inviteAPI } from './invites'
 export { plansAPI } from './plans'
 export { totpAPI } from './totp'
+export { webauthnAPI } from './webauthn'
+export { organizationsAPI } from './organizations'
 
 // Admin APIs
 export { adminAPI } from './admin'
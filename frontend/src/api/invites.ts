@@ -3,7 +3,15 @@
  */
 
 import { apiClient } from './client'
-import type { InviteSummary, InviteRecord, InviteRewardRecord, PaginatedResponse } from '@/types'
+import type {
+  InviteSummary,
+  InviteRecord,
+  InviteRewardRecord,
+  InviteCommissionReport,
+  InviteCommission,
+  CommissionWithdrawal,
+  PaginatedResponse
+} from '@/types'
 
 export interface InviteRecordsQuery {
   page?: number
@@ -35,10 +43,47 @@ export async function listInviteRewards(query: InviteRewardsQuery): Promise<Pagi
   return data
 }
 
+export async function getCommissionReport(): Promise<InviteCommissionReport> {
+  const { data } = await apiClient.get<InviteCommissionReport>('/invites/commission')
+  return data
+}
+
+export async function listCommissionRecords(query: InviteRewardsQuery): Promise<PaginatedResponse<InviteCommission>> {
+  const { data } = await apiClient.get<PaginatedResponse<InviteCommission>>('/invites/commission/records', {
+    params: query
+  })
+  return data
+}
+
+export async function convertCommission(amount: number): Promise<CommissionWithdrawal> {
+  const { data } = await apiClient.post<CommissionWithdrawal>('/invites/commission/convert', { amount })
+  return data
+}
+
+export async function requestCommissionWithdrawal(amount: number, accountInfo: string): Promise<CommissionWithdrawal> {
+  const { data } = await apiClient.post<CommissionWithdrawal>('/invites/commission/withdrawals', {
+    amount,
+    account_info: accountInfo
+  })
+  return data
+}
+
+export async function listCommissionWithdrawals(query: InviteRewardsQuery): Promise<PaginatedResponse<CommissionWithdrawal>> {
+  const { data } = await apiClient.get<PaginatedResponse<CommissionWithdrawal>>('/invites/commission/withdrawals', {
+    params: query
+  })
+  return data
+}
+
 export const inviteAPI = {
   getInviteSummary,
   listInviteRecords,
-  listInviteRewards
+  listInviteRewards,
+  getCommissionReport,
+  listCommissionRecords,
+  convertCommission,
+  requestCommissionWithdrawal,
+  listCommissionWithdrawals
 }
 
 export default inviteAPI
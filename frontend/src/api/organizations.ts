@@ -0,0 +1,147 @@
+/**
+ * Organization (team) API endpoints (user)
+ * Shared balance, member management, email invitations and usage dashboard
+ */
+
+import { apiClient } from './client'
+import type {
+  Organization,
+  OrganizationMember,
+  OrganizationInvitation,
+  OrganizationDashboard,
+  OrganizationRole,
+  UsageLog,
+  PaginatedResponse
+} from '@/types'
+
+export interface UpdateOrganizationMemberRequest {
+  role?: OrganizationRole
+  monthly_limit_usd?: number
+  clear_monthly_limit?: boolean
+}
+
+export interface OrganizationTimeRangeQuery {
+  start_date?: string
+  end_date?: string
+  timezone?: string
+}
+
+export interface OrganizationUsageQuery extends OrganizationTimeRangeQuery {
+  page?: number
+  page_size?: number
+  user_id?: number
+  model?: string
+}
+
+export async function list(): Promise<Organization[]> {
+  const { data } = await apiClient.get<Organization[]>('/organizations')
+  return data
+}
+
+export async function create(name: string): Promise<Organization> {
+  const { data } = await apiClient.post<Organization>('/organizations', { name })
+  return data
+}
+
+export async function getById(id: number): Promise<Organization> {
+  const { data } = await apiClient.get<Organization>(`/organizations/${id}`)
+  return data
+}
+
+export async function listMembers(id: number): Promise<OrganizationMember[]> {
+  const { data } = await apiClient.get<OrganizationMember[]>(`/organizations/${id}/members`)
+  return data
+}
+
+export async function updateMember(
+  id: number,
+  userId: number,
+  payload: UpdateOrganizationMemberRequest
+): Promise<OrganizationMember> {
+  const { data } = await apiClient.put<OrganizationMember>(
+    `/organizations/${id}/members/${userId}`,
+    payload
+  )
+  return data
+}
+
+export async function removeMember(id: number, userId: number): Promise<{ message: string }> {
+  const { data } = await apiClient.delete<{ message: string }>(
+    `/organizations/${id}/members/${userId}`
+  )
+  return data
+}
+
+export async function transfer(id: number, userId: number): Promise<Organization> {
+  const { data } = await apiClient.post<Organization>(`/organizations/${id}/transfer`, {
+    user_id: userId
+  })
+  return data
+}
+
+export async function listInvitations(id: number): Promise<OrganizationInvitation[]> {
+  const { data } = await apiClient.get<OrganizationInvitation[]>(`/organizations/${id}/invitations`)
+  return data
+}
+
+export async function invite(
+  id: number,
+  email: string,
+  role: OrganizationRole = 'member'
+): Promise<OrganizationInvitation> {
+  const { data } = await apiClient.post<OrganizationInvitation>(`/organizations/${id}/invitations`, {
+    email,
+    role
+  })
+  return data
+}
+
+export async function revokeInvitation(id: number, invitationId: number): Promise<{ message: string }> {
+  const { data } = await apiClient.delete<{ message: string }>(
+    `/organizations/${id}/invitations/${invitationId}`
+  )
+  return data
+}
+
+export async function acceptInvitation(token: string): Promise<Organization> {
+  const { data } = await apiClient.post<Organization>('/organizations/invitations/accept', { token })
+  return data
+}
+
+export async function getDashboard(
+  id: number,
+  query: OrganizationTimeRangeQuery = {}
+): Promise<OrganizationDashboard> {
+  const { data } = await apiClient.get<OrganizationDashboard>(`/organizations/${id}/dashboard`, {
+    params: query
+  })
+  return data
+}
+
+export async function listUsage(
+  id: number,
+  query: OrganizationUsageQuery = {}
+): Promise<PaginatedResponse<UsageLog>> {
+  const { data } = await apiClient.get<PaginatedResponse<UsageLog>>(`/organizations/${id}/usage`, {
+    params: query
+  })
+  return data
+}
+
+export const organizationsAPI = {
+  list,
+  create,
+  getById,
+  listMembers,
+  updateMember,
+  removeMember,
+  transfer,
+  listInvitations,
+  invite,
+  revokeInvitation,
+  acceptInvitation,
+  getDashboard,
+  listUsage
+}
+
+export default organizationsAPI
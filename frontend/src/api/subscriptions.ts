@@ -67,10 +67,96 @@ export async function getSubscriptionProgress(
   return response.data
 }
 
+/**
+ * Prorated cost of switching a subscription to another plan
+ */
+export interface SubscriptionSwitchQuote {
+  from_group_id: number
+  to_group_id: number
+  price: number
+  credit: number
+  charge: number
+  period_days: number
+  bonus_days: number
+  validity_days: number
+}
+
+/**
+ * Enable or disable auto-renewal from balance
+ */
+export async function setAutoRenew(
+  subscriptionId: number,
+  enabled: boolean
+): Promise<UserSubscription> {
+  const response = await apiClient.put<UserSubscription>(
+    `/subscriptions/${subscriptionId}/auto-renew`,
+    { enabled }
+  )
+  return response.data
+}
+
+/**
+ * Renew a subscription for one period from balance
+ */
+export async function renewSubscription(subscriptionId: number): Promise<UserSubscription> {
+  const response = await apiClient.post<UserSubscription>(`/subscriptions/${subscriptionId}/renew`)
+  return response.data
+}
+
+/**
+ * Preview the cost of switching to another plan
+ */
+export async function getSwitchQuote(
+  subscriptionId: number,
+  groupId: number
+): Promise<SubscriptionSwitchQuote> {
+  const response = await apiClient.get<SubscriptionSwitchQuote>(
+    `/subscriptions/${subscriptionId}/switch-quote`,
+    { params: { group_id: groupId } }
+  )
+  return response.data
+}
+
+/**
+ * Switch a subscription to another plan with prorated credit
+ */
+export async function switchSubscription(
+  subscriptionId: number,
+  groupId: number
+): Promise<{ subscription: UserSubscription; quote: SubscriptionSwitchQuote }> {
+  const response = await apiClient.post<{
+    subscription: UserSubscription
+    quote: SubscriptionSwitchQuote
+  }>(`/subscriptions/${subscriptionId}/switch`, { group_id: groupId })
+  return response.data
+}
+
+/**
+ * Pause a subscription (remaining time is frozen)
+ */
+export async function pauseSubscription(subscriptionId: number): Promise<UserSubscription> {
+  const response = await apiClient.post<UserSubscription>(`/subscriptions/${subscriptionId}/pause`)
+  return response.data
+}
+
+/**
+ * Resume a paused subscription
+ */
+export async function resumeSubscription(subscriptionId: number): Promise<UserSubscription> {
+  const response = await apiClient.post<UserSubscription>(`/subscriptions/${subscriptionId}/resume`)
+  return response.data
+}
+
 export default {
   getMySubscriptions,
   getActiveSubscriptions,
   getSubscriptionsProgress,
   getSubscriptionSummary,
-  getSubscriptionProgress
+  getSubscriptionProgress,
+  setAutoRenew,
+  renewSubscription,
+  getSwitchQuote,
+  switchSubscription,
+  pauseSubscription,
+  resumeSubscription
 }
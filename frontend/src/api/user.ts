@@ -4,7 +4,7 @@
  */
 
 import { apiClient } from './client'
-import type { User, ChangePasswordRequest } from '@/types'
+import type { User, ChangePasswordRequest, AuthSession } from '@/types'
 
 /**
  * Get current user profile
@@ -45,10 +45,40 @@ export async function changePassword(
   return data
 }
 
+/**
+ * List login sessions (devices) of the current user
+ * @returns Active sessions, most recently used first
+ */
+export async function listSessions(): Promise<AuthSession[]> {
+  const { data } = await apiClient.get<AuthSession[]>('/user/sessions')
+  return data
+}
+
+/**
+ * Revoke one login session of the current user
+ * @param id - Session ID
+ */
+export async function revokeSession(id: string): Promise<{ message: string }> {
+  const { data } = await apiClient.delete<{ message: string }>(`/user/sessions/${id}`)
+  return data
+}
+
+/**
+ * Revoke all login sessions except the current one
+ * @returns Number of revoked sessions
+ */
+export async function revokeOtherSessions(): Promise<{ revoked: number }> {
+  const { data } = await apiClient.delete<{ revoked: number }>('/user/sessions')
+  return data
+}
+
 export const userAPI = {
   getProfile,
   updateProfile,
-  changePassword
+  changePassword,
+  listSessions,
+  revokeSession,
+  revokeOtherSessions
 }
 
 export default userAPI
@@ -0,0 +1,100 @@
+/**
+ * Passkey (WebAuthn) and recovery code API endpoints
+ * Manages the current user's passkeys and 2FA recovery codes
+ */
+
+import { apiClient } from './client'
+import type {
+  PasskeyListResponse,
+  WebAuthnCreationOptions,
+  WebAuthnAttestation,
+  WebAuthnCredential,
+  RecoveryCodeStatus,
+  TotpSetupRequest
+} from '@/types'
+
+/**
+ * Identity verification payload (email code or password, same as TOTP setup)
+ */
+export type IdentityVerifyRequest = TotpSetupRequest
+
+/**
+ * List passkeys registered by the current user
+ */
+export async function listPasskeys(): Promise<PasskeyListResponse> {
+  const { data } = await apiClient.get<PasskeyListResponse>('/user/webauthn/credentials')
+  return data
+}
+
+/**
+ * Begin passkey registration after verifying identity
+ * @returns Creation options to pass to navigator.credentials.create()
+ */
+export async function beginRegistration(request?: IdentityVerifyRequest): Promise<WebAuthnCreationOptions> {
+  const { data } = await apiClient.post<WebAuthnCreationOptions>('/user/webauthn/register/options', request || {})
+  return data
+}
+
+/**
+ * Finish passkey registration with the authenticator's attestation
+ */
+export async function finishRegistration(
+  challengeId: string,
+  name: string,
+  credential: WebAuthnAttestation
+): Promise<WebAuthnCredential> {
+  const { data } = await apiClient.post<WebAuthnCredential>('/user/webauthn/register', {
+    challenge_id: challengeId,
+    name,
+    credential
+  })
+  return data
+}
+
+/**
+ * Rename a passkey
+ */
+export async function renamePasskey(id: number, name: string): Promise<{ success: boolean }> {
+  const { data } = await apiClient.put<{ success: boolean }>(`/user/webauthn/credentials/${id}`, { name })
+  return data
+}
+
+/**
+ * Delete a passkey (requires identity verification)
+ */
+export async function deletePasskey(id: number, request?: IdentityVerifyRequest): Promise<{ success: boolean }> {
+  const { data } = await apiClient.post<{ success: boolean }>(
+    `/user/webauthn/credentials/${id}/delete`,
+    request || {}
+  )
+  return data
+}
+
+/**
+ * Get the number of unused recovery codes
+ */
+export async function getRecoveryCodeStatus(): Promise<RecoveryCodeStatus> {
+  const { data } = await apiClient.get<RecoveryCodeStatus>('/user/2fa/recovery-codes')
+  return data
+}
+
+/**
+ * Generate a new set of recovery codes; previous codes become invalid
+ * @returns Plain-text codes, shown only once
+ */
+export async function generateRecoveryCodes(request?: IdentityVerifyRequest): Promise<{ codes: string[] }> {
+  const { data } = await apiClient.post<{ codes: string[] }>('/user/2fa/recovery-codes', request || {})
+  return data
+}
+
+export const webauthnAPI = {
+  listPasskeys,
+  beginRegistration,
+  finishRegistration,
+  renamePasskey,
+  deletePasskey,
+  getRecoveryCodeStatus,
+  generateRecoveryCodes
+}
+
+export default webauthnAPI
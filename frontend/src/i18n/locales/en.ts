@@ -329,6 +329,9 @@ export default {
     promoCodeAlreadyUsed: 'You have already used this promo code',
     promoCodeValidating: 'Promo code is being validated, please wait',
     promoCodeInvalidCannotRegister: 'Invalid promo code. Please check and try again or clear the promo code field',
+    or: 'or',
+    signInWithPasskey: 'Sign in with a passkey',
+    passkeyLoginFailed: 'Passkey sign-in failed, please try again',
     linuxdo: {
       signIn: 'Continue with Linux.do',
       orContinue: 'or continue with email',
@@ -449,6 +452,7 @@ export default {
     noKeysYet: 'No API keys yet',
     createFirstKey: 'Create your first API key to get started with the API.',
     keyCreatedSuccess: 'API key created successfully',
+    keyCreatedShownOnce: 'API key created. Copy it now, it will not be shown again after you leave this page',
     keyUpdatedSuccess: 'API key updated successfully',
     keyDeletedSuccess: 'API key deleted successfully',
     keyEnabledSuccess: 'API key enabled successfully',
@@ -655,6 +659,53 @@ export default {
     passwordTooShort: 'Password must be at least 8 characters long',
     passwordChangeSuccess: 'Password changed successfully',
     passwordChangeFailed: 'Failed to change password',
+    sessions: {
+      title: 'Login Sessions',
+      description: 'Devices currently signed in to your account',
+      empty: 'No active sessions',
+      current: 'This device',
+      unknownDevice: 'Unknown device',
+      lastSeen: 'Last active',
+      signedIn: 'Signed in',
+      revoke: 'Sign out',
+      revokeOthers: 'Sign out other devices',
+      revoked: 'Session signed out',
+      revokeFailed: 'Failed to sign out session'
+    },
+    passkeys: {
+      title: 'Passkeys',
+      description: 'Sign in with Face ID, Touch ID, Windows Hello or a security key, or use a passkey as your second factor',
+      featureDisabledHint: 'Passkeys have not been enabled by the administrator',
+      empty: 'No passkeys registered yet',
+      unsupported: 'This browser does not support passkeys',
+      namePlaceholder: 'Passkey name, e.g. MacBook Touch ID',
+      add: 'Add Passkey',
+      addTitle: 'Add a Passkey',
+      addHint: 'Confirm your identity, then follow your browser prompt to create the passkey',
+      added: 'Passkey added',
+      rename: 'Rename',
+      deleteTitle: 'Delete Passkey',
+      deleteHint: 'You will no longer be able to sign in with this passkey',
+      deleted: 'Passkey deleted',
+      passwordless: 'Passwordless',
+      addedAt: 'Added',
+      lastUsed: 'Last used',
+      neverUsed: 'Never'
+    },
+    twoFactor: {
+      usePasskey: 'Use a passkey',
+      useRecoveryCode: 'Use a recovery code',
+      useAuthenticator: 'Use authenticator app',
+      recoveryCodeLabel: 'Recovery code',
+      verifyRecoveryCode: 'Verify',
+      recoveryCodes: 'Recovery Codes',
+      recoveryCodesHint: 'One-time codes for signing in when your authenticator or passkey is unavailable. {count} remaining.',
+      generate: 'Generate',
+      regenerate: 'Regenerate',
+      regenerateHint: 'Generating new recovery codes invalidates all previous codes',
+      saveCodesWarning: 'Save these codes somewhere safe. They will not be shown again.',
+      copyCodes: 'Copy codes'
+    },
     // TOTP 2FA
     totp: {
       title: 'Two-Factor Authentication (2FA)',
@@ -862,6 +913,9 @@ export default {
       failedToUpdate: 'Failed to update user',
       failedToDelete: 'Failed to delete user',
       failedToToggle: 'Failed to update user status',
+      forceLogout: 'Force Logout',
+      forceLogoutConfirm: 'Sign {email} out of all devices? All login sessions and issued tokens will be invalidated.',
+      forceLogoutSuccess: 'User signed out ({count} sessions revoked)',
       failedToLoadApiKeys: 'Failed to load user API keys',
       emailRequired: 'Please enter email',
       concurrencyMin: 'Concurrency must be at least 1',
@@ -1285,7 +1339,12 @@ export default {
         tempUnschedulable: 'Temp Unschedulable',
         rateLimitedUntil: 'Rate limited until {time}',
         overloadedUntil: 'Overloaded until {time}',
-        viewTempUnschedDetails: 'View temp unschedulable details'
+        scopeRateLimited: '{scope} limited',
+        scopeRateLimitedTitle: 'Models: {models}',
+        viewTempUnschedDetails: 'View temp unschedulable details',
+        scheduleDraining: 'Draining',
+        scheduleClosed: 'Off schedule',
+        scheduleTitle: 'Availability schedule (extra.availability_schedule): draining accounts only serve existing sticky sessions'
       },
       columns: {
         name: 'Name',
@@ -2153,6 +2212,7 @@ export default {
       allBillingTypes: 'All Billing Types',
       billingTypeBalance: 'Balance',
       billingTypeSubscription: 'Subscription',
+      billingTypeOverage: 'Subscription Overage',
       ipAddress: 'IP',
       cleanup: {
         button: 'Cleanup',
@@ -2397,7 +2457,8 @@ export default {
           routing: 'Routing',
           upstream: 'Upstream',
           network: 'Network',
-          internal: 'Internal'
+          internal: 'Internal',
+          policy: 'Content Policy'
         },
         total: 'Total:',
         searchPlaceholder: 'Search request_id / client_request_id / message',
@@ -2609,7 +2670,8 @@ export default {
         metricGroups: {
           system: 'System Metrics',
           group: 'Group-level Metrics (requires group_id)',
-          account: 'Account-level Metrics'
+          account: 'Account-level Metrics',
+          usage: 'User / API Key Anomalies'
         },
         metrics: {
           successRate: 'Success Rate (%)',
@@ -2626,7 +2688,12 @@ export default {
           accountRateLimitedCount: 'Rate-limited Accounts',
           accountErrorCount: 'Error Accounts (excluding temporarily unschedulable)',
           accountErrorRatio: 'Error Account Ratio (%)',
-          overloadAccountCount: 'Overloaded Accounts'
+          overloadAccountCount: 'Overloaded Accounts',
+          userSpendSpikeRatio: 'User Spend Spike (x baseline)',
+          apiKeySpendSpikeRatio: 'API Key Spend Spike (x baseline)',
+          apiKeyNewIpCount: 'API Key New IPs',
+          apiKeyNewUserAgentCount: 'API Key New User-Agents',
+          apiKeyOffHoursRequests: 'API Key Off-hours Requests'
         },
         metricDescriptions: {
           successRate: 'Percentage of successful requests in the window (0-100).',
@@ -2643,7 +2710,12 @@ export default {
           accountRateLimitedCount: 'Number of rate-limited accounts within the window.',
           accountErrorCount: 'Number of error accounts within the window (excluding temporarily unschedulable).',
           accountErrorRatio: 'Error account ratio within the window (0-100).',
-          overloadAccountCount: 'Number of overloaded accounts within the window.'
+          overloadAccountCount: 'Number of overloaded accounts within the window.',
+          userSpendSpikeRatio: 'Highest ratio of a user\'s spend in the window to their average spend over the same duration in the baseline period (default 7 days).',
+          apiKeySpendSpikeRatio: 'Highest ratio of an API key\'s spend in the window to its average spend over the same duration in the baseline period (default 7 days).',
+          apiKeyNewIpCount: 'Highest number of client IPs an API key used in the window that it never used in the baseline period. Matches exact IPs only; country/region changes are not detected.',
+          apiKeyNewUserAgentCount: 'Highest number of User-Agents an API key used in the window that it never used in the baseline period.',
+          apiKeyOffHoursRequests: 'Highest number of requests an API key made in the window during hours of day it never used in the baseline period.'
         },
         hints: {
           recommended: 'Recommended: operator {operator}, threshold {threshold}{unit}',
@@ -2671,7 +2743,9 @@ export default {
           sustained: 'Sustained (samples)',
           cooldown: 'Cooldown (minutes)',
           enabled: 'Enabled',
-          notifyEmail: 'Send email notifications'
+          notifyEmail: 'Send email notifications',
+          autoDisableApiKey: 'Auto-disable flagged API keys',
+          notifyUser: 'Email the affected user'
         },
         validation: {
           title: 'Please fix the following issues',
@@ -2864,6 +2938,20 @@ export default {
         errorAccounts: 'Errors {count}',
         loadFailed: 'Failed to load concurrency data'
       },
+      trafficArms: {
+        title: 'Traffic Split Arms',
+        hint: 'Compared with control (baseline)',
+        empty: 'No traffic split data for this group in the selected window.',
+        arm: 'Arm',
+        control: 'control (baseline)',
+        requests: 'Requests',
+        errorRate: 'Error Rate',
+        latency: 'Latency P50 / P95',
+        ttft: 'First Token Avg / P95',
+        costPerRequest: 'Cost / Request',
+        totalCost: 'Total Cost',
+        loadFailed: 'Failed to load traffic split stats'
+      },
       realtime: {
         title: 'Realtime',
         connected: 'Realtime connected',
@@ -2933,7 +3021,12 @@ export default {
         totp: 'Two-Factor Authentication (2FA)',
         totpHint: 'Allow users to use authenticator apps like Google Authenticator',
         totpKeyNotConfigured:
-          'Please configure TOTP_ENCRYPTION_KEY in environment variables first. Generate a key with: openssl rand -hex 32'
+          'Please configure TOTP_ENCRYPTION_KEY in environment variables first. Generate a key with: openssl rand -hex 32',
+        webauthn: 'Passkeys (WebAuthn)',
+        webauthnHint: 'Allow users to register passkeys for passwordless login and as a second factor',
+        requireAdmin2fa: 'Require 2FA for Admins',
+        requireAdmin2faHint:
+          'Administrators without TOTP or a passkey are blocked from the admin console until they set one up. Enable a 2FA method for yourself first.'
       },
       turnstile: {
         title: 'Cloudflare Turnstile',
@@ -3192,8 +3285,18 @@ export default {
     status: {
       active: 'Active',
       expired: 'Expired',
+      paused: 'Paused',
       revoked: 'Revoked'
     },
+    autoRenew: 'Auto-renew from balance (${price} per period)',
+    renewNow: 'Renew now',
+    pause: 'Pause',
+    resume: 'Resume',
+    renewSuccess: 'Subscription renewed',
+    pauseSuccess: 'Subscription paused, remaining time is frozen',
+    resumeSuccess: 'Subscription resumed',
+    actionFailed: 'Operation failed',
+    renewalFailed: 'The last auto-renewal failed. Please top up your balance; it will be retried automatically.',
     usage: 'Usage',
     expires: 'Expires',
     noExpiration: 'No expiration',
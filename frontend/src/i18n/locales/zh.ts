@@ -326,6 +326,9 @@ export default {
     promoCodeAlreadyUsed: '您已使用过此优惠码',
     promoCodeValidating: '优惠码正在验证中，请稍候',
     promoCodeInvalidCannotRegister: '优惠码无效，请检查后重试或清空优惠码',
+    or: '或',
+    signInWithPasskey: '使用通行密钥登录',
+    passkeyLoginFailed: '通行密钥登录失败，请重试',
     linuxdo: {
       signIn: '使用 Linux.do 登录',
       orContinue: '或使用邮箱密码继续',
@@ -446,6 +449,7 @@ export default {
     noKeysYet: '暂无 API 密钥',
     createFirstKey: '创建您的第一个 API 密钥以开始使用 API。',
     keyCreatedSuccess: 'API 密钥创建成功',
+    keyCreatedShownOnce: 'API 密钥创建成功，请立即复制，离开本页面后将无法再次查看',
     keyUpdatedSuccess: 'API 密钥更新成功',
     keyDeletedSuccess: 'API 密钥删除成功',
     keyEnabledSuccess: 'API 密钥已启用',
@@ -651,6 +655,53 @@ export default {
     passwordTooShort: '密码至少需要 8 个字符',
     passwordChangeSuccess: '密码修改成功',
     passwordChangeFailed: '密码修改失败',
+    sessions: {
+      title: '登录设备',
+      description: '当前已登录您账户的设备',
+      empty: '暂无有效会话',
+      current: '当前设备',
+      unknownDevice: '未知设备',
+      lastSeen: '最近活跃',
+      signedIn: '登录时间',
+      revoke: '下线',
+      revokeOthers: '下线其他设备',
+      revoked: '已下线该会话',
+      revokeFailed: '下线会话失败'
+    },
+    passkeys: {
+      title: '通行密钥 (Passkey)',
+      description: '使用面容 ID、触控 ID、Windows Hello 或安全密钥登录，或作为两步验证方式',
+      featureDisabledHint: '管理员未开启通行密钥功能',
+      empty: '尚未添加通行密钥',
+      unsupported: '当前浏览器不支持通行密钥',
+      namePlaceholder: '通行密钥名称，例如 MacBook 触控 ID',
+      add: '添加通行密钥',
+      addTitle: '添加通行密钥',
+      addHint: '确认身份后，按照浏览器提示创建通行密钥',
+      added: '通行密钥已添加',
+      rename: '重命名',
+      deleteTitle: '删除通行密钥',
+      deleteHint: '删除后将无法再使用该通行密钥登录',
+      deleted: '通行密钥已删除',
+      passwordless: '免密登录',
+      addedAt: '添加时间',
+      lastUsed: '最近使用',
+      neverUsed: '从未使用'
+    },
+    twoFactor: {
+      usePasskey: '使用通行密钥',
+      useRecoveryCode: '使用恢复码',
+      useAuthenticator: '使用验证器应用',
+      recoveryCodeLabel: '恢复码',
+      verifyRecoveryCode: '验证',
+      recoveryCodes: '恢复码',
+      recoveryCodesHint: '验证器或通行密钥不可用时可用于登录的一次性代码，剩余 {count} 个。',
+      generate: '生成',
+      regenerate: '重新生成',
+      regenerateHint: '重新生成后，之前的所有恢复码将失效',
+      saveCodesWarning: '请妥善保存以下恢复码，它们只会显示这一次。',
+      copyCodes: '复制恢复码'
+    },
     // TOTP 2FA
     totp: {
       title: '双因素认证 (2FA)',
@@ -924,6 +975,9 @@ export default {
       failedToUpdate: '更新用户失败',
       failedToDelete: '删除用户失败',
       failedToToggle: '更新用户状态失败',
+      forceLogout: '强制下线',
+      forceLogoutConfirm: '确定让 {email} 在所有设备上下线吗？其全部登录会话和已签发的令牌都将失效。',
+      forceLogoutSuccess: '已强制下线（吊销 {count} 个会话）',
       failedToLoadApiKeys: '加载用户 API 密钥失败',
       deleteConfirm: "确定要删除用户 '{email}' 吗？此操作无法撤销。",
       roles: {
@@ -1407,7 +1461,12 @@ export default {
         tempUnschedulable: '临时不可调度',
         rateLimitedUntil: '限流中，重置时间：{time}',
         overloadedUntil: '负载过重，重置时间：{time}',
-        viewTempUnschedDetails: '查看临时不可调度详情'
+        scopeRateLimited: '{scope} 限流中',
+        scopeRateLimitedTitle: '受影响模型：{models}',
+        viewTempUnschedDetails: '查看临时不可调度详情',
+        scheduleDraining: '排空中',
+        scheduleClosed: '非可用时段',
+        scheduleTitle: '可用时间窗口（extra.availability_schedule）：排空中的账号仅服务已有粘性会话'
       },
       tempUnschedulable: {
         title: '临时不可调度',
@@ -2301,6 +2360,7 @@ export default {
       allBillingTypes: '全部计费类型',
       billingTypeBalance: '钱包余额',
       billingTypeSubscription: '订阅套餐',
+      billingTypeOverage: '订阅超额',
       ipAddress: 'IP',
       cleanup: {
         button: '清理',
@@ -2551,7 +2611,8 @@ export default {
           routing: '路由',
           upstream: '上游',
           network: '网络',
-          internal: '内部'
+          internal: '内部',
+          policy: '内容策略'
         },
         total: '总计：',
         searchPlaceholder: '搜索 request_id / client_request_id / message',
@@ -2763,7 +2824,8 @@ export default {
         metricGroups: {
           system: '系统指标',
           group: '分组级别指标（需 group_id）',
-          account: '账号级别指标'
+          account: '账号级别指标',
+          usage: '用户 / API Key 异常'
         },
         metrics: {
           successRate: '成功率 (%)',
@@ -2780,7 +2842,12 @@ export default {
           accountRateLimitedCount: '限流账号数',
           accountErrorCount: '错误账号数（不含临时不可调度）',
           accountErrorRatio: '错误账号比例 (%)',
-          overloadAccountCount: '过载账号数'
+          overloadAccountCount: '过载账号数',
+          userSpendSpikeRatio: '用户消费突增（基线倍数）',
+          apiKeySpendSpikeRatio: 'API Key 消费突增（基线倍数）',
+          apiKeyNewIpCount: 'API Key 新 IP 数',
+          apiKeyNewUserAgentCount: 'API Key 新 User-Agent 数',
+          apiKeyOffHoursRequests: 'API Key 非常用时段请求数'
         },
         metricDescriptions: {
           successRate: '统计窗口内成功请求占比（0~100）。',
@@ -2797,7 +2864,12 @@ export default {
           accountRateLimitedCount: '统计窗口内被限流的账号数量。',
           accountErrorCount: '统计窗口内产生错误的账号数量（不含临时不可调度）。',
           accountErrorRatio: '统计窗口内错误账号占比（0~100）。',
-          overloadAccountCount: '统计窗口内过载账号数量。'
+          overloadAccountCount: '统计窗口内过载账号数量。',
+          userSpendSpikeRatio: '单个用户窗口内消费与基线期（默认 7 天）同等时长平均消费之比的最大值。',
+          apiKeySpendSpikeRatio: '单个 API Key 窗口内消费与基线期（默认 7 天）同等时长平均消费之比的最大值。',
+          apiKeyNewIpCount: '单个 API Key 窗口内出现的、基线期内未使用过的客户端 IP 数的最大值。仅按 IP 精确匹配，不识别国家/地区变化。',
+          apiKeyNewUserAgentCount: '单个 API Key 窗口内出现的、基线期内未使用过的 User-Agent 数的最大值。',
+          apiKeyOffHoursRequests: '单个 API Key 在基线期从未使用过的小时段内产生的请求数的最大值。'
         },
         hints: {
           recommended: '推荐：运算符 {operator}，阈值 {threshold}{unit}',
@@ -2825,7 +2897,9 @@ export default {
           sustained: '连续样本数（每分钟）',
           cooldown: '冷却期（分钟）',
           enabled: '启用',
-          notifyEmail: '发送邮件通知'
+          notifyEmail: '发送邮件通知',
+          autoDisableApiKey: '自动停用触发的 API Key',
+          notifyUser: '邮件通知相关用户'
         },
         validation: {
           title: '请先修正以下问题',
@@ -3018,6 +3092,20 @@ export default {
         errorAccounts: '异常 {count}',
         loadFailed: '加载并发数据失败'
       },
+      trafficArms: {
+        title: '分流实验组对比',
+        hint: '与 control（基线）对比',
+        empty: '所选时间窗口内该分组没有分流数据。',
+        arm: '实验组',
+        control: 'control（基线）',
+        requests: '请求数',
+        errorRate: '错误率',
+        latency: '延迟 P50 / P95',
+        ttft: '首 Token 平均 / P95',
+        costPerRequest: '单请求成本',
+        totalCost: '总成本',
+        loadFailed: '加载分流统计失败'
+      },
       realtime: {
         title: '实时信息',
         connected: '实时已连接',
@@ -3087,7 +3175,11 @@ export default {
         totp: '双因素认证 (2FA)',
         totpHint: '允许用户使用 Google Authenticator 等应用进行二次验证',
         totpKeyNotConfigured:
-          '请先在环境变量中配置 TOTP_ENCRYPTION_KEY。使用命令 openssl rand -hex 32 生成密钥。'
+          '请先在环境变量中配置 TOTP_ENCRYPTION_KEY。使用命令 openssl rand -hex 32 生成密钥。',
+        webauthn: '通行密钥 (WebAuthn)',
+        webauthnHint: '允许用户注册通行密钥，用于免密登录或作为两步验证方式',
+        requireAdmin2fa: '管理员必须启用两步验证',
+        requireAdmin2faHint: '未启用 TOTP 或通行密钥的管理员将无法访问管理后台，请先为自己开启一种两步验证方式'
       },
       turnstile: {
         title: 'Cloudflare Turnstile',
@@ -3341,8 +3433,18 @@ export default {
     status: {
       active: '有效',
       expired: '已过期',
+      paused: '已暂停',
       revoked: '已撤销'
     },
+    autoRenew: '到期自动从余额续费（每周期 ${price}）',
+    renewNow: '立即续费',
+    pause: '暂停',
+    resume: '恢复',
+    renewSuccess: '续费成功',
+    pauseSuccess: '订阅已暂停，剩余时长已冻结',
+    resumeSuccess: '订阅已恢复',
+    actionFailed: '操作失败',
+    renewalFailed: '最近一次自动续费失败，请充值余额，系统将自动重试',
     usage: '用量',
     expires: '到期时间',
     noExpiration: '无到期时间',
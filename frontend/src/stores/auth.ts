@@ -6,7 +6,9 @@
 import { defineStore } from 'pinia'
 import { ref, computed, readonly } from 'vue'
 import { authAPI, isTotp2FARequired, type LoginResponse } from '@/api'
+import { REFRESH_TOKEN_KEY } from '@/api/client'
 import type { User, LoginRequest, RegisterRequest, AuthResponse } from '@/types'
+import { getPasskeyAssertion } from '@/utils/webauthn'
 
 const AUTH_TOKEN_KEY = 'auth_token'
 const AUTH_USER_KEY = 'auth_user'
@@ -20,6 +22,14 @@ export const useAuthStore = defineStore('auth', () => {
   const runMode = ref<'standard' | 'simple'>('standard')
   let refreshIntervalId: ReturnType<typeof setInterval> | null = null
 
+  // The API client rotates tokens transparently when the access token expires; keep state in sync
+  window.addEventListener('auth-token-refreshed', (event) => {
+    const refreshed = (event as CustomEvent<string>).detail
+    if (refreshed && token.value) {
+      token.value = refreshed
+    }
+  })
+
   // ==================== Computed ====================
 
   const isAuthenticated = computed(() => {
@@ -119,12 +129,61 @@ export const useAuthStore = defineStore('auth', () => {
    * Complete login with 2FA code
    * @param tempToken - Temporary token from initial login
    * @param totpCode - 6-digit TOTP code
+   * @param recoveryCode - One-time recovery code (used instead of totpCode)
    * @returns Promise resolving to the authenticated user
    * @throws Error if 2FA verification fails
    */
-  async function login2FA(tempToken: string, totpCode: string): Promise<User> {
+  async function login2FA(tempToken: string, totpCode: string, recoveryCode?: string): Promise<User> {
     try {
-      const response = await authAPI.login2FA({ temp_token: tempToken, totp_code: totpCode })
+      const response = await authAPI.login2FA(
+        recoveryCode
+          ? { temp_token: tempToken, recovery_code: recoveryCode }
+          : { temp_token: tempToken, totp_code: totpCode }
+      )
+      setAuthFromResponse(response)
+      return user.value!
+    } catch (error) {
+      clearAuth()
+      throw error
+    }
+  }
+
+  /**
+   * Complete login with a passkey as the second factor
+   * @param tempToken - Temporary token from initial login
+   * @returns Promise resolving to the authenticated user
+   * @throws Error if the browser prompt is cancelled or verification fails
+   */
+  async function login2FAWithPasskey(tempToken: string): Promise<User> {
+    const options = await authAPI.getLogin2FAPasskeyOptions(tempToken)
+    const credential = await getPasskeyAssertion(options)
+    try {
+      const response = await authAPI.login2FAWithPasskey({
+        temp_token: tempToken,
+        challenge_id: options.challenge_id,
+        credential
+      })
+      setAuthFromResponse(response)
+      return user.value!
+    } catch (error) {
+      clearAuth()
+      throw error
+    }
+  }
+
+  /**
+   * Passwordless login with a discoverable passkey
+   * @returns Promise resolving to the authenticated user
+   * @throws Error if the browser prompt is cancelled or verification fails
+   */
+  async function loginWithPasskey(): Promise<User> {
+    const options = await authAPI.getPasskeyLoginOptions()
+    const credential = await getPasskeyAssertion(options)
+    try {
+      const response = await authAPI.loginWithPasskey({
+        challenge_id: options.challenge_id,
+        credential
+      })
       setAuthFromResponse(response)
       return user.value!
     } catch (error) {
@@ -150,6 +209,7 @@ export const useAuthStore = defineStore('auth', () => {
 
     // Persist to localStorage
     localStorage.setItem(AUTH_TOKEN_KEY, response.access_token)
+    localStorage.setItem(REFRESH_TOKEN_KEY, response.refresh_token)
     localStorage.setItem(AUTH_USER_KEY, JSON.stringify(userData))
 
     // Start auto-refresh interval
@@ -178,6 +238,7 @@ export const useAuthStore = defineStore('auth', () => {
 
       // Persist to localStorage
       localStorage.setItem(AUTH_TOKEN_KEY, response.access_token)
+      localStorage.setItem(REFRESH_TOKEN_KEY, response.refresh_token)
       localStorage.setItem(AUTH_USER_KEY, JSON.stringify(userDataWithoutRunMode))
 
       // Start auto-refresh interval
@@ -194,13 +255,17 @@ export const useAuthStore = defineStore('auth', () => {
   /**
    * 直接设置 token（用于 OAuth/SSO 回调），并加载当前用户信息。
    * @param newToken - 后端签发的 JWT access token
+   * @param refreshToken - 与登录会话绑定的 refresh token（可选）
    */
-  async function setToken(newToken: string): Promise<User> {
+  async function setToken(newToken: string, refreshToken?: string): Promise<User> {
     // Clear any previous state first (avoid mixing sessions)
     clearAuth()
 
     token.value = newToken
     localStorage.setItem(AUTH_TOKEN_KEY, newToken)
+    if (refreshToken) {
+      localStorage.setItem(REFRESH_TOKEN_KEY, refreshToken)
+    }
 
     try {
       const userData = await refreshUser()
@@ -217,8 +282,8 @@ export const useAuthStore = defineStore('auth', () => {
    * Clears all authentication state and persisted data
    */
   function logout(): void {
-    // Call API logout (client-side cleanup)
-    authAPI.logout()
+    // Revoke the server-side session (best effort) and clear persisted tokens
+    void authAPI.logout()
 
     // Clear state
     clearAuth()
@@ -267,6 +332,7 @@ export const useAuthStore = defineStore('auth', () => {
     token.value = null
     user.value = null
     localStorage.removeItem(AUTH_TOKEN_KEY)
+    localStorage.removeItem(REFRESH_TOKEN_KEY)
     localStorage.removeItem(AUTH_USER_KEY)
   }
 
@@ -286,6 +352,8 @@ export const useAuthStore = defineStore('auth', () => {
     // Actions
     login,
     login2FA,
+    login2FAWithPasskey,
+    loginWithPasskey,
     register,
     setToken,
     logout,
@@ -47,6 +47,9 @@ export interface User {
 export interface AdminUser extends User {
   // 管理员备注（普通用户接口不返回）
   notes: string
+  // 专属账号（调度时优先使用，其他用户不会调度到）
+  dedicated_account_ids: number[] | null
+  dedicated_accounts_only: boolean
 }
 
 export interface LoginRequest {
@@ -62,6 +65,7 @@ export interface RegisterRequest {
   turnstile_token?: string
   promo_code?: string
   invite_code?: string
+  device_id?: string // 设备标识（用于邀请返佣反作弊）
 }
 
 export interface SendVerifyCodeRequest {
@@ -92,6 +96,7 @@ export interface PublicSettings {
   purchase_subscription_enabled: boolean
   purchase_subscription_url: string
   linuxdo_oauth_enabled: boolean
+  webauthn_enabled?: boolean
   version: string
   customer_service_qr?: string
   after_sales_group_qr?: string
@@ -121,10 +126,23 @@ export interface PlanGroup {
 
 export interface AuthResponse {
   access_token: string
+  refresh_token: string
+  expires_in: number
   token_type: string
   user: User & { run_mode?: 'standard' | 'simple' }
 }
 
+export interface AuthSession {
+  id: string
+  ip: string
+  last_ip: string
+  user_agent: string
+  device_id?: string
+  created_at: string
+  last_seen_at: string
+  current: boolean
+}
+
 export interface CurrentUserResponse extends User {
   run_mode?: 'standard' | 'simple'
 }
@@ -259,6 +277,8 @@ export interface InviteRecord {
   status: string
   confirmed_at?: string | null
   reward_amount: number
+  commission_status: InviteCommissionStatus
+  commission_total: number
 }
 
 export interface InviteRewardRecord {
@@ -284,6 +304,58 @@ export interface InviteLog {
 
 export interface InviteSettings {
   reward_amount: number
+  commission?: InviteCommissionSettings
+}
+
+export type InviteCommissionStatus = 'active' | 'blocked'
+
+export interface InviteCommissionSettings {
+  enabled: boolean
+  rate: number // 百分比
+  days: number // 0 表示永久
+  min_withdraw: number
+}
+
+export interface InviteCommissionReport {
+  settings: InviteCommissionSettings
+  balance: number
+  total_earned: number
+  today_earned: number
+  last_30_days_earned: number
+  pending_withdrawal: number
+  total_withdrawn: number
+  active_invitees: number
+  blocked_invitees: number
+}
+
+export interface InviteCommission {
+  id: number
+  invitee_email: string
+  base_amount: number
+  rate: number
+  amount: number
+  log_count: number
+  created_at: string
+}
+
+export type CommissionWithdrawalMethod = 'balance' | 'withdraw'
+export type CommissionWithdrawalStatus = 'pending' | 'completed' | 'rejected'
+
+export interface CommissionWithdrawal {
+  id: number
+  amount: number
+  method: CommissionWithdrawalMethod
+  status: CommissionWithdrawalStatus
+  account_info?: string
+  admin_notes?: string
+  processed_at?: string | null
+  created_at: string
+}
+
+export interface AdminCommissionWithdrawal extends CommissionWithdrawal {
+  user_id: number
+  user_email: string
+  processed_by?: number | null
 }
 
 // ==================== UI State Types ====================
@@ -353,14 +425,68 @@ export interface Group {
   // Claude Code 客户端限制
   claude_code_only: boolean
   fallback_group_id: number | null
+  // 订阅超额策略：额度用尽后拒绝 / 按超额倍率扣余额 / 降级到按量计费分组
+  overage_policy: OveragePolicy
+  overage_rate_multiplier: number
+  overage_group_id: number | null
+  // 订阅每周期余额价格，null 表示不支持自助续费/切换
+  subscription_price: number | null
+  // 订阅 token / 请求次数额度（与 USD 限额同时生效）
+  usage_quotas: SubscriptionQuota[]
   created_at: string
   updated_at: string
 }
 
+export type SchedulingStrategy = '' | 'least_loaded' | 'weighted' | 'quota_aware' | 'cost_aware'
+
+export type ContentPolicyAction = 'block' | 'redact' | 'log'
+
+export interface ContentPolicyBlocklistRule {
+  name: string
+  pattern: string
+  regex: boolean
+  action: ContentPolicyAction
+}
+
+export interface ContentPolicyPIIDetector {
+  type: 'email' | 'phone' | 'credit_card'
+  action: ContentPolicyAction
+}
+
+export interface ContentPolicyModeration {
+  enabled: boolean
+  url: string
+  // 响应中仅返回 secret_configured；更新时 secret 留空表示保留原值
+  secret?: string
+  secret_configured?: boolean
+  timeout_ms: number
+  fail_closed: boolean
+}
+
+// 分组内容策略：转发前执行的黑名单 / PII 检测 / 图片限制 / 外部审核
+export interface ContentPolicy {
+  enabled: boolean
+  blocklist: ContentPolicyBlocklistRule[]
+  pii_detectors: ContentPolicyPIIDetector[]
+  max_images: number
+  max_image_bytes: number
+  moderation: ContentPolicyModeration | null
+}
+
 export interface AdminGroup extends Group {
   // 模型路由配置（仅管理员可见，内部信息）
   model_routing: Record<string, number[]> | null
   model_routing_enabled: boolean
+  // 账号调度策略（空字符串表示默认策略）
+  scheduling_strategy: SchedulingStrategy
+  // 内容策略（null 表示未配置）
+  content_policy: ContentPolicy | null
+  // 请求改写规则（按顺序执行）
+  rewrite_rules: RequestRewriteRule[]
+  // 模型别名 / 虚拟模型（按目标顺序故障转移）
+  model_aliases: ModelAlias[]
+  // 灰度 / A-B 分流（null 表示未配置）
+  traffic_split: TrafficSplit | null
 
   // 分组下账号数量（仅管理员可见）
   account_count?: number
@@ -369,7 +495,8 @@ export interface AdminGroup extends Group {
 export interface ApiKey {
   id: number
   user_id: number
-  key: string
+  key?: string // Full key, only returned once in the create response
+  key_prefix: string
   name: string
   group_id: number | null
   status: 'active' | 'inactive'
@@ -377,6 +504,7 @@ export interface ApiKey {
   ip_blacklist: string[]
   created_at: string
   updated_at: string
+  organization_id?: number | null // 组织 Key：使用组织共享余额与订阅
   group?: Group
 }
 
@@ -386,6 +514,7 @@ export interface CreateApiKeyRequest {
   custom_key?: string // Optional custom API Key
   ip_whitelist?: string[]
   ip_blacklist?: string[]
+  organization_id?: number | null
 }
 
 export interface UpdateApiKeyRequest {
@@ -411,6 +540,16 @@ export interface CreateGroupRequest {
   image_price_4k?: number | null
   claude_code_only?: boolean
   fallback_group_id?: number | null
+  scheduling_strategy?: SchedulingStrategy
+  content_policy?: ContentPolicy
+  rewrite_rules?: RequestRewriteRule[]
+  model_aliases?: ModelAlias[]
+  traffic_split?: TrafficSplit
+  overage_policy?: OveragePolicy
+  overage_rate_multiplier?: number
+  overage_group_id?: number | null
+  subscription_price?: number | null
+  usage_quotas?: SubscriptionQuota[]
 }
 
 export interface UpdateGroupRequest {
@@ -429,6 +568,134 @@ export interface UpdateGroupRequest {
   image_price_4k?: number | null
   claude_code_only?: boolean
   fallback_group_id?: number | null
+  scheduling_strategy?: SchedulingStrategy
+  content_policy?: ContentPolicy
+  rewrite_rules?: RequestRewriteRule[]
+  model_aliases?: ModelAlias[]
+  traffic_split?: TrafficSplit
+  overage_policy?: OveragePolicy
+  overage_rate_multiplier?: number
+  overage_group_id?: number | null
+  subscription_price?: number | null
+  usage_quotas?: SubscriptionQuota[]
+}
+
+export type OveragePolicy = 'reject' | 'balance' | 'fallback_group'
+
+export type SubscriptionQuotaWindow = 'daily' | 'weekly' | 'monthly'
+
+export type SubscriptionQuotaMetric = 'requests' | 'input_tokens' | 'output_tokens' | 'total_tokens'
+
+export interface SubscriptionQuota {
+  window: SubscriptionQuotaWindow
+  metric: SubscriptionQuotaMetric
+  limit: number
+  model?: string // 为空表示所有模型，支持末尾 * 通配（如 claude-opus-*）
+}
+
+export interface SubscriptionQuotaProgress {
+  window: SubscriptionQuotaWindow
+  metric: SubscriptionQuotaMetric
+  model?: string
+  limit: number
+  used: number
+  remaining: number
+  percentage: number
+  window_start: string
+  resets_at: string
+  resets_in_seconds: number
+}
+
+export interface ModelAliasTarget {
+  platform: GroupPlatform // 账号平台；当前分组无法调度到该平台时跳过
+  model: string
+}
+
+export interface ModelAlias {
+  name: string
+  description?: string
+  targets: ModelAliasTarget[]
+}
+
+export interface TrafficSplitArm {
+  name: string // 'control' 为保留名称
+  weight: number // 流量百分比，所有实验组之和不超过 100
+  models?: string[] // 仅对这些请求模型生效，支持末尾 * 通配
+  model?: string // 目标模型（改写请求模型）
+  account_ids?: number[] // 实验组专用账号
+}
+
+export interface TrafficSplit {
+  enabled: boolean
+  arms: TrafficSplitArm[]
+}
+
+export type RequestRewriteOpType =
+  | 'set'
+  | 'set_default'
+  | 'remove'
+  | 'cap'
+  | 'system_prepend'
+  | 'system_append'
+  | 'header_set'
+  | 'header_remove'
+
+export interface RequestRewriteOp {
+  type: RequestRewriteOpType
+  path?: string // JSON 字段路径（set/set_default/remove/cap）
+  header?: string // 请求头名称（header_set/header_remove）
+  value?: unknown // JSON 值；系统提示词与请求头为字符串
+}
+
+export interface RequestRewriteRule {
+  name: string
+  enabled: boolean
+  platforms: GroupPlatform[] // 空数组表示不限制
+  models: string[] // 支持末尾 * 通配，空数组表示不限制
+  operations: RequestRewriteOp[]
+}
+
+export type RequestBodyFormat = 'anthropic' | 'openai_responses' | 'gemini'
+
+export interface RewriteDryRunResult {
+  platform: string
+  format: RequestBodyFormat
+  model: string
+  changed: boolean
+  applied_rules: string[]
+  headers?: { set?: Record<string, string>; remove?: string[] }
+  body: Record<string, unknown>
+}
+
+export interface SchedulingSimulationAccount {
+  account_id: number
+  name: string
+  platform: string
+  type: string
+  priority: number
+  concurrency: number
+  current_concurrency: number
+  waiting_count: number
+  load_rate: number
+  rate_multiplier: number
+  weight: number
+  quota_remaining?: number
+  last_used_at?: string
+}
+
+export interface SchedulingSimulationResult {
+  group_id: number
+  platform: string
+  model: string
+  current_strategy: SchedulingStrategy
+  candidate_count: number
+  excluded: Array<{ account_id: number; name: string; reason: string }>
+  strategies: Array<{
+    strategy: SchedulingStrategy
+    current: boolean
+    selected?: SchedulingSimulationAccount
+    ranking: SchedulingSimulationAccount[]
+  }>
 }
 
 // ==================== Account & Proxy Types ====================
@@ -524,6 +791,26 @@ export interface TempUnschedulableStatus {
   state?: TempUnschedulableState
 }
 
+export interface AccountModelRateLimit {
+  scope: string
+  source: 'model' | 'antigravity'
+  models?: string[]
+  reset_at: string
+}
+
+export type AccountScheduleState = 'open' | 'draining' | 'closed'
+
+// 账号可用时间窗口（存储于 extra.availability_schedule）
+export interface AccountAvailabilitySchedule {
+  timezone?: string // IANA 时区，为空使用服务端时区
+  // 每周时间段：days 0=周日...6=周六（为空表示每天），end 不晚于 start 表示跨零点
+  windows?: { days?: number[]; start: string; end: string }[]
+  // cron 风格窗口：表达式匹配时刻开始，持续 duration_minutes 分钟
+  cron?: { expr: string; duration_minutes: number }[]
+  drain_minutes?: number // 窗口结束前排空（不接受新会话）
+  drain_before_session_reset_minutes?: number // 5h 会话窗口重置前排空
+}
+
 export interface Account {
   id: number
   name: string
@@ -555,6 +842,10 @@ export interface Account {
   overload_until: string | null
   temp_unschedulable_until: string | null
   temp_unschedulable_reason: string | null
+  // 按模型限流域的限流状态（仅未过期记录）
+  model_rate_limits?: AccountModelRateLimit[]
+  // 可用时间窗口（extra.availability_schedule）当前状态，未配置时为空
+  schedule_state?: AccountScheduleState
 
   // Session window fields (5-hour window)
   session_window_start: string | null
@@ -881,6 +1172,64 @@ export interface UsageStatsResponse {
   models?: Record<string, number>
 }
 
+// ==================== Organization Types ====================
+
+export type OrganizationRole = 'owner' | 'admin' | 'member'
+
+export interface Organization {
+  id: number
+  name: string
+  owner_user_id: number
+  balance: number
+  status: 'active' | 'disabled'
+  member_count: number
+  created_at: string
+  updated_at: string
+  role?: OrganizationRole // 当前用户角色（仅用户侧接口）
+}
+
+export interface AdminOrganization extends Organization {
+  notes: string
+}
+
+export interface OrganizationMember {
+  id: number
+  organization_id: number
+  user_id: number
+  email?: string
+  username?: string
+  role: OrganizationRole
+  monthly_limit_usd: number | null
+  monthly_usage_usd: number
+  monthly_window_start: string | null
+  created_at: string
+}
+
+export interface OrganizationInvitation {
+  id: number
+  organization_id: number
+  organization_name?: string
+  email: string
+  role: OrganizationRole
+  status: 'pending' | 'accepted' | 'revoked'
+  expires_at: string
+  created_at: string
+}
+
+export interface OrganizationMemberUsage {
+  member: OrganizationMember
+  total_requests: number
+  total_tokens: number
+  total_cost: number
+  actual_cost: number
+}
+
+export interface OrganizationDashboard {
+  organization: Organization
+  stats: UsageStatsResponse
+  members: OrganizationMemberUsage[]
+}
+
 // ==================== Trend & Chart Types ====================
 
 export interface TrendDataPoint {
@@ -947,13 +1296,16 @@ export interface UserSubscription {
   id: number
   user_id: number
   group_id: number
-  status: 'active' | 'expired' | 'revoked'
+  status: 'active' | 'expired' | 'paused' | 'revoked'
   daily_usage_usd: number
   weekly_usage_usd: number
   monthly_usage_usd: number
   daily_window_start: string | null
   weekly_window_start: string | null
   monthly_window_start: string | null
+  auto_renew: boolean
+  paused_at: string | null
+  renewal_failed_at: string | null
   created_at: string
   updated_at: string
   expires_at: string | null
@@ -968,21 +1320,26 @@ export interface SubscriptionProgress {
     limit: number | null
     percentage: number
     reset_in_seconds: number | null
+    overage_usd?: number
   } | null
   weekly: {
     used: number
     limit: number | null
     percentage: number
     reset_in_seconds: number | null
+    overage_usd?: number
   } | null
   monthly: {
     used: number
     limit: number | null
     percentage: number
     reset_in_seconds: number | null
+    overage_usd?: number
   } | null
   expires_at: string | null
   days_remaining: number | null
+  overage_policy?: OveragePolicy
+  quotas?: SubscriptionQuotaProgress[]
 }
 
 export interface AssignSubscriptionRequest {
@@ -1224,13 +1581,84 @@ export interface TotpVerificationMethod {
   method: 'email' | 'password'
 }
 
+export type TwoFactorMethod = 'totp' | 'webauthn' | 'recovery_code'
+
 export interface TotpLoginResponse {
   requires_2fa: boolean
   temp_token?: string
   user_email_masked?: string
+  methods?: TwoFactorMethod[]
 }
 
 export interface TotpLogin2FARequest {
   temp_token: string
-  totp_code: string
+  totp_code?: string
+  recovery_code?: string
+}
+
+// ==================== WebAuthn / Passkey Types ====================
+
+export interface WebAuthnCredential {
+  id: number
+  name: string
+  aaguid?: string
+  transports?: string[]
+  passwordless: boolean
+  last_used_at?: string | null
+  created_at: string
+}
+
+export interface WebAuthnCredentialDescriptor {
+  type: string
+  id: string
+  transports?: string[]
+}
+
+export interface WebAuthnCreationOptions {
+  challenge_id: string
+  public_key: {
+    challenge: string
+    rp: { id: string; name: string }
+    user: { id: string; name: string; displayName: string }
+    pubKeyCredParams: { type: string; alg: number }[]
+    timeout: number
+    excludeCredentials: WebAuthnCredentialDescriptor[] | null
+    authenticatorSelection: { residentKey: string; userVerification: string }
+    attestation: string
+  }
+}
+
+export interface WebAuthnRequestOptions {
+  challenge_id: string
+  public_key: {
+    challenge: string
+    rpId: string
+    timeout: number
+    allowCredentials: WebAuthnCredentialDescriptor[] | null
+    userVerification: string
+  }
+}
+
+export interface WebAuthnAttestation {
+  id: string
+  client_data_json: string
+  attestation_object: string
+  transports: string[]
+}
+
+export interface WebAuthnAssertion {
+  id: string
+  client_data_json: string
+  authenticator_data: string
+  signature: string
+  user_handle: string
+}
+
+export interface PasskeyListResponse {
+  feature_enabled: boolean
+  credentials: WebAuthnCredential[]
+}
+
+export interface RecoveryCodeStatus {
+  remaining: number
 }
@@ -0,0 +1,106 @@
+/**
+ * WebAuthn（passkey）浏览器端辅助函数
+ * 后端以 base64url 字符串传递二进制字段，这里负责与 ArrayBuffer 互相转换
+ */
+
+import type {
+  WebAuthnCreationOptions,
+  WebAuthnRequestOptions,
+  WebAuthnAttestation,
+  WebAuthnAssertion,
+  WebAuthnCredentialDescriptor
+} from '@/types'
+
+export function isWebAuthnSupported(): boolean {
+  return typeof window !== 'undefined' && !!window.PublicKeyCredential && !!navigator.credentials
+}
+
+export function base64urlToBuffer(value: string): ArrayBuffer {
+  const base64 = value.replace(/-/g, '+').replace(/_/g, '/')
+  const padded = base64 + '='.repeat((4 - (base64.length % 4)) % 4)
+  const binary = atob(padded)
+  const bytes = new Uint8Array(binary.length)
+  for (let i = 0; i < binary.length; i++) {
+    bytes[i] = binary.charCodeAt(i)
+  }
+  return bytes.buffer
+}
+
+export function bufferToBase64url(buffer: ArrayBuffer): string {
+  const bytes = new Uint8Array(buffer)
+  let binary = ''
+  for (let i = 0; i < bytes.length; i++) {
+    binary += String.fromCharCode(bytes[i])
+  }
+  return btoa(binary).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '')
+}
+
+function toDescriptors(list: WebAuthnCredentialDescriptor[] | null | undefined): PublicKeyCredentialDescriptor[] {
+  return (list || []).map((item) => ({
+    type: 'public-key',
+    id: base64urlToBuffer(item.id),
+    transports: item.transports as AuthenticatorTransport[] | undefined
+  }))
+}
+
+/**
+ * 调用 navigator.credentials.create() 创建 passkey
+ */
+export async function createPasskey(options: WebAuthnCreationOptions): Promise<WebAuthnAttestation> {
+  const pk = options.public_key
+  const credential = (await navigator.credentials.create({
+    publicKey: {
+      challenge: base64urlToBuffer(pk.challenge),
+      rp: pk.rp,
+      user: {
+        id: base64urlToBuffer(pk.user.id),
+        name: pk.user.name,
+        displayName: pk.user.displayName
+      },
+      pubKeyCredParams: pk.pubKeyCredParams.map((p) => ({ type: 'public-key' as const, alg: p.alg })),
+      timeout: pk.timeout,
+      excludeCredentials: toDescriptors(pk.excludeCredentials),
+      authenticatorSelection: pk.authenticatorSelection as AuthenticatorSelectionCriteria,
+      attestation: pk.attestation as AttestationConveyancePreference
+    }
+  })) as PublicKeyCredential | null
+  if (!credential) {
+    throw new Error('Passkey creation was cancelled')
+  }
+
+  const response = credential.response as AuthenticatorAttestationResponse
+  return {
+    id: bufferToBase64url(credential.rawId),
+    client_data_json: bufferToBase64url(response.clientDataJSON),
+    attestation_object: bufferToBase64url(response.attestationObject),
+    transports: typeof response.getTransports === 'function' ? response.getTransports() : []
+  }
+}
+
+/**
+ * 调用 navigator.credentials.get() 获取 passkey 断言
+ */
+export async function getPasskeyAssertion(options: WebAuthnRequestOptions): Promise<WebAuthnAssertion> {
+  const pk = options.public_key
+  const credential = (await navigator.credentials.get({
+    publicKey: {
+      challenge: base64urlToBuffer(pk.challenge),
+      rpId: pk.rpId,
+      timeout: pk.timeout,
+      allowCredentials: toDescriptors(pk.allowCredentials),
+      userVerification: pk.userVerification as UserVerificationRequirement
+    }
+  })) as PublicKeyCredential | null
+  if (!credential) {
+    throw new Error('Passkey sign-in was cancelled')
+  }
+
+  const response = credential.response as AuthenticatorAssertionResponse
+  return {
+    id: bufferToBase64url(credential.rawId),
+    client_data_json: bufferToBase64url(response.clientDataJSON),
+    authenticator_data: bufferToBase64url(response.authenticatorData),
+    signature: bufferToBase64url(response.signature),
+    user_handle: response.userHandle ? bufferToBase64url(response.userHandle) : ''
+  }
+}